@@ -0,0 +1,266 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	StatusPassed = "passed"
+	StatusFailed = "failed"
+
+	// DefaultRotationThreshold is the size at which the audit log file is rotated
+	DefaultRotationThreshold = 10485760 // 10 MB
+
+	// DefaultMaxRecentEvents is how many events are kept in memory, where they may be queried even if
+	// no audit log file is configured
+	DefaultMaxRecentEvents = 1000
+)
+
+// Event records one call to a storage system that changed its configuration or data, or one call to
+// Trident's REST API that may have changed Trident's state, made by the user named in the event.
+type Event struct {
+	Time          time.Time `json:"time"`
+	Backend       string    `json:"backend,omitempty"`
+	Host          string    `json:"host"`
+	SVM           string    `json:"svm,omitempty"`
+	User          string    `json:"user,omitempty"`
+	RequestID     string    `json:"requestID,omitempty"`
+	RequestSource string    `json:"requestSource,omitempty"`
+	Operation     string    `json:"operation"`
+	Volume        string    `json:"volume,omitempty"`
+	Object        string    `json:"object,omitempty"`
+	Status        string    `json:"status"`
+	ErrorCode     string    `json:"errorCode,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// Failed returns true if the storage system rejected the call or could not be reached.
+func (e *Event) Failed() bool {
+	return e.Status != StatusPassed
+}
+
+// Filter selects the events returned by a query.  Empty fields match every event.
+type Filter struct {
+	Backend   string
+	Volume    string
+	Operation string
+	Since     time.Time
+	Failed    bool
+	// Limit is the number of most recent matching events to return, or zero for all of them
+	Limit int
+}
+
+// Matches returns true if an event satisfies the filter.
+func (f *Filter) Matches(e *Event) bool {
+	switch {
+	case f.Backend != "" && e.Backend != f.Backend:
+		return false
+	case f.Volume != "" && e.Volume != f.Volume:
+		return false
+	case f.Operation != "" && e.Operation != f.Operation:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case f.Failed && !e.Failed():
+		return false
+	}
+	return true
+}
+
+// Exporter sends audit events to an external system, such as a syslog server.
+type Exporter interface {
+	Export(e *Event) error
+}
+
+// Log records audit events.  The most recent events are always kept in memory.  If a file is
+// configured, every event is also appended to it as a line of JSON, and the file is rotated to a
+// single ".old" file once it grows too large.
+type Log struct {
+	mutex             sync.Mutex
+	recent            []*Event
+	maxRecent         int
+	path              string
+	rotationThreshold int64
+	exporter          Exporter
+}
+
+// NewLog returns an audit log that appends to the named file, if any, and sends each event to the
+// exporter, if any.
+func NewLog(path string, exporter Exporter) *Log {
+	return &Log{
+		recent:            make([]*Event, 0),
+		maxRecent:         DefaultMaxRecentEvents,
+		path:              path,
+		rotationThreshold: DefaultRotationThreshold,
+		exporter:          exporter,
+	}
+}
+
+// Record adds an event to the log.  Failures to write the event are logged, but don't fail the
+// operation being audited.
+func (l *Log) Record(e *Event) {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.recent = append(l.recent, e)
+	if len(l.recent) > l.maxRecent {
+		l.recent = l.recent[len(l.recent)-l.maxRecent:]
+	}
+
+	if l.path != "" {
+		if err := l.appendToFile(e); err != nil {
+			log.WithField("auditLog", l.path).Errorf("Could not write audit event. %v", err)
+		}
+	}
+
+	if l.exporter != nil {
+		if err := l.exporter.Export(e); err != nil {
+			log.Errorf("Could not export audit event. %v", err)
+		}
+	}
+}
+
+// appendToFile writes an event to the audit log file and rotates the file if it has grown too large.
+func (l *Log) appendToFile(e *Event) error {
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// The Rename call will overwrite any previous .old file
+	if fileInfo, err := os.Stat(l.path); err == nil && fileInfo.Size() >= l.rotationThreshold {
+		return os.Rename(l.path, l.path+".old")
+	}
+	return nil
+}
+
+// Query returns the events matching a filter, oldest first.  If a file is configured, both it and
+// the rotated file are searched, otherwise only the events kept in memory.
+func (l *Log) Query(filter Filter) ([]*Event, error) {
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	events := make([]*Event, 0)
+	keep := func(e *Event) {
+		if !filter.Matches(e) {
+			return
+		}
+		events = append(events, e)
+		if filter.Limit > 0 && len(events) > 2*filter.Limit {
+			events = append(events[:0], events[len(events)-filter.Limit:]...)
+		}
+	}
+
+	if l.path == "" {
+		for _, e := range l.recent {
+			keep(e)
+		}
+	} else {
+		for _, path := range []string{l.path + ".old", l.path} {
+			if err := readEvents(path, keep); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+	return events, nil
+}
+
+// readEvents passes each event in an audit log file to a callback.  A missing file holds no events,
+// and lines that aren't events, such as one cut short by a crash, are skipped.
+func readEvents(path string, callback func(e *Event)) error {
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not read audit log; %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		e := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			continue
+		}
+		callback(e)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read audit log; %v", err)
+	}
+	return nil
+}
+
+var (
+	defaultLog      = NewLog("", nil)
+	defaultLogMutex sync.RWMutex
+)
+
+// Initialize configures where audit events are recorded.  If path is set, events are appended to that
+// file.  If syslogAddress is set, events are also sent to that syslog server, or to the local syslog
+// daemon if the address is "local".
+func Initialize(path, syslogAddress string) error {
+
+	var exporter Exporter
+	if syslogAddress != "" {
+		syslogExporter, err := NewSyslogExporter(syslogAddress)
+		if err != nil {
+			return fmt.Errorf("could not connect to syslog at %s; %v", syslogAddress, err)
+		}
+		exporter = syslogExporter
+	}
+
+	defaultLogMutex.Lock()
+	defaultLog = NewLog(path, exporter)
+	defaultLogMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"auditLog":    path,
+		"auditSyslog": syslogAddress,
+	}).Info("Initialized audit logging.")
+
+	return nil
+}
+
+// Record adds an event to the audit log.
+func Record(e *Event) {
+	defaultLogMutex.RLock()
+	defer defaultLogMutex.RUnlock()
+	defaultLog.Record(e)
+}
+
+// Query returns the events in the audit log that match a filter, oldest first.
+func Query(filter Filter) ([]*Event, error) {
+	defaultLogMutex.RLock()
+	defer defaultLogMutex.RUnlock()
+	return defaultLog.Query(filter)
+}
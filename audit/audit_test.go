@@ -0,0 +1,112 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExporter struct {
+	events []*Event
+}
+
+func (f *fakeExporter) Export(e *Event) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
+func newTestEvents() []*Event {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	return []*Event{
+		{Time: start, Backend: "nas", Operation: "volume-create", Volume: "vol1", Status: StatusPassed},
+		{Time: start.Add(time.Minute), Backend: "san", Operation: "lun-map", Volume: "vol2", Status: StatusPassed},
+		{Time: start.Add(2 * time.Minute), Backend: "nas", Operation: "volume-destroy", Volume: "vol1",
+			Status: StatusFailed, ErrorCode: "13005", Reason: "Volume is online"},
+		{Time: start.Add(3 * time.Minute), Backend: "nas", Operation: "volume-create", Volume: "vol3",
+			Status: StatusPassed},
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+
+	events := newTestEvents()
+
+	assert.True(t, (&Filter{}).Matches(events[0]))
+	assert.True(t, (&Filter{Backend: "nas", Volume: "vol1"}).Matches(events[0]))
+	assert.False(t, (&Filter{Backend: "san"}).Matches(events[0]))
+	assert.False(t, (&Filter{Operation: "volume-destroy"}).Matches(events[0]))
+	assert.False(t, (&Filter{Failed: true}).Matches(events[0]))
+	assert.True(t, (&Filter{Failed: true}).Matches(events[2]))
+	assert.False(t, (&Filter{Since: events[1].Time}).Matches(events[0]))
+	assert.True(t, (&Filter{Since: events[1].Time}).Matches(events[1]))
+}
+
+func TestLogQueryInMemory(t *testing.T) {
+
+	exporter := &fakeExporter{}
+	l := NewLog("", exporter)
+	l.maxRecent = 3
+	for _, e := range newTestEvents() {
+		l.Record(e)
+	}
+
+	assert.Len(t, exporter.events, 4, "expected every event to be exported")
+
+	events, err := l.Query(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 3, "expected only the most recent events to be kept")
+	assert.Equal(t, "lun-map", events[0].Operation)
+
+	events, err = l.Query(Filter{Backend: "nas", Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "vol3", events[0].Volume)
+}
+
+func TestLogQueryFile(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l := NewLog(path, nil)
+	l.maxRecent = 1
+	l.rotationThreshold = 1
+	l.Record(newTestEvents()[0])
+	_, err = os.Stat(path + ".old")
+	assert.NoError(t, err, "expected the file to be rotated")
+
+	l.rotationThreshold = DefaultRotationThreshold
+	for _, e := range newTestEvents()[1:] {
+		l.Record(e)
+	}
+
+	// A partially written line must not hide the events around it
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, _ = file.WriteString("{\"time\":\"2020-06-01\n")
+	file.Close()
+
+	events, err := l.Query(Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 4, "expected events from both the rotated and current files")
+	assert.Equal(t, "volume-create", events[0].Operation)
+	assert.Equal(t, "vol3", events[3].Volume)
+
+	events, err = l.Query(Filter{Volume: "vol1", Failed: true})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "13005", events[0].ErrorCode)
+
+	events, err = l.Query(Filter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "volume-destroy", events[0].Operation)
+}
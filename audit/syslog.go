@@ -0,0 +1,49 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package audit
+
+import (
+	"encoding/json"
+	"log/syslog"
+	"strings"
+)
+
+// syslogTag identifies Trident's audit events among the messages sent to syslog
+const syslogTag = "trident-audit"
+
+// SyslogExporter sends each audit event to syslog as a JSON message.  Failed calls are sent at warning
+// priority, the rest at info priority.
+type SyslogExporter struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogExporter connects to the syslog server at an address such as "udp://host:514",
+// "tcp://host:601", or "host:514", which uses UDP.  The address "local" selects the local syslog daemon.
+func NewSyslogExporter(address string) (*SyslogExporter, error) {
+
+	network, raddr := "udp", address
+	if address == "local" {
+		network, raddr = "", ""
+	} else if parts := strings.SplitN(address, "://", 2); len(parts) == 2 {
+		network, raddr = parts[0], parts[1]
+	}
+
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_DAEMON, syslogTag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogExporter{writer: writer}, nil
+}
+
+// Export sends an event to syslog.
+func (s *SyslogExporter) Export(e *Event) error {
+
+	message, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if e.Failed() {
+		return s.writer.Warning(string(message))
+	}
+	return s.writer.Info(string(message))
+}
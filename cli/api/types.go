@@ -3,6 +3,9 @@
 package api
 
 import (
+	"time"
+
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/utils"
 )
@@ -52,6 +55,61 @@ type MultipleSnapshotResponse struct {
 	Items []storage.SnapshotExternal `json:"items"`
 }
 
+type MultipleQuotaResponse struct {
+	Items []storage.QuotaExternal `json:"items"`
+}
+
+type MultipleRecoverableVolumeResponse struct {
+	Items []storage.RecoverableVolume `json:"items"`
+}
+
+type MultipleBackendVolumeResponse struct {
+	Items []storage.BackendVolume `json:"items"`
+}
+
+type MultipleImportCandidateResponse struct {
+	Items []storage.ImportCandidate `json:"items"`
+}
+
+type MultipleStorageLogEntryResponse struct {
+	Items []storage.StorageLogEntry `json:"items"`
+}
+
+type MultipleAuditEventResponse struct {
+	Items []audit.Event `json:"items"`
+}
+
+type MultipleStorageJobResponse struct {
+	Items  []storage.StorageJob `json:"items"`
+	Counts map[string]int       `json:"counts"`
+}
+
+// PoolCapacity is the space in a storage pool, as last reported by its backend.  Used space is only
+// known for pools whose total capacity is reported.
+type PoolCapacity struct {
+	Name           string    `json:"name"`
+	TotalBytes     uint64    `json:"totalBytes"`
+	UsedBytes      uint64    `json:"usedBytes"`
+	FreeBytes      uint64    `json:"freeBytes"`
+	CommittedBytes uint64    `json:"committedBytes"`
+	Refreshed      time.Time `json:"refreshed"`
+}
+
+// BackendCapacity sums the capacity of a backend's storage pools.
+type BackendCapacity struct {
+	Backend           string         `json:"backend"`
+	StorageDriverName string         `json:"storageDriverName"`
+	TotalBytes        uint64         `json:"totalBytes"`
+	UsedBytes         uint64         `json:"usedBytes"`
+	FreeBytes         uint64         `json:"freeBytes"`
+	CommittedBytes    uint64         `json:"committedBytes"`
+	Pools             []PoolCapacity `json:"pools"`
+}
+
+type MultipleBackendCapacityResponse struct {
+	Items []BackendCapacity `json:"items"`
+}
+
 type Version struct {
 	Version       string `json:"version"`
 	MajorVersion  uint   `json:"majorVersion"`
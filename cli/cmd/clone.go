@@ -0,0 +1,18 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func init() {
+	RootCmd.AddCommand(cloneCmd)
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Clone a resource in Trident to a new one",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	clonePVCName      string
+	clonePVCNamespace string
+	cloneDryRun       bool
+	cloneYes          bool
+)
+
+const cloneConfirmation = "Are you sure you want to create PVC %s as a clone of snapshot %s?"
+
+func init() {
+	cloneCmd.AddCommand(cloneSnapshotCmd)
+	cloneSnapshotCmd.Flags().StringVar(&clonePVCName, "pvc", "", "Name of the PVC to create")
+	cloneSnapshotCmd.Flags().StringVar(&clonePVCNamespace, "pvc-namespace", "",
+		"Namespace of the PVC to create (default is the namespace of the volume's PVC)")
+	cloneSnapshotCmd.Flags().BoolVar(&cloneDryRun, "dry-run", false,
+		"Show the operations that would be performed and the PVC that would be created")
+	cloneSnapshotCmd.Flags().BoolVarP(&cloneYes, "yes", "y", false, "Clone without confirmation")
+}
+
+var cloneSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <volume/snapshot> --pvc <name>",
+	Short: "Clone a snapshot of a volume to a new PVC",
+	Long: `Clone a snapshot of a volume to a new PVC
+
+Creates a PVC whose volume is provisioned as a clone of the snapshot, with the
+storage class, access mode, and size of the volume, and waits for Trident to
+bind a PV to it.  The volume must belong to a PVC, and a PVC in another
+namespace may be created only if the volume's PVC allows clones to that
+namespace.  The operations to be performed are shown and must be confirmed
+unless --yes is given, and --dry-run shows them, with the PVC, without
+creating it.`,
+	Aliases: []string{"s", "snap"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		volumeName, snapshotName, err := storage.ParseSnapshotID(args[0])
+		if err != nil {
+			return err
+		}
+		if clonePVCName == "" {
+			return errors.New("PVC name not specified")
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"clone", "snapshot", args[0], "--pvc", clonePVCName,
+				"--pvc-namespace", clonePVCNamespace}
+			if !cloneDryRun && !cloneYes {
+				if cloneYes, err = getTunneledConfirmation(command,
+					fmt.Sprintf(cloneConfirmation, clonePVCName, args[0])); err != nil {
+					return err
+				} else if !cloneYes {
+					return errors.New("clone canceled")
+				}
+			}
+			TunnelCommand(append(command, "--dry-run="+strconv.FormatBool(cloneDryRun),
+				"--yes="+strconv.FormatBool(cloneYes)))
+			return nil
+		} else {
+			return snapshotClone(volumeName, snapshotName)
+		}
+	},
+}
+
+func snapshotClone(volumeName, snapshotName string) error {
+
+	snapshotID := storage.MakeSnapshotID(volumeName, snapshotName)
+	if _, err := GetSnapshot(snapshotID); err != nil {
+		return err
+	}
+	volume, err := GetVolume(volumeName)
+	if err != nil {
+		return err
+	}
+
+	pvc, err := getSnapshotClonePVC(volume, clonePVCName, clonePVCNamespace)
+	if err != nil {
+		return err
+	}
+
+	if cloneDryRun || !cloneYes {
+		writeOperations([]string{fmt.Sprintf(
+			"Create PVC %s in namespace %s, in storage class %s, as a clone of snapshot %s of PVC %s in "+
+				"namespace %s.", pvc.Name, pvc.Namespace, volume.Config.StorageClass, snapshotName,
+			volume.Config.RequestName, volume.Config.Namespace)})
+	}
+	if cloneDryRun {
+		pvcYAML, err := yaml.Marshal(pvc)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("---\n%s", string(pvcYAML))
+		return nil
+	}
+	if !cloneYes {
+		if cloneYes, err = getUserConfirmation(
+			fmt.Sprintf(cloneConfirmation, pvc.Name, snapshotID)); err != nil {
+			return err
+		} else if !cloneYes {
+			return errors.New("clone canceled")
+		}
+	}
+
+	pvcDataJSON, err := json.Marshal(pvc)
+	if err != nil {
+		return err
+	}
+	request := &storage.CloneSnapshotRequest{
+		Volume:   volumeName,
+		Snapshot: snapshotName,
+		PVCData:  base64.StdEncoding.EncodeToString(pvcDataJSON),
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	url := BaseURL() + "/snapshot/clone"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("could not clone snapshot %s: %v", snapshotID,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var cloneResponse rest.CloneSnapshotResponse
+	if err = json.Unmarshal(responseBody, &cloneResponse); err != nil {
+		return err
+	}
+
+	WriteVolumes([]storage.VolumeExternal{*cloneResponse.Volume})
+
+	return nil
+}
+
+// getSnapshotClonePVC generates the PVC for a clone of a snapshot of a volume, with the storage class,
+// access mode, volume mode, and size of the volume.  The PVC is created in the namespace of the volume's
+// PVC unless another is given.  Trident annotates the PVC with its clone source when it is created.
+func getSnapshotClonePVC(volume storage.VolumeExternal, name, namespace string) (*v1.PersistentVolumeClaim, error) {
+
+	if volume.Config.RequestName == "" {
+		return nil, fmt.Errorf("volume %s does not belong to a PVC", volume.Config.Name)
+	}
+	if namespace == "" {
+		namespace = volume.Config.Namespace
+	}
+
+	size, err := resource.ParseQuantity(volume.Config.Size)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the size of volume %s; %v", volume.Config.Name, err)
+	}
+
+	accessMode := v1.PersistentVolumeAccessMode(volume.Config.AccessMode)
+	if accessMode == "" {
+		accessMode = v1.ReadWriteOnce
+	}
+
+	pvc := &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{accessMode},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: size},
+			},
+			StorageClassName: &volume.Config.StorageClass,
+		},
+	}
+	if volume.Config.VolumeMode == config.RawBlock {
+		volumeMode := v1.PersistentVolumeBlock
+		pvc.Spec.VolumeMode = &volumeMode
+	}
+
+	return pvc, nil
+}
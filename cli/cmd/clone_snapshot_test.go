@@ -0,0 +1,47 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
+func TestGetSnapshotClonePVC(t *testing.T) {
+
+	volume := storage.VolumeExternal{Config: &storage.VolumeConfig{
+		Name:         "pvc-1",
+		Size:         "1073741824",
+		StorageClass: "gold",
+		AccessMode:   config.ReadWriteMany,
+		VolumeMode:   config.RawBlock,
+		Namespace:    "apps",
+		RequestName:  "data",
+	}}
+
+	pvc, err := getSnapshotClonePVC(volume, "data-copy", "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "data-copy", pvc.Name)
+		assert.Equal(t, "apps", pvc.Namespace, "namespace of the volume's PVC not used")
+		assert.Equal(t, "gold", *pvc.Spec.StorageClassName)
+		assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}, pvc.Spec.AccessModes)
+		assert.Equal(t, v1.PersistentVolumeBlock, *pvc.Spec.VolumeMode)
+		size := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		assert.Equal(t, int64(1073741824), size.Value())
+	}
+
+	pvc, err = getSnapshotClonePVC(volume, "data-copy", "test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "test", pvc.Namespace)
+	}
+
+	// Only the volumes of PVCs may be cloned to PVCs
+	volume.Config.RequestName = ""
+	_, err = getSnapshotClonePVC(volume, "data-copy", "")
+	assert.Error(t, err)
+}
@@ -10,8 +10,12 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/netapp/trident/cli/api"
@@ -22,6 +26,7 @@ import (
 var (
 	createFilename   string
 	createBase64Data string
+	createDryRun     bool
 )
 
 func init() {
@@ -29,6 +34,8 @@ func init() {
 	createBackendCmd.Flags().StringVarP(&createFilename, "filename", "f", "", "Path to YAML or JSON file")
 	createBackendCmd.Flags().StringVarP(&createBase64Data, "base64", "", "", "Base64 encoding")
 	createBackendCmd.Flags().MarkHidden("base64")
+	createBackendCmd.Flags().BoolVarP(&createDryRun, "dry-run", "", false,
+		"Show the storage pools the backend would offer without creating it")
 }
 
 var createBackendCmd = &cobra.Command{
@@ -44,8 +51,13 @@ var createBackendCmd = &cobra.Command{
 
 		if OperatingMode == ModeTunnel {
 			command := []string{"create", "backend", "--base64", base64.StdEncoding.EncodeToString(jsonData)}
+			if createDryRun {
+				command = append(command, "--dry-run")
+			}
 			TunnelCommand(append(command, args...))
 			return nil
+		} else if createDryRun {
+			return backendPreview(jsonData)
 		} else {
 			return backendCreate(jsonData)
 		}
@@ -114,3 +126,79 @@ func backendCreate(postData []byte) error {
 
 	return nil
 }
+
+func backendPreview(postData []byte) error {
+
+	// Send the file to Trident, which resolves the backend's pools without creating it
+	url := BaseURL() + "/backend?dryRun=true"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not preview backend: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var previewBackendResponse rest.PreviewBackendResponse
+	err = json.Unmarshal(responseBody, &previewBackendResponse)
+	if err != nil {
+		return err
+	}
+	if previewBackendResponse.Backend == nil {
+		return errors.New("no backend preview was returned")
+	}
+
+	WriteBackendPreview(previewBackendResponse.Backend)
+
+	return nil
+}
+
+func WriteBackendPreview(preview *storage.BackendPreview) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(preview)
+	case FormatYAML:
+		WriteYAML(preview)
+	case FormatName:
+		for _, pool := range preview.Pools {
+			fmt.Println(pool.Name)
+		}
+	default:
+		writeBackendPreviewTable(preview)
+	}
+}
+
+func writeBackendPreviewTable(preview *storage.BackendPreview) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Pool", "Virtual", "Storage Attributes", "Defaults"})
+
+	for _, pool := range preview.Pools {
+		table.Append([]string{
+			pool.Name,
+			strconv.FormatBool(pool.Virtual),
+			formatPreviewMap(pool.Attributes),
+			formatPreviewMap(pool.Defaults),
+		})
+	}
+
+	table.Render()
+}
+
+// formatPreviewMap lists the non-empty values of a map, sorted by key, one per line.
+func formatPreviewMap(values map[string]string) string {
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+values[key])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	createQuotaFilename   string
+	createQuotaBase64Data string
+)
+
+func init() {
+	createCmd.AddCommand(createQuotaCmd)
+	createQuotaCmd.Flags().StringVarP(&createQuotaFilename, "filename", "f", "", "Path to YAML or JSON file")
+	createQuotaCmd.Flags().StringVarP(&createQuotaBase64Data, "base64", "", "", "Base64 encoding")
+	createQuotaCmd.Flags().MarkHidden("base64")
+}
+
+var createQuotaCmd = &cobra.Command{
+	Use:     "quota",
+	Short:   "Add a volume quota to Trident",
+	Aliases: []string{"q"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		jsonData, err := getBackendData(createQuotaFilename, createQuotaBase64Data)
+		if err != nil {
+			return err
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"create", "quota", "--base64", base64.StdEncoding.EncodeToString(jsonData)}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return quotaCreate(jsonData)
+		}
+	},
+}
+
+func quotaCreate(postData []byte) error {
+
+	// Send the file to Trident
+	url := BaseURL() + "/quota"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, postData, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("could not create quota: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var addQuotaResponse rest.AddQuotaResponse
+	err = json.Unmarshal(responseBody, &addQuotaResponse)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve the newly created quota and write to stdout
+	quota, err := GetQuota(addQuotaResponse.QuotaID)
+	if err != nil {
+		return err
+	}
+
+	WriteQuotas([]storage.QuotaExternal{*quota})
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+)
+
+var allQuotas bool
+
+func init() {
+	deleteCmd.AddCommand(deleteQuotaCmd)
+	deleteQuotaCmd.Flags().BoolVarP(&allQuotas, "all", "", false, "Delete all quotas")
+}
+
+var deleteQuotaCmd = &cobra.Command{
+	Use:     "quota <name> [<name>...]",
+	Short:   "Delete one or more volume quotas from Trident",
+	Aliases: []string{"q", "quotas"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"delete", "quota"}
+			if allQuotas {
+				command = append(command, "--all")
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return quotaDelete(args)
+		}
+	},
+}
+
+func quotaDelete(quotaNames []string) error {
+
+	var err error
+
+	if allQuotas {
+		// Make sure --all isn't being used along with specific quotas
+		if len(quotaNames) > 0 {
+			return errors.New("cannot use --all switch and specify individual quotas")
+		}
+
+		// Get list of quota names so we can delete them all
+		quotaNames, err = GetQuotas()
+		if err != nil {
+			return err
+		}
+	} else {
+		// Not using --all, so make sure one or more quotas were specified
+		if len(quotaNames) == 0 {
+			return errors.New("quota name not specified")
+		}
+	}
+
+	for _, quotaName := range quotaNames {
+		url := BaseURL() + "/quota/" + quotaName
+
+		response, responseBody, err := api.InvokeRESTAPI("DELETE", url, nil, Debug)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("could not delete quota %s: %v", quotaName,
+				GetErrorFromHTTPResponse(response, responseBody))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/audit"
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+)
+
+var (
+	auditBackend   string
+	auditVolume    string
+	auditOperation string
+	auditSince     time.Duration
+	auditFailed    bool
+	auditLimit     int
+)
+
+func init() {
+	getCmd.AddCommand(getAuditCmd)
+	getAuditCmd.Flags().StringVar(&auditBackend, "backend", "", "Only show calls made by this backend")
+	getAuditCmd.Flags().StringVar(&auditVolume, "volume", "", "Only show calls that changed this volume")
+	getAuditCmd.Flags().StringVar(&auditOperation, "operation", "", "Only show calls to this storage API")
+	getAuditCmd.Flags().DurationVar(&auditSince, "since", 0, "Only show calls made within this duration")
+	getAuditCmd.Flags().BoolVar(&auditFailed, "failed", false, "Only show calls that failed")
+	getAuditCmd.Flags().IntVar(&auditLimit, "limit", 100, "Number of most recent calls to show, 0 for all")
+}
+
+var getAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Get the audit log of calls that changed storage",
+	Long: `Get the audit log of calls that changed storage
+
+Trident records each storage API call that creates, deletes, maps, or otherwise
+changes storage, along with the backend, volume, and request it was made for and
+whether the storage system accepted it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{
+				"get", "audit",
+				"--backend=" + auditBackend,
+				"--volume=" + auditVolume,
+				"--operation=" + auditOperation,
+				"--since=" + auditSince.String(),
+				"--failed=" + strconv.FormatBool(auditFailed),
+				"--limit=" + strconv.Itoa(auditLimit),
+			}
+			TunnelCommand(command)
+			return nil
+		} else {
+			return auditList()
+		}
+	},
+}
+
+func auditList() error {
+
+	query := url.Values{}
+	if auditBackend != "" {
+		query.Set("backend", auditBackend)
+	}
+	if auditVolume != "" {
+		query.Set("volume", auditVolume)
+	}
+	if auditOperation != "" {
+		query.Set("operation", auditOperation)
+	}
+	if auditSince > 0 {
+		query.Set("since", time.Now().Add(-auditSince).UTC().Format(time.RFC3339))
+	}
+	if auditFailed {
+		query.Set("failed", "true")
+	}
+	query.Set("limit", strconv.Itoa(auditLimit))
+
+	auditURL := BaseURL() + "/audit?" + query.Encode()
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", auditURL, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get the audit log: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listResponse rest.ListAuditEventsResponse
+	if err = json.Unmarshal(responseBody, &listResponse); err != nil {
+		return err
+	}
+
+	events := make([]audit.Event, 0, len(listResponse.Events))
+	for _, event := range listResponse.Events {
+		events = append(events, *event)
+	}
+	WriteAuditEvents(events)
+
+	return nil
+}
+
+func WriteAuditEvents(events []audit.Event) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleAuditEventResponse{Items: events})
+	case FormatYAML:
+		WriteYAML(api.MultipleAuditEventResponse{Items: events})
+	case FormatName:
+		writeAuditEventOperations(events)
+	case FormatWide:
+		writeWideAuditEventTable(events)
+	default:
+		writeAuditEventTable(events)
+	}
+}
+
+func writeAuditEventTable(events []audit.Event) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "Backend", "Operation", "Volume", "Object", "Status"})
+
+	for _, event := range events {
+		table.Append([]string{
+			event.Time.Format(time.RFC3339),
+			event.Backend,
+			event.Operation,
+			event.Volume,
+			event.Object,
+			event.Status,
+		})
+	}
+
+	table.Render()
+}
+
+func writeWideAuditEventTable(events []audit.Event) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{
+		"Time", "Backend", "SVM", "User", "Request ID", "Source", "Operation", "Volume", "Object", "Status",
+		"Error Code", "Reason",
+	})
+
+	for _, event := range events {
+		table.Append([]string{
+			event.Time.Format(time.RFC3339),
+			event.Backend,
+			event.SVM,
+			event.User,
+			event.RequestID,
+			event.RequestSource,
+			event.Operation,
+			event.Volume,
+			event.Object,
+			event.Status,
+			event.ErrorCode,
+			event.Reason,
+		})
+	}
+
+	table.Render()
+}
+
+func writeAuditEventOperations(events []audit.Event) {
+
+	for _, event := range events {
+		fmt.Println(event.Operation)
+	}
+}
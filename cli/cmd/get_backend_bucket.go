@@ -0,0 +1,126 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getBackendCmd.AddCommand(getBackendBucketCmd)
+}
+
+var getBackendBucketCmd = &cobra.Command{
+	Use:     "buckets <backendName>",
+	Short:   "Get how a backend's volumes are distributed among its buckets",
+	Aliases: []string{"bucket"},
+	Long: `Get how a backend's volumes are distributed among its buckets
+
+Lists the volumes on the backend's storage system that each hold several Trident
+volumes, such as the Flexvols holding ontap-nas-economy qtrees, with the number
+of volumes in each against the backend's limit per bucket.  Buckets over the
+limit, as after the limit is lowered, and buckets that could be consolidated
+are reported so the volumes may be rebalanced.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "backend", "buckets"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendBucketGet(args[0])
+		}
+	},
+}
+
+func backendBucketGet(backendName string) error {
+
+	url := BaseURL() + "/backend/" + backendName + "/bucket"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get the buckets of backend %s: %v", backendName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var getResponse rest.GetBackendBucketsResponse
+	if err = json.Unmarshal(responseBody, &getResponse); err != nil {
+		return err
+	}
+	if getResponse.Report == nil {
+		return fmt.Errorf("could not get the buckets of backend %s: no report returned", backendName)
+	}
+
+	WriteBucketReport(getResponse.Report)
+
+	return nil
+}
+
+func WriteBucketReport(report *storage.BucketReport) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(report)
+	case FormatYAML:
+		WriteYAML(report)
+	case FormatName:
+		writeBucketNames(report)
+	default:
+		writeBucketTable(report)
+	}
+}
+
+func writeBucketTable(report *storage.BucketReport) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Pool", "Size", "Allocated", "Volumes", "Deleted", "Full"})
+
+	for _, bucket := range report.Buckets {
+
+		size, _ := strconv.ParseUint(bucket.Size, 10, 64)
+		allocated, _ := strconv.ParseUint(bucket.Allocated, 10, 64)
+
+		full := ""
+		if report.VolumeLimit > 0 {
+			full = fmt.Sprintf("%d%%", 100*(bucket.Volumes+bucket.DeletedVolumes)/report.VolumeLimit)
+		}
+
+		table.Append([]string{
+			bucket.Name,
+			bucket.Pool,
+			humanize.IBytes(size),
+			humanize.IBytes(allocated),
+			strconv.Itoa(bucket.Volumes),
+			strconv.Itoa(bucket.DeletedVolumes),
+			full,
+		})
+	}
+
+	table.Render()
+
+	fmt.Printf("Limit of %d volumes per bucket; the volumes could fit in %d of %d buckets.\n",
+		report.VolumeLimit, report.MinimumBuckets(), len(report.Buckets))
+	for _, bucket := range report.OverLimit() {
+		fmt.Printf("Bucket %s is over the limit.\n", bucket.Name)
+	}
+}
+
+func writeBucketNames(report *storage.BucketReport) {
+
+	for _, bucket := range report.Buckets {
+		fmt.Println(bucket.Name)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getBackendCmd.AddCommand(getBackendLogCmd)
+}
+
+var getBackendLogCmd = &cobra.Command{
+	Use:     "logs <backendName>",
+	Short:   "Get the recent entries of a backend's storage system logs",
+	Aliases: []string{"log"},
+	Long: `Get the recent entries of a backend's storage system logs
+
+Lists the recent entries of the logs kept by the backend's storage system,
+newest first.  For ONTAP backends, these are the jobs run on the SVM and the
+EMS events logged by the cluster; the event log can't be read with SVM-scoped
+credentials.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "backend", "logs"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendLogList(args[0])
+		}
+	},
+}
+
+func backendLogList(backendName string) error {
+
+	url := BaseURL() + "/backend/" + backendName + "/logs"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not read the storage logs of backend %s: %v", backendName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var logsResponse rest.GetBackendStorageLogsResponse
+	if err = json.Unmarshal(responseBody, &logsResponse); err != nil {
+		return err
+	}
+
+	entries := make([]storage.StorageLogEntry, 0, len(logsResponse.Entries))
+	for _, entry := range logsResponse.Entries {
+		entries = append(entries, *entry)
+	}
+	WriteStorageLogEntries(entries)
+
+	return nil
+}
+
+func WriteStorageLogEntries(entries []storage.StorageLogEntry) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleStorageLogEntryResponse{Items: entries})
+	case FormatYAML:
+		WriteYAML(api.MultipleStorageLogEntryResponse{Items: entries})
+	case FormatName:
+		writeStorageLogEntryNames(entries)
+	default:
+		writeStorageLogEntryTable(entries)
+	}
+}
+
+func writeStorageLogEntryTable(entries []storage.StorageLogEntry) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "Log", "Name", "Node", "Severity", "Message"})
+
+	for _, entry := range entries {
+		table.Append([]string{
+			entry.Time,
+			entry.Log,
+			entry.Name,
+			entry.Node,
+			entry.Severity,
+			entry.Message,
+		})
+	}
+
+	table.Render()
+}
+
+func writeStorageLogEntryNames(entries []storage.StorageLogEntry) {
+
+	for _, entry := range entries {
+		fmt.Println(entry.Name)
+	}
+}
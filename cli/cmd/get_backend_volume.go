@@ -0,0 +1,135 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var backendVolumePageSize int
+
+func init() {
+	getBackendCmd.AddCommand(getBackendVolumeCmd)
+	getBackendVolumeCmd.Flags().IntVar(&backendVolumePageSize, "page-size", 100,
+		"Number of volumes to request from the backend at a time")
+}
+
+var getBackendVolumeCmd = &cobra.Command{
+	Use:     "volumes <backendName>",
+	Short:   "Get the volumes on a backend's storage system",
+	Aliases: []string{"volume"},
+	Long: `Get the volumes on a backend's storage system
+
+Lists the volumes on the backend's storage system that Trident may have created,
+i.e. those whose names begin with the backend's storage prefix, along with the
+Trident volume stored in each.  Volumes not belonging to a Trident volume may be
+imported with 'tridentctl import volume'.  The volumes are requested from the
+backend a page at a time.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "backend", "volumes", "--page-size=" + strconv.Itoa(backendVolumePageSize)}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendVolumeList(args[0])
+		}
+	},
+}
+
+func backendVolumeList(backendName string) error {
+
+	if backendVolumePageSize <= 0 {
+		return fmt.Errorf("page size must be positive")
+	}
+
+	volumes := make([]storage.BackendVolume, 0)
+	continueToken := ""
+
+	for {
+		query := url.Values{"limit": []string{strconv.Itoa(backendVolumePageSize)}}
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+		volumeURL := BaseURL() + "/backend/" + backendName + "/volume?" + query.Encode()
+
+		response, responseBody, err := api.InvokeRESTAPI("GET", volumeURL, nil, Debug)
+		if err != nil {
+			return err
+		} else if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("could not list the volumes of backend %s: %v", backendName,
+				GetErrorFromHTTPResponse(response, responseBody))
+		}
+
+		var listResponse rest.ListBackendVolumesResponse
+		if err = json.Unmarshal(responseBody, &listResponse); err != nil {
+			return err
+		}
+
+		for _, volume := range listResponse.Volumes {
+			volumes = append(volumes, *volume)
+		}
+		if listResponse.Continue == "" {
+			break
+		}
+		continueToken = listResponse.Continue
+	}
+
+	WriteBackendVolumes(volumes)
+
+	return nil
+}
+
+func WriteBackendVolumes(volumes []storage.BackendVolume) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleBackendVolumeResponse{Items: volumes})
+	case FormatYAML:
+		WriteYAML(api.MultipleBackendVolumeResponse{Items: volumes})
+	case FormatName:
+		writeBackendVolumeNames(volumes)
+	default:
+		writeBackendVolumeTable(volumes)
+	}
+}
+
+func writeBackendVolumeTable(volumes []storage.BackendVolume) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Size", "Pool", "State", "Volume"})
+
+	for _, volume := range volumes {
+
+		volumeSize, _ := strconv.ParseUint(volume.Size, 10, 64)
+
+		table.Append([]string{
+			volume.InternalName,
+			humanize.IBytes(volumeSize),
+			volume.Pool,
+			volume.State,
+			volume.Volume,
+		})
+	}
+
+	table.Render()
+}
+
+func writeBackendVolumeNames(volumes []storage.BackendVolume) {
+
+	for _, volume := range volumes {
+		fmt.Println(volume.InternalName)
+	}
+}
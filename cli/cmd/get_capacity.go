@@ -0,0 +1,184 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	getCmd.AddCommand(getCapacityCmd)
+}
+
+var getCapacityCmd = &cobra.Command{
+	Use:     "capacity [<backend>...]",
+	Short:   "Get the capacity of one or more storage backends from Trident",
+	Aliases: []string{"cap"},
+	Long: `Get the capacity of one or more storage backends from Trident
+
+Lists the total, used, free, and committed space of each backend's storage
+pools, as last reported by the backend, and the sums for each backend.
+Committed space is the space provisioned to volumes in a pool, which may
+exceed its total capacity when volumes are thin provisioned.  Pools whose
+backends don't report capacity are not listed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "capacity"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return capacityList(args)
+		}
+	},
+}
+
+func capacityList(backendNames []string) error {
+
+	var err error
+
+	// If no backends were specified, we'll report all of them
+	if len(backendNames) == 0 {
+		backendNames, err = GetBackends()
+		if err != nil {
+			return err
+		}
+	}
+
+	capacities := make([]api.BackendCapacity, 0, len(backendNames))
+	for _, backendName := range backendNames {
+
+		backend, err := GetBackend(backendName)
+		if err != nil {
+			return err
+		}
+		capacity, err := getBackendCapacity(backend)
+		if err != nil {
+			return fmt.Errorf("could not read the capacity of backend %s: %v", backendName, err)
+		}
+		capacities = append(capacities, capacity)
+	}
+
+	WriteBackendCapacities(capacities)
+
+	return nil
+}
+
+// getBackendCapacity sums the most recently reported capacity of a backend's storage pools.
+func getBackendCapacity(backend storage.BackendExternal) (api.BackendCapacity, error) {
+
+	backendCapacity := api.BackendCapacity{
+		Backend: backend.Name,
+		Pools:   make([]api.PoolCapacity, 0),
+	}
+	if configAsMap, ok := backend.Config.(map[string]interface{}); ok {
+		backendCapacity.StorageDriverName, _ = configAsMap["storageDriverName"].(string)
+	}
+
+	for poolName, pool := range backend.Storage {
+
+		// The pools are returned as generic maps, so only their capacity is decoded
+		poolJSON, err := json.Marshal(pool)
+		if err != nil {
+			return backendCapacity, err
+		}
+		var poolExternal struct {
+			Capacity *storage.PoolCapacity `json:"capacity"`
+		}
+		if err = json.Unmarshal(poolJSON, &poolExternal); err != nil {
+			return backendCapacity, err
+		}
+		if poolExternal.Capacity == nil {
+			continue
+		}
+
+		poolCapacity := api.PoolCapacity{
+			Name:           poolName,
+			TotalBytes:     poolExternal.Capacity.TotalBytes,
+			FreeBytes:      poolExternal.Capacity.FreeBytes,
+			CommittedBytes: poolExternal.Capacity.ProvisionedBytes,
+			Refreshed:      poolExternal.Capacity.Refreshed,
+		}
+		if poolCapacity.TotalBytes > poolCapacity.FreeBytes {
+			poolCapacity.UsedBytes = poolCapacity.TotalBytes - poolCapacity.FreeBytes
+		}
+		backendCapacity.Pools = append(backendCapacity.Pools, poolCapacity)
+
+		backendCapacity.TotalBytes += poolCapacity.TotalBytes
+		backendCapacity.UsedBytes += poolCapacity.UsedBytes
+		backendCapacity.FreeBytes += poolCapacity.FreeBytes
+		backendCapacity.CommittedBytes += poolCapacity.CommittedBytes
+	}
+
+	sort.Slice(backendCapacity.Pools, func(i, j int) bool {
+		return backendCapacity.Pools[i].Name < backendCapacity.Pools[j].Name
+	})
+
+	return backendCapacity, nil
+}
+
+func WriteBackendCapacities(capacities []api.BackendCapacity) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleBackendCapacityResponse{Items: capacities})
+	case FormatYAML:
+		WriteYAML(api.MultipleBackendCapacityResponse{Items: capacities})
+	case FormatName:
+		writeBackendCapacityNames(capacities)
+	default:
+		writeBackendCapacityTable(capacities)
+	}
+}
+
+func writeBackendCapacityTable(capacities []api.BackendCapacity) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Backend", "Pool", "Total", "Used", "Free", "Committed"})
+
+	for _, backendCapacity := range capacities {
+		for _, pool := range backendCapacity.Pools {
+			table.Append([]string{
+				backendCapacity.Backend,
+				pool.Name,
+				formatCapacity(pool.TotalBytes),
+				formatCapacity(pool.UsedBytes),
+				humanize.IBytes(pool.FreeBytes),
+				humanize.IBytes(pool.CommittedBytes),
+			})
+		}
+		table.Append([]string{
+			backendCapacity.Backend,
+			"(all)",
+			formatCapacity(backendCapacity.TotalBytes),
+			formatCapacity(backendCapacity.UsedBytes),
+			humanize.IBytes(backendCapacity.FreeBytes),
+			humanize.IBytes(backendCapacity.CommittedBytes),
+		})
+	}
+
+	table.Render()
+}
+
+// formatCapacity shows a size that is only known for pools reporting their total capacity.
+func formatCapacity(bytes uint64) string {
+	if bytes == 0 {
+		return ""
+	}
+	return humanize.IBytes(bytes)
+}
+
+func writeBackendCapacityNames(capacities []api.BackendCapacity) {
+
+	for _, backendCapacity := range capacities {
+		fmt.Println(backendCapacity.Backend)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestGetBackendCapacity(t *testing.T) {
+
+	// Decode the backend as tridentctl receives it from Trident
+	backendJSON, err := json.Marshal(&storage.BackendExternal{
+		Name:   "ontapnas",
+		Config: map[string]interface{}{"storageDriverName": "ontap-nas"},
+		Storage: map[string]interface{}{
+			"aggr2": &storage.PoolExternal{Name: "aggr2", Capacity: &storage.PoolCapacity{
+				TotalBytes: 1000, FreeBytes: 400, ProvisionedBytes: 1500}},
+			"aggr1": &storage.PoolExternal{Name: "aggr1", Capacity: &storage.PoolCapacity{
+				TotalBytes: 2000, FreeBytes: 1500, ProvisionedBytes: 800}},
+			"aggr3": &storage.PoolExternal{Name: "aggr3"},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var backend storage.BackendExternal
+	if !assert.NoError(t, json.Unmarshal(backendJSON, &backend)) {
+		return
+	}
+
+	capacity, err := getBackendCapacity(backend)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ontapnas", capacity.Backend)
+		assert.Equal(t, "ontap-nas", capacity.StorageDriverName)
+		assert.Equal(t, uint64(3000), capacity.TotalBytes)
+		assert.Equal(t, uint64(1100), capacity.UsedBytes)
+		assert.Equal(t, uint64(1900), capacity.FreeBytes)
+		assert.Equal(t, uint64(2300), capacity.CommittedBytes)
+		if assert.Len(t, capacity.Pools, 2, "pool without capacity listed") {
+			assert.Equal(t, "aggr1", capacity.Pools[0].Name)
+			assert.Equal(t, uint64(500), capacity.Pools[0].UsedBytes)
+			assert.Equal(t, "aggr2", capacity.Pools[1].Name)
+			assert.Equal(t, uint64(1500), capacity.Pools[1].CommittedBytes)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var jobBackend string
+
+func init() {
+	getCmd.AddCommand(getJobCmd)
+	getJobCmd.Flags().StringVar(&jobBackend, "backend", "", "Only show jobs running on this backend")
+}
+
+var getJobCmd = &cobra.Command{
+	Use:     "job",
+	Short:   "Get the storage jobs Trident is waiting on",
+	Aliases: []string{"j", "jobs"},
+	Long: `Get the storage jobs Trident is waiting on
+
+Lists the operations that backends are running in the background, such as clone
+splits, volume moves, volume copies, and FlexGroup clones and expansions, with
+their progress and the number running on each backend.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"get", "job", "--backend=" + jobBackend}
+			TunnelCommand(command)
+			return nil
+		} else {
+			return jobList()
+		}
+	},
+}
+
+func jobList() error {
+
+	jobURL := BaseURL() + "/job"
+	if jobBackend != "" {
+		jobURL += "?" + url.Values{"backend": []string{jobBackend}}.Encode()
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", jobURL, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get storage jobs: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listResponse rest.ListStorageJobsResponse
+	if err = json.Unmarshal(responseBody, &listResponse); err != nil {
+		return err
+	}
+
+	jobs := make([]storage.StorageJob, 0, len(listResponse.Jobs))
+	for _, job := range listResponse.Jobs {
+		jobs = append(jobs, *job)
+	}
+	WriteStorageJobs(jobs, listResponse.Counts)
+
+	return nil
+}
+
+func WriteStorageJobs(jobs []storage.StorageJob, counts map[string]int) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleStorageJobResponse{Items: jobs, Counts: counts})
+	case FormatYAML:
+		WriteYAML(api.MultipleStorageJobResponse{Items: jobs, Counts: counts})
+	case FormatName:
+		writeStorageJobVolumes(jobs)
+	case FormatWide:
+		writeWideStorageJobTable(jobs)
+		writeStorageJobCounts(counts)
+	default:
+		writeStorageJobTable(jobs)
+		writeStorageJobCounts(counts)
+	}
+}
+
+func writeStorageJobTable(jobs []storage.StorageJob) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Backend", "Volume", "Operation", "State", "Progress", "Started"})
+
+	for _, job := range jobs {
+		table.Append([]string{
+			job.Backend,
+			getStorageJobVolume(job),
+			job.Operation,
+			job.State,
+			getStorageJobProgress(job),
+			job.StartTime,
+		})
+	}
+
+	table.Render()
+}
+
+func writeWideStorageJobTable(jobs []storage.StorageJob) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{
+		"Backend", "Volume", "Internal Volume", "Operation", "Job ID", "State", "Progress", "Started", "Message",
+	})
+
+	for _, job := range jobs {
+		table.Append([]string{
+			job.Backend,
+			job.Volume,
+			job.InternalVolume,
+			job.Operation,
+			job.JobID,
+			job.State,
+			getStorageJobProgress(job),
+			job.StartTime,
+			job.Message,
+		})
+	}
+
+	table.Render()
+}
+
+func writeStorageJobCounts(counts map[string]int) {
+
+	backends := make([]string, 0, len(counts))
+	for backend := range counts {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Backend", "Jobs"})
+	for _, backend := range backends {
+		table.Append([]string{backend, strconv.Itoa(counts[backend])})
+	}
+
+	table.Render()
+}
+
+func writeStorageJobVolumes(jobs []storage.StorageJob) {
+
+	for _, job := range jobs {
+		fmt.Println(getStorageJobVolume(job))
+	}
+}
+
+// getStorageJobVolume names a job's volume, which is only known by its internal name while it is
+// being created.
+func getStorageJobVolume(job storage.StorageJob) string {
+	if job.Volume != "" {
+		return job.Volume
+	}
+	return job.InternalVolume
+}
+
+// getStorageJobProgress describes a job's progress, which is only measured for some operations.
+func getStorageJobProgress(job storage.StorageJob) string {
+	if job.Operation == storage.StorageJobOperationCloneCreate {
+		return job.Message
+	}
+	return strconv.Itoa(job.PercentComplete) + "%"
+}
@@ -156,17 +156,27 @@ func writeWideSnapshotTable(snapshots []storage.SnapshotExternal) {
 		"Volume",
 		"Created",
 		"Size",
+		"Used",
+		"Reserve Spill",
 		"State",
 	}
 	table.SetHeader(header)
 
 	for _, snapshot := range snapshots {
 
+		reserveSpill := ""
+		if snapshot.VolumeReserve != nil {
+			reserveSpill = humanize.IBytes(uint64(snapshot.VolumeReserve.SpillBytes))
+		}
+
 		table.Append([]string{
 			snapshot.Config.Name,
 			snapshot.Config.VolumeName,
 			snapshot.Created,
 			humanize.IBytes(uint64(snapshot.SizeBytes)),
+			humanize.IBytes(uint64(snapshot.UsedBytes)),
+			reserveSpill,
+			string(snapshot.State),
 		})
 	}
 
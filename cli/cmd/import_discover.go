@@ -0,0 +1,296 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/ghodss/yaml"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	discoverBackend      string
+	discoverPrefix       string
+	discoverJunctionPath string
+	discoverMinSize      string
+	discoverMaxSize      string
+	discoverImport       bool
+	discoverDryRun       bool
+	discoverVolumes      []string
+	discoverStorageClass string
+	discoverNamespace    string
+	discoverNoManage     bool
+)
+
+// pvcNameInvalidChars matches the runs of characters that may not appear in a PVC name.
+var pvcNameInvalidChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+func init() {
+	importCmd.AddCommand(importDiscoverCmd)
+	importDiscoverCmd.Flags().StringVar(&discoverBackend, "backend", "", "Backend to search for volumes")
+	importDiscoverCmd.Flags().StringVar(&discoverPrefix, "prefix", "", "List only volumes whose names begin with this prefix")
+	importDiscoverCmd.Flags().StringVar(&discoverJunctionPath, "junction-path", "",
+		"List only volumes whose junction paths begin with this path")
+	importDiscoverCmd.Flags().StringVar(&discoverMinSize, "min-size", "", "List only volumes at least this large")
+	importDiscoverCmd.Flags().StringVar(&discoverMaxSize, "max-size", "", "List only volumes at most this large")
+	importDiscoverCmd.Flags().BoolVar(&discoverImport, "import", false, "Import the volumes listed")
+	importDiscoverCmd.Flags().BoolVar(&discoverDryRun, "dry-run", false,
+		"Show the PVCs that would be created instead of importing the volumes")
+	importDiscoverCmd.Flags().StringSliceVar(&discoverVolumes, "volumes", []string{},
+		"Import only these of the volumes listed")
+	importDiscoverCmd.Flags().StringVar(&discoverStorageClass, "storage-class", "",
+		"Storage class of the PVCs created for imported volumes")
+	importDiscoverCmd.Flags().StringVar(&discoverNamespace, "pvc-namespace", "default",
+		"Namespace of the PVCs created for imported volumes")
+	importDiscoverCmd.Flags().BoolVar(&discoverNoManage, "no-manage", false,
+		"Create PV/PVC only, don't assume volume lifecycle management")
+}
+
+var importDiscoverCmd = &cobra.Command{
+	Use:   "discover --backend <backendName>",
+	Short: "Find volumes on a backend that may be imported to Trident",
+	Long: `Find volumes on a backend that may be imported to Trident
+
+Lists the volumes on the backend's storage system that don't belong to a Trident
+volume and that could be imported, whatever their names, optionally limited to
+those matching a name prefix, junction path prefix, or size range.  With
+--import, each volume listed, or only those named with --volumes, is imported
+with a PVC generated for it in the given storage class and namespace, and
+Trident creates the PV bound to it.  With --dry-run, the generated PVCs are
+shown instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"import", "discover", "--backend", discoverBackend,
+				"--prefix", discoverPrefix, "--junction-path", discoverJunctionPath,
+				"--min-size", discoverMinSize, "--max-size", discoverMaxSize,
+				"--import=" + strconv.FormatBool(discoverImport),
+				"--dry-run=" + strconv.FormatBool(discoverDryRun),
+				"--volumes", strings.Join(discoverVolumes, ","),
+				"--storage-class", discoverStorageClass, "--pvc-namespace", discoverNamespace,
+				"--no-manage=" + strconv.FormatBool(discoverNoManage)}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return importDiscover()
+		}
+	},
+}
+
+func importDiscover() error {
+
+	if discoverBackend == "" {
+		return errors.New("backend not specified")
+	}
+	if discoverImport && discoverStorageClass == "" {
+		return errors.New("a storage class is required to import volumes")
+	}
+
+	candidates, err := GetImportCandidates(discoverBackend)
+	if err != nil {
+		return err
+	}
+
+	if !discoverImport {
+		WriteImportCandidates(candidates)
+		return nil
+	}
+
+	candidates, err = selectImportCandidates(candidates, discoverVolumes)
+	if err != nil {
+		return err
+	}
+
+	// Import the volumes one at a time, reporting those imported before any failure
+	volumes := make([]storage.VolumeExternal, 0, len(candidates))
+	for _, candidate := range candidates {
+
+		pvc := getImportPVC(candidate, discoverStorageClass, discoverNamespace)
+		if discoverDryRun {
+			pvcYAML, err := yaml.Marshal(pvc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("---\n%s", string(pvcYAML))
+			continue
+		}
+
+		pvcDataJSON, err := json.Marshal(pvc)
+		if err != nil {
+			return err
+		}
+		volume, err := importVolume(discoverBackend, candidate.InternalName, discoverNoManage, pvcDataJSON)
+		if err != nil {
+			WriteVolumes(volumes)
+			return fmt.Errorf("could not import volume %s: %v", candidate.InternalName, err)
+		}
+		volumes = append(volumes, *volume)
+	}
+
+	if !discoverDryRun {
+		WriteVolumes(volumes)
+	}
+
+	return nil
+}
+
+// GetImportCandidates asks Trident for the volumes on a backend that could be imported, filtered by
+// the command's flags.
+func GetImportCandidates(backendName string) ([]storage.ImportCandidate, error) {
+
+	query := url.Values{}
+	for param, value := range map[string]string{
+		"prefix":       discoverPrefix,
+		"junctionPath": discoverJunctionPath,
+		"minSize":      discoverMinSize,
+		"maxSize":      discoverMaxSize,
+	} {
+		if value != "" {
+			query.Set(param, value)
+		}
+	}
+	candidatesURL := BaseURL() + "/backend/" + backendName + "/import"
+	if len(query) > 0 {
+		candidatesURL += "?" + query.Encode()
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", candidatesURL, nil, Debug)
+	if err != nil {
+		return nil, err
+	} else if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not find the volumes of backend %s that may be imported: %v",
+			backendName, GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listResponse rest.ListImportCandidatesResponse
+	if err = json.Unmarshal(responseBody, &listResponse); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]storage.ImportCandidate, 0, len(listResponse.Candidates))
+	for _, candidate := range listResponse.Candidates {
+		candidates = append(candidates, *candidate)
+	}
+	return candidates, nil
+}
+
+// selectImportCandidates returns the candidates with the given names, or all of them if no names are
+// given.  Every name must be that of a candidate.
+func selectImportCandidates(
+	candidates []storage.ImportCandidate, names []string,
+) ([]storage.ImportCandidate, error) {
+
+	if len(names) == 0 {
+		return candidates, nil
+	}
+
+	candidatesByName := make(map[string]storage.ImportCandidate, len(candidates))
+	for _, candidate := range candidates {
+		candidatesByName[candidate.InternalName] = candidate
+	}
+
+	selected := make([]storage.ImportCandidate, 0, len(names))
+	for _, name := range names {
+		candidate, ok := candidatesByName[name]
+		if !ok {
+			return nil, fmt.Errorf("volume %s is not among the volumes that may be imported", name)
+		}
+		selected = append(selected, candidate)
+	}
+	return selected, nil
+}
+
+// getImportPVC generates the PVC for importing a volume, named after the volume.  Trident sets the size
+// of the PVC to that of the volume when it is imported.
+func getImportPVC(candidate storage.ImportCandidate, storageClass, namespace string) *v1.PersistentVolumeClaim {
+
+	accessMode := v1.ReadWriteMany
+	if candidate.Protocol == config.Block {
+		accessMode = v1.ReadWriteOnce
+	}
+
+	return &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getImportPVCName(candidate.InternalName),
+			Namespace: namespace,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: &storageClass,
+		},
+	}
+}
+
+// getImportPVCName turns a volume's name into a valid PVC name, lowercasing it and replacing the
+// characters a PVC name may not hold, such as underscores, with hyphens.
+func getImportPVCName(internalName string) string {
+
+	name := pvcNameInvalidChars.ReplaceAllString(strings.ToLower(internalName), "-")
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return strings.Trim(name, "-.")
+}
+
+func WriteImportCandidates(candidates []storage.ImportCandidate) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleImportCandidateResponse{Items: candidates})
+	case FormatYAML:
+		WriteYAML(api.MultipleImportCandidateResponse{Items: candidates})
+	case FormatName:
+		writeImportCandidateNames(candidates)
+	default:
+		writeImportCandidateTable(candidates)
+	}
+}
+
+func writeImportCandidateTable(candidates []storage.ImportCandidate) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Size", "Pool", "Protocol", "Junction Path", "LUN"})
+
+	for _, candidate := range candidates {
+
+		candidateSize, _ := strconv.ParseUint(candidate.Size, 10, 64)
+
+		table.Append([]string{
+			candidate.InternalName,
+			humanize.IBytes(candidateSize),
+			candidate.Pool,
+			string(candidate.Protocol),
+			candidate.JunctionPath,
+			candidate.LUN,
+		})
+	}
+
+	table.Render()
+}
+
+func writeImportCandidateNames(candidates []storage.ImportCandidate) {
+
+	for _, candidate := range candidates {
+		fmt.Println(candidate.InternalName)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
+func TestGetImportPVC(t *testing.T) {
+
+	assert.Equal(t, "app-data-01", getImportPVCName("App_Data_01"))
+	assert.Equal(t, "lun.vol", getImportPVCName("_lun.vol_"))
+
+	pvc := getImportPVC(storage.ImportCandidate{InternalName: "app_data", Protocol: config.File}, "gold", "apps")
+	assert.Equal(t, "app-data", pvc.Name)
+	assert.Equal(t, "apps", pvc.Namespace)
+	assert.Equal(t, "gold", *pvc.Spec.StorageClassName)
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}, pvc.Spec.AccessModes)
+
+	pvc = getImportPVC(storage.ImportCandidate{InternalName: "db", Protocol: config.Block}, "gold", "apps")
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, pvc.Spec.AccessModes)
+}
+
+func TestSelectImportCandidates(t *testing.T) {
+
+	candidates := []storage.ImportCandidate{{InternalName: "vol1"}, {InternalName: "vol2"}, {InternalName: "vol3"}}
+
+	selected, err := selectImportCandidates(candidates, nil)
+	assert.NoError(t, err)
+	assert.Len(t, selected, 3)
+
+	selected, err = selectImportCandidates(candidates, []string{"vol3", "vol1"})
+	if assert.NoError(t, err) && assert.Len(t, selected, 2) {
+		assert.Equal(t, "vol3", selected[0].InternalName)
+		assert.Equal(t, "vol1", selected[1].InternalName)
+	}
+
+	_, err = selectImportCandidates(candidates, []string{"vol4"})
+	assert.Error(t, err, "unlisted volume selected")
+}
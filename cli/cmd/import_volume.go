@@ -96,6 +96,23 @@ func getPVCData(filename, b64Data string) ([]byte, error) {
 
 func volumeImport(backendName, internalVolumeName string, noManage bool, pvcDataJSON []byte) error {
 
+	volume, err := importVolume(backendName, internalVolumeName, noManage, pvcDataJSON)
+	if err != nil {
+		return err
+	}
+
+	volumes := make([]storage.VolumeExternal, 0, 10)
+	volumes = append(volumes, *volume)
+	WriteVolumes(volumes)
+
+	return nil
+}
+
+// importVolume asks Trident to import a volume, creating the PVC described by the PVC data.
+func importVolume(
+	backendName, internalVolumeName string, noManage bool, pvcDataJSON []byte,
+) (*storage.VolumeExternal, error) {
+
 	request := &storage.ImportVolumeRequest{
 		Backend:      backendName,
 		InternalName: internalVolumeName,
@@ -105,7 +122,7 @@ func volumeImport(backendName, internalVolumeName string, noManage bool, pvcData
 
 	requestBytes, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Send the request to Trident
@@ -113,21 +130,16 @@ func volumeImport(backendName, internalVolumeName string, noManage bool, pvcData
 
 	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
 	if err != nil {
-		return err
+		return nil, err
 	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("could not import volume: %v", GetErrorFromHTTPResponse(response, responseBody))
+		return nil, fmt.Errorf("could not import volume: %v", GetErrorFromHTTPResponse(response, responseBody))
 	}
 
 	var importVolumeResponse rest.ImportVolumeResponse
 	err = json.Unmarshal(responseBody, &importVolumeResponse)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	volume := *importVolumeResponse.Volume
 
-	volumes := make([]storage.VolumeExternal, 0, 10)
-	volumes = append(volumes, volume)
-	WriteVolumes(volumes)
-
-	return nil
+	return importVolumeResponse.Volume, nil
 }
@@ -38,13 +38,16 @@ const (
 	DefaultPVName      = tridentconfig.OrchestratorName
 
 	// CRD names
-	BackendCRDName      = "tridentbackends.trident.netapp.io"
-	NodeCRDName         = "tridentnodes.trident.netapp.io"
-	StorageClassCRDName = "tridentstorageclasses.trident.netapp.io"
-	TransactionCRDName  = "tridenttransactions.trident.netapp.io"
-	VersionCRDName      = "tridentversions.trident.netapp.io"
-	VolumeCRDName       = "tridentvolumes.trident.netapp.io"
-	SnapshotCRDName     = "tridentsnapshots.trident.netapp.io"
+	BackendCRDName       = "tridentbackends.trident.netapp.io"
+	NodeCRDName          = "tridentnodes.trident.netapp.io"
+	StorageClassCRDName  = "tridentstorageclasses.trident.netapp.io"
+	TransactionCRDName   = "tridenttransactions.trident.netapp.io"
+	VersionCRDName       = "tridentversions.trident.netapp.io"
+	VolumeCRDName        = "tridentvolumes.trident.netapp.io"
+	SnapshotCRDName      = "tridentsnapshots.trident.netapp.io"
+	QuotaCRDName         = "tridentquotas.trident.netapp.io"
+	BackendConfigCRDName = "tridentbackendconfigs.trident.netapp.io"
+	BatchIntentCRDName   = "tridentbatchintents.trident.netapp.io"
 
 	NamespaceFilename          = "trident-namespace.yaml"
 	ServiceAccountFilename     = "trident-serviceaccount.yaml"
@@ -113,6 +116,9 @@ var (
 		VersionCRDName,
 		VolumeCRDName,
 		SnapshotCRDName,
+		QuotaCRDName,
+		BackendConfigCRDName,
+		BatchIntentCRDName,
 	}
 
 	useCRDv1 bool
@@ -121,6 +121,18 @@ func deleteCRs() error {
 		return err
 	}
 
+	if err := deleteQuotas(); err != nil {
+		return err
+	}
+
+	if err := deleteBackendConfigs(); err != nil {
+		return err
+	}
+
+	if err := deleteBatchIntents(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -523,6 +535,155 @@ func deleteSnapshots() error {
 	return nil
 }
 
+func deleteQuotas() error {
+
+	crd := "tridentquotas.trident.netapp.io"
+	logFields := log.Fields{"CRD": crd}
+
+	// See if CRD exists
+	exists, err := kubeClient.CheckCRDExists(crd)
+	if err != nil {
+		return err
+	} else if !exists {
+		log.WithField("CRD", crd).Debug("CRD not present.")
+		return nil
+	}
+
+	quotas, err := crdClientset.TridentV1().TridentQuotas(resetNamespace).List(ctx(), listOpts)
+	if err != nil {
+		return err
+	} else if len(quotas.Items) == 0 {
+		log.WithFields(logFields).Info("Resources not present.")
+		return nil
+	}
+
+	for _, quota := range quotas.Items {
+		if quota.DeletionTimestamp.IsZero() {
+			_ = crdClientset.TridentV1().TridentQuotas(resetNamespace).Delete(ctx(), quota.Name, deleteOpts)
+		}
+	}
+
+	quotas, err = crdClientset.TridentV1().TridentQuotas(resetNamespace).List(ctx(), listOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range quotas.Items {
+		if quota.HasTridentFinalizers() {
+			crCopy := quota.DeepCopy()
+			crCopy.RemoveTridentFinalizers()
+			_, err := crdClientset.TridentV1().TridentQuotas(resetNamespace).Update(ctx(), crCopy, updateOpts)
+			if isNotFoundError(err) {
+				continue
+			} else if err != nil {
+				log.Errorf("Problem removing finalizers: %v", err)
+				return err
+			}
+		}
+
+		deleteFunc := crdClientset.TridentV1().TridentQuotas(resetNamespace).Delete
+		if err := deleteWithRetry(deleteFunc, ctx(), quota.Name, nil); err != nil {
+			log.Errorf("Problem deleting resource: %v", err)
+			return err
+		}
+	}
+
+	log.WithFields(logFields).Info("Resources deleted.")
+	return nil
+}
+
+func deleteBackendConfigs() error {
+
+	crd := "tridentbackendconfigs.trident.netapp.io"
+	logFields := log.Fields{"CRD": crd}
+
+	// See if CRD exists
+	exists, err := kubeClient.CheckCRDExists(crd)
+	if err != nil {
+		return err
+	} else if !exists {
+		log.WithField("CRD", crd).Debug("CRD not present.")
+		return nil
+	}
+
+	backendConfigs, err := crdClientset.TridentV1().TridentBackendConfigs(resetNamespace).List(ctx(), listOpts)
+	if err != nil {
+		return err
+	} else if len(backendConfigs.Items) == 0 {
+		log.WithFields(logFields).Info("Resources not present.")
+		return nil
+	}
+
+	for _, backendConfig := range backendConfigs.Items {
+		if backendConfig.DeletionTimestamp.IsZero() {
+			_ = crdClientset.TridentV1().TridentBackendConfigs(resetNamespace).Delete(ctx(), backendConfig.Name, deleteOpts)
+		}
+	}
+
+	backendConfigs, err = crdClientset.TridentV1().TridentBackendConfigs(resetNamespace).List(ctx(), listOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, backendConfig := range backendConfigs.Items {
+		if backendConfig.HasTridentFinalizers() {
+			crCopy := backendConfig.DeepCopy()
+			crCopy.RemoveTridentFinalizers()
+			_, err := crdClientset.TridentV1().TridentBackendConfigs(resetNamespace).Update(ctx(), crCopy, updateOpts)
+			if isNotFoundError(err) {
+				continue
+			} else if err != nil {
+				log.Errorf("Problem removing finalizers: %v", err)
+				return err
+			}
+		}
+
+		deleteFunc := crdClientset.TridentV1().TridentBackendConfigs(resetNamespace).Delete
+		if err := deleteWithRetry(deleteFunc, ctx(), backendConfig.Name, nil); err != nil {
+			log.Errorf("Problem deleting resource: %v", err)
+			return err
+		}
+	}
+
+	log.WithFields(logFields).Info("Resources deleted.")
+	return nil
+}
+
+func deleteBatchIntents() error {
+
+	crd := "tridentbatchintents.trident.netapp.io"
+	logFields := log.Fields{"CRD": crd}
+
+	// See if CRD exists
+	exists, err := kubeClient.CheckCRDExists(crd)
+	if err != nil {
+		return err
+	} else if !exists {
+		log.WithField("CRD", crd).Debug("CRD not present.")
+		return nil
+	}
+
+	intents, err := crdClientset.TridentV1().TridentBatchIntents(resetNamespace).List(ctx(), listOpts)
+	if err != nil {
+		return err
+	} else if len(intents.Items) == 0 {
+		log.WithFields(logFields).Info("Resources not present.")
+		return nil
+	}
+
+	// Batch intents have no finalizers, so they may simply be deleted
+	for _, intent := range intents.Items {
+		deleteFunc := crdClientset.TridentV1().TridentBatchIntents(resetNamespace).Delete
+		if err := deleteWithRetry(deleteFunc, ctx(), intent.Name, nil); err != nil {
+			log.Errorf("Problem deleting resource: %v", err)
+			return err
+		}
+	}
+
+	log.WithFields(logFields).Info("Resources deleted.")
+	return nil
+}
+
 func deleteCRDs() error {
 
 	crdNames := []string{
@@ -533,6 +694,9 @@ func deleteCRDs() error {
 		"tridentnodes.trident.netapp.io",
 		"tridenttransactions.trident.netapp.io",
 		"tridentsnapshots.trident.netapp.io",
+		"tridentquotas.trident.netapp.io",
+		"tridentbackendconfigs.trident.netapp.io",
+		"tridentbatchintents.trident.netapp.io",
 	}
 
 	for _, crdName := range crdNames {
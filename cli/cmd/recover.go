@@ -0,0 +1,18 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func init() {
+	RootCmd.AddCommand(recoverCmd)
+}
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Recover a deleted resource in Trident",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := discoverOperatingMode(cmd)
+		return err
+	},
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	recoverCmd.AddCommand(recoverVolumeCmd)
+}
+
+var recoverVolumeCmd = &cobra.Command{
+	Use:   "volume <backendName> [<volumeName>]",
+	Short: "Recover a deleted volume from a backend's recovery queue",
+	Long: `Recover a deleted volume from a backend's recovery queue
+
+Backends with soft delete enabled keep deleted volumes in a recovery queue
+until their retention period expires.  Specify only the name of the backend
+to list the volumes in its queue, or also the name that identified the volume
+on the storage (i.e. ONTAP FlexVol) to recover it.  A recovered volume may
+then be imported with 'tridentctl import volume'.`,
+	Aliases: []string{"v"},
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"recover", "volume"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else if len(args) == 1 {
+			return recoverableVolumeList(args[0])
+		} else {
+			return volumeRecover(args[0], args[1])
+		}
+	},
+}
+
+func recoverableVolumeList(backendName string) error {
+
+	url := BaseURL() + "/backend/" + backendName + "/recovery"
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not list the recovery queue of backend %s: %v", backendName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var listResponse rest.ListRecoverableVolumesResponse
+	if err = json.Unmarshal(responseBody, &listResponse); err != nil {
+		return err
+	}
+
+	volumes := make([]storage.RecoverableVolume, 0, len(listResponse.Volumes))
+	for _, volume := range listResponse.Volumes {
+		volumes = append(volumes, *volume)
+	}
+	WriteRecoverableVolumes(volumes)
+
+	return nil
+}
+
+func volumeRecover(backendName, volumeName string) error {
+
+	request := storage.RecoverVolumeRequest{
+		InternalName: volumeName,
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	url := BaseURL() + "/backend/" + backendName + "/recovery"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not recover volume %s: %v", volumeName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	fmt.Printf("Volume %s recovered on backend %s.\n", volumeName, backendName)
+
+	return nil
+}
+
+func WriteRecoverableVolumes(volumes []storage.RecoverableVolume) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(api.MultipleRecoverableVolumeResponse{Items: volumes})
+	case FormatYAML:
+		WriteYAML(api.MultipleRecoverableVolumeResponse{Items: volumes})
+	case FormatName:
+		writeRecoverableVolumeNames(volumes)
+	default:
+		writeRecoverableVolumeTable(volumes)
+	}
+}
+
+func writeRecoverableVolumeTable(volumes []storage.RecoverableVolume) {
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Queued Name", "Expiration Time"})
+
+	for _, volume := range volumes {
+		table.Append([]string{
+			volume.InternalName,
+			volume.QueuedName,
+			volume.ExpirationTime,
+		})
+	}
+
+	table.Render()
+}
+
+func writeRecoverableVolumeNames(volumes []storage.RecoverableVolume) {
+
+	for _, volume := range volumes {
+		fmt.Println(volume.InternalName)
+	}
+}
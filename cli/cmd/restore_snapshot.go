@@ -0,0 +1,176 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	restoreDryRun bool
+	restoreYes    bool
+)
+
+const restoreConfirmation = "Are you sure you want to restore volume %s from snapshot %s, losing any data " +
+	"written to it since?"
+
+func init() {
+	restoreCmd.AddCommand(restoreSnapshotCmd)
+	restoreSnapshotCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false,
+		"Show the operations that would be performed instead of restoring the volume")
+	restoreSnapshotCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "Restore without confirmation")
+}
+
+var restoreSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <volume/snapshot>",
+	Short: "Restore a volume in place from one of its snapshots",
+	Long: `Restore a volume in place from one of its snapshots
+
+Reverts the volume to its contents when the snapshot was created, discarding
+any data written to it since.  The volume must not be published to any node,
+so stop the pods using it first.  Some backends, such as ONTAP, delete the
+snapshots of the volume newer than the one restored; Trident forgets those
+snapshots once the restore is done.  The operations to be performed are shown
+and must be confirmed unless --yes is given, and --dry-run shows them without
+restoring the volume.`,
+	Aliases: []string{"s", "snap"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		volumeName, snapshotName, err := storage.ParseSnapshotID(args[0])
+		if err != nil {
+			return err
+		}
+
+		if OperatingMode == ModeTunnel {
+			command := []string{"restore", "snapshot", args[0]}
+			if !restoreDryRun && !restoreYes {
+				if restoreYes, err = getTunneledConfirmation(command,
+					fmt.Sprintf(restoreConfirmation, volumeName, snapshotName)); err != nil {
+					return err
+				} else if !restoreYes {
+					return errors.New("restore canceled")
+				}
+			}
+			TunnelCommand(append(command, "--dry-run="+strconv.FormatBool(restoreDryRun),
+				"--yes="+strconv.FormatBool(restoreYes)))
+			return nil
+		} else {
+			return snapshotRestore(volumeName, snapshotName)
+		}
+	},
+}
+
+func snapshotRestore(volumeName, snapshotName string) error {
+
+	snapshot, err := GetSnapshot(storage.MakeSnapshotID(volumeName, snapshotName))
+	if err != nil {
+		return err
+	}
+	volumeSnapshots, err := getVolumeSnapshots(volumeName)
+	if err != nil {
+		return err
+	}
+
+	if restoreDryRun || !restoreYes {
+		writeOperations(getSnapshotRestoreOperations(snapshot, volumeSnapshots))
+	}
+	if restoreDryRun {
+		return nil
+	}
+	if !restoreYes {
+		if restoreYes, err = getUserConfirmation(
+			fmt.Sprintf(restoreConfirmation, volumeName, snapshotName)); err != nil {
+			return err
+		} else if !restoreYes {
+			return errors.New("restore canceled")
+		}
+	}
+
+	url := BaseURL() + "/snapshot/" + snapshot.ID() + "/restore"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not restore volume %s from snapshot %s: %v", volumeName, snapshotName,
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var restoreResponse rest.GetSnapshotResponse
+	if err = json.Unmarshal(responseBody, &restoreResponse); err != nil {
+		return err
+	}
+
+	WriteSnapshots([]storage.SnapshotExternal{*restoreResponse.Snapshot})
+
+	return nil
+}
+
+// getVolumeSnapshots reads all the snapshots of a volume.
+func getVolumeSnapshots(volumeName string) ([]storage.SnapshotExternal, error) {
+
+	snapshotIDs, err := GetSnapshots(volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]storage.SnapshotExternal, 0, len(snapshotIDs))
+	for _, snapshotID := range snapshotIDs {
+		snapshot, err := GetSnapshot(snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// getSnapshotRestoreOperations describes the operations performed to restore a volume from a snapshot,
+// including the deletion of the volume's newer snapshots by backends that discard them.
+func getSnapshotRestoreOperations(
+	snapshot storage.SnapshotExternal, volumeSnapshots []storage.SnapshotExternal,
+) []string {
+
+	operations := []string{fmt.Sprintf("Restore volume %s from snapshot %s, created %s.",
+		snapshot.Config.VolumeName, snapshot.Config.Name, snapshot.Created)}
+
+	// Creation times are in RFC3339 format, so they sort as strings
+	newerSnapshots := make([]storage.SnapshotExternal, 0)
+	for _, volumeSnapshot := range volumeSnapshots {
+		if volumeSnapshot.Created > snapshot.Created {
+			newerSnapshots = append(newerSnapshots, volumeSnapshot)
+		}
+	}
+	sort.Slice(newerSnapshots, func(i, j int) bool {
+		return newerSnapshots[i].Created < newerSnapshots[j].Created
+	})
+
+	for _, newerSnapshot := range newerSnapshots {
+		operations = append(operations, fmt.Sprintf(
+			"Delete snapshot %s, created %s, if the backend discards snapshots newer than %s.",
+			newerSnapshot.Config.Name, newerSnapshot.Created, snapshot.Config.Name))
+	}
+
+	return operations
+}
+
+// writeOperations lists the operations a command will perform.
+func writeOperations(operations []string) {
+
+	fmt.Println("The following operations will be performed:")
+	for _, operation := range operations {
+		fmt.Printf("  - %s\n", operation)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestGetSnapshotRestoreOperations(t *testing.T) {
+
+	snapshot := func(name, created string) storage.SnapshotExternal {
+		return storage.SnapshotExternal{Snapshot: storage.Snapshot{
+			Config:  &storage.SnapshotConfig{Name: name, VolumeName: "pvc-1"},
+			Created: created,
+		}}
+	}
+	restored := snapshot("snap2", "2020-05-02T10:00:00Z")
+	volumeSnapshots := []storage.SnapshotExternal{
+		snapshot("snap4", "2020-05-04T10:00:00Z"),
+		snapshot("snap1", "2020-05-01T10:00:00Z"),
+		restored,
+		snapshot("snap3", "2020-05-03T10:00:00Z"),
+	}
+
+	operations := getSnapshotRestoreOperations(restored, volumeSnapshots)
+	if assert.Len(t, operations, 3, "older snapshots listed") {
+		assert.Contains(t, operations[0], "Restore volume pvc-1 from snapshot snap2")
+		assert.Contains(t, operations[1], "Delete snapshot snap3")
+		assert.Contains(t, operations[2], "Delete snapshot snap4")
+	}
+}
@@ -569,6 +569,21 @@ func getUserConfirmation(s string) (bool, error) {
 	}
 }
 
+// getTunneledConfirmation shows the operations a tunneled command would perform, by running it in the
+// Trident pod with --dry-run, and then asks the user to confirm them.  The command must then be tunneled
+// with its own confirmation flag, since it cannot prompt from within the pod.
+func getTunneledConfirmation(commandArgs []string, s string) (bool, error) {
+
+	output, err := TunnelCommandRaw(append(commandArgs, "--dry-run"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s", string(output))
+		return false, err
+	}
+	fmt.Print(string(output))
+
+	return getUserConfirmation(s)
+}
+
 func homeDir() string {
 	if h := os.Getenv("HOME"); h != "" {
 		return h
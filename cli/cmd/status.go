@@ -0,0 +1,112 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/core"
+)
+
+var liveOnly bool
+
+func init() {
+	RootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&liveOnly, "live", false, "Check only that Trident is live, not that it is ready.")
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the health of Trident and its subsystems",
+	Long: `Show the health of Trident and its subsystems
+
+Reports whether Trident is ready to serve requests, with the health of its
+core, persistent store, CSI server, and each backend's storage system.  An
+unreachable backend leaves Trident degraded but ready.  With --live, reports
+only whether Trident is running and serving its frontends.  The exit code is
+non-zero if Trident is not ready, or not live, so the command may be used as
+a Kubernetes probe.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return discoverOperatingMode(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"status", "--live=" + strconv.FormatBool(liveOnly)}
+			TunnelCommand(command)
+			return nil
+		} else {
+			return status(liveOnly)
+		}
+	},
+}
+
+func status(live bool) error {
+
+	url := fmt.Sprintf("http://%s%s", Server, config.ReadinessURL)
+	if live {
+		url = fmt.Sprintf("http://%s%s", Server, config.LivenessURL)
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("GET", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusServiceUnavailable {
+		return fmt.Errorf("could not get status: %v", GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var report core.HealthReport
+	if err = json.Unmarshal(responseBody, &report); err != nil {
+		return err
+	}
+
+	WriteHealthReport(&report)
+
+	if report.Status == core.HealthFailed {
+		if live {
+			return fmt.Errorf("%s is not live", config.OrchestratorName)
+		}
+		return fmt.Errorf("%s is not ready", config.OrchestratorName)
+	}
+
+	return nil
+}
+
+func WriteHealthReport(report *core.HealthReport) {
+	switch OutputFormat {
+	case FormatJSON:
+		WriteJSON(report)
+	case FormatYAML:
+		WriteYAML(report)
+	default:
+		writeHealthReportTable(report)
+	}
+}
+
+func writeHealthReportTable(report *core.HealthReport) {
+
+	fmt.Printf("Status: %s\n", report.Status)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Subsystem", "Status", "Critical", "Checked", "Message"})
+
+	for _, subsystem := range report.Subsystems {
+		table.Append([]string{
+			subsystem.Name,
+			subsystem.Status,
+			strconv.FormatBool(subsystem.Critical),
+			subsystem.Checked,
+			subsystem.Message,
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,262 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/config"
+)
+
+const (
+	supportBundleFilenameFormat = "support-bundle-2006-01-02T15-04-05-MST.zip"
+
+	redactedValue = "<REDACTED>"
+)
+
+// redactedKeys are the substrings of the names of the config fields whose values are left out of a
+// support bundle, compared in lower case.
+var redactedKeys = []string{
+	"password", "secret", "privatekey", "private_key", "apikey", "token", "username", "credentials",
+}
+
+func init() {
+	RootCmd.AddCommand(supportBundleCmd)
+}
+
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Gather Trident's logs and state into an archive for a support case",
+	Long: `Gather Trident's logs and state into an archive for a support case
+
+Writes a zip archive, named for the current time, holding the current and
+previous logs of the Trident controller, node, and sidecar containers; the
+configs of the backends, with their passwords, secrets, and other credentials
+redacted; Trident's volumes, snapshots, storage classes, and nodes; the recent
+entries of each backend's storage system logs, such as the jobs and EMS events
+of an ONTAP backend; and the iSCSI sessions and multipath devices of each node.
+Anything that can't be gathered is noted in the archive's errors file.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return discoverOperatingMode(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return supportBundle()
+	},
+}
+
+func supportBundle() error {
+
+	if OperatingMode != ModeTunnel {
+		return errors.New("'tridentctl support-bundle' only supports Trident running in a Kubernetes pod")
+	}
+
+	// Create archive file.
+	zipFileName = time.Now().Format(supportBundleFilenameFormat)
+	zipFile, err := os.Create(zipFileName)
+	if err != nil {
+		return err
+	}
+	defer zipFile.Close()
+
+	zipWriter = zip.NewWriter(zipFile)
+	defer zipWriter.Close()
+
+	// Gather the logs of every Trident container as 'tridentctl logs --archive' does
+	archive = true
+	logType = logTypeAll
+	previous = true
+	sidecars = true
+	node = ""
+	if err = getLogs(); err != nil {
+		logErrors = appendError(logErrors, []byte(err.Error()))
+	}
+
+	backendNames := getSupportBundleBackends()
+	getSupportBundleObjects()
+	for _, backendName := range backendNames {
+		getSupportBundleTunneledEntry(fmt.Sprintf("backend-logs-%s.json", backendName),
+			[]string{"get", "backend", "logs", backendName, "-o", "json"})
+	}
+	getSupportBundleNodeState()
+
+	if len(logErrors) > 0 {
+		if err = writeSupportBundleEntry("errors", logErrors); err != nil {
+			return err
+		}
+	}
+
+	// Failures to gather anything are recorded in the archive, so the bundle itself succeeded
+	SetExitCodeFromError(nil)
+
+	return nil
+}
+
+// writeSupportBundleEntry adds a file to the support bundle archive.
+func writeSupportBundleEntry(name string, contents []byte) error {
+
+	entry, err := zipWriter.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err = entry.Write(contents); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s to %s archive file.\n", name, zipFileName)
+	return nil
+}
+
+// getSupportBundleTunneledEntry runs a tridentctl command in the Trident pod and adds its output to the
+// support bundle, recording the output as an error instead if the command fails.
+func getSupportBundleTunneledEntry(name string, commandArgs []string) {
+
+	output, err := TunnelCommandRaw(commandArgs)
+	if err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not get %s; %s", name,
+			strings.TrimSpace(string(output)))))
+		return
+	}
+	if err = writeSupportBundleEntry(name, output); err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not write %s; %v", name, err)))
+	}
+}
+
+// getSupportBundleBackends adds the redacted configs of the backends to the support bundle and returns
+// the names of the backends.
+func getSupportBundleBackends() []string {
+
+	output, err := TunnelCommandRaw([]string{"get", "backend", "-o", "json"})
+	if err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not get backends; %s",
+			strings.TrimSpace(string(output)))))
+		return nil
+	}
+
+	var backends map[string]interface{}
+	if err = json.Unmarshal(output, &backends); err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not parse backends; %v", err)))
+		return nil
+	}
+
+	backendsJSON, err := json.MarshalIndent(redactSecrets(backends), "", "  ")
+	if err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not redact backends; %v", err)))
+		return nil
+	}
+	if err = writeSupportBundleEntry("backends.json", backendsJSON); err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not write backends.json; %v", err)))
+	}
+
+	// The names are read from the output again, as the redacted copy is not typed
+	var backendsResponse api.MultipleBackendResponse
+	if err = json.Unmarshal(output, &backendsResponse); err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not parse backends; %v", err)))
+		return nil
+	}
+	backendNames := make([]string, 0, len(backendsResponse.Items))
+	for _, backend := range backendsResponse.Items {
+		backendNames = append(backendNames, backend.Name)
+	}
+	sort.Strings(backendNames)
+
+	return backendNames
+}
+
+// getSupportBundleObjects adds Trident's version and its volumes, snapshots, storage classes, and nodes
+// to the support bundle.
+func getSupportBundleObjects() {
+
+	getSupportBundleTunneledEntry("version.json", []string{"version", "-o", "json"})
+	getSupportBundleTunneledEntry("volumes.json", []string{"get", "volume", "-o", "json"})
+	getSupportBundleTunneledEntry("snapshots.json", []string{"get", "snapshot", "-o", "json"})
+	getSupportBundleTunneledEntry("storageclasses.json", []string{"get", "storageclass", "-o", "json"})
+	getSupportBundleTunneledEntry("nodes.json", []string{"get", "node", "-o", "json"})
+}
+
+// getSupportBundleNodeState adds the iSCSI sessions and multipath devices of each node, as seen from
+// its Trident node pod, to the support bundle.  A command's output is kept even if it fails, as
+// iscsiadm fails on nodes without any iSCSI sessions.
+func getSupportBundleNodeState() {
+
+	tridentNodes, err := listTridentNodes(TridentPodNamespace)
+	if err != nil {
+		logErrors = appendError(logErrors, []byte(fmt.Sprintf("error listing trident node pods; %v", err)))
+		return
+	}
+
+	nodeNames := make([]string, 0, len(tridentNodes))
+	for nodeName := range tridentNodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		for name, nodeCommand := range map[string][]string{
+			"iscsi-" + nodeName:     {"iscsiadm", "-m", "session", "-P", "3"},
+			"multipath-" + nodeName: {"multipath", "-ll"},
+		} {
+			execCommand := []string{"exec", tridentNodes[nodeName], "-n", TridentPodNamespace, "-c",
+				config.ContainerTrident, "--"}
+			execCommand = append(execCommand, nodeCommand...)
+
+			if Debug {
+				fmt.Printf("Invoking command: %s %v\n", KubernetesCLI, strings.Join(execCommand, " "))
+			}
+
+			output, err := exec.Command(KubernetesCLI, execCommand...).CombinedOutput()
+			if err != nil {
+				logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not get %s; %v", name, err)))
+			}
+			if err = writeSupportBundleEntry(name, output); err != nil {
+				logErrors = appendError(logErrors, []byte(fmt.Sprintf("could not write %s; %v", name, err)))
+			}
+		}
+	}
+}
+
+// redactSecrets returns a copy of a decoded JSON value in which the values of the fields whose names
+// hold a password, secret, or other credential are replaced.
+func redactSecrets(value interface{}) interface{} {
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(typedValue))
+		for key, fieldValue := range typedValue {
+			if isRedactedKey(key) {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactSecrets(fieldValue)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, 0, len(typedValue))
+		for _, element := range typedValue {
+			redacted = append(redacted, redactSecrets(element))
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func isRedactedKey(key string) bool {
+
+	lowerKey := strings.ToLower(key)
+	for _, redactedKey := range redactedKeys {
+		if strings.Contains(lowerKey, redactedKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSecrets(t *testing.T) {
+
+	backendsJSON := `{"items": [{"name": "ontap", "config": {"storageDriverName": "ontap-nas",
+		"username": "admin", "password": "secret!", "clientPrivateKey": "key", "chapInitiatorSecret": "chap",
+		"managementLIF": "10.0.0.1", "storage": [{"labels": {"tier": "gold"}, "apiToken": "token"}]}}]}`
+
+	var backends interface{}
+	if err := json.Unmarshal([]byte(backendsJSON), &backends); err != nil {
+		t.Fatalf("could not parse backends; %v", err)
+	}
+
+	redacted := redactSecrets(backends).(map[string]interface{})
+	backend := redacted["items"].([]interface{})[0].(map[string]interface{})
+	backendConfig := backend["config"].(map[string]interface{})
+
+	assert.Equal(t, "ontap", backend["name"])
+	assert.Equal(t, "ontap-nas", backendConfig["storageDriverName"])
+	assert.Equal(t, "10.0.0.1", backendConfig["managementLIF"])
+	for _, key := range []string{"username", "password", "clientPrivateKey", "chapInitiatorSecret"} {
+		assert.Equal(t, redactedValue, backendConfig[key], key)
+	}
+
+	pool := backendConfig["storage"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, redactedValue, pool["apiToken"])
+	assert.Equal(t, map[string]interface{}{"tier": "gold"}, pool["labels"])
+
+	// The value passed in is left as it was
+	original := backends.(map[string]interface{})["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "secret!", original["config"].(map[string]interface{})["password"])
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	backendTraceFlags []string
+	backendLogLevel   string
+)
+
+func init() {
+	updateBackendCmd.AddCommand(updateBackendLoggingCmd)
+	updateBackendLoggingCmd.Flags().StringSliceVar(&backendTraceFlags, "trace-flags", nil,
+		"Debug trace flags to turn on, such as method and api; all others are turned off")
+	updateBackendLoggingCmd.Flags().StringVar(&backendLogLevel, "log-level", "",
+		"Level at which to log the backend's storage API calls (debug, info, warn, error, or default)")
+}
+
+var updateBackendLoggingCmd = &cobra.Command{
+	Use:   "logging <name> [--trace-flags=<flag>,...] [--log-level=<level>]",
+	Short: "Change the debug trace flags and storage API log level of a backend",
+	Long: `Change the debug trace flags and storage API log level of a backend
+
+Replaces the backend's debug trace flags with those listed, turning off any not
+listed, and sets the level at which messages about its storage API calls,
+including API traces, are logged, whatever Trident's log level.  A log level of
+'default' logs them at Trident's level again.  The settings last until the
+backend is updated or Trident restarts.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		traceFlagsChanged := cmd.Flags().Changed("trace-flags")
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "backend", "logging", "--log-level=" + backendLogLevel}
+			if traceFlagsChanged {
+				command = append(command, "--trace-flags="+strings.Join(backendTraceFlags, ","))
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendUpdateLogging(args, traceFlagsChanged)
+		}
+	},
+}
+
+func backendUpdateLogging(backendNames []string, traceFlagsChanged bool) error {
+
+	switch len(backendNames) {
+	case 0:
+		return errors.New("backend name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple backend names specified")
+	}
+
+	request := storage.UpdateBackendLoggingRequest{
+		LogLevel: backendLogLevel,
+	}
+	if traceFlagsChanged {
+		request.DebugTraceFlags = make(map[string]bool)
+		for _, flag := range backendTraceFlags {
+			if flag != "" {
+				request.DebugTraceFlags[flag] = true
+			}
+		}
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Send the new logging settings to Trident
+	url := BaseURL() + "/backend/" + backendNames[0] + "/logging"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not update logging for backend %s: %v", backendNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var updateBackendResponse rest.UpdateBackendResponse
+	err = json.Unmarshal(responseBody, &updateBackendResponse)
+	if err != nil {
+		return err
+	}
+
+	backends := make([]storage.BackendExternal, 0, 1)
+	backendName := updateBackendResponse.BackendID
+
+	// Retrieve the updated backend and write to stdout
+	backend, err := GetBackend(backendName)
+	if err != nil {
+		return err
+	}
+	backends = append(backends, backend)
+
+	WriteBackends(backends)
+
+	return nil
+}
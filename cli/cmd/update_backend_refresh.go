@@ -0,0 +1,76 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+func init() {
+	updateBackendCmd.AddCommand(updateBackendRefreshCmd)
+}
+
+var updateBackendRefreshCmd = &cobra.Command{
+	Use:   "refresh <name>",
+	Short: "Rediscover the storage pools of a backend",
+	Long: `Rediscover the storage pools of a backend
+
+Trident rediscovers the storage pools of ONTAP backends every 15 minutes, so
+that aggregates assigned to or removed from an SVM are used without restarting
+Trident.  This command rediscovers them right away and matches the storage
+classes against the backend's new pools.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "backend", "refresh"}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendRefresh(args)
+		}
+	},
+}
+
+func backendRefresh(backendNames []string) error {
+
+	switch len(backendNames) {
+	case 0:
+		return errors.New("backend name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple backend names specified")
+	}
+
+	url := BaseURL() + "/backend/" + backendNames[0] + "/refresh"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, nil, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not refresh storage pools for backend %s: %v", backendNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var updateBackendResponse rest.UpdateBackendResponse
+	if err = json.Unmarshal(responseBody, &updateBackendResponse); err != nil {
+		return err
+	}
+
+	// Retrieve the refreshed backend and write to stdout
+	backend, err := GetBackend(updateBackendResponse.BackendID)
+	if err != nil {
+		return err
+	}
+	WriteBackends([]storage.BackendExternal{backend})
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var backendAPITrace bool
+
+func init() {
+	updateBackendCmd.AddCommand(updateBackendTraceCmd)
+	updateBackendTraceCmd.Flags().BoolVarP(&backendAPITrace, "enabled", "", true,
+		"Trace the backend's storage API calls")
+}
+
+var updateBackendTraceCmd = &cobra.Command{
+	Use:   "trace <name> [--enabled=false]",
+	Short: "Turn tracing of a backend's storage API calls on or off",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{
+				"update", "backend", "trace", "--enabled=" + strconv.FormatBool(backendAPITrace),
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return backendUpdateAPITrace(args, backendAPITrace)
+		}
+	},
+}
+
+func backendUpdateAPITrace(backendNames []string, enabled bool) error {
+
+	switch len(backendNames) {
+	case 0:
+		return errors.New("backend name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple backend names specified")
+	}
+
+	// Send the new trace setting to Trident
+	url := BaseURL() + "/backend/" + backendNames[0] + "/trace"
+
+	request := storage.UpdateBackendAPITraceRequest{
+		Enabled: enabled,
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not update API tracing for backend %s: %v", backendNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var updateBackendResponse rest.UpdateBackendResponse
+	err = json.Unmarshal(responseBody, &updateBackendResponse)
+	if err != nil {
+		return err
+	}
+
+	backends := make([]storage.BackendExternal, 0, 1)
+	backendName := updateBackendResponse.BackendID
+
+	// Retrieve the updated backend and write to stdout
+	backend, err := GetBackend(backendName)
+	if err != nil {
+		return err
+	}
+	backends = append(backends, backend)
+
+	WriteBackends(backends)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var (
+	updateSnapshotDir        string
+	updateTieringCoolingDays string
+	updateUnixPermissions    string
+	updateSecurityStyle      string
+	updateExportPolicy       string
+	updateSnapshotPolicy     string
+	updateCloneToNamespaces  string
+)
+
+func init() {
+	updateCmd.AddCommand(updateVolumeCmd)
+	updateVolumeCmd.Flags().StringVarP(&updateSnapshotDir, "snapshot-dir", "", "",
+		"Whether the volume's snapshot directory is visible (true or false)")
+	updateVolumeCmd.Flags().StringVarP(&updateTieringCoolingDays, "tiering-minimum-cooling-days", "", "",
+		"Days the volume's data must be cold before it is tiered (2-183)")
+	updateVolumeCmd.Flags().StringVarP(&updateUnixPermissions, "unix-permissions", "", "",
+		"UNIX permissions of the volume, in octal")
+	updateVolumeCmd.Flags().StringVarP(&updateSecurityStyle, "security-style", "", "",
+		"Security style of the volume (unix or mixed)")
+	updateVolumeCmd.Flags().StringVarP(&updateExportPolicy, "export-policy", "", "",
+		"Export policy of the volume")
+	updateVolumeCmd.Flags().StringVarP(&updateSnapshotPolicy, "snapshot-policy", "", "",
+		"Snapshot policy of the volume")
+	updateVolumeCmd.Flags().StringVarP(&updateCloneToNamespaces, "clone-to-namespaces", "", "",
+		"Comma-separated namespaces permitted to clone the volume, or * for all; empty permits none")
+}
+
+var updateVolumeCmd = &cobra.Command{
+	Use:     "volume <name>",
+	Short:   "Update a volume in Trident",
+	Aliases: []string{"v"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "volume"}
+			if updateSnapshotDir != "" {
+				command = append(command, "--snapshot-dir", updateSnapshotDir)
+			}
+			if updateTieringCoolingDays != "" {
+				command = append(command, "--tiering-minimum-cooling-days", updateTieringCoolingDays)
+			}
+			if updateUnixPermissions != "" {
+				command = append(command, "--unix-permissions", updateUnixPermissions)
+			}
+			if updateSecurityStyle != "" {
+				command = append(command, "--security-style", updateSecurityStyle)
+			}
+			if updateExportPolicy != "" {
+				command = append(command, "--export-policy", updateExportPolicy)
+			}
+			if updateSnapshotPolicy != "" {
+				command = append(command, "--snapshot-policy", updateSnapshotPolicy)
+			}
+			if cmd.Flags().Changed("clone-to-namespaces") {
+				command = append(command, "--clone-to-namespaces", updateCloneToNamespaces)
+			}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeUpdate(args, cmd.Flags().Changed("clone-to-namespaces"))
+		}
+	},
+}
+
+func volumeUpdate(volumeNames []string, cloneToNamespacesSet bool) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+
+	request := storage.UpdateVolumeRequest{
+		SnapshotDirectory:         updateSnapshotDir,
+		TieringMinimumCoolingDays: updateTieringCoolingDays,
+		UnixPermissions:           updateUnixPermissions,
+		SecurityStyle:             updateSecurityStyle,
+		ExportPolicy:              updateExportPolicy,
+		SnapshotPolicy:            updateSnapshotPolicy,
+	}
+	if cloneToNamespacesSet {
+		request.CloneToNamespaces = &updateCloneToNamespaces
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Send the update to Trident
+	url := BaseURL() + "/volume/" + volumeNames[0]
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not update volume %s: %v", volumeNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var updateVolumeResponse rest.UpdateVolumeResponse
+	if err = json.Unmarshal(responseBody, &updateVolumeResponse); err != nil {
+		return err
+	}
+
+	volumes := []storage.VolumeExternal{*updateVolumeResponse.Volume}
+	WriteVolumes(volumes)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var migrateBackend string
+
+func init() {
+	updateVolumeCmd.AddCommand(updateVolumeMigrateCmd)
+	updateVolumeMigrateCmd.Flags().StringVarP(&migrateBackend, "to-backend", "", "",
+		"Backend to migrate the volume to")
+}
+
+var updateVolumeMigrateCmd = &cobra.Command{
+	Use:   "migrate <name> --to-backend <backend>",
+	Short: "Migrate a volume to another backend",
+	Long: `Migrate a volume to another backend
+
+Copies the volume to a pool of the backend in the volume's storage class,
+switches the volume to the copy, and deletes the volume from its old backend.
+The migration continues in the background; its progress is shown by
+'tridentctl get volume <name> -o yaml'.  The volume must not be in use when
+the migration begins, and cannot be used until it ends.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "volume", "migrate", "--to-backend", migrateBackend}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeMigrate(args)
+		}
+	},
+}
+
+func volumeMigrate(volumeNames []string) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+
+	request := storage.MigrateVolumeRequest{
+		Backend: migrateBackend,
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Ask Trident to begin the migration
+	url := BaseURL() + "/volume/" + volumeNames[0] + "/migrate"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not migrate volume %s: %v", volumeNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var migrateVolumeResponse rest.UpdateVolumeResponse
+	if err = json.Unmarshal(responseBody, &migrateVolumeResponse); err != nil {
+		return err
+	}
+
+	volumes := []storage.VolumeExternal{*migrateVolumeResponse.Volume}
+	WriteVolumes(volumes)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netapp/trident/cli/api"
+	"github.com/netapp/trident/frontend/rest"
+	"github.com/netapp/trident/storage"
+)
+
+var movePool string
+
+func init() {
+	updateVolumeCmd.AddCommand(updateVolumeMoveCmd)
+	updateVolumeMoveCmd.Flags().StringVarP(&movePool, "pool", "", "", "Pool to move the volume to")
+}
+
+var updateVolumeMoveCmd = &cobra.Command{
+	Use:     "move <name> --pool <pool>",
+	Short:   "Move a volume to another pool of its backend",
+	Aliases: []string{"m"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if OperatingMode == ModeTunnel {
+			command := []string{"update", "volume", "move", "--pool", movePool}
+			TunnelCommand(append(command, args...))
+			return nil
+		} else {
+			return volumeMove(args)
+		}
+	},
+}
+
+func volumeMove(volumeNames []string) error {
+
+	switch len(volumeNames) {
+	case 0:
+		return errors.New("volume name not specified")
+	case 1:
+		break
+	default:
+		return errors.New("multiple volume names specified")
+	}
+
+	request := storage.MoveVolumeRequest{
+		Pool: movePool,
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// Ask Trident to begin the move
+	url := BaseURL() + "/volume/" + volumeNames[0] + "/move"
+
+	response, responseBody, err := api.InvokeRESTAPI("POST", url, requestBytes, Debug)
+	if err != nil {
+		return err
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not move volume %s: %v", volumeNames[0],
+			GetErrorFromHTTPResponse(response, responseBody))
+	}
+
+	var moveVolumeResponse rest.UpdateVolumeResponse
+	if err = json.Unmarshal(responseBody, &moveVolumeResponse); err != nil {
+		return err
+	}
+
+	volumes := []storage.VolumeExternal{*moveVolumeResponse.Volume}
+	WriteVolumes(volumes)
+
+	return nil
+}
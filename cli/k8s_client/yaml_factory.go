@@ -114,7 +114,7 @@ rules:
     resources: ["customresourcedefinitions"]
     verbs: ["get", "list", "watch"]
   - apiGroups: ["trident.netapp.io"]
-    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots"]
+    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots", "tridentquotas", "tridentbackendconfigs", "tridentbatchintents"]
     verbs: ["get", "list", "watch", "create", "delete", "update", "patch"]
   - apiGroups: ["policy"]
     resources: ["podsecuritypolicies"]
@@ -174,7 +174,7 @@ rules:
     resources: ["customresourcedefinitions"]
     verbs: ["get", "list", "watch", "create", "delete", "update", "patch"]
   - apiGroups: ["trident.netapp.io"]
-    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots"]
+    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots", "tridentquotas", "tridentbackendconfigs", "tridentbatchintents"]
     verbs: ["get", "list", "watch", "create", "delete", "update", "patch"]
   - apiGroups: ["policy"]
     resources: ["podsecuritypolicies"]
@@ -291,11 +291,23 @@ spec:
             - tridentctl
             - -s
             - 127.0.0.1:8000
-            - version
+            - status
+            - --live
           failureThreshold: 2
           initialDelaySeconds: 120
           periodSeconds: 120
           timeoutSeconds: 90
+        readinessProbe:
+          exec:
+            command:
+            - tridentctl
+            - -s
+            - 127.0.0.1:8000
+            - status
+          failureThreshold: 3
+          initialDelaySeconds: 10
+          periodSeconds: 30
+          timeoutSeconds: 20
       {IMAGE_PULL_SECRETS}
       nodeSelector:
         beta.kubernetes.io/os: linux
@@ -424,11 +436,23 @@ spec:
             - tridentctl
             - -s
             - "{IP_LOCALHOST}:8000"
-            - version
+            - status
+            - --live
           failureThreshold: 2
           initialDelaySeconds: 120
           periodSeconds: 120
           timeoutSeconds: 90
+        readinessProbe:
+          exec:
+            command:
+            - tridentctl
+            - -s
+            - "{IP_LOCALHOST}:8000"
+            - status
+          failureThreshold: 3
+          initialDelaySeconds: 10
+          periodSeconds: 30
+          timeoutSeconds: 20
         env:
         - name: KUBE_NODE_NAME
           valueFrom:
@@ -540,11 +564,23 @@ spec:
             - tridentctl
             - -s
             - "{IP_LOCALHOST}:8000"
-            - version
+            - status
+            - --live
           failureThreshold: 2
           initialDelaySeconds: 120
           periodSeconds: 120
           timeoutSeconds: 90
+        readinessProbe:
+          exec:
+            command:
+            - tridentctl
+            - -s
+            - "{IP_LOCALHOST}:8000"
+            - status
+          failureThreshold: 3
+          initialDelaySeconds: 10
+          periodSeconds: 30
+          timeoutSeconds: 20
         env:
         - name: KUBE_NODE_NAME
           valueFrom:
@@ -567,6 +603,7 @@ spec:
         - "--v={LOG_LEVEL}"
         - "--timeout=600s"
         - "--csi-address=$(ADDRESS)"
+        - "--feature-gates=Topology=true"
         env:
         - name: ADDRESS
           value: /var/lib/csi/sockets/pluginproxy/csi.sock
@@ -644,11 +681,23 @@ spec:
             - tridentctl
             - -s
             - "{IP_LOCALHOST}:8000"
-            - version
+            - status
+            - --live
           failureThreshold: 2
           initialDelaySeconds: 120
           periodSeconds: 120
           timeoutSeconds: 90
+        readinessProbe:
+          exec:
+            command:
+            - tridentctl
+            - -s
+            - "{IP_LOCALHOST}:8000"
+            - status
+          failureThreshold: 3
+          initialDelaySeconds: 10
+          periodSeconds: 30
+          timeoutSeconds: 20
         env:
         - name: KUBE_NODE_NAME
           valueFrom:
@@ -671,6 +720,7 @@ spec:
         - "--v={LOG_LEVEL}"
         - "--timeout=600s"
         - "--csi-address=$(ADDRESS)"
+        - "--feature-gates=Topology=true"
         env:
         - name: ADDRESS
           value: /var/lib/csi/sockets/pluginproxy/csi.sock
@@ -760,11 +810,23 @@ spec:
             - tridentctl
             - -s
             - "{IP_LOCALHOST}:8000"
-            - version
+            - status
+            - --live
           failureThreshold: 2
           initialDelaySeconds: 120
           periodSeconds: 120
           timeoutSeconds: 90
+        readinessProbe:
+          exec:
+            command:
+            - tridentctl
+            - -s
+            - "{IP_LOCALHOST}:8000"
+            - status
+          failureThreshold: 3
+          initialDelaySeconds: 10
+          periodSeconds: 30
+          timeoutSeconds: 20
         env:
         - name: KUBE_NODE_NAME
           valueFrom:
@@ -787,6 +849,7 @@ spec:
         - "--v={LOG_LEVEL}"
         - "--timeout=600s"
         - "--csi-address=$(ADDRESS)"
+        - "--feature-gates=Topology=true"
         env:
         - name: ADDRESS
           value: /var/lib/csi/sockets/pluginproxy/csi.sock
@@ -915,6 +978,8 @@ spec:
         - "--csi_endpoint=$(CSI_ENDPOINT)"
         - "--csi_role=node"
         - "--log_format={LOG_FORMAT}"
+        - "--metrics"
+        - "--metrics_port=8002"
         {DEBUG}
         env:
         - name: KUBE_NODE_NAME
@@ -1052,6 +1117,8 @@ spec:
         - "--csi_endpoint=$(CSI_ENDPOINT)"
         - "--csi_role=node"
         - "--log_format={LOG_FORMAT}"
+        - "--metrics"
+        - "--metrics_port=8002"
         {DEBUG}
         env:
         - name: KUBE_NODE_NAME
@@ -1204,7 +1271,7 @@ rules:
     resources: ["customresourcedefinitions"]
     verbs: ["*"]
   - apiGroups: ["trident.netapp.io"]
-    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots"]
+    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots", "tridentquotas", "tridentbackendconfigs", "tridentbatchintents"]
     verbs: ["*"]
   - apiGroups: ["policy"]
     resources: ["podsecuritypolicies"]
@@ -1245,7 +1312,7 @@ rules:
     resources: ["csidrivers", "csinodeinfos"]
     verbs: ["*"]
   - apiGroups: ["trident.netapp.io"]
-    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots"]
+    resources: ["tridentversions", "tridentbackends", "tridentstorageclasses", "tridentvolumes","tridentnodes", "tridenttransactions", "tridentsnapshots", "tridentquotas", "tridentbackendconfigs", "tridentbatchintents"]
     verbs: ["*"]
   - apiGroups: ["policy"]
     resources: ["podsecuritypolicies"]
@@ -1623,6 +1690,9 @@ func GetCRDNames() []string {
 		"tridentnodes.trident.netapp.io",
 		"tridenttransactions.trident.netapp.io",
 		"tridentsnapshots.trident.netapp.io",
+		"tridentquotas.trident.netapp.io",
+		"tridentbackendconfigs.trident.netapp.io",
+		"tridentbatchintents.trident.netapp.io",
 	}
 }
 
@@ -1690,6 +1760,30 @@ func GetSnapshotCRDYAML(useCRDv1 bool) string {
 	}
 }
 
+func GetQuotaCRDYAML(useCRDv1 bool) string {
+	if useCRDv1 {
+		return tridentQuotaCRDYAML_v1
+	} else {
+		return tridentQuotaCRDYAML_v1beta1
+	}
+}
+
+func GetBackendConfigCRDYAML(useCRDv1 bool) string {
+	if useCRDv1 {
+		return tridentBackendConfigCRDYAML_v1
+	} else {
+		return tridentBackendConfigCRDYAML_v1beta1
+	}
+}
+
+func GetBatchIntentCRDYAML(useCRDv1 bool) string {
+	if useCRDv1 {
+		return tridentBatchIntentCRDYAML_v1
+	} else {
+		return tridentBatchIntentCRDYAML_v1beta1
+	}
+}
+
 /*
 kubectl delete crd tridentversions.trident.netapp.io --wait=false
 kubectl delete crd tridentbackends.trident.netapp.io --wait=false
@@ -1698,6 +1792,9 @@ kubectl delete crd tridentvolumes.trident.netapp.io --wait=false
 kubectl delete crd tridentnodes.trident.netapp.io --wait=false
 kubectl delete crd tridenttransactions.trident.netapp.io --wait=false
 kubectl delete crd tridentsnapshots.trident.netapp.io --wait=false
+kubectl delete crd tridentquotas.trident.netapp.io --wait=false
+kubectl delete crd tridentbackendconfigs.trident.netapp.io --wait=false
+kubectl delete crd tridentbatchintents.trident.netapp.io --wait=false
 
 kubectl patch crd tridentversions.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
 kubectl patch crd tridentbackends.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
@@ -1706,6 +1803,9 @@ kubectl patch crd tridentvolumes.trident.netapp.io -p '{"metadata":{"finalizers"
 kubectl patch crd tridentnodes.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
 kubectl patch crd tridenttransactions.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
 kubectl patch crd tridentsnapshots.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
+kubectl patch crd tridentquotas.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
+kubectl patch crd tridentbackendconfigs.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
+kubectl patch crd tridentbatchintents.trident.netapp.io -p '{"metadata":{"finalizers": []}}' --type=merge
 
 kubectl delete crd tridentversions.trident.netapp.io
 kubectl delete crd tridentbackends.trident.netapp.io
@@ -1714,6 +1814,9 @@ kubectl delete crd tridentvolumes.trident.netapp.io
 kubectl delete crd tridentnodes.trident.netapp.io
 kubectl delete crd tridenttransactions.trident.netapp.io
 kubectl delete crd tridentsnapshots.trident.netapp.io
+kubectl delete crd tridentquotas.trident.netapp.io
+kubectl delete crd tridentbackendconfigs.trident.netapp.io
+kubectl delete crd tridentbatchintents.trident.netapp.io
 */
 
 const tridentVersionCRDYAML_v1beta1 = `
@@ -1941,9 +2044,118 @@ spec:
       priority: 1
       JSONPath: .state`
 
+const tridentQuotaCRDYAML_v1beta1 = `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentquotas.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  version: v1
+  versions:
+    - name: v1
+      served: true
+      storage: true
+  scope: Namespaced
+  names:
+    plural: tridentquotas
+    singular: tridentquota
+    kind: TridentQuota
+    shortNames:
+    - tquota
+    categories:
+    - trident
+    - trident-internal
+  additionalPrinterColumns:
+    - name: Scope
+      type: string
+      description: The kind of object the quota limits
+      priority: 0
+      JSONPath: .scope
+    - name: Target
+      type: string
+      description: The object the quota limits
+      priority: 0
+      JSONPath: .target
+    - name: Max Size
+      type: string
+      description: The total size of volumes allowed
+      priority: 1
+      JSONPath: .maxSize
+    - name: Max Volumes
+      type: integer
+      description: The number of volumes allowed
+      priority: 1
+      JSONPath: .maxVolumes`
+
+const tridentBackendConfigCRDYAML_v1beta1 = `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentbackendconfigs.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  version: v1
+  versions:
+    - name: v1
+      served: true
+      storage: true
+  scope: Namespaced
+  names:
+    plural: tridentbackendconfigs
+    singular: tridentbackendconfig
+    kind: TridentBackendConfig
+    shortNames:
+    - tbc
+    - tbconfig
+    - tbackendconfig
+    categories:
+    - trident
+  additionalPrinterColumns:
+    - name: Backend Name
+      type: string
+      description: The name of the backend
+      priority: 0
+      JSONPath: .status.backendName
+    - name: Backend UUID
+      type: string
+      description: The UUID of the backend
+      priority: 0
+      JSONPath: .status.backendUUID
+    - name: Phase
+      type: string
+      description: Whether the backend has been created
+      priority: 0
+      JSONPath: .status.phase`
+
+const tridentBatchIntentCRDYAML_v1beta1 = `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentbatchintents.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  version: v1
+  versions:
+    - name: v1
+      served: true
+      storage: true
+  scope: Namespaced
+  names:
+    plural: tridentbatchintents
+    singular: tridentbatchintent
+    kind: TridentBatchIntent
+    shortNames:
+    - tbi
+    - tbatchintent
+    categories:
+    - trident-internal`
+
 const customResourceDefinitionYAML_v1beta1 = tridentVersionCRDYAML_v1beta1 + "\n---" + tridentBackendCRDYAML_v1beta1 +
 	"\n---" + tridentStorageClassCRDYAML_v1beta1 + "\n---" + tridentVolumeCRDYAML_v1beta1 + "\n---" +
-	tridentNodeCRDYAML_v1beta1 + "\n---" + tridentTransactionCRDYAML_v1beta1 + "\n---" + tridentSnapshotCRDYAML_v1beta1
+	tridentNodeCRDYAML_v1beta1 + "\n---" + tridentTransactionCRDYAML_v1beta1 + "\n---" + tridentSnapshotCRDYAML_v1beta1 +
+	"\n---" + tridentQuotaCRDYAML_v1beta1 + "\n---" + tridentBackendConfigCRDYAML_v1beta1 + "\n---" +
+	tridentBatchIntentCRDYAML_v1beta1
 
 const tridentVersionCRDYAML_v1 = `
 apiVersion: apiextensions.k8s.io/v1
@@ -2191,9 +2403,127 @@ spec:
     - trident
     - trident-internal`
 
+const tridentQuotaCRDYAML_v1 = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentquotas.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+          openAPIV3Schema:
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+      additionalPrinterColumns:
+      - name: Scope
+        type: string
+        description: The kind of object the quota limits
+        priority: 0
+        jsonPath: .scope
+      - name: Target
+        type: string
+        description: The object the quota limits
+        priority: 0
+        jsonPath: .target
+      - name: Max Size
+        type: string
+        description: The total size of volumes allowed
+        priority: 1
+        jsonPath: .maxSize
+      - name: Max Volumes
+        type: integer
+        description: The number of volumes allowed
+        priority: 1
+        jsonPath: .maxVolumes
+  scope: Namespaced
+  names:
+    plural: tridentquotas
+    singular: tridentquota
+    kind: TridentQuota
+    shortNames:
+    - tquota
+    categories:
+    - trident
+    - trident-internal`
+
+const tridentBackendConfigCRDYAML_v1 = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentbackendconfigs.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+          openAPIV3Schema:
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+      additionalPrinterColumns:
+      - name: Backend Name
+        type: string
+        description: The name of the backend
+        priority: 0
+        jsonPath: .status.backendName
+      - name: Backend UUID
+        type: string
+        description: The UUID of the backend
+        priority: 0
+        jsonPath: .status.backendUUID
+      - name: Phase
+        type: string
+        description: Whether the backend has been created
+        priority: 0
+        jsonPath: .status.phase
+  scope: Namespaced
+  names:
+    plural: tridentbackendconfigs
+    singular: tridentbackendconfig
+    kind: TridentBackendConfig
+    shortNames:
+    - tbc
+    - tbconfig
+    - tbackendconfig
+    categories:
+    - trident`
+
+const tridentBatchIntentCRDYAML_v1 = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tridentbatchintents.trident.netapp.io
+spec:
+  group: trident.netapp.io
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+          openAPIV3Schema:
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+  scope: Namespaced
+  names:
+    plural: tridentbatchintents
+    singular: tridentbatchintent
+    kind: TridentBatchIntent
+    shortNames:
+    - tbi
+    - tbatchintent
+    categories:
+    - trident-internal`
+
 const customResourceDefinitionYAML_v1 = tridentVersionCRDYAML_v1 + "\n---" + tridentBackendCRDYAML_v1 +
 	"\n---" + tridentStorageClassCRDYAML_v1 + "\n---" + tridentVolumeCRDYAML_v1 + "\n---" +
-	tridentNodeCRDYAML_v1 + "\n---" + tridentTransactionCRDYAML_v1 + "\n---" + tridentSnapshotCRDYAML_v1 + "\n"
+	tridentNodeCRDYAML_v1 + "\n---" + tridentTransactionCRDYAML_v1 + "\n---" + tridentSnapshotCRDYAML_v1 +
+	"\n---" + tridentQuotaCRDYAML_v1 + "\n---" + tridentBackendConfigCRDYAML_v1 + "\n---" +
+	tridentBatchIntentCRDYAML_v1 + "\n"
 
 func GetCSIDriverCRDYAML() string {
 	return CSIDriverCRDYAML
@@ -2307,6 +2637,10 @@ metadata:
   {OWNER_REF}
 spec:
   attachRequired: true
+  podInfoOnMount: true
+  volumeLifecycleModes:
+  - Persistent
+  - Ephemeral
 `
 
 func GetPrivilegedPodSecurityPolicyYAML(pspName string, labels, controllingCRDetails map[string]string) string {
@@ -22,6 +22,7 @@ type Telemetry struct {
 	TridentVersion  string `json:"version"`
 	Platform        string `json:"platform"`
 	PlatformVersion string `json:"platformVersion"`
+	PlatformUID     string `json:"platformUID,omitempty"`
 }
 
 type PersistentStateVersion struct {
@@ -168,10 +169,18 @@ var (
 	BackendUUIDURL  = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/backendUUID"
 	VolumeURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/volume"
 	TransactionURL  = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/txn"
+	BatchIntentURL  = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/batchintent"
 	StorageClassURL = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/storageclass"
 	NodeURL         = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/node"
 	SnapshotURL     = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/snapshot"
+	AuditURL        = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/audit"
+	JobURL          = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/job"
+	OrphanURL       = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/orphan"
+	QuotaURL        = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/quota"
+	WatchURL        = "/" + OrchestratorName + "/v" + OrchestratorAPIVersion + "/watch"
 	StoreURL        = "/" + OrchestratorName + "/store"
+	LivenessURL     = "/healthz"
+	ReadinessURL    = "/readyz"
 
 	UsingPassthroughStore bool
 	CurrentDriverContext  DriverContext
@@ -32,8 +32,14 @@ basefile=$(basename ${MY_CONFIG})
 MY_CONFIG="/etc/netappdvp/${basefile}"
 export CONFIG_SWITCH="--config=${MY_CONFIG}"
 
+# process sqlite, which names a file on the host
+if [ -n "${sqlite}" ]; then
+    SQLITE_SWITCH="--sqlite=/host${sqlite}"
+fi
+export SQLITE_SWITCH
+
 export PATH=/netapp:$PATH
 
-echo Running: /netapp/trident ${REST_SWITCH} --address=0.0.0.0 --port=8000 ${DEBUG_SWITCH} ${CONFIG_SWITCH} "${@:1}"
-/netapp/trident ${REST_SWITCH} --address=0.0.0.0 --port=8000 ${DEBUG_SWITCH} ${CONFIG_SWITCH} "${@:1}"
+echo Running: /netapp/trident ${REST_SWITCH} --address=0.0.0.0 --port=8000 ${DEBUG_SWITCH} ${CONFIG_SWITCH} ${SQLITE_SWITCH} "${@:1}"
+/netapp/trident ${REST_SWITCH} --address=0.0.0.0 --port=8000 ${DEBUG_SWITCH} ${CONFIG_SWITCH} ${SQLITE_SWITCH} "${@:1}"
 
@@ -0,0 +1,78 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	storageclass "github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+// volumeModeOf returns a volume config's volume mode, which is Filesystem if it isn't set.
+func volumeModeOf(volumeConfig *storage.VolumeConfig) config.VolumeMode {
+	if volumeConfig.VolumeMode == "" {
+		return config.Filesystem
+	}
+	return volumeConfig.VolumeMode
+}
+
+// checkAccessMode returns an UnsupportedAccessModeError if the backend's driver can't provision a volume
+// with the volume mode and access mode of a volume config.
+func checkAccessMode(backend *storage.Backend, volumeConfig *storage.VolumeConfig) error {
+	if backend.SupportsAccessMode(volumeConfig.VolumeMode, volumeConfig.AccessMode) {
+		return nil
+	}
+	return utils.UnsupportedAccessModeError(fmt.Sprintf(
+		"backend %s (%s) does not support access mode %s for volumes in %s mode, as requested by volume %s",
+		backend.Name, backend.GetDriverName(), volumeConfig.AccessMode, volumeModeOf(volumeConfig),
+		volumeConfig.Name))
+}
+
+// filterPoolsByAccessMode removes the backends whose drivers can't provision a volume with the volume
+// mode and access mode of a volume config from a map returned by GetStoragePoolsForProtocolByBackend.
+func filterPoolsByAccessMode(
+	poolsByBackend map[string]*storageclass.BackendPoolInfo, volumeConfig *storage.VolumeConfig,
+) map[string]*storageclass.BackendPoolInfo {
+
+	for backendName, backendPoolInfo := range poolsByBackend {
+		if len(backendPoolInfo.Pools) == 0 {
+			continue
+		}
+		backend := backendPoolInfo.Pools[0].Backend
+		if !backend.SupportsAccessMode(volumeConfig.VolumeMode, volumeConfig.AccessMode) {
+			delete(poolsByBackend, backendName)
+		}
+	}
+	return poolsByBackend
+}
+
+// checkStorageClassAccessMode returns an UnsupportedAccessModeError if the storage class has pools, but
+// none on a backend able to provision a volume with the volume mode and access mode of a volume config,
+// so that such requests fail with an explanation rather than for want of backends.
+func checkStorageClassAccessMode(sc *storageclass.StorageClass, volumeConfig *storage.VolumeConfig) error {
+
+	poolsByBackend := sc.GetStoragePoolsForProtocolByBackend(config.ProtocolAny)
+	if len(poolsByBackend) == 0 {
+		return nil
+	}
+
+	unsupported := make([]string, 0, len(poolsByBackend))
+	for backendName, backendPoolInfo := range poolsByBackend {
+		backend := backendPoolInfo.Pools[0].Backend
+		if backend.SupportsAccessMode(volumeConfig.VolumeMode, volumeConfig.AccessMode) {
+			return nil
+		}
+		unsupported = append(unsupported, fmt.Sprintf("%s (%s)", backendName, backend.GetDriverName()))
+	}
+	sort.Strings(unsupported)
+
+	return utils.UnsupportedAccessModeError(fmt.Sprintf(
+		"no backend of storage class %s supports access mode %s for volumes in %s mode, as requested by "+
+			"volume %s; unsupported backends: %s", sc.GetName(), volumeConfig.AccessMode,
+		volumeModeOf(volumeConfig), volumeConfig.Name, strings.Join(unsupported, ", ")))
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage/fake"
+	sa "github.com/netapp/trident/storage_attribute"
+	storageclass "github.com/netapp/trident/storage_class"
+	fakedriver "github.com/netapp/trident/storage_drivers/fake"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+func TestBlockBackendAccessModes(t *testing.T) {
+
+	const (
+		backendName = "blockBackend"
+		scName      = "blockSC"
+	)
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+
+	configJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(
+		backendName,
+		config.Block,
+		map[string]*fake.StoragePool{
+			"primary": {
+				Attrs: map[string]sa.Offer{sa.Media: sa.NewStringOffer("ssd")},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			},
+		},
+		[]fake.Volume{},
+	)
+	if err != nil {
+		t.Fatal("Unable to create backend config: ", err)
+	}
+	if _, err = orchestrator.AddBackend(configJSON); err != nil {
+		t.Fatal("Unable to add backend: ", err)
+	}
+	if _, err = orchestrator.AddStorageClass(&storageclass.Config{
+		Name:       scName,
+		Attributes: map[string]sa.Request{sa.Media: sa.NewStringRequest("ssd")},
+	}); err != nil {
+		t.Fatal("Unable to add storage class: ", err)
+	}
+
+	// A filesystem may not be written from many nodes
+	rwxConfig := tu.GenerateVolumeConfig("rwxFilesystem", 1, scName, config.ProtocolAny)
+	rwxConfig.AccessMode = config.ReadWriteMany
+	_, err = orchestrator.AddVolume(ctx(), rwxConfig)
+	assert.True(t, utils.IsUnsupportedAccessModeError(err), "RWX filesystem volume created on block backend")
+	assert.NotContains(t, orchestrator.volumes, rwxConfig.Name)
+
+	// A raw device may
+	rawConfig := tu.GenerateVolumeConfig("rwxRaw", 1, scName, config.ProtocolAny)
+	rawConfig.AccessMode = config.ReadWriteMany
+	rawConfig.VolumeMode = config.RawBlock
+	_, err = orchestrator.AddVolume(ctx(), rawConfig)
+	assert.NoError(t, err)
+
+	// Nor may an existing filesystem volume be published for many writers
+	rwoConfig := tu.GenerateVolumeConfig("rwoFilesystem", 1, scName, config.ProtocolAny)
+	rwoConfig.AccessMode = config.ReadWriteOnce
+	if _, err = orchestrator.AddVolume(ctx(), rwoConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	err = orchestrator.PublishVolume(ctx(), rwoConfig.Name, &utils.VolumePublishInfo{})
+	assert.False(t, utils.IsUnsupportedAccessModeError(err), "RWO filesystem volume not published")
+	orchestrator.volumes[rwoConfig.Name].Config.AccessMode = config.ReadWriteMany
+	err = orchestrator.PublishVolume(ctx(), rwoConfig.Name, &utils.VolumePublishInfo{})
+	assert.True(t, utils.IsUnsupportedAccessModeError(err), "RWX filesystem volume published from block backend")
+}
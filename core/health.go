@@ -0,0 +1,238 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the code that reports the health of Trident's
+// subsystems for liveness and readiness probes.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthFailed   = "failed"
+
+	healthSubsystemCore  = "core"
+	healthSubsystemStore = "persistentStore"
+
+	healthMonitorPeriod       = 1 * time.Minute
+	backendHealthCheckTimeout = 30 * time.Second
+)
+
+// SubsystemHealth is the health of one of Trident's subsystems.  Trident is healthy only if all of its
+// critical subsystems are healthy, while a failed subsystem that isn't critical, such as one of several
+// backends, leaves it degraded.
+type SubsystemHealth struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Message  string `json:"message,omitempty"`
+	Checked  string `json:"checked,omitempty"`
+}
+
+// HealthReport is the health of Trident and each of its subsystems.
+type HealthReport struct {
+	Status     string             `json:"status"`
+	Subsystems []*SubsystemHealth `json:"subsystems"`
+}
+
+// newHealthReport summarizes the health of the subsystems.
+func newHealthReport(subsystems []*SubsystemHealth) *HealthReport {
+
+	report := &HealthReport{Status: HealthOK, Subsystems: subsystems}
+	for _, subsystem := range subsystems {
+		if subsystem.Status != HealthFailed {
+			continue
+		}
+		if subsystem.Critical {
+			report.Status = HealthFailed
+			break
+		}
+		report.Status = HealthDegraded
+	}
+	return report
+}
+
+// newSubsystemHealth returns the health of a subsystem given the outcome of its check.
+func newSubsystemHealth(name string, critical bool, err error) *SubsystemHealth {
+	health := &SubsystemHealth{Name: name, Status: HealthOK, Critical: critical}
+	if err != nil {
+		health.Status = HealthFailed
+		health.Message = err.Error()
+	}
+	return health
+}
+
+// CheckLiveness reports whether Trident is running and serving its frontends.  It doesn't wait for the
+// orchestrator lock or call any storage system, so that a long-running operation or an unreachable
+// backend never gets Trident restarted.  Trident is live while it is still bootstrapping.
+func (o *TridentOrchestrator) CheckLiveness(ctx context.Context) *HealthReport {
+
+	var bootstrapErr error
+	if utils.IsBootstrapError(o.bootstrapError) {
+		bootstrapErr = o.bootstrapError
+	}
+
+	subsystems := []*SubsystemHealth{newSubsystemHealth(healthSubsystemCore, true, bootstrapErr)}
+	subsystems = append(subsystems, o.checkFrontendHealth()...)
+
+	return newHealthReport(subsystems)
+}
+
+// CheckReadiness reports whether Trident is ready to serve requests: it has bootstrapped, its persistent
+// store is reachable, and its frontends are serving.  The backends are reported as of the last check by
+// the health monitor, and an unreachable backend leaves Trident degraded rather than unready, as the
+// other backends may still be used.
+func (o *TridentOrchestrator) CheckReadiness(ctx context.Context) *HealthReport {
+
+	subsystems := []*SubsystemHealth{newSubsystemHealth(healthSubsystemCore, true, o.bootstrapError)}
+
+	var storeErr error
+	if _, err := o.storeClient.GetVersion(); err != nil {
+		storeErr = fmt.Errorf("persistent store is not reachable; %v", err)
+	}
+	subsystems = append(subsystems, newSubsystemHealth(healthSubsystemStore, true, storeErr))
+
+	subsystems = append(subsystems, o.checkFrontendHealth()...)
+
+	o.backendHealthMutex.RLock()
+	for _, health := range o.backendHealth {
+		backendHealth := *health
+		subsystems = append(subsystems, &backendHealth)
+	}
+	o.backendHealthMutex.RUnlock()
+
+	return newHealthReport(subsystems)
+}
+
+// checkFrontendHealth returns the health of the frontends that can check it, sorted by name.
+func (o *TridentOrchestrator) checkFrontendHealth() []*SubsystemHealth {
+
+	names := make([]string, 0, len(o.frontends))
+	for name := range o.frontends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	subsystems := make([]*SubsystemHealth, 0)
+	for _, name := range names {
+		if checker, ok := o.frontends[name].(frontend.HealthChecker); ok {
+			subsystems = append(subsystems, newSubsystemHealth("frontend/"+name, true, checker.CheckHealth()))
+		}
+	}
+	return subsystems
+}
+
+// StartHealthMonitor starts the thread that periodically checks whether each backend's storage system is
+// reachable, so that readiness probes need not wait on the storage systems.
+func (o *TridentOrchestrator) StartHealthMonitor(period time.Duration) {
+
+	o.healthMonitorTicker = time.NewTicker(period)
+	o.healthMonitorChannel = make(chan struct{})
+
+	go func() {
+		log.Debug("Health monitor started.")
+
+		o.checkBackendHealth()
+		for {
+			select {
+			case tick := <-o.healthMonitorTicker.C:
+				log.WithField("tick", tick).Debug("Health monitor running.")
+				o.checkBackendHealth()
+			case <-o.healthMonitorChannel:
+				log.Debugf("Health monitor stopped.")
+				return
+			}
+		}
+	}()
+}
+
+// StopHealthMonitor stops the thread that checks the backends' health.
+func (o *TridentOrchestrator) StopHealthMonitor() {
+	if o.healthMonitorTicker != nil {
+		o.healthMonitorTicker.Stop()
+	}
+	if o.healthMonitorChannel != nil && !o.healthMonitorStopped {
+		close(o.healthMonitorChannel)
+		o.healthMonitorStopped = true
+	}
+	log.Debug("Health monitor stopped.")
+}
+
+// checkBackendHealth checks every backend at once and replaces the backends' last reported health.  The
+// orchestrator is locked only while the backends are listed, as a storage system may be slow to answer.
+func (o *TridentOrchestrator) checkBackendHealth() {
+
+	type backendState struct {
+		backend *storage.Backend
+		state   storage.BackendState
+	}
+
+	o.mutex.Lock()
+	backends := make([]backendState, 0, len(o.backends))
+	for _, backend := range o.backends {
+		backends = append(backends, backendState{backend: backend, state: backend.State})
+	}
+	o.mutex.Unlock()
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	var wg sync.WaitGroup
+	health := make([]*SubsystemHealth, len(backends))
+	for i, b := range backends {
+		wg.Add(1)
+		go func(i int, b backendState) {
+			defer wg.Done()
+			health[i] = checkBackendHealth(ctx, b.backend, b.state)
+		}(i, b)
+	}
+	wg.Wait()
+
+	sort.Slice(health, func(i, j int) bool { return health[i].Name < health[j].Name })
+
+	o.backendHealthMutex.Lock()
+	o.backendHealth = health
+	o.backendHealthMutex.Unlock()
+}
+
+// checkBackendHealth returns the health of a backend, which is failed if the backend isn't online or its
+// storage system can't be reached.
+func checkBackendHealth(
+	ctx context.Context, backend *storage.Backend, state storage.BackendState,
+) *SubsystemHealth {
+
+	var err error
+	if !state.IsOnline() {
+		err = fmt.Errorf("backend is %s", state)
+	} else {
+		checkCtx, cancel := context.WithTimeout(ctx, backendHealthCheckTimeout)
+		defer cancel()
+		if err = backend.CheckHealth(checkCtx); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"backend": backend.Name,
+				"error":   err,
+			}).Warning("Backend storage system is not reachable.")
+			err = fmt.Errorf("storage system is not reachable; %v", err)
+		}
+	}
+
+	health := newSubsystemHealth("backend/"+backend.Name, false, err)
+	health.Checked = time.Now().UTC().Format(time.RFC3339)
+	return health
+}
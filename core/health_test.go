@@ -0,0 +1,103 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+type healthCheckedFrontend struct {
+	err error
+}
+
+func (f *healthCheckedFrontend) Activate() error   { return nil }
+func (f *healthCheckedFrontend) Deactivate() error { return nil }
+func (f *healthCheckedFrontend) GetName() string   { return "checked" }
+func (f *healthCheckedFrontend) Version() string   { return "1" }
+func (f *healthCheckedFrontend) CheckHealth() error {
+	return f.err
+}
+
+func TestNewHealthReport(t *testing.T) {
+
+	failure := errors.New("failed")
+
+	report := newHealthReport([]*SubsystemHealth{
+		newSubsystemHealth("a", true, nil),
+		newSubsystemHealth("b", false, nil),
+	})
+	assert.Equal(t, HealthOK, report.Status)
+
+	report = newHealthReport([]*SubsystemHealth{
+		newSubsystemHealth("a", true, nil),
+		newSubsystemHealth("b", false, failure),
+	})
+	assert.Equal(t, HealthDegraded, report.Status)
+	assert.Equal(t, "failed", report.Subsystems[1].Message)
+
+	report = newHealthReport([]*SubsystemHealth{
+		newSubsystemHealth("a", false, failure),
+		newSubsystemHealth("b", true, failure),
+	})
+	assert.Equal(t, HealthFailed, report.Status)
+}
+
+func TestCheckLivenessAndReadiness(t *testing.T) {
+
+	o := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	checked := &healthCheckedFrontend{}
+	o.AddFrontend(checked)
+
+	// Trident is live but not ready while bootstrapping
+	assert.Equal(t, HealthOK, o.CheckLiveness(context.Background()).Status)
+	assert.Equal(t, HealthFailed, o.CheckReadiness(context.Background()).Status)
+
+	o.bootstrapError = nil
+	report := o.CheckReadiness(context.Background())
+	assert.Equal(t, HealthOK, report.Status)
+	names := make([]string, 0)
+	for _, subsystem := range report.Subsystems {
+		names = append(names, subsystem.Name)
+	}
+	assert.Equal(t, []string{healthSubsystemCore, healthSubsystemStore, "frontend/checked"}, names)
+
+	checked.err = errors.New("not serving")
+	assert.Equal(t, HealthFailed, o.CheckLiveness(context.Background()).Status)
+	assert.Equal(t, HealthFailed, o.CheckReadiness(context.Background()).Status)
+
+	// A failed bootstrap can't be recovered from, so Trident is no longer live
+	checked.err = nil
+	o.bootstrapError = utils.BootstrapError(errors.New("bad store"))
+	assert.Equal(t, HealthFailed, o.CheckLiveness(context.Background()).Status)
+}
+
+func TestCheckBackendHealth(t *testing.T) {
+
+	o, _ := setupOrchestratorAndBackend(t)
+	o.StopHealthMonitor()
+
+	o.checkBackendHealth()
+	report := o.CheckReadiness(context.Background())
+	assert.Equal(t, HealthOK, report.Status)
+	backendHealth := report.Subsystems[len(report.Subsystems)-1]
+	assert.Equal(t, "backend/fakeOne", backendHealth.Name)
+	assert.False(t, backendHealth.Critical)
+	assert.NotEmpty(t, backendHealth.Checked)
+
+	// An offline backend leaves Trident degraded but ready
+	for _, backend := range o.backends {
+		backend.State = storage.Offline
+	}
+	o.checkBackendHealth()
+	report = o.CheckReadiness(context.Background())
+	assert.Equal(t, HealthDegraded, report.Status)
+	assert.Equal(t, "backend is offline", report.Subsystems[len(report.Subsystems)-1].Message)
+}
@@ -240,4 +240,53 @@ var (
 		},
 		[]string{"operation", "success"},
 	)
+	operationDurationSecondsHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of orchestrator operations",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20),
+		},
+		[]string{"operation", "success"},
+	)
+	operationsFailedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "operations_failed_total",
+			Help:      "The total number of failed orchestrator operations",
+		},
+		[]string{"operation"},
+	)
+	poolVolumesGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "pool_volume_count",
+			Help:      "The number of volumes by backend, storage pool, and storage class",
+		},
+		[]string{"backend_name", "pool", "storage_class"},
+	)
+	poolProvisionedBytesGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "pool_provisioned_bytes",
+			Help:      "The number of bytes provisioned to volumes by backend, storage pool, and storage class",
+		},
+		[]string{"backend_name", "pool", "storage_class"},
+	)
+	poolTotalBytesGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "pool_total_bytes",
+			Help:      "The total capacity of each storage pool, as last reported by its backend",
+		},
+		[]string{"backend_name", "pool"},
+	)
+	poolFreeBytesGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: config.OrchestratorName,
+			Name:      "pool_free_bytes",
+			Help:      "The free capacity of each storage pool, as last reported by its backend",
+		},
+		[]string{"backend_name", "pool"},
+	)
 )
@@ -3,9 +3,9 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"os"
 	"sort"
 	"strconv"
@@ -16,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
 	persistentstore "github.com/netapp/trident/persistent_store"
@@ -42,6 +43,10 @@ func recordTiming(operation string, err *error) func() {
 		}
 		operationDurationInMsSummaryDeprecated.WithLabelValues(operation, success).Observe(endTimeMS)
 		operationDurationInMsSummary.WithLabelValues(operation, success).Observe(endTimeMS)
+		operationDurationSecondsHistogram.WithLabelValues(operation, success).Observe(endTime.Seconds())
+		if *err != nil {
+			operationsFailedTotal.WithLabelValues(operation).Inc()
+		}
 	}
 }
 
@@ -72,16 +77,42 @@ type TridentOrchestrator struct {
 	storageClasses    map[string]*storageclass.StorageClass
 	nodes             map[string]*utils.Node
 	snapshots         map[string]*storage.Snapshot
+	quotas            map[string]*storage.Quota
 	storeClient       persistentstore.Client
 	bootstrapped      bool
 	bootstrapError    error
 	txnMonitorTicker  *time.Ticker
 	txnMonitorChannel chan struct{}
 	txnMonitorStopped bool
+
+	orphans                map[string]*storage.OrphanedResource // key is OrphanedResource.Key()
+	orphanCollectorTicker  *time.Ticker
+	orphanCollectorChannel chan struct{}
+	orphanCollectorStopped bool
+
+	poolRefresherTicker  *time.Ticker
+	poolRefresherChannel chan struct{}
+	poolRefresherStopped bool
+
+	volumeMigrations      map[string]*storage.VolumeMigrationStatus // key is volume name
+	volumeMigratorTicker  *time.Ticker
+	volumeMigratorChannel chan struct{}
+	volumeMigratorStopped bool
+
+	placementPolicy PlacementPolicy
+
+	watchers *objectWatchers
+
+	backendHealth        []*SubsystemHealth
+	backendHealthMutex   sync.RWMutex
+	healthMonitorTicker  *time.Ticker
+	healthMonitorChannel chan struct{}
+	healthMonitorStopped bool
 }
 
 // NewTridentOrchestrator returns a storage orchestrator instance
 func NewTridentOrchestrator(client persistentstore.Client) *TridentOrchestrator {
+	watchers := newObjectWatchers()
 	return &TridentOrchestrator{
 		backends:       make(map[string]*storage.Backend), // key is UUID, not name
 		volumes:        make(map[string]*storage.Volume),
@@ -89,10 +120,16 @@ func NewTridentOrchestrator(client persistentstore.Client) *TridentOrchestrator
 		storageClasses: make(map[string]*storageclass.StorageClass),
 		nodes:          make(map[string]*utils.Node),
 		snapshots:      make(map[string]*storage.Snapshot), // key is ID, not name
+		quotas:         make(map[string]*storage.Quota),
+		orphans:        make(map[string]*storage.OrphanedResource),
 		mutex:          &sync.Mutex{},
-		storeClient:    client,
+		storeClient:    &watchedStoreClient{Client: client, watchers: watchers},
 		bootstrapped:   false,
 		bootstrapError: utils.NotReadyError(),
+
+		volumeMigrations: make(map[string]*storage.VolumeMigrationStatus),
+		placementPolicy:  placementPolicies[DefaultPlacementPolicy],
+		watchers:         watchers,
 	}
 }
 
@@ -162,6 +199,18 @@ func (o *TridentOrchestrator) Bootstrap() error {
 	// Start transaction monitor
 	o.StartTransactionMonitor(txnMonitorPeriod, txnMonitorMaxAge)
 
+	// Start orphan collector
+	o.StartOrphanCollector(orphanCollectorPeriod)
+
+	// Start pool refresher
+	o.StartPoolRefresher(poolRefresherPeriod)
+
+	// Start volume migrator
+	o.StartVolumeMigrator(volumeMigratorPeriod)
+
+	// Start health monitor
+	o.StartHealthMonitor(healthMonitorPeriod)
+
 	o.bootstrapped = true
 	o.bootstrapError = nil
 	log.Infof("%s bootstrapped successfully.", strings.Title(config.OrchestratorName))
@@ -189,7 +238,16 @@ func (o *TridentOrchestrator) bootstrapBackends() error {
 			return err
 		}
 
+		// Upgrade configs written for older schema versions, so that the upgraded form may be stored.
+		// A config that can't be upgraded is left for addBackend to reject.
+		migrated := false
+		if migratedConfig, changed, migrateErr := drivers.MigrateConfig(serializedConfig); migrateErr == nil {
+			serializedConfig, migrated = migratedConfig, changed
+		}
+
+		o.mutex.Lock()
 		newBackendExternal, backendErr := o.addBackend(serializedConfig, b.BackendUUID)
+		o.mutex.Unlock()
 		newBackendExternal.BackendUUID = b.BackendUUID
 		if backendErr != nil {
 
@@ -210,7 +268,7 @@ func (o *TridentOrchestrator) bootstrapBackends() error {
 
 			if newBackendExternal != nil {
 				newBackend, _ := factory.NewStorageBackendForConfig(serializedConfig)
-				newBackend.BackendUUID = b.BackendUUID
+				newBackend.SetBackendUUID(b.BackendUUID)
 				newBackend.Name = b.Name
 				newBackendExternal.Name = b.Name // have to set it explicitly, so it's not ""
 				o.backends[newBackendExternal.BackendUUID] = newBackend
@@ -238,6 +296,14 @@ func (o *TridentOrchestrator) bootstrapBackends() error {
 				if b.State == storage.Deleting {
 					newBackend.State = storage.Deleting
 				}
+				if migrated && !config.UsingPassthroughStore {
+					if err := o.storeClient.UpdateBackend(newBackend); err != nil {
+						log.WithField("backend", newBackend.Name).Warningf(
+							"Could not store migrated backend config; %v", err)
+					} else {
+						log.WithField("backend", newBackend.Name).Info("Stored migrated backend config.")
+					}
+				}
 			}
 			log.WithFields(log.Fields{
 				"backend":                        newBackend.Name,
@@ -302,6 +368,10 @@ func (o *TridentOrchestrator) bootstrapVolumes() error {
 		var ok bool
 		var vol *storage.Volume
 		vol = storage.NewVolume(v.Config, v.BackendUUID, v.Pool, v.Orphaned)
+		vol.PublishedNodes = v.PublishedNodes
+		if v.State.IsPopulating() {
+			vol.State = v.State
+		}
 		o.volumes[vol.Config.Name] = vol
 
 		if backend, ok = o.backends[v.BackendUUID]; !ok {
@@ -354,6 +424,9 @@ func (o *TridentOrchestrator) bootstrapSnapshots() error {
 				if fakeDriver, ok := backend.Driver.(*fake.StorageDriver); ok {
 					fakeDriver.BootstrapSnapshot(snapshot)
 				}
+				if s.State.IsDeleting() || s.State.IsDeleteFailed() {
+					snapshot.State = s.State
+				}
 			}
 		}
 
@@ -366,14 +439,101 @@ func (o *TridentOrchestrator) bootstrapSnapshots() error {
 	return nil
 }
 
+// bootstrapDeferredSnapshotDeletions resumes deleting any snapshots whose deletion had been deferred by
+// a backend, since backends forget about such snapshots when Trident restarts.
+func (o *TridentOrchestrator) bootstrapDeferredSnapshotDeletions() error {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, snapshot := range o.snapshots {
+		if !snapshot.State.IsDeleting() {
+			continue
+		}
+		if err := o.deleteSnapshot(ctx, snapshot.Config, nil); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"snapshot": snapshot.Config.Name,
+				"volume":   snapshot.Config.VolumeName,
+				"error":    err,
+			}).Warning("Could not resume deleting snapshot.")
+		}
+	}
+	return nil
+}
+
+// bootstrapCloneSplits has the backends follow the splits of cloned volumes again, so that splits that were
+// queued or running when Trident stopped are finished.
+func (o *TridentOrchestrator) bootstrapCloneSplits() error {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, volume := range o.volumes {
+		if volume.Config.CloneSourceVolume == "" {
+			continue
+		}
+		backend, ok := o.backends[volume.BackendUUID]
+		if !ok {
+			continue
+		}
+		if err := backend.ResumeCloneSplit(ctx, volume.Config); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"volume":  volume.Config.Name,
+				"backend": backend.Name,
+				"error":   err,
+			}).Warning("Could not resume clone split.")
+		}
+	}
+	return nil
+}
+
+// bootstrapVolumeMoves has the backends follow the moves of volumes again, so that volumes whose moves
+// finished while Trident was stopped are reassigned to their new pools.
+func (o *TridentOrchestrator) bootstrapVolumeMoves() error {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, volume := range o.volumes {
+		if volume.Config.MoveDestinationPool == "" {
+			continue
+		}
+		backend, ok := o.backends[volume.BackendUUID]
+		if !ok {
+			continue
+		}
+		if err := backend.ResumeVolumeMove(ctx, volume.Config); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"volume":  volume.Config.Name,
+				"backend": backend.Name,
+				"error":   err,
+			}).Warning("Could not resume volume move.")
+		}
+	}
+	return nil
+}
+
+// bootstrapBatchIntents finishes any persistent store batches interrupted by a restart, so that the state
+// bootstrapped afterwards holds either all or none of each batch's writes.
+func (o *TridentOrchestrator) bootstrapBatchIntents() error {
+	return persistentstore.ReplayBatchIntents(o.storeClient)
+}
+
 func (o *TridentOrchestrator) bootstrapVolTxns() error {
 	volTxns, err := o.storeClient.GetVolumeTransactions()
 	if err != nil {
 		log.Warnf("Couldn't retrieve volume transaction logs: %s", err.Error())
 	}
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
 	for _, v := range volTxns {
 		o.mutex.Lock()
-		err = o.handleFailedTransaction(v)
+		err = o.resumeOrHandleFailedTransaction(ctx, v)
 		o.mutex.Unlock()
 		if err != nil {
 			return err
@@ -406,8 +566,9 @@ func (o *TridentOrchestrator) bootstrap() error {
 
 	type bootstrapFunc func() error
 	for _, f := range []bootstrapFunc{
-		o.bootstrapBackends, o.bootstrapStorageClasses, o.bootstrapVolumes,
-		o.bootstrapSnapshots, o.bootstrapVolTxns, o.bootstrapNodes} {
+		o.bootstrapBatchIntents, o.bootstrapBackends, o.bootstrapStorageClasses, o.bootstrapQuotas,
+		o.bootstrapVolumes, o.bootstrapSnapshots, o.bootstrapVolTxns, o.bootstrapNodes,
+		o.bootstrapDeferredSnapshotDeletions, o.bootstrapCloneSplits, o.bootstrapVolumeMoves} {
 		err := f()
 		if err != nil {
 			if persistentstore.MatchKeyNotFoundErr(err) {
@@ -449,6 +610,18 @@ func (o *TridentOrchestrator) Stop() {
 
 	// Stop transaction monitor
 	o.StopTransactionMonitor()
+
+	// Stop orphan collector
+	o.StopOrphanCollector()
+
+	// Stop pool refresher
+	o.StopPoolRefresher()
+
+	// Stop volume migrator
+	o.StopVolumeMigrator()
+
+	// Stop health monitor
+	o.StopHealthMonitor()
 }
 
 // updateMetrics updates the metrics that track the core objects.
@@ -512,6 +685,34 @@ func (o *TridentOrchestrator) updateMetrics() {
 	volumesTotalBytesGaugeDeprecated.Set(volumesTotalBytes)
 	volumesTotalBytesGauge.Set(volumesTotalBytes)
 
+	// Volumes are counted against the pool they were provisioned in and their storage class
+	poolVolumesGauge.Reset()
+	poolProvisionedBytesGauge.Reset()
+	for _, volume := range o.volumes {
+		backendName := ""
+		if backend, ok := o.backends[volume.BackendUUID]; ok {
+			backendName = backend.Name
+		}
+		bytes, _ := strconv.ParseFloat(volume.Config.Size, 64)
+		poolVolumesGauge.WithLabelValues(backendName, volume.Pool, volume.Config.StorageClass).Inc()
+		poolProvisionedBytesGauge.WithLabelValues(backendName, volume.Pool, volume.Config.StorageClass).Add(bytes)
+	}
+
+	poolTotalBytesGauge.Reset()
+	poolFreeBytesGauge.Reset()
+	for _, backend := range o.backends {
+		for poolName, pool := range backend.Storage {
+			capacity := pool.Capacity()
+			if capacity == nil {
+				continue
+			}
+			if capacity.TotalBytes > 0 {
+				poolTotalBytesGauge.WithLabelValues(backend.Name, poolName).Set(float64(capacity.TotalBytes))
+			}
+			poolFreeBytesGauge.WithLabelValues(backend.Name, poolName).Set(float64(capacity.FreeBytes))
+		}
+	}
+
 	scGaugeDeprecated.Set(float64(len(o.storageClasses)))
 	scGauge.Set(float64(len(o.storageClasses)))
 	nodeGaugeDeprecated.Set(float64(len(o.nodes)))
@@ -540,36 +741,43 @@ func (o *TridentOrchestrator) updateMetrics() {
 	}
 }
 
-func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransaction) error {
+func (o *TridentOrchestrator) handleFailedTransaction(ctx context.Context, v *storage.VolumeTransaction) error {
 
 	switch v.Op {
 	case storage.AddVolume, storage.DeleteVolume,
 		storage.ImportVolume, storage.ResizeVolume:
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":       v.Config.Name,
 			"size":         v.Config.Size,
 			"storageClass": v.Config.StorageClass,
 			"op":           v.Op,
 		}).Info("Processed volume transaction log.")
 	case storage.AddSnapshot, storage.DeleteSnapshot:
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":   v.SnapshotConfig.VolumeName,
 			"snapshot": v.SnapshotConfig.Name,
 			"op":       v.Op,
 		}).Info("Processed snapshot transaction log.")
 	case storage.UpgradeVolume:
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume": v.Config.Name,
 			"PVC":    v.PVUpgradeConfig.PVCConfig.Name,
 			"PV":     v.PVUpgradeConfig.PVConfig.Name,
 			"op":     v.Op,
 		}).Info("Processed volume upgrade transaction log.")
 	case storage.VolumeCreating:
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":      v.VolumeCreatingConfig.Name,
 			"backendUUID": v.VolumeCreatingConfig.BackendUUID,
 			"op":          v.Op,
 		}).Info("Processed volume creating transaction log.")
+	case storage.VolumeMigrating:
+		utils.Logc(ctx).WithFields(log.Fields{
+			"volume":      v.Config.Name,
+			"backendUUID": v.BackendUUID,
+			"step":        v.Step,
+			"op":          v.Op,
+		}).Info("Processed volume migration transaction log.")
 	}
 
 	switch v.Op {
@@ -583,7 +791,7 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 			// If the volume was added to the store, we will have loaded the
 			// volume into memory, and we can just delete it normally.
 			// Handles case 3)
-			err := o.deleteVolume(v.Config.Name)
+			err := o.deleteVolume(ctx, v.Config.Name, nil)
 			if err != nil {
 				return fmt.Errorf("unable to clean up volume %s: %v", v.Config.Name, err)
 			}
@@ -603,7 +811,7 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 				// Volume deletion is an idempotent operation, so it's safe to
 				// delete an already deleted volume.
 
-				if err := backend.RemoveVolume(v.Config); err != nil {
+				if err := backend.RemoveVolume(ctx, v.Config); err != nil {
 					return fmt.Errorf("error attempting to clean up volume %s from backend %s: %v", v.Config.Name,
 						backend.Name, err)
 				}
@@ -622,16 +830,16 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 		// volume should have been loaded into memory when we bootstrapped.
 		if _, ok := o.volumes[v.Config.Name]; ok {
 
-			err := o.deleteVolume(v.Config.Name)
+			err := o.deleteVolume(ctx, v.Config.Name, nil)
 			if err != nil {
-				log.WithFields(log.Fields{
+				utils.Logc(ctx).WithFields(log.Fields{
 					"volume": v.Config.Name,
 					"error":  err,
 				}).Errorf("Unable to finalize deletion of the volume! Repeat deleting the volume using %s.",
 					config.OrchestratorClientName)
 			}
 		} else {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume": v.Config.Name,
 			}).Info("Volume for the delete transaction wasn't found.")
 		}
@@ -649,7 +857,7 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 			// If the snapshot was added to the store, we will have loaded the
 			// snapshot into memory, and we can just delete it normally.
 			// Handles case 3)
-			if err := o.deleteSnapshot(v.SnapshotConfig); err != nil {
+			if err := o.deleteSnapshot(ctx, v.SnapshotConfig, nil); err != nil {
 				return fmt.Errorf("unable to clean up snapshot %s: %v", v.SnapshotConfig.Name, err)
 			}
 		} else {
@@ -665,7 +873,7 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 				}
 				// Snapshot deletion is an idempotent operation, so it's safe to
 				// delete an already deleted snapshot.
-				if err := backend.DeleteSnapshot(v.SnapshotConfig, v.Config); err != nil {
+				if err := backend.DeleteSnapshot(ctx, v.SnapshotConfig, v.Config); err != nil {
 					return fmt.Errorf("error attempting to clean up snapshot %s from backend %s: %v",
 						v.SnapshotConfig.Name, backend.Name, err)
 				}
@@ -685,11 +893,11 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 
 		logFields := log.Fields{"volume": v.SnapshotConfig.VolumeName, "snapshot": v.SnapshotConfig.Name}
 
-		if err := o.deleteSnapshot(v.SnapshotConfig); err != nil {
+		if err := o.deleteSnapshot(ctx, v.SnapshotConfig, nil); err != nil {
 			if utils.IsNotFoundError(err) {
-				log.WithFields(logFields).Info("Snapshot for the delete transaction wasn't found.")
+				utils.Logc(ctx).WithFields(logFields).Info("Snapshot for the delete transaction wasn't found.")
 			} else {
-				log.WithFields(logFields).Errorf("Unable to finalize deletion of the snapshot! "+
+				utils.Logc(ctx).WithFields(logFields).Errorf("Unable to finalize deletion of the snapshot! "+
 					"Repeat deleting the snapshot using %s.", config.OrchestratorClientName)
 			}
 		}
@@ -708,24 +916,24 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 		var err error
 		vol, ok := o.volumes[v.Config.Name]
 		if ok {
-			err = o.resizeVolume(vol, v.Config.Size)
+			err = o.resizeVolume(ctx, vol, v.Config.Size, nil)
 			if err != nil {
-				log.WithFields(log.Fields{
+				utils.Logc(ctx).WithFields(log.Fields{
 					"volume": v.Config.Name,
 					"error":  err,
 				}).Error("Unable to resize the volume! Repeat resizing the volume.")
 			} else {
-				log.WithFields(log.Fields{
+				utils.Logc(ctx).WithFields(log.Fields{
 					"volume":      vol.Config.Name,
 					"volume_size": v.Config.Size,
 				}).Info("Orchestrator resized the volume on the storage backend.")
 			}
 		} else {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume": v.Config.Name,
 			}).Error("Volume for the resize transaction wasn't found.")
 		}
-		return o.resizeVolumeCleanup(err, vol, v)
+		return o.resizeVolumeCleanup(ctx, err, vol, v)
 
 	case storage.ImportVolume:
 		/*
@@ -754,13 +962,13 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 		*/
 
 		if volume, ok := o.volumes[v.Config.Name]; ok {
-			if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume); err != nil {
+			if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume, nil); err != nil {
 				return err
 			}
 			delete(o.volumes, v.Config.Name)
 		}
 		if !v.Config.ImportNotManaged {
-			if err := o.resetImportedVolumeName(v.Config); err != nil {
+			if err := o.resetImportedVolumeName(ctx, v.Config); err != nil {
 				return err
 			}
 		}
@@ -770,14 +978,25 @@ func (o *TridentOrchestrator) handleFailedTransaction(v *storage.VolumeTransacti
 			return fmt.Errorf("failed to clean up volume addition transaction: %v", err)
 		}
 
-	case storage.UpgradeVolume, storage.VolumeCreating:
+	case storage.VolumeCreating:
+		// Resume following any storage system job still creating the volume, so that its outcome is
+		// known when the create request is retried
+		if backend, ok := o.backends[v.VolumeCreatingConfig.BackendUUID]; ok {
+			backend.ResumeAsyncJob(&v.VolumeCreatingConfig.VolumeConfig)
+		}
+
+	case storage.VolumeMigrating:
+		// Leave the migration to the volume migrator, reporting it as running in the meantime
+		o.getVolumeMigrationStatus(v)
+
+	case storage.UpgradeVolume:
 		// Do nothing
 	}
 
 	return nil
 }
 
-func (o *TridentOrchestrator) resetImportedVolumeName(volume *storage.VolumeConfig) error {
+func (o *TridentOrchestrator) resetImportedVolumeName(ctx context.Context, volume *storage.VolumeConfig) error {
 	// The volume could be renamed (notManaged = false) without being persisted.
 	// If the volume wasn't added to the persistent store, we attempt to rename
 	// it at each backend, since we don't know where it might have
@@ -785,11 +1004,11 @@ func (o *TridentOrchestrator) resetImportedVolumeName(volume *storage.VolumeConf
 	// unique across backends, thanks to the StoragePrefix field,
 	// so this should be idempotent.
 	for _, backend := range o.backends {
-		if err := backend.RenameVolume(volume, volume.ImportOriginalName); err == nil {
+		if err := backend.RenameVolume(ctx, volume, volume.ImportOriginalName); err == nil {
 			return nil
 		}
 	}
-	log.Debugf("could not find volume %s to reset the volume name", volume.InternalName)
+	utils.Logc(ctx).Debugf("could not find volume %s to reset the volume name", volume.InternalName)
 	return nil
 }
 
@@ -813,6 +1032,185 @@ func (o *TridentOrchestrator) GetFrontend(name string) (frontend.Plugin, error)
 	}
 }
 
+// watchBackend registers handlers with backends whose drivers do work in the background, so that
+// the orchestrator learns when a clone split, a deferred snapshot deletion, or a volume move ends.  The handlers run
+// in a driver's housekeeping jobs, which may be waited on while the orchestrator lock is held, so the
+// lock must only be taken from another goroutine.
+func (o *TridentOrchestrator) watchBackend(backend *storage.Backend) {
+
+	backendUUID := backend.BackendUUID
+
+	if reporter, ok := backend.Driver.(storage.CloneSplitReporter); ok {
+		reporter.SetCloneSplitHandler(func(internalName string, status *storage.CloneSplitStatus) {
+			go o.recordCloneSplitEvent(backendUUID, internalName, status)
+		})
+	}
+
+	if deleter, ok := backend.Driver.(storage.DeferredSnapshotDeleter); ok {
+		deleter.SetSnapshotDeleteHandler(func(snapConfig *storage.SnapshotConfig, err error) {
+			go o.finishDeferredSnapshotDeletion(snapConfig, err)
+		})
+	}
+
+	if mover, ok := backend.Driver.(storage.VolumeMover); ok {
+		mover.SetVolumeMoveHandler(func(internalName string, status *storage.VolumeMoveStatus) {
+			go o.finishVolumeMove(backendUUID, internalName, status)
+		})
+	}
+
+	if reporter, ok := backend.Driver.(storage.PoolChangeReporter); ok {
+		reporter.SetPoolChangeHandler(func() {
+			go o.refreshChangedBackendPools(backendUUID)
+		})
+	}
+}
+
+// recordCloneSplitEvent reports the outcome of a clone split to the frontends.
+func (o *TridentOrchestrator) recordCloneSplitEvent(
+	backendUUID, internalName string, status *storage.CloneSplitStatus,
+) {
+
+	o.mutex.Lock()
+	var volumeName string
+	for _, vol := range o.volumes {
+		if vol.BackendUUID == backendUUID && vol.Config.InternalName == internalName {
+			volumeName = vol.Config.Name
+			break
+		}
+	}
+	recorders := make([]frontend.VolumeEventRecorder, 0)
+	for _, f := range o.frontends {
+		if recorder, ok := f.(frontend.VolumeEventRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+	o.mutex.Unlock()
+
+	logFields := log.Fields{"backendUUID": backendUUID, "volumeInternal": internalName, "state": status.State}
+	if volumeName == "" {
+		log.WithFields(logFields).Debug("Clone split finished for a volume not known to Trident.")
+		return
+	}
+	log.WithFields(logFields).WithField("volume", volumeName).Debug("Recording clone split event.")
+
+	for _, recorder := range recorders {
+		if status.State == storage.CloneSplitStateComplete {
+			recorder.RecordVolumeEvent(volumeName, frontend.EventTypeNormal, "CloneSplitComplete",
+				"volume was split from its parent")
+		} else {
+			recorder.RecordVolumeEvent(volumeName, frontend.EventTypeWarning, "CloneSplitFailed",
+				fmt.Sprintf("volume could not be split from its parent: %s", status.Message))
+		}
+	}
+}
+
+// RecordVolumeEvent reports an event about a volume that was observed elsewhere, such as by a
+// node, to the frontends.
+func (o *TridentOrchestrator) RecordVolumeEvent(volumeName string, event *utils.VolumeEvent) (err error) {
+
+	if o.bootstrapError != nil {
+		return o.bootstrapError
+	}
+
+	defer recordTiming("volume_event", &err)()
+
+	if event.Type != frontend.EventTypeNormal && event.Type != frontend.EventTypeWarning {
+		return fmt.Errorf("invalid event type %s", event.Type)
+	} else if event.Reason == "" {
+		return fmt.Errorf("the following field is mandatory: reason")
+	}
+
+	o.mutex.Lock()
+	if _, ok := o.volumes[volumeName]; !ok {
+		o.mutex.Unlock()
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	recorders := make([]frontend.VolumeEventRecorder, 0)
+	for _, f := range o.frontends {
+		if recorder, ok := f.(frontend.VolumeEventRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+	o.mutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"volume": volumeName,
+		"type":   event.Type,
+		"reason": event.Reason,
+	}).Debug("Recording volume event.")
+
+	for _, recorder := range recorders {
+		recorder.RecordVolumeEvent(volumeName, event.Type, event.Reason, event.Message)
+	}
+	return nil
+}
+
+// finishVolumeMove records the outcome of a volume move.  A volume that moved to another of its
+// backend's pools is reassigned to that pool, unless the backend's pools are virtual pools, which
+// may span several physical pools.
+func (o *TridentOrchestrator) finishVolumeMove(
+	backendUUID, internalName string, status *storage.VolumeMoveStatus,
+) {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	var volume *storage.Volume
+	for _, vol := range o.volumes {
+		if vol.BackendUUID == backendUUID && vol.Config.InternalName == internalName {
+			volume = vol
+			break
+		}
+	}
+	recorders := make([]frontend.VolumeEventRecorder, 0)
+	for _, f := range o.frontends {
+		if recorder, ok := f.(frontend.VolumeEventRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+
+	logFields := log.Fields{"backendUUID": backendUUID, "volumeInternal": internalName, "state": status.State}
+	if volume == nil {
+		o.mutex.Unlock()
+		utils.Logc(ctx).WithFields(logFields).Debug("Move finished for a volume not known to Trident.")
+		return
+	}
+	volumeName := volume.Config.Name
+	logFields["volume"] = volumeName
+
+	// The move no longer needs to be resumed after a restart
+	updated := volume.Config.MoveDestinationPool != ""
+	volume.Config.MoveDestinationPool = ""
+
+	if status.State == storage.VolumeMoveStateComplete {
+		if backend, ok := o.backends[backendUUID]; ok {
+			if _, ok = backend.Storage[status.DestinationPool]; ok && volume.Pool != status.DestinationPool {
+				volume.Pool = status.DestinationPool
+				updated = true
+				utils.Logc(ctx).WithFields(logFields).WithField("pool", volume.Pool).Info(
+					"Updated the pool of a moved volume.")
+			}
+		}
+	}
+	if updated {
+		if err := o.updateVolumeOnPersistentStore(volume); err != nil {
+			utils.Logc(ctx).WithFields(logFields).WithError(err).Error(
+				"Unable to update the moved volume in persistent store.")
+		}
+	}
+	o.mutex.Unlock()
+
+	for _, recorder := range recorders {
+		if status.State == storage.VolumeMoveStateComplete {
+			recorder.RecordVolumeEvent(volumeName, frontend.EventTypeNormal, "VolumeMoveComplete",
+				fmt.Sprintf("volume was moved to %s", status.DestinationPool))
+		} else {
+			recorder.RecordVolumeEvent(volumeName, frontend.EventTypeWarning, "VolumeMoveFailed",
+				fmt.Sprintf("volume could not be moved to %s: %s", status.DestinationPool, status.Message))
+		}
+	}
+}
+
 func (o *TridentOrchestrator) validateBackendUpdate(
 	oldBackend *storage.Backend, newBackend *storage.Backend,
 ) error {
@@ -877,7 +1275,7 @@ func (o *TridentOrchestrator) addBackend(configJSON, backendUUID string) (backen
 
 	backend, err = factory.NewStorageBackendForConfig(configJSON)
 	if backend != nil {
-		backend.BackendUUID = backendUUID
+		backend.SetBackendUUID(backendUUID)
 	}
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -918,6 +1316,7 @@ func (o *TridentOrchestrator) addBackend(configJSON, backendUUID string) (backen
 		return nil, err
 	}
 	o.backends[backend.BackendUUID] = backend
+	o.watchBackend(backend)
 
 	// Update storage class information
 	classes := make([]string, 0, len(o.storageClasses))
@@ -1011,7 +1410,8 @@ func (o *TridentOrchestrator) UpdateBackendByBackendUUID(backendName, configJSON
 }
 
 // TODO combine this one and the one above
-// updateBackendByBackendUUID updates an existing backend. It assumes the mutex lock is already held.
+// updateBackendByBackendUUID updates an existing backend. It assumes the mutex lock is already held, and
+// releases it while waiting for the operations running against the original backend to finish.
 func (o *TridentOrchestrator) updateBackendByBackendUUID(backendName, configJSON, backendUUID string) (
 	backendExternal *storage.BackendExternal, err error) {
 	var (
@@ -1052,10 +1452,37 @@ func (o *TridentOrchestrator) updateBackendByBackendUUID(backendName, configJSON
 	if err != nil {
 		return nil, err
 	}
-	backend.BackendUUID = backendUUID
+	backend.SetBackendUUID(backendUUID)
+	if err = o.validateBackendUpdate(originalBackend, backend); err != nil {
+		return nil, err
+	}
+
+	// Keep new operations from starting against the original backend, and wait for those already running
+	// to finish, so that its driver isn't replaced underneath them.  Running operations may need the lock
+	// to finish, so it's released while draining.  The original backend may have been replaced or changed
+	// meanwhile, so the update is validated again once the lock is reacquired.
+	if err = originalBackend.Fence(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			originalBackend.Reopen()
+		}
+	}()
+	o.mutex.Unlock()
+	err = originalBackend.Drain(storage.BackendDrainTimeout)
+	o.mutex.Lock()
+	if err != nil {
+		return nil, err
+	}
+	if currentBackend, ok := o.backends[backendUUID]; !ok || currentBackend != originalBackend {
+		err = fmt.Errorf("backend %v was changed while it was being updated, try again later", backendUUID)
+		return nil, err
+	}
 	if err = o.validateBackendUpdate(originalBackend, backend); err != nil {
 		return nil, err
 	}
+
 	log.WithFields(log.Fields{
 		"originalBackend.Name":        originalBackend.Name,
 		"originalBackend.BackendUUID": originalBackend.BackendUUID,
@@ -1121,6 +1548,7 @@ func (o *TridentOrchestrator) updateBackendByBackendUUID(backendName, configJSON
 	}
 	originalBackend.Terminate()
 	o.backends[backend.BackendUUID] = backend
+	o.watchBackend(backend)
 
 	// Update the volume state in memory
 	// Identify orphaned volumes (i.e., volumes that are not present on the
@@ -1240,41 +1668,27 @@ func (o *TridentOrchestrator) updateBackendState(backendName, backendState strin
 	return backend.ConstructExternal(), o.storeClient.UpdateBackend(backend)
 }
 
-func (o *TridentOrchestrator) getBackendUUIDByBackendName(backendName string) (string, error) {
-	backendUUID := ""
-	for _, b := range o.backends {
-		if b.Name == backendName {
-			backendUUID = b.BackendUUID
-			return backendUUID, nil
-		}
+// PreviewBackend resolves the storage pools that a backend would offer with the supplied config, so that
+// a config may be checked before a backend is created or updated with it.  No backend is created.
+func (o *TridentOrchestrator) PreviewBackend(configJSON string) (preview *storage.BackendPreview, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
 	}
-	return "", utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
-}
 
-func (o *TridentOrchestrator) getBackendByBackendName(backendName string) (*storage.Backend, error) {
-	for _, b := range o.backends {
-		if b.Name == backendName {
-			return b, nil
-		}
-	}
-	return nil, utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
-}
+	defer recordTiming("backend_preview", &err)()
 
-func (o *TridentOrchestrator) getBackendByBackendUUID(backendUUID string) (*storage.Backend, error) {
-	backend := o.backends[backendUUID]
-	if backend != nil {
-		return backend, nil
-	}
-	return nil, utils.NotFoundError(fmt.Sprintf("backend uuid %v was not found", backendUUID))
+	return factory.PreviewStorageBackendForConfig(configJSON)
 }
 
-func (o *TridentOrchestrator) GetBackend(backendName string) (
+// UpdateBackendAPITrace turns tracing of a backend's storage API calls on or off.  The setting is not
+// persisted, so it lasts until the backend is updated or Trident restarts.
+func (o *TridentOrchestrator) UpdateBackendAPITrace(backendName string, enabled bool) (
 	backendExternal *storage.BackendExternal, err error) {
 	if o.bootstrapError != nil {
 		return nil, o.bootstrapError
 	}
 
-	defer recordTiming("backend_get", &err)()
+	defer recordTiming("backend_update_api_trace", &err)()
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
@@ -1288,53 +1702,334 @@ func (o *TridentOrchestrator) GetBackend(backendName string) (
 		return nil, utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
 	}
 
-	backendExternal = backend.ConstructExternal()
-	log.WithFields(log.Fields{
-		"backend":               backend,
-		"backendExternal":       backendExternal,
-		"backendExternal.Name":  backendExternal.Name,
-		"backendExternal.State": backendExternal.State.String(),
-	}).Debug("GetBackend information.")
-	return backendExternal, nil
+	if err = backend.SetAPITraceEnabled(enabled); err != nil {
+		return nil, err
+	}
+
+	return backend.ConstructExternal(), nil
 }
 
-func (o *TridentOrchestrator) GetBackendByBackendUUID(backendUUID string) (
-	backendExternal *storage.BackendExternal, err error) {
+// UpdateBackendLogging changes a backend's debug trace flags, and the level at which messages about its
+// storage API calls are logged.  Like API tracing, the settings are not persisted.
+func (o *TridentOrchestrator) UpdateBackendLogging(
+	backendName string, request *storage.UpdateBackendLoggingRequest,
+) (backendExternal *storage.BackendExternal, err error) {
 	if o.bootstrapError != nil {
 		return nil, o.bootstrapError
 	}
 
-	defer recordTiming("backend_get", &err)()
+	defer recordTiming("backend_update_logging", &err)()
+
+	if err = request.Validate(); err != nil {
+		return nil, err
+	}
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	backend, err := o.getBackendByBackendUUID(backendUUID)
+	backend, err := o.getBackendByBackendName(backendName)
 	if err != nil {
 		return nil, err
 	}
 
-	backendExternal = backend.ConstructExternal()
-	log.WithFields(log.Fields{
-		"backend":               backend,
-		"backendUUID":           backendUUID,
-		"backendExternal":       backendExternal,
-		"backendExternal.Name":  backendExternal.Name,
-		"backendExternal.State": backendExternal.State.String(),
-	}).Debug("GetBackend information.")
-	return backendExternal, nil
+	if err = backend.UpdateLogging(request); err != nil {
+		return nil, err
+	}
+
+	return backend.ConstructExternal(), nil
 }
 
-func (o *TridentOrchestrator) ListBackends() (
-	backendExternals []*storage.BackendExternal, err error) {
+// ListRecoverableVolumes returns the deleted volumes in a backend's recovery queue.
+func (o *TridentOrchestrator) ListRecoverableVolumes(
+	ctx context.Context, backendName string,
+) (volumes []*storage.RecoverableVolume, err error) {
 	if o.bootstrapError != nil {
-		log.WithFields(log.Fields{
-			"bootstrapError": o.bootstrapError,
-		}).Warn("ListBackends error")
 		return nil, o.bootstrapError
 	}
 
-	defer recordTiming("backend_list", &err)()
+	defer recordTiming("volume_list_recoverable", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.ListRecoverableVolumes(ctx)
+}
+
+// RecoverVolume restores a deleted volume from a backend's recovery queue, undoing its deletion.  The
+// recovered volume isn't managed by Trident until it is imported.
+func (o *TridentOrchestrator) RecoverVolume(ctx context.Context, backendName, internalName string) (err error) {
+	if o.bootstrapError != nil {
+		return o.bootstrapError
+	}
+
+	defer recordTiming("volume_recover", &err)()
+
+	request := &storage.RecoverVolumeRequest{InternalName: internalName}
+	if err = request.Validate(); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return err
+	}
+
+	// A volume with the same name may have been imported or created since the deletion
+	for _, volume := range o.volumes {
+		if volume.BackendUUID == backend.BackendUUID && volume.Config.InternalName == internalName {
+			return utils.FoundError(fmt.Sprintf("volume %s already exists on backend %s", internalName,
+				backendName))
+		}
+	}
+
+	if err = backend.RecoverVolume(ctx, internalName); err != nil {
+		return fmt.Errorf("unable to recover volume %s: %w", internalName, err)
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":        backendName,
+		"volumeInternal": internalName,
+	}).Info("Orchestrator recovered the volume.")
+
+	return nil
+}
+
+// ListBackendVolumes returns one page of the volumes on a backend's storage system, whether or not they
+// belong to volumes known to Trident, continuing from the token returned with the previous page.
+func (o *TridentOrchestrator) ListBackendVolumes(
+	ctx context.Context, backendName string, limit int, continueToken string,
+) (page *storage.VolumePage, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_volume_list", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.ListVolumes(ctx, limit, continueToken)
+}
+
+// ListImportCandidates returns the volumes on a backend's storage system that don't belong to volumes
+// known to Trident and that could be imported, limited to those passing the filter.
+func (o *TridentOrchestrator) ListImportCandidates(
+	ctx context.Context, backendName string, filter *storage.ImportDiscoveryFilter,
+) (candidates []*storage.ImportCandidate, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("import_candidate_list", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.ListImportCandidates(ctx, filter)
+}
+
+// GetBackendStorageLogs returns the recent entries of the logs of a backend's storage system, such as its
+// job and event logs, newest first.
+func (o *TridentOrchestrator) GetBackendStorageLogs(
+	ctx context.Context, backendName string,
+) (entries []*storage.StorageLogEntry, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_storage_log_get", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.GetStorageLogs(ctx)
+}
+
+// GetBackendBuckets reports how a backend's volumes are distributed among the volumes on its storage
+// system holding them, such as the Flexvols holding qtrees.
+func (o *TridentOrchestrator) GetBackendBuckets(
+	ctx context.Context, backendName string,
+) (report *storage.BucketReport, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_bucket_get", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.GetBucketReport(ctx)
+}
+
+// ListAuditEvents returns the recorded calls that changed a storage system and match a filter, oldest first.
+func (o *TridentOrchestrator) ListAuditEvents(filter audit.Filter) (events []*audit.Event, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("audit_list", &err)()
+
+	return audit.Query(filter)
+}
+
+// ListStorageJobs returns the operations each backend is running in the background, such as clone
+// splits and volume moves, naming the volume each is working on if it is known to Trident.
+func (o *TridentOrchestrator) ListStorageJobs() (jobs []*storage.StorageJob, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("storage_job_list", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	jobs = make([]*storage.StorageJob, 0)
+	for _, backend := range o.backends {
+		for _, job := range backend.ListStorageJobs() {
+			for _, vol := range backend.Volumes {
+				if vol.Config.InternalName == job.InternalVolume {
+					job.Volume = vol.Config.Name
+					break
+				}
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Backend != jobs[j].Backend {
+			return jobs[i].Backend < jobs[j].Backend
+		}
+		return jobs[i].StartTime < jobs[j].StartTime
+	})
+	return jobs, nil
+}
+
+func (o *TridentOrchestrator) getBackendUUIDByBackendName(backendName string) (string, error) {
+	backendUUID := ""
+	for _, b := range o.backends {
+		if b.Name == backendName {
+			backendUUID = b.BackendUUID
+			return backendUUID, nil
+		}
+	}
+	return "", utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
+}
+
+func (o *TridentOrchestrator) getBackendByBackendName(backendName string) (*storage.Backend, error) {
+	for _, b := range o.backends {
+		if b.Name == backendName {
+			return b, nil
+		}
+	}
+	return nil, utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
+}
+
+func (o *TridentOrchestrator) getBackendByBackendUUID(backendUUID string) (*storage.Backend, error) {
+	backend := o.backends[backendUUID]
+	if backend != nil {
+		return backend, nil
+	}
+	return nil, utils.NotFoundError(fmt.Sprintf("backend uuid %v was not found", backendUUID))
+}
+
+func (o *TridentOrchestrator) GetBackend(backendName string) (
+	backendExternal *storage.BackendExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_get", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backendUUID, err := o.getBackendUUIDByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+	backend, found := o.backends[backendUUID]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %v was not found", backendName))
+	}
+
+	backendExternal = backend.ConstructExternal()
+	log.WithFields(log.Fields{
+		"backend":               backend,
+		"backendExternal":       backendExternal,
+		"backendExternal.Name":  backendExternal.Name,
+		"backendExternal.State": backendExternal.State.String(),
+	}).Debug("GetBackend information.")
+	return backendExternal, nil
+}
+
+func (o *TridentOrchestrator) GetBackendByBackendUUID(backendUUID string) (
+	backendExternal *storage.BackendExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_get", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	backend, err := o.getBackendByBackendUUID(backendUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	backendExternal = backend.ConstructExternal()
+	log.WithFields(log.Fields{
+		"backend":               backend,
+		"backendUUID":           backendUUID,
+		"backendExternal":       backendExternal,
+		"backendExternal.Name":  backendExternal.Name,
+		"backendExternal.State": backendExternal.State.String(),
+	}).Debug("GetBackend information.")
+	return backendExternal, nil
+}
+
+func (o *TridentOrchestrator) ListBackends() (
+	backendExternals []*storage.BackendExternal, err error) {
+	if o.bootstrapError != nil {
+		log.WithFields(log.Fields{
+			"bootstrapError": o.bootstrapError,
+		}).Warn("ListBackends error")
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_list", &err)()
 
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
@@ -1423,7 +2118,7 @@ func (o *TridentOrchestrator) deleteBackendByBackendUUID(backendName, backendUUI
 	return o.storeClient.UpdateBackend(backend)
 }
 
-func (o *TridentOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (
+func (o *TridentOrchestrator) AddVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (
 	externalVol *storage.VolumeExternal, err error) {
 
 	if o.bootstrapError != nil {
@@ -1438,27 +2133,45 @@ func (o *TridentOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (
 
 	volumeConfig.Version = config.OrchestratorAPIVersion
 
-	if _, ok := o.volumes[volumeConfig.Name]; ok {
+	// A volume created earlier may still be waiting for its data source to be written
+	if vol, ok := o.volumes[volumeConfig.Name]; ok {
+		if vol.State.IsPopulating() {
+			return o.populateVolume(ctx, vol)
+		}
 		return nil, fmt.Errorf("volume %s already exists", volumeConfig.Name)
 	}
 
+	// Refuse a data source no populator handles before creating anything
+	if volumeConfig.DataSource != nil {
+		if _, err = GetVolumePopulator(volumeConfig.DataSource); err != nil {
+			return nil, err
+		}
+	}
+
 	// If a volume is already being created, retry the operation with the same backend
 	// instead of continuing here and potentially starting over on a different backend.
 	// Otherwise, treat this as a new volume creation workflow.
-	if retryTxn, err := o.GetVolumeCreatingTransaction(volumeConfig); err != nil {
+	retryTxn, err := o.GetVolumeCreatingTransaction(volumeConfig)
+	if err != nil {
 		return nil, err
 	} else if retryTxn != nil {
-		return o.addVolumeRetry(retryTxn)
+		externalVol, err = o.addVolumeRetry(ctx, retryTxn)
+	} else {
+		externalVol, err = o.addVolumeInitial(ctx, volumeConfig)
+	}
+	if err != nil || !externalVol.State.IsPopulating() {
+		return externalVol, err
 	}
 
-	return o.addVolumeInitial(volumeConfig)
+	// The volume exists, so begin populating it
+	return o.populateVolume(ctx, o.volumes[externalVol.Config.Name])
 }
 
 // addVolumeInitial continues the volume creation operation.
 // This method should only be called from AddVolume, as it does not take locks or otherwise do much validation
 // of the volume config.
 func (o *TridentOrchestrator) addVolumeInitial(
-	volumeConfig *storage.VolumeConfig,
+	ctx context.Context, volumeConfig *storage.VolumeConfig,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	var (
@@ -1478,52 +2191,73 @@ func (o *TridentOrchestrator) addVolumeInitial(
 	if !ok {
 		return nil, fmt.Errorf("unknown storage class: %s", volumeConfig.StorageClass)
 	}
+	// Leave out backends that can't provide the requested access mode, saying why if none can
 	poolsByBackend := sc.GetStoragePoolsForProtocolByBackend(protocol)
+	poolsByBackend = filterPoolsByAccessMode(poolsByBackend, volumeConfig)
 	if len(poolsByBackend) == 0 {
+		if err = checkStorageClassAccessMode(sc, volumeConfig); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("no available backends for storage class %s", volumeConfig.StorageClass)
 	}
 
+	// Leave out pools too full to keep the free capacity requested by the storage class
+	sizeBytes, _ := strconv.ParseUint(volumeConfig.Size, 10, 64)
+	poolsByBackend = sc.FilterPoolsByCapacity(poolsByBackend, sizeBytes)
+	if len(poolsByBackend) == 0 {
+		return nil, fmt.Errorf("no storage pools for storage class %s have enough free capacity for volume %s",
+			volumeConfig.StorageClass, volumeConfig.Name)
+	}
+
+	// Leave out backends whose quotas the volume would exceed
+	if poolsByBackend, err = o.filterPoolsByQuota(poolsByBackend, volumeConfig, sizeBytes); err != nil {
+		return nil, err
+	}
+
+	// Leave out pools not accessible from the volume's requisite topology, and try the preferred ones first
+	poolsByBackend = sc.FilterPoolsByTopology(poolsByBackend, volumeConfig.RequisiteTopologies)
+	if len(poolsByBackend) == 0 {
+		return nil, fmt.Errorf("no storage pools for storage class %s are accessible from the topology "+
+			"requested for volume %s", volumeConfig.StorageClass, volumeConfig.Name)
+	}
+	poolsByBackend = sc.SortPoolsByTopology(poolsByBackend, volumeConfig.PreferredTopologies)
+
+	// Identify the request, so a retry can recognize anything left behind on the backend by this attempt
+	if volumeConfig.UUID == "" {
+		volumeConfig.UUID = uuid.New().String()
+	}
+
 	// Add a transaction to clean out any existing transactions
 	txn = &storage.VolumeTransaction{
 		Config: volumeConfig,
 		Op:     storage.AddVolume,
 	}
-	if err = o.AddVolumeTransaction(txn); err != nil {
+	if err = o.AddVolumeTransaction(ctx, txn); err != nil {
 		return nil, err
 	}
 
 	// Recovery functions in case of error
 	defer func() {
-		err = o.addVolumeCleanup(err, backend, vol, txn, volumeConfig)
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, volumeConfig)
 	}()
 	defer func() {
-		err = o.addVolumeRetryCleanup(err, backend, pool, vol, txn, volumeConfig)
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, volumeConfig)
 	}()
 
-	log.WithField("volume", volumeConfig.Name).Debugf("Looking through %d storage backends.", len(poolsByBackend))
+	utils.Logc(ctx).WithField("volume", volumeConfig.Name).Debugf("Looking through %d storage backends.", len(poolsByBackend))
 
 	errorMessages := make([]string, 0)
+	var sizeLimitErr, overprovisionedErr error
+	allSizeLimitErrors, allOverprovisionedErrors := true, true
 
 	// Keep trying until we run out of matching backends/pools
 	for len(poolsByBackend) > 0 {
 
-		// Choose a backend at random from the pool map
-		backendNames := make([]string, 0)
-		for backendName := range poolsByBackend {
-			backendNames = append(backendNames, backendName)
-		}
-		backendName := backendNames[rand.Intn(len(backendNames))]
-
-		// The pool lists are already shuffled, so just pick the first one from the chosen backend and
-		// pop it from the list.  If the backend has no more eligible pools, remove it from the map so
-		// the loop terminates when creation on all matching pools has failed.
-		pools := poolsByBackend[backendName].Pools
-		pool = pools[0]
-		if len(pools) == 1 {
-			delete(poolsByBackend, backendName)
-		} else {
-			poolsByBackend[backendName].Pools = pools[1:]
-		}
+		// Let the placement policy choose among the pools most preferred by topology.  The chosen pool is
+		// removed from the map, along with its backend if it has no more eligible pools, so the loop
+		// terminates when creation on all matching pools has failed.
+		pool = o.selectPool(sc, poolsByBackend, volumeConfig, sizeBytes)
+		backendName := pool.Backend.Name
 
 		// Add volume to the backend of the selected pool
 		backend = pool.Backend
@@ -1531,17 +2265,28 @@ func (o *TridentOrchestrator) addVolumeInitial(
 		// CreatePrepare has a side effect that updates the volumeConfig with the backend-specific internal name
 		backend.Driver.CreatePrepare(volumeConfig)
 
+		// Record where the volume will be accessible from, so that pods using it are scheduled near it
+		volumeConfig.AllowedTopologies = nil
+		if topology := pool.Topology(); len(topology) > 0 {
+			volumeConfig.AllowedTopologies = []map[string]string{topology}
+		}
+
 		// Update transaction with updated volumeConfig
 		txn = &storage.VolumeTransaction{
-			Config: volumeConfig,
-			Op:     storage.AddVolume,
+			Config:      volumeConfig,
+			Op:          storage.AddVolume,
+			Step:        storage.StepBackendSelected,
+			BackendUUID: backend.BackendUUID,
+			Pool:        pool.Name,
 		}
 		if err = o.storeClient.UpdateVolumeTransaction(txn); err != nil {
 			return nil, err
 		}
 
-		vol, err = backend.AddVolume(volumeConfig, pool, sc.GetAttributes(), false)
+		o.recordPoolSelectedEvent(volumeConfig.Name, pool)
+		vol, err = backend.AddVolume(ctx, volumeConfig, pool, sc.GetAttributes(), false)
 		if err != nil {
+			o.recordProvisioningAttemptEvent(volumeConfig.Name, pool, err)
 
 			logFields := log.Fields{
 				"backend":     backend.Name,
@@ -1554,12 +2299,25 @@ func (o *TridentOrchestrator) addVolumeInitial(
 			// If the volume is still creating, don't try another pool but let the cleanup logic
 			// save the state of this operation in a transaction.
 			if utils.IsVolumeCreatingError(err) {
-				log.WithFields(logFields).Warn("Volume still creating on this backend.")
+				utils.Logc(ctx).WithFields(logFields).Warn("Volume still creating on this backend.")
 				return nil, err
 			}
 
 			// Log failure and continue for loop to find a backend that can create the volume.
-			log.WithFields(logFields).Warn("Failed to create the volume on this backend.")
+			if drivers.IsVolumeSizeLimitError(err) {
+				utils.Logc(ctx).WithFields(logFields).Warn("Volume size exceeds the limit of this storage pool.")
+				sizeLimitErr = err
+				allOverprovisionedErrors = false
+			} else if drivers.IsPoolOverprovisionedError(err) {
+				utils.Logc(ctx).WithFields(logFields).Warn(
+					"Volume would exceed the overprovisioning limit of this storage pool.")
+				overprovisionedErr = err
+				allSizeLimitErrors = false
+			} else {
+				utils.Logc(ctx).WithFields(logFields).Warn("Failed to create the volume on this backend.")
+				allSizeLimitErrors = false
+				allOverprovisionedErrors = false
+			}
 			errorMessages = append(errorMessages,
 				fmt.Sprintf("[Failed to create volume %s on storage pool %s from backend %s: %s]",
 					volumeConfig.Name, pool.Name, backend.Name, err.Error()))
@@ -1579,7 +2337,7 @@ func (o *TridentOrchestrator) addVolumeInitial(
 			}
 		} else {
 			// Volume creation succeeded, so register it and return the result
-			return o.addVolumeFinish(txn, vol, backend)
+			return o.addVolumeFinish(ctx, txn, vol, backend)
 		}
 	}
 
@@ -1587,6 +2345,12 @@ func (o *TridentOrchestrator) addVolumeInitial(
 	if len(errorMessages) == 0 {
 		err = fmt.Errorf("no suitable %s backend with \"%s\" storage class and %s of free space was found",
 			protocol, volumeConfig.StorageClass, volumeConfig.Size)
+	} else if sizeLimitErr != nil && allSizeLimitErrors {
+		// Every matching pool rejected the size, so preserve the typed error for the caller
+		err = sizeLimitErr
+	} else if overprovisionedErr != nil && allOverprovisionedErrors {
+		// Every matching pool was too overprovisioned, so preserve the typed error for the caller
+		err = overprovisionedErr
 	} else {
 		err = fmt.Errorf("encountered error(s) in creating the volume: %s", strings.Join(errorMessages, ", "))
 	}
@@ -1597,7 +2361,7 @@ func (o *TridentOrchestrator) addVolumeInitial(
 // This method should only be called from AddVolume, as it does not take locks or otherwise do much validation
 // of the volume config.
 func (o *TridentOrchestrator) addVolumeRetry(
-	txn *storage.VolumeTransaction,
+	ctx context.Context, txn *storage.VolumeTransaction,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	var (
@@ -1627,14 +2391,15 @@ func (o *TridentOrchestrator) addVolumeRetry(
 
 	// Recovery functions in case of error
 	defer func() {
-		err = o.addVolumeCleanup(err, backend, vol, txn, volumeConfig)
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, volumeConfig)
 	}()
 	defer func() {
-		err = o.addVolumeRetryCleanup(err, backend, pool, vol, txn, volumeConfig)
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, volumeConfig)
 	}()
 
-	vol, err = backend.AddVolume(volumeConfig, pool, make(map[string]sa.Request), true)
+	vol, err = backend.AddVolume(ctx, volumeConfig, pool, make(map[string]sa.Request), true)
 	if err != nil {
+		o.recordProvisioningAttemptEvent(volumeConfig.Name, pool, err)
 
 		logFields := log.Fields{
 			"backend":     backend.Name,
@@ -1647,22 +2412,22 @@ func (o *TridentOrchestrator) addVolumeRetry(
 		// If the volume is still creating, don't try another pool but let the cleanup logic
 		// save the state of this operation in a transaction.
 		if utils.IsVolumeCreatingError(err) {
-			log.WithFields(logFields).Warn("Volume still creating on this backend.")
+			utils.Logc(ctx).WithFields(logFields).Warn("Volume still creating on this backend.")
 		} else {
-			log.WithFields(logFields).Error("addVolumeRetry failed on this backend.")
+			utils.Logc(ctx).WithFields(logFields).Error("addVolumeRetry failed on this backend.")
 		}
 
 		return nil, err
 	}
 
 	// Volume creation succeeded, so register it and return the result
-	return o.addVolumeFinish(txn, vol, backend)
+	return o.addVolumeFinish(ctx, txn, vol, backend)
 }
 
 // addVolumeFinish is called after successful completion of a volume create/clone operation
 // to save the volume in the persistent store as well as Trident's in-memory cache.
 func (o *TridentOrchestrator) addVolumeFinish(
-	txn *storage.VolumeTransaction, vol *storage.Volume, backend *storage.Backend,
+	ctx context.Context, txn *storage.VolumeTransaction, vol *storage.Volume, backend *storage.Backend,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	recordTransactionTiming(txn, &err)
@@ -1671,19 +2436,38 @@ func (o *TridentOrchestrator) addVolumeFinish(
 		vol.Config.Protocol = backend.GetProtocol()
 	}
 
-	// Add new volume to persistent store
-	if err = o.storeClient.AddVolume(vol); err != nil {
+	// Record that the volume exists on the backend, so that it may be kept rather than destroyed should
+	// Trident restart before the volume is saved
+	if txn.Op == storage.AddVolume {
+		txn.Config = vol.Config
+		if err = o.recordVolumeOperationStep(txn, storage.StepBackendCreated, backend.BackendUUID,
+			vol.Pool); err != nil {
+			return nil, err
+		}
+	}
+
+	// A volume with a data source isn't usable until its populator has written the source to it
+	if vol.Config.DataSource != nil {
+		vol.State = storage.VolumeStatePopulating
+	}
+
+	// Add new volume to persistent store, deleting its transaction in the same batch
+	batch := persistentstore.NewBatch()
+	batch.PutVolume(vol)
+	batch.DeleteVolumeTransaction(txn)
+	if err = o.commitToPersistentStore(batch); err != nil {
 		return nil, err
 	}
 
 	// Update internal cache and return external form of the new volume
 	o.volumes[vol.Config.Name] = vol
+	o.recordCloneSplitStartedEvent(vol, backend)
 	externalVol = vol.ConstructExternal()
 	return externalVol, nil
 }
 
 func (o *TridentOrchestrator) CloneVolume(
-	volumeConfig *storage.VolumeConfig,
+	ctx context.Context, volumeConfig *storage.VolumeConfig,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	if o.bootstrapError != nil {
@@ -1699,6 +2483,10 @@ func (o *TridentOrchestrator) CloneVolume(
 	if _, ok := o.volumes[volumeConfig.Name]; ok {
 		return nil, fmt.Errorf("volume %s already exists", volumeConfig.Name)
 	}
+	if volumeConfig.DataSource != nil {
+		return nil, fmt.Errorf("volume %s cannot be both cloned and populated from a data source",
+			volumeConfig.Name)
+	}
 
 	volumeConfig.Version = config.OrchestratorAPIVersion
 
@@ -1708,14 +2496,14 @@ func (o *TridentOrchestrator) CloneVolume(
 	if retryTxn, err := o.GetVolumeCreatingTransaction(volumeConfig); err != nil {
 		return nil, err
 	} else if retryTxn != nil {
-		return o.cloneVolumeRetry(retryTxn)
+		return o.cloneVolumeRetry(ctx, retryTxn)
 	}
 
-	return o.cloneVolumeInitial(volumeConfig)
+	return o.cloneVolumeInitial(ctx, volumeConfig)
 }
 
 func (o *TridentOrchestrator) cloneVolumeInitial(
-	volumeConfig *storage.VolumeConfig,
+	ctx context.Context, volumeConfig *storage.VolumeConfig,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	var (
@@ -1731,6 +2519,23 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 		return nil, utils.NotFoundError(fmt.Sprintf("source volume not found: %s", volumeConfig.CloneSourceVolume))
 	}
 
+	// A volume may only be cloned into another namespace if its own namespace permits it.  A volume created
+	// before Trident recorded namespaces is in the namespace in which the request found its claim.
+	sourceConfig := *sourceVolume.Config
+	if sourceConfig.Namespace == "" {
+		sourceConfig.Namespace = volumeConfig.CloneSourceNamespace
+	}
+	if !sourceConfig.PermitsCloneTo(volumeConfig.Namespace) {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"source_volume":    sourceConfig.Name,
+			"source_namespace": sourceConfig.Namespace,
+			"volume":           volumeConfig.Name,
+			"namespace":        volumeConfig.Namespace,
+		}).Error("Clone source volume does not permit cloning into the requested namespace.")
+		return nil, utils.CloneNotPermittedError(fmt.Sprintf("namespace %s does not permit volume %s to be "+
+			"cloned into namespace %s", sourceConfig.Namespace, sourceConfig.Name, volumeConfig.Namespace))
+	}
+
 	if volumeConfig.Size != "" {
 		cloneSourceVolumeSize, err := strconv.ParseInt(sourceVolume.Config.Size, 10, 64)
 		if err != nil {
@@ -1743,7 +2548,7 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 		}
 
 		if cloneSourceVolumeSize < cloneVolumeSize {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"source_volume": sourceVolume.Config.Name,
 				"volume":        volumeConfig.Name,
 				"backendUUID":   sourceVolume.BackendUUID,
@@ -1754,7 +2559,7 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 	}
 
 	if sourceVolume.Orphaned {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"source_volume": sourceVolume.Config.Name,
 			"volume":        volumeConfig.Name,
 			"backendUUID":   sourceVolume.BackendUUID,
@@ -1768,11 +2573,36 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 			volumeConfig.VolumeMode))
 	}
 
-	// Get the source backend
-	if backend, found = o.backends[sourceVolume.BackendUUID]; !found {
-		// Should never get here but just to be safe
-		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
-			sourceVolume.BackendUUID, volumeConfig.CloneSourceVolume))
+	// Cloning a snapshot that is waiting to be deleted would keep it from ever being deleted
+	if volumeConfig.CloneSourceSnapshot != "" {
+		snapshotID := storage.MakeSnapshotID(volumeConfig.CloneSourceVolume, volumeConfig.CloneSourceSnapshot)
+		if snapshot, ok := o.snapshots[snapshotID]; ok && snapshot.State.IsDeleting() {
+			return nil, utils.NotFoundError(fmt.Sprintf("source snapshot %s is being deleted",
+				volumeConfig.CloneSourceSnapshot))
+		}
+	}
+
+	// Get the source backend
+	if backend, found = o.backends[sourceVolume.BackendUUID]; !found {
+		// Should never get here but just to be safe
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
+			sourceVolume.BackendUUID, volumeConfig.CloneSourceVolume))
+	}
+
+	// A clone requested in a storage class with no pools on the source's backend is copied to another
+	// backend instead
+	if sc, ok := o.storageClasses[volumeConfig.StorageClass]; ok && needsVolumeCopy(sc, backend) {
+		return o.copyVolumeInitial(ctx, volumeConfig, sourceVolume, backend, sc)
+	}
+
+	if err = checkAccessMode(backend, volumeConfig); err != nil {
+		return nil, err
+	}
+
+	// A clone takes as much space as its source
+	sourceSizeBytes, _ := strconv.ParseUint(sourceVolume.Config.Size, 10, 64)
+	if err = o.checkVolumeQuotas(volumeConfig, backend.Name, sourceSizeBytes); err != nil {
+		return nil, err
 	}
 
 	pool = storage.NewStoragePool(backend, "")
@@ -1788,6 +2618,17 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 	cloneConfig.CloneSourceSnapshot = volumeConfig.CloneSourceSnapshot
 	cloneConfig.QoS = volumeConfig.QoS
 	cloneConfig.QoSType = volumeConfig.QoSType
+	cloneConfig.UUID = volumeConfig.UUID
+	if cloneConfig.UUID == "" {
+		cloneConfig.UUID = uuid.New().String()
+	}
+
+	// The clone belongs to the namespace that requested it, which needn't be its source's, and permits only
+	// the namespaces that request lists to clone it in turn
+	if volumeConfig.Namespace != "" {
+		cloneConfig.Namespace = volumeConfig.Namespace
+	}
+	cloneConfig.CloneToNamespaces = volumeConfig.CloneToNamespaces
 
 	// Override this value only if SplitOnClone has been defined in clone volume's config
 	if volumeConfig.SplitOnClone != "" {
@@ -1813,23 +2654,26 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 
 	// Add transaction in case the operation must be rolled back later
 	txn = &storage.VolumeTransaction{
-		Config: cloneConfig,
-		Op:     storage.AddVolume,
+		Config:      cloneConfig,
+		Op:          storage.AddVolume,
+		Step:        storage.StepBackendSelected,
+		BackendUUID: backend.BackendUUID,
+		Pool:        pool.Name,
 	}
-	if err = o.AddVolumeTransaction(txn); err != nil {
+	if err = o.AddVolumeTransaction(ctx, txn); err != nil {
 		return nil, err
 	}
 
 	// Recovery functions in case of error
 	defer func() {
-		err = o.addVolumeCleanup(err, backend, vol, txn, cloneConfig)
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, cloneConfig)
 	}()
 	defer func() {
-		err = o.addVolumeRetryCleanup(err, backend, pool, vol, txn, cloneConfig)
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, cloneConfig)
 	}()
 
 	// Create the clone
-	if vol, err = backend.CloneVolume(cloneConfig, pool, false); err != nil {
+	if vol, err = backend.CloneVolume(ctx, cloneConfig, pool, false); err != nil {
 
 		logFields := log.Fields{
 			"backend":      backend.Name,
@@ -1842,21 +2686,21 @@ func (o *TridentOrchestrator) cloneVolumeInitial(
 		// If the volume is still creating, return the error to let the cleanup logic
 		// save the state of this operation in a transaction.
 		if utils.IsVolumeCreatingError(err) {
-			log.WithFields(logFields).Warn("Volume still creating on this backend.")
+			utils.Logc(ctx).WithFields(logFields).Warn("Volume still creating on this backend.")
 			return nil, err
 		}
 
-		log.WithFields(logFields).Warn("Failed to create cloned volume on this backend.")
-		return nil, fmt.Errorf("failed to create cloned volume %s on backend %s: %v",
+		utils.Logc(ctx).WithFields(logFields).Warn("Failed to create cloned volume on this backend.")
+		return nil, fmt.Errorf("failed to create cloned volume %s on backend %s: %w",
 			cloneConfig.Name, backend.Name, err)
 	}
 
 	// Volume creation succeeded, so register it and return the result
-	return o.addVolumeFinish(txn, vol, backend)
+	return o.addVolumeFinish(ctx, txn, vol, backend)
 }
 
 func (o *TridentOrchestrator) cloneVolumeRetry(
-	txn *storage.VolumeTransaction,
+	ctx context.Context, txn *storage.VolumeTransaction,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	var (
@@ -1878,6 +2722,11 @@ func (o *TridentOrchestrator) cloneVolumeRetry(
 			txn.VolumeCreatingConfig.BackendUUID, cloneConfig.Name))
 	}
 
+	// A clone on another backend than its source is being copied there
+	if sourceVolume, ok := o.volumes[cloneConfig.CloneSourceVolume]; ok && sourceVolume.BackendUUID != backend.BackendUUID {
+		return o.copyVolumeRetry(ctx, txn, backend, sourceVolume)
+	}
+
 	// Try to place the cloned volume in the same pool as the source.  This doesn't always work,
 	// as may be the case with imported volumes or virtual pools, so drivers must tolerate a nil
 	// or non-existent pool.
@@ -1893,14 +2742,14 @@ func (o *TridentOrchestrator) cloneVolumeRetry(
 
 	// Recovery functions in case of error
 	defer func() {
-		err = o.addVolumeCleanup(err, backend, vol, txn, cloneConfig)
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, cloneConfig)
 	}()
 	defer func() {
-		err = o.addVolumeRetryCleanup(err, backend, pool, vol, txn, cloneConfig)
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, cloneConfig)
 	}()
 
 	// Create the clone
-	if vol, err = backend.CloneVolume(cloneConfig, pool, true); err != nil {
+	if vol, err = backend.CloneVolume(ctx, cloneConfig, pool, true); err != nil {
 
 		logFields := log.Fields{
 			"backend":      backend.Name,
@@ -1913,16 +2762,203 @@ func (o *TridentOrchestrator) cloneVolumeRetry(
 		// If the volume is still creating, let the cleanup logic save the state
 		// of this operation in a transaction.
 		if utils.IsVolumeCreatingError(err) {
-			log.WithFields(logFields).Warn("Volume still creating on this backend.")
+			utils.Logc(ctx).WithFields(logFields).Warn("Volume still creating on this backend.")
 		} else {
-			log.WithFields(logFields).Error("addVolumeRetry failed on this backend.")
+			utils.Logc(ctx).WithFields(logFields).Error("addVolumeRetry failed on this backend.")
 		}
 
 		return nil, err
 	}
 
 	// Volume creation succeeded, so register it and return the result
-	return o.addVolumeFinish(txn, vol, backend)
+	return o.addVolumeFinish(ctx, txn, vol, backend)
+}
+
+// needsVolumeCopy returns true if a clone requested in a storage class must be copied from its source's
+// backend, because the class has pools but none of them on that backend.
+func needsVolumeCopy(sc *storageclass.StorageClass, sourceBackend *storage.Backend) bool {
+	pools := sc.Pools()
+	for _, pool := range pools {
+		if pool.Backend.BackendUUID == sourceBackend.BackendUUID {
+			return false
+		}
+	}
+	return len(pools) > 0
+}
+
+// copyVolumeInitial begins creating a clone on one of the storage class's backends by copying its source
+// from another backend.  The copy is made by the storage systems, so only backends whose storage system
+// can copy data laid out as on the source's are eligible.
+func (o *TridentOrchestrator) copyVolumeInitial(
+	ctx context.Context, volumeConfig *storage.VolumeConfig, sourceVolume *storage.Volume,
+	sourceBackend *storage.Backend, sc *storageclass.StorageClass,
+) (externalVol *storage.VolumeExternal, err error) {
+
+	var (
+		backend *storage.Backend
+		vol     *storage.Volume
+		pool    *storage.Pool
+		txn     *storage.VolumeTransaction
+	)
+
+	protocol, err := o.getProtocol(volumeConfig.VolumeMode, volumeConfig.AccessMode, volumeConfig.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	// The copy holds the source's data, so it must be as large and keep the source's filesystem
+	if volumeConfig.Size == "" {
+		volumeConfig.Size = sourceVolume.Config.Size
+	}
+	volumeConfig.FileSystem = sourceVolume.Config.FileSystem
+	volumeConfig.CloneSourceVolumeInternal = sourceVolume.Config.InternalName
+	volumeConfig.InternalName = ""
+	if volumeConfig.UUID == "" {
+		volumeConfig.UUID = uuid.New().String()
+	}
+
+	poolsByBackend := sc.GetStoragePoolsForProtocolByBackend(protocol)
+	poolsByBackend = filterPoolsByAccessMode(poolsByBackend, volumeConfig)
+	sizeBytes, _ := strconv.ParseUint(volumeConfig.Size, 10, 64)
+	poolsByBackend = sc.FilterPoolsByCapacity(poolsByBackend, sizeBytes)
+	if poolsByBackend, err = o.filterPoolsByQuota(poolsByBackend, volumeConfig, sizeBytes); err != nil {
+		return nil, err
+	}
+	poolsByBackend = sc.FilterPoolsByTopology(poolsByBackend, volumeConfig.RequisiteTopologies)
+	poolsByBackend = sc.SortPoolsByTopology(poolsByBackend, volumeConfig.PreferredTopologies)
+	if len(poolsByBackend) == 0 {
+		return nil, fmt.Errorf("no storage pools for storage class %s can hold a copy of volume %s",
+			volumeConfig.StorageClass, volumeConfig.CloneSourceVolume)
+	}
+
+	txn = &storage.VolumeTransaction{
+		Config: volumeConfig,
+		Op:     storage.AddVolume,
+	}
+	if err = o.AddVolumeTransaction(ctx, txn); err != nil {
+		return nil, err
+	}
+
+	// Recovery functions in case of error
+	defer func() {
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, volumeConfig)
+	}()
+	defer func() {
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, volumeConfig)
+	}()
+
+	errorMessages := make([]string, 0)
+
+	// Keep trying until we run out of matching backends/pools
+	for len(poolsByBackend) > 0 {
+
+		pool = o.selectPool(sc, poolsByBackend, volumeConfig, sizeBytes)
+		backend = pool.Backend
+
+		backend.Driver.CreatePrepare(volumeConfig)
+
+		volumeConfig.AllowedTopologies = nil
+		if topology := pool.Topology(); len(topology) > 0 {
+			volumeConfig.AllowedTopologies = []map[string]string{topology}
+		}
+
+		txn = &storage.VolumeTransaction{
+			Config:      volumeConfig,
+			Op:          storage.AddVolume,
+			Step:        storage.StepBackendSelected,
+			BackendUUID: backend.BackendUUID,
+			Pool:        pool.Name,
+		}
+		if err = o.storeClient.UpdateVolumeTransaction(txn); err != nil {
+			return nil, err
+		}
+
+		vol, err = backend.CopyVolume(ctx, volumeConfig, pool, sourceBackend, sourceVolume.Config, false)
+		if err == nil {
+			return o.addVolumeFinish(ctx, txn, vol, backend)
+		}
+
+		logFields := log.Fields{
+			"backend":       backend.Name,
+			"pool":          pool.Name,
+			"volume":        volumeConfig.Name,
+			"sourceVolume":  volumeConfig.CloneSourceVolume,
+			"sourceBackend": sourceBackend.Name,
+			"error":         err,
+		}
+
+		// If the copy is still running, let the cleanup logic save the state of this operation
+		if utils.IsVolumeCreatingError(err) {
+			utils.Logc(ctx).WithFields(logFields).Info("Volume still being copied to this backend.")
+			return nil, err
+		}
+
+		utils.Logc(ctx).WithFields(logFields).Warn("Failed to copy volume to this backend.")
+		errorMessages = append(errorMessages, fmt.Sprintf("[Failed to copy volume %s to storage pool %s "+
+			"from backend %s: %s]", volumeConfig.Name, pool.Name, backend.Name, err.Error()))
+
+		// A backend unable to copy from the source won't manage it on another pool
+		if utils.IsUnsupportedError(err) {
+			delete(poolsByBackend, backend.Name)
+		}
+	}
+
+	return nil, fmt.Errorf("could not copy volume %s from backend %s to storage class %s: %s",
+		volumeConfig.CloneSourceVolume, sourceBackend.Name, volumeConfig.StorageClass,
+		strings.Join(errorMessages, ", "))
+}
+
+// copyVolumeRetry continues copying a clone from its source's backend, after the copy outlasted an
+// earlier request.
+func (o *TridentOrchestrator) copyVolumeRetry(
+	ctx context.Context, txn *storage.VolumeTransaction, backend *storage.Backend, sourceVolume *storage.Volume,
+) (externalVol *storage.VolumeExternal, err error) {
+
+	var (
+		vol  *storage.Volume
+		pool *storage.Pool
+	)
+
+	volumeConfig := &txn.VolumeCreatingConfig.VolumeConfig
+
+	sourceBackend, found := o.backends[sourceVolume.BackendUUID]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
+			sourceVolume.BackendUUID, volumeConfig.CloneSourceVolume))
+	}
+
+	if pool, found = backend.Storage[txn.VolumeCreatingConfig.Pool]; !found {
+		pool = storage.NewStoragePool(backend, "")
+	}
+
+	// Recovery functions in case of error
+	defer func() {
+		err = o.addVolumeCleanup(ctx, err, backend, vol, txn, volumeConfig)
+	}()
+	defer func() {
+		err = o.addVolumeRetryCleanup(ctx, err, backend, pool, vol, txn, volumeConfig)
+	}()
+
+	if vol, err = backend.CopyVolume(ctx, volumeConfig, pool, sourceBackend, sourceVolume.Config, true); err != nil {
+
+		logFields := log.Fields{
+			"backend":       backend.Name,
+			"volume":        volumeConfig.Name,
+			"sourceVolume":  volumeConfig.CloneSourceVolume,
+			"sourceBackend": sourceBackend.Name,
+			"error":         err,
+		}
+
+		if utils.IsVolumeCreatingError(err) {
+			utils.Logc(ctx).WithFields(logFields).Debug("Volume still being copied to this backend.")
+		} else {
+			utils.Logc(ctx).WithFields(logFields).Error("copyVolumeRetry failed on this backend.")
+		}
+
+		return nil, err
+	}
+
+	return o.addVolumeFinish(ctx, txn, vol, backend)
 }
 
 // This func is used by volume import so it doesn't check core's o.volumes to see if the
@@ -2005,6 +3041,9 @@ func (o *TridentOrchestrator) validateImportVolume(volumeConfig *storage.VolumeC
 			"with the backend %s", volumeConfig.VolumeMode, volumeConfig.AccessMode,
 			volumeConfig.Protocol, backend.Name)
 	}
+	if err = checkAccessMode(backend, volumeConfig); err != nil {
+		return err
+	}
 
 	// For `Any` protocol make it same as the requested backend's protocol
 	if len(volumeConfig.Protocol) == 0 {
@@ -2022,7 +3061,7 @@ func (o *TridentOrchestrator) validateImportVolume(volumeConfig *storage.VolumeC
 }
 
 func (o *TridentOrchestrator) LegacyImportVolume(
-	volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback,
+	ctx context.Context, volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	if o.bootstrapError != nil {
@@ -2035,7 +3074,7 @@ func (o *TridentOrchestrator) LegacyImportVolume(
 	defer o.mutex.Unlock()
 	defer o.updateMetrics()
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"volumeConfig": volumeConfig,
 		"backendName":  backendName,
 	}).Debug("Orchestrator#ImportVolume")
@@ -2055,16 +3094,16 @@ func (o *TridentOrchestrator) LegacyImportVolume(
 		Config: volumeConfig,
 		Op:     storage.ImportVolume,
 	}
-	if err = o.AddVolumeTransaction(volTxn); err != nil {
+	if err = o.AddVolumeTransaction(ctx, volTxn); err != nil {
 		return nil, fmt.Errorf("failed to add volume transaction: %v", err)
 	}
 
 	// Recover function in case or error
 	defer func() {
-		err = o.importVolumeCleanup(err, volumeConfig, volTxn)
+		err = o.importVolumeCleanup(ctx, err, volumeConfig, volTxn)
 	}()
 
-	volume, err := backend.ImportVolume(volumeConfig)
+	volume, err := backend.ImportVolume(ctx, volumeConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import volume %s on backend %s: %v",
 			volumeConfig.ImportOriginalName, backendName, err)
@@ -2086,7 +3125,7 @@ func (o *TridentOrchestrator) LegacyImportVolume(
 		return nil, fmt.Errorf("unable to determine driver type from volume %v", volExternal)
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backendUUID":    volExternal.BackendUUID,
 		"name":           volExternal.Config.Name,
 		"internalName":   volExternal.Config.InternalName,
@@ -2104,7 +3143,7 @@ func (o *TridentOrchestrator) LegacyImportVolume(
 	// This function includes cleanup logic. Thus it must be called at the end of the ImportVolume func.
 	err = createPVandPVC(volExternal, driverType)
 	if err != nil {
-		log.Errorf("error occurred while creating PV and PVC %s: %v", volExternal.Config.Name, err)
+		utils.Logc(ctx).Errorf("error occurred while creating PV and PVC %s: %v", volExternal.Config.Name, err)
 		return nil, err
 	}
 
@@ -2112,7 +3151,7 @@ func (o *TridentOrchestrator) LegacyImportVolume(
 }
 
 func (o *TridentOrchestrator) ImportVolume(
-	volumeConfig *storage.VolumeConfig,
+	ctx context.Context, volumeConfig *storage.VolumeConfig,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	if o.bootstrapError != nil {
@@ -2131,7 +3170,7 @@ func (o *TridentOrchestrator) ImportVolume(
 	defer o.mutex.Unlock()
 	defer o.updateMetrics()
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"volumeConfig": volumeConfig,
 		"backendUUID":  volumeConfig.ImportBackendUUID,
 	}).Debug("Orchestrator#ImportVolume")
@@ -2151,16 +3190,16 @@ func (o *TridentOrchestrator) ImportVolume(
 		Config: volumeConfig,
 		Op:     storage.ImportVolume,
 	}
-	if err = o.AddVolumeTransaction(volTxn); err != nil {
+	if err = o.AddVolumeTransaction(ctx, volTxn); err != nil {
 		return nil, fmt.Errorf("failed to add volume transaction: %v", err)
 	}
 
 	// Recover function in case or error
 	defer func() {
-		err = o.importVolumeCleanup(err, volumeConfig, volTxn)
+		err = o.importVolumeCleanup(ctx, err, volumeConfig, volTxn)
 	}()
 
-	volume, err := backend.ImportVolume(volumeConfig)
+	volume, err := backend.ImportVolume(ctx, volumeConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import volume %s on backend %s: %v", volumeConfig.ImportOriginalName,
 			volumeConfig.ImportBackendUUID, err)
@@ -2179,7 +3218,7 @@ func (o *TridentOrchestrator) ImportVolume(
 		return nil, fmt.Errorf("unable to determine driver type from volume %v", volExternal)
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        volExternal.Backend,
 		"name":           volExternal.Config.Name,
 		"internalName":   volExternal.Config.InternalName,
@@ -2200,7 +3239,7 @@ func (o *TridentOrchestrator) ImportVolume(
 // AddVolumeTransaction is called from the volume create, clone, and resize
 // methods to save a record of the operation in case it fails and must be
 // cleaned up later.
-func (o *TridentOrchestrator) AddVolumeTransaction(volTxn *storage.VolumeTransaction) error {
+func (o *TridentOrchestrator) AddVolumeTransaction(ctx context.Context, volTxn *storage.VolumeTransaction) error {
 
 	// Check if a transaction already exists for this volume. This condition
 	// can occur if we failed to clean up the transaction object during the
@@ -2218,12 +3257,13 @@ func (o *TridentOrchestrator) AddVolumeTransaction(volTxn *storage.VolumeTransac
 	// log a new transaction in the persistent store and proceed.
 	oldTxn, err := o.storeClient.GetExistingVolumeTransaction(volTxn)
 	if err != nil {
-		log.Errorf("Unable to check for a preexisting volume transaction: %v", err)
+		utils.Logc(ctx).Errorf("Unable to check for a preexisting volume transaction: %v", err)
 		return err
 	}
 	if oldTxn != nil {
-		if oldTxn.Op != storage.UpgradeVolume && oldTxn.Op != storage.VolumeCreating {
-			err = o.handleFailedTransaction(oldTxn)
+		if oldTxn.Op != storage.UpgradeVolume && oldTxn.Op != storage.VolumeCreating &&
+			oldTxn.Op != storage.VolumeMigrating {
+			err = o.handleFailedTransaction(ctx, oldTxn)
 			if err != nil {
 				return fmt.Errorf("unable to process the preexisting transaction "+
 					"for volume %s:  %v", volTxn.Config.Name, err)
@@ -2274,10 +3314,23 @@ func (o *TridentOrchestrator) DeleteVolumeTransaction(volTxn *storage.VolumeTran
 	return o.storeClient.DeleteVolumeTransaction(volTxn)
 }
 
+// deleteVolumeTransactionIfPresent deletes a volume transaction unless it is already gone, as it is once
+// an operation has committed its outcome to the persistent store.
+func (o *TridentOrchestrator) deleteVolumeTransactionIfPresent(volTxn *storage.VolumeTransaction) error {
+	if existingTxn, err := o.storeClient.GetExistingVolumeTransaction(volTxn); err != nil {
+		return err
+	} else if existingTxn == nil {
+		return nil
+	}
+	return o.DeleteVolumeTransaction(volTxn)
+}
+
 // addVolumeCleanup is used as a deferred method from the volume create/clone methods
-// to clean up in case anything goes wrong during the operation.
+// to clean up in case anything goes wrong during the operation.  The cleanup must finish
+// even if the request was canceled or timed out, which is often why it failed, so it uses
+// a context detached from the request that still logs the request ID.
 func (o *TridentOrchestrator) addVolumeCleanup(
-	err error, backend *storage.Backend, vol *storage.Volume, volTxn *storage.VolumeTransaction,
+	ctx context.Context, err error, backend *storage.Backend, vol *storage.Volume, volTxn *storage.VolumeTransaction,
 	volumeConfig *storage.VolumeConfig,
 ) error {
 
@@ -2285,6 +3338,8 @@ func (o *TridentOrchestrator) addVolumeCleanup(
 		cleanupErr, txErr error
 	)
 
+	ctx = utils.DetachedContext(ctx)
+
 	// If in a retry situation, we already handled the error in addVolumeRetryCleanup.
 	if utils.IsVolumeCreatingError(err) {
 		return err
@@ -2300,7 +3355,7 @@ func (o *TridentOrchestrator) addVolumeCleanup(
 		if backend != nil && vol != nil {
 			// We succeeded in adding the volume to the backend; now
 			// delete it.
-			cleanupErr = backend.RemoveVolume(vol.Config)
+			cleanupErr = backend.RemoveVolume(ctx, vol.Config)
 			if cleanupErr != nil {
 				cleanupErr = fmt.Errorf("unable to delete volume "+
 					"from backend during cleanup:  %v", cleanupErr)
@@ -2310,8 +3365,8 @@ func (o *TridentOrchestrator) addVolumeCleanup(
 	if cleanupErr == nil {
 		// Only clean up the volume transaction if we've succeeded at
 		// cleaning up on the backend or if we didn't need to do so in the
-		// first place.
-		txErr = o.DeleteVolumeTransaction(volTxn)
+		// first place.  A successful add already deleted it.
+		txErr = o.deleteVolumeTransactionIfPresent(volTxn)
 		if txErr != nil {
 			txErr = fmt.Errorf("unable to clean up add volume transaction:  %v", txErr)
 		}
@@ -2329,7 +3384,7 @@ func (o *TridentOrchestrator) addVolumeCleanup(
 			}
 		}
 		err = fmt.Errorf(strings.Join(errList, ", "))
-		log.Warnf("Unable to clean up artifacts of volume creation: %v. "+
+		utils.Logc(ctx).Warnf("Unable to clean up artifacts of volume creation: %v. "+
 			"Repeat creating the volume or restart %v.",
 			err, config.OrchestratorName)
 	}
@@ -2340,7 +3395,7 @@ func (o *TridentOrchestrator) addVolumeCleanup(
 // to handles the case where a volume creation took too long, is still ongoing, and must be
 // preserved in a transaction.
 func (o *TridentOrchestrator) addVolumeRetryCleanup(
-	err error, backend *storage.Backend, pool *storage.Pool, vol *storage.Volume,
+	ctx context.Context, err error, backend *storage.Backend, pool *storage.Pool, vol *storage.Volume,
 	volTxn *storage.VolumeTransaction, volumeConfig *storage.VolumeConfig,
 ) error {
 
@@ -2358,7 +3413,7 @@ func (o *TridentOrchestrator) addVolumeRetryCleanup(
 	existingTxn, getTxnErr := o.storeClient.GetExistingVolumeTransaction(volTxn)
 
 	if getTxnErr != nil || existingTxn == nil {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"error":       getTxnErr,
 			"existingTxn": existingTxn,
 		}).Debug("addVolumeRetryCleanup, nothing to do.")
@@ -2383,16 +3438,16 @@ func (o *TridentOrchestrator) addVolumeRetryCleanup(
 			return err
 		}
 
-		log.Debug("addVolumeRetryCleanup, volume still creating, replaced AddVolume transaction.")
+		utils.Logc(ctx).Debug("addVolumeRetryCleanup, volume still creating, replaced AddVolume transaction.")
 
 	} else if existingTxn.Op == storage.VolumeCreating {
-		log.Debug("addVolumeRetryCleanup, volume still creating, leaving VolumeCreating transaction.")
+		utils.Logc(ctx).Debug("addVolumeRetryCleanup, volume still creating, leaving VolumeCreating transaction.")
 	}
 	return err
 }
 
 func (o *TridentOrchestrator) importVolumeCleanup(
-	err error, volumeConfig *storage.VolumeConfig, volTxn *storage.VolumeTransaction) error {
+	ctx context.Context, err error, volumeConfig *storage.VolumeConfig, volTxn *storage.VolumeTransaction) error {
 
 	var (
 		cleanupErr, txErr error
@@ -2407,8 +3462,8 @@ func (o *TridentOrchestrator) importVolumeCleanup(
 		// We failed somewhere. Most likely we failed to rename the volume or retrieve its size.
 		// Rename the volume
 		if !volumeConfig.ImportNotManaged {
-			cleanupErr = backend.RenameVolume(volumeConfig, volumeConfig.ImportOriginalName)
-			log.WithFields(log.Fields{
+			cleanupErr = backend.RenameVolume(ctx, volumeConfig, volumeConfig.ImportOriginalName)
+			utils.Logc(ctx).WithFields(log.Fields{
 				"InternalName":       volumeConfig.InternalName,
 				"importOriginalName": volumeConfig.ImportOriginalName,
 				"cleanupErr":         cleanupErr,
@@ -2421,7 +3476,7 @@ func (o *TridentOrchestrator) importVolumeCleanup(
 		// Remove volume from orchestrator cache
 		if volume, ok := o.volumes[volumeConfig.Name]; ok {
 			delete(o.volumes, volumeConfig.Name)
-			if err = o.deleteVolumeFromPersistentStoreIgnoreError(volume); err != nil {
+			if err = o.deleteVolumeFromPersistentStoreIgnoreError(volume, nil); err != nil {
 				return fmt.Errorf("error occured removing volume from persistent store; %v", err)
 			}
 		}
@@ -2440,7 +3495,7 @@ func (o *TridentOrchestrator) importVolumeCleanup(
 			}
 		}
 		err = fmt.Errorf(strings.Join(errList, ", "))
-		log.Warnf("Unable to clean up artifacts of volume import: %v. "+
+		utils.Logc(ctx).Warnf("Unable to clean up artifacts of volume import: %v. "+
 			"Repeat importing the volume %v.",
 			err, volumeConfig.ImportOriginalName)
 	}
@@ -2462,7 +3517,24 @@ func (o *TridentOrchestrator) GetVolume(volume string) (volExternal *storage.Vol
 	if !found {
 		return nil, utils.NotFoundError(fmt.Sprintf("volume %v was not found", volume))
 	}
-	return vol.ConstructExternal(), nil
+	return o.constructVolumeExternal(vol), nil
+}
+
+// constructVolumeExternal returns the external form of a volume, including the progress of any
+// recent clone split or move and the volume's usage, which the backend reports live rather than
+// being persisted.  It assumes the mutex lock is already held.
+func (o *TridentOrchestrator) constructVolumeExternal(vol *storage.Volume) *storage.VolumeExternal {
+	volExternal := vol.ConstructExternal()
+	if backend, ok := o.backends[vol.BackendUUID]; ok {
+		volExternal.CloneSplit = backend.GetCloneSplitStatus(vol.Config.InternalName)
+		volExternal.Move = backend.GetVolumeMoveStatus(vol.Config.InternalName)
+		volExternal.Usage = backend.GetVolumeUsage(vol.Config.InternalName)
+	}
+	if status, ok := o.volumeMigrations[vol.Config.Name]; ok {
+		migration := *status
+		volExternal.Migration = &migration
+	}
+	return volExternal
 }
 
 func (o *TridentOrchestrator) GetDriverTypeForVolume(vol *storage.VolumeExternal) (string, error) {
@@ -2515,6 +3587,10 @@ func (o *TridentOrchestrator) GetVolumeType(vol *storage.VolumeExternal) (
 		volumeType = config.SolidFireISCSI
 	case driver == drivers.EseriesIscsiStorageDriverName:
 		volumeType = config.ESeriesISCSI
+	case driver == drivers.FakeOntapStorageDriverName && o.backends[vol.BackendUUID].GetProtocol() == config.Block:
+		volumeType = config.OntapISCSI
+	case driver == drivers.FakeOntapStorageDriverName:
+		volumeType = config.OntapNFS
 	default:
 		volumeType = config.UnknownVolumeType
 	}
@@ -2534,7 +3610,7 @@ func (o *TridentOrchestrator) ListVolumes() (volumes []*storage.VolumeExternal,
 
 	volumes = make([]*storage.VolumeExternal, 0, len(o.volumes))
 	for _, v := range o.volumes {
-		volumes = append(volumes, v.ConstructExternal())
+		volumes = append(volumes, o.constructVolumeExternal(v))
 	}
 
 	sort.Sort(storage.ByVolumeExternalName(volumes))
@@ -2561,8 +3637,11 @@ func (o *TridentOrchestrator) volumeSnapshots(volumeName string) ([]*storage.Sna
 // deleteVolume does the necessary work to delete a volume entirely.  It does
 // not construct a transaction, nor does it take locks; it assumes that the
 // caller will take care of both of these.  It also assumes that the volume
-// exists in memory.
-func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
+// exists in memory.  The caller's transaction, if any, is deleted along with
+// the volume's record in the persistent store.
+func (o *TridentOrchestrator) deleteVolume(
+	ctx context.Context, volumeName string, volTxn *storage.VolumeTransaction,
+) error {
 	volume := o.volumes[volumeName]
 	volumeBackend := o.backends[volume.BackendUUID]
 
@@ -2573,14 +3652,17 @@ func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
 		return err
 	}
 	if len(snapshotsForVolume) > 0 {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":                  volumeName,
 			"backendUUID":             volume.BackendUUID,
 			"len(snapshotsForVolume)": len(snapshotsForVolume),
 		}).Debug("Soft deleting.")
 		volume.State = storage.VolumeStateDeleting
-		if updateErr := o.updateVolumeOnPersistentStore(volume); updateErr != nil {
-			log.WithFields(log.Fields{
+		batch := persistentstore.NewBatch()
+		batch.PutVolume(volume)
+		batch.DeleteVolumeTransaction(volTxn)
+		if updateErr := o.commitToPersistentStore(batch); updateErr != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":    volume.Config.Name,
 				"updateErr": updateErr.Error(),
 			}).Error("Unable to update the volume's state to deleting in the persistent store.")
@@ -2593,7 +3675,7 @@ func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
 	// is missing it's backend and the backend is nil. If the backend does not
 	// exist, delete the volume and clean up, then return.
 	if volumeBackend == nil {
-		if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume); err != nil {
+		if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume, volTxn); err != nil {
 			return err
 		}
 		delete(o.volumes, volumeName)
@@ -2603,29 +3685,29 @@ func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
 	// Note that this call will only return an error if the backend actually
 	// fails to delete the volume.  If the volume does not exist on the backend,
 	// the driver will not return an error.  Thus, we're fine.
-	if err := volumeBackend.RemoveVolume(volume.Config); err != nil {
+	if err := volumeBackend.RemoveVolume(ctx, volume.Config); err != nil {
 		if _, ok := err.(*storage.NotManagedError); !ok {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":      volumeName,
 				"backendUUID": volume.BackendUUID,
 				"error":       err,
 			}).Error("Unable to delete volume from backend.")
 			return err
 		} else {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":      volumeName,
 				"backendUUID": volume.BackendUUID,
 				"error":       err,
 			}).Debug("Skipping backend deletion of volume.")
 		}
 	}
-	if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume); err != nil {
+	if err := o.deleteVolumeFromPersistentStoreIgnoreError(volume, volTxn); err != nil {
 		return err
 	}
 
 	if volumeBackend.State.IsDeleting() && !volumeBackend.HasVolumes() {
 		if err := o.storeClient.DeleteBackend(volumeBackend); err != nil {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"backendUUID": volume.BackendUUID,
 				"volume":      volumeName,
 			}).Error("Unable to delete offline backend from the backing store" +
@@ -2637,15 +3719,22 @@ func (o *TridentOrchestrator) deleteVolume(volumeName string) error {
 		delete(o.backends, volume.BackendUUID)
 	}
 	delete(o.volumes, volumeName)
+	delete(o.volumeMigrations, volumeName)
 	return nil
 }
 
-func (o *TridentOrchestrator) deleteVolumeFromPersistentStoreIgnoreError(volume *storage.Volume) error {
+func (o *TridentOrchestrator) deleteVolumeFromPersistentStoreIgnoreError(
+	volume *storage.Volume, volTxn *storage.VolumeTransaction,
+) error {
 	// Ignore failures to find the volume being deleted, as this may be called
 	// during recovery of a volume that has already been deleted from the store.
 	// During normal operation, checks on whether the volume is present in the
 	// volume map should suffice to prevent deletion of non-existent volumes.
-	if err := o.storeClient.DeleteVolumeIgnoreNotFound(volume); err != nil {
+	// The volume's transaction, if any, is deleted in the same batch.
+	batch := persistentstore.NewBatch()
+	batch.DeleteVolume(volume)
+	batch.DeleteVolumeTransaction(volTxn)
+	if err := o.commitToPersistentStore(batch); err != nil {
 		log.WithFields(log.Fields{
 			"volume": volume.Config.Name,
 		}).Error("Unable to delete volume from persistent store.")
@@ -2657,7 +3746,7 @@ func (o *TridentOrchestrator) deleteVolumeFromPersistentStoreIgnoreError(volume
 // DeleteVolume does the necessary set up to delete a volume during the course
 // of normal operation, verifying that the volume is present in Trident and
 // creating a transaction to ensure that the delete eventually completes.
-func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
+func (o *TridentOrchestrator) DeleteVolume(ctx context.Context, volumeName string) (err error) {
 	if o.bootstrapError != nil {
 		return o.bootstrapError
 	}
@@ -2673,7 +3762,7 @@ func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
 		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
 	}
 	if volume.Orphaned {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":      volumeName,
 			"backendUUID": volume.BackendUUID,
 		}).Warnf("Delete operation is likely to fail with an orphaned volume.")
@@ -2683,14 +3772,14 @@ func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
 		Config: volume.Config,
 		Op:     storage.DeleteVolume,
 	}
-	if err := o.AddVolumeTransaction(volTxn); err != nil {
+	if err := o.AddVolumeTransaction(ctx, volTxn); err != nil {
 		return err
 	}
 
 	defer func() {
-		errTxn := o.DeleteVolumeTransaction(volTxn)
+		errTxn := o.deleteVolumeTransactionIfPresent(volTxn)
 		if errTxn != nil {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":    volume,
 				"error":     errTxn,
 				"operation": volTxn.Op,
@@ -2708,7 +3797,7 @@ func (o *TridentOrchestrator) DeleteVolume(volumeName string) (err error) {
 		}
 	}()
 
-	return o.deleteVolume(volumeName)
+	return o.deleteVolume(ctx, volumeName, volTxn)
 }
 
 func (o *TridentOrchestrator) ListVolumesByPlugin(pluginName string) (volumes []*storage.VolumeExternal, err error) {
@@ -2734,7 +3823,7 @@ func (o *TridentOrchestrator) ListVolumesByPlugin(pluginName string) (volumes []
 }
 
 func (o *TridentOrchestrator) PublishVolume(
-	volumeName string, publishInfo *utils.VolumePublishInfo,
+	ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo,
 ) (err error) {
 	if o.bootstrapError != nil {
 		return o.bootstrapError
@@ -2752,14 +3841,101 @@ func (o *TridentOrchestrator) PublishVolume(
 	if volume.State.IsDeleting() {
 		return utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
 	}
+	if volume.State.IsPopulating() {
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is still being populated", volumeName))
+	}
+	if o.isVolumeMigrating(volumeName) {
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being migrated to backend %s", volumeName,
+			o.volumeMigrations[volumeName].DestinationBackend))
+	}
 
 	nodes := make([]*utils.Node, 0)
 	for _, node := range o.nodes {
 		nodes = append(nodes, node)
 	}
+	backend, ok := o.backends[volume.BackendUUID]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("backend %s not found", volume.BackendUUID))
+	}
+
+	// Refuse to publish volumes that can't be used as requested, rather than fail when they are mounted
+	if err = checkAccessMode(backend, volume.Config); err != nil {
+		return err
+	}
+
 	publishInfo.Nodes = nodes
 	publishInfo.BackendUUID = volume.BackendUUID
-	return o.backends[volume.BackendUUID].PublishVolume(volume.Config, publishInfo)
+	if err = backend.PublishVolume(ctx, volume.Config, publishInfo); err != nil {
+		o.recordVolumeEvent(volumeName, frontend.EventTypeWarning, eventReasonPublishFailed,
+			fmt.Sprintf("could not publish volume to node %s: %v", publishInfo.HostName, err))
+		return err
+	}
+	if publishInfo.NfsExportPolicy != "" {
+		o.recordVolumeEvent(volumeName, frontend.EventTypeNormal, eventReasonExportPolicyReconciled,
+			fmt.Sprintf("export policy %s grants node %s access", publishInfo.NfsExportPolicy,
+				publishInfo.HostName))
+	}
+
+	// Remember the node, so that the volume's publications can be listed
+	if publishInfo.HostName != "" && volume.AddPublishedNode(publishInfo.HostName) {
+		if err = o.updateVolumeOnPersistentStore(volume); err != nil {
+			volume.RemovePublishedNode(publishInfo.HostName)
+			return err
+		}
+	}
+	return nil
+}
+
+// UnpublishVolume records that a volume is no longer published to a node.  Unpublishing a volume that
+// isn't published to the node is not an error.
+func (o *TridentOrchestrator) UnpublishVolume(volumeName, nodeName string) (err error) {
+	if o.bootstrapError != nil {
+		return o.bootstrapError
+	}
+
+	defer recordTiming("volume_unpublish", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	volume, ok := o.volumes[volumeName]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if !volume.RemovePublishedNode(nodeName) {
+		return nil
+	}
+	if err = o.updateVolumeOnPersistentStore(volume); err != nil {
+		volume.AddPublishedNode(nodeName)
+		return err
+	}
+	return nil
+}
+
+// UpdateVolumePublication corrects publish info that has gone stale since a volume was published,
+// such as iSCSI portals that moved to other data LIFs.  It returns true if publishInfo was changed.
+func (o *TridentOrchestrator) UpdateVolumePublication(
+	ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo,
+) (updated bool, err error) {
+	if o.bootstrapError != nil {
+		return false, o.bootstrapError
+	}
+
+	defer recordTiming("volume_update_publication", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	volume, ok := o.volumes[volumeName]
+	if !ok {
+		return false, utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	backend, ok := o.backends[volume.BackendUUID]
+	if !ok {
+		return false, utils.NotFoundError(fmt.Sprintf("backend %s not found", volume.BackendUUID))
+	}
+
+	return backend.UpdateVolumePublication(ctx, volume.Config, publishInfo)
 }
 
 // AttachVolume mounts a volume to the local host.  This method is currently only used by Docker,
@@ -2892,7 +4068,7 @@ func (o *TridentOrchestrator) SetVolumeState(volumeName string, state storage.Vo
 
 // CreateSnapshot creates a snapshot of the given volume
 func (o *TridentOrchestrator) CreateSnapshot(
-	snapshotConfig *storage.SnapshotConfig,
+	ctx context.Context, snapshotConfig *storage.SnapshotConfig,
 ) (externalSnapshot *storage.SnapshotExternal, err error) {
 
 	var (
@@ -2925,6 +4101,10 @@ func (o *TridentOrchestrator) CreateSnapshot(
 	if volume.State.IsDeleting() {
 		return nil, utils.VolumeDeletingError(fmt.Sprintf("source volume %s is deleting", snapshotConfig.VolumeName))
 	}
+	if o.isVolumeMigrating(volume.Config.Name) {
+		return nil, utils.VolumeCreatingError(fmt.Sprintf("source volume %s is being migrated to backend %s",
+			volume.Config.Name, o.volumeMigrations[volume.Config.Name].DestinationBackend))
+	}
 
 	// Get the backend
 	if backend, ok = o.backends[volume.BackendUUID]; !ok {
@@ -2933,44 +4113,174 @@ func (o *TridentOrchestrator) CreateSnapshot(
 			volume.BackendUUID, snapshotConfig.VolumeName))
 	}
 
-	// Complete the snapshot config
-	snapshotConfig.VolumeInternalName = volume.Config.InternalName
+	// Complete the snapshot config
+	snapshotConfig.VolumeInternalName = volume.Config.InternalName
+
+	// Add transaction in case the operation must be rolled back later
+	txn := &storage.VolumeTransaction{
+		Config:         volume.Config,
+		SnapshotConfig: snapshotConfig,
+		Op:             storage.AddSnapshot,
+	}
+	if err = o.AddVolumeTransaction(ctx, txn); err != nil {
+		return nil, err
+	}
+
+	// Recovery function in case of error
+	defer func() {
+		err = o.addSnapshotCleanup(ctx, err, backend, snapshot, txn, snapshotConfig)
+	}()
+
+	// Create the snapshot
+	snapshot, err = backend.CreateSnapshot(ctx, snapshotConfig, volume.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot %s for volume %s on backend %s: %w",
+			snapshotConfig.Name, snapshotConfig.VolumeName, backend.Name, err)
+	}
+
+	// Save references to new snapshot, deleting its transaction in the same batch
+	batch := persistentstore.NewBatch()
+	batch.PutSnapshot(snapshot)
+	batch.DeleteVolumeTransaction(txn)
+	if err = o.commitToPersistentStore(batch); err != nil {
+		return nil, err
+	}
+	o.snapshots[snapshotConfig.ID()] = snapshot
+
+	return snapshot.ConstructExternal(), nil
+}
+
+// CreateGroupSnapshot creates a crash-consistent set of snapshots, one of each of the named volumes.
+// The volumes must all be on the same backend, whose driver must support group snapshots.  Each
+// snapshot takes the group's name, and either all of them are created or none are.
+func (o *TridentOrchestrator) CreateGroupSnapshot(
+	ctx context.Context, groupConfig *storage.GroupSnapshotConfig,
+) (externalSnapshots []*storage.SnapshotExternal, err error) {
+
+	var (
+		backend   *storage.Backend
+		snapshots []*storage.Snapshot
+	)
+
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("group_snapshot_create", &err)()
+
+	if err = groupConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	snapConfigs := groupConfig.SnapshotConfigs()
+	volConfigs := make([]*storage.VolumeConfig, 0, len(snapConfigs))
+
+	for _, snapConfig := range snapConfigs {
+
+		// Check if the snapshot already exists
+		if _, ok := o.snapshots[snapConfig.ID()]; ok {
+			return nil, fmt.Errorf("snapshot %s already exists", snapConfig.ID())
+		}
+
+		// Get the volume
+		volume, ok := o.volumes[snapConfig.VolumeName]
+		if !ok {
+			return nil, utils.NotFoundError(fmt.Sprintf("source volume %s not found", snapConfig.VolumeName))
+		}
+		if volume.State.IsDeleting() {
+			return nil, utils.VolumeDeletingError(fmt.Sprintf("source volume %s is deleting", snapConfig.VolumeName))
+		}
+
+		// All of the volumes must share a backend
+		if backend == nil {
+			if backend, ok = o.backends[volume.BackendUUID]; !ok {
+				// Should never get here but just to be safe
+				return nil, utils.NotFoundError(fmt.Sprintf("backend %s for the source volume not found: %s",
+					volume.BackendUUID, snapConfig.VolumeName))
+			}
+		} else if volume.BackendUUID != backend.BackendUUID {
+			return nil, fmt.Errorf("volumes in group snapshot %s must be on the same backend; volume %s is not "+
+				"on backend %s", groupConfig.Name, snapConfig.VolumeName, backend.Name)
+		}
 
-	// Add transaction in case the operation must be rolled back later
-	txn := &storage.VolumeTransaction{
-		Config:         volume.Config,
-		SnapshotConfig: snapshotConfig,
-		Op:             storage.AddSnapshot,
-	}
-	if err = o.AddVolumeTransaction(txn); err != nil {
-		return nil, err
+		// Complete the snapshot config
+		snapConfig.VolumeInternalName = volume.Config.InternalName
+		volConfigs = append(volConfigs, volume.Config)
 	}
 
+	// Add a transaction per snapshot in case the operation must be rolled back later
+	txns := make([]*storage.VolumeTransaction, 0, len(snapConfigs))
+
 	// Recovery function in case of error
 	defer func() {
-		err = o.addSnapshotCleanup(err, backend, snapshot, txn, snapshotConfig)
+		if err != nil {
+			for _, snapshot := range snapshots {
+				if _, ok := o.snapshots[snapshot.Config.ID()]; ok {
+					if storeErr := o.storeClient.DeleteSnapshotIgnoreNotFound(snapshot); storeErr != nil {
+						utils.Logc(ctx).WithField("snapshot", snapshot.Config.ID()).WithError(storeErr).Warning(
+							"Could not remove snapshot from the persistent store during cleanup.")
+					}
+					delete(o.snapshots, snapshot.Config.ID())
+				}
+			}
+		}
+		for i, txn := range txns {
+			var snapshot *storage.Snapshot
+			if i < len(snapshots) {
+				snapshot = snapshots[i]
+			}
+			err = o.addSnapshotCleanup(ctx, err, backend, snapshot, txn, snapConfigs[i])
+		}
 	}()
 
-	// Create the snapshot
-	snapshot, err = backend.CreateSnapshot(snapshotConfig, volume.Config)
+	for i, snapConfig := range snapConfigs {
+		txn := &storage.VolumeTransaction{
+			Config:         volConfigs[i],
+			SnapshotConfig: snapConfig,
+			Op:             storage.AddSnapshot,
+		}
+		if err = o.AddVolumeTransaction(ctx, txn); err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+
+	// Create the snapshots
+	snapshots, err = backend.CreateGroupSnapshot(ctx, snapConfigs, volConfigs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create snapshot %s for volume %s on backend %s: %v",
-			snapshotConfig.Name, snapshotConfig.VolumeName, backend.Name, err)
+		return nil, fmt.Errorf("failed to create group snapshot %s on backend %s: %w",
+			groupConfig.Name, backend.Name, err)
 	}
 
-	// Save references to new snapshot
-	if err = o.storeClient.AddSnapshot(snapshot); err != nil {
+	// Save references to new snapshots, deleting their transactions in the same batch so that either
+	// all of the group's snapshots are saved or none are
+	batch := persistentstore.NewBatch()
+	for _, snapshot := range snapshots {
+		batch.PutSnapshot(snapshot)
+	}
+	for _, txn := range txns {
+		batch.DeleteVolumeTransaction(txn)
+	}
+	if err = o.commitToPersistentStore(batch); err != nil {
 		return nil, err
 	}
-	o.snapshots[snapshotConfig.ID()] = snapshot
+	externalSnapshots = make([]*storage.SnapshotExternal, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		o.snapshots[snapshot.Config.ID()] = snapshot
+		externalSnapshots = append(externalSnapshots, snapshot.ConstructExternal())
+	}
 
-	return snapshot.ConstructExternal(), nil
+	return externalSnapshots, nil
 }
 
 // addSnapshotCleanup is used as a deferred method from the snapshot create method
 // to clean up in case anything goes wrong during the operation.
 func (o *TridentOrchestrator) addSnapshotCleanup(
-	err error, backend *storage.Backend, snapshot *storage.Snapshot,
+	ctx context.Context, err error, backend *storage.Backend, snapshot *storage.Snapshot,
 	volTxn *storage.VolumeTransaction, snapConfig *storage.SnapshotConfig) error {
 
 	var (
@@ -2985,7 +4295,7 @@ func (o *TridentOrchestrator) addSnapshotCleanup(
 		//     In this case, we need to remove the snapshot from the backend.
 		if backend != nil && snapshot != nil {
 			// We succeeded in adding the snapshot to the backend; now delete it.
-			cleanupErr = backend.DeleteSnapshot(snapshot.Config, volTxn.Config)
+			cleanupErr = backend.DeleteSnapshot(ctx, snapshot.Config, volTxn.Config)
 			if cleanupErr != nil {
 				cleanupErr = fmt.Errorf("unable to delete snapshot from backend during cleanup:  %v", cleanupErr)
 			}
@@ -2994,8 +4304,8 @@ func (o *TridentOrchestrator) addSnapshotCleanup(
 	if cleanupErr == nil {
 		// Only clean up the snapshot transaction if we've succeeded at
 		// cleaning up on the backend or if we didn't need to do so in the
-		// first place.
-		if txErr = o.DeleteVolumeTransaction(volTxn); txErr != nil {
+		// first place.  A successful add already deleted it.
+		if txErr = o.deleteVolumeTransactionIfPresent(volTxn); txErr != nil {
 			txErr = fmt.Errorf("unable to clean up snapshot transaction: %v", txErr)
 		}
 	}
@@ -3012,7 +4322,7 @@ func (o *TridentOrchestrator) addSnapshotCleanup(
 			}
 		}
 		err = fmt.Errorf(strings.Join(errList, ", "))
-		log.Warnf("Unable to clean up artifacts of snapshot creation: %v. "+
+		utils.Logc(ctx).Warnf("Unable to clean up artifacts of snapshot creation: %v. "+
 			"Repeat creating the snapshot or restart %v.", err, config.OrchestratorName)
 	}
 	return err
@@ -3034,13 +4344,47 @@ func (o *TridentOrchestrator) GetSnapshot(volumeName, snapshotName string) (
 	if !found {
 		return nil, utils.NotFoundError(fmt.Sprintf("snapshot %v was not found", snapshotName))
 	}
-	return snapshot.ConstructExternal(), nil
+	snapshotExternal = snapshot.ConstructExternal()
+	o.readSnapshotSpace(snapshotExternal)
+	return snapshotExternal, nil
+}
+
+// readSnapshotSpace fills in the space used by a snapshot and its volume's snapshots, which change as the
+// volume is written and so aren't stored, from the backend.  Space usage is informational, so the
+// snapshot is returned without it if the backend can't be read.
+func (o *TridentOrchestrator) readSnapshotSpace(snapshot *storage.SnapshotExternal) {
+
+	if !snapshot.State.IsOnline() {
+		return
+	}
+	volume, ok := o.volumes[snapshot.Config.VolumeName]
+	if !ok {
+		return
+	}
+	backend, ok := o.backends[volume.BackendUUID]
+	if !ok {
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+	current, err := backend.GetSnapshot(ctx, snapshot.Config)
+	if err != nil {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"snapshot": snapshot.Config.Name,
+			"volume":   snapshot.Config.VolumeName,
+		}).Debugf("Could not read snapshot space. %v", err)
+		return
+	}
+	snapshot.UsedBytes = current.UsedBytes
+	snapshot.VolumeReserve = current.VolumeReserve
 }
 
 // deleteSnapshot does the necessary work to delete a snapshot entirely.  It does
 // not construct a transaction, nor does it take locks; it assumes that the caller will
 // take care of both of these.
-func (o *TridentOrchestrator) deleteSnapshot(snapshotConfig *storage.SnapshotConfig) error {
+func (o *TridentOrchestrator) deleteSnapshot(
+	ctx context.Context, snapshotConfig *storage.SnapshotConfig, volTxn *storage.VolumeTransaction,
+) error {
 
 	snapshotID := snapshotConfig.ID()
 	snapshot, ok := o.snapshots[snapshotID]
@@ -3061,9 +4405,23 @@ func (o *TridentOrchestrator) deleteSnapshot(snapshotConfig *storage.SnapshotCon
 
 	// Note that this call will only return an error if the backend actually
 	// fails to delete the snapshot.  If the snapshot does not exist on the backend,
-	// the driver will not return an error.  Thus, we're fine.
-	if err := backend.DeleteSnapshot(snapshot.Config, volume.Config); err != nil {
-		log.WithFields(log.Fields{
+	// the driver either returns no error or a not-found error.  Thus, we're fine.
+	err := backend.DeleteSnapshot(ctx, snapshot.Config, volume.Config)
+	if drivers.IsDeletionDeferredError(err) {
+		// The driver will finish deleting the snapshot and report back, so leave the snapshot in place
+		utils.Logc(ctx).WithFields(log.Fields{
+			"volume":   snapshot.Config.VolumeName,
+			"snapshot": snapshot.Config.Name,
+			"backend":  backend.Name,
+			"reason":   err,
+		}).Info("Snapshot deletion deferred by backend.")
+		snapshot.State = storage.SnapshotStateDeleting
+		batch := persistentstore.NewBatch()
+		batch.PutSnapshot(snapshot)
+		batch.DeleteVolumeTransaction(volTxn)
+		return o.commitToPersistentStore(batch)
+	} else if err != nil && !drivers.IsResourceNotFoundError(err) {
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":   snapshot.Config.VolumeName,
 			"snapshot": snapshot.Config.Name,
 			"backend":  backend.Name,
@@ -3071,35 +4429,99 @@ func (o *TridentOrchestrator) deleteSnapshot(snapshotConfig *storage.SnapshotCon
 		}).Error("Unable to delete snapshot from backend.")
 		return err
 	}
-	if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot); err != nil {
+
+	return o.removeSnapshot(ctx, snapshot, volume, volTxn)
+}
+
+// removeSnapshot forgets a snapshot that no longer exists on its backend, and hard deletes the
+// snapshot's volume if the volume was only waiting for its snapshots to be deleted.  It does not
+// take locks; it assumes that the caller will do so.  The caller's transaction, if any, is deleted
+// along with the snapshot's record in the persistent store.
+func (o *TridentOrchestrator) removeSnapshot(
+	ctx context.Context, snapshot *storage.Snapshot, volume *storage.Volume, volTxn *storage.VolumeTransaction,
+) error {
+
+	if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot, volTxn); err != nil {
 		return err
 	}
 
 	delete(o.snapshots, snapshot.ID())
 
-	snapshotsForVolume, err := o.volumeSnapshots(snapshotConfig.VolumeName)
+	snapshotsForVolume, err := o.volumeSnapshots(snapshot.Config.VolumeName)
 	if err != nil {
 		return err
 	}
 
 	if len(snapshotsForVolume) == 0 && volume.State.IsDeleting() {
-		log.WithFields(log.Fields{
-			"snapshotConfig.VolumeName": snapshotConfig.VolumeName,
+		utils.Logc(ctx).WithFields(log.Fields{
+			"snapshotConfig.VolumeName": snapshot.Config.VolumeName,
 			"backendUUID":               volume.BackendUUID,
 			"volume.State":              volume.State,
 		}).Debug("Hard deleting volume.")
-		return o.deleteVolume(snapshotConfig.VolumeName)
+		return o.deleteVolume(ctx, snapshot.Config.VolumeName, nil)
 	}
 
 	return nil
 }
 
-func (o *TridentOrchestrator) deleteSnapshotFromPersistentStoreIgnoreError(snapshot *storage.Snapshot) error {
+// finishDeferredSnapshotDeletion is called once a backend has finished trying to delete a busy
+// snapshot in the background.  A deleted snapshot is removed, while one the backend gave up on is
+// left in the delete_failed state so that its deletion may be requested again.
+func (o *TridentOrchestrator) finishDeferredSnapshotDeletion(snapConfig *storage.SnapshotConfig, deleteErr error) {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	logFields := log.Fields{"volume": snapConfig.VolumeName, "snapshot": snapConfig.Name}
+
+	snapshot, ok := o.snapshots[snapConfig.ID()]
+	if !ok {
+		utils.Logc(ctx).WithFields(logFields).Debug("Deferred deletion ended for an unknown snapshot.")
+		return
+	}
+
+	if deleteErr != nil {
+		utils.Logc(ctx).WithFields(logFields).WithField("error", deleteErr).Error(
+			"Backend could not delete snapshot.")
+		snapshot.State = storage.SnapshotStateDeleteFailed
+		if err := o.storeClient.UpdateSnapshot(snapshot); err != nil {
+			utils.Logc(ctx).WithFields(logFields).WithField("error", err).Error(
+				"Unable to update snapshot in persistent store.")
+		}
+		return
+	}
+
+	volume, ok := o.volumes[snapConfig.VolumeName]
+	if !ok {
+		utils.Logc(ctx).WithFields(logFields).Warning("Volume not found for deleted snapshot.")
+		if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot, nil); err == nil {
+			delete(o.snapshots, snapshot.ID())
+		}
+		return
+	}
+
+	if err := o.removeSnapshot(ctx, snapshot, volume, nil); err != nil {
+		utils.Logc(ctx).WithFields(logFields).WithField("error", err).Error("Unable to remove deleted snapshot.")
+		return
+	}
+	utils.Logc(ctx).WithFields(logFields).Info("Backend finished deleting snapshot.")
+}
+
+func (o *TridentOrchestrator) deleteSnapshotFromPersistentStoreIgnoreError(
+	snapshot *storage.Snapshot, volTxn *storage.VolumeTransaction,
+) error {
 	// Ignore failures to find the snapshot being deleted, as this may be called
 	// during recovery of a snapshot that has already been deleted from the store.
 	// During normal operation, checks on whether the snapshot is present in the
 	// snapshot map should suffice to prevent deletion of non-existent snapshots.
-	if err := o.storeClient.DeleteSnapshotIgnoreNotFound(snapshot); err != nil {
+	// The snapshot's transaction, if any, is deleted in the same batch.
+	batch := persistentstore.NewBatch()
+	batch.DeleteSnapshot(snapshot)
+	batch.DeleteVolumeTransaction(volTxn)
+	if err := o.commitToPersistentStore(batch); err != nil {
 		log.WithFields(log.Fields{
 			"snapshot": snapshot.Config.Name,
 			"volume":   snapshot.Config.VolumeName,
@@ -3110,7 +4532,7 @@ func (o *TridentOrchestrator) deleteSnapshotFromPersistentStoreIgnoreError(snaps
 }
 
 // DeleteSnapshot deletes a snapshot of the given volume
-func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (err error) {
+func (o *TridentOrchestrator) DeleteSnapshot(ctx context.Context, volumeName, snapshotName string) (err error) {
 	if o.bootstrapError != nil {
 		return o.bootstrapError
 	}
@@ -3138,7 +4560,7 @@ func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (e
 	// is missing it's volume and the volume is nil. If the volume does not
 	// exist, delete the snapshot and clean up, then return.
 	if volume == nil {
-		if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot); err != nil {
+		if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot, nil); err != nil {
 			return err
 		}
 		delete(o.snapshots, snapshot.ID())
@@ -3156,7 +4578,7 @@ func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (e
 	// is missing it's backend and the backend is nil. If the backend does not
 	// exist, delete the snapshot and clean up, then return.
 	if backend == nil {
-		if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot); err != nil {
+		if err := o.deleteSnapshotFromPersistentStoreIgnoreError(snapshot, nil); err != nil {
 			return err
 		}
 		delete(o.snapshots, snapshot.ID())
@@ -3165,7 +4587,7 @@ func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (e
 
 	// TODO: Is this needed?
 	if volume.Orphaned {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":   volumeName,
 			"snapshot": snapshotName,
 			"backend":  volume.BackendUUID,
@@ -3177,14 +4599,14 @@ func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (e
 		SnapshotConfig: snapshot.Config,
 		Op:             storage.DeleteSnapshot,
 	}
-	if err := o.AddVolumeTransaction(volTxn); err != nil {
+	if err := o.AddVolumeTransaction(ctx, volTxn); err != nil {
 		return err
 	}
 
 	defer func() {
-		errTxn := o.DeleteVolumeTransaction(volTxn)
+		errTxn := o.deleteVolumeTransactionIfPresent(volTxn)
 		if errTxn != nil {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":    volumeName,
 				"snapshot":  snapshotName,
 				"backend":   volume.BackendUUID,
@@ -3205,7 +4627,95 @@ func (o *TridentOrchestrator) DeleteSnapshot(volumeName, snapshotName string) (e
 	}()
 
 	// Delete the snapshot
-	return o.deleteSnapshot(snapshot.Config)
+	return o.deleteSnapshot(ctx, snapshot.Config, volTxn)
+}
+
+// RestoreSnapshot reverts a volume in place to one of its snapshots.  Restoring may delete any
+// snapshots of the volume newer than the one restored, so those the backend no longer has are
+// removed from Trident afterwards.
+func (o *TridentOrchestrator) RestoreSnapshot(ctx context.Context, volumeName, snapshotName string) (err error) {
+	if o.bootstrapError != nil {
+		return o.bootstrapError
+	}
+
+	defer recordTiming("snapshot_restore", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	snapshot, ok := o.snapshots[storage.MakeSnapshotID(volumeName, snapshotName)]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("snapshot %s not found on volume %s", snapshotName, volumeName))
+	}
+	if snapshot.State.IsDeleting() {
+		return fmt.Errorf("snapshot %s of volume %s is deleting", snapshotName, volumeName)
+	}
+
+	volume, ok := o.volumes[volumeName]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if volume.State.IsDeleting() {
+		return utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
+	}
+	if o.isVolumeMigrating(volumeName) {
+		return fmt.Errorf("volume %s is migrating to another backend", volumeName)
+	}
+
+	// Refuse to change the contents of a volume out from under its users
+	if len(volume.PublishedNodes) > 0 {
+		return utils.VolumePublishedError(fmt.Sprintf("volume %s is published to nodes %s, and may be "+
+			"restored only while it is unpublished", volumeName, strings.Join(volume.PublishedNodes, ", ")))
+	}
+
+	backend, ok := o.backends[volume.BackendUUID]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("backend %s not found", volume.BackendUUID))
+	}
+
+	if err = backend.RestoreSnapshot(ctx, snapshot.Config, volume.Config); err != nil {
+		return fmt.Errorf("failed to restore volume %s from snapshot %s; %v", volumeName, snapshotName, err)
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volume":   volumeName,
+		"snapshot": snapshotName,
+		"backend":  backend.Name,
+	}).Info("Orchestrator restored the volume from the snapshot.")
+
+	// Forget any snapshots destroyed by the restore
+	backendSnapshots, err := backend.GetSnapshots(ctx, volume.Config)
+	if err != nil {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"volume": volumeName,
+			"error":  err,
+		}).Warning("Could not list the snapshots remaining after the restore.")
+		return nil
+	}
+	remaining := make(map[string]bool, len(backendSnapshots))
+	for _, backendSnapshot := range backendSnapshots {
+		remaining[backendSnapshot.Config.InternalName] = true
+	}
+
+	volumeSnapshots, err := o.volumeSnapshots(volumeName)
+	if err != nil {
+		return err
+	}
+	for _, volumeSnapshot := range volumeSnapshots {
+		if remaining[volumeSnapshot.Config.InternalName] {
+			continue
+		}
+		utils.Logc(ctx).WithFields(log.Fields{
+			"volume":   volumeName,
+			"snapshot": volumeSnapshot.Config.Name,
+		}).Info("Removing snapshot deleted by the restore.")
+		if err = o.removeSnapshot(ctx, volumeSnapshot, volume, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (o *TridentOrchestrator) ListSnapshots() (snapshots []*storage.SnapshotExternal, err error) {
@@ -3272,7 +4782,7 @@ func (o *TridentOrchestrator) ListSnapshotsForVolume(volumeName string) (
 	return snapshots, nil
 }
 
-func (o *TridentOrchestrator) ReadSnapshotsForVolume(volumeName string) (
+func (o *TridentOrchestrator) ReadSnapshotsForVolume(ctx context.Context, volumeName string) (
 	externalSnapshots []*storage.SnapshotExternal, err error) {
 	if o.bootstrapError != nil {
 		return nil, o.bootstrapError
@@ -3285,7 +4795,7 @@ func (o *TridentOrchestrator) ReadSnapshotsForVolume(volumeName string) (
 		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
 	}
 
-	snapshots, err := o.backends[volume.BackendUUID].GetSnapshots(volume.Config)
+	snapshots, err := o.backends[volume.BackendUUID].GetSnapshots(ctx, volume.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -3340,7 +4850,7 @@ func (o *TridentOrchestrator) ReloadVolumes() (err error) {
 }
 
 // ResizeVolume resizes a volume to the new size.
-func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err error) {
+func (o *TridentOrchestrator) ResizeVolume(ctx context.Context, volumeName, newSize string) (err error) {
 	if o.bootstrapError != nil {
 		return o.bootstrapError
 	}
@@ -3356,7 +4866,7 @@ func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err erro
 		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
 	}
 	if volume.Orphaned {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":      volumeName,
 			"backendUUID": volume.BackendUUID,
 		}).Warnf("Resize operation is likely to fail with an orphaned volume.")
@@ -3364,6 +4874,18 @@ func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err erro
 	if volume.State.IsDeleting() {
 		return utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
 	}
+	if o.isVolumeMigrating(volumeName) {
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being migrated to backend %s", volumeName,
+			o.volumeMigrations[volumeName].DestinationBackend))
+	}
+
+	// Refuse to grow a volume in use on a backend that can only grow volumes while they are unpublished
+	if backend, ok := o.backends[volume.BackendUUID]; ok && len(volume.PublishedNodes) > 0 &&
+		backend.Driver.GetVolumeExpansion().Offline {
+		return utils.VolumePublishedError(fmt.Sprintf("volume %s is published to nodes %s, and backend %s "+
+			"can grow it only while it is unpublished", volumeName, strings.Join(volume.PublishedNodes, ", "),
+			backend.Name))
+	}
 
 	// Create a new config for the volume transaction
 	cloneConfig := volume.Config.ConstructClone()
@@ -3374,32 +4896,35 @@ func (o *TridentOrchestrator) ResizeVolume(volumeName, newSize string) (err erro
 		Config: cloneConfig,
 		Op:     storage.ResizeVolume,
 	}
-	if err = o.AddVolumeTransaction(volTxn); err != nil {
+	if err = o.AddVolumeTransaction(ctx, volTxn); err != nil {
 		return err
 	}
 
 	defer func() {
 		if err == nil {
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":      volumeName,
 				"volume_size": newSize,
 			}).Info("Orchestrator resized the volume on the storage backend.")
 		}
-		err = o.resizeVolumeCleanup(err, volume, volTxn)
+		err = o.resizeVolumeCleanup(ctx, err, volume, volTxn)
 	}()
 
 	// Resize the volume.
-	return o.resizeVolume(volume, newSize)
+	return o.resizeVolume(ctx, volume, newSize, volTxn)
 }
 
 // resizeVolume does the necessary work to resize a volume. It doesn't
 // construct a transaction, nor does it take locks; it assumes that the
 // caller will take care of both of these. It also assumes that the volume
-// exists in memory.
-func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize string) error {
+// exists in memory. The caller's transaction, if any, is deleted along with
+// updating the volume's size in persistent store.
+func (o *TridentOrchestrator) resizeVolume(
+	ctx context.Context, volume *storage.Volume, newSize string, volTxn *storage.VolumeTransaction,
+) error {
 	volumeBackend, found := o.backends[volume.BackendUUID]
 	if !found {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume":      volume.Config.Name,
 			"backendUUID": volume.BackendUUID,
 		}).Error("Unable to find backend during volume resize.")
@@ -3409,8 +4934,8 @@ func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize strin
 	if volume.Config.Size != newSize {
 		// If the resize is successful the driver updates the volume.Config.Size, as a side effect, with the actual
 		// byte size of the expanded volume.
-		if err := volumeBackend.ResizeVolume(volume.Config, newSize); err != nil {
-			log.WithFields(log.Fields{
+		if err := volumeBackend.ResizeVolume(ctx, volume.Config, newSize); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
 				"volume":          volume.Config.Name,
 				"volume_internal": volume.Config.InternalName,
 				"backendUUID":     volume.BackendUUID,
@@ -3418,14 +4943,20 @@ func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize strin
 				"new_size":        newSize,
 				"error":           err,
 			}).Error("Unable to resize the volume.")
+			if drivers.IsVolumeSizeLimitError(err) || drivers.IsUnsupportedCapacityRangeError(err) {
+				return err
+			}
 			return fmt.Errorf("unable to resize the volume: %v", err)
 		}
 	}
 
-	if err := o.updateVolumeOnPersistentStore(volume); err != nil {
+	batch := persistentstore.NewBatch()
+	batch.PutVolume(volume)
+	batch.DeleteVolumeTransaction(volTxn)
+	if err := o.commitToPersistentStore(batch); err != nil {
 		// It's ok not to revert volume size as we don't clean up the
 		// transaction object in this situation.
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume": volume.Config.Name,
 		}).Error("Unable to update the volume's size in persistent store.")
 		return err
@@ -3436,7 +4967,7 @@ func (o *TridentOrchestrator) resizeVolume(volume *storage.Volume, newSize strin
 // resizeVolumeCleanup is used to clean up artifacts of volume resize in case
 // anything goes wrong during the operation.
 func (o *TridentOrchestrator) resizeVolumeCleanup(
-	err error, vol *storage.Volume, volTxn *storage.VolumeTransaction) error {
+	ctx context.Context, err error, vol *storage.Volume, volTxn *storage.VolumeTransaction) error {
 
 	if vol == nil || err == nil ||
 		(err != nil && vol.Config.Size != volTxn.Config.Size) {
@@ -3447,7 +4978,8 @@ func (o *TridentOrchestrator) resizeVolumeCleanup(
 		// 2.  We failed to update the volume on persistent store. In this
 		//     case, we leave the volume transaction around so that we can
 		//     update the persistent store later.
-		txErr := o.DeleteVolumeTransaction(volTxn)
+		// A successful resize already deleted the volume transaction.
+		txErr := o.deleteVolumeTransactionIfPresent(volTxn)
 		if txErr != nil {
 			txErr = fmt.Errorf("unable to clean up resize transaction:  %v", txErr)
 		}
@@ -3458,8 +4990,11 @@ func (o *TridentOrchestrator) resizeVolumeCleanup(
 			}
 		}
 		if len(errList) > 0 {
-			err = fmt.Errorf(strings.Join(errList, ", "))
-			log.Warnf("Unable to clean up artifacts of volume resize: %v. "+
+			// Leave a lone resize error intact so that callers may still tell what kind of failure it was
+			if txErr != nil {
+				err = fmt.Errorf(strings.Join(errList, ", "))
+			}
+			utils.Logc(ctx).Warnf("Unable to clean up artifacts of volume resize: %v. "+
 				"Repeat resizing the volume or restart %v.",
 				err, config.OrchestratorName)
 		}
@@ -3472,6 +5007,143 @@ func (o *TridentOrchestrator) resizeVolumeCleanup(
 	return err
 }
 
+// UpdateVolume changes attributes of an existing volume on its backend, such as whether the volume's
+// snapshot directory is visible, and records any change the volume's config tracks.
+func (o *TridentOrchestrator) UpdateVolume(
+	ctx context.Context, volumeName string, updateRequest *storage.UpdateVolumeRequest,
+) (volExternal *storage.VolumeExternal, err error) {
+
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("volume_update", &err)()
+
+	if err = updateRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	volume, found := o.volumes[volumeName]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if volume.State.IsDeleting() {
+		return nil, utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
+	}
+
+	backend, found := o.backends[volume.BackendUUID]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for volume %s not found",
+			volume.BackendUUID, volumeName))
+	}
+
+	if updateRequest.UpdatesStorage() {
+		if err = backend.UpdateVolume(ctx, volume.Config, updateRequest); err != nil {
+			return nil, fmt.Errorf("unable to update volume %s: %w", volumeName, err)
+		}
+	}
+
+	// The request was validated above, so the snapshot directory is a well-formed boolean
+	if updateRequest.SnapshotDirectory != "" {
+		snapshotDir, _ := strconv.ParseBool(updateRequest.SnapshotDirectory)
+		volume.Config.SnapshotDir = strconv.FormatBool(snapshotDir)
+	}
+	if updateRequest.UnixPermissions != "" {
+		volume.Config.UnixPermissions = updateRequest.UnixPermissions
+	}
+	if updateRequest.SecurityStyle != "" {
+		volume.Config.SecurityStyle = updateRequest.SecurityStyle
+	}
+	if updateRequest.ExportPolicy != "" {
+		volume.Config.ExportPolicy = updateRequest.ExportPolicy
+	}
+	if updateRequest.SnapshotPolicy != "" {
+		volume.Config.SnapshotPolicy = updateRequest.SnapshotPolicy
+	}
+	if updateRequest.CloneToNamespaces != nil {
+		volume.Config.CloneToNamespaces = storage.ParseCloneToNamespaces(*updateRequest.CloneToNamespaces)
+	}
+
+	if err = o.updateVolumeOnPersistentStore(volume); err != nil {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"volume": volumeName,
+		}).Error("Unable to update the volume in persistent store.")
+		return nil, err
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volume":                    volumeName,
+		"snapshotDirectory":         volume.Config.SnapshotDir,
+		"tieringMinimumCoolingDays": updateRequest.TieringMinimumCoolingDays,
+		"unixPermissions":           volume.Config.UnixPermissions,
+		"securityStyle":             volume.Config.SecurityStyle,
+		"exportPolicy":              volume.Config.ExportPolicy,
+		"snapshotPolicy":            volume.Config.SnapshotPolicy,
+		"cloneToNamespaces":         volume.Config.CloneToNamespaces,
+	}).Info("Orchestrator updated the volume.")
+
+	return o.constructVolumeExternal(volume), nil
+}
+
+// MoveVolume begins moving a volume to another pool of its backend, such as to rebalance the
+// backend's storage or to empty a pool that is being decommissioned.  The move runs in the
+// background and is reported in the volume's status; the volume's pool is updated once it completes.
+func (o *TridentOrchestrator) MoveVolume(
+	ctx context.Context, volumeName string, moveRequest *storage.MoveVolumeRequest,
+) (volExternal *storage.VolumeExternal, err error) {
+
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("volume_move", &err)()
+
+	if err = moveRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	volume, found := o.volumes[volumeName]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if volume.State.IsDeleting() {
+		return nil, utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
+	}
+
+	backend, found := o.backends[volume.BackendUUID]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for volume %s not found",
+			volume.BackendUUID, volumeName))
+	}
+
+	if err = backend.MoveVolume(ctx, volume.Config, moveRequest.Pool); err != nil {
+		return nil, fmt.Errorf("unable to move volume %s: %w", volumeName, err)
+	}
+
+	// Record the move so that it is followed again if Trident restarts before it finishes
+	volume.Config.MoveDestinationPool = moveRequest.Pool
+	if err = o.updateVolumeOnPersistentStore(volume); err != nil {
+		utils.Logc(ctx).WithField("volume", volumeName).WithError(err).Warning(
+			"Could not record the volume move in persistent store.")
+		err = nil
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volume": volumeName,
+		"pool":   moveRequest.Pool,
+	}).Info("Orchestrator began moving the volume.")
+
+	return o.constructVolumeExternal(volume), nil
+}
+
 // getProtocol returns the appropriate protocol based on a specified volume mode, access mode and protocol, or
 // an error if the two settings are incompatible.
 //
@@ -3560,6 +5232,11 @@ func (o *TridentOrchestrator) AddStorageClass(scConfig *storageclass.Config) (
 	if _, ok := o.storageClasses[sc.GetName()]; ok {
 		return nil, fmt.Errorf("storage class %s already exists", sc.GetName())
 	}
+	if policy := sc.GetPlacementPolicy(); policy != "" {
+		if _, err = GetPlacementPolicy(policy); err != nil {
+			return nil, fmt.Errorf("storage class %s: %v", sc.GetName(), err)
+		}
+	}
 	err = o.storeClient.AddStorageClass(sc)
 	if err != nil {
 		return nil, err
@@ -3751,7 +5428,35 @@ func (o *TridentOrchestrator) DeleteNode(nName string) (err error) {
 		return err
 	}
 	delete(o.nodes, nName)
-	return o.reconcileNodeAccessOnAllBackends()
+	return o.revokeNodeAccessOnAllBackends(node)
+}
+
+// revokeNodeAccessOnAllBackends withdraws a deleted node's access to every backend, rather than
+// waiting for the next full reconciliation.  A failure on one backend doesn't stop the others.
+func (o *TridentOrchestrator) revokeNodeAccessOnAllBackends(node *utils.Node) error {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	remainingNodes := make([]*utils.Node, 0, len(o.nodes))
+	for _, n := range o.nodes {
+		remainingNodes = append(remainingNodes, n)
+	}
+
+	errs := make([]string, 0)
+	for _, b := range o.backends {
+		if err := b.RevokeNodeAccess(ctx, node, remainingNodes); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"backend": b.Name,
+				"node":    node.Name,
+			}).Errorf("Unable to revoke node access on backend; %v", err)
+			errs = append(errs, fmt.Sprintf("backend %s: %v", b.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to revoke access of node %s; %v", node.Name, errs)
+	}
+	return nil
 }
 
 func (o *TridentOrchestrator) updateBackendOnPersistentStore(
@@ -3776,6 +5481,21 @@ func (o *TridentOrchestrator) updateBackendOnPersistentStore(
 	return nil
 }
 
+// commitToPersistentStore writes a batch of changes to the persistent store, all or nothing.  A batch that
+// was committed but not fully applied is retried right away; if it fails again, so does the caller's
+// operation, which is retried in turn.  The caller should hold the orchestrator lock.
+func (o *TridentOrchestrator) commitToPersistentStore(batch *persistentstore.Batch) error {
+
+	err := persistentstore.CommitBatch(o.storeClient, batch)
+	notApplied, ok := err.(*persistentstore.BatchNotAppliedError)
+	if !ok {
+		return err
+	}
+
+	log.WithField("error", err).Warning("Persistent store batch not fully applied, retrying.")
+	return persistentstore.RetryBatch(o.storeClient, batch, notApplied)
+}
+
 func (o *TridentOrchestrator) updateVolumeOnPersistentStore(
 	vol *storage.Volume) error {
 	// Update the volume information in persistent store
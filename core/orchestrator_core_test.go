@@ -3,12 +3,14 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/netapp/trident/storage/fake"
 	sa "github.com/netapp/trident/storage_attribute"
 	storageclass "github.com/netapp/trident/storage_class"
+	drivers "github.com/netapp/trident/storage_drivers"
 	fakedriver "github.com/netapp/trident/storage_drivers/fake"
 	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
 	"github.com/netapp/trident/utils"
@@ -33,6 +36,8 @@ var (
 	debug  = flag.Bool("debug", false, "Enable debugging output")
 
 	inMemoryClient *persistentstore.InMemoryClient
+
+	ctx = context.Background
 )
 
 func init() {
@@ -253,7 +258,7 @@ func runDeleteTest(
 		}
 		orchestrator.mutex.Unlock()
 	}
-	err := orchestrator.DeleteVolume(d.name)
+	err := orchestrator.DeleteVolume(ctx(), d.name)
 	if err == nil && !d.expectedSuccess {
 		t.Errorf("%s:  volume delete succeeded when it should not have.", d.name)
 	} else if err != nil && d.expectedSuccess {
@@ -746,7 +751,7 @@ func TestAddStorageClassVolumes(t *testing.T) {
 			deleteAfterSC: false,
 		},
 	} {
-		vol, err := orchestrator.AddVolume(s.config)
+		vol, err := orchestrator.AddVolume(ctx(), s.config)
 		if err != nil && s.expectedSuccess {
 			t.Errorf("%s:  got unexpected error %v", s.name, err)
 			continue
@@ -1094,7 +1099,7 @@ func TestCloneVolumes(t *testing.T) {
 		},
 	} {
 		// Create the source volume
-		_, err := orchestrator.AddVolume(s.config)
+		_, err := orchestrator.AddVolume(ctx(), s.config)
 		if err != nil {
 			t.Errorf("%s:  got unexpected error %v", s.name, err)
 			continue
@@ -1107,7 +1112,7 @@ func TestCloneVolumes(t *testing.T) {
 			StorageClass:      s.config.StorageClass,
 			CloneSourceVolume: s.config.Name,
 		}
-		cloneResult, err := orchestrator.CloneVolume(cloneConfig)
+		cloneResult, err := orchestrator.CloneVolume(ctx(), cloneConfig)
 		if err != nil {
 			t.Errorf("%s:  got unexpected error %v", s.name, err)
 			continue
@@ -1232,7 +1237,7 @@ func TestBackendUpdateAndDelete(t *testing.T) {
 	}
 	orchestrator.mutex.Unlock()
 
-	_, err := orchestrator.AddVolume(tu.GenerateVolumeConfig(volumeName, 50, scName, config.File))
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50, scName, config.File))
 	if err != nil {
 		t.Fatal("Unable to create volume: ", err)
 	}
@@ -1411,7 +1416,7 @@ func TestBackendUpdateAndDelete(t *testing.T) {
 	if !backend.Driver.Initialized() {
 		t.Errorf("Deleted backend with volumes %s is not initialized.", backendName)
 	}
-	_, err = orchestrator.AddVolume(tu.GenerateVolumeConfig(offlineVolumeName, 50, scName, config.File))
+	_, err = orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(offlineVolumeName, 50, scName, config.File))
 	if err == nil {
 		t.Error("Created volume volume on offline backend.")
 	}
@@ -1487,7 +1492,7 @@ func TestBackendUpdateAndDelete(t *testing.T) {
 	newOrchestrator.mutex.Unlock()
 
 	// Test that deleting the volume causes the backend to be deleted.
-	err = orchestrator.DeleteVolume(volumeName)
+	err = orchestrator.DeleteVolume(ctx(), volumeName)
 	if err != nil {
 		t.Fatal("Unable to delete volume for offline backend:  ", err)
 	}
@@ -1509,6 +1514,147 @@ func TestBackendUpdateAndDelete(t *testing.T) {
 	cleanup(t, orchestrator)
 }
 
+// blockingPublishDriver holds a publish until it's released, so that a test may keep an operation
+// running against a backend.
+type blockingPublishDriver struct {
+	storage.Driver
+	publishing chan struct{}
+	release    chan struct{}
+}
+
+func (d *blockingPublishDriver) Publish(
+	ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) error {
+	close(d.publishing)
+	<-d.release
+	return nil
+}
+
+func TestUpdateBackendWithOperationInFlight(t *testing.T) {
+	const backendName = "drainBackend"
+
+	// The orchestrator isn't bootstrapped, so that no background monitors use the backend's driver
+	orchestrator := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	orchestrator.bootstrapError = nil
+	addBackend(t, orchestrator, backendName, config.File)
+
+	orchestrator.mutex.Lock()
+	originalBackend, err := orchestrator.getBackendByBackendName(backendName)
+	if err != nil {
+		t.Fatal("Unable to get backend: ", err)
+	}
+	driver := &blockingPublishDriver{
+		Driver:     originalBackend.Driver,
+		publishing: make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	originalBackend.Driver = driver
+	orchestrator.mutex.Unlock()
+
+	// Start an operation against the backend, without the orchestrator's lock, and leave it running
+	published := make(chan error, 1)
+	go func() {
+		published <- originalBackend.PublishVolume(ctx(), &storage.VolumeConfig{Name: "vol"},
+			&utils.VolumePublishInfo{})
+	}()
+	<-driver.publishing
+
+	// Put the original driver back, as backend updates are validated against it
+	orchestrator.mutex.Lock()
+	originalBackend.Driver = driver.Driver
+	orchestrator.mutex.Unlock()
+
+	newConfigJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(backendName, config.File,
+		map[string]*fake.StoragePool{
+			"primary": {
+				Attrs: map[string]sa.Offer{
+					sa.Media:            sa.NewStringOffer("ssd"),
+					sa.ProvisioningType: sa.NewStringOffer("thick", "thin"),
+					sa.TestingAttribute: sa.NewBoolOffer(true),
+				},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			},
+		}, []fake.Volume{})
+	if err != nil {
+		t.Fatal("Unable to generate new backend config: ", err)
+	}
+	updated := make(chan error, 1)
+	go func() {
+		_, err := orchestrator.UpdateBackend(backendName, newConfigJSON)
+		updated <- err
+	}()
+
+	// The update waits for the running operation, but doesn't hold the orchestrator's lock while it does,
+	// and new operations are refused meanwhile
+	assert.Eventually(t, func() bool {
+		return originalBackend.PublishVolume(ctx(), &storage.VolumeConfig{Name: "vol"},
+			&utils.VolumePublishInfo{}) != nil
+	}, 5*time.Second, 10*time.Millisecond, "backend not fenced by the update")
+	if _, err = orchestrator.GetBackend(backendName); err != nil {
+		t.Fatal("Unable to get backend while it's being updated: ", err)
+	}
+	select {
+	case err = <-updated:
+		t.Fatal("Backend updated while an operation was running: ", err)
+	default:
+	}
+
+	// Once the operation finishes, the update is applied
+	close(driver.release)
+	assert.NoError(t, <-published, "running operation failed")
+	assert.NoError(t, <-updated, "backend update failed")
+
+	orchestrator.mutex.Lock()
+	newBackend, err := orchestrator.getBackendByBackendName(backendName)
+	orchestrator.mutex.Unlock()
+	if err != nil {
+		t.Fatal("Unable to get backend: ", err)
+	}
+	assert.NotEqual(t, originalBackend, newBackend, "backend not replaced by the update")
+	assert.Equal(t, originalBackend.BackendUUID, newBackend.BackendUUID, "backend UUID changed by the update")
+}
+
+// destroyContextDriver records the context each volume is destroyed with.
+type destroyContextDriver struct {
+	storage.Driver
+	destroyContexts []context.Context
+}
+
+func (d *destroyContextDriver) Destroy(ctx context.Context, name string) error {
+	d.destroyContexts = append(d.destroyContexts, ctx)
+	return d.Driver.Destroy(ctx, name)
+}
+
+func TestAddVolumeCleanupAfterCanceledRequest(t *testing.T) {
+	const backendName = "cleanupBackend"
+
+	orchestrator := NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	orchestrator.bootstrapError = nil
+	addBackend(t, orchestrator, backendName, config.File)
+
+	backend, err := orchestrator.getBackendByBackendName(backendName)
+	if err != nil {
+		t.Fatal("Unable to get backend: ", err)
+	}
+	driver := &destroyContextDriver{Driver: backend.Driver}
+	backend.Driver = driver
+
+	volConfig := &storage.VolumeConfig{Name: "vol", InternalName: "vol", Size: "1Gi"}
+	vol := &storage.Volume{Config: volConfig, BackendUUID: backend.BackendUUID}
+	volTxn := &storage.VolumeTransaction{Config: volConfig, Op: storage.AddVolume}
+
+	// The request was canceled, which failed the create, but the volume must still be removed
+	requestCtx, cancel := context.WithCancel(utils.GenerateRequestContext(ctx(), "12345", utils.ContextSourceCSI))
+	cancel()
+	err = orchestrator.addVolumeCleanup(requestCtx, requestCtx.Err(), backend, vol, volTxn, volConfig)
+	assert.Equal(t, context.Canceled, err, "original error not returned")
+
+	if assert.Len(t, driver.destroyContexts, 1, "volume not removed from the backend") {
+		assert.NoError(t, driver.destroyContexts[0].Err(), "volume removed with the canceled context")
+		assert.Equal(t, "12345", utils.GetRequestID(driver.destroyContexts[0]), "request ID not kept")
+	}
+}
+
 func TestEmptyBackendDeletion(t *testing.T) {
 	const (
 		backendName     = "emptyBackend"
@@ -1556,7 +1702,7 @@ func TestBootstrapSnapshotMissingVolume(t *testing.T) {
 	orchestrator := getOrchestrator()
 	defer cleanup(t, orchestrator)
 	addBackendStorageClass(t, orchestrator, offlineBackendName, scName, backendProtocol)
-	_, err := orchestrator.AddVolume(tu.GenerateVolumeConfig(volumeName, 50,
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50,
 		scName, config.File))
 	if err != nil {
 		t.Fatal("Unable to create volume: ", err)
@@ -1564,7 +1710,7 @@ func TestBootstrapSnapshotMissingVolume(t *testing.T) {
 
 	// For the full test, we create everything and recreate the AddSnapshot transaction.
 	snapshotConfig := generateSnapshotConfig(snapName, volumeName, volumeName)
-	if _, err := orchestrator.CreateSnapshot(snapshotConfig); err != nil {
+	if _, err := orchestrator.CreateSnapshot(ctx(), snapshotConfig); err != nil {
 		t.Fatal("Unable to add snapshot: ", err)
 	}
 
@@ -1589,12 +1735,50 @@ func TestBootstrapSnapshotMissingVolume(t *testing.T) {
 		t.Error("Unexpected snapshot state.")
 	}
 	//delete volume in missing_volume state
-	err = newOrchestrator.DeleteSnapshot(volumeName, snapName)
+	err = newOrchestrator.DeleteSnapshot(ctx(), volumeName, snapName)
 	if err != nil {
 		t.Error("could not delete snapshot with missing volume")
 	}
 }
 
+func TestBootstrapMigratesBackendConfig(t *testing.T) {
+	const (
+		backendName = "migratedBackend"
+		scName      = "migratedBackendSC"
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+	addBackendStorageClass(t, orchestrator, backendName, scName, config.File)
+
+	persistentBackend, err := orchestrator.storeClient.GetBackend(backendName)
+	if err != nil {
+		t.Fatalf("Unable to read backend: %v", err)
+	}
+	commonConfig := persistentBackend.Config.FakeStorageDriverConfig.CommonStorageDriverConfig
+	if commonConfig.SchemaVersion != drivers.ConfigSchemaVersion {
+		t.Errorf("New backend stored with schema version %d.", commonConfig.SchemaVersion)
+	}
+
+	// Store the config as a release predating schema versions would have, then bootstrap
+	commonConfig.SchemaVersion = 0
+	if err = orchestrator.storeClient.UpdateBackendPersistent(persistentBackend); err != nil {
+		t.Fatalf("Unable to update backend: %v", err)
+	}
+	newOrchestrator := getOrchestrator()
+	if _, err = newOrchestrator.GetBackend(backendName); err != nil {
+		t.Fatalf("Unable to find backend after bootstrapping: %v", err)
+	}
+
+	persistentBackend, err = newOrchestrator.storeClient.GetBackend(backendName)
+	if err != nil {
+		t.Fatalf("Unable to read backend: %v", err)
+	}
+	if version := persistentBackend.Config.FakeStorageDriverConfig.SchemaVersion; version != drivers.ConfigSchemaVersion {
+		t.Errorf("Migrated backend stored with schema version %d.", version)
+	}
+}
+
 func TestBootstrapSnapshotMissingBackend(t *testing.T) {
 	const (
 		offlineBackendName = "snapNoBackBackend"
@@ -1607,7 +1791,7 @@ func TestBootstrapSnapshotMissingBackend(t *testing.T) {
 	orchestrator := getOrchestrator()
 	defer cleanup(t, orchestrator)
 	addBackendStorageClass(t, orchestrator, offlineBackendName, scName, backendProtocol)
-	_, err := orchestrator.AddVolume(tu.GenerateVolumeConfig(volumeName, 50,
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50,
 		scName, config.File))
 	if err != nil {
 		t.Fatal("Unable to create volume: ", err)
@@ -1615,7 +1799,7 @@ func TestBootstrapSnapshotMissingBackend(t *testing.T) {
 
 	// For the full test, we create everything and recreate the AddSnapshot transaction.
 	snapshotConfig := generateSnapshotConfig(snapName, volumeName, volumeName)
-	if _, err := orchestrator.CreateSnapshot(snapshotConfig); err != nil {
+	if _, err := orchestrator.CreateSnapshot(ctx(), snapshotConfig); err != nil {
 		t.Fatal("Unable to add snapshot: ", err)
 	}
 
@@ -1640,12 +1824,335 @@ func TestBootstrapSnapshotMissingBackend(t *testing.T) {
 		t.Error("Unexpected snapshot state.")
 	}
 	//delete snapshot in missing_backend state
-	err = newOrchestrator.DeleteSnapshot(volumeName, snapName)
+	err = newOrchestrator.DeleteSnapshot(ctx(), volumeName, snapName)
 	if err != nil {
 		t.Error("could not delete snapshot with missing backend")
 	}
 }
 
+func TestFinishDeferredSnapshotDeletion(t *testing.T) {
+	const (
+		backendName     = "deferredDeleteBackend"
+		scName          = "deferredDeleteSC"
+		volumeName      = "deferredDeleteVolume"
+		snapName        = "deferredDeleteSnapshot"
+		backendProtocol = config.File
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+	addBackendStorageClass(t, orchestrator, backendName, scName, backendProtocol)
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50,
+		scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+
+	snapshotConfig := generateSnapshotConfig(snapName, volumeName, volumeName)
+	if _, err := orchestrator.CreateSnapshot(ctx(), snapshotConfig); err != nil {
+		t.Fatal("Unable to add snapshot: ", err)
+	}
+
+	// Simulate a backend deferring the snapshot's deletion
+	orchestrator.mutex.Lock()
+	snapshot := orchestrator.snapshots[snapshotConfig.ID()]
+	snapshot.State = storage.SnapshotStateDeleting
+	err = orchestrator.storeClient.UpdateSnapshot(snapshot)
+	orchestrator.mutex.Unlock()
+	if err != nil {
+		t.Fatal("Unable to update snapshot: ", err)
+	}
+
+	// Cloning a snapshot that is being deleted is not allowed
+	cloneConfig := tu.GenerateVolumeConfig("deferredDeleteClone", 50, scName, config.File)
+	cloneConfig.CloneSourceVolume = volumeName
+	cloneConfig.CloneSourceSnapshot = snapName
+	_, err = orchestrator.CloneVolume(ctx(), cloneConfig)
+	assert.Error(t, err, "expected clone of deleting snapshot to fail")
+
+	// A failed deferred deletion is surfaced in the snapshot's state, which survives bootstrapping
+	orchestrator.finishDeferredSnapshotDeletion(snapshotConfig, fmt.Errorf("snapshot is still busy"))
+	newOrchestrator := getOrchestrator()
+	bootstrappedSnapshot, err := newOrchestrator.GetSnapshot(volumeName, snapName)
+	if err != nil {
+		t.Fatal("Unable to get snapshot: ", err)
+	}
+	assert.True(t, bootstrappedSnapshot.State.IsDeleteFailed(), "unexpected snapshot state")
+
+	// A completed deferred deletion removes the snapshot
+	newOrchestrator.finishDeferredSnapshotDeletion(snapshotConfig, nil)
+	_, err = newOrchestrator.GetSnapshot(volumeName, snapName)
+	assert.True(t, utils.IsNotFoundError(err), "expected snapshot to be removed")
+	persistentSnapshot, _ := newOrchestrator.storeClient.GetSnapshot(volumeName, snapName)
+	assert.Nil(t, persistentSnapshot, "expected snapshot to be removed from the store")
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	const (
+		backendName     = "restoreBackend"
+		scName          = "restoreSC"
+		volumeName      = "restoreVolume"
+		snapName        = "restoreSnapshot"
+		newerSnapName   = "restoreNewerSnapshot"
+		backendProtocol = config.File
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+	addBackendStorageClass(t, orchestrator, backendName, scName, backendProtocol)
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+	for _, name := range []string{snapName, newerSnapName} {
+		if _, err := orchestrator.CreateSnapshot(ctx(),
+			generateSnapshotConfig(name, volumeName, volumeName)); err != nil {
+			t.Fatal("Unable to add snapshot: ", err)
+		}
+	}
+
+	// A published volume may not be restored
+	orchestrator.mutex.Lock()
+	volume := orchestrator.volumes[volumeName]
+	volume.PublishedNodes = []string{"node1"}
+	orchestrator.mutex.Unlock()
+	err = orchestrator.RestoreSnapshot(ctx(), volumeName, snapName)
+	assert.True(t, utils.IsVolumePublishedError(err), "published volume restored")
+
+	orchestrator.mutex.Lock()
+	volume.PublishedNodes = nil
+	orchestrator.mutex.Unlock()
+	err = orchestrator.RestoreSnapshot(ctx(), volumeName, "noSnapshot")
+	assert.True(t, utils.IsNotFoundError(err), "unknown snapshot restored")
+
+	// Simulate a backend discarding the newer snapshot when restoring the volume
+	backend, err := orchestrator.getBackendByBackendName(backendName)
+	if err != nil {
+		t.Fatal("Unable to get backend: ", err)
+	}
+	f := backend.Driver.(*fakedriver.StorageDriver)
+	delete(f.Snapshots[volume.Config.InternalName], newerSnapName)
+
+	if err = orchestrator.RestoreSnapshot(ctx(), volumeName, snapName); err != nil {
+		t.Fatal("Unable to restore snapshot: ", err)
+	}
+	_, err = orchestrator.GetSnapshot(volumeName, snapName)
+	assert.NoError(t, err, "restored snapshot removed")
+	_, err = orchestrator.GetSnapshot(volumeName, newerSnapName)
+	assert.True(t, utils.IsNotFoundError(err), "discarded snapshot not removed")
+	persistentSnapshot, _ := orchestrator.storeClient.GetSnapshot(volumeName, newerSnapName)
+	assert.Nil(t, persistentSnapshot, "discarded snapshot not removed from the store")
+}
+
+func TestCreateGroupSnapshot(t *testing.T) {
+	const (
+		backendName      = "groupSnapshotBackend"
+		otherBackendName = "groupSnapshotOtherBackend"
+		scName           = "groupSnapshotSC"
+		otherSCName      = "groupSnapshotOtherSC"
+		snapName         = "groupSnapshot"
+		backendProtocol  = config.File
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+	addBackendStorageClass(t, orchestrator, backendName, scName, backendProtocol)
+	addBackendStorageClass(t, orchestrator, otherBackendName, otherSCName, config.Block)
+
+	for _, volConfig := range []*storage.VolumeConfig{
+		tu.GenerateVolumeConfig("groupVolume1", 1, scName, config.File),
+		tu.GenerateVolumeConfig("groupVolume2", 1, scName, config.File),
+		tu.GenerateVolumeConfig("groupVolume3", 1, otherSCName, config.Block),
+	} {
+		if _, err := orchestrator.AddVolume(ctx(), volConfig); err != nil {
+			t.Fatal("Unable to create volume: ", err)
+		}
+	}
+
+	// Invalid groups are rejected
+	_, err := orchestrator.CreateGroupSnapshot(ctx(), &storage.GroupSnapshotConfig{Name: snapName})
+	assert.Error(t, err, "expected group snapshot without volumes to fail")
+	_, err = orchestrator.CreateGroupSnapshot(ctx(), &storage.GroupSnapshotConfig{
+		Name:        snapName,
+		VolumeNames: []string{"groupVolume1", "groupVolume1"},
+	})
+	assert.Error(t, err, "expected group snapshot with a repeated volume to fail")
+
+	// Volumes on different backends can't be snapshotted together, and nothing is left behind
+	_, err = orchestrator.CreateGroupSnapshot(ctx(), &storage.GroupSnapshotConfig{
+		Name:        snapName,
+		VolumeNames: []string{"groupVolume1", "groupVolume3"},
+	})
+	assert.Error(t, err, "expected group snapshot across backends to fail")
+	_, err = orchestrator.GetSnapshot("groupVolume1", snapName)
+	assert.True(t, utils.IsNotFoundError(err), "expected no snapshot after failed group snapshot")
+
+	groupConfig := &storage.GroupSnapshotConfig{
+		Version:     config.OrchestratorAPIVersion,
+		Name:        snapName,
+		VolumeNames: []string{"groupVolume1", "groupVolume2"},
+	}
+	snapshots, err := orchestrator.CreateGroupSnapshot(ctx(), groupConfig)
+	if err != nil {
+		t.Fatal("Unable to create group snapshot: ", err)
+	}
+	if assert.Len(t, snapshots, 2, "unexpected number of snapshots") {
+		assert.Equal(t, snapshots[0].Created, snapshots[1].Created, "snapshots should share a creation time")
+	}
+	for _, volumeName := range groupConfig.VolumeNames {
+		snapshot, err := orchestrator.GetSnapshot(volumeName, snapName)
+		if assert.NoError(t, err, "snapshot not found for volume %s", volumeName) {
+			assert.Equal(t, volumeName, snapshot.Config.VolumeInternalName, "unexpected volume internal name")
+		}
+		persistentSnapshot, err := orchestrator.storeClient.GetSnapshot(volumeName, snapName)
+		assert.NoError(t, err, "snapshot not persisted for volume %s", volumeName)
+		assert.NotNil(t, persistentSnapshot, "snapshot not persisted for volume %s", volumeName)
+	}
+	txns, err := orchestrator.storeClient.GetVolumeTransactions()
+	assert.NoError(t, err)
+	assert.Empty(t, txns, "expected transactions to be cleaned up")
+
+	// A group whose snapshots already exist can't be created again
+	_, err = orchestrator.CreateGroupSnapshot(ctx(), groupConfig)
+	assert.Error(t, err, "expected repeated group snapshot to fail")
+}
+
+func TestUpdateVolume(t *testing.T) {
+	const (
+		backendName     = "updateVolumeBackend"
+		scName          = "updateVolumeSC"
+		volumeName      = "updateVolume"
+		backendProtocol = config.File
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+	addBackendStorageClass(t, orchestrator, backendName, scName, backendProtocol)
+	if _, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 1, scName,
+		config.File)); err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+
+	// Invalid requests and unknown volumes are rejected
+	_, err := orchestrator.UpdateVolume(ctx(), volumeName, &storage.UpdateVolumeRequest{})
+	assert.Error(t, err, "expected update without changes to fail")
+	_, err = orchestrator.UpdateVolume(ctx(), volumeName, &storage.UpdateVolumeRequest{SnapshotDirectory: "maybe"})
+	assert.Error(t, err, "expected update with invalid snapshot directory to fail")
+	_, err = orchestrator.UpdateVolume(ctx(), "missingVolume", &storage.UpdateVolumeRequest{SnapshotDirectory: "true"})
+	assert.True(t, utils.IsNotFoundError(err), "expected update of missing volume to fail")
+
+	volume, err := orchestrator.UpdateVolume(ctx(), volumeName, &storage.UpdateVolumeRequest{SnapshotDirectory: "TRUE"})
+	if err != nil {
+		t.Fatal("Unable to update volume: ", err)
+	}
+	assert.Equal(t, "true", volume.Config.SnapshotDir, "unexpected snapshot directory")
+
+	// The change is persisted
+	persistentVolume, err := orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get volume from store: ", err)
+	}
+	assert.Equal(t, "true", persistentVolume.Config.SnapshotDir, "snapshot directory not persisted")
+
+	// Changing only the cooling period leaves the snapshot directory as it was
+	volume, err = orchestrator.UpdateVolume(ctx(), volumeName,
+		&storage.UpdateVolumeRequest{TieringMinimumCoolingDays: "31"})
+	if err != nil {
+		t.Fatal("Unable to update volume: ", err)
+	}
+	assert.Equal(t, "true", volume.Config.SnapshotDir, "unexpected snapshot directory")
+
+	// Permissions and policies are recorded in the volume's config
+	volume, err = orchestrator.UpdateVolume(ctx(), volumeName,
+		&storage.UpdateVolumeRequest{UnixPermissions: "0750", SnapshotPolicy: "default"})
+	if err != nil {
+		t.Fatal("Unable to update volume: ", err)
+	}
+	assert.Equal(t, "0750", volume.Config.UnixPermissions, "unexpected UNIX permissions")
+	assert.Equal(t, "default", volume.Config.SnapshotPolicy, "unexpected snapshot policy")
+	assert.Equal(t, "true", volume.Config.SnapshotDir, "unexpected snapshot directory")
+}
+
+func TestMoveVolume(t *testing.T) {
+	const (
+		backendName = "moveVolumeBackend"
+		scName      = "moveVolumeSC"
+		volumeName  = "moveVolume"
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+
+	// Only the primary pool matches the storage class, so the volume is created there
+	configJSON, err := fakedriver.NewFakeStorageDriverConfigJSON(
+		backendName,
+		config.File,
+		map[string]*fake.StoragePool{
+			"primary": {
+				Attrs: map[string]sa.Offer{
+					sa.Media:            sa.NewStringOffer("hdd"),
+					sa.ProvisioningType: sa.NewStringOffer("thick", "thin"),
+					sa.TestingAttribute: sa.NewBoolOffer(true),
+				},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			},
+			"secondary": {
+				Attrs: map[string]sa.Offer{
+					sa.Media:            sa.NewStringOffer("hdd"),
+					sa.ProvisioningType: sa.NewStringOffer("thick", "thin"),
+				},
+				Bytes: 100 * 1024 * 1024 * 1024,
+			},
+		},
+		[]fake.Volume{},
+	)
+	if err != nil {
+		t.Fatal("Unable to create mock driver config JSON: ", err)
+	}
+	if _, err = orchestrator.AddBackend(configJSON); err != nil {
+		t.Fatal("Unable to add backend: ", err)
+	}
+	if _, err = orchestrator.AddStorageClass(&storageclass.Config{
+		Name: scName,
+		Attributes: map[string]sa.Request{
+			sa.TestingAttribute: sa.NewBoolRequest(true),
+		},
+	}); err != nil {
+		t.Fatal("Unable to add storage class: ", err)
+	}
+	volume, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 1, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+	assert.Equal(t, "primary", volume.Pool, "volume created in unexpected pool")
+
+	// Invalid requests and unknown volumes or pools are rejected
+	_, err = orchestrator.MoveVolume(ctx(), volumeName, &storage.MoveVolumeRequest{})
+	assert.Error(t, err, "expected move without a pool to fail")
+	_, err = orchestrator.MoveVolume(ctx(), "missingVolume", &storage.MoveVolumeRequest{Pool: "secondary"})
+	assert.True(t, utils.IsNotFoundError(err), "expected move of missing volume to fail")
+	_, err = orchestrator.MoveVolume(ctx(), volumeName, &storage.MoveVolumeRequest{Pool: "missingPool"})
+	assert.Error(t, err, "expected move to missing pool to fail")
+
+	if _, err = orchestrator.MoveVolume(ctx(), volumeName, &storage.MoveVolumeRequest{Pool: "secondary"}); err != nil {
+		t.Fatal("Unable to move volume: ", err)
+	}
+
+	// Once the move completes, the volume belongs to the new pool.  The pool is updated under the
+	// orchestrator's lock, so it's polled through GetVolume rather than read from the store directly.
+	assert.Eventually(t, func() bool {
+		volume, err := orchestrator.GetVolume(volumeName)
+		return err == nil && volume.Pool == "secondary"
+	}, 5*time.Second, 10*time.Millisecond, "volume pool not updated after move")
+
+	persistentVolume, err := orchestrator.storeClient.GetVolume(volumeName)
+	if err != nil {
+		t.Fatal("Unable to get volume from persistent store: ", err)
+	}
+	assert.Equal(t, "secondary", persistentVolume.Pool, "persisted volume pool not updated after move")
+	assert.Empty(t, persistentVolume.Config.MoveDestinationPool, "finished move still recorded")
+}
+
 func TestBootstrapVolumeMissingBackend(t *testing.T) {
 	const (
 		offlineBackendName = "bootstrapVolBackend"
@@ -1657,7 +2164,7 @@ func TestBootstrapVolumeMissingBackend(t *testing.T) {
 	orchestrator := getOrchestrator()
 	defer cleanup(t, orchestrator)
 	addBackendStorageClass(t, orchestrator, offlineBackendName, scName, backendProtocol)
-	_, err := orchestrator.AddVolume(tu.GenerateVolumeConfig(volumeName, 50,
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50,
 		scName, config.File))
 	if err != nil {
 		t.Fatal("Unable to create volume: ", err)
@@ -1685,7 +2192,7 @@ func TestBootstrapVolumeMissingBackend(t *testing.T) {
 	}
 
 	//delete volume in missing_backend state
-	err = newOrchestrator.DeleteVolume(volumeName)
+	err = newOrchestrator.DeleteVolume(ctx(), volumeName)
 	if err != nil {
 		t.Error("could not delete volume with missing backend")
 	}
@@ -1702,7 +2209,7 @@ func TestBackendCleanup(t *testing.T) {
 
 	orchestrator := getOrchestrator()
 	addBackendStorageClass(t, orchestrator, offlineBackendName, scName, backendProtocol)
-	_, err := orchestrator.AddVolume(tu.GenerateVolumeConfig(volumeName, 50,
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50,
 		scName, config.File))
 	if err != nil {
 		t.Fatal("Unable to create volume: ", err)
@@ -1897,7 +2404,7 @@ func TestAddVolumeRecovery(t *testing.T) {
 	// afterwards
 	fullVolumeConfig := tu.GenerateVolumeConfig(fullVolumeName, 50, scName,
 		config.File)
-	_, err := orchestrator.AddVolume(fullVolumeConfig)
+	_, err := orchestrator.AddVolume(ctx(), fullVolumeConfig)
 	if err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
@@ -1924,15 +2431,15 @@ func TestDeleteVolumeRecovery(t *testing.T) {
 
 	// For the full test, we delete everything but the ending transaction.
 	fullVolumeConfig := tu.GenerateVolumeConfig(fullVolumeName, 50, scName, config.File)
-	if _, err := orchestrator.AddVolume(fullVolumeConfig); err != nil {
+	if _, err := orchestrator.AddVolume(ctx(), fullVolumeConfig); err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
-	if err := orchestrator.DeleteVolume(fullVolumeName); err != nil {
+	if err := orchestrator.DeleteVolume(ctx(), fullVolumeName); err != nil {
 		t.Fatal("Unable to remove full volume:  ", err)
 	}
 
 	txOnlyVolumeConfig := tu.GenerateVolumeConfig(txOnlyVolumeName, 50, scName, config.File)
-	if _, err := orchestrator.AddVolume(txOnlyVolumeConfig); err != nil {
+	if _, err := orchestrator.AddVolume(ctx(), txOnlyVolumeConfig); err != nil {
 		t.Fatal("Unable to add tx only volume: ", err)
 	}
 
@@ -2026,13 +2533,13 @@ func TestAddSnapshotRecovery(t *testing.T) {
 
 	// It's easier to add the volume/snapshot and then reinject the transaction again afterwards.
 	volumeConfig := tu.GenerateVolumeConfig(volumeName, 50, scName, config.File)
-	if _, err := orchestrator.AddVolume(volumeConfig); err != nil {
+	if _, err := orchestrator.AddVolume(ctx(), volumeConfig); err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
 
 	// For the full test, we create everything and recreate the AddSnapshot transaction.
 	fullSnapshotConfig := generateSnapshotConfig(fullSnapshotName, volumeName, volumeName)
-	if _, err := orchestrator.CreateSnapshot(fullSnapshotConfig); err != nil {
+	if _, err := orchestrator.CreateSnapshot(ctx(), fullSnapshotConfig); err != nil {
 		t.Fatal("Unable to add snapshot: ", err)
 	}
 
@@ -2063,21 +2570,21 @@ func TestDeleteSnapshotRecovery(t *testing.T) {
 
 	// For the full test, we delete everything and recreate the delete transaction.
 	volumeConfig := tu.GenerateVolumeConfig(volumeName, 50, scName, config.File)
-	if _, err := orchestrator.AddVolume(volumeConfig); err != nil {
+	if _, err := orchestrator.AddVolume(ctx(), volumeConfig); err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
 	fullSnapshotConfig := generateSnapshotConfig(fullSnapshotName, volumeName, volumeName)
-	if _, err := orchestrator.CreateSnapshot(fullSnapshotConfig); err != nil {
+	if _, err := orchestrator.CreateSnapshot(ctx(), fullSnapshotConfig); err != nil {
 		t.Fatal("Unable to add snapshot: ", err)
 	}
-	if err := orchestrator.DeleteSnapshot(volumeName, fullSnapshotName); err != nil {
+	if err := orchestrator.DeleteSnapshot(ctx(), volumeName, fullSnapshotName); err != nil {
 		t.Fatal("Unable to remove full snapshot: ", err)
 	}
 
 	// For the partial test, we ensure the snapshot will be restored during bootstrapping,
 	// and the delete transaction will ensure everything is deleted.
 	txOnlySnapshotConfig := generateSnapshotConfig(txOnlySnapshotName, volumeName, volumeName)
-	if _, err := orchestrator.CreateSnapshot(txOnlySnapshotConfig); err != nil {
+	if _, err := orchestrator.CreateSnapshot(ctx(), txOnlySnapshotConfig); err != nil {
 		t.Fatal("Unable to add snapshot: ", err)
 	}
 
@@ -2287,12 +2794,12 @@ func TestOrchestratorNotReady(t *testing.T) {
 		t.Errorf("Expected DeleteBackend to return an error.")
 	}
 
-	volume, err = orchestrator.AddVolume(nil)
+	volume, err = orchestrator.AddVolume(ctx(), nil)
 	if volume != nil || !utils.IsNotReadyError(err) {
 		t.Errorf("Expected AddVolume to return an error.")
 	}
 
-	volume, err = orchestrator.CloneVolume(nil)
+	volume, err = orchestrator.CloneVolume(ctx(), nil)
 	if volume != nil || !utils.IsNotReadyError(err) {
 		t.Errorf("Expected CloneVolume to return an error.")
 	}
@@ -2317,7 +2824,7 @@ func TestOrchestratorNotReady(t *testing.T) {
 		t.Errorf("Expected ListVolumes to return an error.")
 	}
 
-	err = orchestrator.DeleteVolume("")
+	err = orchestrator.DeleteVolume(ctx(), "")
 	if !utils.IsNotReadyError(err) {
 		t.Errorf("Expected DeleteVolume to return an error.")
 	}
@@ -2337,7 +2844,12 @@ func TestOrchestratorNotReady(t *testing.T) {
 		t.Errorf("Expected DetachVolume to return an error.")
 	}
 
-	snapshot, err = orchestrator.CreateSnapshot(nil)
+	_, err = orchestrator.UpdateVolumePublication(ctx(), "", nil)
+	if !utils.IsNotReadyError(err) {
+		t.Errorf("Expected UpdateVolumePublication to return an error.")
+	}
+
+	snapshot, err = orchestrator.CreateSnapshot(ctx(), nil)
 	if snapshot != nil || !utils.IsNotReadyError(err) {
 		t.Errorf("Expected CreateSnapshot to return an error.")
 	}
@@ -2352,12 +2864,12 @@ func TestOrchestratorNotReady(t *testing.T) {
 		t.Errorf("Expected ListSnapshots to return an error.")
 	}
 
-	snapshots, err = orchestrator.ReadSnapshotsForVolume("")
+	snapshots, err = orchestrator.ReadSnapshotsForVolume(ctx(), "")
 	if snapshots != nil || !utils.IsNotReadyError(err) {
 		t.Errorf("Expected ReadSnapshotsForVolume to return an error.")
 	}
 
-	err = orchestrator.DeleteSnapshot("", "")
+	err = orchestrator.DeleteSnapshot(ctx(), "", "")
 	if !utils.IsNotReadyError(err) {
 		t.Errorf("Expected DeleteSnapshot to return an error.")
 	}
@@ -2434,7 +2946,7 @@ func TestImportVolumeFailures(t *testing.T) {
 
 	orchestrator, volumeConfig := importVolumeSetup(t, backendName, scName, volumeName, originalName01, backendProtocol)
 
-	_, err := orchestrator.LegacyImportVolume(volumeConfig, backendName, false, createPVandPVCError)
+	_, err := orchestrator.LegacyImportVolume(ctx(), volumeConfig, backendName, false, createPVandPVCError)
 
 	// verify that importVolumeCleanup renamed volume to originalName
 	backend, _ := orchestrator.getBackendByBackendName(backendName)
@@ -2500,7 +3012,7 @@ func TestLegacyImportVolume(t *testing.T) {
 			createFunc: createPVandPVCNoOp, expectedInternalName: originalName02},
 	} {
 		// The test code
-		volExternal, err := orchestrator.LegacyImportVolume(c.volumeConfig, backendName, c.notManaged, c.createFunc)
+		volExternal, err := orchestrator.LegacyImportVolume(ctx(), c.volumeConfig, backendName, c.notManaged, c.createFunc)
 		if err != nil {
 			t.Errorf("%s: unexpected error %v", c.name, err)
 		} else {
@@ -2550,7 +3062,7 @@ func TestImportVolume(t *testing.T) {
 		{name: "notManaged", volumeConfig: notManagedVolConfig, expectedInternalName: originalName02},
 	} {
 		// The test code
-		volExternal, err := orchestrator.ImportVolume(c.volumeConfig)
+		volExternal, err := orchestrator.ImportVolume(ctx(), c.volumeConfig)
 		if err != nil {
 			t.Errorf("%s: unexpected error %v", c.name, err)
 		} else {
@@ -2567,6 +3079,43 @@ func TestImportVolume(t *testing.T) {
 	cleanup(t, orchestrator)
 }
 
+func TestListImportCandidates(t *testing.T) {
+	const (
+		backendName     = "backend03"
+		scName          = "sc01"
+		volumeName      = "volume01"
+		originalName    = "origVolume01"
+		backendProtocol = config.File
+	)
+
+	orchestrator, volumeConfig := importVolumeSetup(t, backendName, scName, volumeName, originalName, backendProtocol)
+	defer cleanup(t, orchestrator)
+
+	candidates, err := orchestrator.ListImportCandidates(ctx(), backendName, nil)
+	if assert.NoError(t, err) && assert.Len(t, candidates, 2) {
+		assert.Equal(t, "origVolume01", candidates[0].InternalName)
+		assert.Equal(t, "origVolume02", candidates[1].InternalName)
+		assert.Equal(t, backendName, candidates[0].Backend)
+		assert.Equal(t, backendProtocol, candidates[0].Protocol)
+	}
+
+	// Imported volumes are no longer candidates, nor are those the filter leaves out
+	if _, err = orchestrator.ImportVolume(ctx(), volumeConfig); err != nil {
+		t.Fatal("Unable to import volume: ", err)
+	}
+	candidates, err = orchestrator.ListImportCandidates(ctx(), backendName, nil)
+	if assert.NoError(t, err) && assert.Len(t, candidates, 1) {
+		assert.Equal(t, "origVolume02", candidates[0].InternalName)
+	}
+	candidates, err = orchestrator.ListImportCandidates(ctx(), backendName,
+		&storage.ImportDiscoveryFilter{MinSize: 2000000000})
+	assert.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	_, err = orchestrator.ListImportCandidates(ctx(), "noBackend", nil)
+	assert.True(t, utils.IsNotFoundError(err), "unknown backend found")
+}
+
 func TestValidateImportVolumeNasBackend(t *testing.T) {
 	const (
 		backendName     = "backend01"
@@ -2578,7 +3127,7 @@ func TestValidateImportVolumeNasBackend(t *testing.T) {
 
 	orchestrator, volumeConfig := importVolumeSetup(t, backendName, scName, volumeName, originalName, backendProtocol)
 
-	_, err := orchestrator.AddVolume(volumeConfig)
+	_, err := orchestrator.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
@@ -2651,7 +3200,7 @@ func TestValidateImportVolumeSanBackend(t *testing.T) {
 
 	orchestrator, volumeConfig := importVolumeSetup(t, backendName, scName, volumeName, originalName, backendProtocol)
 
-	_, err := orchestrator.AddVolume(volumeConfig)
+	_, err := orchestrator.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		t.Fatal("Unable to add volume: ", err)
 	}
@@ -2943,7 +3492,7 @@ func TestSnapshotVolumes(t *testing.T) {
 		},
 	} {
 		// Create the source volume
-		_, err := orchestrator.AddVolume(s.config)
+		_, err := orchestrator.AddVolume(ctx(), s.config)
 		if err != nil {
 			t.Errorf("%s: could not add volume: %v", s.name, err)
 			continue
@@ -2964,7 +3513,7 @@ func TestSnapshotVolumes(t *testing.T) {
 			Name:       snapshotName,
 			VolumeName: volume.Config.Name,
 		}
-		snapshotExternal, err := orchestrator.CreateSnapshot(snapshotConfig)
+		snapshotExternal, err := orchestrator.CreateSnapshot(ctx(), snapshotConfig)
 		if err != nil {
 			t.Fatalf("%s: got unexpected error creating snapshot: %v", s.name, err)
 		}
@@ -2991,7 +3540,7 @@ func TestSnapshotVolumes(t *testing.T) {
 		}
 		orchestrator.mutex.Unlock()
 
-		err = orchestrator.DeleteSnapshot(volume.Config.Name, snapshotName)
+		err = orchestrator.DeleteSnapshot(ctx(), volume.Config.Name, snapshotName)
 		if err != nil {
 			t.Fatalf("%s: got unexpected error deleting snapshot: %v", s.name, err)
 		}
@@ -3069,3 +3618,163 @@ func TestGetProtocol(t *testing.T) {
 		assert.True(t, tc.expected == protocolLocal, "expected both the protocols to be equal!")
 	}
 }
+
+func TestAddVolumeUUID(t *testing.T) {
+
+	const (
+		backendName = "uuidBackend"
+		scName      = "uuidBackendTest"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName, config.File)
+
+	// A volume gets a UUID if its request doesn't supply one
+	_, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig("uuidVolume1", 1, scName, config.File))
+	assert.NoError(t, err)
+	source := orchestrator.volumes["uuidVolume1"]
+	assert.NotEmpty(t, source.Config.UUID)
+
+	volumeConfig := tu.GenerateVolumeConfig("uuidVolume2", 1, scName, config.File)
+	volumeConfig.UUID = "2c5a1f4e-0d57-4a0b-9d6e-8c9f0e7b3a21"
+	_, err = orchestrator.AddVolume(ctx(), volumeConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, volumeConfig.UUID, orchestrator.volumes["uuidVolume2"].Config.UUID)
+
+	// A clone is identified by its own request, not the source volume's
+	cloneConfig := tu.GenerateVolumeConfig("uuidClone", 1, scName, config.File)
+	cloneConfig.CloneSourceVolume = "uuidVolume1"
+	_, err = orchestrator.CloneVolume(ctx(), cloneConfig)
+	assert.NoError(t, err)
+	clone := orchestrator.volumes["uuidClone"]
+	assert.NotEmpty(t, clone.Config.UUID)
+	assert.NotEqual(t, source.Config.UUID, clone.Config.UUID)
+}
+
+func TestUnpublishVolume(t *testing.T) {
+
+	const (
+		backendName = "unpublishBackend"
+		scName      = "unpublishSC"
+		volumeName  = "unpublishVolume"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	volumeConfig := tu.GenerateVolumeConfig(volumeName, 1, scName, config.File)
+	if _, err := orchestrator.AddVolume(ctx(), volumeConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	// The fake driver can't publish volumes, so record the publications directly
+	vol := orchestrator.volumes[volumeName]
+	vol.AddPublishedNode("node1")
+	vol.AddPublishedNode("node2")
+	if err := orchestrator.updateVolumeOnPersistentStore(vol); err != nil {
+		t.Fatal("Unable to update volume: ", err)
+	}
+
+	assert.NoError(t, orchestrator.UnpublishVolume(volumeName, "node1"))
+	assert.NoError(t, orchestrator.UnpublishVolume(volumeName, "node1"))
+	err := orchestrator.UnpublishVolume("missingVolume", "node1")
+	assert.True(t, utils.IsNotFoundError(err), "unpublished missing volume")
+
+	// The remaining publication survives a restart
+	newOrchestrator := getOrchestrator()
+	bootstrappedVolume, err := newOrchestrator.GetVolume(volumeName)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"node2"}, bootstrappedVolume.PublishedNodes)
+	}
+}
+
+func TestResizeVolumeOfflineExpansion(t *testing.T) {
+
+	const (
+		backendName = "offlineExpansionBackend"
+		scName      = "offlineExpansionSC"
+		volumeName  = "offlineExpansionVolume"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	backend, err := orchestrator.getBackendByBackendName(backendName)
+	if err != nil {
+		t.Fatal("Unable to find backend: ", err)
+	}
+	backend.Driver.(*fakedriver.StorageDriver).Config.OfflineExpansion = true
+
+	volumeConfig := tu.GenerateVolumeConfig(volumeName, 1, scName, config.File)
+	if _, err = orchestrator.AddVolume(ctx(), volumeConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	// A published volume may not be grown
+	orchestrator.volumes[volumeName].AddPublishedNode("node1")
+	err = orchestrator.ResizeVolume(ctx(), volumeName, "2147483648")
+	assert.True(t, utils.IsVolumePublishedError(err), "published volume grown offline")
+
+	// Once unpublished, it may
+	assert.NoError(t, orchestrator.UnpublishVolume(volumeName, "node1"))
+	assert.NoError(t, orchestrator.ResizeVolume(ctx(), volumeName, "2147483648"))
+	assert.Equal(t, "2147483648", orchestrator.volumes[volumeName].Config.Size)
+}
+
+func TestCloneVolumeAcrossNamespaces(t *testing.T) {
+
+	const (
+		backendName = "namespaceCloneBackend"
+		scName      = "namespaceCloneSC"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, backendName, scName, config.File)
+
+	sourceConfig := tu.GenerateVolumeConfig("namespaceSource", 1, scName, config.File)
+	sourceConfig.Namespace = "team-a"
+	if _, err := orchestrator.AddVolume(ctx(), sourceConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	// Another namespace may not clone the volume until the volume's namespace permits it
+	cloneConfig := tu.GenerateVolumeConfig("namespaceClone", 1, scName, config.File)
+	cloneConfig.CloneSourceVolume = "namespaceSource"
+	cloneConfig.Namespace = "team-b"
+	_, err := orchestrator.CloneVolume(ctx(), cloneConfig)
+	assert.True(t, utils.IsCloneNotPermittedError(err), "clone into unpermitted namespace")
+	assert.NotContains(t, orchestrator.volumes, "namespaceClone")
+
+	namespaces := "team-b"
+	_, err = orchestrator.UpdateVolume(ctx(), "namespaceSource",
+		&storage.UpdateVolumeRequest{CloneToNamespaces: &namespaces})
+	assert.NoError(t, err)
+
+	clone, err := orchestrator.CloneVolume(ctx(), cloneConfig)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "team-b", clone.Config.Namespace)
+		assert.Empty(t, clone.Config.CloneToNamespaces)
+	}
+
+	// A legacy volume saved without its namespace may not be cloned into a namespace it doesn't permit
+	legacyConfig := tu.GenerateVolumeConfig("namespaceLegacy", 1, scName, config.File)
+	if _, err := orchestrator.AddVolume(ctx(), legacyConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	legacyCloneConfig := tu.GenerateVolumeConfig("namespaceLegacyClone", 1, scName, config.File)
+	legacyCloneConfig.CloneSourceVolume = "namespaceLegacy"
+	legacyCloneConfig.Namespace = "team-b"
+	_, err = orchestrator.CloneVolume(ctx(), legacyCloneConfig)
+	assert.True(t, utils.IsCloneNotPermittedError(err), "legacy volume cloned into unpermitted namespace")
+
+	// A legacy volume may be cloned into the namespace in which the request found its claim
+	legacyCloneConfig.CloneSourceNamespace = "team-a"
+	legacyCloneConfig.Namespace = "team-a"
+	if _, err = orchestrator.CloneVolume(ctx(), legacyCloneConfig); err != nil {
+		t.Error("Unable to clone legacy volume into its own namespace: ", err)
+	}
+	otherCloneConfig := tu.GenerateVolumeConfig("namespaceLegacyOther", 1, scName, config.File)
+	otherCloneConfig.CloneSourceVolume = "namespaceLegacy"
+	otherCloneConfig.CloneSourceNamespace = "team-a"
+	otherCloneConfig.Namespace = "team-b"
+	_, err = orchestrator.CloneVolume(ctx(), otherCloneConfig)
+	assert.True(t, utils.IsCloneNotPermittedError(err), "legacy volume cloned into another namespace")
+}
@@ -3,6 +3,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -14,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
 	"github.com/netapp/trident/storage"
@@ -57,6 +59,7 @@ type MockOrchestrator struct {
 	//mockBackends       map[string]*mockBackend
 	mockBackendsByUUID map[string]*mockBackend
 	storageClasses     map[string]*storageclass.StorageClass
+	quotas             map[string]*storage.Quota
 	volumes            map[string]*storage.Volume
 	nodes              map[string]*utils.Node
 	mutex              *sync.Mutex
@@ -78,6 +81,14 @@ func (m *MockOrchestrator) GetVersion() (string, error) {
 	return config.OrchestratorVersion.String(), nil
 }
 
+func (m *MockOrchestrator) CheckLiveness(ctx context.Context) *HealthReport {
+	return newHealthReport([]*SubsystemHealth{newSubsystemHealth(healthSubsystemCore, true, nil)})
+}
+
+func (m *MockOrchestrator) CheckReadiness(ctx context.Context) *HealthReport {
+	return newHealthReport([]*SubsystemHealth{newSubsystemHealth(healthSubsystemCore, true, nil)})
+}
+
 // TODO:  Add extra methods to add backends without needing to provide a valid,
 // stringified JSON config.
 func (m *MockOrchestrator) AddBackend(configJSON string) (*storage.BackendExternal, error) {
@@ -237,6 +248,91 @@ func (m *MockOrchestrator) UpdateBackendState(backendName, backendState string)
 	return nil, fmt.Errorf("operation not currently supported")
 }
 
+// UpdateBackendAPITrace turns API tracing on or off for an existing backend
+func (m *MockOrchestrator) UpdateBackendAPITrace(backendName string, enabled bool) (
+	storageBackendExternal *storage.BackendExternal, err error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) UpdateBackendLogging(
+	backendName string, request *storage.UpdateBackendLoggingRequest,
+) (*storage.BackendExternal, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) RefreshBackendPools(
+	ctx context.Context, backendName string,
+) (*storage.BackendExternal, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListRecoverableVolumes(
+	ctx context.Context, backendName string,
+) ([]*storage.RecoverableVolume, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) RecoverVolume(ctx context.Context, backendName, internalName string) error {
+	//TODO
+	return fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListBackendVolumes(
+	ctx context.Context, backendName string, limit int, continueToken string,
+) (*storage.VolumePage, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListImportCandidates(
+	ctx context.Context, backendName string, filter *storage.ImportDiscoveryFilter,
+) ([]*storage.ImportCandidate, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) GetBackendStorageLogs(
+	ctx context.Context, backendName string,
+) ([]*storage.StorageLogEntry, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) GetBackendBuckets(ctx context.Context, backendName string) (*storage.BucketReport, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListAuditEvents(filter audit.Filter) ([]*audit.Event, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListStorageJobs() ([]*storage.StorageJob, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) ListOrphanedResources() ([]*storage.OrphanedResource, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
+func (m *MockOrchestrator) RecordVolumeEvent(volumeName string, event *utils.VolumeEvent) error {
+	//TODO
+	return fmt.Errorf("operation not currently supported")
+}
+
+// PreviewBackend resolves the storage pools of a backend config
+func (m *MockOrchestrator) PreviewBackend(configJSON string) (*storage.BackendPreview, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
+
 func (m *MockOrchestrator) dumpKnownBackends() {
 	log.Debug(">>>MockOrchestrator#dumpKnownBackends")
 	defer log.Debug("<<<MockOrchestrator#dumpKnownBackends")
@@ -323,7 +419,7 @@ func (m *MockOrchestrator) DeleteBackendByBackendUUID(backendName, backendUUID s
 	return nil
 }
 
-func (m *MockOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
+func (m *MockOrchestrator) AddVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
 	var mockBackends map[string]*mockBackend
 
 	// Don't bother with actually getting the backends from the storage class;
@@ -389,7 +485,7 @@ func (m *MockOrchestrator) AddVolume(volumeConfig *storage.VolumeConfig) (*stora
 	return volume.ConstructExternal(), nil
 }
 
-func (m *MockOrchestrator) CloneVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
+func (m *MockOrchestrator) CloneVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error) {
 	// TODO: write this method to enable CloneVolume unit tests
 	return nil, nil
 }
@@ -400,7 +496,7 @@ func (m *MockOrchestrator) GetVolumeExternal(volumeName string, backendName stri
 }
 
 func (m *MockOrchestrator) LegacyImportVolume(
-	volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback,
+	ctx context.Context, volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	// TODO: write this method to enable GetVolumeExternal unit tests
@@ -408,7 +504,7 @@ func (m *MockOrchestrator) LegacyImportVolume(
 }
 
 func (m *MockOrchestrator) ImportVolume(
-	volumeConfig *storage.VolumeConfig,
+	ctx context.Context, volumeConfig *storage.VolumeConfig,
 ) (externalVol *storage.VolumeExternal, err error) {
 
 	// TODO: write this method to enable GetVolumeExternal unit tests
@@ -506,7 +602,7 @@ func (m *MockOrchestrator) ListVolumes() ([]*storage.VolumeExternal, error) {
 	return volumes, nil
 }
 
-func (m *MockOrchestrator) DeleteVolume(volumeName string) error {
+func (m *MockOrchestrator) DeleteVolume(ctx context.Context, volumeName string) error {
 
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -538,11 +634,40 @@ func (m *MockOrchestrator) DetachVolume(volumeName, mountpoint string) error {
 }
 
 func (m *MockOrchestrator) PublishVolume(
-	volumeName string, publishInfo *utils.VolumePublishInfo) error {
+	ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if vol, found := m.volumes[volumeName]; found && publishInfo.HostName != "" {
+		vol.AddPublishedNode(publishInfo.HostName)
+	}
+	return nil
+}
+
+func (m *MockOrchestrator) UnpublishVolume(volumeName, nodeName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	vol, found := m.volumes[volumeName]
+	if !found {
+		return utils.NotFoundError("not found")
+	}
+	vol.RemovePublishedNode(nodeName)
 	return nil
 }
 
-func (m *MockOrchestrator) CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error) {
+func (m *MockOrchestrator) UpdateVolumePublication(
+	ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo) (bool, error) {
+	return false, nil
+}
+
+func (m *MockOrchestrator) CreateSnapshot(ctx context.Context, snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error) {
+	return nil, nil
+}
+
+func (m *MockOrchestrator) CreateGroupSnapshot(
+	ctx context.Context, groupConfig *storage.GroupSnapshotConfig,
+) ([]*storage.SnapshotExternal, error) {
 	return nil, nil
 }
 
@@ -562,11 +687,15 @@ func (m *MockOrchestrator) ListSnapshotsForVolume(volumeName string) ([]*storage
 	return make([]*storage.SnapshotExternal, 0), nil
 }
 
-func (m *MockOrchestrator) ReadSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error) {
+func (m *MockOrchestrator) ReadSnapshotsForVolume(ctx context.Context, volumeName string) ([]*storage.SnapshotExternal, error) {
 	return make([]*storage.SnapshotExternal, 0), nil
 }
 
-func (m *MockOrchestrator) DeleteSnapshot(volumeName, snapshotName string) error {
+func (m *MockOrchestrator) DeleteSnapshot(ctx context.Context, volumeName, snapshotName string) error {
+	return nil
+}
+
+func (m *MockOrchestrator) RestoreSnapshot(ctx context.Context, volumeName, snapshotName string) error {
 	return nil
 }
 
@@ -574,10 +703,28 @@ func (m *MockOrchestrator) ReloadVolumes() error {
 	return nil
 }
 
-func (m *MockOrchestrator) ResizeVolume(volumeName, newSize string) error {
+func (m *MockOrchestrator) ResizeVolume(ctx context.Context, volumeName, newSize string) error {
 	return nil
 }
 
+func (m *MockOrchestrator) UpdateVolume(
+	ctx context.Context, volumeName string, updateRequest *storage.UpdateVolumeRequest,
+) (*storage.VolumeExternal, error) {
+	return nil, nil
+}
+
+func (m *MockOrchestrator) MoveVolume(
+	ctx context.Context, volumeName string, moveRequest *storage.MoveVolumeRequest,
+) (*storage.VolumeExternal, error) {
+	return nil, nil
+}
+
+func (m *MockOrchestrator) MigrateVolume(
+	ctx context.Context, volumeName string, migrateRequest *storage.MigrateVolumeRequest,
+) (*storage.VolumeExternal, error) {
+	return nil, nil
+}
+
 func NewMockOrchestrator() *MockOrchestrator {
 	return &MockOrchestrator{
 		backendsByUUID:     make(map[string]*storage.Backend),
@@ -585,6 +732,7 @@ func NewMockOrchestrator() *MockOrchestrator {
 		// backends:       make(map[string]*storage.Backend),
 		// mockBackends:   make(map[string]*mockBackend),
 		storageClasses: make(map[string]*storageclass.StorageClass),
+		quotas:         make(map[string]*storage.Quota),
 		volumes:        make(map[string]*storage.Volume),
 		mutex:          &sync.Mutex{},
 	}
@@ -625,6 +773,43 @@ func (m *MockOrchestrator) DeleteStorageClass(scName string) error {
 	return nil
 }
 
+func (m *MockOrchestrator) AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.quotas[quota.Name] = quota
+	return quota.ConstructExternal(0, 0), nil
+}
+
+func (m *MockOrchestrator) GetQuota(quotaName string) (*storage.QuotaExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	quota, found := m.quotas[quotaName]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("quota %s not found", quotaName))
+	}
+	return quota.ConstructExternal(0, 0), nil
+}
+
+func (m *MockOrchestrator) ListQuotas() ([]*storage.QuotaExternal, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	ret := make([]*storage.QuotaExternal, 0, len(m.quotas))
+	for _, quota := range m.quotas {
+		ret = append(ret, quota.ConstructExternal(0, 0))
+	}
+	return ret, nil
+}
+
+func (m *MockOrchestrator) DeleteQuota(quotaName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.quotas[quotaName]; !ok {
+		return utils.NotFoundError(fmt.Sprintf("quota %s not found", quotaName))
+	}
+	delete(m.quotas, quotaName)
+	return nil
+}
+
 func (m *MockOrchestrator) AddNode(node *utils.Node) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -662,7 +847,7 @@ func (m *MockOrchestrator) DeleteNode(nName string) error {
 	return nil
 }
 
-func (m *MockOrchestrator) AddVolumeTransaction(volTxn *storage.VolumeTransaction) error {
+func (m *MockOrchestrator) AddVolumeTransaction(ctx context.Context, volTxn *storage.VolumeTransaction) error {
 	return nil
 }
 
@@ -673,3 +858,8 @@ func (m *MockOrchestrator) GetVolumeTransaction(volTxn *storage.VolumeTransactio
 func (m *MockOrchestrator) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction) error {
 	return nil
 }
+
+func (m *MockOrchestrator) WatchObjects(ctx context.Context, sinceID uint64) (<-chan *ObjectEvent, error) {
+	//TODO
+	return nil, fmt.Errorf("operation not currently supported")
+}
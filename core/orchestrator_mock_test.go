@@ -73,7 +73,7 @@ func addAndRetrieveVolume(
 		t.Fatalf("Unable to add storage class %s (%s): %v", vc.Name,
 			vc.Protocol, err)
 	}
-	vol, err := m.AddVolume(vc)
+	vol, err := m.AddVolume(ctx(), vc)
 	if err != nil {
 		t.Fatalf("Unable to add volume %s (%s): %s", vc.Name, vc.Protocol, err)
 	}
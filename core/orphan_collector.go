@@ -0,0 +1,301 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+const orphanCollectorPeriod = 60 * time.Minute
+
+// StartOrphanCollector starts the thread that finds the resources on each backend's storage system that
+// no longer belong to anything known to Trident, and deletes them if the backend's policy says so.
+func (o *TridentOrchestrator) StartOrphanCollector(period time.Duration) {
+
+	o.orphanCollectorTicker = time.NewTicker(period)
+	o.orphanCollectorChannel = make(chan struct{})
+
+	go func() {
+		log.Debug("Orphan collector started.")
+
+		for {
+			select {
+			case tick := <-o.orphanCollectorTicker.C:
+				log.WithField("tick", tick).Debug("Orphan collector running.")
+				o.collectOrphanedResources()
+			case <-o.orphanCollectorChannel:
+				log.Debugf("Orphan collector stopped.")
+				return
+			}
+		}
+	}()
+}
+
+// StopOrphanCollector stops the thread that collects orphaned resources.
+func (o *TridentOrchestrator) StopOrphanCollector() {
+	if o.orphanCollectorTicker != nil {
+		o.orphanCollectorTicker.Stop()
+	}
+	if o.orphanCollectorChannel != nil && !o.orphanCollectorStopped {
+		close(o.orphanCollectorChannel)
+		o.orphanCollectorStopped = true
+	}
+	log.Debug("Orphan collector stopped.")
+}
+
+// collectOrphanedResources is called periodically by the orphan collector to find the orphaned resources
+// on every backend, remembering when each was first seen, and to delete those that have been orphaned for
+// longer than their backend's grace period if the backend's policy allows it.  The backends are scanned
+// without the orchestrator lock, which is only held to read what Trident knows.
+func (o *TridentOrchestrator) collectOrphanedResources() {
+
+	if o.bootstrapError != nil {
+		log.WithField("error", o.bootstrapError).Errorf("Orphan collector blocked by bootstrap error.")
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+
+	// Discard any persistent store batches whose operations failed.  Those that still can't be discarded
+	// keep their objects known below.
+	if err := persistentstore.DiscardBatchIntents(o.storeClient); err != nil {
+		log.WithField("error", err).Warning("Orphan collector could not discard persistent store batches.")
+	}
+
+	volumes, snapshots, err := o.getKnownVolumesAndSnapshots()
+	backends := make([]*storage.Backend, 0, len(o.backends))
+	for _, backend := range o.backends {
+		backends = append(backends, backend)
+	}
+	previousOrphans := o.orphans
+
+	o.mutex.Unlock()
+
+	if err != nil {
+		log.WithField("error", err).Errorf("Orphan collector could not read transactions.")
+		return
+	}
+
+	now := time.Now()
+	orphans := make(map[string]*storage.OrphanedResource)
+	expired := make([]*orphanedBackendResource, 0)
+
+	for _, backend := range backends {
+
+		resources, err := backend.ListOrphanedResources(ctx, volumes, snapshots)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"backend": backend.Name,
+				"error":   err,
+			}).Warning("Orphan collector could not list orphaned resources.")
+
+			// Remember what was found before, so that it isn't seen afresh next time
+			for key, orphan := range previousOrphans {
+				if orphan.Backend == backend.Name {
+					orphans[key] = orphan
+				}
+			}
+			continue
+		}
+
+		policy := backend.GetOrphanPolicy()
+
+		for _, resource := range resources {
+
+			firstSeen := now
+			previous, seenBefore := previousOrphans[resource.Key()]
+			if seenBefore {
+				if t, err := time.Parse(time.RFC3339, previous.FirstSeen); err == nil {
+					firstSeen = t
+				}
+			}
+			resource.FirstSeen = firstSeen.Format(time.RFC3339)
+			orphans[resource.Key()] = resource
+
+			// A resource must have been orphaned when the backend was last scanned as well, so that one
+			// created while this scan ran isn't deleted
+			if policy.Delete && seenBefore && now.Sub(firstSeen) >= policy.GracePeriod {
+				expired = append(expired, &orphanedBackendResource{backend: backend, resource: resource})
+			} else if !seenBefore {
+				log.WithFields(orphanLogFields(resource)).Warning("Orphan collector found orphaned resource.")
+			}
+		}
+	}
+
+	o.deleteOrphanedResources(ctx, expired, orphans)
+
+	o.mutex.Lock()
+	o.orphans = orphans
+	o.mutex.Unlock()
+}
+
+// orphanedBackendResource is an orphaned resource along with the backend on which it was found.
+type orphanedBackendResource struct {
+	backend  *storage.Backend
+	resource *storage.OrphanedResource
+}
+
+// deleteOrphanedResources deletes orphaned resources, in the order they were found, and forgets those
+// deleted.  Each resource is first checked against the volumes and snapshots Trident now knows, in case
+// one was created for it since the backends were scanned.
+func (o *TridentOrchestrator) deleteOrphanedResources(
+	ctx context.Context, expired []*orphanedBackendResource, orphans map[string]*storage.OrphanedResource,
+) {
+
+	if len(expired) == 0 {
+		return
+	}
+
+	o.mutex.Lock()
+	volumes, snapshots, err := o.getKnownVolumesAndSnapshots()
+	o.mutex.Unlock()
+
+	if err != nil {
+		log.WithField("error", err).Errorf("Orphan collector could not read transactions.")
+		return
+	}
+
+	for _, orphan := range expired {
+
+		logFields := orphanLogFields(orphan.resource)
+
+		if isOrphanKnown(orphan.resource, volumes, snapshots) {
+			log.WithFields(logFields).Debug("Orphan collector found resource no longer orphaned.")
+			delete(orphans, orphan.resource.Key())
+			continue
+		}
+
+		if err := orphan.backend.DeleteOrphanedResource(ctx, orphan.resource); err != nil {
+			log.WithFields(logFields).WithField("error", err).Warning(
+				"Orphan collector could not delete orphaned resource.")
+			continue
+		}
+
+		log.WithFields(logFields).Info("Orphan collector deleted orphaned resource.")
+		delete(orphans, orphan.resource.Key())
+	}
+}
+
+// isOrphanKnown returns whether an orphaned volume, LUN, or snapshot belongs to one of the supplied volumes
+// or snapshots.
+func isOrphanKnown(
+	resource *storage.OrphanedResource, volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig,
+) bool {
+
+	isVolumeKnown := func(internalName string) bool {
+		for _, volume := range volumes {
+			if volume.InternalName == internalName {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch resource.Type {
+	case storage.OrphanedResourceVolume:
+		return isVolumeKnown(resource.Name)
+	case storage.OrphanedResourceLUN:
+		return isVolumeKnown(resource.Parent)
+	case storage.OrphanedResourceSnapshot:
+		for _, snapshot := range snapshots {
+			if snapshot.VolumeInternalName == resource.Parent && snapshot.InternalName == resource.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func orphanLogFields(resource *storage.OrphanedResource) log.Fields {
+	return log.Fields{
+		"backend":   resource.Backend,
+		"type":      resource.Type,
+		"resource":  resource.Name,
+		"parent":    resource.Parent,
+		"firstSeen": resource.FirstSeen,
+	}
+}
+
+// getKnownVolumesAndSnapshots returns the configs of the volumes and snapshots known to Trident, including
+// those whose creation is in progress, or whose saving is recorded by an unfinished persistent store batch.
+// The caller should hold the orchestrator lock.
+func (o *TridentOrchestrator) getKnownVolumesAndSnapshots() (
+	[]*storage.VolumeConfig, []*storage.SnapshotConfig, error,
+) {
+
+	volumes := make([]*storage.VolumeConfig, 0, len(o.volumes))
+	for _, volume := range o.volumes {
+		volumes = append(volumes, volume.Config)
+	}
+	snapshots := make([]*storage.SnapshotConfig, 0, len(o.snapshots))
+	for _, snapshot := range o.snapshots {
+		snapshots = append(snapshots, snapshot.Config)
+	}
+
+	txns, err := o.storeClient.GetVolumeTransactions()
+	if err != nil && !persistentstore.MatchKeyNotFoundErr(err) {
+		return nil, nil, err
+	}
+	for _, txn := range txns {
+		if txn.Config != nil {
+			volumes = append(volumes, txn.Config)
+		}
+		if txn.VolumeCreatingConfig != nil {
+			volumes = append(volumes, &txn.VolumeCreatingConfig.VolumeConfig)
+		}
+		if txn.SnapshotConfig != nil {
+			snapshots = append(snapshots, txn.SnapshotConfig)
+		}
+	}
+
+	intents, err := o.storeClient.GetBatchIntents()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, intent := range intents {
+		for _, entry := range intent.Entries {
+			if entry.Volume != nil {
+				volumes = append(volumes, entry.Volume.Config)
+			}
+			if entry.Snapshot != nil {
+				snapshots = append(snapshots, entry.Snapshot.Config)
+			}
+		}
+	}
+
+	return volumes, snapshots, nil
+}
+
+// ListOrphanedResources returns the orphaned resources found on the backends by the orphan collector,
+// along with when each was first seen.
+func (o *TridentOrchestrator) ListOrphanedResources() (resources []*storage.OrphanedResource, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("orphan_list", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	resources = make([]*storage.OrphanedResource, 0, len(o.orphans))
+	for _, orphan := range o.orphans {
+		resource := *orphan
+		resources = append(resources, &resource)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Key() < resources[j].Key()
+	})
+	return resources, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+)
+
+func TestOrphanCollectorStartStop(t *testing.T) {
+
+	storeClient := persistentstore.NewInMemoryClient()
+	o := NewTridentOrchestrator(storeClient)
+	if err := o.Bootstrap(); err != nil {
+		log.Fatal("Failure occurred during bootstrapping: ", err)
+	}
+
+	assert.NotNil(t, o.orphanCollectorChannel)
+	assert.False(t, o.orphanCollectorStopped)
+
+	o.StopOrphanCollector()
+	assert.True(t, o.orphanCollectorStopped)
+}
+
+func TestListOrphanedResources(t *testing.T) {
+
+	o, _ := setupOrchestratorAndBackend(t)
+	o.StopOrphanCollector()
+
+	firstSeen := time.Unix(1600000000, 0).Format(time.RFC3339)
+	for _, orphan := range []*storage.OrphanedResource{
+		{Backend: "b", Type: storage.OrphanedResourceVolume, Name: "trident_pvc_1", FirstSeen: firstSeen},
+		{Backend: "a", Type: storage.OrphanedResourceSnapshot, Name: "snapshot-1", Parent: "trident_pvc_2"},
+	} {
+		o.orphans[orphan.Key()] = orphan
+	}
+
+	resources, err := o.ListOrphanedResources()
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "a", resources[0].Backend)
+	assert.Equal(t, "b", resources[1].Backend)
+	assert.Equal(t, firstSeen, resources[1].FirstSeen)
+
+	// The fake backend has no orphaned resources, so the collector forgets those found before
+	o.collectOrphanedResources()
+	resources, err = o.ListOrphanedResources()
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestIsOrphanKnown(t *testing.T) {
+
+	volumes := []*storage.VolumeConfig{{InternalName: "trident_pvc_1"}}
+	snapshots := []*storage.SnapshotConfig{{InternalName: "snapshot-a", VolumeInternalName: "trident_pvc_1"}}
+
+	tests := map[string]struct {
+		resource *storage.OrphanedResource
+		known    bool
+	}{
+		"Known volume": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceVolume, Name: "trident_pvc_1"},
+			known:    true,
+		},
+		"Unknown volume": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceVolume, Name: "trident_pvc_2"},
+		},
+		"LUN of known volume": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceLUN, Name: "lun0",
+				Parent: "trident_pvc_1"},
+			known: true,
+		},
+		"LUN of unknown volume": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceLUN, Name: "lun0",
+				Parent: "trident_pvc_2"},
+		},
+		"Known snapshot": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceSnapshot, Name: "snapshot-a",
+				Parent: "trident_pvc_1"},
+			known: true,
+		},
+		"Unknown snapshot": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceSnapshot, Name: "snapshot-b",
+				Parent: "trident_pvc_1"},
+		},
+		"Export policy": {
+			resource: &storage.OrphanedResource{Type: storage.OrphanedResourceExportPolicy, Name: "policy"},
+		},
+	}
+	for name, test := range tests {
+		assert.Equal(t, test.known, isOrphanKnown(test.resource, volumes, snapshots), name)
+	}
+}
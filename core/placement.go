@@ -0,0 +1,257 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/netapp/trident/storage"
+	storageclass "github.com/netapp/trident/storage_class"
+)
+
+const (
+	// PlacementRandom picks a backend at random, then one of its pools at random.
+	PlacementRandom = "random"
+	// PlacementSpread picks a pool on the backend holding the fewest volumes.
+	PlacementSpread = "spread"
+	// PlacementBinPack picks the pool with the least free space that can still hold the volume.
+	PlacementBinPack = "binpack"
+	// PlacementWeightedRandom picks a pool at random, weighted by its free space.
+	PlacementWeightedRandom = "weightedRandom"
+
+	DefaultPlacementPolicy = PlacementRandom
+)
+
+// PlacementPolicy chooses the storage pool on which the orchestrator tries to place a new volume, from
+// among the pools that match the volume's storage class, have room for it, and are equally preferred by
+// its topology.
+type PlacementPolicy interface {
+	Name() string
+
+	// SelectPool returns one of the candidate pools.  The candidates are keyed by backend name, and each
+	// backend's pools are in the order in which they would best be tried.  The volume's size may be zero
+	// if it isn't known.
+	SelectPool(candidates map[string][]*storage.Pool, sizeBytes uint64) *storage.Pool
+}
+
+var placementPolicies = map[string]PlacementPolicy{
+	PlacementRandom:         &randomPlacement{},
+	PlacementSpread:         &spreadPlacement{},
+	PlacementBinPack:        &binPackPlacement{},
+	PlacementWeightedRandom: &weightedRandomPlacement{},
+}
+
+// GetPlacementPolicy returns the placement policy with the given name.
+func GetPlacementPolicy(name string) (PlacementPolicy, error) {
+	if policy, ok := placementPolicies[name]; ok {
+		return policy, nil
+	}
+	names := make([]string, 0, len(placementPolicies))
+	for policyName := range placementPolicies {
+		names = append(names, policyName)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown volume placement policy %s, must be one of %s", name, strings.Join(names, ", "))
+}
+
+// SetPlacementPolicy sets the placement policy used for volumes whose storage class doesn't request one.
+func (o *TridentOrchestrator) SetPlacementPolicy(name string) error {
+	policy, err := GetPlacementPolicy(name)
+	if err != nil {
+		return err
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.placementPolicy = policy
+	return nil
+}
+
+// getPlacementPolicy returns the placement policy requested by a storage class, or the orchestrator's
+// policy if the class requests none.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) getPlacementPolicy(sc *storageclass.StorageClass) PlacementPolicy {
+	if name := sc.GetPlacementPolicy(); name != "" {
+		if policy, err := GetPlacementPolicy(name); err == nil {
+			return policy
+		}
+	}
+	if o.placementPolicy == nil {
+		return placementPolicies[DefaultPlacementPolicy]
+	}
+	return o.placementPolicy
+}
+
+// selectPool chooses the next pool to try for a new volume and removes it from poolsByBackend, removing
+// its backend as well if no pools are left on it.  The storage class's placement policy chooses among
+// the pools most preferred by the volume's topology, which are first in each backend's sorted list.
+// The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) selectPool(
+	sc *storageclass.StorageClass, poolsByBackend map[string]*storageclass.BackendPoolInfo,
+	volumeConfig *storage.VolumeConfig, sizeBytes uint64,
+) *storage.Pool {
+
+	candidates := make(map[string][]*storage.Pool)
+	bestPreference := -1
+	for backendName, backendPoolInfo := range poolsByBackend {
+		for _, pool := range backendPoolInfo.Pools {
+			preference := pool.TopologyPreference(volumeConfig.PreferredTopologies)
+			if bestPreference < 0 || preference < bestPreference {
+				candidates = make(map[string][]*storage.Pool)
+				bestPreference = preference
+			}
+			if preference != bestPreference {
+				break
+			}
+			candidates[backendName] = append(candidates[backendName], pool)
+		}
+	}
+
+	pool := o.getPlacementPolicy(sc).SelectPool(candidates, sizeBytes)
+
+	backendName := pool.Backend.Name
+	pools := poolsByBackend[backendName].Pools
+	remaining := make([]*storage.Pool, 0, len(pools))
+	for _, p := range pools {
+		if p != pool {
+			remaining = append(remaining, p)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(poolsByBackend, backendName)
+	} else {
+		poolsByBackend[backendName].Pools = remaining
+	}
+	return pool
+}
+
+// sortedBackendNames returns the backend names of a candidate map in a stable order, so that policies
+// choosing among them at random do so reproducibly for a given random source.
+func sortedBackendNames(candidates map[string][]*storage.Pool) []string {
+	names := make([]string, 0, len(candidates))
+	for backendName := range candidates {
+		names = append(names, backendName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// randomPlacement chooses a backend at random, then its first pool, as backend pool lists are shuffled.
+type randomPlacement struct{}
+
+func (p *randomPlacement) Name() string {
+	return PlacementRandom
+}
+
+func (p *randomPlacement) SelectPool(candidates map[string][]*storage.Pool, _ uint64) *storage.Pool {
+	backendNames := sortedBackendNames(candidates)
+	return candidates[backendNames[rand.Intn(len(backendNames))]][0]
+}
+
+// spreadPlacement chooses the first pool of the backend with the fewest volumes, breaking ties at random.
+type spreadPlacement struct{}
+
+func (p *spreadPlacement) Name() string {
+	return PlacementSpread
+}
+
+func (p *spreadPlacement) SelectPool(candidates map[string][]*storage.Pool, _ uint64) *storage.Pool {
+
+	leastUsed := make([]string, 0)
+	fewest := -1
+	for _, backendName := range sortedBackendNames(candidates) {
+		count := len(candidates[backendName][0].Backend.Volumes)
+		if fewest < 0 || count < fewest {
+			leastUsed = leastUsed[:0]
+			fewest = count
+		}
+		if count == fewest {
+			leastUsed = append(leastUsed, backendName)
+		}
+	}
+	return candidates[leastUsed[rand.Intn(len(leastUsed))]][0]
+}
+
+// binPackPlacement chooses the pool with the least free space that can still hold the volume, so that
+// pools are filled before others are used.  Pools whose capacity isn't known are chosen only if no
+// other pool can hold the volume, and then at random.
+type binPackPlacement struct{}
+
+func (p *binPackPlacement) Name() string {
+	return PlacementBinPack
+}
+
+func (p *binPackPlacement) SelectPool(candidates map[string][]*storage.Pool, sizeBytes uint64) *storage.Pool {
+
+	var fullest *storage.Pool
+	var fullestFree uint64
+	for _, backendName := range sortedBackendNames(candidates) {
+		for _, pool := range candidates[backendName] {
+			capacity := pool.Capacity()
+			if capacity == nil || capacity.FreeBytes < sizeBytes {
+				continue
+			}
+			if fullest == nil || capacity.FreeBytes < fullestFree {
+				fullest = pool
+				fullestFree = capacity.FreeBytes
+			}
+		}
+	}
+	if fullest == nil {
+		return placementPolicies[PlacementRandom].SelectPool(candidates, sizeBytes)
+	}
+	return fullest
+}
+
+// weightedRandomPlacement chooses a pool at random with a probability in proportion to its free space,
+// so that emptier pools fill faster without all volumes landing on the emptiest one.  Pools whose
+// capacity isn't known are weighted as the average of those whose capacity is known.
+type weightedRandomPlacement struct{}
+
+func (p *weightedRandomPlacement) Name() string {
+	return PlacementWeightedRandom
+}
+
+func (p *weightedRandomPlacement) SelectPool(candidates map[string][]*storage.Pool, sizeBytes uint64) *storage.Pool {
+
+	pools := make([]*storage.Pool, 0)
+	weights := make([]float64, 0)
+	var known, knownTotal float64
+	for _, backendName := range sortedBackendNames(candidates) {
+		for _, pool := range candidates[backendName] {
+			weight := -1.0
+			if capacity := pool.Capacity(); capacity != nil {
+				weight = float64(capacity.FreeBytes)
+				known++
+				knownTotal += weight
+			}
+			pools = append(pools, pool)
+			weights = append(weights, weight)
+		}
+	}
+
+	unknownWeight := 1.0
+	if known > 0 && knownTotal > 0 {
+		unknownWeight = knownTotal / known
+	}
+	var total float64
+	for i, weight := range weights {
+		if weight < 0 {
+			weights[i] = unknownWeight
+		}
+		total += weights[i]
+	}
+	if total == 0 {
+		return placementPolicies[PlacementRandom].SelectPool(candidates, sizeBytes)
+	}
+
+	target := rand.Float64() * total
+	for i, weight := range weights {
+		if target < weight {
+			return pools[i]
+		}
+		target -= weight
+	}
+	return pools[len(pools)-1]
+}
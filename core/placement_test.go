@@ -0,0 +1,148 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
+	storageclass "github.com/netapp/trident/storage_class"
+)
+
+func newPlacementBackend(name string, volumes int) *storage.Backend {
+	backend := &storage.Backend{Name: name, Volumes: make(map[string]*storage.Volume)}
+	for i := 0; i < volumes; i++ {
+		backend.Volumes[string(rune('a'+i))] = &storage.Volume{}
+	}
+	return backend
+}
+
+func newPlacementPool(backend *storage.Backend, name string, freeBytes uint64) *storage.Pool {
+	pool := storage.NewStoragePool(backend, name)
+	if freeBytes > 0 {
+		pool.SetCapacity(storage.PoolCapacity{TotalBytes: 1000, FreeBytes: freeBytes})
+	}
+	return pool
+}
+
+func TestGetPlacementPolicy(t *testing.T) {
+
+	for _, name := range []string{PlacementRandom, PlacementSpread, PlacementBinPack, PlacementWeightedRandom} {
+		policy, err := GetPlacementPolicy(name)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, name, policy.Name())
+		}
+	}
+
+	_, err := GetPlacementPolicy("roundRobin")
+	assert.Error(t, err)
+}
+
+func TestSpreadPlacement(t *testing.T) {
+
+	busy := newPlacementBackend("busy", 3)
+	idle := newPlacementBackend("idle", 1)
+	candidates := map[string][]*storage.Pool{
+		"busy": {newPlacementPool(busy, "busy1", 0)},
+		"idle": {newPlacementPool(idle, "idle1", 0), newPlacementPool(idle, "idle2", 0)},
+	}
+
+	for i := 0; i < 10; i++ {
+		pool := placementPolicies[PlacementSpread].SelectPool(candidates, 100)
+		assert.Equal(t, "idle1", pool.Name)
+	}
+}
+
+func TestBinPackPlacement(t *testing.T) {
+
+	backend := newPlacementBackend("backend", 0)
+	candidates := map[string][]*storage.Pool{
+		"backend": {
+			newPlacementPool(backend, "empty", 900),
+			newPlacementPool(backend, "tooFull", 50),
+			newPlacementPool(backend, "nearlyFull", 200),
+			newPlacementPool(backend, "unknown", 0),
+		},
+	}
+
+	pool := placementPolicies[PlacementBinPack].SelectPool(candidates, 100)
+	assert.Equal(t, "nearlyFull", pool.Name)
+
+	// With no pool known to have room, any pool may be tried
+	pool = placementPolicies[PlacementBinPack].SelectPool(candidates, 2000)
+	assert.NotNil(t, pool)
+}
+
+func TestWeightedRandomPlacement(t *testing.T) {
+
+	backend := newPlacementBackend("backend", 0)
+	candidates := map[string][]*storage.Pool{
+		"backend": {
+			newPlacementPool(backend, "emptier", 990),
+			newPlacementPool(backend, "fuller", 10),
+		},
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[placementPolicies[PlacementWeightedRandom].SelectPool(candidates, 0).Name]++
+	}
+	assert.Greater(t, counts["emptier"], counts["fuller"])
+	assert.Equal(t, 1000, counts["emptier"]+counts["fuller"])
+}
+
+func TestSelectPool(t *testing.T) {
+
+	orchestrator := NewTridentOrchestrator(nil)
+	sc := storageclass.New(&storageclass.Config{
+		Name: "binpack",
+		Attributes: map[string]sa.Request{
+			sa.PlacementPolicy: sa.NewStringRequest(PlacementBinPack),
+		},
+	})
+
+	east := newPlacementBackend("east", 0)
+	west := newPlacementBackend("west", 0)
+	eastPool := newPlacementPool(east, "eastPool", 100)
+	eastPool.Attributes[sa.Zone] = sa.NewStringOffer("east")
+	westFull := newPlacementPool(west, "westFull", 100)
+	westFull.Attributes[sa.Zone] = sa.NewStringOffer("west")
+	westEmpty := newPlacementPool(west, "westEmpty", 900)
+	westEmpty.Attributes[sa.Zone] = sa.NewStringOffer("west")
+
+	poolsByBackend := map[string]*storageclass.BackendPoolInfo{
+		"east": {Pools: []*storage.Pool{eastPool}},
+		"west": {Pools: []*storage.Pool{westEmpty, westFull}},
+	}
+	volumeConfig := &storage.VolumeConfig{
+		PreferredTopologies: []map[string]string{{storage.TopologyZoneKey: "west"}},
+	}
+
+	// Bin-packing chooses the fuller of the preferred pools, then the other, then the rest
+	pool := orchestrator.selectPool(sc, poolsByBackend, volumeConfig, 10)
+	assert.Equal(t, westFull, pool)
+	pool = orchestrator.selectPool(sc, poolsByBackend, volumeConfig, 10)
+	assert.Equal(t, westEmpty, pool)
+	assert.NotContains(t, poolsByBackend, "west")
+	pool = orchestrator.selectPool(sc, poolsByBackend, volumeConfig, 10)
+	assert.Equal(t, eastPool, pool)
+	assert.Empty(t, poolsByBackend)
+}
+
+func TestAddStorageClassRejectsUnknownPlacementPolicy(t *testing.T) {
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+
+	_, err := orchestrator.AddStorageClass(&storageclass.Config{
+		Name: "unknownPlacement",
+		Attributes: map[string]sa.Request{
+			sa.PlacementPolicy: sa.NewStringRequest("roundRobin"),
+		},
+	})
+	assert.Error(t, err)
+	assert.NotContains(t, orchestrator.storageClasses, "unknownPlacement")
+}
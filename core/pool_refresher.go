@@ -0,0 +1,193 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+const poolRefresherPeriod = 15 * time.Minute
+
+// StartPoolRefresher starts the thread that has each backend rediscover its storage pools, so that changes
+// on the storage system, such as aggregates assigned to an SVM, are picked up without restarting Trident.
+func (o *TridentOrchestrator) StartPoolRefresher(period time.Duration) {
+
+	o.poolRefresherTicker = time.NewTicker(period)
+	o.poolRefresherChannel = make(chan struct{})
+
+	go func() {
+		log.Debug("Pool refresher started.")
+
+		for {
+			select {
+			case tick := <-o.poolRefresherTicker.C:
+				log.WithField("tick", tick).Debug("Pool refresher running.")
+				o.refreshAllBackendPools()
+			case <-o.poolRefresherChannel:
+				log.Debugf("Pool refresher stopped.")
+				return
+			}
+		}
+	}()
+}
+
+// StopPoolRefresher stops the thread that refreshes the backends' storage pools.
+func (o *TridentOrchestrator) StopPoolRefresher() {
+	if o.poolRefresherTicker != nil {
+		o.poolRefresherTicker.Stop()
+	}
+	if o.poolRefresherChannel != nil && !o.poolRefresherStopped {
+		close(o.poolRefresherChannel)
+		o.poolRefresherStopped = true
+	}
+	log.Debug("Pool refresher stopped.")
+}
+
+// refreshAllBackendPools is called periodically by the pool refresher to rediscover the storage pools of
+// every online backend that can do so.
+func (o *TridentOrchestrator) refreshAllBackendPools() {
+
+	if o.bootstrapError != nil {
+		log.WithField("error", o.bootstrapError).Errorf("Pool refresher blocked by bootstrap error.")
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	for _, backend := range o.backends {
+		if _, ok := backend.Driver.(storage.PoolRefresher); !ok || !backend.State.IsOnline() {
+			continue
+		}
+		// Backends watching for changes to their pools refresh them when a change is reported
+		if reporter, ok := backend.Driver.(storage.PoolChangeReporter); ok && reporter.WatchesPoolChanges() {
+			continue
+		}
+		if err := o.refreshBackendPools(ctx, backend); err != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"backend": backend.Name,
+				"error":   err,
+			}).Warning("Could not refresh backend storage pools.")
+		}
+	}
+}
+
+// refreshChangedBackendPools is called when a backend reports that its storage pools may have changed.
+func (o *TridentOrchestrator) refreshChangedBackendPools(backendUUID string) {
+
+	if o.bootstrapError != nil {
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	backend, ok := o.backends[backendUUID]
+	if !ok || !backend.State.IsOnline() {
+		return
+	}
+	if err := o.refreshBackendPools(ctx, backend); err != nil {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"backend": backend.Name,
+			"error":   err,
+		}).Warning("Could not refresh changed backend storage pools.")
+	}
+}
+
+// RefreshBackendPools has a backend rediscover its storage pools right away, rather than waiting for the
+// pool refresher.
+func (o *TridentOrchestrator) RefreshBackendPools(ctx context.Context, backendName string) (
+	backendExternal *storage.BackendExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("backend_refresh_pools", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	backend, err := o.getBackendByBackendName(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = o.refreshBackendPools(ctx, backend); err != nil {
+		return nil, err
+	}
+
+	return backend.ConstructExternal(), nil
+}
+
+// refreshBackendPools rediscovers a backend's storage pools and matches the storage classes against the
+// new pools.  The caller should hold the orchestrator lock, so that no volume is placed while the storage
+// classes still refer to the old pools.
+func (o *TridentOrchestrator) refreshBackendPools(ctx context.Context, backend *storage.Backend) error {
+
+	oldPools := make(map[string]bool, len(backend.Storage))
+	for name := range backend.Storage {
+		oldPools[name] = true
+	}
+
+	if err := backend.RefreshPools(ctx); err != nil {
+		return err
+	}
+
+	classes := make([]string, 0, len(o.storageClasses))
+	for _, sc := range o.storageClasses {
+		sc.RemovePoolsForBackend(backend)
+		if added := sc.CheckAndAddBackend(backend); added > 0 {
+			classes = append(classes, sc.GetName())
+		}
+	}
+	sort.Strings(classes)
+
+	added, removed := diffPoolNames(oldPools, backend.Storage)
+	logFields := log.Fields{
+		"backend":        backend.Name,
+		"storageClasses": strings.Join(classes, ","),
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		utils.Logc(ctx).WithFields(logFields).Debug("Backend storage pools unchanged.")
+		return nil
+	}
+	logFields["addedPools"] = strings.Join(added, ",")
+	logFields["removedPools"] = strings.Join(removed, ",")
+	logFields["pools"] = len(backend.Storage)
+	utils.Logc(ctx).WithFields(logFields).Info("Backend storage pools changed.")
+
+	return nil
+}
+
+// diffPoolNames returns the sorted names of the pools added and removed since the old pools were listed.
+func diffPoolNames(oldPools map[string]bool, newPools map[string]*storage.Pool) (added, removed []string) {
+	added, removed = make([]string, 0), make([]string, 0)
+	for name := range newPools {
+		if !oldPools[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range oldPools {
+		if _, ok := newPools[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+func TestPoolRefresherStartStop(t *testing.T) {
+
+	storeClient := persistentstore.NewInMemoryClient()
+	o := NewTridentOrchestrator(storeClient)
+	if err := o.Bootstrap(); err != nil {
+		log.Fatal("Failure occurred during bootstrapping: ", err)
+	}
+
+	assert.NotNil(t, o.poolRefresherChannel)
+	assert.False(t, o.poolRefresherStopped)
+
+	o.StopPoolRefresher()
+	assert.True(t, o.poolRefresherStopped)
+}
+
+func TestRefreshBackendPools(t *testing.T) {
+
+	o, _ := setupOrchestratorAndBackend(t)
+	o.StopPoolRefresher()
+
+	// The fake driver can't rediscover its pools, so the periodic refresh skips it
+	_, err := o.RefreshBackendPools(context.Background(), "fakeOne")
+	assert.True(t, utils.IsUnsupportedError(err), "expected unsupported error")
+	o.refreshAllBackendPools()
+	for backendUUID := range o.backends {
+		o.refreshChangedBackendPools(backendUUID)
+	}
+	o.refreshChangedBackendPools("missing")
+
+	_, err = o.RefreshBackendPools(context.Background(), "missing")
+	assert.True(t, utils.IsNotFoundError(err), "expected not found error")
+}
+
+func TestDiffPoolNames(t *testing.T) {
+
+	oldPools := map[string]bool{"aggr1": true, "aggr2": true}
+	newPools := map[string]*storage.Pool{
+		"aggr2": storage.NewStoragePool(nil, "aggr2"),
+		"aggr4": storage.NewStoragePool(nil, "aggr4"),
+		"aggr3": storage.NewStoragePool(nil, "aggr3"),
+	}
+
+	added, removed := diffPoolNames(oldPools, newPools)
+	assert.Equal(t, []string{"aggr3", "aggr4"}, added)
+	assert.Equal(t, []string{"aggr1"}, removed)
+
+	added, removed = diffPoolNames(map[string]bool{"aggr2": true}, map[string]*storage.Pool{
+		"aggr2": storage.NewStoragePool(nil, "aggr2"),
+	})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
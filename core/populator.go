@@ -0,0 +1,110 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// VolumePopulator writes the initial contents of new volumes whose data source is a Kubernetes object
+// other than a PVC or volume snapshot, such as an image kept in an object store.  A volume with a data
+// source isn't reported as created until its populator finishes, so it is never bound while incomplete.
+type VolumePopulator interface {
+	// GroupKind returns the kind of data source the populator handles, qualified by its API group.
+	GroupKind() string
+
+	// Populate begins writing a data source to a new volume, or checks on the progress of writing it
+	// once begun.  It returns a VolumeCreatingError while population runs, and nil once it completes.
+	Populate(ctx context.Context, backend *storage.Backend, volume *storage.Volume) error
+}
+
+var (
+	volumePopulators      = make(map[string]VolumePopulator)
+	volumePopulatorsMutex sync.RWMutex
+)
+
+// RegisterVolumePopulator makes a populator available to volumes with data sources of its kind,
+// replacing any populator already registered for that kind.
+func RegisterVolumePopulator(populator VolumePopulator) {
+	volumePopulatorsMutex.Lock()
+	defer volumePopulatorsMutex.Unlock()
+	volumePopulators[populator.GroupKind()] = populator
+}
+
+// UnregisterVolumePopulator removes the populator registered for a kind of data source, if any.
+func UnregisterVolumePopulator(groupKind string) {
+	volumePopulatorsMutex.Lock()
+	defer volumePopulatorsMutex.Unlock()
+	delete(volumePopulators, groupKind)
+}
+
+// GetVolumePopulator returns the populator registered for a kind of data source.
+func GetVolumePopulator(dataSource *storage.VolumeDataSource) (VolumePopulator, error) {
+	volumePopulatorsMutex.RLock()
+	defer volumePopulatorsMutex.RUnlock()
+
+	if populator, ok := volumePopulators[dataSource.GroupKind()]; ok {
+		return populator, nil
+	}
+	if len(volumePopulators) == 0 {
+		return nil, utils.UnsupportedError(fmt.Sprintf("no volume populator handles data source %s %s",
+			dataSource.GroupKind(), dataSource.Name))
+	}
+	groupKinds := make([]string, 0, len(volumePopulators))
+	for groupKind := range volumePopulators {
+		groupKinds = append(groupKinds, groupKind)
+	}
+	sort.Strings(groupKinds)
+	return nil, utils.UnsupportedError(fmt.Sprintf("no volume populator handles data source %s %s, must be "+
+		"one of %s", dataSource.GroupKind(), dataSource.Name, strings.Join(groupKinds, ", ")))
+}
+
+// populateVolume runs the populator of a volume in the populating state, and brings the volume online once
+// population completes.  It returns a VolumeCreatingError while population runs.  The caller must hold the
+// orchestrator lock.
+func (o *TridentOrchestrator) populateVolume(
+	ctx context.Context, vol *storage.Volume,
+) (externalVol *storage.VolumeExternal, err error) {
+
+	logFields := log.Fields{
+		"volume":     vol.Config.Name,
+		"dataSource": vol.Config.DataSource.GroupKind() + "/" + vol.Config.DataSource.Name,
+	}
+
+	backend, ok := o.backends[vol.BackendUUID]
+	if !ok {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for volume %s not found", vol.BackendUUID,
+			vol.Config.Name))
+	}
+	populator, err := GetVolumePopulator(vol.Config.DataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = populator.Populate(ctx, backend, vol); err != nil {
+		if utils.IsVolumeCreatingError(err) {
+			utils.Logc(ctx).WithFields(logFields).Debug("Volume still populating.")
+		} else {
+			utils.Logc(ctx).WithFields(logFields).WithError(err).Error("Could not populate volume.")
+		}
+		return nil, err
+	}
+
+	vol.State = storage.VolumeStateOnline
+	if err = o.updateVolumeOnPersistentStore(vol); err != nil {
+		vol.State = storage.VolumeStatePopulating
+		return nil, err
+	}
+	utils.Logc(ctx).WithFields(logFields).Info("Volume populated.")
+
+	return vol.ConstructExternal(), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+// fakePopulator completes populating a volume once it has been called a given number of times.
+type fakePopulator struct {
+	calls map[string]int
+	steps int
+}
+
+func (p *fakePopulator) GroupKind() string {
+	return "VolumeImage.example.com"
+}
+
+func (p *fakePopulator) Populate(_ context.Context, _ *storage.Backend, volume *storage.Volume) error {
+	p.calls[volume.Config.Name]++
+	if p.calls[volume.Config.Name] < p.steps {
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being populated", volume.Config.Name))
+	}
+	return nil
+}
+
+func TestGetVolumePopulator(t *testing.T) {
+
+	populator := &fakePopulator{}
+	RegisterVolumePopulator(populator)
+	defer UnregisterVolumePopulator(populator.GroupKind())
+
+	found, err := GetVolumePopulator(&storage.VolumeDataSource{
+		APIGroup: "example.com", Kind: "VolumeImage", Name: "image"})
+	assert.NoError(t, err)
+	assert.Equal(t, populator, found)
+
+	_, err = GetVolumePopulator(&storage.VolumeDataSource{Kind: "VolumeImage", Name: "image"})
+	assert.True(t, utils.IsUnsupportedError(err), "populator found for unqualified kind")
+}
+
+func TestAddVolumeWithDataSource(t *testing.T) {
+
+	const (
+		backendName = "populatorBackend"
+		scName      = "populatorSC"
+		volumeName  = "populatedVolume"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	populator := &fakePopulator{calls: make(map[string]int), steps: 2}
+	RegisterVolumePopulator(populator)
+	defer UnregisterVolumePopulator(populator.GroupKind())
+
+	// A data source no populator handles is refused before the volume is created
+	volumeConfig := tu.GenerateVolumeConfig(volumeName, 1, scName, config.File)
+	volumeConfig.DataSource = &storage.VolumeDataSource{Kind: "Unknown", Name: "source"}
+	_, err := orchestrator.AddVolume(ctx(), volumeConfig)
+	assert.True(t, utils.IsUnsupportedError(err), "volume created with unknown data source")
+	assert.NotContains(t, orchestrator.volumes, volumeName)
+
+	// The volume is created but not reported until population completes
+	volumeConfig = tu.GenerateVolumeConfig(volumeName, 1, scName, config.File)
+	volumeConfig.DataSource = &storage.VolumeDataSource{APIGroup: "example.com", Kind: "VolumeImage",
+		Name: "image"}
+	_, err = orchestrator.AddVolume(ctx(), volumeConfig)
+	assert.True(t, utils.IsVolumeCreatingError(err), "volume reported before it was populated")
+	if assert.Contains(t, orchestrator.volumes, volumeName) {
+		assert.True(t, orchestrator.volumes[volumeName].State.IsPopulating())
+	}
+
+	err = orchestrator.PublishVolume(ctx(), volumeName, &utils.VolumePublishInfo{})
+	assert.True(t, utils.IsVolumeCreatingError(err), "volume published before it was populated")
+
+	// Population resumes after a restart
+	orchestrator = getOrchestrator()
+	if assert.Contains(t, orchestrator.volumes, volumeName) {
+		assert.True(t, orchestrator.volumes[volumeName].State.IsPopulating())
+	}
+
+	volumeConfig = tu.GenerateVolumeConfig(volumeName, 1, scName, config.File)
+	volumeConfig.DataSource = &storage.VolumeDataSource{APIGroup: "example.com", Kind: "VolumeImage",
+		Name: "image"}
+	volume, err := orchestrator.AddVolume(ctx(), volumeConfig)
+	if assert.NoError(t, err) {
+		assert.True(t, volume.State.IsOnline())
+	}
+	assert.Equal(t, 2, populator.calls[volumeName])
+
+	// A clone may not also be populated from a data source
+	cloneConfig := tu.GenerateVolumeConfig("populatedClone", 1, scName, config.File)
+	cloneConfig.CloneSourceVolume = volumeName
+	cloneConfig.DataSource = volumeConfig.DataSource
+	_, err = orchestrator.CloneVolume(ctx(), cloneConfig)
+	assert.Error(t, err)
+}
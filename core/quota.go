@@ -0,0 +1,197 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	storageclass "github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+func (o *TridentOrchestrator) bootstrapQuotas() error {
+	quotas, err := o.storeClient.GetQuotas()
+	if err != nil {
+		return err
+	}
+	for _, quota := range quotas {
+		log.WithFields(log.Fields{
+			"quota":   quota.Name,
+			"handler": "Bootstrap",
+		}).Info("Added an existing quota.")
+		o.quotas[quota.Name] = quota
+	}
+	return nil
+}
+
+// AddQuota adds a quota on the volumes of a namespace, storage class, or backend.  Existing volumes that
+// already exceed the quota are left alone, but no more are created until enough are deleted.
+func (o *TridentOrchestrator) AddQuota(quota *storage.Quota) (quotaExternal *storage.QuotaExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("quota_add", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if err = quota.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := o.quotas[quota.Name]; ok {
+		return nil, fmt.Errorf("quota %s already exists", quota.Name)
+	}
+	if err = o.storeClient.AddQuota(quota); err != nil {
+		return nil, err
+	}
+	o.quotas[quota.Name] = quota
+
+	log.WithFields(log.Fields{
+		"quota":  quota.Name,
+		"scope":  quota.Scope,
+		"target": quota.Target,
+	}).Info("Added a new quota.")
+
+	return o.constructQuotaExternal(quota), nil
+}
+
+func (o *TridentOrchestrator) GetQuota(quotaName string) (quotaExternal *storage.QuotaExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("quota_get", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	quota, found := o.quotas[quotaName]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("quota %v was not found", quotaName))
+	}
+	return o.constructQuotaExternal(quota), nil
+}
+
+func (o *TridentOrchestrator) ListQuotas() (quotas []*storage.QuotaExternal, err error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("quota_list", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	quotas = make([]*storage.QuotaExternal, 0, len(o.quotas))
+	for _, quota := range o.quotas {
+		quotas = append(quotas, o.constructQuotaExternal(quota))
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Name < quotas[j].Name
+	})
+	return quotas, nil
+}
+
+func (o *TridentOrchestrator) DeleteQuota(quotaName string) (err error) {
+	if o.bootstrapError != nil {
+		return o.bootstrapError
+	}
+
+	defer recordTiming("quota_delete", &err)()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	quota, found := o.quotas[quotaName]
+	if !found {
+		return utils.NotFoundError(fmt.Sprintf("quota %s not found", quotaName))
+	}
+	if err = o.storeClient.DeleteQuota(quota); err != nil {
+		return err
+	}
+	delete(o.quotas, quotaName)
+	return nil
+}
+
+// constructQuotaExternal reports a quota along with the space and volumes it covers.  The caller must hold
+// the orchestrator lock.
+func (o *TridentOrchestrator) constructQuotaExternal(quota *storage.Quota) *storage.QuotaExternal {
+	usedBytes, usedVolumes := o.getQuotaUsage(quota)
+	return quota.ConstructExternal(usedBytes, usedVolumes)
+}
+
+// getQuotaUsage returns the total size and number of the existing volumes a quota covers.  The caller
+// must hold the orchestrator lock.
+func (o *TridentOrchestrator) getQuotaUsage(quota *storage.Quota) (usedBytes uint64, usedVolumes int) {
+	for _, vol := range o.volumes {
+		backendName := ""
+		if backend, ok := o.backends[vol.BackendUUID]; ok {
+			backendName = backend.Name
+		}
+		if !quota.Applies(vol.Config, backendName) {
+			continue
+		}
+		sizeBytes, _ := strconv.ParseUint(vol.Config.Size, 10, 64)
+		usedBytes += sizeBytes
+		usedVolumes++
+	}
+	return usedBytes, usedVolumes
+}
+
+// checkVolumeQuotas returns a QuotaExceededError if a new volume of the given size would exceed any quota
+// on its namespace or storage class, or on the named backend if it isn't empty.  The caller must hold the
+// orchestrator lock.
+func (o *TridentOrchestrator) checkVolumeQuotas(
+	volumeConfig *storage.VolumeConfig, backendName string, sizeBytes uint64,
+) error {
+	for _, quota := range o.quotas {
+		if !quota.Applies(volumeConfig, backendName) {
+			continue
+		}
+		usedBytes, usedVolumes := o.getQuotaUsage(quota)
+		if err := quota.Check(usedBytes, usedVolumes, sizeBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterPoolsByQuota checks the quotas on a new volume's namespace and storage class, then removes the
+// backends whose quotas it would exceed from a map returned by GetStoragePoolsForProtocolByBackend.  It
+// returns a QuotaExceededError if the volume exceeds a quota on its namespace or storage class, or on
+// every backend.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) filterPoolsByQuota(
+	poolsByBackend map[string]*storageclass.BackendPoolInfo, volumeConfig *storage.VolumeConfig,
+	sizeBytes uint64,
+) (map[string]*storageclass.BackendPoolInfo, error) {
+
+	if len(o.quotas) == 0 {
+		return poolsByBackend, nil
+	}
+	if err := o.checkVolumeQuotas(volumeConfig, "", sizeBytes); err != nil {
+		return nil, err
+	}
+
+	var quotaErr error
+	for backendName := range poolsByBackend {
+		if err := o.checkVolumeQuotas(volumeConfig, backendName, sizeBytes); err != nil {
+			log.WithFields(log.Fields{
+				"backend": backendName,
+				"volume":  volumeConfig.Name,
+				"error":   err,
+			}).Debug("Backend quota would be exceeded by volume.")
+			delete(poolsByBackend, backendName)
+			quotaErr = err
+		}
+	}
+	if len(poolsByBackend) == 0 && quotaErr != nil {
+		return nil, quotaErr
+	}
+	return poolsByBackend, nil
+}
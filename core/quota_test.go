@@ -0,0 +1,131 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+func addTestQuota(t *testing.T, orchestrator *TridentOrchestrator, quota *storage.Quota) {
+	if _, err := orchestrator.AddQuota(quota); err != nil {
+		t.Fatal("Unable to add quota: ", err)
+	}
+}
+
+func TestAddDeleteQuota(t *testing.T) {
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+
+	quota := &storage.Quota{
+		Name:       "teamA",
+		Scope:      storage.QuotaScopeNamespace,
+		Target:     "team-a",
+		MaxSize:    "10Gi",
+		MaxVolumes: 5,
+	}
+	external, err := orchestrator.AddQuota(quota)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(10*1024*1024*1024), external.MaxBytes)
+		assert.Zero(t, external.UsedVolumes)
+	}
+
+	_, err = orchestrator.AddQuota(quota)
+	assert.Error(t, err, "duplicate quota added")
+
+	_, err = orchestrator.AddQuota(&storage.Quota{Name: "invalid", Scope: "cluster", Target: "x", MaxVolumes: 1})
+	assert.Error(t, err, "quota with invalid scope added")
+
+	stored, err := orchestrator.storeClient.GetQuota(quota.Name)
+	if assert.NoError(t, err) {
+		assert.Equal(t, quota, stored)
+	}
+
+	quotas, err := orchestrator.ListQuotas()
+	if assert.NoError(t, err) && assert.Len(t, quotas, 1) {
+		assert.Equal(t, quota.Name, quotas[0].Name)
+	}
+
+	assert.NoError(t, orchestrator.DeleteQuota(quota.Name))
+	_, err = orchestrator.GetQuota(quota.Name)
+	assert.True(t, utils.IsNotFoundError(err), "quota not deleted")
+	assert.True(t, utils.IsNotFoundError(orchestrator.DeleteQuota(quota.Name)))
+}
+
+func TestVolumeQuotas(t *testing.T) {
+
+	const (
+		backendName = "quotaBackend"
+		scName      = "quotaBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	newVolumeConfig := func(name, namespace string, sizeGB int) *storage.VolumeConfig {
+		volumeConfig := tu.GenerateVolumeConfig(name, sizeGB, scName, config.File)
+		volumeConfig.Namespace = namespace
+		return volumeConfig
+	}
+
+	// A namespace may hold one volume
+	addTestQuota(t, orchestrator, &storage.Quota{
+		Name:       "oneVolume",
+		Scope:      storage.QuotaScopeNamespace,
+		Target:     "small",
+		MaxVolumes: 1,
+	})
+	_, err := orchestrator.AddVolume(ctx(), newVolumeConfig("small1", "small", 1))
+	assert.NoError(t, err)
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("small2", "small", 1))
+	assert.True(t, utils.IsQuotaExceededError(err), "namespace quota not enforced")
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("other1", "other", 1))
+	assert.NoError(t, err, "namespace quota applied to another namespace")
+
+	quota, err := orchestrator.GetQuota("oneVolume")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, quota.UsedVolumes)
+		assert.Equal(t, uint64(1024*1024*1024), quota.UsedBytes)
+	}
+	assert.NoError(t, orchestrator.DeleteQuota("oneVolume"))
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("small2", "small", 1))
+	assert.NoError(t, err, "deleted quota still enforced")
+
+	// The storage class may hold 5 GiB, of which 3 are used
+	addTestQuota(t, orchestrator, &storage.Quota{
+		Name:    "fiveGiB",
+		Scope:   storage.QuotaScopeStorageClass,
+		Target:  scName,
+		MaxSize: "5Gi",
+	})
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("large", "other", 3))
+	assert.True(t, utils.IsQuotaExceededError(err), "storage class quota not enforced")
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("medium", "other", 2))
+	assert.NoError(t, err)
+	assert.NoError(t, orchestrator.DeleteQuota("fiveGiB"))
+
+	// The only backend may hold no more volumes
+	addTestQuota(t, orchestrator, &storage.Quota{
+		Name:       "fullBackend",
+		Scope:      storage.QuotaScopeBackend,
+		Target:     backendName,
+		MaxVolumes: 4,
+	})
+	_, err = orchestrator.AddVolume(ctx(), newVolumeConfig("extra", "other", 1))
+	assert.True(t, utils.IsQuotaExceededError(err), "backend quota not enforced")
+	_, err = orchestrator.CloneVolume(ctx(), &storage.VolumeConfig{
+		Name:              "small1Clone",
+		Namespace:         "small",
+		StorageClass:      scName,
+		CloneSourceVolume: "small1",
+	})
+	assert.True(t, utils.IsQuotaExceededError(err), "backend quota not enforced on clone")
+	assert.NoError(t, orchestrator.DeleteQuota("fullBackend"))
+}
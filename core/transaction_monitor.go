@@ -3,8 +3,10 @@
 package core
 
 import (
+	"context"
 	"time"
 
+	"github.com/netapp/trident/utils"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/netapp/trident/storage"
@@ -100,6 +102,8 @@ func (o *TridentOrchestrator) checkLongRunningTransactions(txnMaxAge time.Durati
 // storage resources associated with them are not orphaned indefinitely.
 func (o *TridentOrchestrator) reapLongRunningTransaction(txn *storage.VolumeTransaction) {
 
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
@@ -134,7 +138,7 @@ func (o *TridentOrchestrator) reapLongRunningTransaction(txn *storage.VolumeTran
 
 		// Delete the volume.  This should be safe since the transaction was left around and Trident doesn't
 		// know anything about the volume.
-		if err := backend.RemoveVolume(&txn.VolumeCreatingConfig.VolumeConfig); err != nil {
+		if err := backend.RemoveVolume(ctx, &txn.VolumeCreatingConfig.VolumeConfig); err != nil {
 
 			log.WithFields(log.Fields{
 				"backendUUID": txn.VolumeCreatingConfig.BackendUUID,
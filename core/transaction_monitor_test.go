@@ -61,12 +61,12 @@ func TestLongRunningTransaction(t *testing.T) {
 	volName := fakeDriver.PVC_creating_01
 	volumeConfig := tu.GenerateVolumeConfig(volName, 1, "slow", config.File)
 
-	_, err := o.AddVolume(volumeConfig)
+	_, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
 
-	_, err = o.AddVolume(volumeConfig)
+	_, err = o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -77,12 +77,12 @@ func TestLongRunningTransaction(t *testing.T) {
 	}
 	assert.Equal(t, volName, volTxns[0].VolumeCreatingConfig.InternalName, "failed to find matching transaction")
 
-	_, err = o.AddVolume(volumeConfig)
+	_, err = o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
 
-	vol, err := o.AddVolume(volumeConfig)
+	vol, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		t.Errorf("Unable to create volume %s: %v", volName, err)
 	}
@@ -100,7 +100,7 @@ func TestCancelledLongRunningTransaction(t *testing.T) {
 	volName := fakeDriver.PVC_creating_01
 	volumeConfig := tu.GenerateVolumeConfig(volName, 1, "slow", config.File)
 
-	_, err := o.AddVolume(volumeConfig)
+	_, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -130,7 +130,7 @@ func TestUpdateVolumeCreatingTransaction(t *testing.T) {
 	volName := fakeDriver.PVC_creating_01
 	volumeConfig := tu.GenerateVolumeConfig(volName, 1, "slow", config.File)
 
-	_, err := o.AddVolume(volumeConfig)
+	_, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -167,7 +167,7 @@ func TestErrorVolumeCreatingTransaction(t *testing.T) {
 	volName := fakeDriver.PVC_creating_02
 	volumeConfig := tu.GenerateVolumeConfig(volName, 1, "slow", config.File)
 
-	_, err := o.AddVolume(volumeConfig)
+	_, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -180,7 +180,7 @@ func TestErrorVolumeCreatingTransaction(t *testing.T) {
 	assert.Equal(t, volName, volTxns[0].VolumeCreatingConfig.InternalName, "failed to find matching transaction")
 
 	// Call AddVolume again to receive volume creation error
-	_, err = o.AddVolume(volumeConfig)
+	_, err = o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		assert.Equal(t, "error occurred during creation on backend", err.Error())
 	}
@@ -205,7 +205,7 @@ func TestVolumeCreatingTwoTransactions(t *testing.T) {
 	volumeConfig := tu.GenerateVolumeConfig(volName, 1, "slow", config.File)
 	cloneVolumeConfig := tu.GenerateVolumeConfig(cloneName, 1, "slow", config.File)
 
-	_, err := o.AddVolume(volumeConfig)
+	_, err := o.AddVolume(ctx(), volumeConfig)
 	if err != nil {
 		t.Errorf("failed to create volume: %v", err)
 	}
@@ -213,7 +213,7 @@ func TestVolumeCreatingTwoTransactions(t *testing.T) {
 	cloneVolumeConfig.CloneSourceVolume = volName
 	log.Debugf("CloneSourceVolume %s", cloneVolumeConfig.CloneSourceVolume)
 
-	_, err = o.CloneVolume(cloneVolumeConfig)
+	_, err = o.CloneVolume(ctx(), cloneVolumeConfig)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -228,7 +228,7 @@ func TestVolumeCreatingTwoTransactions(t *testing.T) {
 	volName02 := fakeDriver.PVC_creating_01
 	volumeConfig02 := tu.GenerateVolumeConfig(volName02, 1, "slow", config.File)
 
-	_, err = o.AddVolume(volumeConfig02)
+	_, err = o.AddVolume(ctx(), volumeConfig02)
 	if err != nil {
 		assert.True(t, utils.IsVolumeCreatingError(err))
 	}
@@ -246,7 +246,7 @@ func TestVolumeCreatingTwoTransactions(t *testing.T) {
 			t.Errorf("did not find expected transaction name %s", volTxnName)
 		}
 	}
-	_, err = o.CloneVolume(cloneVolumeConfig)
+	_, err = o.CloneVolume(ctx(), cloneVolumeConfig)
 	if err != nil {
 		t.Errorf("failed to clone volume: %v", err)
 	}
@@ -3,6 +3,8 @@
 package core
 
 import (
+	"context"
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/frontend"
 	"github.com/netapp/trident/storage"
@@ -15,6 +17,8 @@ type Orchestrator interface {
 	AddFrontend(f frontend.Plugin)
 	GetFrontend(name string) (frontend.Plugin, error)
 	GetVersion() (string, error)
+	CheckLiveness(ctx context.Context) *HealthReport
+	CheckReadiness(ctx context.Context) *HealthReport
 
 	AddBackend(configJSON string) (*storage.BackendExternal, error)
 	DeleteBackend(backend string) error
@@ -25,30 +29,51 @@ type Orchestrator interface {
 	UpdateBackend(backendName, configJSON string) (storageBackendExternal *storage.BackendExternal, err error)
 	UpdateBackendByBackendUUID(backendName, configJSON, backendUUID string) (storageBackendExternal *storage.BackendExternal, err error)
 	UpdateBackendState(backendName, backendState string) (storageBackendExternal *storage.BackendExternal, err error)
+	UpdateBackendAPITrace(backendName string, enabled bool) (storageBackendExternal *storage.BackendExternal, err error)
+	UpdateBackendLogging(backendName string, request *storage.UpdateBackendLoggingRequest) (*storage.BackendExternal, error)
+	RefreshBackendPools(ctx context.Context, backendName string) (*storage.BackendExternal, error)
+	ListRecoverableVolumes(ctx context.Context, backendName string) ([]*storage.RecoverableVolume, error)
+	RecoverVolume(ctx context.Context, backendName, internalName string) error
+	ListBackendVolumes(ctx context.Context, backendName string, limit int, continueToken string) (*storage.VolumePage, error)
+	ListImportCandidates(ctx context.Context, backendName string, filter *storage.ImportDiscoveryFilter) ([]*storage.ImportCandidate, error)
+	GetBackendStorageLogs(ctx context.Context, backendName string) ([]*storage.StorageLogEntry, error)
+	GetBackendBuckets(ctx context.Context, backendName string) (*storage.BucketReport, error)
+	PreviewBackend(configJSON string) (*storage.BackendPreview, error)
+	ListAuditEvents(filter audit.Filter) ([]*audit.Event, error)
+	ListStorageJobs() ([]*storage.StorageJob, error)
+	ListOrphanedResources() ([]*storage.OrphanedResource, error)
 
-	AddVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
+	AddVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
 	AttachVolume(volumeName, mountpoint string, publishInfo *utils.VolumePublishInfo) error
-	CloneVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
+	CloneVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
 	DetachVolume(volumeName, mountpoint string) error
-	DeleteVolume(volume string) error
+	DeleteVolume(ctx context.Context, volume string) error
 	GetVolume(volume string) (*storage.VolumeExternal, error)
 	GetVolumeExternal(volumeName string, backendName string) (*storage.VolumeExternal, error)
 	GetVolumeType(vol *storage.VolumeExternal) (config.VolumeType, error)
-	LegacyImportVolume(volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback) (*storage.VolumeExternal, error)
-	ImportVolume(volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
+	LegacyImportVolume(ctx context.Context, volumeConfig *storage.VolumeConfig, backendName string, notManaged bool, createPVandPVC VolumeCallback) (*storage.VolumeExternal, error)
+	ImportVolume(ctx context.Context, volumeConfig *storage.VolumeConfig) (*storage.VolumeExternal, error)
 	ListVolumes() ([]*storage.VolumeExternal, error)
 	ListVolumesByPlugin(pluginName string) ([]*storage.VolumeExternal, error)
-	PublishVolume(volumeName string, publishInfo *utils.VolumePublishInfo) error
-	ResizeVolume(volumeName, newSize string) error
+	PublishVolume(ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo) error
+	UnpublishVolume(volumeName, nodeName string) error
+	ResizeVolume(ctx context.Context, volumeName, newSize string) error
+	UpdateVolume(ctx context.Context, volumeName string, updateRequest *storage.UpdateVolumeRequest) (*storage.VolumeExternal, error)
+	MoveVolume(ctx context.Context, volumeName string, moveRequest *storage.MoveVolumeRequest) (*storage.VolumeExternal, error)
+	MigrateVolume(ctx context.Context, volumeName string, migrateRequest *storage.MigrateVolumeRequest) (*storage.VolumeExternal, error)
+	RecordVolumeEvent(volumeName string, event *utils.VolumeEvent) error
+	UpdateVolumePublication(ctx context.Context, volumeName string, publishInfo *utils.VolumePublishInfo) (bool, error)
 	SetVolumeState(volumeName string, state storage.VolumeState) error
 
-	CreateSnapshot(snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error)
+	CreateSnapshot(ctx context.Context, snapshotConfig *storage.SnapshotConfig) (*storage.SnapshotExternal, error)
+	CreateGroupSnapshot(ctx context.Context, groupConfig *storage.GroupSnapshotConfig) ([]*storage.SnapshotExternal, error)
 	GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotExternal, error)
 	ListSnapshots() ([]*storage.SnapshotExternal, error)
 	ListSnapshotsByName(snapshotName string) ([]*storage.SnapshotExternal, error)
 	ListSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error)
-	ReadSnapshotsForVolume(volumeName string) ([]*storage.SnapshotExternal, error)
-	DeleteSnapshot(volumeName, snapshotName string) error
+	ReadSnapshotsForVolume(ctx context.Context, volumeName string) ([]*storage.SnapshotExternal, error)
+	DeleteSnapshot(ctx context.Context, volumeName, snapshotName string) error
+	RestoreSnapshot(ctx context.Context, volumeName, snapshotName string) error
 
 	GetDriverTypeForVolume(vol *storage.VolumeExternal) (string, error)
 	ReloadVolumes() error
@@ -58,14 +83,21 @@ type Orchestrator interface {
 	GetStorageClass(scName string) (*storageclass.External, error)
 	ListStorageClasses() ([]*storageclass.External, error)
 
+	AddQuota(quota *storage.Quota) (*storage.QuotaExternal, error)
+	DeleteQuota(quotaName string) error
+	GetQuota(quotaName string) (*storage.QuotaExternal, error)
+	ListQuotas() ([]*storage.QuotaExternal, error)
+
 	AddNode(node *utils.Node) error
 	GetNode(nName string) (*utils.Node, error)
 	ListNodes() ([]*utils.Node, error)
 	DeleteNode(nName string) error
 
-	AddVolumeTransaction(volTxn *storage.VolumeTransaction) error
+	AddVolumeTransaction(ctx context.Context, volTxn *storage.VolumeTransaction) error
 	GetVolumeTransaction(volTxn *storage.VolumeTransaction) (*storage.VolumeTransaction, error)
 	DeleteVolumeTransaction(volTxn *storage.VolumeTransaction) error
+
+	WatchObjects(ctx context.Context, sinceID uint64) (<-chan *ObjectEvent, error)
 }
 
 type VolumeCallback func(*storage.VolumeExternal, string) error
@@ -0,0 +1,92 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// Reasons of the events recorded as a volume is provisioned and published, so that users may see why
+// provisioning is slow or failing by describing the volume's PVC.
+const (
+	eventReasonPoolSelected              = "PoolSelected"
+	eventReasonProvisioningInProgress    = "ProvisioningInProgress"
+	eventReasonProvisioningAttemptFailed = "ProvisioningAttemptFailed"
+	eventReasonCloneSplitStarted         = "CloneSplitStarted"
+	eventReasonExportPolicyReconciled    = "ExportPolicyReconciled"
+	eventReasonPublishFailed             = "PublishFailed"
+)
+
+// recordVolumeEvent reports an event about a volume to the frontends that can record it.  The event is
+// recorded in the background, as a frontend may wait to find the volume's PVC, so the orchestrator lock
+// may be held by the caller.
+func (o *TridentOrchestrator) recordVolumeEvent(volumeName, eventType, reason, message string) {
+
+	recorders := make([]frontend.VolumeEventRecorder, 0)
+	for _, f := range o.frontends {
+		if recorder, ok := f.(frontend.VolumeEventRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+	if len(recorders) == 0 {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"volume": volumeName,
+		"type":   eventType,
+		"reason": reason,
+	}).Debug("Recording volume event.")
+
+	go func() {
+		for _, recorder := range recorders {
+			recorder.RecordVolumeEvent(volumeName, eventType, reason, message)
+		}
+	}()
+}
+
+// recordPoolSelectedEvent reports the storage pool on which a volume is about to be created.
+func (o *TridentOrchestrator) recordPoolSelectedEvent(volumeName string, pool *storage.Pool) {
+	o.recordVolumeEvent(volumeName, frontend.EventTypeNormal, eventReasonPoolSelected,
+		fmt.Sprintf("creating volume on storage pool %s of backend %s", pool.Name, pool.Backend.Name))
+}
+
+// recordProvisioningAttemptEvent reports the outcome of an attempt to create a volume on a storage pool
+// that didn't succeed, with the storage system's error, if any.
+func (o *TridentOrchestrator) recordProvisioningAttemptEvent(volumeName string, pool *storage.Pool, err error) {
+	if utils.IsVolumeCreatingError(err) {
+		o.recordVolumeEvent(volumeName, frontend.EventTypeNormal, eventReasonProvisioningInProgress,
+			fmt.Sprintf("volume is still being created on storage pool %s of backend %s", pool.Name,
+				pool.Backend.Name))
+		return
+	}
+	o.recordVolumeEvent(volumeName, frontend.EventTypeWarning, eventReasonProvisioningAttemptFailed,
+		fmt.Sprintf("could not create volume on storage pool %s of backend %s: %v", pool.Name,
+			pool.Backend.Name, err))
+}
+
+// recordCloneSplitStartedEvent reports that a new volume is being split from its parent, if its backend
+// splits clones.
+func (o *TridentOrchestrator) recordCloneSplitStartedEvent(vol *storage.Volume, backend *storage.Backend) {
+
+	reporter, ok := backend.Driver.(storage.CloneSplitReporter)
+	if !ok {
+		return
+	}
+	status := reporter.GetCloneSplitStatus(vol.Config.InternalName)
+	if status == nil || status.State.IsDone() {
+		return
+	}
+
+	message := "volume is being split from its parent"
+	if status.State == storage.CloneSplitStateQueued {
+		message = "volume is queued to be split from its parent"
+	}
+	o.recordVolumeEvent(vol.Config.Name, frontend.EventTypeNormal, eventReasonCloneSplitStarted, message)
+}
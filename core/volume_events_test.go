@@ -0,0 +1,75 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/storage"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+type recordedVolumeEvent struct {
+	name, eventType, reason, message string
+}
+
+type volumeEventRecordingFrontend struct {
+	events chan recordedVolumeEvent
+}
+
+func (f *volumeEventRecordingFrontend) Activate() error   { return nil }
+func (f *volumeEventRecordingFrontend) Deactivate() error { return nil }
+func (f *volumeEventRecordingFrontend) GetName() string   { return "recording" }
+func (f *volumeEventRecordingFrontend) Version() string   { return "1" }
+func (f *volumeEventRecordingFrontend) RecordVolumeEvent(name, eventType, reason, message string) {
+	f.events <- recordedVolumeEvent{name, eventType, reason, message}
+}
+
+func (f *volumeEventRecordingFrontend) next(t *testing.T) recordedVolumeEvent {
+	select {
+	case event := <-f.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Volume event not recorded.")
+		return recordedVolumeEvent{}
+	}
+}
+
+func TestProvisioningVolumeEvents(t *testing.T) {
+
+	o, _ := setupOrchestratorAndBackend(t)
+	recorder := &volumeEventRecordingFrontend{events: make(chan recordedVolumeEvent, 10)}
+	o.AddFrontend(recorder)
+
+	volumeConfig := tu.GenerateVolumeConfig("eventVolume", 1, "slow", config.File)
+	if _, err := o.AddVolume(ctx(), volumeConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+
+	event := recorder.next(t)
+	assert.Equal(t, "eventVolume", event.name)
+	assert.Equal(t, frontend.EventTypeNormal, event.eventType)
+	assert.Equal(t, eventReasonPoolSelected, event.reason)
+	assert.Contains(t, event.message, "of backend fakeOne")
+
+	pool := &storage.Pool{Name: "aggr1", Backend: &storage.Backend{Name: "ontap"}}
+
+	o.recordProvisioningAttemptEvent("eventVolume", pool, errors.New("API status: failed, Reason: No space"))
+	event = recorder.next(t)
+	assert.Equal(t, frontend.EventTypeWarning, event.eventType)
+	assert.Equal(t, eventReasonProvisioningAttemptFailed, event.reason)
+	assert.Equal(t, "could not create volume on storage pool aggr1 of backend ontap: "+
+		"API status: failed, Reason: No space", event.message)
+
+	o.recordProvisioningAttemptEvent("eventVolume", pool, utils.VolumeCreatingError("creating"))
+	event = recorder.next(t)
+	assert.Equal(t, frontend.EventTypeNormal, event.eventType)
+	assert.Equal(t, eventReasonProvisioningInProgress, event.reason)
+}
@@ -0,0 +1,449 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+const volumeMigratorPeriod = 1 * time.Minute
+
+// MigrateVolume begins migrating a volume to another backend, such as to empty a backend that is being
+// decommissioned.  The volume is copied by the storage systems to a pool of the new backend in its storage
+// class, then switched to the copy, which keeps the volume's name so that the container orchestrator's
+// references to it stay valid, and finally deleted from its old backend.  The migration runs in the
+// background, resuming after a restart, and is reported in the volume's status.  The volume must not be
+// published when the migration begins, and may not be published until it ends.
+func (o *TridentOrchestrator) MigrateVolume(
+	ctx context.Context, volumeName string, migrateRequest *storage.MigrateVolumeRequest,
+) (volExternal *storage.VolumeExternal, err error) {
+
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	defer recordTiming("volume_migrate", &err)()
+
+	if err = migrateRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	defer o.updateMetrics()
+
+	volume, found := o.volumes[volumeName]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", volumeName))
+	}
+	if volume.State.IsDeleting() {
+		return nil, utils.VolumeDeletingError(fmt.Sprintf("volume %s is deleting", volumeName))
+	}
+	if !volume.State.IsOnline() {
+		return nil, fmt.Errorf("volume %s is not online", volumeName)
+	}
+	if len(volume.PublishedNodes) > 0 {
+		return nil, fmt.Errorf("volume %s is published to node(s) %s; it may be migrated only while it is "+
+			"unpublished", volumeName, strings.Join(volume.PublishedNodes, ", "))
+	}
+	if snapshots, err := o.volumeSnapshots(volumeName); err != nil {
+		return nil, err
+	} else if len(snapshots) > 0 {
+		return nil, fmt.Errorf("volume %s has snapshots, which cannot be migrated", volumeName)
+	}
+
+	sourceBackend, found := o.backends[volume.BackendUUID]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("backend %s for volume %s not found",
+			volume.BackendUUID, volumeName))
+	}
+	backend, err := o.getBackendByBackendName(migrateRequest.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if backend.BackendUUID == sourceBackend.BackendUUID {
+		return nil, fmt.Errorf("volume %s is already on backend %s", volumeName, backend.Name)
+	}
+	if !backend.State.IsOnline() {
+		return nil, fmt.Errorf("backend %s is not online", backend.Name)
+	}
+
+	pool, err := o.selectMigrationPool(volume, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	// The copy keeps the volume's name and attributes, but is given a new internal name by its backend.  It
+	// is copied from the volume itself, not from any snapshot the volume was cloned from.
+	targetConfig := volume.Config.ConstructClone()
+	targetConfig.InternalName = ""
+	targetConfig.CloneSourceSnapshot = ""
+	backend.Driver.CreatePrepare(targetConfig)
+	targetConfig.AllowedTopologies = nil
+	if topology := pool.Topology(); len(topology) > 0 {
+		targetConfig.AllowedTopologies = []map[string]string{topology}
+	}
+
+	txn := &storage.VolumeTransaction{
+		Config:      targetConfig,
+		Op:          storage.VolumeMigrating,
+		Step:        storage.StepMigrationCopying,
+		BackendUUID: backend.BackendUUID,
+		Pool:        pool.Name,
+		MigrationConfig: &storage.VolumeMigrationConfig{
+			StartTime:         time.Now(),
+			SourceBackendUUID: sourceBackend.BackendUUID,
+			SourcePool:        volume.Pool,
+			SourceConfig:      volume.Config,
+		},
+	}
+	if err = o.AddVolumeTransaction(ctx, txn); err != nil {
+		if utils.IsFoundError(err) {
+			return nil, fmt.Errorf("volume %s is already being migrated or changed", volumeName)
+		}
+		return nil, err
+	}
+	status := o.getVolumeMigrationStatus(txn)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volume":             volumeName,
+		"sourceBackend":      sourceBackend.Name,
+		"destinationBackend": backend.Name,
+		"pool":               pool.Name,
+	}).Info("Orchestrator began migrating the volume.")
+
+	// Begin copying the volume now, leaving the volume migrator to follow the copy
+	o.advanceVolumeMigration(ctx, txn, false)
+	if status.State == storage.VolumeMigrationStateFailed {
+		return nil, fmt.Errorf("unable to migrate volume %s: %s", volumeName, status.Message)
+	}
+
+	return o.constructVolumeExternal(o.volumes[volumeName]), nil
+}
+
+// selectMigrationPool chooses the pool to which a volume migrates, among a backend's pools in the volume's
+// storage class that can hold it.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) selectMigrationPool(volume *storage.Volume, backend *storage.Backend) (
+	*storage.Pool, error) {
+
+	sc, found := o.storageClasses[volume.Config.StorageClass]
+	if !found {
+		return nil, utils.NotFoundError(fmt.Sprintf("storage class %s of volume %s not found",
+			volume.Config.StorageClass, volume.Config.Name))
+	}
+	protocol, err := o.getProtocol(volume.Config.VolumeMode, volume.Config.AccessMode, volume.Config.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only quotas on the new backend matter, as the volume already counts against any others
+	sizeBytes, _ := strconv.ParseUint(volume.Config.Size, 10, 64)
+	for _, quota := range o.quotas {
+		if quota.Scope != storage.QuotaScopeBackend || !quota.Applies(volume.Config, backend.Name) {
+			continue
+		}
+		usedBytes, usedVolumes := o.getQuotaUsage(quota)
+		if err = quota.Check(usedBytes, usedVolumes, sizeBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	poolsByBackend := sc.GetStoragePoolsForProtocolByBackend(protocol)
+	for backendName := range poolsByBackend {
+		if backendName != backend.Name {
+			delete(poolsByBackend, backendName)
+		}
+	}
+	poolsByBackend = filterPoolsByAccessMode(poolsByBackend, volume.Config)
+	poolsByBackend = sc.FilterPoolsByCapacity(poolsByBackend, sizeBytes)
+	poolsByBackend = sc.FilterPoolsByTopology(poolsByBackend, volume.Config.RequisiteTopologies)
+	poolsByBackend = sc.SortPoolsByTopology(poolsByBackend, volume.Config.PreferredTopologies)
+	if len(poolsByBackend) == 0 {
+		return nil, fmt.Errorf("backend %s has no storage pools in storage class %s that can hold volume %s",
+			backend.Name, sc.GetName(), volume.Config.Name)
+	}
+
+	return o.selectPool(sc, poolsByBackend, volume.Config, sizeBytes), nil
+}
+
+// getVolumeMigrationStatus returns the status of a migration, creating it for a migration found in the
+// persistent store after a restart.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) getVolumeMigrationStatus(txn *storage.VolumeTransaction) *storage.VolumeMigrationStatus {
+
+	if status, ok := o.volumeMigrations[txn.Config.Name]; ok && status.State == storage.VolumeMigrationStateRunning {
+		return status
+	}
+
+	status := &storage.VolumeMigrationStatus{
+		State:              storage.VolumeMigrationStateRunning,
+		Step:               txn.Step,
+		SourceBackend:      txn.MigrationConfig.SourceBackendUUID,
+		DestinationBackend: txn.BackendUUID,
+		StartTime:          txn.MigrationConfig.StartTime.UTC().Format(time.RFC3339),
+	}
+	if backend, ok := o.backends[txn.MigrationConfig.SourceBackendUUID]; ok {
+		status.SourceBackend = backend.Name
+	}
+	if backend, ok := o.backends[txn.BackendUUID]; ok {
+		status.DestinationBackend = backend.Name
+	}
+	o.volumeMigrations[txn.Config.Name] = status
+
+	return status
+}
+
+// isVolumeMigrating reports whether a volume is migrating to another backend.  The caller must hold the
+// orchestrator lock.
+func (o *TridentOrchestrator) isVolumeMigrating(volumeName string) bool {
+	status, ok := o.volumeMigrations[volumeName]
+	return ok && status.State == storage.VolumeMigrationStateRunning
+}
+
+// advanceVolumeMigration takes a migration as far as it can go now.  Once the volume's copy is complete,
+// the volume is switched to it and deleted from its old backend; a migration that fails is abandoned,
+// leaving the volume where it was.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) advanceVolumeMigration(
+	ctx context.Context, txn *storage.VolumeTransaction, retry bool,
+) {
+
+	volumeName := txn.Config.Name
+	status := o.getVolumeMigrationStatus(txn)
+	logFields := log.Fields{
+		"volume":             volumeName,
+		"sourceBackend":      status.SourceBackend,
+		"destinationBackend": status.DestinationBackend,
+		"step":               txn.Step,
+	}
+
+	backend := o.backends[txn.BackendUUID]
+	sourceBackend := o.backends[txn.MigrationConfig.SourceBackendUUID]
+
+	if txn.Step == storage.StepMigrationCopying {
+
+		volume, ok := o.volumes[volumeName]
+		if !ok {
+			o.failVolumeMigration(ctx, txn, backend, utils.NotFoundError(fmt.Sprintf("volume %s not found",
+				volumeName)))
+			return
+		}
+		if backend == nil || sourceBackend == nil {
+			o.failVolumeMigration(ctx, txn, backend, utils.NotFoundError("backend not found"))
+			return
+		}
+
+		// A restart may have interrupted the migration after the volume was switched to its copy
+		if volume.BackendUUID != backend.BackendUUID {
+
+			pool, ok := backend.Storage[txn.Pool]
+			if !ok {
+				pool = storage.NewStoragePool(backend, "")
+			}
+
+			migrated, err := backend.CopyVolume(ctx, txn.Config, pool, sourceBackend, txn.MigrationConfig.SourceConfig,
+				retry)
+			if err != nil {
+				if utils.IsVolumeCreatingError(err) {
+					utils.Logc(ctx).WithFields(logFields).Debug("Volume still being copied to its new backend.")
+					return
+				}
+				o.failVolumeMigration(ctx, txn, backend, err)
+				return
+			}
+
+			if err = o.switchMigratedVolume(ctx, volume, migrated, sourceBackend); err != nil {
+				utils.Logc(ctx).WithFields(logFields).WithError(err).Warning(
+					"Could not switch the volume to its new backend.")
+				status.Message = err.Error()
+				return
+			}
+		}
+
+		txn.Step = storage.StepMigrationRetiring
+		if err := o.storeClient.UpdateVolumeTransaction(txn); err != nil {
+			utils.Logc(ctx).WithFields(logFields).WithError(err).Warning("Could not update migration transaction.")
+			return
+		}
+		status.Step = txn.Step
+		logFields["step"] = txn.Step
+	}
+
+	// Delete the volume from its old backend, unless the backend is gone
+	if sourceBackend != nil {
+		if err := sourceBackend.RemoveVolume(ctx, txn.MigrationConfig.SourceConfig); err != nil {
+			utils.Logc(ctx).WithFields(logFields).WithError(err).Warning(
+				"Could not delete the migrated volume from its old backend.")
+			status.Message = err.Error()
+			return
+		}
+		if sourceBackend.State.IsDeleting() && !sourceBackend.HasVolumes() {
+			if err := o.storeClient.DeleteBackend(sourceBackend); err != nil {
+				utils.Logc(ctx).WithFields(logFields).WithError(err).Warning(
+					"Could not delete the old backend after its last volume migrated.")
+				status.Message = err.Error()
+				return
+			}
+			sourceBackend.Terminate()
+			delete(o.backends, sourceBackend.BackendUUID)
+		}
+	}
+
+	if err := o.DeleteVolumeTransaction(txn); err != nil {
+		utils.Logc(ctx).WithFields(logFields).WithError(err).Warning("Could not delete migration transaction.")
+		return
+	}
+
+	status.State = storage.VolumeMigrationStateComplete
+	status.EndTime = time.Now().UTC().Format(time.RFC3339)
+	status.Message = ""
+
+	utils.Logc(ctx).WithFields(logFields).Info("Orchestrator migrated the volume.")
+}
+
+// switchMigratedVolume makes a migrating volume's copy on its new backend the volume, under the volume's
+// name.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) switchMigratedVolume(
+	ctx context.Context, volume, migrated *storage.Volume, sourceBackend *storage.Backend,
+) error {
+
+	// The copy was taken of the volume itself, but remains a clone of whatever the volume was cloned from
+	migrated.Config.CloneSourceSnapshot = volume.Config.CloneSourceSnapshot
+	migrated.PublishedNodes = volume.PublishedNodes
+
+	if err := o.updateVolumeOnPersistentStore(migrated); err != nil {
+		return err
+	}
+	o.volumes[volume.Config.Name] = migrated
+	sourceBackend.RemoveCachedVolume(volume.Config.Name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volume":         volume.Config.Name,
+		"volumeInternal": migrated.Config.InternalName,
+		"backendUUID":    migrated.BackendUUID,
+	}).Debug("Switched the volume to its new backend.")
+
+	return nil
+}
+
+// failVolumeMigration abandons a migration that cannot finish, deleting the volume's copy from its new
+// backend.  The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) failVolumeMigration(
+	ctx context.Context, txn *storage.VolumeTransaction, backend *storage.Backend, err error,
+) {
+
+	status := o.getVolumeMigrationStatus(txn)
+	status.State = storage.VolumeMigrationStateFailed
+	status.EndTime = time.Now().UTC().Format(time.RFC3339)
+	status.Message = err.Error()
+
+	logFields := log.Fields{
+		"volume":             txn.Config.Name,
+		"sourceBackend":      status.SourceBackend,
+		"destinationBackend": status.DestinationBackend,
+	}
+	utils.Logc(ctx).WithFields(logFields).WithError(err).Error("Could not migrate the volume.")
+
+	if backend != nil {
+		if removeErr := backend.RemoveVolume(ctx, txn.Config); removeErr != nil {
+			utils.Logc(ctx).WithFields(logFields).WithError(removeErr).Warning(
+				"Could not delete the copy of a volume that failed to migrate. It may have to be removed manually.")
+		}
+	}
+	if deleteErr := o.DeleteVolumeTransaction(txn); deleteErr != nil {
+		utils.Logc(ctx).WithFields(logFields).WithError(deleteErr).Warning(
+			"Could not delete migration transaction.")
+	}
+}
+
+// StartVolumeMigrator starts the thread that follows volume migrations to their end.
+func (o *TridentOrchestrator) StartVolumeMigrator(period time.Duration) {
+
+	o.volumeMigratorTicker = time.NewTicker(period)
+	o.volumeMigratorChannel = make(chan struct{})
+
+	go func() {
+		log.Debug("Volume migrator started.")
+
+		for {
+			select {
+			case tick := <-o.volumeMigratorTicker.C:
+				log.WithField("tick", tick).Debug("Volume migrator running.")
+				o.advanceVolumeMigrations()
+			case <-o.volumeMigratorChannel:
+				log.Debugf("Volume migrator stopped.")
+				return
+			}
+		}
+	}()
+}
+
+// StopVolumeMigrator stops the thread that follows volume migrations.
+func (o *TridentOrchestrator) StopVolumeMigrator() {
+	if o.volumeMigratorTicker != nil {
+		o.volumeMigratorTicker.Stop()
+	}
+	if o.volumeMigratorChannel != nil && !o.volumeMigratorStopped {
+		close(o.volumeMigratorChannel)
+		o.volumeMigratorStopped = true
+	}
+	log.Debug("Volume migrator stopped.")
+}
+
+// advanceVolumeMigrations is called periodically by the volume migrator to take each migration recorded in
+// the persistent store as far as it can go, recording an event for each one that ends.
+func (o *TridentOrchestrator) advanceVolumeMigrations() {
+
+	if o.bootstrapError != nil {
+		log.WithField("error", o.bootstrapError).Errorf("Volume migrator blocked by bootstrap error.")
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	txns, err := o.storeClient.GetVolumeTransactions()
+	if err != nil {
+		log.WithField("error", err).Errorf("could not read transactions")
+		return
+	}
+
+	o.mutex.Lock()
+	ended := make(map[string]storage.VolumeMigrationStatus)
+	for _, txn := range txns {
+		if txn.Op != storage.VolumeMigrating {
+			continue
+		}
+		o.advanceVolumeMigration(ctx, txn, true)
+		if status := o.volumeMigrations[txn.Config.Name]; status.State != storage.VolumeMigrationStateRunning {
+			ended[txn.Config.Name] = *status
+		}
+	}
+	recorders := make([]frontend.VolumeEventRecorder, 0)
+	for _, f := range o.frontends {
+		if recorder, ok := f.(frontend.VolumeEventRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+	o.mutex.Unlock()
+
+	for volumeName, status := range ended {
+		for _, recorder := range recorders {
+			if status.State == storage.VolumeMigrationStateComplete {
+				recorder.RecordVolumeEvent(volumeName, frontend.EventTypeNormal, "VolumeMigrationComplete",
+					fmt.Sprintf("volume was migrated to backend %s", status.DestinationBackend))
+			} else {
+				recorder.RecordVolumeEvent(volumeName, frontend.EventTypeWarning, "VolumeMigrationFailed",
+					fmt.Sprintf("volume could not be migrated to backend %s: %s", status.DestinationBackend,
+						status.Message))
+			}
+		}
+	}
+}
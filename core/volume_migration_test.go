@@ -0,0 +1,105 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+func TestMigrateVolume(t *testing.T) {
+
+	const (
+		scName     = "migrateSC"
+		volumeName = "migrateVolume"
+	)
+	orchestrator := getOrchestrator()
+	addBackendStorageClass(t, orchestrator, "migrateFrom", scName, config.File)
+	addBackend(t, orchestrator, "migrateTo", config.File)
+	defer cleanup(t, orchestrator)
+
+	volume, err := orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50, scName, config.File))
+	if err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	source, err := orchestrator.GetBackendByBackendUUID(volume.BackendUUID)
+	if err != nil {
+		t.Fatal("Unable to find backend: ", err)
+	}
+	destinationName := "migrateTo"
+	if source.Name == destinationName {
+		destinationName = "migrateFrom"
+	}
+
+	// Migrating to the volume's own backend is refused
+	_, err = orchestrator.MigrateVolume(ctx(), volumeName, &storage.MigrateVolumeRequest{Backend: source.Name})
+	assert.Error(t, err, "volume migrated to its own backend")
+
+	// The copy outlasts the request, so the migration is left running
+	migrating, err := orchestrator.MigrateVolume(ctx(), volumeName,
+		&storage.MigrateVolumeRequest{Backend: destinationName})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.NotNil(t, migrating.Migration) {
+		assert.Equal(t, storage.VolumeMigrationStateRunning, migrating.Migration.State)
+		assert.Equal(t, storage.StepMigrationCopying, migrating.Migration.Step)
+		assert.Equal(t, destinationName, migrating.Migration.DestinationBackend)
+	}
+	assert.Equal(t, source.BackendUUID, migrating.BackendUUID)
+
+	// The volume may be neither published, snapshotted, resized, nor migrated again until the migration ends
+	err = orchestrator.PublishVolume(ctx(), volumeName, &utils.VolumePublishInfo{HostName: "node1"})
+	assert.True(t, utils.IsVolumeCreatingError(err), "migrating volume published")
+	_, err = orchestrator.CreateSnapshot(ctx(), &storage.SnapshotConfig{Name: "snap", VolumeName: volumeName})
+	assert.True(t, utils.IsVolumeCreatingError(err), "migrating volume snapshotted")
+	err = orchestrator.ResizeVolume(ctx(), volumeName, "100")
+	assert.True(t, utils.IsVolumeCreatingError(err), "migrating volume resized")
+	_, err = orchestrator.MigrateVolume(ctx(), volumeName, &storage.MigrateVolumeRequest{Backend: destinationName})
+	assert.Error(t, err, "volume migrated twice")
+
+	// A restart finds the migration in the persistent store and reports it until it is resumed
+	orchestrator.mutex.Lock()
+	delete(orchestrator.volumeMigrations, volumeName)
+	txns, err := orchestrator.storeClient.GetVolumeTransactions()
+	if assert.NoError(t, err) && assert.Len(t, txns, 1) {
+		assert.NoError(t, orchestrator.handleFailedTransaction(ctx(), txns[0]))
+	}
+	assert.True(t, orchestrator.isVolumeMigrating(volumeName), "migration not resumed")
+	orchestrator.mutex.Unlock()
+
+	// The volume migrator finishes the copy, switches the volume, and deletes it from its old backend
+	orchestrator.advanceVolumeMigrations()
+
+	migrated, err := orchestrator.GetVolume(volumeName)
+	if !assert.NoError(t, err) {
+		return
+	}
+	destination, err := orchestrator.GetBackend(destinationName)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, destination.BackendUUID, migrated.BackendUUID)
+	if assert.NotNil(t, migrated.Migration) {
+		assert.Equal(t, storage.VolumeMigrationStateComplete, migrated.Migration.State)
+		assert.NotEmpty(t, migrated.Migration.EndTime)
+	}
+
+	orchestrator.mutex.Lock()
+	assert.NotContains(t, orchestrator.backends[source.BackendUUID].Volumes, volumeName)
+	txns, err = orchestrator.storeClient.GetVolumeTransactions()
+	assert.NoError(t, err)
+	assert.Empty(t, txns, "migration transaction not deleted")
+	orchestrator.mutex.Unlock()
+
+	stored, err := orchestrator.storeClient.GetVolume(volumeName)
+	if assert.NoError(t, err) {
+		assert.Equal(t, destination.BackendUUID, stored.BackendUUID)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// recordVolumeOperationStep saves how far a volume create or clone has got in its transaction, so that the
+// operation may be finished or rolled back from there if Trident restarts before it completes.
+func (o *TridentOrchestrator) recordVolumeOperationStep(
+	txn *storage.VolumeTransaction, step storage.VolumeOperationStep, backendUUID, pool string,
+) error {
+	txn.Step = step
+	txn.BackendUUID = backendUUID
+	txn.Pool = pool
+	return o.storeClient.UpdateVolumeTransaction(txn)
+}
+
+// resumeOrHandleFailedTransaction is called for each transaction left over when Trident starts.  A volume
+// create or clone that got far enough is finished, and any other operation rolled back or completed as
+// handleFailedTransaction sees fit.  Resuming is left to bootstrap, as a transaction found while Trident
+// runs belongs to an operation that already failed and whose request is being retried.
+func (o *TridentOrchestrator) resumeOrHandleFailedTransaction(
+	ctx context.Context, v *storage.VolumeTransaction,
+) error {
+
+	if v.Op != storage.AddVolume || !o.resumeAddVolumeTransaction(ctx, v) {
+		return o.handleFailedTransaction(ctx, v)
+	}
+
+	if err := o.DeleteVolumeTransaction(v); err != nil {
+		return fmt.Errorf("failed to clean up volume addition transaction: %v", err)
+	}
+	return nil
+}
+
+// resumeAddVolumeTransaction finishes a volume create or clone that was interrupted by a restart after the
+// volume was created on its backend, if the backend's driver confirms the volume is ready for use.  It
+// returns false if the operation must be rolled back instead, as it must if it recorded no such step.
+// The caller must hold the orchestrator lock.
+func (o *TridentOrchestrator) resumeAddVolumeTransaction(ctx context.Context, v *storage.VolumeTransaction) bool {
+
+	if v.Step != storage.StepBackendCreated {
+		return false
+	}
+
+	logFields := log.Fields{
+		"volume":      v.Config.Name,
+		"backendUUID": v.BackendUUID,
+		"step":        v.Step,
+	}
+
+	// The volume was saved, and only the transaction was left
+	if _, ok := o.volumes[v.Config.Name]; ok {
+		utils.Logc(ctx).WithFields(logFields).Debug("Volume creation had finished.")
+		return true
+	}
+
+	backend, ok := o.backends[v.BackendUUID]
+	if !ok {
+		utils.Logc(ctx).WithFields(logFields).Warning("Backend of interrupted volume creation not found.")
+		return false
+	}
+
+	if err := o.resumeVolumeCreate(ctx, backend, v); err != nil {
+		logFields["error"] = err
+		if utils.IsUnsupportedError(err) {
+			utils.Logc(ctx).WithFields(logFields).Debug("Could not resume volume creation, rolling it back.")
+		} else {
+			utils.Logc(ctx).WithFields(logFields).Warning("Could not resume volume creation, rolling it back.")
+		}
+		return false
+	}
+
+	utils.Logc(ctx).WithFields(logFields).Info("Resumed interrupted volume creation.")
+	return true
+}
+
+// resumeVolumeCreate has the backend confirm the volume of an interrupted create is ready, then saves it.
+func (o *TridentOrchestrator) resumeVolumeCreate(
+	ctx context.Context, backend *storage.Backend, v *storage.VolumeTransaction,
+) error {
+
+	if err := backend.ResumeVolumeCreate(ctx, v.Config); err != nil {
+		return err
+	}
+
+	vol := storage.NewVolume(v.Config, backend.BackendUUID, v.Pool, false)
+	if err := o.storeClient.AddVolume(vol); err != nil {
+		return fmt.Errorf("could not save volume %s; %v", v.Config.Name, err)
+	}
+	backend.Volumes[vol.Config.Name] = vol
+	o.volumes[vol.Config.Name] = vol
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+)
+
+func TestResumeAddVolumeTransaction(t *testing.T) {
+
+	const (
+		backendName = "resumeBackend"
+		scName      = "resumeBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	backend, err := orchestrator.getBackendByBackendName(backendName)
+	if err != nil {
+		t.Fatal("Unable to find backend: ", err)
+	}
+
+	// A volume created on the backend, but not saved before Trident restarted
+	createdConfig := tu.GenerateVolumeConfig("resumeCreated", 50, scName, config.File)
+	if _, err = orchestrator.AddVolume(ctx(), createdConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	vol := orchestrator.volumes[createdConfig.Name]
+	if err = orchestrator.storeClient.DeleteVolume(vol); err != nil {
+		t.Fatal("Unable to delete volume from store: ", err)
+	}
+	delete(orchestrator.volumes, createdConfig.Name)
+	delete(backend.Volumes, createdConfig.Name)
+
+	createdTxn := &storage.VolumeTransaction{
+		Config:      createdConfig,
+		Op:          storage.AddVolume,
+		Step:        storage.StepBackendCreated,
+		BackendUUID: backend.BackendUUID,
+		Pool:        vol.Pool,
+	}
+	if err = orchestrator.storeClient.AddVolumeTransaction(createdTxn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+
+	orchestrator.mutex.Lock()
+	err = orchestrator.resumeOrHandleFailedTransaction(ctx(), createdTxn)
+	orchestrator.mutex.Unlock()
+	assert.NoError(t, err)
+
+	if resumed, ok := orchestrator.volumes[createdConfig.Name]; assert.True(t, ok, "volume not resumed") {
+		assert.Equal(t, backend.BackendUUID, resumed.BackendUUID)
+		assert.Equal(t, vol.Pool, resumed.Pool)
+		assert.Contains(t, backend.Volumes, createdConfig.Name)
+	}
+	_, err = orchestrator.storeClient.GetVolume(createdConfig.Name)
+	assert.NoError(t, err, "volume not saved")
+
+	// A volume that never reached the backend is rolled back
+	missingConfig := tu.GenerateVolumeConfig("resumeMissing", 50, scName, config.File)
+	backend.Driver.CreatePrepare(missingConfig)
+	missingTxn := &storage.VolumeTransaction{
+		Config:      missingConfig,
+		Op:          storage.AddVolume,
+		Step:        storage.StepBackendCreated,
+		BackendUUID: backend.BackendUUID,
+	}
+	if err = orchestrator.storeClient.AddVolumeTransaction(missingTxn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+
+	orchestrator.mutex.Lock()
+	err = orchestrator.resumeOrHandleFailedTransaction(ctx(), missingTxn)
+	orchestrator.mutex.Unlock()
+	assert.NoError(t, err)
+	assert.NotContains(t, orchestrator.volumes, missingConfig.Name)
+
+	txns, err := orchestrator.storeClient.GetVolumeTransactions()
+	assert.NoError(t, err)
+	assert.Empty(t, txns, "transactions not cleared")
+}
+
+func TestAddVolumeRecordsSteps(t *testing.T) {
+
+	const (
+		backendName = "stepBackend"
+		scName      = "stepBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	volConfig := tu.GenerateVolumeConfig("stepVolume", 50, scName, config.File)
+	txn := &storage.VolumeTransaction{Config: volConfig, Op: storage.AddVolume}
+	if err := orchestrator.storeClient.AddVolumeTransaction(txn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+
+	err := orchestrator.recordVolumeOperationStep(txn, storage.StepBackendCreated, "uuid", "primary")
+	assert.NoError(t, err)
+
+	stored, err := orchestrator.storeClient.GetExistingVolumeTransaction(txn)
+	if assert.NoError(t, err) && assert.NotNil(t, stored) {
+		assert.Equal(t, storage.StepBackendCreated, stored.Step)
+		assert.Equal(t, "uuid", stored.BackendUUID)
+		assert.Equal(t, "primary", stored.Pool)
+	}
+	assert.NoError(t, orchestrator.DeleteVolumeTransaction(txn))
+}
+
+func TestAddVolumeCommitsTransaction(t *testing.T) {
+
+	const (
+		backendName = "batchBackend"
+		scName      = "batchBackendSC"
+	)
+	orchestrator := getOrchestrator()
+	prepRecoveryTest(t, orchestrator, backendName, scName)
+	defer cleanup(t, orchestrator)
+
+	volConfig := tu.GenerateVolumeConfig("batchVolume", 50, scName, config.File)
+	if _, err := orchestrator.AddVolume(ctx(), volConfig); err != nil {
+		t.Fatal("Unable to add volume: ", err)
+	}
+	_, err := orchestrator.storeClient.GetVolume(volConfig.Name)
+	assert.NoError(t, err, "volume not saved")
+	txn := &storage.VolumeTransaction{Config: volConfig, Op: storage.AddVolume}
+	existingTxn, err := orchestrator.storeClient.GetExistingVolumeTransaction(txn)
+	assert.NoError(t, err)
+	assert.Nil(t, existingTxn, "transaction not deleted")
+
+	// Leave a batch behind as if Trident restarted before saving the volume and deleting its transaction
+	vol := orchestrator.volumes[volConfig.Name]
+	if err = orchestrator.storeClient.DeleteVolume(vol); err != nil {
+		t.Fatal("Unable to delete volume from store: ", err)
+	}
+	if err = orchestrator.storeClient.AddVolumeTransaction(txn); err != nil {
+		t.Fatal("Unable to add volume transaction: ", err)
+	}
+	batch := persistentstore.NewBatch()
+	batch.PutVolume(vol)
+	batch.DeleteVolumeTransaction(txn)
+	intent := &storage.BatchIntent{Name: "batch-restarted", Entries: batch.Entries()}
+	if err = orchestrator.storeClient.AddBatchIntent(intent); err != nil {
+		t.Fatal("Unable to add batch intent: ", err)
+	}
+
+	newOrchestrator := getOrchestrator()
+	assert.Contains(t, newOrchestrator.volumes, volConfig.Name, "volume not bootstrapped")
+	txns, err := newOrchestrator.storeClient.GetVolumeTransactions()
+	assert.NoError(t, err)
+	assert.Empty(t, txns, "transactions left after bootstrap")
+	intents, err := newOrchestrator.storeClient.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "batch intents left after bootstrap")
+}
+
+// unappliedStoreClient can't commit batches itself, so that batches are written with intents, and fails to
+// save volumes and delete batch intents the given numbers of times
+type unappliedStoreClient struct {
+	persistentstore.Client
+	volumeFailures       int
+	intentDeleteFailures int
+}
+
+func (c *unappliedStoreClient) AddVolumePersistent(vol *storage.VolumeExternal) error {
+	if c.volumeFailures > 0 {
+		c.volumeFailures--
+		return fmt.Errorf("store unavailable")
+	}
+	return c.Client.AddVolumePersistent(vol)
+}
+
+func (c *unappliedStoreClient) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	if c.intentDeleteFailures > 0 {
+		c.intentDeleteFailures--
+		return fmt.Errorf("store unavailable")
+	}
+	return c.Client.DeleteBatchIntent(intent)
+}
+
+func TestCommitUnappliedBatch(t *testing.T) {
+
+	storeClient := &unappliedStoreClient{Client: persistentstore.NewInMemoryClient(), volumeFailures: 1}
+	orchestrator := NewTridentOrchestrator(storeClient)
+	orchestrator.bootstrapError = nil
+
+	volConfig := tu.GenerateVolumeConfig("unappliedVolume", 1, "sc", config.File)
+	txn := &storage.VolumeTransaction{Config: volConfig, Op: storage.AddVolume}
+	assert.NoError(t, storeClient.AddVolumeTransaction(txn))
+	vol := storage.NewVolume(volConfig, "backendUUID", "pool", false)
+
+	// A batch that fails once committed is retried right away
+	batch := persistentstore.NewBatch()
+	batch.PutVolume(vol)
+	batch.DeleteVolumeTransaction(txn)
+	assert.NoError(t, orchestrator.commitToPersistentStore(batch))
+	_, err := storeClient.GetVolume(volConfig.Name)
+	assert.NoError(t, err, "volume not saved")
+	intents, err := storeClient.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "batch intent left behind")
+
+	// A batch that still can't be finished fails, and its intent is discarded so it is never replayed
+	assert.NoError(t, storeClient.DeleteVolume(vol))
+	assert.NoError(t, storeClient.AddVolumeTransaction(txn))
+	storeClient.volumeFailures = 2
+	assert.True(t, persistentstore.IsBatchNotAppliedError(orchestrator.commitToPersistentStore(batch)))
+	_, err = storeClient.GetVolume(volConfig.Name)
+	assert.Error(t, err, "volume saved")
+	intents, err = storeClient.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "batch intent left behind")
+
+	// An intent that can't be discarded keeps its volume known to the orphan collector
+	storeClient.volumeFailures = 2
+	storeClient.intentDeleteFailures = 1
+	assert.Error(t, orchestrator.commitToPersistentStore(batch))
+	intents, err = storeClient.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Len(t, intents, 1)
+
+	volumes, _, err := orchestrator.getKnownVolumesAndSnapshots()
+	assert.NoError(t, err)
+	assert.Contains(t, volumes, volConfig)
+
+	// The orphan collector discards the intent without replaying it
+	orchestrator.collectOrphanedResources()
+	_, err = storeClient.GetVolume(volConfig.Name)
+	assert.Error(t, err, "failed batch replayed")
+	intents, err = storeClient.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "batch intent left behind")
+}
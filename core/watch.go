@@ -0,0 +1,336 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	persistentstore "github.com/netapp/trident/persistent_store"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the code that reports changes to Trident's objects to
+// watchers, such as the REST frontend's event stream.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+type ObjectEventType string
+
+const (
+	ObjectEventCreated ObjectEventType = "created"
+	ObjectEventUpdated ObjectEventType = "updated"
+	ObjectEventDeleted ObjectEventType = "deleted"
+)
+
+type ObjectKind string
+
+const (
+	ObjectKindVolume   ObjectKind = "volume"
+	ObjectKindSnapshot ObjectKind = "snapshot"
+	ObjectKindBackend  ObjectKind = "backend"
+	ObjectKindNode     ObjectKind = "node"
+)
+
+// ObjectKinds are the kinds of objects whose changes are reported to watchers.
+var ObjectKinds = []ObjectKind{ObjectKindVolume, ObjectKindSnapshot, ObjectKindBackend, ObjectKindNode}
+
+const (
+	// objectEventHistorySize is the number of recent events kept so that a watcher that reconnects
+	// may be sent those it missed.
+	objectEventHistorySize = 1000
+
+	// objectWatcherBufferSize is the number of events that may be waiting to be read by a watcher
+	// before it is dropped for falling behind.
+	objectWatcherBufferSize = 100
+)
+
+// ObjectEvent records the creation, update, or deletion of a Trident object.  Events are numbered
+// in the order they happened, starting from 1 each time Trident starts.  The object is in its
+// external form, and for a deletion is the object as it was deleted.
+type ObjectEvent struct {
+	ID     uint64          `json:"id"`
+	Type   ObjectEventType `json:"type"`
+	Kind   ObjectKind      `json:"kind"`
+	Name   string          `json:"name"`
+	Object interface{}     `json:"object,omitempty"`
+}
+
+// objectWatchers broadcasts object events to the channels of the current watchers and keeps the
+// recent events for watchers resuming after a given event.
+type objectWatchers struct {
+	mutex    sync.Mutex
+	lastID   uint64
+	history  []*ObjectEvent
+	watchers map[chan *ObjectEvent]struct{}
+}
+
+func newObjectWatchers() *objectWatchers {
+	return &objectWatchers{
+		history:  make([]*ObjectEvent, 0, objectEventHistorySize),
+		watchers: make(map[chan *ObjectEvent]struct{}),
+	}
+}
+
+// watch returns a channel of the events that happen after the given event, or after now if none
+// is given, which is closed when the context is done or if the watcher falls behind.  The events
+// after the given one must still be in the history.
+func (w *objectWatchers) watch(ctx context.Context, sinceID uint64) (<-chan *ObjectEvent, error) {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// Events are numbered anew when Trident restarts, so a later event than the last is unknown
+	missed := make([]*ObjectEvent, 0)
+	if sinceID > w.lastID {
+		return nil, utils.NotFoundError(fmt.Sprintf("event %d not found", sinceID))
+	} else if sinceID > 0 && sinceID < w.lastID {
+		if w.history[0].ID > sinceID+1 {
+			return nil, utils.NotFoundError(fmt.Sprintf("the events after event %d are no longer available",
+				sinceID))
+		}
+		missed = w.history[sinceID+1-w.history[0].ID:]
+	}
+
+	events := make(chan *ObjectEvent, len(missed)+objectWatcherBufferSize)
+	for _, event := range missed {
+		events <- event
+	}
+	w.watchers[events] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		w.remove(events)
+	}()
+
+	return events, nil
+}
+
+// remove closes a watcher's channel unless it was closed already.
+func (w *objectWatchers) remove(events chan *ObjectEvent) {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, ok := w.watchers[events]; ok {
+		delete(w.watchers, events)
+		close(events)
+	}
+}
+
+// notify numbers an event, adds it to the history, and sends it to every watcher.  Watchers are never
+// waited for, as events are sent while the orchestrator is locked, so a watcher whose channel is full
+// is dropped; it may watch again from the last event it read.
+func (w *objectWatchers) notify(eventType ObjectEventType, kind ObjectKind, name string, object interface{}) {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.lastID++
+	event := &ObjectEvent{ID: w.lastID, Type: eventType, Kind: kind, Name: name, Object: object}
+
+	if len(w.history) == objectEventHistorySize {
+		w.history = append(w.history[:0], w.history[1:]...)
+	}
+	w.history = append(w.history, event)
+
+	for events := range w.watchers {
+		select {
+		case events <- event:
+		default:
+			log.WithField("event", event.ID).Warning("Object watcher fell behind, dropping it.")
+			delete(w.watchers, events)
+			close(events)
+		}
+	}
+}
+
+// watchedStoreClient is a persistent store client that reports each change it stores to the
+// orchestrator's watchers, so that every path that changes an object is covered.
+type watchedStoreClient struct {
+	persistentstore.Client
+	watchers *objectWatchers
+}
+
+func (c *watchedStoreClient) AddBackend(b *storage.Backend) error {
+	if err := c.Client.AddBackend(b); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventCreated, ObjectKindBackend, b.Name, b.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) UpdateBackend(b *storage.Backend) error {
+	if err := c.Client.UpdateBackend(b); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventUpdated, ObjectKindBackend, b.Name, b.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteBackend(b *storage.Backend) error {
+	if err := c.Client.DeleteBackend(b); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindBackend, b.Name, b.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *storage.Backend) error {
+	if err := c.Client.ReplaceBackendAndUpdateVolumes(origBackend, newBackend); err != nil {
+		return err
+	}
+	if origBackend.Name != newBackend.Name {
+		c.watchers.notify(ObjectEventDeleted, ObjectKindBackend, origBackend.Name, origBackend.ConstructExternal())
+		c.watchers.notify(ObjectEventCreated, ObjectKindBackend, newBackend.Name, newBackend.ConstructExternal())
+	} else {
+		c.watchers.notify(ObjectEventUpdated, ObjectKindBackend, newBackend.Name, newBackend.ConstructExternal())
+	}
+	return nil
+}
+
+func (c *watchedStoreClient) AddVolume(vol *storage.Volume) error {
+	if err := c.Client.AddVolume(vol); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventCreated, ObjectKindVolume, vol.Config.Name, vol.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) UpdateVolume(vol *storage.Volume) error {
+	if err := c.Client.UpdateVolume(vol); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventUpdated, ObjectKindVolume, vol.Config.Name, vol.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteVolume(vol *storage.Volume) error {
+	if err := c.Client.DeleteVolume(vol); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindVolume, vol.Config.Name, vol.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteVolumeIgnoreNotFound(vol *storage.Volume) error {
+	if err := c.Client.DeleteVolumeIgnoreNotFound(vol); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindVolume, vol.Config.Name, vol.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) AddOrUpdateNode(n *utils.Node) error {
+
+	// The store doesn't say whether the node was new, so ask it first
+	eventType := ObjectEventUpdated
+	if _, err := c.Client.GetNode(n.Name); err != nil {
+		eventType = ObjectEventCreated
+	}
+
+	if err := c.Client.AddOrUpdateNode(n); err != nil {
+		return err
+	}
+	c.watchers.notify(eventType, ObjectKindNode, n.Name, n)
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteNode(n *utils.Node) error {
+	if err := c.Client.DeleteNode(n); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindNode, n.Name, n)
+	return nil
+}
+
+func (c *watchedStoreClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	if err := c.Client.AddSnapshot(snapshot); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventCreated, ObjectKindSnapshot, snapshot.ID(), snapshot.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	if err := c.Client.UpdateSnapshot(snapshot); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventUpdated, ObjectKindSnapshot, snapshot.ID(), snapshot.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	if err := c.Client.DeleteSnapshot(snapshot); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindSnapshot, snapshot.ID(), snapshot.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	if err := c.Client.DeleteSnapshotIgnoreNotFound(snapshot); err != nil {
+		return err
+	}
+	c.watchers.notify(ObjectEventDeleted, ObjectKindSnapshot, snapshot.ID(), snapshot.ConstructExternal())
+	return nil
+}
+
+func (c *watchedStoreClient) CommitBatch(batch *persistentstore.Batch) error {
+
+	// The store doesn't say whether each object put was new, so ask it first
+	putEventTypes := make([]ObjectEventType, len(batch.Entries()))
+	for i, entry := range batch.Entries() {
+		putEventTypes[i] = ObjectEventUpdated
+		switch entry.Op {
+		case storage.BatchPutVolume:
+			if _, err := c.Client.GetVolume(entry.Volume.Config.Name); err != nil {
+				putEventTypes[i] = ObjectEventCreated
+			}
+		case storage.BatchPutSnapshot:
+			snapConfig := entry.Snapshot.Config
+			if _, err := c.Client.GetSnapshot(snapConfig.VolumeName, snapConfig.Name); err != nil {
+				putEventTypes[i] = ObjectEventCreated
+			}
+		}
+	}
+
+	// A batch that was committed but not fully applied is reported by the writes that retry it
+	if err := persistentstore.CommitBatch(c.Client, batch); err != nil {
+		return err
+	}
+
+	for i, entry := range batch.Entries() {
+		switch entry.Op {
+		case storage.BatchPutVolume:
+			c.watchers.notify(putEventTypes[i], ObjectKindVolume, entry.Volume.Config.Name, entry.Volume)
+		case storage.BatchDeleteVolume:
+			c.watchers.notify(ObjectEventDeleted, ObjectKindVolume, entry.Volume.Config.Name, entry.Volume)
+		case storage.BatchPutSnapshot:
+			c.watchers.notify(putEventTypes[i], ObjectKindSnapshot, entry.Snapshot.ID(),
+				entry.Snapshot.ConstructExternal())
+		case storage.BatchDeleteSnapshot:
+			c.watchers.notify(ObjectEventDeleted, ObjectKindSnapshot, entry.Snapshot.ID(),
+				entry.Snapshot.ConstructExternal())
+		}
+	}
+	return nil
+}
+
+// WatchObjects returns a channel of the changes to Trident's volumes, snapshots, backends, and nodes
+// after the given event, or after now if the event ID is 0.  The channel is closed when the context is
+// done, or if the caller falls behind in reading it, in which case it may watch again from the last
+// event it read.
+func (o *TridentOrchestrator) WatchObjects(ctx context.Context, sinceID uint64) (<-chan *ObjectEvent, error) {
+	if o.bootstrapError != nil {
+		return nil, o.bootstrapError
+	}
+
+	return o.watchers.watch(ctx, sinceID)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	tu "github.com/netapp/trident/storage_drivers/fake/test_utils"
+	"github.com/netapp/trident/utils"
+)
+
+// readObjectEvents reads the events waiting in a watcher's channel without blocking.
+func readObjectEvents(events <-chan *ObjectEvent) []*ObjectEvent {
+	read := make([]*ObjectEvent, 0)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return read
+			}
+			read = append(read, event)
+		default:
+			return read
+		}
+	}
+}
+
+func TestObjectWatchers(t *testing.T) {
+
+	watchers := newObjectWatchers()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watchers.notify(ObjectEventCreated, ObjectKindVolume, "vol1", nil)
+
+	// A new watcher is sent only later events
+	events, err := watchers.watch(ctx, 0)
+	if err != nil {
+		t.Fatal("Unable to watch objects: ", err)
+	}
+	watchers.notify(ObjectEventUpdated, ObjectKindVolume, "vol1", nil)
+	watchers.notify(ObjectEventDeleted, ObjectKindVolume, "vol1", nil)
+
+	read := readObjectEvents(events)
+	if assert.Len(t, read, 2) {
+		assert.Equal(t, &ObjectEvent{ID: 2, Type: ObjectEventUpdated, Kind: ObjectKindVolume, Name: "vol1"}, read[0])
+		assert.Equal(t, uint64(3), read[1].ID)
+	}
+
+	// A resuming watcher is sent the events it missed first
+	resumed, err := watchers.watch(ctx, 1)
+	if err != nil {
+		t.Fatal("Unable to resume watching objects: ", err)
+	}
+	watchers.notify(ObjectEventCreated, ObjectKindNode, "node1", nil)
+	ids := make([]uint64, 0)
+	for _, event := range readObjectEvents(resumed) {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []uint64{2, 3, 4}, ids)
+
+	// Events after the last one are unknown
+	_, err = watchers.watch(ctx, 5)
+	assert.True(t, utils.IsNotFoundError(err), "unknown event watched")
+
+	// A watcher's channel is closed when its context is done
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+	closed, err := watchers.watch(watchCtx, 0)
+	if err != nil {
+		t.Fatal("Unable to watch objects: ", err)
+	}
+	watchCancel()
+	_, ok := <-closed
+	assert.False(t, ok, "channel not closed")
+}
+
+func TestObjectWatchersSlowWatcher(t *testing.T) {
+
+	watchers := newObjectWatchers()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := watchers.watch(ctx, 0)
+	if err != nil {
+		t.Fatal("Unable to watch objects: ", err)
+	}
+
+	// A watcher whose channel fills up is dropped
+	for i := 0; i <= objectWatcherBufferSize; i++ {
+		watchers.notify(ObjectEventUpdated, ObjectKindBackend, "backend1", nil)
+	}
+	read := readObjectEvents(events)
+	assert.Len(t, read, objectWatcherBufferSize)
+	_, ok := <-events
+	assert.False(t, ok, "slow watcher not dropped")
+
+	// It may resume from the last event it read while that is still in the history
+	resumed, err := watchers.watch(ctx, read[len(read)-1].ID)
+	if err != nil {
+		t.Fatal("Unable to resume watching objects: ", err)
+	}
+	if missed := readObjectEvents(resumed); assert.Len(t, missed, 1) {
+		assert.Equal(t, uint64(objectWatcherBufferSize+1), missed[0].ID)
+	}
+
+	for i := 0; i < objectEventHistorySize; i++ {
+		watchers.notify(ObjectEventUpdated, ObjectKindBackend, "backend1", nil)
+	}
+	_, err = watchers.watch(ctx, read[len(read)-1].ID)
+	assert.True(t, utils.IsNotFoundError(err), "expired events watched")
+}
+
+func TestWatchObjects(t *testing.T) {
+	const (
+		backendName = "watchBackend"
+		scName      = "watchSC"
+		volumeName  = "watchVolume"
+	)
+
+	orchestrator := getOrchestrator()
+	defer cleanup(t, orchestrator)
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := orchestrator.WatchObjects(watchCtx, 0)
+	if err != nil {
+		t.Fatal("Unable to watch objects: ", err)
+	}
+
+	addBackendStorageClass(t, orchestrator, backendName, scName, config.File)
+	if _, err = orchestrator.AddVolume(ctx(), tu.GenerateVolumeConfig(volumeName, 50, scName, config.File)); err != nil {
+		t.Fatal("Unable to create volume: ", err)
+	}
+	if err = orchestrator.DeleteVolume(ctx(), volumeName); err != nil {
+		t.Fatal("Unable to delete volume: ", err)
+	}
+
+	changes := make([]string, 0)
+	for _, event := range readObjectEvents(events) {
+		changes = append(changes, string(event.Kind)+" "+event.Name+" "+string(event.Type))
+		assert.NotNil(t, event.Object, "event has no object")
+	}
+	assert.Equal(t, []string{
+		"backend " + backendName + " created",
+		"volume " + volumeName + " created",
+		"volume " + volumeName + " deleted",
+	}, changes)
+}
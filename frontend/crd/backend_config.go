@@ -0,0 +1,343 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package crd
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	tridentv1 "github.com/netapp/trident/persistent_store/crd/apis/netapp/v1"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the code that manages the backends declared by
+// TridentBackendConfig objects.  Each declared backend is created when its
+// TridentBackendConfig is, updated when its spec or the Secret holding its
+// credentials changes, restored if it is changed or deleted outside of its
+// TridentBackendConfig, and deleted with its TridentBackendConfig.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+// Reasons of the conditions of a TridentBackendConfig and of the events recorded on it
+const (
+	backendConfigReasonAccepted           = "Accepted"
+	backendConfigReasonApplied            = "Applied"
+	backendConfigReasonDriftCorrected     = "DriftCorrected"
+	backendConfigReasonInvalidSpec        = "InvalidSpec"
+	backendConfigReasonInvalidCredentials = "InvalidCredentials"
+	backendConfigReasonBackendConflict    = "BackendConflict"
+	backendConfigReasonBackendFailed      = "BackendFailed"
+	backendConfigReasonDeleting           = "Deleting"
+)
+
+// enqueueBackendConfig queues a TridentBackendConfig to be reconciled with its backend.  The informer
+// resyncs periodically, so each backend is also checked for drift at that period.
+func (c *TridentCrdController) enqueueBackendConfig(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.backendConfigQueue.Add(key)
+}
+
+// runBackendConfigWorker reconciles queued TridentBackendConfigs until the queue is shut down.
+func (c *TridentCrdController) runBackendConfigWorker() {
+	for c.processNextBackendConfig() {
+	}
+}
+
+// processNextBackendConfig reconciles the next queued TridentBackendConfig, which is queued again
+// after a back-off period if it can't be reconciled.
+func (c *TridentCrdController) processNextBackendConfig() bool {
+
+	obj, shutdown := c.backendConfigQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.backendConfigQueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		c.backendConfigQueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := c.syncBackendConfig(key); err != nil {
+		c.backendConfigQueue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error()))
+		return true
+	}
+
+	c.backendConfigQueue.Forget(obj)
+	return true
+}
+
+// syncBackendConfig converges the backend declared by a TridentBackendConfig with its spec, and
+// records the outcome in the TridentBackendConfig's status.
+func (c *TridentCrdController) syncBackendConfig(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	cached, err := c.backendConfigsLister.TridentBackendConfigs(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	tbc := cached.DeepCopy()
+
+	if !tbc.ObjectMeta.DeletionTimestamp.IsZero() {
+		return c.deleteBackendConfigBackend(tbc)
+	}
+
+	// Keep the TridentBackendConfig until its backend has been deleted
+	if !tbc.HasTridentFinalizers() {
+		tbc.AddTridentFinalizers()
+		if tbc, err = c.crdClientset.TridentV1().TridentBackendConfigs(namespace).Update(ctx(), tbc,
+			updateOpts); err != nil {
+			return err
+		}
+	}
+
+	status := tbc.Status.DeepCopy()
+	applyErr := c.reconcileBackendConfig(tbc)
+
+	if !reflect.DeepEqual(status, &tbc.Status) {
+		if _, err = c.crdClientset.TridentV1().TridentBackendConfigs(namespace).Update(ctx(), tbc,
+			updateOpts); err != nil {
+			return err
+		}
+	}
+
+	return applyErr
+}
+
+// reconcileBackendConfig creates or updates the backend declared by a TridentBackendConfig if the
+// backend doesn't match the spec, and updates the TridentBackendConfig's status.  An error is returned
+// only if the backend could not be created or updated, so that it may be tried again.
+func (c *TridentCrdController) reconcileBackendConfig(tbc *tridentv1.TridentBackendConfig) error {
+
+	logFields := log.Fields{"backendConfig": tbc.Namespace + "/" + tbc.Name}
+
+	configJSON, reason, err := c.getBackendConfigJSON(tbc)
+	if err != nil {
+		log.WithFields(logFields).WithField("error", err).Warning("Invalid backend configuration.")
+		c.setBackendConfigFailed(tbc, reason, err.Error())
+		return nil
+	}
+	configDigest := digest([]byte(configJSON))
+
+	var backend *storage.BackendExternal
+	if tbc.Status.BackendUUID != "" {
+		if backend, err = c.orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID); err != nil {
+			if !utils.IsNotFoundError(err) {
+				return err
+			}
+			backend = nil
+		}
+	}
+
+	// Determine whether the backend matches the spec and hasn't been changed by anyone else
+	syncReason, syncMessage := backendConfigReasonApplied, "backend configuration applied"
+	switch {
+	case backend == nil && tbc.Status.BackendUUID == "":
+		if conflict := c.getConflictingBackendConfig(tbc); conflict != "" {
+			c.setBackendConfigFailed(tbc, backendConfigReasonBackendConflict,
+				fmt.Sprintf("backend is already declared by TridentBackendConfig %s", conflict))
+			return nil
+		}
+	case backend == nil:
+		syncReason, syncMessage = backendConfigReasonDriftCorrected, "backend was deleted outside of its "+
+			"TridentBackendConfig and has been created again"
+	case tbc.Status.ConfigDigest != configDigest:
+	case tbc.Status.BackendDigest != backendDigest(backend):
+		syncReason, syncMessage = backendConfigReasonDriftCorrected, "backend was changed outside of its "+
+			"TridentBackendConfig and has been restored"
+	default:
+		return nil
+	}
+
+	log.WithFields(logFields).WithField("reason", syncReason).Info("Applying backend configuration.")
+
+	if backend == nil {
+		backend, err = c.orchestrator.AddBackend(configJSON)
+	} else {
+		backend, err = c.orchestrator.UpdateBackendByBackendUUID(backend.Name, configJSON, backend.BackendUUID)
+	}
+	if err != nil {
+		log.WithFields(logFields).WithField("error", err).Error("Could not apply backend configuration.")
+		if backend != nil {
+			tbc.Status.BackendName = backend.Name
+			tbc.Status.BackendUUID = backend.BackendUUID
+		}
+		c.setBackendConfigFailed(tbc, backendConfigReasonBackendFailed, err.Error())
+		return err
+	}
+
+	tbc.Status.Phase = tridentv1.BackendConfigPhaseBound
+	tbc.Status.BackendName = backend.Name
+	tbc.Status.BackendUUID = backend.BackendUUID
+	tbc.Status.ConfigDigest = configDigest
+	tbc.Status.BackendDigest = backendDigest(backend)
+	tbc.SetCondition(tridentv1.BackendConfigConditionValid, true, backendConfigReasonAccepted,
+		"backend configuration accepted")
+	tbc.SetCondition(tridentv1.BackendConfigConditionInSync, true, syncReason, syncMessage)
+
+	eventType := corev1.EventTypeNormal
+	if syncReason == backendConfigReasonDriftCorrected {
+		eventType = corev1.EventTypeWarning
+	}
+	c.recorder.Event(tbc, eventType, syncReason, syncMessage)
+
+	return nil
+}
+
+// setBackendConfigFailed records in a TridentBackendConfig's status why its spec couldn't be applied.
+func (c *TridentCrdController) setBackendConfigFailed(tbc *tridentv1.TridentBackendConfig, reason, message string) {
+
+	if condition := tbc.GetCondition(tridentv1.BackendConfigConditionValid); condition == nil ||
+		condition.Reason != reason || condition.Message != message {
+		c.recorder.Event(tbc, corev1.EventTypeWarning, reason, message)
+	}
+
+	tbc.Status.Phase = tridentv1.BackendConfigPhaseFailed
+	tbc.Status.ConfigDigest = ""
+	tbc.SetCondition(tridentv1.BackendConfigConditionValid, false, reason, message)
+	tbc.SetCondition(tridentv1.BackendConfigConditionInSync, false, reason, "backend configuration not applied")
+}
+
+// getBackendConfigJSON returns the backend configuration declared by a TridentBackendConfig, with the
+// keys of its credentials Secret added as fields, or the reason it couldn't be made.
+func (c *TridentCrdController) getBackendConfigJSON(tbc *tridentv1.TridentBackendConfig) (string, string, error) {
+
+	config, credentials, err := tbc.ParseSpec()
+	if err != nil {
+		return "", backendConfigReasonInvalidSpec, err
+	}
+
+	if credentials != nil {
+		secret, err := c.kubeClientset.CoreV1().Secrets(tbc.Namespace).Get(ctx(), credentials.Name, getOpts)
+		if err != nil {
+			return "", backendConfigReasonInvalidCredentials, fmt.Errorf("could not get secret %s; %v",
+				credentials.Name, err)
+		}
+		for key, value := range secret.Data {
+			if _, ok := config[key]; ok {
+				return "", backendConfigReasonInvalidSpec, fmt.Errorf("field %s must be set only in secret %s",
+					key, credentials.Name)
+			}
+			config[key] = string(value)
+		}
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return "", backendConfigReasonInvalidSpec, err
+	}
+	return string(configJSON), "", nil
+}
+
+// getConflictingBackendConfig returns the name of another TridentBackendConfig that declares the
+// backend named in a TridentBackendConfig's spec, if any.
+func (c *TridentCrdController) getConflictingBackendConfig(tbc *tridentv1.TridentBackendConfig) string {
+
+	config, _, err := tbc.ParseSpec()
+	if err != nil {
+		return ""
+	}
+	backendName, ok := config["backendName"].(string)
+	if !ok || backendName == "" {
+		return ""
+	}
+
+	others, err := c.backendConfigsLister.TridentBackendConfigs(tbc.Namespace).List(labels.Everything())
+	if err != nil {
+		return ""
+	}
+	for _, other := range others {
+		if other.Name != tbc.Name && other.Status.BackendName == backendName {
+			return other.Name
+		}
+	}
+	return ""
+}
+
+// deleteBackendConfigBackend deletes the backend of a TridentBackendConfig being deleted, and releases
+// the TridentBackendConfig once the backend is gone.  A backend with volumes is deleted by Trident only
+// after its last volume is, so the TridentBackendConfig is kept until then.
+func (c *TridentCrdController) deleteBackendConfigBackend(tbc *tridentv1.TridentBackendConfig) error {
+
+	if !tbc.HasTridentFinalizers() {
+		return nil
+	}
+
+	if tbc.Status.BackendUUID != "" {
+		backend, err := c.orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID)
+		if err != nil && !utils.IsNotFoundError(err) {
+			return err
+		}
+		if backend != nil {
+			if !backend.State.IsDeleting() {
+				log.WithFields(log.Fields{
+					"backendConfig": tbc.Namespace + "/" + tbc.Name,
+					"backend":       backend.Name,
+				}).Info("Deleting backend of deleted backend configuration.")
+				if err = c.orchestrator.DeleteBackendByBackendUUID(backend.Name,
+					backend.BackendUUID); err != nil && !utils.IsNotFoundError(err) {
+					return err
+				}
+			}
+			if backend, err = c.orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID); err == nil {
+				message := fmt.Sprintf("backend will be deleted once its %d volumes are", len(backend.Volumes))
+				if tbc.Status.Phase != tridentv1.BackendConfigPhaseDeleting {
+					tbc.Status.Phase = tridentv1.BackendConfigPhaseDeleting
+					tbc.SetCondition(tridentv1.BackendConfigConditionInSync, false, backendConfigReasonDeleting,
+						message)
+					if _, err = c.crdClientset.TridentV1().TridentBackendConfigs(tbc.Namespace).Update(ctx(), tbc,
+						updateOpts); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if !utils.IsNotFoundError(err) {
+				return err
+			}
+		}
+	}
+
+	tbc.RemoveTridentFinalizers()
+	_, err := c.crdClientset.TridentV1().TridentBackendConfigs(tbc.Namespace).Update(ctx(), tbc, updateOpts)
+	return err
+}
+
+// backendDigest identifies a backend's configuration as reported by Trident, without its credentials.
+func backendDigest(backend *storage.BackendExternal) string {
+	configJSON, err := json.Marshal(backend.Config)
+	if err != nil {
+		return ""
+	}
+	return digest(configJSON)
+}
+
+func digest(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
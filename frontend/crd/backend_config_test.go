@@ -0,0 +1,135 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package crd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/netapp/trident/core"
+	persistentstore "github.com/netapp/trident/persistent_store"
+	tridentv1 "github.com/netapp/trident/persistent_store/crd/apis/netapp/v1"
+)
+
+func newTestBackendConfig(t *testing.T, name, backendName, secretName string) *tridentv1.TridentBackendConfig {
+
+	configJSON, err := newFakeStorageDriverConfigJSON(backendName)
+	if err != nil {
+		t.Fatal("Unable to generate backend config: ", err)
+	}
+	spec := make(map[string]interface{})
+	if err = json.Unmarshal([]byte(configJSON), &spec); err != nil {
+		t.Fatal("Unable to parse backend config: ", err)
+	}
+	spec["backendName"] = backendName
+	spec["credentials"] = map[string]string{"name": secretName}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal("Unable to generate spec: ", err)
+	}
+
+	return &tridentv1.TridentBackendConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "trident"},
+		Spec:       runtime.RawExtension{Raw: specJSON},
+	}
+}
+
+func TestSyncBackendConfig(t *testing.T) {
+
+	orchestrator := core.NewTridentOrchestrator(persistentstore.NewInMemoryClient())
+	if err := orchestrator.Bootstrap(); err != nil {
+		t.Fatal("Unable to bootstrap orchestrator: ", err)
+	}
+	defer orchestrator.Stop()
+
+	kubeClient := GetTestKubernetesClientset()
+	crdClient := GetTestCrdClientset()
+	controller, err := newTridentCrdControllerImpl(orchestrator, "trident", kubeClient, crdClient)
+	if err != nil {
+		t.Fatal("Unable to create Trident CRD controller: ", err)
+	}
+	indexer := controller.crdInformer.TridentBackendConfigs().Informer().GetIndexer()
+	tbcs := crdClient.TridentV1().TridentBackendConfigs("trident")
+
+	// sync applies the TridentBackendConfig as the informer would see it
+	sync := func(name string) *tridentv1.TridentBackendConfig {
+		tbc, err := tbcs.Get(ctx(), name, getOpts)
+		if err != nil {
+			t.Fatal("Unable to get TridentBackendConfig: ", err)
+		}
+		_ = indexer.Update(tbc)
+		_ = controller.syncBackendConfig("trident/" + name)
+		if tbc, err = tbcs.Get(ctx(), name, getOpts); err != nil {
+			t.Fatal("Unable to get TridentBackendConfig: ", err)
+		}
+		return tbc
+	}
+
+	// A missing secret leaves the backend configuration invalid
+	if _, err = tbcs.Create(ctx(), newTestBackendConfig(t, "tbc1", "fake1", "creds"), createOpts); err != nil {
+		t.Fatal("Unable to create TridentBackendConfig: ", err)
+	}
+	tbc := sync("tbc1")
+	assert.Equal(t, tridentv1.BackendConfigPhaseFailed, tbc.Status.Phase)
+	assert.Equal(t, tridentv1.ConditionFalse, tbc.GetCondition(tridentv1.BackendConfigConditionValid).Status)
+	assert.Equal(t, backendConfigReasonInvalidCredentials,
+		tbc.GetCondition(tridentv1.BackendConfigConditionValid).Reason)
+	assert.True(t, tbc.HasTridentFinalizers())
+
+	// Once the secret exists, the backend is created
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "trident"},
+		Data:       map[string][]byte{"username": []byte("admin")},
+	}
+	if _, err = kubeClient.CoreV1().Secrets("trident").Create(ctx(), secret, createOpts); err != nil {
+		t.Fatal("Unable to create secret: ", err)
+	}
+	tbc = sync("tbc1")
+	assert.Equal(t, tridentv1.BackendConfigPhaseBound, tbc.Status.Phase)
+	assert.Equal(t, "fake1", tbc.Status.BackendName)
+	assert.Equal(t, tridentv1.ConditionTrue, tbc.GetCondition(tridentv1.BackendConfigConditionValid).Status)
+	assert.Equal(t, backendConfigReasonApplied, tbc.GetCondition(tridentv1.BackendConfigConditionInSync).Reason)
+	backend, err := orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID)
+	if err != nil {
+		t.Fatal("Backend not created: ", err)
+	}
+	assert.Equal(t, "fake1", backend.Name)
+
+	// A backend deleted outside of its TridentBackendConfig is created again
+	if err = orchestrator.DeleteBackendByBackendUUID(backend.Name, backend.BackendUUID); err != nil {
+		t.Fatal("Unable to delete backend: ", err)
+	}
+	tbc = sync("tbc1")
+	assert.Equal(t, tridentv1.BackendConfigPhaseBound, tbc.Status.Phase)
+	assert.Equal(t, backendConfigReasonDriftCorrected,
+		tbc.GetCondition(tridentv1.BackendConfigConditionInSync).Reason)
+	if _, err = orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID); err != nil {
+		t.Fatal("Backend not created again: ", err)
+	}
+
+	// Another TridentBackendConfig may not declare the same backend
+	if _, err = tbcs.Create(ctx(), newTestBackendConfig(t, "tbc2", "fake1", "creds"), createOpts); err != nil {
+		t.Fatal("Unable to create TridentBackendConfig: ", err)
+	}
+	tbc2 := sync("tbc2")
+	assert.Equal(t, tridentv1.BackendConfigPhaseFailed, tbc2.Status.Phase)
+	assert.Equal(t, backendConfigReasonBackendConflict,
+		tbc2.GetCondition(tridentv1.BackendConfigConditionValid).Reason)
+
+	// Deleting the TridentBackendConfig deletes its backend and releases it
+	now := metav1.Now()
+	tbc.DeletionTimestamp = &now
+	if _, err = tbcs.Update(ctx(), tbc, updateOpts); err != nil {
+		t.Fatal("Unable to update TridentBackendConfig: ", err)
+	}
+	tbc = sync("tbc1")
+	assert.False(t, tbc.HasTridentFinalizers())
+	if _, err = orchestrator.GetBackendByBackendUUID(tbc.Status.BackendUUID); err == nil {
+		t.Fatal("Backend not deleted.")
+	}
+}
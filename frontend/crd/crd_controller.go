@@ -83,6 +83,10 @@ type TridentCrdController struct {
 	backendsLister listers.TridentBackendLister
 	backendsSynced cache.InformerSynced
 
+	// TridentBackendConfig CRD handling
+	backendConfigsLister listers.TridentBackendConfigLister
+	backendConfigsSynced cache.InformerSynced
+
 	// TridentNode CRD handling
 	nodesLister listers.TridentNodeLister
 	nodesSynced cache.InformerSynced
@@ -107,6 +111,10 @@ type TridentCrdController struct {
 	snapshotsLister listers.TridentSnapshotLister
 	snapshotsSynced cache.InformerSynced
 
+	// TridentQuota CRD handling
+	quotasLister listers.TridentQuotaLister
+	quotasSynced cache.InformerSynced
+
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
@@ -114,6 +122,9 @@ type TridentCrdController struct {
 	// simultaneously in two different workers.
 	workqueue workqueue.RateLimitingInterface
 
+	// backendConfigQueue is the work queue of TridentBackendConfigs to be reconciled with their backends.
+	backendConfigQueue workqueue.RateLimitingInterface
+
 	// recorder is an event recorder for recording Event resources to the Kubernetes API.
 	recorder record.EventRecorder
 }
@@ -184,12 +195,14 @@ func newTridentCrdControllerImpl(
 	crdInformer := trident_informers_v1.New(crdInformerFactory, tridentNamespace, nil)
 
 	backendInformer := crdInformer.TridentBackends()
+	backendConfigInformer := crdInformer.TridentBackendConfigs()
 	nodeInformer := crdInformer.TridentNodes()
 	storageClassInformer := crdInformer.TridentStorageClasses()
 	transactionInformer := crdInformer.TridentTransactions()
 	versionInformer := crdInformer.TridentVersions()
 	volumeInformer := crdInformer.TridentVolumes()
 	snapshotInformer := crdInformer.TridentSnapshots()
+	quotaInformer := crdInformer.TridentQuotas()
 
 	// Create event broadcaster
 	// Add our types to the default Kubernetes Scheme so Events can be logged.
@@ -209,6 +222,8 @@ func newTridentCrdControllerImpl(
 		crdInformer:           crdInformer,
 		backendsLister:        backendInformer.Lister(),
 		backendsSynced:        backendInformer.Informer().HasSynced,
+		backendConfigsLister:  backendConfigInformer.Lister(),
+		backendConfigsSynced:  backendConfigInformer.Informer().HasSynced,
 		nodesLister:           nodeInformer.Lister(),
 		nodesSynced:           nodeInformer.Informer().HasSynced,
 		storageClassesLister:  storageClassInformer.Lister(),
@@ -221,8 +236,12 @@ func newTridentCrdControllerImpl(
 		volumesSynced:         volumeInformer.Informer().HasSynced,
 		snapshotsLister:       snapshotInformer.Lister(),
 		snapshotsSynced:       snapshotInformer.Informer().HasSynced,
+		quotasLister:          quotaInformer.Lister(),
+		quotasSynced:          quotaInformer.Informer().HasSynced,
 		workqueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TridentBackends"),
-		recorder:              recorder,
+		backendConfigQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(),
+			"TridentBackendConfigs"),
+		recorder: recorder,
 	}
 
 	// Set up event handlers for when our Trident CRDs change
@@ -306,6 +325,13 @@ func newTridentCrdControllerImpl(
 		})
 	}
 
+	backendConfigInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueBackendConfig,
+		UpdateFunc: func(oldCrd, newCrd interface{}) {
+			controller.enqueueBackendConfig(newCrd)
+		},
+	})
+
 	informers := []cache.SharedIndexInformer{
 		backendInformer.Informer(), // TODO what we do depends on useComplicatedBackendUpdateHandling above
 		nodeInformer.Informer(),
@@ -314,6 +340,7 @@ func newTridentCrdControllerImpl(
 		versionInformer.Informer(),
 		volumeInformer.Informer(),
 		snapshotInformer.Informer(),
+		quotaInformer.Informer(),
 	}
 	for _, informer := range informers {
 		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -363,6 +390,7 @@ func (c *TridentCrdController) Run(threadiness int, stopCh <-chan struct{}) erro
 
 	defer utilruntime.HandleCrash()
 	defer c.workqueue.ShutDown()
+	defer c.backendConfigQueue.ShutDown()
 
 	// Start the informer factories to begin populating the informer caches
 	log.Info("Starting Trident CRD controller.")
@@ -371,12 +399,14 @@ func (c *TridentCrdController) Run(threadiness int, stopCh <-chan struct{}) erro
 	log.Info("Waiting for informer caches to sync.")
 	if ok := cache.WaitForCacheSync(stopCh,
 		c.backendsSynced,
+		c.backendConfigsSynced,
 		c.nodesSynced,
 		c.storageClassesSynced,
 		c.transactionsSynced,
 		c.versionsSynced,
 		c.volumesSynced,
-		c.snapshotsSynced); !ok {
+		c.snapshotsSynced,
+		c.quotasSynced); !ok {
 		waitErr := fmt.Errorf("failed to wait for caches to sync")
 		log.Errorf("Error: %v", waitErr)
 		return waitErr
@@ -387,6 +417,7 @@ func (c *TridentCrdController) Run(threadiness int, stopCh <-chan struct{}) erro
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
 	}
+	go wait.Until(c.runBackendConfigWorker, time.Second, stopCh)
 
 	log.Info("Started workers.")
 	<-stopCh
@@ -574,6 +605,8 @@ func (c *TridentCrdController) updateBackend(backend *tridentv1.TridentBackend)
 		configJSON, marshalErr = json.Marshal(psbc.EseriesConfig)
 	} else if psbc.FakeStorageDriverConfig != nil {
 		configJSON, marshalErr = json.Marshal(psbc.FakeStorageDriverConfig)
+	} else if psbc.FakeOntapConfig != nil {
+		configJSON, marshalErr = json.Marshal(psbc.FakeOntapConfig)
 	} else if psbc.OntapConfig != nil {
 		configJSON, marshalErr = json.Marshal(psbc.OntapConfig)
 	} else if psbc.SolidfireConfig != nil {
@@ -689,6 +722,10 @@ func (c *TridentCrdController) removeFinalizers(obj interface{}, force bool) {
 		if force || !crd.ObjectMeta.DeletionTimestamp.IsZero() {
 			c.removeSnapshotFinalizers(crd)
 		}
+	case *tridentv1.TridentQuota:
+		if force || !crd.ObjectMeta.DeletionTimestamp.IsZero() {
+			c.removeQuotaFinalizers(crd)
+		}
 	default:
 		log.Warnf("unexpected type %T", crd)
 	}
@@ -840,3 +877,24 @@ func (c *TridentCrdController) removeSnapshotFinalizers(snap *tridentv1.TridentS
 		log.Debug("No finalizers to remove.")
 	}
 }
+
+// removeQuotaFinalizers removes Trident's finalizers from TridentQuota CRD objects
+func (c *TridentCrdController) removeQuotaFinalizers(quota *tridentv1.TridentQuota) {
+	log.WithFields(log.Fields{
+		"quota.ResourceVersion":              quota.ResourceVersion,
+		"quota.ObjectMeta.DeletionTimestamp": quota.ObjectMeta.DeletionTimestamp,
+	}).Debug("removeQuotaFinalizers")
+
+	if quota.HasTridentFinalizers() {
+		log.Debug("Has finalizers, removing them.")
+		quotaCopy := quota.DeepCopy()
+		quotaCopy.RemoveTridentFinalizers()
+		_, err := c.crdClientset.TridentV1().TridentQuotas(quota.Namespace).Update(ctx(), quotaCopy, updateOpts)
+		if err != nil {
+			log.Errorf("Problem removing finalizers: %v", err)
+			return
+		}
+	} else {
+		log.Debug("No finalizers to remove.")
+	}
+}
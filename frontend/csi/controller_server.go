@@ -5,7 +5,7 @@ package csi
 import (
 	"context"
 	"fmt"
-	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,7 +18,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	tridentconfig "github.com/netapp/trident/config"
-	"github.com/netapp/trident/frontend/csi/helpers"
+	"github.com/netapp/trident/frontend"
 	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/utils"
 )
@@ -53,8 +53,9 @@ func (p *Plugin) CreateVolume(
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
-	// If pre-existing volume found, check for the requested capacity and already allocated capacity
-	if existingVolume != nil {
+	// If pre-existing volume found, check for the requested capacity and already allocated capacity.  A volume
+	// still being populated from its data source is left to the orchestrator, so it isn't bound until complete.
+	if existingVolume != nil && !existingVolume.State.IsPopulating() {
 
 		// Check if the size of existing volume is compatible with the new request
 		existingSize, _ := strconv.ParseInt(existingVolume.Config.Size, 10, 64)
@@ -155,10 +156,16 @@ func (p *Plugin) CreateVolume(
 	volConfig, err := p.helper.GetVolumeConfig(req.Name, sizeBytes, req.Parameters, protocol, accessModes,
 		volumeMode, fsType)
 	if err != nil {
-		p.helper.RecordVolumeEvent(req.Name, helpers.EventTypeNormal, "ProvisioningFailed", err.Error())
+		p.helper.RecordVolumeEvent(req.Name, frontend.EventTypeNormal, "ProvisioningFailed", err.Error())
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
+	// Place the volume where it will be accessible from the topology requested by CSI
+	if req.AccessibilityRequirements != nil {
+		volConfig.RequisiteTopologies = getTopologySegments(req.AccessibilityRequirements.Requisite)
+		volConfig.PreferredTopologies = getTopologySegments(req.AccessibilityRequirements.Preferred)
+	}
+
 	// Check if CSI asked for a clone (overrides trident.netapp.io/cloneFromPVC PVC annotation, if present)
 	if req.VolumeContentSource != nil {
 		switch contentSource := req.VolumeContentSource.Type.(type) {
@@ -169,6 +176,7 @@ func (p *Plugin) CreateVolume(
 				return nil, status.Error(codes.InvalidArgument, "content source volume ID missing in request")
 			}
 			volConfig.CloneSourceVolume = volumeID
+			volConfig.CloneSourceNamespace = volConfig.Namespace
 
 		case *csi.VolumeContentSource_Snapshot:
 			snapshotID := contentSource.Snapshot.SnapshotId
@@ -184,6 +192,7 @@ func (p *Plugin) CreateVolume(
 			} else {
 				volConfig.CloneSourceVolume = cloneSourceVolume
 				volConfig.CloneSourceSnapshot = cloneSourceSnapshot
+				volConfig.CloneSourceNamespace = volConfig.Namespace
 			}
 		}
 	}
@@ -191,15 +200,15 @@ func (p *Plugin) CreateVolume(
 	// Invoke the orchestrator to create or clone the new volume
 	var newVolume *storage.VolumeExternal
 	if volConfig.CloneSourceVolume != "" {
-		newVolume, err = p.orchestrator.CloneVolume(volConfig)
+		newVolume, err = p.orchestrator.CloneVolume(ctx, volConfig)
 	} else if volConfig.ImportOriginalName != "" {
-		newVolume, err = p.orchestrator.ImportVolume(volConfig)
+		newVolume, err = p.orchestrator.ImportVolume(ctx, volConfig)
 	} else {
-		newVolume, err = p.orchestrator.AddVolume(volConfig)
+		newVolume, err = p.orchestrator.AddVolume(ctx, volConfig)
 	}
 
 	if err != nil {
-		p.helper.RecordVolumeEvent(req.Name, helpers.EventTypeNormal, "ProvisioningFailed", err.Error())
+		p.helper.RecordVolumeEvent(req.Name, frontend.EventTypeNormal, "ProvisioningFailed", err.Error())
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	} else {
 		p.helper.RecordVolumeEvent(req.Name, v1.EventTypeNormal, "ProvisioningSuccess", "provisioned a volume")
@@ -225,7 +234,7 @@ func (p *Plugin) DeleteVolume(
 		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
 	}
 
-	if err := p.orchestrator.DeleteVolume(req.VolumeId); err != nil {
+	if err := p.orchestrator.DeleteVolume(ctx, req.VolumeId); err != nil {
 
 		log.WithFields(log.Fields{
 			"volumeName": req.VolumeId,
@@ -299,16 +308,23 @@ func (p *Plugin) ControllerPublishVolume(
 	}
 
 	// Update NFS export rules (?), add node IQN to igroup, etc.
-	err = p.orchestrator.PublishVolume(volume.Config.Name, volumePublishInfo)
+	err = p.orchestrator.PublishVolume(ctx, volume.Config.Name, volumePublishInfo)
 	if err != nil {
+		if utils.IsUnsupportedAccessModeError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if csiErr := getCSIErrorForDriverError(err); csiErr != nil {
+			return nil, csiErr
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	// If any mount options are passed in via CSI (e.g. from a StorageClass), then any mount options
-	// that were specified in the storage driver's backend configuration and passed here in the
-	// VolumePublishInfo struct are completely discarded and replaced by the CSI-supplied values.
+	// If any mount options are passed in via CSI (e.g. from a PV), then any mount options that were
+	// specified in the storage driver's backend configuration and passed here in the VolumePublishInfo
+	// struct are completely discarded and replaced by the CSI-supplied values.  Options recorded for the
+	// volume itself, from its PVC, storage class, or virtual pool, were validated and win.
 	mount := req.VolumeCapability.GetMount()
-	if mount != nil && len(mount.MountFlags) > 0 {
+	if mount != nil && len(mount.MountFlags) > 0 && volume.Config.MountOptions == "" {
 		volumePublishInfo.MountOptions = strings.Join(mount.MountFlags, ",")
 	}
 
@@ -321,10 +337,16 @@ func (p *Plugin) ControllerPublishVolume(
 	if volume.Config.Protocol == tridentconfig.File {
 		publishInfo["nfsServerIp"] = volume.Config.AccessInfo.NfsServerIP
 		publishInfo["nfsPath"] = volume.Config.AccessInfo.NfsPath
+		if len(volumePublishInfo.NfsServerIPs) > 0 {
+			publishInfo["nfsServerIps"] = strings.Join(volumePublishInfo.NfsServerIPs, ",")
+		}
 	} else if volume.Config.Protocol == tridentconfig.Block {
 		stashIscsiTargetPortals(publishInfo, volumePublishInfo)
 		publishInfo["iscsiTargetIqn"] = volume.Config.AccessInfo.IscsiTargetIQN
 		publishInfo["iscsiLunNumber"] = strconv.Itoa(int(volume.Config.AccessInfo.IscsiLunNumber))
+		publishInfo["iscsiLunSerial"] = volumePublishInfo.IscsiLunSerial
+		publishInfo["iscsiExpectedPaths"] = strconv.Itoa(volumePublishInfo.IscsiExpectedPaths)
+		publishInfo["iscsiLunUuid"] = volumePublishInfo.IscsiLunUUID
 		publishInfo["iscsiInterface"] = volume.Config.AccessInfo.IscsiInterface
 		publishInfo["iscsiIgroup"] = volume.Config.AccessInfo.IscsiIgroup
 		publishInfo["iscsiUsername"] = volumePublishInfo.IscsiUsername               //volume.Config.AccessInfo.IscsiUsername
@@ -334,6 +356,9 @@ func (p *Plugin) ControllerPublishVolume(
 		publishInfo["filesystemType"] = volumePublishInfo.FilesystemType
 		publishInfo["useCHAP"] = strconv.FormatBool(volumePublishInfo.UseCHAP)
 		publishInfo["sharedTarget"] = strconv.FormatBool(volumePublishInfo.SharedTarget)
+		if volumePublishInfo.SpaceReclamationPeriod > 0 {
+			publishInfo["spaceReclamationPeriod"] = strconv.Itoa(volumePublishInfo.SpaceReclamationPeriod)
+		}
 	}
 
 	return &csi.ControllerPublishVolumeResponse{PublishContext: publishInfo}, nil
@@ -352,12 +377,12 @@ func (p *Plugin) ControllerUnpublishVolume(
 		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
 	}
 
-	// Check if volume exists.  If not, return success.
-	if _, err := p.orchestrator.GetVolume(volumeID); err != nil && !utils.IsNotFoundError(err) {
+	// Forget the node the volume was published to.  If the volume doesn't exist, return success.
+	if err := p.orchestrator.UnpublishVolume(volumeID, req.GetNodeId()); err != nil && !utils.IsNotFoundError(err) {
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
-	// Apart from validation, Trident has nothing to do for this entry point
+	// Trident has nothing else to do for this entry point
 	return &csi.ControllerUnpublishVolumeResponse{}, nil
 }
 
@@ -415,52 +440,28 @@ func (p *Plugin) ListVolumes(
 	log.WithFields(fields).Debug(">>>> ListVolumes")
 	defer log.WithFields(fields).Debug("<<<< ListVolumes")
 
-	// Verify volume named same as starting-token exists or not.
-	if req.StartingToken != "" {
-		existingVolume, err := p.orchestrator.GetVolume(req.StartingToken)
-		if err != nil && !utils.IsNotFoundError(err) {
-			return nil, p.getCSIErrorForOrchestratorError(err)
-		}
-
-		if existingVolume == nil {
-			return nil, status.Errorf(codes.Aborted, "starting_token '%s' is not valid", req.StartingToken)
-		}
-	}
-
 	volumes, err := p.orchestrator.ListVolumes()
 	if err != nil {
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
+	sort.Sort(storage.ByVolumeExternalName(volumes))
 
-	entries := make([]*csi.ListVolumesResponse_Entry, 0)
-	maxPageEntries := int(req.MaxEntries)
-	encounteredStartingToken := req.StartingToken == ""
-	nextToken := ""
-
-	if maxPageEntries < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "max_entries value '%d' is not valid", maxPageEntries)
-	} else if maxPageEntries == 0 {
-		maxPageEntries = math.MaxInt16
+	names := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		names = append(names, volume.Config.Name)
+	}
+	start, end, nextToken, err := getListPage(names, req.StartingToken, req.MaxEntries)
+	if err != nil {
+		return nil, err
 	}
 
-	// NextToken might get set only when requested number of entries < number of volumes returned by the core,
-	// it also depends on the condition whether the starting token (if any) has been found or not.
-	for _, volume := range volumes {
-		if !encounteredStartingToken {
-			if volume.Config.Name == req.StartingToken {
-				encounteredStartingToken = true
-			} else {
-				continue
-			}
-		}
-
-		if len(entries) < maxPageEntries {
-			if csiVolume, err := p.getCSIVolumeFromTridentVolume(volume); err == nil {
-				entries = append(entries, &csi.ListVolumesResponse_Entry{Volume: csiVolume})
-			}
-		} else {
-			nextToken = volume.Config.Name
-			break
+	entries := make([]*csi.ListVolumesResponse_Entry, 0, end-start)
+	for _, volume := range volumes[start:end] {
+		if csiVolume, err := p.getCSIVolumeFromTridentVolume(volume); err == nil {
+			entries = append(entries, &csi.ListVolumesResponse_Entry{
+				Volume: csiVolume,
+				Status: &csi.ListVolumesResponse_VolumeStatus{PublishedNodeIds: volume.PublishedNodes},
+			})
 		}
 	}
 
@@ -533,17 +534,19 @@ func (p *Plugin) CreateSnapshot(
 	}
 
 	// Convert snapshot creation options into a Trident snapshot config
-	snapshotConfig, err := p.helper.GetSnapshotConfig(volumeName, snapshotName)
+	snapshotConfig, err := p.helper.GetSnapshotConfig(volumeName, snapshotName, req.GetParameters())
 	if err != nil {
-		p.helper.RecordVolumeEvent(req.Name, helpers.EventTypeNormal, "ProvisioningFailed", err.Error())
+		p.helper.RecordVolumeEvent(req.Name, frontend.EventTypeNormal, "ProvisioningFailed", err.Error())
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
 	// Create the snapshot
-	newSnapshot, err := p.orchestrator.CreateSnapshot(snapshotConfig)
+	newSnapshot, err := p.orchestrator.CreateSnapshot(ctx, snapshotConfig)
 	if err != nil {
 		if utils.IsNotFoundError(err) {
 			return nil, status.Error(codes.NotFound, err.Error())
+		} else if csiErr := getCSIErrorForDriverError(err); csiErr != nil {
+			return nil, csiErr
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -576,7 +579,7 @@ func (p *Plugin) DeleteSnapshot(
 	}
 
 	// Delete the snapshot
-	if err = p.orchestrator.DeleteSnapshot(volumeName, snapshotName); err != nil {
+	if err = p.orchestrator.DeleteSnapshot(ctx, volumeName, snapshotName); err != nil {
 
 		log.WithFields(log.Fields{
 			"volumeName":   volumeName,
@@ -659,36 +662,21 @@ func (p *Plugin) ListSnapshots(
 func (p *Plugin) getListSnapshots(
 	req *csi.ListSnapshotsRequest, snapshots []*storage.SnapshotExternal) (*csi.ListSnapshotsResponse, error) {
 
-	entries := make([]*csi.ListSnapshotsResponse_Entry, 0)
-	maxPageEntries := int(req.MaxEntries)
-	encounteredStartingToken := req.StartingToken == ""
-	nextToken := ""
-
-	if maxPageEntries < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "max_entries value '%d' is not valid", maxPageEntries)
-	} else if maxPageEntries == 0 {
-		maxPageEntries = math.MaxInt16
-	}
+	sort.Sort(storage.BySnapshotExternalID(snapshots))
 
-	// NextToken might get set only when requested number of entries < number of snapshots returned by the core,
-	// it also depends on the condition whether the starting token (if any) has been found or not.
+	snapshotIDs := make([]string, 0, len(snapshots))
 	for _, snapshot := range snapshots {
-		snapshotID := storage.MakeSnapshotID(snapshot.Config.VolumeName, snapshot.Config.Name)
-		if !encounteredStartingToken {
-			if snapshotID == req.StartingToken {
-				encounteredStartingToken = true
-			} else {
-				continue
-			}
-		}
+		snapshotIDs = append(snapshotIDs, storage.MakeSnapshotID(snapshot.Config.VolumeName, snapshot.Config.Name))
+	}
+	start, end, nextToken, err := getListPage(snapshotIDs, req.StartingToken, req.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
 
-		if len(entries) < maxPageEntries {
-			if csiSnapshot, err := p.getCSISnapshotFromTridentSnapshot(snapshot); err == nil {
-				entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: csiSnapshot})
-			}
-		} else {
-			nextToken = snapshotID
-			break
+	entries := make([]*csi.ListSnapshotsResponse_Entry, 0, end-start)
+	for _, snapshot := range snapshots[start:end] {
+		if csiSnapshot, err := p.getCSISnapshotFromTridentSnapshot(snapshot); err == nil {
+			entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: csiSnapshot})
 		}
 	}
 	return &csi.ListSnapshotsResponse{Entries: entries, NextToken: nextToken}, nil
@@ -733,7 +721,7 @@ func (p *Plugin) ControllerExpandVolume(
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
-	if err = p.orchestrator.ResizeVolume(volume.Config.Name, newSize); err != nil {
+	if err = p.orchestrator.ResizeVolume(ctx, volume.Config.Name, newSize); err != nil {
 		log.WithFields(log.Fields{
 			"volumeId":          volumeId,
 			"requestedCapacity": newSize,
@@ -751,7 +739,11 @@ func (p *Plugin) ControllerExpandVolume(
 		return nil, p.getCSIErrorForOrchestratorError(err)
 	}
 
+	// Let the backend say whether nodes must grow the volume too, assuming block devices need it if unknown
 	nodeExpansionRequired := resizedVolume.Config.Protocol == tridentconfig.Block
+	if backend, err := p.orchestrator.GetBackendByBackendUUID(resizedVolume.BackendUUID); err == nil {
+		nodeExpansionRequired = backend.VolumeExpansion.NodeExpansion
+	}
 	responseSize, err := strconv.ParseInt(resizedVolume.Config.Size, 10, 64)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -788,13 +780,30 @@ func (p *Plugin) getCSIVolumeFromTridentVolume(volume *storage.VolumeExternal) (
 		"protocol":     string(volume.Config.Protocol),
 	}
 
+	accessibleTopology := make([]*csi.Topology, 0, len(volume.Config.AllowedTopologies))
+	for _, segments := range volume.Config.AllowedTopologies {
+		accessibleTopology = append(accessibleTopology, &csi.Topology{Segments: segments})
+	}
+
 	return &csi.Volume{
-		CapacityBytes: capacity,
-		VolumeId:      volume.Config.Name,
-		VolumeContext: attributes,
+		CapacityBytes:      capacity,
+		VolumeId:           volume.Config.Name,
+		VolumeContext:      attributes,
+		AccessibleTopology: accessibleTopology,
 	}, nil
 }
 
+// getTopologySegments returns the segments of a list of CSI topologies.
+func getTopologySegments(topologies []*csi.Topology) []map[string]string {
+	segments := make([]map[string]string, 0, len(topologies))
+	for _, topology := range topologies {
+		if topology != nil && len(topology.Segments) > 0 {
+			segments = append(segments, topology.Segments)
+		}
+	}
+	return segments
+}
+
 func (p *Plugin) getCSISnapshotFromTridentSnapshot(snapshot *storage.SnapshotExternal) (*csi.Snapshot, error) {
 
 	createdSeconds, err := time.Parse(time.RFC3339, snapshot.Created)
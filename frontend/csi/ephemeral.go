@@ -0,0 +1,254 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// Kubernetes sets these in the volume context of CSI ephemeral volumes, alongside the attributes
+	// given in the pod spec
+	ephemeralContextKey    = "csi.storage.k8s.io/ephemeral"
+	podNamespaceContextKey = "csi.storage.k8s.io/pod.namespace"
+	podNameContextKey      = "csi.storage.k8s.io/pod.name"
+	podInfoContextPrefix   = "csi.storage.k8s.io/"
+
+	// EphemeralSizeAttribute is the size of an ephemeral volume, such as 500Mi
+	EphemeralSizeAttribute = "size"
+	// EphemeralStorageClassAttribute names the storage class from which an ephemeral volume is provisioned
+	EphemeralStorageClassAttribute = "storageClass"
+
+	// DefaultEphemeralSize is the size of an ephemeral volume whose pod doesn't give one
+	DefaultEphemeralSize = "1Gi"
+	// DefaultEphemeralStorageClass names the storage class an administrator creates to designate the
+	// backends or virtual pools that provision ephemeral volumes whose pods don't name a storage class
+	DefaultEphemeralStorageClass = "trident-ephemeral"
+
+	ephemeralTrackingFileSuffix = "-ephemeral.json"
+)
+
+// ephemeralTrackingInfo is recorded on a node for each ephemeral volume it provisions, so that the volume
+// is deleted once its pod is gone.
+type ephemeralTrackingInfo struct {
+	TargetPath   string `json:"targetPath"`
+	PodNamespace string `json:"podNamespace,omitempty"`
+	PodName      string `json:"podName,omitempty"`
+}
+
+// isEphemeralVolume reports whether a volume context is that of a CSI ephemeral volume.
+func isEphemeralVolume(volumeContext map[string]string) bool {
+	return volumeContext[ephemeralContextKey] == "true"
+}
+
+// getEphemeralVolumeConfig returns the config of the NFS volume to provision for a CSI ephemeral volume.
+func getEphemeralVolumeConfig(volumeID string, volumeContext map[string]string) (*storage.VolumeConfig, error) {
+
+	size := DefaultEphemeralSize
+	storageClass := DefaultEphemeralStorageClass
+	for key, value := range volumeContext {
+		switch key {
+		case EphemeralSizeAttribute:
+			size = value
+		case EphemeralStorageClassAttribute:
+			storageClass = value
+		default:
+			if !strings.HasPrefix(key, podInfoContextPrefix) {
+				return nil, fmt.Errorf("unknown ephemeral volume attribute %s, must be %s or %s", key,
+					EphemeralSizeAttribute, EphemeralStorageClassAttribute)
+			}
+		}
+	}
+
+	sizeBytes, err := utils.ConvertSizeToBytes(size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral volume size %s; %v", size, err)
+	}
+
+	return &storage.VolumeConfig{
+		Version:      tridentconfig.OrchestratorAPIVersion,
+		Name:         volumeID,
+		Size:         sizeBytes,
+		StorageClass: storageClass,
+		Protocol:     tridentconfig.File,
+		AccessMode:   tridentconfig.ReadWriteOnce,
+		VolumeMode:   tridentconfig.Filesystem,
+		Namespace:    volumeContext[podNamespaceContextKey],
+	}, nil
+}
+
+// nodePublishEphemeralVolume provisions an NFS volume for a CSI ephemeral volume, publishes it to this
+// node, and mounts it on the target path.  Each step is skipped if an earlier attempt completed it.
+func (p *Plugin) nodePublishEphemeralVolume(
+	ctx context.Context, req *csi.NodePublishVolumeRequest,
+) (*csi.NodePublishVolumeResponse, error) {
+
+	volumeID := req.GetVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
+	}
+	targetPath := req.GetTargetPath()
+	if targetPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "no target path provided")
+	}
+	if req.GetVolumeCapability().GetBlock() != nil {
+		return nil, status.Error(codes.InvalidArgument, "ephemeral volumes must be filesystems")
+	}
+
+	volumeContext := req.GetVolumeContext()
+	volConfig, err := getEphemeralVolumeConfig(volumeID, volumeContext)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	mounted, err := p.ensureNFSTargetPath(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	if mounted {
+		return &csi.NodePublishVolumeResponse{}, nil
+	}
+
+	// Record the volume before creating it, so it's deleted with its pod even if it's never mounted
+	trackingInfo := &ephemeralTrackingInfo{
+		TargetPath:   targetPath,
+		PodNamespace: volumeContext[podNamespaceContextKey],
+		PodName:      volumeContext[podNameContextKey],
+	}
+	if err = p.writeEphemeralTrackingFile(volumeID, trackingInfo); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	volume, err := p.restClient.GetVolume(volumeID)
+	if utils.IsNotFoundError(err) {
+		log.WithFields(log.Fields{
+			"volume":       volumeID,
+			"size":         volConfig.Size,
+			"storageClass": volConfig.StorageClass,
+			"pod":          trackingInfo.PodNamespace + "/" + trackingInfo.PodName,
+		}).Info("Creating ephemeral volume.")
+
+		if err = p.restClient.CreateVolume(volConfig); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		volume, err = p.restClient.GetVolume(volumeID)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	controllerPublishInfo, err := p.restClient.PublishVolume(volumeID, p.nodeName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	publishInfo := &utils.VolumePublishInfo{
+		Localhost:      true,
+		FilesystemType: "nfs",
+	}
+	publishInfo.MountOptions = controllerPublishInfo.MountOptions
+	if volume.Config.MountOptions != "" {
+		publishInfo.MountOptions = volume.Config.MountOptions
+	}
+	publishInfo.NfsServerIP = volume.Config.AccessInfo.NfsServerIP
+	publishInfo.NfsPath = volume.Config.AccessInfo.NfsPath
+	publishInfo.NfsServerIPs = controllerPublishInfo.NfsServerIPs
+
+	if err = p.mountNFSVolume(volume.Config.InternalName, targetPath, req.GetReadonly(), publishInfo); err != nil {
+		return nil, err
+	}
+
+	return &csi.NodePublishVolumeResponse{}, nil
+}
+
+// nodeUnpublishEphemeralVolume unmounts a CSI ephemeral volume, if it is mounted, and deletes it.
+func (p *Plugin) nodeUnpublishEphemeralVolume(
+	ctx context.Context, req *csi.NodeUnpublishVolumeRequest,
+) (*csi.NodeUnpublishVolumeResponse, error) {
+
+	volumeID := req.GetVolumeId()
+	targetPath := req.GetTargetPath()
+
+	notMnt, err := utils.IsLikelyNotMountPoint(targetPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, status.Errorf(codes.Internal, "unable to check if targetPath (%s) is mounted or not; %s",
+			targetPath, err)
+	}
+	if err == nil {
+		if !notMnt {
+			if err = utils.Umount(targetPath); err != nil {
+				log.WithFields(log.Fields{"path": targetPath, "error": err}).Error("unable to unmount volume.")
+				return nil, status.Errorf(codes.Internal, "unable to unmount volume; %s", err)
+			}
+		}
+		if err = utils.DeleteResourceAtPath(targetPath); err != nil {
+			log.Debugf("Unable to delete resource at target path: %s; %s", targetPath, err)
+		}
+	}
+
+	log.WithField("volume", volumeID).Info("Deleting ephemeral volume.")
+	if err = p.restClient.DeleteVolume(volumeID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err = p.clearEphemeralTrackingFile(volumeID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if p.volumeIOStats != nil {
+		p.volumeIOStats.forget(volumeID)
+	}
+
+	return &csi.NodeUnpublishVolumeResponse{}, nil
+}
+
+// writeEphemeralTrackingFile records that an ephemeral volume was provisioned by this node.
+func (p *Plugin) writeEphemeralTrackingFile(volumeID string, trackingInfo *ephemeralTrackingInfo) error {
+
+	trackingInfoBytes, err := json.Marshal(trackingInfo)
+	if err != nil {
+		return err
+	}
+
+	trackingFilename := path.Join(tridentDeviceInfoPath, volumeID+ephemeralTrackingFileSuffix)
+	if err = ioutil.WriteFile(trackingFilename, trackingInfoBytes, 0600); err != nil {
+		log.WithFields(log.Fields{
+			"volumeId": volumeID,
+			"error":    err.Error(),
+		}).Error("Unable to write ephemeral volume tracking file.")
+		return err
+	}
+	return nil
+}
+
+// hasEphemeralTrackingFile reports whether a volume is an ephemeral volume provisioned by this node.
+func (p *Plugin) hasEphemeralTrackingFile(volumeID string) bool {
+	_, err := os.Stat(path.Join(tridentDeviceInfoPath, volumeID+ephemeralTrackingFileSuffix))
+	return err == nil
+}
+
+func (p *Plugin) clearEphemeralTrackingFile(volumeID string) error {
+
+	trackingFilename := path.Join(tridentDeviceInfoPath, volumeID+ephemeralTrackingFileSuffix)
+	if err := os.Remove(trackingFilename); err != nil && !os.IsNotExist(err) {
+		log.WithFields(log.Fields{
+			"trackingFilename": trackingFilename,
+			"error":            err,
+		}).Error("Removing ephemeral volume tracking file failed.")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tridentconfig "github.com/netapp/trident/config"
+)
+
+func TestIsEphemeralVolume(t *testing.T) {
+
+	assert.True(t, isEphemeralVolume(map[string]string{ephemeralContextKey: "true"}))
+	assert.False(t, isEphemeralVolume(map[string]string{ephemeralContextKey: "false"}))
+	assert.False(t, isEphemeralVolume(map[string]string{"internalName": "trident_pvc_1"}))
+}
+
+func TestGetEphemeralVolumeConfig(t *testing.T) {
+
+	// Defaults apply to attributes the pod doesn't give
+	volConfig, err := getEphemeralVolumeConfig("csi-1234", map[string]string{
+		ephemeralContextKey:    "true",
+		podNamespaceContextKey: "team-a",
+		podNameContextKey:      "scratch",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "csi-1234", volConfig.Name)
+		assert.Equal(t, "1073741824", volConfig.Size)
+		assert.Equal(t, DefaultEphemeralStorageClass, volConfig.StorageClass)
+		assert.Equal(t, tridentconfig.File, volConfig.Protocol)
+		assert.Equal(t, tridentconfig.ReadWriteOnce, volConfig.AccessMode)
+		assert.Equal(t, "team-a", volConfig.Namespace)
+	}
+
+	volConfig, err = getEphemeralVolumeConfig("csi-1234", map[string]string{
+		EphemeralSizeAttribute:         "500Mi",
+		EphemeralStorageClassAttribute: "scratch",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "524288000", volConfig.Size)
+		assert.Equal(t, "scratch", volConfig.StorageClass)
+	}
+
+	_, err = getEphemeralVolumeConfig("csi-1234", map[string]string{EphemeralSizeAttribute: "lots"})
+	assert.Error(t, err)
+	_, err = getEphemeralVolumeConfig("csi-1234", map[string]string{"sise": "1Gi"})
+	assert.Error(t, err)
+}
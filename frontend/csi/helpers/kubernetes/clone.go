@@ -0,0 +1,115 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/netapp/trident/frontend/csi"
+	"github.com/netapp/trident/storage"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the code that clones snapshots to new PVCs.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+// CloneSnapshot creates the PVC in a request, annotated so that its volume is provisioned as a clone
+// of a snapshot of the given volume, and waits for the PV to be bound to it.
+func (p *Plugin) CloneSnapshot(request *storage.CloneSnapshotRequest) (*storage.VolumeExternal, error) {
+	log.WithField("request", request).Debug("CloneSnapshot")
+
+	// Get PVC from CloneSnapshotRequest
+	jsonData, err := base64.StdEncoding.DecodeString(request.PVCData)
+	if err != nil {
+		return nil, fmt.Errorf("the pvcData field does not contain valid base64-encoded data: %v", err)
+	}
+
+	claim := &v1.PersistentVolumeClaim{}
+	if err = json.Unmarshal(jsonData, &claim); err != nil {
+		return nil, fmt.Errorf("could not parse JSON PVC: %v", err)
+	}
+	if len(claim.Namespace) == 0 {
+		return nil, fmt.Errorf("a valid PVC namespace is required to clone a snapshot")
+	}
+
+	// The source volume must belong to a PVC, which the clone is annotated to copy
+	sourceVolume, err := p.orchestrator.GetVolume(request.Volume)
+	if err != nil {
+		return nil, fmt.Errorf("could not find volume %s; %v", request.Volume, err)
+	}
+	if _, err = p.orchestrator.GetSnapshot(request.Volume, request.Snapshot); err != nil {
+		return nil, fmt.Errorf("could not find snapshot %s of volume %s; %v", request.Snapshot, request.Volume, err)
+	}
+	if sourceVolume.Config.RequestName == "" {
+		return nil, fmt.Errorf("volume %s does not belong to a PVC", request.Volume)
+	}
+
+	// Cloning from a PVC requires both PVCs have the same storage class
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+		claim.Spec.StorageClassName = &sourceVolume.Config.StorageClass
+	} else if *claim.Spec.StorageClassName != sourceVolume.Config.StorageClass {
+		return nil, fmt.Errorf("the PVC must have the storage class of volume %s, %s", request.Volume,
+			sourceVolume.Config.StorageClass)
+	}
+
+	// Set the PVC's storage field to the size of the source volume unless a size was requested
+	if claim.Spec.Resources.Requests == nil {
+		claim.Spec.Resources.Requests = v1.ResourceList{}
+	}
+	if _, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]; !ok {
+		size, err := resource.ParseQuantity(sourceVolume.Config.Size)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the size of volume %s; %v", request.Volume, err)
+		}
+		claim.Spec.Resources.Requests[v1.ResourceStorage] = size
+	}
+
+	// Set required annotations
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+	claim.Annotations[AnnCloneFromPVC] = sourceVolume.Config.RequestName
+	claim.Annotations[AnnCloneFromSnapshot] = request.Snapshot
+	if sourceVolume.Config.Namespace != claim.Namespace {
+		claim.Annotations[AnnCloneFromNamespace] = sourceVolume.Config.Namespace
+	}
+	claim.Annotations[AnnStorageProvisioner] = csi.Provisioner
+
+	log.WithFields(log.Fields{
+		"PVC":              claim.Name,
+		"PVC_namespace":    claim.Namespace,
+		"PVC_storageClass": getStorageClassForPVC(claim),
+		"PVC_annotations":  claim.Annotations,
+	}).Debug("CloneSnapshot: creating PVC.")
+
+	pvc, err := p.createImportPVC(claim)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"claim": claim.Name,
+			"error": err,
+		}).Warn("CloneSnapshot: error occurred during PVC creation.")
+		return nil, err
+	}
+	log.WithField("PVC", pvc).Debug("CloneSnapshot: created pending PVC.")
+
+	pvName := fmt.Sprintf("pvc-%s", pvc.GetUID())
+
+	// Wait for the clone to be provisioned and its PV to be created by the sidecar
+	if _, err = p.waitForCachedPVByName(pvName, ImportPVCacheWaitPeriod); err != nil {
+		return nil, fmt.Errorf("error waiting for PV %s; %v", pvName, err)
+	}
+
+	volume, err := p.orchestrator.GetVolume(pvName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting volume %s; %v", pvName, err)
+	}
+	return volume, nil
+}
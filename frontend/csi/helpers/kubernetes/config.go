@@ -30,6 +30,12 @@ const (
 	// Kubernetes-defined storage class parameters
 	K8sFsType = "fsType"
 
+	// Prefix of the parameters added by the CSI sidecars, such as the snapshotter's snapshot name and namespace
+	csiParameterPrefix = "csi.storage.k8s.io/"
+
+	// API group of the volume snapshots that CSI provides as a volume's content source
+	snapshotAPIGroup = "snapshot.storage.k8s.io"
+
 	// Kubernetes-defined annotations
 	// (Based on kubernetes/pkg/controller/volume/persistentvolume/controller.go)
 	AnnClass                  = "volume.beta.kubernetes.io/storage-class"
@@ -55,6 +61,10 @@ const (
 	AnnNotManaged         = annPrefix + "/notManaged"
 	AnnImportOriginalName = annPrefix + "/importOriginalName"
 	AnnImportBackendUUID  = annPrefix + "/importBackendUUID"
+	AnnMountOptions       = annPrefix + "/mountOptions"
+	AnnCloneFromNamespace = annPrefix + "/cloneFromNamespace"
+	AnnCloneToNamespaces  = annPrefix + "/cloneToNamespaces"
+	AnnCloneFromSnapshot  = annPrefix + "/cloneFromSnapshot"
 )
 
 var features = map[helpers.Feature]*utils.Version{
@@ -49,7 +49,7 @@ func (p *Plugin) updateLegacyPV(oldObj, newObj interface{}) {
 	}
 
 	// Delete the volume on the backend
-	if err := p.orchestrator.DeleteVolume(pv.Name); err != nil && !utils.IsNotFoundError(err) {
+	if err := p.orchestrator.DeleteVolume(ctx(), pv.Name); err != nil && !utils.IsNotFoundError(err) {
 		// Updating the PV's phase to "VolumeFailed", so that a storage admin can take action.
 		message := fmt.Sprintf("failed to delete the volume for PV %s: %s. Will eventually retry, "+
 			"but the volume and PV may need to be manually deleted.", pv.Name, err.Error())
@@ -12,9 +12,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/frontend"
 	frontendcommon "github.com/netapp/trident/frontend/common"
 	"github.com/netapp/trident/frontend/csi"
-	"github.com/netapp/trident/frontend/csi/helpers"
 	"github.com/netapp/trident/storage"
 )
 
@@ -84,17 +84,52 @@ func (p *Plugin) GetVolumeConfig(
 	// Create the volume config
 	volumeConfig := getVolumeConfig(pvc.Spec.AccessModes, pvc.Spec.VolumeMode, pvName, pvcSize,
 		processPVCAnnotations(pvc, fsType), sc)
+	volumeConfig.Namespace = pvc.Namespace
+	volumeConfig.RequestName = pvc.Name
+	volumeConfig.UUID = string(pvc.UID)
 
 	// Check if we're cloning a PVC, and if so, do some further validation
-	if cloneSourcePVName, err := p.getCloneSourceInfo(pvc); err != nil {
+	if cloneSourcePVName, cloneSourceNamespace, err := p.getCloneSourceInfo(pvc); err != nil {
 		return nil, err
 	} else if cloneSourcePVName != "" {
 		volumeConfig.CloneSourceVolume = cloneSourcePVName
+		volumeConfig.CloneSourceSnapshot = getAnnotation(pvc.Annotations, AnnCloneFromSnapshot)
+		volumeConfig.CloneSourceNamespace = cloneSourceNamespace
 	}
 
+	// Check if the volume is to be populated from a data source of which CSI is unaware
+	volumeConfig.DataSource = getPopulatorDataSource(pvc)
+
 	return volumeConfig, nil
 }
 
+// getPopulatorDataSource returns the data source of a PVC if it is to be written to the new volume by a
+// volume populator, or nil if the PVC has no data source or CSI provides it as the volume's content source.
+func getPopulatorDataSource(pvc *v1.PersistentVolumeClaim) *storage.VolumeDataSource {
+
+	dataSource := pvc.Spec.DataSource
+	if dataSource == nil {
+		return nil
+	}
+
+	apiGroup := ""
+	if dataSource.APIGroup != nil {
+		apiGroup = *dataSource.APIGroup
+	}
+	switch {
+	case apiGroup == "" && dataSource.Kind == "PersistentVolumeClaim":
+		return nil
+	case apiGroup == snapshotAPIGroup && dataSource.Kind == "VolumeSnapshot":
+		return nil
+	}
+
+	return &storage.VolumeDataSource{
+		APIGroup: apiGroup,
+		Kind:     dataSource.Kind,
+		Name:     dataSource.Name,
+	}
+}
+
 // getPVCForCSIVolume accepts the name of a volume being requested by the CSI provisioner,
 // extracts the PVC name from the volume name, and returns the PVC object as read from the
 // Kubernetes API server.  The method waits for the object to appear in cache, resyncs the
@@ -175,27 +210,33 @@ func (p *Plugin) getStorageClass(name string) (*k8sstoragev1.StorageClass, error
 // getCloneSourceInfo accepts the PVC of a volume being provisioned by CSI and inspects it
 // for the annotations indicating a clone operation (of which CSI is unaware). If a clone is
 // being created, the method completes several checks on the source PVC/PV and returns the
-// name of the source PV as needed by Trident to clone a volume as well as an optional
-// snapshot name (also potentially unknown to CSI).  Note that these legacy clone annotations
-// will be overridden if the VolumeContentSource is set in the CSI CreateVolume request.
-func (p *Plugin) getCloneSourceInfo(clonePVC *v1.PersistentVolumeClaim) (string, error) {
+// name of the source PV as needed by Trident to clone a volume, along with the namespace of
+// the source PVC.  Note that these legacy clone annotations will be overridden if the
+// VolumeContentSource is set in the CSI CreateVolume request.
+func (p *Plugin) getCloneSourceInfo(clonePVC *v1.PersistentVolumeClaim) (string, string, error) {
 
 	// Check if this is a clone operation
 	annotations := processPVCAnnotations(clonePVC, "")
 	sourcePVCName := getAnnotation(annotations, AnnCloneFromPVC)
 	if sourcePVCName == "" {
-		return "", nil
+		return "", "", nil
 	}
 
-	// Check that the source PVC is in the same namespace.
-	// NOTE: For VolumeContentSource this check is performed by CSI
-	sourcePVC, err := p.waitForCachedPVCByName(sourcePVCName, clonePVC.Namespace, PreSyncCacheWaitPeriod)
+	// Find the source PVC, which is in the same namespace unless another is named.  The orchestrator checks
+	// that the source's namespace permits the clone.
+	// NOTE: For VolumeContentSource CSI requires the source be in the same namespace
+	sourceNamespace := getAnnotation(annotations, AnnCloneFromNamespace)
+	if sourceNamespace == "" {
+		sourceNamespace = clonePVC.Namespace
+	}
+	sourcePVC, err := p.waitForCachedPVCByName(sourcePVCName, sourceNamespace, PreSyncCacheWaitPeriod)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"sourcePVCName": sourcePVCName,
-			"namespace":     clonePVC.Namespace,
+			"namespace":     sourceNamespace,
 		}).Errorf("Clone source PVC not found in local cache: %v", err)
-		return "", fmt.Errorf("cloning from a PVC requires both PVCs be in the same namespace: %v", err)
+		return "", "", fmt.Errorf("clone source PVC %s not found in namespace %s: %v", sourcePVCName,
+			sourceNamespace, err)
 	}
 
 	// Check that both source and clone PVCs have the same storage class
@@ -209,7 +250,7 @@ func (p *Plugin) getCloneSourceInfo(clonePVC *v1.PersistentVolumeClaim) (string,
 			"sourcePVCNamespace":    sourcePVC.Namespace,
 			"sourcePVCStorageClass": getStorageClassForPVC(sourcePVC),
 		}).Error("Cloning from a PVC requires both PVCs have the same storage class.")
-		return "", fmt.Errorf("cloning from a PVC requires both PVCs have the same storage class")
+		return "", "", fmt.Errorf("cloning from a PVC requires both PVCs have the same storage class")
 	}
 
 	// Check that the source PVC has an associated PV
@@ -219,24 +260,46 @@ func (p *Plugin) getCloneSourceInfo(clonePVC *v1.PersistentVolumeClaim) (string,
 			"sourcePVCName":      sourcePVC.Name,
 			"sourcePVCNamespace": sourcePVC.Namespace,
 		}).Error("Cloning from a PVC requires the source to be bound to a PV.")
-		return "", fmt.Errorf("cloning from a PVC requires the source to be bound to a PV")
+		return "", "", fmt.Errorf("cloning from a PVC requires the source to be bound to a PV")
 	}
 
-	return sourcePVName, nil
+	return sourcePVName, sourcePVC.Namespace, nil
 }
 
 // GetSnapshotConfig accepts the attributes of a snapshot being requested by the CSI
 // provisioner and returns a SnapshotConfig structure as needed by Trident to create a new snapshot.
-func (p *Plugin) GetSnapshotConfig(volumeName, snapshotName string) (*storage.SnapshotConfig, error) {
+func (p *Plugin) GetSnapshotConfig(
+	volumeName, snapshotName string, parameters map[string]string,
+) (*storage.SnapshotConfig, error) {
 	return &storage.SnapshotConfig{
 		Version:    config.OrchestratorAPIVersion,
 		Name:       snapshotName,
 		VolumeName: volumeName,
+		Parameters: getSnapshotParameters(parameters),
 	}, nil
 }
 
+// getSnapshotParameters returns the parameters of a VolumeSnapshotClass meant for the storage driver,
+// leaving out those the CSI snapshotter adds to describe the snapshot.
+func getSnapshotParameters(parameters map[string]string) map[string]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	snapshotParameters := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		if !strings.HasPrefix(key, csiParameterPrefix) {
+			snapshotParameters[key] = value
+		}
+	}
+	if len(snapshotParameters) == 0 {
+		return nil
+	}
+	return snapshotParameters
+}
+
 // RecordVolumeEvent accepts the name of a CSI volume (i.e. a PV name), finds the associated
-// PVC, and posts and event message on the PVC object with the K8S API server.
+// PVC, and posts and event message on the PVC object with the K8S API server.  The event is
+// also posted on the PV, once the PV exists.
 func (p *Plugin) RecordVolumeEvent(name, eventType, reason, message string) {
 
 	log.WithFields(log.Fields{
@@ -251,6 +314,10 @@ func (p *Plugin) RecordVolumeEvent(name, eventType, reason, message string) {
 	} else {
 		p.eventRecorder.Event(pvc, mapEventType(eventType), reason, message)
 	}
+
+	if pv, err := p.getCachedPVByName(name); err == nil {
+		p.eventRecorder.Event(pv, mapEventType(eventType), reason, message)
+	}
 }
 
 // mapEventType maps between K8S API event types and Trident CSI helper event types.  The
@@ -258,9 +325,9 @@ func (p *Plugin) RecordVolumeEvent(name, eventType, reason, message string) {
 // coupled to Kubernetes.
 func mapEventType(eventType string) string {
 	switch eventType {
-	case helpers.EventTypeNormal:
+	case frontend.EventTypeNormal:
 		return v1.EventTypeNormal
-	case helpers.EventTypeWarning:
+	case frontend.EventTypeWarning:
 		return v1.EventTypeWarning
 	default:
 		return v1.EventTypeWarning
@@ -299,6 +366,12 @@ func getVolumeConfig(
 		log.Warnf("unable to parse notManaged annotation into bool; %v", err)
 	}
 
+	// Mount options requested for the PVC win over those of its storage class
+	mountOptions := getAnnotation(annotations, AnnMountOptions)
+	if mountOptions == "" {
+		mountOptions = strings.Join(storageClass.MountOptions, ",")
+	}
+
 	return &storage.VolumeConfig{
 		Name:               name,
 		Size:               fmt.Sprintf("%d", size.Value()),
@@ -317,7 +390,8 @@ func getVolumeConfig(
 		ImportOriginalName: getAnnotation(annotations, AnnImportOriginalName),
 		ImportBackendUUID:  getAnnotation(annotations, AnnImportBackendUUID),
 		ImportNotManaged:   notManaged,
-		MountOptions:       strings.Join(storageClass.MountOptions, ","),
+		MountOptions:       mountOptions,
+		CloneToNamespaces:  storage.ParseCloneToNamespaces(getAnnotation(annotations, AnnCloneToNamespaces)),
 	}
 }
 
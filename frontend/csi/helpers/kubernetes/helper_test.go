@@ -0,0 +1,55 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestGetSnapshotConfig(t *testing.T) {
+
+	plugin := &Plugin{}
+
+	snapConfig, err := plugin.GetSnapshotConfig("pvc-1", "snapshot-1", map[string]string{
+		"snapmirrorLabel":                               "weekly",
+		"csi.storage.k8s.io/volumesnapshot/name":        "snap",
+		"csi.storage.k8s.io/volumesnapshot/namespace":   "default",
+		"csi.storage.k8s.io/volumesnapshotcontent/name": "snapcontent-1",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "pvc-1", snapConfig.VolumeName)
+		assert.Equal(t, "snapshot-1", snapConfig.Name)
+		assert.Equal(t, map[string]string{"snapmirrorLabel": "weekly"}, snapConfig.Parameters)
+	}
+
+	snapConfig, err = plugin.GetSnapshotConfig("pvc-1", "snapshot-2", nil)
+	if assert.NoError(t, err) {
+		assert.Nil(t, snapConfig.Parameters)
+	}
+}
+
+func TestGetPopulatorDataSource(t *testing.T) {
+
+	snapshotGroup := snapshotAPIGroup
+	imageGroup := "example.com"
+
+	pvc := &v1.PersistentVolumeClaim{}
+	assert.Nil(t, getPopulatorDataSource(pvc))
+
+	// Clones and snapshot restores are left to CSI
+	pvc.Spec.DataSource = &v1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "source"}
+	assert.Nil(t, getPopulatorDataSource(pvc))
+	pvc.Spec.DataSource = &v1.TypedLocalObjectReference{APIGroup: &snapshotGroup, Kind: "VolumeSnapshot",
+		Name: "snap"}
+	assert.Nil(t, getPopulatorDataSource(pvc))
+
+	pvc.Spec.DataSource = &v1.TypedLocalObjectReference{APIGroup: &imageGroup, Kind: "VolumeImage",
+		Name: "image"}
+	assert.Equal(t, &storage.VolumeDataSource{APIGroup: "example.com", Kind: "VolumeImage", Name: "image"},
+		getPopulatorDataSource(pvc))
+}
@@ -198,6 +198,13 @@ func newKubernetesPlugin(orchestrator core.Orchestrator, kubeConfig *rest.Config
 		},
 	)
 
+	// Add handler for applying changed annotations to volumes
+	p.pvcController.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: p.updatePVCAnnotations,
+		},
+	)
+
 	if !p.SupportsFeature(csi.ExpandCSIVolumes) {
 		p.pvcController.AddEventHandlerWithResyncPeriod(
 			cache.ResourceEventHandlerFuncs{
@@ -345,6 +352,7 @@ func (p *Plugin) Activate() error {
 	// Configure telemetry
 	config.OrchestratorTelemetry.Platform = string(config.PlatformKubernetes)
 	config.OrchestratorTelemetry.PlatformVersion = p.Version()
+	config.OrchestratorTelemetry.PlatformUID = p.getClusterUID()
 
 	if err := p.handleFailedPVUpgrades(); err != nil {
 		return fmt.Errorf("error cleaning up previously failed PV upgrades; %v", err)
@@ -373,6 +381,17 @@ func (p *Plugin) Version() string {
 	return p.kubeVersion.GitVersion
 }
 
+// getClusterUID returns a stable identifier for the Kubernetes cluster, which is the UID of the
+// kube-system namespace.  An empty string is returned if the namespace cannot be read.
+func (p *Plugin) getClusterUID() string {
+	namespace, err := p.kubeClient.CoreV1().Namespaces().Get(ctx(), metav1.NamespaceSystem, getOpts)
+	if err != nil {
+		log.WithField("err", err).Warning("Could not determine Kubernetes cluster UID.")
+		return ""
+	}
+	return string(namespace.UID)
+}
+
 // listClusterNodes returns the list of worker node names as a map for kubernetes cluster
 func (p *Plugin) listClusterNodes() (map[string]bool, error) {
 	nodeNames := make(map[string]bool)
@@ -168,7 +168,7 @@ func (p *Plugin) resizeVolumeAndPV(pv *v1.PersistentVolume, newSize resource.Qua
 	pvSize := pv.Spec.Capacity[v1.ResourceStorage]
 	if pvSize.Cmp(newSize) < 0 {
 		// Calling the orchestrator to resize the volume on the storage backend.
-		if err := p.orchestrator.ResizeVolume(pv.Name, fmt.Sprintf("%d", newSize.Value())); err != nil {
+		if err := p.orchestrator.ResizeVolume(ctx(), pv.Name, fmt.Sprintf("%d", newSize.Value())); err != nil {
 			return err
 		}
 	} else if pvSize.Cmp(newSize) == 0 {
@@ -0,0 +1,95 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package kubernetes
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/netapp/trident/frontend/csi"
+	"github.com/netapp/trident/storage"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the event handlers that apply changed annotations to
+// the volumes of existing CSI Trident PVCs.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+// updatePVCAnnotations is the update handler for the PVC watcher whose job is to
+// detect changed snapshot directory, UNIX permissions, export policy, snapshot
+// policy, or clone namespace annotations on a bound PVC and apply them to the
+// underlying volume.
+func (p *Plugin) updatePVCAnnotations(oldObj, newObj interface{}) {
+
+	// Ensure we got PVC objects
+	oldPVC, ok := oldObj.(*v1.PersistentVolumeClaim)
+	if !ok {
+		log.Errorf("K8S helper expected PVC; got %v", oldObj)
+		return
+	}
+	newPVC, ok := newObj.(*v1.PersistentVolumeClaim)
+	if !ok {
+		log.Errorf("K8S helper expected PVC; got %v", newObj)
+		return
+	}
+
+	// Verify there is work to be done.  Removing an annotation leaves the volume as it is.
+	changed := func(annotation string) string {
+		newValue := getAnnotation(newPVC.Annotations, annotation)
+		if newValue == getAnnotation(oldPVC.Annotations, annotation) {
+			return ""
+		}
+		return newValue
+	}
+	updateRequest := &storage.UpdateVolumeRequest{
+		SnapshotDirectory: changed(AnnSnapshotDir),
+		UnixPermissions:   changed(AnnUnixPermissions),
+		ExportPolicy:      changed(AnnExportPolicy),
+		SnapshotPolicy:    changed(AnnSnapshotPolicy),
+	}
+
+	// Removing the namespaces permitted to clone the volume revokes their permission
+	if cloneToNamespaces := getAnnotation(newPVC.Annotations, AnnCloneToNamespaces); cloneToNamespaces !=
+		getAnnotation(oldPVC.Annotations, AnnCloneToNamespaces) {
+		updateRequest.CloneToNamespaces = &cloneToNamespaces
+	}
+	if *updateRequest == (storage.UpdateVolumeRequest{}) {
+		return
+	}
+
+	// Verify the PVC is Bound
+	if newPVC.Status.Phase != v1.ClaimBound {
+		return
+	}
+
+	// Verify the PVC is managed by Trident (include legacy volumes)
+	pvcProvisioner := getPVCProvisioner(newPVC)
+	if pvcProvisioner != csi.Provisioner && pvcProvisioner != csi.LegacyProvisioner {
+		return
+	}
+
+	logFields := log.Fields{
+		"PVC":               newPVC.Name,
+		"PV":                newPVC.Spec.VolumeName,
+		"snapshotDirectory": updateRequest.SnapshotDirectory,
+		"unixPermissions":   updateRequest.UnixPermissions,
+		"exportPolicy":      updateRequest.ExportPolicy,
+		"snapshotPolicy":    updateRequest.SnapshotPolicy,
+		"cloneToNamespaces": getAnnotation(newPVC.Annotations, AnnCloneToNamespaces),
+	}
+	log.WithFields(logFields).Debug("K8S helper detected changed volume annotations.")
+
+	if _, err := p.orchestrator.UpdateVolume(ctx(), newPVC.Spec.VolumeName, updateRequest); err != nil {
+		message := fmt.Sprintf("failed to update the volume: %v", err)
+		p.eventRecorder.Event(newPVC, v1.EventTypeWarning, "UpdateFailed", message)
+		log.WithFields(logFields).Errorf("K8S helper %v", message)
+		return
+	}
+
+	p.eventRecorder.Event(newPVC, v1.EventTypeNormal, "UpdateSuccess",
+		"applied changed annotations to the volume.")
+}
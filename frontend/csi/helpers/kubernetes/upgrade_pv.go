@@ -194,7 +194,7 @@ func (p *Plugin) UpgradeVolume(request *storage.UpgradeVolumeRequest) (*storage.
 		PVUpgradeConfig: upgradeConfig,
 		Op:              storage.UpgradeVolume,
 	}
-	txnErr := p.orchestrator.AddVolumeTransaction(volTxn)
+	txnErr := p.orchestrator.AddVolumeTransaction(ctx(), volTxn)
 	if utils.IsFoundError(txnErr) {
 		oldTxn, getErr := p.orchestrator.GetVolumeTransaction(volTxn)
 		if getErr != nil {
@@ -204,7 +204,7 @@ func (p *Plugin) UpgradeVolume(request *storage.UpgradeVolumeRequest) (*storage.
 			if cleanupErr := p.rollBackPVUpgrade(oldTxn); cleanupErr != nil {
 				return nil, fmt.Errorf("PV upgrade: error rolling back previous upgrade attempt; %v", cleanupErr)
 			}
-			txnErr = p.orchestrator.AddVolumeTransaction(volTxn)
+			txnErr = p.orchestrator.AddVolumeTransaction(ctx(), volTxn)
 		}
 	}
 	if txnErr != nil {
@@ -85,11 +85,14 @@ func (p *Plugin) GetVolumeConfig(
 
 // GetSnapshotConfig accepts the attributes of a snapshot being requested by the CSI
 // provisioner and returns a SnapshotConfig structure as needed by Trident to create a new snapshot.
-func (p *Plugin) GetSnapshotConfig(volumeName, snapshotName string) (*storage.SnapshotConfig, error) {
+func (p *Plugin) GetSnapshotConfig(
+	volumeName, snapshotName string, parameters map[string]string,
+) (*storage.SnapshotConfig, error) {
 	return &storage.SnapshotConfig{
 		Version:    config.OrchestratorAPIVersion,
 		Name:       snapshotName,
 		VolumeName: volumeName,
+		Parameters: parameters,
 	}, nil
 }
 
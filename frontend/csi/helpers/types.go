@@ -10,9 +10,6 @@ import (
 const (
 	KubernetesHelper = "k8s_csi_helper"
 	PlainCSIHelper   = "plain_csi_helper"
-
-	EventTypeNormal  = "Normal"
-	EventTypeWarning = "Warning"
 )
 
 type Feature string
@@ -31,9 +28,12 @@ type HybridPlugin interface {
 	) (*storage.VolumeConfig, error)
 
 	// GetSnapshotConfig accepts the attributes of a snapshot being requested byt the CSI
-	// provisioner, adds in any CO-specific details about the new volume, and returns
-	// a SnapshotConfig structure as needed by Trident to create a new snapshot.
-	GetSnapshotConfig(volumeName, snapshotName string) (*storage.SnapshotConfig, error)
+	// provisioner, including the parameters of its snapshot class, adds in any CO-specific
+	// details about the new volume, and returns a SnapshotConfig structure as needed by
+	// Trident to create a new snapshot.
+	GetSnapshotConfig(
+		volumeName, snapshotName string, parameters map[string]string,
+	) (*storage.SnapshotConfig, error)
 
 	// RecordVolumeEvent accepts the name of a CSI volume and writes the specified
 	// event message in a manner appropriate to the container orchestrator.
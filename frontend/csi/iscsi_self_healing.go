@@ -0,0 +1,233 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/frontend"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	iSCSISelfHealingLockContext = "ISCSISelfHealing"
+
+	eventReasonISCSISessionsLost     = "ISCSISessionsLost"
+	eventReasonISCSISessionsRestored = "ISCSISessionsRestored"
+)
+
+// stagedISCSIVolume is an iSCSI volume staged on this node, as recorded in its tracking file.
+type stagedISCSIVolume struct {
+	volumeID          string
+	stagingTargetPath string
+	publishInfo       *utils.VolumePublishInfo
+	stagedTime        time.Time
+}
+
+// startISCSISelfHealing starts the service that periodically restores the iSCSI sessions of the
+// volumes staged on this node.
+func (p *Plugin) startISCSISelfHealing() {
+
+	if p.iSCSISelfHealingInterval <= 0 {
+		log.Info("iSCSI self-healing is disabled.")
+		return
+	}
+
+	log.WithField("interval", p.iSCSISelfHealingInterval).Info("Starting iSCSI self-healing.")
+
+	stop := make(chan struct{})
+	p.iSCSISelfHealingStop = stop
+
+	go func() {
+		ticker := time.NewTicker(p.iSCSISelfHealingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.healISCSISessions()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopISCSISelfHealing stops the iSCSI self-healing service, if it is running.
+func (p *Plugin) stopISCSISelfHealing() {
+	if p.iSCSISelfHealingStop != nil {
+		close(p.iSCSISelfHealingStop)
+		p.iSCSISelfHealingStop = nil
+	}
+}
+
+// healISCSISessions restores the sessions to each iSCSI target used by a staged volume.  Each
+// target is healed while holding the node lock, so that its volumes can't be staged or unstaged
+// meanwhile, and a target whose last volume was unstaged isn't logged in to again.
+func (p *Plugin) healISCSISessions() {
+
+	log.Debug(">>>> healISCSISessions")
+	defer log.Debug("<<<< healISCSISessions")
+
+	targets := make([]string, 0)
+	for target := range p.stagedISCSIVolumes() {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	staged := make(map[string]bool)
+	for _, target := range targets {
+		for _, volumeID := range p.healISCSITarget(target) {
+			staged[volumeID] = true
+		}
+	}
+
+	// Forget the sessions lost by volumes that are no longer staged
+	for volumeID := range p.iSCSISessionsLost {
+		if !staged[volumeID] {
+			delete(p.iSCSISessionsLost, volumeID)
+		}
+	}
+}
+
+// healISCSITarget restores the sessions to an iSCSI target and reports the outcome for each of the
+// volumes staged from it, whose IDs are returned.
+func (p *Plugin) healISCSITarget(targetIQN string) []string {
+
+	utils.Lock(iSCSISelfHealingLockContext, lockID)
+	defer utils.Unlock(iSCSISelfHealingLockContext, lockID)
+
+	volumes := p.stagedISCSIVolumes()[targetIQN]
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	err := utils.HealISCSISessions(mergeStagedISCSIPublishInfo(volumes))
+	if err != nil {
+		log.WithFields(log.Fields{"targetIQN": targetIQN, "error": err}).Warning(
+			"Could not restore iSCSI sessions.")
+	}
+
+	volumeIDs := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		p.reportISCSISessionHealth(volume.volumeID, err)
+		volumeIDs = append(volumeIDs, volume.volumeID)
+	}
+	return volumeIDs
+}
+
+// reportISCSISessionHealth records an event when a volume's iSCSI sessions can't be restored, and
+// again once they have been.  An event that can't be sent to the controller is retried on the next
+// pass.
+func (p *Plugin) reportISCSISessionHealth(volumeID string, healErr error) {
+
+	var event *utils.VolumeEvent
+	if healErr != nil && !p.iSCSISessionsLost[volumeID] {
+		event = &utils.VolumeEvent{
+			Type:    frontend.EventTypeWarning,
+			Reason:  eventReasonISCSISessionsLost,
+			Message: "node " + p.nodeName + " could not restore iSCSI sessions: " + healErr.Error(),
+		}
+	} else if healErr == nil && p.iSCSISessionsLost[volumeID] {
+		event = &utils.VolumeEvent{
+			Type:    frontend.EventTypeNormal,
+			Reason:  eventReasonISCSISessionsRestored,
+			Message: "node " + p.nodeName + " restored iSCSI sessions",
+		}
+	}
+	if event == nil {
+		return
+	}
+
+	if err := p.restClient.CreateVolumeEvent(volumeID, event); err != nil {
+		log.WithFields(log.Fields{"volume": volumeID, "error": err}).Warning("Could not record volume event.")
+		return
+	}
+
+	if healErr != nil {
+		p.iSCSISessionsLost[volumeID] = true
+	} else {
+		delete(p.iSCSISessionsLost, volumeID)
+	}
+}
+
+// stagedISCSIVolumes returns the iSCSI volumes staged on this node, keyed by target IQN.
+func (p *Plugin) stagedISCSIVolumes() map[string][]*stagedISCSIVolume {
+
+	volumes := make(map[string][]*stagedISCSIVolume)
+
+	files, err := ioutil.ReadDir(tridentDeviceInfoPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithField("error", err).Warning("Could not read tracking files.")
+		}
+		return volumes
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		volumeID := strings.TrimSuffix(file.Name(), ".json")
+
+		stagingTargetPath, err := p.readStagedTrackingFile(volumeID)
+		if err != nil {
+			continue
+		}
+		publishInfo, err := p.readStagedDeviceInfo(stagingTargetPath)
+		if err != nil || publishInfo.IscsiTargetIQN == "" {
+			continue
+		}
+
+		var stagedTime time.Time
+		if info, err := os.Stat(path.Join(stagingTargetPath, volumePublishInfoFilename)); err == nil {
+			stagedTime = info.ModTime()
+		}
+
+		volumes[publishInfo.IscsiTargetIQN] = append(volumes[publishInfo.IscsiTargetIQN], &stagedISCSIVolume{
+			volumeID:          volumeID,
+			stagingTargetPath: stagingTargetPath,
+			publishInfo:       publishInfo,
+			stagedTime:        stagedTime,
+		})
+	}
+
+	return volumes
+}
+
+// mergeStagedISCSIPublishInfo combines the publish info of the volumes staged from one iSCSI target.
+// The volumes share the target's sessions, so the portals of all of them are included, and the CHAP
+// credentials of the volume staged most recently are used.
+func mergeStagedISCSIPublishInfo(volumes []*stagedISCSIVolume) *utils.VolumePublishInfo {
+
+	newest := volumes[0]
+	for _, volume := range volumes[1:] {
+		if volume.stagedTime.After(newest.stagedTime) {
+			newest = volume
+		}
+	}
+	merged := *newest.publishInfo
+
+	seen := make(map[string]bool)
+	portals := make([]string, 0)
+	for _, volume := range volumes {
+		for _, portal := range append([]string{volume.publishInfo.IscsiTargetPortal}, volume.publishInfo.IscsiPortals...) {
+			if portal != "" && !seen[portal] {
+				seen[portal] = true
+				portals = append(portals, portal)
+			}
+		}
+	}
+	if len(portals) > 0 {
+		merged.IscsiTargetPortal = portals[0]
+		merged.IscsiPortals = portals[1:]
+	}
+
+	return &merged
+}
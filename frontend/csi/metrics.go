@@ -0,0 +1,130 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/utils"
+)
+
+// The I/O counters of the volumes mounted on a node, labeled by volume ID (the PV name in Kubernetes).
+// They are sampled whenever the container orchestrator asks for a volume's stats, which kubelet does
+// periodically for every mounted volume.
+var (
+	nodeVolumeReadOpsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_read_ops_total",
+			Help:      "The number of reads completed by a volume on this node",
+		},
+		[]string{"volume"},
+	)
+	nodeVolumeWriteOpsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_write_ops_total",
+			Help:      "The number of writes completed by a volume on this node",
+		},
+		[]string{"volume"},
+	)
+	nodeVolumeReadBytesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_read_bytes_total",
+			Help:      "The number of bytes read from a volume on this node",
+		},
+		[]string{"volume"},
+	)
+	nodeVolumeWriteBytesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_write_bytes_total",
+			Help:      "The number of bytes written to a volume on this node",
+		},
+		[]string{"volume"},
+	)
+	nodeVolumeReadTimeCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_read_time_seconds_total",
+			Help:      "The time spent completing reads from a volume on this node",
+		},
+		[]string{"volume"},
+	)
+	nodeVolumeWriteTimeCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: config.OrchestratorName,
+			Subsystem: "node",
+			Name:      "volume_write_time_seconds_total",
+			Help:      "The time spent completing writes to a volume on this node",
+		},
+		[]string{"volume"},
+	)
+)
+
+// volumeIOStatsRecorder turns the cumulative I/O counters the kernel keeps for each volume into
+// Prometheus counters, which may only grow, by adding the change since each volume's previous sample.
+type volumeIOStatsRecorder struct {
+	mutex       sync.Mutex
+	lastSamples map[string]*utils.VolumeIOStats
+}
+
+func newVolumeIOStatsRecorder() *volumeIOStatsRecorder {
+	return &volumeIOStatsRecorder{lastSamples: make(map[string]*utils.VolumeIOStats)}
+}
+
+// record adds the change in a volume's I/O counters since its previous sample.  Counters that went down
+// were reset, as when a device is reattached, so their whole value is added.
+func (r *volumeIOStatsRecorder) record(volumeID string, sample *utils.VolumeIOStats) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	last, ok := r.lastSamples[volumeID]
+	if !ok {
+		last = &utils.VolumeIOStats{}
+	}
+	delta := func(current, previous uint64) float64 {
+		if current < previous {
+			return float64(current)
+		}
+		return float64(current - previous)
+	}
+
+	nodeVolumeReadOpsCounter.WithLabelValues(volumeID).Add(delta(sample.ReadOps, last.ReadOps))
+	nodeVolumeWriteOpsCounter.WithLabelValues(volumeID).Add(delta(sample.WriteOps, last.WriteOps))
+	nodeVolumeReadBytesCounter.WithLabelValues(volumeID).Add(delta(sample.ReadBytes, last.ReadBytes))
+	nodeVolumeWriteBytesCounter.WithLabelValues(volumeID).Add(delta(sample.WriteBytes, last.WriteBytes))
+	nodeVolumeReadTimeCounter.WithLabelValues(volumeID).Add(delta(sample.ReadTimeMs, last.ReadTimeMs) / 1000)
+	nodeVolumeWriteTimeCounter.WithLabelValues(volumeID).Add(delta(sample.WriteTimeMs, last.WriteTimeMs) / 1000)
+
+	r.lastSamples[volumeID] = sample
+}
+
+// forget stops reporting a volume that is no longer mounted on this node.
+func (r *volumeIOStatsRecorder) forget(volumeID string) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.lastSamples[volumeID]; !ok {
+		return
+	}
+	delete(r.lastSamples, volumeID)
+	nodeVolumeReadOpsCounter.DeleteLabelValues(volumeID)
+	nodeVolumeWriteOpsCounter.DeleteLabelValues(volumeID)
+	nodeVolumeReadBytesCounter.DeleteLabelValues(volumeID)
+	nodeVolumeWriteBytesCounter.DeleteLabelValues(volumeID)
+	nodeVolumeReadTimeCounter.DeleteLabelValues(volumeID)
+	nodeVolumeWriteTimeCounter.DeleteLabelValues(volumeID)
+}
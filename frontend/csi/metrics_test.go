@@ -0,0 +1,33 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/utils"
+)
+
+func TestVolumeIOStatsRecorder(t *testing.T) {
+
+	recorder := newVolumeIOStatsRecorder()
+	defer recorder.forget("pvc-1")
+
+	recorder.record("pvc-1", &utils.VolumeIOStats{ReadOps: 100, WriteOps: 10, ReadTimeMs: 2000})
+	recorder.record("pvc-1", &utils.VolumeIOStats{ReadOps: 150, WriteOps: 30, ReadTimeMs: 2500})
+	assert.Equal(t, float64(150), testutil.ToFloat64(nodeVolumeReadOpsCounter.WithLabelValues("pvc-1")))
+	assert.Equal(t, float64(30), testutil.ToFloat64(nodeVolumeWriteOpsCounter.WithLabelValues("pvc-1")))
+	assert.Equal(t, 2.5, testutil.ToFloat64(nodeVolumeReadTimeCounter.WithLabelValues("pvc-1")))
+
+	// Counters reset by the kernel keep growing
+	recorder.record("pvc-1", &utils.VolumeIOStats{ReadOps: 20, WriteOps: 30, ReadTimeMs: 2500})
+	assert.Equal(t, float64(170), testutil.ToFloat64(nodeVolumeReadOpsCounter.WithLabelValues("pvc-1")))
+	assert.Equal(t, float64(30), testutil.ToFloat64(nodeVolumeWriteOpsCounter.WithLabelValues("pvc-1")))
+
+	// A forgotten volume is no longer reported
+	recorder.forget("pvc-1")
+	assert.Equal(t, 0, testutil.CollectAndCount(nodeVolumeReadOpsCounter))
+}
@@ -79,14 +79,21 @@ func (p *Plugin) NodeUnstageVolume(
 		return nil, status.Errorf(codes.Internal, "unable to read protocol info from publish info; %s", err)
 	}
 
+	var response *csi.NodeUnstageVolumeResponse
 	switch protocol {
 	case tridentconfig.File:
-		return p.nodeUnstageNFSVolume(ctx, req)
+		response, err = p.nodeUnstageNFSVolume(ctx, req)
 	case tridentconfig.Block:
-		return p.nodeUnstageISCSIVolume(ctx, req, publishInfo)
+		response, err = p.nodeUnstageISCSIVolume(ctx, req, publishInfo)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "unknown protocol")
 	}
+
+	// Stop reporting the I/O of a volume no longer mounted here
+	if err == nil && p.volumeIOStats != nil {
+		p.volumeIOStats.forget(req.GetVolumeId())
+	}
+	return response, err
 }
 
 func (p *Plugin) NodePublishVolume(
@@ -101,6 +108,11 @@ func (p *Plugin) NodePublishVolume(
 	log.WithFields(fields).Debug(">>>> NodePublishVolume")
 	defer log.WithFields(fields).Debug("<<<< NodePublishVolume")
 
+	// Kubernetes doesn't provision or publish ephemeral volumes, so the node does both itself
+	if isEphemeralVolume(req.GetVolumeContext()) {
+		return p.nodePublishEphemeralVolume(ctx, req)
+	}
+
 	switch req.PublishContext["protocol"] {
 	case string(tridentconfig.File):
 		return p.nodePublishNFSVolume(ctx, req)
@@ -133,6 +145,11 @@ func (p *Plugin) NodeUnpublishVolume(
 		return nil, status.Error(codes.InvalidArgument, "no target path provided")
 	}
 
+	// Ephemeral volumes are deleted along with their pods
+	if p.hasEphemeralTrackingFile(req.GetVolumeId()) {
+		return p.nodeUnpublishEphemeralVolume(ctx, req)
+	}
+
 	isDir, err := utils.IsLikelyDir(targetPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -189,59 +206,88 @@ func (p *Plugin) NodeGetVolumeStats(
 	ctx context.Context, req *csi.NodeGetVolumeStatsRequest,
 ) (*csi.NodeGetVolumeStatsResponse, error) {
 
-        if req.GetVolumeId() == "" {
-                return nil, status.Error(codes.InvalidArgument, "empty volume id provided")
-        }
-
-        if req.GetVolumePath() == "" {
-                return nil, status.Error(codes.InvalidArgument, "empty volume path provided")
-        }
-
-        // Ensure volume is published at path
-        _, err := os.Stat(req.GetVolumePath())
-        if err != nil {
-                return nil, status.Error(codes.NotFound,
-                        fmt.Sprintf("could not find volume mount at path: %s; %v ", req.GetVolumePath(), err))
-        }
-
-        // If raw block volume, dont return usage
-        isRawBlock := false
-        if req.StagingTargetPath != "" {
-                publishInfo, err := p.readStagedDeviceInfo(req.StagingTargetPath)
-                if err != nil {
-                        return nil, status.Error(codes.Internal, err.Error())
-                }
-
-                isRawBlock = publishInfo.FilesystemType == fsRaw
-        }
-        if isRawBlock {
-                // Return no capacity info for raw block volumes, we cannot reliably determine the capacity
-                return &csi.NodeGetVolumeStatsResponse{}, nil
-        } else {
-                // If filesystem, return usage reported by FS
-                available, capacity, usage, inodes, inodesFree, inodesUsed, err := utils.GetFilesystemStats(req.GetVolumePath())
-                if err != nil {
-                        log.Errorf("unable to get filesystem stats at path: %s; %v", req.GetVolumePath(), err)
-                        return nil, status.Error(codes.Unknown, "Failed to get filesystem stats")
-                }
-                return &csi.NodeGetVolumeStatsResponse{
-                        Usage: []*csi.VolumeUsage{
-                                &csi.VolumeUsage{
-                                        Unit:      csi.VolumeUsage_BYTES,
-                                        Available: available,
-                                        Total:     capacity,
-                                        Used:      usage,
-                                },
-                                &csi.VolumeUsage{
-                                        Unit:      csi.VolumeUsage_INODES,
-                                        Available: inodesFree,
-                                        Total:     inodes,
-                                        Used:      inodesUsed,
-                                },
-                        },
-                }, nil
-        }
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume id provided")
+	}
+
+	if req.GetVolumePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty volume path provided")
+	}
+
+	// Ensure volume is published at path
+	_, err := os.Stat(req.GetVolumePath())
+	if err != nil {
+		return nil, status.Error(codes.NotFound,
+			fmt.Sprintf("could not find volume mount at path: %s; %v ", req.GetVolumePath(), err))
+	}
+
+	var publishInfo *utils.VolumePublishInfo
+	if req.StagingTargetPath != "" {
+		publishInfo, err = p.readStagedDeviceInfo(req.StagingTargetPath)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Sample the volume's I/O counters for this node's metrics, since the stats are requested periodically
+	p.recordVolumeIOStats(req.GetVolumeId(), req.GetVolumePath(), publishInfo)
 
+	// If raw block volume, dont return usage
+	if publishInfo != nil && publishInfo.FilesystemType == fsRaw {
+		// Return no capacity info for raw block volumes, we cannot reliably determine the capacity
+		return &csi.NodeGetVolumeStatsResponse{}, nil
+	}
+
+	// If filesystem, return usage reported by FS
+	available, capacity, usage, inodes, inodesFree, inodesUsed, err := utils.GetFilesystemStats(req.GetVolumePath())
+	if err != nil {
+		log.Errorf("unable to get filesystem stats at path: %s; %v", req.GetVolumePath(), err)
+		return nil, status.Error(codes.Unknown, "Failed to get filesystem stats")
+	}
+	return &csi.NodeGetVolumeStatsResponse{
+		Usage: []*csi.VolumeUsage{
+			{
+				Unit:      csi.VolumeUsage_BYTES,
+				Available: available,
+				Total:     capacity,
+				Used:      usage,
+			},
+			{
+				Unit:      csi.VolumeUsage_INODES,
+				Available: inodesFree,
+				Total:     inodes,
+				Used:      inodesUsed,
+			},
+		},
+	}, nil
+}
+
+// recordVolumeIOStats samples the I/O counters of a volume mounted on this node, from its block device if
+// it has one and otherwise from its NFS mount.  CSI has no place for I/O statistics in a volume's stats,
+// so they are only reported as metrics.
+func (p *Plugin) recordVolumeIOStats(volumeID, volumePath string, publishInfo *utils.VolumePublishInfo) {
+
+	if p.volumeIOStats == nil {
+		return
+	}
+
+	var ioStats *utils.VolumeIOStats
+	var err error
+	if publishInfo != nil && publishInfo.DevicePath != "" {
+		ioStats, err = utils.GetBlockDeviceIOStats(publishInfo.DevicePath)
+	} else {
+		ioStats, err = utils.GetNFSMountIOStats(volumePath)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volumeId":   volumeID,
+			"volumePath": volumePath,
+			"error":      err,
+		}).Debug("Could not read volume I/O stats.")
+		return
+	}
+
+	p.volumeIOStats.record(volumeID, ioStats)
 }
 
 // The CO only calls NodeExpandVolume for the Block protocol as the filesystem has to be mounted to perform
@@ -251,6 +297,10 @@ func (p *Plugin) NodeExpandVolume(
 	ctx context.Context, req *csi.NodeExpandVolumeRequest,
 ) (*csi.NodeExpandVolumeResponse, error) {
 
+	lockContext := "NodeExpandVolume-" + req.GetVolumeId()
+	utils.Lock(lockContext, lockID)
+	defer utils.Unlock(lockContext, lockID)
+
 	fields := log.Fields{"Method": "NodeExpandVolume", "Type": "CSI_Node"}
 	log.WithFields(fields).Debug(">>>> NodeExpandVolume")
 	defer log.WithFields(fields).Debug("<<<< NodeExpandVolume")
@@ -311,6 +361,49 @@ func (p *Plugin) NodeExpandVolume(
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	return p.nodeExpandVolume(req, publishInfo, stagingTargetPath)
+}
+
+// The host's iSCSI devices are grown through these, so that tests may stand in for them.
+var (
+	isISCSIAttached       = utils.IsAlreadyAttached
+	rescanISCSIDevices    = utils.ISCSIRescanDevices
+	expandISCSIFilesystem = utils.ExpandISCSIFilesystem
+)
+
+// nodeExpandVolume grows a staged volume on the node as its protocol requires.
+func (p *Plugin) nodeExpandVolume(
+	req *csi.NodeExpandVolumeRequest, publishInfo *utils.VolumePublishInfo, stagingTargetPath string,
+) (*csi.NodeExpandVolumeResponse, error) {
+
+	protocol, err := p.getVolumeProtocolFromPublishInfo(publishInfo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read protocol info from publish info; %s", err)
+	}
+
+	switch protocol {
+	case tridentconfig.File:
+		// An NFS volume grows with its export, so there is nothing to do on the node
+		log.WithField("volumeId", req.GetVolumeId()).Debug("No node expansion needed for NFS volume.")
+		return &csi.NodeExpandVolumeResponse{}, nil
+	case tridentconfig.Block:
+		return p.nodeExpandISCSIVolume(req, publishInfo, stagingTargetPath)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "unknown protocol")
+	}
+}
+
+// nodeExpandISCSIVolume makes the host see a LUN's new size once the controller has resized it.
+// Each path to the LUN is rescanned, its multipath map is grown, and, unless the volume is a raw
+// block device, the filesystem is grown with xfs_growfs or resize2fs.
+func (p *Plugin) nodeExpandISCSIVolume(
+	req *csi.NodeExpandVolumeRequest, publishInfo *utils.VolumePublishInfo, stagingTargetPath string,
+) (*csi.NodeExpandVolumeResponse, error) {
+
+	volumeId := req.GetVolumeId()
+	volumePath := req.GetVolumePath()
+	requiredBytes := req.GetCapacityRange().GetRequiredBytes()
+	limitBytes := req.GetCapacityRange().GetLimitBytes()
 	lunID := int(publishInfo.IscsiLunNumber)
 
 	log.WithFields(log.Fields{
@@ -322,10 +415,10 @@ func (p *Plugin) NodeExpandVolume(
 	}).Debug("PublishInfo for device to expand.")
 
 	// Make sure device is ready
-	if utils.IsAlreadyAttached(lunID, publishInfo.IscsiTargetIQN) {
+	if isISCSIAttached(lunID, publishInfo.IscsiTargetIQN) {
 
 		// Rescan device to detect increased size
-		if err = utils.ISCSIRescanDevices(publishInfo.IscsiTargetIQN, publishInfo.IscsiLunNumber, requiredBytes); err != nil {
+		if err := rescanISCSIDevices(publishInfo.IscsiTargetIQN, publishInfo.IscsiLunNumber, requiredBytes); err != nil {
 			log.WithFields(log.Fields{
 				"device": publishInfo.DevicePath,
 				"error":  err,
@@ -334,8 +427,8 @@ func (p *Plugin) NodeExpandVolume(
 		}
 
 		// Expand filesystem
-		if publishInfo.FilesystemType != fsRaw {
-			filesystemSize, err := utils.ExpandISCSIFilesystem(publishInfo, stagingTargetPath)
+		if publishInfo.FilesystemType != fsRaw && req.GetVolumeCapability().GetBlock() == nil {
+			filesystemSize, err := expandISCSIFilesystem(publishInfo, stagingTargetPath)
 			if err != nil {
 				log.WithFields(log.Fields{
 					"device":         publishInfo.DevicePath,
@@ -352,7 +445,7 @@ func (p *Plugin) NodeExpandVolume(
 		}
 	} else {
 		log.WithField("devicePath", publishInfo.DevicePath).Error("Unable to expand volume as device is not attached.")
-		err = fmt.Errorf("device %s to expand is not attached", publishInfo.DevicePath)
+		err := fmt.Errorf("device %s to expand is not attached", publishInfo.DevicePath)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -382,7 +475,11 @@ func (p *Plugin) NodeGetInfo(
 	log.WithFields(fields).Debug(">>>> NodeGetInfo")
 	defer log.WithFields(fields).Debug("<<<< NodeGetInfo")
 
-	return &csi.NodeGetInfoResponse{NodeId: p.nodeName}, nil
+	response := &csi.NodeGetInfoResponse{NodeId: p.nodeName}
+	if topology := p.getNodeTopology(); len(topology) > 0 {
+		response.AccessibleTopology = &csi.Topology{Segments: topology}
+	}
+	return response, nil
 }
 
 func (p *Plugin) nodeGetInfo() *utils.Node {
@@ -459,6 +556,9 @@ func (p *Plugin) nodeStageNFSVolume(ctx context.Context, req *csi.NodeStageVolum
 	publishInfo.MountOptions = req.PublishContext["mountOptions"]
 	publishInfo.NfsServerIP = req.PublishContext["nfsServerIp"]
 	publishInfo.NfsPath = req.PublishContext["nfsPath"]
+	if nfsServerIPs := req.PublishContext["nfsServerIps"]; nfsServerIPs != "" {
+		publishInfo.NfsServerIPs = strings.Split(nfsServerIPs, ",")
+	}
 
 	volumeId, stagingTargetPath, err := p.getVolumeIdAndStagingPath(req)
 	if err != nil {
@@ -493,46 +593,70 @@ func (p *Plugin) nodePublishNFSVolume(
 	ctx context.Context, req *csi.NodePublishVolumeRequest,
 ) (*csi.NodePublishVolumeResponse, error) {
 
-	targetPath := req.GetTargetPath()
+	mounted, err := p.ensureNFSTargetPath(req.GetTargetPath())
+	if err != nil {
+		return nil, err
+	}
+	if mounted {
+		return &csi.NodePublishVolumeResponse{}, nil
+	}
+
+	publishInfo, err := p.readStagedDeviceInfo(req.StagingTargetPath)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err = p.mountNFSVolume(req.VolumeContext["internalName"], req.TargetPath, req.GetReadonly(),
+		publishInfo); err != nil {
+		return nil, err
+	}
+
+	return &csi.NodePublishVolumeResponse{}, nil
+}
+
+// ensureNFSTargetPath creates the directory on which an NFS volume is to be mounted, if need be, and
+// reports whether a volume is already mounted there.  Returns a status.Error if an error is returned.
+func (p *Plugin) ensureNFSTargetPath(targetPath string) (bool, error) {
+
 	notMnt, err := utils.IsLikelyNotMountPoint(targetPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			if err := os.MkdirAll(targetPath, 0750); err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
+				return false, status.Error(codes.Internal, err.Error())
 			}
 			notMnt = true
 		} else {
-			return nil, status.Error(codes.Internal, err.Error())
+			return false, status.Error(codes.Internal, err.Error())
 		}
 	}
+	return !notMnt, nil
+}
 
-	if !notMnt {
-		return &csi.NodePublishVolumeResponse{}, nil
-	}
-
-	publishInfo, err := p.readStagedDeviceInfo(req.StagingTargetPath)
-	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
+// mountNFSVolume mounts an NFS volume on the target path.  Returns a status.Error if an error is returned.
+func (p *Plugin) mountNFSVolume(
+	internalName, targetPath string, readOnly bool, publishInfo *utils.VolumePublishInfo,
+) error {
 
-	if req.GetReadonly() {
+	if readOnly {
 		mountOptions := strings.Split(publishInfo.MountOptions, ",")
 		mountOptions = append(mountOptions, "ro")
 		publishInfo.MountOptions = strings.Join(mountOptions, ",")
 	}
 
-	err = utils.AttachNFSVolume(req.VolumeContext["internalName"], req.TargetPath, publishInfo)
+	err := utils.AttachNFSVolume(internalName, targetPath, publishInfo)
 	if err != nil {
 		if os.IsPermission(err) {
-			return nil, status.Error(codes.PermissionDenied, err.Error())
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		if utils.IsUnsupportedError(err) {
+			return status.Error(codes.FailedPrecondition, err.Error())
 		}
 		if strings.Contains(err.Error(), "invalid argument") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+			return status.Error(codes.InvalidArgument, err.Error())
 		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-
-	return &csi.NodePublishVolumeResponse{}, nil
+	return nil
 }
 
 func unstashIscsiTargetPortals(publishInfo *utils.VolumePublishInfo, reqPublishInfo map[string]string) error {
@@ -616,6 +740,18 @@ func (p *Plugin) nodeStageISCSIVolume(
 	publishInfo.MountOptions = req.PublishContext["mountOptions"]
 	publishInfo.IscsiTargetIQN = req.PublishContext["iscsiTargetIqn"]
 	publishInfo.IscsiLunNumber = int32(lunID)
+	publishInfo.IscsiLunSerial = req.PublishContext["iscsiLunSerial"]
+	if expectedPaths, ok := req.PublishContext["iscsiExpectedPaths"]; ok {
+		if publishInfo.IscsiExpectedPaths, err = strconv.Atoi(expectedPaths); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	publishInfo.IscsiLunUUID = req.PublishContext["iscsiLunUuid"]
+	if period, ok := req.PublishContext["spaceReclamationPeriod"]; ok {
+		if publishInfo.SpaceReclamationPeriod, err = strconv.Atoi(period); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
 	publishInfo.IscsiInterface = req.PublishContext["iscsiInterface"]
 	publishInfo.IscsiIgroup = req.PublishContext["iscsiIgroup"]
 	publishInfo.IscsiUsername = req.PublishContext["iscsiUsername"]
@@ -623,8 +759,15 @@ func (p *Plugin) nodeStageISCSIVolume(
 	publishInfo.IscsiTargetUsername = req.PublishContext["iscsiTargetUsername"]
 	publishInfo.IscsiTargetSecret = req.PublishContext["iscsiTargetSecret"]
 
+	p.updateISCSIPortals(req.GetVolumeId(), publishInfo)
+
 	// Perform the login/rescan/discovery/(optionally)format, mount & get the device back in the publish info
 	if err := utils.AttachISCSIVolume(req.VolumeContext["internalName"], "", publishInfo); err != nil {
+		if utils.IsISCSIPathsDegradedError(err) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		} else if utils.IsISCSIDeviceMismatchError(err) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -641,6 +784,31 @@ func (p *Plugin) nodeStageISCSIVolume(
 	return &csi.NodeStageVolumeResponse{}, nil
 }
 
+// updateISCSIPortals asks the controller to correct the portals in a volume's publish context, which
+// may be stale if the data LIFs moved after the volume was published.  The portals are left as they
+// are if the controller can't be reached, since they may still be correct.
+func (p *Plugin) updateISCSIPortals(volumeID string, publishInfo *utils.VolumePublishInfo) {
+
+	if p.restClient == nil {
+		return
+	}
+
+	updated, err := p.restClient.UpdateVolumePublication(volumeID, publishInfo)
+	if err != nil {
+		log.WithFields(log.Fields{"volume": volumeID, "error": err}).Warning(
+			"Could not check the iSCSI portals of the volume.")
+		return
+	}
+	if updated {
+		log.WithFields(log.Fields{
+			"volume":        volumeID,
+			"targetPortal":  publishInfo.IscsiTargetPortal,
+			"portals":       publishInfo.IscsiPortals,
+			"expectedPaths": publishInfo.IscsiExpectedPaths,
+		}).Info("Using updated iSCSI portals.")
+	}
+}
+
 func (p *Plugin) nodeUnstageISCSIVolume(
 	ctx context.Context, req *csi.NodeUnstageVolumeRequest, publishInfo *utils.VolumePublishInfo,
 ) (*csi.NodeUnstageVolumeResponse, error) {
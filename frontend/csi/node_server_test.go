@@ -0,0 +1,110 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/netapp/trident/utils"
+)
+
+func TestNodeExpandVolume(t *testing.T) {
+
+	savedAttached, savedRescan, savedExpand := isISCSIAttached, rescanISCSIDevices, expandISCSIFilesystem
+	defer func() {
+		isISCSIAttached, rescanISCSIDevices, expandISCSIFilesystem = savedAttached, savedRescan, savedExpand
+	}()
+
+	nfsAccessInfo := utils.VolumeAccessInfo{NfsAccessInfo: utils.NfsAccessInfo{NfsServerIP: "10.0.0.1"}}
+	iscsiAccessInfo := utils.VolumeAccessInfo{IscsiAccessInfo: utils.IscsiAccessInfo{
+		IscsiTargetIQN: "iqn.1992-08.com.netapp:sn.1234:vs.3",
+		IscsiLunNumber: 1,
+	}}
+	mountCapability := &csi.VolumeCapability{AccessType: &csi.VolumeCapability_Mount{
+		Mount: &csi.VolumeCapability_MountVolume{},
+	}}
+	blockCapability := &csi.VolumeCapability{AccessType: &csi.VolumeCapability_Block{
+		Block: &csi.VolumeCapability_BlockVolume{},
+	}}
+
+	tests := map[string]struct {
+		accessInfo     utils.VolumeAccessInfo
+		filesystemType string
+		capability     *csi.VolumeCapability
+		notAttached    bool
+		rescanErr      error
+		expandErr      error
+		code           codes.Code
+		rescanned      bool
+		expanded       bool
+	}{
+		"NFS": {
+			accessInfo: nfsAccessInfo, capability: mountCapability, code: codes.OK,
+		},
+		"Unknown protocol": {
+			capability: mountCapability, code: codes.Internal,
+		},
+		"iSCSI filesystem": {
+			accessInfo: iscsiAccessInfo, filesystemType: "ext4", capability: mountCapability,
+			code: codes.OK, rescanned: true, expanded: true,
+		},
+		"iSCSI raw filesystem": {
+			accessInfo: iscsiAccessInfo, filesystemType: fsRaw, capability: mountCapability,
+			code: codes.OK, rescanned: true,
+		},
+		"iSCSI raw block": {
+			accessInfo: iscsiAccessInfo, filesystemType: "ext4", capability: blockCapability,
+			code: codes.OK, rescanned: true,
+		},
+		"iSCSI not attached": {
+			accessInfo: iscsiAccessInfo, filesystemType: "ext4", capability: mountCapability, notAttached: true,
+			code: codes.Internal,
+		},
+		"iSCSI rescan failed": {
+			accessInfo: iscsiAccessInfo, filesystemType: "ext4", capability: mountCapability,
+			rescanErr: errors.New("device not large enough"), code: codes.Internal, rescanned: true,
+		},
+		"iSCSI filesystem expansion failed": {
+			accessInfo: iscsiAccessInfo, filesystemType: "xfs", capability: mountCapability,
+			expandErr: errors.New("xfs_growfs failed"), code: codes.Internal, rescanned: true, expanded: true,
+		},
+	}
+	for name, test := range tests {
+		rescanned, expanded := false, false
+		isISCSIAttached = func(int, string) bool { return !test.notAttached }
+		rescanISCSIDevices = func(targetIQN string, lunID int32, minSize int64) error {
+			assert.Equal(t, iscsiAccessInfo.IscsiTargetIQN, targetIQN, name)
+			assert.Equal(t, int64(2048), minSize, name)
+			rescanned = true
+			return test.rescanErr
+		}
+		expandISCSIFilesystem = func(publishInfo *utils.VolumePublishInfo, stagedTargetPath string) (int64, error) {
+			assert.Equal(t, "/staging", stagedTargetPath, name)
+			expanded = true
+			return 2048, test.expandErr
+		}
+
+		req := &csi.NodeExpandVolumeRequest{
+			VolumeId:         "pvc-1",
+			VolumePath:       "/staging",
+			CapacityRange:    &csi.CapacityRange{RequiredBytes: 2048},
+			VolumeCapability: test.capability,
+		}
+		publishInfo := &utils.VolumePublishInfo{
+			VolumeAccessInfo: test.accessInfo,
+			FilesystemType:   test.filesystemType,
+		}
+
+		p := &Plugin{}
+		_, err := p.nodeExpandVolume(req, publishInfo, "/staging")
+		assert.Equal(t, test.code, status.Code(err), name)
+		assert.Equal(t, test.rescanned, rescanned, name)
+		assert.Equal(t, test.expanded, expanded, name)
+	}
+}
@@ -3,8 +3,11 @@
 package csi
 
 import (
+	"fmt"
+	"net"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/netapp/trident/utils"
@@ -15,12 +18,15 @@ import (
 	tridentconfig "github.com/netapp/trident/config"
 	"github.com/netapp/trident/core"
 	"github.com/netapp/trident/frontend/csi/helpers"
+	drivers "github.com/netapp/trident/storage_drivers"
 )
 
 const (
 	CSIController = "controller"
 	CSINode       = "node"
 	CSIAllInOne   = "allInOne"
+
+	healthCheckTimeout = 5 * time.Second
 )
 
 type Plugin struct {
@@ -42,6 +48,15 @@ type Plugin struct {
 	vCap  []*csi.VolumeCapability_AccessMode
 
 	opCache map[string]bool
+
+	iSCSISelfHealingInterval time.Duration
+	iSCSISelfHealingStop     chan struct{}
+	iSCSISessionsLost        map[string]bool
+
+	spaceReclamationStop chan struct{}
+	lastTrimmed          map[string]time.Time
+
+	volumeIOStats *volumeIOStatsRecorder
 }
 
 func NewControllerPlugin(
@@ -64,6 +79,7 @@ func NewControllerPlugin(
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
 		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
 		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
@@ -83,17 +99,21 @@ func NewControllerPlugin(
 }
 
 func NewNodePlugin(
-	nodeName, endpoint, caCert, clientCert, clientKey string, orchestrator core.Orchestrator,
+	nodeName, endpoint, caCert, clientCert, clientKey string, iSCSISelfHealingInterval time.Duration,
+	orchestrator core.Orchestrator,
 ) (*Plugin, error) {
 
 	p := &Plugin{
-		orchestrator: orchestrator,
-		name:         Provisioner,
-		nodeName:     nodeName,
-		version:      tridentconfig.OrchestratorVersion.ShortString(),
-		endpoint:     endpoint,
-		role:         CSINode,
-		opCache:      make(map[string]bool),
+		orchestrator:             orchestrator,
+		name:                     Provisioner,
+		nodeName:                 nodeName,
+		version:                  tridentconfig.OrchestratorVersion.ShortString(),
+		endpoint:                 endpoint,
+		role:                     CSINode,
+		opCache:                  make(map[string]bool),
+		iSCSISelfHealingInterval: iSCSISelfHealingInterval,
+		iSCSISessionsLost:        make(map[string]bool),
+		volumeIOStats:            newVolumeIOStatsRecorder(),
 	}
 
 	p.addNodeServiceCapabilities([]csi.NodeServiceCapability_RPC_Type{
@@ -135,19 +155,22 @@ func NewNodePlugin(
 // CSI Sanity expects a single process to respond to controller, node, and
 // identity interfaces.
 func NewAllInOnePlugin(
-	nodeName, endpoint, caCert, clientCert, clientKey string,
+	nodeName, endpoint, caCert, clientCert, clientKey string, iSCSISelfHealingInterval time.Duration,
 	orchestrator core.Orchestrator, helper *helpers.HybridPlugin,
 ) (*Plugin, error) {
 
 	p := &Plugin{
-		orchestrator: orchestrator,
-		name:         Provisioner,
-		nodeName:     nodeName,
-		version:      tridentconfig.OrchestratorVersion.ShortString(),
-		endpoint:     endpoint,
-		role:         CSIAllInOne,
-		helper:       *helper,
-		opCache:      make(map[string]bool),
+		orchestrator:             orchestrator,
+		name:                     Provisioner,
+		nodeName:                 nodeName,
+		version:                  tridentconfig.OrchestratorVersion.ShortString(),
+		endpoint:                 endpoint,
+		role:                     CSIAllInOne,
+		helper:                   *helper,
+		opCache:                  make(map[string]bool),
+		iSCSISelfHealingInterval: iSCSISelfHealingInterval,
+		iSCSISessionsLost:        make(map[string]bool),
+		volumeIOStats:            newVolumeIOStatsRecorder(),
 	}
 
 	// Define controller capabilities
@@ -155,6 +178,7 @@ func NewAllInOnePlugin(
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
 		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
 		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
@@ -200,6 +224,8 @@ func (p *Plugin) Activate() error {
 		p.grpc.Start(p.endpoint, p, p, p)
 		if p.role == CSINode || p.role == CSIAllInOne {
 			go p.nodeRegisterWithController()
+			p.startISCSISelfHealing()
+			p.startSpaceReclamation()
 		}
 	}()
 	return nil
@@ -209,6 +235,8 @@ func (p *Plugin) Deactivate() error {
 	log.Info("Deactivating CSI frontend.")
 	p.grpc.GracefulStop()
 	if p.role == CSINode || p.role == CSIAllInOne {
+		p.stopISCSISelfHealing()
+		p.stopSpaceReclamation()
 		err := p.nodeDeregisterWithController()
 		if err != nil {
 			log.Errorf("Error deregistering node %s with controller; %v", p.nodeName, err)
@@ -226,6 +254,24 @@ func (p *Plugin) Version() string {
 	return tridentconfig.OrchestratorVersion.String()
 }
 
+// CheckHealth returns an error if the CSI server isn't accepting connections on its endpoint.
+func (p *Plugin) CheckHealth() error {
+
+	proto, addr, err := ParseEndpoint(p.endpoint)
+	if err != nil {
+		return err
+	}
+	if proto == "unix" {
+		addr = "/" + addr
+	}
+
+	conn, err := net.DialTimeout(proto, addr, healthCheckTimeout)
+	if err != nil {
+		return fmt.Errorf("CSI server is not accepting connections; %v", err)
+	}
+	return conn.Close()
+}
+
 func (p *Plugin) addControllerServiceCapabilities(cl []csi.ControllerServiceCapability_RPC_Type) {
 
 	var csCap []*csi.ControllerServiceCapability
@@ -269,7 +315,38 @@ func (p *Plugin) getCSIErrorForOrchestratorError(err error) error {
 		return status.Error(codes.FailedPrecondition, err.Error())
 	} else if utils.IsNotFoundError(err) {
 		return status.Error(codes.NotFound, err.Error())
+	} else if drivers.IsVolumeSizeLimitError(err) {
+		return status.Error(codes.OutOfRange, err.Error())
+	} else if utils.IsQuotaExceededError(err) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	} else if utils.IsUnsupportedAccessModeError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	} else if utils.IsVolumePublishedError(err) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	} else if utils.IsCloneNotPermittedError(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	} else if csiErr := getCSIErrorForDriverError(err); csiErr != nil {
+		return csiErr
 	} else {
 		return status.Error(codes.Unknown, err.Error())
 	}
 }
+
+// getCSIErrorForDriverError returns the gRPC status error corresponding to a typed storage driver
+// error, or nil if err is not one of those types.
+func getCSIErrorForDriverError(err error) error {
+	if drivers.IsResourceNotFoundError(err) {
+		return status.Error(codes.NotFound, err.Error())
+	} else if drivers.IsResourceExistsError(err) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	} else if drivers.IsResourceExhaustedError(err) || drivers.IsPoolOverprovisionedError(err) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	} else if drivers.IsUnauthorizedError(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	} else if drivers.IsRetriableError(err) {
+		return status.Error(codes.Unavailable, err.Error())
+	} else if drivers.IsUnsupportedCapacityRangeError(err) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
 	"github.com/netapp/trident/utils"
 )
 
@@ -123,6 +124,23 @@ func (c *RestClient) CreateNode(node *utils.Node) error {
 	return nil
 }
 
+// CreateVolumeEvent reports an event about a volume observed by this node to the CSI controller server
+func (c *RestClient) CreateVolumeEvent(volumeName string, event *utils.VolumeEvent) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error parsing create volume event request; %v", err)
+	}
+	resp, _, err := c.InvokeAPI(eventData, "POST", config.VolumeURL+"/"+volumeName+"/event")
+	if err != nil {
+		return fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not record event for volume %s", volumeName)
+	}
+	return nil
+}
+
 type ListNodesResponse struct {
 	Nodes []string `json:"nodes"`
 	Error string   `json:"error,omitempty"`
@@ -167,3 +185,146 @@ func (c *RestClient) DeleteNode(name string) error {
 	}
 	return nil
 }
+
+type GetVolumeResponse struct {
+	Volume *storage.VolumeExternal `json:"volume"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// GetVolume returns a volume known to the CSI controller server
+func (c *RestClient) GetVolume(name string) (*storage.VolumeExternal, error) {
+	resp, respBody, err := c.InvokeAPI(nil, "GET", config.VolumeURL+"/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", name))
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not get volume %s", name)
+	}
+
+	respData := GetVolumeResponse{}
+	if err := json.Unmarshal(respBody, &respData); err != nil {
+		return nil, fmt.Errorf("could not parse volume: %s; %v", string(respBody), err)
+	}
+	return respData.Volume, nil
+}
+
+type CreateVolumeResponse struct {
+	BackendID string `json:"backend"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CreateVolume asks the CSI controller server to provision a volume
+func (c *RestClient) CreateVolume(volConfig *storage.VolumeConfig) error {
+	volumeData, err := json.Marshal(volConfig)
+	if err != nil {
+		return fmt.Errorf("error parsing create volume request; %v", err)
+	}
+	resp, respBody, err := c.InvokeAPI(volumeData, "POST", config.VolumeURL)
+	if err != nil {
+		return fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		respData := CreateVolumeResponse{}
+		if err := json.Unmarshal(respBody, &respData); err == nil && respData.Error != "" {
+			return fmt.Errorf("could not create volume %s; %s", volConfig.Name, respData.Error)
+		}
+		return fmt.Errorf("could not create volume %s", volConfig.Name)
+	}
+	return nil
+}
+
+type PublishVolumeResponse struct {
+	Volume      string                   `json:"volume"`
+	Node        string                   `json:"node"`
+	PublishInfo *utils.VolumePublishInfo `json:"publishInfo,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+// PublishVolume asks the CSI controller server to publish a volume to a node
+func (c *RestClient) PublishVolume(volumeName, nodeName string) (*utils.VolumePublishInfo, error) {
+	requestData, err := json.Marshal(&storage.PublishVolumeRequest{Node: nodeName})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing publish volume request; %v", err)
+	}
+	resp, respBody, err := c.InvokeAPI(requestData, "POST", config.VolumeURL+"/"+volumeName+"/publish")
+	if err != nil {
+		return nil, fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	respData := PublishVolumeResponse{}
+	if err := json.Unmarshal(respBody, &respData); err != nil {
+		return nil, fmt.Errorf("could not parse publish info: %s; %v", string(respBody), err)
+	}
+	if resp.StatusCode != http.StatusOK || respData.PublishInfo == nil {
+		return nil, fmt.Errorf("could not publish volume %s to node %s; %s", volumeName, nodeName, respData.Error)
+	}
+	return respData.PublishInfo, nil
+}
+
+type UpdateVolumePublicationResponse struct {
+	Volume      string                   `json:"volume"`
+	Updated     bool                     `json:"updated"`
+	PublishInfo *utils.VolumePublishInfo `json:"publishInfo,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+// UpdateVolumePublication asks the CSI controller server to correct the iSCSI portals of a published
+// volume if they have moved.  Only the fields needed to find the portals are sent, so no CHAP secrets
+// leave the node, and only the portals are copied back.  It returns true if publishInfo was changed.
+func (c *RestClient) UpdateVolumePublication(volumeName string, publishInfo *utils.VolumePublishInfo) (bool, error) {
+	request := &utils.VolumePublishInfo{}
+	request.IscsiIgroup = publishInfo.IscsiIgroup
+	request.IscsiTargetIQN = publishInfo.IscsiTargetIQN
+	request.IscsiLunNumber = publishInfo.IscsiLunNumber
+	request.IscsiTargetPortal = publishInfo.IscsiTargetPortal
+	request.IscsiPortals = publishInfo.IscsiPortals
+	request.IscsiExpectedPaths = publishInfo.IscsiExpectedPaths
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return false, fmt.Errorf("error parsing update volume publication request; %v", err)
+	}
+	resp, respBody, err := c.InvokeAPI(requestData, "POST", config.VolumeURL+"/"+volumeName+"/publication")
+	if err != nil {
+		return false, fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	respData := UpdateVolumePublicationResponse{}
+	if err := json.Unmarshal(respBody, &respData); err != nil {
+		return false, fmt.Errorf("could not parse publish info: %s; %v", string(respBody), err)
+	}
+	if resp.StatusCode != http.StatusOK || respData.PublishInfo == nil {
+		return false, fmt.Errorf("could not update publication of volume %s; %s", volumeName, respData.Error)
+	}
+	if !respData.Updated {
+		return false, nil
+	}
+
+	publishInfo.IscsiTargetPortal = respData.PublishInfo.IscsiTargetPortal
+	publishInfo.IscsiPortals = respData.PublishInfo.IscsiPortals
+	publishInfo.IscsiExpectedPaths = respData.PublishInfo.IscsiExpectedPaths
+	return true, nil
+}
+
+// DeleteVolume asks the CSI controller server to delete a volume
+func (c *RestClient) DeleteVolume(name string) error {
+	resp, _, err := c.InvokeAPI(nil, "DELETE", config.VolumeURL+"/"+name)
+	if err != nil {
+		return fmt.Errorf("could not log into the Trident CSI Controller: %v", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+	case http.StatusNotFound:
+	case http.StatusGone:
+		break
+	default:
+		return fmt.Errorf("could not delete volume %s", name)
+	}
+	return nil
+}
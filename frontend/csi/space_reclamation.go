@@ -0,0 +1,128 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	spaceReclamationLockContext = "SpaceReclamation"
+
+	// How often the node looks for staged volumes that are due to be trimmed
+	spaceReclamationCheckInterval = 10 * time.Minute
+)
+
+// startSpaceReclamation starts the service that trims the filesystems of the staged iSCSI volumes
+// whose backends ask for space reclamation, so that the blocks freed on them are returned to the
+// storage system.  Each volume is trimmed once per the period in its publish info.
+func (p *Plugin) startSpaceReclamation() {
+
+	stop := make(chan struct{})
+	p.spaceReclamationStop = stop
+	p.lastTrimmed = make(map[string]time.Time)
+
+	go func() {
+		ticker := time.NewTicker(spaceReclamationCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.reclaimSpace()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopSpaceReclamation stops the space reclamation service, if it is running.
+func (p *Plugin) stopSpaceReclamation() {
+	if p.spaceReclamationStop != nil {
+		close(p.spaceReclamationStop)
+		p.spaceReclamationStop = nil
+	}
+}
+
+// reclaimSpace trims the filesystem of each staged volume that is due.  Each volume is trimmed while
+// holding the node lock, so that it can't be unstaged meanwhile.
+func (p *Plugin) reclaimSpace() {
+
+	log.Debug(">>>> reclaimSpace")
+	defer log.Debug("<<<< reclaimSpace")
+
+	staged := p.stagedISCSIVolumes()
+	for _, volume := range volumesDueForTrim(staged, p.lastTrimmed, time.Now()) {
+		p.trimStagedVolume(volume)
+	}
+
+	// Forget the volumes that are no longer staged
+	stagedIDs := make(map[string]bool)
+	for _, volumes := range staged {
+		for _, volume := range volumes {
+			stagedIDs[volume.volumeID] = true
+		}
+	}
+	for volumeID := range p.lastTrimmed {
+		if !stagedIDs[volumeID] {
+			delete(p.lastTrimmed, volumeID)
+		}
+	}
+}
+
+// trimStagedVolume trims the filesystem of a staged volume, unless it was unstaged since it was found.
+func (p *Plugin) trimStagedVolume(volume *stagedISCSIVolume) {
+
+	utils.Lock(spaceReclamationLockContext, lockID)
+	defer utils.Unlock(spaceReclamationLockContext, lockID)
+
+	logFields := log.Fields{"volume": volume.volumeID, "stagingTargetPath": volume.stagingTargetPath}
+
+	if stagingTargetPath, err := p.readStagedTrackingFile(volume.volumeID); err != nil ||
+		stagingTargetPath != volume.stagingTargetPath {
+		log.WithFields(logFields).Debug("Volume was unstaged, not trimming it.")
+		return
+	}
+
+	// The volume isn't trimmed again until its next period, even if this attempt fails
+	p.lastTrimmed[volume.volumeID] = time.Now()
+
+	if err := utils.TrimFilesystem(volume.stagingTargetPath); err != nil {
+		log.WithFields(logFields).WithError(err).Warning("Could not trim filesystem.")
+		return
+	}
+	log.WithFields(logFields).Info("Trimmed filesystem so that freed blocks may be reclaimed.")
+}
+
+// volumesDueForTrim returns the staged volumes whose space reclamation period has passed since they
+// were last trimmed, or since they were staged if they haven't been trimmed yet.  Raw block volumes
+// are never trimmed, since the node doesn't know which of their blocks are in use.
+func volumesDueForTrim(
+	staged map[string][]*stagedISCSIVolume, lastTrimmed map[string]time.Time, now time.Time,
+) []*stagedISCSIVolume {
+
+	due := make([]*stagedISCSIVolume, 0)
+	for _, volumes := range staged {
+		for _, volume := range volumes {
+			period := time.Duration(volume.publishInfo.SpaceReclamationPeriod) * time.Second
+			if period <= 0 || volume.publishInfo.FilesystemType == fsRaw {
+				continue
+			}
+			last, ok := lastTrimmed[volume.volumeID]
+			if !ok {
+				last = volume.stagedTime
+			}
+			if now.Sub(last) >= period {
+				due = append(due, volume)
+			}
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].volumeID < due[j].volumeID })
+	return due
+}
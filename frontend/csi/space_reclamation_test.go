@@ -0,0 +1,68 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/utils"
+)
+
+func TestVolumesDueForTrim(t *testing.T) {
+
+	now := time.Unix(1600000000, 0)
+	stagedVolume := func(volumeID, fstype string, period int, stagedAgo time.Duration) *stagedISCSIVolume {
+		return &stagedISCSIVolume{
+			volumeID:          volumeID,
+			stagingTargetPath: "/var/lib/kubelet/plugins/csi.trident.netapp.io/pv/" + volumeID + "/globalmount",
+			publishInfo:       &utils.VolumePublishInfo{FilesystemType: fstype, SpaceReclamationPeriod: period},
+			stagedTime:        now.Add(-stagedAgo),
+		}
+	}
+
+	tests := map[string]struct {
+		volume      *stagedISCSIVolume
+		lastTrimmed time.Duration
+		due         bool
+	}{
+		"Reclamation disabled": {
+			volume: stagedVolume("pvc-1", "ext4", 0, 48*time.Hour),
+		},
+		"Raw block volume": {
+			volume: stagedVolume("pvc-2", fsRaw, 3600, 48*time.Hour),
+		},
+		"Staged within the period": {
+			volume: stagedVolume("pvc-3", "ext4", 3600, 30*time.Minute),
+		},
+		"Staged before the period": {
+			volume: stagedVolume("pvc-4", "xfs", 3600, 2*time.Hour),
+			due:    true,
+		},
+		"Trimmed within the period": {
+			volume:      stagedVolume("pvc-5", "xfs", 3600, 48*time.Hour),
+			lastTrimmed: 30 * time.Minute,
+		},
+		"Trimmed before the period": {
+			volume:      stagedVolume("pvc-6", "xfs", 3600, 48*time.Hour),
+			lastTrimmed: 2 * time.Hour,
+			due:         true,
+		},
+	}
+	for name, test := range tests {
+		staged := map[string][]*stagedISCSIVolume{"iqn.1992-08.com.netapp:sn.1234:vs.3": {test.volume}}
+		lastTrimmed := make(map[string]time.Time)
+		if test.lastTrimmed > 0 {
+			lastTrimmed[test.volume.volumeID] = now.Add(-test.lastTrimmed)
+		}
+
+		due := volumesDueForTrim(staged, lastTrimmed, now)
+		if test.due {
+			assert.Equal(t, []*stagedISCSIVolume{test.volume}, due, name)
+		} else {
+			assert.Empty(t, due, name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/netapp/trident/storage"
+)
+
+const nodeTopologyTimeout = 30 * time.Second
+
+// getNodeTopology returns the region and zone of this node, as set by the well-known topology labels of
+// its Kubernetes node object.  A node outside Kubernetes, or one that can't be read, has no topology, so
+// only volumes accessible from anywhere are scheduled to it.
+func (p *Plugin) getNodeTopology() map[string]string {
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.WithField("error", err).Debug("Not running in Kubernetes, node has no topology.")
+		return nil
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.WithField("error", err).Warning("Could not create Kubernetes client, node has no topology.")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeTopologyTimeout)
+	defer cancel()
+
+	node, err := kubeClient.CoreV1().Nodes().Get(ctx, p.nodeName, metav1.GetOptions{})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"node":  p.nodeName,
+			"error": err,
+		}).Warning("Could not read Kubernetes node, node has no topology.")
+		return nil
+	}
+
+	return getNodeTopologyFromLabels(node.Labels)
+}
+
+// getNodeTopologyFromLabels returns the topology segment named by a node's region and zone labels.
+func getNodeTopologyFromLabels(labels map[string]string) map[string]string {
+	topology := make(map[string]string)
+	for _, key := range []string{storage.TopologyRegionKey, storage.TopologyZoneKey} {
+		if value := labels[key]; value != "" {
+			topology[key] = value
+		}
+	}
+	return topology
+}
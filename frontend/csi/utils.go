@@ -6,12 +6,18 @@ package csi
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
-	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/netapp/trident/utils"
 )
 
 func ParseEndpoint(ep string) (string, string, error) {
@@ -48,14 +54,48 @@ func NewNodeServiceCapability(cap csi.NodeServiceCapability_RPC_Type) *csi.NodeS
 	}
 }
 
+// makeListToken returns an opaque pagination token that resumes a list at the entry with the given key.
+func makeListToken(key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+// getListPage returns the bounds of the page of a list of sorted keys that begins at a pagination token
+// and has at most maxEntries entries, along with the token for the next page, if any.  The page starts
+// at the token's key, or at the key after it if that entry has since been deleted, so that deleting
+// entries between calls doesn't cause others to be skipped or listed twice.
+func getListPage(keys []string, startingToken string, maxEntries int32) (start, end int, nextToken string, err error) {
+
+	if maxEntries < 0 {
+		return 0, 0, "", status.Errorf(codes.InvalidArgument, "max_entries value '%d' is not valid", maxEntries)
+	} else if maxEntries == 0 {
+		maxEntries = math.MaxInt16
+	}
+
+	if startingToken != "" {
+		startingKey, decodeErr := base64.StdEncoding.DecodeString(startingToken)
+		if decodeErr != nil || len(startingKey) == 0 {
+			return 0, 0, "", status.Errorf(codes.Aborted, "starting_token '%s' is not valid", startingToken)
+		}
+		start = sort.SearchStrings(keys, string(startingKey))
+	}
+
+	end = len(keys)
+	if end-start > int(maxEntries) {
+		end = start + int(maxEntries)
+		nextToken = makeListToken(keys[end])
+	}
+	return start, end, nextToken, nil
+}
+
 func logGRPC(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	log.Debugf("GRPC call: %s", info.FullMethod)
-	log.Debugf("GRPC request: %+v", req)
+	ctx = utils.GenerateRequestContext(ctx, "", utils.ContextSourceCSI)
+	utils.Logc(ctx).Debugf("GRPC call: %s", info.FullMethod)
+	utils.Logc(ctx).Debugf("GRPC request: %+v", req)
 	resp, err := handler(ctx, req)
 	if err != nil {
-		log.Errorf("GRPC error: %v", err)
+		utils.Logc(ctx).Errorf("GRPC error: %v", err)
 	} else {
-		log.Debugf("GRPC response: %+v", resp)
+		utils.Logc(ctx).Debugf("GRPC response: %+v", resp)
 	}
 	return resp, err
 }
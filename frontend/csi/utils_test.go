@@ -0,0 +1,46 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package csi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetListPage(t *testing.T) {
+
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	// All entries fit on one page
+	start, end, nextToken, err := getListPage(keys, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 5, end)
+	assert.Empty(t, nextToken)
+
+	// Pages resume where the previous one stopped
+	start, end, nextToken, err = getListPage(keys, "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys[start:end])
+	assert.Equal(t, makeListToken("c"), nextToken)
+
+	start, end, nextToken, err = getListPage(keys, nextToken, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, keys[start:end])
+
+	// Deleting the next page's first entry doesn't skip the rest
+	remaining := []string{"a", "b", "c", "d", "f"}
+	start, end, nextToken, err = getListPage(remaining, nextToken, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f"}, remaining[start:end])
+	assert.Empty(t, nextToken)
+
+	// Bad arguments are rejected
+	_, _, _, err = getListPage(keys, "invalid-token", 2)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	_, _, _, err = getListPage(keys, "", -1)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
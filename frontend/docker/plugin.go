@@ -3,6 +3,7 @@
 package docker
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -170,7 +171,9 @@ func (p *Plugin) Version() string {
 
 func (p *Plugin) Create(request *volume.CreateRequest) error {
 
-	log.WithFields(log.Fields{
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceDocker)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"method":  "Create",
 		"name":    request.Name,
 		"options": request.Options,
@@ -197,9 +200,9 @@ func (p *Plugin) Create(request *volume.CreateRequest) error {
 
 	// Invoke the orchestrator to create or clone the new volume
 	if volConfig.CloneSourceVolume != "" {
-		_, err = p.orchestrator.CloneVolume(volConfig)
+		_, err = p.orchestrator.CloneVolume(ctx, volConfig)
 	} else {
-		_, err = p.orchestrator.AddVolume(volConfig)
+		_, err = p.orchestrator.AddVolume(ctx, volConfig)
 	}
 	return p.dockerError(err)
 }
@@ -232,7 +235,9 @@ func (p *Plugin) List() (*volume.ListResponse, error) {
 
 func (p *Plugin) Get(request *volume.GetRequest) (*volume.GetResponse, error) {
 
-	log.WithFields(log.Fields{
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceDocker)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"method": "Get",
 		"name":   request.Name,
 	}).Debug("Docker frontend method is invoked")
@@ -251,7 +256,7 @@ func (p *Plugin) Get(request *volume.GetRequest) (*volume.GetResponse, error) {
 	}
 
 	// Get the volume's snapshots and convert to struct Docker expects
-	snapshots, err := p.orchestrator.ReadSnapshotsForVolume(request.Name)
+	snapshots, err := p.orchestrator.ReadSnapshotsForVolume(ctx, request.Name)
 	if err != nil {
 		return &volume.GetResponse{}, p.dockerError(err)
 	}
@@ -280,14 +285,16 @@ func (p *Plugin) Get(request *volume.GetRequest) (*volume.GetResponse, error) {
 
 func (p *Plugin) Remove(request *volume.RemoveRequest) error {
 
-	log.WithFields(log.Fields{
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceDocker)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"method": "Remove",
 		"name":   request.Name,
 	}).Debug("Docker frontend method is invoked.")
 
-	err := p.orchestrator.DeleteVolume(request.Name)
+	err := p.orchestrator.DeleteVolume(ctx, request.Name)
 	if err != nil {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume": request.Name,
 			"error":  err,
 		}).Warn("Could not delete volume.")
@@ -317,7 +324,9 @@ func (p *Plugin) Path(request *volume.PathRequest) (*volume.PathResponse, error)
 
 func (p *Plugin) Mount(request *volume.MountRequest) (*volume.MountResponse, error) {
 
-	log.WithFields(log.Fields{
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceDocker)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"method": "Mount",
 		"name":   request.Name,
 		"id":     request.ID,
@@ -332,16 +341,16 @@ func (p *Plugin) Mount(request *volume.MountRequest) (*volume.MountResponse, err
 
 	// First call PublishVolume to make the volume available to the node
 	publishInfo := &utils.VolumePublishInfo{Localhost: true}
-	if err = p.orchestrator.PublishVolume(request.Name, publishInfo); err != nil {
+	if err = p.orchestrator.PublishVolume(ctx, request.Name, publishInfo); err != nil {
 		err = fmt.Errorf("error publishing volume %s: %v", request.Name, err)
-		log.Error(err)
+		utils.Logc(ctx).Error(err)
 		return &volume.MountResponse{}, p.dockerError(err)
 	}
 
 	// Then call AttachVolume to discover/format/mount the volume on the node
 	if err = p.orchestrator.AttachVolume(request.Name, mountpoint, publishInfo); err != nil {
 		err = fmt.Errorf("error attaching volume %v, mountpoint %v, error: %v", request.Name, mountpoint, err)
-		log.Error(err)
+		utils.Logc(ctx).Error(err)
 		return &volume.MountResponse{}, p.dockerError(err)
 	}
 
@@ -8,3 +8,24 @@ type Plugin interface {
 	GetName() string
 	Version() string
 }
+
+// Types of the events reported by a VolumeEventRecorder
+const (
+	EventTypeNormal  = "Normal"
+	EventTypeWarning = "Warning"
+)
+
+// VolumeEventRecorder is implemented by frontends that can report events about a volume to
+// the container orchestrator.
+type VolumeEventRecorder interface {
+	// RecordVolumeEvent accepts the name of a volume and writes the specified event message
+	// in a manner appropriate to the container orchestrator.
+	RecordVolumeEvent(name, eventType, reason, message string)
+}
+
+// HealthChecker is implemented by frontends that can check that they are serving their clients, such as
+// a CSI server accepting connections from the container orchestrator.
+type HealthChecker interface {
+	// CheckHealth returns an error if the frontend isn't serving its clients.
+	CheckHealth() error
+}
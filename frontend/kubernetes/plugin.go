@@ -47,6 +47,7 @@ import (
 type KubernetesPlugin interface {
 	frontend.Plugin
 	ImportVolume(request *storage.ImportVolumeRequest) (*storage.VolumeExternal, error)
+	CloneSnapshot(request *storage.CloneSnapshotRequest) (*storage.VolumeExternal, error)
 }
 
 // StorageClassSummary captures relevant fields in the storage class that are needed during PV creation or PV resize.
@@ -637,6 +638,11 @@ func (p *Plugin) processPendingClaim(claim *v1.PersistentVolumeClaim) {
 	}).Infof("Kubernetes frontend %s", message)
 }
 
+// CloneSnapshot is not supported by the legacy frontend, which cannot clone snapshots to new PVCs.
+func (p *Plugin) CloneSnapshot(request *storage.CloneSnapshotRequest) (*storage.VolumeExternal, error) {
+	return nil, fmt.Errorf("cloning snapshots to new PVCs requires Trident to run as a CSI provisioner")
+}
+
 func (p *Plugin) ImportVolume(request *storage.ImportVolumeRequest) (*storage.VolumeExternal, error) {
 
 	log.WithField("request", request).Debug("ImportVolume")
@@ -774,7 +780,7 @@ func (p *Plugin) ImportVolume(request *storage.ImportVolumeRequest) (*storage.Vo
 		return nil
 	}
 
-	volumeExternal, err := p.orchestrator.LegacyImportVolume(volConfig, request.Backend, request.NoManage, createPVandPVC)
+	volumeExternal, err := p.orchestrator.LegacyImportVolume(ctx(), volConfig, request.Backend, request.NoManage, createPVandPVC)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"name":     volConfig.Name,
@@ -838,7 +844,7 @@ func (p *Plugin) createVolumeFromConfig(
 	}
 
 	if volConfig.CloneSourceVolume == "" {
-		vol, err = p.orchestrator.AddVolume(volConfig)
+		vol, err = p.orchestrator.AddVolume(ctx(), volConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -897,7 +903,7 @@ func (p *Plugin) createVolumeFromConfig(
 		volConfig.CloneSourceVolume = getUniqueClaimName(pvc)
 
 		// 5) Clone the existing volume
-		vol, err = p.orchestrator.CloneVolume(volConfig)
+		vol, err = p.orchestrator.CloneVolume(ctx(), volConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -1007,7 +1013,7 @@ func (p *Plugin) createPV(
 		nfsSource = CreateNFSVolumeSource(volume)
 		pv.Spec.NFS = nfsSource
 
-	case drivers.FakeStorageDriverName:
+	case drivers.FakeStorageDriverName, drivers.FakeOntapStorageDriverName:
 		if volume.Config.Protocol == config.File {
 			nfsSource = CreateNFSVolumeSource(volume)
 			pv.Spec.NFS = nfsSource
@@ -1060,7 +1066,7 @@ func (p *Plugin) createVolumeAndPV(
 		if volExternal != nil && err != nil {
 			err1 := err
 			// Delete the volume on the backend
-			err = p.orchestrator.DeleteVolume(volExternal.Config.Name)
+			err = p.orchestrator.DeleteVolume(ctx(), volExternal.Config.Name)
 			if err != nil {
 				err2 := "Kubernetes frontend couldn't delete the volume after failed creation: " + err.Error()
 				log.WithFields(log.Fields{
@@ -1141,7 +1147,7 @@ func (p *Plugin) getPVMountOptions(scSummary *StorageClassSummary, volume *stora
 }
 
 func (p *Plugin) deleteVolumeAndPV(pv *v1.PersistentVolume) error {
-	err := p.orchestrator.DeleteVolume(pv.GetName())
+	err := p.orchestrator.DeleteVolume(ctx(), pv.GetName())
 	if err != nil && !utils.IsNotFoundError(err) {
 		message := fmt.Sprintf("failed to delete the volume for PV %s: %s. Volume and PV may "+
 			"need to be manually deleted.", pv.GetName(), err.Error())
@@ -1257,7 +1263,7 @@ func (p *Plugin) processDeletedVolume(pv *v1.PersistentVolume) {
 	if vol, _ := p.orchestrator.GetVolume(pv.Name); vol == nil {
 		return
 	}
-	err = p.orchestrator.DeleteVolume(pv.Name)
+	err = p.orchestrator.DeleteVolume(ctx(), pv.Name)
 	if err != nil {
 		message := "failed to delete the provisioned volume for the lost PVC."
 		p.updatePVCWithEvent(claim, v1.EventTypeWarning, "FailedVolumeDelete", message)
@@ -1317,7 +1323,7 @@ func (p *Plugin) processUpdatedVolume(pv *v1.PersistentVolume) {
 		if pv.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete {
 			return
 		}
-		err := p.orchestrator.DeleteVolume(pv.Name)
+		err := p.orchestrator.DeleteVolume(ctx(), pv.Name)
 		if err != nil && !utils.IsNotFoundError(err) {
 			// Updating the PV's phase to "VolumeFailed", so that a storage admin can take action.
 			message := fmt.Sprintf("failed to delete the volume for PV %s: %s. Will eventually retry, "+
@@ -1855,7 +1861,7 @@ func (p *Plugin) resizeVolumeAndPV(pv *v1.PersistentVolume, newSize resource.Qua
 	pvSize := pv.Spec.Capacity[v1.ResourceStorage]
 	if pvSize.Cmp(newSize) < 0 {
 		// Calling the orchestrator to resize the volume on the storage backend.
-		if err := p.orchestrator.ResizeVolume(pv.Name,
+		if err := p.orchestrator.ResizeVolume(ctx(), pv.Name,
 			fmt.Sprintf("%d", newSize.Value())); err != nil {
 			return pv, err
 		}
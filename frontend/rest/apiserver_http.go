@@ -19,14 +19,15 @@ type APIServerHTTP struct {
 	server *http.Server
 }
 
-func NewHTTPServer(p core.Orchestrator, address, port string) *APIServerHTTP {
+// NewHTTPServer returns the HTTP REST frontend.  Without an auth config, every caller is an admin.
+func NewHTTPServer(p core.Orchestrator, address, port string, authConfig *AuthConfig) *APIServerHTTP {
 
 	orchestrator = p
 
 	apiServer := &APIServerHTTP{
 		server: &http.Server{
 			Addr:         fmt.Sprintf("%s:%s", address, port),
-			Handler:      NewRouter(),
+			Handler:      &authHandler{handler: NewRouter(), authConfig: authConfig},
 			ReadTimeout:  config.HTTPTimeout,
 			WriteTimeout: config.HTTPTimeout,
 		},
@@ -23,17 +23,24 @@ type APIServerHTTPS struct {
 	serverKeyFile  string
 }
 
+// NewHTTPSServer returns the HTTPS REST frontend.  Without an auth config, only Trident nodes may call it,
+// with their client certificate; otherwise callers may also present a bearer token instead.
 func NewHTTPSServer(
-	p core.Orchestrator, address, port, caCertFile, serverCertFile, serverKeyFile string,
+	p core.Orchestrator, address, port, caCertFile, serverCertFile, serverKeyFile string, authConfig *AuthConfig,
 ) (*APIServerHTTPS, error) {
 
 	orchestrator = p
 
+	clientAuth := tls.RequireAndVerifyClientCert
+	if authConfig != nil {
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
 	apiServer := &APIServerHTTPS{
 		server: &http.Server{
 			Addr:         fmt.Sprintf("%s:%s", address, port),
-			Handler:      &tlsAuthHandler{handler: NewRouter()},
-			TLSConfig:    &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert},
+			Handler:      &authHandler{handler: NewRouter(), authConfig: authConfig},
+			TLSConfig:    &tls.Config{ClientAuth: clientAuth},
 			ReadTimeout:  config.HTTPTimeout,
 			WriteTimeout: config.HTTPTimeout,
 		},
@@ -82,19 +89,3 @@ func (s *APIServerHTTPS) GetName() string {
 func (s *APIServerHTTPS) Version() string {
 	return config.OrchestratorAPIVersion
 }
-
-type tlsAuthHandler struct {
-	handler http.Handler
-}
-
-func (h *tlsAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-
-	// Service requests from Trident nodes with a valid client certificate
-	if len(r.TLS.PeerCertificates) > 0 && r.TLS.PeerCertificates[0].Subject.CommonName == config.ClientCertName {
-		log.WithField("peerCert", config.ClientCertName).Debug("Authenticated by HTTPS REST frontend.")
-		h.handler.ServeHTTP(w, r)
-	} else {
-		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=\"%s\"", config.OrchestratorName))
-		w.WriteHeader(http.StatusUnauthorized)
-	}
-}
@@ -0,0 +1,361 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package rest
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/audit"
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/utils"
+)
+
+/////////////////////////////////////////////////////////////////////////////
+//
+// This file contains the code that authenticates REST callers, authorizes
+// their calls by role, and audits the calls that change Trident's state.
+//
+/////////////////////////////////////////////////////////////////////////////
+
+const (
+	// RoleReadOnly may call every GET endpoint
+	RoleReadOnly = "read-only"
+	// RoleVolumeAdmin may also change volumes and snapshots
+	RoleVolumeAdmin = "volume-admin"
+	// RoleBackendAdmin may also change backends, storage classes, and quotas
+	RoleBackendAdmin = "backend-admin"
+	// RoleAdmin may call every endpoint
+	RoleAdmin = "admin"
+
+	scopeRead    = "read"
+	scopeVolume  = "volume"
+	scopeBackend = "backend"
+	scopeAdmin   = "admin"
+
+	// localUser names the unauthenticated callers on the same host, such as tridentctl in the Trident pod
+	localUser = "local"
+)
+
+// roleScopes are the scopes of the endpoints each role may call.
+var roleScopes = map[string][]string{
+	RoleReadOnly:     {scopeRead},
+	RoleVolumeAdmin:  {scopeRead, scopeVolume},
+	RoleBackendAdmin: {scopeRead, scopeBackend},
+	RoleAdmin:        {scopeRead, scopeVolume, scopeBackend, scopeAdmin},
+}
+
+// routeScopes are the scopes of the routes that change Trident's state.  Every GET route is in the read
+// scope, and any other route not listed here may be called only by admins.
+var routeScopes = map[string]string{
+	"AddBackend":            scopeBackend,
+	"ValidateBackend":       scopeBackend,
+	"UpdateBackend":         scopeBackend,
+	"UpdateBackendState":    scopeBackend,
+	"UpdateBackendAPITrace": scopeBackend,
+	"UpdateBackendLogging":  scopeBackend,
+	"RefreshBackendPools":   scopeBackend,
+	"DeleteBackend":         scopeBackend,
+	"AddStorageClass":       scopeBackend,
+	"DeleteStorageClass":    scopeBackend,
+	"AddQuota":              scopeBackend,
+	"DeleteQuota":           scopeBackend,
+
+	"AddVolume":               scopeVolume,
+	"UpdateVolume":            scopeVolume,
+	"MoveVolume":              scopeVolume,
+	"MigrateVolume":           scopeVolume,
+	"PublishVolume":           scopeVolume,
+	"UpdateVolumePublication": scopeVolume,
+	"AddVolumeEvent":          scopeVolume,
+	"DeleteVolume":            scopeVolume,
+	"ImportVolume":            scopeVolume,
+	"UpgradeVolume":           scopeVolume,
+	"RecoverVolume":           scopeVolume,
+	"AddSnapshot":             scopeVolume,
+	"DeleteSnapshot":          scopeVolume,
+	"RestoreSnapshot":         scopeVolume,
+	"CloneSnapshot":           scopeVolume,
+}
+
+// probePaths are the paths of the health endpoints, which may be called without authenticating so that
+// Kubernetes probes need no credentials.
+var probePaths = map[string]bool{
+	config.LivenessURL:  true,
+	config.ReadinessURL: true,
+}
+
+// AuthConfig names the callers of the REST API and the role of each.  Callers present a bearer token in
+// the Authorization header, or a client certificate to the HTTPS interface.  Unauthenticated calls from
+// the same host, such as those of tridentctl in the Trident pod, are given the local role, if one is set.
+type AuthConfig struct {
+	LocalRole    string               `json:"localRole,omitempty"`
+	Tokens       []*TokenAuthConfig   `json:"tokens,omitempty"`
+	Certificates []*CertificateConfig `json:"certificates,omitempty"`
+}
+
+// TokenAuthConfig grants a role to the callers presenting a bearer token.  The token may be given as its
+// hex-encoded SHA-256 hash, so that the config file need not hold the token itself.
+type TokenAuthConfig struct {
+	Name        string `json:"name"`
+	Token       string `json:"token,omitempty"`
+	TokenSHA256 string `json:"tokenSHA256,omitempty"`
+	Role        string `json:"role"`
+}
+
+// CertificateConfig grants a role to the callers presenting a client certificate with a common name.
+type CertificateConfig struct {
+	CommonName string `json:"commonName"`
+	Role       string `json:"role"`
+}
+
+// LoadAuthConfig reads an AuthConfig from a JSON or YAML file.
+func LoadAuthConfig(path string) (*AuthConfig, error) {
+
+	configBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read REST auth config file; %v", err)
+	}
+
+	authConfig := &AuthConfig{}
+	if err = yaml.Unmarshal(configBytes, authConfig); err != nil {
+		return nil, fmt.Errorf("could not parse REST auth config file; %v", err)
+	}
+	if err = authConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid REST auth config file; %v", err)
+	}
+
+	return authConfig, nil
+}
+
+// Validate checks that every caller has a name, a credential, and a known role, and stores each token's
+// hash in place of the token.
+func (c *AuthConfig) Validate() error {
+
+	if c.LocalRole != "" && !isValidRole(c.LocalRole) {
+		return fmt.Errorf("unknown local role %s", c.LocalRole)
+	}
+
+	names := make(map[string]bool)
+	for _, token := range c.Tokens {
+		if token.Name == "" {
+			return fmt.Errorf("a token has no name")
+		} else if names[token.Name] {
+			return fmt.Errorf("token %s is named more than once", token.Name)
+		} else if !isValidRole(token.Role) {
+			return fmt.Errorf("unknown role %s for token %s", token.Role, token.Name)
+		}
+		names[token.Name] = true
+
+		if token.Token != "" {
+			hash := sha256.Sum256([]byte(token.Token))
+			token.TokenSHA256 = hex.EncodeToString(hash[:])
+			token.Token = ""
+		}
+		if hash, err := hex.DecodeString(token.TokenSHA256); err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("token %s has no token or a tokenSHA256 that is not a SHA-256 hash", token.Name)
+		}
+		token.TokenSHA256 = strings.ToLower(token.TokenSHA256)
+	}
+
+	for _, certificate := range c.Certificates {
+		if certificate.CommonName == "" {
+			return fmt.Errorf("a certificate has no common name")
+		} else if !isValidRole(certificate.Role) {
+			return fmt.Errorf("unknown role %s for certificate %s", certificate.Role, certificate.CommonName)
+		}
+	}
+
+	return nil
+}
+
+func isValidRole(role string) bool {
+	_, ok := roleScopes[role]
+	return ok
+}
+
+// caller identifies the authenticated caller of a REST endpoint.
+type caller struct {
+	User string
+	Role string
+}
+
+type callerContextKey struct{}
+
+func getCaller(r *http.Request) *caller {
+	if c, ok := r.Context().Value(callerContextKey{}).(*caller); ok {
+		return c
+	}
+	return nil
+}
+
+// authenticate identifies the caller of a request.  Without an auth config, every caller of the HTTP
+// interface is an admin, as is the Trident node client certificate on the HTTPS interface, so that the
+// API behaves as it always has.  A nil caller is returned if the request is not authenticated.
+func authenticate(r *http.Request, authConfig *AuthConfig) *caller {
+
+	// Trident nodes are always admins, as they publish volumes and register themselves
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 &&
+		r.TLS.PeerCertificates[0].Subject.CommonName == config.ClientCertName {
+		return &caller{User: config.ClientCertName, Role: RoleAdmin}
+	}
+
+	if authConfig == nil {
+		if r.TLS == nil {
+			return &caller{Role: RoleAdmin}
+		}
+		return nil
+	}
+
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		hash := sha256.Sum256([]byte(strings.TrimPrefix(authorization, "Bearer ")))
+		hexHash := []byte(hex.EncodeToString(hash[:]))
+		for _, token := range authConfig.Tokens {
+			if subtle.ConstantTimeCompare(hexHash, []byte(token.TokenSHA256)) == 1 {
+				return &caller{User: token.Name, Role: token.Role}
+			}
+		}
+		return nil
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		commonName := r.TLS.PeerCertificates[0].Subject.CommonName
+		for _, certificate := range authConfig.Certificates {
+			if certificate.CommonName == commonName {
+				return &caller{User: commonName, Role: certificate.Role}
+			}
+		}
+		return nil
+	}
+
+	if r.TLS == nil && authConfig.LocalRole != "" && isLoopbackRequest(r) {
+		return &caller{User: localUser, Role: authConfig.LocalRole}
+	}
+
+	return nil
+}
+
+func isLoopbackRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// authHandler rejects the requests whose callers can't be authenticated, and passes the others on with
+// their callers in their contexts.
+type authHandler struct {
+	handler    http.Handler
+	authConfig *AuthConfig
+}
+
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	c := authenticate(r, h.authConfig)
+	if c == nil && probePaths[r.URL.Path] {
+		c = &caller{Role: RoleReadOnly}
+	}
+	if c == nil {
+		log.WithField("remoteAddr", r.RemoteAddr).Warning("Unauthenticated REST API call rejected.")
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer realm=\"%s\"", config.OrchestratorName))
+		writeHTTPResponse(w, &authErrorResponse{Error: "not authenticated"}, http.StatusUnauthorized)
+		return
+	}
+
+	log.WithFields(log.Fields{"user": c.User, "role": c.Role}).Debug("Authenticated by REST frontend.")
+	h.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerContextKey{}, c)))
+}
+
+type authErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// routeScope returns the scope of a route.
+func routeScope(routeName, method string) string {
+	if method == http.MethodGet {
+		return scopeRead
+	}
+	if scope, ok := routeScopes[routeName]; ok {
+		return scope
+	}
+	return scopeAdmin
+}
+
+// isAuthorized returns true if a role may call the endpoints in a scope.
+func isAuthorized(role, scope string) bool {
+	for _, roleScope := range roleScopes[role] {
+		if roleScope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorizer rejects the calls to a route by callers whose roles don't cover its scope, and records the
+// calls to any route that may change Trident's state in the audit log, with the caller and outcome.
+func Authorizer(inner http.Handler, routeName, method string) http.Handler {
+
+	scope := routeScope(routeName, method)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		c := getCaller(r)
+		if c == nil {
+			c = &caller{}
+		}
+
+		lrw := NewLoggingResponseWriter(w)
+		if !isAuthorized(c.Role, scope) {
+			log.WithFields(log.Fields{
+				"user":  c.User,
+				"role":  c.Role,
+				"route": routeName,
+			}).Warning("Unauthorized REST API call rejected.")
+			writeHTTPResponse(lrw, &authErrorResponse{
+				Error: fmt.Sprintf("role %s may not call %s", c.Role, routeName)}, http.StatusForbidden)
+		} else {
+			inner.ServeHTTP(lrw, r)
+		}
+
+		if scope != scopeRead {
+			recordRESTAuditEvent(r, routeName, c, lrw.statusCode)
+		}
+	})
+}
+
+// recordRESTAuditEvent records a call to a REST endpoint that may change Trident's state.
+func recordRESTAuditEvent(r *http.Request, routeName string, c *caller, statusCode int) {
+
+	vars := mux.Vars(r)
+	event := &audit.Event{
+		Time:          time.Now(),
+		Backend:       vars["backend"],
+		Host:          r.RemoteAddr,
+		User:          c.User,
+		RequestSource: utils.ContextSourceREST,
+		Operation:     routeName,
+		Volume:        vars["volume"],
+		Object:        r.URL.Path,
+		Status:        audit.StatusPassed,
+	}
+	if statusCode >= http.StatusBadRequest {
+		event.Status = audit.StatusFailed
+		event.ErrorCode = fmt.Sprintf("%d", statusCode)
+		event.Reason = http.StatusText(statusCode)
+	}
+	audit.Record(event)
+}
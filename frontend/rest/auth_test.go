@@ -0,0 +1,172 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package rest
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/audit"
+	"github.com/netapp/trident/config"
+)
+
+func getTestAuthConfig(t *testing.T) *AuthConfig {
+
+	hash := sha256.Sum256([]byte("volumes-token"))
+	authConfig := &AuthConfig{
+		LocalRole: RoleAdmin,
+		Tokens: []*TokenAuthConfig{
+			{Name: "dashboard", Token: "read-token", Role: RoleReadOnly},
+			{Name: "provisioner", TokenSHA256: hex.EncodeToString(hash[:]), Role: RoleVolumeAdmin},
+		},
+		Certificates: []*CertificateConfig{
+			{CommonName: "storage-team", Role: RoleBackendAdmin},
+		},
+	}
+	if err := authConfig.Validate(); err != nil {
+		t.Fatal("Invalid auth config: ", err)
+	}
+	return authConfig
+}
+
+func TestAuthConfigValidate(t *testing.T) {
+
+	authConfig := getTestAuthConfig(t)
+	assert.Empty(t, authConfig.Tokens[0].Token, "token kept in memory")
+	assert.Len(t, authConfig.Tokens[0].TokenSHA256, 2*sha256.Size)
+
+	for name, invalidConfig := range map[string]*AuthConfig{
+		"local role": {LocalRole: "root"},
+		"token role": {Tokens: []*TokenAuthConfig{{Name: "a", Token: "t", Role: "root"}}},
+		"token name": {Tokens: []*TokenAuthConfig{{Token: "t", Role: RoleAdmin}}},
+		"no token":   {Tokens: []*TokenAuthConfig{{Name: "a", Role: RoleAdmin}}},
+		"bad hash":   {Tokens: []*TokenAuthConfig{{Name: "a", TokenSHA256: "abc", Role: RoleAdmin}}},
+		"cert role":  {Certificates: []*CertificateConfig{{CommonName: "a", Role: "root"}}},
+		"cert name":  {Certificates: []*CertificateConfig{{Role: RoleAdmin}}},
+		"twice named": {Tokens: []*TokenAuthConfig{
+			{Name: "a", Token: "t1", Role: RoleAdmin}, {Name: "a", Token: "t2", Role: RoleAdmin}}},
+	} {
+		assert.Error(t, invalidConfig.Validate(), name)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+
+	authConfig := getTestAuthConfig(t)
+
+	newRequest := func(remoteAddr, token, commonName string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, config.VolumeURL, nil)
+		r.RemoteAddr = remoteAddr
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if commonName != "" {
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+				{Subject: pkix.Name{CommonName: commonName}}}}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		request  *http.Request
+		expected *caller
+	}{
+		{"read token", newRequest("10.0.0.1:1234", "read-token", ""), &caller{"dashboard", RoleReadOnly}},
+		{"hashed token", newRequest("10.0.0.1:1234", "volumes-token", ""), &caller{"provisioner", RoleVolumeAdmin}},
+		{"unknown token", newRequest("127.0.0.1:1234", "other-token", ""), nil},
+		{"certificate", newRequest("10.0.0.1:1234", "", "storage-team"), &caller{"storage-team", RoleBackendAdmin}},
+		{"unknown certificate", newRequest("10.0.0.1:1234", "", "someone"), nil},
+		{"node certificate", newRequest("10.0.0.1:1234", "", config.ClientCertName),
+			&caller{config.ClientCertName, RoleAdmin}},
+		{"local", newRequest("127.0.0.1:1234", "", ""), &caller{localUser, RoleAdmin}},
+		{"remote", newRequest("10.0.0.1:1234", "", ""), nil},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, authenticate(test.request, authConfig), test.name)
+	}
+
+	// Without an auth config, only HTTP callers and Trident nodes are admins
+	assert.Equal(t, &caller{Role: RoleAdmin}, authenticate(newRequest("10.0.0.1:1234", "", ""), nil))
+	assert.Nil(t, authenticate(newRequest("10.0.0.1:1234", "", "someone"), nil))
+}
+
+func TestAuthorizer(t *testing.T) {
+
+	authConfig := getTestAuthConfig(t)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	router := mux.NewRouter()
+	for _, route := range []Route{
+		{"ListBackends", "GET", config.BackendURL, ok},
+		{"AddBackend", "POST", config.BackendURL, ok},
+		{"DeleteVolume", "DELETE", config.VolumeURL + "/{volume}", ok},
+		{"DeleteNode", "DELETE", config.NodeURL + "/{node}", ok},
+		{"GetReadiness", "GET", config.ReadinessURL, ok},
+	} {
+		router.Methods(route.Method).Path(route.Pattern).Name(route.Name).
+			Handler(Authorizer(route.HandlerFunc, route.Name, route.Method))
+	}
+	server := &authHandler{handler: router, authConfig: authConfig}
+
+	call := func(method, url, token string) int {
+		r := httptest.NewRequest(method, url, nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, call("GET", config.BackendURL, ""))
+	assert.Equal(t, http.StatusOK, call("GET", config.BackendURL, "read-token"))
+	assert.Equal(t, http.StatusForbidden, call("POST", config.BackendURL, "read-token"))
+	assert.Equal(t, http.StatusForbidden, call("POST", config.BackendURL, "volumes-token"))
+	assert.Equal(t, http.StatusOK, call("DELETE", config.VolumeURL+"/vol1", "volumes-token"))
+	assert.Equal(t, http.StatusForbidden, call("DELETE", config.NodeURL+"/node1", "volumes-token"))
+
+	// Probes need no credentials
+	assert.Equal(t, http.StatusOK, call("GET", config.ReadinessURL, ""))
+
+	// The calls to mutating routes are audited with their callers
+	events, err := audit.Query(audit.Filter{Operation: "DeleteVolume"})
+	if err != nil {
+		t.Fatal("Unable to query audit log: ", err)
+	}
+	if assert.NotEmpty(t, events) {
+		event := events[len(events)-1]
+		assert.Equal(t, "provisioner", event.User)
+		assert.Equal(t, "vol1", event.Volume)
+		assert.Equal(t, audit.StatusPassed, event.Status)
+	}
+	events, err = audit.Query(audit.Filter{Operation: "AddBackend", Failed: true})
+	if err != nil {
+		t.Fatal("Unable to query audit log: ", err)
+	}
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "403", events[0].ErrorCode)
+	}
+}
+
+func TestRouteScopes(t *testing.T) {
+
+	// Every route listed must exist, so renaming a route doesn't silently make it admin-only
+	routeNames := make(map[string]bool)
+	for _, route := range routes {
+		routeNames[route.Name] = true
+	}
+	for routeName := range routeScopes {
+		assert.True(t, routeNames[routeName], "unknown route %s", routeName)
+	}
+}
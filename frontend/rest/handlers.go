@@ -3,17 +3,23 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/core"
 	"github.com/netapp/trident/frontend/csi/helpers"
 	k8shelper "github.com/netapp/trident/frontend/csi/helpers/kubernetes"
 	"github.com/netapp/trident/frontend/kubernetes"
@@ -337,7 +343,33 @@ func GetVersion(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+// GetLiveness reports whether Trident is running and serving its frontends, for liveness probes.  The
+// status code is 503 if any critical subsystem has failed.
+func GetLiveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, orchestrator.CheckLiveness(r.Context()))
+}
+
+// GetReadiness reports whether Trident is ready to serve requests, with the health of its persistent
+// store, frontends, and backends, for readiness probes.  The status code is 503 if any critical subsystem
+// has failed.
+func GetReadiness(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, orchestrator.CheckReadiness(r.Context()))
+}
+
+func writeHealthResponse(w http.ResponseWriter, report *core.HealthReport) {
+	httpStatusCode := http.StatusOK
+	if report.Status == core.HealthFailed {
+		httpStatusCode = http.StatusServiceUnavailable
+	}
+	writeHTTPResponse(w, report, httpStatusCode)
+}
+
 func AddBackend(w http.ResponseWriter, r *http.Request) {
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		PreviewBackend(w, r)
+		return
+	}
+
 	response := &AddBackendResponse{}
 	AddGeneric(w, r, response,
 		func(body []byte) int {
@@ -353,6 +385,60 @@ func AddBackend(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+type PreviewBackendResponse struct {
+	Backend *storage.BackendPreview `json:"backend"`
+	Error   string                  `json:"error,omitempty"`
+	handler string
+}
+
+func (r *PreviewBackendResponse) setError(err error) {
+	r.Error = err.Error()
+}
+
+func (r *PreviewBackendResponse) isError() bool {
+	return r.Error != ""
+}
+
+func (r *PreviewBackendResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": r.handler,
+	}).Info("Previewed a backend.")
+}
+
+func (r *PreviewBackendResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": r.handler,
+	}).Error(r.Error)
+}
+
+// PreviewBackend resolves the storage pools of a candidate backend configuration without creating
+// the backend.  It serves backend creation requests made with the dryRun query parameter.
+func PreviewBackend(w http.ResponseWriter, r *http.Request) {
+	previewBackend(w, r, "PreviewBackend")
+}
+
+// ValidateBackend checks a candidate backend configuration as the driver would when creating the
+// backend, without creating it, and returns the resolved storage pools and the capabilities detected
+// on the storage system.  A configuration that fails validation is rejected with its error.
+func ValidateBackend(w http.ResponseWriter, r *http.Request) {
+	previewBackend(w, r, "ValidateBackend")
+}
+
+func previewBackend(w http.ResponseWriter, r *http.Request, handler string) {
+	response := &PreviewBackendResponse{handler: handler}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			preview, err := orchestrator.PreviewBackend(string(body))
+			if err != nil {
+				response.setError(err)
+			} else {
+				response.Backend = preview
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
 type UpdateBackendResponse struct {
 	BackendID string `json:"backend"`
 	Error     string `json:"error,omitempty"`
@@ -418,6 +504,444 @@ func UpdateBackendState(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func UpdateBackendAPITrace(w http.ResponseWriter, r *http.Request) {
+	response := &UpdateBackendResponse{}
+	UpdateGeneric(w, r, "backend", response,
+		func(backendName string, body []byte) int {
+			request := new(storage.UpdateBackendAPITraceRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			backend, err := orchestrator.UpdateBackendAPITrace(backendName, request.Enabled)
+			if err != nil {
+				response.Error = err.Error()
+			}
+			if backend != nil {
+				response.BackendID = backend.Name
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func UpdateBackendLogging(w http.ResponseWriter, r *http.Request) {
+	response := &UpdateBackendResponse{}
+	UpdateGeneric(w, r, "backend", response,
+		func(backendName string, body []byte) int {
+			request := new(storage.UpdateBackendLoggingRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			backend, err := orchestrator.UpdateBackendLogging(backendName, request)
+			if err != nil {
+				response.Error = err.Error()
+			}
+			if backend != nil {
+				response.BackendID = backend.Name
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+// RefreshBackendPools has a backend rediscover its storage pools.  The request has no body.
+func RefreshBackendPools(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateBackendResponse{}
+	UpdateGeneric(w, r, "backend", response,
+		func(backendName string, _ []byte) int {
+			backend, err := orchestrator.RefreshBackendPools(ctx, backendName)
+			if err != nil {
+				response.Error = err.Error()
+			}
+			if backend != nil {
+				response.BackendID = backend.Name
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListRecoverableVolumesResponse struct {
+	Volumes []*storage.RecoverableVolume `json:"volumes"`
+	Error   string                       `json:"error,omitempty"`
+}
+
+func ListRecoverableVolumes(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &ListRecoverableVolumesResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			volumes, err := orchestrator.ListRecoverableVolumes(ctx, backendName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Volumes = volumes
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func RecoverVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateBackendResponse{}
+	UpdateGeneric(w, r, "backend", response,
+		func(backendName string, body []byte) int {
+			request := new(storage.RecoverVolumeRequest)
+			err := json.Unmarshal(body, request)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			err = orchestrator.RecoverVolume(ctx, backendName, request.InternalName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.BackendID = backendName
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListBackendVolumesResponse struct {
+	Volumes  []*storage.BackendVolume `json:"volumes"`
+	Continue string                   `json:"continue,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// ListBackendVolumes returns one page of the volumes on a backend's storage system.  The limit query
+// parameter sets the size of the page, and the continue query parameter passes the continuation token
+// returned with the previous page.
+func ListBackendVolumes(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &ListBackendVolumesResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			query := r.URL.Query()
+			limit := 0
+			if limitValue := query.Get("limit"); limitValue != "" {
+				var err error
+				if limit, err = strconv.Atoi(limitValue); err != nil || limit < 0 {
+					err = fmt.Errorf("invalid limit: %s", limitValue)
+					response.Error = err.Error()
+					return httpStatusCodeForGetUpdateList(err)
+				}
+			}
+			page, err := orchestrator.ListBackendVolumes(ctx, backendName, limit, query.Get("continue"))
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Volumes = page.Volumes
+				response.Continue = page.Continue
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListImportCandidatesResponse struct {
+	Candidates []*storage.ImportCandidate `json:"candidates"`
+	Error      string                     `json:"error,omitempty"`
+}
+
+// ListImportCandidates returns the volumes on a backend's storage system that could be imported.  The
+// prefix and junctionPath query parameters limit them to those whose names and junction paths begin with
+// the given values, and the minSize and maxSize query parameters to those within the given sizes.
+func ListImportCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &ListImportCandidatesResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			query := r.URL.Query()
+			filter := &storage.ImportDiscoveryFilter{
+				Prefix:       query.Get("prefix"),
+				JunctionPath: query.Get("junctionPath"),
+			}
+			for param, size := range map[string]*uint64{"minSize": &filter.MinSize, "maxSize": &filter.MaxSize} {
+				value := query.Get(param)
+				if value == "" {
+					continue
+				}
+				sizeBytes, err := utils.ConvertSizeToBytes(value)
+				if err == nil {
+					*size, err = strconv.ParseUint(sizeBytes, 10, 64)
+				}
+				if err != nil {
+					err = fmt.Errorf("invalid %s: %s", param, value)
+					response.Error = err.Error()
+					return httpStatusCodeForGetUpdateList(err)
+				}
+			}
+			candidates, err := orchestrator.ListImportCandidates(ctx, backendName, filter)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Candidates = candidates
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetBackendStorageLogsResponse struct {
+	Entries []*storage.StorageLogEntry `json:"entries"`
+	Error   string                     `json:"error,omitempty"`
+}
+
+// GetBackendStorageLogs returns the recent entries of the logs of a backend's storage system.
+func GetBackendStorageLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &GetBackendStorageLogsResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			entries, err := orchestrator.GetBackendStorageLogs(ctx, backendName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Entries = entries
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetBackendBucketsResponse struct {
+	Report *storage.BucketReport `json:"report"`
+	Error  string                `json:"error,omitempty"`
+}
+
+// GetBackendBuckets reports how a backend's volumes are distributed among the buckets holding them.
+func GetBackendBuckets(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &GetBackendBucketsResponse{}
+	GetGeneric(w, r, "backend", response,
+		func(backendName string) int {
+			report, err := orchestrator.GetBackendBuckets(ctx, backendName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Report = report
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type ListAuditEventsResponse struct {
+	Events []*audit.Event `json:"events"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// ListAuditEvents returns the recorded calls that changed a storage system, filtered by the backend,
+// volume, operation, since, failed, and limit query parameters.
+func ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	response := &ListAuditEventsResponse{}
+	GetGenericNoArg(w, r, response,
+		func() int {
+			filter, err := getAuditFilter(r)
+			if err == nil {
+				response.Events, err = orchestrator.ListAuditEvents(filter)
+			}
+			if err != nil {
+				response.Error = err.Error()
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func getAuditFilter(r *http.Request) (audit.Filter, error) {
+
+	var err error
+	query := r.URL.Query()
+	filter := audit.Filter{
+		Backend:   query.Get("backend"),
+		Volume:    query.Get("volume"),
+		Operation: query.Get("operation"),
+	}
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return filter, fmt.Errorf("invalid since time: %v", err)
+		}
+	}
+	if failed := query.Get("failed"); failed != "" {
+		if filter.Failed, err = strconv.ParseBool(failed); err != nil {
+			return filter, fmt.Errorf("invalid failed value: %v", err)
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("invalid limit: %s", limit)
+		}
+	}
+	return filter, nil
+}
+
+// watchStreamPeriod is how long an object event stream is kept open.  It ends before the server's write
+// timeout would cut it off, and clients reconnect with the ID of the last event they were sent.
+const watchStreamPeriod = config.HTTPTimeout - 15*time.Second
+
+type WatchObjectsResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// WatchObjects streams the changes to Trident's volumes, snapshots, backends, and nodes as server-sent
+// events, optionally only those of the kinds in the comma-separated kind query parameter.  A client
+// resuming a stream passes the ID of the last event it was sent in the Last-Event-ID header, or the
+// since query parameter, to be sent the events it missed.
+func WatchObjects(w http.ResponseWriter, r *http.Request) {
+	response := &WatchObjectsResponse{}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		response.Error = "streaming is not supported"
+		writeHTTPResponse(w, response, http.StatusInternalServerError)
+		return
+	}
+
+	kinds, sinceID, err := getWatchFilter(r)
+	if err != nil {
+		response.Error = err.Error()
+		writeHTTPResponse(w, response, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), watchStreamPeriod)
+	defer cancel()
+
+	events, err := orchestrator.WatchObjects(ctx, sinceID)
+	if err != nil {
+		response.Error = err.Error()
+		writeHTTPResponse(w, response, httpStatusCodeForGetUpdateList(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for event := range events {
+
+		// An event without data isn't delivered, but still advances the client's last event ID
+		if len(kinds) > 0 && !kinds[event.Kind] {
+			_, err = fmt.Fprintf(w, "id: %d\n\n", event.ID)
+		} else if data, marshalErr := json.Marshal(event); marshalErr != nil {
+			log.WithFields(log.Fields{
+				"event": event.ID,
+				"error": marshalErr,
+			}).Error("Failed to marshal object event.")
+			_, err = fmt.Fprintf(w, "id: %d\n\n", event.ID)
+		} else {
+			_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.ID, data)
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func getWatchFilter(r *http.Request) (map[core.ObjectKind]bool, uint64, error) {
+
+	var err error
+	query := r.URL.Query()
+
+	kinds := make(map[core.ObjectKind]bool)
+	if kindList := query.Get("kind"); kindList != "" {
+		for _, kind := range strings.Split(kindList, ",") {
+			valid := false
+			for _, objectKind := range core.ObjectKinds {
+				if core.ObjectKind(kind) == objectKind {
+					valid = true
+				}
+			}
+			if !valid {
+				return nil, 0, fmt.Errorf("invalid kind: %s", kind)
+			}
+			kinds[core.ObjectKind(kind)] = true
+		}
+	}
+
+	var sinceID uint64
+	since := r.Header.Get("Last-Event-ID")
+	if since == "" {
+		since = query.Get("since")
+	}
+	if since != "" {
+		if sinceID, err = strconv.ParseUint(since, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid event ID: %s", since)
+		}
+	}
+
+	return kinds, sinceID, nil
+}
+
+type ListStorageJobsResponse struct {
+	Jobs   []*storage.StorageJob `json:"jobs"`
+	Counts map[string]int        `json:"counts"`
+	Error  string                `json:"error,omitempty"`
+}
+
+// ListStorageJobs returns the operations the backends are running in the background, optionally only
+// those of the backend named by the backend query parameter, and the number of them on each backend.
+func ListStorageJobs(w http.ResponseWriter, r *http.Request) {
+	response := &ListStorageJobsResponse{}
+	GetGenericNoArg(w, r, response,
+		func() int {
+			jobs, err := orchestrator.ListStorageJobs()
+			if err != nil {
+				response.Error = err.Error()
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			backendName := r.URL.Query().Get("backend")
+			response.Jobs = make([]*storage.StorageJob, 0)
+			response.Counts = make(map[string]int)
+			for _, job := range jobs {
+				if backendName == "" || job.Backend == backendName {
+					response.Jobs = append(response.Jobs, job)
+					response.Counts[job.Backend]++
+				}
+			}
+			return httpStatusCodeForGetUpdateList(nil)
+		},
+	)
+}
+
+type ListOrphanedResourcesResponse struct {
+	Resources []*storage.OrphanedResource `json:"resources"`
+	Error     string                      `json:"error,omitempty"`
+}
+
+// ListOrphanedResources returns the orphaned resources found on the backends' storage systems, optionally
+// only those of the backend named by the backend query parameter.
+func ListOrphanedResources(w http.ResponseWriter, r *http.Request) {
+	response := &ListOrphanedResourcesResponse{}
+	GetGenericNoArg(w, r, response,
+		func() int {
+			resources, err := orchestrator.ListOrphanedResources()
+			if err != nil {
+				response.Error = err.Error()
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			backendName := r.URL.Query().Get("backend")
+			response.Resources = make([]*storage.OrphanedResource, 0)
+			for _, resource := range resources {
+				if backendName == "" || resource.Backend == backendName {
+					response.Resources = append(response.Resources, resource)
+				}
+			}
+			return httpStatusCodeForGetUpdateList(nil)
+		},
+	)
+}
+
 type ListBackendsResponse struct {
 	Backends []string `json:"backends"`
 	Error    string   `json:"error,omitempty"`
@@ -481,122 +1005,401 @@ func GetBackend(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
-func GetBackendByBackendUUID(w http.ResponseWriter, r *http.Request) {
-	response := &GetBackendResponse{}
-	GetGeneric(w, r, "backendUUID", response,
-		func(backendUUID string) int {
-			backend, err := orchestrator.GetBackendByBackendUUID(backendUUID)
+func GetBackendByBackendUUID(w http.ResponseWriter, r *http.Request) {
+	response := &GetBackendResponse{}
+	GetGeneric(w, r, "backendUUID", response,
+		func(backendUUID string) int {
+			backend, err := orchestrator.GetBackendByBackendUUID(backendUUID)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Backend = backend
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+// DeleteBackend calls OfflineBackend in the orchestrator, as we currently do
+// not allow for full deletion of backends due to the potential for race
+// conditions and the additional bookkeeping that would be required.
+func DeleteBackend(w http.ResponseWriter, r *http.Request) {
+	DeleteGeneric(w, r, orchestrator.DeleteBackend, "backend")
+}
+
+type AddVolumeResponse struct {
+	BackendID string `json:"backend"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (a *AddVolumeResponse) setError(err error) {
+	a.Error = err.Error()
+}
+
+func (a *AddVolumeResponse) isError() bool {
+	return a.Error != ""
+}
+
+func (a *AddVolumeResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "AddVolume",
+		"backend": a.BackendID,
+	}).Info("Added a new volume.")
+}
+func (a *AddVolumeResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "AddVolume",
+	}).Error(a.Error)
+}
+
+func AddVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &AddVolumeResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			volumeConfig := new(storage.VolumeConfig)
+			err := json.Unmarshal(body, volumeConfig)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			if err = volumeConfig.Validate(); err != nil {
+				response.setError(err)
+				return httpStatusCodeForAdd(err)
+			}
+			volume, err := orchestrator.AddVolume(ctx, volumeConfig)
+			if err != nil {
+				response.setError(err)
+			}
+			if volume != nil {
+				response.BackendID = volume.BackendUUID
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
+}
+
+type ListVolumesResponse struct {
+	Volumes []string `json:"volumes"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func (l *ListVolumesResponse) setList(payload []string) {
+	l.Volumes = payload
+}
+
+func ListVolumes(w http.ResponseWriter, r *http.Request) {
+	response := &ListVolumesResponse{}
+	ListGeneric(w, r, response,
+		func() int {
+			volumeNames := make([]string, 0)
+			volumes, err := orchestrator.ListVolumes()
+			if err != nil {
+				response.Error = err.Error()
+			} else if volumes != nil && len(volumes) > 0 {
+				volumeNames = make([]string, 0, len(volumes))
+				for _, volume := range volumes {
+					volumeNames = append(volumeNames, volume.Config.Name)
+				}
+			}
+			response.setList(volumeNames)
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetVolumeResponse struct {
+	Volume *storage.VolumeExternal `json:"volume"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+func GetVolume(w http.ResponseWriter, r *http.Request) {
+	response := &GetVolumeResponse{}
+	GetGeneric(w, r, "volume", response,
+		func(volName string) int {
+			volume, err := orchestrator.GetVolume(volName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Volume = volume
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type UpdateVolumeResponse struct {
+	Volume *storage.VolumeExternal `json:"volume"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+func (u *UpdateVolumeResponse) setError(err error) {
+	u.Error = err.Error()
+}
+
+func (u *UpdateVolumeResponse) isError() bool {
+	return u.Error != ""
+}
+
+func (u *UpdateVolumeResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "UpdateVolume",
+		"volume":  u.Volume.Config.Name,
+	}).Info("Updated a volume.")
+}
+
+func (u *UpdateVolumeResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "UpdateVolume",
+	}).Error(u.Error)
+}
+
+func UpdateVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateVolumeResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			updateVolumeRequest := new(storage.UpdateVolumeRequest)
+			err := json.Unmarshal(body, updateVolumeRequest)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			volume, err := orchestrator.UpdateVolume(ctx, volumeName, updateVolumeRequest)
+			if err != nil {
+				response.setError(err)
+			}
+			if volume != nil {
+				response.Volume = volume
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func MoveVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateVolumeResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			moveVolumeRequest := new(storage.MoveVolumeRequest)
+			err := json.Unmarshal(body, moveVolumeRequest)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			volume, err := orchestrator.MoveVolume(ctx, volumeName, moveVolumeRequest)
+			if err != nil {
+				response.setError(err)
+			}
+			if volume != nil {
+				response.Volume = volume
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func MigrateVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateVolumeResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			migrateVolumeRequest := new(storage.MigrateVolumeRequest)
+			err := json.Unmarshal(body, migrateVolumeRequest)
 			if err != nil {
-				response.Error = err.Error()
-			} else {
-				response.Backend = backend
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			volume, err := orchestrator.MigrateVolume(ctx, volumeName, migrateVolumeRequest)
+			if err != nil {
+				response.setError(err)
+			}
+			if volume != nil {
+				response.Volume = volume
 			}
 			return httpStatusCodeForGetUpdateList(err)
 		},
 	)
 }
 
-// DeleteBackend calls OfflineBackend in the orchestrator, as we currently do
-// not allow for full deletion of backends due to the potential for race
-// conditions and the additional bookkeeping that would be required.
-func DeleteBackend(w http.ResponseWriter, r *http.Request) {
-	DeleteGeneric(w, r, orchestrator.DeleteBackend, "backend")
-}
-
-type AddVolumeResponse struct {
-	BackendID string `json:"backend"`
-	Error     string `json:"error,omitempty"`
+type PublishVolumeResponse struct {
+	Volume      string                   `json:"volume"`
+	Node        string                   `json:"node"`
+	PublishInfo *utils.VolumePublishInfo `json:"publishInfo,omitempty"`
+	Error       string                   `json:"error,omitempty"`
 }
 
-func (a *AddVolumeResponse) setError(err error) {
-	a.Error = err.Error()
+func (p *PublishVolumeResponse) setError(err error) {
+	p.Error = err.Error()
 }
 
-func (a *AddVolumeResponse) isError() bool {
-	return a.Error != ""
+func (p *PublishVolumeResponse) isError() bool {
+	return p.Error != ""
 }
 
-func (a *AddVolumeResponse) logSuccess() {
+func (p *PublishVolumeResponse) logSuccess() {
 	log.WithFields(log.Fields{
-		"handler": "AddVolume",
-		"backend": a.BackendID,
-	}).Info("Added a new volume.")
+		"handler": "PublishVolume",
+		"volume":  p.Volume,
+		"node":    p.Node,
+	}).Info("Published a volume.")
 }
-func (a *AddVolumeResponse) logFailure() {
+
+func (p *PublishVolumeResponse) logFailure() {
 	log.WithFields(log.Fields{
-		"handler": "AddVolume",
-	}).Error(a.Error)
+		"handler": "PublishVolume",
+		"volume":  p.Volume,
+		"node":    p.Node,
+	}).Error(p.Error)
 }
 
-func AddVolume(w http.ResponseWriter, r *http.Request) {
-	response := &AddVolumeResponse{}
-	AddGeneric(w, r, response,
-		func(body []byte) int {
-			volumeConfig := new(storage.VolumeConfig)
-			err := json.Unmarshal(body, volumeConfig)
+// PublishVolume publishes a volume to a registered node, as ControllerPublishVolume would, for volumes that
+// a node provisions for itself.
+func PublishVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &PublishVolumeResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			response.Volume = volumeName
+			publishVolumeRequest := new(storage.PublishVolumeRequest)
+			err := json.Unmarshal(body, publishVolumeRequest)
 			if err != nil {
 				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
-				return httpStatusCodeForAdd(err)
+				return httpStatusCodeForGetUpdateList(err)
 			}
-			if err = volumeConfig.Validate(); err != nil {
+			if err = publishVolumeRequest.Validate(); err != nil {
 				response.setError(err)
-				return httpStatusCodeForAdd(err)
+				return httpStatusCodeForGetUpdateList(err)
 			}
-			volume, err := orchestrator.AddVolume(volumeConfig)
+			response.Node = publishVolumeRequest.Node
+
+			node, err := orchestrator.GetNode(publishVolumeRequest.Node)
 			if err != nil {
 				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
 			}
-			if volume != nil {
-				response.BackendID = volume.BackendUUID
+			volume, err := orchestrator.GetVolume(volumeName)
+			if err != nil {
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
 			}
-			return httpStatusCodeForAdd(err)
+			publishInfo := &utils.VolumePublishInfo{
+				HostIQN:   []string{node.IQN},
+				HostIP:    node.IPs,
+				HostName:  node.Name,
+				Unmanaged: volume.Config.ImportNotManaged,
+			}
+			if err = orchestrator.PublishVolume(ctx, volumeName, publishInfo); err != nil {
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.PublishInfo = publishInfo
+			return httpStatusCodeForGetUpdateList(nil)
 		},
 	)
 }
 
-type ListVolumesResponse struct {
-	Volumes []string `json:"volumes"`
-	Error   string   `json:"error,omitempty"`
+type UpdateVolumePublicationResponse struct {
+	Volume      string                   `json:"volume"`
+	Updated     bool                     `json:"updated"`
+	PublishInfo *utils.VolumePublishInfo `json:"publishInfo,omitempty"`
+	Error       string                   `json:"error,omitempty"`
 }
 
-func (l *ListVolumesResponse) setList(payload []string) {
-	l.Volumes = payload
+func (u *UpdateVolumePublicationResponse) setError(err error) {
+	u.Error = err.Error()
 }
 
-func ListVolumes(w http.ResponseWriter, r *http.Request) {
-	response := &ListVolumesResponse{}
-	ListGeneric(w, r, response,
-		func() int {
-			volumeNames := make([]string, 0)
-			volumes, err := orchestrator.ListVolumes()
+func (u *UpdateVolumePublicationResponse) isError() bool {
+	return u.Error != ""
+}
+
+func (u *UpdateVolumePublicationResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "UpdateVolumePublication",
+		"volume":  u.Volume,
+		"updated": u.Updated,
+	}).Info("Checked a volume publication.")
+}
+
+func (u *UpdateVolumePublicationResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "UpdateVolumePublication",
+		"volume":  u.Volume,
+	}).Error(u.Error)
+}
+
+// UpdateVolumePublication corrects the publish info a node holds for a volume, such as iSCSI portals
+// that have moved since the volume was published, and returns the corrected publish info.
+func UpdateVolumePublication(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &UpdateVolumePublicationResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			response.Volume = volumeName
+			publishInfo := new(utils.VolumePublishInfo)
+			err := json.Unmarshal(body, publishInfo)
 			if err != nil {
-				response.Error = err.Error()
-			} else if volumes != nil && len(volumes) > 0 {
-				volumeNames = make([]string, 0, len(volumes))
-				for _, volume := range volumes {
-					volumeNames = append(volumeNames, volume.Config.Name)
-				}
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
 			}
-			response.setList(volumeNames)
-			return httpStatusCodeForGetUpdateList(err)
+			updated, err := orchestrator.UpdateVolumePublication(ctx, volumeName, publishInfo)
+			if err != nil {
+				response.setError(err)
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Updated = updated
+			response.PublishInfo = publishInfo
+			return httpStatusCodeForGetUpdateList(nil)
 		},
 	)
 }
 
-type GetVolumeResponse struct {
-	Volume *storage.VolumeExternal `json:"volume"`
-	Error  string                  `json:"error,omitempty"`
+type AddVolumeEventResponse struct {
+	Volume string `json:"volume"`
+	Reason string `json:"reason"`
+	Error  string `json:"error,omitempty"`
 }
 
-func GetVolume(w http.ResponseWriter, r *http.Request) {
-	response := &GetVolumeResponse{}
-	GetGeneric(w, r, "volume", response,
-		func(volName string) int {
-			volume, err := orchestrator.GetVolume(volName)
+func (a *AddVolumeEventResponse) setError(err error) {
+	a.Error = err.Error()
+}
+
+func (a *AddVolumeEventResponse) isError() bool {
+	return a.Error != ""
+}
+
+func (a *AddVolumeEventResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "AddVolumeEvent",
+		"volume":  a.Volume,
+		"reason":  a.Reason,
+	}).Info("Recorded a volume event.")
+}
+
+func (a *AddVolumeEventResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "AddVolumeEvent",
+		"volume":  a.Volume,
+	}).Error(a.Error)
+}
+
+func AddVolumeEvent(w http.ResponseWriter, r *http.Request) {
+	response := &AddVolumeEventResponse{}
+	UpdateGeneric(w, r, "volume", response,
+		func(volumeName string, body []byte) int {
+			response.Volume = volumeName
+			event := new(utils.VolumeEvent)
+			err := json.Unmarshal(body, event)
 			if err != nil {
-				response.Error = err.Error()
-			} else {
-				response.Volume = volume
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForGetUpdateList(err)
+			}
+			response.Reason = event.Reason
+			err = orchestrator.RecordVolumeEvent(volumeName, event)
+			if err != nil {
+				response.setError(err)
 			}
 			return httpStatusCodeForGetUpdateList(err)
 		},
@@ -604,7 +1407,10 @@ func GetVolume(w http.ResponseWriter, r *http.Request) {
 }
 
 func DeleteVolume(w http.ResponseWriter, r *http.Request) {
-	DeleteGeneric(w, r, orchestrator.DeleteVolume, "volume")
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	DeleteGeneric(w, r, func(volumeName string) error {
+		return orchestrator.DeleteVolume(ctx, volumeName)
+	}, "volume")
 }
 
 type ImportVolumeResponse struct {
@@ -846,6 +1652,109 @@ func DeleteStorageClass(w http.ResponseWriter, r *http.Request) {
 	DeleteGeneric(w, r, orchestrator.DeleteStorageClass, "storageClass")
 }
 
+type AddQuotaResponse struct {
+	QuotaID string `json:"quota"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (a *AddQuotaResponse) setError(err error) {
+	a.Error = err.Error()
+}
+
+func (a *AddQuotaResponse) isError() bool {
+	return a.Error != ""
+}
+
+func (a *AddQuotaResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "AddQuota",
+		"quota":   a.QuotaID,
+	}).Info("Added a new quota.")
+}
+func (a *AddQuotaResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "AddQuota",
+		"quota":   a.QuotaID,
+	}).Error(a.Error)
+}
+
+func AddQuota(w http.ResponseWriter, r *http.Request) {
+	response := &AddQuotaResponse{
+		QuotaID: "",
+		Error:   "",
+	}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			quota := new(storage.Quota)
+			err := json.Unmarshal(body, quota)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			quotaExternal, err := orchestrator.AddQuota(quota)
+			if err != nil {
+				response.setError(err)
+			}
+			if quotaExternal != nil {
+				response.QuotaID = quotaExternal.Name
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
+}
+
+type ListQuotasResponse struct {
+	Quotas []string `json:"quotas"`
+	Error  string   `json:"error,omitempty"`
+}
+
+func (l *ListQuotasResponse) setList(payload []string) {
+	l.Quotas = payload
+}
+
+func ListQuotas(w http.ResponseWriter, r *http.Request) {
+	response := &ListQuotasResponse{}
+	ListGeneric(w, r, response,
+		func() int {
+			quotaNames := make([]string, 0)
+			quotas, err := orchestrator.ListQuotas()
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				for _, quota := range quotas {
+					quotaNames = append(quotaNames, quota.Name)
+				}
+			}
+			response.setList(quotaNames)
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type GetQuotaResponse struct {
+	Quota *storage.QuotaExternal `json:"quota"`
+	Error string                 `json:"error,omitempty"`
+}
+
+func GetQuota(w http.ResponseWriter, r *http.Request) {
+	response := &GetQuotaResponse{}
+	GetGeneric(w, r, "quota", response,
+		func(quotaName string) int {
+			quota, err := orchestrator.GetQuota(quotaName)
+			if err != nil {
+				response.Error = err.Error()
+			} else {
+				response.Quota = quota
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+func DeleteQuota(w http.ResponseWriter, r *http.Request) {
+	DeleteGeneric(w, r, orchestrator.DeleteQuota, "quota")
+}
+
 type AddNodeResponse struct {
 	Name  string `json:"name"`
 	Error string `json:"error,omitempty"`
@@ -1046,6 +1955,7 @@ func (r *AddSnapshotResponse) logFailure() {
 }
 
 func AddSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
 	response := &AddSnapshotResponse{}
 	AddGeneric(w, r, response,
 		func(body []byte) int {
@@ -1058,7 +1968,7 @@ func AddSnapshot(w http.ResponseWriter, r *http.Request) {
 				response.setError(err)
 				return httpStatusCodeForAdd(err)
 			}
-			snapshot, err := orchestrator.CreateSnapshot(snapshotConfig)
+			snapshot, err := orchestrator.CreateSnapshot(ctx, snapshotConfig)
 			if err != nil {
 				response.setError(err)
 			}
@@ -1071,5 +1981,92 @@ func AddSnapshot(w http.ResponseWriter, r *http.Request) {
 }
 
 func DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
-	DeleteGenericTwoArg(w, r, orchestrator.DeleteSnapshot, "volume", "snapshot")
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	DeleteGenericTwoArg(w, r, func(volumeName, snapshotName string) error {
+		return orchestrator.DeleteSnapshot(ctx, volumeName, snapshotName)
+	}, "volume", "snapshot")
+}
+
+// RestoreSnapshot reverts a volume to one of its snapshots and returns the snapshot restored.
+func RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := utils.GenerateRequestContext(r.Context(), "", utils.ContextSourceREST)
+	response := &GetSnapshotResponse{}
+	GetGenericTwoArg(w, r, "volume", "snapshot", response,
+		func(volumeName, snapshotName string) int {
+			err := orchestrator.RestoreSnapshot(ctx, volumeName, snapshotName)
+			if err == nil {
+				response.Snapshot, err = orchestrator.GetSnapshot(volumeName, snapshotName)
+			}
+			if err != nil {
+				response.Error = err.Error()
+			}
+			return httpStatusCodeForGetUpdateList(err)
+		},
+	)
+}
+
+type CloneSnapshotResponse struct {
+	Volume *storage.VolumeExternal `json:"volume"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+func (c *CloneSnapshotResponse) setError(err error) {
+	c.Error = err.Error()
+}
+
+func (c *CloneSnapshotResponse) isError() bool {
+	return c.Error != ""
+}
+
+func (c *CloneSnapshotResponse) logSuccess() {
+	log.WithFields(log.Fields{
+		"handler": "CloneSnapshot",
+		"volume":  c.Volume.Config.Name,
+	}).Info("Cloned a snapshot to a new volume.")
+}
+
+func (c *CloneSnapshotResponse) logFailure() {
+	log.WithFields(log.Fields{
+		"handler": "CloneSnapshot",
+	}).Error(c.Error)
+}
+
+func CloneSnapshot(w http.ResponseWriter, r *http.Request) {
+	response := &CloneSnapshotResponse{}
+	AddGeneric(w, r, response,
+		func(body []byte) int {
+			cloneSnapshotRequest := new(storage.CloneSnapshotRequest)
+			err := json.Unmarshal(body, cloneSnapshotRequest)
+			if err != nil {
+				response.setError(fmt.Errorf("invalid JSON: %s", err.Error()))
+				return httpStatusCodeForAdd(err)
+			}
+			if err = cloneSnapshotRequest.Validate(); err != nil {
+				response.setError(err)
+				return httpStatusCodeForAdd(err)
+			}
+			k8sFrontend, err := orchestrator.GetFrontend(string(config.ContextKubernetes))
+			if err != nil {
+				k8sFrontend, err = orchestrator.GetFrontend(string(helpers.KubernetesHelper))
+			}
+			if err != nil {
+				response.setError(err)
+				return httpStatusCodeForAdd(err)
+			}
+			k8s, ok := k8sFrontend.(kubernetes.KubernetesPlugin)
+			if !ok {
+				err = fmt.Errorf("unable to obtain Kubernetes frontend")
+				response.setError(err)
+				return httpStatusCodeForAdd(err)
+			}
+			volume, err := k8s.CloneSnapshot(cloneSnapshotRequest)
+			if err != nil {
+				response.setError(err)
+			}
+			if volume != nil {
+				response.Volume = volume
+			}
+			return httpStatusCodeForAdd(err)
+		},
+	)
 }
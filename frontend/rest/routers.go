@@ -15,6 +15,7 @@ func NewRouter() *mux.Router {
 		var handler http.Handler
 
 		handler = route.HandlerFunc
+		handler = Authorizer(handler, route.Name, route.Method)
 		handler = Logger(handler, route.Name)
 
 		router.
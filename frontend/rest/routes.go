@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"github.com/netapp/trident/config"
 )
 
@@ -31,12 +33,36 @@ var routes = Routes{
 		config.VersionURL,
 		GetVersion,
 	},
+	Route{
+		"GetLiveness",
+		"GET",
+		config.LivenessURL,
+		GetLiveness,
+	},
+	Route{
+		"GetReadiness",
+		"GET",
+		config.ReadinessURL,
+		GetReadiness,
+	},
+	Route{
+		"GetMetrics",
+		"GET",
+		"/metrics",
+		promhttp.Handler().ServeHTTP,
+	},
 	Route{
 		"AddBackend",
 		"POST",
 		config.BackendURL,
 		AddBackend,
 	},
+	Route{
+		"ValidateBackend",
+		"POST",
+		config.BackendURL + "/validate",
+		ValidateBackend,
+	},
 	Route{
 		"UpdateBackend",
 		"POST",
@@ -49,6 +75,84 @@ var routes = Routes{
 		config.BackendURL + "/{backend}" + "/state",
 		UpdateBackendState,
 	},
+	Route{
+		"UpdateBackendAPITrace",
+		"POST",
+		config.BackendURL + "/{backend}" + "/trace",
+		UpdateBackendAPITrace,
+	},
+	Route{
+		"UpdateBackendLogging",
+		"POST",
+		config.BackendURL + "/{backend}" + "/logging",
+		UpdateBackendLogging,
+	},
+	Route{
+		"RefreshBackendPools",
+		"POST",
+		config.BackendURL + "/{backend}" + "/refresh",
+		RefreshBackendPools,
+	},
+	Route{
+		"ListAuditEvents",
+		"GET",
+		config.AuditURL,
+		ListAuditEvents,
+	},
+	Route{
+		"WatchObjects",
+		"GET",
+		config.WatchURL,
+		WatchObjects,
+	},
+	Route{
+		"ListStorageJobs",
+		"GET",
+		config.JobURL,
+		ListStorageJobs,
+	},
+	Route{
+		"ListOrphanedResources",
+		"GET",
+		config.OrphanURL,
+		ListOrphanedResources,
+	},
+	Route{
+		"ListRecoverableVolumes",
+		"GET",
+		config.BackendURL + "/{backend}" + "/recovery",
+		ListRecoverableVolumes,
+	},
+	Route{
+		"RecoverVolume",
+		"POST",
+		config.BackendURL + "/{backend}" + "/recovery",
+		RecoverVolume,
+	},
+	Route{
+		"ListBackendVolumes",
+		"GET",
+		config.BackendURL + "/{backend}" + "/volume",
+		ListBackendVolumes,
+	},
+	Route{
+		"ListImportCandidates",
+		"GET",
+		config.BackendURL + "/{backend}" + "/import",
+		ListImportCandidates,
+	},
+	Route{
+		"GetBackendStorageLogs",
+		"GET",
+		config.BackendURL + "/{backend}" + "/logs",
+		GetBackendStorageLogs,
+	},
+	Route{
+		"GetBackendBuckets",
+		"GET",
+		config.BackendURL + "/{backend}" + "/bucket",
+		GetBackendBuckets,
+	},
 	Route{
 		"GetBackend",
 		"GET",
@@ -85,6 +189,42 @@ var routes = Routes{
 		config.VolumeURL,
 		ListVolumes,
 	},
+	Route{
+		"UpdateVolume",
+		"POST",
+		config.VolumeURL + "/{volume}",
+		UpdateVolume,
+	},
+	Route{
+		"MoveVolume",
+		"POST",
+		config.VolumeURL + "/{volume}" + "/move",
+		MoveVolume,
+	},
+	Route{
+		"MigrateVolume",
+		"POST",
+		config.VolumeURL + "/{volume}" + "/migrate",
+		MigrateVolume,
+	},
+	Route{
+		"PublishVolume",
+		"POST",
+		config.VolumeURL + "/{volume}" + "/publish",
+		PublishVolume,
+	},
+	Route{
+		"UpdateVolumePublication",
+		"POST",
+		config.VolumeURL + "/{volume}" + "/publication",
+		UpdateVolumePublication,
+	},
+	Route{
+		"AddVolumeEvent",
+		"POST",
+		config.VolumeURL + "/{volume}" + "/event",
+		AddVolumeEvent,
+	},
 	Route{
 		"DeleteVolume",
 		"DELETE",
@@ -127,6 +267,30 @@ var routes = Routes{
 		config.StorageClassURL + "/{storageClass}",
 		DeleteStorageClass,
 	},
+	Route{
+		"AddQuota",
+		"POST",
+		config.QuotaURL,
+		AddQuota,
+	},
+	Route{
+		"GetQuota",
+		"GET",
+		config.QuotaURL + "/{quota}",
+		GetQuota,
+	},
+	Route{
+		"ListQuotas",
+		"GET",
+		config.QuotaURL,
+		ListQuotas,
+	},
+	Route{
+		"DeleteQuota",
+		"DELETE",
+		config.QuotaURL + "/{quota}",
+		DeleteQuota,
+	},
 	Route{
 		"AddOrUpdateNode",
 		"PUT",
@@ -181,4 +345,16 @@ var routes = Routes{
 		config.SnapshotURL + "/{volume}/{snapshot}",
 		DeleteSnapshot,
 	},
+	Route{
+		"RestoreSnapshot",
+		"POST",
+		config.SnapshotURL + "/{volume}/{snapshot}/restore",
+		RestoreSnapshot,
+	},
+	Route{
+		"CloneSnapshot",
+		"POST",
+		config.SnapshotURL + "/clone",
+		CloneSnapshot,
+	},
 }
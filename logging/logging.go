@@ -84,6 +84,17 @@ func InitLogLevel(debug bool, logLevel string) error {
 	return nil
 }
 
+// InitModuleLogLevels configures the logging levels of individual modules from a comma-separated list of
+// module=level pairs, such as "ontap=debug,core=warn".  Modules not listed log at the level set by InitLogLevel.
+func InitModuleLogLevels(moduleLogLevels string) error {
+	levels, err := utils.ParseModuleLogLevels(moduleLogLevels)
+	if err != nil {
+		return err
+	}
+	utils.SetModuleLogLevels(levels)
+	return nil
+}
+
 // InitLogFormat configures the log format, allowing a choice of text or JSON.
 func InitLogFormat(logFormat string) error {
 	switch logFormat {
@@ -16,6 +16,7 @@ import (
 	"github.com/netapp/trident/frontend/crd"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/netapp/trident/audit"
 	"github.com/netapp/trident/config"
 	"github.com/netapp/trident/core"
 	"github.com/netapp/trident/frontend"
@@ -36,6 +37,7 @@ var (
 	debug     = flag.Bool("debug", false, "Enable debugging output")
 	logLevel  = flag.String("log_level", "info", "Logging level (debug, info, warn, error, fatal)")
 	logFormat = flag.String("log_format", "text", "Logging format (text, json)")
+	logLevels = flag.String("log_levels", "", "Logging levels of individual modules (e.g. ontap=debug,core=warn)")
 
 	// Kubernetes
 	k8sAPIServer = flag.String("k8s_api_server", "", "Kubernetes API server "+
@@ -57,6 +59,9 @@ var (
 	csiNodeName = flag.String("csi_node_name", "", "CSI node name")
 	csiRole     = flag.String("csi_role", "", fmt.Sprintf("CSI role to play: '%s' or '%s'", csi.CSIController, csi.CSINode))
 
+	iSCSISelfHealingInterval = flag.Duration("iscsi_self_healing_interval", 5*time.Minute,
+		"Interval at which a CSI node restores the iSCSI sessions of its volumes (0 disables)")
+
 	// Persistence
 	etcdV2 = flag.String("etcd_v2", "", "etcd server (v2 API) for "+
 		"persisting orchestrator state (e.g., -etcd_v2=http://127.0.0.1:8001)")
@@ -72,7 +77,12 @@ var (
 		"any metadata.  WILL LOSE TRACK OF VOLUMES ON REBOOT/CRASH.")
 	usePassthrough = flag.Bool("passthrough", false, "Uses the storage backends "+
 		"as the source of truth.  No data is stored anywhere else.")
-	useCRD = flag.Bool("crd_persistence", false, "Uses CRDs for persisting orchestrator state.")
+	useCRD     = flag.Bool("crd_persistence", false, "Uses CRDs for persisting orchestrator state.")
+	sqlitePath = flag.String("sqlite", "", "SQLite database file for "+
+		"persisting orchestrator state (e.g., -sqlite=/var/lib/trident/trident.db)")
+	migrateFrom = flag.String("migrate_from", "", "Persistent store from which orchestrator state is "+
+		"copied at startup if the configured store holds none (e.g., -migrate_from=etcd_v3=http://127.0.0.1:8001 "+
+		"or -migrate_from=sqlite=/var/lib/trident/trident.db)")
 
 	// HTTP REST interface
 	address    = flag.String("address", "127.0.0.1", "Storage orchestrator HTTP API address")
@@ -89,11 +99,25 @@ var (
 	httpsClientKey  = flag.String("https_client_key", config.ClientKeyPath, "HTTPS client private key")
 	httpsClientCert = flag.String("https_client_cert", config.ClientCertPath, "HTTPS client certificate")
 
+	// REST API authentication and authorization
+	restAuthConfig = flag.String("rest_auth_config", "",
+		"File naming the REST API callers, by bearer token or client certificate, and their roles")
+
 	// HTTP metrics interface
 	metricsAddress = flag.String("metrics_address", "", "Storage orchestrator metrics address")
 	metricsPort    = flag.String("metrics_port", "8001", "Storage orchestrator metrics port")
 	enableMetrics  = flag.Bool("metrics", false, "Enable metrics interface")
 
+	// Volume placement
+	placementPolicy = flag.String("volume_placement_policy", core.DefaultPlacementPolicy,
+		"Policy choosing among the storage pools that can hold a new volume (random, spread, binpack, "+
+			"weightedRandom), unless its storage class sets placementPolicy")
+
+	// Audit log of calls that change storage
+	auditLogFile = flag.String("audit_log", "", "File to which the audit log of storage changes is appended")
+	auditSyslog  = flag.String("audit_syslog", "",
+		"Syslog server to which audit events are sent (udp://host:port, tcp://host:port, or local)")
+
 	storeClient      persistentstore.Client
 	enableKubernetes bool
 	enableDocker     bool
@@ -166,6 +190,9 @@ func processCmdLineArgs() {
 	if *useCRD {
 		storeCount++
 	}
+	if *sqlitePath != "" {
+		storeCount++
+	}
 	// Infer persistent store type if not explicitly specified
 	if storeCount == 0 && enableDocker {
 		log.Debug("Inferred passthrough persistent store.")
@@ -213,6 +240,12 @@ func processCmdLineArgs() {
 		if err != nil {
 			log.Fatalf("Unable to create the Kubernetes store client. %v", err)
 		}
+	} else if *sqlitePath != "" {
+		log.Debug("Trident is configured with a SQLite store client.")
+		storeClient, err = persistentstore.NewSQLiteClient(*sqlitePath)
+		if err != nil {
+			log.Fatalf("Unable to create the SQLite store client. %v", err)
+		}
 	} else if *usePassthrough {
 		log.Debug("Trident is configured with passthrough store client.")
 		storeClient, err = persistentstore.NewPassthroughClient(*configPath)
@@ -221,9 +254,60 @@ func processCmdLineArgs() {
 		}
 	}
 
+	if *migrateFrom != "" {
+		migratePersistentState()
+	}
+
 	config.UsingPassthroughStore = storeClient.GetType() == persistentstore.PassthroughStore
 }
 
+// migratePersistentState copies the orchestrator state from the store named by -migrate_from into the
+// configured store, unless the configured store already holds state from an earlier start.
+func migratePersistentState() {
+
+	hasState, err := persistentstore.HasState(storeClient)
+	if err != nil {
+		log.Fatalf("Unable to check the %s store for orchestrator state. %v", storeClient.GetType(), err)
+	} else if hasState {
+		log.WithField("store", storeClient.GetType()).Info(
+			"Persistent store already holds orchestrator state, skipping migration.")
+		return
+	}
+
+	var sourceClient persistentstore.Client
+
+	source := strings.SplitN(*migrateFrom, "=", 2)
+	if len(source) != 2 || source[1] == "" {
+		log.Fatalf("Invalid store to migrate from: %s", *migrateFrom)
+	}
+	switch source[0] {
+	case "etcd_v3":
+		if shouldEnableTLS() {
+			sourceClient, err = persistentstore.NewEtcdClientV3WithTLS(source[1],
+				*etcdV3Cert, *etcdV3CACert, *etcdV3Key)
+		} else {
+			sourceClient, err = persistentstore.NewEtcdClientV3(source[1])
+		}
+	case "sqlite":
+		sourceClient, err = persistentstore.NewSQLiteClient(source[1])
+	default:
+		log.Fatalf("Unsupported store to migrate from: %s", source[0])
+	}
+	if err != nil {
+		log.Fatalf("Unable to create the %s client to migrate from. %v", source[0], err)
+	}
+	defer sourceClient.Stop()
+
+	log.WithFields(log.Fields{
+		"source":      sourceClient.GetType(),
+		"destination": storeClient.GetType(),
+	}).Info("Migrating orchestrator state.")
+
+	if err = persistentstore.NewDataMigrator(sourceClient, storeClient, false).Run(); err != nil {
+		log.Fatalf("Unable to migrate the orchestrator state. %v", err)
+	}
+}
+
 func main() {
 
 	var err error
@@ -242,6 +326,12 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Set log levels of individual modules
+	err = logging.InitModuleLogLevels(*logLevels)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Set log format
 	err = logging.InitLogFormat(*logFormat)
 	if err != nil {
@@ -263,7 +353,14 @@ func main() {
 
 	processCmdLineArgs()
 
+	if err = audit.Initialize(*auditLogFile, *auditSyslog); err != nil {
+		log.Fatalf("Unable to initialize the audit log. %v", err)
+	}
+
 	orchestrator := core.NewTridentOrchestrator(storeClient)
+	if err = orchestrator.SetPlacementPolicy(*placementPolicy); err != nil {
+		log.Fatalf("Unable to set the volume placement policy. %v", err)
+	}
 
 	// Create HTTP metrics frontend
 	if *enableMetrics {
@@ -276,12 +373,21 @@ func main() {
 		}
 	}
 
+	// Read the REST API callers and roles
+	var authConfig *rest.AuthConfig
+	if *restAuthConfig != "" && (*enableREST || *enableHTTPSREST) {
+		if authConfig, err = rest.LoadAuthConfig(*restAuthConfig); err != nil {
+			log.Fatalf("Unable to read the REST API auth config. %v", err)
+		}
+		log.WithField("restAuthConfig", *restAuthConfig).Info("Enabled REST API authorization.")
+	}
+
 	// Create HTTP REST frontend
 	if *enableREST {
 		if *port == "" {
 			log.Warning("HTTP REST interface will not be available (port not specified).")
 		} else {
-			httpServer := rest.NewHTTPServer(orchestrator, *address, *port)
+			httpServer := rest.NewHTTPServer(orchestrator, *address, *port, authConfig)
 			preBootstrapFrontends = append(preBootstrapFrontends, httpServer)
 			log.WithFields(log.Fields{"name": httpServer.GetName()}).Info("Added frontend.")
 		}
@@ -293,7 +399,7 @@ func main() {
 			log.Warning("HTTPS REST interface will not be available (httpsPort not specified).")
 		} else {
 			httpsServer, err := rest.NewHTTPSServer(
-				orchestrator, *httpsAddress, *httpsPort, *httpsCACert, *httpsServerCert, *httpsServerKey)
+				orchestrator, *httpsAddress, *httpsPort, *httpsCACert, *httpsServerCert, *httpsServerKey, authConfig)
 			if err != nil {
 				log.Fatalf("Unable to start the HTTPS REST frontend. %v", err)
 			}
@@ -393,10 +499,10 @@ func main() {
 			csiFrontend, err = csi.NewControllerPlugin(*csiNodeName, *csiEndpoint, orchestrator, &hybridPlugin)
 		case csi.CSINode:
 			csiFrontend, err = csi.NewNodePlugin(*csiNodeName, *csiEndpoint, *httpsCACert, *httpsClientCert,
-				*httpsClientKey, orchestrator)
+				*httpsClientKey, *iSCSISelfHealingInterval, orchestrator)
 		case csi.CSIAllInOne:
 			csiFrontend, err = csi.NewAllInOnePlugin(*csiNodeName, *csiEndpoint, *httpsCACert, *httpsClientCert,
-				*httpsClientKey, orchestrator, &hybridPlugin)
+				*httpsClientKey, *iSCSISelfHealingInterval, orchestrator, &hybridPlugin)
 		}
 		if err != nil {
 			log.Fatalf("Unable to start the CSI frontend. %v", err)
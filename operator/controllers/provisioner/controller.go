@@ -16,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
@@ -85,6 +86,10 @@ type Controller struct {
 	daemonsetWatcher   cache.ListerWatcher
 	stopChan           chan struct{}
 
+	// rolledBackUpgrades is the generation of each CR, by UID, whose upgrade failed and was rolled back,
+	// so that the upgrade isn't retried until the CR changes.
+	rolledBackUpgrades map[types.UID]int64
+
 	// workqueue is a rate limited work queue. This is used to queue work to be
 	// processed instead of performing it as soon as a change happens. This
 	// means we can ensure we only process a fixed amount of resources at a
@@ -98,10 +103,11 @@ func NewController(clients *clients.Clients) (*Controller, error) {
 	log.Infof("Initializing %s controller.", ControllerName)
 
 	c := &Controller{
-		Clients:   clients,
-		mutex:     &sync.Mutex{},
-		stopChan:  make(chan struct{}),
-		workqueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TridentProvisioner"),
+		Clients:            clients,
+		mutex:              &sync.Mutex{},
+		stopChan:           make(chan struct{}),
+		rolledBackUpgrades: make(map[types.UID]int64),
+		workqueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TridentProvisioner"),
 	}
 
 	// Set up event broadcaster
@@ -1054,6 +1060,16 @@ func (c *Controller) controllingCRBasedReconcile(controllingCR *netappv1.Trident
 			" installation.", controllingCR.Name, controllingCR.Namespace))
 	} else {
 
+		// An upgrade that was rolled back is retried only once the CR changes
+		if generation, ok := c.rolledBackUpgrades[controllingCR.UID]; ok {
+			if generation == controllingCR.Generation {
+				log.Warnf("Upgrade of Trident controlled by CR '%v' in namespace '%v' was rolled back; update"+
+					" the CR to retry.", controllingCR.Name, controllingCR.Namespace)
+				return nil
+			}
+			delete(c.rolledBackUpgrades, controllingCR.UID)
+		}
+
 		// There are certain checks that should be run before each install, update, patch
 
 		// Check: Alpha-snapshot CRDs should not be present
@@ -1265,7 +1281,24 @@ func (c *Controller) installTridentAndUpdateStatus(tridentCR netappv1.TridentPro
 		return utils.ReconcileFailedError(err)
 	}
 	if identifiedSpecValues, identifiedTridentVersion, err = i.InstallOrPatchTrident(tridentCR, currentInstalledTridentVersion,
-		shouldUpdate); err != nil {
+		shouldUpdate); installer.IsUpgradeRolledBackError(err) {
+		// The previous version is running again, so the CR keeps its installation details
+		logMessage := "Updating Trident Provisioner CR after failed upgrade."
+		statusMessage := fmt.Sprintf("Failed to upgrade Trident; err: %s; update the CR to retry", err.Error())
+
+		if warningMessage != "" {
+			statusMessage = statusMessage + "; " + warningMessage
+		}
+
+		c.eventRecorder.Event(&tridentCR, corev1.EventTypeWarning, string(AppStatusFailed), statusMessage)
+
+		c.updateCRStatus(&tridentCR, logMessage, statusMessage, string(AppStatusFailed),
+			currentInstalledTridentVersion, &tridentCR.Status.CurrentInstallationParams)
+
+		c.rolledBackUpgrades[tridentCR.UID] = tridentCR.Generation
+
+		return utils.ReconcileFailedError(err)
+	} else if err != nil {
 		// Update status of the tridentCR  to `Failed`
 		logMessage := "Updating Trident Provisioner CR after failed installation."
 		statusMessage := fmt.Sprintf("Failed to install Trident; err: %s", err.Error())
@@ -31,13 +31,16 @@ import (
 
 const (
 	// CRD names
-	BackendCRDName      = "tridentbackends.trident.netapp.io"
-	NodeCRDName         = "tridentnodes.trident.netapp.io"
-	StorageClassCRDName = "tridentstorageclasses.trident.netapp.io"
-	TransactionCRDName  = "tridenttransactions.trident.netapp.io"
-	VersionCRDName      = "tridentversions.trident.netapp.io"
-	VolumeCRDName       = "tridentvolumes.trident.netapp.io"
-	SnapshotCRDName     = "tridentsnapshots.trident.netapp.io"
+	BackendCRDName       = "tridentbackends.trident.netapp.io"
+	NodeCRDName          = "tridentnodes.trident.netapp.io"
+	StorageClassCRDName  = "tridentstorageclasses.trident.netapp.io"
+	TransactionCRDName   = "tridenttransactions.trident.netapp.io"
+	VersionCRDName       = "tridentversions.trident.netapp.io"
+	VolumeCRDName        = "tridentvolumes.trident.netapp.io"
+	SnapshotCRDName      = "tridentsnapshots.trident.netapp.io"
+	QuotaCRDName         = "tridentquotas.trident.netapp.io"
+	BackendConfigCRDName = "tridentbackendconfigs.trident.netapp.io"
+	BatchIntentCRDName   = "tridentbatchintents.trident.netapp.io"
 
 	VolumeSnapshotCRDName        = "volumesnapshots.snapshot.storage.k8s.io"
 	VolumeSnapshotClassCRDName   = "volumesnapshotclasses.snapshot.storage.k8s.io"
@@ -73,6 +76,9 @@ var (
 		VersionCRDName,
 		VolumeCRDName,
 		SnapshotCRDName,
+		QuotaCRDName,
+		BackendConfigCRDName,
+		BatchIntentCRDName,
 	}
 
 	AlphaCRDNames = []string{
@@ -334,6 +340,28 @@ func (i *Installer) InstallOrPatchTrident(cr netappv1.TridentProvisioner,
 	// Identify if update is required because of change in K8s version or Trident Operator version
 	shouldUpdate = shouldUpdate || imageUpdateNeeded
 
+	// An upgrade replaces a running Trident, so verify that the new version can take over its state, and
+	// record the workloads it replaces so that they may be restored if the new version fails to start
+	upgrading := currentInstallationVersion != "" && imageUpdateNeeded
+	var snapshot *workloadSnapshot
+	if upgrading {
+		if returnError = i.upgradePreflightChecks(currentInstallationVersion,
+			labels[TridentVersionLabelKey]); returnError != nil {
+			return nil, "", returnError
+		}
+		if snapshot, returnError = i.snapshotTridentWorkloads(); returnError != nil {
+			return nil, "", returnError
+		}
+	}
+
+	// failRollout rolls back an upgrade whose new workloads could not be rolled out
+	failRollout := func(err error) error {
+		if upgrading && snapshot.deployment != nil {
+			return i.rollbackUpgrade(snapshot, currentInstallationVersion, err)
+		}
+		return err
+	}
+
 	// Begin Trident installation logic...
 
 	// All checks succeeded, so proceed with installation
@@ -404,30 +432,33 @@ func (i *Installer) InstallOrPatchTrident(cr netappv1.TridentProvisioner,
 		returnError = i.createOrPatchTridentDeployment(controllingCRDetails, labels, shouldUpdate)
 		if returnError != nil {
 			returnError = fmt.Errorf("could not create the Trident Deployment; %v", returnError)
-			return nil, "", returnError
+			return nil, "", failRollout(returnError)
+		}
+
+		// During an upgrade, the new controller must be serving before the node pods are replaced
+		if upgrading {
+			if returnError = i.waitForTridentController(); returnError != nil {
+				return nil, "", failRollout(returnError)
+			}
 		}
 
 		// Create or update the Trident CSI daemonset
 		returnError = i.createOrPatchTridentDaemonSet(controllingCRDetails, labels, shouldUpdate)
 		if returnError != nil {
 			returnError = fmt.Errorf("could not create the Trident DaemonSet; %v", returnError)
-			return nil, "", returnError
+			return nil, "", failRollout(returnError)
 		}
-	}
-
-	// Wait for Trident pod to be running
-	var tridentPod *v1.Pod
 
-	tridentPod, returnError = i.waitForTridentPod()
-	if returnError != nil {
-		return nil, "", returnError
+		if upgrading {
+			if returnError = i.waitForUpgradedDaemonSet(snapshot); returnError != nil {
+				return nil, "", failRollout(returnError)
+			}
+		}
 	}
 
-	// Wait for Trident REST interface to be available
-	returnError = i.waitForRESTInterface(tridentPod.Name)
-	if returnError != nil {
-		returnError = fmt.Errorf("%v; use 'tridentctl logs' to learn more", returnError)
-		return nil, "", returnError
+	// Wait for Trident pod to be running and its REST interface to be available
+	if returnError = i.waitForTridentController(); returnError != nil {
+		return nil, "", failRollout(returnError)
 	}
 
 	identifiedSpecValues := netappv1.TridentProvisionerSpecValues{
@@ -571,6 +602,15 @@ func (i *Installer) createCRDs() error {
 	if err = i.createCRD(SnapshotCRDName, k8sclient.GetSnapshotCRDYAML(useCRDv1)); err != nil {
 		return err
 	}
+	if err = i.createCRD(QuotaCRDName, k8sclient.GetQuotaCRDYAML(useCRDv1)); err != nil {
+		return err
+	}
+	if err = i.createCRD(BackendConfigCRDName, k8sclient.GetBackendConfigCRDYAML(useCRDv1)); err != nil {
+		return err
+	}
+	if err = i.createCRD(BatchIntentCRDName, k8sclient.GetBatchIntentCRDYAML(useCRDv1)); err != nil {
+		return err
+	}
 
 	return err
 }
@@ -1488,6 +1528,21 @@ func (i *Installer) TridentDaemonSetInformation() (*appsv1.DaemonSet,
 	return currentDaemonset, unwantedDaemonsets, createDaemonset, nil
 }
 
+// waitForTridentController waits for the Trident pod to be running and its REST interface to be available.
+func (i *Installer) waitForTridentController() error {
+
+	tridentPod, err := i.waitForTridentPod()
+	if err != nil {
+		return err
+	}
+
+	if err = i.waitForRESTInterface(tridentPod.Name); err != nil {
+		return fmt.Errorf("%v; use 'tridentctl logs' to learn more", err)
+	}
+
+	return nil
+}
+
 func (i *Installer) waitForTridentPod() (*v1.Pod, error) {
 
 	var pod *v1.Pod
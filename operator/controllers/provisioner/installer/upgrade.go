@@ -0,0 +1,440 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/cli/api"
+	commonconfig "github.com/netapp/trident/config"
+	tridentv1 "github.com/netapp/trident/persistent_store/crd/apis/netapp/v1"
+	"github.com/netapp/trident/utils"
+)
+
+// crdStoreVersion is the persistent store version recorded by a Trident that keeps its state in CRDs
+const crdStoreVersion = "crdv1"
+
+var (
+	listOpts = metav1.ListOptions{}
+
+	ctx = context.TODO
+
+	// ontapMinimumReleases is the oldest ONTAP release supported by each Trident release
+	ontapMinimumReleases = map[string]string{
+		"20.07": "9.1",
+	}
+
+	// ontapFeatureReleases is the ONTAP release that introduced each feature Trident reports for
+	// ONTAP backends.  Only features that depend on nothing but the release are listed, so that a
+	// feature reported as unavailable means the backend's release predates it.
+	ontapFeatureReleases = map[string]string{
+		"flexGroups":          "9.2",
+		"fabricPoolFlexVol":   "9.2",
+		"fabricPoolFlexGroup": "9.5",
+		"lunGeometryResize":   "9.5",
+		"fabricPoolForSVMDR":  "9.5",
+		"flexCache":           "9.5",
+	}
+)
+
+/////////////////////////////////////////////////////////////////////////////
+// upgradeRolledBackError
+/////////////////////////////////////////////////////////////////////////////
+
+type upgradeRolledBackError struct {
+	message string
+}
+
+func (e *upgradeRolledBackError) Error() string { return e.message }
+
+// IsUpgradeRolledBackError returns whether an upgrade failed and Trident was restored to the version
+// it was replacing.
+func IsUpgradeRolledBackError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*upgradeRolledBackError)
+	return ok
+}
+
+// upgradePreflightChecks verifies that the new Trident version can take over the state of the
+// installed one, before anything is changed.  All checks are run so that every problem is reported.
+func (i *Installer) upgradePreflightChecks(currentVersion, newVersion string) error {
+
+	log.WithFields(log.Fields{
+		"currentVersion": currentVersion,
+		"newVersion":     newVersion,
+	}).Info("Running upgrade preflight checks.")
+
+	var failures []string
+	checks := []func(string) error{
+		i.crdCompatibilityPreflightCheck,
+		i.persistentStatePreflightCheck,
+		i.ontapSupportPreflightCheck,
+	}
+	for _, check := range checks {
+		if err := check(newVersion); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("upgrade preflight checks failed; %s", strings.Join(failures, "; "))
+	}
+
+	log.Info("Upgrade preflight checks passed.")
+	return nil
+}
+
+// crdCompatibilityPreflightCheck verifies that the existing Trident CRDs store their objects in an
+// API version the new Trident reads.
+func (i *Installer) crdCompatibilityPreflightCheck(_ string) error {
+
+	var incompatibleCRDs []string
+
+	for _, crdName := range CRDnames {
+		exists, err := i.client.CheckCRDExists(crdName)
+		if err != nil {
+			return fmt.Errorf("could not check if CRD %s exists; %v", crdName, err)
+		} else if !exists {
+			// New CRDs are created during the upgrade
+			continue
+		}
+
+		crd, err := i.client.GetCRD(crdName)
+		if err != nil {
+			return fmt.Errorf("could not get CRD %s; %v", crdName, err)
+		}
+
+		for _, storedVersion := range crd.Status.StoredVersions {
+			if storedVersion != tridentv1.SchemeGroupVersion.Version {
+				incompatibleCRDs = append(incompatibleCRDs, fmt.Sprintf("%s (%s)", crdName, storedVersion))
+			}
+		}
+	}
+
+	if len(incompatibleCRDs) > 0 {
+		return fmt.Errorf("CRDs store objects in API versions this Trident does not read: %s",
+			strings.Join(incompatibleCRDs, ", "))
+	}
+
+	return nil
+}
+
+// persistentStatePreflightCheck verifies that Trident's persistent state may be migrated to the new
+// version, which must not be older than the one that last wrote the state, and that no volume
+// transactions or persistent store batches are in progress.  Trident versions that predate batch
+// intents would ignore an unfinished batch, leaving only some of its writes made.
+func (i *Installer) persistentStatePreflightCheck(newVersion string) error {
+
+	versions, err := i.tridentCRDClient.TridentV1().TridentVersions(i.namespace).List(ctx(), listOpts)
+	if err != nil {
+		return fmt.Errorf("could not read the persistent state version; %v", err)
+	}
+
+	if len(versions.Items) > 0 {
+		stateVersion := versions.Items[0]
+
+		if stateVersion.PersistentStoreVersion != crdStoreVersion {
+			return fmt.Errorf("persistent store version '%s' must be migrated to '%s' with tridentctl",
+				stateVersion.PersistentStoreVersion, crdStoreVersion)
+		}
+		if stateVersion.OrchestratorAPIVersion != commonconfig.OrchestratorAPIVersion {
+			return fmt.Errorf("persistent state API version '%s' is not supported, expected '%s'",
+				stateVersion.OrchestratorAPIVersion, commonconfig.OrchestratorAPIVersion)
+		}
+
+		stateTridentVersion, err := utils.ParseDate(stateVersion.TridentVersion)
+		if err != nil {
+			return fmt.Errorf("could not parse the Trident version '%s' of the persistent state; %v",
+				stateVersion.TridentVersion, err)
+		}
+		newTridentVersion, err := utils.ParseDate(newVersion)
+		if err != nil {
+			return fmt.Errorf("could not parse Trident version '%s'; %v", newVersion, err)
+		}
+		if newTridentVersion.ToMajorMinorVersion().LessThan(stateTridentVersion.ToMajorMinorVersion()) {
+			return fmt.Errorf("persistent state was written by Trident %s and cannot be read by Trident %s",
+				stateTridentVersion.ShortStringWithRelease(), newTridentVersion.ShortStringWithRelease())
+		}
+	}
+
+	transactions, err := i.tridentCRDClient.TridentV1().TridentTransactions(i.namespace).List(ctx(), listOpts)
+	if err != nil {
+		return fmt.Errorf("could not list volume transactions; %v", err)
+	}
+	if len(transactions.Items) > 0 {
+		var transactionNames []string
+		for _, transaction := range transactions.Items {
+			transactionNames = append(transactionNames, transaction.Name)
+		}
+		sort.Strings(transactionNames)
+		return fmt.Errorf("%d volume transaction(s) in progress must complete first: %s",
+			len(transactionNames), strings.Join(transactionNames, ", "))
+	}
+
+	if exists, err := i.client.CheckCRDExists(BatchIntentCRDName); err != nil {
+		return fmt.Errorf("could not check if CRD %s exists; %v", BatchIntentCRDName, err)
+	} else if exists {
+		intents, err := i.tridentCRDClient.TridentV1().TridentBatchIntents(i.namespace).List(ctx(), listOpts)
+		if err != nil {
+			return fmt.Errorf("could not list persistent store batches; %v", err)
+		}
+		if len(intents.Items) > 0 {
+			return fmt.Errorf("%d persistent store batch(es) in progress must complete first; restart "+
+				"Trident to finish them", len(intents.Items))
+		}
+	}
+
+	return nil
+}
+
+// ontapSupportPreflightCheck verifies that the ONTAP release of each ONTAP backend is supported by
+// the new Trident version.  The release is bounded by the features the installed Trident reports for
+// the backend, so backends it can't be reached for, or reports no features for, are not checked.
+func (i *Installer) ontapSupportPreflightCheck(newVersion string) error {
+
+	newTridentVersion, err := utils.ParseDate(newVersion)
+	if err != nil {
+		return fmt.Errorf("could not parse Trident version '%s'; %v", newVersion, err)
+	}
+	release := fmt.Sprintf("%d.%02d", newTridentVersion.MajorVersion(), newTridentVersion.MinorVersion())
+	minimumRelease, ok := ontapMinimumReleases[release]
+	if !ok {
+		log.WithField("version", newVersion).Debug("No ONTAP support information, skipping ONTAP check.")
+		return nil
+	}
+
+	pod, err := i.client.GetPodByLabel(appLabel, false)
+	if err != nil || pod.Status.Phase != v1.PodRunning {
+		log.WithField("err", err).Warning("Trident is not running, could not check ONTAP backends.")
+		return nil
+	}
+
+	cliCommand := []string{"tridentctl", "-s", ControllerServer, "get", "backend", "-o", "json"}
+	backendsJSON, err := i.client.Exec(pod.Name, TridentContainer, cliCommand)
+	if err != nil {
+		log.WithField("err", err).Warning("Could not list backends, could not check ONTAP backends.")
+		return nil
+	}
+
+	var backendsResponse api.MultipleBackendResponse
+	if err = json.Unmarshal(backendsJSON, &backendsResponse); err != nil {
+		return fmt.Errorf("could not parse backends; %v", err)
+	}
+
+	var unsupportedBackends []string
+	for _, backend := range backendsResponse.Items {
+		config, ok := backend.Config.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		driverName, _ := config["storageDriverName"].(string)
+		if !strings.HasPrefix(driverName, "ontap-") {
+			continue
+		}
+		if ontapReleaseBefore(backend.Features, minimumRelease) {
+			unsupportedBackends = append(unsupportedBackends, backend.Name)
+		}
+	}
+
+	if len(unsupportedBackends) > 0 {
+		sort.Strings(unsupportedBackends)
+		return fmt.Errorf("Trident %s requires ONTAP %s or later, which backend(s) %s do not run",
+			newTridentVersion.ShortStringWithRelease(), minimumRelease, strings.Join(unsupportedBackends, ", "))
+	}
+
+	return nil
+}
+
+// ontapReleaseBefore returns whether the reported features show that a backend's ONTAP release is
+// older than the given release, because a feature introduced no later than it is unavailable.
+func ontapReleaseBefore(features map[string]bool, release string) bool {
+
+	minimum := utils.MustParseGeneric(release)
+
+	for feature, introduced := range ontapFeatureReleases {
+		available, reported := features[feature]
+		if reported && !available && minimum.AtLeast(utils.MustParseGeneric(introduced)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// workloadSnapshot holds the Trident deployment and daemonset as they were before an upgrade, so
+// that they may be restored if the upgrade fails.
+type workloadSnapshot struct {
+	deployment *appsv1.Deployment
+	daemonset  *appsv1.DaemonSet
+}
+
+// snapshotTridentWorkloads records the Trident deployment and daemonset before an upgrade.
+func (i *Installer) snapshotTridentWorkloads() (*workloadSnapshot, error) {
+
+	deployment, _, _, err := i.TridentDeploymentInformation(appLabel, csi)
+	if err != nil {
+		return nil, fmt.Errorf("could not record the Trident deployment before upgrading; %v", err)
+	}
+	daemonset, _, _, err := i.TridentDaemonSetInformation()
+	if err != nil {
+		return nil, fmt.Errorf("could not record the Trident daemonset before upgrading; %v", err)
+	}
+
+	snapshot := &workloadSnapshot{}
+	if deployment != nil {
+		snapshot.deployment = &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: restorableObjectMeta(deployment.ObjectMeta),
+			Spec:       *deployment.Spec.DeepCopy(),
+		}
+	}
+	if daemonset != nil {
+		snapshot.daemonset = &appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: restorableObjectMeta(daemonset.ObjectMeta),
+			Spec:       *daemonset.Spec.DeepCopy(),
+			Status:     appsv1.DaemonSetStatus{NumberAvailable: daemonset.Status.NumberAvailable},
+		}
+	}
+
+	return snapshot, nil
+}
+
+// restorableObjectMeta returns the metadata with which a deleted object may be created again.
+func restorableObjectMeta(objectMeta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            objectMeta.Name,
+		Namespace:       objectMeta.Namespace,
+		Labels:          objectMeta.Labels,
+		Annotations:     objectMeta.Annotations,
+		OwnerReferences: objectMeta.OwnerReferences,
+	}
+}
+
+// waitForUpgradedDaemonSet waits until the upgraded Trident daemonset has replaced its pods, and at
+// least as many are available as were before the upgrade.
+func (i *Installer) waitForUpgradedDaemonSet(snapshot *workloadSnapshot) error {
+
+	var minAvailable int32
+	if snapshot.daemonset != nil {
+		minAvailable = snapshot.daemonset.Status.NumberAvailable
+	}
+
+	checkDaemonSetRolledOut := func() error {
+		daemonset, err := i.client.GetDaemonSetByLabel(TridentNodeLabel, false)
+		if err != nil {
+			return err
+		}
+		status := daemonset.Status
+		if status.ObservedGeneration < daemonset.Generation ||
+			status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+			return fmt.Errorf("%d of %d pods updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+		}
+		if status.NumberAvailable < minAvailable {
+			return fmt.Errorf("%d of %d pods available", status.NumberAvailable, minAvailable)
+		}
+		return nil
+	}
+	rolloutNotify := func(err error, duration time.Duration) {
+		log.WithFields(log.Fields{
+			"increment": duration,
+			"status":    err,
+		}).Debugf("Trident daemonset not yet rolled out, waiting.")
+	}
+	rolloutBackoff := backoff.NewExponentialBackOff()
+	rolloutBackoff.MaxElapsedTime = k8sTimeout
+
+	log.Info("Waiting for Trident daemonset to roll out.")
+
+	if err := backoff.RetryNotify(checkDaemonSetRolledOut, rolloutBackoff, rolloutNotify); err != nil {
+		return fmt.Errorf("Trident daemonset was not rolled out after %3.2f seconds; %v",
+			k8sTimeout.Seconds(), err)
+	}
+
+	log.Info("Trident daemonset rolled out.")
+	return nil
+}
+
+// rollbackUpgrade restores the Trident deployment and daemonset recorded before a failed upgrade.
+func (i *Installer) rollbackUpgrade(snapshot *workloadSnapshot, previousVersion string, upgradeErr error) error {
+
+	log.WithFields(log.Fields{
+		"version": previousVersion,
+		"err":     upgradeErr,
+	}).Warning("Trident upgrade failed, rolling back.")
+
+	if err := i.restoreTridentWorkloads(snapshot); err != nil {
+		return fmt.Errorf("%v; could not roll back to Trident %s; %v", upgradeErr, previousVersion, err)
+	}
+
+	log.WithField("version", previousVersion).Info("Rolled back Trident upgrade.")
+
+	return &upgradeRolledBackError{
+		message: fmt.Sprintf("%v; rolled back to Trident %s", upgradeErr, previousVersion),
+	}
+}
+
+func (i *Installer) restoreTridentWorkloads(snapshot *workloadSnapshot) error {
+
+	if snapshot.deployment == nil {
+		return errors.New("no Trident deployment was recorded before upgrading")
+	}
+
+	currentDeployment, unwantedDeployments, _, err := i.TridentDeploymentInformation(appLabel, csi)
+	if err != nil {
+		return err
+	}
+	if currentDeployment != nil {
+		unwantedDeployments = append(unwantedDeployments, *currentDeployment)
+	}
+	if err = i.RemoveMultipleDeployments(unwantedDeployments); err != nil {
+		return err
+	}
+	if err = i.createObjectFromSnapshot(snapshot.deployment); err != nil {
+		return fmt.Errorf("could not restore Trident deployment; %v", err)
+	}
+
+	if snapshot.daemonset != nil {
+		currentDaemonset, unwantedDaemonsets, _, err := i.TridentDaemonSetInformation()
+		if err != nil {
+			return err
+		}
+		if currentDaemonset != nil {
+			unwantedDaemonsets = append(unwantedDaemonsets, *currentDaemonset)
+		}
+		if err = i.RemoveMultipleDaemonSets(unwantedDaemonsets); err != nil {
+			return err
+		}
+		restoredDaemonset := snapshot.daemonset.DeepCopy()
+		restoredDaemonset.Status = appsv1.DaemonSetStatus{}
+		if err = i.createObjectFromSnapshot(restoredDaemonset); err != nil {
+			return fmt.Errorf("could not restore Trident daemonset; %v", err)
+		}
+	}
+
+	return i.waitForTridentController()
+}
+
+func (i *Installer) createObjectFromSnapshot(object interface{}) error {
+
+	// JSON is valid YAML
+	objectJSON, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return i.client.CreateObjectByYAML(string(objectJSON))
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package installer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOntapReleaseBefore(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		features map[string]bool
+		release  string
+		before   bool
+	}{
+		{"no features reported", nil, "9.5", false},
+		{"all features available", map[string]bool{"flexGroups": true, "flexCache": true}, "9.5", false},
+		{"feature newer than release unavailable", map[string]bool{"flexGroups": true, "flexCache": false},
+			"9.2", false},
+		{"feature as old as release unavailable", map[string]bool{"flexGroups": true, "flexCache": false},
+			"9.5", true},
+		{"feature older than release unavailable", map[string]bool{"flexGroups": false}, "9.6", true},
+		{"unlisted feature unavailable", map[string]bool{"snapMirrorSync": false, "restAPI": false}, "9.6", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.before, ontapReleaseBefore(test.features, test.release))
+		})
+	}
+}
+
+func TestIsUpgradeRolledBackError(t *testing.T) {
+
+	assert.True(t, IsUpgradeRolledBackError(&upgradeRolledBackError{message: "rolled back"}))
+	assert.False(t, IsUpgradeRolledBackError(errors.New("rolled back")))
+	assert.False(t, IsUpgradeRolledBackError(nil))
+}
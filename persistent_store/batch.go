@@ -0,0 +1,299 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
+// Batch collects writes of volumes, snapshots, and volume transactions that must be made all or nothing,
+// such as saving the outcome of an operation along with deleting the operation's transaction.
+type Batch struct {
+	entries []storage.BatchEntry
+}
+
+func NewBatch() *Batch {
+	return &Batch{
+		entries: make([]storage.BatchEntry, 0),
+	}
+}
+
+// Len returns the number of writes in the batch
+func (b *Batch) Len() int {
+	return len(b.entries)
+}
+
+// Entries returns the writes in the batch, in the order they were added
+func (b *Batch) Entries() []storage.BatchEntry {
+	return b.entries
+}
+
+// PutVolume adds a volume to the batch, to be saved whether or not it exists in the persistent store
+func (b *Batch) PutVolume(vol *storage.Volume) {
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:     storage.BatchPutVolume,
+		Volume: vol.ConstructExternal(),
+	})
+}
+
+// DeleteVolume adds a volume's deletion to the batch.  A volume missing from the persistent store is ignored.
+func (b *Batch) DeleteVolume(vol *storage.Volume) {
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:     storage.BatchDeleteVolume,
+		Volume: vol.ConstructExternal(),
+	})
+}
+
+// PutSnapshot adds a snapshot to the batch, to be saved whether or not it exists in the persistent store
+func (b *Batch) PutSnapshot(snapshot *storage.Snapshot) {
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:       storage.BatchPutSnapshot,
+		Snapshot: snapshot.ConstructPersistent(),
+	})
+}
+
+// DeleteSnapshot adds a snapshot's deletion to the batch.  A snapshot missing from the persistent store
+// is ignored.
+func (b *Batch) DeleteSnapshot(snapshot *storage.Snapshot) {
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:       storage.BatchDeleteSnapshot,
+		Snapshot: snapshot.ConstructPersistent(),
+	})
+}
+
+// PutVolumeTransaction adds a volume transaction to the batch, to be saved whether or not it exists in the
+// persistent store
+func (b *Batch) PutVolumeTransaction(volTxn *storage.VolumeTransaction) {
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:          storage.BatchPutVolumeTransaction,
+		Transaction: volTxn,
+	})
+}
+
+// DeleteVolumeTransaction adds a volume transaction's deletion to the batch.  A transaction missing from
+// the persistent store is ignored, as is a nil transaction, so that work done both with and without a
+// transaction may share a batch.
+func (b *Batch) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction) {
+	if volTxn == nil {
+		return
+	}
+	b.entries = append(b.entries, storage.BatchEntry{
+		Op:          storage.BatchDeleteVolumeTransaction,
+		Transaction: volTxn,
+	})
+}
+
+// BatchClient is implemented by persistent stores that can write a batch atomically.
+type BatchClient interface {
+	CommitBatch(batch *Batch) error
+}
+
+// CommitBatch writes a batch to a persistent store, all or nothing.  A store that can't write a batch
+// atomically first saves an intent record listing the batch's writes, and deletes it once all of the
+// writes are made.  The batch is committed once its intent is saved, so if any write fails after that,
+// a BatchNotAppliedError is returned, and the caller should finish the batch with RetryBatch.
+func CommitBatch(client Client, batch *Batch) error {
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	if batchClient, ok := client.(BatchClient); ok {
+		return batchClient.CommitBatch(batch)
+	}
+
+	// A single write needs no intent
+	if batch.Len() == 1 {
+		return applyBatchEntry(client, batch.entries[0])
+	}
+
+	// Intents are named in the order they're saved, so that they may be replayed in that order
+	intent := &storage.BatchIntent{
+		Name:    fmt.Sprintf("batch-%d-%s", time.Now().UnixNano(), uuid.New().String()[:8]),
+		Entries: batch.entries,
+	}
+	if err := client.AddBatchIntent(intent); err != nil {
+		return fmt.Errorf("could not save batch intent; %v", err)
+	}
+
+	if err := applyBatchIntent(client, intent); err != nil {
+		return &BatchNotAppliedError{Intent: intent.Name, Err: err}
+	}
+	return nil
+}
+
+// ReplayBatchIntents finishes writing any batches whose intent records were left in a persistent store
+// by an interrupted or failed CommitBatch, oldest first.  It must be called before Trident's state is read
+// from the store.
+func ReplayBatchIntents(client Client) error {
+
+	intents, err := client.GetBatchIntents()
+	if err != nil {
+		return err
+	}
+	sort.Slice(intents, func(i, j int) bool {
+		return intents[i].Name < intents[j].Name
+	})
+
+	for _, intent := range intents {
+		log.WithFields(log.Fields{
+			"intent":  intent.Name,
+			"entries": len(intent.Entries),
+		}).Info("Finishing interrupted persistent store batch.")
+
+		if err = applyBatchIntent(client, intent); err != nil {
+			return fmt.Errorf("could not finish persistent store batch %s; %v", intent.Name, err)
+		}
+	}
+	return nil
+}
+
+// RetryBatch makes the writes of a batch that was committed but not applied once more.  The batch's intent
+// is deleted whether or not they succeed, as a batch that fails again fails its operation, and its writes
+// must not be replayed later over any made since.
+func RetryBatch(client Client, batch *Batch, notApplied *BatchNotAppliedError) error {
+
+	intent := &storage.BatchIntent{
+		Name:    notApplied.Intent,
+		Entries: batch.entries,
+	}
+	if err := applyBatchIntent(client, intent); err != nil {
+		if deleteErr := client.DeleteBatchIntent(intent); deleteErr != nil && !MatchKeyNotFoundErr(deleteErr) {
+			log.WithFields(log.Fields{
+				"intent": intent.Name,
+				"error":  deleteErr,
+			}).Error("Could not discard persistent store batch.")
+		}
+		return &BatchNotAppliedError{Intent: intent.Name, Err: err}
+	}
+	return nil
+}
+
+// DiscardBatchIntents deletes the intents left in a persistent store by batches that failed while Trident
+// was running, and whose intents couldn't be deleted by RetryBatch at the time.  Their operations failed,
+// so their writes are not replayed.
+func DiscardBatchIntents(client Client) error {
+
+	intents, err := client.GetBatchIntents()
+	if err != nil {
+		return err
+	}
+	for _, intent := range intents {
+		log.WithField("intent", intent.Name).Info("Discarding failed persistent store batch.")
+		if err = client.DeleteBatchIntent(intent); err != nil && !MatchKeyNotFoundErr(err) {
+			return fmt.Errorf("could not discard persistent store batch %s; %v", intent.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyBatchIntent makes each write recorded by a batch intent, then deletes the intent
+func applyBatchIntent(client Client, intent *storage.BatchIntent) error {
+	for _, entry := range intent.Entries {
+		if err := applyBatchEntry(client, entry); err != nil {
+			return err
+		}
+	}
+	return client.DeleteBatchIntent(intent)
+}
+
+// applyBatchEntry makes a single write of a batch using the store's regular methods.  Each write may be
+// repeated, as it is when a batch is replayed.
+func applyBatchEntry(client Client, entry storage.BatchEntry) error {
+
+	switch entry.Op {
+
+	case storage.BatchPutVolume:
+		if _, err := client.GetVolume(entry.Volume.Config.Name); err == nil {
+			return client.UpdateVolumePersistent(entry.Volume)
+		} else if !isNotFoundErr(err) {
+			return err
+		}
+		return client.AddVolumePersistent(entry.Volume)
+
+	case storage.BatchDeleteVolume:
+		return client.DeleteVolumeIgnoreNotFound(&storage.Volume{Config: entry.Volume.Config})
+
+	case storage.BatchPutSnapshot:
+		snapshot := &entry.Snapshot.Snapshot
+		if _, err := client.GetSnapshot(snapshot.Config.VolumeName, snapshot.Config.Name); err == nil {
+			return client.UpdateSnapshot(snapshot)
+		} else if !isNotFoundErr(err) {
+			return err
+		}
+		return client.AddSnapshot(snapshot)
+
+	case storage.BatchDeleteSnapshot:
+		return client.DeleteSnapshotIgnoreNotFound(&entry.Snapshot.Snapshot)
+
+	case storage.BatchPutVolumeTransaction:
+		if existingTxn, err := client.GetExistingVolumeTransaction(entry.Transaction); err != nil {
+			return err
+		} else if existingTxn != nil {
+			return client.UpdateVolumeTransaction(entry.Transaction)
+		}
+		return client.AddVolumeTransaction(entry.Transaction)
+
+	case storage.BatchDeleteVolumeTransaction:
+		if existingTxn, err := client.GetExistingVolumeTransaction(entry.Transaction); err != nil {
+			return err
+		} else if existingTxn == nil {
+			return nil
+		}
+		if err := client.DeleteVolumeTransaction(entry.Transaction); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown batch operation %s", entry.Op)
+	}
+}
+
+// batchEntryKeyValue returns the key written by a batch entry in a key-value store, along with the value
+// to save there, or whether the key is deleted instead.
+func batchEntryKeyValue(entry storage.BatchEntry) (key, value string, isDelete bool, err error) {
+
+	var object interface{}
+
+	switch entry.Op {
+	case storage.BatchPutVolume, storage.BatchDeleteVolume:
+		key = config.VolumeURL + "/" + entry.Volume.Config.Name
+		object = entry.Volume
+	case storage.BatchPutSnapshot, storage.BatchDeleteSnapshot:
+		key = config.SnapshotURL + "/" + entry.Snapshot.ID()
+		object = entry.Snapshot
+	case storage.BatchPutVolumeTransaction, storage.BatchDeleteVolumeTransaction:
+		key = config.TransactionURL + "/" + entry.Transaction.Name()
+		object = entry.Transaction
+	default:
+		return "", "", false, fmt.Errorf("unknown batch operation %s", entry.Op)
+	}
+
+	switch entry.Op {
+	case storage.BatchDeleteVolume, storage.BatchDeleteSnapshot, storage.BatchDeleteVolumeTransaction:
+		return key, "", true, nil
+	}
+
+	objectJSON, err := json.Marshal(object)
+	if err != nil {
+		return "", "", false, err
+	}
+	return key, string(objectJSON), false, nil
+}
+
+// isNotFoundErr returns whether an error reports a missing object, from either a key-value store or
+// Kubernetes.
+func isNotFoundErr(err error) bool {
+	return MatchKeyNotFoundErr(err) || errors.IsNotFound(err)
+}
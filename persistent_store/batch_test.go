@@ -0,0 +1,236 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
+func newTestBatchVolume(name string) *storage.Volume {
+	return &storage.Volume{
+		Config: &storage.VolumeConfig{
+			Version:      config.OrchestratorAPIVersion,
+			Name:         name,
+			Size:         "1GB",
+			Protocol:     config.File,
+			StorageClass: "gold",
+		},
+		BackendUUID: "b1f8e5c4-1c1c-4c7a-9a5e-5f7b6f3e2d10",
+		Pool:        storagePool,
+	}
+}
+
+// newTestBatch returns a batch that saves a volume and snapshot, and deletes the volume's transaction
+func newTestBatch(t *testing.T, client Client) (*Batch, *storage.Volume, *storage.Snapshot, *storage.VolumeTransaction) {
+
+	vol := newTestBatchVolume("vol1")
+	volTxn := &storage.VolumeTransaction{
+		Config: vol.Config,
+		Op:     storage.AddVolume,
+	}
+	assert.NoError(t, client.AddVolumeTransaction(volTxn))
+
+	snapshot := storage.NewSnapshot(&storage.SnapshotConfig{Name: "snap1", VolumeName: "vol1"},
+		"2020-06-01T00:00:00Z", 1024)
+
+	batch := NewBatch()
+	batch.PutVolume(vol)
+	batch.PutSnapshot(snapshot)
+	batch.DeleteVolumeTransaction(volTxn)
+	batch.DeleteVolumeTransaction(nil)
+	assert.Equal(t, 3, batch.Len())
+
+	return batch, vol, snapshot, volTxn
+}
+
+// assertTestBatchCommitted checks that everything written by the batch from newTestBatch is in the store
+func assertTestBatchCommitted(
+	t *testing.T, client Client, vol *storage.Volume, snapshot *storage.Snapshot, volTxn *storage.VolumeTransaction,
+) {
+	recoveredVolume, err := client.GetVolume(vol.Config.Name)
+	if assert.NoError(t, err) {
+		assert.Equal(t, vol.Config.Size, recoveredVolume.Config.Size)
+	}
+	recoveredSnapshot, err := client.GetSnapshot(snapshot.Config.VolumeName, snapshot.Config.Name)
+	if assert.NoError(t, err) {
+		assert.Equal(t, snapshot.ID(), recoveredSnapshot.ID())
+	}
+	existingTxn, err := client.GetExistingVolumeTransaction(volTxn)
+	assert.NoError(t, err)
+	assert.Nil(t, existingTxn, "transaction wasn't deleted")
+	intents, err := client.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "batch intent was left behind")
+}
+
+// nonBatchClient hides whether a store can commit batches itself, so that batches are written with intents.
+// It fails to save snapshots the given number of times.
+type nonBatchClient struct {
+	Client
+	snapshotFailures int
+}
+
+func (c *nonBatchClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	if c.snapshotFailures > 0 {
+		c.snapshotFailures--
+		return fmt.Errorf("store unavailable")
+	}
+	return c.Client.AddSnapshot(snapshot)
+}
+
+func TestCommitBatchSQLite(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	batch, vol, snapshot, volTxn := newTestBatch(t, p)
+	assert.NoError(t, CommitBatch(p, batch))
+	assertTestBatchCommitted(t, p, vol, snapshot, volTxn)
+
+	// Putting an existing volume updates it, and deleting a missing transaction is ignored
+	vol.Config.Size = "2GB"
+	batch = NewBatch()
+	batch.PutVolume(vol)
+	batch.DeleteVolumeTransaction(volTxn)
+	assert.NoError(t, CommitBatch(p, batch))
+	recoveredVolume, err := p.GetVolume(vol.Config.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "2GB", recoveredVolume.Config.Size)
+
+	// A failed batch writes nothing
+	batch = NewBatch()
+	batch.DeleteVolume(vol)
+	batch.entries = append(batch.entries, storage.BatchEntry{Op: "unknown"})
+	assert.Error(t, CommitBatch(p, batch))
+	_, err = p.GetVolume(vol.Config.Name)
+	assert.NoError(t, err, "volume was deleted by a failed batch")
+}
+
+func TestCommitBatchInMemory(t *testing.T) {
+	client := NewInMemoryClient()
+
+	batch, vol, snapshot, volTxn := newTestBatch(t, client)
+	assert.NoError(t, CommitBatch(client, batch))
+	assertTestBatchCommitted(t, client, vol, snapshot, volTxn)
+
+	batch = NewBatch()
+	batch.DeleteVolume(vol)
+	batch.DeleteSnapshot(snapshot)
+	assert.NoError(t, CommitBatch(client, batch))
+	volumes, err := client.GetVolumes()
+	assert.NoError(t, err)
+	assert.Empty(t, volumes)
+	snapshots, err := client.GetSnapshots()
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestCommitBatchWithIntent(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+	client := &nonBatchClient{Client: p}
+
+	batch, vol, snapshot, volTxn := newTestBatch(t, client)
+	assert.NoError(t, CommitBatch(client, batch))
+	assertTestBatchCommitted(t, client, vol, snapshot, volTxn)
+}
+
+func TestCommitBatchNotApplied(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+	client := &nonBatchClient{Client: p, snapshotFailures: 1}
+
+	// A write failing once the intent is saved leaves the batch committed, but not applied
+	batch, vol, snapshot, volTxn := newTestBatch(t, client)
+	notApplied, ok := CommitBatch(client, batch).(*BatchNotAppliedError)
+	assert.True(t, ok, "expected batch not applied error")
+	intents, err := client.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Len(t, intents, 1)
+
+	// The batch is finished by retrying it
+	assert.NoError(t, RetryBatch(client, batch, notApplied))
+	assertTestBatchCommitted(t, client, vol, snapshot, volTxn)
+
+	// A batch that fails again has its intent discarded
+	assert.NoError(t, client.DeleteSnapshot(snapshot))
+	client.snapshotFailures = 2
+	batch, _, _, _ = newTestBatch(t, client)
+	notApplied, ok = CommitBatch(client, batch).(*BatchNotAppliedError)
+	assert.True(t, ok, "expected batch not applied error")
+	assert.Error(t, RetryBatch(client, batch, notApplied))
+	intents, err = client.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents, "failed batch intent left behind")
+
+	// Nothing is written if the intent can't be saved
+	batch, _, _, _ = newTestBatch(t, client)
+	assert.NoError(t, p.Stop())
+	err = CommitBatch(client, batch)
+	assert.Error(t, err)
+	assert.False(t, IsBatchNotAppliedError(err), "batch committed without an intent")
+}
+
+func TestCommitBatchWithIntentCRD(t *testing.T) {
+	client, _ := GetTestKubernetesClient()
+
+	batch, vol, snapshot, volTxn := newTestBatch(t, client)
+	assert.NoError(t, CommitBatch(client, batch))
+	assertTestBatchCommitted(t, client, vol, snapshot, volTxn)
+
+	// Committing the same writes again updates the objects saved the first time
+	batch, _, _, _ = newTestBatch(t, client)
+	assert.NoError(t, CommitBatch(client, batch))
+	assertTestBatchCommitted(t, client, vol, snapshot, volTxn)
+}
+
+func TestReplayBatchIntents(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	batch, vol, snapshot, volTxn := newTestBatch(t, p)
+
+	// Leave an intent behind as an interrupted commit would, having made only its first write
+	intent := &storage.BatchIntent{
+		Name:    "batch-interrupted",
+		Entries: batch.Entries(),
+	}
+	assert.NoError(t, p.AddBatchIntent(intent))
+	assert.NoError(t, p.AddVolume(vol))
+
+	// Intents are kept apart from volume transactions, which older versions of Trident read
+	volTxns, err := p.GetVolumeTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, volTxns, 1)
+
+	assert.NoError(t, ReplayBatchIntents(p))
+	assertTestBatchCommitted(t, p, vol, snapshot, volTxn)
+
+	// Nothing is left to replay
+	assert.NoError(t, ReplayBatchIntents(p))
+}
+
+func TestDiscardBatchIntents(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	batch, vol, _, _ := newTestBatch(t, p)
+	intent := &storage.BatchIntent{
+		Name:    "batch-failed",
+		Entries: batch.Entries(),
+	}
+	assert.NoError(t, p.AddBatchIntent(intent))
+
+	// Discarded intents make none of their writes
+	assert.NoError(t, DiscardBatchIntents(p))
+	intents, err := p.GetBatchIntents()
+	assert.NoError(t, err)
+	assert.Empty(t, intents)
+	_, err = p.GetVolume(vol.Config.Name)
+	assert.Error(t, err, "discarded batch written")
+}
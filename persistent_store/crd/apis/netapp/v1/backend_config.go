@@ -0,0 +1,131 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	BackendConfigPhaseBound    = "Bound"
+	BackendConfigPhaseFailed   = "Failed"
+	BackendConfigPhaseDeleting = "Deleting"
+
+	// BackendConfigConditionValid is whether Trident accepted the configuration and its credentials
+	BackendConfigConditionValid = "Valid"
+	// BackendConfigConditionInSync is whether the backend matches the configuration
+	BackendConfigConditionInSync = "InSync"
+
+	ConditionTrue  = "True"
+	ConditionFalse = "False"
+
+	// backendConfigCredentialsField is the field of the spec naming the Secret that holds the credentials
+	backendConfigCredentialsField = "credentials"
+)
+
+// BackendConfigCredentials names the Secret, in the TridentBackendConfig's namespace, whose keys are
+// added to the backend's configuration, such as username and password.
+type BackendConfigCredentials struct {
+	Name string `json:"name"`
+}
+
+// ParseSpec returns the backend configuration of the spec, without its credentials, and the Secret
+// that holds the credentials, if any.
+func (in *TridentBackendConfig) ParseSpec() (map[string]interface{}, *BackendConfigCredentials, error) {
+
+	config := make(map[string]interface{})
+	if len(in.Spec.Raw) == 0 {
+		return nil, nil, fmt.Errorf("spec is empty")
+	}
+	if err := json.Unmarshal(in.Spec.Raw, &config); err != nil {
+		return nil, nil, fmt.Errorf("could not parse spec; %v", err)
+	}
+
+	rawCredentials, ok := config[backendConfigCredentialsField]
+	if !ok {
+		return config, nil, nil
+	}
+	delete(config, backendConfigCredentialsField)
+
+	credentialsJSON, err := json.Marshal(rawCredentials)
+	if err != nil {
+		return nil, nil, err
+	}
+	credentials := &BackendConfigCredentials{}
+	if err = json.Unmarshal(credentialsJSON, credentials); err != nil || credentials.Name == "" {
+		return nil, nil, fmt.Errorf("spec.credentials must name a secret")
+	}
+
+	return config, credentials, nil
+}
+
+// GetCondition returns the condition of the given type, or nil if it hasn't been observed.
+func (in *TridentBackendConfig) GetCondition(conditionType string) *TridentBackendConfigCondition {
+	for i := range in.Status.Conditions {
+		if in.Status.Conditions[i].Type == conditionType {
+			return &in.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition records an observation of the backend's state.  The transition time changes only if
+// the condition's status does.
+func (in *TridentBackendConfig) SetCondition(conditionType string, status bool, reason, message string) {
+
+	conditionStatus := ConditionFalse
+	if status {
+		conditionStatus = ConditionTrue
+	}
+
+	condition := in.GetCondition(conditionType)
+	if condition == nil {
+		in.Status.Conditions = append(in.Status.Conditions, TridentBackendConfigCondition{Type: conditionType})
+		condition = &in.Status.Conditions[len(in.Status.Conditions)-1]
+	}
+	if condition.Status != conditionStatus {
+		condition.Status = conditionStatus
+		condition.LastTransitionTime = metav1.Now()
+	}
+	condition.Reason = reason
+	condition.Message = message
+}
+
+func (in *TridentBackendConfig) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *TridentBackendConfig) GetFinalizers() []string {
+	if in.ObjectMeta.Finalizers != nil {
+		return in.ObjectMeta.Finalizers
+	}
+	return []string{}
+}
+
+func (in *TridentBackendConfig) HasTridentFinalizers() bool {
+	for _, finalizerName := range GetTridentFinalizers() {
+		if utils.SliceContainsString(in.ObjectMeta.Finalizers, finalizerName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *TridentBackendConfig) AddTridentFinalizers() {
+	for _, finalizerName := range GetTridentFinalizers() {
+		if !utils.SliceContainsString(in.ObjectMeta.Finalizers, finalizerName) {
+			in.ObjectMeta.Finalizers = append(in.ObjectMeta.Finalizers, finalizerName)
+		}
+	}
+}
+
+func (in *TridentBackendConfig) RemoveTridentFinalizers() {
+	for _, finalizerName := range GetTridentFinalizers() {
+		in.ObjectMeta.Finalizers = utils.RemoveStringFromSlice(in.ObjectMeta.Finalizers, finalizerName)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestBackendConfigParseSpec(t *testing.T) {
+
+	tbc := &TridentBackendConfig{Spec: runtime.RawExtension{
+		Raw: []byte(`{"version": 1, "storageDriverName": "ontap-nas", "credentials": {"name": "ontap-creds"}}`),
+	}}
+
+	config, credentials, err := tbc.ParseSpec()
+	if err != nil {
+		t.Fatal("Unable to parse spec: ", err)
+	}
+	assert.Equal(t, map[string]interface{}{"version": float64(1), "storageDriverName": "ontap-nas"}, config)
+	assert.Equal(t, &BackendConfigCredentials{Name: "ontap-creds"}, credentials)
+
+	tbc.Spec.Raw = []byte(`{"version": 1, "credentials": {}}`)
+	_, _, err = tbc.ParseSpec()
+	assert.Error(t, err)
+
+	tbc.Spec.Raw = nil
+	_, _, err = tbc.ParseSpec()
+	assert.Error(t, err)
+}
+
+func TestBackendConfigSetCondition(t *testing.T) {
+
+	tbc := &TridentBackendConfig{}
+
+	tbc.SetCondition(BackendConfigConditionValid, false, "InvalidSpec", "spec is empty")
+	condition := tbc.GetCondition(BackendConfigConditionValid)
+	assert.Equal(t, ConditionFalse, condition.Status)
+	assert.False(t, condition.LastTransitionTime.IsZero())
+	transitioned := condition.LastTransitionTime
+
+	// The transition time changes only with the status
+	tbc.SetCondition(BackendConfigConditionValid, false, "InvalidCredentials", "secret not found")
+	condition = tbc.GetCondition(BackendConfigConditionValid)
+	assert.Equal(t, "InvalidCredentials", condition.Reason)
+	assert.Equal(t, transitioned, condition.LastTransitionTime)
+
+	tbc.SetCondition(BackendConfigConditionValid, true, "Accepted", "")
+	assert.Equal(t, ConditionTrue, tbc.GetCondition(BackendConfigConditionValid).Status)
+	assert.Nil(t, tbc.GetCondition(BackendConfigConditionInSync))
+	assert.Len(t, tbc.Status.Conditions, 1)
+}
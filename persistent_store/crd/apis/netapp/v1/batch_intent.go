@@ -0,0 +1,92 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// NewTridentBatchIntent creates a new batch intent CRD object from an internal storage.BatchIntent object.
+// Unlike Trident's other objects, a batch intent has no finalizers, as only Trident deletes it, and
+// sparing the update that removes them keeps the cost of committing a batch down.
+func NewTridentBatchIntent(persistent *storage.BatchIntent) (*TridentBatchIntent, error) {
+
+	intent := &TridentBatchIntent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "trident.netapp.io/v1",
+			Kind:       "TridentBatchIntent",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: NameFix(persistent.Name),
+		},
+	}
+
+	if err := intent.Apply(persistent); err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+// Apply applies changes from an internal storage.BatchIntent
+// object to its Kubernetes CRD equivalent.
+func (in *TridentBatchIntent) Apply(persistent *storage.BatchIntent) error {
+	if NameFix(persistent.Name) != in.ObjectMeta.Name {
+		return ErrNamesDontMatch
+	}
+
+	entries, err := json.Marshal(persistent.Entries)
+	if err != nil {
+		return err
+	}
+
+	in.Entries.Raw = entries
+
+	return nil
+}
+
+// Persistent converts a Kubernetes CRD object into its internal
+// storage.BatchIntent equivalent.
+func (in *TridentBatchIntent) Persistent() (*storage.BatchIntent, error) {
+
+	persistent := &storage.BatchIntent{
+		Name: in.ObjectMeta.Name,
+	}
+
+	if err := json.Unmarshal(in.Entries.Raw, &persistent.Entries); err != nil {
+		return nil, err
+	}
+
+	return persistent, nil
+}
+
+func (in *TridentBatchIntent) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *TridentBatchIntent) GetFinalizers() []string {
+	if in.ObjectMeta.Finalizers != nil {
+		return in.ObjectMeta.Finalizers
+	}
+	return []string{}
+}
+
+func (in *TridentBatchIntent) HasTridentFinalizers() bool {
+	for _, finalizerName := range GetTridentFinalizers() {
+		if utils.SliceContainsString(in.ObjectMeta.Finalizers, finalizerName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *TridentBatchIntent) RemoveTridentFinalizers() {
+	for _, finalizerName := range GetTridentFinalizers() {
+		in.ObjectMeta.Finalizers = utils.RemoveStringFromSlice(in.ObjectMeta.Finalizers, finalizerName)
+	}
+}
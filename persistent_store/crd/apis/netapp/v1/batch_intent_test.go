@@ -0,0 +1,52 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+)
+
+func TestNewBatchIntent(t *testing.T) {
+	// Build batch intent
+	volConfig := &storage.VolumeConfig{
+		Version:      config.OrchestratorAPIVersion,
+		Name:         "vol1",
+		Size:         "1GB",
+		Protocol:     config.File,
+		StorageClass: "gold",
+	}
+	storageIntent := &storage.BatchIntent{
+		Name: "batch-6f0a4c2e",
+		Entries: []storage.BatchEntry{
+			{
+				Op:     storage.BatchPutVolume,
+				Volume: &storage.VolumeExternal{Config: volConfig, Backend: "b1", Pool: "aggr1"},
+			},
+			{
+				Op:          storage.BatchDeleteVolumeTransaction,
+				Transaction: &storage.VolumeTransaction{Config: volConfig, Op: storage.AddVolume},
+			},
+		},
+	}
+
+	// Convert to Kubernetes Object using the NewTridentBatchIntent method
+	intent, err := NewTridentBatchIntent(storageIntent)
+	if err != nil {
+		t.Fatal("Unable to construct TridentBatchIntent CRD: ", err)
+	}
+
+	assert.Equal(t, "batch-6f0a4c2e", intent.ObjectMeta.Name)
+	assert.Empty(t, intent.GetFinalizers(), "batch intent has finalizers")
+
+	// Convert back to the internal batch intent
+	persistent, err := intent.Persistent()
+	if err != nil {
+		t.Fatal("Unable to convert TridentBatchIntent CRD: ", err)
+	}
+	assert.Equal(t, storageIntent, persistent)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// NewTridentQuota creates a new quota CRD object from an internal storage.Quota object.
+func NewTridentQuota(persistent *storage.Quota) (*TridentQuota, error) {
+
+	quota := &TridentQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "trident.netapp.io/v1",
+			Kind:       "TridentQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       NameFix(persistent.Name),
+			Finalizers: GetTridentFinalizers(),
+		},
+	}
+
+	if err := quota.Apply(persistent); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+// Apply applies changes from an internal storage.Quota
+// object to its Kubernetes CRD equivalent.
+func (in *TridentQuota) Apply(persistent *storage.Quota) error {
+	if NameFix(persistent.Name) != in.ObjectMeta.Name {
+		return ErrNamesDontMatch
+	}
+
+	in.QuotaName = persistent.Name
+	in.Scope = string(persistent.Scope)
+	in.Target = persistent.Target
+	in.MaxSize = persistent.MaxSize
+	in.MaxVolumes = persistent.MaxVolumes
+
+	return nil
+}
+
+// Persistent converts a Kubernetes CRD object into its internal
+// storage.Quota equivalent.
+func (in *TridentQuota) Persistent() (*storage.Quota, error) {
+	persistent := &storage.Quota{
+		Name:       in.QuotaName,
+		Scope:      storage.QuotaScope(in.Scope),
+		Target:     in.Target,
+		MaxSize:    in.MaxSize,
+		MaxVolumes: in.MaxVolumes,
+	}
+
+	return persistent, nil
+}
+
+func (in *TridentQuota) GetObjectMeta() metav1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+func (in *TridentQuota) GetFinalizers() []string {
+	if in.ObjectMeta.Finalizers != nil {
+		return in.ObjectMeta.Finalizers
+	}
+	return []string{}
+}
+
+func (in *TridentQuota) HasTridentFinalizers() bool {
+	for _, finalizerName := range GetTridentFinalizers() {
+		if utils.SliceContainsString(in.ObjectMeta.Finalizers, finalizerName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *TridentQuota) RemoveTridentFinalizers() {
+	for _, finalizerName := range GetTridentFinalizers() {
+		in.ObjectMeta.Finalizers = utils.RemoveStringFromSlice(in.ObjectMeta.Finalizers, finalizerName)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestNewQuota(t *testing.T) {
+	// Build quota
+	storageQuota := &storage.Quota{
+		Name:       "Team-A",
+		Scope:      storage.QuotaScopeNamespace,
+		Target:     "team-a",
+		MaxSize:    "100Gi",
+		MaxVolumes: 20,
+	}
+
+	// Convert to Kubernetes Object using the NewTridentQuota method
+	quota, err := NewTridentQuota(storageQuota)
+	if err != nil {
+		t.Fatal("Unable to construct TridentQuota CRD: ", err)
+	}
+
+	assert.Equal(t, "team-a", quota.ObjectMeta.Name)
+	assert.Equal(t, storageQuota.Name, quota.QuotaName)
+	assert.Equal(t, string(storageQuota.Scope), quota.Scope)
+	assert.Equal(t, storageQuota.Target, quota.Target)
+	assert.Equal(t, storageQuota.MaxSize, quota.MaxSize)
+	assert.Equal(t, storageQuota.MaxVolumes, quota.MaxVolumes)
+
+	// Convert back to the internal quota
+	persistent, err := quota.Persistent()
+	if err != nil {
+		t.Fatal("Unable to convert TridentQuota CRD: ", err)
+	}
+	assert.Equal(t, storageQuota, persistent)
+}
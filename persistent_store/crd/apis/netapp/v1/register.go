@@ -34,6 +34,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&TridentBackend{},
 		&TridentBackendList{},
+		&TridentBackendConfig{},
+		&TridentBackendConfigList{},
+		&TridentBatchIntent{},
+		&TridentBatchIntentList{},
 		&TridentVolume{},
 		&TridentVolumeList{},
 		&TridentStorageClass{},
@@ -46,6 +50,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TridentVersionList{},
 		&TridentSnapshot{},
 		&TridentSnapshotList{},
+		&TridentQuota{},
+		&TridentQuotaList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
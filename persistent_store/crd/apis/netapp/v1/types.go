@@ -57,6 +57,84 @@ type TridentBackendList struct {
 	Items []*TridentBackend `json:"items"`
 }
 
+// TridentBackendConfig declares a Trident backend, which Trident creates and keeps in sync with its spec.
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentBackendConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the backend's configuration, as given to tridentctl, with a reference to the
+	// Secret holding its credentials in place of the credentials
+	Spec runtime.RawExtension `json:"spec"`
+	// Status is the outcome of applying the configuration to the backend
+	Status TridentBackendConfigStatus `json:"status,omitempty"`
+}
+
+// TridentBackendConfigStatus is the observed state of a declared backend.
+type TridentBackendConfigStatus struct {
+	// Phase is Bound once the backend has been created, Failed if it couldn't be, or Deleting
+	Phase string `json:"phase,omitempty"`
+	// BackendName is the name of the backend
+	BackendName string `json:"backendName,omitempty"`
+	// BackendUUID is the unique identifier of the backend
+	BackendUUID string `json:"backendUUID,omitempty"`
+	// ConfigDigest identifies the configuration, with its credentials, last applied to the backend
+	ConfigDigest string `json:"configDigest,omitempty"`
+	// BackendDigest identifies the backend's configuration as reported by Trident once last applied
+	BackendDigest string `json:"backendDigest,omitempty"`
+	// Conditions are the latest observations of the backend's state
+	Conditions []TridentBackendConfigCondition `json:"conditions,omitempty"`
+}
+
+// TridentBackendConfigCondition is an observation of a declared backend's state.
+type TridentBackendConfigCondition struct {
+	// Type of the condition: Valid or InSync
+	Type string `json:"type"`
+	// Status of the condition: True or False
+	Status string `json:"status"`
+	// Reason is a brief, machine-readable cause of the condition's last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable explanation of the condition
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when the condition last changed status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// TridentBackendConfigList is a list of TridentBackendConfig objects.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentBackendConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of TridentBackendConfig objects
+	Items []*TridentBackendConfig `json:"items"`
+}
+
+// TridentBatchIntent records the writes of a persistent store batch before any of them are made, so that
+// an interrupted batch may be finished.
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentBatchIntent struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Entries are the writes of the batch
+	Entries runtime.RawExtension `json:"entries"`
+}
+
+// TridentBatchIntentList is a list of TridentBatchIntent objects.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentBatchIntentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of TridentBatchIntent objects
+	Items []*TridentBatchIntent `json:"items"`
+}
+
 // TridentVolume defines a Trident volume.
 // +genclient
 // +k8s:openapi-gen=true
@@ -75,6 +153,8 @@ type TridentVolume struct {
 	Orphaned bool `json:"orphaned"`
 	// State records the TridentVolume's state
 	State string `json:"state"`
+	// PublishedNodes names the nodes to which the volume is published
+	PublishedNodes []string `json:"publishedNodes,omitempty"`
 }
 
 // TridentVolumeList is a list of TridentVolume objects.
@@ -157,6 +237,36 @@ type TridentNodeList struct {
 	Items []*TridentNode `json:"items"`
 }
 
+// TridentQuota defines a Trident quota on the volumes of a namespace, storage class, or backend.
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentQuota struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// QuotaName is the name of the quota
+	QuotaName string `json:"name"`
+	// Scope is the kind of object the quota limits: namespace, storageClass, or backend
+	Scope string `json:"scope"`
+	// Target is the name of the namespace, storage class, or backend the quota limits
+	Target string `json:"target"`
+	// MaxSize is the total size of the volumes allowed
+	MaxSize string `json:"maxSize,omitempty"`
+	// MaxVolumes is the number of volumes allowed
+	MaxVolumes int `json:"maxVolumes,omitempty"`
+}
+
+// TridentQuotaList is a list of TridentQuota objects.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TridentQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of TridentQuota objects
+	Items []*TridentQuota `json:"items"`
+}
+
 // TridentVersion defines a Trident version object.
 // +genclient
 // +k8s:openapi-gen=true
@@ -66,6 +66,7 @@ func (in *TridentVolume) Apply(persistent *storage.VolumeExternal) error {
 	in.Orphaned = persistent.Orphaned
 	in.Pool = persistent.Pool
 	in.State = string(persistent.State)
+	in.PublishedNodes = persistent.PublishedNodes
 
 	return nil
 }
@@ -74,11 +75,12 @@ func (in *TridentVolume) Apply(persistent *storage.VolumeExternal) error {
 // storage.VolumeExternal equivalent
 func (in *TridentVolume) Persistent() (*storage.VolumeExternal, error) {
 	persistent := &storage.VolumeExternal{
-		BackendUUID: in.BackendUUID,
-		Orphaned:    in.Orphaned,
-		Pool:        in.Pool,
-		Config:      &storage.VolumeConfig{},
-		State:       storage.VolumeState(in.State),
+		BackendUUID:    in.BackendUUID,
+		Orphaned:       in.Orphaned,
+		Pool:           in.Pool,
+		Config:         &storage.VolumeConfig{},
+		State:          storage.VolumeState(in.State),
+		PublishedNodes: in.PublishedNodes,
 	}
 
 	return persistent, json.Unmarshal(in.Config.Raw, persistent.Config)
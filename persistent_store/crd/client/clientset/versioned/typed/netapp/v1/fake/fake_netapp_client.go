@@ -18,10 +18,22 @@ func (c *FakeTridentV1) TridentBackends(namespace string) v1.TridentBackendInter
 	return &FakeTridentBackends{c, namespace}
 }
 
+func (c *FakeTridentV1) TridentBackendConfigs(namespace string) v1.TridentBackendConfigInterface {
+	return &FakeTridentBackendConfigs{c, namespace}
+}
+
+func (c *FakeTridentV1) TridentBatchIntents(namespace string) v1.TridentBatchIntentInterface {
+	return &FakeTridentBatchIntents{c, namespace}
+}
+
 func (c *FakeTridentV1) TridentNodes(namespace string) v1.TridentNodeInterface {
 	return &FakeTridentNodes{c, namespace}
 }
 
+func (c *FakeTridentV1) TridentQuotas(namespace string) v1.TridentQuotaInterface {
+	return &FakeTridentQuotas{c, namespace}
+}
+
 func (c *FakeTridentV1) TridentSnapshots(namespace string) v1.TridentSnapshotInterface {
 	return &FakeTridentSnapshots{c, namespace}
 }
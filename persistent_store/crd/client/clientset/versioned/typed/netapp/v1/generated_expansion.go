@@ -6,8 +6,14 @@ package v1
 
 type TridentBackendExpansion interface{}
 
+type TridentBackendConfigExpansion interface{}
+
+type TridentBatchIntentExpansion interface{}
+
 type TridentNodeExpansion interface{}
 
+type TridentQuotaExpansion interface{}
+
 type TridentSnapshotExpansion interface{}
 
 type TridentStorageClassExpansion interface{}
@@ -13,7 +13,10 @@ import (
 type TridentV1Interface interface {
 	RESTClient() rest.Interface
 	TridentBackendsGetter
+	TridentBackendConfigsGetter
+	TridentBatchIntentsGetter
 	TridentNodesGetter
+	TridentQuotasGetter
 	TridentSnapshotsGetter
 	TridentStorageClassesGetter
 	TridentTransactionsGetter
@@ -30,10 +33,22 @@ func (c *TridentV1Client) TridentBackends(namespace string) TridentBackendInterf
 	return newTridentBackends(c, namespace)
 }
 
+func (c *TridentV1Client) TridentBackendConfigs(namespace string) TridentBackendConfigInterface {
+	return newTridentBackendConfigs(c, namespace)
+}
+
+func (c *TridentV1Client) TridentBatchIntents(namespace string) TridentBatchIntentInterface {
+	return newTridentBatchIntents(c, namespace)
+}
+
 func (c *TridentV1Client) TridentNodes(namespace string) TridentNodeInterface {
 	return newTridentNodes(c, namespace)
 }
 
+func (c *TridentV1Client) TridentQuotas(namespace string) TridentQuotaInterface {
+	return newTridentQuotas(c, namespace)
+}
+
 func (c *TridentV1Client) TridentSnapshots(namespace string) TridentSnapshotInterface {
 	return newTridentSnapshots(c, namespace)
 }
@@ -41,8 +41,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=trident.netapp.io, Version=v1
 	case v1.SchemeGroupVersion.WithResource("tridentbackends"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentBackends().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("tridentbackendconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentBackendConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("tridentbatchintents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentBatchIntents().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("tridentnodes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentNodes().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("tridentquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentQuotas().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("tridentsnapshots"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Trident().V1().TridentSnapshots().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("tridentstorageclasses"):
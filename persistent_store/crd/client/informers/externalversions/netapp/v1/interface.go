@@ -12,8 +12,14 @@ import (
 type Interface interface {
 	// TridentBackends returns a TridentBackendInformer.
 	TridentBackends() TridentBackendInformer
+	// TridentBackendConfigs returns a TridentBackendConfigInformer.
+	TridentBackendConfigs() TridentBackendConfigInformer
+	// TridentBatchIntents returns a TridentBatchIntentInformer.
+	TridentBatchIntents() TridentBatchIntentInformer
 	// TridentNodes returns a TridentNodeInformer.
 	TridentNodes() TridentNodeInformer
+	// TridentQuotas returns a TridentQuotaInformer.
+	TridentQuotas() TridentQuotaInformer
 	// TridentSnapshots returns a TridentSnapshotInformer.
 	TridentSnapshots() TridentSnapshotInformer
 	// TridentStorageClasses returns a TridentStorageClassInformer.
@@ -42,11 +48,26 @@ func (v *version) TridentBackends() TridentBackendInformer {
 	return &tridentBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TridentBackendConfigs returns a TridentBackendConfigInformer.
+func (v *version) TridentBackendConfigs() TridentBackendConfigInformer {
+	return &tridentBackendConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TridentBatchIntents returns a TridentBatchIntentInformer.
+func (v *version) TridentBatchIntents() TridentBatchIntentInformer {
+	return &tridentBatchIntentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TridentNodes returns a TridentNodeInformer.
 func (v *version) TridentNodes() TridentNodeInformer {
 	return &tridentNodeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TridentQuotas returns a TridentQuotaInformer.
+func (v *version) TridentQuotas() TridentQuotaInformer {
+	return &tridentQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TridentSnapshots returns a TridentSnapshotInformer.
 func (v *version) TridentSnapshots() TridentSnapshotInformer {
 	return &tridentSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -12,6 +12,22 @@ type TridentBackendListerExpansion interface{}
 // TridentBackendNamespaceLister.
 type TridentBackendNamespaceListerExpansion interface{}
 
+// TridentBackendConfigListerExpansion allows custom methods to be added to
+// TridentBackendConfigLister.
+type TridentBackendConfigListerExpansion interface{}
+
+// TridentBackendConfigNamespaceListerExpansion allows custom methods to be added to
+// TridentBackendConfigNamespaceLister.
+type TridentBackendConfigNamespaceListerExpansion interface{}
+
+// TridentBatchIntentListerExpansion allows custom methods to be added to
+// TridentBatchIntentLister.
+type TridentBatchIntentListerExpansion interface{}
+
+// TridentBatchIntentNamespaceListerExpansion allows custom methods to be added to
+// TridentBatchIntentNamespaceLister.
+type TridentBatchIntentNamespaceListerExpansion interface{}
+
 // TridentNodeListerExpansion allows custom methods to be added to
 // TridentNodeLister.
 type TridentNodeListerExpansion interface{}
@@ -20,6 +36,14 @@ type TridentNodeListerExpansion interface{}
 // TridentNodeNamespaceLister.
 type TridentNodeNamespaceListerExpansion interface{}
 
+// TridentQuotaListerExpansion allows custom methods to be added to
+// TridentQuotaLister.
+type TridentQuotaListerExpansion interface{}
+
+// TridentQuotaNamespaceListerExpansion allows custom methods to be added to
+// TridentQuotaNamespaceLister.
+type TridentQuotaNamespaceListerExpansion interface{}
+
 // TridentSnapshotListerExpansion allows custom methods to be added to
 // TridentSnapshotLister.
 type TridentSnapshotListerExpansion interface{}
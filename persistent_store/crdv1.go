@@ -1058,6 +1058,41 @@ func (k *CRDClientV1) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction)
 	return k.crdClient.TridentV1().TridentTransactions(k.namespace).Delete(ctx(), v1.NameFix(volTxn.Name()), k.deleteOpts())
 }
 
+func (k *CRDClientV1) AddBatchIntent(intent *storage.BatchIntent) error {
+
+	newIntent, err := v1.NewTridentBatchIntent(intent)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.crdClient.TridentV1().TridentBatchIntents(k.namespace).Create(ctx(), newIntent, createOpts)
+	return err
+}
+
+func (k *CRDClientV1) GetBatchIntents() ([]*storage.BatchIntent, error) {
+
+	intentList, err := k.crdClient.TridentV1().TridentBatchIntents(k.namespace).List(ctx(), listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*storage.BatchIntent, 0)
+
+	for _, item := range intentList.Items {
+		persistent, err := item.Persistent()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, persistent)
+	}
+
+	return results, nil
+}
+
+func (k *CRDClientV1) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	return k.crdClient.TridentV1().TridentBatchIntents(k.namespace).Delete(ctx(), v1.NameFix(intent.Name), k.deleteOpts())
+}
+
 func (k *CRDClientV1) AddStorageClass(sc *storageclass.StorageClass) error {
 
 	persistentSC, err := v1.NewTridentStorageClass(sc.ConstructPersistent())
@@ -1232,6 +1267,64 @@ func (k *CRDClientV1) DeleteNode(n *utils.Node) error {
 	return k.crdClient.TridentV1().TridentNodes(k.namespace).Delete(ctx(), v1.NameFix(n.Name), k.deleteOpts())
 }
 
+func (k *CRDClientV1) AddQuota(quota *storage.Quota) error {
+
+	newQuota, err := v1.NewTridentQuota(quota)
+	if err != nil {
+		return err
+	}
+
+	_, err = k.crdClient.TridentV1().TridentQuotas(k.namespace).Create(ctx(), newQuota, createOpts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (k *CRDClientV1) GetQuota(quotaName string) (*storage.Quota, error) {
+
+	quota, err := k.crdClient.TridentV1().TridentQuotas(k.namespace).Get(ctx(), v1.NameFix(quotaName), getOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return quota.Persistent()
+}
+
+func (k *CRDClientV1) GetQuotas() ([]*storage.Quota, error) {
+
+	quotaList, err := k.crdClient.TridentV1().TridentQuotas(k.namespace).List(ctx(), listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*storage.Quota, 0)
+
+	for _, item := range quotaList.Items {
+		if !item.ObjectMeta.DeletionTimestamp.IsZero() {
+			log.WithFields(log.Fields{
+				"Name":              item.Name,
+				"DeletionTimestamp": item.DeletionTimestamp,
+			}).Debug("GetQuotas skipping deleted Quota")
+			continue
+		}
+
+		persistent, err := item.Persistent()
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, persistent)
+	}
+
+	return results, nil
+}
+
+func (k *CRDClientV1) DeleteQuota(quota *storage.Quota) error {
+	return k.crdClient.TridentV1().TridentQuotas(k.namespace).Delete(ctx(), v1.NameFix(quota.Name), k.deleteOpts())
+}
+
 // deleteOpts returns a DeleteOptions struct suitable for most DELETE calls to the K8S REST API.
 func (k *CRDClientV1) deleteOpts() metav1.DeleteOptions {
 
@@ -1301,6 +1394,26 @@ func (k *CRDClientV1) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
 	return results, nil
 }
 
+// UpdateSnapshot updates a snapshot's state on the persistent store
+func (k *CRDClientV1) UpdateSnapshot(update *storage.Snapshot) error {
+
+	snapshot, err := k.crdClient.TridentV1().TridentSnapshots(k.namespace).Get(ctx(), v1.NameFix(update.ID()), getOpts)
+	if err != nil {
+		return err
+	}
+
+	if err = snapshot.Apply(update.ConstructPersistent()); err != nil {
+		return err
+	}
+
+	_, err = k.crdClient.TridentV1().TridentSnapshots(k.namespace).Update(ctx(), snapshot, updateOpts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (k *CRDClientV1) DeleteSnapshot(snapshot *storage.Snapshot) error {
 	return k.crdClient.TridentV1().TridentSnapshots(k.namespace).Delete(ctx(), v1.NameFix(snapshot.ID()), k.deleteOpts())
 }
@@ -5,8 +5,6 @@ package persistentstore
 import (
 	"errors"
 	"fmt"
-
-	log "github.com/sirupsen/logrus"
 )
 
 type DataMigrator struct {
@@ -25,11 +23,14 @@ func NewDataMigrator(SourceClient, DestClient Client, dryRun bool) *DataMigrator
 
 func (m *DataMigrator) Run() error {
 
-	// Determine if this is a supported data migration.
-	// DataMigrator currently only supports etcdv3 to crdv1 migration.
-	if m.SourceClient.GetType() != EtcdV3Store || m.DestClient.GetType() != CRDV1Store {
-		log.Debug("DataMigrator currently only supports etcdv3 to crdv1 migration.")
-		return nil
+	// Untransformed etcdv3 data may be migrated to CRDs directly, as the CRD migrator transforms it on the
+	// fly.  All other data is copied as is.
+	if !m.isUntransformedEtcdToCRD() {
+		storeDataMigrator := NewStoreDataMigrator(m.SourceClient, m.DestClient, m.dryRun)
+		if err := storeDataMigrator.RunPrechecks(); err != nil {
+			return fmt.Errorf("data migration prechecks failed: %v", err)
+		}
+		return storeDataMigrator.Run()
 	}
 
 	etcdClient, ok := m.SourceClient.(EtcdClient)
@@ -51,3 +52,12 @@ func (m *DataMigrator) Run() error {
 
 	return crdDataMigrator.Run()
 }
+
+func (m *DataMigrator) isUntransformedEtcdToCRD() bool {
+
+	if m.SourceClient.GetType() != EtcdV3bStore || m.DestClient.GetType() != CRDV1Store {
+		return false
+	}
+	version, err := m.SourceClient.GetVersion()
+	return err == nil && version.PersistentStoreVersion == string(EtcdV3Store)
+}
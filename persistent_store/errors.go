@@ -3,6 +3,7 @@
 package persistentstore
 
 import (
+	"fmt"
 	"net/http"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -49,6 +50,25 @@ func MatchUnavailableClusterErr(err error) bool {
 	return false
 }
 
+// BatchNotAppliedError is returned when a batch was committed, by saving its intent, but not all of its
+// writes could be made.  The batch's writes are finished by retrying it.
+type BatchNotAppliedError struct {
+	Intent string
+	Err    error
+}
+
+func (e *BatchNotAppliedError) Error() string {
+	return fmt.Sprintf("batch %s was committed but not applied; %v", e.Intent, e.Err)
+}
+
+func IsBatchNotAppliedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*BatchNotAppliedError)
+	return ok
+}
+
 func IsStatusError(err error) bool {
 	if err == nil {
 		return false
@@ -517,6 +517,43 @@ func (p *EtcdClientV2) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction
 	return nil
 }
 
+// AddBatchIntent saves the intent of a batch that can't be written atomically
+func (p *EtcdClientV2) AddBatchIntent(intent *storage.BatchIntent) error {
+	intentJSON, err := json.Marshal(intent)
+	if err != nil {
+		return err
+	}
+	return p.Create(config.BatchIntentURL+"/"+intent.Name, string(intentJSON))
+}
+
+// GetBatchIntents retrieves the intents of batches not yet written
+func (p *EtcdClientV2) GetBatchIntents() ([]*storage.BatchIntent, error) {
+	intentList := make([]*storage.BatchIntent, 0)
+	keys, err := p.ReadKeys(config.BatchIntentURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return intentList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		intentJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		intent := &storage.BatchIntent{}
+		if err = json.Unmarshal([]byte(intentJSON), intent); err != nil {
+			return nil, err
+		}
+		intentList = append(intentList, intent)
+	}
+	return intentList, nil
+}
+
+// DeleteBatchIntent deletes the intent of a batch once all of its writes are made
+func (p *EtcdClientV2) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	return p.Delete(config.BatchIntentURL + "/" + intent.Name)
+}
+
 func (p *EtcdClientV2) AddStorageClass(sc *storageclass.StorageClass) error {
 	sClass := sc.ConstructPersistent()
 	storageClassJSON, err := json.Marshal(sClass)
@@ -670,6 +707,15 @@ func (p *EtcdClientV2) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
 	return snapshotList, nil
 }
 
+// UpdateSnapshot updates a snapshot's state on the persistent store
+func (p *EtcdClientV2) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	snapJSON, err := json.Marshal(snapshot.ConstructPersistent())
+	if err != nil {
+		return err
+	}
+	return p.Update(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+}
+
 // DeleteSnapshot deletes a snapshot from the persistent store
 func (p *EtcdClientV2) DeleteSnapshot(snapshot *storage.Snapshot) error {
 	return p.Delete(config.SnapshotURL + "/" + snapshot.ID())
@@ -689,3 +735,49 @@ func (p *EtcdClientV2) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot)
 func (p *EtcdClientV2) DeleteSnapshots() error {
 	return p.deleteKeys(config.SnapshotURL)
 }
+
+// AddQuota adds a quota's state to the persistent store
+func (p *EtcdClientV2) AddQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	return p.Create(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+}
+
+// GetQuota fetches a quota's state from the persistent store
+func (p *EtcdClientV2) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	if err = json.Unmarshal([]byte(quotaJSON), quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *EtcdClientV2) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota, err := p.GetQuota(strings.TrimPrefix(key, config.QuotaURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *EtcdClientV2) DeleteQuota(quota *storage.Quota) error {
+	return p.Delete(config.QuotaURL + "/" + quota.Name)
+}
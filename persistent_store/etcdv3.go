@@ -494,6 +494,29 @@ func (p *EtcdClientV3) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *s
 	return err
 }
 
+// CommitBatch writes a batch of objects in a single etcd transaction
+func (p *EtcdClientV3) CommitBatch(batch *Batch) error {
+	_, err := conc.NewSTMSerializable(context.TODO(), p.clientV3,
+		func(s conc.STM) error {
+			for _, entry := range batch.entries {
+				key, value, isDelete, err := batchEntryKeyValue(entry)
+				if err != nil {
+					return err
+				}
+				if isDelete {
+					err = p.DeleteSTM(s, key)
+				} else {
+					err = p.SetSTM(s, key, value)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	return err
+}
+
 // failedReplaceBackendAndUpdateVolumes simulates a transaction failure in
 // replacing a backend and updating all the volumes to reflect the new backend
 // state. This method is intended to be used by unit tests only.
@@ -757,6 +780,43 @@ func (p *EtcdClientV3) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction
 	return nil
 }
 
+// AddBatchIntent saves the intent of a batch that can't be written atomically
+func (p *EtcdClientV3) AddBatchIntent(intent *storage.BatchIntent) error {
+	intentJSON, err := json.Marshal(intent)
+	if err != nil {
+		return err
+	}
+	return p.Create(config.BatchIntentURL+"/"+intent.Name, string(intentJSON))
+}
+
+// GetBatchIntents retrieves the intents of batches not yet written
+func (p *EtcdClientV3) GetBatchIntents() ([]*storage.BatchIntent, error) {
+	intentList := make([]*storage.BatchIntent, 0)
+	keys, err := p.ReadKeys(config.BatchIntentURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return intentList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		intentJSON, err := p.Read(key)
+		if err != nil {
+			return nil, err
+		}
+		intent := &storage.BatchIntent{}
+		if err = json.Unmarshal([]byte(intentJSON), intent); err != nil {
+			return nil, err
+		}
+		intentList = append(intentList, intent)
+	}
+	return intentList, nil
+}
+
+// DeleteBatchIntent deletes the intent of a batch once all of its writes are made
+func (p *EtcdClientV3) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	return p.Delete(config.BatchIntentURL + "/" + intent.Name)
+}
+
 func (p *EtcdClientV3) AddStorageClass(sc *storageclass.StorageClass) error {
 	sClass := sc.ConstructPersistent()
 	storageClassJSON, err := json.Marshal(sClass)
@@ -911,6 +971,15 @@ func (p *EtcdClientV3) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
 	return snapshotList, nil
 }
 
+// UpdateSnapshot updates a snapshot's state on the persistent store
+func (p *EtcdClientV3) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	snapJSON, err := json.Marshal(snapshot.ConstructPersistent())
+	if err != nil {
+		return err
+	}
+	return p.Update(config.SnapshotURL+"/"+snapshot.ID(), string(snapJSON))
+}
+
 // DeleteSnapshot deletes a snapshot from the persistent store
 func (p *EtcdClientV3) DeleteSnapshot(snapshot *storage.Snapshot) error {
 	return p.Delete(config.SnapshotURL + "/" + snapshot.ID())
@@ -930,3 +999,49 @@ func (p *EtcdClientV3) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot)
 func (p *EtcdClientV3) DeleteSnapshots() error {
 	return p.deleteKeys(config.SnapshotURL)
 }
+
+// AddQuota adds a quota's state to the persistent store
+func (p *EtcdClientV3) AddQuota(quota *storage.Quota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	return p.Create(config.QuotaURL+"/"+quota.Name, string(quotaJSON))
+}
+
+// GetQuota fetches a quota's state from the persistent store
+func (p *EtcdClientV3) GetQuota(quotaName string) (*storage.Quota, error) {
+	quotaJSON, err := p.Read(config.QuotaURL + "/" + quotaName)
+	if err != nil {
+		return nil, err
+	}
+	quota := &storage.Quota{}
+	if err = json.Unmarshal([]byte(quotaJSON), quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *EtcdClientV3) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	keys, err := p.ReadKeys(config.QuotaURL)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return quotaList, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		quota, err := p.GetQuota(strings.TrimPrefix(key, config.QuotaURL+"/"))
+		if err != nil {
+			return nil, err
+		}
+		quotaList = append(quotaList, quota)
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *EtcdClientV3) DeleteQuota(quota *storage.Quota) error {
+	return p.Delete(config.QuotaURL + "/" + quota.Name)
+}
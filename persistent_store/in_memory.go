@@ -25,6 +25,9 @@ type InMemoryClient struct {
 	nodesAdded          int
 	snapshots           map[string]*storage.SnapshotPersistent
 	snapshotsAdded      int
+	quotas              map[string]*storage.Quota
+	quotasAdded         int
+	batchIntents        map[string]*storage.BatchIntent
 }
 
 func NewInMemoryClient() *InMemoryClient {
@@ -35,6 +38,8 @@ func NewInMemoryClient() *InMemoryClient {
 		volumeTxns:     make(map[string]*storage.VolumeTransaction),
 		nodes:          make(map[string]*utils.Node),
 		snapshots:      make(map[string]*storage.SnapshotPersistent),
+		quotas:         make(map[string]*storage.Quota),
+		batchIntents:   make(map[string]*storage.BatchIntent),
 		version: &config.PersistentStateVersion{
 			"memory", config.OrchestratorAPIVersion,
 		},
@@ -52,6 +57,7 @@ func (c *InMemoryClient) Stop() error {
 	c.volumeTxnsAdded = 0
 	c.nodesAdded = 0
 	c.snapshotsAdded = 0
+	c.quotasAdded = 0
 	return nil
 }
 
@@ -229,6 +235,16 @@ func (c *InMemoryClient) DeleteVolumes() error {
 	return nil
 }
 
+// CommitBatch writes a batch directly, as nothing in memory outlives an interruption
+func (c *InMemoryClient) CommitBatch(batch *Batch) error {
+	for _, entry := range batch.entries {
+		if err := applyBatchEntry(c, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *InMemoryClient) AddVolumeTransaction(volTxn *storage.VolumeTransaction) error {
 	// AddVolumeTransaction overwrites existing keys, unlike the other methods
 	c.volumeTxns[volTxn.Name()] = volTxn
@@ -271,6 +287,30 @@ func (c *InMemoryClient) DeleteVolumeTransaction(volTxn *storage.VolumeTransacti
 	return nil
 }
 
+func (c *InMemoryClient) AddBatchIntent(intent *storage.BatchIntent) error {
+	if _, ok := c.batchIntents[intent.Name]; ok {
+		return fmt.Errorf("batch intent %s already exists", intent.Name)
+	}
+	c.batchIntents[intent.Name] = intent
+	return nil
+}
+
+func (c *InMemoryClient) GetBatchIntents() ([]*storage.BatchIntent, error) {
+	ret := make([]*storage.BatchIntent, 0, len(c.batchIntents))
+	for _, intent := range c.batchIntents {
+		ret = append(ret, intent)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	if _, ok := c.batchIntents[intent.Name]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, intent.Name)
+	}
+	delete(c.batchIntents, intent.Name)
+	return nil
+}
+
 func (c *InMemoryClient) AddStorageClass(s *sc.StorageClass) error {
 	storageClass := s.ConstructPersistent()
 	if _, ok := c.storageClasses[storageClass.GetName()]; ok {
@@ -382,6 +422,16 @@ func (c *InMemoryClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
 	return ret, nil
 }
 
+// UpdateSnapshot updates a snapshot's state on the persistent store
+func (c *InMemoryClient) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	// UpdateSnapshot requires the snapshot to already exist.
+	if _, ok := c.snapshots[snapshot.ID()]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, snapshot.Config.Name)
+	}
+	c.snapshots[snapshot.ID()] = snapshot.ConstructPersistent()
+	return nil
+}
+
 // DeleteSnapshot deletes a snapshot from the persistent store
 func (c *InMemoryClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
 	if _, ok := c.snapshots[snapshot.ID()]; !ok {
@@ -407,3 +457,40 @@ func (c *InMemoryClient) DeleteSnapshots() error {
 	c.snapshots = make(map[string]*storage.SnapshotPersistent)
 	return nil
 }
+
+func (c *InMemoryClient) AddQuota(quota *storage.Quota) error {
+	if _, ok := c.quotas[quota.Name]; ok {
+		return fmt.Errorf("quota %s already exists", quota.Name)
+	}
+	c.quotas[quota.Name] = quota
+	c.quotasAdded++
+	return nil
+}
+
+func (c *InMemoryClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	ret, ok := c.quotas[quotaName]
+	if !ok {
+		return nil, NewPersistentStoreError(KeyNotFoundErr, quotaName)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) GetQuotas() ([]*storage.Quota, error) {
+	ret := make([]*storage.Quota, 0, len(c.quotas))
+	if c.quotasAdded == 0 {
+		// Try to match etcd semantics as closely as possible.
+		return ret, nil
+	}
+	for _, v := range c.quotas {
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func (c *InMemoryClient) DeleteQuota(quota *storage.Quota) error {
+	if _, ok := c.quotas[quota.Name]; !ok {
+		return NewPersistentStoreError(KeyNotFoundErr, quota.Name)
+	}
+	delete(c.quotas, quota.Name)
+	return nil
+}
@@ -174,6 +174,8 @@ func (c *PassthroughClient) unmarshalConfig(fileContents []byte) (string, error)
 		configType = "gcp_config"
 	case drivers.FakeStorageDriverName:
 		configType = "fake_config"
+	case drivers.FakeOntapStorageDriverName:
+		configType = "fake_ontap_config"
 	default:
 		return "", fmt.Errorf("unknown storage driver: %v", commonConfig.StorageDriverName)
 	}
@@ -386,6 +388,11 @@ func (c *PassthroughClient) DeleteVolumes() error {
 	return nil
 }
 
+// CommitBatch need not do anything, as the passthrough store saves no volumes, snapshots, or transactions
+func (c *PassthroughClient) CommitBatch(batch *Batch) error {
+	return nil
+}
+
 func (c *PassthroughClient) AddVolumeTransaction(volTxn *storage.VolumeTransaction) error {
 	return nil
 }
@@ -406,6 +413,18 @@ func (c *PassthroughClient) DeleteVolumeTransaction(volTxn *storage.VolumeTransa
 	return nil
 }
 
+func (c *PassthroughClient) AddBatchIntent(intent *storage.BatchIntent) error {
+	return nil
+}
+
+func (c *PassthroughClient) GetBatchIntents() ([]*storage.BatchIntent, error) {
+	return make([]*storage.BatchIntent, 0), nil
+}
+
+func (c *PassthroughClient) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	return nil
+}
+
 func (c *PassthroughClient) AddStorageClass(sc *sc.StorageClass) error {
 	return nil
 }
@@ -451,6 +470,10 @@ func (c *PassthroughClient) GetSnapshots() ([]*storage.SnapshotPersistent, error
 	return make([]*storage.SnapshotPersistent, 0), nil
 }
 
+func (c *PassthroughClient) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	return nil
+}
+
 func (c *PassthroughClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
 	return nil
 }
@@ -462,3 +485,19 @@ func (c *PassthroughClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snaps
 func (c *PassthroughClient) DeleteSnapshots() error {
 	return nil
 }
+
+func (c *PassthroughClient) AddQuota(quota *storage.Quota) error {
+	return nil
+}
+
+func (c *PassthroughClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	return nil, NewPersistentStoreError(KeyNotFoundErr, quotaName)
+}
+
+func (c *PassthroughClient) GetQuotas() ([]*storage.Quota, error) {
+	return make([]*storage.Quota, 0), nil
+}
+
+func (c *PassthroughClient) DeleteQuota(quota *storage.Quota) error {
+	return nil
+}
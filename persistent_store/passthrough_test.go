@@ -3,6 +3,7 @@
 package persistentstore
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -556,7 +557,7 @@ func TestPassthroughClient_GetVolumes(t *testing.T) {
 		InternalName: "fake_volume_1",
 		Size:         "1000000000",
 	}
-	err := fakeBackend.Driver.Create(volConfig, fakeBackend.Storage["pool-0"], make(map[string]sa.Request))
+	err := fakeBackend.Driver.Create(context.Background(), volConfig, fakeBackend.Storage["pool-0"], make(map[string]sa.Request))
 	if err != nil {
 		t.Error(err)
 	}
@@ -566,7 +567,7 @@ func TestPassthroughClient_GetVolumes(t *testing.T) {
 		InternalName: "fake_volume_2",
 		Size:         "2000000000",
 	}
-	err = fakeBackend.Driver.Create(volConfig, fakeBackend.Storage["pool-0"], make(map[string]sa.Request))
+	err = fakeBackend.Driver.Create(context.Background(), volConfig, fakeBackend.Storage["pool-0"], make(map[string]sa.Request))
 	if err != nil {
 		t.Error(err)
 	}
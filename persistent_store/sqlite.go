@@ -0,0 +1,697 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	storageclass "github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// sqliteSchema holds Trident's objects by the same keys used with etcd, so that state may be copied
+	// between the two as is
+	sqliteSchema = `CREATE TABLE IF NOT EXISTS trident_objects (
+	key   TEXT PRIMARY KEY NOT NULL,
+	value TEXT NOT NULL
+)`
+
+	// sqliteBusyTimeoutMS is how long a statement waits for another connection's lock, in milliseconds
+	sqliteBusyTimeoutMS = 5000
+
+	// sqliteDriverName is the name the SQLite driver registers with database/sql
+	sqliteDriverName = "sqlite3"
+)
+
+// ErrSQLiteUnavailable is returned when Trident was built without the SQLite driver, which requires cgo.
+var ErrSQLiteUnavailable = errors.New("the SQLite persistent store is not available in this build of " +
+	"Trident, which was built without cgo")
+
+// SQLiteClient keeps Trident's state in a SQLite database file, giving Docker and other deployments
+// without Kubernetes durable state without running etcd.
+type SQLiteClient struct {
+	db   *sql.DB
+	path string
+}
+
+// NewSQLiteClient opens the SQLite database at the specified path, creating it if necessary.
+func NewSQLiteClient(path string) (*SQLiteClient, error) {
+
+	if !SQLiteAvailable() {
+		return nil, ErrSQLiteUnavailable
+	}
+	if path == "" {
+		return nil, fmt.Errorf("SQLite database path must be specified")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("could not create directory for SQLite database %s; %v", path, err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=%d&_journal_mode=WAL&_synchronous=FULL", path, sqliteBusyTimeoutMS)
+	db, err := sql.Open(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not open SQLite database %s; %v", path, err)
+	}
+
+	// SQLite allows a single writer, so a single connection avoids lock contention within Trident
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("could not initialize SQLite database %s; %v", path, err)
+	}
+
+	log.WithField("path", path).Debug("Opened SQLite database.")
+
+	return &SQLiteClient{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// SQLiteAvailable returns whether the SQLite driver was built into Trident.
+func SQLiteAvailable() bool {
+	for _, driver := range sql.Drivers() {
+		if driver == sqliteDriverName {
+			return true
+		}
+	}
+	return false
+}
+
+// Create creates a key
+func (p *SQLiteClient) Create(key, value string) error {
+	result, err := p.db.Exec("INSERT OR IGNORE INTO trident_objects (key, value) VALUES (?, ?)", key, value)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return NewPersistentStoreError(KeyExistsErr, key)
+	}
+	return nil
+}
+
+// Read reads a key
+func (p *SQLiteClient) Read(key string) (string, error) {
+	var value string
+	err := p.db.QueryRow("SELECT value FROM trident_objects WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", NewPersistentStoreError(KeyNotFoundErr, key)
+	} else if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// ReadKeys returns all the keys with the designated prefix, in order
+func (p *SQLiteClient) ReadKeys(keyPrefix string) ([]string, error) {
+	keys := make([]string, 0)
+	rows, err := p.db.Query(
+		"SELECT key FROM trident_objects WHERE substr(key, 1, ?) = ? ORDER BY key", len(keyPrefix), keyPrefix)
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return keys, err
+	}
+	if len(keys) == 0 {
+		return keys, NewPersistentStoreError(KeyNotFoundErr, keyPrefix)
+	}
+	return keys, nil
+}
+
+// Update updates an existing key
+func (p *SQLiteClient) Update(key, value string) error {
+	result, err := p.db.Exec("UPDATE trident_objects SET value = ? WHERE key = ?", value, key)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return nil
+}
+
+// Set creates or updates a key
+func (p *SQLiteClient) Set(key, value string) error {
+	_, err := p.db.Exec("INSERT OR REPLACE INTO trident_objects (key, value) VALUES (?, ?)", key, value)
+	return err
+}
+
+// Delete deletes a key
+func (p *SQLiteClient) Delete(key string) error {
+	result, err := p.db.Exec("DELETE FROM trident_objects WHERE key = ?", key)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return NewPersistentStoreError(KeyNotFoundErr, key)
+	}
+	return nil
+}
+
+// DeleteKeys deletes all the keys with the designated prefix
+func (p *SQLiteClient) DeleteKeys(keyPrefix string) error {
+	result, err := p.db.Exec("DELETE FROM trident_objects WHERE substr(key, 1, ?) = ?", len(keyPrefix), keyPrefix)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return NewPersistentStoreError(KeyNotFoundErr, keyPrefix)
+	}
+	return nil
+}
+
+// GetType returns the persistent store type
+func (p *SQLiteClient) GetType() StoreType {
+	return SQLiteStore
+}
+
+// Stop closes the database
+func (p *SQLiteClient) Stop() error {
+	return p.db.Close()
+}
+
+// GetConfig returns the configuration for the SQLite client
+func (p *SQLiteClient) GetConfig() *ClientConfig {
+	return &ClientConfig{endpoints: p.path}
+}
+
+// GetVersion returns the version of the persistent data
+func (p *SQLiteClient) GetVersion() (*config.PersistentStateVersion, error) {
+	versionJSON, err := p.Read(config.StoreURL)
+	if err != nil {
+		return nil, err
+	}
+	version := &config.PersistentStateVersion{}
+	if err = json.Unmarshal([]byte(versionJSON), version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// SetVersion sets the version of the persistent data
+func (p *SQLiteClient) SetVersion(version *config.PersistentStateVersion) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Setting version: %v", string(versionJSON))
+	return p.Set(config.StoreURL, string(versionJSON))
+}
+
+// createJSON creates a key holding the JSON form of an object
+func (p *SQLiteClient) createJSON(key string, object interface{}) error {
+	objectJSON, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return p.Create(key, string(objectJSON))
+}
+
+// updateJSON updates a key to hold the JSON form of an object
+func (p *SQLiteClient) updateJSON(key string, object interface{}) error {
+	objectJSON, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return p.Update(key, string(objectJSON))
+}
+
+// readJSON reads the object held by a key
+func (p *SQLiteClient) readJSON(key string, object interface{}) error {
+	objectJSON, err := p.Read(key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(objectJSON), object)
+}
+
+// readAllJSON calls newObject for each key with the designated prefix, and reads the key's object into
+// the value it returns
+func (p *SQLiteClient) readAllJSON(keyPrefix string, newObject func() interface{}) error {
+	keys, err := p.ReadKeys(keyPrefix + "/")
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = p.readJSON(key, newObject()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteKeysIgnoreNotFound deletes all the keys with the designated prefix, if any
+func (p *SQLiteClient) deleteKeysIgnoreNotFound(keyPrefix string) error {
+	if err := p.DeleteKeys(keyPrefix + "/"); err != nil && !MatchKeyNotFoundErr(err) {
+		return err
+	}
+	return nil
+}
+
+// AddBackend saves the minimally required backend state to the persistent store
+func (p *SQLiteClient) AddBackend(b *storage.Backend) error {
+	return p.AddBackendPersistent(b.ConstructPersistent())
+}
+
+// AddBackendPersistent saves a backend's persistent state to the persistent store
+func (p *SQLiteClient) AddBackendPersistent(backend *storage.BackendPersistent) error {
+	return p.createJSON(config.BackendURL+"/"+backend.Name, backend)
+}
+
+// GetBackend retrieves a backend from the persistent store
+func (p *SQLiteClient) GetBackend(backendName string) (*storage.BackendPersistent, error) {
+	backend := &storage.BackendPersistent{}
+	if err := p.readJSON(config.BackendURL+"/"+backendName, backend); err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+// UpdateBackend updates the backend state on the persistent store
+func (p *SQLiteClient) UpdateBackend(b *storage.Backend) error {
+	return p.UpdateBackendPersistent(b.ConstructPersistent())
+}
+
+// UpdateBackendPersistent updates a backend's persistent state
+func (p *SQLiteClient) UpdateBackendPersistent(backend *storage.BackendPersistent) error {
+	return p.updateJSON(config.BackendURL+"/"+backend.Name, backend)
+}
+
+// DeleteBackend deletes the backend state on the persistent store
+func (p *SQLiteClient) DeleteBackend(backend *storage.Backend) error {
+	return p.Delete(config.BackendURL + "/" + backend.Name)
+}
+
+// GetBackends retrieves all backends
+func (p *SQLiteClient) GetBackends() ([]*storage.BackendPersistent, error) {
+	backendList := make([]*storage.BackendPersistent, 0)
+	err := p.readAllJSON(config.BackendURL, func() interface{} {
+		backend := &storage.BackendPersistent{}
+		backendList = append(backendList, backend)
+		return backend
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range backendList {
+		if !backend.Online {
+			// handle upgrade logic for "Offline" (aka Deleting) backends
+			backend.State = storage.Deleting
+		}
+	}
+	return backendList, nil
+}
+
+// DeleteBackends deletes all backends
+func (p *SQLiteClient) DeleteBackends() error {
+	return p.deleteKeysIgnoreNotFound(config.BackendURL)
+}
+
+// ReplaceBackendAndUpdateVolumes replaces a backend and updates all volumes to
+// reflect the new backend.
+func (p *SQLiteClient) ReplaceBackendAndUpdateVolumes(origBackend, newBackend *storage.Backend) error {
+
+	newBackend.BackendUUID = origBackend.BackendUUID
+	backendJSON, err := json.Marshal(newBackend.ConstructPersistent())
+	if err != nil {
+		return err
+	}
+
+	// It's important to update the persistent store objects in an atomic way.
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	newKey := config.BackendURL + "/" + newBackend.Name
+	if result, err := tx.Exec("INSERT OR IGNORE INTO trident_objects (key, value) VALUES (?, ?)",
+		newKey, string(backendJSON)); err != nil {
+		_ = tx.Rollback()
+		return err
+	} else if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return NewPersistentStoreError(KeyExistsErr, newKey)
+	}
+
+	origKey := config.BackendURL + "/" + origBackend.Name
+	if result, err := tx.Exec("DELETE FROM trident_objects WHERE key = ?", origKey); err != nil {
+		_ = tx.Rollback()
+		return err
+	} else if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return NewPersistentStoreError(KeyNotFoundErr, origKey)
+	}
+
+	return tx.Commit()
+}
+
+// CommitBatch writes a batch of objects in a single SQL transaction
+func (p *SQLiteClient) CommitBatch(batch *Batch) error {
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range batch.entries {
+		key, value, isDelete, err := batchEntryKeyValue(entry)
+		if err == nil {
+			if isDelete {
+				_, err = tx.Exec("DELETE FROM trident_objects WHERE key = ?", key)
+			} else {
+				_, err = tx.Exec("INSERT OR REPLACE INTO trident_objects (key, value) VALUES (?, ?)", key, value)
+			}
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AddVolume saves a volume's state to the persistent store
+func (p *SQLiteClient) AddVolume(vol *storage.Volume) error {
+	return p.AddVolumePersistent(vol.ConstructExternal())
+}
+
+// AddVolumePersistent saves a volume's persistent state to the persistent store
+func (p *SQLiteClient) AddVolumePersistent(volExternal *storage.VolumeExternal) error {
+	return p.createJSON(config.VolumeURL+"/"+volExternal.Config.Name, volExternal)
+}
+
+// GetVolume retrieves a volume's state from the persistent store
+func (p *SQLiteClient) GetVolume(volName string) (*storage.VolumeExternal, error) {
+	volExternal := &storage.VolumeExternal{}
+	if err := p.readJSON(config.VolumeURL+"/"+volName, volExternal); err != nil {
+		return nil, err
+	}
+	return volExternal, nil
+}
+
+// UpdateVolume updates a volume's state on the persistent store
+func (p *SQLiteClient) UpdateVolume(vol *storage.Volume) error {
+	return p.UpdateVolumePersistent(vol.ConstructExternal())
+}
+
+// UpdateVolumePersistent updates a volume's persistent state
+func (p *SQLiteClient) UpdateVolumePersistent(volExternal *storage.VolumeExternal) error {
+	return p.updateJSON(config.VolumeURL+"/"+volExternal.Config.Name, volExternal)
+}
+
+// DeleteVolume deletes a volume's state from the persistent store
+func (p *SQLiteClient) DeleteVolume(vol *storage.Volume) error {
+	return p.Delete(config.VolumeURL + "/" + vol.Config.Name)
+}
+
+func (p *SQLiteClient) DeleteVolumeIgnoreNotFound(vol *storage.Volume) error {
+	err := p.DeleteVolume(vol)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// GetVolumes retrieves all volumes
+func (p *SQLiteClient) GetVolumes() ([]*storage.VolumeExternal, error) {
+	volumeList := make([]*storage.VolumeExternal, 0)
+	err := p.readAllJSON(config.VolumeURL, func() interface{} {
+		volume := &storage.VolumeExternal{}
+		volumeList = append(volumeList, volume)
+		return volume
+	})
+	if err != nil {
+		return nil, err
+	}
+	return volumeList, nil
+}
+
+// DeleteVolumes deletes all volumes
+func (p *SQLiteClient) DeleteVolumes() error {
+	return p.deleteKeysIgnoreNotFound(config.VolumeURL)
+}
+
+// AddVolumeTransaction logs an AddVolume operation
+func (p *SQLiteClient) AddVolumeTransaction(volTxn *storage.VolumeTransaction) error {
+	volTxnJSON, err := json.Marshal(volTxn)
+	if err != nil {
+		return err
+	}
+	return p.Set(config.TransactionURL+"/"+volTxn.Name(), string(volTxnJSON))
+}
+
+// GetVolumeTransactions retrieves AddVolume logs
+func (p *SQLiteClient) GetVolumeTransactions() ([]*storage.VolumeTransaction, error) {
+	volTxnList := make([]*storage.VolumeTransaction, 0)
+	err := p.readAllJSON(config.TransactionURL, func() interface{} {
+		volTxn := &storage.VolumeTransaction{}
+		volTxnList = append(volTxnList, volTxn)
+		return volTxn
+	})
+	if err != nil {
+		return nil, err
+	}
+	return volTxnList, nil
+}
+
+func (p *SQLiteClient) UpdateVolumeTransaction(volTxn *storage.VolumeTransaction) error {
+	return p.updateJSON(config.TransactionURL+"/"+volTxn.Name(), volTxn)
+}
+
+// GetExistingVolumeTransaction returns an existing version of the current
+// volume transaction, if it exists.  If no volume transaction with the same
+// key exists, it returns nil.
+func (p *SQLiteClient) GetExistingVolumeTransaction(
+	volTxn *storage.VolumeTransaction,
+) (*storage.VolumeTransaction, error) {
+	var ret storage.VolumeTransaction
+
+	key := volTxn.Name()
+	if err := p.readJSON(config.TransactionURL+"/"+key, &ret); err != nil {
+		if MatchKeyNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read volume transaction %s from SQLite: %v", key, err)
+	}
+	return &ret, nil
+}
+
+// DeleteVolumeTransaction deletes an AddVolume log
+func (p *SQLiteClient) DeleteVolumeTransaction(volTxn *storage.VolumeTransaction) error {
+	return p.Delete(config.TransactionURL + "/" + volTxn.Name())
+}
+
+// AddBatchIntent saves the intent of a batch that can't be written atomically
+func (p *SQLiteClient) AddBatchIntent(intent *storage.BatchIntent) error {
+	return p.createJSON(config.BatchIntentURL+"/"+intent.Name, intent)
+}
+
+// GetBatchIntents retrieves the intents of batches not yet written
+func (p *SQLiteClient) GetBatchIntents() ([]*storage.BatchIntent, error) {
+	intentList := make([]*storage.BatchIntent, 0)
+	err := p.readAllJSON(config.BatchIntentURL, func() interface{} {
+		intent := &storage.BatchIntent{}
+		intentList = append(intentList, intent)
+		return intent
+	})
+	if err != nil {
+		return nil, err
+	}
+	return intentList, nil
+}
+
+// DeleteBatchIntent deletes the intent of a batch once all of its writes are made
+func (p *SQLiteClient) DeleteBatchIntent(intent *storage.BatchIntent) error {
+	return p.Delete(config.BatchIntentURL + "/" + intent.Name)
+}
+
+func (p *SQLiteClient) AddStorageClass(sc *storageclass.StorageClass) error {
+	sClass := sc.ConstructPersistent()
+	return p.createJSON(config.StorageClassURL+"/"+sClass.GetName(), sClass)
+}
+
+func (p *SQLiteClient) GetStorageClass(scName string) (*storageclass.Persistent, error) {
+	persistent := &storageclass.Persistent{}
+	if err := p.readJSON(config.StorageClassURL+"/"+scName, persistent); err != nil {
+		return nil, err
+	}
+	return persistent, nil
+}
+
+func (p *SQLiteClient) GetStorageClasses() ([]*storageclass.Persistent, error) {
+	storageClassList := make([]*storageclass.Persistent, 0)
+	err := p.readAllJSON(config.StorageClassURL, func() interface{} {
+		sc := &storageclass.Persistent{}
+		storageClassList = append(storageClassList, sc)
+		return sc
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storageClassList, nil
+}
+
+// DeleteStorageClass deletes a storage class's state from the persistent store
+func (p *SQLiteClient) DeleteStorageClass(sc *storageclass.StorageClass) error {
+	return p.Delete(config.StorageClassURL + "/" + sc.GetName())
+}
+
+// AddOrUpdateNode adds/updates a CSI node object to the persistent store
+func (p *SQLiteClient) AddOrUpdateNode(n *utils.Node) error {
+	nodeJSON, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return p.Set(config.NodeURL+"/"+n.Name, string(nodeJSON))
+}
+
+func (p *SQLiteClient) GetNode(nName string) (*utils.Node, error) {
+	node := &utils.Node{}
+	if err := p.readJSON(config.NodeURL+"/"+nName, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (p *SQLiteClient) GetNodes() ([]*utils.Node, error) {
+	nodeList := make([]*utils.Node, 0)
+	err := p.readAllJSON(config.NodeURL, func() interface{} {
+		node := &utils.Node{}
+		nodeList = append(nodeList, node)
+		return node
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodeList, nil
+}
+
+// DeleteNode deletes a node from the persistent store
+func (p *SQLiteClient) DeleteNode(n *utils.Node) error {
+	return p.Delete(config.NodeURL + "/" + n.Name)
+}
+
+// AddSnapshot adds a snapshot's state to the persistent store
+func (p *SQLiteClient) AddSnapshot(snapshot *storage.Snapshot) error {
+	return p.createJSON(config.SnapshotURL+"/"+snapshot.ID(), snapshot.ConstructPersistent())
+}
+
+// GetSnapshot fetches a snapshot's state from the persistent store
+func (p *SQLiteClient) GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error) {
+	snapPersistent := &storage.SnapshotPersistent{}
+	key := config.SnapshotURL + "/" + storage.MakeSnapshotID(volumeName, snapshotName)
+	if err := p.readJSON(key, snapPersistent); err != nil {
+		return nil, err
+	}
+	return snapPersistent, nil
+}
+
+// GetSnapshots retrieves all snapshots
+func (p *SQLiteClient) GetSnapshots() ([]*storage.SnapshotPersistent, error) {
+	snapshotList := make([]*storage.SnapshotPersistent, 0)
+	err := p.readAllJSON(config.SnapshotURL, func() interface{} {
+		snapshot := &storage.SnapshotPersistent{}
+		snapshotList = append(snapshotList, snapshot)
+		return snapshot
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshotList, nil
+}
+
+// UpdateSnapshot updates a snapshot's state on the persistent store
+func (p *SQLiteClient) UpdateSnapshot(snapshot *storage.Snapshot) error {
+	return p.updateJSON(config.SnapshotURL+"/"+snapshot.ID(), snapshot.ConstructPersistent())
+}
+
+// DeleteSnapshot deletes a snapshot from the persistent store
+func (p *SQLiteClient) DeleteSnapshot(snapshot *storage.Snapshot) error {
+	return p.Delete(config.SnapshotURL + "/" + snapshot.ID())
+}
+
+// DeleteSnapshotIgnoreNotFound deletes a snapshot from the persistent store,
+// returning no error if the record does not exist.
+func (p *SQLiteClient) DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error {
+	err := p.DeleteSnapshot(snapshot)
+	if err != nil && MatchKeyNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteSnapshots deletes all snapshots
+func (p *SQLiteClient) DeleteSnapshots() error {
+	return p.deleteKeysIgnoreNotFound(config.SnapshotURL)
+}
+
+// AddQuota adds a quota's state to the persistent store
+func (p *SQLiteClient) AddQuota(quota *storage.Quota) error {
+	return p.createJSON(config.QuotaURL+"/"+quota.Name, quota)
+}
+
+// GetQuota fetches a quota's state from the persistent store
+func (p *SQLiteClient) GetQuota(quotaName string) (*storage.Quota, error) {
+	quota := &storage.Quota{}
+	if err := p.readJSON(config.QuotaURL+"/"+quotaName, quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// GetQuotas retrieves all quotas
+func (p *SQLiteClient) GetQuotas() ([]*storage.Quota, error) {
+	quotaList := make([]*storage.Quota, 0)
+	err := p.readAllJSON(config.QuotaURL, func() interface{} {
+		quota := &storage.Quota{}
+		quotaList = append(quotaList, quota)
+		return quota
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quotaList, nil
+}
+
+// DeleteQuota deletes a quota from the persistent store
+func (p *SQLiteClient) DeleteQuota(quota *storage.Quota) error {
+	return p.Delete(config.QuotaURL + "/" + quota.Name)
+}
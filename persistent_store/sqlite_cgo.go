@@ -0,0 +1,10 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+//go:build cgo
+// +build cgo
+
+package persistentstore
+
+// The SQLite driver wraps SQLite's C library, so the SQLite store is only built into Trident when cgo is
+// enabled.  Without it, NewSQLiteClient reports that the store is unavailable.
+import _ "github.com/mattn/go-sqlite3"
@@ -0,0 +1,276 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap"
+	"github.com/netapp/trident/utils"
+)
+
+func newTestSQLiteClient(t *testing.T) (*SQLiteClient, func()) {
+
+	if !SQLiteAvailable() {
+		t.Skip("SQLite store requires cgo")
+	}
+
+	dir, err := ioutil.TempDir("", "trident-sqlite")
+	if err != nil {
+		t.Fatal("Unable to create temporary directory: ", err)
+	}
+	p, err := NewSQLiteClient(filepath.Join(dir, "state", "trident.db"))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatal("Unable to create SQLite client: ", err)
+	}
+	return p, func() {
+		_ = p.Stop()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestSQLiteUnavailable(t *testing.T) {
+	if SQLiteAvailable() {
+		t.Skip("SQLite store is available")
+	}
+
+	_, err := NewSQLiteClient(filepath.Join(os.TempDir(), "trident.db"))
+	assert.Equal(t, ErrSQLiteUnavailable, err)
+}
+
+func newTestSQLiteBackend(name string) *storage.Backend {
+	return &storage.Backend{
+		Driver: &ontap.NASStorageDriver{
+			Config: drivers.OntapStorageDriverConfig{
+				CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{
+					StorageDriverName: drivers.OntapNASStorageDriverName,
+				},
+				ManagementLIF: "10.0.0.4",
+				DataLIF:       "10.0.0.100",
+				SVM:           "svm1",
+				Username:      "admin",
+				Password:      "netapp",
+			},
+		},
+		Name:        name,
+		BackendUUID: "b1f8e5c4-1c1c-4c7a-9a5e-5f7b6f3e2d10",
+		Online:      true,
+		State:       storage.Online,
+	}
+}
+
+func TestNewSQLiteClientRequiresPath(t *testing.T) {
+	_, err := NewSQLiteClient("")
+	assert.Error(t, err)
+}
+
+func TestSQLiteCRUD(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	assert.NoError(t, p.Create("key1", "val1"))
+	assert.EqualError(t, p.Create("key1", "val1"), KeyExistsErr, "duplicate key wasn't caught")
+
+	val, err := p.Read("key1")
+	assert.NoError(t, err)
+	assert.Equal(t, "val1", val)
+
+	assert.NoError(t, p.Update("key1", "val2"))
+	val, _ = p.Read("key1")
+	assert.Equal(t, "val2", val)
+
+	assert.NoError(t, p.Set("key1", "val3"))
+	assert.NoError(t, p.Set("key2", "val4"))
+	val, _ = p.Read("key1")
+	assert.Equal(t, "val3", val)
+
+	assert.NoError(t, p.Delete("key1"))
+	_, err = p.Read("key1")
+	assert.True(t, MatchKeyNotFoundErr(err), "deleted key was found")
+	assert.True(t, MatchKeyNotFoundErr(p.Update("key1", "val5")), "missing key was updated")
+	assert.True(t, MatchKeyNotFoundErr(p.Delete("key1")), "missing key was deleted")
+}
+
+func TestSQLiteReadDeleteKeys(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, p.Create(fmt.Sprintf("/volume/vol%d", i), fmt.Sprintf("val%d", i)))
+	}
+	assert.NoError(t, p.Create("/backend/backend1", "val"))
+
+	keys, err := p.ReadKeys("/volume/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/volume/vol1", "/volume/vol2", "/volume/vol3", "/volume/vol4", "/volume/vol5"}, keys)
+
+	assert.NoError(t, p.DeleteKeys("/volume/"))
+	_, err = p.ReadKeys("/volume/")
+	assert.True(t, MatchKeyNotFoundErr(err), "deleted keys were found")
+	assert.True(t, MatchKeyNotFoundErr(p.DeleteKeys("/volume/")), "missing keys were deleted")
+
+	keys, err = p.ReadKeys("/backend/")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestSQLiteVersion(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	_, err := p.GetVersion()
+	assert.True(t, MatchKeyNotFoundErr(err), "version was found in an empty store")
+
+	version := &config.PersistentStateVersion{
+		PersistentStoreVersion: string(SQLiteStore),
+		OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+	}
+	assert.NoError(t, p.SetVersion(version))
+	recoveredVersion, err := p.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, version, recoveredVersion)
+}
+
+func TestSQLiteStateIsDurable(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	backend := newTestSQLiteBackend("ontapnas_1")
+	assert.NoError(t, p.AddBackend(backend))
+	assert.NoError(t, p.Stop())
+
+	p, err := NewSQLiteClient(p.path)
+	if err != nil {
+		t.Fatal("Unable to reopen SQLite client: ", err)
+	}
+	recoveredBackend, err := p.GetBackend(backend.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, backend.BackendUUID, recoveredBackend.BackendUUID)
+	assert.NoError(t, p.Stop())
+}
+
+func TestSQLiteBackendsAndVolumes(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	backend := newTestSQLiteBackend("ontapnas_1")
+	assert.NoError(t, p.AddBackend(backend))
+	assert.EqualError(t, p.AddBackend(backend), KeyExistsErr, "duplicate backend wasn't caught")
+
+	backend.State = storage.Deleting
+	assert.NoError(t, p.UpdateBackend(backend))
+	recoveredBackend, err := p.GetBackend(backend.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.Deleting, recoveredBackend.State)
+
+	for i := 0; i < 3; i++ {
+		vol := &storage.Volume{
+			Config: &storage.VolumeConfig{
+				Version:      config.OrchestratorAPIVersion,
+				Name:         fmt.Sprintf("vol%d", i),
+				Size:         "1GB",
+				Protocol:     config.File,
+				StorageClass: "gold",
+			},
+			BackendUUID: backend.BackendUUID,
+			Pool:        storagePool,
+		}
+		assert.NoError(t, p.AddVolume(vol))
+	}
+	volumes, err := p.GetVolumes()
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 3)
+
+	volume, err := p.GetVolume("vol1")
+	assert.NoError(t, err)
+	assert.Equal(t, storagePool, volume.Pool)
+
+	assert.NoError(t, p.DeleteVolumeIgnoreNotFound(&storage.Volume{Config: &storage.VolumeConfig{Name: "vol1"}}))
+	assert.NoError(t, p.DeleteVolumeIgnoreNotFound(&storage.Volume{Config: &storage.VolumeConfig{Name: "vol1"}}))
+	assert.NoError(t, p.DeleteVolumes())
+	volumes, err = p.GetVolumes()
+	assert.NoError(t, err)
+	assert.Empty(t, volumes)
+
+	assert.NoError(t, p.DeleteBackend(backend))
+	backends, err := p.GetBackends()
+	assert.NoError(t, err)
+	assert.Empty(t, backends)
+}
+
+func TestSQLiteReplaceBackendAndUpdateVolumes(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	origBackend := newTestSQLiteBackend("ontapnas_1")
+	assert.NoError(t, p.AddBackend(origBackend))
+
+	newBackend := newTestSQLiteBackend("AFF")
+	newBackend.BackendUUID = ""
+	assert.NoError(t, p.ReplaceBackendAndUpdateVolumes(origBackend, newBackend))
+
+	backends, err := p.GetBackends()
+	assert.NoError(t, err)
+	if assert.Len(t, backends, 1) {
+		assert.Equal(t, "AFF", backends[0].Name)
+		assert.Equal(t, origBackend.BackendUUID, backends[0].BackendUUID)
+	}
+
+	// A failed replacement leaves the store unchanged
+	err = p.ReplaceBackendAndUpdateVolumes(origBackend, newTestSQLiteBackend("AFF2"))
+	assert.True(t, MatchKeyNotFoundErr(err), "missing original backend wasn't caught")
+	_, err = p.GetBackend("AFF2")
+	assert.True(t, MatchKeyNotFoundErr(err), "replacement backend was left behind")
+}
+
+func TestSQLiteTransactionsNodesSnapshotsQuotas(t *testing.T) {
+	p, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	txn := &storage.VolumeTransaction{
+		Config: &storage.VolumeConfig{Name: "vol1"},
+		Op:     storage.AddVolume,
+	}
+	assert.NoError(t, p.AddVolumeTransaction(txn))
+	existingTxn, err := p.GetExistingVolumeTransaction(txn)
+	assert.NoError(t, err)
+	assert.Equal(t, txn.Name(), existingTxn.Name())
+	assert.NoError(t, p.DeleteVolumeTransaction(txn))
+	existingTxn, err = p.GetExistingVolumeTransaction(txn)
+	assert.NoError(t, err)
+	assert.Nil(t, existingTxn)
+
+	node := &utils.Node{Name: "node1", IQN: "iqn.1993-08.org.debian:01:1"}
+	assert.NoError(t, p.AddOrUpdateNode(node))
+	node.IQN = "iqn.1993-08.org.debian:01:2"
+	assert.NoError(t, p.AddOrUpdateNode(node))
+	recoveredNode, err := p.GetNode("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, node.IQN, recoveredNode.IQN)
+
+	snapshot := storage.NewSnapshot(&storage.SnapshotConfig{Name: "snap1", VolumeName: "vol1"},
+		"2020-06-01T00:00:00Z", 1024)
+	assert.NoError(t, p.AddSnapshot(snapshot))
+	recoveredSnapshot, err := p.GetSnapshot("vol1", "snap1")
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.ID(), recoveredSnapshot.ID())
+	assert.NoError(t, p.DeleteSnapshotIgnoreNotFound(snapshot))
+	assert.NoError(t, p.DeleteSnapshotIgnoreNotFound(snapshot))
+
+	quota := &storage.Quota{Name: "team1", Target: "team1", MaxVolumes: 5}
+	assert.NoError(t, p.AddQuota(quota))
+	quotas, err := p.GetQuotas()
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Quota{quota}, quotas)
+	assert.NoError(t, p.DeleteQuota(quota))
+}
@@ -0,0 +1,262 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/config"
+	storageclass "github.com/netapp/trident/storage_class"
+)
+
+// StoreDataMigrator copies Trident's state between any two persistent stores that use the current schema,
+// such as from etcd to SQLite.
+type StoreDataMigrator struct {
+	sourceClient  Client
+	destClient    Client
+	dryRun        bool
+	totalCount    int
+	migratedCount int
+	startTime     time.Time
+}
+
+func NewStoreDataMigrator(sourceClient, destClient Client, dryRun bool) *StoreDataMigrator {
+	return &StoreDataMigrator{
+		sourceClient:  sourceClient,
+		destClient:    destClient,
+		dryRun:        dryRun,
+		totalCount:    0,
+		migratedCount: 0,
+	}
+}
+
+func (m *StoreDataMigrator) RunPrechecks() error {
+
+	// The passthrough store holds no state of its own, so there is nothing to copy to or from it
+	if m.sourceClient.GetType() == PassthroughStore || m.destClient.GetType() == PassthroughStore {
+		return errors.New("the passthrough store cannot be migrated to or from")
+	}
+
+	// Ensure the source holds Trident state in the current schema
+	sourceVersion, err := m.sourceClient.GetVersion()
+	if err != nil {
+		if MatchKeyNotFoundErr(err) {
+			return fmt.Errorf("no Trident state found in the %s store", m.sourceClient.GetType())
+		}
+		return fmt.Errorf("could not check for Trident state in the %s store; %v", m.sourceClient.GetType(), err)
+	}
+
+	log.WithFields(log.Fields{
+		"PersistentStoreVersion": sourceVersion.PersistentStoreVersion,
+		"OrchestratorAPIVersion": sourceVersion.OrchestratorAPIVersion,
+	}).Debug("Found source persistent state version.")
+
+	switch StoreType(sourceVersion.PersistentStoreVersion) {
+	case EtcdV2Store, EtcdV3Store:
+		return fmt.Errorf("persistent state version is %s; start Trident once with this store to upgrade "+
+			"its state before migrating", sourceVersion.PersistentStoreVersion)
+	}
+
+	// Ensure the destination holds no Trident state that could be overwritten
+	return m.ensureDestinationEmpty()
+}
+
+func (m *StoreDataMigrator) ensureDestinationEmpty() error {
+
+	destType := m.destClient.GetType()
+
+	if destVersion, err := m.destClient.GetVersion(); err == nil {
+		return fmt.Errorf("the %s store already holds Trident state with version %s, aborting migration",
+			destType, destVersion.PersistentStoreVersion)
+	} else if !MatchKeyNotFoundErr(err) {
+		return fmt.Errorf("could not check for Trident state in the %s store; %v", destType, err)
+	}
+
+	if backends, err := m.destClient.GetBackends(); err != nil {
+		return fmt.Errorf("could not check for backends in the %s store; %v", destType, err)
+	} else if len(backends) > 0 {
+		return fmt.Errorf("backends are already present in the %s store, aborting migration", destType)
+	}
+
+	if volumes, err := m.destClient.GetVolumes(); err != nil {
+		return fmt.Errorf("could not check for volumes in the %s store; %v", destType, err)
+	} else if len(volumes) > 0 {
+		return fmt.Errorf("volumes are already present in the %s store, aborting migration", destType)
+	}
+
+	log.WithField("store", destType).Debug("No Trident state found in destination store.")
+	return nil
+}
+
+func (m *StoreDataMigrator) Run() error {
+
+	sourceType := m.sourceClient.GetType()
+
+	backends, err := m.sourceClient.GetBackends()
+	if err != nil {
+		return fmt.Errorf("could not read backends from the %s store; %v", sourceType, err)
+	}
+	storageClasses, err := m.sourceClient.GetStorageClasses()
+	if err != nil {
+		return fmt.Errorf("could not read storage classes from the %s store; %v", sourceType, err)
+	}
+	volumes, err := m.sourceClient.GetVolumes()
+	if err != nil {
+		return fmt.Errorf("could not read volumes from the %s store; %v", sourceType, err)
+	}
+	transactions, err := m.sourceClient.GetVolumeTransactions()
+	if err != nil {
+		return fmt.Errorf("could not read transactions from the %s store; %v", sourceType, err)
+	}
+	nodes, err := m.sourceClient.GetNodes()
+	if err != nil {
+		return fmt.Errorf("could not read nodes from the %s store; %v", sourceType, err)
+	}
+	snapshots, err := m.sourceClient.GetSnapshots()
+	if err != nil {
+		return fmt.Errorf("could not read snapshots from the %s store; %v", sourceType, err)
+	}
+	quotas, err := m.sourceClient.GetQuotas()
+	if err != nil {
+		return fmt.Errorf("could not read quotas from the %s store; %v", sourceType, err)
+	}
+	intents, err := m.sourceClient.GetBatchIntents()
+	if err != nil {
+		return fmt.Errorf("could not read batch intents from the %s store; %v", sourceType, err)
+	}
+
+	// Determine number of objects to migrate
+	m.totalCount = len(backends) + len(storageClasses) + len(volumes) + len(transactions) + len(nodes) +
+		len(snapshots) + len(quotas) + len(intents)
+
+	if m.dryRun {
+		log.WithFields(log.Fields{
+			"backends":       len(backends),
+			"storageClasses": len(storageClasses),
+			"volumes":        len(volumes),
+			"transactions":   len(transactions),
+			"nodes":          len(nodes),
+			"snapshots":      len(snapshots),
+			"quotas":         len(quotas),
+			"batchIntents":   len(intents),
+		}).Info("Dry run: read all objects.")
+		log.Info("Migration dry run completed, no problems found.")
+		return nil
+	}
+
+	// Save start time
+	m.startTime = time.Now()
+
+	log.WithFields(log.Fields{
+		"source":      sourceType,
+		"destination": m.destClient.GetType(),
+	}).Infof("Migrating %d objects. Please do not interrupt this operation!", m.totalCount)
+
+	for _, backend := range backends {
+		if err := m.destClient.AddBackendPersistent(backend); err != nil {
+			return fmt.Errorf("could not write backend %s; %v", backend.Name, err)
+		}
+		m.copied("backend", backend.Name)
+	}
+
+	for _, sc := range storageClasses {
+		if err := m.destClient.AddStorageClass(storageclass.NewFromPersistent(sc)); err != nil {
+			return fmt.Errorf("could not write storage class %s; %v", sc.GetName(), err)
+		}
+		m.copied("storage class", sc.GetName())
+	}
+
+	for _, volume := range volumes {
+		if err := m.destClient.AddVolumePersistent(volume); err != nil {
+			return fmt.Errorf("could not write volume %s; %v", volume.Config.Name, err)
+		}
+		m.copied("volume", volume.Config.Name)
+	}
+
+	for _, txn := range transactions {
+		if err := m.destClient.AddVolumeTransaction(txn); err != nil {
+			return fmt.Errorf("could not write transaction %s; %v", txn.Name(), err)
+		}
+		m.copied("transaction", txn.Name())
+	}
+
+	for _, node := range nodes {
+		if err := m.destClient.AddOrUpdateNode(node); err != nil {
+			return fmt.Errorf("could not write node %s; %v", node.Name, err)
+		}
+		m.copied("node", node.Name)
+	}
+
+	for _, snapshot := range snapshots {
+		if err := m.destClient.AddSnapshot(&snapshot.Snapshot); err != nil {
+			return fmt.Errorf("could not write snapshot %s; %v", snapshot.ID(), err)
+		}
+		m.copied("snapshot", snapshot.ID())
+	}
+
+	for _, quota := range quotas {
+		if err := m.destClient.AddQuota(quota); err != nil {
+			return fmt.Errorf("could not write quota %s; %v", quota.Name, err)
+		}
+		m.copied("quota", quota.Name)
+	}
+
+	// Unfinished batches are finished when Trident next starts with the destination store
+	for _, intent := range intents {
+		if err := m.destClient.AddBatchIntent(intent); err != nil {
+			return fmt.Errorf("could not write batch intent %s; %v", intent.Name, err)
+		}
+		m.copied("batch intent", intent.Name)
+	}
+
+	// Write schema version last, so an interrupted migration leaves no version behind and may be retried
+	// once the partially written destination is cleared
+	destVersion := &config.PersistentStateVersion{
+		PersistentStoreVersion: string(m.destClient.GetType()),
+		OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+	}
+	if err := m.destClient.SetVersion(destVersion); err != nil {
+		return fmt.Errorf("failed to set the persistent state version after migration: %v", err)
+	}
+
+	log.WithField("count", m.migratedCount).Info("Migration succeeded.")
+
+	return nil
+}
+
+// copied counts and logs a migrated object
+func (m *StoreDataMigrator) copied(kind, name string) {
+
+	log.WithField("name", name).Debugf("Copied %s.", kind)
+
+	m.migratedCount++
+
+	// Log the time remaining every 100 objects
+	if m.migratedCount%100 != 0 {
+		return
+	}
+	timeElapsed := time.Since(m.startTime)
+	if timeElapsed <= 0 {
+		return
+	}
+	nanosecondsPerObject := timeElapsed.Nanoseconds() / int64(m.migratedCount)
+	objectsRemaining := m.totalCount - m.migratedCount
+	timeRemaining := time.Duration(nanosecondsPerObject * int64(objectsRemaining)).Truncate(time.Second)
+
+	log.WithField("estimatedTimeRemaining", timeRemaining).Infof("Migrated %d of %d objects.",
+		m.migratedCount, m.totalCount)
+}
+
+// HasState returns whether a persistent store holds any Trident state.
+func HasState(client Client) (bool, error) {
+	if _, err := client.GetVersion(); err == nil {
+		return true, nil
+	} else if !MatchKeyNotFoundErr(err) {
+		return false, err
+	}
+	return false, nil
+}
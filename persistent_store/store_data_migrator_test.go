@@ -0,0 +1,124 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package persistentstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	storageclass "github.com/netapp/trident/storage_class"
+	"github.com/netapp/trident/utils"
+)
+
+func newTestMigrationSource(t *testing.T) *InMemoryClient {
+
+	source := NewInMemoryClient()
+
+	backend := newTestSQLiteBackend("ontapnas_1")
+	assert.NoError(t, source.AddBackend(backend))
+	assert.NoError(t, source.AddStorageClass(storageclass.New(&storageclass.Config{Name: "gold"})))
+	for i := 0; i < 3; i++ {
+		vol := &storage.Volume{
+			Config: &storage.VolumeConfig{
+				Version:      config.OrchestratorAPIVersion,
+				Name:         fmt.Sprintf("vol%d", i),
+				Size:         "1GB",
+				Protocol:     config.File,
+				StorageClass: "gold",
+			},
+			BackendUUID: backend.BackendUUID,
+			Pool:        storagePool,
+		}
+		assert.NoError(t, source.AddVolume(vol))
+	}
+	assert.NoError(t, source.AddVolumeTransaction(&storage.VolumeTransaction{
+		Config: &storage.VolumeConfig{Name: "vol3"},
+		Op:     storage.AddVolume,
+	}))
+	assert.NoError(t, source.AddOrUpdateNode(&utils.Node{Name: "node1"}))
+	assert.NoError(t, source.AddSnapshot(storage.NewSnapshot(
+		&storage.SnapshotConfig{Name: "snap1", VolumeName: "vol1"}, "2020-06-01T00:00:00Z", 1024)))
+	assert.NoError(t, source.AddQuota(&storage.Quota{Name: "team1", Target: "team1", MaxVolumes: 5}))
+	assert.NoError(t, source.AddBatchIntent(&storage.BatchIntent{
+		Name: "batch-1",
+		Entries: []storage.BatchEntry{
+			{Op: storage.BatchDeleteVolume, Volume: &storage.VolumeExternal{Config: &storage.VolumeConfig{Name: "vol0"}}},
+		},
+	}))
+
+	return source
+}
+
+func TestStoreDataMigrator(t *testing.T) {
+	dest, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	m := NewStoreDataMigrator(newTestMigrationSource(t), dest, false)
+	assert.NoError(t, m.RunPrechecks())
+	assert.NoError(t, m.Run())
+	assert.Equal(t, 10, m.migratedCount)
+
+	backends, _ := dest.GetBackends()
+	assert.Len(t, backends, 1)
+	storageClasses, _ := dest.GetStorageClasses()
+	assert.Len(t, storageClasses, 1)
+	volumes, _ := dest.GetVolumes()
+	assert.Len(t, volumes, 3)
+	transactions, _ := dest.GetVolumeTransactions()
+	assert.Len(t, transactions, 1)
+	nodes, _ := dest.GetNodes()
+	assert.Len(t, nodes, 1)
+	snapshots, _ := dest.GetSnapshots()
+	assert.Len(t, snapshots, 1)
+	quotas, _ := dest.GetQuotas()
+	assert.Len(t, quotas, 1)
+	intents, _ := dest.GetBatchIntents()
+	assert.Len(t, intents, 1)
+
+	version, err := dest.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, string(SQLiteStore), version.PersistentStoreVersion)
+
+	// The destination now holds state, so another migration must not overwrite it
+	assert.Error(t, NewStoreDataMigrator(newTestMigrationSource(t), dest, false).RunPrechecks())
+}
+
+func TestStoreDataMigratorDryRun(t *testing.T) {
+	dest, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	m := NewStoreDataMigrator(newTestMigrationSource(t), dest, true)
+	assert.NoError(t, m.RunPrechecks())
+	assert.NoError(t, m.Run())
+
+	hasState, err := HasState(dest)
+	assert.NoError(t, err)
+	assert.False(t, hasState)
+	backends, _ := dest.GetBackends()
+	assert.Empty(t, backends)
+}
+
+func TestStoreDataMigratorPrechecks(t *testing.T) {
+	dest, cleanup := newTestSQLiteClient(t)
+	defer cleanup()
+
+	// An empty source
+	source, sourceCleanup := newTestSQLiteClient(t)
+	defer sourceCleanup()
+	assert.Error(t, NewStoreDataMigrator(source, dest, false).RunPrechecks())
+
+	// A source whose etcd data has not been transformed to the current schema
+	assert.NoError(t, source.SetVersion(&config.PersistentStateVersion{
+		PersistentStoreVersion: string(EtcdV3Store),
+		OrchestratorAPIVersion: config.OrchestratorAPIVersion,
+	}))
+	assert.Error(t, NewStoreDataMigrator(source, dest, false).RunPrechecks())
+
+	// A destination already holding objects, though no version
+	assert.NoError(t, dest.AddBackend(newTestSQLiteBackend("ontapnas_1")))
+	assert.Error(t, NewStoreDataMigrator(newTestMigrationSource(t), dest, false).RunPrechecks())
+}
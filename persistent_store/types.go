@@ -20,6 +20,7 @@ const (
 	EtcdV3bStore     StoreType = "etcdv3b"
 	PassthroughStore StoreType = "passthrough"
 	CRDV1Store       StoreType = "crdv1"
+	SQLiteStore      StoreType = "sqlite"
 )
 
 type ClientConfig struct {
@@ -60,6 +61,10 @@ type Client interface {
 	GetExistingVolumeTransaction(volTxn *storage.VolumeTransaction) (*storage.VolumeTransaction, error)
 	DeleteVolumeTransaction(volTxn *storage.VolumeTransaction) error
 
+	AddBatchIntent(intent *storage.BatchIntent) error
+	GetBatchIntents() ([]*storage.BatchIntent, error)
+	DeleteBatchIntent(intent *storage.BatchIntent) error
+
 	AddStorageClass(sc *storageclass.StorageClass) error
 	GetStorageClass(scName string) (*storageclass.Persistent, error)
 	GetStorageClasses() ([]*storageclass.Persistent, error)
@@ -73,9 +78,15 @@ type Client interface {
 	AddSnapshot(snapshot *storage.Snapshot) error
 	GetSnapshot(volumeName, snapshotName string) (*storage.SnapshotPersistent, error)
 	GetSnapshots() ([]*storage.SnapshotPersistent, error)
+	UpdateSnapshot(snapshot *storage.Snapshot) error
 	DeleteSnapshot(snapshot *storage.Snapshot) error
 	DeleteSnapshotIgnoreNotFound(snapshot *storage.Snapshot) error
 	DeleteSnapshots() error
+
+	AddQuota(quota *storage.Quota) error
+	GetQuota(quotaName string) (*storage.Quota, error)
+	GetQuotas() ([]*storage.Quota, error)
+	DeleteQuota(quota *storage.Quota) error
 }
 
 type EtcdClient interface {
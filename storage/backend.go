@@ -3,9 +3,11 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -27,30 +29,34 @@ type Driver interface {
 	Initialized() bool
 	// Terminate tells the driver to clean up, as it won't be called again.
 	Terminate(backendUUID string)
-	Create(volConfig *VolumeConfig, storagePool *Pool, volAttributes map[string]sa.Request) error
+	Create(ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, volAttributes map[string]sa.Request) error
 	CreatePrepare(volConfig *VolumeConfig)
 	// CreateFollowup adds necessary information for accessing the volume to VolumeConfig.
-	CreateFollowup(volConfig *VolumeConfig) error
+	CreateFollowup(ctx context.Context, volConfig *VolumeConfig) error
 	// GetInternalVolumeName will return a name that satisfies any character
 	// constraints present on the backend and that will be unique to Trident.
 	// The latter requirement should generally be done by prepending the
 	// value of CommonStorageDriver.SnapshotPrefix to the name.
-	CreateClone(volConfig *VolumeConfig, storagePool *Pool) error
-	Import(volConfig *VolumeConfig, originalName string) error
-	Destroy(name string) error
-	Rename(name string, newName string) error
-	Resize(volConfig *VolumeConfig, sizeBytes uint64) error
+	CreateClone(ctx context.Context, volConfig *VolumeConfig, storagePool *Pool) error
+	Import(ctx context.Context, volConfig *VolumeConfig, originalName string) error
+	Destroy(ctx context.Context, name string) error
+	Rename(ctx context.Context, name string, newName string) error
+	Resize(ctx context.Context, volConfig *VolumeConfig, sizeBytes uint64) error
 	Get(name string) error
 	GetInternalVolumeName(name string) string
 	GetStorageBackendSpecs(backend *Backend) error
 	GetStorageBackendPhysicalPoolNames() []string
 	GetProtocol() tridentconfig.Protocol
-	Publish(volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo) error
-	GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error)
-	GetSnapshots(volConfig *VolumeConfig) ([]*Snapshot, error)
-	CreateSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error)
-	RestoreSnapshot(snapConfig *SnapshotConfig) error
-	DeleteSnapshot(snapConfig *SnapshotConfig) error
+	// GetSupportedAccessModes returns the access modes the driver's volumes support in each volume mode.
+	GetSupportedAccessModes() drivers.AccessModes
+	// GetVolumeExpansion returns how the driver's volumes are grown.
+	GetVolumeExpansion() drivers.VolumeExpansion
+	Publish(ctx context.Context, volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo) error
+	GetSnapshot(ctx context.Context, snapConfig *SnapshotConfig) (*Snapshot, error)
+	GetSnapshots(ctx context.Context, volConfig *VolumeConfig) ([]*Snapshot, error)
+	CreateSnapshot(ctx context.Context, snapConfig *SnapshotConfig) (*Snapshot, error)
+	RestoreSnapshot(ctx context.Context, snapConfig *SnapshotConfig) error
+	DeleteSnapshot(ctx context.Context, snapConfig *SnapshotConfig) error
 	StoreConfig(b *PersistentStorageBackendConfig)
 	// GetExternalConfig returns a version of the driver configuration that
 	// lacks confidential information, such as usernames and passwords.
@@ -66,6 +72,258 @@ type Driver interface {
 	ReconcileNodeAccess(nodes []*utils.Node, backendUUID string) error
 }
 
+// PublicationUpdater is implemented by drivers whose publish info may go stale after a volume is
+// published, such as when iSCSI data LIFs move between nodes.
+type PublicationUpdater interface {
+	// UpdatePublication refreshes publishInfo for an already-published volume, returning
+	// true if anything changed.
+	UpdatePublication(ctx context.Context, volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo) (bool, error)
+}
+
+// PoolResizer is implemented by drivers that enforce the limits of the pool a volume was created in,
+// such as its maximum volume size, when the volume is resized.
+type PoolResizer interface {
+	// ResizeInPool resizes a volume like Resize, given the pool holding it, or nil if that isn't known.
+	ResizeInPool(ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, sizeBytes uint64) error
+}
+
+// VolumeDestroyer is implemented by drivers that destroy some kinds of volumes differently, and can tell
+// which kind a volume is from its config rather than by asking the storage system.
+type VolumeDestroyer interface {
+	// DestroyVolume destroys a volume like Destroy, given its config.
+	DestroyVolume(ctx context.Context, volConfig *VolumeConfig) error
+}
+
+// CloneSplitReporter is implemented by drivers that split cloned volumes from their parents in the
+// background and can report how those splits are progressing.
+type CloneSplitReporter interface {
+	// GetCloneSplitStatus returns the progress of the most recent split of a volume, or nil if the
+	// driver has not split the volume recently.
+	GetCloneSplitStatus(internalName string) *CloneSplitStatus
+	// SetCloneSplitHandler registers a function to be called whenever a split completes or fails.
+	SetCloneSplitHandler(handler CloneSplitHandler)
+	// ResumeCloneSplit follows the split of a cloned volume again after Trident restarts, starting or
+	// queueing the split if it was to be split but ONTAP isn't splitting it.
+	ResumeCloneSplit(ctx context.Context, volConfig *VolumeConfig) error
+}
+
+// CloneSplitHandler is notified when the split of the named volume completes or fails.
+type CloneSplitHandler func(internalName string, status *CloneSplitStatus)
+
+// DeferredSnapshotDeleter is implemented by drivers that may defer deleting a snapshot that is busy,
+// such as one still backing clones, and finish deleting it in the background.
+type DeferredSnapshotDeleter interface {
+	// SetSnapshotDeleteHandler registers a function to be called whenever a deferred deletion ends.
+	SetSnapshotDeleteHandler(handler SnapshotDeleteHandler)
+}
+
+// SnapshotDeleteHandler is notified when a deferred snapshot deletion ends, with a nil error if the
+// snapshot was deleted or the error that made the driver give up.
+type SnapshotDeleteHandler func(snapConfig *SnapshotConfig, err error)
+
+// VolumeUpdater is implemented by drivers that can change attributes of an existing volume.
+type VolumeUpdater interface {
+	// UpdateVolume applies the non-empty fields of updateRequest to the volume.
+	UpdateVolume(ctx context.Context, volConfig *VolumeConfig, updateRequest *UpdateVolumeRequest) error
+}
+
+// GroupSnapshotter is implemented by drivers that can snapshot several volumes at the same instant,
+// yielding a crash-consistent set of snapshots.
+type GroupSnapshotter interface {
+	// CreateGroupSnapshot creates one snapshot per config, all of them or none.  Each config names a
+	// distinct volume.
+	CreateGroupSnapshot(ctx context.Context, snapConfigs []*SnapshotConfig) ([]*Snapshot, error)
+}
+
+// VolumeMover is implemented by drivers that can move a volume between the physical pools of a
+// backend without interrupting access to it.  Moves run in the background.
+type VolumeMover interface {
+	// MoveVolume begins moving a volume to the named physical pool.
+	MoveVolume(ctx context.Context, volConfig *VolumeConfig, destinationPool string) error
+	// GetVolumeMoveStatus returns the progress of the most recent move of a volume, or nil if the
+	// driver has not moved the volume recently.
+	GetVolumeMoveStatus(internalName string) *VolumeMoveStatus
+	// SetVolumeMoveHandler registers a function to be called whenever a move completes or fails.
+	SetVolumeMoveHandler(handler VolumeMoveHandler)
+	// ResumeVolumeMove follows the move of a volume to its MoveDestinationPool again after Trident
+	// restarts, so that the handler hears when the move finishes.
+	ResumeVolumeMove(ctx context.Context, volConfig *VolumeConfig) error
+}
+
+// VolumeMoveHandler is notified when the move of the named volume completes or fails.
+type VolumeMoveHandler func(internalName string, status *VolumeMoveStatus)
+
+// VolumeCopier is implemented by drivers whose storage system can copy a volume from another backend,
+// so that a clone may be placed on a backend other than that of its source.  Copies run in the background.
+type VolumeCopier interface {
+	// GetCopySource returns where the data of a volume, or of one of its snapshots, may be copied from.
+	GetCopySource(ctx context.Context, volConfig *VolumeConfig, snapshotName string) (*VolumeCopySource, error)
+	// CreateCopy begins creating a volume holding a copy of the source, or finishes creating it once the
+	// copy completes.  It returns a VolumeCreatingError while the copy runs, and an UnsupportedError if
+	// the driver can't copy data in the source's format.
+	CreateCopy(ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, source *VolumeCopySource) error
+	// ReleaseCopySource discards what the source's storage system kept for a finished copy to the
+	// destination.
+	ReleaseCopySource(ctx context.Context, source, destination *VolumeCopySource) error
+}
+
+// StorageJobLister is implemented by drivers that run operations in the background and can list
+// those still in progress.
+type StorageJobLister interface {
+	// ListStorageJobs returns the operations the driver is waiting on, whether running or queued.
+	ListStorageJobs() []*StorageJob
+}
+
+// AsyncJobReporter is implemented by drivers that create volumes using storage system jobs which may
+// outlast the request that started them.  A driver returns a VolumeCreatingError while the job runs,
+// having recorded the job in the volume config's CreateJobID.
+type AsyncJobReporter interface {
+	// ResumeAsyncJob resumes following the job creating a volume after Trident restarts.
+	ResumeAsyncJob(volConfig *VolumeConfig)
+}
+
+// NodeAccessRevoker is implemented by drivers that can withdraw a single departed node's access to
+// their volumes without reconciling the access of every other node.
+type NodeAccessRevoker interface {
+	// RevokeNodeAccess removes the node from the driver's access controls and ends any sessions the
+	// node still has with the storage system.  It returns an UnsupportedError if it can't, in which case
+	// the access of the remaining nodes is reconciled instead.
+	RevokeNodeAccess(ctx context.Context, node *utils.Node) error
+}
+
+// VolumeRecoverer is implemented by drivers that can keep deleted volumes in a recovery queue for a
+// while, so that an accidental deletion may be undone.
+type VolumeRecoverer interface {
+	// ListRecoverableVolumes returns the deleted volumes that may still be recovered.
+	ListRecoverableVolumes(ctx context.Context) ([]*RecoverableVolume, error)
+	// RecoverVolume restores a deleted volume under its original internal name.
+	RecoverVolume(ctx context.Context, internalName string) error
+}
+
+// VolumeCreateResumer is implemented by drivers that can check a volume left behind by a create or clone
+// interrupted by a restart, so that the operation may be finished rather than rolled back.
+type VolumeCreateResumer interface {
+	// ResumeVolumeCreate returns nil if the volume was created for the request and is ready for use, having
+	// repeated any step the driver takes after creating a volume that may not have finished, such as
+	// splitting a clone.  Each such step must be safe to repeat.  It returns a NotFoundError if the volume
+	// doesn't exist.
+	ResumeVolumeCreate(ctx context.Context, volConfig *VolumeConfig) error
+}
+
+// VolumeUsageReporter is implemented by drivers that track how much space and how many files each of their
+// volumes uses, against the limits enforced on them.
+type VolumeUsageReporter interface {
+	// GetVolumeUsage returns the most recently read usage of a volume, or nil if it hasn't been read.
+	GetVolumeUsage(internalName string) *VolumeUsage
+}
+
+// APITracer is implemented by drivers whose tracing of storage API calls may be turned on or off
+// while the backend is running.
+type APITracer interface {
+	SetAPITraceEnabled(enabled bool)
+}
+
+// LogConfigurer is implemented by drivers whose debug trace flags, and the level at which messages about
+// their storage API calls are logged, may be changed while the backend is running.
+type LogConfigurer interface {
+	// SetDebugTraceFlags replaces the driver's debug trace flags, which must not be changed afterwards.
+	SetDebugTraceFlags(flags map[string]bool)
+	// SetAPILogLevel sets the level at which messages about the driver's storage API calls are logged,
+	// or restores Trident's level if the level is empty.
+	SetAPILogLevel(level string) error
+	// GetAPILogLevel returns the level set by SetAPILogLevel, or an empty string if Trident's level is used.
+	GetAPILogLevel() string
+}
+
+// LogFielder is implemented by drivers that add fields of their own, such as the SVM, to the messages
+// logged for operations on their backends.
+type LogFielder interface {
+	LogFields() map[string]interface{}
+}
+
+// BackendUUIDSetter is implemented by drivers that tag what they create on their storage system with
+// their backend's UUID, so that it may be told apart from what other backends and Trident instances create.
+type BackendUUIDSetter interface {
+	SetBackendUUID(backendUUID string)
+}
+
+// OrphanCollector is implemented by drivers that can find the resources they created on their storage
+// system that no longer belong to any volume or snapshot known to Trident.
+type OrphanCollector interface {
+	// ListOrphanedResources returns the resources the driver created for the backend that belong to none
+	// of the supplied volumes or snapshots, which are all those known to Trident.
+	ListOrphanedResources(
+		ctx context.Context, backendUUID string, volumes []*VolumeConfig, snapshots []*SnapshotConfig,
+	) ([]*OrphanedResource, error)
+	// DeleteOrphanedResource deletes a resource returned by ListOrphanedResources.
+	DeleteOrphanedResource(ctx context.Context, resource *OrphanedResource) error
+	// GetOrphanPolicy returns whether, and when, the driver's orphaned resources should be deleted.
+	GetOrphanPolicy() *OrphanPolicy
+}
+
+// VolumeLister is implemented by drivers that can list the volumes they may have created on their
+// storage system a page at a time.
+type VolumeLister interface {
+	// ListVolumes returns up to limit of the driver's volumes, starting after those listed before the
+	// continuation token was returned, or from the first volume if the token is empty.
+	ListVolumes(ctx context.Context, limit int, continueToken string) (*VolumePage, error)
+}
+
+// ImportCandidateLister is implemented by drivers that can find the volumes on their storage system that
+// they are able to import, whatever their names.
+type ImportCandidateLister interface {
+	// ListImportCandidates returns every volume on the storage system that the driver could import.
+	ListImportCandidates(ctx context.Context) ([]*ImportCandidate, error)
+}
+
+// StorageLogReader is implemented by drivers that can read the recent entries of their storage system's
+// logs, such as its job and event logs, to be gathered for support cases.
+type StorageLogReader interface {
+	// GetStorageLogs returns the recent log entries, newest first.
+	GetStorageLogs(ctx context.Context) ([]*StorageLogEntry, error)
+}
+
+// HealthChecker is implemented by drivers that can check that their storage system is reachable with
+// their credentials, so that an unreachable backend is reported before its volumes are needed.
+type HealthChecker interface {
+	// CheckHealth returns an error if the storage system can't be reached or refuses the driver's
+	// credentials.
+	CheckHealth(ctx context.Context) error
+}
+
+// BucketReporter is implemented by drivers that place several volumes in each volume on their storage
+// system, and can report how the volumes are distributed among those buckets.
+type BucketReporter interface {
+	// GetBucketReport returns the driver's buckets and the number of volumes in each.
+	GetBucketReport(ctx context.Context) (*BucketReport, error)
+}
+
+// FeatureReporter is implemented by drivers that can report which optional features of their storage
+// system are available, such as those requiring a license.
+type FeatureReporter interface {
+	// GetFeatures returns whether each feature is available, by name.
+	GetFeatures() map[string]bool
+}
+
+// PoolRefresher is implemented by drivers that can rediscover their storage pools while running, as when
+// aggregates are assigned to or removed from an SVM.
+type PoolRefresher interface {
+	// RefreshPools rediscovers the driver's storage pools, which GetStorageBackendSpecs then reports.
+	RefreshPools(ctx context.Context) error
+}
+
+// PoolChangeReporter is implemented by drivers that watch their storage system for changes that may affect
+// their storage pools, so that the pools need only be rediscovered when something has changed.
+type PoolChangeReporter interface {
+	// WatchesPoolChanges returns true if the driver is watching for changes to its pools.
+	WatchesPoolChanges() bool
+	// SetPoolChangeHandler registers a function to be called whenever the driver's pools may have changed.
+	SetPoolChangeHandler(handler PoolChangeHandler)
+}
+
+// PoolChangeHandler is notified when a driver's storage pools may have changed and should be rediscovered.
+type PoolChangeHandler func()
+
 type Backend struct {
 	Driver      Driver
 	Name        string
@@ -74,12 +332,87 @@ type Backend struct {
 	State       BackendState
 	Storage     map[string]*Pool
 	Volumes     map[string]*Volume
+	operations  operationTracker
+	gate        *OperationGate
 }
 
 type UpdateBackendStateRequest struct {
 	State string `json:"state"`
 }
 
+type UpdateBackendAPITraceRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// UpdateBackendLoggingRequest changes how much a running backend logs.  Nil flags and an empty log level
+// are left unchanged, and the log level "default" restores Trident's level.
+type UpdateBackendLoggingRequest struct {
+	DebugTraceFlags map[string]bool `json:"debugTraceFlags,omitempty"`
+	LogLevel        string          `json:"logLevel,omitempty"`
+}
+
+// DefaultLogLevel is the log level that makes a backend log at Trident's level.
+const DefaultLogLevel = "default"
+
+func (r *UpdateBackendLoggingRequest) Validate() error {
+	if r.DebugTraceFlags == nil && r.LogLevel == "" {
+		return fmt.Errorf("at least one of the following fields is mandatory: debugTraceFlags, logLevel")
+	}
+	if r.LogLevel != "" && r.LogLevel != DefaultLogLevel {
+		if _, err := log.ParseLevel(r.LogLevel); err != nil {
+			return fmt.Errorf("invalid log level %s", r.LogLevel)
+		}
+	}
+	return nil
+}
+
+// RecoverVolumeRequest asks a backend to recover a volume from its recovery queue.
+type RecoverVolumeRequest struct {
+	InternalName string `json:"internalName"`
+}
+
+func (r *RecoverVolumeRequest) Validate() error {
+	if r.InternalName == "" {
+		return fmt.Errorf("the following field is mandatory: internalName")
+	}
+	return nil
+}
+
+// BackendPreview describes the storage pools that a backend configuration would offer, as resolved by
+// a dry run of the backend's creation, and the capabilities detected on its storage system.
+type BackendPreview struct {
+	Name              string          `json:"name"`
+	StorageDriverName string          `json:"storageDriverName"`
+	Pools             []*PoolPreview  `json:"pools"`
+	Capabilities      map[string]bool `json:"capabilities,omitempty"`
+}
+
+// NewBackendPreview returns a preview of a backend's physical and virtual pools, with the physical
+// pools listed first and each set of pools sorted by name.
+func NewBackendPreview(name, driverName string, physicalPools, virtualPools map[string]*Pool) *BackendPreview {
+
+	preview := &BackendPreview{
+		Name:              name,
+		StorageDriverName: driverName,
+		Pools:             make([]*PoolPreview, 0, len(physicalPools)+len(virtualPools)),
+	}
+
+	addPools := func(pools map[string]*Pool, virtual bool) {
+		names := make([]string, 0, len(pools))
+		for poolName := range pools {
+			names = append(names, poolName)
+		}
+		sort.Strings(names)
+		for _, poolName := range names {
+			preview.Pools = append(preview.Pools, pools[poolName].ConstructPreview(virtual))
+		}
+	}
+	addPools(physicalPools, false)
+	addPools(virtualPools, true)
+
+	return preview
+}
+
 type NotManagedError struct {
 	volumeName string
 }
@@ -184,13 +517,20 @@ func (b *Backend) GetProtocol() tridentconfig.Protocol {
 	return b.Driver.GetProtocol()
 }
 
+// SupportsAccessMode reports whether the backend's driver can provision a volume with the given volume mode
+// and access mode.
+func (b *Backend) SupportsAccessMode(volumeMode tridentconfig.VolumeMode, accessMode tridentconfig.AccessMode) bool {
+	return b.Driver.GetSupportedAccessModes().Supports(volumeMode, accessMode)
+}
+
 func (b *Backend) AddVolume(
-	volConfig *VolumeConfig, storagePool *Pool, volAttributes map[string]sa.Request, retry bool,
-) (*Volume, error) {
+	ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, volAttributes map[string]sa.Request, retry bool,
+) (volume *Volume, err error) {
 
-	var err error
+	ctx = b.logContext(ctx, "create", volConfig.Name)
+	defer b.observeOperation(opVolumeCreate, time.Now(), &err)
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"backendUUID":    b.BackendUUID,
 		"volume":         volConfig.Name,
@@ -205,21 +545,34 @@ func (b *Backend) AddVolume(
 		return nil, err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
 	// Ensure the internal name exists
 	if volConfig.InternalName == "" {
 		return nil, errors.New("internal name not set")
 	}
 
+	// Wait for the backend to admit another create
+	finish, err := b.acquireOperation(ctx, OperationCreate, volConfig.InternalName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { finish(err) }()
+
 	// Add volume to the backend
 	volumeExists := false
-	if err = b.Driver.Create(volConfig, storagePool, volAttributes); err != nil {
+	if err = b.Driver.Create(ctx, volConfig, storagePool, volAttributes); err != nil {
 
 		if drivers.IsVolumeExistsError(err) {
 
 			// Implement idempotency by ignoring the error if the volume exists already
 			volumeExists = true
 
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"backend": b.Name,
 				"volume":  volConfig.InternalName,
 			}).Warning("Volume already exists.")
@@ -231,9 +584,9 @@ func (b *Backend) AddVolume(
 	}
 
 	// Always perform the follow-up steps
-	if err = b.Driver.CreateFollowup(volConfig); err != nil {
+	if err = b.Driver.CreateFollowup(ctx, volConfig); err != nil {
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"backend":      b.Name,
 			"volume":       volConfig.InternalName,
 			"volumeExists": volumeExists,
@@ -243,14 +596,129 @@ func (b *Backend) AddVolume(
 		// If follow-up fails and we just created the volume, clean up by deleting it
 		if !volumeExists || retry {
 
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"backend": b.Name,
 				"volume":  volConfig.InternalName,
 			}).Errorf("CreateFollowup failed for newly created volume, deleting the volume.")
 
-			errDestroy := b.Driver.Destroy(volConfig.InternalName)
+			errDestroy := b.destroyVolume(ctx, volConfig)
+			if errDestroy != nil {
+				utils.Logc(ctx).WithFields(log.Fields{
+					"backend": b.Name,
+					"volume":  volConfig.InternalName,
+				}).Warnf("Mapping the created volume failed "+
+					"and %s wasn't able to delete it afterwards: %s. "+
+					"Volume must be manually deleted.",
+					tridentconfig.OrchestratorName, errDestroy)
+			}
+		}
+
+		// In all cases where follow-up fails, return the follow-up error
+		return nil, err
+	}
+
+	vol := NewVolume(volConfig, b.BackendUUID, storagePool.Name, false)
+	b.Volumes[vol.Config.Name] = vol
+	return vol, nil
+}
+
+func (b *Backend) CloneVolume(
+	ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, retry bool,
+) (volume *Volume, err error) {
+
+	ctx = b.logContext(ctx, "clone", volConfig.Name)
+	defer b.observeOperation(opVolumeClone, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":                b.Name,
+		"backendUUID":            b.BackendUUID,
+		"storage_class":          volConfig.StorageClass,
+		"source_volume":          volConfig.CloneSourceVolume,
+		"source_volume_internal": volConfig.CloneSourceVolumeInternal,
+		"source_snapshot":        volConfig.CloneSourceSnapshot,
+		"clone_volume":           volConfig.Name,
+		"clone_volume_internal":  volConfig.InternalName,
+	}).Debug("Attempting volume clone.")
+
+	// Ensure volume is managed
+	if volConfig.ImportNotManaged {
+		return nil, &NotManagedError{volConfig.InternalName}
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	// Ensure the internal names exist
+	if volConfig.InternalName == "" {
+		return nil, errors.New("internal name not set")
+	}
+	if volConfig.CloneSourceVolumeInternal == "" {
+		return nil, errors.New("clone source volume internal name not set")
+	}
+
+	// Wait for the backend to admit another clone
+	finish, err := b.acquireOperation(ctx, OperationClone, volConfig.InternalName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { finish(err) }()
+
+	// Clone volume on the backend
+	volumeExists := false
+	if err := b.Driver.CreateClone(ctx, volConfig, storagePool); err != nil {
+
+		if drivers.IsVolumeExistsError(err) {
+
+			// Implement idempotency by ignoring the error if the volume exists already
+			volumeExists = true
+
+			utils.Logc(ctx).WithFields(log.Fields{
+				"backend": b.Name,
+				"volume":  volConfig.InternalName,
+			}).Warning("Volume already exists.")
+
+		} else {
+			// If the volume doesn't exist but the create failed, return the error
+			return nil, err
+		}
+	}
+
+	// The clone may not be fully created when the clone API returns, so wait here until it exists.
+	checkCloneExists := func() error {
+		return b.Driver.Get(volConfig.InternalName)
+	}
+	cloneExistsNotify := func(err error, duration time.Duration) {
+		utils.Logc(ctx).WithField("increment", duration).Debug("Clone not yet present, waiting.")
+	}
+	cloneBackoff := backoff.NewExponentialBackOff()
+	cloneBackoff.InitialInterval = 1 * time.Second
+	cloneBackoff.Multiplier = 2
+	cloneBackoff.RandomizationFactor = 0.1
+	cloneBackoff.MaxElapsedTime = 90 * time.Second
+
+	// Run the clone check using an exponential backoff
+	if err := backoff.RetryNotify(checkCloneExists, cloneBackoff, cloneExistsNotify); err != nil {
+		utils.Logc(ctx).WithField("clone_volume", volConfig.Name).Warnf("Could not find clone after %3.2f seconds.",
+			float64(cloneBackoff.MaxElapsedTime))
+	} else {
+		utils.Logc(ctx).WithField("clone_volume", volConfig.Name).Debug("Clone found.")
+	}
+
+	if err := b.Driver.CreateFollowup(ctx, volConfig); err != nil {
+
+		// If follow-up fails and we just created the volume, clean up by deleting it
+		if !volumeExists || retry {
+			errDestroy := b.destroyVolume(ctx, volConfig)
 			if errDestroy != nil {
-				log.WithFields(log.Fields{
+				utils.Logc(ctx).WithFields(log.Fields{
 					"backend": b.Name,
 					"volume":  volConfig.InternalName,
 				}).Warnf("Mapping the created volume failed "+
@@ -260,136 +728,707 @@ func (b *Backend) AddVolume(
 			}
 		}
 
-		// In all cases where follow-up fails, return the follow-up error
-		return nil, err
+		// In all cases where follow-up fails, return the follow-up error
+		return nil, err
+	}
+
+	// Traditionally, cloned volumes never got assigned to a storage pool, however, after introduction
+	// of Virtual Pools we try to assign cloned volume to the same storage pool as source volume.
+	// It should be noted that imported volumes do not get assigned to any storage pools, therefore,
+	// clones of imported volumes also gets assigned to unset storage pools.
+	poolName := drivers.UnsetPool
+	if storagePool != nil {
+		poolName = storagePool.Name
+	}
+
+	vol := NewVolume(volConfig, b.BackendUUID, poolName, false)
+	b.Volumes[vol.Config.Name] = vol
+	return vol, nil
+}
+
+// CopyVolume creates a volume holding a copy of a volume on another backend, as when a clone is requested
+// in a storage class with no pools on its source's backend.  The copy is made by the storage systems and
+// may outlast the request, in which case a VolumeCreatingError is returned and the request is retried.
+func (b *Backend) CopyVolume(
+	ctx context.Context, volConfig *VolumeConfig, storagePool *Pool, sourceBackend *Backend,
+	sourceVolConfig *VolumeConfig, retry bool,
+) (*Volume, error) {
+
+	ctx = b.logContext(ctx, "copy", volConfig.Name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":              b.Name,
+		"backendUUID":          b.BackendUUID,
+		"source_backend":       sourceBackend.Name,
+		"source_volume":        volConfig.CloneSourceVolume,
+		"source_snapshot":      volConfig.CloneSourceSnapshot,
+		"copy_volume":          volConfig.Name,
+		"copy_volume_internal": volConfig.InternalName,
+		"retry":                retry,
+	}).Debug("Attempting volume copy.")
+
+	copier, ok := b.Driver.(VolumeCopier)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support copying volumes", b.Name))
+	}
+	sourceCopier, ok := sourceBackend.Driver.(VolumeCopier)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support copying volumes",
+			sourceBackend.Name))
+	}
+
+	// Ensure volume is managed
+	if volConfig.ImportNotManaged {
+		return nil, &NotManagedError{volConfig.InternalName}
+	}
+
+	// Ensure both backends are ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+	if err := sourceBackend.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	// Ensure the internal names exist
+	if volConfig.InternalName == "" {
+		return nil, errors.New("internal name not set")
+	}
+
+	// Wait for the backend to admit another clone
+	finish, err := b.acquireOperation(ctx, OperationClone, volConfig.InternalName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { finish(err) }()
+
+	source, err := sourceCopier.GetCopySource(ctx, sourceVolConfig, volConfig.CloneSourceSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the data of volume %s on backend %s; %v",
+			sourceVolConfig.Name, sourceBackend.Name, err)
+	}
+
+	if err = copier.CreateCopy(ctx, volConfig, storagePool, source); err != nil {
+		return nil, err
+	}
+
+	// The source need no longer keep anything for the copy
+	if destination, err := copier.GetCopySource(ctx, volConfig, ""); err != nil {
+		utils.Logc(ctx).WithError(err).Warning("Could not describe copied volume to release its source.")
+	} else if err = sourceCopier.ReleaseCopySource(ctx, source, destination); err != nil {
+		utils.Logc(ctx).WithError(err).Warning("Could not release the source of copied volume.")
+	}
+
+	if err = b.Driver.CreateFollowup(ctx, volConfig); err != nil {
+
+		// The copy was created by this request, so clean up by deleting it
+		if errDestroy := b.destroyVolume(ctx, volConfig); errDestroy != nil {
+			utils.Logc(ctx).WithFields(log.Fields{
+				"backend": b.Name,
+				"volume":  volConfig.InternalName,
+			}).Warnf("Mapping the copied volume failed "+
+				"and %s wasn't able to delete it afterwards: %s. "+
+				"Volume must be manually deleted.",
+				tridentconfig.OrchestratorName, errDestroy)
+		}
+		return nil, err
+	}
+
+	poolName := drivers.UnsetPool
+	if storagePool != nil {
+		poolName = storagePool.Name
+	}
+
+	vol := NewVolume(volConfig, b.BackendUUID, poolName, false)
+	b.Volumes[vol.Config.Name] = vol
+	return vol, nil
+}
+
+func (b *Backend) PublishVolume(
+	ctx context.Context, volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) (err error) {
+
+	ctx = b.logContext(ctx, "publish", volConfig.Name)
+	defer b.observeOperation(opVolumePublish, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":        b.Name,
+		"backendUUID":    b.BackendUUID,
+		"volume":         volConfig.Name,
+		"volumeInternal": volConfig.InternalName,
+	}).Debug("Attempting volume publish.")
+
+	// Ensure backend is ready
+	if err := b.ensureOnlineOrDeleting(); err != nil {
+		return err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return b.Driver.Publish(ctx, volConfig, publishInfo)
+}
+
+// UpdateVolumePublication refreshes the publish info of an already-published volume.  Drivers
+// that don't implement PublicationUpdater never have stale publish info, so nothing is changed.
+func (b *Backend) UpdateVolumePublication(
+	ctx context.Context, volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) (bool, error) {
+
+	ctx = b.logContext(ctx, "publish", volConfig.Name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":        b.Name,
+		"backendUUID":    b.BackendUUID,
+		"volume":         volConfig.Name,
+		"volumeInternal": volConfig.InternalName,
+	}).Debug("Attempting volume publication update.")
+
+	updater, ok := b.Driver.(PublicationUpdater)
+	if !ok {
+		return false, nil
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnlineOrDeleting(); err != nil {
+		return false, err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	return updater.UpdatePublication(ctx, volConfig, publishInfo)
+}
+
+// GetCloneSplitStatus returns the progress of a volume's most recent clone split, or nil if there
+// is none or the driver doesn't split clones in the background.
+func (b *Backend) GetCloneSplitStatus(volumeInternalName string) *CloneSplitStatus {
+	if reporter, ok := b.Driver.(CloneSplitReporter); ok && b.Driver.Initialized() {
+		return reporter.GetCloneSplitStatus(volumeInternalName)
+	}
+	return nil
+}
+
+// ResumeCloneSplit has the driver follow the split of a cloned volume again after Trident restarts, as
+// splits that were queued or running are tracked only in memory.
+func (b *Backend) ResumeCloneSplit(ctx context.Context, volConfig *VolumeConfig) error {
+
+	reporter, ok := b.Driver.(CloneSplitReporter)
+	if !ok || !b.Driver.Initialized() || !b.State.IsOnline() || volConfig.CloneSourceVolume == "" {
+		return nil
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return reporter.ResumeCloneSplit(ctx, volConfig)
+}
+
+// ListStorageJobs returns the operations the backend's driver is running in the background, or none
+// if the driver doesn't run any or isn't initialized.
+func (b *Backend) ListStorageJobs() []*StorageJob {
+	lister, ok := b.Driver.(StorageJobLister)
+	if !ok || !b.Driver.Initialized() {
+		return []*StorageJob{}
+	}
+	jobs := lister.ListStorageJobs()
+	for _, job := range jobs {
+		job.Backend = b.Name
+	}
+	return jobs
+}
+
+// ListVolumes returns one page of the volumes on this backend's storage system, naming the Trident volume
+// stored in each if there is one.
+func (b *Backend) ListVolumes(ctx context.Context, limit int, continueToken string) (*VolumePage, error) {
+
+	lister, ok := b.Driver.(VolumeLister)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support listing volumes", b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	page, err := lister.ListVolumes(ctx, limit, continueToken)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeNames := make(map[string]string, len(b.Volumes))
+	for _, volume := range b.Volumes {
+		volumeNames[volume.Config.InternalName] = volume.Config.Name
+	}
+	for _, volume := range page.Volumes {
+		volume.Backend = b.Name
+		volume.Volume = volumeNames[volume.InternalName]
+	}
+	return page, nil
+}
+
+// ListImportCandidates returns the volumes on this backend's storage system that don't belong to a Trident
+// volume and that could be imported, limited to those passing the filter and sorted by name.
+func (b *Backend) ListImportCandidates(ctx context.Context, filter *ImportDiscoveryFilter) ([]*ImportCandidate, error) {
+
+	lister, ok := b.Driver.(ImportCandidateLister)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support import discovery", b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	found, err := lister.ListImportCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Volumes imported without being managed still belong to Trident volumes
+	managed := make(map[string]bool, len(b.Volumes))
+	for _, volume := range b.Volumes {
+		managed[volume.Config.InternalName] = true
+	}
+
+	candidates := make([]*ImportCandidate, 0, len(found))
+	for _, candidate := range found {
+		if managed[candidate.InternalName] || !filter.Matches(candidate) {
+			continue
+		}
+		candidate.Backend = b.Name
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].InternalName < candidates[j].InternalName
+	})
+
+	return candidates, nil
+}
+
+// GetStorageLogs returns the recent entries of the logs of this backend's storage system, newest first.
+func (b *Backend) GetStorageLogs(ctx context.Context) ([]*StorageLogEntry, error) {
+
+	reader, ok := b.Driver.(StorageLogReader)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support reading storage logs", b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	entries, err := reader.GetStorageLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		entry.Backend = b.Name
+	}
+	return entries, nil
+}
+
+// CheckHealth returns an error if this backend's storage system can't be reached.  A backend whose driver
+// can't check its storage system is assumed to be reachable.
+func (b *Backend) CheckHealth(ctx context.Context) error {
+
+	checker, ok := b.Driver.(HealthChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckHealth(ctx)
+}
+
+// GetBucketReport returns how this backend's volumes are distributed among the buckets holding them.
+func (b *Backend) GetBucketReport(ctx context.Context) (*BucketReport, error) {
+
+	reporter, ok := b.Driver.(BucketReporter)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not place volumes in buckets", b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	report, err := reporter.GetBucketReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Backend = b.Name
+	return report, nil
+}
+
+// ListOrphanedResources returns the resources on this backend's storage system that belong to none of
+// the supplied volumes or snapshots.  Backends that can't find orphaned resources have none.
+func (b *Backend) ListOrphanedResources(
+	ctx context.Context, volumes []*VolumeConfig, snapshots []*SnapshotConfig,
+) ([]*OrphanedResource, error) {
+
+	collector, ok := b.Driver.(OrphanCollector)
+	if !ok || !b.Driver.Initialized() || !b.State.IsOnline() {
+		return []*OrphanedResource{}, nil
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	resources, err := collector.ListOrphanedResources(ctx, b.BackendUUID, volumes, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		resource.Backend = b.Name
+	}
+	return resources, nil
+}
+
+// DeleteOrphanedResource deletes a resource found by ListOrphanedResources.
+func (b *Backend) DeleteOrphanedResource(ctx context.Context, resource *OrphanedResource) error {
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":  b.Name,
+		"type":     resource.Type,
+		"resource": resource.Name,
+		"parent":   resource.Parent,
+	}).Debug("Attempting orphaned resource deletion.")
+
+	collector, ok := b.Driver.(OrphanCollector)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support collecting orphaned resources",
+			b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return collector.DeleteOrphanedResource(ctx, resource)
+}
+
+// GetOrphanPolicy returns whether, and when, this backend's orphaned resources should be deleted.  Unless
+// the backend says otherwise, they are only reported.
+func (b *Backend) GetOrphanPolicy() *OrphanPolicy {
+	if collector, ok := b.Driver.(OrphanCollector); ok && b.Driver.Initialized() {
+		if policy := collector.GetOrphanPolicy(); policy != nil {
+			return policy
+		}
+	}
+	return &OrphanPolicy{}
+}
+
+// ResumeAsyncJob asks the driver to resume following the storage system job creating a volume, if
+// the driver creates volumes that way.
+func (b *Backend) ResumeAsyncJob(volConfig *VolumeConfig) {
+	if reporter, ok := b.Driver.(AsyncJobReporter); ok && b.Driver.Initialized() && volConfig.CreateJobID != "" {
+		reporter.ResumeAsyncJob(volConfig)
+	}
+}
+
+// SetAPITraceEnabled turns tracing of this backend's storage API calls on or off.
+func (b *Backend) SetAPITraceEnabled(enabled bool) error {
+
+	tracer, ok := b.Driver.(APITracer)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support API tracing", b.Name))
+	}
+
+	log.WithFields(log.Fields{
+		"backend": b.Name,
+		"enabled": enabled,
+	}).Info("Updating API tracing.")
+
+	tracer.SetAPITraceEnabled(enabled)
+	return nil
+}
+
+// UpdateLogging changes this backend's debug trace flags, and the level at which messages about its storage
+// API calls are logged, as requested.
+func (b *Backend) UpdateLogging(request *UpdateBackendLoggingRequest) error {
+
+	configurer, ok := b.Driver.(LogConfigurer)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support updating its logging", b.Name))
+	}
+
+	log.WithFields(log.Fields{
+		"backend":         b.Name,
+		"debugTraceFlags": request.DebugTraceFlags,
+		"logLevel":        request.LogLevel,
+	}).Info("Updating backend logging.")
+
+	switch request.LogLevel {
+	case "":
+	case DefaultLogLevel:
+		if err := configurer.SetAPILogLevel(""); err != nil {
+			return err
+		}
+	default:
+		if err := configurer.SetAPILogLevel(request.LogLevel); err != nil {
+			return err
+		}
+	}
+
+	if request.DebugTraceFlags != nil {
+		// The driver keeps the flags, so give it a copy that nothing else may change
+		flags := make(map[string]bool, len(request.DebugTraceFlags))
+		for flag, enabled := range request.DebugTraceFlags {
+			flags[flag] = enabled
+		}
+		configurer.SetDebugTraceFlags(flags)
+	}
+	return nil
+}
+
+// UpdateVolume changes attributes of an existing volume on this backend.
+func (b *Backend) UpdateVolume(
+	ctx context.Context, volConfig *VolumeConfig, updateRequest *UpdateVolumeRequest,
+) error {
+
+	ctx = b.logContext(ctx, "update", volConfig.Name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":           b.Name,
+		"volume":            volConfig.Name,
+		"volumeInternal":    volConfig.InternalName,
+		"snapshotDirectory": updateRequest.SnapshotDirectory,
+	}).Debug("Attempting volume update.")
+
+	updater, ok := b.Driver.(VolumeUpdater)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support updating volumes", b.Name))
+	}
+
+	// Ensure volume is managed
+	if volConfig.ImportNotManaged {
+		return &NotManagedError{volConfig.InternalName}
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return err
 	}
 
-	vol := NewVolume(volConfig, b.BackendUUID, storagePool.Name, false)
-	b.Volumes[vol.Config.Name] = vol
-	return vol, nil
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return updater.UpdateVolume(ctx, volConfig, updateRequest)
 }
 
-func (b *Backend) CloneVolume(volConfig *VolumeConfig, storagePool *Pool, retry bool) (*Volume, error) {
+// MoveVolume begins moving a volume to another physical pool of this backend.
+func (b *Backend) MoveVolume(ctx context.Context, volConfig *VolumeConfig, destinationPool string) error {
 
-	log.WithFields(log.Fields{
-		"backend":                volConfig.Name,
-		"backendUUID":            b.BackendUUID,
-		"storage_class":          volConfig.StorageClass,
-		"source_volume":          volConfig.CloneSourceVolume,
-		"source_volume_internal": volConfig.CloneSourceVolumeInternal,
-		"source_snapshot":        volConfig.CloneSourceSnapshot,
-		"clone_volume":           volConfig.Name,
-		"clone_volume_internal":  volConfig.InternalName,
-	}).Debug("Attempting volume clone.")
+	ctx = b.logContext(ctx, "move", volConfig.Name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":         b.Name,
+		"volume":          volConfig.Name,
+		"volumeInternal":  volConfig.InternalName,
+		"destinationPool": destinationPool,
+	}).Debug("Attempting volume move.")
+
+	mover, ok := b.Driver.(VolumeMover)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support moving volumes", b.Name))
+	}
 
 	// Ensure volume is managed
 	if volConfig.ImportNotManaged {
-		return nil, &NotManagedError{volConfig.InternalName}
+		return &NotManagedError{volConfig.InternalName}
 	}
 
 	// Ensure backend is ready
 	if err := b.ensureOnline(); err != nil {
-		return nil, err
+		return err
 	}
 
-	// Ensure the internal names exist
-	if volConfig.InternalName == "" {
-		return nil, errors.New("internal name not set")
-	}
-	if volConfig.CloneSourceVolumeInternal == "" {
-		return nil, errors.New("clone source volume internal name not set")
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
 	}
+	defer done()
 
-	// Clone volume on the backend
-	volumeExists := false
-	if err := b.Driver.CreateClone(volConfig, storagePool); err != nil {
+	return mover.MoveVolume(ctx, volConfig, destinationPool)
+}
 
-		if drivers.IsVolumeExistsError(err) {
+// GetVolumeMoveStatus returns the progress of a volume's most recent move, or nil if there is none
+// or the driver doesn't move volumes.
+func (b *Backend) GetVolumeMoveStatus(volumeInternalName string) *VolumeMoveStatus {
+	if mover, ok := b.Driver.(VolumeMover); ok && b.Driver.Initialized() {
+		return mover.GetVolumeMoveStatus(volumeInternalName)
+	}
+	return nil
+}
 
-			// Implement idempotency by ignoring the error if the volume exists already
-			volumeExists = true
+// ResumeVolumeMove has the driver follow the move of a volume again after Trident restarts, as moves
+// are tracked only in memory.
+func (b *Backend) ResumeVolumeMove(ctx context.Context, volConfig *VolumeConfig) error {
 
-			log.WithFields(log.Fields{
-				"backend": b.Name,
-				"volume":  volConfig.InternalName,
-			}).Warning("Volume already exists.")
+	mover, ok := b.Driver.(VolumeMover)
+	if !ok || !b.Driver.Initialized() || !b.State.IsOnline() || volConfig.MoveDestinationPool == "" {
+		return nil
+	}
 
-		} else {
-			// If the volume doesn't exist but the create failed, return the error
-			return nil, err
-		}
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
 	}
+	defer done()
 
-	// The clone may not be fully created when the clone API returns, so wait here until it exists.
-	checkCloneExists := func() error {
-		return b.Driver.Get(volConfig.InternalName)
+	return mover.ResumeVolumeMove(ctx, volConfig)
+}
+
+// GetVolumeUsage returns the most recently read usage of a volume, or nil if it hasn't been read or
+// the driver doesn't track usage.
+func (b *Backend) GetVolumeUsage(volumeInternalName string) *VolumeUsage {
+	if reporter, ok := b.Driver.(VolumeUsageReporter); ok && b.Driver.Initialized() {
+		return reporter.GetVolumeUsage(volumeInternalName)
 	}
-	cloneExistsNotify := func(err error, duration time.Duration) {
-		log.WithField("increment", duration).Debug("Clone not yet present, waiting.")
+	return nil
+}
+
+// ListRecoverableVolumes returns the deleted volumes in this backend's recovery queue.
+func (b *Backend) ListRecoverableVolumes(ctx context.Context) ([]*RecoverableVolume, error) {
+
+	recoverer, ok := b.Driver.(VolumeRecoverer)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support recovering volumes", b.Name))
 	}
-	cloneBackoff := backoff.NewExponentialBackOff()
-	cloneBackoff.InitialInterval = 1 * time.Second
-	cloneBackoff.Multiplier = 2
-	cloneBackoff.RandomizationFactor = 0.1
-	cloneBackoff.MaxElapsedTime = 90 * time.Second
 
-	// Run the clone check using an exponential backoff
-	if err := backoff.RetryNotify(checkCloneExists, cloneBackoff, cloneExistsNotify); err != nil {
-		log.WithField("clone_volume", volConfig.Name).Warnf("Could not find clone after %3.2f seconds.",
-			float64(cloneBackoff.MaxElapsedTime))
-	} else {
-		log.WithField("clone_volume", volConfig.Name).Debug("Clone found.")
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
 	}
 
-	if err := b.Driver.CreateFollowup(volConfig); err != nil {
+	return recoverer.ListRecoverableVolumes(ctx)
+}
 
-		// If follow-up fails and we just created the volume, clean up by deleting it
-		if !volumeExists || retry {
-			errDestroy := b.Driver.Destroy(volConfig.InternalName)
-			if errDestroy != nil {
-				log.WithFields(log.Fields{
-					"backend": b.Name,
-					"volume":  volConfig.InternalName,
-				}).Warnf("Mapping the created volume failed "+
-					"and %s wasn't able to delete it afterwards: %s. "+
-					"Volume must be manually deleted.",
-					tridentconfig.OrchestratorName, errDestroy)
-			}
-		}
+// RecoverVolume restores a deleted volume from this backend's recovery queue.  The volume is not
+// managed by Trident until it is imported.
+func (b *Backend) RecoverVolume(ctx context.Context, internalName string) error {
 
-		// In all cases where follow-up fails, return the follow-up error
-		return nil, err
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":        b.Name,
+		"volumeInternal": internalName,
+	}).Debug("Attempting volume recovery.")
+
+	recoverer, ok := b.Driver.(VolumeRecoverer)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support recovering volumes", b.Name))
 	}
 
-	// Traditionally, cloned volumes never got assigned to a storage pool, however, after introduction
-	// of Virtual Pools we try to assign cloned volume to the same storage pool as source volume.
-	// It should be noted that imported volumes do not get assigned to any storage pools, therefore,
-	// clones of imported volumes also gets assigned to unset storage pools.
-	poolName := drivers.UnsetPool
-	if storagePool != nil {
-		poolName = storagePool.Name
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return err
 	}
 
-	vol := NewVolume(volConfig, b.BackendUUID, poolName, false)
-	b.Volumes[vol.Config.Name] = vol
-	return vol, nil
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return recoverer.RecoverVolume(ctx, internalName)
 }
 
-func (b *Backend) PublishVolume(volConfig *VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+// ResumeVolumeCreate has the driver check that a volume left behind by an interrupted create or clone is
+// ready for use, so that the operation may be finished.
+func (b *Backend) ResumeVolumeCreate(ctx context.Context, volConfig *VolumeConfig) error {
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
-		"backendUUID":    b.BackendUUID,
 		"volume":         volConfig.Name,
 		"volumeInternal": volConfig.InternalName,
-	}).Debug("Attempting volume publish.")
+	}).Debug("Attempting to resume volume creation.")
+
+	resumer, ok := b.Driver.(VolumeCreateResumer)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support resuming volume creation",
+			b.Name))
+	}
 
 	// Ensure backend is ready
-	if err := b.ensureOnlineOrDeleting(); err != nil {
+	if err := b.ensureOnline(); err != nil {
+		return err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return resumer.ResumeVolumeCreate(ctx, volConfig)
+}
+
+// RefreshPools has the driver rediscover its storage pools and replaces the backend's pools with those
+// found.  The pools are new objects even if their names are unchanged, so storage classes must be
+// matched against the backend again afterward.  The backend keeps its pools if they can't be rediscovered.
+func (b *Backend) RefreshPools(ctx context.Context) error {
+
+	utils.Logc(ctx).WithField("backend", b.Name).Debug("Attempting storage pool refresh.")
+
+	refresher, ok := b.Driver.(PoolRefresher)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("backend %s does not support refreshing its storage pools",
+			b.Name))
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
 		return err
 	}
+	defer done()
+
+	if err = refresher.RefreshPools(ctx); err != nil {
+		return fmt.Errorf("could not refresh storage pools of backend %s; %v", b.Name, err)
+	}
+
+	storagePools := b.Storage
+	b.Storage = make(map[string]*Pool)
+	if err = b.Driver.GetStorageBackendSpecs(b); err != nil {
+		b.Storage = storagePools
+		return fmt.Errorf("could not refresh storage pools of backend %s; %v", b.Name, err)
+	}
 
-	return b.Driver.Publish(volConfig, publishInfo)
+	return nil
 }
 
 func (b *Backend) GetVolumeExternal(volumeName string) (*VolumeExternal, error) {
@@ -412,9 +1451,12 @@ func (b *Backend) GetVolumeExternal(volumeName string) (*VolumeExternal, error)
 	return volExternal, nil
 }
 
-func (b *Backend) ImportVolume(volConfig *VolumeConfig) (*Volume, error) {
+func (b *Backend) ImportVolume(ctx context.Context, volConfig *VolumeConfig) (volume *Volume, err error) {
 
-	log.WithFields(log.Fields{
+	ctx = b.logContext(ctx, "import", volConfig.Name)
+	defer b.observeOperation(opVolumeImport, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":    b.Name,
 		"volume":     volConfig.ImportOriginalName,
 		"NotManaged": volConfig.ImportNotManaged,
@@ -425,6 +1467,12 @@ func (b *Backend) ImportVolume(volConfig *VolumeConfig) (*Volume, error) {
 		return nil, err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
 	if volConfig.ImportNotManaged {
 		// The volume is not managed and will not be renamed during import.
 		volConfig.InternalName = volConfig.ImportOriginalName
@@ -433,22 +1481,25 @@ func (b *Backend) ImportVolume(volConfig *VolumeConfig) (*Volume, error) {
 		b.Driver.CreatePrepare(volConfig)
 	}
 
-	err := b.Driver.Import(volConfig, volConfig.ImportOriginalName)
+	err = b.Driver.Import(ctx, volConfig, volConfig.ImportOriginalName)
 	if err != nil {
 		return nil, fmt.Errorf("driver import volume failed: %v", err)
 	}
 
-	err = b.Driver.CreateFollowup(volConfig)
+	err = b.Driver.CreateFollowup(ctx, volConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed post import volume operations : %v", err)
 	}
 
-	volume := NewVolume(volConfig, b.BackendUUID, drivers.UnsetPool, false)
+	volume = NewVolume(volConfig, b.BackendUUID, drivers.UnsetPool, false)
 	b.Volumes[volume.Config.Name] = volume
 	return volume, nil
 }
 
-func (b *Backend) ResizeVolume(volConfig *VolumeConfig, newSize string) error {
+func (b *Backend) ResizeVolume(ctx context.Context, volConfig *VolumeConfig, newSize string) (err error) {
+
+	ctx = b.logContext(ctx, "resize", volConfig.Name)
+	defer b.observeOperation(opVolumeResize, time.Now(), &err)
 
 	// Ensure volume is managed
 	if volConfig.ImportNotManaged {
@@ -460,6 +1511,12 @@ func (b *Backend) ResizeVolume(volConfig *VolumeConfig, newSize string) error {
 		return err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	// Determine volume size in bytes
 	requestedSize, err := utils.ConvertSizeToBytes(newSize)
 	if err != nil {
@@ -470,15 +1527,28 @@ func (b *Backend) ResizeVolume(volConfig *VolumeConfig, newSize string) error {
 		return fmt.Errorf("%v is an invalid volume size: %v", newSize, err)
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":     b.Name,
 		"volume":      volConfig.InternalName,
 		"volume_size": newSizeBytes,
 	}).Debug("Attempting volume resize.")
-	return b.Driver.Resize(volConfig, newSizeBytes)
+	if resizer, ok := b.Driver.(PoolResizer); ok {
+		return resizer.ResizeInPool(ctx, volConfig, b.getVolumePool(volConfig.Name), newSizeBytes)
+	}
+	return b.Driver.Resize(ctx, volConfig, newSizeBytes)
+}
+
+// getVolumePool returns the storage pool holding a volume, or nil if the volume or its pool isn't known.
+func (b *Backend) getVolumePool(volumeName string) *Pool {
+	if volume, ok := b.Volumes[volumeName]; ok {
+		return b.Storage[volume.Pool]
+	}
+	return nil
 }
 
-func (b *Backend) RenameVolume(volConfig *VolumeConfig, newName string) error {
+func (b *Backend) RenameVolume(ctx context.Context, volConfig *VolumeConfig, newName string) error {
+
+	ctx = b.logContext(ctx, "rename", volConfig.Name)
 
 	oldName := volConfig.InternalName
 
@@ -488,25 +1558,34 @@ func (b *Backend) RenameVolume(volConfig *VolumeConfig, newName string) error {
 	}
 
 	if b.State != Online {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"state":         b.State,
 			"expectedState": string(Online),
 		}).Error("Invalid backend state.")
 		return fmt.Errorf("backend %s is not Online", b.Name)
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	if err := b.Driver.Get(oldName); err != nil {
 		return fmt.Errorf("volume %s not found on backend %s; %v", oldName, b.Name, err)
 	}
-	if err := b.Driver.Rename(oldName, newName); err != nil {
+	if err := b.Driver.Rename(ctx, oldName, newName); err != nil {
 		return fmt.Errorf("error attempting to rename volume %s on backend %s: %v", oldName, b.Name, err)
 	}
 	return nil
 }
 
-func (b *Backend) RemoveVolume(volConfig *VolumeConfig) error {
+func (b *Backend) RemoveVolume(ctx context.Context, volConfig *VolumeConfig) (err error) {
 
-	log.WithFields(log.Fields{
+	ctx = b.logContext(ctx, "delete", volConfig.Name)
+	defer b.observeOperation(opVolumeDelete, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         volConfig.Name,
 		"volumeInternal": volConfig.InternalName,
@@ -522,15 +1601,30 @@ func (b *Backend) RemoveVolume(volConfig *VolumeConfig) error {
 		return err
 	}
 
-	if err := b.Driver.Destroy(volConfig.InternalName); err != nil {
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := b.destroyVolume(ctx, volConfig); err != nil {
 		// TODO:  Check the error being returned once the nDVP throws errors
 		// for volumes that aren't found.
 		return err
 	}
+	b.gate.forget(volConfig.InternalName)
 	b.RemoveCachedVolume(volConfig.Name)
 	return nil
 }
 
+// destroyVolume destroys a volume with the driver, giving the driver the volume's config if it can use it.
+func (b *Backend) destroyVolume(ctx context.Context, volConfig *VolumeConfig) error {
+	if destroyer, ok := b.Driver.(VolumeDestroyer); ok {
+		return destroyer.DestroyVolume(ctx, volConfig)
+	}
+	return b.Driver.Destroy(ctx, volConfig.InternalName)
+}
+
 func (b *Backend) RemoveCachedVolume(volumeName string) {
 
 	if _, ok := b.Volumes[volumeName]; ok {
@@ -538,9 +1632,9 @@ func (b *Backend) RemoveCachedVolume(volumeName string) {
 	}
 }
 
-func (b *Backend) GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error) {
+func (b *Backend) GetSnapshot(ctx context.Context, snapConfig *SnapshotConfig) (*Snapshot, error) {
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         snapConfig.Name,
 		"volumeInternal": snapConfig.InternalName,
@@ -552,7 +1646,7 @@ func (b *Backend) GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error) {
 		return nil, err
 	}
 
-	if snapshot, err := b.Driver.GetSnapshot(snapConfig); err != nil {
+	if snapshot, err := b.Driver.GetSnapshot(ctx, snapConfig); err != nil {
 		// An error here means we couldn't check for the snapshot.  It does not mean the snapshot doesn't exist.
 		return nil, err
 	} else if snapshot == nil {
@@ -563,9 +1657,9 @@ func (b *Backend) GetSnapshot(snapConfig *SnapshotConfig) (*Snapshot, error) {
 	}
 }
 
-func (b *Backend) GetSnapshots(volConfig *VolumeConfig) ([]*Snapshot, error) {
+func (b *Backend) GetSnapshots(ctx context.Context, volConfig *VolumeConfig) ([]*Snapshot, error) {
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         volConfig.Name,
 		"volumeInternal": volConfig.InternalName,
@@ -576,12 +1670,17 @@ func (b *Backend) GetSnapshots(volConfig *VolumeConfig) ([]*Snapshot, error) {
 		return nil, err
 	}
 
-	return b.Driver.GetSnapshots(volConfig)
+	return b.Driver.GetSnapshots(ctx, volConfig)
 }
 
-func (b *Backend) CreateSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeConfig) (*Snapshot, error) {
+func (b *Backend) CreateSnapshot(
+	ctx context.Context, snapConfig *SnapshotConfig, volConfig *VolumeConfig,
+) (snapshot *Snapshot, err error) {
 
-	log.WithFields(log.Fields{
+	ctx = b.logContext(ctx, "createSnapshot", snapConfig.VolumeName)
+	defer b.observeOperation(opSnapshotCreate, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         snapConfig.Name,
 		"volumeInternal": snapConfig.InternalName,
@@ -598,19 +1697,25 @@ func (b *Backend) CreateSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeCo
 		return nil, err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
 	// Set the default internal snapshot name to match the snapshot name.  Drivers
 	// may override this value in the SnapshotConfig structure if necessary.
 	snapConfig.InternalName = snapConfig.Name
 
 	// Implement idempotency by checking for the snapshot first
-	if existingSnapshot, err := b.Driver.GetSnapshot(snapConfig); err != nil {
+	if existingSnapshot, err := b.Driver.GetSnapshot(ctx, snapConfig); err != nil {
 
 		// An error here means we couldn't check for the snapshot.  It does not mean the snapshot doesn't exist.
 		return nil, err
 
 	} else if existingSnapshot != nil {
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"backend":      b.Name,
 			"volumeName":   snapConfig.VolumeName,
 			"snapshotName": snapConfig.Name,
@@ -621,12 +1726,83 @@ func (b *Backend) CreateSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeCo
 	}
 
 	// Create snapshot
-	return b.Driver.CreateSnapshot(snapConfig)
+	return b.Driver.CreateSnapshot(ctx, snapConfig)
 }
 
-func (b *Backend) RestoreSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeConfig) error {
+// CreateGroupSnapshot snapshots a set of volumes on this backend at the same instant.  The volume
+// configs must be given in the same order as the snapshot configs.
+func (b *Backend) CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*SnapshotConfig, volConfigs []*VolumeConfig,
+) ([]*Snapshot, error) {
 
-	log.WithFields(log.Fields{
+	ctx = b.logContext(ctx, "createSnapshot", "")
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":   b.Name,
+		"snapshots": len(snapConfigs),
+	}).Debug("Attempting group snapshot create.")
+
+	snapshotter, ok := b.Driver.(GroupSnapshotter)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("backend %s does not support group snapshots", b.Name))
+	}
+
+	if len(snapConfigs) != len(volConfigs) {
+		return nil, fmt.Errorf("group snapshot has %d snapshots but %d volumes", len(snapConfigs), len(volConfigs))
+	}
+
+	// Ensure volumes are managed
+	for _, volConfig := range volConfigs {
+		if volConfig.ImportNotManaged {
+			return nil, &NotManagedError{volConfig.InternalName}
+		}
+	}
+
+	// Ensure backend is ready
+	if err := b.ensureOnline(); err != nil {
+		return nil, err
+	}
+
+	done, err := b.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	// Implement idempotency by checking for the snapshots first.  The group was created only if every
+	// snapshot in it exists; a group that exists only in part can't be completed.
+	existingSnapshots := make([]*Snapshot, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		snapConfig.InternalName = snapConfig.Name
+		existingSnapshot, err := b.Driver.GetSnapshot(ctx, snapConfig)
+		if err != nil {
+			return nil, err
+		} else if existingSnapshot != nil {
+			existingSnapshots = append(existingSnapshots, existingSnapshot)
+		}
+	}
+	if len(existingSnapshots) == len(snapConfigs) {
+		utils.Logc(ctx).WithFields(log.Fields{
+			"backend":      b.Name,
+			"snapshotName": snapConfigs[0].Name,
+		}).Warning("Group snapshot already exists.")
+		return existingSnapshots, nil
+	} else if len(existingSnapshots) > 0 {
+		return nil, fmt.Errorf("snapshot %s already exists on volume %s",
+			existingSnapshots[0].Config.Name, existingSnapshots[0].Config.VolumeName)
+	}
+
+	return snapshotter.CreateGroupSnapshot(ctx, snapConfigs)
+}
+
+func (b *Backend) RestoreSnapshot(
+	ctx context.Context, snapConfig *SnapshotConfig, volConfig *VolumeConfig,
+) (err error) {
+
+	ctx = b.logContext(ctx, "restoreSnapshot", snapConfig.VolumeName)
+	defer b.observeOperation(opSnapshotRestore, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         snapConfig.Name,
 		"volumeInternal": snapConfig.InternalName,
@@ -643,13 +1819,24 @@ func (b *Backend) RestoreSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeC
 		return err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	// Restore snapshot
-	return b.Driver.RestoreSnapshot(snapConfig)
+	return b.Driver.RestoreSnapshot(ctx, snapConfig)
 }
 
-func (b *Backend) DeleteSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeConfig) error {
+func (b *Backend) DeleteSnapshot(
+	ctx context.Context, snapConfig *SnapshotConfig, volConfig *VolumeConfig,
+) (err error) {
 
-	log.WithFields(log.Fields{
+	ctx = b.logContext(ctx, "deleteSnapshot", snapConfig.VolumeName)
+	defer b.observeOperation(opSnapshotDelete, time.Now(), &err)
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":        b.Name,
 		"volume":         snapConfig.Name,
 		"volumeInternal": snapConfig.InternalName,
@@ -666,15 +1853,21 @@ func (b *Backend) DeleteSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeCo
 		return err
 	}
 
+	done, err := b.beginOperation()
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	// Implement idempotency by checking for the snapshot first
-	if existingSnapshot, err := b.Driver.GetSnapshot(snapConfig); err != nil {
+	if existingSnapshot, err := b.Driver.GetSnapshot(ctx, snapConfig); err != nil {
 
 		// An error here means we couldn't check for the snapshot.  It does not mean the snapshot doesn't exist.
 		return err
 
 	} else if existingSnapshot == nil {
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"backend":      b.Name,
 			"volumeName":   snapConfig.VolumeName,
 			"snapshotName": snapConfig.Name,
@@ -685,7 +1878,7 @@ func (b *Backend) DeleteSnapshot(snapConfig *SnapshotConfig, volConfig *VolumeCo
 	}
 
 	// Delete snapshot
-	return b.Driver.DeleteSnapshot(snapConfig)
+	return b.Driver.DeleteSnapshot(ctx, snapConfig)
 }
 
 const (
@@ -713,6 +1906,14 @@ func (b *Backend) HasVolumes() bool {
 // Terminate informs the backend that it is being deleted from the core
 // and will not be called again.  This may be a signal to the storage
 // driver to clean up and stop any ongoing operations.
+// SetBackendUUID sets the backend's UUID, passing it to the driver if the driver tags what it creates.
+func (b *Backend) SetBackendUUID(backendUUID string) {
+	b.BackendUUID = backendUUID
+	if setter, ok := b.Driver.(BackendUUIDSetter); ok {
+		setter.SetBackendUUID(backendUUID)
+	}
+}
+
 func (b *Backend) Terminate() {
 
 	logFields := log.Fields{
@@ -740,6 +1941,38 @@ func (b *Backend) ReconcileNodeAccess(nodes []*utils.Node) error {
 	return nil
 }
 
+// RevokeNodeAccess withdraws a deleted node's access to this backend's volumes.  Drivers that can't
+// revoke a single node's access, or refuse to, fall back to reconciling the access of the remaining nodes.
+func (b *Backend) RevokeNodeAccess(ctx context.Context, node *utils.Node, remainingNodes []*utils.Node) error {
+	if b.State != Online && b.State != Deleting {
+		return nil
+	}
+	if revoker, ok := b.Driver.(NodeAccessRevoker); ok {
+		if err := revoker.RevokeNodeAccess(ctx, node); !utils.IsUnsupportedError(err) {
+			return err
+		}
+	}
+	return b.Driver.ReconcileNodeAccess(remainingNodes, b.BackendUUID)
+}
+
+// logContext returns a context carrying the fields that correlate the messages logged for an operation
+// on this backend, so that the driver's messages about the operation carry them too.
+func (b *Backend) logContext(ctx context.Context, operation, volumeName string) context.Context {
+	fields := log.Fields{
+		utils.LogFieldBackend:   b.Name,
+		utils.LogFieldOperation: operation,
+	}
+	if volumeName != "" {
+		fields[utils.LogFieldVolume] = volumeName
+	}
+	if fielder, ok := b.Driver.(LogFielder); ok && b.Driver.Initialized() {
+		for k, v := range fielder.LogFields() {
+			fields[k] = v
+		}
+	}
+	return utils.WithLogFields(ctx, fields)
+}
+
 func (b *Backend) ensureOnline() error {
 	if b.State != Online {
 		log.WithFields(log.Fields{
@@ -771,18 +2004,24 @@ type BackendExternal struct {
 	State       BackendState           `json:"state"`
 	Online      bool                   `json:"online"`
 	Volumes     []string               `json:"volumes"`
+	Features    map[string]bool        `json:"features,omitempty"`
+	// VolumeExpansion is how the backend's volumes are grown
+	VolumeExpansion drivers.VolumeExpansion `json:"volumeExpansion"`
+	// APILogLevel is the level at which messages about storage API calls are logged, if not Trident's
+	APILogLevel string `json:"apiLogLevel,omitempty"`
 }
 
 func (b *Backend) ConstructExternal() *BackendExternal {
 	backendExternal := BackendExternal{
-		Name:        b.Name,
-		BackendUUID: b.BackendUUID,
-		Protocol:    b.GetProtocol(),
-		Config:      b.Driver.GetExternalConfig(),
-		Storage:     make(map[string]interface{}),
-		Online:      b.Online,
-		State:       b.State,
-		Volumes:     make([]string, 0),
+		Name:            b.Name,
+		BackendUUID:     b.BackendUUID,
+		Protocol:        b.GetProtocol(),
+		Config:          b.Driver.GetExternalConfig(),
+		Storage:         make(map[string]interface{}),
+		Online:          b.Online,
+		State:           b.State,
+		Volumes:         make([]string, 0),
+		VolumeExpansion: b.Driver.GetVolumeExpansion(),
 	}
 
 	for name, pool := range b.Storage {
@@ -791,6 +2030,12 @@ func (b *Backend) ConstructExternal() *BackendExternal {
 	for volName := range b.Volumes {
 		backendExternal.Volumes = append(backendExternal.Volumes, volName)
 	}
+	if reporter, ok := b.Driver.(FeatureReporter); ok && b.Driver.Initialized() {
+		backendExternal.Features = reporter.GetFeatures()
+	}
+	if configurer, ok := b.Driver.(LogConfigurer); ok && b.Driver.Initialized() {
+		backendExternal.APILogLevel = configurer.GetAPILogLevel()
+	}
 	return &backendExternal
 }
 
@@ -806,6 +2051,7 @@ type PersistentStorageBackendConfig struct {
 	AzureConfig             *drivers.AzureNFSStorageDriverConfig  `json:"azure_config,omitempty"`
 	GCPConfig               *drivers.GCPNFSStorageDriverConfig    `json:"gcp_config,omitempty"`
 	FakeStorageDriverConfig *drivers.FakeStorageDriverConfig      `json:"fake_config,omitempty"`
+	FakeOntapConfig         *drivers.FakeOntapStorageDriverConfig `json:"fake_ontap_config,omitempty"`
 }
 
 type BackendPersistent struct {
@@ -854,6 +2100,8 @@ func (p *BackendPersistent) MarshalConfig() (string, error) {
 		bytes, err = json.Marshal(p.Config.GCPConfig)
 	case p.Config.FakeStorageDriverConfig != nil:
 		bytes, err = json.Marshal(p.Config.FakeStorageDriverConfig)
+	case p.Config.FakeOntapConfig != nil:
+		bytes, err = json.Marshal(p.Config.FakeOntapConfig)
 	default:
 		return "", fmt.Errorf("no recognized config found for backend %s", p.Name)
 	}
@@ -922,7 +2170,7 @@ func (p *BackendPersistent) ExtractBackendSecrets(secretName string) (*BackendPe
 		secretMap["Private_Key_ID"] = backend.Config.GCPConfig.APIKey.PrivateKeyID
 		backend.Config.GCPConfig.APIKey.PrivateKey = secretName
 		backend.Config.GCPConfig.APIKey.PrivateKeyID = secretName
-	case p.Config.FakeStorageDriverConfig != nil:
+	case p.Config.FakeStorageDriverConfig != nil, p.Config.FakeOntapConfig != nil:
 		// Nothing to do
 	default:
 		return nil, nil, errors.New("cannot extract secrets, unknown backend type")
@@ -997,7 +2245,7 @@ func (p *BackendPersistent) InjectBackendSecrets(secretMap map[string]string) er
 		if p.Config.GCPConfig.APIKey.PrivateKeyID, ok = secretMap["Private_Key_ID"]; !ok {
 			return makeError("Private_Key_ID")
 		}
-	case p.Config.FakeStorageDriverConfig != nil:
+	case p.Config.FakeStorageDriverConfig != nil, p.Config.FakeOntapConfig != nil:
 		// Nothing to do
 	default:
 		return errors.New("cannot inject secrets, unknown backend type")
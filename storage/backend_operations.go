@@ -0,0 +1,255 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/netapp/trident/utils"
+)
+
+// BackendDrainTimeout is how long a backend update waits for the operations already running against
+// the original backend to finish.
+const BackendDrainTimeout = 2 * time.Minute
+
+// OperationGateTimeout is how long an operation waits for a slot when its backend is already running
+// as many operations of that kind as it allows.
+const OperationGateTimeout = 30 * time.Second
+
+// Kinds of operations whose concurrency may be limited on each backend.
+const (
+	OperationCreate = "create"
+	OperationClone  = "clone"
+)
+
+// OperationGate limits how many operations of each kind may run against a backend at once, so that a
+// burst of requests can't overwhelm a single storage system.  The orchestrator calls a backend's driver
+// for one request at a time, so operations only overlap once they outlast their request, as creates and
+// clones that the driver reports as still creating do; such an operation keeps its slot until a later
+// call for its volume finishes it, or until the volume is deleted.  Operations without a limit are never
+// held, and a nil gate holds nothing.
+type OperationGate struct {
+	semaphores map[string]chan struct{}
+
+	mutex   sync.Mutex
+	running map[string]func() // releases the slots of operations still running, by volume
+}
+
+// NewOperationGate returns a gate enforcing the supplied limits, by operation kind.  A limit of zero
+// leaves that kind of operation unlimited.
+func NewOperationGate(limits map[string]int) *OperationGate {
+
+	gate := &OperationGate{
+		semaphores: make(map[string]chan struct{}),
+		running:    make(map[string]func()),
+	}
+	for operation, limit := range limits {
+		if limit > 0 {
+			gate.semaphores[operation] = make(chan struct{}, limit)
+		}
+	}
+	return gate
+}
+
+// acquire waits up to the timeout for a slot to run an operation of the given kind on a volume, unless
+// the volume still holds the slot of an earlier call.  The returned function must be called once the
+// call to the driver returns, saying whether the operation is still running on the storage system, in
+// which case the volume keeps the slot.
+func (g *OperationGate) acquire(
+	ctx context.Context, operation, volume string, timeout time.Duration,
+) (func(running bool), error) {
+
+	if g == nil || g.semaphores[operation] == nil {
+		return func(bool) {}, nil
+	}
+	semaphore := g.semaphores[operation]
+
+	g.mutex.Lock()
+	release, held := g.running[volume]
+	delete(g.running, volume)
+	g.mutex.Unlock()
+
+	if !held {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case semaphore <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-semaphore }) }
+		case <-timer.C:
+			return nil, fmt.Errorf("timed out after %v waiting for one of %d concurrent %s operations to finish",
+				timeout, cap(semaphore), operation)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return func(running bool) {
+		if !running {
+			release()
+			return
+		}
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+		g.running[volume] = release
+	}, nil
+}
+
+// forget releases the slot held by an operation still running on a volume, as when the volume is deleted.
+func (g *OperationGate) forget(volume string) {
+
+	if g == nil {
+		return
+	}
+
+	g.mutex.Lock()
+	release, held := g.running[volume]
+	delete(g.running, volume)
+	g.mutex.Unlock()
+
+	if held {
+		release()
+	}
+}
+
+// operationTracker counts the operations running against a backend's driver, so that a backend update
+// can fence off new operations and wait for running ones to finish before replacing the driver.  The
+// zero value is ready to use.
+type operationTracker struct {
+	mutex    sync.Mutex
+	inFlight int
+	fenced   bool
+	drained  chan struct{}
+}
+
+// begin registers a new operation, unless the tracker is fenced.  The returned function must be called
+// once the operation finishes.
+func (t *operationTracker) begin() (func(), bool) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.fenced {
+		return nil, false
+	}
+	t.inFlight++
+
+	var once sync.Once
+	return func() { once.Do(t.end) }, true
+}
+
+func (t *operationTracker) end() {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.inFlight--
+	if t.inFlight == 0 && t.drained != nil {
+		close(t.drained)
+		t.drained = nil
+	}
+}
+
+// fence stops new operations from starting without waiting for the running ones, returning false if the
+// tracker was already fenced.
+func (t *operationTracker) fence() bool {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.fenced {
+		return false
+	}
+	t.fenced = true
+	return true
+}
+
+// drain stops new operations from starting and waits up to the timeout for the running ones to finish.
+// The tracker stays fenced if the wait times out, so the caller must unfence it to give up.
+func (t *operationTracker) drain(timeout time.Duration) error {
+
+	t.mutex.Lock()
+	t.fenced = true
+	if t.inFlight == 0 {
+		t.mutex.Unlock()
+		return nil
+	}
+	if t.drained == nil {
+		t.drained = make(chan struct{})
+	}
+	drained, inFlight := t.drained, t.inFlight
+	t.mutex.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %v waiting for %d operations to finish", timeout, inFlight)
+	}
+}
+
+func (t *operationTracker) unfence() {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.fenced = false
+}
+
+// Fence stops new operations from being started against the backend without waiting for the running
+// ones, so that a backend update may claim the backend before releasing its locks to drain it.  An error
+// is returned if the backend is already fenced, as it is while another update drains it.
+func (b *Backend) Fence() error {
+	if !b.operations.fence() {
+		return fmt.Errorf("backend %s is already being updated, try again later", b.Name)
+	}
+	return nil
+}
+
+// Drain stops new operations from being started against the backend and waits for the running ones to
+// finish, so that the backend's driver may be safely replaced.  If the operations don't finish within
+// the timeout, the backend is reopened and an error is returned.
+func (b *Backend) Drain(timeout time.Duration) error {
+	if err := b.operations.drain(timeout); err != nil {
+		b.operations.unfence()
+		return fmt.Errorf("could not drain backend %s; %v", b.Name, err)
+	}
+	return nil
+}
+
+// Reopen allows operations against a drained backend again, as when its update fails.
+func (b *Backend) Reopen() {
+	b.operations.unfence()
+}
+
+// beginOperation registers an operation against the backend's driver, failing if the backend is
+// drained.  Every call into the driver goes through it, so that a backend update can't replace the
+// driver while an operation is still using it.  The returned function must be called once the
+// operation finishes.
+func (b *Backend) beginOperation() (func(), error) {
+	done, ok := b.operations.begin()
+	if !ok {
+		return nil, fmt.Errorf("backend %s is being updated, try again later", b.Name)
+	}
+	return done, nil
+}
+
+// SetOperationGate limits the concurrent operations against the backend with the supplied gate.
+func (b *Backend) SetOperationGate(gate *OperationGate) {
+	b.gate = gate
+}
+
+// acquireOperation waits for the backend's operation gate to admit an operation of the given kind on a
+// volume.  The returned function must be called once the call to the driver returns, with the error it
+// returned, so that an operation still creating the volume keeps its slot.
+func (b *Backend) acquireOperation(ctx context.Context, operation, volume string) (func(error), error) {
+	finish, err := b.gate.acquire(ctx, operation, volume, OperationGateTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("backend %s is busy, try again later; %v", b.Name, err)
+	}
+	return func(err error) { finish(utils.IsVolumeCreatingError(err)) }, nil
+}
@@ -3,9 +3,14 @@
 package storage
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	sa "github.com/netapp/trident/storage_attribute"
+	"github.com/netapp/trident/utils"
 )
 
 func TestBackendState(t *testing.T) {
@@ -65,3 +70,304 @@ func TestBackendState(t *testing.T) {
 		assert.True(t, test.predicate(test.input), "Predicate failed")
 	}
 }
+
+// nodeAccessDriver records how a backend withdrew a node's access.
+type nodeAccessDriver struct {
+	Driver
+	reconciledNodes []*utils.Node
+}
+
+func (d *nodeAccessDriver) ReconcileNodeAccess(nodes []*utils.Node, _ string) error {
+	d.reconciledNodes = nodes
+	return nil
+}
+
+type nodeAccessRevokingDriver struct {
+	nodeAccessDriver
+	revokedNode *utils.Node
+}
+
+func (d *nodeAccessRevokingDriver) RevokeNodeAccess(_ context.Context, node *utils.Node) error {
+	d.revokedNode = node
+	return nil
+}
+
+// nodeAccessRefusingDriver can revoke a node's access on some storage systems, but not this one's.
+type nodeAccessRefusingDriver struct {
+	nodeAccessDriver
+}
+
+func (d *nodeAccessRefusingDriver) RevokeNodeAccess(_ context.Context, _ *utils.Node) error {
+	return utils.UnsupportedError("cannot revoke a single node's access")
+}
+
+func TestBackendRevokeNodeAccess(t *testing.T) {
+
+	ctx := context.Background()
+	deleted := &utils.Node{Name: "deleted", IQN: "iqn.deleted"}
+	remaining := []*utils.Node{{Name: "remaining", IQN: "iqn.remaining"}}
+
+	revoker := &nodeAccessRevokingDriver{}
+	backend := &Backend{Driver: revoker, State: Online}
+	assert.NoError(t, backend.RevokeNodeAccess(ctx, deleted, remaining))
+	assert.Equal(t, deleted, revoker.revokedNode)
+	assert.Nil(t, revoker.reconciledNodes, "revoking driver should not reconcile")
+
+	// Drivers that can't revoke one node's access reconcile the others' instead
+	reconciler := &nodeAccessDriver{}
+	backend = &Backend{Driver: reconciler, State: Online}
+	assert.NoError(t, backend.RevokeNodeAccess(ctx, deleted, remaining))
+	assert.Equal(t, remaining, reconciler.reconciledNodes)
+
+	// As do drivers that refuse to
+	refuser := &nodeAccessRefusingDriver{}
+	backend = &Backend{Driver: refuser, State: Online}
+	assert.NoError(t, backend.RevokeNodeAccess(ctx, deleted, remaining))
+	assert.Equal(t, remaining, refuser.reconciledNodes)
+
+	// Offline backends are left alone
+	revoker = &nodeAccessRevokingDriver{}
+	backend = &Backend{Driver: revoker, State: Offline}
+	assert.NoError(t, backend.RevokeNodeAccess(ctx, deleted, remaining))
+	assert.Nil(t, revoker.revokedNode)
+}
+
+// poolResizingDriver records the pool a backend resized a volume in.
+type poolResizingDriver struct {
+	Driver
+	resized bool
+	pool    *Pool
+}
+
+func (d *poolResizingDriver) Name() string {
+	return "fake"
+}
+
+func (d *poolResizingDriver) ResizeInPool(_ context.Context, _ *VolumeConfig, pool *Pool, _ uint64) error {
+	d.resized = true
+	d.pool = pool
+	return nil
+}
+
+func TestBackendResizeVolumeInPool(t *testing.T) {
+
+	pool := NewStoragePool(nil, "pool1")
+	volConfig := &VolumeConfig{Name: "vol1", InternalName: "trident_vol1"}
+
+	tests := map[string]struct {
+		volumes map[string]*Volume
+		pool    *Pool
+	}{
+		"Known pool": {
+			volumes: map[string]*Volume{"vol1": NewVolume(volConfig, "uuid", "pool1", false)},
+			pool:    pool,
+		},
+		"Unknown pool": {
+			volumes: map[string]*Volume{"vol1": NewVolume(volConfig, "uuid", "pool2", false)},
+		},
+		"Unknown volume": {
+			volumes: map[string]*Volume{},
+		},
+	}
+	for name, test := range tests {
+		driver := &poolResizingDriver{}
+		backend := &Backend{Driver: driver, State: Online, Storage: map[string]*Pool{"pool1": pool},
+			Volumes: test.volumes}
+
+		assert.NoError(t, backend.ResizeVolume(context.Background(), volConfig, "2Gi"), name)
+		assert.True(t, driver.resized, name)
+		assert.Equal(t, test.pool, driver.pool, name)
+	}
+}
+
+func TestNewBackendPreview(t *testing.T) {
+
+	aggr2 := NewStoragePool(nil, "aggr2")
+	aggr1 := NewStoragePool(nil, "aggr1")
+	aggr1.Attributes[sa.Snapshots] = sa.NewBoolOffer(true)
+	aggr1.InternalAttributes["snapshotPolicy"] = "default"
+	vpool := NewStoragePool(nil, "backend_pool_0")
+	vpool.InternalAttributes["snapshotPolicy"] = "none"
+
+	preview := NewBackendPreview("backend", "ontap-nas",
+		map[string]*Pool{"aggr2": aggr2, "aggr1": aggr1}, map[string]*Pool{"backend_pool_0": vpool})
+
+	assert.Equal(t, "backend", preview.Name)
+	assert.Equal(t, "ontap-nas", preview.StorageDriverName)
+	if assert.Len(t, preview.Pools, 3) {
+		assert.Equal(t, "aggr1", preview.Pools[0].Name)
+		assert.False(t, preview.Pools[0].Virtual)
+		assert.Equal(t, "true", preview.Pools[0].Attributes[sa.Snapshots])
+		assert.Equal(t, "default", preview.Pools[0].Defaults["snapshotPolicy"])
+		assert.Equal(t, "aggr2", preview.Pools[1].Name)
+		assert.Equal(t, "backend_pool_0", preview.Pools[2].Name)
+		assert.True(t, preview.Pools[2].Virtual)
+		assert.Equal(t, "none", preview.Pools[2].Defaults["snapshotPolicy"])
+	}
+}
+
+func TestBackendDrain(t *testing.T) {
+
+	backend := &Backend{Name: "test"}
+
+	// An idle backend drains immediately and refuses new operations until reopened
+	assert.NoError(t, backend.Drain(time.Second))
+	_, err := backend.beginOperation()
+	assert.Error(t, err)
+	backend.Reopen()
+
+	done, err := backend.beginOperation()
+	assert.NoError(t, err)
+
+	// A running operation that doesn't finish in time fails the drain and reopens the backend
+	assert.Error(t, backend.Drain(10*time.Millisecond))
+	again, err := backend.beginOperation()
+	assert.NoError(t, err)
+	again()
+
+	// The drain finishes when the running operation does
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		done()
+		done()
+	}()
+	assert.NoError(t, backend.Drain(time.Second))
+	_, err = backend.beginOperation()
+	assert.Error(t, err)
+}
+
+func TestBackendFence(t *testing.T) {
+
+	backend := &Backend{Name: "test"}
+
+	done, err := backend.beginOperation()
+	assert.NoError(t, err)
+
+	// Fencing doesn't wait for running operations, but refuses new ones and a second fence
+	assert.NoError(t, backend.Fence())
+	_, err = backend.beginOperation()
+	assert.Error(t, err)
+	assert.Error(t, backend.Fence())
+
+	// A fenced backend drains once its running operations finish
+	done()
+	assert.NoError(t, backend.Drain(time.Second))
+
+	backend.Reopen()
+	assert.NoError(t, backend.Fence())
+}
+
+func TestOperationGate(t *testing.T) {
+
+	ctx := context.Background()
+	gate := NewOperationGate(map[string]int{OperationCreate: 1, OperationClone: 0})
+
+	// Unlimited operations, and any operation on a nil gate, are never held
+	for i := 0; i < 3; i++ {
+		_, err := gate.acquire(ctx, OperationClone, "vol1", time.Millisecond)
+		assert.NoError(t, err)
+		_, err = (*OperationGate)(nil).acquire(ctx, OperationCreate, "vol1", time.Millisecond)
+		assert.NoError(t, err)
+	}
+
+	// A limited operation waits for a slot, failing if none frees up in time
+	finish, err := gate.acquire(ctx, OperationCreate, "vol1", time.Millisecond)
+	assert.NoError(t, err)
+	_, err = gate.acquire(ctx, OperationCreate, "vol2", 10*time.Millisecond)
+	assert.Error(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		finish(false)
+	}()
+	finish, err = gate.acquire(ctx, OperationCreate, "vol2", time.Second)
+	assert.NoError(t, err)
+
+	// A cancelled context stops the wait
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = gate.acquire(cancelled, OperationCreate, "vol1", time.Second)
+	assert.Error(t, err)
+
+	// An operation still running on the storage keeps its slot for later calls on its volume
+	finish(true)
+	_, err = gate.acquire(ctx, OperationCreate, "vol1", time.Millisecond)
+	assert.Error(t, err, "slot of running operation taken")
+	finish, err = gate.acquire(ctx, OperationCreate, "vol2", time.Millisecond)
+	assert.NoError(t, err, "running operation not readmitted")
+	finish(false)
+	finish, err = gate.acquire(ctx, OperationCreate, "vol1", time.Millisecond)
+	assert.NoError(t, err)
+
+	// Deleting the volume of a running operation frees its slot
+	finish(true)
+	gate.forget("vol1")
+	_, err = gate.acquire(ctx, OperationCreate, "vol3", time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestPoolCapacityHasHeadroom(t *testing.T) {
+
+	capacity := &PoolCapacity{TotalBytes: 1000, FreeBytes: 300}
+
+	assert.True(t, capacity.HasHeadroom(100, 0))
+	assert.True(t, capacity.HasHeadroom(100, 20))
+	assert.False(t, capacity.HasHeadroom(150, 20), "volume would leave less than 20% free")
+	assert.False(t, capacity.HasHeadroom(400, 0), "volume larger than the free space")
+
+	// Without a total, only the free space is checked
+	capacity = &PoolCapacity{FreeBytes: 300}
+	assert.True(t, capacity.HasHeadroom(300, 50))
+}
+
+func TestPoolTopology(t *testing.T) {
+
+	pool := NewStoragePool(nil, "pool1")
+	assert.Empty(t, pool.Topology())
+	assert.True(t, pool.MatchesTopologies([]map[string]string{{TopologyZoneKey: "z1"}}),
+		"pools without a topology are accessible from anywhere")
+
+	pool.Attributes[sa.Region] = sa.NewStringOffer("r1")
+	pool.Attributes[sa.Zone] = sa.NewStringOffer("z1")
+	assert.Equal(t, map[string]string{TopologyRegionKey: "r1", TopologyZoneKey: "z1"}, pool.Topology())
+
+	assert.True(t, pool.MatchesTopologies(nil))
+	assert.True(t, pool.MatchesTopologies([]map[string]string{{TopologyRegionKey: "r1"}}))
+	assert.True(t, pool.MatchesTopologies([]map[string]string{
+		{TopologyZoneKey: "z2"},
+		{TopologyRegionKey: "r1", TopologyZoneKey: "z1", "kubernetes.io/hostname": "node1"},
+	}))
+	assert.False(t, pool.MatchesTopologies([]map[string]string{{TopologyZoneKey: "z2"}}))
+	assert.False(t, pool.MatchesTopologies([]map[string]string{{TopologyRegionKey: "r2", TopologyZoneKey: "z1"}}))
+
+	preferred := []map[string]string{{TopologyZoneKey: "z2"}, {TopologyZoneKey: "z1"}}
+	assert.Equal(t, 1, pool.TopologyPreference(preferred))
+	assert.Equal(t, 1, pool.TopologyPreference(preferred[:1]))
+	assert.Equal(t, 0, pool.TopologyPreference(nil))
+}
+
+func TestUpdateBackendLoggingRequestValidate(t *testing.T) {
+
+	assert.Error(t, (&UpdateBackendLoggingRequest{}).Validate())
+	assert.Error(t, (&UpdateBackendLoggingRequest{LogLevel: "loud"}).Validate())
+
+	assert.NoError(t, (&UpdateBackendLoggingRequest{LogLevel: "debug"}).Validate())
+	assert.NoError(t, (&UpdateBackendLoggingRequest{LogLevel: DefaultLogLevel}).Validate())
+
+	// An empty set of flags turns them all off
+	assert.NoError(t, (&UpdateBackendLoggingRequest{DebugTraceFlags: map[string]bool{}}).Validate())
+}
+
+func TestBackendLogContext(t *testing.T) {
+
+	backend := &Backend{Name: "test"}
+
+	ctx := backend.logContext(context.Background(), "create", "pvc-1")
+	assert.Equal(t, "test", utils.GetLogFields(ctx)[utils.LogFieldBackend])
+	assert.Equal(t, "create", utils.GetLogFields(ctx)[utils.LogFieldOperation])
+	assert.Equal(t, "pvc-1", utils.GetLogFields(ctx)[utils.LogFieldVolume])
+
+	ctx = backend.logContext(context.Background(), "createSnapshot", "")
+	_, ok := utils.GetLogFields(ctx)[utils.LogFieldVolume]
+	assert.False(t, ok)
+}
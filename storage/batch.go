@@ -0,0 +1,29 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+// BatchIntent records the writes of a persistent store batch before any of them are made, so that a batch
+// interrupted by a restart, or by a failed write, may be finished later.
+type BatchIntent struct {
+	Name    string       `json:"name"`
+	Entries []BatchEntry `json:"entries"`
+}
+
+type BatchOperation string
+
+const (
+	BatchPutVolume               BatchOperation = "putVolume"
+	BatchDeleteVolume            BatchOperation = "deleteVolume"
+	BatchPutSnapshot             BatchOperation = "putSnapshot"
+	BatchDeleteSnapshot          BatchOperation = "deleteSnapshot"
+	BatchPutVolumeTransaction    BatchOperation = "putVolumeTransaction"
+	BatchDeleteVolumeTransaction BatchOperation = "deleteVolumeTransaction"
+)
+
+// BatchEntry is a single write in a persistent store batch.  Only the object written by the operation is set.
+type BatchEntry struct {
+	Op          BatchOperation      `json:"op"`
+	Volume      *VolumeExternal     `json:"volume,omitempty"`
+	Snapshot    *SnapshotPersistent `json:"snapshot,omitempty"`
+	Transaction *VolumeTransaction  `json:"transaction,omitempty"`
+}
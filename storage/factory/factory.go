@@ -19,8 +19,10 @@ import (
 	"github.com/netapp/trident/storage_drivers/azure"
 	"github.com/netapp/trident/storage_drivers/eseries"
 	"github.com/netapp/trident/storage_drivers/fake"
+	"github.com/netapp/trident/storage_drivers/fakeontap"
 	"github.com/netapp/trident/storage_drivers/ontap"
 	"github.com/netapp/trident/storage_drivers/solidfire"
+	"github.com/netapp/trident/utils"
 )
 
 func NewStorageBackendForConfig(configJSON string) (sb *storage.Backend, err error) {
@@ -44,6 +46,12 @@ func NewStorageBackendForConfig(configJSON string) (sb *storage.Backend, err err
 	}
 	configJSON = string(configJSONBytes)
 
+	// Upgrade configs written for older schema versions
+	if configJSON, _, err = drivers.MigrateConfig(configJSON); err != nil {
+		err = fmt.Errorf("input failed validation: %v", err)
+		return nil, err
+	}
+
 	// Parse the common config struct from JSON
 	commonConfig, err := drivers.ValidateCommonSettings(configJSON)
 	if err != nil {
@@ -75,11 +83,18 @@ func NewStorageBackendForConfig(configJSON string) (sb *storage.Backend, err err
 		storageDriver = &gcp.NFSStorageDriver{}
 	case drivers.FakeStorageDriverName:
 		storageDriver = &fake.StorageDriver{}
+	case drivers.FakeOntapStorageDriverName:
+		storageDriver = &fakeontap.StorageDriver{}
 	default:
 		err = fmt.Errorf("unknown storage driver: %v", commonConfig.StorageDriverName)
 		return nil, err
 	}
 
+	// An ONTAP backend may span several SVMs, each of which is managed by its own driver
+	if _, ok := storageDriver.(ontap.StorageDriver); ok && ontap.UsesMultipleSVMs(configJSON) {
+		storageDriver = ontap.NewMultiSVMStorageDriver(commonConfig.StorageDriverName)
+	}
+
 	log.WithField("driver", commonConfig.StorageDriverName).Debug("Initializing storage driver.")
 
 	// Initialize the driver.  If this fails, return a 'failed' backend object.
@@ -105,6 +120,53 @@ func NewStorageBackendForConfig(configJSON string) (sb *storage.Backend, err err
 	}
 
 	sb.State = storage.Online
+	sb.SetOperationGate(storage.NewOperationGate(map[string]int{
+		storage.OperationCreate: commonConfig.MaxConcurrentCreates,
+		storage.OperationClone:  commonConfig.MaxConcurrentClones,
+	}))
 
 	return sb, err
 }
+
+// PreviewStorageBackendForConfig resolves the storage pools that a backend would offer with the supplied
+// configuration, without creating the backend.  Only the ONTAP drivers support previews.
+func PreviewStorageBackendForConfig(configJSON string) (preview *storage.BackendPreview, err error) {
+
+	// Some drivers may panic during initialize if given invalid parameters,
+	// so catch any panics that might occur and return an error.
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithField("Stack trace", string(debug.Stack())).Error("unable to preview backend")
+			err = fmt.Errorf("unable to preview backend: %v", r)
+		}
+	}()
+
+	// Convert config (JSON or YAML) to JSON
+	configJSONBytes, err := yaml.YAMLToJSON([]byte(configJSON))
+	if err != nil {
+		return nil, fmt.Errorf("invalid config format: %v", err)
+	}
+	configJSON = string(configJSONBytes)
+
+	// Upgrade configs written for older schema versions
+	if configJSON, _, err = drivers.MigrateConfig(configJSON); err != nil {
+		err = fmt.Errorf("input failed validation: %v", err)
+		return nil, err
+	}
+
+	// Parse the common config struct from JSON
+	commonConfig, err := drivers.ValidateCommonSettings(configJSON)
+	if err != nil {
+		return nil, fmt.Errorf("input failed validation: %v", err)
+	}
+
+	switch commonConfig.StorageDriverName {
+	case drivers.OntapNASStorageDriverName, drivers.OntapNASFlexGroupStorageDriverName,
+		drivers.OntapNASQtreeStorageDriverName, drivers.OntapSANStorageDriverName,
+		drivers.OntapSANEconomyStorageDriverName:
+		return ontap.PreviewStoragePools(config.CurrentDriverContext, configJSON, commonConfig)
+	default:
+		return nil, utils.UnsupportedError(fmt.Sprintf("storage driver %s does not support previews",
+			commonConfig.StorageDriverName))
+	}
+}
@@ -37,3 +37,28 @@ func TestInitializeRecovery(t *testing.T) {
 		t.Error("Failed to get error for invalid configuration.")
 	}
 }
+
+func TestFakeOntapBackend(t *testing.T) {
+	configJSON := `{"version": 1, "storageDriverName": "fake-ontap", "svm": "factory",
+		"aggregates": [{"name": "aggr1", "sizeBytes": 1073741824}]}`
+
+	backend, err := NewStorageBackendForConfig(configJSON)
+	if err != nil {
+		t.Fatalf("Could not create fake-ontap backend: %v", err)
+	}
+	if backend.GetDriverName() != drivers.FakeOntapStorageDriverName {
+		t.Errorf("Expected driver %s, got %s.", drivers.FakeOntapStorageDriverName, backend.GetDriverName())
+	}
+	if backend.Name != "fake-ontap_factory" {
+		t.Errorf("Unexpected backend name %s.", backend.Name)
+	}
+
+	// The persisted config must recreate the same backend
+	persistentConfig, err := backend.ConstructPersistent().MarshalConfig()
+	if err != nil {
+		t.Fatalf("Could not marshal backend config: %v", err)
+	}
+	if _, err = NewStorageBackendForConfig(persistentConfig); err != nil {
+		t.Errorf("Could not recreate fake-ontap backend: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	tridentconfig "github.com/netapp/trident/config"
+)
+
+// Names of the backend operations reported via the backend metrics
+const (
+	opVolumeCreate    = "volume_create"
+	opVolumeClone     = "volume_clone"
+	opVolumeImport    = "volume_import"
+	opVolumeResize    = "volume_resize"
+	opVolumePublish   = "volume_publish"
+	opVolumeDelete    = "volume_delete"
+	opSnapshotCreate  = "snapshot_create"
+	opSnapshotRestore = "snapshot_restore"
+	opSnapshotDelete  = "snapshot_delete"
+)
+
+var (
+	backendOpsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "backend",
+			Name:      "ops_total",
+			Help:      "The total number of backend operations",
+		},
+		[]string{"backend", "driver", "op"},
+	)
+
+	backendOpsFailedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "backend",
+			Name:      "ops_failed_total",
+			Help:      "The total number of failed backend operations",
+		},
+		[]string{"backend", "driver", "op"},
+	)
+
+	// Backend operations range from LUN maps taking milliseconds to FlexGroup clones taking minutes
+	backendOpsDurationSecondsHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "backend",
+			Name:      "operation_duration_seconds",
+			Help:      "The duration of backend operations",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 16),
+		},
+		[]string{"backend", "driver", "op"},
+	)
+)
+
+// observeOperation records the outcome and latency of an operation on the backend.  It is intended
+// to be deferred at the top of a Backend method that uses a named error return.
+func (b *Backend) observeOperation(op string, start time.Time, err *error) {
+
+	backendName := b.Name
+	driverName := ""
+	if b.Driver != nil {
+		driverName = b.Driver.Name()
+	}
+
+	backendOpsTotal.WithLabelValues(backendName, driverName, op).Inc()
+	if *err != nil {
+		backendOpsFailedTotal.WithLabelValues(backendName, driverName, op).Inc()
+	}
+	backendOpsDurationSecondsHistogram.WithLabelValues(backendName, driverName, op).
+		Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveOperation(t *testing.T) {
+
+	backend := &Backend{Name: "metricsBackend"}
+
+	succeed := func() (err error) {
+		defer backend.observeOperation(opVolumeCreate, time.Now(), &err)
+		return nil
+	}
+	fail := func() (err error) {
+		defer backend.observeOperation(opVolumeCreate, time.Now(), &err)
+		return errors.New("failed")
+	}
+
+	assert.Nil(t, succeed())
+	assert.NotNil(t, fail())
+	assert.NotNil(t, fail())
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(
+		backendOpsTotal.WithLabelValues("metricsBackend", "", opVolumeCreate)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		backendOpsFailedTotal.WithLabelValues("metricsBackend", "", opVolumeCreate)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(
+		backendOpsTotal.WithLabelValues("metricsBackend", "", opVolumeDelete)))
+}
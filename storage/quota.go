@@ -0,0 +1,123 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/netapp/trident/utils"
+)
+
+type QuotaScope string
+
+const (
+	QuotaScopeNamespace    = QuotaScope("namespace")
+	QuotaScopeStorageClass = QuotaScope("storageClass")
+	QuotaScopeBackend      = QuotaScope("backend")
+)
+
+// Quota caps the total size and number of the volumes provisioned in a Kubernetes namespace, with a
+// storage class, or on a backend.  A zero limit is no limit.
+type Quota struct {
+	Name       string     `json:"name"`
+	Scope      QuotaScope `json:"scope"`
+	Target     string     `json:"target"`
+	MaxSize    string     `json:"maxSize,omitempty"`
+	MaxVolumes int        `json:"maxVolumes,omitempty"`
+}
+
+// QuotaExternal is a quota along with how much of it the existing volumes use.
+type QuotaExternal struct {
+	Quota
+	MaxBytes    uint64 `json:"maxBytes,omitempty"`
+	UsedBytes   uint64 `json:"usedBytes"`
+	UsedVolumes int    `json:"usedVolumes"`
+}
+
+// Validate checks that a quota names its scope and target and sets at least one valid limit.
+func (q *Quota) Validate() error {
+
+	if q.Name == "" {
+		return fmt.Errorf("quota name missing")
+	}
+	switch q.Scope {
+	case QuotaScopeNamespace, QuotaScopeStorageClass, QuotaScopeBackend:
+	default:
+		return fmt.Errorf("invalid scope '%s' for quota %s, must be %s, %s, or %s", q.Scope, q.Name,
+			QuotaScopeNamespace, QuotaScopeStorageClass, QuotaScopeBackend)
+	}
+	if q.Target == "" {
+		return fmt.Errorf("quota %s names no %s", q.Name, q.Scope)
+	}
+	if q.MaxVolumes < 0 {
+		return fmt.Errorf("invalid maxVolumes %d for quota %s", q.MaxVolumes, q.Name)
+	}
+	if _, err := q.MaxBytes(); err != nil {
+		return err
+	}
+	if q.MaxSize == "" && q.MaxVolumes == 0 {
+		return fmt.Errorf("quota %s sets neither maxSize nor maxVolumes", q.Name)
+	}
+	return nil
+}
+
+// MaxBytes returns the quota's size limit in bytes, or zero if it has none.
+func (q *Quota) MaxBytes() (uint64, error) {
+	if q.MaxSize == "" {
+		return 0, nil
+	}
+	sizeBytesStr, err := utils.ConvertSizeToBytes(q.MaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxSize '%s' for quota %s; %v", q.MaxSize, q.Name, err)
+	}
+	sizeBytes, err := strconv.ParseUint(sizeBytesStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxSize '%s' for quota %s; %v", q.MaxSize, q.Name, err)
+	}
+	return sizeBytes, nil
+}
+
+// Applies reports whether the quota covers a volume with the given config, placed on the named backend.
+// The backend may be empty if the volume hasn't been placed yet, in which case backend quotas don't apply.
+func (q *Quota) Applies(volConfig *VolumeConfig, backendName string) bool {
+	switch q.Scope {
+	case QuotaScopeNamespace:
+		return volConfig.Namespace == q.Target
+	case QuotaScopeStorageClass:
+		return volConfig.StorageClass == q.Target
+	case QuotaScopeBackend:
+		return backendName != "" && backendName == q.Target
+	}
+	return false
+}
+
+// ConstructExternal reports the quota's usage, given the total size and number of the volumes it covers.
+func (q *Quota) ConstructExternal(usedBytes uint64, usedVolumes int) *QuotaExternal {
+	maxBytes, _ := q.MaxBytes()
+	return &QuotaExternal{
+		Quota:       *q,
+		MaxBytes:    maxBytes,
+		UsedBytes:   usedBytes,
+		UsedVolumes: usedVolumes,
+	}
+}
+
+// Check returns a QuotaExceededError if adding a volume of the given size to the volumes the quota
+// already covers would exceed either of its limits.
+func (q *Quota) Check(usedBytes uint64, usedVolumes int, sizeBytes uint64) error {
+
+	if q.MaxVolumes > 0 && usedVolumes+1 > q.MaxVolumes {
+		return utils.QuotaExceededError(fmt.Sprintf("quota %s allows %d volumes in %s %s, which has %d",
+			q.Name, q.MaxVolumes, q.Scope, q.Target, usedVolumes))
+	}
+	maxBytes, err := q.MaxBytes()
+	if err != nil {
+		return err
+	}
+	if maxBytes > 0 && usedBytes+sizeBytes > maxBytes {
+		return utils.QuotaExceededError(fmt.Sprintf("quota %s allows %d bytes in %s %s, which has %d bytes "+
+			"provisioned, too few for %d more", q.Name, maxBytes, q.Scope, q.Target, usedBytes, sizeBytes))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/utils"
+)
+
+func TestQuotaValidate(t *testing.T) {
+
+	tests := map[string]struct {
+		quota Quota
+		valid bool
+	}{
+		"Namespace size": {
+			quota: Quota{Name: "q", Scope: QuotaScopeNamespace, Target: "ns", MaxSize: "1Ti"},
+			valid: true,
+		},
+		"Backend volumes": {
+			quota: Quota{Name: "q", Scope: QuotaScopeBackend, Target: "b", MaxVolumes: 10},
+			valid: true,
+		},
+		"No name": {
+			quota: Quota{Scope: QuotaScopeNamespace, Target: "ns", MaxVolumes: 10},
+		},
+		"Bad scope": {
+			quota: Quota{Name: "q", Scope: "pool", Target: "p", MaxVolumes: 10},
+		},
+		"No target": {
+			quota: Quota{Name: "q", Scope: QuotaScopeStorageClass, MaxVolumes: 10},
+		},
+		"No limit": {
+			quota: Quota{Name: "q", Scope: QuotaScopeNamespace, Target: "ns"},
+		},
+		"Bad size": {
+			quota: Quota{Name: "q", Scope: QuotaScopeNamespace, Target: "ns", MaxSize: "lots"},
+		},
+		"Negative volumes": {
+			quota: Quota{Name: "q", Scope: QuotaScopeNamespace, Target: "ns", MaxVolumes: -1},
+		},
+	}
+	for name, test := range tests {
+		err := test.quota.Validate()
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+	}
+}
+
+func TestQuotaCheck(t *testing.T) {
+
+	quota := &Quota{Name: "q", Scope: QuotaScopeNamespace, Target: "ns", MaxSize: "1000", MaxVolumes: 3}
+
+	assert.True(t, quota.Applies(&VolumeConfig{Namespace: "ns"}, "backend"))
+	assert.False(t, quota.Applies(&VolumeConfig{Namespace: "other"}, "backend"))
+
+	assert.NoError(t, quota.Check(500, 2, 500))
+	assert.True(t, utils.IsQuotaExceededError(quota.Check(500, 2, 501)), "size limit not enforced")
+	assert.True(t, utils.IsQuotaExceededError(quota.Check(0, 3, 1)), "volume limit not enforced")
+
+	backendQuota := &Quota{Name: "b", Scope: QuotaScopeBackend, Target: "backend", MaxVolumes: 1}
+	assert.True(t, backendQuota.Applies(&VolumeConfig{}, "backend"))
+	assert.False(t, backendQuota.Applies(&VolumeConfig{}, ""), "backend quota applied to unplaced volume")
+}
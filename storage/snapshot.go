@@ -18,6 +18,8 @@ type SnapshotConfig struct {
 	InternalName       string `json:"internalName,omitempty"`
 	VolumeName         string `json:"volumeName,omitempty"`
 	VolumeInternalName string `json:"volumeInternalName,omitempty"`
+	// Parameters are driver-specific options for the snapshot, such as those of a VolumeSnapshotClass.
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 func (c *SnapshotConfig) ID() string {
@@ -31,11 +33,63 @@ func (c *SnapshotConfig) Validate() error {
 	return nil
 }
 
+// GroupSnapshotConfig describes a set of snapshots, one of each named volume, taken at the same
+// instant so that together they are crash-consistent.  Each snapshot takes the group's name.
+type GroupSnapshotConfig struct {
+	Version     string   `json:"version,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	VolumeNames []string `json:"volumeNames,omitempty"`
+}
+
+func (c *GroupSnapshotConfig) Validate() error {
+	if c.Name == "" || len(c.VolumeNames) == 0 {
+		return fmt.Errorf("the following fields for \"GroupSnapshot\" are mandatory: name and volumeNames")
+	}
+	seen := make(map[string]bool, len(c.VolumeNames))
+	for _, volumeName := range c.VolumeNames {
+		if seen[volumeName] {
+			return fmt.Errorf("volume %s appears more than once in group snapshot %s", volumeName, c.Name)
+		}
+		seen[volumeName] = true
+	}
+	return nil
+}
+
+// SnapshotConfigs returns the config of each snapshot in the group.
+func (c *GroupSnapshotConfig) SnapshotConfigs() []*SnapshotConfig {
+	snapConfigs := make([]*SnapshotConfig, 0, len(c.VolumeNames))
+	for _, volumeName := range c.VolumeNames {
+		snapConfigs = append(snapConfigs, &SnapshotConfig{
+			Version:    c.Version,
+			Name:       c.Name,
+			VolumeName: volumeName,
+		})
+	}
+	return snapConfigs
+}
+
 type Snapshot struct {
 	Config    *SnapshotConfig
 	Created   string `json:"dateCreated"` // The UTC time that the snapshot was created, in RFC3339 format
 	SizeBytes int64  `json:"size"`        // The size of the volume at the time the snapshot was created
-	State     SnapshotState
+	// The space held only by the snapshot, and the snapshot space of its volume, if the backend reports them
+	UsedBytes     int64            `json:"usedBytes,omitempty"`
+	VolumeReserve *SnapshotReserve `json:"volumeReserve,omitempty"`
+	State         SnapshotState
+}
+
+// SnapshotReserve describes how a volume's snapshots use the space reserved for them.  Snapshots needing more
+// space than is reserved spill into the space for the volume's data.
+type SnapshotReserve struct {
+	ReserveBytes int64 `json:"reserveBytes"` // The space reserved for snapshots
+	UsedBytes    int64 `json:"usedBytes"`    // The space used by all of the volume's snapshots
+	SpillBytes   int64 `json:"spillBytes"`   // The space used by snapshots beyond the reserve
+	Count        int   `json:"count"`        // The number of snapshots of the volume
+}
+
+// Spilled returns whether the volume's snapshots use more space than is reserved for them.
+func (r *SnapshotReserve) Spilled() bool {
+	return r != nil && r.SpillBytes > 0
 }
 
 type SnapshotState string
@@ -44,6 +98,8 @@ const (
 	SnapshotStateOnline         = SnapshotState("online")
 	SnapshotStateMissingBackend = SnapshotState("missing_backend")
 	SnapshotStateMissingVolume  = SnapshotState("missing_volume")
+	SnapshotStateDeleting       = SnapshotState("deleting")
+	SnapshotStateDeleteFailed   = SnapshotState("delete_failed")
 )
 
 func (s SnapshotState) IsOnline() bool {
@@ -58,6 +114,14 @@ func (s SnapshotState) IsMissingVolume() bool {
 	return s == SnapshotStateMissingVolume
 }
 
+func (s SnapshotState) IsDeleting() bool {
+	return s == SnapshotStateDeleting
+}
+
+func (s SnapshotState) IsDeleteFailed() bool {
+	return s == SnapshotStateDeleteFailed
+}
+
 type SnapshotExternal struct {
 	Snapshot
 }
@@ -84,8 +148,12 @@ func (s *Snapshot) ConstructExternal() *SnapshotExternal {
 	return &SnapshotExternal{Snapshot: *clone}
 }
 
+// ConstructPersistent returns the snapshot as it is stored.  Space usage changes as the volume is written,
+// so it is read from the backend when needed rather than stored.
 func (s *Snapshot) ConstructPersistent() *SnapshotPersistent {
 	clone := s.ConstructClone()
+	clone.UsedBytes = 0
+	clone.VolumeReserve = nil
 	return &SnapshotPersistent{Snapshot: *clone}
 }
 
@@ -98,10 +166,20 @@ func (s *Snapshot) ConstructClone() *Snapshot {
 			VolumeName:         s.Config.VolumeName,
 			VolumeInternalName: s.Config.VolumeInternalName,
 		},
-		Created:   s.Created,
-		SizeBytes: s.SizeBytes,
-		State:     s.State,
+		Created:       s.Created,
+		SizeBytes:     s.SizeBytes,
+		UsedBytes:     s.UsedBytes,
+		VolumeReserve: s.VolumeReserve.clone(),
+		State:         s.State,
+	}
+}
+
+func (r *SnapshotReserve) clone() *SnapshotReserve {
+	if r == nil {
+		return nil
 	}
+	clone := *r
+	return &clone
 }
 
 func (s *Snapshot) ID() string {
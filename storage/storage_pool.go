@@ -4,10 +4,18 @@ package storage
 
 import (
 	"sort"
+	"sync"
+	"time"
 
 	sa "github.com/netapp/trident/storage_attribute"
 )
 
+const (
+	// Well-known Kubernetes node labels naming the region and zone of the topology of a pool
+	TopologyRegionKey = "topology.kubernetes.io/region"
+	TopologyZoneKey   = "topology.kubernetes.io/zone"
+)
+
 type Pool struct {
 	Name string
 	// A Trident storage pool can potentially satisfy more than one storage class.
@@ -15,6 +23,29 @@ type Pool struct {
 	Backend            *Backend
 	Attributes         map[string]sa.Offer // These attributes are used to match storage classes
 	InternalAttributes map[string]string   // These attributes are defined & used internally by storage drivers
+
+	// The pool's capacity is refreshed by its driver in the background, so it has its own lock
+	capacity      *PoolCapacity
+	capacityMutex sync.RWMutex
+}
+
+// PoolCapacity is the space in a storage pool, as last reported by the pool's driver.  A total of zero
+// means the driver could only determine how much space is free.
+type PoolCapacity struct {
+	TotalBytes       uint64    `json:"totalBytes"`
+	FreeBytes        uint64    `json:"freeBytes"`
+	ProvisionedBytes uint64    `json:"provisionedBytes"`
+	Refreshed        time.Time `json:"refreshed"`
+}
+
+// HasHeadroom reports whether a volume of the given size would fit in the free space while leaving
+// the given percentage of the total capacity free.
+func (c *PoolCapacity) HasHeadroom(sizeBytes uint64, headroomPercent int) bool {
+	if sizeBytes > c.FreeBytes {
+		return false
+	}
+	reserveBytes := c.TotalBytes / 100 * uint64(headroomPercent)
+	return c.FreeBytes-sizeBytes >= reserveBytes
 }
 
 func NewStoragePool(backend *Backend, name string) *Pool {
@@ -27,6 +58,76 @@ func NewStoragePool(backend *Backend, name string) *Pool {
 	}
 }
 
+// SetCapacity records the pool's most recently discovered capacity.
+func (pool *Pool) SetCapacity(capacity PoolCapacity) {
+	pool.capacityMutex.Lock()
+	defer pool.capacityMutex.Unlock()
+	pool.capacity = &capacity
+}
+
+// Capacity returns a copy of the pool's most recently discovered capacity, or nil if the pool's
+// driver doesn't report capacity or hasn't yet discovered it.
+func (pool *Pool) Capacity() *PoolCapacity {
+	pool.capacityMutex.RLock()
+	defer pool.capacityMutex.RUnlock()
+	if pool.capacity == nil {
+		return nil
+	}
+	capacity := *pool.capacity
+	return &capacity
+}
+
+// Topology returns the region and zone of the pool as a topology segment, keyed by the well-known
+// Kubernetes topology labels.  A pool with neither a region nor a zone has no topology, and so is
+// accessible from anywhere.
+func (pool *Pool) Topology() map[string]string {
+	topology := make(map[string]string)
+	if offer, ok := pool.Attributes[sa.Region]; ok && offer.ToString() != "" {
+		topology[TopologyRegionKey] = offer.ToString()
+	}
+	if offer, ok := pool.Attributes[sa.Zone]; ok && offer.ToString() != "" {
+		topology[TopologyZoneKey] = offer.ToString()
+	}
+	return topology
+}
+
+// MatchesTopology reports whether the pool is accessible from a topology segment.  Only the region
+// and zone of the segment are considered, and a pool matches any segment that doesn't name them.
+func (pool *Pool) MatchesTopology(segment map[string]string) bool {
+	for key, value := range pool.Topology() {
+		if segmentValue, ok := segment[key]; ok && segmentValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesTopologies reports whether the pool is accessible from any of a set of topology segments.
+// Every pool matches an empty set.
+func (pool *Pool) MatchesTopologies(segments []map[string]string) bool {
+	if len(segments) == 0 {
+		return true
+	}
+	for _, segment := range segments {
+		if pool.MatchesTopology(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// TopologyPreference returns the index of the first of a list of preferred topology segments from which
+// the pool is accessible, or the length of the list if it is accessible from none of them.  Pools with
+// lower values are preferred.
+func (pool *Pool) TopologyPreference(preferred []map[string]string) int {
+	for i, segment := range preferred {
+		if pool.MatchesTopology(segment) {
+			return i
+		}
+	}
+	return len(preferred)
+}
+
 func (pool *Pool) AddStorageClass(class string) {
 	// Note that this function should get called once per storage class
 	// affecting the volume; thus, we don't need to check for duplicates.
@@ -51,6 +152,7 @@ type PoolExternal struct {
 	StorageClasses []string `json:"storageClasses"`
 	//TODO: can't have an interface here for unmarshalling
 	Attributes map[string]sa.Offer `json:"storageAttributes"`
+	Capacity   *PoolCapacity       `json:"capacity,omitempty"`
 }
 
 func (pool *Pool) ConstructExternal() *PoolExternal {
@@ -58,6 +160,7 @@ func (pool *Pool) ConstructExternal() *PoolExternal {
 		Name:           pool.Name,
 		StorageClasses: pool.StorageClasses,
 		Attributes:     pool.Attributes,
+		Capacity:       pool.Capacity(),
 	}
 
 	// We want to sort these so that the output remains consistent;
@@ -65,3 +168,28 @@ func (pool *Pool) ConstructExternal() *PoolExternal {
 	sort.Strings(external.StorageClasses)
 	return external
 }
+
+// PoolPreview describes a storage pool that a backend configuration would produce, including the
+// effective defaults with which the pool's volumes would be created.
+type PoolPreview struct {
+	Name       string            `json:"name"`
+	Virtual    bool              `json:"virtual"`
+	Attributes map[string]string `json:"storageAttributes"`
+	Defaults   map[string]string `json:"defaults"`
+}
+
+func (pool *Pool) ConstructPreview(virtual bool) *PoolPreview {
+	preview := &PoolPreview{
+		Name:       pool.Name,
+		Virtual:    virtual,
+		Attributes: make(map[string]string, len(pool.Attributes)),
+		Defaults:   make(map[string]string, len(pool.InternalAttributes)),
+	}
+	for name, offer := range pool.Attributes {
+		preview.Attributes[name] = offer.ToString()
+	}
+	for name, value := range pool.InternalAttributes {
+		preview.Defaults[name] = value
+	}
+	return preview
+}
@@ -5,6 +5,8 @@ package storage
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,15 +39,57 @@ type VolumeConfig struct {
 	CloneSourceVolume         string                 `json:"cloneSourceVolume"`
 	CloneSourceVolumeInternal string                 `json:"cloneSourceVolumeInternal"`
 	CloneSourceSnapshot       string                 `json:"cloneSourceSnapshot"`
+	CloneSourceNamespace      string                 `json:"cloneSourceNamespace,omitempty"`
 	SplitOnClone              string                 `json:"splitOnClone"`
 	QoS                       string                 `json:"qos,omitempty"`
 	QoSType                   string                 `json:"type,omitempty"`
 	ServiceLevel              string                 `json:"serviceLevel,omitempty"`
 	Network                   string                 `json:"network,omitempty"`
+	FlexcacheOrigin           string                 `json:"flexcacheOrigin,omitempty"`
 	ImportOriginalName        string                 `json:"importOriginalName,omitempty"`
 	ImportBackendUUID         string                 `json:"importBackendUUID,omitempty"`
 	ImportNotManaged          bool                   `json:"importNotManaged,omitempty"`
 	MountOptions              string                 `json:"mountOptions,omitempty"`
+	Namespace                 string                 `json:"namespace,omitempty"`
+	RequestName               string                 `json:"requestName,omitempty"`
+	// UUID identifies the request that created the volume, so that a create retried after a failure
+	// can recognize anything left behind by an earlier attempt
+	UUID string `json:"uuid,omitempty"`
+	// Topology segments from which the volume must be, and would preferably be, accessible
+	RequisiteTopologies []map[string]string `json:"requisiteTopologies,omitempty"`
+	PreferredTopologies []map[string]string `json:"preferredTopologies,omitempty"`
+	// Topology segments from which the volume is accessible, set from the pool it was created in
+	AllowedTopologies []map[string]string `json:"allowedTopologies,omitempty"`
+	// CreateJobID is set by drivers that create the volume using a storage system job that is still
+	// running, so that the job is persisted with the volume's VolumeCreating transaction
+	CreateJobID string `json:"createJobID,omitempty"`
+	// SVM is set by drivers managing several SVMs as one backend to the SVM holding the volume
+	SVM string `json:"svm,omitempty"`
+	// MoveDestinationPool is set while the volume is being moved to another physical pool of its backend,
+	// so that the move may be followed again after a restart
+	MoveDestinationPool string `json:"moveDestinationPool,omitempty"`
+	// CloneToNamespaces lists the namespaces, other than the volume's own, permitted to clone the volume,
+	// or holds CloneToAllNamespaces if any namespace may
+	CloneToNamespaces []string `json:"cloneToNamespaces,omitempty"`
+	// DataSource names the object from which a volume populator writes the volume's initial contents
+	DataSource *VolumeDataSource `json:"dataSource,omitempty"`
+}
+
+// VolumeDataSource refers to a Kubernetes object, other than a PVC or volume snapshot, from which a new
+// volume is populated.  The object is in the volume's namespace.
+type VolumeDataSource struct {
+	APIGroup string `json:"apiGroup,omitempty"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+}
+
+// GroupKind returns the kind of the data source qualified by its API group, such as
+// VolumeImage.example.com, which identifies the populator that handles it.
+func (s *VolumeDataSource) GroupKind() string {
+	if s.APIGroup == "" {
+		return s.Kind
+	}
+	return s.Kind + "." + s.APIGroup
 }
 
 type VolumeCreatingConfig struct {
@@ -68,6 +112,36 @@ func (c *VolumeConfig) Validate() error {
 	return nil
 }
 
+// CloneToAllNamespaces in a volume's CloneToNamespaces permits the volume to be cloned into any namespace.
+const CloneToAllNamespaces = "*"
+
+// ParseCloneToNamespaces splits a comma-separated list of namespaces permitted to clone a volume.
+func ParseCloneToNamespaces(namespaces string) []string {
+	var parsed []string
+	for _, namespace := range strings.Split(namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			parsed = append(parsed, namespace)
+		}
+	}
+	return parsed
+}
+
+// PermitsCloneTo reports whether a volume may be cloned into the given namespace.  A volume may always be
+// cloned into its own namespace, and by requests from outside any namespace, such as an administrator's.
+// A volume whose namespace isn't known, and isn't named by the clone request, may only be cloned into the
+// namespaces it explicitly permits.
+func (c *VolumeConfig) PermitsCloneTo(namespace string) bool {
+	if namespace == "" || namespace == c.Namespace {
+		return true
+	}
+	for _, permitted := range c.CloneToNamespaces {
+		if permitted == CloneToAllNamespaces || permitted == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *VolumeConfig) ConstructClone() *VolumeConfig {
 
 	clone, err := copystructure.Copy(*c)
@@ -89,6 +163,8 @@ type Volume struct {
 	Pool        string // Name of the pool on which this volume was first provisioned
 	Orphaned    bool   // An Orphaned volume isn't currently tracked by the storage backend
 	State       VolumeState
+	// PublishedNodes names the nodes to which the volume is published, in sorted order
+	PublishedNodes []string
 }
 
 type VolumeState string
@@ -99,12 +175,14 @@ const (
 	VolumeStateDeleting       = VolumeState("deleting")
 	VolumeStateUpgrading      = VolumeState("upgrading")
 	VolumeStateMissingBackend = VolumeState("missing_backend")
+	// VolumeStatePopulating is the state of a volume whose data source is still being written to it
+	VolumeStatePopulating = VolumeState("populating")
 	// TODO should Orphaned be moved to a VolumeState?
 )
 
 func (s VolumeState) String() string {
 	switch s {
-	case VolumeStateUnknown, VolumeStateOnline, VolumeStateDeleting:
+	case VolumeStateUnknown, VolumeStateOnline, VolumeStateDeleting, VolumeStatePopulating:
 		return string(s)
 	default:
 		return "unknown"
@@ -113,7 +191,7 @@ func (s VolumeState) String() string {
 
 func (s VolumeState) IsUnknown() bool {
 	switch s {
-	case VolumeStateOnline, VolumeStateDeleting:
+	case VolumeStateOnline, VolumeStateDeleting, VolumeStatePopulating:
 		return false
 	case VolumeStateUnknown:
 		return true
@@ -134,6 +212,10 @@ func (s VolumeState) IsMissingBackend() bool {
 	return s == VolumeStateMissingBackend
 }
 
+func (s VolumeState) IsPopulating() bool {
+	return s == VolumeStatePopulating
+}
+
 func NewVolume(conf *VolumeConfig, backendUUID string, pool string, orphaned bool) *Volume {
 	return &Volume{
 		Config:      conf,
@@ -151,6 +233,277 @@ type VolumeExternal struct {
 	Pool        string      `json:"pool"`
 	Orphaned    bool        `json:"orphaned"`
 	State       VolumeState `json:"state"`
+	// PublishedNodes names the nodes to which the volume is published
+	PublishedNodes []string `json:"publishedNodes,omitempty"`
+	// CloneSplit is reported live by drivers that split clones in the background; it is never persisted
+	CloneSplit *CloneSplitStatus `json:"cloneSplit,omitempty"`
+	// Move is reported live by drivers that move volumes between pools; it is never persisted
+	Move *VolumeMoveStatus `json:"move,omitempty"`
+	// Migration is reported by the orchestrator while the volume migrates to another backend and after
+	// the migration ends; it is never persisted
+	Migration *VolumeMigrationStatus `json:"migration,omitempty"`
+	// Usage is reported by drivers that track volume usage; it is never persisted
+	Usage *VolumeUsage `json:"usage,omitempty"`
+}
+
+// VolumeUsage describes the space and files a volume uses and the limits enforced on them, as last read
+// from the storage system.  A limit of zero means none is enforced.
+type VolumeUsage struct {
+	UsedBytes  int64  `json:"usedBytes"`
+	LimitBytes int64  `json:"limitBytes,omitempty"`
+	FilesUsed  int64  `json:"filesUsed"`
+	FilesLimit int64  `json:"filesLimit,omitempty"`
+	UpdateTime string `json:"updateTime"`
+}
+
+type CloneSplitState string
+
+const (
+	CloneSplitStateQueued   = CloneSplitState("queued")
+	CloneSplitStateRunning  = CloneSplitState("running")
+	CloneSplitStateComplete = CloneSplitState("complete")
+	CloneSplitStateFailed   = CloneSplitState("failed")
+)
+
+func (s CloneSplitState) IsDone() bool {
+	return s == CloneSplitStateComplete || s == CloneSplitStateFailed
+}
+
+// CloneSplitStatus describes the progress of splitting a cloned volume from its parent.
+type CloneSplitStatus struct {
+	State           CloneSplitState `json:"state"`
+	PercentComplete int             `json:"percentComplete"`
+	StartTime       string          `json:"startTime,omitempty"`
+	EndTime         string          `json:"endTime,omitempty"`
+	Message         string          `json:"message,omitempty"`
+}
+
+type VolumeMoveState string
+
+const (
+	VolumeMoveStateRunning  = VolumeMoveState("running")
+	VolumeMoveStateComplete = VolumeMoveState("complete")
+	VolumeMoveStateFailed   = VolumeMoveState("failed")
+)
+
+func (s VolumeMoveState) IsDone() bool {
+	return s == VolumeMoveStateComplete || s == VolumeMoveStateFailed
+}
+
+// VolumeMoveStatus describes the progress of moving a volume from one of its backend's pools to another.
+type VolumeMoveStatus struct {
+	State           VolumeMoveState `json:"state"`
+	SourcePool      string          `json:"sourcePool,omitempty"`
+	DestinationPool string          `json:"destinationPool"`
+	PercentComplete int             `json:"percentComplete"`
+	StartTime       string          `json:"startTime,omitempty"`
+	EndTime         string          `json:"endTime,omitempty"`
+	Message         string          `json:"message,omitempty"`
+}
+
+// StorageJob describes an operation a backend's storage system is running in the background on a
+// volume, such as a clone split or a volume move.
+type StorageJob struct {
+	Backend         string `json:"backend"`
+	Volume          string `json:"volume,omitempty"`
+	InternalVolume  string `json:"internalVolume"`
+	Operation       string `json:"operation"`
+	State           string `json:"state"`
+	PercentComplete int    `json:"percentComplete"`
+	JobID           string `json:"jobID,omitempty"`
+	StartTime       string `json:"startTime,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
+
+const (
+	StorageJobOperationCloneCreate     = "cloneCreate"
+	StorageJobOperationCloneSplit      = "cloneSplit"
+	StorageJobOperationVolumeMove      = "volumeMove"
+	StorageJobOperationFlexGroupExpand = "flexgroupExpand"
+	StorageJobOperationVolumeCopy      = "volumeCopy"
+)
+
+// StorageLogEntry is an entry of one of a backend's storage system logs, such as a job or an event.
+type StorageLogEntry struct {
+	Backend string `json:"backend"`
+	// Log names the storage system log holding the entry
+	Log  string `json:"log"`
+	Time string `json:"time,omitempty"`
+	// Name is the name of the job, or of the message logged with the event
+	Name string `json:"name"`
+	Node string `json:"node,omitempty"`
+	// Severity is the severity of an event, or the state of a job
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// VolumeCopySource describes where a volume's data may be copied from by a backend other than the
+// volume's own, so that a clone may be placed on a different backend than its source.  Format names
+// how the data is laid out on the storage system, and only a backend using the same format may copy it.
+type VolumeCopySource struct {
+	Format    string `json:"format"`
+	SVM       string `json:"svm"`
+	Volume    string `json:"volume"`
+	Snapshot  string `json:"snapshot,omitempty"`
+	UsedBytes uint64 `json:"usedBytes"`
+}
+
+// MoveVolumeRequest asks for a volume to be moved to another pool of the same backend.
+type MoveVolumeRequest struct {
+	Pool string `json:"pool"`
+}
+
+func (r *MoveVolumeRequest) Validate() error {
+	if r.Pool == "" {
+		return fmt.Errorf("the following field is mandatory: pool")
+	}
+	return nil
+}
+
+// MigrateVolumeRequest asks for a volume to be migrated to another backend.
+type MigrateVolumeRequest struct {
+	Backend string `json:"backend"`
+}
+
+func (r *MigrateVolumeRequest) Validate() error {
+	if r.Backend == "" {
+		return fmt.Errorf("the following field is mandatory: backend")
+	}
+	return nil
+}
+
+type VolumeMigrationState string
+
+const (
+	VolumeMigrationStateRunning  = VolumeMigrationState("running")
+	VolumeMigrationStateComplete = VolumeMigrationState("complete")
+	VolumeMigrationStateFailed   = VolumeMigrationState("failed")
+)
+
+// VolumeMigrationStatus describes the progress of a volume's migration to another backend.
+type VolumeMigrationStatus struct {
+	State              VolumeMigrationState `json:"state"`
+	Step               VolumeOperationStep  `json:"step,omitempty"`
+	SourceBackend      string               `json:"sourceBackend"`
+	DestinationBackend string               `json:"destinationBackend"`
+	StartTime          string               `json:"startTime,omitempty"`
+	EndTime            string               `json:"endTime,omitempty"`
+	Message            string               `json:"message,omitempty"`
+}
+
+// PublishVolumeRequest asks for a volume to be published to a node by Trident itself, for volumes that
+// the container orchestrator doesn't publish, such as CSI ephemeral volumes.
+type PublishVolumeRequest struct {
+	Node string `json:"node"`
+}
+
+func (r *PublishVolumeRequest) Validate() error {
+	if r.Node == "" {
+		return fmt.Errorf("the following field is mandatory: node")
+	}
+	return nil
+}
+
+// RecoverableVolume is a deleted volume that a backend keeps in its recovery queue until it expires.
+type RecoverableVolume struct {
+	InternalName   string `json:"internalName"`
+	QueuedName     string `json:"queuedName"`
+	ExpirationTime string `json:"expirationTime"`
+}
+
+// OrphanedResource is a resource that Trident created on a storage system, such as a volume or
+// snapshot, that no longer belongs to any volume, snapshot, or node known to Trident.
+type OrphanedResource struct {
+	Backend string `json:"backend"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	// Parent names the resource containing this one, such as the volume of a snapshot or LUN
+	Parent    string `json:"parent,omitempty"`
+	FirstSeen string `json:"firstSeen,omitempty"`
+}
+
+const (
+	OrphanedResourceVolume       = "volume"
+	OrphanedResourceLUN          = "lun"
+	OrphanedResourceSnapshot     = "snapshot"
+	OrphanedResourceExportPolicy = "exportPolicy"
+)
+
+// Key uniquely identifies the resource among those of every backend.
+func (r *OrphanedResource) Key() string {
+	return strings.Join([]string{r.Backend, r.Type, r.Parent, r.Name}, "/")
+}
+
+// OrphanPolicy says whether a backend's orphaned resources are deleted, and how long a resource must
+// have been seen to be orphaned before it is.
+type OrphanPolicy struct {
+	Delete      bool
+	GracePeriod time.Duration
+}
+
+// BackendVolume is a volume found on a backend's storage system that Trident may have created, whether
+// or not it belongs to a volume known to Trident.
+type BackendVolume struct {
+	Backend      string `json:"backend"`
+	InternalName string `json:"internalName"`
+	// Volume names the Trident volume stored in this one, if there is one
+	Volume string `json:"volume,omitempty"`
+	Size   string `json:"size"`
+	Pool   string `json:"pool"`
+	State  string `json:"state"`
+}
+
+// VolumePage is one page of the volumes on a backend's storage system.  Continue is passed when
+// listing the next page, and is empty after the last page.
+type VolumePage struct {
+	Volumes  []*BackendVolume `json:"volumes"`
+	Continue string           `json:"continue,omitempty"`
+}
+
+// VolumeBucket is a volume on a backend's storage system that holds several Trident volumes, such as a
+// Flexvol holding qtrees.
+type VolumeBucket struct {
+	Name string `json:"name"`
+	Pool string `json:"pool"`
+	Size string `json:"size"`
+	// Allocated is the sum of the sizes of the Trident volumes in the bucket
+	Allocated string `json:"allocated"`
+	Volumes   int    `json:"volumes"`
+	// DeletedVolumes counts the deleted volumes not yet reclaimed, which still count against the limit
+	DeletedVolumes int `json:"deletedVolumes"`
+}
+
+// BucketReport shows how a backend's volumes are distributed among the buckets holding them, so that
+// crowded or nearly empty buckets may be found and the volumes rebalanced.
+type BucketReport struct {
+	Backend string `json:"backend"`
+	// VolumeLimit is the most volumes the backend places in one bucket
+	VolumeLimit int             `json:"volumeLimit"`
+	Buckets     []*VolumeBucket `json:"buckets"`
+}
+
+// OverLimit returns the buckets holding more volumes than the limit, as happens when the limit is lowered.
+func (r *BucketReport) OverLimit() []*VolumeBucket {
+	buckets := make([]*VolumeBucket, 0)
+	for _, bucket := range r.Buckets {
+		if bucket.Volumes+bucket.DeletedVolumes > r.VolumeLimit {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// MinimumBuckets returns the fewest buckets that could hold the volumes within the limit, if every bucket
+// could hold any volume.
+func (r *BucketReport) MinimumBuckets() int {
+	if r.VolumeLimit <= 0 {
+		return 0
+	}
+	volumes := 0
+	for _, bucket := range r.Buckets {
+		volumes += bucket.Volumes
+	}
+	return (volumes + r.VolumeLimit - 1) / r.VolumeLimit
 }
 
 func (v *VolumeExternal) GetCHAPSecretName() string {
@@ -163,14 +516,46 @@ func (v *VolumeExternal) GetCHAPSecretName() string {
 
 func (v *Volume) ConstructExternal() *VolumeExternal {
 	return &VolumeExternal{
-		Config:      v.Config,
-		BackendUUID: v.BackendUUID,
-		Pool:        v.Pool,
-		Orphaned:    v.Orphaned,
-		State:       v.State,
+		Config:         v.Config,
+		BackendUUID:    v.BackendUUID,
+		Pool:           v.Pool,
+		Orphaned:       v.Orphaned,
+		State:          v.State,
+		PublishedNodes: v.PublishedNodes,
 	}
 }
 
+// AddPublishedNode records that the volume is published to the named node.  It returns false if the
+// volume was already published there.
+func (v *Volume) AddPublishedNode(nodeName string) bool {
+	i := sort.SearchStrings(v.PublishedNodes, nodeName)
+	if i < len(v.PublishedNodes) && v.PublishedNodes[i] == nodeName {
+		return false
+	}
+	nodes := make([]string, 0, len(v.PublishedNodes)+1)
+	nodes = append(nodes, v.PublishedNodes[:i]...)
+	nodes = append(nodes, nodeName)
+	v.PublishedNodes = append(nodes, v.PublishedNodes[i:]...)
+	return true
+}
+
+// RemovePublishedNode records that the volume is no longer published to the named node.  It returns
+// false if the volume wasn't published there.
+func (v *Volume) RemovePublishedNode(nodeName string) bool {
+	i := sort.SearchStrings(v.PublishedNodes, nodeName)
+	if i == len(v.PublishedNodes) || v.PublishedNodes[i] != nodeName {
+		return false
+	}
+	nodes := make([]string, 0, len(v.PublishedNodes)-1)
+	nodes = append(nodes, v.PublishedNodes[:i]...)
+	nodes = append(nodes, v.PublishedNodes[i+1:]...)
+	if len(nodes) == 0 {
+		nodes = nil
+	}
+	v.PublishedNodes = nodes
+	return true
+}
+
 // VolumeExternalWrapper is used to return volumes and errors via channels between goroutines
 type VolumeExternalWrapper struct {
 	Volume *VolumeExternal
@@ -194,6 +579,65 @@ func (r *ImportVolumeRequest) Validate() error {
 	return nil
 }
 
+// CloneSnapshotRequest asks for a new PVC whose volume is a clone of a snapshot of another volume.
+type CloneSnapshotRequest struct {
+	Volume   string `json:"volume"`
+	Snapshot string `json:"snapshot"`
+	PVCData  string `json:"pvcData"` // Opaque, base64-encoded
+}
+
+func (r *CloneSnapshotRequest) Validate() error {
+	if r.Volume == "" || r.Snapshot == "" {
+		return fmt.Errorf("the following fields are mandatory: volume and snapshot")
+	}
+	if _, err := base64.StdEncoding.DecodeString(r.PVCData); err != nil {
+		return fmt.Errorf("the pvcData field does not contain valid base64-encoded data: %v", err)
+	}
+	return nil
+}
+
+// ImportCandidate is a volume on a backend's storage system that doesn't belong to a Trident volume and
+// that may be imported as one.
+type ImportCandidate struct {
+	Backend      string          `json:"backend"`
+	InternalName string          `json:"internalName"`
+	Size         string          `json:"size"`
+	Pool         string          `json:"pool"`
+	Protocol     config.Protocol `json:"protocol"`
+	// JunctionPath is where a NAS volume is mounted in its storage system's namespace
+	JunctionPath string `json:"junctionPath,omitempty"`
+	// LUN is the path of the LUN in a SAN volume
+	LUN string `json:"lun,omitempty"`
+}
+
+// ImportDiscoveryFilter limits the import candidates listed to those whose names and junction paths
+// begin with the given prefixes and whose sizes, in bytes, fall within the given bounds.  Empty or zero
+// fields match every candidate.
+type ImportDiscoveryFilter struct {
+	Prefix       string `json:"prefix,omitempty"`
+	JunctionPath string `json:"junctionPath,omitempty"`
+	MinSize      uint64 `json:"minSize,omitempty"`
+	MaxSize      uint64 `json:"maxSize,omitempty"`
+}
+
+// Matches reports whether an import candidate passes the filter.
+func (f *ImportDiscoveryFilter) Matches(candidate *ImportCandidate) bool {
+	if f == nil {
+		return true
+	}
+	if !strings.HasPrefix(candidate.InternalName, f.Prefix) {
+		return false
+	}
+	if f.JunctionPath != "" && !strings.HasPrefix(candidate.JunctionPath, f.JunctionPath) {
+		return false
+	}
+	size, _ := strconv.ParseUint(candidate.Size, 10, 64)
+	if size < f.MinSize || (f.MaxSize > 0 && size > f.MaxSize) {
+		return false
+	}
+	return true
+}
+
 type UpgradeVolumeRequest struct {
 	Type   string `json:"type"`
 	Volume string `json:"volume"`
@@ -209,6 +653,57 @@ func (r *UpgradeVolumeRequest) Validate() error {
 	return nil
 }
 
+// UpdateVolumeRequest changes attributes of an existing volume.  Fields left empty are not changed.
+type UpdateVolumeRequest struct {
+	SnapshotDirectory         string `json:"snapshotDirectory,omitempty"`
+	TieringMinimumCoolingDays string `json:"tieringMinimumCoolingDays,omitempty"`
+	UnixPermissions           string `json:"unixPermissions,omitempty"`
+	SecurityStyle             string `json:"securityStyle,omitempty"`
+	ExportPolicy              string `json:"exportPolicy,omitempty"`
+	SnapshotPolicy            string `json:"snapshotPolicy,omitempty"`
+	// CloneToNamespaces replaces the comma-separated namespaces permitted to clone the volume.  Unlike the
+	// other fields, it is changed if empty, which permits no other namespace, and left alone only if nil.
+	CloneToNamespaces *string `json:"cloneToNamespaces,omitempty"`
+}
+
+func (r *UpdateVolumeRequest) Validate() error {
+	if *r == (UpdateVolumeRequest{}) {
+		return fmt.Errorf("at least one of the following fields is mandatory: snapshotDirectory, " +
+			"tieringMinimumCoolingDays, unixPermissions, securityStyle, exportPolicy, snapshotPolicy, " +
+			"cloneToNamespaces")
+	}
+	if r.SnapshotDirectory != "" {
+		if _, err := strconv.ParseBool(r.SnapshotDirectory); err != nil {
+			return fmt.Errorf("invalid boolean value for snapshotDirectory: %s", r.SnapshotDirectory)
+		}
+	}
+	if r.TieringMinimumCoolingDays != "" {
+		if _, err := strconv.Atoi(r.TieringMinimumCoolingDays); err != nil {
+			return fmt.Errorf("invalid integer value for tieringMinimumCoolingDays: %s", r.TieringMinimumCoolingDays)
+		}
+	}
+	if r.UnixPermissions != "" {
+		if _, err := strconv.ParseUint(r.UnixPermissions, 8, 32); err != nil {
+			return fmt.Errorf("invalid octal value for unixPermissions: %s", r.UnixPermissions)
+		}
+	}
+	switch r.SecurityStyle {
+	case "", "unix", "mixed":
+		break
+	default:
+		return fmt.Errorf("invalid value for securityStyle: %s", r.SecurityStyle)
+	}
+	return nil
+}
+
+// UpdatesStorage reports whether the request changes anything the volume's storage driver must apply, as
+// opposed to attributes kept only by Trident.
+func (r *UpdateVolumeRequest) UpdatesStorage() bool {
+	storageRequest := *r
+	storageRequest.CloneToNamespaces = nil
+	return storageRequest != (UpdateVolumeRequest{})
+}
+
 type ByVolumeExternalName []*VolumeExternal
 
 func (a ByVolumeExternalName) Len() int           { return len(a) }
@@ -43,6 +43,13 @@ func TestVolumeState(t *testing.T) {
 				return input.IsDeleting()
 			},
 		},
+		"Populating state": {
+			input:  VolumeStatePopulating,
+			output: "populating",
+			predicate: func(input VolumeState) bool {
+				return input.IsPopulating()
+			},
+		},
 	}
 	for testName, test := range tests {
 		t.Logf("Running test case '%s'", testName)
@@ -51,3 +58,99 @@ func TestVolumeState(t *testing.T) {
 		assert.True(t, test.predicate(test.input), "Predicate failed")
 	}
 }
+
+func TestUpdateVolumeRequestValidate(t *testing.T) {
+
+	noNamespaces := ""
+	tests := map[string]struct {
+		request UpdateVolumeRequest
+		valid   bool
+	}{
+		"Empty":                  {UpdateVolumeRequest{}, false},
+		"Snapshot directory":     {UpdateVolumeRequest{SnapshotDirectory: "true"}, true},
+		"Bad snapshot directory": {UpdateVolumeRequest{SnapshotDirectory: "maybe"}, false},
+		"Cooling days":           {UpdateVolumeRequest{TieringMinimumCoolingDays: "31"}, true},
+		"Bad cooling days":       {UpdateVolumeRequest{TieringMinimumCoolingDays: "month"}, false},
+		"UNIX permissions":       {UpdateVolumeRequest{UnixPermissions: "0755"}, true},
+		"Bad UNIX permissions":   {UpdateVolumeRequest{UnixPermissions: "rwxr-xr-x"}, false},
+		"Security style":         {UpdateVolumeRequest{SecurityStyle: "mixed"}, true},
+		"Bad security style":     {UpdateVolumeRequest{SecurityStyle: "ntfs"}, false},
+		"Export and snap policy": {UpdateVolumeRequest{ExportPolicy: "p1", SnapshotPolicy: "default"}, true},
+		"No clone namespaces":    {UpdateVolumeRequest{CloneToNamespaces: &noNamespaces}, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.valid {
+				assert.NoError(t, test.request.Validate())
+			} else {
+				assert.Error(t, test.request.Validate())
+			}
+		})
+	}
+}
+
+func TestPublishedNodes(t *testing.T) {
+
+	volume := NewVolume(&VolumeConfig{Name: "vol"}, "backendUUID", "pool", false)
+
+	assert.True(t, volume.AddPublishedNode("node2"))
+	assert.True(t, volume.AddPublishedNode("node1"))
+	assert.False(t, volume.AddPublishedNode("node2"))
+	assert.Equal(t, []string{"node1", "node2"}, volume.PublishedNodes)
+	assert.Equal(t, []string{"node1", "node2"}, volume.ConstructExternal().PublishedNodes)
+
+	assert.False(t, volume.RemovePublishedNode("node3"))
+	assert.True(t, volume.RemovePublishedNode("node1"))
+	assert.Equal(t, []string{"node2"}, volume.PublishedNodes)
+	assert.True(t, volume.RemovePublishedNode("node2"))
+	assert.Nil(t, volume.PublishedNodes)
+}
+
+func TestPermitsCloneTo(t *testing.T) {
+
+	assert.Equal(t, []string{"team-b", "team-c"}, ParseCloneToNamespaces(" team-b,,team-c "))
+	assert.Nil(t, ParseCloneToNamespaces(""))
+
+	config := &VolumeConfig{Name: "vol", Namespace: "team-a"}
+	assert.True(t, config.PermitsCloneTo("team-a"))
+	assert.True(t, config.PermitsCloneTo(""))
+	assert.False(t, config.PermitsCloneTo("team-b"))
+
+	config.CloneToNamespaces = ParseCloneToNamespaces("team-b,team-c")
+	assert.True(t, config.PermitsCloneTo("team-b"))
+	assert.False(t, config.PermitsCloneTo("team-d"))
+
+	config.CloneToNamespaces = []string{CloneToAllNamespaces}
+	assert.True(t, config.PermitsCloneTo("team-d"))
+
+	// A legacy volume saved before its namespace was recorded may not be cloned into any namespace by default
+	legacy := &VolumeConfig{Name: "legacy"}
+	assert.True(t, legacy.PermitsCloneTo(""))
+	assert.False(t, legacy.PermitsCloneTo("team-a"))
+
+	legacy.CloneToNamespaces = []string{"team-a"}
+	assert.True(t, legacy.PermitsCloneTo("team-a"))
+	assert.False(t, legacy.PermitsCloneTo("team-b"))
+
+	// Changing only who may clone a volume needn't involve its storage driver
+	namespaces := "team-b"
+	assert.False(t, (&UpdateVolumeRequest{CloneToNamespaces: &namespaces}).UpdatesStorage())
+	assert.True(t, (&UpdateVolumeRequest{CloneToNamespaces: &namespaces, ExportPolicy: "p1"}).UpdatesStorage())
+}
+
+func TestImportDiscoveryFilter(t *testing.T) {
+
+	candidate := &ImportCandidate{InternalName: "app_data", Size: "2147483648", JunctionPath: "/apps/data"}
+
+	var none *ImportDiscoveryFilter
+	assert.True(t, none.Matches(candidate))
+	assert.True(t, (&ImportDiscoveryFilter{}).Matches(candidate))
+
+	assert.True(t, (&ImportDiscoveryFilter{Prefix: "app_", JunctionPath: "/apps"}).Matches(candidate))
+	assert.False(t, (&ImportDiscoveryFilter{Prefix: "db_"}).Matches(candidate))
+	assert.False(t, (&ImportDiscoveryFilter{JunctionPath: "/db"}).Matches(candidate))
+
+	assert.True(t, (&ImportDiscoveryFilter{MinSize: 1073741824, MaxSize: 2147483648}).Matches(candidate))
+	assert.False(t, (&ImportDiscoveryFilter{MinSize: 4294967296}).Matches(candidate))
+	assert.False(t, (&ImportDiscoveryFilter{MaxSize: 1073741824}).Matches(candidate))
+}
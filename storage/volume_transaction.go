@@ -3,6 +3,8 @@
 package storage
 
 import (
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -19,7 +21,25 @@ const (
 	DeleteSnapshot VolumeOperation = "deleteSnapshot"
 
 	// Transactions for long-running operations
-	VolumeCreating VolumeOperation = "volumeCreating"
+	VolumeCreating  VolumeOperation = "volumeCreating"
+	VolumeMigrating VolumeOperation = "volumeMigrating"
+)
+
+// VolumeOperationStep records how far a volume create or clone got, so that an operation interrupted by
+// a restart may be finished or rolled back from there.  Transactions written by older versions of Trident
+// record no step, and are rolled back.
+type VolumeOperationStep string
+
+const (
+	// The backend and pool were chosen and the volume's internal name assigned
+	StepBackendSelected VolumeOperationStep = "backendSelected"
+	// The volume was created on the backend, so only saving it remains
+	StepBackendCreated VolumeOperationStep = "backendCreated"
+
+	// A migrating volume is being copied to its new backend
+	StepMigrationCopying VolumeOperationStep = "copying"
+	// A migrated volume was switched to its new backend, so only deleting it from its old backend remains
+	StepMigrationRetiring VolumeOperationStep = "retiring"
 )
 
 type VolumeTransaction struct {
@@ -27,7 +47,11 @@ type VolumeTransaction struct {
 	VolumeCreatingConfig *VolumeCreatingConfig
 	SnapshotConfig       *SnapshotConfig
 	PVUpgradeConfig      *PVUpgradeConfig
+	MigrationConfig      *VolumeMigrationConfig
 	Op                   VolumeOperation
+	Step                 VolumeOperationStep
+	BackendUUID          string
+	Pool                 string
 }
 
 type PVUpgradeConfig struct {
@@ -36,6 +60,15 @@ type PVUpgradeConfig struct {
 	OwnedPodsForPVC []string                  `json:"ownedPodsForPVC,omitempty"`
 }
 
+// VolumeMigrationConfig records where a volume is being migrated from.  The transaction's Config, BackendUUID,
+// and Pool describe the copy of the volume on its new backend.
+type VolumeMigrationConfig struct {
+	StartTime         time.Time     `json:"startTime"`
+	SourceBackendUUID string        `json:"sourceBackendUUID"`
+	SourcePool        string        `json:"sourcePool"`
+	SourceConfig      *VolumeConfig `json:"sourceConfig"`
+}
+
 // Name returns a unique identifier for the VolumeTransaction.  Volume transactions should only
 // be identified by their name, while snapshot transactions should be identified by their name as
 // well as their volume name.  It's possible that some situations will leave a delete transaction
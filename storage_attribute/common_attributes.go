@@ -4,7 +4,8 @@ package storageattribute
 
 const (
 	// Constants for integer storage category attributes
-	IOPS = "IOPS"
+	IOPS                      = "IOPS"
+	RequestedCapacityHeadroom = "requestedCapacityHeadroom"
 
 	// Constants for boolean storage category attributes
 	Snapshots  = "snapshots"
@@ -17,6 +18,7 @@ const (
 	Media            = "media"
 	Region           = "region"
 	Zone             = "zone"
+	PlacementPolicy  = "placementPolicy"
 
 	// Constants for label attributes
 	Labels   = "labels"
@@ -44,19 +46,21 @@ const (
 )
 
 var attrTypes = map[string]Type{
-	IOPS:             intType,
-	Snapshots:        boolType,
-	Clones:           boolType,
-	Encryption:       boolType,
-	ProvisioningType: stringType,
-	BackendType:      stringType,
-	Media:            stringType,
-	Region:           stringType,
-	Zone:             stringType,
-	Labels:           labelType,
-	Selector:         labelType,
-	RecoveryTest:     boolType,
-	UniqueOptions:    stringType,
-	TestingAttribute: boolType,
-	NonexistentBool:  boolType,
+	IOPS:                      intType,
+	RequestedCapacityHeadroom: intType,
+	Snapshots:                 boolType,
+	Clones:                    boolType,
+	Encryption:                boolType,
+	ProvisioningType:          stringType,
+	BackendType:               stringType,
+	Media:                     stringType,
+	Region:                    stringType,
+	Zone:                      stringType,
+	PlacementPolicy:           stringType,
+	Labels:                    labelType,
+	Selector:                  labelType,
+	RecoveryTest:              boolType,
+	UniqueOptions:             stringType,
+	TestingAttribute:          boolType,
+	NonexistentBool:           boolType,
 }
@@ -182,6 +182,17 @@ func (s *StorageClass) Matches(storagePool *storage.Pool) bool {
 			name = "labels"
 		}
 
+		// The requested headroom depends on a pool's current capacity, so it is checked when a
+		// volume is placed rather than when pools are matched
+		if name == storageattribute.RequestedCapacityHeadroom {
+			continue
+		}
+
+		// The placement policy chooses among the matching pools, so it isn't offered by any pool
+		if name == storageattribute.PlacementPolicy {
+			continue
+		}
+
 		if offer, ok := storagePool.Attributes[name]; !ok || !offer.Matches(request) {
 			log.WithFields(log.Fields{
 				"offer":        offer,
@@ -334,6 +345,118 @@ func (s *StorageClass) GetStoragePoolsForProtocolByBackend(p config.Protocol) ma
 	return poolMap
 }
 
+// HasCapacityFor reports whether a pool has room for a new volume of the given size while keeping free
+// the percentage of its capacity set by the storage class's requestedCapacityHeadroom attribute.  Pools
+// whose capacity isn't known, and all pools of classes without the attribute, are assumed to have room.
+func (s *StorageClass) HasCapacityFor(storagePool *storage.Pool, sizeBytes uint64) bool {
+
+	request, ok := s.config.Attributes[storageattribute.RequestedCapacityHeadroom]
+	if !ok {
+		return true
+	}
+	headroom, ok := request.Value().(int)
+	if !ok {
+		return true
+	}
+	capacity := storagePool.Capacity()
+	if capacity == nil {
+		return true
+	}
+
+	hasCapacity := capacity.HasHeadroom(sizeBytes, headroom)
+	if !hasCapacity {
+		log.WithFields(log.Fields{
+			"storageClass": s.GetName(),
+			"pool":         storagePool.Name,
+			"poolBackend":  storagePool.Backend.Name,
+			"size":         sizeBytes,
+			"freeBytes":    capacity.FreeBytes,
+			"totalBytes":   capacity.TotalBytes,
+			"headroom":     headroom,
+		}).Debug("Storage pool lacks the requested capacity headroom.")
+	}
+	return hasCapacity
+}
+
+// GetPlacementPolicy returns the name of the volume placement policy requested by the storage class's
+// placementPolicy attribute, or an empty string if the class doesn't request one.
+func (s *StorageClass) GetPlacementPolicy() string {
+
+	request, ok := s.config.Attributes[storageattribute.PlacementPolicy]
+	if !ok {
+		return ""
+	}
+	policy, _ := request.Value().(string)
+	return policy
+}
+
+// FilterPoolsByCapacity removes the pools that lack room for a new volume of the given size from a map
+// returned by GetStoragePoolsForProtocolByBackend, along with any backends left without pools.
+func (s *StorageClass) FilterPoolsByCapacity(
+	poolMap map[string]*BackendPoolInfo, sizeBytes uint64,
+) map[string]*BackendPoolInfo {
+
+	for backendName, backendPoolInfo := range poolMap {
+		pools := make([]*storage.Pool, 0, len(backendPoolInfo.Pools))
+		for _, pool := range backendPoolInfo.Pools {
+			if s.HasCapacityFor(pool, sizeBytes) {
+				pools = append(pools, pool)
+			}
+		}
+		if len(pools) == 0 {
+			delete(poolMap, backendName)
+		} else {
+			backendPoolInfo.Pools = pools
+		}
+	}
+	return poolMap
+}
+
+// FilterPoolsByTopology removes the pools not accessible from any of the requisite topology segments of a
+// new volume from a map returned by GetStoragePoolsForProtocolByBackend, along with any backends left
+// without pools.
+func (s *StorageClass) FilterPoolsByTopology(
+	poolMap map[string]*BackendPoolInfo, requisite []map[string]string,
+) map[string]*BackendPoolInfo {
+
+	if len(requisite) == 0 {
+		return poolMap
+	}
+	for backendName, backendPoolInfo := range poolMap {
+		pools := make([]*storage.Pool, 0, len(backendPoolInfo.Pools))
+		for _, pool := range backendPoolInfo.Pools {
+			if pool.MatchesTopologies(requisite) {
+				pools = append(pools, pool)
+			}
+		}
+		if len(pools) == 0 {
+			delete(poolMap, backendName)
+		} else {
+			backendPoolInfo.Pools = pools
+		}
+	}
+	return poolMap
+}
+
+// SortPoolsByTopology orders the pools of each backend in a map returned by GetStoragePoolsForProtocolByBackend
+// so that pools accessible from earlier preferred topology segments come first.  Equally preferred pools
+// keep their shuffled order.
+func (s *StorageClass) SortPoolsByTopology(
+	poolMap map[string]*BackendPoolInfo, preferred []map[string]string,
+) map[string]*BackendPoolInfo {
+
+	if len(preferred) == 0 {
+		return poolMap
+	}
+	for _, backendPoolInfo := range poolMap {
+		pools := backendPoolInfo.Pools
+		sort.SliceStable(pools, func(i, j int) bool {
+			return pools[i].TopologyPreference(preferred) < pools[j].TopologyPreference(preferred)
+		})
+	}
+	return poolMap
+}
+
 func (s *StorageClass) Pools() []*storage.Pool {
 	return s.pools
 }
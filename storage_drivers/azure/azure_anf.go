@@ -3,6 +3,7 @@
 package azure
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -475,7 +476,7 @@ func (d *NFSStorageDriver) validate() error {
 
 // Create a volume with the specified options
 func (d *NFSStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
 	name := volConfig.InternalName
@@ -487,8 +488,8 @@ func (d *NFSStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	// Make sure we got a valid name
@@ -517,7 +518,7 @@ func (d *NFSStorageDriver) Create(
 				fmt.Sprintf("volume state is still %s, not %s", sdk.StateCreating, sdk.StateAvailable))
 		}
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"name":  name,
 			"state": extantVolume.ProvisioningState,
 		}).Warning("Volume already exists.")
@@ -546,7 +547,7 @@ func (d *NFSStorageDriver) Create(
 	// TODO: remove this code once ANF can handle smaller volumes
 	if sizeBytes < MinimumANFVolumeSizeBytes {
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"name": name,
 			"size": sizeBytes,
 		}).Warningf("Requested size is too small. Setting volume size to the minimum allowable (100 GB).")
@@ -603,7 +604,7 @@ func (d *NFSStorageDriver) Create(
 		Rules: []sdk.ExportRule{apiExportRule},
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"creationToken": name,
 		"size":          sizeBytes,
 		"serviceLevel":  serviceLevel,
@@ -636,7 +637,7 @@ func (d *NFSStorageDriver) Create(
 }
 
 // CreateClone clones an existing volume.  If a snapshot is not specified, one is created.
-func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *storage.Pool) error {
+func (d *NFSStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, _ *storage.Pool) error {
 
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
@@ -650,8 +651,8 @@ func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *stora
 			"source":   source,
 			"snapshot": snapshot,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
 	// ensure new volume doesn't exist, fail if so
@@ -701,7 +702,7 @@ func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *stora
 				sourceSnapshot.ProvisioningState, sdk.StateAvailable)
 		}
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"snapshot": snapshot,
 			"source":   sourceVolume.Name,
 		}).Debug("Found source snapshot.")
@@ -717,7 +718,7 @@ func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *stora
 			Location:     sourceVolume.Location,
 		}
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"snapshot": snapName,
 			"source":   sourceVolume.Name,
 		}).Debug("Creating source snapshot.")
@@ -740,13 +741,13 @@ func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *stora
 			return fmt.Errorf("could not retrieve newly-created snapshot")
 		}
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"snapshot": sourceSnapshot.Name,
 			"source":   sourceVolume.Name,
 		}).Debug("Created source snapshot.")
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"creationToken":  name,
 		"sourceVolume":   sourceVolume.CreationToken,
 		"sourceSnapshot": sourceSnapshot.Name,
@@ -782,7 +783,7 @@ func (d *NFSStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *stora
 	return d.waitForVolumeCreate(clone, name)
 }
 
-func (d *NFSStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *NFSStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -791,8 +792,8 @@ func (d *NFSStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 			"originalName": originalName,
 			"newName":      volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	// Get the volume
@@ -806,7 +807,7 @@ func (d *NFSStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	// Get the volume size
 	volConfig.Size = strconv.FormatInt(volume.QuotaInBytes, 10)
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"creationToken": volume.CreationToken,
 		"managed":       !volConfig.ImportNotManaged,
 		"state":         volume.ProvisioningState,
@@ -817,7 +818,7 @@ func (d *NFSStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	// Update the volume labels if Trident will manage its lifecycle
 	if !volConfig.ImportNotManaged {
 		if _, err := d.SDK.RelabelVolume(volume, d.updateTelemetryLabels(volume)); err != nil {
-			log.WithField("originalName", originalName).Errorf("Could not import volume, relabel failed: %v", err)
+			utils.Logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, relabel failed: %v", err)
 			return fmt.Errorf("could not import volume %s, relabel failed: %v", originalName, err)
 		}
 		_, err := d.SDK.WaitForVolumeState(volume, sdk.StateAvailable, []string{sdk.StateError}, d.defaultTimeout())
@@ -832,7 +833,7 @@ func (d *NFSStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	return nil
 }
 
-func (d *NFSStorageDriver) Rename(name string, newName string) error {
+func (d *NFSStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -841,8 +842,8 @@ func (d *NFSStorageDriver) Rename(name string, newName string) error {
 			"name":    name,
 			"newName": newName,
 		}
-		log.WithFields(fields).Debug(">>>> Rename")
-		defer log.WithFields(fields).Debug("<<<< Rename")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Rename")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Rename")
 	}
 
 	// Rename is only needed for the import workflow, and we aren't currently renaming the
@@ -931,7 +932,7 @@ func (d *NFSStorageDriver) waitForVolumeCreate(volume *sdk.FileSystem, volumeNam
 }
 
 // Destroy deletes a volume.
-func (d *NFSStorageDriver) Destroy(name string) error {
+func (d *NFSStorageDriver) Destroy(ctx context.Context, name string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -939,8 +940,8 @@ func (d *NFSStorageDriver) Destroy(name string) error {
 			"Type":   "NFSStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	// If volume doesn't exist, return success
@@ -951,7 +952,7 @@ func (d *NFSStorageDriver) Destroy(name string) error {
 		return err
 	}
 	if !volumeExists {
-		log.WithField("volume", name).Warn("Volume already deleted.")
+		utils.Logc(ctx).WithField("volume", name).Warn("Volume already deleted.")
 		return nil
 	} else if extantVolume.ProvisioningState == sdk.StateDeleting {
 		// This is a retry, so give it more time before giving up again.
@@ -972,7 +973,7 @@ func (d *NFSStorageDriver) Destroy(name string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *NFSStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *NFSStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
 	name := volConfig.InternalName
 
@@ -982,8 +983,8 @@ func (d *NFSStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 			"Type":   "NFSStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	// Get the volume
@@ -1014,7 +1015,7 @@ func (d *NFSStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 }
 
 // GetSnapshot returns a snapshot of a volume, or an error if it does not exist.
-func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NFSStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1026,8 +1027,8 @@ func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	// Get the volume
@@ -1048,7 +1049,7 @@ func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 
 			created := snapshot.Created.UTC().Format(storage.SnapshotTimestampFormat)
 
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"snapshotName": internalSnapName,
 				"volumeName":   internalVolName,
 				"created":      created,
@@ -1062,7 +1063,7 @@ func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 		}
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"snapshotName": internalSnapName,
 		"volumeName":   internalVolName,
 	}).Warning("Snapshot not found.")
@@ -1071,7 +1072,7 @@ func (d *NFSStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 }
 
 // Return the list of snapshots associated with the specified volume
-func (d *NFSStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *NFSStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	internalVolName := volConfig.InternalName
 
@@ -1081,8 +1082,8 @@ func (d *NFSStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 			"Type":       "NFSStorageDriver",
 			"volumeName": internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	// Get the volume
@@ -1124,7 +1125,7 @@ func (d *NFSStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *NFSStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NFSStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1136,8 +1137,8 @@ func (d *NFSStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
 	// Check if volume exists
@@ -1177,7 +1178,7 @@ func (d *NFSStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *NFSStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NFSStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1189,8 +1190,8 @@ func (d *NFSStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) e
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
 	// Get the volume
@@ -1210,7 +1211,7 @@ func (d *NFSStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) e
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *NFSStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NFSStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1222,8 +1223,8 @@ func (d *NFSStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) er
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
 	// Get the volume
@@ -1303,7 +1304,7 @@ func (d *NFSStorageDriver) Get(name string) error {
 }
 
 // Resize increases a volume's quota
-func (d *NFSStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *NFSStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
 
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
@@ -1313,8 +1314,8 @@ func (d *NFSStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Resize")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Resize")
 	}
 
 	// Get the volume
@@ -1339,7 +1340,8 @@ func (d *NFSStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 
 	// Make sure we're not shrinking the volume
 	if int64(sizeBytes) < volume.QuotaInBytes {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, volume.QuotaInBytes)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, volume.QuotaInBytes), nil)
 	}
 
 	// Make sure the request isn't above the configured maximum volume size (if any)
@@ -1392,7 +1394,7 @@ func (d *NFSStorageDriver) GetInternalVolumeName(name string) string {
 	}
 }
 
-func (d *NFSStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *NFSStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1400,8 +1402,8 @@ func (d *NFSStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 			"Type":   "NFSStorageDriver",
 			"name":   volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateFollowup")
-		defer log.WithFields(fields).Debug("<<<< CreateFollowup")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateFollowup")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateFollowup")
 	}
 
 	// Get the volume
@@ -1433,6 +1435,14 @@ func (d *NFSStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.File
 }
 
+func (d *NFSStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.FileAccessModes
+}
+
+func (d *NFSStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.FileVolumeExpansion
+}
+
 func (d *NFSStorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
 	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
 	b.AzureConfig = &d.Config
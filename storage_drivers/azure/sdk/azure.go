@@ -118,7 +118,7 @@ func (d *Client) Init(pools map[string]*storage.Pool) (err error) {
 
 	// Map vpools to backend
 	for _, p := range pools {
-		d.registerStoragePool(*p)
+		d.registerStoragePool(p)
 	}
 
 	return nil
@@ -90,8 +90,8 @@ func createCookie(rg string, naa string, cpool string, spool string) *AzureCapac
 }
 
 // RegisterStoragePool makes a note of pools defined by the driver for later mapping
-func (d *Client) registerStoragePool(spool storage.Pool) {
-	d.SDKClient.AzureResources.StoragePoolMap[spool.Name] = &spool
+func (d *Client) registerStoragePool(spool *storage.Pool) {
+	d.SDKClient.AzureResources.StoragePoolMap[spool.Name] = spool
 }
 
 // GetCookieByCapacityPoolName searches for a matching capacity pool name and returns an access cookie
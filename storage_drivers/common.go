@@ -89,6 +89,16 @@ func ValidateCommonSettings(configJSON string) (*CommonStorageDriverConfig, erro
 		}
 	}
 
+	// Validate concurrent operation limits (if set)
+	for name, limit := range map[string]int{
+		"maxConcurrentCreates": config.MaxConcurrentCreates,
+		"maxConcurrentClones":  config.MaxConcurrentClones,
+	} {
+		if limit < 0 {
+			return nil, fmt.Errorf("invalid value for %s: %d", name, limit)
+		}
+	}
+
 	log.Debugf("Parsed commonConfig: %+v", *config)
 
 	return config, nil
@@ -141,10 +151,15 @@ func GetCommonInternalVolumeName(c *CommonStorageDriverConfig, name string) stri
 
 // CheckVolumeSizeLimits if a limit has been set, ensures the requestedSize is under it.
 func CheckVolumeSizeLimits(requestedSizeInt uint64, config *CommonStorageDriverConfig) (bool, uint64, error) {
+	return CheckVolumeSizeLimit(requestedSizeInt, config.LimitVolumeSize)
+}
+
+// CheckVolumeSizeLimit if a limit has been set, ensures the requestedSize is under it.  A
+// VolumeSizeLimitError is returned if the limit is exceeded.
+func CheckVolumeSizeLimit(requestedSizeInt uint64, limitVolumeSize string) (bool, uint64, error) {
 
 	requestedSize := float64(requestedSizeInt)
 	// If the user specified a limit for volume size, parse and enforce it
-	limitVolumeSize := config.LimitVolumeSize
 	log.WithFields(log.Fields{
 		"limitVolumeSize": limitVolumeSize,
 	}).Debugf("Limits")
@@ -167,7 +182,7 @@ func CheckVolumeSizeLimits(requestedSizeInt uint64, config *CommonStorageDriverC
 	}).Debugf("Comparing limits")
 
 	if requestedSize > float64(volumeSizeLimit) {
-		return true, volumeSizeLimit, fmt.Errorf("requested size: %1.f > the size limit: %d", requestedSize, volumeSizeLimit)
+		return true, volumeSizeLimit, NewVolumeSizeLimitError(requestedSizeInt, volumeSizeLimit)
 	}
 
 	return true, volumeSizeLimit, nil
@@ -26,6 +26,7 @@ const (
 	AzureNFSStorageDriverName          = "azure-netapp-files"
 	GCPNFSStorageDriverName            = "gcp-cvs"
 	FakeStorageDriverName              = "fake"
+	FakeOntapStorageDriverName         = "fake-ontap"
 )
 
 // Filesystem types
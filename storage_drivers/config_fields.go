@@ -0,0 +1,124 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storagedrivers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// FindUnknownConfigFields returns the paths, such as "storage[1].defaults.snapshotPolicy", of the attributes
+// in a backend's JSON configuration that match no field of the driver's config struct, and would therefore
+// be ignored when the configuration is decoded into it.  Like encoding/json, attribute names are matched
+// to fields regardless of case.
+func FindUnknownConfigFields(configJSON string, config interface{}) ([]string, error) {
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(configJSON), &value); err != nil {
+		return nil, fmt.Errorf("could not decode JSON configuration: %v", err)
+	}
+
+	unknown := make([]string, 0)
+	findUnknownFields(value, reflect.TypeOf(config), "", &unknown)
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// CheckUnknownConfigFields fails if a backend's JSON configuration has attributes that match no field of the
+// driver's config struct and the configuration is strict, or otherwise logs a warning naming them.
+func CheckUnknownConfigFields(configJSON string, config interface{}, strict bool) error {
+
+	unknown, err := FindUnknownConfigFields(configJSON, config)
+	if err != nil {
+		return err
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if strict {
+		return fmt.Errorf("unknown attributes in backend configuration: %s", strings.Join(unknown, ", "))
+	}
+	log.WithField("attributes", strings.Join(unknown, ", ")).Warning(
+		"Ignoring unknown attributes in backend configuration.")
+	return nil
+}
+
+// findUnknownFields walks a decoded JSON value alongside the type it would be decoded into, adding the path
+// of each object attribute with no matching struct field to the list.
+func findUnknownFields(value interface{}, t reflect.Type, path string, unknown *[]string) {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := make(map[string]reflect.Type)
+			addJSONFields(t, fields)
+			for name, child := range v {
+				childPath := name
+				if path != "" {
+					childPath = path + "." + name
+				}
+				if fieldType, ok := fields[strings.ToLower(name)]; ok {
+					findUnknownFields(child, fieldType, childPath, unknown)
+				} else {
+					*unknown = append(*unknown, childPath)
+				}
+			}
+		case reflect.Map:
+			for name, child := range v {
+				findUnknownFields(child, t.Elem(), path+"."+name, unknown)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, child := range v {
+				findUnknownFields(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	}
+}
+
+// addJSONFields adds the JSON names, in lower case, and types of a struct's fields to the map, including
+// those of untagged embedded structs as encoding/json does.
+func addJSONFields(t reflect.Type, fields map[string]reflect.Type) {
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			addJSONFields(fieldType, fields)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+}
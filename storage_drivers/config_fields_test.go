@@ -0,0 +1,69 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storagedrivers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUnknownConfigFields(t *testing.T) {
+
+	configJSON := `{
+		"version": 1,
+		"storageDriverName": "ontap-nas",
+		"StoragePrefix": "trident_",
+		"managementLIF": "10.0.0.1",
+		"snapshotPolicy": "default",
+		"spaceReserv": "none",
+		"labels": {"app": "db"},
+		"defaults": {"snapshotReserve": "10", "exportPolicy": "default"},
+		"exportRule": {"anonUID": "65534", "readOnly": ["10.0.0.0/24"]},
+		"retryBudgets": {"create": "60"},
+		"storage": [
+			{"zone": "z1", "defaults": {"snapshotPolcy": "none"}},
+			{"region": "r1", "lables": {"app": "web"}}
+		]
+	}`
+
+	unknown, err := FindUnknownConfigFields(configJSON, &OntapStorageDriverConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"exportRule.readOnly",
+		"snapshotPolicy", // belongs in the defaults
+		"spaceReserv",
+		"storage[0].defaults.snapshotPolcy",
+		"storage[1].lables",
+	}, unknown)
+
+	_, err = FindUnknownConfigFields("{", &OntapStorageDriverConfig{})
+	assert.Error(t, err)
+
+	// The sample ONTAP backend configurations have no unknown attributes
+	samples, err := filepath.Glob("../trident-installer/sample-input/backend-ontap-*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, samples)
+	for _, sample := range samples {
+		configJSON, err := ioutil.ReadFile(sample)
+		assert.NoError(t, err)
+		unknown, err = FindUnknownConfigFields(string(configJSON), &OntapStorageDriverConfig{})
+		assert.NoError(t, err)
+		assert.Empty(t, unknown, sample)
+	}
+}
+
+func TestCheckUnknownConfigFields(t *testing.T) {
+
+	configJSON := `{"version": 1, "storageDriverName": "ontap-san", "igroupNme": "trident"}`
+
+	assert.NoError(t, CheckUnknownConfigFields(configJSON, &OntapStorageDriverConfig{}, false))
+
+	err := CheckUnknownConfigFields(configJSON, &OntapStorageDriverConfig{}, true)
+	assert.EqualError(t, err, "unknown attributes in backend configuration: igroupNme")
+
+	configJSON = `{"version": 1, "storageDriverName": "ontap-san", "igroupName": "trident"}`
+	assert.NoError(t, CheckUnknownConfigFields(configJSON, &OntapStorageDriverConfig{}, true))
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storagedrivers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigSchemaVersion is the schema version of the backend configs written by this release.  A config with
+// no schemaVersion predates versioning and has schema version 1.
+const ConfigSchemaVersion = 2
+
+// ConfigMigration upgrades a backend config, decoded as a JSON object, from one schema version to the next.
+type ConfigMigration struct {
+	FromVersion int
+	Description string
+	Migrate     func(driverName string, config map[string]interface{}) error
+}
+
+// configMigrations are applied in order to configs older than ConfigSchemaVersion.  A migration must leave
+// an already-migrated config unchanged, since a config may reach the current release before its schema
+// version was first written.
+var configMigrations = []ConfigMigration{
+	{
+		FromVersion: 1,
+		Description: "rename deprecated attributes",
+		Migrate:     renameDeprecatedConfigFields,
+	},
+	{
+		FromVersion: 1,
+		Description: "split data LIF lists",
+		Migrate:     splitDataLIFList,
+	},
+}
+
+// deprecatedConfigFields maps each driver's deprecated top-level attributes to the attributes replacing them.
+var deprecatedConfigFields = map[string]map[string]string{
+	EseriesIscsiStorageDriverName: {"hostData_IP": "hostDataIP"},
+}
+
+// MigrateConfig upgrades a backend's JSON configuration to ConfigSchemaVersion, returning the upgraded
+// configuration and whether it changed.  A configuration already at the current version is returned as is,
+// and one written by a newer release is rejected rather than misread.
+func MigrateConfig(configJSON string) (string, bool, error) {
+
+	decoder := json.NewDecoder(strings.NewReader(configJSON))
+	decoder.UseNumber()
+	config := make(map[string]interface{})
+	if err := decoder.Decode(&config); err != nil {
+		return "", false, fmt.Errorf("could not parse JSON configuration: %v", err)
+	}
+
+	version, err := getConfigSchemaVersion(config)
+	if err != nil {
+		return "", false, err
+	}
+	if version == ConfigSchemaVersion {
+		return configJSON, false, nil
+	} else if version > ConfigSchemaVersion {
+		return "", false, fmt.Errorf("backend config schema version %d is newer than the supported version %d",
+			version, ConfigSchemaVersion)
+	}
+
+	driverName, _ := config["storageDriverName"].(string)
+	for _, migration := range configMigrations {
+		if migration.FromVersion < version {
+			continue
+		}
+		if err = migration.Migrate(driverName, config); err != nil {
+			return "", false, fmt.Errorf("could not %s in backend config of schema version %d: %v",
+				migration.Description, migration.FromVersion, err)
+		}
+	}
+	config["schemaVersion"] = ConfigSchemaVersion
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(config); err != nil {
+		return "", false, fmt.Errorf("could not encode migrated JSON configuration: %v", err)
+	}
+
+	log.WithFields(log.Fields{
+		"driver":      driverName,
+		"fromVersion": version,
+		"toVersion":   ConfigSchemaVersion,
+	}).Info("Migrated backend config.")
+
+	return strings.TrimSpace(buffer.String()), true, nil
+}
+
+// getConfigSchemaVersion returns the schema version of a backend config decoded as a JSON object.
+func getConfigSchemaVersion(config map[string]interface{}) (int, error) {
+
+	value, ok := config["schemaVersion"]
+	if !ok {
+		return 1, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid backend config schema version %v", value)
+	}
+	version, err := number.Int64()
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid backend config schema version %v", value)
+	}
+	return int(version), nil
+}
+
+// renameDeprecatedConfigFields moves the values of a driver's deprecated attributes to the attributes replacing
+// them.  A value already set in the new attribute is kept.
+func renameDeprecatedConfigFields(driverName string, config map[string]interface{}) error {
+
+	for oldName, newName := range deprecatedConfigFields[driverName] {
+		value, ok := config[oldName]
+		if !ok {
+			continue
+		}
+		delete(config, oldName)
+		if current, ok := config[newName]; ok && current != "" {
+			log.WithFields(log.Fields{
+				"attribute":  oldName,
+				"replacedBy": newName,
+			}).Warning("Dropping deprecated backend config attribute set alongside its replacement.")
+			continue
+		}
+		config[newName] = value
+	}
+	return nil
+}
+
+// splitDataLIFList reduces a comma-separated list of data LIFs, which some older ONTAP backend configs carry,
+// to its first entry.  The ONTAP drivers take a single data LIF, discovering the SVM's others themselves, and
+// would otherwise reject the list as an invalid IP address.
+func splitDataLIFList(driverName string, config map[string]interface{}) error {
+
+	if !strings.HasPrefix(driverName, "ontap-") {
+		return nil
+	}
+	dataLIF, ok := config["dataLIF"].(string)
+	if !ok || !strings.Contains(dataLIF, ",") {
+		return nil
+	}
+
+	dataLIFs := strings.Split(dataLIF, ",")
+	config["dataLIF"] = strings.TrimSpace(dataLIFs[0])
+
+	log.WithFields(log.Fields{
+		"dataLIF": config["dataLIF"],
+		"ignored": strings.Join(dataLIFs[1:], ","),
+	}).Warning("Backend config listed several data LIFs; keeping the first.")
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storagedrivers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateConfig(t *testing.T) {
+
+	for _, test := range []struct {
+		name     string
+		config   string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "deprecated attribute renamed",
+			config: `{"version": 1, "storageDriverName": "eseries-iscsi", "hostData_IP": "10.0.0.1"}`,
+			expected: map[string]interface{}{"version": 1.0, "storageDriverName": "eseries-iscsi",
+				"hostDataIP": "10.0.0.1", "schemaVersion": 2.0},
+		},
+		{
+			name: "replacement attribute kept",
+			config: `{"version": 1, "storageDriverName": "eseries-iscsi", "hostData_IP": "10.0.0.1",
+				"hostDataIP": "10.0.0.2"}`,
+			expected: map[string]interface{}{"version": 1.0, "storageDriverName": "eseries-iscsi",
+				"hostDataIP": "10.0.0.2", "schemaVersion": 2.0},
+		},
+		{
+			name:   "data LIF list split",
+			config: `{"version": 1, "storageDriverName": "ontap-nas", "dataLIF": "10.0.0.1, 10.0.0.2"}`,
+			expected: map[string]interface{}{"version": 1.0, "storageDriverName": "ontap-nas",
+				"dataLIF": "10.0.0.1", "schemaVersion": 2.0},
+		},
+		{
+			name: "other attributes untouched",
+			config: `{"version": 1, "storageDriverName": "ontap-san", "storagePrefix": "", "limitVolumeSize": "5Gi",
+				"storage": [{"labels": {"app": "db&cache"}}]}`,
+			expected: map[string]interface{}{"version": 1.0, "storageDriverName": "ontap-san", "storagePrefix": "",
+				"limitVolumeSize": "5Gi", "storage": []interface{}{
+					map[string]interface{}{"labels": map[string]interface{}{"app": "db&cache"}}},
+				"schemaVersion": 2.0},
+		},
+	} {
+		migrated, changed, err := MigrateConfig(test.config)
+		assert.NoError(t, err, test.name)
+		assert.True(t, changed, test.name)
+
+		actual := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(migrated), &actual), test.name)
+		assert.Equal(t, test.expected, actual, test.name)
+
+		// Migrating again changes nothing
+		again, changed, err := MigrateConfig(migrated)
+		assert.NoError(t, err, test.name)
+		assert.False(t, changed, test.name)
+		assert.Equal(t, migrated, again, test.name)
+	}
+}
+
+func TestMigrateConfigInvalid(t *testing.T) {
+
+	for _, config := range []string{
+		`{"version": 1, "storageDriverName": "ontap-nas", "schemaVersion": 3}`,
+		`{"version": 1, "storageDriverName": "ontap-nas", "schemaVersion": 0}`,
+		`{"version": 1, "storageDriverName": "ontap-nas", "schemaVersion": "2"}`,
+		`{"version": 1, "storageDriverName": "ontap-nas"`,
+	} {
+		_, _, err := MigrateConfig(config)
+		assert.Error(t, err, config)
+	}
+}
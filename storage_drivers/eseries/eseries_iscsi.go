@@ -3,6 +3,7 @@
 package eseries
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -430,7 +431,7 @@ func (d *SANStorageDriver) validate() error {
 // and disk media type may be provided in the opts map. If more than one pool on the storage controller can satisfy the request, the
 // one with the most free space is selected.
 func (d *SANStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
 	name := volConfig.InternalName
@@ -444,8 +445,8 @@ func (d *SANStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	// If the volume already exists, bail out
@@ -510,7 +511,7 @@ func (d *SANStorageDriver) Create(
 		pools, err := d.API.GetVolumePools(mediaType, sizeBytes, poolName)
 		if err != nil {
 			errMessage := fmt.Sprintf("E-series pool %s not found", poolName)
-			log.Error(errMessage)
+			utils.Logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, errors.New(errMessage))
 			continue
 		}
@@ -522,12 +523,12 @@ func (d *SANStorageDriver) Create(
 		vol, err := d.API.CreateVolume(name, pool.VolumeGroupRef, sizeBytes, mediaType, fstype)
 		if err != nil {
 			errMessage := fmt.Sprintf("E-series pool %s could not create volume %s: %v", poolName, name, err)
-			log.Error(errMessage)
+			utils.Logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, errors.New(errMessage))
 			continue
 		}
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"Name":          name,
 			"Size":          sizeBytes,
 			"MediaType":     mediaType,
@@ -589,7 +590,7 @@ func (d *SANStorageDriver) getPoolsForCreate(
 }
 
 // Destroy is called by Docker to delete a container volume.
-func (d *SANStorageDriver) Destroy(name string) error {
+func (d *SANStorageDriver) Destroy(ctx context.Context, name string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -597,8 +598,8 @@ func (d *SANStorageDriver) Destroy(name string) error {
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	var (
@@ -617,7 +618,7 @@ func (d *SANStorageDriver) Destroy(name string) error {
 		// Get target info
 		iSCSINodeName, _, err = d.getISCSITargetInfo()
 		if err != nil {
-			log.WithField("error", err).Error("Could not get target info.")
+			utils.Logc(ctx).WithField("error", err).Error("Could not get target info.")
 			return err
 		}
 
@@ -643,7 +644,7 @@ func (d *SANStorageDriver) Destroy(name string) error {
 	} else {
 
 		// If volume was deleted on this storage for any reason, don't fail it here.
-		log.WithField("Name", name).Warn("Could not find volume on array. Allowing deletion to proceed.")
+		utils.Logc(ctx).WithField("Name", name).Warn("Could not find volume on array. Allowing deletion to proceed.")
 	}
 
 	return nil
@@ -652,7 +653,7 @@ func (d *SANStorageDriver) Destroy(name string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *SANStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
 	name := volConfig.InternalName
 
@@ -662,8 +663,8 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	// Get the volume
@@ -686,13 +687,13 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 	for _, tag := range vol.VolumeTags {
 		if tag.Key == "fstype" {
 			fstype = tag.Value
-			log.WithFields(log.Fields{"LUN": name, "fstype": fstype}).Debug("Found LUN fstype.")
+			utils.Logc(ctx).WithFields(log.Fields{"LUN": name, "fstype": fstype}).Debug("Found LUN fstype.")
 			break
 		}
 	}
 	if fstype == "" {
 		fstype = drivers.DefaultFileSystemType
-		log.WithFields(log.Fields{"LUN": name, "fstype": fstype}).Warn("LUN fstype not found, using default.")
+		utils.Logc(ctx).WithFields(log.Fields{"LUN": name, "fstype": fstype}).Warn("LUN fstype not found, using default.")
 	}
 
 	var iqn string
@@ -847,7 +848,7 @@ func (d *SANStorageDriver) MapVolumeToLocalHost(volume api.VolumeEx) (api.LUNMap
 }
 
 // GetSnapshot returns a snapshot of a volume, or an error if it does not exist.
-func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -856,8 +857,8 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 			"snapshotName": snapConfig.InternalName,
 			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	return nil, drivers.NewSnapshotsNotSupportedError(d.Name())
@@ -865,7 +866,7 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 
 // SnapshotList returns the list of snapshots associated with the specified volume. The E-series volume
 // plugin does not support snapshots, so this method always returns an empty array.
-func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -873,8 +874,8 @@ func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 			"Type":       "SANStorageDriver",
 			"volumeName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	return make([]*storage.Snapshot, 0), nil
@@ -882,7 +883,7 @@ func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 
 // CreateSnapshot creates a snapshot for the given volume. The E-series volume plugin
 // does not support cloning or snapshots, so this method always returns an error.
-func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -891,15 +892,15 @@ func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 			"snapshotName": snapConfig.InternalName,
 			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
 	return nil, drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -908,15 +909,15 @@ func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) e
 			"snapshotName": snapConfig.InternalName,
 			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
 	return drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // DeleteSnapshot deletes a volume snapshot.
-func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -925,8 +926,8 @@ func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) er
 			"snapshotName": snapConfig.InternalName,
 			"volumeName":   snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
 	return drivers.NewSnapshotsNotSupportedError(d.Name())
@@ -934,7 +935,7 @@ func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) er
 
 // CreateClone creates a new volume from the named volume, either by direct clone or from the named snapshot. The E-series volume plugin
 // does not support cloning or snapshots, so this method always returns an error.
-func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+func (d *SANStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
 
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
@@ -948,18 +949,18 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 			"source":   source,
 			"snapshot": snapshot,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
 	return fmt.Errorf("cloning is not supported by backend type %s", d.Name())
 }
 
-func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *SANStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 	return errors.New("import is not implemented")
 }
 
-func (d *SANStorageDriver) Rename(name string, newName string) error {
+func (d *SANStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 	return errors.New("rename is not implemented")
 }
 
@@ -1115,7 +1116,7 @@ func (d *SANStorageDriver) GetVolumeOpts(
 	return opts, nil
 }
 
-func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *SANStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1124,12 +1125,12 @@ func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 			"name":         volConfig.Name,
 			"internalName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateFollowup")
-		defer log.WithFields(fields).Debug("<<<< CreateFollowup")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateFollowup")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateFollowup")
 	}
 
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
-		log.Debug("No follow-up create actions for Docker.")
+		utils.Logc(ctx).Debug("No follow-up create actions for Docker.")
 		return nil
 	}
 
@@ -1169,7 +1170,7 @@ func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 	volConfig.AccessInfo.IscsiTargetIQN = targetIQN
 	volConfig.AccessInfo.IscsiLunNumber = int32(mapping.LunNumber)
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"volume":          volConfig.Name,
 		"volume_internal": volConfig.InternalName,
 		"targetIQN":       volConfig.AccessInfo.IscsiTargetIQN,
@@ -1184,6 +1185,14 @@ func (d *SANStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.Block
 }
 
+func (d *SANStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.BlockAccessModes
+}
+
+func (d *SANStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.BlockVolumeExpansion
+}
+
 func (d *SANStorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
 	log.Debugln("EseriesStorageDriver:StoreConfig")
 
@@ -1352,7 +1361,7 @@ func (d *SANStorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bit
 
 // Resize expands the volume size. This method relies on the desired state model of Kubernetes
 // and will not work with Docker.
-func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *SANStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
 
 	name := volConfig.InternalName
 	vol, err := d.getVolume(name)
@@ -1380,7 +1389,7 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 	}
 
 	if sameSize {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"requestedSize":     sizeBytes,
 			"currentVolumeSize": volSizeBytes,
 			"name":              name,
@@ -1391,7 +1400,8 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 
 	if sizeBytes < volSizeBytes {
 
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes), nil)
 	}
 
 	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
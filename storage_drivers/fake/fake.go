@@ -4,6 +4,7 @@ package fake
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"encoding/json"
 	"errors"
@@ -39,6 +40,9 @@ const (
 	PVC_creating_01       = "creating-c44b-40f9-a0a2-a09172f1a1f6"
 	PVC_creating_02       = "creating-686e-4960-9135-b040c2d54332"
 	PVC_creating_clone_03 = "creating-382g-4ccj-k0k4-z88la30d9k22"
+
+	// The layout of fake volumes, which only other fake backends can copy
+	volumeCopyFormat = "fake"
 )
 
 type StorageDriver struct {
@@ -69,6 +73,11 @@ type StorageDriver struct {
 	// different driver instances with the same config won't actually share
 	// state.
 	DestroyedSnapshots map[string]bool
+
+	volumeMoveHandler storage.VolumeMoveHandler
+
+	// copyingVolumes names the volumes being copied from other backends
+	copyingVolumes map[string]bool
 }
 
 func NewFakeStorageBackend(configJSON string) (sb *storage.Backend, err error) {
@@ -217,7 +226,7 @@ func (d *StorageDriver) Initialize(
 			InternalName: volume.Name,
 			Size:         strconv.FormatUint(volume.SizeBytes, 10),
 		}
-		if err = d.Create(volConfig, requestedPool, make(map[string]sa.Request)); err != nil {
+		if err = d.Create(context.Background(), volConfig, requestedPool, make(map[string]sa.Request)); err != nil {
 			return fmt.Errorf("error creating volume %s; %v", volume.Name, err)
 		}
 
@@ -412,7 +421,7 @@ func (d *StorageDriver) validate() error {
 }
 
 func (d *StorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
 	name := volConfig.InternalName
@@ -459,7 +468,7 @@ func (d *StorageDriver) Create(
 		fakePool, ok := d.fakePools[physicalPool.Name]
 		if !ok {
 			errMessage := fmt.Sprintf("fake pool %s not found.", fakePoolName)
-			log.Error(errMessage)
+			utils.Logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, errors.New(errMessage))
 			continue
 		}
@@ -467,7 +476,7 @@ func (d *StorageDriver) Create(
 		if sizeBytes > fakePool.Bytes {
 			errMessage := fmt.Sprintf("requested volume is too large, requested %d bytes, "+
 				"have %d available in pool %s", sizeBytes, fakePool.Bytes, fakePoolName)
-			log.Error(errMessage)
+			utils.Logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, errors.New(errMessage))
 			continue
 		}
@@ -486,7 +495,7 @@ func (d *StorageDriver) Create(
 		d.DestroyedVolumes[name] = false
 		fakePool.Bytes -= sizeBytes
 
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"backend":       d.Config.InstanceName,
 			"name":          name,
 			"requestedPool": storagePool.Name,
@@ -570,14 +579,14 @@ func (d *StorageDriver) BootstrapVolume(volume *storage.Volume) {
 		"sizeBytes":     volume.Config.Size,
 	}
 
-	if err := d.Create(volume.Config, pool, volAttrs); err != nil {
+	if err := d.Create(context.Background(), volume.Config, pool, volAttrs); err != nil {
 		log.WithFields(logFields).Error("Failed to bootstrap fake volume.")
 	} else {
 		log.WithFields(logFields).Debug("Bootstrapped fake volume.")
 	}
 }
 
-func (d *StorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *storage.Pool) error {
+func (d *StorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, _ *storage.Pool) error {
 
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
@@ -622,7 +631,7 @@ func (d *StorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *storage.
 	d.DestroyedVolumes[name] = false
 	fakePool.Bytes -= sizeBytes
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":       d.Config.InstanceName,
 		"Name":          name,
 		"source":        sourceVolume.Name,
@@ -635,9 +644,9 @@ func (d *StorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *storage.
 	return nil
 }
 
-func (d *StorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *StorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"volumeConfig": volConfig,
 		"originalName": originalName,
 	}).Debug("Import")
@@ -657,9 +666,24 @@ func (d *StorageDriver) Import(volConfig *storage.VolumeConfig, originalName str
 	return nil
 }
 
-func (d *StorageDriver) Rename(name string, newName string) error {
+// ListImportCandidates returns every volume on the fake storage system.
+func (d *StorageDriver) ListImportCandidates(ctx context.Context) ([]*storage.ImportCandidate, error) {
 
-	log.WithFields(log.Fields{
+	candidates := make([]*storage.ImportCandidate, 0, len(d.Volumes))
+	for name, volume := range d.Volumes {
+		candidates = append(candidates, &storage.ImportCandidate{
+			InternalName: name,
+			Size:         strconv.FormatUint(volume.SizeBytes, 10),
+			Pool:         volume.PhysicalPool,
+			Protocol:     d.Config.Protocol,
+		})
+	}
+	return candidates, nil
+}
+
+func (d *StorageDriver) Rename(ctx context.Context, name string, newName string) error {
+
+	utils.Logc(ctx).WithFields(log.Fields{
 		"name":    name,
 		"newName": newName,
 	}).Debug("Rename")
@@ -675,7 +699,7 @@ func (d *StorageDriver) Rename(name string, newName string) error {
 	return nil
 }
 
-func (d *StorageDriver) Destroy(name string) error {
+func (d *StorageDriver) Destroy(ctx context.Context, name string) error {
 
 	d.DestroyedVolumes[name] = true
 
@@ -694,7 +718,7 @@ func (d *StorageDriver) Destroy(name string) error {
 	delete(d.Volumes, name)
 	delete(d.Snapshots, name)
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":       d.Config.InstanceName,
 		"Name":          name,
 		"requestedPool": volume.RequestedPool,
@@ -705,13 +729,13 @@ func (d *StorageDriver) Destroy(name string) error {
 	return nil
 }
 
-func (d *StorageDriver) Publish(_ *storage.VolumeConfig, _ *utils.VolumePublishInfo) error {
+func (d *StorageDriver) Publish(ctx context.Context, _ *storage.VolumeConfig, _ *utils.VolumePublishInfo) error {
 	return errors.New("fake driver does not support Publish")
 }
 
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *StorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *StorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -733,7 +757,7 @@ func (d *StorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storag
 }
 
 // GetSnapshots returns the list of snapshots associated with the specified volume
-func (d *StorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *StorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	internalVolName := volConfig.InternalName
 
@@ -753,7 +777,7 @@ func (d *StorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storag
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *StorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *StorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -765,8 +789,8 @@ func (d *StorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
 	// Ensure source volume exists
@@ -793,7 +817,7 @@ func (d *StorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 	d.Snapshots[internalVolName][internalSnapName] = snapshot
 	d.DestroyedSnapshots[snapConfig.ID()] = false
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"backend":      d.Config.InstanceName,
 		"snapshotName": internalSnapName,
 		"sourceVolume": internalVolName,
@@ -802,6 +826,61 @@ func (d *StorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 	return snapshot, nil
 }
 
+// CreateGroupSnapshot creates a snapshot of each of the given volumes, all with the same creation time
+func (d *StorageDriver) CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*storage.SnapshotConfig,
+) ([]*storage.Snapshot, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "StorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	// Ensure all source volumes exist and none has a snapshot of the same name, so that either all
+	// snapshots are created or none are
+	for _, snapConfig := range snapConfigs {
+		if _, ok := d.Volumes[snapConfig.VolumeInternalName]; !ok {
+			return nil, fmt.Errorf("source volume %s not found", snapConfig.VolumeInternalName)
+		}
+		if _, ok := d.Snapshots[snapConfig.VolumeInternalName][snapConfig.InternalName]; ok {
+			return nil, fmt.Errorf("snapshot %s already exists", snapConfig.InternalName)
+		}
+	}
+
+	created := time.Now().UTC().Format(storage.SnapshotTimestampFormat)
+	snapshots := make([]*storage.Snapshot, 0, len(snapConfigs))
+
+	for _, snapConfig := range snapConfigs {
+		internalVolName := snapConfig.VolumeInternalName
+
+		if _, ok := d.Snapshots[internalVolName]; !ok {
+			d.Snapshots[internalVolName] = make(map[string]*storage.Snapshot)
+		}
+
+		snapshot := &storage.Snapshot{
+			Config:    snapConfig,
+			Created:   created,
+			SizeBytes: int64(d.Volumes[internalVolName].SizeBytes),
+		}
+		d.Snapshots[internalVolName][snapConfig.InternalName] = snapshot
+		d.DestroyedSnapshots[snapConfig.ID()] = false
+		snapshots = append(snapshots, snapshot)
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":      d.Config.InstanceName,
+		"snapshotName": snapConfigs[0].InternalName,
+		"snapshots":    len(snapshots),
+	}).Info("Created fake group snapshot.")
+
+	return snapshots, nil
+}
+
 func (d *StorageDriver) BootstrapSnapshot(snapshot *storage.Snapshot) {
 
 	logFields := log.Fields{
@@ -810,7 +889,7 @@ func (d *StorageDriver) BootstrapSnapshot(snapshot *storage.Snapshot) {
 		"sourceVolume": snapshot.Config.VolumeInternalName,
 	}
 
-	if newSnapshot, err := d.CreateSnapshot(snapshot.Config); err != nil {
+	if newSnapshot, err := d.CreateSnapshot(context.Background(), snapshot.Config); err != nil {
 		log.WithFields(logFields).Error("Failed to bootstrap fake snapshot.")
 	} else {
 		newSnapshot.Created = snapshot.Created
@@ -819,7 +898,7 @@ func (d *StorageDriver) BootstrapSnapshot(snapshot *storage.Snapshot) {
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *StorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *StorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -841,7 +920,7 @@ func (d *StorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) erro
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *StorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *StorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -870,8 +949,164 @@ func (d *StorageDriver) Get(name string) error {
 	return nil
 }
 
+// ResumeVolumeCreate confirms that a fake volume left behind by an interrupted create exists.
+func (d *StorageDriver) ResumeVolumeCreate(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	if _, ok := d.Volumes[volConfig.InternalName]; !ok {
+		return utils.NotFoundError(fmt.Sprintf("could not find volume %s", volConfig.InternalName))
+	}
+	return nil
+}
+
+// UpdateVolume accepts changes to an existing volume's attributes
+func (d *StorageDriver) UpdateVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, updateRequest *storage.UpdateVolumeRequest,
+) error {
+
+	if _, ok := d.Volumes[volConfig.InternalName]; !ok {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", volConfig.InternalName))
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":           d.Config.InstanceName,
+		"name":              volConfig.InternalName,
+		"snapshotDirectory": updateRequest.SnapshotDirectory,
+		"coolingDays":       updateRequest.TieringMinimumCoolingDays,
+	}).Info("Updated fake volume.")
+
+	return nil
+}
+
+// MoveVolume moves a fake volume to another physical pool, completing the move at once
+func (d *StorageDriver) MoveVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, destinationPool string,
+) error {
+
+	name := volConfig.InternalName
+	vol, ok := d.Volumes[name]
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", name))
+	}
+	fakePool, ok := d.fakePools[destinationPool]
+	if !ok {
+		return fmt.Errorf("fake pool %s not found", destinationPool)
+	}
+	if vol.PhysicalPool == destinationPool {
+		return fmt.Errorf("volume %s is already in pool %s", name, destinationPool)
+	}
+	if vol.SizeBytes > fakePool.Bytes {
+		return fmt.Errorf("volume is too large to move, requires %d bytes, have %d available in pool %s",
+			vol.SizeBytes, fakePool.Bytes, destinationPool)
+	}
+
+	if sourcePool, ok := d.fakePools[vol.PhysicalPool]; ok {
+		sourcePool.Bytes += vol.SizeBytes
+	}
+	fakePool.Bytes -= vol.SizeBytes
+
+	status := &storage.VolumeMoveStatus{
+		State:           storage.VolumeMoveStateComplete,
+		SourcePool:      vol.PhysicalPool,
+		DestinationPool: destinationPool,
+		PercentComplete: 100,
+	}
+	vol.PhysicalPool = destinationPool
+	d.Volumes[name] = vol
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":     d.Config.InstanceName,
+		"name":        name,
+		"source":      status.SourcePool,
+		"destination": destinationPool,
+	}).Info("Moved fake volume.")
+
+	if d.volumeMoveHandler != nil {
+		d.volumeMoveHandler(name, status)
+	}
+	return nil
+}
+
+// GetCopySource describes a fake volume, or one of its snapshots, as the source of a copy by another
+// fake backend.
+func (d *StorageDriver) GetCopySource(
+	_ context.Context, volConfig *storage.VolumeConfig, snapshotName string,
+) (*storage.VolumeCopySource, error) {
+
+	name := volConfig.InternalName
+	vol, ok := d.Volumes[name]
+	if !ok {
+		return nil, utils.NotFoundError(fmt.Sprintf("volume %s not found", name))
+	}
+	if _, ok = d.Snapshots[name][snapshotName]; snapshotName != "" && !ok {
+		return nil, utils.NotFoundError(fmt.Sprintf("snapshot %s of volume %s not found", snapshotName, name))
+	}
+
+	return &storage.VolumeCopySource{
+		Format:    volumeCopyFormat,
+		SVM:       d.Config.InstanceName,
+		Volume:    name,
+		Snapshot:  snapshotName,
+		UsedBytes: vol.SizeBytes,
+	}, nil
+}
+
+// CreateCopy creates a fake volume holding a copy of a volume on another fake backend.  Like the copies
+// made by storage systems, each copy outlasts the request that begins it, finishing when it is retried.
+func (d *StorageDriver) CreateCopy(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool,
+	source *storage.VolumeCopySource,
+) error {
+
+	if source.Format != volumeCopyFormat {
+		return utils.UnsupportedError(fmt.Sprintf("fake backends cannot copy volumes in %s format",
+			source.Format))
+	}
+
+	name := volConfig.InternalName
+	if d.copyingVolumes == nil {
+		d.copyingVolumes = make(map[string]bool)
+	}
+	if !d.copyingVolumes[name] {
+		d.copyingVolumes[name] = true
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being copied", name))
+	}
+
+	if err := d.Create(ctx, volConfig, storagePool, make(map[string]sa.Request)); err != nil {
+		return err
+	}
+	delete(d.copyingVolumes, name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"backend":       d.Config.InstanceName,
+		"name":          name,
+		"sourceBackend": source.SVM,
+		"source":        source.Volume,
+	}).Debug("Copied fake volume.")
+
+	return nil
+}
+
+// ReleaseCopySource does nothing, since fake backends keep nothing for finished copies
+func (d *StorageDriver) ReleaseCopySource(_ context.Context, _, _ *storage.VolumeCopySource) error {
+	return nil
+}
+
+// GetVolumeMoveStatus returns nil, since fake volume moves finish as soon as they begin
+func (d *StorageDriver) GetVolumeMoveStatus(name string) *storage.VolumeMoveStatus {
+	return nil
+}
+
+// SetVolumeMoveHandler registers a function to be called whenever a fake volume move completes
+func (d *StorageDriver) SetVolumeMoveHandler(handler storage.VolumeMoveHandler) {
+	d.volumeMoveHandler = handler
+}
+
+// ResumeVolumeMove does nothing, since fake volume moves finish as soon as they begin
+func (d *StorageDriver) ResumeVolumeMove(_ context.Context, _ *storage.VolumeConfig) error {
+	return nil
+}
+
 // Resize expands the volume size.
-func (d *StorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *StorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
 
 	name := volConfig.InternalName
 	vol := d.Volumes[name]
@@ -881,7 +1116,8 @@ func (d *StorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64
 	}
 
 	if sizeBytes < vol.SizeBytes {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, vol.SizeBytes)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, vol.SizeBytes), nil)
 	} else {
 		vol.SizeBytes = sizeBytes
 		d.Volumes[name] = vol
@@ -946,7 +1182,7 @@ func (d *StorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
 	volConfig.InternalName = d.GetInternalVolumeName(volConfig.Name)
 }
 
-func (d *StorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *StorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	switch d.Config.Protocol {
 	case tridentconfig.File:
@@ -965,6 +1201,22 @@ func (d *StorageDriver) GetProtocol() tridentconfig.Protocol {
 	return d.Config.Protocol
 }
 
+func (d *StorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	if d.Config.Protocol == tridentconfig.Block {
+		return drivers.BlockAccessModes
+	}
+	return drivers.FileAccessModes
+}
+
+func (d *StorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	expansion := drivers.FileVolumeExpansion
+	if d.Config.Protocol == tridentconfig.Block {
+		expansion = drivers.BlockVolumeExpansion
+	}
+	expansion.Offline = d.Config.OfflineExpansion
+	return expansion
+}
+
 func (d *StorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
 
 	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
@@ -0,0 +1,738 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package fakeontap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+	log "github.com/sirupsen/logrus"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	MinimumVolumeSizeBytes = 20971520 // 20 MiB, as for ONTAP FlexVols
+
+	defaultSVM            = "fake-svm"
+	defaultDataLIF        = "192.0.2.1" // unrouteable test address, see RFC 5737
+	defaultIgroupName     = "trident"
+	defaultSnapshotPolicy = "none"
+
+	// Constants for internal pool attributes
+	Size = "size"
+)
+
+// StorageDriver is the fake-ontap driver, which provisions FlexVols, LUNs, and snapshots on an SVM modeled
+// in memory, so that complete workflows may be exercised without an ONTAP cluster.  Drivers configured with
+// the same SVM name share its state.
+type StorageDriver struct {
+	initialized   bool
+	Config        drivers.FakeOntapStorageDriverConfig
+	SVM           *SVM
+	physicalPools map[string]*storage.Pool
+}
+
+func (d *StorageDriver) Name() string {
+	return drivers.FakeOntapStorageDriverName
+}
+
+func (d *StorageDriver) Initialize(
+	_ tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
+) error {
+
+	if commonConfig.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Initialize", "Type": "StorageDriver"}
+		log.WithFields(fields).Debug(">>>> Initialize")
+		defer log.WithFields(fields).Debug("<<<< Initialize")
+	}
+
+	d.Config.CommonStorageDriverConfig = commonConfig
+	if err := json.Unmarshal([]byte(configJSON), &d.Config); err != nil {
+		return fmt.Errorf("unable to initialize fake-ontap driver: %v", err)
+	}
+
+	d.populateConfigurationDefaults(&d.Config)
+
+	if err := d.validate(); err != nil {
+		return fmt.Errorf("error validating %s driver. %v", d.Name(), err)
+	}
+
+	d.SVM = GetSVM(d.Config.SVM)
+	d.SVM.AddAggregates(d.Config.Aggregates)
+	d.Config.SerialNumbers = []string{d.Config.SVM + "_SN"}
+
+	d.initializeStoragePools()
+
+	d.initialized = true
+	return nil
+}
+
+func (d *StorageDriver) Initialized() bool {
+	return d.initialized
+}
+
+func (d *StorageDriver) Terminate(string) {
+	d.initialized = false
+}
+
+// populateConfigurationDefaults fills in default values for configuration settings if not supplied in the config file
+func (d *StorageDriver) populateConfigurationDefaults(config *drivers.FakeOntapStorageDriverConfig) {
+
+	if config.StoragePrefix == nil {
+		prefix := drivers.GetDefaultStoragePrefix(config.DriverContext)
+		config.StoragePrefix = &prefix
+		config.StoragePrefixRaw = json.RawMessage("\"" + *config.StoragePrefix + "\"")
+	}
+	if config.SVM == "" {
+		config.SVM = defaultSVM
+	}
+	if config.Protocol == "" {
+		config.Protocol = tridentconfig.File
+	}
+	if config.DataLIF == "" {
+		config.DataLIF = defaultDataLIF
+	}
+	if config.Size == "" {
+		config.Size = drivers.DefaultVolumeSize
+	}
+	if config.SnapshotPolicy == "" {
+		config.SnapshotPolicy = defaultSnapshotPolicy
+	}
+	if config.ExportPolicy == "" {
+		config.ExportPolicy = "default"
+	}
+
+	log.WithFields(log.Fields{
+		"SVM":            config.SVM,
+		"Protocol":       config.Protocol,
+		"Size":           config.Size,
+		"SnapshotPolicy": config.SnapshotPolicy,
+		"ExportPolicy":   config.ExportPolicy,
+	}).Debugf("Configuration defaults")
+}
+
+// validate ensures the driver configuration is valid
+func (d *StorageDriver) validate() error {
+
+	switch d.Config.Protocol {
+	case tridentconfig.File, tridentconfig.Block:
+	default:
+		return fmt.Errorf("invalid protocol %s; must be %s or %s",
+			d.Config.Protocol, tridentconfig.File, tridentconfig.Block)
+	}
+
+	if len(d.Config.Aggregates) == 0 {
+		return errors.New("at least one aggregate is required")
+	}
+	for _, aggr := range d.Config.Aggregates {
+		if aggr.Name == "" {
+			return errors.New("every aggregate must have a name")
+		}
+		switch aggr.Media {
+		case "", sa.HDD, sa.SSD, sa.Hybrid:
+		default:
+			return fmt.Errorf("invalid media %s for aggregate %s", aggr.Media, aggr.Name)
+		}
+	}
+
+	if _, err := utils.ConvertSizeToBytes(d.Config.Size); err != nil {
+		return fmt.Errorf("invalid value for default volume size: %v", err)
+	}
+
+	return nil
+}
+
+// initializeStoragePools defines a physical pool for each aggregate
+func (d *StorageDriver) initializeStoragePools() {
+
+	d.physicalPools = make(map[string]*storage.Pool)
+
+	for _, aggr := range d.Config.Aggregates {
+
+		pool := storage.NewStoragePool(nil, aggr.Name)
+
+		pool.Attributes[sa.BackendType] = sa.NewStringOffer(d.Name())
+		pool.Attributes[sa.Snapshots] = sa.NewBoolOffer(true)
+		pool.Attributes[sa.Clones] = sa.NewBoolOffer(true)
+		pool.Attributes[sa.Encryption] = sa.NewBoolOffer(false)
+		pool.Attributes[sa.ProvisioningType] = sa.NewStringOffer("thick", "thin")
+		pool.Attributes[sa.Labels] = sa.NewLabelOffer(d.Config.Labels)
+		if aggr.Media != "" {
+			pool.Attributes[sa.Media] = sa.NewStringOffer(aggr.Media)
+		}
+
+		pool.InternalAttributes[Size] = d.Config.Size
+
+		d.physicalPools[pool.Name] = pool
+	}
+}
+
+// LogFields adds the SVM to the messages logged for operations on this backend
+func (d *StorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+func (d *StorageDriver) Create(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, _ map[string]sa.Request,
+) error {
+
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Create", "Type": "StorageDriver", "name": name}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Create")
+	}
+
+	if _, ok := d.SVM.GetVolume(name); ok {
+		return drivers.NewVolumeExistsError(name)
+	}
+
+	if storagePool == nil {
+		return errors.New("a storage pool is required")
+	}
+	if _, ok := d.physicalPools[storagePool.Name]; !ok {
+		return fmt.Errorf("could not find pool %s", storagePool.Name)
+	}
+
+	// Determine volume size in bytes
+	requestedSize, err := utils.ConvertSizeToBytes(volConfig.Size)
+	if err != nil {
+		return fmt.Errorf("could not convert volume size %s: %v", volConfig.Size, err)
+	}
+	sizeBytes, err := strconv.ParseUint(requestedSize, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%v is an invalid volume size: %v", volConfig.Size, err)
+	}
+	if sizeBytes == 0 {
+		defaultSize, _ := utils.ConvertSizeToBytes(storagePool.InternalAttributes[Size])
+		sizeBytes, _ = strconv.ParseUint(defaultSize, 10, 64)
+	}
+	if sizeBytes < MinimumVolumeSizeBytes {
+		return fmt.Errorf("requested volume size (%d bytes) is too small; the minimum volume size is %d bytes",
+			sizeBytes, MinimumVolumeSizeBytes)
+	}
+	if _, _, err = drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); err != nil {
+		return err
+	}
+
+	snapshotPolicy := d.Config.SnapshotPolicy
+	if volConfig.SnapshotPolicy != "" {
+		snapshotPolicy = volConfig.SnapshotPolicy
+	}
+	exportPolicy := d.Config.ExportPolicy
+	if volConfig.ExportPolicy != "" {
+		exportPolicy = volConfig.ExportPolicy
+	}
+
+	err = d.SVM.CreateVolume(name, storagePool.Name, sizeBytes, exportPolicy, snapshotPolicy,
+		d.Config.Protocol == tridentconfig.Block)
+	if err != nil {
+		if drivers.IsVolumeExistsError(err) {
+			return err
+		}
+		return drivers.NewBackendIneligibleError(name, []error{err}, []string{storagePool.Name})
+	}
+
+	volConfig.Size = strconv.FormatUint(sizeBytes, 10)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"svm":       d.Config.SVM,
+		"name":      name,
+		"aggregate": storagePool.Name,
+		"sizeBytes": sizeBytes,
+	}).Debug("Created fake-ontap volume.")
+
+	return nil
+}
+
+// CreateClone creates a volume from a snapshot of another.  If no snapshot is named, one is taken first,
+// as the ONTAP drivers do.
+func (d *StorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, _ *storage.Pool) error {
+
+	name := volConfig.InternalName
+	source := volConfig.CloneSourceVolumeInternal
+	snapshot := volConfig.CloneSourceSnapshot
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":   "CreateClone",
+			"Type":     "StorageDriver",
+			"name":     name,
+			"source":   source,
+			"snapshot": snapshot,
+		}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
+	}
+
+	if snapshot == "" {
+		snapshot = time.Now().UTC().Format(storage.SnapshotNameFormat)
+		if _, err := d.SVM.CreateSnapshot(source, snapshot, time.Now().UTC().Format(
+			storage.SnapshotTimestampFormat)); err != nil {
+			return err
+		}
+		volConfig.CloneSourceSnapshot = snapshot
+	}
+
+	if err := d.SVM.CloneVolume(name, source, snapshot); err != nil {
+		return err
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"svm":      d.Config.SVM,
+		"name":     name,
+		"source":   source,
+		"snapshot": snapshot,
+	}).Debug("Cloned fake-ontap volume.")
+
+	return nil
+}
+
+func (d *StorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"volumeConfig": volConfig,
+		"originalName": originalName,
+	}).Debug("Import")
+
+	volume, ok := d.SVM.GetVolume(originalName)
+	if !ok {
+		return fmt.Errorf("volume %s not found", originalName)
+	}
+	if (d.Config.Protocol == tridentconfig.Block) != (volume.LUN != nil) {
+		return fmt.Errorf("volume %s cannot be imported by a %s backend", originalName, d.Config.Protocol)
+	}
+
+	volConfig.Size = strconv.FormatUint(volume.SizeBytes, 10)
+
+	if !volConfig.ImportNotManaged {
+		if err := d.SVM.RenameVolume(originalName, volConfig.InternalName); err != nil {
+			return err
+		}
+	} else {
+		volConfig.InternalName = originalName
+	}
+
+	return nil
+}
+
+func (d *StorageDriver) Rename(ctx context.Context, name string, newName string) error {
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"name":    name,
+		"newName": newName,
+	}).Debug("Rename")
+
+	return d.SVM.RenameVolume(name, newName)
+}
+
+// Destroy deletes a volume along with its snapshots.  Destroying a volume that doesn't exist succeeds.
+func (d *StorageDriver) Destroy(ctx context.Context, name string) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Destroy", "Type": "StorageDriver", "name": name}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Destroy")
+	}
+
+	d.SVM.DeleteVolume(name)
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"svm":  d.Config.SVM,
+		"name": name,
+	}).Debug("Deleted fake-ontap volume.")
+
+	return nil
+}
+
+// Publish grants a node access to a volume, adding the node's addresses to the volume's export policy
+// or the node's initiators to the backend's igroup, and fills in what the node needs to attach it.
+func (d *StorageDriver) Publish(
+	ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) error {
+
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Publish", "Type": "StorageDriver", "name": name}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Publish")
+	}
+
+	volume, ok := d.SVM.GetVolume(name)
+	if !ok {
+		return fmt.Errorf("volume %s not found", name)
+	}
+
+	switch d.Config.Protocol {
+	case tridentconfig.File:
+		d.SVM.AddExportRules(volume.ExportPolicy, publishInfo.HostIP)
+		publishInfo.NfsServerIP = d.Config.DataLIF
+		publishInfo.NfsPath = "/" + name
+		publishInfo.FilesystemType = "nfs"
+		publishInfo.MountOptions = volConfig.MountOptions
+
+	case tridentconfig.Block:
+		lunID, serial, err := d.SVM.MapLUN(name, defaultIgroupName, publishInfo.HostIQN)
+		if err != nil {
+			return err
+		}
+		fstype := volConfig.FileSystem
+		if fstype == "" {
+			fstype = drivers.DefaultFileSystemType
+		}
+		publishInfo.IscsiTargetPortal = d.Config.DataLIF
+		publishInfo.IscsiPortals = []string{}
+		publishInfo.IscsiTargetIQN = d.targetIQN()
+		publishInfo.IscsiLunNumber = int32(lunID)
+		publishInfo.IscsiLunSerial = serial
+		publishInfo.IscsiIgroup = defaultIgroupName
+		publishInfo.FilesystemType = fstype
+		publishInfo.UseCHAP = false
+		publishInfo.SharedTarget = true
+	}
+
+	return nil
+}
+
+// targetIQN returns the iSCSI target name of the modeled SVM
+func (d *StorageDriver) targetIQN() string {
+	return "iqn.1992-08.com.netapp:sn.fakeontap:vs." + d.Config.SVM
+}
+
+// GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
+// and a non-existent snapshot, this method may return (nil, nil).
+func (d *StorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	volume, ok := d.SVM.GetVolume(snapConfig.VolumeInternalName)
+	if !ok {
+		return nil, fmt.Errorf("volume %s not found", snapConfig.VolumeInternalName)
+	}
+
+	snapshot, ok := volume.Snapshots[snapConfig.InternalName]
+	if !ok {
+		return nil, nil
+	}
+	return &storage.Snapshot{
+		Config:    snapConfig,
+		Created:   snapshot.Created,
+		SizeBytes: snapshot.SizeBytes,
+	}, nil
+}
+
+// GetSnapshots returns the list of snapshots associated with the specified volume
+func (d *StorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	volume, ok := d.SVM.GetVolume(volConfig.InternalName)
+	if !ok {
+		return nil, fmt.Errorf("volume %s not found", volConfig.InternalName)
+	}
+
+	snapshots := make([]*storage.Snapshot, 0, len(volume.Snapshots))
+	for _, snapshot := range volume.Snapshots {
+		snapshots = append(snapshots, &storage.Snapshot{
+			Config: &storage.SnapshotConfig{
+				Version:            tridentconfig.OrchestratorAPIVersion,
+				Name:               snapshot.Name,
+				InternalName:       snapshot.Name,
+				VolumeName:         volConfig.Name,
+				VolumeInternalName: volConfig.InternalName,
+			},
+			Created:   snapshot.Created,
+			SizeBytes: snapshot.SizeBytes,
+		})
+	}
+	return snapshots, nil
+}
+
+// CreateSnapshot creates a snapshot for the given volume
+func (d *StorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateSnapshot",
+			"Type":         "StorageDriver",
+			"snapshotName": internalSnapName,
+			"volumeName":   internalVolName,
+		}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	snapshot, err := d.SVM.CreateSnapshot(internalVolName, internalSnapName,
+		time.Now().UTC().Format(storage.SnapshotTimestampFormat))
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Logc(ctx).WithFields(log.Fields{
+		"svm":          d.Config.SVM,
+		"snapshotName": internalSnapName,
+		"sourceVolume": internalVolName,
+	}).Info("Created fake-ontap snapshot.")
+
+	return &storage.Snapshot{
+		Config:    snapConfig,
+		Created:   snapshot.Created,
+		SizeBytes: snapshot.SizeBytes,
+	}, nil
+}
+
+// RestoreSnapshot restores a volume (in place) from a snapshot.
+func (d *StorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "RestoreSnapshot",
+			"Type":         "StorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
+	}
+
+	return d.SVM.RestoreSnapshot(snapConfig.VolumeInternalName, snapConfig.InternalName)
+}
+
+// DeleteSnapshot deletes a snapshot of a volume.  Deleting a snapshot that doesn't exist succeeds.
+func (d *StorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "DeleteSnapshot",
+			"Type":         "StorageDriver",
+			"snapshotName": snapConfig.InternalName,
+			"volumeName":   snapConfig.VolumeInternalName,
+		}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	d.SVM.DeleteSnapshot(snapConfig.VolumeInternalName, snapConfig.InternalName)
+	return nil
+}
+
+func (d *StorageDriver) Get(name string) error {
+	if _, ok := d.SVM.GetVolume(name); !ok {
+		return fmt.Errorf("could not find volume %s", name)
+	}
+	return nil
+}
+
+// Resize expands the volume size.
+func (d *StorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Resize", "Type": "StorageDriver", "name": name, "sizeBytes": sizeBytes}
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Resize")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Resize")
+	}
+
+	if _, _, err := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); err != nil {
+		return err
+	}
+	if err := d.SVM.ResizeVolume(name, sizeBytes); err != nil {
+		return err
+	}
+
+	volConfig.Size = strconv.FormatUint(sizeBytes, 10)
+	return nil
+}
+
+func (d *StorageDriver) GetStorageBackendSpecs(backend *storage.Backend) error {
+
+	if d.Config.BackendName == "" {
+		backend.Name = d.Name() + "_" + d.Config.SVM
+	} else {
+		backend.Name = d.Config.BackendName
+	}
+
+	for _, pool := range d.physicalPools {
+		pool.Backend = backend
+		backend.AddStoragePool(pool)
+	}
+
+	return nil
+}
+
+// Retrieve storage backend physical pools
+func (d *StorageDriver) GetStorageBackendPhysicalPoolNames() []string {
+	physicalPoolNames := make([]string, 0, len(d.physicalPools))
+	for poolName := range d.physicalPools {
+		physicalPoolNames = append(physicalPoolNames, poolName)
+	}
+	return physicalPoolNames
+}
+
+func (d *StorageDriver) GetInternalVolumeName(name string) string {
+	if tridentconfig.UsingPassthroughStore {
+		// With a passthrough store, the name mapping must remain reversible
+		return *d.Config.StoragePrefix + name
+	} else {
+		// With an external store, any transformation of the name is fine
+		internal := drivers.GetCommonInternalVolumeName(d.Config.CommonStorageDriverConfig, name)
+		internal = strings.Replace(internal, "-", "_", -1)  // ONTAP disallows hyphens
+		internal = strings.Replace(internal, ".", "_", -1)  // ONTAP disallows periods
+		internal = strings.Replace(internal, "__", "_", -1) // Remove any double underscores
+		return internal
+	}
+}
+
+func (d *StorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
+	volConfig.InternalName = d.GetInternalVolumeName(volConfig.Name)
+}
+
+func (d *StorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
+
+	volume, ok := d.SVM.GetVolume(volConfig.InternalName)
+	if !ok {
+		return fmt.Errorf("volume %s not found", volConfig.InternalName)
+	}
+
+	switch d.Config.Protocol {
+	case tridentconfig.File:
+		volConfig.AccessInfo.NfsServerIP = d.Config.DataLIF
+		volConfig.AccessInfo.NfsPath = "/" + volConfig.InternalName
+		volConfig.AccessInfo.MountOptions = strings.TrimPrefix(volConfig.MountOptions, "-o ")
+		volConfig.FileSystem = ""
+	case tridentconfig.Block:
+		volConfig.AccessInfo.IscsiTargetPortal = d.Config.DataLIF
+		volConfig.AccessInfo.IscsiPortals = []string{}
+		volConfig.AccessInfo.IscsiTargetIQN = d.targetIQN()
+		volConfig.AccessInfo.IscsiLunSerial = volume.LUN.SerialNumber
+		volConfig.AccessInfo.IscsiIgroup = defaultIgroupName
+	}
+	return nil
+}
+
+func (d *StorageDriver) GetProtocol() tridentconfig.Protocol {
+	return d.Config.Protocol
+}
+
+func (d *StorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	if d.Config.Protocol == tridentconfig.Block {
+		return drivers.BlockAccessModes
+	}
+	return drivers.FileAccessModes
+}
+
+func (d *StorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	if d.Config.Protocol == tridentconfig.Block {
+		return drivers.BlockVolumeExpansion
+	}
+	return drivers.FileVolumeExpansion
+}
+
+func (d *StorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
+
+	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
+
+	config := d.Config
+	config.Aggregates = append([]drivers.FakeOntapAggregate(nil), d.Config.Aggregates...)
+	b.FakeOntapConfig = &config
+}
+
+func (d *StorageDriver) GetExternalConfig() interface{} {
+	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
+	return d.Config
+}
+
+func (d *StorageDriver) GetVolumeExternal(name string) (*storage.VolumeExternal, error) {
+
+	volume, ok := d.SVM.GetVolume(name)
+	if !ok {
+		return nil, fmt.Errorf("volume %s not found", name)
+	}
+
+	return d.getVolumeExternal(volume), nil
+}
+
+func (d *StorageDriver) GetVolumeExternalWrappers(channel chan *storage.VolumeExternalWrapper) {
+
+	// Let the caller know we're done by closing the channel
+	defer close(channel)
+
+	for _, volume := range d.SVM.ListVolumes(*d.Config.StoragePrefix) {
+		channel <- &storage.VolumeExternalWrapper{Volume: d.getVolumeExternal(volume), Error: nil}
+	}
+}
+
+func (d *StorageDriver) getVolumeExternal(volume *Volume) *storage.VolumeExternal {
+
+	internalName := volume.Name
+	name := strings.TrimPrefix(internalName, *d.Config.StoragePrefix)
+
+	volumeConfig := &storage.VolumeConfig{
+		Version:        tridentconfig.OrchestratorAPIVersion,
+		Name:           name,
+		InternalName:   internalName,
+		Size:           strconv.FormatUint(volume.SizeBytes, 10),
+		Protocol:       d.Config.Protocol,
+		SnapshotPolicy: volume.SnapshotPolicy,
+		ExportPolicy:   volume.ExportPolicy,
+		AccessMode:     tridentconfig.ReadWriteOnce,
+		AccessInfo:     utils.VolumeAccessInfo{},
+	}
+
+	return &storage.VolumeExternal{
+		Config: volumeConfig,
+		Pool:   volume.Aggregate,
+	}
+}
+
+// GetUpdateType returns a bitmap populated with updates to the driver
+func (d *StorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bitmap {
+
+	bitmap := roaring.New()
+	dOrig, ok := driverOrig.(*StorageDriver)
+	if !ok {
+		bitmap.Add(storage.InvalidUpdate)
+		return bitmap
+	}
+
+	if d.Config.SVM != dOrig.Config.SVM || d.Config.Protocol != dOrig.Config.Protocol {
+		bitmap.Add(storage.InvalidUpdate)
+	}
+	if d.Config.DataLIF != dOrig.Config.DataLIF {
+		bitmap.Add(storage.VolumeAccessInfoChange)
+	}
+
+	return bitmap
+}
+
+func (d *StorageDriver) ReconcileNodeAccess(nodes []*utils.Node, _ string) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		nodeNames := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			nodeNames = append(nodeNames, node.Name)
+		}
+		fields := log.Fields{
+			"Method": "ReconcileNodeAccess",
+			"Type":   "StorageDriver",
+			"Nodes":  nodeNames,
+		}
+		log.WithFields(fields).Debug(">>>> ReconcileNodeAccess")
+		defer log.WithFields(fields).Debug("<<<< ReconcileNodeAccess")
+	}
+
+	return nil
+}
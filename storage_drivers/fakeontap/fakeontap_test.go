@@ -0,0 +1,160 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package fakeontap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+func newTestDriver(t *testing.T, configJSON string) *StorageDriver {
+	commonConfig, err := drivers.ValidateCommonSettings(configJSON)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	driver := &StorageDriver{}
+	if err = driver.Initialize(tridentconfig.ContextCSI, configJSON, commonConfig); err != nil {
+		t.Fatalf("could not initialize driver: %v", err)
+	}
+	return driver
+}
+
+func newTestPool(driver *StorageDriver, name string) *storage.Pool {
+	return driver.physicalPools[name]
+}
+
+func TestInitializeValidation(t *testing.T) {
+	ResetSVMs()
+
+	for _, configJSON := range []string{
+		`{"version": 1, "storageDriverName": "fake-ontap"}`,
+		`{"version": 1, "storageDriverName": "fake-ontap", "protocol": "any", "aggregates": [{"name": "aggr1"}]}`,
+		`{"version": 1, "storageDriverName": "fake-ontap", "aggregates": [{"name": "aggr1", "media": "tape"}]}`,
+	} {
+		commonConfig, err := drivers.ValidateCommonSettings(configJSON)
+		assert.NoError(t, err)
+		driver := &StorageDriver{}
+		assert.Error(t, driver.Initialize(tridentconfig.ContextCSI, configJSON, commonConfig), configJSON)
+	}
+}
+
+func TestNASWorkflow(t *testing.T) {
+	ResetSVMs()
+	ctx := context.Background()
+
+	driver := newTestDriver(t, `{"version": 1, "storageDriverName": "fake-ontap", "svm": "svm1",
+		"storagePrefix": "trident_", "aggregates": [{"name": "aggr1", "sizeBytes": 10737418240, "media": "ssd"}]}`)
+	pool := newTestPool(driver, "aggr1")
+	assert.NotNil(t, pool)
+	assert.Equal(t, "ssd", pool.Attributes[sa.Media].ToString())
+
+	volConfig := &storage.VolumeConfig{Name: "pvc-1", Size: "1Gi"}
+	driver.CreatePrepare(volConfig)
+	assert.Equal(t, "trident_pvc_1", volConfig.InternalName)
+	assert.NoError(t, driver.Create(ctx, volConfig, pool, nil))
+	assert.True(t, drivers.IsVolumeExistsError(driver.Create(ctx, volConfig, pool, nil)))
+	assert.NoError(t, driver.CreateFollowup(ctx, volConfig))
+	assert.Equal(t, "/trident_pvc_1", volConfig.AccessInfo.NfsPath)
+
+	aggr, _ := driver.SVM.GetAggregate("aggr1")
+	assert.Equal(t, uint64(1073741824), aggr.UsedBytes)
+
+	// Too large for the aggregate
+	bigConfig := &storage.VolumeConfig{Name: "pvc-big", InternalName: "trident_pvc_big", Size: "20Gi"}
+	err := driver.Create(ctx, bigConfig, pool, nil)
+	_, ineligible := err.(*drivers.BackendIneligibleError)
+	assert.True(t, ineligible, "expected a BackendIneligibleError, got %v", err)
+
+	// Publishing adds the node to the export policy
+	publishInfo := &utils.VolumePublishInfo{HostIP: []string{"10.0.0.1"}}
+	assert.NoError(t, driver.Publish(ctx, volConfig, publishInfo))
+	assert.Equal(t, "nfs", publishInfo.FilesystemType)
+	policy, _ := driver.SVM.GetExportPolicy("default")
+	assert.Equal(t, []string{"10.0.0.1"}, policy.Rules)
+
+	// Snapshots, restore, and clone
+	snap1 := &storage.SnapshotConfig{Name: "snap1", InternalName: "snap1", VolumeInternalName: volConfig.InternalName}
+	snap2 := &storage.SnapshotConfig{Name: "snap2", InternalName: "snap2", VolumeInternalName: volConfig.InternalName}
+	_, err = driver.CreateSnapshot(ctx, snap1)
+	assert.NoError(t, err)
+	_, err = driver.CreateSnapshot(ctx, snap2)
+	assert.NoError(t, err)
+	assert.NoError(t, driver.RestoreSnapshot(ctx, snap1))
+	snapshots, err := driver.GetSnapshots(ctx, volConfig)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1, "restoring should delete the newer snapshot")
+
+	cloneConfig := &storage.VolumeConfig{
+		Name:                      "pvc-2",
+		InternalName:              "trident_pvc_2",
+		CloneSourceVolumeInternal: volConfig.InternalName,
+		CloneSourceSnapshot:       "snap1",
+	}
+	assert.NoError(t, driver.CreateClone(ctx, cloneConfig, pool))
+	assert.NoError(t, driver.Get("trident_pvc_2"))
+
+	// Resize
+	assert.NoError(t, driver.Resize(ctx, volConfig, 2147483648))
+	assert.Equal(t, "2147483648", volConfig.Size)
+	err = driver.Resize(ctx, volConfig, 1073741824)
+	assert.True(t, drivers.IsUnsupportedCapacityRangeError(err))
+
+	// A driver for the same SVM sees the same volumes
+	other := newTestDriver(t, `{"version": 1, "storageDriverName": "fake-ontap", "svm": "svm1",
+		"storagePrefix": "trident_", "aggregates": [{"name": "aggr1", "sizeBytes": 10737418240}]}`)
+	channel := make(chan *storage.VolumeExternalWrapper, 10)
+	other.GetVolumeExternalWrappers(channel)
+	names := make([]string, 0)
+	for wrapper := range channel {
+		names = append(names, wrapper.Volume.Config.InternalName)
+	}
+	assert.Equal(t, []string{"trident_pvc_1", "trident_pvc_2"}, names)
+
+	// Destroying is idempotent and releases the space
+	assert.NoError(t, driver.Destroy(ctx, "trident_pvc_2"))
+	assert.NoError(t, driver.Destroy(ctx, "trident_pvc_2"))
+	assert.NoError(t, driver.Destroy(ctx, volConfig.InternalName))
+	aggr, _ = driver.SVM.GetAggregate("aggr1")
+	assert.Equal(t, uint64(0), aggr.UsedBytes)
+}
+
+func TestSANPublish(t *testing.T) {
+	ResetSVMs()
+	ctx := context.Background()
+
+	driver := newTestDriver(t, `{"version": 1, "storageDriverName": "fake-ontap", "svm": "svm2",
+		"protocol": "block", "dataLIF": "10.1.1.1", "aggregates": [{"name": "aggr1", "sizeBytes": 10737418240}]}`)
+	pool := newTestPool(driver, "aggr1")
+
+	vol1 := &storage.VolumeConfig{Name: "vol1", InternalName: "trident_vol1", Size: "1Gi"}
+	vol2 := &storage.VolumeConfig{Name: "vol2", InternalName: "trident_vol2", Size: "1Gi"}
+	assert.NoError(t, driver.Create(ctx, vol1, pool, nil))
+	assert.NoError(t, driver.Create(ctx, vol2, pool, nil))
+
+	publishInfo1 := &utils.VolumePublishInfo{HostIQN: []string{"iqn.1993-08.org.debian:01:node1"}}
+	publishInfo2 := &utils.VolumePublishInfo{HostIQN: []string{"iqn.1993-08.org.debian:01:node1"}}
+	assert.NoError(t, driver.Publish(ctx, vol1, publishInfo1))
+	assert.NoError(t, driver.Publish(ctx, vol2, publishInfo2))
+
+	assert.Equal(t, "10.1.1.1", publishInfo1.IscsiTargetPortal)
+	assert.Equal(t, "ext4", publishInfo1.FilesystemType)
+	assert.Equal(t, int32(0), publishInfo1.IscsiLunNumber)
+	assert.Equal(t, int32(1), publishInfo2.IscsiLunNumber)
+	assert.NotEqual(t, publishInfo1.IscsiLunSerial, publishInfo2.IscsiLunSerial)
+
+	igroup, ok := driver.SVM.GetIgroup("trident")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"iqn.1993-08.org.debian:01:node1"}, igroup.Initiators)
+
+	// Publishing again keeps the same LUN ID
+	assert.NoError(t, driver.Publish(ctx, vol1, publishInfo1))
+	assert.Equal(t, int32(0), publishInfo1.IscsiLunNumber)
+}
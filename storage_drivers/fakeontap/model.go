@@ -0,0 +1,507 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package fakeontap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+// Aggregate models an ONTAP aggregate, whose space is consumed by the volumes placed on it.
+type Aggregate struct {
+	Name      string
+	Media     string
+	SizeBytes uint64
+	UsedBytes uint64
+}
+
+// AvailableBytes returns the space on the aggregate not yet consumed by any volume.
+func (a *Aggregate) AvailableBytes() uint64 {
+	if a.UsedBytes > a.SizeBytes {
+		return 0
+	}
+	return a.SizeBytes - a.UsedBytes
+}
+
+// Snapshot models a snapshot of a FlexVol.  Sequence orders the snapshots of a volume by creation.
+type Snapshot struct {
+	Name      string
+	Created   string
+	SizeBytes int64
+	Sequence  int
+}
+
+// LUN models the single LUN that the fake-ontap driver places in each FlexVol of a SAN backend.
+type LUN struct {
+	Path         string
+	SerialNumber string
+	SizeBytes    uint64
+	// Maps holds the LUN ID by which the LUN is mapped to each igroup.
+	Maps map[string]int
+}
+
+// Volume models a FlexVol.
+type Volume struct {
+	Name           string
+	Aggregate      string
+	SizeBytes      uint64
+	ExportPolicy   string
+	SnapshotPolicy string
+	Snapshots      map[string]*Snapshot
+	LUN            *LUN
+}
+
+// ExportPolicy models an NFS export policy, whose rules are the client addresses allowed to mount.
+type ExportPolicy struct {
+	Name  string
+	Rules []string
+}
+
+// Igroup models an iSCSI initiator group.
+type Igroup struct {
+	Name       string
+	Initiators []string
+}
+
+// SVM models an ONTAP SVM along with the aggregates assigned to it.  All of its methods are safe
+// for concurrent use.
+type SVM struct {
+	Name           string
+	aggregates     map[string]*Aggregate
+	volumes        map[string]*Volume
+	exportPolicies map[string]*ExportPolicy
+	igroups        map[string]*Igroup
+	sequence       int
+	mutex          sync.Mutex
+}
+
+var (
+	svms     = make(map[string]*SVM)
+	svmsLock sync.Mutex
+)
+
+// GetSVM returns the modeled SVM with the given name, creating it if necessary.  SVMs outlive the drivers
+// that use them, so a backend that is updated or bootstrapped again finds the volumes it created before.
+func GetSVM(name string) *SVM {
+
+	svmsLock.Lock()
+	defer svmsLock.Unlock()
+
+	svm, ok := svms[name]
+	if !ok {
+		svm = &SVM{
+			Name:           name,
+			aggregates:     make(map[string]*Aggregate),
+			volumes:        make(map[string]*Volume),
+			exportPolicies: map[string]*ExportPolicy{"default": {Name: "default"}},
+			igroups:        make(map[string]*Igroup),
+		}
+		svms[name] = svm
+	}
+	return svm
+}
+
+// ResetSVMs discards every modeled SVM, so that each test may start from an empty storage system.
+func ResetSVMs() {
+	svmsLock.Lock()
+	defer svmsLock.Unlock()
+
+	svms = make(map[string]*SVM)
+}
+
+// AddAggregates assigns the aggregates to the SVM.  Aggregates it already has keep their used space.
+func (s *SVM) AddAggregates(aggregates []drivers.FakeOntapAggregate) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, aggr := range aggregates {
+		if existing, ok := s.aggregates[aggr.Name]; ok {
+			existing.SizeBytes = aggr.SizeBytes
+			existing.Media = aggr.Media
+			continue
+		}
+		s.aggregates[aggr.Name] = &Aggregate{Name: aggr.Name, Media: aggr.Media, SizeBytes: aggr.SizeBytes}
+	}
+}
+
+// GetAggregate returns a copy of the named aggregate.
+func (s *SVM) GetAggregate(name string) (Aggregate, bool) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if aggr, ok := s.aggregates[name]; ok {
+		return *aggr, true
+	}
+	return Aggregate{}, false
+}
+
+// CreateVolume creates a FlexVol on the aggregate, and a LUN filling it if withLUN is set.
+func (s *SVM) CreateVolume(
+	name, aggregate string, sizeBytes uint64, exportPolicy, snapshotPolicy string, withLUN bool,
+) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.volumes[name]; ok {
+		return drivers.NewVolumeExistsError(name)
+	}
+	aggr, ok := s.aggregates[aggregate]
+	if !ok {
+		return fmt.Errorf("aggregate %s not found on SVM %s", aggregate, s.Name)
+	}
+	if sizeBytes > aggr.AvailableBytes() {
+		return fmt.Errorf("not enough space on aggregate %s; requested %d bytes, have %d available",
+			aggregate, sizeBytes, aggr.AvailableBytes())
+	}
+	if exportPolicy == "" {
+		exportPolicy = "default"
+	}
+	if _, ok := s.exportPolicies[exportPolicy]; !ok {
+		s.exportPolicies[exportPolicy] = &ExportPolicy{Name: exportPolicy}
+	}
+
+	volume := &Volume{
+		Name:           name,
+		Aggregate:      aggregate,
+		SizeBytes:      sizeBytes,
+		ExportPolicy:   exportPolicy,
+		SnapshotPolicy: snapshotPolicy,
+		Snapshots:      make(map[string]*Snapshot),
+	}
+	if withLUN {
+		s.sequence++
+		volume.LUN = &LUN{
+			Path:         fmt.Sprintf("/vol/%s/lun0", name),
+			SerialNumber: fmt.Sprintf("fakeontap%06d", s.sequence),
+			SizeBytes:    sizeBytes,
+			Maps:         make(map[string]int),
+		}
+	}
+	s.volumes[name] = volume
+	aggr.UsedBytes += sizeBytes
+
+	return nil
+}
+
+// CloneVolume creates a FlexVol from a snapshot of another, on the same aggregate and of the same size.
+// The clone shares nothing with its source, as if it had been split at once.
+func (s *SVM) CloneVolume(name, source, snapshot string) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sourceVolume, ok := s.volumes[source]
+	if !ok {
+		return fmt.Errorf("source volume %s not found", source)
+	}
+	if snapshot != "" {
+		if _, ok = sourceVolume.Snapshots[snapshot]; !ok {
+			return fmt.Errorf("snapshot %s not found in volume %s", snapshot, source)
+		}
+	}
+	if _, ok = s.volumes[name]; ok {
+		return drivers.NewVolumeExistsError(name)
+	}
+	aggr := s.aggregates[sourceVolume.Aggregate]
+	if aggr == nil || sourceVolume.SizeBytes > aggr.AvailableBytes() {
+		return fmt.Errorf("not enough space on aggregate %s to clone volume %s", sourceVolume.Aggregate, source)
+	}
+
+	clone := &Volume{
+		Name:           name,
+		Aggregate:      sourceVolume.Aggregate,
+		SizeBytes:      sourceVolume.SizeBytes,
+		ExportPolicy:   sourceVolume.ExportPolicy,
+		SnapshotPolicy: sourceVolume.SnapshotPolicy,
+		Snapshots:      make(map[string]*Snapshot),
+	}
+	if sourceVolume.LUN != nil {
+		s.sequence++
+		clone.LUN = &LUN{
+			Path:         fmt.Sprintf("/vol/%s/lun0", name),
+			SerialNumber: fmt.Sprintf("fakeontap%06d", s.sequence),
+			SizeBytes:    sourceVolume.LUN.SizeBytes,
+			Maps:         make(map[string]int),
+		}
+	}
+	s.volumes[name] = clone
+	aggr.UsedBytes += clone.SizeBytes
+
+	return nil
+}
+
+// GetVolume returns a copy of the named FlexVol, which the caller may modify freely.
+func (s *SVM) GetVolume(name string) (*Volume, bool) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return nil, false
+	}
+	return copyVolume(volume), true
+}
+
+// ListVolumes returns copies of the FlexVols whose names start with the prefix, sorted by name.
+func (s *SVM) ListVolumes(prefix string) []*Volume {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volumes := make([]*Volume, 0)
+	for name, volume := range s.volumes {
+		if strings.HasPrefix(name, prefix) {
+			volumes = append(volumes, copyVolume(volume))
+		}
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes
+}
+
+// RenameVolume renames a FlexVol, and the LUN in it if there is one.
+func (s *SVM) RenameVolume(name, newName string) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return fmt.Errorf("volume %s not found", name)
+	}
+	if _, ok = s.volumes[newName]; ok {
+		return drivers.NewVolumeExistsError(newName)
+	}
+	volume.Name = newName
+	if volume.LUN != nil {
+		volume.LUN.Path = fmt.Sprintf("/vol/%s/lun0", newName)
+	}
+	s.volumes[newName] = volume
+	delete(s.volumes, name)
+
+	return nil
+}
+
+// ResizeVolume grows a FlexVol, and the LUN in it if there is one.  Shrinking isn't allowed.
+func (s *SVM) ResizeVolume(name string, sizeBytes uint64) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return fmt.Errorf("volume %s not found", name)
+	}
+	if sizeBytes < volume.SizeBytes {
+		return drivers.NewUnsupportedCapacityRangeError(fmt.Sprintf(
+			"requested size %d is less than existing volume size %d", sizeBytes, volume.SizeBytes), nil)
+	}
+	aggr := s.aggregates[volume.Aggregate]
+	growth := sizeBytes - volume.SizeBytes
+	if aggr == nil || growth > aggr.AvailableBytes() {
+		return fmt.Errorf("not enough space on aggregate %s to grow volume %s by %d bytes",
+			volume.Aggregate, name, growth)
+	}
+	aggr.UsedBytes += growth
+	volume.SizeBytes = sizeBytes
+	if volume.LUN != nil {
+		volume.LUN.SizeBytes = sizeBytes
+	}
+
+	return nil
+}
+
+// DeleteVolume deletes a FlexVol along with its snapshots and LUN, releasing its space.  Deleting a
+// FlexVol that doesn't exist succeeds.
+func (s *SVM) DeleteVolume(name string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return
+	}
+	if aggr, ok := s.aggregates[volume.Aggregate]; ok {
+		if aggr.UsedBytes >= volume.SizeBytes {
+			aggr.UsedBytes -= volume.SizeBytes
+		} else {
+			aggr.UsedBytes = 0
+		}
+	}
+	delete(s.volumes, name)
+}
+
+// CreateSnapshot creates a snapshot of a FlexVol, returning a copy of it.
+func (s *SVM) CreateSnapshot(volumeName, name, created string) (*Snapshot, error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[volumeName]
+	if !ok {
+		return nil, fmt.Errorf("volume %s not found", volumeName)
+	}
+	if _, ok = volume.Snapshots[name]; ok {
+		return nil, fmt.Errorf("snapshot %s already exists in volume %s", name, volumeName)
+	}
+	s.sequence++
+	snapshot := &Snapshot{Name: name, Created: created, SizeBytes: int64(volume.SizeBytes), Sequence: s.sequence}
+	volume.Snapshots[name] = snapshot
+
+	snapshotCopy := *snapshot
+	return &snapshotCopy, nil
+}
+
+// RestoreSnapshot reverts a FlexVol to one of its snapshots.  As in ONTAP, the snapshots taken after
+// that one are deleted.
+func (s *SVM) RestoreSnapshot(volumeName, name string) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[volumeName]
+	if !ok {
+		return fmt.Errorf("volume %s not found", volumeName)
+	}
+	snapshot, ok := volume.Snapshots[name]
+	if !ok {
+		return fmt.Errorf("snapshot %s not found in volume %s", name, volumeName)
+	}
+	for snapName, snap := range volume.Snapshots {
+		if snap.Sequence > snapshot.Sequence {
+			delete(volume.Snapshots, snapName)
+		}
+	}
+
+	return nil
+}
+
+// DeleteSnapshot deletes a snapshot of a FlexVol.  Deleting a snapshot that doesn't exist succeeds.
+func (s *SVM) DeleteSnapshot(volumeName, name string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if volume, ok := s.volumes[volumeName]; ok {
+		delete(volume.Snapshots, name)
+	}
+}
+
+// AddExportRules adds rules for the client addresses to an export policy, creating the policy if necessary.
+func (s *SVM) AddExportRules(policyName string, clients []string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy, ok := s.exportPolicies[policyName]
+	if !ok {
+		policy = &ExportPolicy{Name: policyName}
+		s.exportPolicies[policyName] = policy
+	}
+	for _, client := range clients {
+		if !utils.SliceContainsString(policy.Rules, client) {
+			policy.Rules = append(policy.Rules, client)
+		}
+	}
+}
+
+// GetExportPolicy returns a copy of the named export policy.
+func (s *SVM) GetExportPolicy(name string) (ExportPolicy, bool) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy, ok := s.exportPolicies[name]
+	if !ok {
+		return ExportPolicy{}, false
+	}
+	return ExportPolicy{Name: policy.Name, Rules: append([]string(nil), policy.Rules...)}, true
+}
+
+// MapLUN adds the initiators to an igroup, creating the igroup if necessary, and maps the LUN in a FlexVol
+// to it.  The LUN ID by which the LUN is mapped is returned, along with the LUN's serial number.
+func (s *SVM) MapLUN(volumeName, igroupName string, initiators []string) (int, string, error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume, ok := s.volumes[volumeName]
+	if !ok {
+		return 0, "", fmt.Errorf("volume %s not found", volumeName)
+	}
+	if volume.LUN == nil {
+		return 0, "", fmt.Errorf("volume %s has no LUN", volumeName)
+	}
+
+	igroup, ok := s.igroups[igroupName]
+	if !ok {
+		igroup = &Igroup{Name: igroupName}
+		s.igroups[igroupName] = igroup
+	}
+	for _, initiator := range initiators {
+		if !utils.SliceContainsString(igroup.Initiators, initiator) {
+			igroup.Initiators = append(igroup.Initiators, initiator)
+		}
+	}
+
+	if lunID, ok := volume.LUN.Maps[igroupName]; ok {
+		return lunID, volume.LUN.SerialNumber, nil
+	}
+
+	// Use the lowest LUN ID not yet mapped to the igroup
+	used := make(map[int]bool)
+	for _, vol := range s.volumes {
+		if vol.LUN != nil {
+			if id, ok := vol.LUN.Maps[igroupName]; ok {
+				used[id] = true
+			}
+		}
+	}
+	lunID := 0
+	for used[lunID] {
+		lunID++
+	}
+	volume.LUN.Maps[igroupName] = lunID
+
+	return lunID, volume.LUN.SerialNumber, nil
+}
+
+// GetIgroup returns a copy of the named igroup.
+func (s *SVM) GetIgroup(name string) (Igroup, bool) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	igroup, ok := s.igroups[name]
+	if !ok {
+		return Igroup{}, false
+	}
+	return Igroup{Name: igroup.Name, Initiators: append([]string(nil), igroup.Initiators...)}, true
+}
+
+func copyVolume(volume *Volume) *Volume {
+	volumeCopy := *volume
+	volumeCopy.Snapshots = make(map[string]*Snapshot, len(volume.Snapshots))
+	for name, snapshot := range volume.Snapshots {
+		snapshotCopy := *snapshot
+		volumeCopy.Snapshots[name] = &snapshotCopy
+	}
+	if volume.LUN != nil {
+		lunCopy := *volume.LUN
+		lunCopy.Maps = make(map[string]int, len(volume.LUN.Maps))
+		for igroup, id := range volume.LUN.Maps {
+			lunCopy.Maps[igroup] = id
+		}
+		volumeCopy.LUN = &lunCopy
+	}
+	return &volumeCopy
+}
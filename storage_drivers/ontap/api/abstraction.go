@@ -0,0 +1,65 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package api
+
+import (
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+// OntapAPI is the set of ONTAP API calls made by the helpers the ONTAP drivers share to publish volumes,
+// reconcile node access, manage snapshot policies, and create clones, so that those helpers may be exercised against a
+// MockOntapAPI instead of an ONTAP cluster.  Client implements it.
+type OntapAPI interface {
+	// Export policies
+	ExportPolicyCreate(policy string) (*azgo.ExportPolicyCreateResponse, error)
+	ExportPolicyDestroy(policy string) (*azgo.ExportPolicyDestroyResponse, error)
+	ExportPolicyGet(policy string) (*azgo.ExportPolicyGetResponse, error)
+	ExportRuleCreateWithOptions(
+		policy, clientMatch string, ruleIndex int, anonymousUserID string,
+		protocols, roSecFlavors, rwSecFlavors, suSecFlavors []string,
+	) (*azgo.ExportRuleCreateResponse, error)
+	ExportRuleDestroy(policy string, ruleIndex int) (*azgo.ExportRuleDestroyResponse, error)
+	ExportRuleGetIterRequest(policy string) (*azgo.ExportRuleGetIterResponse, error)
+
+	// Igroups and iSCSI
+	IgroupAdd(initiatorGroupName, initiator string) (*azgo.IgroupAddResponse, error)
+	IgroupCreate(initiatorGroupName, initiatorGroupType, osType string) (*azgo.IgroupCreateResponse, error)
+	IgroupGet(initiatorGroupName string) (*azgo.InitiatorGroupInfoType, error)
+	IgroupRemove(initiatorGroupName, initiator string, force bool) (*azgo.IgroupRemoveResponse, error)
+	IscsiInitiatorSessionsGet(initiator string) ([]azgo.IscsiInitiatorListEntryInfoType, error)
+	IscsiInterfaceGetIterRequest() (*azgo.IscsiInterfaceGetIterResponse, error)
+	IscsiNodeGetNameRequest() (*azgo.IscsiNodeGetNameResponse, error)
+	IscsiServiceGetIterRequest() (*azgo.IscsiServiceGetIterResponse, error)
+	IscsiSessionShutdown(tpgroupName string, sessionID int) (*azgo.IscsiSessionShutdownResponse, error)
+	NetInterfaceGetDataLIFsNode(ip string) (string, error)
+
+	// LUNs
+	LunGet(path string) (*azgo.LunInfoType, error)
+	LunGetAttribute(lunPath, name string) (*azgo.LunGetAttributeResponse, error)
+	LunMapGet(initiatorGroupName, lunPath string) (*azgo.LunMapGetIterResponse, error)
+	LunMapIfNotMapped(initiatorGroupName, lunPath string, importNotManaged bool) (int, error)
+
+	// Snapshot policies
+	SnapshotPolicyAddSchedule(policy, schedule string, count int, prefix string) (*azgo.SnapshotPolicyAddScheduleResponse, error)
+	SnapshotPolicyCreate(
+		policy string, enabled bool, comment, schedule string, count int, prefix string,
+	) (*azgo.SnapshotPolicyCreateResponse, error)
+	SnapshotPolicyGet(policy string) (*azgo.SnapshotPolicyInfoType, error)
+	SnapshotPolicyModify(policy string, enabled bool, comment string) (*azgo.SnapshotPolicyModifyResponse, error)
+	SnapshotPolicyModifySchedule(policy, schedule string, count int) (*azgo.SnapshotPolicyModifyScheduleResponse, error)
+	SnapshotPolicyRemoveSchedule(policy, schedule string) (*azgo.SnapshotPolicyRemoveScheduleResponse, error)
+
+	// Volumes, clones, and snapshots
+	SnapshotCreateWithComment(snapshotName, volumeName, comment string) (*azgo.SnapshotCreateResponse, error)
+	VolumeCloneCreate(name, source, snapshot string) (*azgo.VolumeCloneCreateResponse, error)
+	VolumeCloneCreateAsync(name, source, snapshot string) (*azgo.VolumeCloneCreateAsyncResponse, error)
+	VolumeCloneSplitStart(name string) (*azgo.VolumeCloneSplitStartResponse, error)
+	VolumeDestroy(name string, force bool) (*azgo.VolumeDestroyResponse, error)
+	VolumeExists(name string) (bool, error)
+	VolumeGet(name string) (*azgo.VolumeAttributesType, error)
+	VolumeModifyExportPolicy(volumeName, exportPolicyName string) (*azgo.VolumeModifyIterResponse, error)
+	VolumeMount(name, junctionPath string) (*azgo.VolumeMountResponse, error)
+	VolumeSetComment(volumeName, comment string) (*azgo.VolumeModifyIterResponse, error)
+}
+
+var _ OntapAPI = &Client{}
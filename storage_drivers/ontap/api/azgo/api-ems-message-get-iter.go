@@ -0,0 +1,334 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EmsMessageGetIterRequest is a structure to represent a ems-message-get-iter Request ZAPI object
+type EmsMessageGetIterRequest struct {
+	XMLName              xml.Name                                   `xml:"ems-message-get-iter"`
+	DesiredAttributesPtr *EmsMessageGetIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                       `xml:"max-records"`
+	QueryPtr             *EmsMessageGetIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                    `xml:"tag"`
+}
+
+// EmsMessageGetIterResponse is a structure to represent a ems-message-get-iter Response ZAPI object
+type EmsMessageGetIterResponse struct {
+	XMLName         xml.Name                        `xml:"netapp"`
+	ResponseVersion string                          `xml:"version,attr"`
+	ResponseXmlns   string                          `xml:"xmlns,attr"`
+	Result          EmsMessageGetIterResponseResult `xml:"results"`
+}
+
+// NewEmsMessageGetIterResponse is a factory method for creating new instances of EmsMessageGetIterResponse objects
+func NewEmsMessageGetIterResponse() *EmsMessageGetIterResponse {
+	return &EmsMessageGetIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *EmsMessageGetIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// EmsMessageGetIterResponseResult is a structure to represent a ems-message-get-iter Response Result ZAPI object
+type EmsMessageGetIterResponseResult struct {
+	XMLName           xml.Name                                       `xml:"results"`
+	ResultStatusAttr  string                                         `xml:"status,attr"`
+	ResultReasonAttr  string                                         `xml:"reason,attr"`
+	ResultErrnoAttr   string                                         `xml:"errno,attr"`
+	AttributesListPtr *EmsMessageGetIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                        `xml:"next-tag"`
+	NumRecordsPtr     *int                                           `xml:"num-records"`
+}
+
+// NewEmsMessageGetIterRequest is a factory method for creating new instances of EmsMessageGetIterRequest objects
+func NewEmsMessageGetIterRequest() *EmsMessageGetIterRequest {
+	return &EmsMessageGetIterRequest{}
+}
+
+// NewEmsMessageGetIterResponseResult is a factory method for creating new instances of EmsMessageGetIterResponseResult objects
+func NewEmsMessageGetIterResponseResult() *EmsMessageGetIterResponseResult {
+	return &EmsMessageGetIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *EmsMessageGetIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *EmsMessageGetIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *EmsMessageGetIterRequest) ExecuteUsing(zr *ZapiRunner) (*EmsMessageGetIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *EmsMessageGetIterRequest) executeWithoutIteration(zr *ZapiRunner) (*EmsMessageGetIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "EmsMessageGetIterRequest", NewEmsMessageGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*EmsMessageGetIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *EmsMessageGetIterRequest) executeWithIteration(zr *ZapiRunner) (*EmsMessageGetIterResponse, error) {
+	combined := NewEmsMessageGetIterResponse()
+	combined.Result.SetAttributesList(EmsMessageGetIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(EmsMessageGetIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// EmsMessageGetIterRequestDesiredAttributes is a wrapper
+type EmsMessageGetIterRequestDesiredAttributes struct {
+	XMLName           xml.Name            `xml:"desired-attributes"`
+	EmsMessageInfoPtr *EmsMessageInfoType `xml:"ems-message-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// EmsMessageInfo is a 'getter' method
+func (o *EmsMessageGetIterRequestDesiredAttributes) EmsMessageInfo() EmsMessageInfoType {
+	r := *o.EmsMessageInfoPtr
+	return r
+}
+
+// SetEmsMessageInfo is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequestDesiredAttributes) SetEmsMessageInfo(newValue EmsMessageInfoType) *EmsMessageGetIterRequestDesiredAttributes {
+	o.EmsMessageInfoPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *EmsMessageGetIterRequest) DesiredAttributes() EmsMessageGetIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequest) SetDesiredAttributes(newValue EmsMessageGetIterRequestDesiredAttributes) *EmsMessageGetIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *EmsMessageGetIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequest) SetMaxRecords(newValue int) *EmsMessageGetIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// EmsMessageGetIterRequestQuery is a wrapper
+type EmsMessageGetIterRequestQuery struct {
+	XMLName           xml.Name            `xml:"query"`
+	EmsMessageInfoPtr *EmsMessageInfoType `xml:"ems-message-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// EmsMessageInfo is a 'getter' method
+func (o *EmsMessageGetIterRequestQuery) EmsMessageInfo() EmsMessageInfoType {
+	r := *o.EmsMessageInfoPtr
+	return r
+}
+
+// SetEmsMessageInfo is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequestQuery) SetEmsMessageInfo(newValue EmsMessageInfoType) *EmsMessageGetIterRequestQuery {
+	o.EmsMessageInfoPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *EmsMessageGetIterRequest) Query() EmsMessageGetIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequest) SetQuery(newValue EmsMessageGetIterRequestQuery) *EmsMessageGetIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *EmsMessageGetIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterRequest) SetTag(newValue string) *EmsMessageGetIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// EmsMessageGetIterResponseResultAttributesList is a wrapper
+type EmsMessageGetIterResponseResultAttributesList struct {
+	XMLName           xml.Name             `xml:"attributes-list"`
+	EmsMessageInfoPtr []EmsMessageInfoType `xml:"ems-message-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageGetIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// EmsMessageInfo is a 'getter' method
+func (o *EmsMessageGetIterResponseResultAttributesList) EmsMessageInfo() []EmsMessageInfoType {
+	r := o.EmsMessageInfoPtr
+	return r
+}
+
+// SetEmsMessageInfo is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterResponseResultAttributesList) SetEmsMessageInfo(newValue []EmsMessageInfoType) *EmsMessageGetIterResponseResultAttributesList {
+	newSlice := make([]EmsMessageInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.EmsMessageInfoPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *EmsMessageGetIterResponseResultAttributesList) values() []EmsMessageInfoType {
+	r := o.EmsMessageInfoPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterResponseResultAttributesList) setValues(newValue []EmsMessageInfoType) *EmsMessageGetIterResponseResultAttributesList {
+	newSlice := make([]EmsMessageInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.EmsMessageInfoPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *EmsMessageGetIterResponseResult) AttributesList() EmsMessageGetIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterResponseResult) SetAttributesList(newValue EmsMessageGetIterResponseResultAttributesList) *EmsMessageGetIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *EmsMessageGetIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterResponseResult) SetNextTag(newValue string) *EmsMessageGetIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *EmsMessageGetIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *EmsMessageGetIterResponseResult) SetNumRecords(newValue int) *EmsMessageGetIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportPolicyGetIterRequest is a structure to represent a export-policy-get-iter Request ZAPI object
+type ExportPolicyGetIterRequest struct {
+	XMLName              xml.Name                                     `xml:"export-policy-get-iter"`
+	DesiredAttributesPtr *ExportPolicyGetIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                         `xml:"max-records"`
+	QueryPtr             *ExportPolicyGetIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                      `xml:"tag"`
+}
+
+// ExportPolicyGetIterResponse is a structure to represent a export-policy-get-iter Response ZAPI object
+type ExportPolicyGetIterResponse struct {
+	XMLName         xml.Name                          `xml:"netapp"`
+	ResponseVersion string                            `xml:"version,attr"`
+	ResponseXmlns   string                            `xml:"xmlns,attr"`
+	Result          ExportPolicyGetIterResponseResult `xml:"results"`
+}
+
+// NewExportPolicyGetIterResponse is a factory method for creating new instances of ExportPolicyGetIterResponse objects
+func NewExportPolicyGetIterResponse() *ExportPolicyGetIterResponse {
+	return &ExportPolicyGetIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *ExportPolicyGetIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ExportPolicyGetIterResponseResult is a structure to represent a export-policy-get-iter Response Result ZAPI object
+type ExportPolicyGetIterResponseResult struct {
+	XMLName           xml.Name                                         `xml:"results"`
+	ResultStatusAttr  string                                           `xml:"status,attr"`
+	ResultReasonAttr  string                                           `xml:"reason,attr"`
+	ResultErrnoAttr   string                                           `xml:"errno,attr"`
+	AttributesListPtr *ExportPolicyGetIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                          `xml:"next-tag"`
+	NumRecordsPtr     *int                                             `xml:"num-records"`
+}
+
+// NewExportPolicyGetIterRequest is a factory method for creating new instances of ExportPolicyGetIterRequest objects
+func NewExportPolicyGetIterRequest() *ExportPolicyGetIterRequest {
+	return &ExportPolicyGetIterRequest{}
+}
+
+// NewExportPolicyGetIterResponseResult is a factory method for creating new instances of ExportPolicyGetIterResponseResult objects
+func NewExportPolicyGetIterResponseResult() *ExportPolicyGetIterResponseResult {
+	return &ExportPolicyGetIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *ExportPolicyGetIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *ExportPolicyGetIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *ExportPolicyGetIterRequest) ExecuteUsing(zr *ZapiRunner) (*ExportPolicyGetIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *ExportPolicyGetIterRequest) executeWithoutIteration(zr *ZapiRunner) (*ExportPolicyGetIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "ExportPolicyGetIterRequest", NewExportPolicyGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*ExportPolicyGetIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *ExportPolicyGetIterRequest) executeWithIteration(zr *ZapiRunner) (*ExportPolicyGetIterResponse, error) {
+	combined := NewExportPolicyGetIterResponse()
+	combined.Result.SetAttributesList(ExportPolicyGetIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(ExportPolicyGetIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// ExportPolicyGetIterRequestDesiredAttributes is a wrapper
+type ExportPolicyGetIterRequestDesiredAttributes struct {
+	XMLName             xml.Name              `xml:"desired-attributes"`
+	ExportPolicyInfoPtr *ExportPolicyInfoType `xml:"export-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExportPolicyInfo is a 'getter' method
+func (o *ExportPolicyGetIterRequestDesiredAttributes) ExportPolicyInfo() ExportPolicyInfoType {
+	r := *o.ExportPolicyInfoPtr
+	return r
+}
+
+// SetExportPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequestDesiredAttributes) SetExportPolicyInfo(newValue ExportPolicyInfoType) *ExportPolicyGetIterRequestDesiredAttributes {
+	o.ExportPolicyInfoPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *ExportPolicyGetIterRequest) DesiredAttributes() ExportPolicyGetIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequest) SetDesiredAttributes(newValue ExportPolicyGetIterRequestDesiredAttributes) *ExportPolicyGetIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *ExportPolicyGetIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequest) SetMaxRecords(newValue int) *ExportPolicyGetIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// ExportPolicyGetIterRequestQuery is a wrapper
+type ExportPolicyGetIterRequestQuery struct {
+	XMLName             xml.Name              `xml:"query"`
+	ExportPolicyInfoPtr *ExportPolicyInfoType `xml:"export-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExportPolicyInfo is a 'getter' method
+func (o *ExportPolicyGetIterRequestQuery) ExportPolicyInfo() ExportPolicyInfoType {
+	r := *o.ExportPolicyInfoPtr
+	return r
+}
+
+// SetExportPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequestQuery) SetExportPolicyInfo(newValue ExportPolicyInfoType) *ExportPolicyGetIterRequestQuery {
+	o.ExportPolicyInfoPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *ExportPolicyGetIterRequest) Query() ExportPolicyGetIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequest) SetQuery(newValue ExportPolicyGetIterRequestQuery) *ExportPolicyGetIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *ExportPolicyGetIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterRequest) SetTag(newValue string) *ExportPolicyGetIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// ExportPolicyGetIterResponseResultAttributesList is a wrapper
+type ExportPolicyGetIterResponseResultAttributesList struct {
+	XMLName             xml.Name               `xml:"attributes-list"`
+	ExportPolicyInfoPtr []ExportPolicyInfoType `xml:"export-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o ExportPolicyGetIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExportPolicyInfo is a 'getter' method
+func (o *ExportPolicyGetIterResponseResultAttributesList) ExportPolicyInfo() []ExportPolicyInfoType {
+	r := o.ExportPolicyInfoPtr
+	return r
+}
+
+// SetExportPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterResponseResultAttributesList) SetExportPolicyInfo(newValue []ExportPolicyInfoType) *ExportPolicyGetIterResponseResultAttributesList {
+	newSlice := make([]ExportPolicyInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.ExportPolicyInfoPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *ExportPolicyGetIterResponseResultAttributesList) values() []ExportPolicyInfoType {
+	r := o.ExportPolicyInfoPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterResponseResultAttributesList) setValues(newValue []ExportPolicyInfoType) *ExportPolicyGetIterResponseResultAttributesList {
+	newSlice := make([]ExportPolicyInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.ExportPolicyInfoPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *ExportPolicyGetIterResponseResult) AttributesList() ExportPolicyGetIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterResponseResult) SetAttributesList(newValue ExportPolicyGetIterResponseResultAttributesList) *ExportPolicyGetIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *ExportPolicyGetIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterResponseResult) SetNextTag(newValue string) *ExportPolicyGetIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *ExportPolicyGetIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *ExportPolicyGetIterResponseResult) SetNumRecords(newValue int) *ExportPolicyGetIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
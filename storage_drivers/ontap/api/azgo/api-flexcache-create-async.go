@@ -0,0 +1,283 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FlexcacheCreateAsyncRequest is a structure to represent a flexcache-create-async Request ZAPI object
+type FlexcacheCreateAsyncRequest struct {
+	XMLName               xml.Name                             `xml:"flexcache-create-async"`
+	AggrListPtr           *FlexcacheCreateAsyncRequestAggrList `xml:"aggr-list"`
+	AggrListMultiplierPtr *int                                 `xml:"aggr-list-multiplier"`
+	JunctionPathPtr       *string                              `xml:"junction-path"`
+	OriginVolumePtr       *string                              `xml:"origin-volume"`
+	OriginVserverPtr      *string                              `xml:"origin-vserver"`
+	SizePtr               *int                                 `xml:"size"`
+	UseTieredAggregatePtr *bool                                `xml:"use-tiered-aggregate"`
+	VolumePtr             *string                              `xml:"volume"`
+}
+
+// FlexcacheCreateAsyncResponse is a structure to represent a flexcache-create-async Response ZAPI object
+type FlexcacheCreateAsyncResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          FlexcacheCreateAsyncResponseResult `xml:"results"`
+}
+
+// NewFlexcacheCreateAsyncResponse is a factory method for creating new instances of FlexcacheCreateAsyncResponse objects
+func NewFlexcacheCreateAsyncResponse() *FlexcacheCreateAsyncResponse {
+	return &FlexcacheCreateAsyncResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheCreateAsyncResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheCreateAsyncResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// FlexcacheCreateAsyncResponseResult is a structure to represent a flexcache-create-async Response Result ZAPI object
+type FlexcacheCreateAsyncResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewFlexcacheCreateAsyncRequest is a factory method for creating new instances of FlexcacheCreateAsyncRequest objects
+func NewFlexcacheCreateAsyncRequest() *FlexcacheCreateAsyncRequest {
+	return &FlexcacheCreateAsyncRequest{}
+}
+
+// NewFlexcacheCreateAsyncResponseResult is a factory method for creating new instances of FlexcacheCreateAsyncResponseResult objects
+func NewFlexcacheCreateAsyncResponseResult() *FlexcacheCreateAsyncResponseResult {
+	return &FlexcacheCreateAsyncResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheCreateAsyncRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheCreateAsyncResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheCreateAsyncRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheCreateAsyncResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *FlexcacheCreateAsyncRequest) ExecuteUsing(zr *ZapiRunner) (*FlexcacheCreateAsyncResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *FlexcacheCreateAsyncRequest) executeWithoutIteration(zr *ZapiRunner) (*FlexcacheCreateAsyncResponse, error) {
+	result, err := zr.ExecuteUsing(o, "FlexcacheCreateAsyncRequest", NewFlexcacheCreateAsyncResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*FlexcacheCreateAsyncResponse), err
+}
+
+// AggrList is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) AggrList() FlexcacheCreateAsyncRequestAggrList {
+	r := *o.AggrListPtr
+	return r
+}
+
+// SetAggrList is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetAggrList(newValue FlexcacheCreateAsyncRequestAggrList) *FlexcacheCreateAsyncRequest {
+	o.AggrListPtr = &newValue
+	return o
+}
+
+// AggrListMultiplier is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) AggrListMultiplier() int {
+	r := *o.AggrListMultiplierPtr
+	return r
+}
+
+// SetAggrListMultiplier is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetAggrListMultiplier(newValue int) *FlexcacheCreateAsyncRequest {
+	o.AggrListMultiplierPtr = &newValue
+	return o
+}
+
+// JunctionPath is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) JunctionPath() string {
+	r := *o.JunctionPathPtr
+	return r
+}
+
+// SetJunctionPath is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetJunctionPath(newValue string) *FlexcacheCreateAsyncRequest {
+	o.JunctionPathPtr = &newValue
+	return o
+}
+
+// OriginVolume is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) OriginVolume() string {
+	r := *o.OriginVolumePtr
+	return r
+}
+
+// SetOriginVolume is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetOriginVolume(newValue string) *FlexcacheCreateAsyncRequest {
+	o.OriginVolumePtr = &newValue
+	return o
+}
+
+// OriginVserver is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) OriginVserver() string {
+	r := *o.OriginVserverPtr
+	return r
+}
+
+// SetOriginVserver is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetOriginVserver(newValue string) *FlexcacheCreateAsyncRequest {
+	o.OriginVserverPtr = &newValue
+	return o
+}
+
+// Size is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) Size() int {
+	r := *o.SizePtr
+	return r
+}
+
+// SetSize is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetSize(newValue int) *FlexcacheCreateAsyncRequest {
+	o.SizePtr = &newValue
+	return o
+}
+
+// UseTieredAggregate is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) UseTieredAggregate() bool {
+	r := *o.UseTieredAggregatePtr
+	return r
+}
+
+// SetUseTieredAggregate is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetUseTieredAggregate(newValue bool) *FlexcacheCreateAsyncRequest {
+	o.UseTieredAggregatePtr = &newValue
+	return o
+}
+
+// Volume is a 'getter' method
+func (o *FlexcacheCreateAsyncRequest) Volume() string {
+	r := *o.VolumePtr
+	return r
+}
+
+// SetVolume is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequest) SetVolume(newValue string) *FlexcacheCreateAsyncRequest {
+	o.VolumePtr = &newValue
+	return o
+}
+
+// FlexcacheCreateAsyncRequestAggrList is a wrapper
+type FlexcacheCreateAsyncRequestAggrList struct {
+	XMLName     xml.Name       `xml:"aggr-list"`
+	AggrNamePtr []AggrNameType `xml:"aggr-name"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheCreateAsyncRequestAggrList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// AggrName is a 'getter' method
+func (o *FlexcacheCreateAsyncRequestAggrList) AggrName() []AggrNameType {
+	r := o.AggrNamePtr
+	return r
+}
+
+// SetAggrName is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncRequestAggrList) SetAggrName(newValue []AggrNameType) *FlexcacheCreateAsyncRequestAggrList {
+	newSlice := make([]AggrNameType, len(newValue))
+	copy(newSlice, newValue)
+	o.AggrNamePtr = newSlice
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *FlexcacheCreateAsyncResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncResponseResult) SetResultErrorCode(newValue int) *FlexcacheCreateAsyncResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *FlexcacheCreateAsyncResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncResponseResult) SetResultErrorMessage(newValue string) *FlexcacheCreateAsyncResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *FlexcacheCreateAsyncResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncResponseResult) SetResultJobid(newValue int) *FlexcacheCreateAsyncResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *FlexcacheCreateAsyncResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *FlexcacheCreateAsyncResponseResult) SetResultStatus(newValue string) *FlexcacheCreateAsyncResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
@@ -0,0 +1,167 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FlexcacheDestroyAsyncRequest is a structure to represent a flexcache-destroy-async Request ZAPI object
+type FlexcacheDestroyAsyncRequest struct {
+	XMLName   xml.Name `xml:"flexcache-destroy-async"`
+	VolumePtr *string  `xml:"volume"`
+}
+
+// FlexcacheDestroyAsyncResponse is a structure to represent a flexcache-destroy-async Response ZAPI object
+type FlexcacheDestroyAsyncResponse struct {
+	XMLName         xml.Name                            `xml:"netapp"`
+	ResponseVersion string                              `xml:"version,attr"`
+	ResponseXmlns   string                              `xml:"xmlns,attr"`
+	Result          FlexcacheDestroyAsyncResponseResult `xml:"results"`
+}
+
+// NewFlexcacheDestroyAsyncResponse is a factory method for creating new instances of FlexcacheDestroyAsyncResponse objects
+func NewFlexcacheDestroyAsyncResponse() *FlexcacheDestroyAsyncResponse {
+	return &FlexcacheDestroyAsyncResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheDestroyAsyncResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheDestroyAsyncResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// FlexcacheDestroyAsyncResponseResult is a structure to represent a flexcache-destroy-async Response Result ZAPI object
+type FlexcacheDestroyAsyncResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewFlexcacheDestroyAsyncRequest is a factory method for creating new instances of FlexcacheDestroyAsyncRequest objects
+func NewFlexcacheDestroyAsyncRequest() *FlexcacheDestroyAsyncRequest {
+	return &FlexcacheDestroyAsyncRequest{}
+}
+
+// NewFlexcacheDestroyAsyncResponseResult is a factory method for creating new instances of FlexcacheDestroyAsyncResponseResult objects
+func NewFlexcacheDestroyAsyncResponseResult() *FlexcacheDestroyAsyncResponseResult {
+	return &FlexcacheDestroyAsyncResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheDestroyAsyncRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *FlexcacheDestroyAsyncResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheDestroyAsyncRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o FlexcacheDestroyAsyncResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *FlexcacheDestroyAsyncRequest) ExecuteUsing(zr *ZapiRunner) (*FlexcacheDestroyAsyncResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *FlexcacheDestroyAsyncRequest) executeWithoutIteration(zr *ZapiRunner) (*FlexcacheDestroyAsyncResponse, error) {
+	result, err := zr.ExecuteUsing(o, "FlexcacheDestroyAsyncRequest", NewFlexcacheDestroyAsyncResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*FlexcacheDestroyAsyncResponse), err
+}
+
+// Volume is a 'getter' method
+func (o *FlexcacheDestroyAsyncRequest) Volume() string {
+	r := *o.VolumePtr
+	return r
+}
+
+// SetVolume is a fluent style 'setter' method that can be chained
+func (o *FlexcacheDestroyAsyncRequest) SetVolume(newValue string) *FlexcacheDestroyAsyncRequest {
+	o.VolumePtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *FlexcacheDestroyAsyncResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *FlexcacheDestroyAsyncResponseResult) SetResultErrorCode(newValue int) *FlexcacheDestroyAsyncResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *FlexcacheDestroyAsyncResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *FlexcacheDestroyAsyncResponseResult) SetResultErrorMessage(newValue string) *FlexcacheDestroyAsyncResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *FlexcacheDestroyAsyncResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *FlexcacheDestroyAsyncResponseResult) SetResultJobid(newValue int) *FlexcacheDestroyAsyncResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *FlexcacheDestroyAsyncResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *FlexcacheDestroyAsyncResponseResult) SetResultStatus(newValue string) *FlexcacheDestroyAsyncResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
@@ -0,0 +1,128 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// IscsiSessionShutdownRequest is a structure to represent a iscsi-session-shutdown Request ZAPI object
+type IscsiSessionShutdownRequest struct {
+	XMLName        xml.Name `xml:"iscsi-session-shutdown"`
+	SessionIdPtr   *int     `xml:"session-id"`
+	TpgroupNamePtr *string  `xml:"tpgroup-name"`
+}
+
+// IscsiSessionShutdownResponse is a structure to represent a iscsi-session-shutdown Response ZAPI object
+type IscsiSessionShutdownResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          IscsiSessionShutdownResponseResult `xml:"results"`
+}
+
+// NewIscsiSessionShutdownResponse is a factory method for creating new instances of IscsiSessionShutdownResponse objects
+func NewIscsiSessionShutdownResponse() *IscsiSessionShutdownResponse {
+	return &IscsiSessionShutdownResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o IscsiSessionShutdownResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *IscsiSessionShutdownResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// IscsiSessionShutdownResponseResult is a structure to represent a iscsi-session-shutdown Response Result ZAPI object
+type IscsiSessionShutdownResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewIscsiSessionShutdownRequest is a factory method for creating new instances of IscsiSessionShutdownRequest objects
+func NewIscsiSessionShutdownRequest() *IscsiSessionShutdownRequest {
+	return &IscsiSessionShutdownRequest{}
+}
+
+// NewIscsiSessionShutdownResponseResult is a factory method for creating new instances of IscsiSessionShutdownResponseResult objects
+func NewIscsiSessionShutdownResponseResult() *IscsiSessionShutdownResponseResult {
+	return &IscsiSessionShutdownResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *IscsiSessionShutdownRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *IscsiSessionShutdownResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o IscsiSessionShutdownRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o IscsiSessionShutdownResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *IscsiSessionShutdownRequest) ExecuteUsing(zr *ZapiRunner) (*IscsiSessionShutdownResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *IscsiSessionShutdownRequest) executeWithoutIteration(zr *ZapiRunner) (*IscsiSessionShutdownResponse, error) {
+	result, err := zr.ExecuteUsing(o, "IscsiSessionShutdownRequest", NewIscsiSessionShutdownResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*IscsiSessionShutdownResponse), err
+}
+
+// SessionId is a 'getter' method
+func (o *IscsiSessionShutdownRequest) SessionId() int {
+	r := *o.SessionIdPtr
+	return r
+}
+
+// SetSessionId is a fluent style 'setter' method that can be chained
+func (o *IscsiSessionShutdownRequest) SetSessionId(newValue int) *IscsiSessionShutdownRequest {
+	o.SessionIdPtr = &newValue
+	return o
+}
+
+// TpgroupName is a 'getter' method
+func (o *IscsiSessionShutdownRequest) TpgroupName() string {
+	r := *o.TpgroupNamePtr
+	return r
+}
+
+// SetTpgroupName is a fluent style 'setter' method that can be chained
+func (o *IscsiSessionShutdownRequest) SetTpgroupName(newValue string) *IscsiSessionShutdownRequest {
+	o.TpgroupNamePtr = &newValue
+	return o
+}
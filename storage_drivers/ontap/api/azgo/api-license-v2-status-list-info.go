@@ -0,0 +1,140 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LicenseV2StatusListInfoRequest is a structure to represent a license-v2-status-list-info Request ZAPI object
+type LicenseV2StatusListInfoRequest struct {
+	XMLName xml.Name `xml:"license-v2-status-list-info"`
+}
+
+// LicenseV2StatusListInfoResponse is a structure to represent a license-v2-status-list-info Response ZAPI object
+type LicenseV2StatusListInfoResponse struct {
+	XMLName         xml.Name                              `xml:"netapp"`
+	ResponseVersion string                                `xml:"version,attr"`
+	ResponseXmlns   string                                `xml:"xmlns,attr"`
+	Result          LicenseV2StatusListInfoResponseResult `xml:"results"`
+}
+
+// NewLicenseV2StatusListInfoResponse is a factory method for creating new instances of LicenseV2StatusListInfoResponse objects
+func NewLicenseV2StatusListInfoResponse() *LicenseV2StatusListInfoResponse {
+	return &LicenseV2StatusListInfoResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LicenseV2StatusListInfoResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LicenseV2StatusListInfoResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// LicenseV2StatusListInfoResponseResult is a structure to represent a license-v2-status-list-info Response Result ZAPI object
+type LicenseV2StatusListInfoResponseResult struct {
+	XMLName            xml.Name                                              `xml:"results"`
+	ResultStatusAttr   string                                                `xml:"status,attr"`
+	ResultReasonAttr   string                                                `xml:"reason,attr"`
+	ResultErrnoAttr    string                                                `xml:"errno,attr"`
+	LicenseV2StatusPtr *LicenseV2StatusListInfoResponseResultLicenseV2Status `xml:"license-v2-status"`
+}
+
+// NewLicenseV2StatusListInfoRequest is a factory method for creating new instances of LicenseV2StatusListInfoRequest objects
+func NewLicenseV2StatusListInfoRequest() *LicenseV2StatusListInfoRequest {
+	return &LicenseV2StatusListInfoRequest{}
+}
+
+// NewLicenseV2StatusListInfoResponseResult is a factory method for creating new instances of LicenseV2StatusListInfoResponseResult objects
+func NewLicenseV2StatusListInfoResponseResult() *LicenseV2StatusListInfoResponseResult {
+	return &LicenseV2StatusListInfoResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LicenseV2StatusListInfoRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LicenseV2StatusListInfoResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LicenseV2StatusListInfoRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LicenseV2StatusListInfoResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *LicenseV2StatusListInfoRequest) ExecuteUsing(zr *ZapiRunner) (*LicenseV2StatusListInfoResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *LicenseV2StatusListInfoRequest) executeWithoutIteration(zr *ZapiRunner) (*LicenseV2StatusListInfoResponse, error) {
+	result, err := zr.ExecuteUsing(o, "LicenseV2StatusListInfoRequest", NewLicenseV2StatusListInfoResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*LicenseV2StatusListInfoResponse), err
+}
+
+// LicenseV2StatusListInfoResponseResultLicenseV2Status is a wrapper
+type LicenseV2StatusListInfoResponseResultLicenseV2Status struct {
+	XMLName                xml.Name                  `xml:"license-v2-status"`
+	LicenseV2StatusInfoPtr []LicenseV2StatusInfoType `xml:"license-v2-status-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LicenseV2StatusListInfoResponseResultLicenseV2Status) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// LicenseV2StatusInfo is a 'getter' method
+func (o *LicenseV2StatusListInfoResponseResultLicenseV2Status) LicenseV2StatusInfo() []LicenseV2StatusInfoType {
+	r := o.LicenseV2StatusInfoPtr
+	return r
+}
+
+// SetLicenseV2StatusInfo is a fluent style 'setter' method that can be chained
+func (o *LicenseV2StatusListInfoResponseResultLicenseV2Status) SetLicenseV2StatusInfo(newValue []LicenseV2StatusInfoType) *LicenseV2StatusListInfoResponseResultLicenseV2Status {
+	newSlice := make([]LicenseV2StatusInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.LicenseV2StatusInfoPtr = newSlice
+	return o
+}
+
+// LicenseV2Status is a 'getter' method
+func (o *LicenseV2StatusListInfoResponseResult) LicenseV2Status() LicenseV2StatusListInfoResponseResultLicenseV2Status {
+	r := *o.LicenseV2StatusPtr
+	return r
+}
+
+// SetLicenseV2Status is a fluent style 'setter' method that can be chained
+func (o *LicenseV2StatusListInfoResponseResult) SetLicenseV2Status(newValue LicenseV2StatusListInfoResponseResultLicenseV2Status) *LicenseV2StatusListInfoResponseResult {
+	o.LicenseV2StatusPtr = &newValue
+	return o
+}
@@ -0,0 +1,128 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LunSetCommentRequest is a structure to represent a lun-set-comment Request ZAPI object
+type LunSetCommentRequest struct {
+	XMLName    xml.Name `xml:"lun-set-comment"`
+	CommentPtr *string  `xml:"comment"`
+	PathPtr    *string  `xml:"path"`
+}
+
+// LunSetCommentResponse is a structure to represent a lun-set-comment Response ZAPI object
+type LunSetCommentResponse struct {
+	XMLName         xml.Name                    `xml:"netapp"`
+	ResponseVersion string                      `xml:"version,attr"`
+	ResponseXmlns   string                      `xml:"xmlns,attr"`
+	Result          LunSetCommentResponseResult `xml:"results"`
+}
+
+// NewLunSetCommentResponse is a factory method for creating new instances of LunSetCommentResponse objects
+func NewLunSetCommentResponse() *LunSetCommentResponse {
+	return &LunSetCommentResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LunSetCommentResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LunSetCommentResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// LunSetCommentResponseResult is a structure to represent a lun-set-comment Response Result ZAPI object
+type LunSetCommentResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewLunSetCommentRequest is a factory method for creating new instances of LunSetCommentRequest objects
+func NewLunSetCommentRequest() *LunSetCommentRequest {
+	return &LunSetCommentRequest{}
+}
+
+// NewLunSetCommentResponseResult is a factory method for creating new instances of LunSetCommentResponseResult objects
+func NewLunSetCommentResponseResult() *LunSetCommentResponseResult {
+	return &LunSetCommentResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LunSetCommentRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LunSetCommentResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LunSetCommentRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LunSetCommentResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *LunSetCommentRequest) ExecuteUsing(zr *ZapiRunner) (*LunSetCommentResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *LunSetCommentRequest) executeWithoutIteration(zr *ZapiRunner) (*LunSetCommentResponse, error) {
+	result, err := zr.ExecuteUsing(o, "LunSetCommentRequest", NewLunSetCommentResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*LunSetCommentResponse), err
+}
+
+// Comment is a 'getter' method
+func (o *LunSetCommentRequest) Comment() string {
+	r := *o.CommentPtr
+	return r
+}
+
+// SetComment is a fluent style 'setter' method that can be chained
+func (o *LunSetCommentRequest) SetComment(newValue string) *LunSetCommentRequest {
+	o.CommentPtr = &newValue
+	return o
+}
+
+// Path is a 'getter' method
+func (o *LunSetCommentRequest) Path() string {
+	r := *o.PathPtr
+	return r
+}
+
+// SetPath is a fluent style 'setter' method that can be chained
+func (o *LunSetCommentRequest) SetPath(newValue string) *LunSetCommentRequest {
+	o.PathPtr = &newValue
+	return o
+}
@@ -0,0 +1,334 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// QuotaReportIterRequest is a structure to represent a quota-report-iter Request ZAPI object
+type QuotaReportIterRequest struct {
+	XMLName              xml.Name                                 `xml:"quota-report-iter"`
+	DesiredAttributesPtr *QuotaReportIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                     `xml:"max-records"`
+	QueryPtr             *QuotaReportIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                  `xml:"tag"`
+}
+
+// QuotaReportIterResponse is a structure to represent a quota-report-iter Response ZAPI object
+type QuotaReportIterResponse struct {
+	XMLName         xml.Name                      `xml:"netapp"`
+	ResponseVersion string                        `xml:"version,attr"`
+	ResponseXmlns   string                        `xml:"xmlns,attr"`
+	Result          QuotaReportIterResponseResult `xml:"results"`
+}
+
+// NewQuotaReportIterResponse is a factory method for creating new instances of QuotaReportIterResponse objects
+func NewQuotaReportIterResponse() *QuotaReportIterResponse {
+	return &QuotaReportIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *QuotaReportIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// QuotaReportIterResponseResult is a structure to represent a quota-report-iter Response Result ZAPI object
+type QuotaReportIterResponseResult struct {
+	XMLName           xml.Name                                     `xml:"results"`
+	ResultStatusAttr  string                                       `xml:"status,attr"`
+	ResultReasonAttr  string                                       `xml:"reason,attr"`
+	ResultErrnoAttr   string                                       `xml:"errno,attr"`
+	AttributesListPtr *QuotaReportIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                      `xml:"next-tag"`
+	NumRecordsPtr     *int                                         `xml:"num-records"`
+}
+
+// NewQuotaReportIterRequest is a factory method for creating new instances of QuotaReportIterRequest objects
+func NewQuotaReportIterRequest() *QuotaReportIterRequest {
+	return &QuotaReportIterRequest{}
+}
+
+// NewQuotaReportIterResponseResult is a factory method for creating new instances of QuotaReportIterResponseResult objects
+func NewQuotaReportIterResponseResult() *QuotaReportIterResponseResult {
+	return &QuotaReportIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *QuotaReportIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *QuotaReportIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *QuotaReportIterRequest) ExecuteUsing(zr *ZapiRunner) (*QuotaReportIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *QuotaReportIterRequest) executeWithoutIteration(zr *ZapiRunner) (*QuotaReportIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "QuotaReportIterRequest", NewQuotaReportIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*QuotaReportIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *QuotaReportIterRequest) executeWithIteration(zr *ZapiRunner) (*QuotaReportIterResponse, error) {
+	combined := NewQuotaReportIterResponse()
+	combined.Result.SetAttributesList(QuotaReportIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(QuotaReportIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// QuotaReportIterRequestDesiredAttributes is a wrapper
+type QuotaReportIterRequestDesiredAttributes struct {
+	XMLName  xml.Name   `xml:"desired-attributes"`
+	QuotaPtr *QuotaType `xml:"quota"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Quota is a 'getter' method
+func (o *QuotaReportIterRequestDesiredAttributes) Quota() QuotaType {
+	r := *o.QuotaPtr
+	return r
+}
+
+// SetQuota is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequestDesiredAttributes) SetQuota(newValue QuotaType) *QuotaReportIterRequestDesiredAttributes {
+	o.QuotaPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *QuotaReportIterRequest) DesiredAttributes() QuotaReportIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequest) SetDesiredAttributes(newValue QuotaReportIterRequestDesiredAttributes) *QuotaReportIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *QuotaReportIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequest) SetMaxRecords(newValue int) *QuotaReportIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// QuotaReportIterRequestQuery is a wrapper
+type QuotaReportIterRequestQuery struct {
+	XMLName  xml.Name   `xml:"query"`
+	QuotaPtr *QuotaType `xml:"quota"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Quota is a 'getter' method
+func (o *QuotaReportIterRequestQuery) Quota() QuotaType {
+	r := *o.QuotaPtr
+	return r
+}
+
+// SetQuota is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequestQuery) SetQuota(newValue QuotaType) *QuotaReportIterRequestQuery {
+	o.QuotaPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *QuotaReportIterRequest) Query() QuotaReportIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequest) SetQuery(newValue QuotaReportIterRequestQuery) *QuotaReportIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *QuotaReportIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterRequest) SetTag(newValue string) *QuotaReportIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// QuotaReportIterResponseResultAttributesList is a wrapper
+type QuotaReportIterResponseResultAttributesList struct {
+	XMLName  xml.Name    `xml:"attributes-list"`
+	QuotaPtr []QuotaType `xml:"quota"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaReportIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Quota is a 'getter' method
+func (o *QuotaReportIterResponseResultAttributesList) Quota() []QuotaType {
+	r := o.QuotaPtr
+	return r
+}
+
+// SetQuota is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterResponseResultAttributesList) SetQuota(newValue []QuotaType) *QuotaReportIterResponseResultAttributesList {
+	newSlice := make([]QuotaType, len(newValue))
+	copy(newSlice, newValue)
+	o.QuotaPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *QuotaReportIterResponseResultAttributesList) values() []QuotaType {
+	r := o.QuotaPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterResponseResultAttributesList) setValues(newValue []QuotaType) *QuotaReportIterResponseResultAttributesList {
+	newSlice := make([]QuotaType, len(newValue))
+	copy(newSlice, newValue)
+	o.QuotaPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *QuotaReportIterResponseResult) AttributesList() QuotaReportIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterResponseResult) SetAttributesList(newValue QuotaReportIterResponseResultAttributesList) *QuotaReportIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *QuotaReportIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterResponseResult) SetNextTag(newValue string) *QuotaReportIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *QuotaReportIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *QuotaReportIterResponseResult) SetNumRecords(newValue int) *QuotaReportIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
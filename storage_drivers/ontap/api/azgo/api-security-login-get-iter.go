@@ -0,0 +1,334 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SecurityLoginGetIterRequest is a structure to represent a security-login-get-iter Request ZAPI object
+type SecurityLoginGetIterRequest struct {
+	XMLName              xml.Name                                      `xml:"security-login-get-iter"`
+	DesiredAttributesPtr *SecurityLoginGetIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                          `xml:"max-records"`
+	QueryPtr             *SecurityLoginGetIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                       `xml:"tag"`
+}
+
+// SecurityLoginGetIterResponse is a structure to represent a security-login-get-iter Response ZAPI object
+type SecurityLoginGetIterResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          SecurityLoginGetIterResponseResult `xml:"results"`
+}
+
+// NewSecurityLoginGetIterResponse is a factory method for creating new instances of SecurityLoginGetIterResponse objects
+func NewSecurityLoginGetIterResponse() *SecurityLoginGetIterResponse {
+	return &SecurityLoginGetIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginGetIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SecurityLoginGetIterResponseResult is a structure to represent a security-login-get-iter Response Result ZAPI object
+type SecurityLoginGetIterResponseResult struct {
+	XMLName           xml.Name                                          `xml:"results"`
+	ResultStatusAttr  string                                            `xml:"status,attr"`
+	ResultReasonAttr  string                                            `xml:"reason,attr"`
+	ResultErrnoAttr   string                                            `xml:"errno,attr"`
+	AttributesListPtr *SecurityLoginGetIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                           `xml:"next-tag"`
+	NumRecordsPtr     *int                                              `xml:"num-records"`
+}
+
+// NewSecurityLoginGetIterRequest is a factory method for creating new instances of SecurityLoginGetIterRequest objects
+func NewSecurityLoginGetIterRequest() *SecurityLoginGetIterRequest {
+	return &SecurityLoginGetIterRequest{}
+}
+
+// NewSecurityLoginGetIterResponseResult is a factory method for creating new instances of SecurityLoginGetIterResponseResult objects
+func NewSecurityLoginGetIterResponseResult() *SecurityLoginGetIterResponseResult {
+	return &SecurityLoginGetIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginGetIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginGetIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SecurityLoginGetIterRequest) ExecuteUsing(zr *ZapiRunner) (*SecurityLoginGetIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SecurityLoginGetIterRequest) executeWithoutIteration(zr *ZapiRunner) (*SecurityLoginGetIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SecurityLoginGetIterRequest", NewSecurityLoginGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SecurityLoginGetIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *SecurityLoginGetIterRequest) executeWithIteration(zr *ZapiRunner) (*SecurityLoginGetIterResponse, error) {
+	combined := NewSecurityLoginGetIterResponse()
+	combined.Result.SetAttributesList(SecurityLoginGetIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(SecurityLoginGetIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// SecurityLoginGetIterRequestDesiredAttributes is a wrapper
+type SecurityLoginGetIterRequestDesiredAttributes struct {
+	XMLName                     xml.Name                      `xml:"desired-attributes"`
+	SecurityLoginAccountInfoPtr *SecurityLoginAccountInfoType `xml:"security-login-account-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginAccountInfo is a 'getter' method
+func (o *SecurityLoginGetIterRequestDesiredAttributes) SecurityLoginAccountInfo() SecurityLoginAccountInfoType {
+	r := *o.SecurityLoginAccountInfoPtr
+	return r
+}
+
+// SetSecurityLoginAccountInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequestDesiredAttributes) SetSecurityLoginAccountInfo(newValue SecurityLoginAccountInfoType) *SecurityLoginGetIterRequestDesiredAttributes {
+	o.SecurityLoginAccountInfoPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *SecurityLoginGetIterRequest) DesiredAttributes() SecurityLoginGetIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequest) SetDesiredAttributes(newValue SecurityLoginGetIterRequestDesiredAttributes) *SecurityLoginGetIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *SecurityLoginGetIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequest) SetMaxRecords(newValue int) *SecurityLoginGetIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// SecurityLoginGetIterRequestQuery is a wrapper
+type SecurityLoginGetIterRequestQuery struct {
+	XMLName                     xml.Name                      `xml:"query"`
+	SecurityLoginAccountInfoPtr *SecurityLoginAccountInfoType `xml:"security-login-account-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginAccountInfo is a 'getter' method
+func (o *SecurityLoginGetIterRequestQuery) SecurityLoginAccountInfo() SecurityLoginAccountInfoType {
+	r := *o.SecurityLoginAccountInfoPtr
+	return r
+}
+
+// SetSecurityLoginAccountInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequestQuery) SetSecurityLoginAccountInfo(newValue SecurityLoginAccountInfoType) *SecurityLoginGetIterRequestQuery {
+	o.SecurityLoginAccountInfoPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *SecurityLoginGetIterRequest) Query() SecurityLoginGetIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequest) SetQuery(newValue SecurityLoginGetIterRequestQuery) *SecurityLoginGetIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *SecurityLoginGetIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterRequest) SetTag(newValue string) *SecurityLoginGetIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// SecurityLoginGetIterResponseResultAttributesList is a wrapper
+type SecurityLoginGetIterResponseResultAttributesList struct {
+	XMLName                     xml.Name                       `xml:"attributes-list"`
+	SecurityLoginAccountInfoPtr []SecurityLoginAccountInfoType `xml:"security-login-account-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginGetIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginAccountInfo is a 'getter' method
+func (o *SecurityLoginGetIterResponseResultAttributesList) SecurityLoginAccountInfo() []SecurityLoginAccountInfoType {
+	r := o.SecurityLoginAccountInfoPtr
+	return r
+}
+
+// SetSecurityLoginAccountInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterResponseResultAttributesList) SetSecurityLoginAccountInfo(newValue []SecurityLoginAccountInfoType) *SecurityLoginGetIterResponseResultAttributesList {
+	newSlice := make([]SecurityLoginAccountInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SecurityLoginAccountInfoPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *SecurityLoginGetIterResponseResultAttributesList) values() []SecurityLoginAccountInfoType {
+	r := o.SecurityLoginAccountInfoPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterResponseResultAttributesList) setValues(newValue []SecurityLoginAccountInfoType) *SecurityLoginGetIterResponseResultAttributesList {
+	newSlice := make([]SecurityLoginAccountInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SecurityLoginAccountInfoPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *SecurityLoginGetIterResponseResult) AttributesList() SecurityLoginGetIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterResponseResult) SetAttributesList(newValue SecurityLoginGetIterResponseResultAttributesList) *SecurityLoginGetIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *SecurityLoginGetIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterResponseResult) SetNextTag(newValue string) *SecurityLoginGetIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *SecurityLoginGetIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginGetIterResponseResult) SetNumRecords(newValue int) *SecurityLoginGetIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
@@ -0,0 +1,334 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SecurityLoginRoleGetIterRequest is a structure to represent a security-login-role-get-iter Request ZAPI object
+type SecurityLoginRoleGetIterRequest struct {
+	XMLName              xml.Name                                          `xml:"security-login-role-get-iter"`
+	DesiredAttributesPtr *SecurityLoginRoleGetIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                              `xml:"max-records"`
+	QueryPtr             *SecurityLoginRoleGetIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                           `xml:"tag"`
+}
+
+// SecurityLoginRoleGetIterResponse is a structure to represent a security-login-role-get-iter Response ZAPI object
+type SecurityLoginRoleGetIterResponse struct {
+	XMLName         xml.Name                               `xml:"netapp"`
+	ResponseVersion string                                 `xml:"version,attr"`
+	ResponseXmlns   string                                 `xml:"xmlns,attr"`
+	Result          SecurityLoginRoleGetIterResponseResult `xml:"results"`
+}
+
+// NewSecurityLoginRoleGetIterResponse is a factory method for creating new instances of SecurityLoginRoleGetIterResponse objects
+func NewSecurityLoginRoleGetIterResponse() *SecurityLoginRoleGetIterResponse {
+	return &SecurityLoginRoleGetIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginRoleGetIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SecurityLoginRoleGetIterResponseResult is a structure to represent a security-login-role-get-iter Response Result ZAPI object
+type SecurityLoginRoleGetIterResponseResult struct {
+	XMLName           xml.Name                                              `xml:"results"`
+	ResultStatusAttr  string                                                `xml:"status,attr"`
+	ResultReasonAttr  string                                                `xml:"reason,attr"`
+	ResultErrnoAttr   string                                                `xml:"errno,attr"`
+	AttributesListPtr *SecurityLoginRoleGetIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                               `xml:"next-tag"`
+	NumRecordsPtr     *int                                                  `xml:"num-records"`
+}
+
+// NewSecurityLoginRoleGetIterRequest is a factory method for creating new instances of SecurityLoginRoleGetIterRequest objects
+func NewSecurityLoginRoleGetIterRequest() *SecurityLoginRoleGetIterRequest {
+	return &SecurityLoginRoleGetIterRequest{}
+}
+
+// NewSecurityLoginRoleGetIterResponseResult is a factory method for creating new instances of SecurityLoginRoleGetIterResponseResult objects
+func NewSecurityLoginRoleGetIterResponseResult() *SecurityLoginRoleGetIterResponseResult {
+	return &SecurityLoginRoleGetIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginRoleGetIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginRoleGetIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SecurityLoginRoleGetIterRequest) ExecuteUsing(zr *ZapiRunner) (*SecurityLoginRoleGetIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SecurityLoginRoleGetIterRequest) executeWithoutIteration(zr *ZapiRunner) (*SecurityLoginRoleGetIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SecurityLoginRoleGetIterRequest", NewSecurityLoginRoleGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SecurityLoginRoleGetIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *SecurityLoginRoleGetIterRequest) executeWithIteration(zr *ZapiRunner) (*SecurityLoginRoleGetIterResponse, error) {
+	combined := NewSecurityLoginRoleGetIterResponse()
+	combined.Result.SetAttributesList(SecurityLoginRoleGetIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(SecurityLoginRoleGetIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// SecurityLoginRoleGetIterRequestDesiredAttributes is a wrapper
+type SecurityLoginRoleGetIterRequestDesiredAttributes struct {
+	XMLName                  xml.Name                   `xml:"desired-attributes"`
+	SecurityLoginRoleInfoPtr *SecurityLoginRoleInfoType `xml:"security-login-role-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginRoleInfo is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequestDesiredAttributes) SecurityLoginRoleInfo() SecurityLoginRoleInfoType {
+	r := *o.SecurityLoginRoleInfoPtr
+	return r
+}
+
+// SetSecurityLoginRoleInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequestDesiredAttributes) SetSecurityLoginRoleInfo(newValue SecurityLoginRoleInfoType) *SecurityLoginRoleGetIterRequestDesiredAttributes {
+	o.SecurityLoginRoleInfoPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequest) DesiredAttributes() SecurityLoginRoleGetIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequest) SetDesiredAttributes(newValue SecurityLoginRoleGetIterRequestDesiredAttributes) *SecurityLoginRoleGetIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequest) SetMaxRecords(newValue int) *SecurityLoginRoleGetIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// SecurityLoginRoleGetIterRequestQuery is a wrapper
+type SecurityLoginRoleGetIterRequestQuery struct {
+	XMLName                  xml.Name                   `xml:"query"`
+	SecurityLoginRoleInfoPtr *SecurityLoginRoleInfoType `xml:"security-login-role-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginRoleInfo is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequestQuery) SecurityLoginRoleInfo() SecurityLoginRoleInfoType {
+	r := *o.SecurityLoginRoleInfoPtr
+	return r
+}
+
+// SetSecurityLoginRoleInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequestQuery) SetSecurityLoginRoleInfo(newValue SecurityLoginRoleInfoType) *SecurityLoginRoleGetIterRequestQuery {
+	o.SecurityLoginRoleInfoPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequest) Query() SecurityLoginRoleGetIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequest) SetQuery(newValue SecurityLoginRoleGetIterRequestQuery) *SecurityLoginRoleGetIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *SecurityLoginRoleGetIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterRequest) SetTag(newValue string) *SecurityLoginRoleGetIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// SecurityLoginRoleGetIterResponseResultAttributesList is a wrapper
+type SecurityLoginRoleGetIterResponseResultAttributesList struct {
+	XMLName                  xml.Name                    `xml:"attributes-list"`
+	SecurityLoginRoleInfoPtr []SecurityLoginRoleInfoType `xml:"security-login-role-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleGetIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SecurityLoginRoleInfo is a 'getter' method
+func (o *SecurityLoginRoleGetIterResponseResultAttributesList) SecurityLoginRoleInfo() []SecurityLoginRoleInfoType {
+	r := o.SecurityLoginRoleInfoPtr
+	return r
+}
+
+// SetSecurityLoginRoleInfo is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterResponseResultAttributesList) SetSecurityLoginRoleInfo(newValue []SecurityLoginRoleInfoType) *SecurityLoginRoleGetIterResponseResultAttributesList {
+	newSlice := make([]SecurityLoginRoleInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SecurityLoginRoleInfoPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *SecurityLoginRoleGetIterResponseResultAttributesList) values() []SecurityLoginRoleInfoType {
+	r := o.SecurityLoginRoleInfoPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterResponseResultAttributesList) setValues(newValue []SecurityLoginRoleInfoType) *SecurityLoginRoleGetIterResponseResultAttributesList {
+	newSlice := make([]SecurityLoginRoleInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SecurityLoginRoleInfoPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *SecurityLoginRoleGetIterResponseResult) AttributesList() SecurityLoginRoleGetIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterResponseResult) SetAttributesList(newValue SecurityLoginRoleGetIterResponseResultAttributesList) *SecurityLoginRoleGetIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *SecurityLoginRoleGetIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterResponseResult) SetNextTag(newValue string) *SecurityLoginRoleGetIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *SecurityLoginRoleGetIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleGetIterResponseResult) SetNumRecords(newValue int) *SecurityLoginRoleGetIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
@@ -0,0 +1,180 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapmirrorDestroyRequest is a structure to represent a snapmirror-destroy Request ZAPI object
+type SnapmirrorDestroyRequest struct {
+	XMLName                xml.Name `xml:"snapmirror-destroy"`
+	DestinationLocationPtr *string  `xml:"destination-location"`
+}
+
+// SnapmirrorDestroyResponse is a structure to represent a snapmirror-destroy Response ZAPI object
+type SnapmirrorDestroyResponse struct {
+	XMLName         xml.Name                        `xml:"netapp"`
+	ResponseVersion string                          `xml:"version,attr"`
+	ResponseXmlns   string                          `xml:"xmlns,attr"`
+	Result          SnapmirrorDestroyResponseResult `xml:"results"`
+}
+
+// NewSnapmirrorDestroyResponse is a factory method for creating new instances of SnapmirrorDestroyResponse objects
+func NewSnapmirrorDestroyResponse() *SnapmirrorDestroyResponse {
+	return &SnapmirrorDestroyResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorDestroyResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorDestroyResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapmirrorDestroyResponseResult is a structure to represent a snapmirror-destroy Response Result ZAPI object
+type SnapmirrorDestroyResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultOperationIdPtr  *string  `xml:"result-operation-id"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewSnapmirrorDestroyRequest is a factory method for creating new instances of SnapmirrorDestroyRequest objects
+func NewSnapmirrorDestroyRequest() *SnapmirrorDestroyRequest {
+	return &SnapmirrorDestroyRequest{}
+}
+
+// NewSnapmirrorDestroyResponseResult is a factory method for creating new instances of SnapmirrorDestroyResponseResult objects
+func NewSnapmirrorDestroyResponseResult() *SnapmirrorDestroyResponseResult {
+	return &SnapmirrorDestroyResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorDestroyRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorDestroyResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorDestroyRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorDestroyResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorDestroyRequest) ExecuteUsing(zr *ZapiRunner) (*SnapmirrorDestroyResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorDestroyRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapmirrorDestroyResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapmirrorDestroyRequest", NewSnapmirrorDestroyResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapmirrorDestroyResponse), err
+}
+
+// DestinationLocation is a 'getter' method
+func (o *SnapmirrorDestroyRequest) DestinationLocation() string {
+	r := *o.DestinationLocationPtr
+	return r
+}
+
+// SetDestinationLocation is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyRequest) SetDestinationLocation(newValue string) *SnapmirrorDestroyRequest {
+	o.DestinationLocationPtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *SnapmirrorDestroyResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyResponseResult) SetResultErrorCode(newValue int) *SnapmirrorDestroyResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *SnapmirrorDestroyResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyResponseResult) SetResultErrorMessage(newValue string) *SnapmirrorDestroyResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *SnapmirrorDestroyResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyResponseResult) SetResultJobid(newValue int) *SnapmirrorDestroyResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultOperationId is a 'getter' method
+func (o *SnapmirrorDestroyResponseResult) ResultOperationId() string {
+	r := *o.ResultOperationIdPtr
+	return r
+}
+
+// SetResultOperationId is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyResponseResult) SetResultOperationId(newValue string) *SnapmirrorDestroyResponseResult {
+	o.ResultOperationIdPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *SnapmirrorDestroyResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorDestroyResponseResult) SetResultStatus(newValue string) *SnapmirrorDestroyResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
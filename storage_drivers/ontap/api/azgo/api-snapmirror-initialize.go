@@ -0,0 +1,206 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapmirrorInitializeRequest is a structure to represent a snapmirror-initialize Request ZAPI object
+type SnapmirrorInitializeRequest struct {
+	XMLName                xml.Name `xml:"snapmirror-initialize"`
+	DestinationLocationPtr *string  `xml:"destination-location"`
+	SourceLocationPtr      *string  `xml:"source-location"`
+	SourceSnapshotPtr      *string  `xml:"source-snapshot"`
+}
+
+// SnapmirrorInitializeResponse is a structure to represent a snapmirror-initialize Response ZAPI object
+type SnapmirrorInitializeResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          SnapmirrorInitializeResponseResult `xml:"results"`
+}
+
+// NewSnapmirrorInitializeResponse is a factory method for creating new instances of SnapmirrorInitializeResponse objects
+func NewSnapmirrorInitializeResponse() *SnapmirrorInitializeResponse {
+	return &SnapmirrorInitializeResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorInitializeResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorInitializeResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapmirrorInitializeResponseResult is a structure to represent a snapmirror-initialize Response Result ZAPI object
+type SnapmirrorInitializeResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultOperationIdPtr  *string  `xml:"result-operation-id"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewSnapmirrorInitializeRequest is a factory method for creating new instances of SnapmirrorInitializeRequest objects
+func NewSnapmirrorInitializeRequest() *SnapmirrorInitializeRequest {
+	return &SnapmirrorInitializeRequest{}
+}
+
+// NewSnapmirrorInitializeResponseResult is a factory method for creating new instances of SnapmirrorInitializeResponseResult objects
+func NewSnapmirrorInitializeResponseResult() *SnapmirrorInitializeResponseResult {
+	return &SnapmirrorInitializeResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorInitializeRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapmirrorInitializeResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorInitializeRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapmirrorInitializeResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorInitializeRequest) ExecuteUsing(zr *ZapiRunner) (*SnapmirrorInitializeResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapmirrorInitializeRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapmirrorInitializeResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapmirrorInitializeRequest", NewSnapmirrorInitializeResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapmirrorInitializeResponse), err
+}
+
+// DestinationLocation is a 'getter' method
+func (o *SnapmirrorInitializeRequest) DestinationLocation() string {
+	r := *o.DestinationLocationPtr
+	return r
+}
+
+// SetDestinationLocation is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeRequest) SetDestinationLocation(newValue string) *SnapmirrorInitializeRequest {
+	o.DestinationLocationPtr = &newValue
+	return o
+}
+
+// SourceLocation is a 'getter' method
+func (o *SnapmirrorInitializeRequest) SourceLocation() string {
+	r := *o.SourceLocationPtr
+	return r
+}
+
+// SetSourceLocation is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeRequest) SetSourceLocation(newValue string) *SnapmirrorInitializeRequest {
+	o.SourceLocationPtr = &newValue
+	return o
+}
+
+// SourceSnapshot is a 'getter' method
+func (o *SnapmirrorInitializeRequest) SourceSnapshot() string {
+	r := *o.SourceSnapshotPtr
+	return r
+}
+
+// SetSourceSnapshot is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeRequest) SetSourceSnapshot(newValue string) *SnapmirrorInitializeRequest {
+	o.SourceSnapshotPtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *SnapmirrorInitializeResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeResponseResult) SetResultErrorCode(newValue int) *SnapmirrorInitializeResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *SnapmirrorInitializeResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeResponseResult) SetResultErrorMessage(newValue string) *SnapmirrorInitializeResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *SnapmirrorInitializeResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeResponseResult) SetResultJobid(newValue int) *SnapmirrorInitializeResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultOperationId is a 'getter' method
+func (o *SnapmirrorInitializeResponseResult) ResultOperationId() string {
+	r := *o.ResultOperationIdPtr
+	return r
+}
+
+// SetResultOperationId is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeResponseResult) SetResultOperationId(newValue string) *SnapmirrorInitializeResponseResult {
+	o.ResultOperationIdPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *SnapmirrorInitializeResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *SnapmirrorInitializeResponseResult) SetResultStatus(newValue string) *SnapmirrorInitializeResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
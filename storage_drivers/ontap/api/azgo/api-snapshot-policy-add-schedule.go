@@ -0,0 +1,154 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyAddScheduleRequest is a structure to represent a snapshot-policy-add-schedule Request ZAPI object
+type SnapshotPolicyAddScheduleRequest struct {
+	XMLName     xml.Name `xml:"snapshot-policy-add-schedule"`
+	CountPtr    *int     `xml:"count"`
+	PolicyPtr   *string  `xml:"policy"`
+	PrefixPtr   *string  `xml:"prefix"`
+	SchedulePtr *string  `xml:"schedule"`
+}
+
+// SnapshotPolicyAddScheduleResponse is a structure to represent a snapshot-policy-add-schedule Response ZAPI object
+type SnapshotPolicyAddScheduleResponse struct {
+	XMLName         xml.Name                                `xml:"netapp"`
+	ResponseVersion string                                  `xml:"version,attr"`
+	ResponseXmlns   string                                  `xml:"xmlns,attr"`
+	Result          SnapshotPolicyAddScheduleResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyAddScheduleResponse is a factory method for creating new instances of SnapshotPolicyAddScheduleResponse objects
+func NewSnapshotPolicyAddScheduleResponse() *SnapshotPolicyAddScheduleResponse {
+	return &SnapshotPolicyAddScheduleResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyAddScheduleResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyAddScheduleResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyAddScheduleResponseResult is a structure to represent a snapshot-policy-add-schedule Response Result ZAPI object
+type SnapshotPolicyAddScheduleResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapshotPolicyAddScheduleRequest is a factory method for creating new instances of SnapshotPolicyAddScheduleRequest objects
+func NewSnapshotPolicyAddScheduleRequest() *SnapshotPolicyAddScheduleRequest {
+	return &SnapshotPolicyAddScheduleRequest{}
+}
+
+// NewSnapshotPolicyAddScheduleResponseResult is a factory method for creating new instances of SnapshotPolicyAddScheduleResponseResult objects
+func NewSnapshotPolicyAddScheduleResponseResult() *SnapshotPolicyAddScheduleResponseResult {
+	return &SnapshotPolicyAddScheduleResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyAddScheduleRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyAddScheduleResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyAddScheduleRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyAddScheduleResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyAddScheduleRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyAddScheduleResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyAddScheduleRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyAddScheduleResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyAddScheduleRequest", NewSnapshotPolicyAddScheduleResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyAddScheduleResponse), err
+}
+
+// Count is a 'getter' method
+func (o *SnapshotPolicyAddScheduleRequest) Count() int {
+	r := *o.CountPtr
+	return r
+}
+
+// SetCount is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyAddScheduleRequest) SetCount(newValue int) *SnapshotPolicyAddScheduleRequest {
+	o.CountPtr = &newValue
+	return o
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyAddScheduleRequest) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyAddScheduleRequest) SetPolicy(newValue string) *SnapshotPolicyAddScheduleRequest {
+	o.PolicyPtr = &newValue
+	return o
+}
+
+// Prefix is a 'getter' method
+func (o *SnapshotPolicyAddScheduleRequest) Prefix() string {
+	r := *o.PrefixPtr
+	return r
+}
+
+// SetPrefix is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyAddScheduleRequest) SetPrefix(newValue string) *SnapshotPolicyAddScheduleRequest {
+	o.PrefixPtr = &newValue
+	return o
+}
+
+// Schedule is a 'getter' method
+func (o *SnapshotPolicyAddScheduleRequest) Schedule() string {
+	r := *o.SchedulePtr
+	return r
+}
+
+// SetSchedule is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyAddScheduleRequest) SetSchedule(newValue string) *SnapshotPolicyAddScheduleRequest {
+	o.SchedulePtr = &newValue
+	return o
+}
@@ -0,0 +1,180 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyCreateRequest is a structure to represent a snapshot-policy-create Request ZAPI object
+type SnapshotPolicyCreateRequest struct {
+	XMLName      xml.Name `xml:"snapshot-policy-create"`
+	CommentPtr   *string  `xml:"comment"`
+	Count1Ptr    *int     `xml:"count1"`
+	EnabledPtr   *bool    `xml:"enabled"`
+	PolicyPtr    *string  `xml:"policy"`
+	Prefix1Ptr   *string  `xml:"prefix1"`
+	Schedule1Ptr *string  `xml:"schedule1"`
+}
+
+// SnapshotPolicyCreateResponse is a structure to represent a snapshot-policy-create Response ZAPI object
+type SnapshotPolicyCreateResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          SnapshotPolicyCreateResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyCreateResponse is a factory method for creating new instances of SnapshotPolicyCreateResponse objects
+func NewSnapshotPolicyCreateResponse() *SnapshotPolicyCreateResponse {
+	return &SnapshotPolicyCreateResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyCreateResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyCreateResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyCreateResponseResult is a structure to represent a snapshot-policy-create Response Result ZAPI object
+type SnapshotPolicyCreateResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapshotPolicyCreateRequest is a factory method for creating new instances of SnapshotPolicyCreateRequest objects
+func NewSnapshotPolicyCreateRequest() *SnapshotPolicyCreateRequest {
+	return &SnapshotPolicyCreateRequest{}
+}
+
+// NewSnapshotPolicyCreateResponseResult is a factory method for creating new instances of SnapshotPolicyCreateResponseResult objects
+func NewSnapshotPolicyCreateResponseResult() *SnapshotPolicyCreateResponseResult {
+	return &SnapshotPolicyCreateResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyCreateRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyCreateResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyCreateRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyCreateResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyCreateRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyCreateResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyCreateRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyCreateResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyCreateRequest", NewSnapshotPolicyCreateResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyCreateResponse), err
+}
+
+// Comment is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Comment() string {
+	r := *o.CommentPtr
+	return r
+}
+
+// SetComment is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetComment(newValue string) *SnapshotPolicyCreateRequest {
+	o.CommentPtr = &newValue
+	return o
+}
+
+// Count1 is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Count1() int {
+	r := *o.Count1Ptr
+	return r
+}
+
+// SetCount1 is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetCount1(newValue int) *SnapshotPolicyCreateRequest {
+	o.Count1Ptr = &newValue
+	return o
+}
+
+// Enabled is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Enabled() bool {
+	r := *o.EnabledPtr
+	return r
+}
+
+// SetEnabled is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetEnabled(newValue bool) *SnapshotPolicyCreateRequest {
+	o.EnabledPtr = &newValue
+	return o
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetPolicy(newValue string) *SnapshotPolicyCreateRequest {
+	o.PolicyPtr = &newValue
+	return o
+}
+
+// Prefix1 is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Prefix1() string {
+	r := *o.Prefix1Ptr
+	return r
+}
+
+// SetPrefix1 is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetPrefix1(newValue string) *SnapshotPolicyCreateRequest {
+	o.Prefix1Ptr = &newValue
+	return o
+}
+
+// Schedule1 is a 'getter' method
+func (o *SnapshotPolicyCreateRequest) Schedule1() string {
+	r := *o.Schedule1Ptr
+	return r
+}
+
+// SetSchedule1 is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyCreateRequest) SetSchedule1(newValue string) *SnapshotPolicyCreateRequest {
+	o.Schedule1Ptr = &newValue
+	return o
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyGetIterRequest is a structure to represent a snapshot-policy-get-iter Request ZAPI object
+type SnapshotPolicyGetIterRequest struct {
+	XMLName              xml.Name                                       `xml:"snapshot-policy-get-iter"`
+	DesiredAttributesPtr *SnapshotPolicyGetIterRequestDesiredAttributes `xml:"desired-attributes"`
+	MaxRecordsPtr        *int                                           `xml:"max-records"`
+	QueryPtr             *SnapshotPolicyGetIterRequestQuery             `xml:"query"`
+	TagPtr               *string                                        `xml:"tag"`
+}
+
+// SnapshotPolicyGetIterResponse is a structure to represent a snapshot-policy-get-iter Response ZAPI object
+type SnapshotPolicyGetIterResponse struct {
+	XMLName         xml.Name                            `xml:"netapp"`
+	ResponseVersion string                              `xml:"version,attr"`
+	ResponseXmlns   string                              `xml:"xmlns,attr"`
+	Result          SnapshotPolicyGetIterResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyGetIterResponse is a factory method for creating new instances of SnapshotPolicyGetIterResponse objects
+func NewSnapshotPolicyGetIterResponse() *SnapshotPolicyGetIterResponse {
+	return &SnapshotPolicyGetIterResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyGetIterResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyGetIterResponseResult is a structure to represent a snapshot-policy-get-iter Response Result ZAPI object
+type SnapshotPolicyGetIterResponseResult struct {
+	XMLName           xml.Name                                           `xml:"results"`
+	ResultStatusAttr  string                                             `xml:"status,attr"`
+	ResultReasonAttr  string                                             `xml:"reason,attr"`
+	ResultErrnoAttr   string                                             `xml:"errno,attr"`
+	AttributesListPtr *SnapshotPolicyGetIterResponseResultAttributesList `xml:"attributes-list"`
+	NextTagPtr        *string                                            `xml:"next-tag"`
+	NumRecordsPtr     *int                                               `xml:"num-records"`
+}
+
+// NewSnapshotPolicyGetIterRequest is a factory method for creating new instances of SnapshotPolicyGetIterRequest objects
+func NewSnapshotPolicyGetIterRequest() *SnapshotPolicyGetIterRequest {
+	return &SnapshotPolicyGetIterRequest{}
+}
+
+// NewSnapshotPolicyGetIterResponseResult is a factory method for creating new instances of SnapshotPolicyGetIterResponseResult objects
+func NewSnapshotPolicyGetIterResponseResult() *SnapshotPolicyGetIterResponseResult {
+	return &SnapshotPolicyGetIterResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyGetIterRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyGetIterResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyGetIterRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyGetIterResponse, error) {
+	return o.executeWithIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyGetIterRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyGetIterResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyGetIterRequest", NewSnapshotPolicyGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyGetIterResponse), err
+}
+
+// executeWithIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+func (o *SnapshotPolicyGetIterRequest) executeWithIteration(zr *ZapiRunner) (*SnapshotPolicyGetIterResponse, error) {
+	combined := NewSnapshotPolicyGetIterResponse()
+	combined.Result.SetAttributesList(SnapshotPolicyGetIterResponseResultAttributesList{})
+	var nextTagPtr *string
+	done := false
+	for done != true {
+		n, err := o.executeWithoutIteration(zr)
+
+		if err != nil {
+			return nil, err
+		}
+		nextTagPtr = n.Result.NextTagPtr
+		if nextTagPtr == nil {
+			done = true
+		} else {
+			o.SetTag(*nextTagPtr)
+		}
+
+		if n.Result.NumRecordsPtr == nil {
+			done = true
+		} else {
+			recordsRead := n.Result.NumRecords()
+			if recordsRead == 0 {
+				done = true
+			}
+		}
+
+		if n.Result.AttributesListPtr != nil {
+			if combined.Result.AttributesListPtr == nil {
+				combined.Result.SetAttributesList(SnapshotPolicyGetIterResponseResultAttributesList{})
+			}
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+
+			resultAttributesList := n.Result.AttributesList()
+			resultAttributes := resultAttributesList.values()
+
+			combined.Result.AttributesListPtr.setValues(append(combinedAttributes, resultAttributes...))
+		}
+
+		if done == true {
+
+			combined.Result.ResultErrnoAttr = n.Result.ResultErrnoAttr
+			combined.Result.ResultReasonAttr = n.Result.ResultReasonAttr
+			combined.Result.ResultStatusAttr = n.Result.ResultStatusAttr
+
+			combinedAttributesList := combined.Result.AttributesList()
+			combinedAttributes := combinedAttributesList.values()
+			combined.Result.SetNumRecords(len(combinedAttributes))
+
+		}
+	}
+	return combined, nil
+}
+
+// SnapshotPolicyGetIterRequestDesiredAttributes is a wrapper
+type SnapshotPolicyGetIterRequestDesiredAttributes struct {
+	XMLName               xml.Name                `xml:"desired-attributes"`
+	SnapshotPolicyInfoPtr *SnapshotPolicyInfoType `xml:"snapshot-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterRequestDesiredAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SnapshotPolicyInfo is a 'getter' method
+func (o *SnapshotPolicyGetIterRequestDesiredAttributes) SnapshotPolicyInfo() SnapshotPolicyInfoType {
+	r := *o.SnapshotPolicyInfoPtr
+	return r
+}
+
+// SetSnapshotPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequestDesiredAttributes) SetSnapshotPolicyInfo(newValue SnapshotPolicyInfoType) *SnapshotPolicyGetIterRequestDesiredAttributes {
+	o.SnapshotPolicyInfoPtr = &newValue
+	return o
+}
+
+// DesiredAttributes is a 'getter' method
+func (o *SnapshotPolicyGetIterRequest) DesiredAttributes() SnapshotPolicyGetIterRequestDesiredAttributes {
+	r := *o.DesiredAttributesPtr
+	return r
+}
+
+// SetDesiredAttributes is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequest) SetDesiredAttributes(newValue SnapshotPolicyGetIterRequestDesiredAttributes) *SnapshotPolicyGetIterRequest {
+	o.DesiredAttributesPtr = &newValue
+	return o
+}
+
+// MaxRecords is a 'getter' method
+func (o *SnapshotPolicyGetIterRequest) MaxRecords() int {
+	r := *o.MaxRecordsPtr
+	return r
+}
+
+// SetMaxRecords is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequest) SetMaxRecords(newValue int) *SnapshotPolicyGetIterRequest {
+	o.MaxRecordsPtr = &newValue
+	return o
+}
+
+// SnapshotPolicyGetIterRequestQuery is a wrapper
+type SnapshotPolicyGetIterRequestQuery struct {
+	XMLName               xml.Name                `xml:"query"`
+	SnapshotPolicyInfoPtr *SnapshotPolicyInfoType `xml:"snapshot-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterRequestQuery) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SnapshotPolicyInfo is a 'getter' method
+func (o *SnapshotPolicyGetIterRequestQuery) SnapshotPolicyInfo() SnapshotPolicyInfoType {
+	r := *o.SnapshotPolicyInfoPtr
+	return r
+}
+
+// SetSnapshotPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequestQuery) SetSnapshotPolicyInfo(newValue SnapshotPolicyInfoType) *SnapshotPolicyGetIterRequestQuery {
+	o.SnapshotPolicyInfoPtr = &newValue
+	return o
+}
+
+// Query is a 'getter' method
+func (o *SnapshotPolicyGetIterRequest) Query() SnapshotPolicyGetIterRequestQuery {
+	r := *o.QueryPtr
+	return r
+}
+
+// SetQuery is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequest) SetQuery(newValue SnapshotPolicyGetIterRequestQuery) *SnapshotPolicyGetIterRequest {
+	o.QueryPtr = &newValue
+	return o
+}
+
+// Tag is a 'getter' method
+func (o *SnapshotPolicyGetIterRequest) Tag() string {
+	r := *o.TagPtr
+	return r
+}
+
+// SetTag is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterRequest) SetTag(newValue string) *SnapshotPolicyGetIterRequest {
+	o.TagPtr = &newValue
+	return o
+}
+
+// SnapshotPolicyGetIterResponseResultAttributesList is a wrapper
+type SnapshotPolicyGetIterResponseResultAttributesList struct {
+	XMLName               xml.Name                 `xml:"attributes-list"`
+	SnapshotPolicyInfoPtr []SnapshotPolicyInfoType `xml:"snapshot-policy-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyGetIterResponseResultAttributesList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// SnapshotPolicyInfo is a 'getter' method
+func (o *SnapshotPolicyGetIterResponseResultAttributesList) SnapshotPolicyInfo() []SnapshotPolicyInfoType {
+	r := o.SnapshotPolicyInfoPtr
+	return r
+}
+
+// SetSnapshotPolicyInfo is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterResponseResultAttributesList) SetSnapshotPolicyInfo(newValue []SnapshotPolicyInfoType) *SnapshotPolicyGetIterResponseResultAttributesList {
+	newSlice := make([]SnapshotPolicyInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SnapshotPolicyInfoPtr = newSlice
+	return o
+}
+
+// values is a 'getter' method
+func (o *SnapshotPolicyGetIterResponseResultAttributesList) values() []SnapshotPolicyInfoType {
+	r := o.SnapshotPolicyInfoPtr
+	return r
+}
+
+// setValues is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterResponseResultAttributesList) setValues(newValue []SnapshotPolicyInfoType) *SnapshotPolicyGetIterResponseResultAttributesList {
+	newSlice := make([]SnapshotPolicyInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SnapshotPolicyInfoPtr = newSlice
+	return o
+}
+
+// AttributesList is a 'getter' method
+func (o *SnapshotPolicyGetIterResponseResult) AttributesList() SnapshotPolicyGetIterResponseResultAttributesList {
+	r := *o.AttributesListPtr
+	return r
+}
+
+// SetAttributesList is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterResponseResult) SetAttributesList(newValue SnapshotPolicyGetIterResponseResultAttributesList) *SnapshotPolicyGetIterResponseResult {
+	o.AttributesListPtr = &newValue
+	return o
+}
+
+// NextTag is a 'getter' method
+func (o *SnapshotPolicyGetIterResponseResult) NextTag() string {
+	r := *o.NextTagPtr
+	return r
+}
+
+// SetNextTag is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterResponseResult) SetNextTag(newValue string) *SnapshotPolicyGetIterResponseResult {
+	o.NextTagPtr = &newValue
+	return o
+}
+
+// NumRecords is a 'getter' method
+func (o *SnapshotPolicyGetIterResponseResult) NumRecords() int {
+	r := *o.NumRecordsPtr
+	return r
+}
+
+// SetNumRecords is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyGetIterResponseResult) SetNumRecords(newValue int) *SnapshotPolicyGetIterResponseResult {
+	o.NumRecordsPtr = &newValue
+	return o
+}
@@ -0,0 +1,141 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyModifyScheduleRequest is a structure to represent a snapshot-policy-modify-schedule Request ZAPI object
+type SnapshotPolicyModifyScheduleRequest struct {
+	XMLName     xml.Name `xml:"snapshot-policy-modify-schedule"`
+	NewCountPtr *int     `xml:"new-count"`
+	PolicyPtr   *string  `xml:"policy"`
+	SchedulePtr *string  `xml:"schedule"`
+}
+
+// SnapshotPolicyModifyScheduleResponse is a structure to represent a snapshot-policy-modify-schedule Response ZAPI object
+type SnapshotPolicyModifyScheduleResponse struct {
+	XMLName         xml.Name                                   `xml:"netapp"`
+	ResponseVersion string                                     `xml:"version,attr"`
+	ResponseXmlns   string                                     `xml:"xmlns,attr"`
+	Result          SnapshotPolicyModifyScheduleResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyModifyScheduleResponse is a factory method for creating new instances of SnapshotPolicyModifyScheduleResponse objects
+func NewSnapshotPolicyModifyScheduleResponse() *SnapshotPolicyModifyScheduleResponse {
+	return &SnapshotPolicyModifyScheduleResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyScheduleResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyScheduleResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyModifyScheduleResponseResult is a structure to represent a snapshot-policy-modify-schedule Response Result ZAPI object
+type SnapshotPolicyModifyScheduleResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapshotPolicyModifyScheduleRequest is a factory method for creating new instances of SnapshotPolicyModifyScheduleRequest objects
+func NewSnapshotPolicyModifyScheduleRequest() *SnapshotPolicyModifyScheduleRequest {
+	return &SnapshotPolicyModifyScheduleRequest{}
+}
+
+// NewSnapshotPolicyModifyScheduleResponseResult is a factory method for creating new instances of SnapshotPolicyModifyScheduleResponseResult objects
+func NewSnapshotPolicyModifyScheduleResponseResult() *SnapshotPolicyModifyScheduleResponseResult {
+	return &SnapshotPolicyModifyScheduleResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyScheduleRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyScheduleResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyScheduleRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyScheduleResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyModifyScheduleRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyModifyScheduleResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyModifyScheduleRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyModifyScheduleResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyModifyScheduleRequest", NewSnapshotPolicyModifyScheduleResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyModifyScheduleResponse), err
+}
+
+// NewCount is a 'getter' method
+func (o *SnapshotPolicyModifyScheduleRequest) NewCount() int {
+	r := *o.NewCountPtr
+	return r
+}
+
+// SetNewCount is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyScheduleRequest) SetNewCount(newValue int) *SnapshotPolicyModifyScheduleRequest {
+	o.NewCountPtr = &newValue
+	return o
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyModifyScheduleRequest) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyScheduleRequest) SetPolicy(newValue string) *SnapshotPolicyModifyScheduleRequest {
+	o.PolicyPtr = &newValue
+	return o
+}
+
+// Schedule is a 'getter' method
+func (o *SnapshotPolicyModifyScheduleRequest) Schedule() string {
+	r := *o.SchedulePtr
+	return r
+}
+
+// SetSchedule is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyScheduleRequest) SetSchedule(newValue string) *SnapshotPolicyModifyScheduleRequest {
+	o.SchedulePtr = &newValue
+	return o
+}
@@ -0,0 +1,141 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyModifyRequest is a structure to represent a snapshot-policy-modify Request ZAPI object
+type SnapshotPolicyModifyRequest struct {
+	XMLName    xml.Name `xml:"snapshot-policy-modify"`
+	CommentPtr *string  `xml:"comment"`
+	EnabledPtr *bool    `xml:"enabled"`
+	PolicyPtr  *string  `xml:"policy"`
+}
+
+// SnapshotPolicyModifyResponse is a structure to represent a snapshot-policy-modify Response ZAPI object
+type SnapshotPolicyModifyResponse struct {
+	XMLName         xml.Name                           `xml:"netapp"`
+	ResponseVersion string                             `xml:"version,attr"`
+	ResponseXmlns   string                             `xml:"xmlns,attr"`
+	Result          SnapshotPolicyModifyResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyModifyResponse is a factory method for creating new instances of SnapshotPolicyModifyResponse objects
+func NewSnapshotPolicyModifyResponse() *SnapshotPolicyModifyResponse {
+	return &SnapshotPolicyModifyResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyModifyResponseResult is a structure to represent a snapshot-policy-modify Response Result ZAPI object
+type SnapshotPolicyModifyResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapshotPolicyModifyRequest is a factory method for creating new instances of SnapshotPolicyModifyRequest objects
+func NewSnapshotPolicyModifyRequest() *SnapshotPolicyModifyRequest {
+	return &SnapshotPolicyModifyRequest{}
+}
+
+// NewSnapshotPolicyModifyResponseResult is a factory method for creating new instances of SnapshotPolicyModifyResponseResult objects
+func NewSnapshotPolicyModifyResponseResult() *SnapshotPolicyModifyResponseResult {
+	return &SnapshotPolicyModifyResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyModifyResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyModifyResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyModifyRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyModifyResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyModifyRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyModifyResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyModifyRequest", NewSnapshotPolicyModifyResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyModifyResponse), err
+}
+
+// Comment is a 'getter' method
+func (o *SnapshotPolicyModifyRequest) Comment() string {
+	r := *o.CommentPtr
+	return r
+}
+
+// SetComment is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyRequest) SetComment(newValue string) *SnapshotPolicyModifyRequest {
+	o.CommentPtr = &newValue
+	return o
+}
+
+// Enabled is a 'getter' method
+func (o *SnapshotPolicyModifyRequest) Enabled() bool {
+	r := *o.EnabledPtr
+	return r
+}
+
+// SetEnabled is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyRequest) SetEnabled(newValue bool) *SnapshotPolicyModifyRequest {
+	o.EnabledPtr = &newValue
+	return o
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyModifyRequest) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyModifyRequest) SetPolicy(newValue string) *SnapshotPolicyModifyRequest {
+	o.PolicyPtr = &newValue
+	return o
+}
@@ -0,0 +1,128 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyRemoveScheduleRequest is a structure to represent a snapshot-policy-remove-schedule Request ZAPI object
+type SnapshotPolicyRemoveScheduleRequest struct {
+	XMLName     xml.Name `xml:"snapshot-policy-remove-schedule"`
+	PolicyPtr   *string  `xml:"policy"`
+	SchedulePtr *string  `xml:"schedule"`
+}
+
+// SnapshotPolicyRemoveScheduleResponse is a structure to represent a snapshot-policy-remove-schedule Response ZAPI object
+type SnapshotPolicyRemoveScheduleResponse struct {
+	XMLName         xml.Name                                   `xml:"netapp"`
+	ResponseVersion string                                     `xml:"version,attr"`
+	ResponseXmlns   string                                     `xml:"xmlns,attr"`
+	Result          SnapshotPolicyRemoveScheduleResponseResult `xml:"results"`
+}
+
+// NewSnapshotPolicyRemoveScheduleResponse is a factory method for creating new instances of SnapshotPolicyRemoveScheduleResponse objects
+func NewSnapshotPolicyRemoveScheduleResponse() *SnapshotPolicyRemoveScheduleResponse {
+	return &SnapshotPolicyRemoveScheduleResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyRemoveScheduleResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyRemoveScheduleResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// SnapshotPolicyRemoveScheduleResponseResult is a structure to represent a snapshot-policy-remove-schedule Response Result ZAPI object
+type SnapshotPolicyRemoveScheduleResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewSnapshotPolicyRemoveScheduleRequest is a factory method for creating new instances of SnapshotPolicyRemoveScheduleRequest objects
+func NewSnapshotPolicyRemoveScheduleRequest() *SnapshotPolicyRemoveScheduleRequest {
+	return &SnapshotPolicyRemoveScheduleRequest{}
+}
+
+// NewSnapshotPolicyRemoveScheduleResponseResult is a factory method for creating new instances of SnapshotPolicyRemoveScheduleResponseResult objects
+func NewSnapshotPolicyRemoveScheduleResponseResult() *SnapshotPolicyRemoveScheduleResponseResult {
+	return &SnapshotPolicyRemoveScheduleResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyRemoveScheduleRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyRemoveScheduleResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyRemoveScheduleRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyRemoveScheduleResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyRemoveScheduleRequest) ExecuteUsing(zr *ZapiRunner) (*SnapshotPolicyRemoveScheduleResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *SnapshotPolicyRemoveScheduleRequest) executeWithoutIteration(zr *ZapiRunner) (*SnapshotPolicyRemoveScheduleResponse, error) {
+	result, err := zr.ExecuteUsing(o, "SnapshotPolicyRemoveScheduleRequest", NewSnapshotPolicyRemoveScheduleResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*SnapshotPolicyRemoveScheduleResponse), err
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyRemoveScheduleRequest) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyRemoveScheduleRequest) SetPolicy(newValue string) *SnapshotPolicyRemoveScheduleRequest {
+	o.PolicyPtr = &newValue
+	return o
+}
+
+// Schedule is a 'getter' method
+func (o *SnapshotPolicyRemoveScheduleRequest) Schedule() string {
+	r := *o.SchedulePtr
+	return r
+}
+
+// SetSchedule is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyRemoveScheduleRequest) SetSchedule(newValue string) *SnapshotPolicyRemoveScheduleRequest {
+	o.SchedulePtr = &newValue
+	return o
+}
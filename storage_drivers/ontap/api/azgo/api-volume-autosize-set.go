@@ -0,0 +1,154 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VolumeAutosizeSetRequest is a structure to represent a volume-autosize-set Request ZAPI object
+type VolumeAutosizeSetRequest struct {
+	XMLName                 xml.Name `xml:"volume-autosize-set"`
+	GrowThresholdPercentPtr *int     `xml:"grow-threshold-percent"`
+	MaximumSizePtr          *int     `xml:"maximum-size"`
+	ModePtr                 *string  `xml:"mode"`
+	VolumePtr               *string  `xml:"volume"`
+}
+
+// VolumeAutosizeSetResponse is a structure to represent a volume-autosize-set Response ZAPI object
+type VolumeAutosizeSetResponse struct {
+	XMLName         xml.Name                        `xml:"netapp"`
+	ResponseVersion string                          `xml:"version,attr"`
+	ResponseXmlns   string                          `xml:"xmlns,attr"`
+	Result          VolumeAutosizeSetResponseResult `xml:"results"`
+}
+
+// NewVolumeAutosizeSetResponse is a factory method for creating new instances of VolumeAutosizeSetResponse objects
+func NewVolumeAutosizeSetResponse() *VolumeAutosizeSetResponse {
+	return &VolumeAutosizeSetResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeAutosizeSetResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeAutosizeSetResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// VolumeAutosizeSetResponseResult is a structure to represent a volume-autosize-set Response Result ZAPI object
+type VolumeAutosizeSetResponseResult struct {
+	XMLName          xml.Name `xml:"results"`
+	ResultStatusAttr string   `xml:"status,attr"`
+	ResultReasonAttr string   `xml:"reason,attr"`
+	ResultErrnoAttr  string   `xml:"errno,attr"`
+}
+
+// NewVolumeAutosizeSetRequest is a factory method for creating new instances of VolumeAutosizeSetRequest objects
+func NewVolumeAutosizeSetRequest() *VolumeAutosizeSetRequest {
+	return &VolumeAutosizeSetRequest{}
+}
+
+// NewVolumeAutosizeSetResponseResult is a factory method for creating new instances of VolumeAutosizeSetResponseResult objects
+func NewVolumeAutosizeSetResponseResult() *VolumeAutosizeSetResponseResult {
+	return &VolumeAutosizeSetResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeAutosizeSetRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeAutosizeSetResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeAutosizeSetRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeAutosizeSetResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeAutosizeSetRequest) ExecuteUsing(zr *ZapiRunner) (*VolumeAutosizeSetResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeAutosizeSetRequest) executeWithoutIteration(zr *ZapiRunner) (*VolumeAutosizeSetResponse, error) {
+	result, err := zr.ExecuteUsing(o, "VolumeAutosizeSetRequest", NewVolumeAutosizeSetResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*VolumeAutosizeSetResponse), err
+}
+
+// GrowThresholdPercent is a 'getter' method
+func (o *VolumeAutosizeSetRequest) GrowThresholdPercent() int {
+	r := *o.GrowThresholdPercentPtr
+	return r
+}
+
+// SetGrowThresholdPercent is a fluent style 'setter' method that can be chained
+func (o *VolumeAutosizeSetRequest) SetGrowThresholdPercent(newValue int) *VolumeAutosizeSetRequest {
+	o.GrowThresholdPercentPtr = &newValue
+	return o
+}
+
+// MaximumSize is a 'getter' method
+func (o *VolumeAutosizeSetRequest) MaximumSize() int {
+	r := *o.MaximumSizePtr
+	return r
+}
+
+// SetMaximumSize is a fluent style 'setter' method that can be chained
+func (o *VolumeAutosizeSetRequest) SetMaximumSize(newValue int) *VolumeAutosizeSetRequest {
+	o.MaximumSizePtr = &newValue
+	return o
+}
+
+// Mode is a 'getter' method
+func (o *VolumeAutosizeSetRequest) Mode() string {
+	r := *o.ModePtr
+	return r
+}
+
+// SetMode is a fluent style 'setter' method that can be chained
+func (o *VolumeAutosizeSetRequest) SetMode(newValue string) *VolumeAutosizeSetRequest {
+	o.ModePtr = &newValue
+	return o
+}
+
+// Volume is a 'getter' method
+func (o *VolumeAutosizeSetRequest) Volume() string {
+	r := *o.VolumePtr
+	return r
+}
+
+// SetVolume is a fluent style 'setter' method that can be chained
+func (o *VolumeAutosizeSetRequest) SetVolume(newValue string) *VolumeAutosizeSetRequest {
+	o.VolumePtr = &newValue
+	return o
+}
@@ -0,0 +1,153 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VolumeCloneSplitStatusRequest is a structure to represent a volume-clone-split-status Request ZAPI object
+type VolumeCloneSplitStatusRequest struct {
+	XMLName   xml.Name `xml:"volume-clone-split-status"`
+	VolumePtr *string  `xml:"volume"`
+}
+
+// VolumeCloneSplitStatusResponse is a structure to represent a volume-clone-split-status Response ZAPI object
+type VolumeCloneSplitStatusResponse struct {
+	XMLName         xml.Name                             `xml:"netapp"`
+	ResponseVersion string                               `xml:"version,attr"`
+	ResponseXmlns   string                               `xml:"xmlns,attr"`
+	Result          VolumeCloneSplitStatusResponseResult `xml:"results"`
+}
+
+// NewVolumeCloneSplitStatusResponse is a factory method for creating new instances of VolumeCloneSplitStatusResponse objects
+func NewVolumeCloneSplitStatusResponse() *VolumeCloneSplitStatusResponse {
+	return &VolumeCloneSplitStatusResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeCloneSplitStatusResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeCloneSplitStatusResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// VolumeCloneSplitStatusResponseResult is a structure to represent a volume-clone-split-status Response Result ZAPI object
+type VolumeCloneSplitStatusResponseResult struct {
+	XMLName              xml.Name                                               `xml:"results"`
+	ResultStatusAttr     string                                                 `xml:"status,attr"`
+	ResultReasonAttr     string                                                 `xml:"reason,attr"`
+	ResultErrnoAttr      string                                                 `xml:"errno,attr"`
+	CloneSplitDetailsPtr *VolumeCloneSplitStatusResponseResultCloneSplitDetails `xml:"clone-split-details"`
+}
+
+// NewVolumeCloneSplitStatusRequest is a factory method for creating new instances of VolumeCloneSplitStatusRequest objects
+func NewVolumeCloneSplitStatusRequest() *VolumeCloneSplitStatusRequest {
+	return &VolumeCloneSplitStatusRequest{}
+}
+
+// NewVolumeCloneSplitStatusResponseResult is a factory method for creating new instances of VolumeCloneSplitStatusResponseResult objects
+func NewVolumeCloneSplitStatusResponseResult() *VolumeCloneSplitStatusResponseResult {
+	return &VolumeCloneSplitStatusResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeCloneSplitStatusRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeCloneSplitStatusResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeCloneSplitStatusRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeCloneSplitStatusResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeCloneSplitStatusRequest) ExecuteUsing(zr *ZapiRunner) (*VolumeCloneSplitStatusResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeCloneSplitStatusRequest) executeWithoutIteration(zr *ZapiRunner) (*VolumeCloneSplitStatusResponse, error) {
+	result, err := zr.ExecuteUsing(o, "VolumeCloneSplitStatusRequest", NewVolumeCloneSplitStatusResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*VolumeCloneSplitStatusResponse), err
+}
+
+// Volume is a 'getter' method
+func (o *VolumeCloneSplitStatusRequest) Volume() string {
+	r := *o.VolumePtr
+	return r
+}
+
+// SetVolume is a fluent style 'setter' method that can be chained
+func (o *VolumeCloneSplitStatusRequest) SetVolume(newValue string) *VolumeCloneSplitStatusRequest {
+	o.VolumePtr = &newValue
+	return o
+}
+
+// VolumeCloneSplitStatusResponseResultCloneSplitDetails is a wrapper
+type VolumeCloneSplitStatusResponseResultCloneSplitDetails struct {
+	XMLName                 xml.Name                   `xml:"clone-split-details"`
+	CloneSplitDetailInfoPtr []CloneSplitDetailInfoType `xml:"clone-split-detail-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeCloneSplitStatusResponseResultCloneSplitDetails) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// CloneSplitDetailInfo is a 'getter' method
+func (o *VolumeCloneSplitStatusResponseResultCloneSplitDetails) CloneSplitDetailInfo() []CloneSplitDetailInfoType {
+	r := o.CloneSplitDetailInfoPtr
+	return r
+}
+
+// SetCloneSplitDetailInfo is a fluent style 'setter' method that can be chained
+func (o *VolumeCloneSplitStatusResponseResultCloneSplitDetails) SetCloneSplitDetailInfo(newValue []CloneSplitDetailInfoType) *VolumeCloneSplitStatusResponseResultCloneSplitDetails {
+	newSlice := make([]CloneSplitDetailInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.CloneSplitDetailInfoPtr = newSlice
+	return o
+}
+
+// CloneSplitDetails is a 'getter' method
+func (o *VolumeCloneSplitStatusResponseResult) CloneSplitDetails() VolumeCloneSplitStatusResponseResultCloneSplitDetails {
+	r := *o.CloneSplitDetailsPtr
+	return r
+}
+
+// SetCloneSplitDetails is a fluent style 'setter' method that can be chained
+func (o *VolumeCloneSplitStatusResponseResult) SetCloneSplitDetails(newValue VolumeCloneSplitStatusResponseResultCloneSplitDetails) *VolumeCloneSplitStatusResponseResult {
+	o.CloneSplitDetailsPtr = &newValue
+	return o
+}
@@ -0,0 +1,218 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VolumeExpandAsyncRequest is a structure to represent a volume-expand-async Request ZAPI object
+type VolumeExpandAsyncRequest struct {
+	XMLName               xml.Name                          `xml:"volume-expand-async"`
+	AggrListPtr           *VolumeExpandAsyncRequestAggrList `xml:"aggr-list"`
+	AggrListMultiplierPtr *int                              `xml:"aggr-list-multiplier"`
+	VolumeNamePtr         *string                           `xml:"volume-name"`
+}
+
+// VolumeExpandAsyncResponse is a structure to represent a volume-expand-async Response ZAPI object
+type VolumeExpandAsyncResponse struct {
+	XMLName         xml.Name                        `xml:"netapp"`
+	ResponseVersion string                          `xml:"version,attr"`
+	ResponseXmlns   string                          `xml:"xmlns,attr"`
+	Result          VolumeExpandAsyncResponseResult `xml:"results"`
+}
+
+// NewVolumeExpandAsyncResponse is a factory method for creating new instances of VolumeExpandAsyncResponse objects
+func NewVolumeExpandAsyncResponse() *VolumeExpandAsyncResponse {
+	return &VolumeExpandAsyncResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeExpandAsyncResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeExpandAsyncResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// VolumeExpandAsyncResponseResult is a structure to represent a volume-expand-async Response Result ZAPI object
+type VolumeExpandAsyncResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewVolumeExpandAsyncRequest is a factory method for creating new instances of VolumeExpandAsyncRequest objects
+func NewVolumeExpandAsyncRequest() *VolumeExpandAsyncRequest {
+	return &VolumeExpandAsyncRequest{}
+}
+
+// NewVolumeExpandAsyncResponseResult is a factory method for creating new instances of VolumeExpandAsyncResponseResult objects
+func NewVolumeExpandAsyncResponseResult() *VolumeExpandAsyncResponseResult {
+	return &VolumeExpandAsyncResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeExpandAsyncRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeExpandAsyncResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeExpandAsyncRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeExpandAsyncResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeExpandAsyncRequest) ExecuteUsing(zr *ZapiRunner) (*VolumeExpandAsyncResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeExpandAsyncRequest) executeWithoutIteration(zr *ZapiRunner) (*VolumeExpandAsyncResponse, error) {
+	result, err := zr.ExecuteUsing(o, "VolumeExpandAsyncRequest", NewVolumeExpandAsyncResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*VolumeExpandAsyncResponse), err
+}
+
+// VolumeExpandAsyncRequestAggrList is a wrapper
+type VolumeExpandAsyncRequestAggrList struct {
+	XMLName     xml.Name       `xml:"aggr-list"`
+	AggrNamePtr []AggrNameType `xml:"aggr-name"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeExpandAsyncRequestAggrList) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// AggrName is a 'getter' method
+func (o *VolumeExpandAsyncRequestAggrList) AggrName() []AggrNameType {
+	r := o.AggrNamePtr
+	return r
+}
+
+// SetAggrName is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncRequestAggrList) SetAggrName(newValue []AggrNameType) *VolumeExpandAsyncRequestAggrList {
+	newSlice := make([]AggrNameType, len(newValue))
+	copy(newSlice, newValue)
+	o.AggrNamePtr = newSlice
+	return o
+}
+
+// AggrList is a 'getter' method
+func (o *VolumeExpandAsyncRequest) AggrList() VolumeExpandAsyncRequestAggrList {
+	r := *o.AggrListPtr
+	return r
+}
+
+// SetAggrList is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncRequest) SetAggrList(newValue VolumeExpandAsyncRequestAggrList) *VolumeExpandAsyncRequest {
+	o.AggrListPtr = &newValue
+	return o
+}
+
+// AggrListMultiplier is a 'getter' method
+func (o *VolumeExpandAsyncRequest) AggrListMultiplier() int {
+	r := *o.AggrListMultiplierPtr
+	return r
+}
+
+// SetAggrListMultiplier is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncRequest) SetAggrListMultiplier(newValue int) *VolumeExpandAsyncRequest {
+	o.AggrListMultiplierPtr = &newValue
+	return o
+}
+
+// VolumeName is a 'getter' method
+func (o *VolumeExpandAsyncRequest) VolumeName() string {
+	r := *o.VolumeNamePtr
+	return r
+}
+
+// SetVolumeName is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncRequest) SetVolumeName(newValue string) *VolumeExpandAsyncRequest {
+	o.VolumeNamePtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *VolumeExpandAsyncResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncResponseResult) SetResultErrorCode(newValue int) *VolumeExpandAsyncResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *VolumeExpandAsyncResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncResponseResult) SetResultErrorMessage(newValue string) *VolumeExpandAsyncResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *VolumeExpandAsyncResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncResponseResult) SetResultJobid(newValue int) *VolumeExpandAsyncResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *VolumeExpandAsyncResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *VolumeExpandAsyncResponseResult) SetResultStatus(newValue string) *VolumeExpandAsyncResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
@@ -0,0 +1,164 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VolumeMoveGetRequest is a structure to represent a volume-move-get Request ZAPI object
+type VolumeMoveGetRequest struct {
+	XMLName         xml.Name `xml:"volume-move-get"`
+	SourceVolumePtr *string  `xml:"source-volume"`
+	VserverPtr      *string  `xml:"vserver"`
+}
+
+// VolumeMoveGetResponse is a structure to represent a volume-move-get Response ZAPI object
+type VolumeMoveGetResponse struct {
+	XMLName         xml.Name                    `xml:"netapp"`
+	ResponseVersion string                      `xml:"version,attr"`
+	ResponseXmlns   string                      `xml:"xmlns,attr"`
+	Result          VolumeMoveGetResponseResult `xml:"results"`
+}
+
+// NewVolumeMoveGetResponse is a factory method for creating new instances of VolumeMoveGetResponse objects
+func NewVolumeMoveGetResponse() *VolumeMoveGetResponse {
+	return &VolumeMoveGetResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveGetResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveGetResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// VolumeMoveGetResponseResult is a structure to represent a volume-move-get Response Result ZAPI object
+type VolumeMoveGetResponseResult struct {
+	XMLName          xml.Name                               `xml:"results"`
+	ResultStatusAttr string                                 `xml:"status,attr"`
+	ResultReasonAttr string                                 `xml:"reason,attr"`
+	ResultErrnoAttr  string                                 `xml:"errno,attr"`
+	AttributesPtr    *VolumeMoveGetResponseResultAttributes `xml:"attributes"`
+}
+
+// NewVolumeMoveGetRequest is a factory method for creating new instances of VolumeMoveGetRequest objects
+func NewVolumeMoveGetRequest() *VolumeMoveGetRequest {
+	return &VolumeMoveGetRequest{}
+}
+
+// NewVolumeMoveGetResponseResult is a factory method for creating new instances of VolumeMoveGetResponseResult objects
+func NewVolumeMoveGetResponseResult() *VolumeMoveGetResponseResult {
+	return &VolumeMoveGetResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveGetRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveGetResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveGetRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveGetResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeMoveGetRequest) ExecuteUsing(zr *ZapiRunner) (*VolumeMoveGetResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeMoveGetRequest) executeWithoutIteration(zr *ZapiRunner) (*VolumeMoveGetResponse, error) {
+	result, err := zr.ExecuteUsing(o, "VolumeMoveGetRequest", NewVolumeMoveGetResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*VolumeMoveGetResponse), err
+}
+
+// SourceVolume is a 'getter' method
+func (o *VolumeMoveGetRequest) SourceVolume() string {
+	r := *o.SourceVolumePtr
+	return r
+}
+
+// SetSourceVolume is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveGetRequest) SetSourceVolume(newValue string) *VolumeMoveGetRequest {
+	o.SourceVolumePtr = &newValue
+	return o
+}
+
+// Vserver is a 'getter' method
+func (o *VolumeMoveGetRequest) Vserver() string {
+	r := *o.VserverPtr
+	return r
+}
+
+// SetVserver is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveGetRequest) SetVserver(newValue string) *VolumeMoveGetRequest {
+	o.VserverPtr = &newValue
+	return o
+}
+
+// VolumeMoveGetResponseResultAttributes is a wrapper
+type VolumeMoveGetResponseResultAttributes struct {
+	XMLName           xml.Name            `xml:"attributes"`
+	VolumeMoveInfoPtr *VolumeMoveInfoType `xml:"volume-move-info"`
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveGetResponseResultAttributes) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// VolumeMoveInfo is a 'getter' method
+func (o *VolumeMoveGetResponseResultAttributes) VolumeMoveInfo() VolumeMoveInfoType {
+	r := *o.VolumeMoveInfoPtr
+	return r
+}
+
+// SetVolumeMoveInfo is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveGetResponseResultAttributes) SetVolumeMoveInfo(newValue VolumeMoveInfoType) *VolumeMoveGetResponseResultAttributes {
+	o.VolumeMoveInfoPtr = &newValue
+	return o
+}
+
+// Attributes is a 'getter' method
+func (o *VolumeMoveGetResponseResult) Attributes() VolumeMoveGetResponseResultAttributes {
+	r := *o.AttributesPtr
+	return r
+}
+
+// SetAttributes is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveGetResponseResult) SetAttributes(newValue VolumeMoveGetResponseResultAttributes) *VolumeMoveGetResponseResult {
+	o.AttributesPtr = &newValue
+	return o
+}
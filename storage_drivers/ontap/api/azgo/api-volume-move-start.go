@@ -0,0 +1,219 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// VolumeMoveStartRequest is a structure to represent a volume-move-start Request ZAPI object
+type VolumeMoveStartRequest struct {
+	XMLName                  xml.Name `xml:"volume-move-start"`
+	CutoverActionPtr         *string  `xml:"cutover-action"`
+	DestAggrPtr              *string  `xml:"dest-aggr"`
+	PerformValidationOnlyPtr *bool    `xml:"perform-validation-only"`
+	SourceVolumePtr          *string  `xml:"source-volume"`
+	VserverPtr               *string  `xml:"vserver"`
+}
+
+// VolumeMoveStartResponse is a structure to represent a volume-move-start Response ZAPI object
+type VolumeMoveStartResponse struct {
+	XMLName         xml.Name                      `xml:"netapp"`
+	ResponseVersion string                        `xml:"version,attr"`
+	ResponseXmlns   string                        `xml:"xmlns,attr"`
+	Result          VolumeMoveStartResponseResult `xml:"results"`
+}
+
+// NewVolumeMoveStartResponse is a factory method for creating new instances of VolumeMoveStartResponse objects
+func NewVolumeMoveStartResponse() *VolumeMoveStartResponse {
+	return &VolumeMoveStartResponse{}
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveStartResponse) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveStartResponse) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// VolumeMoveStartResponseResult is a structure to represent a volume-move-start Response Result ZAPI object
+type VolumeMoveStartResponseResult struct {
+	XMLName               xml.Name `xml:"results"`
+	ResultStatusAttr      string   `xml:"status,attr"`
+	ResultReasonAttr      string   `xml:"reason,attr"`
+	ResultErrnoAttr       string   `xml:"errno,attr"`
+	ResultErrorCodePtr    *int     `xml:"result-error-code"`
+	ResultErrorMessagePtr *string  `xml:"result-error-message"`
+	ResultJobidPtr        *int     `xml:"result-jobid"`
+	ResultStatusPtr       *string  `xml:"result-status"`
+}
+
+// NewVolumeMoveStartRequest is a factory method for creating new instances of VolumeMoveStartRequest objects
+func NewVolumeMoveStartRequest() *VolumeMoveStartRequest {
+	return &VolumeMoveStartRequest{}
+}
+
+// NewVolumeMoveStartResponseResult is a factory method for creating new instances of VolumeMoveStartResponseResult objects
+func NewVolumeMoveStartResponseResult() *VolumeMoveStartResponseResult {
+	return &VolumeMoveStartResponseResult{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveStartRequest) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// ToXML converts this object into an xml string representation
+func (o *VolumeMoveStartResponseResult) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveStartRequest) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o VolumeMoveStartResponseResult) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// ExecuteUsing converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeMoveStartRequest) ExecuteUsing(zr *ZapiRunner) (*VolumeMoveStartResponse, error) {
+	return o.executeWithoutIteration(zr)
+}
+
+// executeWithoutIteration converts this object to a ZAPI XML representation and uses the supplied ZapiRunner to send to a filer
+
+func (o *VolumeMoveStartRequest) executeWithoutIteration(zr *ZapiRunner) (*VolumeMoveStartResponse, error) {
+	result, err := zr.ExecuteUsing(o, "VolumeMoveStartRequest", NewVolumeMoveStartResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*VolumeMoveStartResponse), err
+}
+
+// CutoverAction is a 'getter' method
+func (o *VolumeMoveStartRequest) CutoverAction() string {
+	r := *o.CutoverActionPtr
+	return r
+}
+
+// SetCutoverAction is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartRequest) SetCutoverAction(newValue string) *VolumeMoveStartRequest {
+	o.CutoverActionPtr = &newValue
+	return o
+}
+
+// DestAggr is a 'getter' method
+func (o *VolumeMoveStartRequest) DestAggr() string {
+	r := *o.DestAggrPtr
+	return r
+}
+
+// SetDestAggr is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartRequest) SetDestAggr(newValue string) *VolumeMoveStartRequest {
+	o.DestAggrPtr = &newValue
+	return o
+}
+
+// PerformValidationOnly is a 'getter' method
+func (o *VolumeMoveStartRequest) PerformValidationOnly() bool {
+	r := *o.PerformValidationOnlyPtr
+	return r
+}
+
+// SetPerformValidationOnly is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartRequest) SetPerformValidationOnly(newValue bool) *VolumeMoveStartRequest {
+	o.PerformValidationOnlyPtr = &newValue
+	return o
+}
+
+// SourceVolume is a 'getter' method
+func (o *VolumeMoveStartRequest) SourceVolume() string {
+	r := *o.SourceVolumePtr
+	return r
+}
+
+// SetSourceVolume is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartRequest) SetSourceVolume(newValue string) *VolumeMoveStartRequest {
+	o.SourceVolumePtr = &newValue
+	return o
+}
+
+// Vserver is a 'getter' method
+func (o *VolumeMoveStartRequest) Vserver() string {
+	r := *o.VserverPtr
+	return r
+}
+
+// SetVserver is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartRequest) SetVserver(newValue string) *VolumeMoveStartRequest {
+	o.VserverPtr = &newValue
+	return o
+}
+
+// ResultErrorCode is a 'getter' method
+func (o *VolumeMoveStartResponseResult) ResultErrorCode() int {
+	r := *o.ResultErrorCodePtr
+	return r
+}
+
+// SetResultErrorCode is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartResponseResult) SetResultErrorCode(newValue int) *VolumeMoveStartResponseResult {
+	o.ResultErrorCodePtr = &newValue
+	return o
+}
+
+// ResultErrorMessage is a 'getter' method
+func (o *VolumeMoveStartResponseResult) ResultErrorMessage() string {
+	r := *o.ResultErrorMessagePtr
+	return r
+}
+
+// SetResultErrorMessage is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartResponseResult) SetResultErrorMessage(newValue string) *VolumeMoveStartResponseResult {
+	o.ResultErrorMessagePtr = &newValue
+	return o
+}
+
+// ResultJobid is a 'getter' method
+func (o *VolumeMoveStartResponseResult) ResultJobid() int {
+	r := *o.ResultJobidPtr
+	return r
+}
+
+// SetResultJobid is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartResponseResult) SetResultJobid(newValue int) *VolumeMoveStartResponseResult {
+	o.ResultJobidPtr = &newValue
+	return o
+}
+
+// ResultStatus is a 'getter' method
+func (o *VolumeMoveStartResponseResult) ResultStatus() string {
+	r := *o.ResultStatusPtr
+	return r
+}
+
+// SetResultStatus is a fluent style 'setter' method that can be chained
+func (o *VolumeMoveStartResponseResult) SetResultStatus(newValue string) *VolumeMoveStartResponseResult {
+	o.ResultStatusPtr = &newValue
+	return o
+}
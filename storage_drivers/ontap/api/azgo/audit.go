@@ -0,0 +1,170 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package azgo
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/netapp/trident/audit"
+	"github.com/netapp/trident/utils"
+)
+
+// readOnlyZAPIMarkers appear in the names of ZAPIs that only read the state of the storage system
+var readOnlyZAPIMarkers = []string{"-get", "-list", "-show"}
+
+// readOnlyZAPIs don't change the state of the storage system, despite their names
+var readOnlyZAPIs = map[string]bool{
+	"ems-autosupport-log": true,
+}
+
+// volumeObjectElements name the ZAPI elements identifying the object, other than the volume itself,
+// that a call changes.  The first one present in a request is recorded.
+var volumeObjectElements = []string{
+	"qtree", "path", "snapshot", "policy-name", "initiator-group", "initiator-group-name", "initiator",
+	"new-volume-name", "new-name", "parent-volume", "volume-destination", "name",
+}
+
+// APIAuditor records the ZAPI calls that change the storage system in the audit log, along with the
+// backend, volume, and caller they were made on behalf of and how the storage system responded.
+type APIAuditor struct {
+	Backend string
+}
+
+// NewAPIAuditor returns an auditor for the calls made by the named backend.
+func NewAPIAuditor(backend string) *APIAuditor {
+	return &APIAuditor{Backend: backend}
+}
+
+// IsMutatingZAPI returns whether a ZAPI request changes the storage system.  Requests such as
+// volume-size both read and change a value, depending on whether a new value is supplied.
+func IsMutatingZAPI(zapiName, requestXML string) bool {
+	if readOnlyZAPIs[zapiName] || strings.HasSuffix(zapiName, "-status") {
+		return false
+	}
+	for _, marker := range readOnlyZAPIMarkers {
+		if strings.Contains(zapiName, marker) {
+			return false
+		}
+	}
+	if zapiName == "volume-size" {
+		return strings.Contains(requestXML, "<new-size>")
+	}
+	return true
+}
+
+// Audit records a call made by a ZapiRunner, if it changed the storage system.  The response body is
+// ignored if the call failed before a response was read.  A nil auditor records nothing.
+func (a *APIAuditor) Audit(o *ZapiRunner, r ZAPIRequest, responseBody []byte, callErr error) {
+
+	if a == nil {
+		return
+	}
+	requestXML, err := r.ToXML()
+	if err != nil {
+		return
+	}
+	zapiName, err := getZAPINameFromXML(requestXML)
+	if err != nil || !IsMutatingZAPI(zapiName, requestXML) {
+		return
+	}
+
+	event := &audit.Event{
+		Time:          time.Now().UTC(),
+		Backend:       a.Backend,
+		Host:          o.ManagementLIF,
+		SVM:           o.SVM,
+		User:          o.Username,
+		RequestID:     utils.GetRequestID(o.Context),
+		RequestSource: utils.GetRequestSource(o.Context),
+		Operation:     zapiName,
+	}
+	event.Volume, event.Object = getAuditedObjects(zapiName, requestXML)
+
+	if callErr != nil {
+		event.Status = audit.StatusFailed
+		event.Reason = callErr.Error()
+	} else {
+		event.Status, event.ErrorCode, event.Reason = getResultStatus(responseBody)
+	}
+
+	audit.Record(event)
+}
+
+// getAuditedObjects returns the volume and any other object a ZAPI request changes.  Elements are
+// looked for at any depth, so that the volume named in the query of an iterative request is found.
+func getAuditedObjects(zapiName, requestXML string) (volume, object string) {
+
+	values := make(map[string]string)
+	decoder := xml.NewDecoder(strings.NewReader(requestXML))
+	element := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+		case xml.CharData:
+			value := strings.TrimSpace(string(t))
+			if _, ok := values[element]; !ok && element != "" && value != "" {
+				values[element] = value
+			}
+		case xml.EndElement:
+			element = ""
+		}
+	}
+
+	for _, name := range []string{"volume", "volume-name"} {
+		if values[name] != "" {
+			volume = values[name]
+			break
+		}
+	}
+	if volume == "" {
+		// LUN paths have the form /vol/<volume>/<lun>
+		if parts := strings.Split(values["path"], "/"); len(parts) > 2 && parts[1] == "vol" {
+			volume = parts[2]
+		} else if strings.HasPrefix(zapiName, "volume-") {
+			volume = values["name"]
+		}
+	}
+
+	for _, name := range volumeObjectElements {
+		if values[name] != "" && values[name] != volume {
+			object = values[name]
+			break
+		}
+	}
+	return volume, object
+}
+
+// getResultStatus returns the status, error number, and reason of a ZAPI response.
+func getResultStatus(responseBody []byte) (status, errorCode, reason string) {
+
+	decoder := xml.NewDecoder(strings.NewReader(string(responseBody)))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return audit.StatusFailed, "", "no results in response"
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "results" {
+			status = audit.StatusFailed
+			for _, attr := range start.Attr {
+				switch attr.Name.Local {
+				case "status":
+					if attr.Value == audit.StatusPassed {
+						status = audit.StatusPassed
+					}
+				case "errno":
+					errorCode = attr.Value
+				case "reason":
+					reason = attr.Value
+				}
+			}
+			return status, errorCode, reason
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package azgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/audit"
+)
+
+func TestIsMutatingZAPI(t *testing.T) {
+
+	tests := []struct {
+		zapiName string
+		request  string
+		mutating bool
+	}{
+		{"volume-create", "<volume-create><volume>vol1</volume></volume-create>", true},
+		{"volume-destroy", "<volume-destroy><name>vol1</name></volume-destroy>", true},
+		{"lun-map", "<lun-map><path>/vol/vol1/lun0</path></lun-map>", true},
+		{"export-rule-create", "<export-rule-create></export-rule-create>", true},
+		{"volume-modify-iter", "<volume-modify-iter></volume-modify-iter>", true},
+		{"volume-get-iter", "<volume-get-iter></volume-get-iter>", false},
+		{"lun-map-list-info", "<lun-map-list-info></lun-map-list-info>", false},
+		{"system-get-version", "<system-get-version></system-get-version>", false},
+		{"volume-clone-split-status", "<volume-clone-split-status></volume-clone-split-status>", false},
+		{"ems-autosupport-log", "<ems-autosupport-log></ems-autosupport-log>", false},
+		{"volume-size", "<volume-size><volume>vol1</volume></volume-size>", false},
+		{"volume-size", "<volume-size><new-size>2g</new-size><volume>vol1</volume></volume-size>", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.mutating, IsMutatingZAPI(test.zapiName, test.request), test.request)
+	}
+}
+
+func TestGetAuditedObjects(t *testing.T) {
+
+	tests := []struct {
+		zapiName string
+		request  string
+		volume   string
+		object   string
+	}{
+		{"volume-create", "<volume-create><volume>vol1</volume><containing-aggr-name>aggr1" +
+			"</containing-aggr-name></volume-create>", "vol1", ""},
+		{"volume-destroy", "<volume-destroy><name>vol1</name></volume-destroy>", "vol1", ""},
+		{"volume-rename", "<volume-rename><volume>vol1</volume><new-volume-name>vol2</new-volume-name>" +
+			"</volume-rename>", "vol1", "vol2"},
+		{"volume-modify-iter", "<volume-modify-iter><query><volume-attributes><volume-id-attributes>" +
+			"<name>vol1</name></volume-id-attributes></volume-attributes></query></volume-modify-iter>", "vol1", ""},
+		{"lun-map", "<lun-map><initiator-group>trident</initiator-group><path>/vol/vol1/lun0</path></lun-map>",
+			"vol1", "/vol/vol1/lun0"},
+		{"qtree-create", "<qtree-create><qtree>qt1</qtree><volume>vol1</volume></qtree-create>", "vol1", "qt1"},
+		{"export-rule-create", "<export-rule-create><policy-name>trident</policy-name></export-rule-create>",
+			"", "trident"},
+		{"igroup-add", "<igroup-add><initiator>iqn.1993-08.org.debian:01:abc</initiator>" +
+			"<initiator-group-name>trident</initiator-group-name></igroup-add>", "", "trident"},
+	}
+	for _, test := range tests {
+		volume, object := getAuditedObjects(test.zapiName, test.request)
+		assert.Equal(t, test.volume, volume, test.request)
+		assert.Equal(t, test.object, object, test.request)
+	}
+}
+
+func TestGetResultStatus(t *testing.T) {
+
+	status, errorCode, reason := getResultStatus([]byte(`<?xml version='1.0' encoding='UTF-8' ?>
+<netapp version='1.21' xmlns='http://www.netapp.com/filer/admin'><results status="passed"/></netapp>`))
+	assert.Equal(t, audit.StatusPassed, status)
+	assert.Empty(t, errorCode)
+	assert.Empty(t, reason)
+
+	status, errorCode, reason = getResultStatus([]byte(`<netapp version='1.21'><results status="failed" ` +
+		`errno="13005" reason="Unable to destroy volume: volume is online"/></netapp>`))
+	assert.Equal(t, audit.StatusFailed, status)
+	assert.Equal(t, "13005", errorCode)
+	assert.Equal(t, "Unable to destroy volume: volume is online", reason)
+
+	status, _, reason = getResultStatus([]byte("<html>Bad gateway</html>"))
+	assert.Equal(t, audit.StatusFailed, status)
+	assert.Equal(t, "no results in response", reason)
+}
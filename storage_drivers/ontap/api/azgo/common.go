@@ -4,9 +4,9 @@ package azgo
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -34,6 +34,9 @@ type ZapiRunner struct {
 	Secure          bool
 	OntapiVersion   string
 	DebugTraceFlags map[string]bool // Example: {"api":false, "method":true}
+	Context         context.Context // Cancels requests and identifies them in logs, may be nil
+	Tracer          *APITracer      // Logs API requests and responses at its own level, may be nil
+	Auditor         *APIAuditor     // Records API calls that change the storage system, may be nil
 }
 
 // GetZAPIName returns the name of the ZAPI request; it must parse the XML because ZAPIRequest is an interface
@@ -43,7 +46,11 @@ func GetZAPIName(zr ZAPIRequest) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return getZAPINameFromXML(zapiXML)
+}
 
+// getZAPINameFromXML returns the name of the ZAPI request with the given XML representation
+func getZAPINameFromXML(zapiXML string) (string, error) {
 	decoder := xml.NewDecoder(strings.NewReader(zapiXML))
 	for {
 		token, _ := decoder.Token()
@@ -65,8 +72,8 @@ func (o *ZapiRunner) SendZapi(r ZAPIRequest) (*http.Response, error) {
 
 	if o.DebugTraceFlags["method"] {
 		fields := log.Fields{"Method": "SendZapi", "Type": "ZapiRunner"}
-		log.WithFields(fields).Debug(">>>> SendZapi")
-		defer log.WithFields(fields).Debug("<<<< SendZapi")
+		o.Tracer.Logc(o.Context).WithFields(fields).Debug(">>>> SendZapi")
+		defer o.Tracer.Logc(o.Context).WithFields(fields).Debug("<<<< SendZapi")
 	}
 
 	zapiCommand, err := r.ToXML()
@@ -95,20 +102,26 @@ func (o *ZapiRunner) SendZapi(r ZAPIRequest) (*http.Response, error) {
             %s
         </netapp>`, "vfiler=\""+o.SVM+"\"", zapiCommand)
 	}
-	if o.DebugTraceFlags["api"] {
-		log.Debugf("sending to '%s' xml: \n%s", o.ManagementLIF, s)
-	}
+	o.Tracer.TraceRequest(o.Context, zapiName, o.ManagementLIF, s)
 
 	url := "http://" + o.ManagementLIF + "/servlets/netapp.servlets.admin.XMLrequest_filer"
 	if o.Secure {
 		url = "https://" + o.ManagementLIF + "/servlets/netapp.servlets.admin.XMLrequest_filer"
 	}
-	if o.DebugTraceFlags["api"] {
-		log.Debugf("URL:> %s", url)
+	if o.Tracer.Enabled() {
+		o.Tracer.Logc(o.Context).Debugf("URL:> %s", url)
+	}
+
+	ctx := o.Context
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	b := []byte(s)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/xml")
 	req.SetBasicAuth(o.Username, o.Password)
 
@@ -124,12 +137,7 @@ func (o *ZapiRunner) SendZapi(r ZAPIRequest) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	} else if response.StatusCode == 401 {
-		return nil, errors.New("response code 401 (Unauthorized): incorrect or missing credentials")
-	}
-
-	if o.DebugTraceFlags["api"] {
-		log.Debugf("response Status: %s", response.Status)
-		log.Debugf("response Headers: %s", response.Header)
+		return nil, ErrUnauthorized
 	}
 
 	return response, err
@@ -145,32 +153,30 @@ func (o *ZapiRunner) ExecuteWithoutIteration(z ZAPIRequest, requestType string,
 
 	if o.DebugTraceFlags["method"] {
 		fields := log.Fields{"Method": "ExecuteUsing", "Type": requestType}
-		log.WithFields(fields).Debug(">>>> ExecuteUsing")
-		defer log.WithFields(fields).Debug("<<<< ExecuteUsing")
+		o.Tracer.Logc(o.Context).WithFields(fields).Debug(">>>> ExecuteUsing")
+		defer o.Tracer.Logc(o.Context).WithFields(fields).Debug("<<<< ExecuteUsing")
 	}
 
 	resp, err := o.SendZapi(z)
 	if err != nil {
 		log.Errorf("API invocation failed. %v", err.Error())
+		o.Auditor.Audit(o, z, nil, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 	body, readErr := ioutil.ReadAll(resp.Body)
 	if readErr != nil {
 		log.Errorf("Error reading response body. %v", readErr.Error())
+		o.Auditor.Audit(o, z, nil, readErr)
 		return nil, readErr
 	}
-	if o.DebugTraceFlags["api"] {
-		log.Debugf("response Body:\n%s", string(body))
-	}
+	o.Tracer.TraceResponse(o.Context, requestType, resp.Status, string(body))
+	o.Auditor.Audit(o, z, body, nil)
 
 	//unmarshalErr := xml.Unmarshal(body, &v)
 	unmarshalErr := xml.Unmarshal(body, v)
 	if unmarshalErr != nil {
-		log.WithField("body", string(body)).Warnf("Error unmarshaling response body. %v", unmarshalErr.Error())
-	}
-	if o.DebugTraceFlags["api"] {
-		log.Debugf("%s result:\n%v", requestType, v)
+		log.WithField("body", RedactSecrets(string(body))).Warnf("Error unmarshaling response body. %v", unmarshalErr.Error())
 	}
 
 	return v, nil
@@ -2,6 +2,8 @@
 
 package azgo
 
+import "errors"
+
 const EONTAPI_EEXIST = "17"
 const EONTAPI_EVOLOPNOTSUPP = "160"
 const EVDISK_ERROR_NO_SUCH_INITGROUP = "9003"
@@ -28,3 +30,6 @@ const EINVALIDINPUTERROR = "13115"
 const EDUPLICATEENTRY = "13130"
 const EAGGRDOESNOTEXIST = "14420"
 const EOBJECTNOTFOUND = "15661"
+
+// ErrUnauthorized is returned when ONTAP rejects the credentials used to call it.
+var ErrUnauthorized = errors.New("response code 401 (Unauthorized): incorrect or missing credentials")
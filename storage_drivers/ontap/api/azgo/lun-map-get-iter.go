@@ -55,19 +55,14 @@ func (o *LunMapGetIterRequest) ExecuteUsing(zr *ZapiRunner) (LunMapGetIterRespon
 			log.Errorf("Error reading response body. %v", readErr.Error())
 			return *combined, readErr
 		}
-		if zr.DebugTraceFlags["api"] {
-			log.Debugf("response Body:\n%s", string(body))
-		}
+		zr.Tracer.TraceResponse(zr.Context, "lun-map-get-iter", resp.Status, string(body))
 
 		var n LunMapGetIterResponse
 		unmarshalErr := xml.Unmarshal(body, &n)
 		if unmarshalErr != nil {
-			log.WithField("body", string(body)).Warnf("Error unmarshaling response body. %v", unmarshalErr.Error())
+			log.WithField("body", RedactSecrets(string(body))).Warnf("Error unmarshaling response body. %v", unmarshalErr.Error())
 			//return *combined, unmarshalErr
 		}
-		if zr.DebugTraceFlags["api"] {
-			log.Debugf("lun-map-get-iter result:\n%s", n.Result)
-		}
 
 		if err == nil {
 			nextTagPtr = n.Result.NextTagPtr
@@ -0,0 +1,122 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package azgo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/utils"
+)
+
+// DefaultMaxTracedBodyLength is the number of bytes of a ZAPI payload that are logged before the rest is truncated
+const DefaultMaxTracedBodyLength = 8192
+
+// redactedValue replaces the contents of secret-bearing ZAPI elements in traces
+const redactedValue = "REDACTED"
+
+// secretElementRegex matches the start tag and contents of ZAPI elements holding secrets, such as the
+// password of security-login-create or the CHAP passphrases of iscsi-initiator-set-default-auth.
+var secretElementRegex = regexp.MustCompile(`<([\w-]*(?:password|passphrase|secret)[\w-]*)>[^<]*`)
+
+// APITracer logs ZAPI requests and responses with secrets redacted and large payloads truncated.  A
+// tracer is shared by all copies of a ZapiRunner, so tracing may be turned on or off while the backend
+// is running.
+type APITracer struct {
+	enabled       int32
+	level         int32 // log level plus one, or zero to log at the standard logger's level
+	MaxBodyLength int
+}
+
+// NewAPITracer returns a tracer, which logs nothing unless enabled.
+func NewAPITracer(enabled bool) *APITracer {
+	t := &APITracer{MaxBodyLength: DefaultMaxTracedBodyLength}
+	t.SetEnabled(enabled)
+	return t
+}
+
+// Enabled returns whether API calls are being traced.  A nil tracer is never enabled.
+func (t *APITracer) Enabled() bool {
+	return t != nil && atomic.LoadInt32(&t.enabled) == 1
+}
+
+// SetEnabled turns tracing on or off.
+func (t *APITracer) SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&t.enabled, 1)
+	} else {
+		atomic.StoreInt32(&t.enabled, 0)
+	}
+}
+
+// SetLogLevel makes the messages about API calls logged at the given level, whatever the level of the
+// standard logger.
+func (t *APITracer) SetLogLevel(level log.Level) {
+	atomic.StoreInt32(&t.level, int32(level)+1)
+}
+
+// ResetLogLevel makes the messages about API calls logged at the level of the standard logger again.
+func (t *APITracer) ResetLogLevel() {
+	atomic.StoreInt32(&t.level, 0)
+}
+
+// LogLevel returns the level at which messages about API calls are logged, and false if that is the level
+// of the standard logger.  A nil tracer always uses the standard logger's level.
+func (t *APITracer) LogLevel() (log.Level, bool) {
+	if t == nil {
+		return log.GetLevel(), false
+	}
+	if level := atomic.LoadInt32(&t.level); level > 0 {
+		return log.Level(level - 1), true
+	}
+	return log.GetLevel(), false
+}
+
+// Logc returns an entry for logging a message about an API call, at the tracer's log level.
+func (t *APITracer) Logc(ctx context.Context) *log.Entry {
+	if level, ok := t.LogLevel(); ok {
+		return utils.LogcAtLevel(ctx, level)
+	}
+	return utils.Logc(ctx)
+}
+
+// TraceRequest logs a request about to be sent to the named host.
+func (t *APITracer) TraceRequest(ctx context.Context, zapiName, host, payload string) {
+	if !t.Enabled() {
+		return
+	}
+	t.Logc(ctx).WithFields(log.Fields{
+		"api":  zapiName,
+		"host": host,
+	}).Debugf("API request:\n%s", t.sanitize(payload))
+}
+
+// TraceResponse logs the status and body of a response.
+func (t *APITracer) TraceResponse(ctx context.Context, zapiName, status, body string) {
+	if !t.Enabled() {
+		return
+	}
+	t.Logc(ctx).WithFields(log.Fields{
+		"api":    zapiName,
+		"status": status,
+	}).Debugf("API response:\n%s", t.sanitize(body))
+}
+
+// sanitize redacts secrets from a payload and truncates it to the maximum traced length.
+func (t *APITracer) sanitize(payload string) string {
+	payload = RedactSecrets(payload)
+	if t.MaxBodyLength > 0 && len(payload) > t.MaxBodyLength {
+		payload = fmt.Sprintf("%s... (%d bytes truncated)", payload[:t.MaxBodyLength],
+			len(payload)-t.MaxBodyLength)
+	}
+	return payload
+}
+
+// RedactSecrets replaces the contents of ZAPI elements that hold passwords, passphrases, or other secrets.
+func RedactSecrets(payload string) string {
+	return secretElementRegex.ReplaceAllString(payload, "<$1>"+redactedValue)
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package azgo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSecrets(t *testing.T) {
+
+	payload := `<security-login-create><user-name>admin</user-name><password>Netapp1!</password>` +
+		`</security-login-create><iscsi-initiator-set-default-auth><outbound-passphrase>s3cr3t` +
+		`</outbound-passphrase><passphrase>also secret</passphrase></iscsi-initiator-set-default-auth>`
+
+	redacted := RedactSecrets(payload)
+
+	assert.NotContains(t, redacted, "Netapp1!")
+	assert.NotContains(t, redacted, "s3cr3t")
+	assert.NotContains(t, redacted, "also secret")
+	assert.Contains(t, redacted, "<user-name>admin</user-name>")
+	assert.Contains(t, redacted, "<password>REDACTED</password>")
+	assert.Contains(t, redacted, "<outbound-passphrase>REDACTED</outbound-passphrase>")
+}
+
+func TestAPITracerSanitize(t *testing.T) {
+
+	tracer := NewAPITracer(false)
+	tracer.MaxBodyLength = 16
+
+	assert.Equal(t, "<name>vol1</name>"[:16]+"... (1 bytes truncated)", tracer.sanitize("<name>vol1</name>"))
+	assert.Equal(t, "<name>vol1</name>", NewAPITracer(false).sanitize("<name>vol1</name>"))
+	assert.True(t, strings.HasPrefix(tracer.sanitize("<password>abcdefghijklmnop</password>"),
+		"<password>REDACT"))
+}
+
+func TestAPITracerEnabled(t *testing.T) {
+
+	var nilTracer *APITracer
+	assert.False(t, nilTracer.Enabled())
+
+	tracer := NewAPITracer(false)
+	assert.False(t, tracer.Enabled())
+
+	// Copies of a runner share its tracer
+	runner := ZapiRunner{Tracer: tracer}
+	runnerCopy := runner
+	tracer.SetEnabled(true)
+	assert.True(t, runnerCopy.Tracer.Enabled())
+}
+
+func TestAPITracerLogLevel(t *testing.T) {
+
+	var nilTracer *APITracer
+	_, ok := nilTracer.LogLevel()
+	assert.False(t, ok)
+
+	tracer := NewAPITracer(false)
+	_, ok = tracer.LogLevel()
+	assert.False(t, ok)
+
+	tracer.SetLogLevel(log.DebugLevel)
+	level, ok := tracer.LogLevel()
+	assert.True(t, ok)
+	assert.Equal(t, log.DebugLevel, level)
+	assert.Equal(t, log.DebugLevel, tracer.Logc(context.Background()).Logger.Level)
+
+	tracer.ResetLogLevel()
+	_, ok = tracer.LogLevel()
+	assert.False(t, ok)
+}
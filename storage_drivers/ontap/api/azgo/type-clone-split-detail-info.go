@@ -0,0 +1,123 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CloneSplitDetailInfoType is a structure to represent a clone-split-detail-info ZAPI object
+type CloneSplitDetailInfoType struct {
+	XMLName                    xml.Name `xml:"clone-split-detail-info"`
+	BlockPercentageCompletePtr *int     `xml:"block-percentage-complete"`
+	BlocksScannedPtr           *int     `xml:"blocks-scanned"`
+	BlocksUpdatedPtr           *int     `xml:"blocks-updated"`
+	InodePercentageCompletePtr *int     `xml:"inode-percentage-complete"`
+	InodesProcessedPtr         *int     `xml:"inodes-processed"`
+	InodesTotalPtr             *int     `xml:"inodes-total"`
+	NamePtr                    *string  `xml:"name"`
+}
+
+// NewCloneSplitDetailInfoType is a factory method for creating new instances of CloneSplitDetailInfoType objects
+func NewCloneSplitDetailInfoType() *CloneSplitDetailInfoType {
+	return &CloneSplitDetailInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *CloneSplitDetailInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o CloneSplitDetailInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// BlockPercentageComplete is a 'getter' method
+func (o *CloneSplitDetailInfoType) BlockPercentageComplete() int {
+	r := *o.BlockPercentageCompletePtr
+	return r
+}
+
+// SetBlockPercentageComplete is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetBlockPercentageComplete(newValue int) *CloneSplitDetailInfoType {
+	o.BlockPercentageCompletePtr = &newValue
+	return o
+}
+
+// BlocksScanned is a 'getter' method
+func (o *CloneSplitDetailInfoType) BlocksScanned() int {
+	r := *o.BlocksScannedPtr
+	return r
+}
+
+// SetBlocksScanned is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetBlocksScanned(newValue int) *CloneSplitDetailInfoType {
+	o.BlocksScannedPtr = &newValue
+	return o
+}
+
+// BlocksUpdated is a 'getter' method
+func (o *CloneSplitDetailInfoType) BlocksUpdated() int {
+	r := *o.BlocksUpdatedPtr
+	return r
+}
+
+// SetBlocksUpdated is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetBlocksUpdated(newValue int) *CloneSplitDetailInfoType {
+	o.BlocksUpdatedPtr = &newValue
+	return o
+}
+
+// InodePercentageComplete is a 'getter' method
+func (o *CloneSplitDetailInfoType) InodePercentageComplete() int {
+	r := *o.InodePercentageCompletePtr
+	return r
+}
+
+// SetInodePercentageComplete is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetInodePercentageComplete(newValue int) *CloneSplitDetailInfoType {
+	o.InodePercentageCompletePtr = &newValue
+	return o
+}
+
+// InodesProcessed is a 'getter' method
+func (o *CloneSplitDetailInfoType) InodesProcessed() int {
+	r := *o.InodesProcessedPtr
+	return r
+}
+
+// SetInodesProcessed is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetInodesProcessed(newValue int) *CloneSplitDetailInfoType {
+	o.InodesProcessedPtr = &newValue
+	return o
+}
+
+// InodesTotal is a 'getter' method
+func (o *CloneSplitDetailInfoType) InodesTotal() int {
+	r := *o.InodesTotalPtr
+	return r
+}
+
+// SetInodesTotal is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetInodesTotal(newValue int) *CloneSplitDetailInfoType {
+	o.InodesTotalPtr = &newValue
+	return o
+}
+
+// Name is a 'getter' method
+func (o *CloneSplitDetailInfoType) Name() string {
+	r := *o.NamePtr
+	return r
+}
+
+// SetName is a fluent style 'setter' method that can be chained
+func (o *CloneSplitDetailInfoType) SetName(newValue string) *CloneSplitDetailInfoType {
+	o.NamePtr = &newValue
+	return o
+}
@@ -0,0 +1,123 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EmsMessageInfoType is a structure to represent a ems-message-info ZAPI object
+type EmsMessageInfoType struct {
+	XMLName        xml.Name `xml:"ems-message-info"`
+	EventPtr       *string  `xml:"event"`
+	MessageNamePtr *string  `xml:"message-name"`
+	NodePtr        *string  `xml:"node"`
+	SeqNumPtr      *int     `xml:"seq-num"`
+	SeverityPtr    *string  `xml:"severity"`
+	SourcePtr      *string  `xml:"source"`
+	TimePtr        *int     `xml:"time"`
+}
+
+// NewEmsMessageInfoType is a factory method for creating new instances of EmsMessageInfoType objects
+func NewEmsMessageInfoType() *EmsMessageInfoType {
+	return &EmsMessageInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *EmsMessageInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o EmsMessageInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Event is a 'getter' method
+func (o *EmsMessageInfoType) Event() string {
+	r := *o.EventPtr
+	return r
+}
+
+// SetEvent is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetEvent(newValue string) *EmsMessageInfoType {
+	o.EventPtr = &newValue
+	return o
+}
+
+// MessageName is a 'getter' method
+func (o *EmsMessageInfoType) MessageName() string {
+	r := *o.MessageNamePtr
+	return r
+}
+
+// SetMessageName is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetMessageName(newValue string) *EmsMessageInfoType {
+	o.MessageNamePtr = &newValue
+	return o
+}
+
+// Node is a 'getter' method
+func (o *EmsMessageInfoType) Node() string {
+	r := *o.NodePtr
+	return r
+}
+
+// SetNode is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetNode(newValue string) *EmsMessageInfoType {
+	o.NodePtr = &newValue
+	return o
+}
+
+// SeqNum is a 'getter' method
+func (o *EmsMessageInfoType) SeqNum() int {
+	r := *o.SeqNumPtr
+	return r
+}
+
+// SetSeqNum is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetSeqNum(newValue int) *EmsMessageInfoType {
+	o.SeqNumPtr = &newValue
+	return o
+}
+
+// Severity is a 'getter' method
+func (o *EmsMessageInfoType) Severity() string {
+	r := *o.SeverityPtr
+	return r
+}
+
+// SetSeverity is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetSeverity(newValue string) *EmsMessageInfoType {
+	o.SeverityPtr = &newValue
+	return o
+}
+
+// Source is a 'getter' method
+func (o *EmsMessageInfoType) Source() string {
+	r := *o.SourcePtr
+	return r
+}
+
+// SetSource is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetSource(newValue string) *EmsMessageInfoType {
+	o.SourcePtr = &newValue
+	return o
+}
+
+// Time is a 'getter' method
+func (o *EmsMessageInfoType) Time() int {
+	r := *o.TimePtr
+	return r
+}
+
+// SetTime is a fluent style 'setter' method that can be chained
+func (o *EmsMessageInfoType) SetTime(newValue int) *EmsMessageInfoType {
+	o.TimePtr = &newValue
+	return o
+}
@@ -0,0 +1,71 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LicenseV2StatusInfoType is a structure to represent a license-v2-status-info ZAPI object
+type LicenseV2StatusInfoType struct {
+	XMLName        xml.Name `xml:"license-v2-status-info"`
+	DescriptionPtr *string  `xml:"description"`
+	MethodPtr      *string  `xml:"method"`
+	PackagePtr     *string  `xml:"package"`
+}
+
+// NewLicenseV2StatusInfoType is a factory method for creating new instances of LicenseV2StatusInfoType objects
+func NewLicenseV2StatusInfoType() *LicenseV2StatusInfoType {
+	return &LicenseV2StatusInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *LicenseV2StatusInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o LicenseV2StatusInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Description is a 'getter' method
+func (o *LicenseV2StatusInfoType) Description() string {
+	r := *o.DescriptionPtr
+	return r
+}
+
+// SetDescription is a fluent style 'setter' method that can be chained
+func (o *LicenseV2StatusInfoType) SetDescription(newValue string) *LicenseV2StatusInfoType {
+	o.DescriptionPtr = &newValue
+	return o
+}
+
+// Method is a 'getter' method
+func (o *LicenseV2StatusInfoType) Method() string {
+	r := *o.MethodPtr
+	return r
+}
+
+// SetMethod is a fluent style 'setter' method that can be chained
+func (o *LicenseV2StatusInfoType) SetMethod(newValue string) *LicenseV2StatusInfoType {
+	o.MethodPtr = &newValue
+	return o
+}
+
+// Package is a 'getter' method
+func (o *LicenseV2StatusInfoType) Package() string {
+	r := *o.PackagePtr
+	return r
+}
+
+// SetPackage is a fluent style 'setter' method that can be chained
+func (o *LicenseV2StatusInfoType) SetPackage(newValue string) *LicenseV2StatusInfoType {
+	o.PackagePtr = &newValue
+	return o
+}
@@ -0,0 +1,175 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// QuotaType is a structure to represent a quota ZAPI object
+type QuotaType struct {
+	XMLName          xml.Name `xml:"quota"`
+	DiskLimitPtr     *string  `xml:"disk-limit"`
+	DiskUsedPtr      *string  `xml:"disk-used"`
+	FileLimitPtr     *string  `xml:"file-limit"`
+	FilesUsedPtr     *string  `xml:"files-used"`
+	QuotaTargetPtr   *string  `xml:"quota-target"`
+	QuotaTypePtr     *string  `xml:"quota-type"`
+	SoftDiskLimitPtr *string  `xml:"soft-disk-limit"`
+	SoftFileLimitPtr *string  `xml:"soft-file-limit"`
+	TreePtr          *string  `xml:"tree"`
+	VolumePtr        *string  `xml:"volume"`
+	VserverPtr       *string  `xml:"vserver"`
+}
+
+// NewQuotaType is a factory method for creating new instances of QuotaType objects
+func NewQuotaType() *QuotaType {
+	return &QuotaType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *QuotaType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o QuotaType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// DiskLimit is a 'getter' method
+func (o *QuotaType) DiskLimit() string {
+	r := *o.DiskLimitPtr
+	return r
+}
+
+// SetDiskLimit is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetDiskLimit(newValue string) *QuotaType {
+	o.DiskLimitPtr = &newValue
+	return o
+}
+
+// DiskUsed is a 'getter' method
+func (o *QuotaType) DiskUsed() string {
+	r := *o.DiskUsedPtr
+	return r
+}
+
+// SetDiskUsed is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetDiskUsed(newValue string) *QuotaType {
+	o.DiskUsedPtr = &newValue
+	return o
+}
+
+// FileLimit is a 'getter' method
+func (o *QuotaType) FileLimit() string {
+	r := *o.FileLimitPtr
+	return r
+}
+
+// SetFileLimit is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetFileLimit(newValue string) *QuotaType {
+	o.FileLimitPtr = &newValue
+	return o
+}
+
+// FilesUsed is a 'getter' method
+func (o *QuotaType) FilesUsed() string {
+	r := *o.FilesUsedPtr
+	return r
+}
+
+// SetFilesUsed is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetFilesUsed(newValue string) *QuotaType {
+	o.FilesUsedPtr = &newValue
+	return o
+}
+
+// QuotaTarget is a 'getter' method
+func (o *QuotaType) QuotaTarget() string {
+	r := *o.QuotaTargetPtr
+	return r
+}
+
+// SetQuotaTarget is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetQuotaTarget(newValue string) *QuotaType {
+	o.QuotaTargetPtr = &newValue
+	return o
+}
+
+// QuotaType is a 'getter' method
+func (o *QuotaType) QuotaType() string {
+	r := *o.QuotaTypePtr
+	return r
+}
+
+// SetQuotaType is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetQuotaType(newValue string) *QuotaType {
+	o.QuotaTypePtr = &newValue
+	return o
+}
+
+// SoftDiskLimit is a 'getter' method
+func (o *QuotaType) SoftDiskLimit() string {
+	r := *o.SoftDiskLimitPtr
+	return r
+}
+
+// SetSoftDiskLimit is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetSoftDiskLimit(newValue string) *QuotaType {
+	o.SoftDiskLimitPtr = &newValue
+	return o
+}
+
+// SoftFileLimit is a 'getter' method
+func (o *QuotaType) SoftFileLimit() string {
+	r := *o.SoftFileLimitPtr
+	return r
+}
+
+// SetSoftFileLimit is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetSoftFileLimit(newValue string) *QuotaType {
+	o.SoftFileLimitPtr = &newValue
+	return o
+}
+
+// Tree is a 'getter' method
+func (o *QuotaType) Tree() string {
+	r := *o.TreePtr
+	return r
+}
+
+// SetTree is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetTree(newValue string) *QuotaType {
+	o.TreePtr = &newValue
+	return o
+}
+
+// Volume is a 'getter' method
+func (o *QuotaType) Volume() string {
+	r := *o.VolumePtr
+	return r
+}
+
+// SetVolume is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetVolume(newValue string) *QuotaType {
+	o.VolumePtr = &newValue
+	return o
+}
+
+// Vserver is a 'getter' method
+func (o *QuotaType) Vserver() string {
+	r := *o.VserverPtr
+	return r
+}
+
+// SetVserver is a fluent style 'setter' method that can be chained
+func (o *QuotaType) SetVserver(newValue string) *QuotaType {
+	o.VserverPtr = &newValue
+	return o
+}
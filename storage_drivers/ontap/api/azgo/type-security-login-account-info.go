@@ -0,0 +1,110 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SecurityLoginAccountInfoType is a structure to represent a security-login-account-info ZAPI object
+type SecurityLoginAccountInfoType struct {
+	XMLName                 xml.Name `xml:"security-login-account-info"`
+	ApplicationPtr          *string  `xml:"application"`
+	AuthenticationMethodPtr *string  `xml:"authentication-method"`
+	IsLockedPtr             *bool    `xml:"is-locked"`
+	RoleNamePtr             *string  `xml:"role-name"`
+	UserNamePtr             *string  `xml:"user-name"`
+	VserverPtr              *string  `xml:"vserver"`
+}
+
+// NewSecurityLoginAccountInfoType is a factory method for creating new instances of SecurityLoginAccountInfoType objects
+func NewSecurityLoginAccountInfoType() *SecurityLoginAccountInfoType {
+	return &SecurityLoginAccountInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginAccountInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginAccountInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Application is a 'getter' method
+func (o *SecurityLoginAccountInfoType) Application() string {
+	r := *o.ApplicationPtr
+	return r
+}
+
+// SetApplication is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetApplication(newValue string) *SecurityLoginAccountInfoType {
+	o.ApplicationPtr = &newValue
+	return o
+}
+
+// AuthenticationMethod is a 'getter' method
+func (o *SecurityLoginAccountInfoType) AuthenticationMethod() string {
+	r := *o.AuthenticationMethodPtr
+	return r
+}
+
+// SetAuthenticationMethod is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetAuthenticationMethod(newValue string) *SecurityLoginAccountInfoType {
+	o.AuthenticationMethodPtr = &newValue
+	return o
+}
+
+// IsLocked is a 'getter' method
+func (o *SecurityLoginAccountInfoType) IsLocked() bool {
+	r := *o.IsLockedPtr
+	return r
+}
+
+// SetIsLocked is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetIsLocked(newValue bool) *SecurityLoginAccountInfoType {
+	o.IsLockedPtr = &newValue
+	return o
+}
+
+// RoleName is a 'getter' method
+func (o *SecurityLoginAccountInfoType) RoleName() string {
+	r := *o.RoleNamePtr
+	return r
+}
+
+// SetRoleName is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetRoleName(newValue string) *SecurityLoginAccountInfoType {
+	o.RoleNamePtr = &newValue
+	return o
+}
+
+// UserName is a 'getter' method
+func (o *SecurityLoginAccountInfoType) UserName() string {
+	r := *o.UserNamePtr
+	return r
+}
+
+// SetUserName is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetUserName(newValue string) *SecurityLoginAccountInfoType {
+	o.UserNamePtr = &newValue
+	return o
+}
+
+// Vserver is a 'getter' method
+func (o *SecurityLoginAccountInfoType) Vserver() string {
+	r := *o.VserverPtr
+	return r
+}
+
+// SetVserver is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginAccountInfoType) SetVserver(newValue string) *SecurityLoginAccountInfoType {
+	o.VserverPtr = &newValue
+	return o
+}
@@ -0,0 +1,97 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SecurityLoginRoleInfoType is a structure to represent a security-login-role-info ZAPI object
+type SecurityLoginRoleInfoType struct {
+	XMLName                 xml.Name `xml:"security-login-role-info"`
+	AccessLevelPtr          *string  `xml:"access-level"`
+	CommandDirectoryNamePtr *string  `xml:"command-directory-name"`
+	RoleNamePtr             *string  `xml:"role-name"`
+	RoleQueryPtr            *string  `xml:"role-query"`
+	VserverPtr              *string  `xml:"vserver"`
+}
+
+// NewSecurityLoginRoleInfoType is a factory method for creating new instances of SecurityLoginRoleInfoType objects
+func NewSecurityLoginRoleInfoType() *SecurityLoginRoleInfoType {
+	return &SecurityLoginRoleInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SecurityLoginRoleInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SecurityLoginRoleInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// AccessLevel is a 'getter' method
+func (o *SecurityLoginRoleInfoType) AccessLevel() string {
+	r := *o.AccessLevelPtr
+	return r
+}
+
+// SetAccessLevel is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleInfoType) SetAccessLevel(newValue string) *SecurityLoginRoleInfoType {
+	o.AccessLevelPtr = &newValue
+	return o
+}
+
+// CommandDirectoryName is a 'getter' method
+func (o *SecurityLoginRoleInfoType) CommandDirectoryName() string {
+	r := *o.CommandDirectoryNamePtr
+	return r
+}
+
+// SetCommandDirectoryName is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleInfoType) SetCommandDirectoryName(newValue string) *SecurityLoginRoleInfoType {
+	o.CommandDirectoryNamePtr = &newValue
+	return o
+}
+
+// RoleName is a 'getter' method
+func (o *SecurityLoginRoleInfoType) RoleName() string {
+	r := *o.RoleNamePtr
+	return r
+}
+
+// SetRoleName is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleInfoType) SetRoleName(newValue string) *SecurityLoginRoleInfoType {
+	o.RoleNamePtr = &newValue
+	return o
+}
+
+// RoleQuery is a 'getter' method
+func (o *SecurityLoginRoleInfoType) RoleQuery() string {
+	r := *o.RoleQueryPtr
+	return r
+}
+
+// SetRoleQuery is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleInfoType) SetRoleQuery(newValue string) *SecurityLoginRoleInfoType {
+	o.RoleQueryPtr = &newValue
+	return o
+}
+
+// Vserver is a 'getter' method
+func (o *SecurityLoginRoleInfoType) Vserver() string {
+	r := *o.VserverPtr
+	return r
+}
+
+// SetVserver is a fluent style 'setter' method that can be chained
+func (o *SecurityLoginRoleInfoType) SetVserver(newValue string) *SecurityLoginRoleInfoType {
+	o.VserverPtr = &newValue
+	return o
+}
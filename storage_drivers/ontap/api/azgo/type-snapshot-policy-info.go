@@ -0,0 +1,130 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotPolicyInfoType is a structure to represent a snapshot-policy-info ZAPI object
+type SnapshotPolicyInfoType struct {
+	XMLName                    xml.Name                                       `xml:"snapshot-policy-info"`
+	CommentPtr                 *string                                        `xml:"comment"`
+	EnabledPtr                 *bool                                          `xml:"enabled"`
+	PolicyPtr                  *string                                        `xml:"policy"`
+	SnapshotPolicySchedulesPtr *SnapshotPolicyInfoTypeSnapshotPolicySchedules `xml:"snapshot-policy-schedules"`
+	TotalSchedulesPtr          *int                                           `xml:"total-schedules"`
+	VserverNamePtr             *string                                        `xml:"vserver-name"`
+}
+
+// NewSnapshotPolicyInfoType is a factory method for creating new instances of SnapshotPolicyInfoType objects
+func NewSnapshotPolicyInfoType() *SnapshotPolicyInfoType {
+	return &SnapshotPolicyInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotPolicyInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotPolicyInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Comment is a 'getter' method
+func (o *SnapshotPolicyInfoType) Comment() string {
+	r := *o.CommentPtr
+	return r
+}
+
+// SetComment is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetComment(newValue string) *SnapshotPolicyInfoType {
+	o.CommentPtr = &newValue
+	return o
+}
+
+// Enabled is a 'getter' method
+func (o *SnapshotPolicyInfoType) Enabled() bool {
+	r := *o.EnabledPtr
+	return r
+}
+
+// SetEnabled is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetEnabled(newValue bool) *SnapshotPolicyInfoType {
+	o.EnabledPtr = &newValue
+	return o
+}
+
+// Policy is a 'getter' method
+func (o *SnapshotPolicyInfoType) Policy() string {
+	r := *o.PolicyPtr
+	return r
+}
+
+// SetPolicy is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetPolicy(newValue string) *SnapshotPolicyInfoType {
+	o.PolicyPtr = &newValue
+	return o
+}
+
+// SnapshotPolicySchedules is a 'getter' method
+func (o *SnapshotPolicyInfoType) SnapshotPolicySchedules() SnapshotPolicyInfoTypeSnapshotPolicySchedules {
+	r := *o.SnapshotPolicySchedulesPtr
+	return r
+}
+
+// SetSnapshotPolicySchedules is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetSnapshotPolicySchedules(newValue SnapshotPolicyInfoTypeSnapshotPolicySchedules) *SnapshotPolicyInfoType {
+	o.SnapshotPolicySchedulesPtr = &newValue
+	return o
+}
+
+// TotalSchedules is a 'getter' method
+func (o *SnapshotPolicyInfoType) TotalSchedules() int {
+	r := *o.TotalSchedulesPtr
+	return r
+}
+
+// SetTotalSchedules is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetTotalSchedules(newValue int) *SnapshotPolicyInfoType {
+	o.TotalSchedulesPtr = &newValue
+	return o
+}
+
+// VserverName is a 'getter' method
+func (o *SnapshotPolicyInfoType) VserverName() string {
+	r := *o.VserverNamePtr
+	return r
+}
+
+// SetVserverName is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoType) SetVserverName(newValue string) *SnapshotPolicyInfoType {
+	o.VserverNamePtr = &newValue
+	return o
+}
+
+// SnapshotPolicyInfoTypeSnapshotPolicySchedules is a wrapper
+type SnapshotPolicyInfoTypeSnapshotPolicySchedules struct {
+	XMLName                 xml.Name                   `xml:"snapshot-policy-schedules"`
+	SnapshotScheduleInfoPtr []SnapshotScheduleInfoType `xml:"snapshot-schedule-info"`
+}
+
+// SnapshotScheduleInfo is a 'getter' method
+func (o *SnapshotPolicyInfoTypeSnapshotPolicySchedules) SnapshotScheduleInfo() []SnapshotScheduleInfoType {
+	r := o.SnapshotScheduleInfoPtr
+	return r
+}
+
+// SetSnapshotScheduleInfo is a fluent style 'setter' method that can be chained
+func (o *SnapshotPolicyInfoTypeSnapshotPolicySchedules) SetSnapshotScheduleInfo(newValue []SnapshotScheduleInfoType) *SnapshotPolicyInfoTypeSnapshotPolicySchedules {
+	newSlice := make([]SnapshotScheduleInfoType, len(newValue))
+	copy(newSlice, newValue)
+	o.SnapshotScheduleInfoPtr = newSlice
+	return o
+}
@@ -0,0 +1,84 @@
+package azgo
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotScheduleInfoType is a structure to represent a snapshot-schedule-info ZAPI object
+type SnapshotScheduleInfoType struct {
+	XMLName            xml.Name `xml:"snapshot-schedule-info"`
+	CountPtr           *int     `xml:"count"`
+	PrefixPtr          *string  `xml:"prefix"`
+	SchedulePtr        *string  `xml:"schedule"`
+	SnapmirrorLabelPtr *string  `xml:"snapmirror-label"`
+}
+
+// NewSnapshotScheduleInfoType is a factory method for creating new instances of SnapshotScheduleInfoType objects
+func NewSnapshotScheduleInfoType() *SnapshotScheduleInfoType {
+	return &SnapshotScheduleInfoType{}
+}
+
+// ToXML converts this object into an xml string representation
+func (o *SnapshotScheduleInfoType) ToXML() (string, error) {
+	output, err := xml.MarshalIndent(o, " ", "    ")
+	if err != nil {
+		log.Errorf("error: %v", err)
+	}
+	return string(output), err
+}
+
+// String returns a string representation of this object's fields and implements the Stringer interface
+func (o SnapshotScheduleInfoType) String() string {
+	return ToString(reflect.ValueOf(o))
+}
+
+// Count is a 'getter' method
+func (o *SnapshotScheduleInfoType) Count() int {
+	r := *o.CountPtr
+	return r
+}
+
+// SetCount is a fluent style 'setter' method that can be chained
+func (o *SnapshotScheduleInfoType) SetCount(newValue int) *SnapshotScheduleInfoType {
+	o.CountPtr = &newValue
+	return o
+}
+
+// Prefix is a 'getter' method
+func (o *SnapshotScheduleInfoType) Prefix() string {
+	r := *o.PrefixPtr
+	return r
+}
+
+// SetPrefix is a fluent style 'setter' method that can be chained
+func (o *SnapshotScheduleInfoType) SetPrefix(newValue string) *SnapshotScheduleInfoType {
+	o.PrefixPtr = &newValue
+	return o
+}
+
+// Schedule is a 'getter' method
+func (o *SnapshotScheduleInfoType) Schedule() string {
+	r := *o.SchedulePtr
+	return r
+}
+
+// SetSchedule is a fluent style 'setter' method that can be chained
+func (o *SnapshotScheduleInfoType) SetSchedule(newValue string) *SnapshotScheduleInfoType {
+	o.SchedulePtr = &newValue
+	return o
+}
+
+// SnapmirrorLabel is a 'getter' method
+func (o *SnapshotScheduleInfoType) SnapmirrorLabel() string {
+	r := *o.SnapmirrorLabelPtr
+	return r
+}
+
+// SetSnapmirrorLabel is a fluent style 'setter' method that can be chained
+func (o *SnapshotScheduleInfoType) SetSnapmirrorLabel(newValue string) *SnapshotScheduleInfoType {
+	o.SnapmirrorLabelPtr = &newValue
+	return o
+}
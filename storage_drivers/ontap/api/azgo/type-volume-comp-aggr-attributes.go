@@ -9,8 +9,9 @@ import (
 
 // VolumeCompAggrAttributesType is a structure to represent a volume-comp-aggr-attributes ZAPI object
 type VolumeCompAggrAttributesType struct {
-	XMLName          xml.Name `xml:"volume-comp-aggr-attributes"`
-	TieringPolicyPtr *string  `xml:"tiering-policy"`
+	XMLName                      xml.Name `xml:"volume-comp-aggr-attributes"`
+	TieringMinimumCoolingDaysPtr *int     `xml:"tiering-minimum-cooling-days"`
+	TieringPolicyPtr             *string  `xml:"tiering-policy"`
 }
 
 // NewVolumeCompAggrAttributesType is a factory method for creating new instances of VolumeCompAggrAttributesType objects
@@ -32,6 +33,18 @@ func (o VolumeCompAggrAttributesType) String() string {
 	return ToString(reflect.ValueOf(o))
 }
 
+// TieringMinimumCoolingDays is a 'getter' method
+func (o *VolumeCompAggrAttributesType) TieringMinimumCoolingDays() int {
+	r := *o.TieringMinimumCoolingDaysPtr
+	return r
+}
+
+// SetTieringMinimumCoolingDays is a fluent style 'setter' method that can be chained
+func (o *VolumeCompAggrAttributesType) SetTieringMinimumCoolingDays(newValue int) *VolumeCompAggrAttributesType {
+	o.TieringMinimumCoolingDaysPtr = &newValue
+	return o
+}
+
 // TieringPolicy is a 'getter' method
 func (o *VolumeCompAggrAttributesType) TieringPolicy() string {
 	r := *o.TieringPolicyPtr
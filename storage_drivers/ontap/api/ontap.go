@@ -4,12 +4,19 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"reflect"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -36,14 +43,26 @@ type ClientConfig struct {
 	DriverContext           tridentconfig.DriverContext
 	ContextBasedZapiRecords int
 	DebugTraceFlags         map[string]bool
+	BackendName             string
+
+	// SVM-DR partner, to which the client may fail over if the SVM becomes unreachable
+	SecondaryManagementLIF string
+	SecondarySVM           string
 }
 
 // Client is the object to use for interacting with ONTAP controllers
 type Client struct {
-	config  ClientConfig
-	zr      *azgo.ZapiRunner
+	target  *atomic.Value // holds the *clientTarget API calls are sent to
 	m       *sync.Mutex
-	SVMUUID string
+	probed  *probedFeatures
+	SVMUUID string // the SVM the client was created for, which doesn't change if the client fails over
+}
+
+// clientTarget is the SVM a client sends its API calls to, which is replaced as a whole if the client fails
+// over, so that all copies of the client may keep reading it while the failover happens.
+type clientTarget struct {
+	config ClientConfig
+	zr     *azgo.ZapiRunner
 }
 
 // NewClient is a factory method for creating a new instance
@@ -56,6 +75,11 @@ func NewClient(config ClientConfig) *Client {
 	}
 
 	d := &Client{
+		target: &atomic.Value{},
+		m:      &sync.Mutex{},
+		probed: &probedFeatures{},
+	}
+	d.target.Store(&clientTarget{
 		config: config,
 		zr: &azgo.ZapiRunner{
 			ManagementLIF:   config.ManagementLIF,
@@ -64,25 +88,158 @@ func NewClient(config ClientConfig) *Client {
 			Password:        config.Password,
 			Secure:          true,
 			DebugTraceFlags: config.DebugTraceFlags,
+			Tracer:          azgo.NewAPITracer(config.DebugTraceFlags["api"]),
+			Auditor:         azgo.NewAPIAuditor(config.BackendName),
 		},
-		m: &sync.Mutex{},
-	}
+	})
 	return d
 }
 
+// config returns the configuration of the SVM this client is currently sending API calls to.
+func (d Client) config() ClientConfig {
+	return d.target.Load().(*clientTarget).config
+}
+
+// zr returns the ZapiRunner that sends API calls to the SVM this client is currently using.
+func (d Client) zr() *azgo.ZapiRunner {
+	return d.target.Load().(*clientTarget).zr
+}
+
+// SetAPITraceEnabled turns tracing of this client's API calls on or off.  Traces redact secrets and
+// truncate large payloads.  All copies of the client share the setting.
+func (d Client) SetAPITraceEnabled(enabled bool) {
+	d.zr().Tracer.SetEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of this client, which turn tracing of its method calls
+// and API calls on or off.  The flags must not be changed after they are set.
+func (d Client) SetDebugTraceFlags(flags map[string]bool) {
+	d.zr().DebugTraceFlags = flags
+	d.zr().Tracer.SetEnabled(flags["api"])
+}
+
+// SetLogLevel makes the messages about this client's API calls, including their traces, logged at the given
+// level rather than Trident's, or at Trident's level again if the level is empty.  All copies of the client
+// share the setting.
+func (d Client) SetLogLevel(level string) error {
+	if level == "" {
+		d.zr().Tracer.ResetLogLevel()
+		return nil
+	}
+	logLevel, err := log.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	d.zr().Tracer.SetLogLevel(logLevel)
+	return nil
+}
+
+// LogLevel returns the level at which messages about this client's API calls are logged, or an empty string
+// if they are logged at Trident's level.
+func (d Client) LogLevel() string {
+	if level, ok := d.zr().Tracer.LogLevel(); ok {
+		return level.String()
+	}
+	return ""
+}
+
+// APITraceEnabled returns whether this client's API calls are being traced.
+func (d Client) APITraceEnabled() bool {
+	return d.zr().Tracer.Enabled()
+}
+
+// SVM returns the name of the SVM this client is managing, which changes if the client fails over.
+func (d Client) SVM() string {
+	return d.config().SVM
+}
+
+// ManagementLIF returns the address this client is sending API calls to.
+func (d Client) ManagementLIF() string {
+	return d.config().ManagementLIF
+}
+
+// SecondarySVM returns the name of the SVM this client would fail over to.
+func (d Client) SecondarySVM() string {
+	return d.config().SecondarySVM
+}
+
+// SecondaryManagementLIF returns the address this client would send API calls to after failing over.
+func (d Client) SecondaryManagementLIF() string {
+	return d.config().SecondaryManagementLIF
+}
+
+// CanFailOver returns whether an SVM-DR partner is configured for this client.
+func (d Client) CanFailOver() bool {
+	return d.config().SecondaryManagementLIF != ""
+}
+
+// FailOver switches this client, and all copies of it, to its SVM-DR partner.  The primary and secondary
+// are swapped, so a later failover returns the client to the original SVM.  The partner SVM must be
+// running, as it is once SVM-DR has been activated at the secondary site.
+func (d *Client) FailOver() error {
+
+	if !d.CanFailOver() {
+		return errors.New("no SVM-DR partner is configured")
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	current := d.target.Load().(*clientTarget)
+	partnerConfig := current.config
+	partnerConfig.ManagementLIF = current.config.SecondaryManagementLIF
+	partnerConfig.SecondaryManagementLIF = current.config.ManagementLIF
+	partnerConfig.SVM = current.config.SecondarySVM
+	partnerConfig.SecondarySVM = current.config.SVM
+	partner := NewClient(partnerConfig)
+	partner.zr().Tracer = current.zr.Tracer
+	partner.zr().DebugTraceFlags = current.zr.DebugTraceFlags
+
+	vserverResponse, err := partner.VserverGetRequest()
+	if err = GetError(vserverResponse, err); err != nil {
+		return fmt.Errorf("could not read SVM %s at %s; %v", partnerConfig.SVM, partnerConfig.ManagementLIF, err)
+	}
+	if vserverResponse.Result.AttributesPtr == nil || vserverResponse.Result.AttributesPtr.VserverInfoPtr == nil {
+		return fmt.Errorf("could not read SVM %s at %s", partnerConfig.SVM, partnerConfig.ManagementLIF)
+	}
+	vserverInfo := vserverResponse.Result.AttributesPtr.VserverInfoPtr
+	if vserverInfo.StatePtr != nil && vserverInfo.State() != "running" {
+		return fmt.Errorf("SVM %s is %s", partnerConfig.SVM, vserverInfo.State())
+	}
+	if _, err = partner.SystemGetOntapiVersion(); err != nil {
+		return err
+	}
+
+	d.target.Store(partner.target.Load())
+	d.probed.set(nil)
+	return nil
+}
+
 // GetClonedZapiRunner returns a clone of the ZapiRunner configured on this driver.
 func (d Client) GetClonedZapiRunner() *azgo.ZapiRunner {
 	clone := new(azgo.ZapiRunner)
-	*clone = *d.zr
+	*clone = *d.zr()
 	return clone
 }
 
+// WithContext returns a copy of this client whose API calls are cancelled when ctx is done, and whose
+// log messages carry the request ID in ctx.  The copy keeps using the SVM the client is using now.
+func (d Client) WithContext(ctx context.Context) *Client {
+	current := d.target.Load().(*clientTarget)
+	clone := new(azgo.ZapiRunner)
+	*clone = *current.zr
+	clone.Context = ctx
+	d.target = &atomic.Value{}
+	d.target.Store(&clientTarget{config: current.config, zr: clone})
+	return &d
+}
+
 // GetNontunneledZapiRunner returns a clone of the ZapiRunner configured on this driver with the SVM field cleared so ZAPI calls
 // made with the resulting runner aren't tunneled.  Note that the calls could still go directly to either a cluster or
 // vserver management LIF.
 func (d Client) GetNontunneledZapiRunner() *azgo.ZapiRunner {
 	clone := new(azgo.ZapiRunner)
-	*clone = *d.zr
+	*clone = *d.zr()
 	clone.SVM = ""
 	return clone
 }
@@ -198,6 +355,26 @@ type ZapiAsyncResult struct {
 	errorCode int
 }
 
+// JobID returns the ID of the job running an async request, which is only meaningful while the job is in progress.
+func (r ZapiAsyncResult) JobID() int {
+	return r.jobId
+}
+
+// IsInProgress returns true if an async request is still being run by an ONTAP job.
+func (r ZapiAsyncResult) IsInProgress() bool {
+	return r.status == "in_progress"
+}
+
+// IsFailed returns true if an async request failed before its job could start.
+func (r ZapiAsyncResult) IsFailed() bool {
+	return r.status == "failed"
+}
+
+// ErrorCode returns the error code of a failed async request.
+func (r ZapiAsyncResult) ErrorCode() int {
+	return r.errorCode
+}
+
 // ZapiError encapsulates the status, reason, and errno values from a ZAPI invocation, and it provides helper methods for detecting
 // common error conditions.
 type ZapiError struct {
@@ -291,6 +468,9 @@ const (
 	NetAppFabricPoolFlexGroup feature = "NETAPP_FABRICPOOL_FLEXGROUP"
 	LunGeometrySkip           feature = "LUN_GEOMETRY_SKIP"
 	FabricPoolForSVMDR        feature = "FABRICPOOL_FOR_SVMDR"
+	NetAppFlexCache           feature = "NETAPP_FLEXCACHE"
+	SnapMirrorSync            feature = "SNAPMIRROR_SYNC"
+	RESTAPI                   feature = "REST_API"
 )
 
 // Indicate the minimum Ontapi version for each feature here
@@ -302,11 +482,65 @@ var features = map[feature]*utils.Version{
 	NetAppFabricPoolFlexGroup: utils.MustParseSemantic("1.150.0"), // cDOT 9.5.0
 	LunGeometrySkip:           utils.MustParseSemantic("1.150.0"), // cDOT 9.5.0
 	FabricPoolForSVMDR:        utils.MustParseSemantic("1.150.0"), // cDOT 9.5.0
+	NetAppFlexCache:           utils.MustParseSemantic("1.150.0"), // cDOT 9.5.0
+	SnapMirrorSync:            utils.MustParseSemantic("1.150.0"), // cDOT 9.5.0
+	RESTAPI:                   utils.MustParseSemantic("1.160.0"), // cDOT 9.6.0
+}
+
+// Indicate the license package each licensed feature requires here
+var licensedFeatures = map[feature]string{
+	NetAppFlexGroupsClone: "flexclone",
+	SnapMirrorSync:        "snapmirror_sync",
+}
+
+// Indicate the name by which each feature is reported to users here
+var featureNames = map[feature]string{
+	NetAppFlexGroups:          "flexGroups",
+	NetAppFlexGroupsClone:     "flexGroupClone",
+	NetAppFabricPoolFlexVol:   "fabricPoolFlexVol",
+	NetAppFabricPoolFlexGroup: "fabricPoolFlexGroup",
+	LunGeometrySkip:           "lunGeometryResize",
+	FabricPoolForSVMDR:        "fabricPoolForSVMDR",
+	NetAppFlexCache:           "flexCache",
+	SnapMirrorSync:            "snapMirrorSync",
+	RESTAPI:                   "restAPI",
 }
 
-// SupportsFeature returns true if the Ontapi version supports the supplied feature
+// probedFeatures holds the results of the most recent feature probe, shared by all copies of a client
+type probedFeatures struct {
+	sync.RWMutex
+	results map[feature]bool
+}
+
+func (p *probedFeatures) get(f feature) (supported, probed bool) {
+	if p == nil {
+		return false, false
+	}
+	p.RLock()
+	defer p.RUnlock()
+	supported, probed = p.results[f]
+	return
+}
+
+func (p *probedFeatures) set(results map[feature]bool) {
+	p.Lock()
+	defer p.Unlock()
+	p.results = results
+}
+
+// SupportsFeature returns true if the supplied feature is available.  Once the features have been
+// probed, the probe's results are used, otherwise only the Ontapi version is considered.
 func (d Client) SupportsFeature(feature feature) bool {
 
+	if supported, probed := d.probed.get(feature); probed {
+		return supported
+	}
+	return d.ontapiVersionSupportsFeature(feature)
+}
+
+// ontapiVersionSupportsFeature returns true if the Ontapi version supports the supplied feature
+func (d Client) ontapiVersionSupportsFeature(feature feature) bool {
+
 	ontapiVersion, err := d.SystemGetOntapiVersion()
 	if err != nil {
 		return false
@@ -324,6 +558,46 @@ func (d Client) SupportsFeature(feature feature) bool {
 	}
 }
 
+// ProbeFeatures detects which features are available on the SVM, and remembers the results for
+// SupportsFeature.  Besides needing a recent enough Ontapi version, licensed features must be licensed,
+// and the REST API must answer a request.  If the licenses can't be read, such as by a user lacking the
+// privilege to do so, licensed features are assumed to be licensed.
+func (d Client) ProbeFeatures() map[string]bool {
+
+	licenses, licenseErr := d.LicensedPackages()
+	if licenseErr != nil {
+		log.Debugf("Could not read licenses, assuming licensed features are available. %v", licenseErr)
+	}
+
+	results := make(map[feature]bool)
+	for f := range features {
+		supported := d.ontapiVersionSupportsFeature(f)
+		if licensePackage, ok := licensedFeatures[f]; ok && supported && licenseErr == nil {
+			supported = licenses[licensePackage]
+		}
+		results[f] = supported
+	}
+	if results[RESTAPI] {
+		var response struct{}
+		if err := d.restGet("/api/storage/volumes?max_records=1", &response); err != nil {
+			log.Debugf("ONTAP REST API is not available. %v", err)
+			results[RESTAPI] = false
+		}
+	}
+	d.probed.set(results)
+
+	return d.Features()
+}
+
+// Features returns whether each feature is available, by the name it is reported to users.
+func (d Client) Features() map[string]bool {
+	reported := make(map[string]bool, len(featureNames))
+	for f, name := range featureNames {
+		reported[name] = d.SupportsFeature(f)
+	}
+	return reported
+}
+
 // API feature operations END
 /////////////////////////////////////////////////////////////////////////////
 
@@ -337,7 +611,7 @@ func (d Client) IgroupCreate(initiatorGroupName, initiatorGroupType, osType stri
 		SetInitiatorGroupName(initiatorGroupName).
 		SetInitiatorGroupType(initiatorGroupType).
 		SetOsType(osType).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -347,7 +621,7 @@ func (d Client) IgroupAdd(initiatorGroupName, initiator string) (*azgo.IgroupAdd
 	response, err := azgo.NewIgroupAddRequest().
 		SetInitiatorGroupName(initiatorGroupName).
 		SetInitiator(initiator).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -357,7 +631,7 @@ func (d Client) IgroupRemove(initiatorGroupName, initiator string, force bool) (
 		SetInitiatorGroupName(initiatorGroupName).
 		SetInitiator(initiator).
 		SetForce(force).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -365,7 +639,7 @@ func (d Client) IgroupRemove(initiatorGroupName, initiator string, force bool) (
 func (d Client) IgroupDestroy(initiatorGroupName string) (*azgo.IgroupDestroyResponse, error) {
 	response, err := azgo.NewIgroupDestroyRequest().
 		SetInitiatorGroupName(initiatorGroupName).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -373,7 +647,7 @@ func (d Client) IgroupDestroy(initiatorGroupName string) (*azgo.IgroupDestroyRes
 func (d Client) IgroupList() (*azgo.IgroupGetIterResponse, error) {
 	response, err := azgo.NewIgroupGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -386,7 +660,7 @@ func (d Client) IgroupGet(initiatorGroupName string) (*azgo.InitiatorGroupInfoTy
 
 	response, err := azgo.NewIgroupGetIterRequest().
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	if err != nil {
 		return &azgo.InitiatorGroupInfoType{}, err
 	} else if response.Result.NumRecords() == 0 {
@@ -416,7 +690,7 @@ func (d Client) LunCreate(lunPath string, sizeInBytes int, osType string, spaceR
 		SetOstype(osType).
 		SetSpaceReservationEnabled(spaceReserved).
 		SetSpaceAllocationEnabled(spaceAllocated).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -426,7 +700,7 @@ func (d Client) LunCloneCreate(volumeName, sourceLun, destinationLun string) (*a
 		SetVolume(volumeName).
 		SetSourcePath(sourceLun).
 		SetDestinationPath(destinationLun).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -434,7 +708,7 @@ func (d Client) LunCloneCreate(volumeName, sourceLun, destinationLun string) (*a
 func (d Client) LunGetSerialNumber(lunPath string) (*azgo.LunGetSerialNumberResponse, error) {
 	response, err := azgo.NewLunGetSerialNumberRequest().
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -448,7 +722,7 @@ func (d Client) LunMapGet(initiatorGroupName, lunPath string) (*azgo.LunMapGetIt
 
 	response, err := azgo.NewLunMapGetIterRequest().
 		SetQuery(lunMapInfo).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return &response, err
 }
 
@@ -459,7 +733,7 @@ func (d Client) LunMap(initiatorGroupName, lunPath string, lunID int) (*azgo.Lun
 		SetInitiatorGroup(initiatorGroupName).
 		SetPath(lunPath).
 		SetLunId(lunID).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -469,7 +743,7 @@ func (d Client) LunMapAutoID(initiatorGroupName, lunPath string) (*azgo.LunMapRe
 	response, err := azgo.NewLunMapRequest().
 		SetInitiatorGroup(initiatorGroupName).
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -536,7 +810,7 @@ func (d Client) LunMapIfNotMapped(initiatorGroupName, lunPath string, importNotM
 func (d Client) LunMapListInfo(lunPath string) (*azgo.LunMapListInfoResponse, error) {
 	response, err := azgo.NewLunMapListInfoRequest().
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -545,7 +819,7 @@ func (d Client) LunMapListInfo(lunPath string) (*azgo.LunMapListInfoResponse, er
 func (d Client) LunOffline(lunPath string) (*azgo.LunOfflineResponse, error) {
 	response, err := azgo.NewLunOfflineRequest().
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -554,7 +828,7 @@ func (d Client) LunOffline(lunPath string) (*azgo.LunOfflineResponse, error) {
 func (d Client) LunOnline(lunPath string) (*azgo.LunOnlineResponse, error) {
 	response, err := azgo.NewLunOnlineRequest().
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -563,7 +837,7 @@ func (d Client) LunOnline(lunPath string) (*azgo.LunOnlineResponse, error) {
 func (d Client) LunDestroy(lunPath string) (*azgo.LunDestroyResponse, error) {
 	response, err := azgo.NewLunDestroyRequest().
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -573,7 +847,16 @@ func (d Client) LunSetAttribute(lunPath, name, value string) (*azgo.LunSetAttrib
 		SetPath(lunPath).
 		SetName(name).
 		SetValue(value).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// LunSetComment sets the comment for a given LUN.
+func (d Client) LunSetComment(lunPath, comment string) (*azgo.LunSetCommentResponse, error) {
+	response, err := azgo.NewLunSetCommentRequest().
+		SetPath(lunPath).
+		SetComment(comment).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -582,7 +865,7 @@ func (d Client) LunGetAttribute(lunPath, name string) (*azgo.LunGetAttributeResp
 	response, err := azgo.NewLunGetAttributeRequest().
 		SetPath(lunPath).
 		SetName(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -604,14 +887,16 @@ func (d Client) LunGet(path string) (*azgo.LunInfoType, error) {
 		SetSize(0).
 		SetCreationTimestamp(0).
 		SetOnline(false).
-		SetMapped(false)
+		SetMapped(false).
+		SetSerialNumber("").
+		SetUuid("")
 	desiredAttributes.SetLunInfo(*lunInfo)
 
 	response, err := azgo.NewLunGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return &azgo.LunInfoType{}, err
@@ -634,21 +919,23 @@ func (d Client) lunGetAllCommon(query *azgo.LunGetIterRequestQuery) (*azgo.LunGe
 		SetPath("").
 		SetVolume("").
 		SetSize(0).
-		SetCreationTimestamp(0)
+		SetCreationTimestamp(0).
+		SetIsSpaceReservationEnabled(false).
+		SetIsSpaceAllocEnabled(false)
 	desiredAttributes.SetLunInfo(*lunInfo)
 
 	response, err := azgo.NewLunGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
 func (d Client) LunGetGeometry(path string) (*azgo.LunGetGeometryResponse, error) {
 	response, err := azgo.NewLunGetGeometryRequest().
 		SetPath(path).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -656,7 +943,7 @@ func (d Client) LunResize(path string, sizeBytes int) (uint64, error) {
 	response, err := azgo.NewLunResizeRequest().
 		SetPath(path).
 		SetSize(sizeBytes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	var errSize uint64 = 0
 	if err != nil {
@@ -736,7 +1023,7 @@ func (d Client) LunCount(volume string) (int, error) {
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	if err = GetError(response, err); err != nil {
 		return 0, err
 	}
@@ -749,7 +1036,7 @@ func (d Client) LunRename(path, newPath string) (*azgo.LunMoveResponse, error) {
 	response, err := azgo.NewLunMoveRequest().
 		SetPath(path).
 		SetNewPath(newPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -759,7 +1046,7 @@ func (d Client) LunUnmap(initiatorGroupName, lunPath string) (*azgo.LunUnmapResp
 	response, err := azgo.NewLunUnmapRequest().
 		SetInitiatorGroup(initiatorGroupName).
 		SetPath(lunPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -817,7 +1104,7 @@ func (d Client) FlexGroupCreate(
 		request.SetTieringPolicy(tieringPolicy)
 	}
 
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
 	if zerr := GetError(*response, err); zerr != nil {
 		return response, zerr
 	}
@@ -834,7 +1121,7 @@ func (d Client) FlexGroupCreate(
 func (d Client) FlexGroupDestroy(name string, force bool) (*azgo.VolumeDestroyAsyncResponse, error) {
 	response, err := azgo.NewVolumeDestroyAsyncRequest().
 		SetVolumeName(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if zerr := NewZapiError(*response); !zerr.IsPassed() {
 		// It's not an error if the volume no longer exists
@@ -860,7 +1147,7 @@ func (d Client) FlexGroupDestroy(name string, force bool) (*azgo.VolumeDestroyAs
 func (d Client) FlexGroupExists(name string) (bool, error) {
 	response, err := azgo.NewVolumeSizeAsyncRequest().
 		SetVolumeName(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if zerr := NewZapiError(response); !zerr.IsPassed() {
 		switch zerr.Code() {
@@ -903,7 +1190,7 @@ func (d Client) FlexGroupSetSize(name, newSize string) (*azgo.VolumeSizeAsyncRes
 	response, err := azgo.NewVolumeSizeAsyncRequest().
 		SetVolumeName(name).
 		SetNewSize(newSize).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if zerr := GetError(*response, err); zerr != nil {
 		return response, zerr
@@ -917,12 +1204,42 @@ func (d Client) FlexGroupSetSize(name, newSize string) (*azgo.VolumeSizeAsyncRes
 	return response, err
 }
 
+// FlexGroupExpand adds constituents to the specified FlexGroup on each of the listed aggregates, returning
+// without waiting for the job that adds them
+// equivalent to filer::> volume expand -vserver svm_name -volume fg_vol_name -aggr-list aggr1,aggr2 -aggr-list-multiplier 1
+func (d Client) FlexGroupExpand(
+	name string, aggrs []azgo.AggrNameType, multiplier int,
+) (*azgo.VolumeExpandAsyncResponse, error) {
+
+	aggrList := azgo.VolumeExpandAsyncRequestAggrList{}
+	aggrList.SetAggrName(aggrs)
+
+	response, err := azgo.NewVolumeExpandAsyncRequest().
+		SetVolumeName(name).
+		SetAggrList(aggrList).
+		SetAggrListMultiplier(multiplier).
+		ExecuteUsing(d.zr())
+
+	if zerr := GetError(*response, err); zerr != nil {
+		return response, zerr
+	}
+
+	return response, nil
+}
+
 // FlexGroupVolumeDisableSnapshotDirectoryAccess disables access to the ".snapshot" directory
 // Disable '.snapshot' to allow official mysql container's chmod-in-init to work
 func (d Client) FlexGroupVolumeDisableSnapshotDirectoryAccess(name string) (*azgo.VolumeModifyIterAsyncResponse, error) {
+	return d.FlexGroupVolumeModifySnapshotDirectoryAccess(name, false)
+}
+
+// FlexGroupVolumeModifySnapshotDirectoryAccess enables or disables access to the ".snapshot" directory
+func (d Client) FlexGroupVolumeModifySnapshotDirectoryAccess(
+	name string, enable bool,
+) (*azgo.VolumeModifyIterAsyncResponse, error) {
 
 	volattr := &azgo.VolumeModifyIterAsyncRequestAttributes{}
-	ssattr := azgo.NewVolumeSnapshotAttributesType().SetSnapdirAccessEnabled(false)
+	ssattr := azgo.NewVolumeSnapshotAttributesType().SetSnapdirAccessEnabled(enable)
 	volSnapshotAttrs := azgo.NewVolumeAttributesType().SetVolumeSnapshotAttributes(*ssattr)
 	volattr.SetVolumeAttributes(*volSnapshotAttrs)
 
@@ -934,7 +1251,39 @@ func (d Client) FlexGroupVolumeDisableSnapshotDirectoryAccess(name string) (*azg
 	response, err := azgo.NewVolumeModifyIterAsyncRequest().
 		SetQuery(*queryattr).
 		SetAttributes(*volattr).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+
+	if zerr := GetError(response, err); zerr != nil {
+		return response, zerr
+	}
+
+	err = d.WaitForAsyncResponse(*response, time.Duration(maxFlexGroupWait))
+	if err != nil {
+		return response, fmt.Errorf("error waiting for response: %v", err)
+	}
+
+	return response, err
+}
+
+// FlexGroupVolumeModifyTieringMinimumCoolingDays sets how many days data must be cold before it is tiered
+func (d Client) FlexGroupVolumeModifyTieringMinimumCoolingDays(
+	name string, days int,
+) (*azgo.VolumeModifyIterAsyncResponse, error) {
+
+	volattr := &azgo.VolumeModifyIterAsyncRequestAttributes{}
+	compAggrAttrs := azgo.NewVolumeCompAggrAttributesType().SetTieringMinimumCoolingDays(days)
+	volCompAggrAttrs := azgo.NewVolumeAttributesType().SetVolumeCompAggrAttributes(*compAggrAttrs)
+	volattr.SetVolumeAttributes(*volCompAggrAttrs)
+
+	queryattr := &azgo.VolumeModifyIterAsyncRequestQuery{}
+	volidattr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(name))
+	volIdAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volidattr)
+	queryattr.SetVolumeAttributes(*volIdAttrs)
+
+	response, err := azgo.NewVolumeModifyIterAsyncRequest().
+		SetQuery(*queryattr).
+		SetAttributes(*volattr).
+		ExecuteUsing(d.zr())
 
 	if zerr := GetError(response, err); zerr != nil {
 		return response, zerr
@@ -964,7 +1313,7 @@ func (d Client) FlexGroupModifyUnixPermissions(volumeName, unixPermissions strin
         response, err := azgo.NewVolumeModifyIterAsyncRequest().
                 SetQuery(*queryAttr).
                 SetAttributes(*volAttr).
-                ExecuteUsing(d.zr)
+                ExecuteUsing(d.zr())
 
         if zerr := GetError(response, err); zerr != nil {
                 return response, zerr
@@ -1089,9 +1438,143 @@ func (d Client) JobGetIterStatus(jobId int) (*azgo.JobGetIterResponse, error) {
 	return response, err
 }
 
+// JobState returns the state and progress of an ONTAP job, or an empty state if ONTAP no longer has a record
+// of the job.
+func (d Client) JobState(jobId int) (state, progress string, err error) {
+	jobResponse, err := d.JobGetIterStatus(jobId)
+	if err = GetError(jobResponse, err); err != nil {
+		return "", "", fmt.Errorf("error getting status of job %d: %v", jobId, err)
+	}
+	if jobResponse.Result.AttributesListPtr == nil || len(jobResponse.Result.AttributesListPtr.JobInfoPtr) == 0 {
+		return "", "", nil
+	}
+	jobInfo := jobResponse.Result.AttributesListPtr.JobInfoPtr[0]
+	if jobInfo.JobStatePtr == nil {
+		return "", "", nil
+	}
+	if jobInfo.JobProgressPtr != nil {
+		progress = jobInfo.JobProgress()
+	}
+	return string(jobInfo.JobState()), progress, nil
+}
+
+// JobGetIterForSVM returns the jobs ONTAP has a record of on the client's SVM, whether queued, running,
+// or recently finished.
+// equivalent to filer::> job show -vserver <svm>
+func (d Client) JobGetIterForSVM() ([]azgo.JobInfoType, error) {
+	jobInfo := azgo.NewJobInfoType().SetJobVserver(d.SVM())
+	query := &azgo.JobGetIterRequestQuery{}
+	query.SetJobInfo(*jobInfo)
+
+	response, err := azgo.NewJobGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]azgo.JobInfoType, 0)
+	if response.Result.AttributesListPtr != nil {
+		jobs = append(jobs, response.Result.AttributesListPtr.JobInfoPtr...)
+	}
+	return jobs, nil
+}
+
 // FlexGroup operations END
 /////////////////////////////////////////////////////////////////////////////
 
+/////////////////////////////////////////////////////////////////////////////
+// FLEXCACHE operations BEGIN
+
+// FlexcacheCreate creates a FlexCache volume of an origin volume, which may be on a peered SVM of
+// another cluster, and mounts it at a junction named for the cache
+// equivalent to filer::> volume flexcache create -vserver svm -volume cache -aggr-list aggr1 -origin-vserver origin_svm -origin-volume origin -size 1g -junction-path /cache
+func (d Client) FlexcacheCreate(
+	name string, size int, aggregate, originVserver, originVolume string,
+) (*azgo.FlexcacheCreateAsyncResponse, error) {
+
+	aggrList := azgo.FlexcacheCreateAsyncRequestAggrList{}
+	aggrList.SetAggrName([]azgo.AggrNameType{aggregate})
+
+	response, err := azgo.NewFlexcacheCreateAsyncRequest().
+		SetVolume(name).
+		SetSize(size).
+		SetAggrList(aggrList).
+		SetOriginVserver(originVserver).
+		SetOriginVolume(originVolume).
+		SetJunctionPath("/" + name).
+		ExecuteUsing(d.zr())
+	if zerr := GetError(response, err); zerr != nil {
+		return response, zerr
+	}
+
+	err = d.WaitForAsyncResponse(*response, maxFlexGroupWait)
+	if err != nil {
+		return response, fmt.Errorf("error waiting for response: %v", err)
+	}
+
+	return response, err
+}
+
+// FlexcacheGet returns all relevant details for a single FlexCache volume
+func (d Client) FlexcacheGet(name string) (*azgo.VolumeAttributesType, error) {
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().
+		SetName(azgo.VolumeNameType(name)).
+		SetFlexcacheEndpointType("cache")
+	return d.volumeGetIterCommon(name, queryVolIDAttrs)
+}
+
+// FlexcacheExists tests whether a volume is a FlexCache volume
+func (d Client) FlexcacheExists(name string) (bool, error) {
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().
+		SetName(azgo.VolumeNameType(name)).
+		SetFlexcacheEndpointType("cache")
+
+	query := &azgo.VolumeGetIterRequestQuery{}
+	volAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*queryVolIDAttrs)
+	query.SetVolumeAttributes(*volAttrs)
+
+	response, err := azgo.NewVolumeGetIterRequest().
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	if err = GetError(response, err); err != nil {
+		return false, err
+	}
+
+	return response.Result.NumRecords() > 0, nil
+}
+
+// FlexcacheDestroy destroys a FlexCache volume, leaving its origin volume untouched
+func (d Client) FlexcacheDestroy(name string) (*azgo.FlexcacheDestroyAsyncResponse, error) {
+	response, err := azgo.NewFlexcacheDestroyAsyncRequest().
+		SetVolume(name).
+		ExecuteUsing(d.zr())
+
+	if zerr := NewZapiError(response); !zerr.IsPassed() {
+		// It's not an error if the volume no longer exists
+		if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
+			log.WithField("volume", name).Warn("FlexCache already deleted.")
+			return response, nil
+		}
+	}
+
+	if gerr := GetError(response, err); gerr != nil {
+		return response, gerr
+	}
+
+	err = d.WaitForAsyncResponse(*response, maxFlexGroupWait)
+	if err != nil {
+		return response, fmt.Errorf("error waiting for response: %v", err)
+	}
+
+	return response, err
+}
+
+// FLEXCACHE operations END
+/////////////////////////////////////////////////////////////////////////////
+
 /////////////////////////////////////////////////////////////////////////////
 // VOLUME operations BEGIN
 
@@ -1099,7 +1582,7 @@ func (d Client) JobGetIterStatus(jobId int) (*azgo.JobGetIterResponse, error) {
 // equivalent to filer::> volume create -vserver iscsi_vs -volume v -aggregate aggr1 -size 1g -state online -type RW -policy default -unix-permissions ---rwxr-xr-x -space-guarantee none -snapshot-policy none -security-style unix -encrypt false
 func (d Client) VolumeCreate(
 	name, aggregateName, size, spaceReserve, snapshotPolicy, unixPermissions,
-	exportPolicy, securityStyle, tieringPolicy string, encrypt bool, snapshotReserve int,
+	exportPolicy, securityStyle, tieringPolicy, comment string, encrypt bool, snapshotReserve int,
 ) (*azgo.VolumeCreateResponse, error) {
 	request := azgo.NewVolumeCreateRequest().
 		SetVolume(name).
@@ -1116,6 +1599,10 @@ func (d Client) VolumeCreate(
 		request.SetPercentageSnapshotReserve(snapshotReserve)
 	}
 
+	if comment != "" {
+		request.SetVolumeComment(comment)
+	}
+
 	// Allowed ONTAP tiering Policy values
 	//
 	// =================================================================================
@@ -1143,7 +1630,31 @@ func (d Client) VolumeCreate(
 		request.SetTieringPolicy(tieringPolicy)
 	}
 
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeCreateDataProtection creates a data protection Flexvol, to be the destination of a snapmirror relationship
+// equivalent to filer::> volume create -vserver svm_name -volume vol_name -aggregate aggr1 -size 1g -type DP
+func (d Client) VolumeCreateDataProtection(
+	name, aggregateName, size, spaceReserve, exportPolicy, comment string,
+) (*azgo.VolumeCreateResponse, error) {
+	request := azgo.NewVolumeCreateRequest().
+		SetVolume(name).
+		SetContainingAggrName(aggregateName).
+		SetSize(size).
+		SetSpaceReserve(spaceReserve).
+		SetVolumeType("dp")
+
+	if exportPolicy != "" {
+		request.SetExportPolicy(exportPolicy)
+	}
+
+	if comment != "" {
+		request.SetVolumeComment(comment)
+	}
+
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1161,7 +1672,7 @@ func (d Client) VolumeModifyExportPolicy(volumeName, exportPolicyName string) (*
 	response, err := azgo.NewVolumeModifyIterRequest().
 		SetQuery(*queryAttr).
 		SetAttributes(*volAttr).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1180,17 +1691,74 @@ func (d Client) VolumeModifyUnixPermissions(volumeName, unixPermissions string)
         response, err := azgo.NewVolumeModifyIterRequest().
                 SetQuery(*queryAttr).
                 SetAttributes(*volAttr).
-                ExecuteUsing(d.zr)
+                ExecuteUsing(d.zr())
         return response, err
 }
 
+// VolumeModifySecurityStyle sets the security style of a Flexvol
+func (d Client) VolumeModifySecurityStyle(volumeName, securityStyle string) (*azgo.VolumeModifyIterResponse, error) {
+	volAttr := &azgo.VolumeModifyIterRequestAttributes{}
+	volSecurityAttrs := azgo.NewVolumeSecurityAttributesType().SetStyle(securityStyle)
+	securityAttributes := azgo.NewVolumeAttributesType().SetVolumeSecurityAttributes(*volSecurityAttrs)
+	volAttr.SetVolumeAttributes(*securityAttributes)
+
+	queryAttr := &azgo.VolumeModifyIterRequestQuery{}
+	volIDAttr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(volumeName))
+	volIDAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volIDAttr)
+	queryAttr.SetVolumeAttributes(*volIDAttrs)
+
+	response, err := azgo.NewVolumeModifyIterRequest().
+		SetQuery(*queryAttr).
+		SetAttributes(*volAttr).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeModifySnapshotPolicy sets the snapshot policy of a Flexvol
+func (d Client) VolumeModifySnapshotPolicy(volumeName, snapshotPolicy string) (*azgo.VolumeModifyIterResponse, error) {
+	volAttr := &azgo.VolumeModifyIterRequestAttributes{}
+	volSnapshotAttrs := azgo.NewVolumeSnapshotAttributesType().SetSnapshotPolicy(snapshotPolicy)
+	snapshotAttributes := azgo.NewVolumeAttributesType().SetVolumeSnapshotAttributes(*volSnapshotAttrs)
+	volAttr.SetVolumeAttributes(*snapshotAttributes)
+
+	queryAttr := &azgo.VolumeModifyIterRequestQuery{}
+	volIDAttr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(volumeName))
+	volIDAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volIDAttr)
+	queryAttr.SetVolumeAttributes(*volIDAttrs)
+
+	response, err := azgo.NewVolumeModifyIterRequest().
+		SetQuery(*queryAttr).
+		SetAttributes(*volAttr).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeSetComment sets the comment for a given Flexvol or FlexGroup.
+func (d Client) VolumeSetComment(volumeName, comment string) (*azgo.VolumeModifyIterResponse, error) {
+	volAttr := &azgo.VolumeModifyIterRequestAttributes{}
+	idAttr := azgo.NewVolumeIdAttributesType().SetComment(comment)
+	volIDCommentAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*idAttr)
+	volAttr.SetVolumeAttributes(*volIDCommentAttrs)
+
+	queryAttr := &azgo.VolumeModifyIterRequestQuery{}
+	volIDAttr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(volumeName))
+	volIDAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volIDAttr)
+	queryAttr.SetVolumeAttributes(*volIDAttrs)
+
+	response, err := azgo.NewVolumeModifyIterRequest().
+		SetQuery(*queryAttr).
+		SetAttributes(*volAttr).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
 // VolumeCloneCreate clones a volume from a snapshot
 func (d Client) VolumeCloneCreate(name, source, snapshot string) (*azgo.VolumeCloneCreateResponse, error) {
 	response, err := azgo.NewVolumeCloneCreateRequest().
 		SetVolume(name).
 		SetParentVolume(source).
 		SetParentSnapshot(snapshot).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1200,7 +1768,7 @@ func (d Client) VolumeCloneCreateAsync(name, source, snapshot string) (*azgo.Vol
 		SetVolume(name).
 		SetParentVolume(source).
 		SetParentSnapshot(snapshot).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1208,15 +1776,67 @@ func (d Client) VolumeCloneCreateAsync(name, source, snapshot string) (*azgo.Vol
 func (d Client) VolumeCloneSplitStart(name string) (*azgo.VolumeCloneSplitStartResponse, error) {
 	response, err := azgo.NewVolumeCloneSplitStartRequest().
 		SetVolume(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
+// VolumeCloneSplitStatus returns the progress of a clone split operation
+func (d Client) VolumeCloneSplitStatus(name string) (*azgo.VolumeCloneSplitStatusResponse, error) {
+	response, err := azgo.NewVolumeCloneSplitStatusRequest().
+		SetVolume(name).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeMoveStart begins moving a Flexvol to another aggregate in the same cluster.  Moves are
+// cluster-level operations, so this call is not tunneled through the SVM.
+func (d Client) VolumeMoveStart(name, destinationAggregate string) (*azgo.VolumeMoveStartResponse, error) {
+	response, err := azgo.NewVolumeMoveStartRequest().
+		SetSourceVolume(name).
+		SetVserver(d.config().SVM).
+		SetDestAggr(destinationAggregate).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	return response, err
+}
+
+// VolumeMoveGet returns the progress of the most recent move of a Flexvol
+func (d Client) VolumeMoveGet(name string) (*azgo.VolumeMoveInfoType, error) {
+	response, err := azgo.NewVolumeMoveGetRequest().
+		SetSourceVolume(name).
+		SetVserver(d.config().SVM).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+	if response.Result.AttributesPtr == nil || response.Result.AttributesPtr.VolumeMoveInfoPtr == nil {
+		return nil, fmt.Errorf("no move found for volume %s", name)
+	}
+	return response.Result.AttributesPtr.VolumeMoveInfoPtr, nil
+}
+
+// VolumeIsClone returns true if a FlexVol or FlexGroup is still a clone of a parent volume
+func (d Client) VolumeIsClone(name string) (bool, error) {
+
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(name))
+	volAttrs, err := d.volumeGetIterCommon(name, queryVolIDAttrs)
+	if err != nil {
+		return false, err
+	}
+
+	cloneAttrs := volAttrs.VolumeCloneAttributesPtr
+	return cloneAttrs != nil && cloneAttrs.VolumeCloneParentAttributesPtr != nil, nil
+}
+
 // VolumeDisableSnapshotDirectoryAccess disables access to the ".snapshot" directory
 // Disable '.snapshot' to allow official mysql container's chmod-in-init to work
 func (d Client) VolumeDisableSnapshotDirectoryAccess(name string) (*azgo.VolumeModifyIterResponse, error) {
+	return d.VolumeModifySnapshotDirectoryAccess(name, false)
+}
+
+// VolumeModifySnapshotDirectoryAccess enables or disables access to the ".snapshot" directory
+func (d Client) VolumeModifySnapshotDirectoryAccess(name string, enable bool) (*azgo.VolumeModifyIterResponse, error) {
 	volattr := &azgo.VolumeModifyIterRequestAttributes{}
-	ssattr := azgo.NewVolumeSnapshotAttributesType().SetSnapdirAccessEnabled(false)
+	ssattr := azgo.NewVolumeSnapshotAttributesType().SetSnapdirAccessEnabled(enable)
 	volSnapshotAttrs := azgo.NewVolumeAttributesType().SetVolumeSnapshotAttributes(*ssattr)
 	volattr.SetVolumeAttributes(*volSnapshotAttrs)
 
@@ -1228,7 +1848,26 @@ func (d Client) VolumeDisableSnapshotDirectoryAccess(name string) (*azgo.VolumeM
 	response, err := azgo.NewVolumeModifyIterRequest().
 		SetQuery(*queryattr).
 		SetAttributes(*volattr).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeModifyTieringMinimumCoolingDays sets how many days data must be cold before it is tiered
+func (d Client) VolumeModifyTieringMinimumCoolingDays(name string, days int) (*azgo.VolumeModifyIterResponse, error) {
+	volattr := &azgo.VolumeModifyIterRequestAttributes{}
+	compAggrAttrs := azgo.NewVolumeCompAggrAttributesType().SetTieringMinimumCoolingDays(days)
+	volCompAggrAttrs := azgo.NewVolumeAttributesType().SetVolumeCompAggrAttributes(*compAggrAttrs)
+	volattr.SetVolumeAttributes(*volCompAggrAttrs)
+
+	queryattr := &azgo.VolumeModifyIterRequestQuery{}
+	volidattr := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(name))
+	volIdAttrs := azgo.NewVolumeAttributesType().SetVolumeIdAttributes(*volidattr)
+	queryattr.SetVolumeAttributes(*volIdAttrs)
+
+	response, err := azgo.NewVolumeModifyIterRequest().
+		SetQuery(*queryattr).
+		SetAttributes(*volattr).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1236,7 +1875,7 @@ func (d Client) VolumeDisableSnapshotDirectoryAccess(name string) (*azgo.VolumeM
 func (d Client) VolumeExists(name string) (bool, error) {
 	response, err := azgo.NewVolumeSizeRequest().
 		SetVolume(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return false, err
@@ -1266,12 +1905,44 @@ func (d Client) VolumeSize(name string) (int, error) {
 	return volSpaceAttrs.Size(), nil
 }
 
+// VolumeSpaceGet returns the space attributes of the FlexVol or FlexGroup with the supplied name
+func (d Client) VolumeSpaceGet(name string) (*azgo.VolumeSpaceAttributesType, error) {
+
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().SetName(azgo.VolumeNameType(name))
+	volAttrs, err := d.volumeGetIterCommon(name, queryVolIDAttrs)
+	if err != nil {
+		return nil, err
+	}
+	if volAttrs.VolumeSpaceAttributesPtr == nil {
+		return nil, fmt.Errorf("no space attributes returned for volume %s", name)
+	}
+	return volAttrs.VolumeSpaceAttributesPtr, nil
+}
+
 // VolumeSetSize sets the size of the specified volume
 func (d Client) VolumeSetSize(name, newSize string) (*azgo.VolumeSizeResponse, error) {
 	response, err := azgo.NewVolumeSizeRequest().
 		SetVolume(name).
-		SetNewSize(newSize).
-		ExecuteUsing(d.zr)
+		SetNewSize(newSize).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// VolumeSetAutosize sets the autosize mode of a Flexvol.  A zero maximum size or grow
+// threshold leaves the corresponding ONTAP setting unchanged.
+func (d Client) VolumeSetAutosize(
+	name, mode string, maximumSizeBytes, growThresholdPercent int,
+) (*azgo.VolumeAutosizeSetResponse, error) {
+	request := azgo.NewVolumeAutosizeSetRequest().
+		SetVolume(name).
+		SetMode(mode)
+	if maximumSizeBytes > 0 {
+		request.SetMaximumSize(maximumSizeBytes)
+	}
+	if growThresholdPercent > 0 {
+		request.SetGrowThresholdPercent(growThresholdPercent)
+	}
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1280,7 +1951,7 @@ func (d Client) VolumeMount(name, junctionPath string) (*azgo.VolumeMountRespons
 	response, err := azgo.NewVolumeMountRequest().
 		SetVolumeName(name).
 		SetJunctionPath(junctionPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1289,7 +1960,7 @@ func (d Client) VolumeUnmount(name string, force bool) (*azgo.VolumeUnmountRespo
 	response, err := azgo.NewVolumeUnmountRequest().
 		SetVolumeName(name).
 		SetForce(force).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1297,7 +1968,7 @@ func (d Client) VolumeUnmount(name string, force bool) (*azgo.VolumeUnmountRespo
 func (d Client) VolumeOffline(name string) (*azgo.VolumeOfflineResponse, error) {
 	response, err := azgo.NewVolumeOfflineRequest().
 		SetName(name).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1306,7 +1977,7 @@ func (d Client) VolumeDestroy(name string, force bool) (*azgo.VolumeDestroyRespo
 	response, err := azgo.NewVolumeDestroyRequest().
 		SetName(name).
 		SetUnmountAndOffline(force).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1333,9 +2004,9 @@ func (d Client) volumeGetIterCommon(name string,
 	query.SetVolumeAttributes(*volAttrs)
 
 	response, err := azgo.NewVolumeGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return &azgo.VolumeAttributesType{}, err
@@ -1374,6 +2045,20 @@ func (d Client) volumeGetIterAll(prefix string, queryVolIDAttrs *azgo.VolumeIdAt
 	query.SetVolumeAttributes(*volumeAttributes)
 
 	// Limit the returned data to only the data relevant to containers
+	desiredAttributes := &azgo.VolumeGetIterRequestDesiredAttributes{}
+	desiredAttributes.SetVolumeAttributes(*volumeDesiredAttributes())
+
+	response, err := azgo.NewVolumeGetIterRequest().
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
+		SetQuery(*query).
+		SetDesiredAttributes(*desiredAttributes).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// volumeDesiredAttributes returns the Flexvol attributes relevant to containers
+func volumeDesiredAttributes() *azgo.VolumeAttributesType {
+
 	desiredVolExportAttrs := azgo.NewVolumeExportAttributesType().
 		SetPolicy("")
 	desiredVolIDAttrs := azgo.NewVolumeIdAttributesType().
@@ -1385,26 +2070,58 @@ func (d Client) volumeGetIterAll(prefix string, queryVolIDAttrs *azgo.VolumeIdAt
 		SetVolumeSecurityUnixAttributes(*desiredVolSecurityUnixAttrs)
 	desiredVolSpaceAttrs := azgo.NewVolumeSpaceAttributesType().
 		SetSize(0).
+		SetPercentageSnapshotReserve(0).
 		SetSpaceGuarantee("")
 	desiredVolSnapshotAttrs := azgo.NewVolumeSnapshotAttributesType().
 		SetSnapdirAccessEnabled(true).
 		SetSnapshotPolicy("")
 
-	desiredAttributes := &azgo.VolumeGetIterRequestDesiredAttributes{}
-	desiredVolumeAttributes := azgo.NewVolumeAttributesType().
+	return azgo.NewVolumeAttributesType().
 		SetVolumeExportAttributes(*desiredVolExportAttrs).
 		SetVolumeIdAttributes(*desiredVolIDAttrs).
 		SetVolumeSecurityAttributes(*desiredVolSecurityAttrs).
 		SetVolumeSpaceAttributes(*desiredVolSpaceAttrs).
 		SetVolumeSnapshotAttributes(*desiredVolSnapshotAttrs)
+}
+
+// VolumeListPage returns up to maxRecords Flexvols whose names match the supplied prefix, whatever their
+// state, starting from the supplied tag, or from the first Flexvol if the tag is empty.  The response's
+// next tag continues the listing, and is absent after the last page.
+func (d Client) VolumeListPage(prefix string, maxRecords int, tag string) (*azgo.VolumeGetIterResponse, error) {
+
+	// Limit the Flexvols to those matching the name prefix
+	query := &azgo.VolumeGetIterRequestQuery{}
+	queryVolIDAttrs := azgo.NewVolumeIdAttributesType().
+		SetName(azgo.VolumeNameType(prefix + "*")).
+		SetStyleExtended("flexvol")
+	volumeAttributes := azgo.NewVolumeAttributesType().
+		SetVolumeIdAttributes(*queryVolIDAttrs)
+	query.SetVolumeAttributes(*volumeAttributes)
+
+	// Return the data relevant to containers, along with the Flexvol comments, types, junction paths,
+	// and states
+	desiredVolumeAttributes := volumeDesiredAttributes()
+	desiredVolumeAttributes.VolumeIdAttributesPtr.SetComment("").SetType("").SetJunctionPath("")
+	desiredVolumeAttributes.SetVolumeStateAttributes(*azgo.NewVolumeStateAttributesType().
+		SetState("").
+		SetIsVserverRoot(false))
+	desiredAttributes := &azgo.VolumeGetIterRequestDesiredAttributes{}
 	desiredAttributes.SetVolumeAttributes(*desiredVolumeAttributes)
 
-	response, err := azgo.NewVolumeGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+	request := azgo.NewVolumeGetIterRequest().
+		SetMaxRecords(maxRecords).
 		SetQuery(*query).
-		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
-	return response, err
+		SetDesiredAttributes(*desiredAttributes)
+	if tag != "" {
+		request.SetTag(tag)
+	}
+
+	// Only this page is wanted, so don't follow the next tag
+	result, err := d.zr().ExecuteWithoutIteration(request, "VolumeGetIterRequest", azgo.NewVolumeGetIterResponse())
+	if result == nil {
+		return nil, err
+	}
+	return result.(*azgo.VolumeGetIterResponse), err
 }
 
 // VolumeList returns the names of all Flexvols whose names match the supplied prefix
@@ -1428,10 +2145,10 @@ func (d Client) VolumeList(prefix string) (*azgo.VolumeGetIterResponse, error) {
 	desiredAttributes.SetVolumeAttributes(*desiredVolumeAttributes)
 
 	response, err := azgo.NewVolumeGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1471,10 +2188,10 @@ func (d Client) VolumeListByAttrs(
 	desiredAttributes.SetVolumeAttributes(*desiredVolumeAttributes)
 
 	response, err := azgo.NewVolumeGetIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1502,7 +2219,7 @@ func (d Client) VolumeListAllBackedBySnapshot(volumeName, snapshotName string) (
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err = GetError(response, err); err != nil {
 		return nil, fmt.Errorf("error enumerating volumes backed by snapshot: %v", err)
@@ -1525,7 +2242,7 @@ func (d Client) VolumeRename(volumeName, newVolumeName string) (*azgo.VolumeRena
 	response, err := azgo.NewVolumeRenameRequest().
 		SetVolume(volumeName).
 		SetNewVolumeName(newVolumeName).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1545,7 +2262,7 @@ func (d Client) QtreeCreate(name, volumeName, unixPermissions, exportPolicy,
 		SetMode(unixPermissions).
 		SetSecurityStyle(securityStyle).
 		SetExportPolicy(exportPolicy).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1555,7 +2272,7 @@ func (d Client) QtreeRename(path, newPath string) (*azgo.QtreeRenameResponse, er
 	response, err := azgo.NewQtreeRenameRequest().
 		SetQtree(path).
 		SetNewQtreeName(newPath).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1565,7 +2282,7 @@ func (d Client) QtreeDestroyAsync(path string, force bool) (*azgo.QtreeDeleteAsy
 	response, err := azgo.NewQtreeDeleteAsyncRequest().
 		SetQtree(path).
 		SetForce(force).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1584,10 +2301,10 @@ func (d Client) QtreeList(prefix, volumePrefix string) (*azgo.QtreeListIterRespo
 	desiredAttributes.SetQtreeInfo(*desiredInfo)
 
 	response, err := azgo.NewQtreeListIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1605,10 +2322,10 @@ func (d Client) QtreeCount(volume string) (int, error) {
 	desiredAttributes.SetQtreeInfo(*desiredInfo)
 
 	response, err := azgo.NewQtreeListIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err = GetError(response, err); err != nil {
 		return 0, err
@@ -1639,10 +2356,10 @@ func (d Client) QtreeExists(name, volumePrefix string) (bool, string, error) {
 	desiredAttributes.SetQtreeInfo(*desiredInfo)
 
 	response, err := azgo.NewQtreeListIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	// Ensure the API call succeeded
 	if err = GetError(response, err); err != nil {
@@ -1674,9 +2391,9 @@ func (d Client) QtreeGet(name, volumePrefix string) (*azgo.QtreeInfoType, error)
 	query.SetQtreeInfo(*info)
 
 	response, err := azgo.NewQtreeListIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return &azgo.QtreeInfoType{}, err
@@ -1712,10 +2429,10 @@ func (d Client) QtreeGetAll(volumePrefix string) (*azgo.QtreeListIterResponse, e
 	desiredAttributes.SetQtreeInfo(*desiredInfo)
 
 	response, err := azgo.NewQtreeListIterRequest().
-		SetMaxRecords(d.config.ContextBasedZapiRecords).
+		SetMaxRecords(d.config().ContextBasedZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1725,7 +2442,7 @@ func (d Client) QtreeModifyExportPolicy(name, volumeName, exportPolicy string) (
 		SetQtree(name).
 		SetVolume(volumeName).
 		SetExportPolicy(exportPolicy).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 }
 
 // QuotaOn enables quotas on a Flexvol
@@ -1733,7 +2450,7 @@ func (d Client) QtreeModifyExportPolicy(name, volumeName, exportPolicy string) (
 func (d Client) QuotaOn(volume string) (*azgo.QuotaOnResponse, error) {
 	response, err := azgo.NewQuotaOnRequest().
 		SetVolume(volume).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1742,7 +2459,7 @@ func (d Client) QuotaOn(volume string) (*azgo.QuotaOnResponse, error) {
 func (d Client) QuotaOff(volume string) (*azgo.QuotaOffResponse, error) {
 	response, err := azgo.NewQuotaOffRequest().
 		SetVolume(volume).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1751,7 +2468,7 @@ func (d Client) QuotaOff(volume string) (*azgo.QuotaOffResponse, error) {
 func (d Client) QuotaResize(volume string) (*azgo.QuotaResizeResponse, error) {
 	response, err := azgo.NewQuotaResizeRequest().
 		SetVolume(volume).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1760,13 +2477,15 @@ func (d Client) QuotaResize(volume string) (*azgo.QuotaResizeResponse, error) {
 func (d Client) QuotaStatus(volume string) (*azgo.QuotaStatusResponse, error) {
 	response, err := azgo.NewQuotaStatusRequest().
 		SetVolume(volume).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
-// QuotaSetEntry creates a new quota rule with an optional hard disk limit
+// QuotaSetEntry creates a new quota rule with optional hard disk and file limits
 // equivalent to filer::> volume quota policy rule create
-func (d Client) QuotaSetEntry(qtreeName, volumeName, quotaTarget, quotaType, diskLimit string) (*azgo.QuotaSetEntryResponse, error) {
+func (d Client) QuotaSetEntry(
+	qtreeName, volumeName, quotaTarget, quotaType, diskLimit, fileLimit string,
+) (*azgo.QuotaSetEntryResponse, error) {
 
 	request := azgo.NewQuotaSetEntryRequest().
 		SetQtree(qtreeName).
@@ -1778,8 +2497,11 @@ func (d Client) QuotaSetEntry(qtreeName, volumeName, quotaTarget, quotaType, dis
 	if diskLimit != "" {
 		request.SetDiskLimit(diskLimit)
 	}
+	if fileLimit != "" {
+		request.SetFileLimit(fileLimit)
+	}
 
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1800,7 +2522,7 @@ func (d Client) QuotaGetEntry(target string) (*azgo.QuotaEntryType, error) {
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return &azgo.QuotaEntryType{}, err
@@ -1832,7 +2554,34 @@ func (d Client) QuotaEntryList(volume string) (*azgo.QuotaListEntriesIterRespons
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
 		SetDesiredAttributes(*desiredAttributes).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// QuotaReport returns the space and files used by each qtree, and the limits enforced on them, in the
+// Flexvols matching the supplied name pattern
+// equivalent to filer::> volume quota report
+func (d Client) QuotaReport(volume string) (*azgo.QuotaReportIterResponse, error) {
+	query := &azgo.QuotaReportIterRequestQuery{}
+	quota := azgo.NewQuotaType().SetVolume(volume).SetQuotaType("tree")
+	query.SetQuota(*quota)
+
+	// Limit the returned data to the usage and limits
+	desiredAttributes := &azgo.QuotaReportIterRequestDesiredAttributes{}
+	desiredQuotaFields := azgo.NewQuotaType().
+		SetVolume("").
+		SetTree("").
+		SetDiskUsed("").
+		SetDiskLimit("").
+		SetFilesUsed("").
+		SetFileLimit("")
+	desiredAttributes.SetQuota(*desiredQuotaFields)
+
+	response, err := azgo.NewQuotaReportIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		SetDesiredAttributes(*desiredAttributes).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1847,20 +2596,34 @@ func (d Client) QuotaEntryList(volume string) (*azgo.QuotaListEntriesIterRespons
 func (d Client) ExportPolicyCreate(policy string) (*azgo.ExportPolicyCreateResponse, error) {
 	response, err := azgo.NewExportPolicyCreateRequest().
 		SetPolicyName(policy).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
 func (d Client) ExportPolicyGet(policy string) (*azgo.ExportPolicyGetResponse, error) {
 	return azgo.NewExportPolicyGetRequest().
 		SetPolicyName(policy).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 }
 
 func (d Client) ExportPolicyDestroy(policy string) (*azgo.ExportPolicyDestroyResponse, error) {
 	return azgo.NewExportPolicyDestroyRequest().
 		SetPolicyName(policy).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+}
+
+// ExportPolicyList returns the export policies whose names match the supplied prefix
+// equivalent to filer::> vserver export-policy show -policyname trident-*
+func (d Client) ExportPolicyList(prefix string) (*azgo.ExportPolicyGetIterResponse, error) {
+	query := &azgo.ExportPolicyGetIterRequestQuery{}
+	exportPolicyInfo := azgo.NewExportPolicyInfoType().SetPolicyName(azgo.ExportPolicyNameType(prefix + "*"))
+	query.SetExportPolicyInfo(*exportPolicyInfo)
+
+	response, err := azgo.NewExportPolicyGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	return response, err
 }
 
 // ExportRuleCreate creates a rule in an export policy
@@ -1869,6 +2632,18 @@ func (d Client) ExportRuleCreate(
 	policy, clientMatch string,
 	protocols, roSecFlavors, rwSecFlavors, suSecFlavors []string,
 ) (*azgo.ExportRuleCreateResponse, error) {
+	return d.ExportRuleCreateWithOptions(policy, clientMatch, 0, "", protocols, roSecFlavors, rwSecFlavors,
+		suSecFlavors)
+}
+
+// ExportRuleCreateWithOptions creates a rule in an export policy at the given rule index, mapping anonymous
+// users to the given UID.  ONTAP appends the rule to the policy if the index is zero, and uses its default
+// anonymous UID if none is given.
+// equivalent to filer::> vserver export-policy rule create -ruleindex 10 -anon 65534
+func (d Client) ExportRuleCreateWithOptions(
+	policy, clientMatch string, ruleIndex int, anonymousUserID string,
+	protocols, roSecFlavors, rwSecFlavors, suSecFlavors []string,
+) (*azgo.ExportRuleCreateResponse, error) {
 
 	protocolTypes := &azgo.ExportRuleCreateRequestProtocol{}
 	var protocolTypesToUse []azgo.AccessProtocolType
@@ -1898,14 +2673,20 @@ func (d Client) ExportRuleCreate(
 	}
 	suSecFlavorTypes.SecurityFlavorPtr = suSecFlavorTypesToUse
 
-	response, err := azgo.NewExportRuleCreateRequest().
+	request := azgo.NewExportRuleCreateRequest().
 		SetPolicyName(azgo.ExportPolicyNameType(policy)).
 		SetClientMatch(clientMatch).
 		SetProtocol(*protocolTypes).
 		SetRoRule(*roSecFlavorTypes).
 		SetRwRule(*rwSecFlavorTypes).
-		SetSuperUserSecurity(*suSecFlavorTypes).
-		ExecuteUsing(d.zr)
+		SetSuperUserSecurity(*suSecFlavorTypes)
+	if ruleIndex > 0 {
+		request.SetRuleIndex(ruleIndex)
+	}
+	if anonymousUserID != "" {
+		request.SetAnonymousUserId(anonymousUserID)
+	}
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1921,7 +2702,7 @@ func (d Client) ExportRuleGetIterRequest(policy string) (*azgo.ExportRuleGetIter
 	response, err := azgo.NewExportRuleGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1930,7 +2711,7 @@ func (d Client) ExportRuleDestroy(policy string, ruleIndex int) (*azgo.ExportRul
 	response, err := azgo.NewExportRuleDestroyRequest().
 		SetPolicyName(policy).
 		SetRuleIndex(ruleIndex).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1945,7 +2726,63 @@ func (d Client) SnapshotCreate(snapshotName, volumeName string) (*azgo.SnapshotC
 	response, err := azgo.NewSnapshotCreateRequest().
 		SetSnapshot(snapshotName).
 		SetVolume(volumeName).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapshotCreateWithComment creates a snapshot of a volume, marked with a comment so that it may be found later
+func (d Client) SnapshotCreateWithComment(
+	snapshotName, volumeName, comment string,
+) (*azgo.SnapshotCreateResponse, error) {
+	request := azgo.NewSnapshotCreateRequest().
+		SetSnapshot(snapshotName).
+		SetVolume(volumeName)
+	if comment != "" {
+		request.SetComment(comment)
+	}
+	response, err := request.ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapshotCreateWithAttributes creates a snapshot of a volume with an optional comment and SnapMirror label,
+// the latter selecting the SnapMirror policy rules that replicate and retain the snapshot
+func (d Client) SnapshotCreateWithAttributes(
+	snapshotName, volumeName, comment, snapmirrorLabel string,
+) (*azgo.SnapshotCreateResponse, error) {
+	request := azgo.NewSnapshotCreateRequest().
+		SetSnapshot(snapshotName).
+		SetVolume(volumeName)
+	if comment != "" {
+		request.SetComment(comment)
+	}
+	if snapmirrorLabel != "" {
+		request.SetSnapmirrorLabel(snapmirrorLabel)
+	}
+	response, err := request.ExecuteUsing(d.zr())
+	return response, err
+}
+
+// ConsistencyGroupStart fences I/O to a set of volumes and begins a snapshot of each of them.  The
+// snapshots must be committed with ConsistencyGroupCommit before the timeout expires.
+func (d Client) ConsistencyGroupStart(
+	snapshotName string, volumeNames []string, timeout string,
+) (*azgo.CgStartResponse, error) {
+	volumes := azgo.CgStartRequestVolumes{}
+	volumes.SetVolumeName(volumeNames)
+
+	response, err := azgo.NewCgStartRequest().
+		SetSnapshot(snapshotName).
+		SetVolumes(volumes).
+		SetTimeout(timeout).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// ConsistencyGroupCommit completes the snapshots begun by ConsistencyGroupStart and releases the fence
+func (d Client) ConsistencyGroupCommit(cgID int) (*azgo.CgCommitResponse, error) {
+	response, err := azgo.NewCgCommitRequest().
+		SetCgId(cgID).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1958,7 +2795,33 @@ func (d Client) SnapshotList(volumeName string) (*azgo.SnapshotGetIterResponse,
 	response, err := azgo.NewSnapshotGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapshotListForVolumes returns the snapshots of every volume on the SVM whose name matches the supplied prefix
+func (d Client) SnapshotListForVolumes(prefix string) (*azgo.SnapshotGetIterResponse, error) {
+	query := &azgo.SnapshotGetIterRequestQuery{}
+	snapshotInfo := azgo.NewSnapshotInfoType().SetVolume(prefix + "*")
+	query.SetSnapshotInfo(*snapshotInfo)
+
+	response, err := azgo.NewSnapshotGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapshotListByComment returns the snapshots of every volume on the SVM that are marked with a comment
+func (d Client) SnapshotListByComment(comment string) (*azgo.SnapshotGetIterResponse, error) {
+	query := &azgo.SnapshotGetIterRequestQuery{}
+	snapshotInfo := azgo.NewSnapshotInfoType().SetComment(comment)
+	query.SetSnapshotInfo(*snapshotInfo)
+
+	response, err := azgo.NewSnapshotGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1968,7 +2831,7 @@ func (d Client) SnapshotRestoreVolume(snapshotName, volumeName string) (*azgo.Sn
 		SetVolume(volumeName).
 		SetSnapshot(snapshotName).
 		SetPreserveLunIds(true).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -1978,10 +2841,104 @@ func (d Client) SnapshotDelete(snapshotName, volumeName string) (*azgo.SnapshotD
 		SetVolume(volumeName).
 		SetSnapshot(snapshotName).
 		SetIgnoreOwners(true).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
+// SnapshotPolicyGet returns the snapshot policy with the supplied name, or nil if there is none
+// equivalent to filer::> volume snapshot policy show -policy <policy> -instance
+func (d Client) SnapshotPolicyGet(policy string) (*azgo.SnapshotPolicyInfoType, error) {
+	query := &azgo.SnapshotPolicyGetIterRequestQuery{}
+	query.SetSnapshotPolicyInfo(*azgo.NewSnapshotPolicyInfoType().SetPolicy(policy))
+
+	response, err := azgo.NewSnapshotPolicyGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+	if response.Result.AttributesListPtr == nil {
+		return nil, nil
+	}
+	for _, info := range response.Result.AttributesListPtr.SnapshotPolicyInfoPtr {
+		if info.PolicyPtr != nil && info.Policy() == policy {
+			return &info, nil
+		}
+	}
+	return nil, nil
+}
+
+// SnapshotPolicyCreate creates a snapshot policy with its first schedule, which keeps count snapshots
+// named with the prefix, or with the schedule name if the prefix is empty
+// equivalent to filer::> volume snapshot policy create
+func (d Client) SnapshotPolicyCreate(
+	policy string, enabled bool, comment, schedule string, count int, prefix string,
+) (*azgo.SnapshotPolicyCreateResponse, error) {
+	request := azgo.NewSnapshotPolicyCreateRequest().
+		SetPolicy(policy).
+		SetEnabled(enabled).
+		SetSchedule1(schedule).
+		SetCount1(count)
+	if comment != "" {
+		request.SetComment(comment)
+	}
+	if prefix != "" {
+		request.SetPrefix1(prefix)
+	}
+	return request.ExecuteUsing(d.zr())
+}
+
+// SnapshotPolicyModify enables or disables a snapshot policy and sets its comment
+// equivalent to filer::> volume snapshot policy modify
+func (d Client) SnapshotPolicyModify(
+	policy string, enabled bool, comment string,
+) (*azgo.SnapshotPolicyModifyResponse, error) {
+	return azgo.NewSnapshotPolicyModifyRequest().
+		SetPolicy(policy).
+		SetEnabled(enabled).
+		SetComment(comment).
+		ExecuteUsing(d.zr())
+}
+
+// SnapshotPolicyAddSchedule adds a schedule to a snapshot policy
+// equivalent to filer::> volume snapshot policy add-schedule
+func (d Client) SnapshotPolicyAddSchedule(
+	policy, schedule string, count int, prefix string,
+) (*azgo.SnapshotPolicyAddScheduleResponse, error) {
+	request := azgo.NewSnapshotPolicyAddScheduleRequest().
+		SetPolicy(policy).
+		SetSchedule(schedule).
+		SetCount(count)
+	if prefix != "" {
+		request.SetPrefix(prefix)
+	}
+	return request.ExecuteUsing(d.zr())
+}
+
+// SnapshotPolicyModifySchedule changes how many snapshots a snapshot policy's schedule keeps
+// equivalent to filer::> volume snapshot policy modify-schedule
+func (d Client) SnapshotPolicyModifySchedule(
+	policy, schedule string, count int,
+) (*azgo.SnapshotPolicyModifyScheduleResponse, error) {
+	return azgo.NewSnapshotPolicyModifyScheduleRequest().
+		SetPolicy(policy).
+		SetSchedule(schedule).
+		SetNewCount(count).
+		ExecuteUsing(d.zr())
+}
+
+// SnapshotPolicyRemoveSchedule removes a schedule from a snapshot policy
+// equivalent to filer::> volume snapshot policy remove-schedule
+func (d Client) SnapshotPolicyRemoveSchedule(
+	policy, schedule string,
+) (*azgo.SnapshotPolicyRemoveScheduleResponse, error) {
+	return azgo.NewSnapshotPolicyRemoveScheduleRequest().
+		SetPolicy(policy).
+		SetSchedule(schedule).
+		ExecuteUsing(d.zr())
+}
+
 // SNAPSHOT operations END
 /////////////////////////////////////////////////////////////////////////////
 
@@ -1992,13 +2949,13 @@ func (d Client) SnapshotDelete(snapshotName, volumeName string) (*azgo.SnapshotD
 func (d Client) IscsiServiceGetIterRequest() (*azgo.IscsiServiceGetIterResponse, error) {
 	response, err := azgo.NewIscsiServiceGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
 // IscsiNodeGetNameRequest gets the IQN of the vserver
 func (d Client) IscsiNodeGetNameRequest() (*azgo.IscsiNodeGetNameResponse, error) {
-	response, err := azgo.NewIscsiNodeGetNameRequest().ExecuteUsing(d.zr)
+	response, err := azgo.NewIscsiNodeGetNameRequest().ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2006,7 +2963,17 @@ func (d Client) IscsiNodeGetNameRequest() (*azgo.IscsiNodeGetNameResponse, error
 func (d Client) IscsiInterfaceGetIterRequest() (*azgo.IscsiInterfaceGetIterResponse, error) {
 	response, err := azgo.NewIscsiInterfaceGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// IscsiSessionShutdown logs out an initiator's iSCSI session
+// equivalent to filer::> vserver iscsi session shutdown -vserver iscsi_vs -tpgroup iscsi_lif -tsih 5
+func (d Client) IscsiSessionShutdown(tpgroupName string, sessionID int) (*azgo.IscsiSessionShutdownResponse, error) {
+	response, err := azgo.NewIscsiSessionShutdownRequest().
+		SetTpgroupName(tpgroupName).
+		SetSessionId(sessionID).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2021,7 +2988,7 @@ func (d Client) IscsiInterfaceGetIterRequest() (*azgo.IscsiInterfaceGetIterRespo
 func (d Client) VserverGetIterRequest() (*azgo.VserverGetIterResponse, error) {
 	response, err := azgo.NewVserverGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2046,10 +3013,107 @@ func (d Client) VserverGetIterAdminRequest() (*azgo.VserverGetIterResponse, erro
 	return response, err
 }
 
+// IsClusterScoped returns true if the client's credentials belong to a cluster administrator, in
+// which case API calls are tunneled to the configured SVM.  SVM-scoped accounts cannot see the
+// admin vserver.
+func (d Client) IsClusterScoped() (bool, error) {
+
+	response, err := d.VserverGetIterAdminRequest()
+	if err = GetError(response, err); err != nil {
+		if zerr, ok := err.(ZapiError); ok && zerr.IsScopeError() {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return response.Result.NumRecords() > 0, nil
+}
+
+// RoleAccess maps the command directories listed in an ONTAP role to the access the role grants
+// to them, which is "all", "readonly" or "none".
+type RoleAccess map[string]string
+
+// AccessLevel returns the access the role grants to a command directory.  As in ONTAP, that is set
+// by the longest listed directory containing it, or else by the role's DEFAULT entry.
+func (r RoleAccess) AccessLevel(directory string) string {
+
+	level, longest := r["DEFAULT"], -1
+	for listed, listedLevel := range r {
+		if (directory == listed || strings.HasPrefix(directory, listed+" ")) && len(listed) > longest {
+			level, longest = listedLevel, len(listed)
+		}
+	}
+	if level == "" {
+		return "none"
+	}
+	return level
+}
+
+// GetUserRoleAccess looks up the role an account uses for API calls and returns the access it
+// grants to each of its command directories.  Only queries are sent, so nothing is changed on the
+// storage system.  An account of the same name may exist on several SVMs, in which case the one on
+// the configured SVM is used.
+func (d Client) GetUserRoleAccess(username string) (RoleAccess, error) {
+
+	accountQuery := &azgo.SecurityLoginGetIterRequestQuery{}
+	accountInfo := azgo.NewSecurityLoginAccountInfoType().
+		SetUserName(username).
+		SetApplication("ontapi")
+	accountQuery.SetSecurityLoginAccountInfo(*accountInfo)
+
+	accountResponse, err := azgo.NewSecurityLoginGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*accountQuery).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	if err = GetError(accountResponse, err); err != nil {
+		return nil, err
+	}
+
+	var account *azgo.SecurityLoginAccountInfoType
+	if accountResponse.Result.AttributesListPtr != nil {
+		for i, info := range accountResponse.Result.AttributesListPtr.SecurityLoginAccountInfoPtr {
+			if account == nil || (info.VserverPtr != nil && *info.VserverPtr == d.config().SVM) {
+				account = &accountResponse.Result.AttributesListPtr.SecurityLoginAccountInfoPtr[i]
+			}
+		}
+	}
+	if account == nil || account.RoleNamePtr == nil || account.VserverPtr == nil {
+		return nil, fmt.Errorf("could not find API login for user %s", username)
+	}
+
+	roleQuery := &azgo.SecurityLoginRoleGetIterRequestQuery{}
+	roleInfo := azgo.NewSecurityLoginRoleInfoType().
+		SetRoleName(account.RoleName()).
+		SetVserver(account.Vserver())
+	roleQuery.SetSecurityLoginRoleInfo(*roleInfo)
+
+	roleResponse, err := azgo.NewSecurityLoginRoleGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*roleQuery).
+		ExecuteUsing(d.GetNontunneledZapiRunner())
+	if err = GetError(roleResponse, err); err != nil {
+		return nil, err
+	}
+
+	access := make(RoleAccess)
+	if roleResponse.Result.AttributesListPtr != nil {
+		for _, info := range roleResponse.Result.AttributesListPtr.SecurityLoginRoleInfoPtr {
+			if info.CommandDirectoryNamePtr != nil && info.AccessLevelPtr != nil {
+				access[info.CommandDirectoryName()] = info.AccessLevel()
+			}
+		}
+	}
+	if len(access) == 0 {
+		return nil, fmt.Errorf("could not find role %s on SVM %s", account.RoleName(), account.Vserver())
+	}
+
+	return access, nil
+}
+
 // VserverGetRequest returns vserver to which it is sent
 // equivalent to filer::> vserver show
 func (d Client) VserverGetRequest() (*azgo.VserverGetResponse, error) {
-	response, err := azgo.NewVserverGetRequest().ExecuteUsing(d.zr)
+	response, err := azgo.NewVserverGetRequest().ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2060,19 +3124,19 @@ func (d Client) VserverGetAggregateNames() ([]string, error) {
 
 	// Get just the SVM of interest
 	query := &azgo.VserverGetIterRequestQuery{}
-	info := azgo.NewVserverInfoType().SetVserverName(d.config.SVM)
+	info := azgo.NewVserverInfoType().SetVserverName(d.config().SVM)
 	query.SetVserverInfo(*info)
 
 	response, err := azgo.NewVserverGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return nil, err
 	}
 	if response.Result.NumRecords() != 1 {
-		return nil, fmt.Errorf("could not find SVM %s", d.config.SVM)
+		return nil, fmt.Errorf("could not find SVM %s", d.config().SVM)
 	}
 
 	// Get the aggregates assigned to the SVM
@@ -2096,10 +3160,128 @@ func (d Client) VserverShowAggrGetIterRequest() (*azgo.VserverShowAggrGetIterRes
 
 	response, err := azgo.NewVserverShowAggrGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
+// restAggregate is the subset of an ONTAP REST storage/aggregates record needed to classify aggregate media.
+type restAggregate struct {
+	Name         string `json:"name"`
+	BlockStorage struct {
+		Primary struct {
+			DiskClass string `json:"disk_class"`
+		} `json:"primary"`
+		HybridCache struct {
+			Enabled bool `json:"enabled"`
+		} `json:"hybrid_cache"`
+	} `json:"block_storage"`
+}
+
+// AggregateMediaTypesREST returns the type (hdd, hybrid, or ssd) of each aggregate, using the ONTAP REST
+// API available in ONTAP 9.6 and later.  This is a fallback for users not permitted to invoke
+// vserver-show-aggr-get-iter.  Aggregates of unknown media type are omitted.
+func (d Client) AggregateMediaTypesREST() (map[string]string, error) {
+
+	var response struct {
+		Records []restAggregate `json:"records"`
+	}
+	fields := "name,block_storage.primary.disk_class,block_storage.hybrid_cache.enabled"
+	if err := d.restGet("/api/storage/aggregates?fields="+fields, &response); err != nil {
+		return nil, err
+	}
+
+	mediaTypes := make(map[string]string)
+	for _, aggr := range response.Records {
+		if aggr.BlockStorage.HybridCache.Enabled {
+			mediaTypes[aggr.Name] = "hybrid"
+			continue
+		}
+		switch aggr.BlockStorage.Primary.DiskClass {
+		case "solid_state":
+			mediaTypes[aggr.Name] = "ssd"
+		case "capacity", "performance", "archive":
+			mediaTypes[aggr.Name] = "hdd"
+		}
+	}
+	return mediaTypes, nil
+}
+
+// restKerberosInterface is the subset of an ONTAP REST protocols/nfs/kerberos/interfaces record needed to
+// tell whether NFS Kerberos is enabled on a data LIF.
+type restKerberosInterface struct {
+	Interface struct {
+		IP struct {
+			Address string `json:"address"`
+		} `json:"ip"`
+	} `json:"interface"`
+	Enabled bool `json:"enabled"`
+}
+
+// KerberosEnabledDataLIFsREST returns the addresses of the SVM's data LIFs on which NFS Kerberos is
+// enabled, using the ONTAP REST API available in ONTAP 9.6 and later.
+// equivalent to filer::> vserver nfs kerberos interface show
+func (d Client) KerberosEnabledDataLIFsREST() ([]string, error) {
+
+	var response struct {
+		Records []restKerberosInterface `json:"records"`
+	}
+	path := "/api/protocols/nfs/kerberos/interfaces?svm.name=" + url.QueryEscape(d.config().SVM) +
+		"&fields=interface.ip.address,enabled"
+	if err := d.restGet(path, &response); err != nil {
+		return nil, err
+	}
+
+	dataLIFs := make([]string, 0)
+	for _, record := range response.Records {
+		if record.Enabled && record.Interface.IP.Address != "" {
+			dataLIFs = append(dataLIFs, record.Interface.IP.Address)
+		}
+	}
+	return dataLIFs, nil
+}
+
+// restGet invokes an ONTAP REST API GET request and decodes the JSON response into v.
+func (d Client) restGet(path string, v interface{}) error {
+
+	url := "https://" + d.config().ManagementLIF + path
+	if d.zr().Tracer.Enabled() {
+		log.Debugf("URL:> %s", url)
+	}
+
+	ctx := d.zr().Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(d.config().Username, d.config().Password)
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		Timeout:   time.Duration(tridentconfig.StorageAPITimeoutSeconds * time.Second),
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	d.zr().Tracer.TraceResponse(ctx, path, response.Status, string(body))
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("REST request %s failed: %s", path, response.Status)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
 // VSERVER operations END
 /////////////////////////////////////////////////////////////////////////////
 
@@ -2273,7 +3455,7 @@ func (d Client) SnapmirrorGetIterRequest(relGroupType string) (*azgo.SnapmirrorG
 
 	response, err := azgo.NewSnapmirrorGetIterRequest().
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2290,7 +3472,87 @@ func (d Client) SnapmirrorGetDestinationIterRequest(relGroupType string) (*azgo.
 
 	response, err := azgo.NewSnapmirrorGetDestinationIterRequest().
 		SetQuery(*query).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapmirrorGet returns the snapmirror relationship whose destination is the specified location, in the
+// form "svm:volume", or nil if there is none
+// equivalent to filer::> snapmirror show -destination-path svm:volume
+func (d Client) SnapmirrorGet(destinationLocation string) (*azgo.SnapmirrorInfoType, error) {
+
+	query := &azgo.SnapmirrorGetIterRequestQuery{}
+	info := azgo.NewSnapmirrorInfoType().SetDestinationLocation(destinationLocation)
+	query.SetSnapmirrorInfo(*info)
+
+	response, err := azgo.NewSnapmirrorGetIterRequest().
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	if response.Result.NumRecords() == 0 || response.Result.AttributesListPtr == nil ||
+		len(response.Result.AttributesListPtr.SnapmirrorInfoPtr) == 0 {
+		return nil, nil
+	}
+	return &response.Result.AttributesListPtr.SnapmirrorInfoPtr[0], nil
+}
+
+// SnapmirrorCreate creates a snapmirror relationship between two volumes
+// equivalent to filer::> snapmirror create -source-path svm1:vol1 -destination-path svm2:vol2 -type XDP -policy MirrorAllSnapshots
+func (d Client) SnapmirrorCreate(
+	sourceLocation, destinationLocation, relationshipType, policy string,
+) (*azgo.SnapmirrorCreateResponse, error) {
+	response, err := azgo.NewSnapmirrorCreateRequest().
+		SetSourceLocation(sourceLocation).
+		SetDestinationLocation(destinationLocation).
+		SetRelationshipType(relationshipType).
+		SetPolicy(policy).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapmirrorInitialize begins the baseline transfer of a snapmirror relationship, of the specified source
+// snapshot if there is one and of a new snapshot otherwise
+// equivalent to filer::> snapmirror initialize -destination-path svm2:vol2 -source-snapshot snap1
+func (d Client) SnapmirrorInitialize(
+	sourceLocation, destinationLocation, sourceSnapshot string,
+) (*azgo.SnapmirrorInitializeResponse, error) {
+	request := azgo.NewSnapmirrorInitializeRequest().
+		SetSourceLocation(sourceLocation).
+		SetDestinationLocation(destinationLocation)
+	if sourceSnapshot != "" {
+		request.SetSourceSnapshot(sourceSnapshot)
+	}
+	response, err := request.ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapmirrorBreak makes the destination of a snapmirror relationship writable
+// equivalent to filer::> snapmirror break -destination-path svm2:vol2
+func (d Client) SnapmirrorBreak(destinationLocation string) (*azgo.SnapmirrorBreakResponse, error) {
+	response, err := azgo.NewSnapmirrorBreakRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapmirrorDestroy deletes a snapmirror relationship from its destination
+// equivalent to filer::> snapmirror delete -destination-path svm2:vol2
+func (d Client) SnapmirrorDestroy(destinationLocation string) (*azgo.SnapmirrorDestroyResponse, error) {
+	response, err := azgo.NewSnapmirrorDestroyRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr())
+	return response, err
+}
+
+// SnapmirrorRelease removes a deleted snapmirror relationship, and the snapshots kept for it, from its source
+// equivalent to filer::> snapmirror release -destination-path svm2:vol2
+func (d Client) SnapmirrorRelease(destinationLocation string) (*azgo.SnapmirrorReleaseResponse, error) {
+	response, err := azgo.NewSnapmirrorReleaseRequest().
+		SetDestinationLocation(destinationLocation).
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2371,7 +3633,7 @@ func (d Client) isVserverInSVMDR() bool {
 func (d Client) NetInterfaceGet() (*azgo.NetInterfaceGetIterResponse, error) {
 	response, err := azgo.NewNetInterfaceGetIterRequest().
 		SetMaxRecords(defaultZapiRecords).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2417,25 +3679,50 @@ func (d Client) NetInterfaceGetDataLIFs(protocol string) ([]string, error) {
 // SystemGetVersion returns the system version
 // equivalent to filer::> version
 func (d Client) SystemGetVersion() (*azgo.SystemGetVersionResponse, error) {
-	response, err := azgo.NewSystemGetVersionRequest().ExecuteUsing(d.zr)
+	response, err := azgo.NewSystemGetVersionRequest().ExecuteUsing(d.zr())
 	return response, err
 }
 
 // SystemGetOntapiVersion gets the ONTAPI version using the credentials, and caches & returns the result.
 func (d Client) SystemGetOntapiVersion() (string, error) {
 
-	if d.zr.OntapiVersion == "" {
-		result, err := azgo.NewSystemGetOntapiVersionRequest().ExecuteUsing(d.zr)
+	if d.zr().OntapiVersion == "" {
+		result, err := azgo.NewSystemGetOntapiVersionRequest().ExecuteUsing(d.zr())
 		if err = GetError(result, err); err != nil {
 			return "", fmt.Errorf("could not read ONTAPI version: %v", err)
 		}
 
 		major := result.Result.MajorVersion()
 		minor := result.Result.MinorVersion()
-		d.zr.OntapiVersion = fmt.Sprintf("%d.%d", major, minor)
+		d.zr().OntapiVersion = fmt.Sprintf("%d.%d", major, minor)
+	}
+
+	return d.zr().OntapiVersion, nil
+}
+
+// SetOntapiVersion seeds the cached ONTAPI version, such as with one recently read by another client of
+// the same cluster, so that SystemGetOntapiVersion need not ask for it.
+func (d Client) SetOntapiVersion(version string) {
+	d.zr().OntapiVersion = version
+}
+
+// LicensedPackages returns the names of the packages licensed on the cluster, such as "flexclone".
+func (d Client) LicensedPackages() (map[string]bool, error) {
+
+	response, err := azgo.NewLicenseV2StatusListInfoRequest().ExecuteUsing(d.GetNontunneledZapiRunner())
+	if err = GetError(response, err); err != nil {
+		return nil, fmt.Errorf("could not read licenses: %v", err)
 	}
 
-	return d.zr.OntapiVersion, nil
+	packages := make(map[string]bool)
+	if response.Result.LicenseV2StatusPtr != nil {
+		for _, license := range response.Result.LicenseV2StatusPtr.LicenseV2StatusInfoPtr {
+			if license.PackagePtr != nil && license.MethodPtr != nil && license.Method() != "none" {
+				packages[license.Package()] = true
+			}
+		}
+	}
+	return packages, nil
 }
 
 func (d Client) NodeListSerialNumbers() ([]string, error) {
@@ -2510,10 +3797,35 @@ func (d Client) EmsAutosupportLog(
 		SetEventId(eventID).
 		SetEventSource(eventSource).
 		SetLogLevel(logLevel).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
+// EmsMessageGetIterRequest returns the EMS events whose message names match the supplied pattern, such as
+// "vifmgr.*|aggr.*".  The event log is a cluster-level resource, so this call is not tunneled through the SVM.
+// equivalent to filer::> event log show -message-name messageNames
+func (d Client) EmsMessageGetIterRequest(messageNames string) ([]azgo.EmsMessageInfoType, error) {
+	zr := d.GetNontunneledZapiRunner()
+
+	query := &azgo.EmsMessageGetIterRequestQuery{}
+	queryInfo := azgo.NewEmsMessageInfoType().SetMessageName(messageNames)
+	query.SetEmsMessageInfo(*queryInfo)
+
+	response, err := azgo.NewEmsMessageGetIterRequest().
+		SetMaxRecords(defaultZapiRecords).
+		SetQuery(*query).
+		ExecuteUsing(zr)
+	if err = GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	events := make([]azgo.EmsMessageInfoType, 0)
+	if response.Result.AttributesListPtr != nil {
+		events = append(events, response.Result.AttributesListPtr.EmsMessageInfoPtr...)
+	}
+	return events, nil
+}
+
 // ONTAP tiering Policy value is set based on below rules
 //
 // =================================================================================
@@ -2570,7 +3882,7 @@ func (d Client) IscsiInitiatorAddAuth(initiator, authType, userName, passphrase,
 		request.SetOutboundUserName(outboundUserName)
 		request.SetOutboundPassphrase(outboundPassphrase)
 	}
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2578,7 +3890,7 @@ func (d Client) IscsiInitiatorAddAuth(initiator, authType, userName, passphrase,
 // equivalent to filer::> vserver iscsi security show -vserver SVM
 func (d Client) IscsiInitiatorAuthGetIter() ([]azgo.IscsiSecurityEntryInfoType, error) {
 	response, err := azgo.NewIscsiInitiatorAuthGetIterRequest().
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return []azgo.IscsiSecurityEntryInfoType{}, err
@@ -2597,7 +3909,7 @@ func (d Client) IscsiInitiatorAuthGetIter() ([]azgo.IscsiSecurityEntryInfoType,
 func (d Client) IscsiInitiatorDeleteAuth(initiator string) (*azgo.IscsiInitiatorDeleteAuthResponse, error) {
 	response, err := azgo.NewIscsiInitiatorDeleteAuthRequest().
 		SetInitiator(initiator).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2607,7 +3919,7 @@ func (d Client) IscsiInitiatorDeleteAuth(initiator string) (*azgo.IscsiInitiator
 func (d Client) IscsiInitiatorGetAuth(initiator string) (*azgo.IscsiInitiatorGetAuthResponse, error) {
 	response, err := azgo.NewIscsiInitiatorGetAuthRequest().
 		SetInitiator(initiator).
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2615,7 +3927,7 @@ func (d Client) IscsiInitiatorGetAuth(initiator string) (*azgo.IscsiInitiatorGet
 // equivalent to filer::> vserver iscsi security show -vserver SVM -initiator-name default
 func (d Client) IscsiInitiatorGetDefaultAuth() (*azgo.IscsiInitiatorGetDefaultAuthResponse, error) {
 	response, err := azgo.NewIscsiInitiatorGetDefaultAuthRequest().
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2623,7 +3935,7 @@ func (d Client) IscsiInitiatorGetDefaultAuth() (*azgo.IscsiInitiatorGetDefaultAu
 // equivalent to filer::> vserver iscsi initiator show -vserver SVM
 func (d Client) IscsiInitiatorGetIter() ([]azgo.IscsiInitiatorListEntryInfoType, error) {
 	response, err := azgo.NewIscsiInitiatorGetIterRequest().
-		ExecuteUsing(d.zr)
+		ExecuteUsing(d.zr())
 
 	if err != nil {
 		return []azgo.IscsiInitiatorListEntryInfoType{}, err
@@ -2637,6 +3949,25 @@ func (d Client) IscsiInitiatorGetIter() ([]azgo.IscsiInitiatorListEntryInfoType,
 	return []azgo.IscsiInitiatorListEntryInfoType{}, fmt.Errorf("no iscsi initiator entries found")
 }
 
+// IscsiInitiatorSessionsGet returns the iSCSI sessions of a single initiator, which may be none
+// equivalent to filer::> vserver iscsi initiator show -vserver SVM -initiator-name iqn.1993-08.org.debian:01:9031309bbebd
+func (d Client) IscsiInitiatorSessionsGet(initiator string) ([]azgo.IscsiInitiatorListEntryInfoType, error) {
+	query := &azgo.IscsiInitiatorGetIterRequestQuery{}
+	initiatorInfo := azgo.NewIscsiInitiatorListEntryInfoType().
+		SetInitiatorNodename(initiator)
+	query.SetIscsiInitiatorListEntryInfo(*initiatorInfo)
+
+	response, err := azgo.NewIscsiInitiatorGetIterRequest().
+		SetQuery(*query).
+		ExecuteUsing(d.zr())
+	if err = GetError(response, err); err != nil {
+		return []azgo.IscsiInitiatorListEntryInfoType{}, err
+	} else if response.Result.NumRecords() == 0 || response.Result.AttributesListPtr == nil {
+		return []azgo.IscsiInitiatorListEntryInfoType{}, nil
+	}
+	return response.Result.AttributesListPtr.IscsiInitiatorListEntryInfoPtr, nil
+}
+
 // IscsiInitiatorModifyCHAPParams modifies the authorization details for a single initiator
 // equivalent to filer::> vserver iscsi security modify -vserver SVM -initiator-name iqn.1993-08.org.debian:01:9031309bbebd \
 //                          -user-name outboundUserName -outbound-user-name outboundPassphrase
@@ -2649,7 +3980,7 @@ func (d Client) IscsiInitiatorModifyCHAPParams(initiator, userName, passphrase,
 		request.SetOutboundUserName(outboundUserName)
 		request.SetOutboundPassphrase(outboundPassphrase)
 	}
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
@@ -2665,7 +3996,7 @@ func (d Client) IscsiInitiatorSetDefaultAuth(authType, userName, passphrase, out
 		request.SetOutboundUserName(outboundUserName)
 		request.SetOutboundPassphrase(outboundPassphrase)
 	}
-	response, err := request.ExecuteUsing(d.zr)
+	response, err := request.ExecuteUsing(d.zr())
 	return response, err
 }
 
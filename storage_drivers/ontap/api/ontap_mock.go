@@ -0,0 +1,889 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+	"github.com/netapp/trident/utils"
+)
+
+// MockVolume is a FlexVol held by a MockOntapAPI.
+type MockVolume struct {
+	Comment      string
+	ExportPolicy string
+	JunctionPath string
+	CloneParent  string
+	Splitting    bool
+	Snapshots    []string
+}
+
+// MockSnapshotPolicy is a snapshot policy held by a MockOntapAPI.
+type MockSnapshotPolicy struct {
+	Enabled bool
+	Comment string
+	// Schedules maps each of the policy's schedules to the number of snapshots it keeps
+	Schedules map[string]int
+}
+
+// MockOntapAPI is an in-memory implementation of OntapAPI for use in testing the ONTAP driver helpers.
+// It keeps just enough SVM state for calls to see the effects of earlier ones, answering as ONTAP would
+// when asked to create something that exists or change something that doesn't, and any call may be made
+// to fail with FailCall.  The state is exported so that tests may arrange it and check it directly.
+type MockOntapAPI struct {
+	SVM       string
+	TargetIQN string
+
+	// ExportPolicies maps each export policy to its rules' client matches by rule index
+	ExportPolicies map[string]map[int]string
+	// Igroups maps each igroup to its initiators
+	Igroups map[string][]string
+	// Sessions maps each initiator to its iSCSI sessions
+	Sessions map[string][]azgo.IscsiInitiatorListEntryInfoType
+	// DataLIFs maps each iSCSI data LIF to the node it is on
+	DataLIFs map[string]string
+	// LUNs maps each LUN path to its serial number
+	LUNs map[string]string
+	// LUNUUIDs maps each LUN path to its UUID, if it has one
+	LUNUUIDs map[string]string
+	// LUNAttributes maps each LUN path to its attributes
+	LUNAttributes map[string]map[string]string
+	// LUNMaps maps each LUN path to its LUN IDs by igroup
+	LUNMaps map[string]map[string]int
+	// ReportingNodes maps each LUN path to the nodes reporting it
+	ReportingNodes map[string][]string
+	// SnapshotPolicies maps each snapshot policy name to the policy
+	SnapshotPolicies map[string]*MockSnapshotPolicy
+	// Volumes maps each FlexVol name to the FlexVol
+	Volumes map[string]*MockVolume
+
+	// Calls lists the names of the methods called, in order
+	Calls []string
+
+	failures map[string]ZapiError
+	mutex    sync.Mutex
+}
+
+// NewMockOntapAPI returns a MockOntapAPI for an empty SVM.
+func NewMockOntapAPI(svm string) *MockOntapAPI {
+	return &MockOntapAPI{
+		SVM:              svm,
+		TargetIQN:        "iqn.1992-08.com.netapp:sn.mock:vs." + svm,
+		ExportPolicies:   make(map[string]map[int]string),
+		Igroups:          make(map[string][]string),
+		Sessions:         make(map[string][]azgo.IscsiInitiatorListEntryInfoType),
+		DataLIFs:         make(map[string]string),
+		LUNs:             make(map[string]string),
+		LUNUUIDs:         make(map[string]string),
+		LUNAttributes:    make(map[string]map[string]string),
+		LUNMaps:          make(map[string]map[string]int),
+		ReportingNodes:   make(map[string][]string),
+		SnapshotPolicies: make(map[string]*MockSnapshotPolicy),
+		Volumes:          make(map[string]*MockVolume),
+		failures:         make(map[string]ZapiError),
+	}
+}
+
+// FailCall makes every later call to the named method fail with the ZAPI error code and reason.  An empty
+// code makes the method succeed again.
+func (m *MockOntapAPI) FailCall(method, code, reason string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if code == "" {
+		delete(m.failures, method)
+		return
+	}
+	m.failures[method] = ZapiError{status: "failed", reason: reason, code: code}
+}
+
+// Called returns how many times the named method has been called.
+func (m *MockOntapAPI) Called(method string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	count := 0
+	for _, call := range m.Calls {
+		if call == method {
+			count++
+		}
+	}
+	return count
+}
+
+// call records a call to the named method, returning the error it was set to fail with, if any.
+// The caller must hold the mutex.
+func (m *MockOntapAPI) call(method string) (ZapiError, bool) {
+	m.Calls = append(m.Calls, method)
+	zerr, failed := m.failures[method]
+	return zerr, failed
+}
+
+// setResult sets the status of a ZAPI response to that of the error, or to passed if there is none.
+func setResult(response interface{}, zerr *ZapiError) {
+	result := reflect.Indirect(reflect.ValueOf(response)).FieldByName("Result")
+	if zerr == nil {
+		result.FieldByName("ResultStatusAttr").SetString("passed")
+		return
+	}
+	result.FieldByName("ResultStatusAttr").SetString(zerr.status)
+	result.FieldByName("ResultReasonAttr").SetString(zerr.reason)
+	result.FieldByName("ResultErrnoAttr").SetString(zerr.code)
+}
+
+// newZapiError returns a failed call's ZAPI error.
+func newZapiError(code, format string, args ...interface{}) *ZapiError {
+	return &ZapiError{status: "failed", reason: fmt.Sprintf(format, args...), code: code}
+}
+
+// finish sets the status of a ZAPI response to that of an injected failure, if any, or else to that of
+// the error returned by the call itself.
+func finish(response interface{}, injected ZapiError, failed bool, zerr *ZapiError) {
+	if failed {
+		setResult(response, &injected)
+	} else {
+		setResult(response, zerr)
+	}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// Export policies
+/////////////////////////////////////////////////////////////////////////////
+
+func (m *MockOntapAPI) ExportPolicyCreate(policy string) (*azgo.ExportPolicyCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportPolicyCreateResponse()
+	injected, failed := m.call("ExportPolicyCreate")
+	var zerr *ZapiError
+	if _, ok := m.ExportPolicies[policy]; ok {
+		zerr = newZapiError(azgo.EDUPLICATEENTRY, "export policy %s already exists", policy)
+	} else if !failed {
+		m.ExportPolicies[policy] = make(map[int]string)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) ExportPolicyDestroy(policy string) (*azgo.ExportPolicyDestroyResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportPolicyDestroyResponse()
+	injected, failed := m.call("ExportPolicyDestroy")
+	var zerr *ZapiError
+	if _, ok := m.ExportPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "export policy %s not found", policy)
+	} else if !failed {
+		delete(m.ExportPolicies, policy)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) ExportPolicyGet(policy string) (*azgo.ExportPolicyGetResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportPolicyGetResponse()
+	injected, failed := m.call("ExportPolicyGet")
+	var zerr *ZapiError
+	if _, ok := m.ExportPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "export policy %s not found", policy)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+// ExportRuleCreateWithOptions adds a rule at the index, or after the existing rules if the index is zero.
+func (m *MockOntapAPI) ExportRuleCreateWithOptions(
+	policy, clientMatch string, ruleIndex int, anonymousUserID string,
+	protocols, roSecFlavors, rwSecFlavors, suSecFlavors []string,
+) (*azgo.ExportRuleCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportRuleCreateResponse()
+	injected, failed := m.call("ExportRuleCreateWithOptions")
+	var zerr *ZapiError
+	rules, ok := m.ExportPolicies[policy]
+	if !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "export policy %s not found", policy)
+	} else if _, ok = rules[ruleIndex]; ok && ruleIndex > 0 {
+		zerr = newZapiError(azgo.EDUPLICATEENTRY, "export policy %s has a rule at index %d", policy, ruleIndex)
+	} else if !failed {
+		if ruleIndex == 0 {
+			for index := range rules {
+				if index > ruleIndex {
+					ruleIndex = index
+				}
+			}
+			ruleIndex++
+		}
+		rules[ruleIndex] = clientMatch
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) ExportRuleDestroy(policy string, ruleIndex int) (*azgo.ExportRuleDestroyResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportRuleDestroyResponse()
+	injected, failed := m.call("ExportRuleDestroy")
+	var zerr *ZapiError
+	if _, ok := m.ExportPolicies[policy][ruleIndex]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "export policy %s has no rule at index %d", policy, ruleIndex)
+	} else if !failed {
+		delete(m.ExportPolicies[policy], ruleIndex)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) ExportRuleGetIterRequest(policy string) (*azgo.ExportRuleGetIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewExportRuleGetIterResponse()
+	injected, failed := m.call("ExportRuleGetIterRequest")
+	rules := make([]azgo.ExportRuleInfoType, 0)
+	for ruleIndex, clientMatch := range m.ExportPolicies[policy] {
+		rule := azgo.NewExportRuleInfoType().SetPolicyName(policy).SetClientMatch(clientMatch).SetRuleIndex(ruleIndex)
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].RuleIndex() < rules[j].RuleIndex() })
+	response.Result.SetAttributesList(azgo.ExportRuleGetIterResponseResultAttributesList{ExportRuleInfoPtr: rules})
+	response.Result.SetNumRecords(len(rules))
+	finish(response, injected, failed, nil)
+	return response, nil
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// Igroups and iSCSI
+/////////////////////////////////////////////////////////////////////////////
+
+func (m *MockOntapAPI) IgroupAdd(initiatorGroupName, initiator string) (*azgo.IgroupAddResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIgroupAddResponse()
+	injected, failed := m.call("IgroupAdd")
+	var zerr *ZapiError
+	if initiators, ok := m.Igroups[initiatorGroupName]; !ok {
+		zerr = newZapiError(azgo.EVDISK_ERROR_NO_SUCH_INITGROUP, "igroup %s not found", initiatorGroupName)
+	} else if utils.SliceContainsString(initiators, initiator) {
+		zerr = newZapiError(azgo.EVDISK_ERROR_INITGROUP_HAS_NODE, "igroup %s already has %s",
+			initiatorGroupName, initiator)
+	} else if !failed {
+		m.Igroups[initiatorGroupName] = append(initiators, initiator)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) IgroupCreate(
+	initiatorGroupName, initiatorGroupType, osType string,
+) (*azgo.IgroupCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIgroupCreateResponse()
+	injected, failed := m.call("IgroupCreate")
+	var zerr *ZapiError
+	if _, ok := m.Igroups[initiatorGroupName]; ok {
+		zerr = newZapiError(azgo.EVDISK_ERROR_INITGROUP_EXISTS, "igroup %s already exists", initiatorGroupName)
+	} else if !failed {
+		m.Igroups[initiatorGroupName] = make([]string, 0)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) IgroupGet(initiatorGroupName string) (*azgo.InitiatorGroupInfoType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("IgroupGet"); failed {
+		return &azgo.InitiatorGroupInfoType{}, injected
+	}
+	initiators, ok := m.Igroups[initiatorGroupName]
+	if !ok {
+		return &azgo.InitiatorGroupInfoType{}, fmt.Errorf("igroup %s not found", initiatorGroupName)
+	}
+	igroup := azgo.NewInitiatorGroupInfoType().SetInitiatorGroupName(initiatorGroupName)
+	if len(initiators) > 0 {
+		infos := make([]azgo.InitiatorInfoType, 0, len(initiators))
+		for _, initiator := range initiators {
+			infos = append(infos, *azgo.NewInitiatorInfoType().SetInitiatorName(initiator))
+		}
+		igroup.SetInitiators(azgo.InitiatorGroupInfoTypeInitiators{InitiatorInfoPtr: infos})
+	}
+	return igroup, nil
+}
+
+func (m *MockOntapAPI) IgroupRemove(initiatorGroupName, initiator string, force bool) (*azgo.IgroupRemoveResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIgroupRemoveResponse()
+	injected, failed := m.call("IgroupRemove")
+	var zerr *ZapiError
+	if initiators, ok := m.Igroups[initiatorGroupName]; !ok {
+		zerr = newZapiError(azgo.EVDISK_ERROR_NO_SUCH_INITGROUP, "igroup %s not found", initiatorGroupName)
+	} else if !utils.SliceContainsString(initiators, initiator) {
+		zerr = newZapiError(azgo.EVDISK_ERROR_NODE_NOT_IN_INITGROUP, "igroup %s doesn't have %s",
+			initiatorGroupName, initiator)
+	} else if !failed {
+		remaining := make([]string, 0, len(initiators))
+		for _, existing := range initiators {
+			if existing != initiator {
+				remaining = append(remaining, existing)
+			}
+		}
+		m.Igroups[initiatorGroupName] = remaining
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) IscsiInitiatorSessionsGet(initiator string) ([]azgo.IscsiInitiatorListEntryInfoType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("IscsiInitiatorSessionsGet"); failed {
+		return nil, injected
+	}
+	return append([]azgo.IscsiInitiatorListEntryInfoType{}, m.Sessions[initiator]...), nil
+}
+
+func (m *MockOntapAPI) IscsiInterfaceGetIterRequest() (*azgo.IscsiInterfaceGetIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIscsiInterfaceGetIterResponse()
+	injected, failed := m.call("IscsiInterfaceGetIterRequest")
+	interfaces := make([]azgo.IscsiInterfaceListEntryInfoType, 0, len(m.DataLIFs))
+	for _, ip := range m.sortedDataLIFs() {
+		iscsiInterface := azgo.NewIscsiInterfaceListEntryInfoType().
+			SetVserver(m.SVM).
+			SetIpAddress(ip).
+			SetIpPort(3260).
+			SetIsInterfaceEnabled(true)
+		interfaces = append(interfaces, *iscsiInterface)
+	}
+	response.Result.SetAttributesList(azgo.IscsiInterfaceGetIterResponseResultAttributesList{
+		IscsiInterfaceListEntryInfoPtr: interfaces,
+	})
+	response.Result.SetNumRecords(len(interfaces))
+	finish(response, injected, failed, nil)
+	return response, nil
+}
+
+func (m *MockOntapAPI) IscsiNodeGetNameRequest() (*azgo.IscsiNodeGetNameResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIscsiNodeGetNameResponse()
+	injected, failed := m.call("IscsiNodeGetNameRequest")
+	response.Result.SetNodeName(m.TargetIQN)
+	finish(response, injected, failed, nil)
+	return response, nil
+}
+
+func (m *MockOntapAPI) IscsiServiceGetIterRequest() (*azgo.IscsiServiceGetIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIscsiServiceGetIterResponse()
+	injected, failed := m.call("IscsiServiceGetIterRequest")
+	service := azgo.NewIscsiServiceInfoType().SetVserver(m.SVM).SetNodeName(m.TargetIQN)
+	response.Result.SetAttributesList(azgo.IscsiServiceGetIterResponseResultAttributesList{
+		IscsiServiceInfoPtr: []azgo.IscsiServiceInfoType{*service},
+	})
+	response.Result.SetNumRecords(1)
+	finish(response, injected, failed, nil)
+	return response, nil
+}
+
+// IscsiSessionShutdown ends the session with the ID in the target portal group, whichever initiator has it.
+func (m *MockOntapAPI) IscsiSessionShutdown(tpgroupName string, sessionID int) (*azgo.IscsiSessionShutdownResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewIscsiSessionShutdownResponse()
+	injected, failed := m.call("IscsiSessionShutdown")
+	zerr := newZapiError(azgo.EOBJECTNOTFOUND, "session %d not found in %s", sessionID, tpgroupName)
+	for initiator, sessions := range m.Sessions {
+		for i, session := range sessions {
+			if session.TpgroupName() == tpgroupName && session.TargetSessionId() == sessionID {
+				zerr = nil
+				if !failed {
+					m.Sessions[initiator] = append(sessions[:i:i], sessions[i+1:]...)
+				}
+				break
+			}
+		}
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) NetInterfaceGetDataLIFsNode(ip string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("NetInterfaceGetDataLIFsNode"); failed {
+		return "", injected
+	}
+	node, ok := m.DataLIFs[ip]
+	if !ok {
+		return "", fmt.Errorf("could not find data LIF %s", ip)
+	}
+	return node, nil
+}
+
+// sortedDataLIFs returns the iSCSI data LIFs in order.  The caller must hold the mutex.
+func (m *MockOntapAPI) sortedDataLIFs() []string {
+	ips := make([]string, 0, len(m.DataLIFs))
+	for ip := range m.DataLIFs {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// LUNs
+/////////////////////////////////////////////////////////////////////////////
+
+func (m *MockOntapAPI) LunGet(path string) (*azgo.LunInfoType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("LunGet"); failed {
+		return &azgo.LunInfoType{}, injected
+	}
+	serial, ok := m.LUNs[path]
+	if !ok {
+		return &azgo.LunInfoType{}, fmt.Errorf("LUN %s not found", path)
+	}
+	lunInfo := azgo.NewLunInfoType().SetPath(path).SetVserver(m.SVM).SetSerialNumber(serial)
+	if uuid, ok := m.LUNUUIDs[path]; ok {
+		lunInfo.SetUuid(uuid)
+	}
+	return lunInfo, nil
+}
+
+func (m *MockOntapAPI) LunGetAttribute(lunPath, name string) (*azgo.LunGetAttributeResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewLunGetAttributeResponse()
+	injected, failed := m.call("LunGetAttribute")
+	var zerr *ZapiError
+	if value, ok := m.LUNAttributes[lunPath][name]; ok {
+		response.Result.SetValue(value)
+	} else {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "LUN %s has no attribute %s", lunPath, name)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) LunMapGet(initiatorGroupName, lunPath string) (*azgo.LunMapGetIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewLunMapGetIterResponse()
+	injected, failed := m.call("LunMapGet")
+	if lunID, ok := m.LUNMaps[lunPath][initiatorGroupName]; ok {
+		lunMap := azgo.NewLunMapInfoType().
+			SetPath(lunPath).
+			SetInitiatorGroup(initiatorGroupName).
+			SetLunId(lunID).
+			SetReportingNodes(append([]string{}, m.ReportingNodes[lunPath]...))
+		response.Result.AttributesListPtr = []azgo.LunMapInfoType{*lunMap}
+	}
+	finish(response, injected, failed, nil)
+	return response, nil
+}
+
+// LunMapIfNotMapped maps the LUN to the igroup at the lowest LUN ID the igroup isn't using, unless it is
+// mapped already.  Maps to other igroups are removed unless importNotManaged is set, in which case the
+// LUN ID of any existing map is returned.
+func (m *MockOntapAPI) LunMapIfNotMapped(initiatorGroupName, lunPath string, importNotManaged bool) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("LunMapIfNotMapped"); failed {
+		return -1, injected
+	}
+	if _, ok := m.LUNs[lunPath]; !ok {
+		return -1, fmt.Errorf("problem reading maps for LUN %s: %v", lunPath,
+			*newZapiError(azgo.EVDISK_ERROR_NO_SUCH_VOLUME, "LUN %s not found", lunPath))
+	}
+	if _, ok := m.Igroups[initiatorGroupName]; !ok {
+		return -1, fmt.Errorf("problem mapping LUN %s: %v", lunPath,
+			*newZapiError(azgo.EVDISK_ERROR_NO_SUCH_INITGROUP, "igroup %s not found", initiatorGroupName))
+	}
+
+	maps := m.LUNMaps[lunPath]
+	if maps == nil {
+		maps = make(map[string]int)
+		m.LUNMaps[lunPath] = maps
+	}
+	if lunID, ok := maps[initiatorGroupName]; ok {
+		return lunID, nil
+	}
+	for igroup, lunID := range maps {
+		if importNotManaged {
+			return lunID, nil
+		}
+		delete(maps, igroup)
+	}
+
+	used := make(map[int]bool)
+	for _, lunMaps := range m.LUNMaps {
+		if lunID, ok := lunMaps[initiatorGroupName]; ok {
+			used[lunID] = true
+		}
+	}
+	lunID := 0
+	for used[lunID] {
+		lunID++
+	}
+	maps[initiatorGroupName] = lunID
+	return lunID, nil
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// Volumes, clones, and snapshots
+/////////////////////////////////////////////////////////////////////////////
+
+func (m *MockOntapAPI) SnapshotCreateWithComment(
+	snapshotName, volumeName, comment string,
+) (*azgo.SnapshotCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotCreateResponse()
+	injected, failed := m.call("SnapshotCreateWithComment")
+	var zerr *ZapiError
+	if volume, ok := m.Volumes[volumeName]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", volumeName)
+	} else if utils.SliceContainsString(volume.Snapshots, snapshotName) {
+		zerr = newZapiError(azgo.EDUPLICATEENTRY, "snapshot %s already exists", snapshotName)
+	} else if !failed {
+		volume.Snapshots = append(volume.Snapshots, snapshotName)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyAddSchedule(
+	policy, schedule string, count int, prefix string,
+) (*azgo.SnapshotPolicyAddScheduleResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotPolicyAddScheduleResponse()
+	injected, failed := m.call("SnapshotPolicyAddSchedule")
+	var zerr *ZapiError
+	if snapshotPolicy, ok := m.SnapshotPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "snapshot policy %s not found", policy)
+	} else if _, ok = snapshotPolicy.Schedules[schedule]; ok {
+		zerr = newZapiError(azgo.EDUPLICATEENTRY, "schedule %s already in snapshot policy %s", schedule, policy)
+	} else if len(snapshotPolicy.Schedules) >= 5 {
+		zerr = newZapiError(azgo.EINVALIDINPUTERROR, "snapshot policy %s already has 5 schedules", policy)
+	} else if !failed {
+		snapshotPolicy.Schedules[schedule] = count
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyCreate(
+	policy string, enabled bool, comment, schedule string, count int, prefix string,
+) (*azgo.SnapshotPolicyCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotPolicyCreateResponse()
+	injected, failed := m.call("SnapshotPolicyCreate")
+	var zerr *ZapiError
+	if _, ok := m.SnapshotPolicies[policy]; ok {
+		zerr = newZapiError(azgo.EDUPLICATEENTRY, "duplicate entry for snapshot policy %s", policy)
+	} else if !failed {
+		m.SnapshotPolicies[policy] = &MockSnapshotPolicy{
+			Enabled:   enabled,
+			Comment:   comment,
+			Schedules: map[string]int{schedule: count},
+		}
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyGet(policy string) (*azgo.SnapshotPolicyInfoType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("SnapshotPolicyGet"); failed {
+		return nil, injected
+	}
+	snapshotPolicy, ok := m.SnapshotPolicies[policy]
+	if !ok {
+		return nil, nil
+	}
+	schedules := make([]azgo.SnapshotScheduleInfoType, 0)
+	for schedule, count := range snapshotPolicy.Schedules {
+		schedules = append(schedules, *azgo.NewSnapshotScheduleInfoType().SetSchedule(schedule).SetCount(count))
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Schedule() < schedules[j].Schedule() })
+	scheduleList := azgo.SnapshotPolicyInfoTypeSnapshotPolicySchedules{}
+	scheduleList.SetSnapshotScheduleInfo(schedules)
+	return azgo.NewSnapshotPolicyInfoType().
+		SetPolicy(policy).
+		SetVserverName(m.SVM).
+		SetEnabled(snapshotPolicy.Enabled).
+		SetComment(snapshotPolicy.Comment).
+		SetTotalSchedules(len(schedules)).
+		SetSnapshotPolicySchedules(scheduleList), nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyModify(
+	policy string, enabled bool, comment string,
+) (*azgo.SnapshotPolicyModifyResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotPolicyModifyResponse()
+	injected, failed := m.call("SnapshotPolicyModify")
+	var zerr *ZapiError
+	if snapshotPolicy, ok := m.SnapshotPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "snapshot policy %s not found", policy)
+	} else if !failed {
+		snapshotPolicy.Enabled = enabled
+		snapshotPolicy.Comment = comment
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyModifySchedule(
+	policy, schedule string, count int,
+) (*azgo.SnapshotPolicyModifyScheduleResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotPolicyModifyScheduleResponse()
+	injected, failed := m.call("SnapshotPolicyModifySchedule")
+	var zerr *ZapiError
+	if snapshotPolicy, ok := m.SnapshotPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "snapshot policy %s not found", policy)
+	} else if _, ok = snapshotPolicy.Schedules[schedule]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "schedule %s not in snapshot policy %s", schedule, policy)
+	} else if !failed {
+		snapshotPolicy.Schedules[schedule] = count
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) SnapshotPolicyRemoveSchedule(
+	policy, schedule string,
+) (*azgo.SnapshotPolicyRemoveScheduleResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewSnapshotPolicyRemoveScheduleResponse()
+	injected, failed := m.call("SnapshotPolicyRemoveSchedule")
+	var zerr *ZapiError
+	if snapshotPolicy, ok := m.SnapshotPolicies[policy]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "snapshot policy %s not found", policy)
+	} else if _, ok = snapshotPolicy.Schedules[schedule]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "schedule %s not in snapshot policy %s", schedule, policy)
+	} else if len(snapshotPolicy.Schedules) == 1 {
+		zerr = newZapiError(azgo.EINVALIDINPUTERROR, "cannot remove the last schedule of snapshot policy %s", policy)
+	} else if !failed {
+		delete(snapshotPolicy.Schedules, schedule)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) VolumeCloneCreate(name, source, snapshot string) (*azgo.VolumeCloneCreateResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeCloneCreateResponse()
+	injected, failed := m.call("VolumeCloneCreate")
+	zerr := m.cloneVolume(name, source, snapshot, failed)
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+// VolumeCloneCreateAsync creates the clone at once, so its job has always succeeded.
+func (m *MockOntapAPI) VolumeCloneCreateAsync(
+	name, source, snapshot string,
+) (*azgo.VolumeCloneCreateAsyncResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeCloneCreateAsyncResponse()
+	injected, failed := m.call("VolumeCloneCreateAsync")
+	zerr := m.cloneVolume(name, source, snapshot, failed)
+	if failed {
+		zerr = &injected
+	}
+	if zerr != nil {
+		return response, *zerr
+	}
+	response.Result.SetResultStatus("succeeded")
+	setResult(response, nil)
+	return response, nil
+}
+
+// cloneVolume creates a clone of the volume's snapshot, unless the call was set to fail, and returns the
+// error ONTAP would.  The caller must hold the mutex.
+func (m *MockOntapAPI) cloneVolume(name, source, snapshot string, failed bool) *ZapiError {
+	parent, ok := m.Volumes[source]
+	if !ok {
+		return newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", source)
+	} else if !utils.SliceContainsString(parent.Snapshots, snapshot) {
+		return newZapiError(azgo.EOBJECTNOTFOUND, "snapshot %s not found in volume %s", snapshot, source)
+	} else if _, ok = m.Volumes[name]; ok {
+		return newZapiError(azgo.EONTAPI_EEXIST, "volume %s already exists", name)
+	} else if !failed {
+		m.Volumes[name] = &MockVolume{ExportPolicy: parent.ExportPolicy, CloneParent: source}
+	}
+	return nil
+}
+
+func (m *MockOntapAPI) VolumeCloneSplitStart(name string) (*azgo.VolumeCloneSplitStartResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeCloneSplitStartResponse()
+	injected, failed := m.call("VolumeCloneSplitStart")
+	var zerr *ZapiError
+	if volume, ok := m.Volumes[name]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", name)
+	} else if volume.CloneParent == "" {
+		zerr = newZapiError(azgo.EAPIERROR, "volume %s is not a clone", name)
+	} else if !failed {
+		volume.Splitting = true
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) VolumeDestroy(name string, force bool) (*azgo.VolumeDestroyResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeDestroyResponse()
+	injected, failed := m.call("VolumeDestroy")
+	var zerr *ZapiError
+	if _, ok := m.Volumes[name]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", name)
+	} else if !failed {
+		delete(m.Volumes, name)
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) VolumeExists(name string) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("VolumeExists"); failed {
+		return false, injected
+	}
+	_, ok := m.Volumes[name]
+	return ok, nil
+}
+
+func (m *MockOntapAPI) VolumeGet(name string) (*azgo.VolumeAttributesType, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if injected, failed := m.call("VolumeGet"); failed {
+		return &azgo.VolumeAttributesType{}, injected
+	}
+	volume, ok := m.Volumes[name]
+	if !ok {
+		return &azgo.VolumeAttributesType{}, fmt.Errorf("flexvol %s not found", name)
+	}
+	idAttrs := azgo.NewVolumeIdAttributesType().
+		SetName(name).
+		SetOwningVserverName(m.SVM).
+		SetComment(volume.Comment).
+		SetJunctionPath(volume.JunctionPath)
+	exportAttrs := azgo.NewVolumeExportAttributesType().SetPolicy(volume.ExportPolicy)
+	return azgo.NewVolumeAttributesType().
+		SetVolumeIdAttributes(*idAttrs).
+		SetVolumeExportAttributes(*exportAttrs), nil
+}
+
+func (m *MockOntapAPI) VolumeModifyExportPolicy(
+	volumeName, exportPolicyName string,
+) (*azgo.VolumeModifyIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeModifyIterResponse()
+	injected, failed := m.call("VolumeModifyExportPolicy")
+	var zerr *ZapiError
+	if volume, ok := m.Volumes[volumeName]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", volumeName)
+	} else if _, ok = m.ExportPolicies[exportPolicyName]; !ok {
+		zerr = newZapiError(azgo.EOBJECTNOTFOUND, "export policy %s not found", exportPolicyName)
+	} else if !failed {
+		volume.ExportPolicy = exportPolicyName
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) VolumeMount(name, junctionPath string) (*azgo.VolumeMountResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeMountResponse()
+	injected, failed := m.call("VolumeMount")
+	var zerr *ZapiError
+	if volume, ok := m.Volumes[name]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", name)
+	} else if !failed {
+		volume.JunctionPath = junctionPath
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+func (m *MockOntapAPI) VolumeSetComment(volumeName, comment string) (*azgo.VolumeModifyIterResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := azgo.NewVolumeModifyIterResponse()
+	injected, failed := m.call("VolumeSetComment")
+	var zerr *ZapiError
+	if volume, ok := m.Volumes[volumeName]; !ok {
+		zerr = newZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume %s not found", volumeName)
+	} else if !failed {
+		volume.Comment = comment
+	}
+	finish(response, injected, failed, zerr)
+	return response, nil
+}
+
+var _ OntapAPI = &MockOntapAPI{}
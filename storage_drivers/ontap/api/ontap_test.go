@@ -3,6 +3,10 @@
 package api
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
@@ -18,3 +22,252 @@ func TestGetError(t *testing.T) {
 
 	assert.Equal(t, "unexpected nil ZAPI result", e.(ZapiError).Reason(), "Strings not equal")
 }
+
+func TestGetUserRoleAccess(t *testing.T) {
+
+	accounts := `<security-login-account-info><role-name>vsadmin</role-name><vserver>svm0</vserver>` +
+		`</security-login-account-info><security-login-account-info><role-name>trident</role-name>` +
+		`<vserver>svm1</vserver></security-login-account-info>`
+	roles := `<security-login-role-info><command-directory-name>DEFAULT</command-directory-name>` +
+		`<access-level>readonly</access-level></security-login-role-info><security-login-role-info>` +
+		`<command-directory-name>volume</command-directory-name><access-level>all</access-level>` +
+		`</security-login-role-info>`
+
+	tests := map[string]struct {
+		accounts string
+		roles    string
+		access   RoleAccess
+		errorMsg string
+	}{
+		"Role of account on SVM": {
+			accounts: accounts,
+			roles:    roles,
+			access:   RoleAccess{"DEFAULT": "readonly", "volume": "all"},
+		},
+		"No login": {
+			roles:    roles,
+			errorMsg: "could not find API login for user trident",
+		},
+		"No role": {
+			accounts: accounts,
+			errorMsg: "could not find role trident on SVM svm1",
+		},
+	}
+	for name, test := range tests {
+
+		// Answer only the two queries, so that any other call fails the test
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			result := `<results status="failed" errno="13005" reason="Unexpected API"/>`
+			if strings.Contains(string(body), "<security-login-get-iter>") {
+				assert.Contains(t, string(body), "<user-name>trident</user-name>", name)
+				result = `<results status="passed"><attributes-list>` + test.accounts +
+					`</attributes-list><num-records>2</num-records></results>`
+			} else if strings.Contains(string(body), "<security-login-role-get-iter>") {
+				assert.Contains(t, string(body), "<role-name>trident</role-name>", name)
+				result = `<results status="passed"><attributes-list>` + test.roles +
+					`</attributes-list><num-records>2</num-records></results>`
+			} else {
+				t.Errorf("%s: unexpected request %s", name, body)
+			}
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+				`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+		}))
+
+		client := NewClient(ClientConfig{
+			ManagementLIF: strings.TrimPrefix(server.URL, "https://"),
+			SVM:           "svm1",
+		})
+
+		access, err := client.GetUserRoleAccess("trident")
+		if test.errorMsg != "" {
+			assert.EqualError(t, err, test.errorMsg, name)
+		} else {
+			assert.NoError(t, err, name)
+			assert.Equal(t, test.access, access, name)
+		}
+		server.Close()
+	}
+}
+
+func TestRoleAccessLevel(t *testing.T) {
+
+	access := RoleAccess{"DEFAULT": "readonly", "volume": "all", "volume snapshot": "none", "lun": "all"}
+
+	tests := map[string]struct {
+		access    RoleAccess
+		directory string
+		level     string
+	}{
+		"Listed directory":       {access: access, directory: "volume", level: "all"},
+		"Inside listed":          {access: access, directory: "volume qtree", level: "all"},
+		"Longest listed wins":    {access: access, directory: "volume snapshot policy", level: "none"},
+		"Prefix of another word": {access: access, directory: "lunar", level: "readonly"},
+		"Default":                {access: access, directory: "network interface", level: "readonly"},
+		"No default":             {access: RoleAccess{"volume": "all"}, directory: "lun", level: "none"},
+	}
+	for name, test := range tests {
+		assert.Equal(t, test.level, test.access.AccessLevel(test.directory), name)
+	}
+}
+
+func TestAggregateMediaTypesREST(t *testing.T) {
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storage/aggregates", r.URL.Path)
+		_, _ = w.Write([]byte(`{"records": [
+			{"name": "aggr1", "block_storage": {"primary": {"disk_class": "solid_state"}}},
+			{"name": "aggr2", "block_storage": {"primary": {"disk_class": "capacity"}}},
+			{"name": "aggr3", "block_storage": {"primary": {"disk_class": "performance"},
+				"hybrid_cache": {"enabled": true}}},
+			{"name": "aggr4", "block_storage": {"primary": {"disk_class": "virtual"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+
+	mediaTypes, err := client.AggregateMediaTypesREST()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"aggr1": "ssd", "aggr2": "hdd", "aggr3": "hybrid"}, mediaTypes)
+}
+
+func TestAggregateMediaTypesRESTForbidden(t *testing.T) {
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+
+	_, err := client.AggregateMediaTypesREST()
+	assert.Error(t, err)
+}
+
+func newFeatureProbeServer(t *testing.T, licenses string, restStatus int) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			assert.Equal(t, "/api/storage/volumes", r.URL.Path)
+			w.WriteHeader(restStatus)
+			_, _ = w.Write([]byte(`{"records": []}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(body), "<license-v2-status-list-info>")
+		result := `<results status="failed" errno="13003" reason="Insufficient privileges"/>`
+		if licenses != "" {
+			result = `<results status="passed"><license-v2-status>` + licenses + `</license-v2-status></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+}
+
+func TestProbeFeatures(t *testing.T) {
+
+	licenses := `<license-v2-status-info><package>flexclone</package><method>license</method>` +
+		`</license-v2-status-info><license-v2-status-info><package>snapmirror_sync</package>` +
+		`<method>none</method></license-v2-status-info>`
+	server := newFeatureProbeServer(t, licenses, http.StatusOK)
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+	client.SetOntapiVersion("1.170")
+
+	// Before probing, only the Ontapi version is considered
+	assert.True(t, client.SupportsFeature(SnapMirrorSync))
+
+	features := client.ProbeFeatures()
+	assert.True(t, features["flexGroupClone"])
+	assert.False(t, features["snapMirrorSync"])
+	assert.True(t, features["restAPI"])
+	assert.True(t, features["lunGeometryResize"])
+	assert.False(t, client.SupportsFeature(SnapMirrorSync))
+	assert.True(t, client.SupportsFeature(MinimumONTAPIVersion))
+}
+
+func TestProbeFeaturesUnreadableLicenses(t *testing.T) {
+
+	server := newFeatureProbeServer(t, "", http.StatusNotFound)
+	defer server.Close()
+
+	client := NewClient(ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+	client.SetOntapiVersion("1.150")
+
+	features := client.ProbeFeatures()
+	assert.True(t, features["snapMirrorSync"], "expected licensed features to be assumed available")
+	assert.False(t, features["flexGroupClone"], "expected the Ontapi version to be too old")
+	assert.False(t, features["restAPI"])
+}
+
+// newTestSVMServer returns a server answering for an SVM in the given state.
+func newTestSVMServer(svm, state string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result := `<results status="passed"/>`
+		switch {
+		case strings.Contains(string(body), "<vserver-get>"):
+			result = `<results status="passed"><attributes><vserver-info><vserver-name>` + svm +
+				`</vserver-name><uuid>uuid-` + svm + `</uuid><state>` + state +
+				`</state></vserver-info></attributes></results>`
+		case strings.Contains(string(body), "<system-get-ontapi-version>"):
+			result = `<results status="passed"><major-version>1</major-version>` +
+				`<minor-version>170</minor-version></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.170" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+}
+
+func TestClientFailOver(t *testing.T) {
+
+	primary := newTestSVMServer("svm1", "running")
+	defer primary.Close()
+
+	tests := map[string]struct {
+		partnerState string
+		failedOver   bool
+	}{
+		"Partner running": {partnerState: "running", failedOver: true},
+		"Partner stopped": {partnerState: "stopped"},
+	}
+	for name, test := range tests {
+		partner := newTestSVMServer("svm1_dr", test.partnerState)
+		primaryLIF := strings.TrimPrefix(primary.URL, "https://")
+		partnerLIF := strings.TrimPrefix(partner.URL, "https://")
+		client := NewClient(ClientConfig{
+			ManagementLIF:          primaryLIF,
+			SVM:                    "svm1",
+			SecondaryManagementLIF: partnerLIF,
+			SecondarySVM:           "svm1_dr",
+		})
+
+		// The client may be used while it fails over
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				_ = client.SVM()
+				_, _ = client.VserverGetRequest()
+			}
+		}()
+
+		err := client.FailOver()
+		<-done
+		partner.Close()
+
+		if !test.failedOver {
+			assert.Error(t, err, name)
+			assert.Equal(t, "svm1", client.SVM(), name)
+			assert.Equal(t, primaryLIF, client.ManagementLIF(), name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, "svm1_dr", client.SVM(), name)
+		assert.Equal(t, partnerLIF, client.ManagementLIF(), name)
+		assert.Equal(t, "svm1", client.SecondarySVM(), name)
+		assert.Equal(t, primaryLIF, client.SecondaryManagementLIF(), name)
+		assert.Equal(t, partnerLIF, client.GetClonedZapiRunner().ManagementLIF, name)
+	}
+}
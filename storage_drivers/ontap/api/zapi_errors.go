@@ -0,0 +1,166 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package api
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+// ZapiErrorCategory classifies a failed ONTAP API call by what its caller may do about it.
+type ZapiErrorCategory string
+
+const (
+	// ZapiErrorCategoryNone is the category of a call that didn't fail.
+	ZapiErrorCategoryNone ZapiErrorCategory = ""
+	// ZapiErrorCategoryRetryable means ONTAP couldn't handle the call just now, as when it is busy or the
+	// connection drops, and the same call may succeed if simply attempted again.
+	ZapiErrorCategoryRetryable ZapiErrorCategory = "retryable"
+	// ZapiErrorCategoryBusy means the object is in use, as by a clone or a job, and the call may succeed
+	// once that use ends.
+	ZapiErrorCategoryBusy ZapiErrorCategory = "busy"
+	// ZapiErrorCategoryNotFound means the object the call refers to doesn't exist.
+	ZapiErrorCategoryNotFound ZapiErrorCategory = "notfound"
+	// ZapiErrorCategoryExists means the object the call would create exists already.
+	ZapiErrorCategoryExists ZapiErrorCategory = "exists"
+	// ZapiErrorCategoryAuth means the credentials are wrong or lack the privileges the call needs.
+	ZapiErrorCategoryAuth ZapiErrorCategory = "auth"
+	// ZapiErrorCategoryNoSpace means there isn't enough space for what the call would create or grow.
+	ZapiErrorCategoryNoSpace ZapiErrorCategory = "nospace"
+	// ZapiErrorCategoryPermanent means the call will keep failing until something else changes.
+	ZapiErrorCategoryPermanent ZapiErrorCategory = "permanent"
+)
+
+// zapiErrorCategories maps the ZAPI error codes that always mean the same thing to their categories.
+// Codes not listed here are categorized by their reasons, if at all.
+var zapiErrorCategories = map[string]ZapiErrorCategory{
+	azgo.EVOLUMEDOESNOTEXIST:                ZapiErrorCategoryNotFound,
+	azgo.EOBJECTNOTFOUND:                    ZapiErrorCategoryNotFound,
+	azgo.EAGGRDOESNOTEXIST:                  ZapiErrorCategoryNotFound,
+	azgo.EVDISK_ERROR_NO_SUCH_VOLUME:        ZapiErrorCategoryNotFound,
+	azgo.EVDISK_ERROR_NO_SUCH_INITGROUP:     ZapiErrorCategoryNotFound,
+	azgo.EVDISK_ERROR_NODE_NOT_IN_INITGROUP: ZapiErrorCategoryNotFound,
+	azgo.EONTAPI_EEXIST:                     ZapiErrorCategoryExists,
+	azgo.EDUPLICATEENTRY:                    ZapiErrorCategoryExists,
+	azgo.EVDISK_ERROR_VDISK_EXISTS:          ZapiErrorCategoryExists,
+	azgo.EVDISK_ERROR_INITGROUP_EXISTS:      ZapiErrorCategoryExists,
+	azgo.EVDISK_ERROR_INITGROUP_HAS_NODE:    ZapiErrorCategoryExists,
+	azgo.EAPIPRIVILEGE:                      ZapiErrorCategoryAuth,
+	azgo.ESNAPSHOTBUSY:                      ZapiErrorCategoryBusy,
+}
+
+// Category returns the category of a ZAPI failure, or ZapiErrorCategoryNone if the call passed.
+func (e ZapiError) Category() ZapiErrorCategory {
+
+	if e.IsPassed() {
+		return ZapiErrorCategoryNone
+	}
+	if category, ok := zapiErrorCategories[e.code]; ok {
+		return category
+	}
+
+	reason := strings.ToLower(e.reason)
+	switch {
+	case e.IsFailedToLoadJobError(), e.code == azgo.EAPIERROR && strings.Contains(reason, "busy"):
+		return ZapiErrorCategoryRetryable
+	case e.IsJobExistsError():
+		return ZapiErrorCategoryBusy
+	case strings.Contains(reason, "not enough space"), strings.Contains(reason, "insufficient space"):
+		return ZapiErrorCategoryNoSpace
+	}
+	return ZapiErrorCategoryPermanent
+}
+
+// IsJobExistsError returns true if the call failed because ONTAP is already running a job to do the same.
+func (e ZapiError) IsJobExistsError() bool {
+	return e.code == azgo.EAPIERROR && strings.Contains(strings.ToLower(e.reason), "job exists")
+}
+
+// IsRetryable returns true if the same call may succeed if simply attempted again.
+func (e ZapiError) IsRetryable() bool {
+	return e.Category() == ZapiErrorCategoryRetryable
+}
+
+// IsBusy returns true if the call failed because the object is in use.
+func (e ZapiError) IsBusy() bool {
+	return e.Category() == ZapiErrorCategoryBusy
+}
+
+// IsNotFound returns true if the call failed because the object it refers to doesn't exist.
+func (e ZapiError) IsNotFound() bool {
+	return e.Category() == ZapiErrorCategoryNotFound
+}
+
+// IsExists returns true if the call failed because the object it would create exists already.
+func (e ZapiError) IsExists() bool {
+	return e.Category() == ZapiErrorCategoryExists
+}
+
+// IsAuth returns true if the call failed for lack of credentials or privileges.
+func (e ZapiError) IsAuth() bool {
+	return e.Category() == ZapiErrorCategoryAuth
+}
+
+// IsPermanent returns true if the call failed and will fail again however soon or often it is retried.
+func (e ZapiError) IsPermanent() bool {
+	switch e.Category() {
+	case ZapiErrorCategoryNone, ZapiErrorCategoryRetryable, ZapiErrorCategoryBusy:
+		return false
+	default:
+		return true
+	}
+}
+
+// GetErrorCategory returns the category of an error returned by GetError or any call to ONTAP, whether
+// ONTAP failed the call or the call never reached it.  Timeouts and dropped connections are retryable, and
+// rejected credentials are an auth error.  Other errors not from ZAPI are permanent.
+func GetErrorCategory(err error) ZapiErrorCategory {
+
+	if err == nil {
+		return ZapiErrorCategoryNone
+	}
+
+	var zerr ZapiError
+	if errors.As(err, &zerr) {
+		return zerr.Category()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout(),
+		errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED):
+		return ZapiErrorCategoryRetryable
+	case errors.Is(err, azgo.ErrUnauthorized):
+		return ZapiErrorCategoryAuth
+	}
+	return ZapiErrorCategoryPermanent
+}
+
+// IsRetryableError returns true if the call that returned the error may succeed if simply attempted again.
+func IsRetryableError(err error) bool {
+	return GetErrorCategory(err) == ZapiErrorCategoryRetryable
+}
+
+// IsBusyError returns true if the call that returned the error failed because the object is in use.
+func IsBusyError(err error) bool {
+	return GetErrorCategory(err) == ZapiErrorCategoryBusy
+}
+
+// IsNotFoundError returns true if the call that returned the error refers to an object that doesn't exist.
+func IsNotFoundError(err error) bool {
+	return GetErrorCategory(err) == ZapiErrorCategoryNotFound
+}
+
+// IsExistsError returns true if the call that returned the error would create an object that exists already.
+func IsExistsError(err error) bool {
+	return GetErrorCategory(err) == ZapiErrorCategoryExists
+}
+
+// IsAuthError returns true if the call that returned the error failed for lack of credentials or privileges.
+func IsAuthError(err error) bool {
+	return GetErrorCategory(err) == ZapiErrorCategoryAuth
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func newTestZapiError(errno, reason string) ZapiError {
+	return NewZapiError(azgo.CloneCreateResponseResult{
+		ResultStatusAttr: "failed",
+		ResultReasonAttr: reason,
+		ResultErrnoAttr:  errno,
+	})
+}
+
+func TestZapiErrorCategory(t *testing.T) {
+
+	tests := []struct {
+		err      ZapiError
+		category ZapiErrorCategory
+	}{
+		{NewZapiError(azgo.CloneCreateResponseResult{ResultStatusAttr: "passed"}), ZapiErrorCategoryNone},
+		{newTestZapiError(azgo.EVOLUMEDOESNOTEXIST, "volume doesn't exist"), ZapiErrorCategoryNotFound},
+		{newTestZapiError(azgo.EVDISK_ERROR_NODE_NOT_IN_INITGROUP, "not in igroup"), ZapiErrorCategoryNotFound},
+		{newTestZapiError(azgo.EDUPLICATEENTRY, "duplicate entry"), ZapiErrorCategoryExists},
+		{newTestZapiError(azgo.EAPIPRIVILEGE, "insufficient privileges"), ZapiErrorCategoryAuth},
+		{newTestZapiError(azgo.ESNAPSHOTBUSY, "snapshot is busy"), ZapiErrorCategoryBusy},
+		{newTestZapiError(azgo.EAPIERROR, "Job exists"), ZapiErrorCategoryBusy},
+		{newTestZapiError(azgo.EAPIERROR, "Volume is busy"), ZapiErrorCategoryRetryable},
+		{newTestZapiError(azgo.EINTERNALERROR, "Failed to load job"), ZapiErrorCategoryRetryable},
+		{newTestZapiError(azgo.EAPIERROR, "Not enough space in aggregate"), ZapiErrorCategoryNoSpace},
+		{newTestZapiError(azgo.EINVALIDINPUTERROR, "invalid input"), ZapiErrorCategoryPermanent},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.category, test.err.Category(), test.err.Error())
+	}
+
+	assert.True(t, newTestZapiError(azgo.EAPIERROR, "Job exists").IsJobExistsError())
+	assert.False(t, newTestZapiError(azgo.ESNAPSHOTBUSY, "snapshot is busy").IsPermanent())
+	assert.True(t, newTestZapiError(azgo.EOBJECTNOTFOUND, "entry doesn't exist").IsPermanent())
+}
+
+func TestGetErrorCategory(t *testing.T) {
+
+	assert.Equal(t, ZapiErrorCategoryNone, GetErrorCategory(nil))
+
+	// ZAPI failures are found even if wrapped
+	wrapped := fmt.Errorf("error creating clone: %w", newTestZapiError(azgo.EOBJECTNOTFOUND, "not found"))
+	assert.True(t, IsNotFoundError(wrapped))
+
+	// Only failures of the call itself may simply be retried
+	assert.True(t, IsRetryableError(newTestZapiError(azgo.EAPIERROR, "Volume is busy")))
+	assert.True(t, IsRetryableError(&net.OpError{Op: "read", Err: syscall.ECONNRESET}))
+	assert.False(t, IsRetryableError(newTestZapiError(azgo.EAPIERROR, "Job exists")))
+	assert.False(t, IsRetryableError(newTestZapiError(azgo.ESNAPSHOTBUSY, "snapshot is busy")))
+	assert.False(t, IsRetryableError(errors.New("invalid input")))
+
+	assert.True(t, IsBusyError(newTestZapiError(azgo.ESNAPSHOTBUSY, "snapshot is busy")))
+	assert.True(t, IsExistsError(newTestZapiError(azgo.EVDISK_ERROR_VDISK_EXISTS, "LUN exists")))
+	assert.True(t, IsAuthError(azgo.ErrUnauthorized))
+	assert.Equal(t, ZapiErrorCategoryPermanent, GetErrorCategory(errors.New("invalid input")))
+}
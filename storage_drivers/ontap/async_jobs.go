@@ -0,0 +1,188 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// Finished jobs are remembered long enough for a retried request to learn their outcome
+	asyncJobStatusRetention = 1 * time.Hour
+
+	// asyncJobMaxPollInterval caps the backoff between polls of a job being waited on
+	asyncJobMaxPollInterval = 8 * time.Second
+)
+
+// AsyncJobTracker follows the ONTAP jobs that create volumes asynchronously, such as FlexGroup
+// clones.  A request that starts a job waits for it only briefly, after which the driver returns a
+// VolumeCreatingError and records the job ID in the volume's config.  The orchestrator persists the
+// config with its VolumeCreating transaction, so that the tracker may resume following the job after
+// a restart.  The tracker's housekeeping job polls running jobs, so the outcome is known by the time
+// the request is retried.
+type AsyncJobTracker struct {
+	jobs         *jobTracker
+	pollInterval time.Duration
+}
+
+// NewAsyncJobTracker returns a tracker for the jobs a driver starts on an SVM.
+func NewAsyncJobTracker(client *api.Client) *AsyncJobTracker {
+	return &AsyncJobTracker{
+		jobs: &jobTracker{
+			description: "ONTAP job",
+			retention:   asyncJobStatusRetention,
+			jobs:        make(map[string]*trackedJob),
+			read: func(name string, job trackedJob) (jobReading, error) {
+				return getOntapAsyncJobState(client, name, job.id)
+			},
+		},
+		pollInterval: 1 * time.Second,
+	}
+}
+
+// Track begins following a job working on the named volume.
+func (t *AsyncJobTracker) Track(name, operation string, id int) {
+	if t == nil {
+		return
+	}
+	t.jobs.track(name, trackedJob{operation: operation, id: id})
+	log.WithFields(log.Fields{"volume": name, "jobId": id, "operation": operation}).Debug("Tracking ONTAP job.")
+}
+
+// Resume follows a job recorded in a volume's config, unless it is being followed already.
+func (t *AsyncJobTracker) Resume(name, operation, jobID string) {
+	if t == nil {
+		return
+	}
+
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		log.WithFields(log.Fields{"volume": name, "jobId": jobID}).Warning("Invalid ONTAP job ID.")
+		return
+	}
+
+	if job, ok := t.jobs.get(name); ok && job.id == id {
+		return
+	}
+	t.Track(name, operation, id)
+}
+
+// Forget discards any record of a job working on the named volume.
+func (t *AsyncJobTracker) Forget(name string) {
+	if t == nil {
+		return
+	}
+	t.jobs.forget(name)
+}
+
+// Has returns true if a job working on the named volume is being followed or recently finished.
+func (t *AsyncJobTracker) Has(name string) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.jobs.get(name)
+	return ok
+}
+
+// Operation returns the operation of the job working on the named volume, or an empty string if
+// there is none.
+func (t *AsyncJobTracker) Operation(name string) string {
+	if t == nil {
+		return ""
+	}
+	job, _ := t.jobs.get(name)
+	return job.operation
+}
+
+// RunningJobID returns the ID of the job still working on the named volume, or an empty string
+// if there is none.
+func (t *AsyncJobTracker) RunningJobID(name string) string {
+	if t == nil {
+		return ""
+	}
+	if job, ok := t.jobs.get(name); ok && job.state == jobStateRunning {
+		return strconv.Itoa(job.id)
+	}
+	return ""
+}
+
+// Jobs returns the jobs still running.
+func (t *AsyncJobTracker) Jobs() []*storage.StorageJob {
+	if t == nil {
+		return make([]*storage.StorageJob, 0)
+	}
+	return t.jobs.storageJobs()
+}
+
+// Wait polls the job working on the named volume until it finishes or maxWait elapses.  It returns
+// nil if the job succeeded, and a VolumeCreatingError if the job is still running.
+func (t *AsyncJobTracker) Wait(name string, maxWait time.Duration) error {
+
+	start := time.Now()
+	interval := t.pollInterval
+
+	for {
+		job, ok := t.jobs.poll(name)
+		if !ok {
+			return fmt.Errorf("no ONTAP job is working on volume %s", name)
+		}
+
+		switch job.state {
+		case jobStateComplete:
+			return nil
+		case jobStateFailed:
+			return fmt.Errorf("ONTAP job %d failed: %s", job.id, job.message)
+		}
+
+		if time.Since(start)+interval > maxWait {
+			return utils.VolumeCreatingError(fmt.Sprintf("ONTAP job %d is still working on volume %s", job.id, name))
+		}
+
+		log.WithFields(log.Fields{"volume": name, "jobId": job.id, "wait": interval}).Debug(
+			"Job not yet completed, waiting.")
+		time.Sleep(interval)
+		if interval *= 2; interval > asyncJobMaxPollInterval {
+			interval = asyncJobMaxPollInterval
+		}
+	}
+}
+
+// HousekeepingJob returns the job that polls running ONTAP jobs.
+func (t *AsyncJobTracker) HousekeepingJob() *HousekeepingJob {
+	return t.jobs.housekeepingJob(asyncJobPollJob, asyncJobPollPeriod)
+}
+
+// getOntapAsyncJobState reads a job's state from ONTAP.  ONTAP purges the records of finished jobs
+// after a while, so a job it no longer reports is judged by whether its volume exists.
+func getOntapAsyncJobState(client *api.Client, name string, id int) (jobReading, error) {
+
+	state, progress, err := client.JobState(id)
+	if err != nil {
+		return jobReading{}, err
+	}
+
+	switch state {
+	case "success":
+		return jobReading{state: jobStateComplete}, nil
+	case "failure", "error", "quit", "dead":
+		return jobReading{state: jobStateFailed, message: fmt.Sprintf("job state is %s", state)}, nil
+	case "":
+		exists, err := client.VolumeExists(name)
+		if err != nil {
+			return jobReading{}, err
+		} else if !exists {
+			return jobReading{state: jobStateFailed, message: "job ended without creating the volume"}, nil
+		}
+		return jobReading{state: jobStateComplete}, nil
+	default:
+		return jobReading{state: jobStateRunning, message: progress}, nil
+	}
+}
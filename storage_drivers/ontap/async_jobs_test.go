@@ -0,0 +1,200 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+// readAsyncJobStates reads the state of each job from states, failing to read jobs not listed.
+func readAsyncJobStates(states map[int]jobState) func(string, trackedJob) (jobReading, error) {
+	return func(_ string, job trackedJob) (jobReading, error) {
+		state, ok := states[job.id]
+		if !ok {
+			return jobReading{}, errors.New("job not found")
+		}
+		if state == jobStateFailed {
+			return jobReading{state: state, message: "job state is failure"}, nil
+		}
+		return jobReading{state: state}, nil
+	}
+}
+
+func TestAsyncJobTrackerWait(t *testing.T) {
+
+	tests := map[string]struct {
+		state        jobState
+		maxWait      time.Duration
+		valid        bool
+		creating     bool
+		runningJobID string
+	}{
+		"Job complete": {
+			state:   jobStateComplete,
+			maxWait: time.Second,
+			valid:   true,
+		},
+		"Job failed": {
+			state:   jobStateFailed,
+			maxWait: time.Second,
+		},
+		"Job outlasts the wait": {
+			state:        jobStateRunning,
+			maxWait:      10 * time.Millisecond,
+			creating:     true,
+			runningJobID: "1",
+		},
+		"Job status unreadable": {
+			maxWait:      10 * time.Millisecond,
+			creating:     true,
+			runningJobID: "1",
+		},
+	}
+	for name, test := range tests {
+		states := make(map[int]jobState)
+		if test.state != "" {
+			states[1] = test.state
+		}
+		tracker := NewAsyncJobTracker(nil)
+		tracker.pollInterval = time.Millisecond
+		tracker.jobs.read = readAsyncJobStates(states)
+
+		tracker.Track("vol1", storage.StorageJobOperationCloneCreate, 1)
+		err := tracker.Wait("vol1", test.maxWait)
+
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+			assert.Equal(t, test.creating, utils.IsVolumeCreatingError(err), name)
+		}
+		assert.True(t, tracker.Has("vol1"), name)
+		assert.Equal(t, storage.StorageJobOperationCloneCreate, tracker.Operation("vol1"), name)
+		assert.Equal(t, test.runningJobID, tracker.RunningJobID("vol1"), name)
+		assert.Error(t, tracker.Wait("vol2", test.maxWait), name)
+	}
+}
+
+func TestAsyncJobTrackerResume(t *testing.T) {
+
+	tests := map[string]struct {
+		trackedID    int
+		state        jobState
+		jobID        string
+		runningJobID string
+	}{
+		"Job recorded before a restart": {
+			jobID:        "7",
+			runningJobID: "7",
+		},
+		"Job already followed": {
+			trackedID: 7,
+			state:     jobStateComplete,
+			jobID:     "7",
+		},
+		"Job replaced": {
+			trackedID:    6,
+			state:        jobStateComplete,
+			jobID:        "7",
+			runningJobID: "7",
+		},
+		"Invalid job ID": {
+			jobID: "bogus",
+		},
+	}
+	for name, test := range tests {
+		tracker := NewAsyncJobTracker(nil)
+		tracker.jobs.read = readAsyncJobStates(map[int]jobState{test.trackedID: test.state})
+
+		if test.trackedID != 0 {
+			tracker.Track("vol1", storage.StorageJobOperationCloneCreate, test.trackedID)
+			tracker.jobs.run()
+		}
+		tracker.Resume("vol1", storage.StorageJobOperationCloneCreate, test.jobID)
+
+		assert.Equal(t, test.runningJobID, tracker.RunningJobID("vol1"), name)
+		assert.Equal(t, test.trackedID != 0 || test.runningJobID != "", tracker.Has("vol1"), name)
+
+		tracker.Forget("vol1")
+		assert.False(t, tracker.Has("vol1"), name)
+	}
+
+	var nilTracker *AsyncJobTracker
+	nilTracker.Track("vol1", storage.StorageJobOperationCloneCreate, 1)
+	nilTracker.Resume("vol1", storage.StorageJobOperationCloneCreate, "1")
+	nilTracker.Forget("vol1")
+	assert.False(t, nilTracker.Has("vol1"))
+	assert.Empty(t, nilTracker.Operation("vol1"))
+	assert.Empty(t, nilTracker.RunningJobID("vol1"))
+	assert.Empty(t, nilTracker.Jobs())
+}
+
+func TestAsyncJobTrackerRun(t *testing.T) {
+
+	tests := map[string]struct {
+		state   jobState
+		age     time.Duration
+		running bool
+		tracked bool
+		listed  bool
+	}{
+		"Job status unreadable": {
+			running: true,
+			tracked: true,
+			listed:  true,
+		},
+		"Job running": {
+			state:   jobStateRunning,
+			running: true,
+			tracked: true,
+			listed:  true,
+		},
+		"Job complete": {
+			state:   jobStateComplete,
+			tracked: true,
+		},
+		"Job failed": {
+			state:   jobStateFailed,
+			tracked: true,
+		},
+		"Job finished long ago": {
+			state: jobStateComplete,
+			age:   2 * asyncJobStatusRetention,
+		},
+	}
+	for name, test := range tests {
+		states := make(map[int]jobState)
+		if test.state != "" {
+			states[1] = test.state
+		}
+		tracker := NewAsyncJobTracker(nil)
+		tracker.jobs.read = readAsyncJobStates(states)
+
+		tracker.Track("vol1", storage.StorageJobOperationCloneCreate, 1)
+		tracker.jobs.run()
+		if test.age > 0 {
+			tracker.jobs.jobs["vol1"].finished = time.Now().Add(-test.age)
+			tracker.jobs.run()
+		}
+
+		assert.Equal(t, test.running, tracker.RunningJobID("vol1") != "", name)
+		assert.Equal(t, test.tracked, tracker.Has("vol1"), name)
+
+		jobs := tracker.Jobs()
+		if !test.listed {
+			assert.Empty(t, jobs, name)
+		} else if assert.Len(t, jobs, 1, name) {
+			assert.Equal(t, "vol1", jobs[0].InternalVolume, name)
+			assert.Equal(t, "1", jobs[0].JobID, name)
+			assert.Equal(t, storage.StorageJobOperationCloneCreate, jobs[0].Operation, name)
+			assert.NotEmpty(t, jobs[0].StartTime, name)
+		}
+	}
+}
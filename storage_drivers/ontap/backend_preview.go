@@ -0,0 +1,102 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+// PreviewStoragePools resolves the storage pools that an ONTAP backend would offer with the supplied
+// configuration, and validates them as the driver would.  The SVM is read to discover its aggregates
+// and data LIFs, and probed for the features it offers, but nothing is created on it and no background
+// work is started, so a configuration may be previewed before a backend is created or updated with it.
+func PreviewStoragePools(
+	context tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
+) (*storage.BackendPreview, error) {
+
+	if UsesMultipleSVMs(configJSON) {
+		return nil, utils.UnsupportedError("backends spanning several SVMs cannot be previewed")
+	}
+
+	config, err := InitializeOntapConfig(context, configJSON, commonConfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := InitializeOntapDriver(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		physicalPools, virtualPools map[string]*storage.Pool
+		backendName                 string
+	)
+
+	switch commonConfig.StorageDriverName {
+	case drivers.OntapNASStorageDriverName:
+		d := &NASStorageDriver{Config: *config, API: client}
+		if err = ValidateNASDriver(d.API, &d.Config); err != nil {
+			return nil, err
+		}
+		backendName = d.backendName()
+		d.physicalPools, d.virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
+			backendName)
+		if err != nil {
+			return nil, err
+		}
+		d.restrictFlexcachePools()
+		physicalPools, virtualPools = d.physicalPools, d.virtualPools
+
+	case drivers.OntapNASQtreeStorageDriverName:
+		d := &NASQtreeStorageDriver{Config: *config, API: client}
+		if err = ValidateNASDriver(d.API, &d.Config); err != nil {
+			return nil, err
+		}
+		backendName = d.backendName()
+		physicalPools, virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
+			backendName)
+
+	case drivers.OntapNASFlexGroupStorageDriverName:
+		d := &NASFlexGroupStorageDriver{Config: *config, API: client}
+		if err = ValidateNASDriver(d.API, &d.Config); err != nil {
+			return nil, err
+		}
+		backendName = d.backendName()
+		if err = d.initializeStoragePools(); err == nil {
+			physicalPools = map[string]*storage.Pool{d.physicalPool.Name: d.physicalPool}
+			virtualPools = d.virtualPools
+		}
+
+	case drivers.OntapSANStorageDriverName:
+		d := &SANStorageDriver{Config: *config, API: client}
+		backendName = d.backendName()
+		physicalPools, virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
+			backendName)
+
+	case drivers.OntapSANEconomyStorageDriverName:
+		d := &SANEconomyStorageDriver{Config: *config, API: client}
+		backendName = d.backendName()
+		physicalPools, virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
+			backendName)
+
+	default:
+		return nil, fmt.Errorf("unknown ONTAP storage driver: %s", commonConfig.StorageDriverName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not configure storage pools: %v", err)
+	}
+	if err = ValidateStoragePools(physicalPools, virtualPools, commonConfig.StorageDriverName); err != nil {
+		return nil, fmt.Errorf("storage pool validation failed: %v", err)
+	}
+
+	preview := storage.NewBackendPreview(backendName, commonConfig.StorageDriverName, physicalPools, virtualPools)
+	preview.Capabilities = client.Features()
+
+	return preview, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// cloneBaseSnapshotComment marks the snapshots Trident creates as the base of a clone, so that they
+	// may be found and deleted once no clone depends on them, even after Trident restarts.
+	cloneBaseSnapshotComment = "Created by Trident as the base of a clone"
+
+	// cloneSnapshotReapPeriod is how often base snapshots no longer in use are looked for.
+	cloneSnapshotReapPeriod = 1 * time.Hour
+
+	// cloneSnapshotGracePeriod keeps new base snapshots from being reaped while their clone is created.
+	cloneSnapshotGracePeriod = 10 * time.Minute
+)
+
+// baseSnapshot identifies a snapshot of a volume.
+type baseSnapshot struct {
+	volume   string
+	snapshot string
+}
+
+// markedSnapshot is a base snapshot found on the storage system.
+type markedSnapshot struct {
+	baseSnapshot
+	busy    bool
+	created time.Time
+}
+
+// CloneSnapshotReaper deletes the snapshots a driver created as the base of clones once the clones no
+// longer depend on them, that is once each clone has been split from its parent or deleted.  Snapshots
+// that are still busy when their clone is released, or that were created before Trident restarted, are
+// deleted by the reaper's housekeeping job.
+type CloneSnapshotReaper struct {
+	config  *drivers.OntapStorageDriverConfig
+	enabled bool
+	clones  map[string]baseSnapshot
+	mutex   sync.Mutex
+	now     func() time.Time
+
+	listSnapshots  func() ([]markedSnapshot, error)
+	deleteSnapshot func(snapshot, volume string) error
+}
+
+// NewCloneSnapshotReaper returns the reaper for a driver's base snapshots.  Base snapshots are kept
+// if the config sets retainCloneSnapshots.
+func NewCloneSnapshotReaper(config *drivers.OntapStorageDriverConfig, client *api.Client) *CloneSnapshotReaper {
+	r := &CloneSnapshotReaper{
+		config:  config,
+		enabled: !config.RetainCloneSnapshots,
+		clones:  make(map[string]baseSnapshot),
+		now:     time.Now,
+		listSnapshots: func() ([]markedSnapshot, error) {
+			return listMarkedSnapshots(client)
+		},
+		deleteSnapshot: func(snapshot, volume string) error {
+			response, err := client.SnapshotDelete(snapshot, volume)
+			return api.GetError(response, err)
+		},
+	}
+	return r
+}
+
+// Comment returns the comment with which a new base snapshot should be created, which is empty if
+// base snapshots are kept, so that they aren't reaped by another backend sharing the SVM.
+func (r *CloneSnapshotReaper) Comment() string {
+	if r == nil || !r.enabled {
+		return ""
+	}
+	return cloneBaseSnapshotComment
+}
+
+// Track records the base snapshot a driver created for a clone.
+func (r *CloneSnapshotReaper) Track(clone, volume, snapshot string) {
+	if r == nil || !r.enabled {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clones[clone] = baseSnapshot{volume: volume, snapshot: snapshot}
+}
+
+// Release deletes the base snapshot of a clone that has been split or deleted, if the driver created
+// it.  A snapshot that is still busy is left for the housekeeping job.
+func (r *CloneSnapshotReaper) Release(ctx context.Context, clone string) {
+	if r == nil || !r.enabled {
+		return
+	}
+
+	r.mutex.Lock()
+	base, ok := r.clones[clone]
+	delete(r.clones, clone)
+	r.mutex.Unlock()
+	if !ok {
+		return
+	}
+
+	r.delete(ctx, base)
+}
+
+// splitComplete releases the base snapshot of a clone that has been split from its parent.
+func (r *CloneSnapshotReaper) splitComplete(clone string) {
+	r.Release(utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal), clone)
+}
+
+// delete deletes a base snapshot, returning true if it is gone.
+func (r *CloneSnapshotReaper) delete(ctx context.Context, base baseSnapshot) bool {
+
+	logFields := log.Fields{"volume": base.volume, "snapshot": base.snapshot}
+
+	if err := r.deleteSnapshot(base.snapshot, base.volume); err != nil {
+		if zerr, ok := err.(api.ZapiError); ok && zerr.IsBusy() {
+			logc(ctx).WithFields(logFields).Debug("Clone base snapshot still in use, will delete it later.")
+		} else {
+			logc(ctx).WithFields(logFields).Warningf("Could not delete clone base snapshot. %v", err)
+		}
+		return false
+	}
+
+	observeCloneSnapshotReclaimed(r.config)
+	logc(ctx).WithFields(logFields).Info("Deleted clone base snapshot.")
+	return true
+}
+
+// reap deletes the base snapshots on the SVM that are no longer in use.
+func (r *CloneSnapshotReaper) reap() {
+
+	if !r.enabled {
+		return
+	}
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	snapshots, err := r.listSnapshots()
+	if err != nil {
+		logc(ctx).Warningf("Could not list clone base snapshots. %v", err)
+		return
+	}
+
+	now := r.now()
+	for _, snapshot := range snapshots {
+		if snapshot.busy || now.Sub(snapshot.created) < cloneSnapshotGracePeriod {
+			continue
+		}
+		r.delete(ctx, snapshot.baseSnapshot)
+	}
+}
+
+// HousekeepingJob returns the job that deletes base snapshots no longer in use.
+func (r *CloneSnapshotReaper) HousekeepingJob() *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         cloneSnapshotReapJob,
+		Interval:     cloneSnapshotReapPeriod,
+		InitialDelay: cloneSnapshotGracePeriod,
+		Jitter:       cloneSnapshotReapPeriod / housekeepingJitterDivisor,
+		Run:          r.reap,
+	}
+}
+
+// listMarkedSnapshots returns the base snapshots Trident created on the SVM.
+func listMarkedSnapshots(client *api.Client) ([]markedSnapshot, error) {
+
+	response, err := client.SnapshotListByComment(cloneBaseSnapshotComment)
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]markedSnapshot, 0)
+	if response.Result.AttributesListPtr == nil {
+		return snapshots, nil
+	}
+	for _, snap := range response.Result.AttributesListPtr.SnapshotInfoPtr {
+		if snap.NamePtr == nil || snap.VolumePtr == nil || snap.CommentPtr == nil ||
+			snap.Comment() != cloneBaseSnapshotComment {
+			continue
+		}
+		marked := markedSnapshot{baseSnapshot: baseSnapshot{volume: snap.Volume(), snapshot: snap.Name()}}
+		if snap.BusyPtr != nil {
+			marked.busy = snap.Busy()
+		}
+		if snap.AccessTimePtr != nil {
+			marked.created = time.Unix(int64(snap.AccessTime()), 0)
+		}
+		snapshots = append(snapshots, marked)
+	}
+	return snapshots, nil
+}
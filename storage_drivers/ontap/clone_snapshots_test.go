@@ -0,0 +1,175 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+// fakeBaseSnapshots stands in for the base snapshots on an SVM.
+type fakeBaseSnapshots map[baseSnapshot]*markedSnapshot
+
+func (s fakeBaseSnapshots) list() ([]markedSnapshot, error) {
+	marked := make([]markedSnapshot, 0)
+	for _, snapshot := range s {
+		marked = append(marked, *snapshot)
+	}
+	return marked, nil
+}
+
+func (s fakeBaseSnapshots) delete(snapshot, volume string) error {
+	base := baseSnapshot{volume: volume, snapshot: snapshot}
+	marked, ok := s[base]
+	if !ok {
+		return errors.New("snapshot not found")
+	}
+	if marked.busy {
+		return api.NewZapiError(azgo.SnapshotDeleteResponse{Result: azgo.SnapshotDeleteResponseResult{
+			ResultStatusAttr: "failed",
+			ResultErrnoAttr:  azgo.ESNAPSHOTBUSY,
+		}})
+	}
+	delete(s, base)
+	return nil
+}
+
+func TestCloneSnapshotReaperRelease(t *testing.T) {
+
+	base := baseSnapshot{volume: "vol1", snapshot: "snap1"}
+
+	tests := map[string]struct {
+		retain   bool
+		busy     bool
+		released string
+		comment  string
+		deleted  bool
+	}{
+		"Clone released": {
+			released: "clone1",
+			comment:  cloneBaseSnapshotComment,
+			deleted:  true,
+		},
+		"Not a tracked clone": {
+			released: "vol1",
+			comment:  cloneBaseSnapshotComment,
+		},
+		"Snapshot busy": {
+			busy:     true,
+			released: "clone1",
+			comment:  cloneBaseSnapshotComment,
+		},
+		"Snapshots retained": {
+			retain:   true,
+			released: "clone1",
+		},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.RetainCloneSnapshots = test.retain
+		snapshots := fakeBaseSnapshots{base: {baseSnapshot: base, busy: test.busy}}
+		reaper := NewCloneSnapshotReaper(config, nil)
+		reaper.listSnapshots = snapshots.list
+		reaper.deleteSnapshot = snapshots.delete
+
+		assert.Equal(t, test.comment, reaper.Comment(), name)
+
+		reaper.Track("clone1", "vol1", "snap1")
+		reaper.Release(context.Background(), test.released)
+
+		if test.deleted {
+			assert.NotContains(t, snapshots, base, name)
+		} else {
+			assert.Contains(t, snapshots, base, name)
+		}
+
+		// A busy snapshot is no longer tracked, but is left for the housekeeping job
+		if test.released == "clone1" {
+			assert.Empty(t, reaper.clones, name)
+		}
+	}
+
+	var nilReaper *CloneSnapshotReaper
+	assert.Empty(t, nilReaper.Comment())
+	nilReaper.Track("clone1", "vol1", "snap1")
+	nilReaper.Release(context.Background(), "clone1")
+}
+
+func TestCloneSnapshotReaperReap(t *testing.T) {
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	base := baseSnapshot{volume: "vol1", snapshot: "snap1"}
+
+	tests := map[string]struct {
+		retain  bool
+		age     time.Duration
+		busy    bool
+		deleted bool
+	}{
+		"Idle snapshot":            {age: time.Hour, deleted: true},
+		"Busy snapshot":            {age: time.Hour, busy: true},
+		"Snapshot in grace period": {age: time.Minute},
+		"Snapshots retained":       {retain: true, age: time.Hour},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.RetainCloneSnapshots = test.retain
+		snapshots := fakeBaseSnapshots{base: {baseSnapshot: base, busy: test.busy, created: now.Add(-test.age)}}
+		reaper := NewCloneSnapshotReaper(config, nil)
+		reaper.now = func() time.Time { return now }
+		reaper.listSnapshots = snapshots.list
+		reaper.deleteSnapshot = snapshots.delete
+
+		reaper.reap()
+		if test.deleted {
+			assert.NotContains(t, snapshots, base, name)
+		} else {
+			assert.Contains(t, snapshots, base, name)
+		}
+	}
+}
+
+func TestCloneSplitTrackerReleasesBaseSnapshot(t *testing.T) {
+
+	base := baseSnapshot{volume: "vol1", snapshot: "snap1"}
+
+	tests := map[string]struct {
+		ontapState jobState
+		deleted    bool
+	}{
+		"Split running":  {ontapState: jobStateRunning},
+		"Split failed":   {ontapState: jobStateFailed},
+		"Split complete": {ontapState: jobStateComplete, deleted: true},
+	}
+	for name, test := range tests {
+		snapshots := fakeBaseSnapshots{base: {baseSnapshot: base}}
+		reaper := NewCloneSnapshotReaper(newTestOntapSANConfig(), nil)
+		reaper.listSnapshots = snapshots.list
+		reaper.deleteSnapshot = snapshots.delete
+		reaper.Track("clone1", "vol1", "snap1")
+
+		states := make(map[string]jobState)
+		tracker := NewCloneSplitTracker(newTestOntapSANConfig(), nil)
+		tracker.jobs.start = startCloneSplits(states)
+		tracker.jobs.read = readCloneSplitStates(states)
+		tracker.jobs.failedReadingLimit = 1
+		tracker.OnComplete(reaper.splitComplete)
+
+		assert.NoError(t, tracker.Start("clone1"), name)
+		states["clone1"] = test.ontapState
+		tracker.jobs.run()
+
+		if test.deleted {
+			assert.NotContains(t, snapshots, base, name)
+		} else {
+			assert.Contains(t, snapshots, base, name)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+const (
+	defaultCloneSplitConcurrency = uint64(4)
+
+	// A running split is considered failed if ONTAP stops reporting it while the volume is still a clone
+	maxCloneSplitStatusMisses = 3
+
+	// Finished splits are remembered long enough for their outcome to be seen in the volume's status
+	cloneSplitStatusRetention = 1 * time.Hour
+)
+
+// CloneSplitTracker starts clone splits on behalf of a driver, running no more than a configured
+// number at once so that many simultaneous splits can't starve an aggregate.  Splits beyond the
+// limit are queued.  The tracker's housekeeping job polls each running split, starts queued splits
+// as running ones finish, and notifies a handler (if any) when a split completes or fails.
+type CloneSplitTracker struct {
+	client    *api.Client
+	jobs      *jobTracker
+	handler   storage.CloneSplitHandler
+	completed func(name string)
+	mutex     sync.Mutex
+}
+
+// NewCloneSplitTracker returns a tracker for a driver's clone splits.  The concurrency limit is read
+// from the config, falling back to the default if missing or invalid.
+func NewCloneSplitTracker(config *drivers.OntapStorageDriverConfig, client *api.Client) *CloneSplitTracker {
+
+	concurrency := defaultCloneSplitConcurrency
+	if config.CloneSplitConcurrency != "" {
+		i, err := strconv.ParseUint(config.CloneSplitConcurrency, 10, 64)
+		if err != nil || i == 0 {
+			log.WithField("concurrency", config.CloneSplitConcurrency).Warnf(
+				"Invalid clone split concurrency. %v", err)
+		} else {
+			concurrency = i
+		}
+	}
+	log.WithField("concurrency", concurrency).Debug("Configured clone split concurrency.")
+
+	t := &CloneSplitTracker{client: client}
+	t.jobs = &jobTracker{
+		description:        "clone split",
+		maxConcurrent:      int(concurrency),
+		failedReadingLimit: maxCloneSplitStatusMisses,
+		readFailureLimit:   maxJobStatusReadFailures,
+		retention:          cloneSplitStatusRetention,
+		jobs:               make(map[string]*trackedJob),
+		start:              t.startOntapSplit,
+		read:               t.getOntapSplitStatus,
+		finished:           t.notify,
+	}
+	return t
+}
+
+// SetHandler registers a function to be called whenever a split completes or fails.
+func (t *CloneSplitTracker) SetHandler(handler storage.CloneSplitHandler) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handler = handler
+}
+
+// OnComplete registers a function to be called within the driver whenever a split completes, before
+// the handler is notified.
+func (t *CloneSplitTracker) OnComplete(completed func(name string)) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.completed = completed
+}
+
+// Status returns a copy of the latest status of a volume's split, or nil if the volume hasn't
+// been split recently.
+func (t *CloneSplitTracker) Status(name string) *storage.CloneSplitStatus {
+	if t == nil {
+		return nil
+	}
+	split, ok := t.jobs.get(name)
+	if !ok {
+		return nil
+	}
+	return cloneSplitStatus(split)
+}
+
+// Jobs returns the splits still queued or running.
+func (t *CloneSplitTracker) Jobs() []*storage.StorageJob {
+	if t == nil {
+		return make([]*storage.StorageJob, 0)
+	}
+	return t.jobs.storageJobs()
+}
+
+// Start begins splitting a clone from its parent, or queues the split if the concurrency limit has
+// been reached.  Starting a split that is already queued or running has no effect.  An error is
+// returned only if ONTAP refused to start the split.
+func (t *CloneSplitTracker) Start(name string) error {
+	if _, err := t.jobs.begin(name, trackedJob{operation: storage.StorageJobOperationCloneSplit}); err != nil {
+		return fmt.Errorf("error splitting clone: %v", err)
+	}
+	return nil
+}
+
+// Resume follows a split that may have been requested before Trident restarted.  The split is started
+// again, or queued, unless ONTAP is still running it or the volume is no longer a clone.
+func (t *CloneSplitTracker) Resume(name string) error {
+
+	split := trackedJob{operation: storage.StorageJobOperationCloneSplit}
+	reading, err := t.jobs.read(name, split)
+	if err != nil {
+		return fmt.Errorf("error reading clone split status: %v", err)
+	}
+
+	switch reading.state {
+	case jobStateComplete:
+		return nil
+	case jobStateRunning:
+		split.percent = reading.percent
+		t.jobs.resume(name, split)
+		return nil
+	default:
+		return t.Start(name)
+	}
+}
+
+func (t *CloneSplitTracker) startOntapSplit(name string, _ trackedJob) error {
+	splitResponse, err := t.client.VolumeCloneSplitStart(name)
+	return api.GetError(splitResponse, err)
+}
+
+// getOntapSplitStatus reads a split's progress from ONTAP.  Once ONTAP no longer reports a split,
+// the split either finished (the volume no longer has a parent) or stopped (it still does).
+func (t *CloneSplitTracker) getOntapSplitStatus(name string, _ trackedJob) (jobReading, error) {
+
+	statusResponse, err := t.client.VolumeCloneSplitStatus(name)
+	if err = api.GetError(statusResponse, err); err == nil && statusResponse.Result.CloneSplitDetailsPtr != nil {
+
+		// A FlexGroup reports each of its constituents, so average them
+		details := statusResponse.Result.CloneSplitDetailsPtr.CloneSplitDetailInfo()
+		total, count := 0, 0
+		for _, detail := range details {
+			if detail.BlockPercentageCompletePtr != nil {
+				total += detail.BlockPercentageComplete()
+				count++
+			}
+		}
+		if count > 0 {
+			return jobReading{state: jobStateRunning, percent: total / count}, nil
+		}
+	}
+
+	isClone, err := t.client.VolumeIsClone(name)
+	if err != nil {
+		return jobReading{}, err
+	} else if !isClone {
+		return jobReading{state: jobStateComplete, percent: 100}, nil
+	}
+	return jobReading{
+		state:   jobStateFailed,
+		message: "clone split stopped before the volume was split from its parent",
+	}, nil
+}
+
+// notify tells the driver and the handler about a split that finished.
+func (t *CloneSplitTracker) notify(name string, split trackedJob) {
+
+	t.mutex.Lock()
+	handler, completed := t.handler, t.completed
+	t.mutex.Unlock()
+
+	if completed != nil && split.state == jobStateComplete {
+		completed(name)
+	}
+	if handler != nil {
+		handler(name, cloneSplitStatus(split))
+	}
+}
+
+// HousekeepingJob returns the job that polls running clone splits and starts queued ones.
+func (t *CloneSplitTracker) HousekeepingJob() *HousekeepingJob {
+	return t.jobs.housekeepingJob(cloneSplitPollJob, cloneSplitPollPeriod)
+}
+
+func cloneSplitStatus(split trackedJob) *storage.CloneSplitStatus {
+	return &storage.CloneSplitStatus{
+		State:           storage.CloneSplitState(split.state),
+		PercentComplete: split.percent,
+		StartTime:       formatJobTime(split.started),
+		EndTime:         formatJobTime(split.finished),
+		Message:         split.message,
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+// startCloneSplits records each split started in states, refusing to start a split of "refused".
+func startCloneSplits(states map[string]jobState) func(string, trackedJob) error {
+	return func(name string, _ trackedJob) error {
+		if name == "refused" {
+			return errors.New("clone split refused")
+		}
+		states[name] = jobStateRunning
+		return nil
+	}
+}
+
+// readCloneSplitStates reads the state of each split from states, failing to read splits not listed.
+func readCloneSplitStates(states map[string]jobState) func(string, trackedJob) (jobReading, error) {
+	return func(name string, _ trackedJob) (jobReading, error) {
+		switch states[name] {
+		case "":
+			return jobReading{}, errors.New("clone split status unavailable")
+		case jobStateFailed:
+			return jobReading{state: jobStateFailed, message: "clone split stopped"}, nil
+		}
+		return jobReading{state: states[name], percent: 50}, nil
+	}
+}
+
+func TestNewCloneSplitTracker(t *testing.T) {
+
+	tests := map[string]struct {
+		concurrency   string
+		maxConcurrent int
+	}{
+		"Default":      {concurrency: "", maxConcurrent: int(defaultCloneSplitConcurrency)},
+		"Configured":   {concurrency: "2", maxConcurrent: 2},
+		"Zero":         {concurrency: "0", maxConcurrent: int(defaultCloneSplitConcurrency)},
+		"Not a number": {concurrency: "lots", maxConcurrent: int(defaultCloneSplitConcurrency)},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.CloneSplitConcurrency = test.concurrency
+
+		tracker := NewCloneSplitTracker(config, nil)
+		assert.Equal(t, test.maxConcurrent, tracker.jobs.maxConcurrent, name)
+	}
+
+	var nilTracker *CloneSplitTracker
+	assert.Nil(t, nilTracker.Status("vol1"))
+	assert.Empty(t, nilTracker.Jobs())
+	nilTracker.SetHandler(nil)
+	nilTracker.OnComplete(nil)
+}
+
+func TestCloneSplitTrackerStart(t *testing.T) {
+
+	tests := map[string]struct {
+		concurrency string
+		volumes     []string
+		states      map[string]storage.CloneSplitState
+		queued      int
+		valid       bool
+	}{
+		"Under the limit": {
+			concurrency: "2",
+			volumes:     []string{"vol1", "vol2"},
+			states: map[string]storage.CloneSplitState{
+				"vol1": storage.CloneSplitStateRunning,
+				"vol2": storage.CloneSplitStateRunning,
+			},
+			valid: true,
+		},
+		"Limit reached": {
+			concurrency: "1",
+			volumes:     []string{"vol1", "vol2"},
+			states: map[string]storage.CloneSplitState{
+				"vol1": storage.CloneSplitStateRunning,
+				"vol2": storage.CloneSplitStateQueued,
+			},
+			queued: 1,
+			valid:  true,
+		},
+		"Started again while queued": {
+			concurrency: "1",
+			volumes:     []string{"vol1", "vol2", "vol2"},
+			states: map[string]storage.CloneSplitState{
+				"vol1": storage.CloneSplitStateRunning,
+				"vol2": storage.CloneSplitStateQueued,
+			},
+			queued: 1,
+			valid:  true,
+		},
+		"Started again while running": {
+			concurrency: "1",
+			volumes:     []string{"vol1", "vol1"},
+			states:      map[string]storage.CloneSplitState{"vol1": storage.CloneSplitStateRunning},
+			valid:       true,
+		},
+		"Refused by ONTAP": {
+			concurrency: "1",
+			volumes:     []string{"refused"},
+			states:      map[string]storage.CloneSplitState{"refused": storage.CloneSplitStateFailed},
+		},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.CloneSplitConcurrency = test.concurrency
+		states := make(map[string]jobState)
+		tracker := NewCloneSplitTracker(config, nil)
+		tracker.jobs.start = startCloneSplits(states)
+		tracker.jobs.read = readCloneSplitStates(states)
+
+		var err error
+		for _, volume := range test.volumes {
+			if err = tracker.Start(volume); err != nil {
+				break
+			}
+		}
+
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+		listed := 0
+		for volume, state := range test.states {
+			status := tracker.Status(volume)
+			if assert.NotNil(t, status, name) {
+				assert.Equal(t, state, status.State, name)
+			}
+			if !state.IsDone() {
+				listed++
+			}
+		}
+		assert.Len(t, tracker.jobs.queue, test.queued, name)
+		assert.Len(t, tracker.Jobs(), listed, name)
+	}
+}
+
+func TestCloneSplitTrackerRun(t *testing.T) {
+
+	tests := map[string]struct {
+		ontapState    jobState
+		polls         int
+		state         storage.CloneSplitState
+		notified      bool
+		queuedStarted bool
+	}{
+		"Split in progress": {
+			ontapState: jobStateRunning,
+			polls:      1,
+			state:      storage.CloneSplitStateRunning,
+		},
+		"Split complete": {
+			ontapState:    jobStateComplete,
+			polls:         1,
+			state:         storage.CloneSplitStateComplete,
+			notified:      true,
+			queuedStarted: true,
+		},
+		"Split briefly unreported": {
+			ontapState: jobStateFailed,
+			polls:      maxCloneSplitStatusMisses - 1,
+			state:      storage.CloneSplitStateRunning,
+		},
+		"Split stopped": {
+			ontapState:    jobStateFailed,
+			polls:         maxCloneSplitStatusMisses,
+			state:         storage.CloneSplitStateFailed,
+			notified:      true,
+			queuedStarted: true,
+		},
+		"Split status briefly unreadable": {
+			polls: maxJobStatusReadFailures - 1,
+			state: storage.CloneSplitStateRunning,
+		},
+		"Split status never readable": {
+			polls:         maxJobStatusReadFailures,
+			state:         storage.CloneSplitStateFailed,
+			notified:      true,
+			queuedStarted: true,
+		},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.CloneSplitConcurrency = "1"
+		states := make(map[string]jobState)
+		tracker := NewCloneSplitTracker(config, nil)
+		tracker.jobs.start = startCloneSplits(states)
+		tracker.jobs.read = readCloneSplitStates(states)
+
+		notified := make(map[string]storage.CloneSplitState)
+		tracker.SetHandler(func(name string, status *storage.CloneSplitStatus) {
+			notified[name] = status.State
+		})
+		completed := make([]string, 0)
+		tracker.OnComplete(func(name string) {
+			completed = append(completed, name)
+		})
+
+		// The second split waits for the first
+		assert.NoError(t, tracker.Start("vol1"), name)
+		assert.NoError(t, tracker.Start("vol2"), name)
+		states["vol1"] = test.ontapState
+
+		for i := 0; i < test.polls; i++ {
+			tracker.jobs.run()
+		}
+
+		status := tracker.Status("vol1")
+		assert.Equal(t, test.state, status.State, name)
+		if test.state == storage.CloneSplitStateRunning {
+			assert.Empty(t, status.EndTime, name)
+		} else {
+			assert.NotEmpty(t, status.EndTime, name)
+		}
+		if test.state == storage.CloneSplitStateFailed {
+			assert.NotEmpty(t, status.Message, name)
+		}
+
+		if test.notified {
+			assert.Equal(t, map[string]storage.CloneSplitState{"vol1": test.state}, notified, name)
+		} else {
+			assert.Empty(t, notified, name)
+		}
+		if test.state == storage.CloneSplitStateComplete {
+			assert.Equal(t, []string{"vol1"}, completed, name)
+		} else {
+			assert.Empty(t, completed, name)
+		}
+
+		if test.queuedStarted {
+			assert.Equal(t, storage.CloneSplitStateRunning, tracker.Status("vol2").State, name)
+			assert.Empty(t, tracker.jobs.queue, name)
+			assert.Len(t, tracker.Jobs(), 1, name)
+
+			// A finished split may be started again
+			assert.NoError(t, tracker.Start("vol1"), name)
+			assert.Equal(t, storage.CloneSplitStateQueued, tracker.Status("vol1").State, name)
+		} else {
+			assert.Equal(t, storage.CloneSplitStateQueued, tracker.Status("vol2").State, name)
+			assert.Len(t, tracker.Jobs(), 2, name)
+		}
+	}
+}
+
+func TestResumeCloneSplit(t *testing.T) {
+
+	tests := map[string]struct {
+		volConfig     *storage.VolumeConfig
+		backendSplit  string
+		ontapState    storage.CloneSplitState
+		expectedState storage.CloneSplitState
+	}{
+		"Not a clone": {
+			volConfig:    &storage.VolumeConfig{InternalName: "vol1"},
+			backendSplit: "true",
+		},
+		"Clone not to be split": {
+			volConfig:    &storage.VolumeConfig{InternalName: "vol1", CloneSourceVolume: "src", SplitOnClone: "false"},
+			backendSplit: "true",
+		},
+		"Clone split by the backend's default, split stopped": {
+			volConfig:     &storage.VolumeConfig{InternalName: "vol1", CloneSourceVolume: "src"},
+			backendSplit:  "true",
+			ontapState:    storage.CloneSplitStateFailed,
+			expectedState: storage.CloneSplitStateRunning,
+		},
+		"Clone split by request, split running": {
+			volConfig:     &storage.VolumeConfig{InternalName: "vol1", CloneSourceVolume: "src", SplitOnClone: "true"},
+			backendSplit:  "false",
+			ontapState:    storage.CloneSplitStateRunning,
+			expectedState: storage.CloneSplitStateRunning,
+		},
+		"Clone split by request, split complete": {
+			volConfig:    &storage.VolumeConfig{InternalName: "vol1", CloneSourceVolume: "src", SplitOnClone: "true"},
+			backendSplit: "false",
+			ontapState:   storage.CloneSplitStateComplete,
+		},
+	}
+	for name, test := range tests {
+		config := newTestOntapSANConfig()
+		config.SplitOnClone = test.backendSplit
+
+		tracker := NewCloneSplitTracker(config, nil)
+		tracker.jobs.start = func(string, trackedJob) error { return nil }
+		tracker.jobs.read = func(string, trackedJob) (jobReading, error) {
+			return jobReading{state: jobState(test.ontapState)}, nil
+		}
+
+		assert.NoError(t, resumeCloneSplit(context.Background(), config, test.volConfig, tracker), name)
+		if test.expectedState == "" {
+			assert.Nil(t, tracker.Status("vol1"), name)
+		} else {
+			assert.Equal(t, test.expectedState, tracker.Status("vol1").State, name)
+		}
+	}
+}
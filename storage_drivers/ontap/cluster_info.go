@@ -0,0 +1,120 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+// clusterInfoTTL is how long discovered cluster details are reused before they are read again, so
+// that an ONTAP upgrade is noticed by backends initialized afterward.
+const clusterInfoTTL = 10 * time.Minute
+
+// clusterInfo holds details of an ONTAP cluster that rarely change.  Either field may be empty if it
+// has not been discovered yet.
+type clusterInfo struct {
+	ontapiVersion string
+	serialNumbers []string
+	expires       time.Time
+}
+
+// clusterInfoCache remembers the details discovered through each management LIF, so that the many
+// backends sharing a cluster, and backends being updated, need not each read them again.
+type clusterInfoCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]*clusterInfo
+	mutex   sync.Mutex
+}
+
+var ontapClusterInfo = newClusterInfoCache(clusterInfoTTL)
+
+func newClusterInfoCache(ttl time.Duration) *clusterInfoCache {
+	return &clusterInfoCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*clusterInfo),
+	}
+}
+
+// get returns a copy of the unexpired details known for a management LIF.
+func (c *clusterInfoCache) get(managementLIF string) clusterInfo {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[managementLIF]
+	if !ok {
+		return clusterInfo{}
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, managementLIF)
+		return clusterInfo{}
+	}
+	return *entry
+}
+
+// update records details discovered for a management LIF.  Details the update function leaves alone
+// are kept, and each update extends the life of the entry.
+func (c *clusterInfoCache) update(managementLIF string, update func(info *clusterInfo)) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[managementLIF]
+	if !ok || c.now().After(entry.expires) {
+		entry = &clusterInfo{}
+		c.entries[managementLIF] = entry
+	}
+	update(entry)
+	entry.expires = c.now().Add(c.ttl)
+}
+
+// discoverOntapiVersion reads the ONTAPI version through the client, unless another backend using the
+// same management LIF recently did.
+func discoverOntapiVersion(client *api.Client, config *drivers.OntapStorageDriverConfig) (string, error) {
+
+	if cached := ontapClusterInfo.get(config.ManagementLIF).ontapiVersion; cached != "" {
+		client.SetOntapiVersion(cached)
+		return cached, nil
+	}
+
+	ontapi, err := client.SystemGetOntapiVersion()
+	if err != nil {
+		return "", err
+	}
+	ontapClusterInfo.update(config.ManagementLIF, func(info *clusterInfo) { info.ontapiVersion = ontapi })
+	return ontapi, nil
+}
+
+// discoverSerialNumbers fills in the controller serial numbers of the config.  They are only reported,
+// so unless they were recently discovered they are listed in the background rather than delaying the
+// backend's initialization.
+func discoverSerialNumbers(client *api.Client, config *drivers.OntapStorageDriverConfig) {
+
+	if cached := ontapClusterInfo.get(config.ManagementLIF).serialNumbers; len(cached) > 0 {
+		config.SerialNumbers = cached
+		return
+	}
+
+	go func() {
+		serialNumbers, err := client.NodeListSerialNumbers()
+		if err != nil {
+			log.Warnf("Could not determine controller serial numbers. %v", err)
+			return
+		}
+		log.WithFields(log.Fields{
+			"serialNumbers": strings.Join(serialNumbers, ","),
+		}).Info("Controller serial numbers.")
+
+		config.SerialNumbers = serialNumbers
+		ontapClusterInfo.update(config.ManagementLIF, func(info *clusterInfo) { info.serialNumbers = serialNumbers })
+	}()
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterInfoCache(t *testing.T) {
+
+	now := time.Now()
+	cache := newClusterInfoCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	assert.Equal(t, clusterInfo{}, cache.get("10.0.0.1"))
+
+	cache.update("10.0.0.1", func(info *clusterInfo) { info.ontapiVersion = "1.170" })
+	cache.update("10.0.0.1", func(info *clusterInfo) { info.serialNumbers = []string{"4114", "4115"} })
+
+	info := cache.get("10.0.0.1")
+	assert.Equal(t, "1.170", info.ontapiVersion)
+	assert.Equal(t, []string{"4114", "4115"}, info.serialNumbers)
+	assert.Equal(t, clusterInfo{}, cache.get("10.0.0.2"))
+
+	// Expired details are discovered again
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, clusterInfo{}, cache.get("10.0.0.1"))
+
+	cache.update("10.0.0.1", func(info *clusterInfo) { info.ontapiVersion = "1.180" })
+	info = cache.get("10.0.0.1")
+	assert.Equal(t, "1.180", info.ontapiVersion)
+	assert.Empty(t, info.serialNumbers)
+}
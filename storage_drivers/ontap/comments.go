@@ -0,0 +1,243 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+const (
+	volumeCommentTemplate = "volume comment"
+
+	// ONTAP limits volume and LUN comments to 1023 characters
+	maxVolumeCommentLength = 1023
+
+	// creationTagPrefix marks the UUID of the request that created a Flexvol in the Flexvol's comment,
+	// so that a create retried after timing out can recognize a Flexvol left behind by an earlier attempt
+	creationTagPrefix = "trident-uuid:"
+
+	// backendTagPrefix marks the UUID of the backend that created a Flexvol, ahead of the creation tag,
+	// so that the Flexvols of other backends and Trident instances sharing the SVM may be told apart
+	backendTagPrefix = "trident-backend:"
+)
+
+// ValidateVolumeCommentTemplate ensures a volumeCommentTemplate backend option is well formed.
+func ValidateVolumeCommentTemplate(commentTemplate string) error {
+	if commentTemplate == "" {
+		return nil
+	}
+	_, err := parseVolumeTemplate(volumeCommentTemplate, commentTemplate)
+	return err
+}
+
+// getVolumeComment renders the backend's volumeCommentTemplate for a volume.  An empty
+// comment is returned if the backend does not specify a template.
+func getVolumeComment(config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig) (string, error) {
+
+	if config.VolumeCommentTemplate == "" {
+		return "", nil
+	}
+
+	tmpl, err := parseVolumeTemplate(volumeCommentTemplate, config.VolumeCommentTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	comment, err := executeVolumeTemplate(tmpl, volumeTemplateData(*config.StoragePrefix, volConfig))
+	if err != nil {
+		return "", err
+	}
+
+	if len(comment) > maxVolumeCommentLength {
+		comment = comment[:maxVolumeCommentLength]
+	}
+
+	return comment, nil
+}
+
+// getFlexvolComment returns the comment for a Flexvol or FlexGroup, which is the rendered
+// volumeCommentTemplate followed by the volume's creation tag.  The template is informational only,
+// so a failure to render it is logged and the creation tag is used alone.
+func getFlexvolComment(config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig) string {
+
+	comment, err := getVolumeComment(config, volConfig)
+	if err != nil {
+		log.WithField("volume", volConfig.InternalName).Warningf("Could not render volume comment; %v", err)
+		comment = ""
+	}
+	return addCreationTag(comment, volConfig.UUID, config.BackendUUID)
+}
+
+// addCreationTag appends the creation tag for a request UUID, preceded by the backend's tag if its UUID is
+// known, to a comment, truncating the rest of the comment if needed to keep the tags intact.
+func addCreationTag(comment, uuid, backendUUID string) string {
+
+	if uuid == "" {
+		return comment
+	}
+
+	tag := creationTagPrefix + uuid
+	if backendUUID != "" {
+		tag = backendTagPrefix + backendUUID + " " + tag
+	}
+	if comment == "" {
+		return tag
+	}
+	if maxLength := maxVolumeCommentLength - len(tag) - 1; len(comment) > maxLength {
+		comment = comment[:maxLength]
+	}
+	return comment + " " + tag
+}
+
+// getCreationUUID returns the request UUID tagged in a comment, or an empty string if there is no tag.
+func getCreationUUID(comment string) string {
+	return getTaggedUUID(comment, creationTagPrefix)
+}
+
+// getCreationBackendUUID returns the backend UUID tagged in a comment, or an empty string if there is no
+// tag, as for Flexvols created by earlier releases.
+func getCreationBackendUUID(comment string) string {
+	return getTaggedUUID(comment, backendTagPrefix)
+}
+
+// getTaggedUUID returns the UUID following the last instance of a tag prefix in a comment.
+func getTaggedUUID(comment, prefix string) string {
+
+	index := strings.LastIndex(comment, prefix)
+	if index < 0 {
+		return ""
+	}
+	fields := strings.Fields(comment[index+len(prefix):])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// getFlexvolCreationUUID returns the request UUID tagged in an existing Flexvol's comment.
+func getFlexvolCreationUUID(client api.OntapAPI, name string) (string, error) {
+
+	flexvol, err := client.VolumeGet(name)
+	if err != nil {
+		return "", err
+	}
+	if flexvol.VolumeIdAttributesPtr == nil {
+		return "", fmt.Errorf("error reading volume id attributes for volume %s", name)
+	}
+	return getCreationUUID(flexvol.VolumeIdAttributesPtr.Comment()), nil
+}
+
+// checkExistingFlexvol decides what a create should do about a Flexvol that already exists with the
+// name it would create.  A Flexvol tagged as created for a different request is never touched, so an
+// error is returned.  A Flexvol created for this request is completed by returning a VolumeExistsError,
+// unless isComplete reports it was left unfinished, in which case it is destroyed and nil is returned
+// so the create starts over.  An untagged Flexvol, such as one created by an earlier release, is
+// completed if untaggedIsOwned is set and rejected otherwise.
+func checkExistingFlexvol(
+	ctx context.Context, client api.OntapAPI, name, uuid string, untaggedIsOwned bool,
+	isComplete func() (bool, error),
+) error {
+
+	creationUUID, err := getFlexvolCreationUUID(client, name)
+	if err != nil {
+		return fmt.Errorf("error checking for existing volume: %v", err)
+	}
+
+	switch {
+	case creationUUID == "" && !untaggedIsOwned, creationUUID != "" && creationUUID != uuid:
+		return drivers.NewResourceExistsError(fmt.Sprintf("volume %s already exists", name), nil)
+	case creationUUID == "" || isComplete == nil:
+		return drivers.NewVolumeExistsError(name)
+	}
+
+	complete, err := isComplete()
+	if err != nil {
+		return fmt.Errorf("error checking existing volume %s; %v", name, err)
+	} else if complete {
+		return drivers.NewVolumeExistsError(name)
+	}
+
+	logc(ctx).WithField("volume", name).Warning("Destroying volume left unfinished by an earlier create.")
+	response, err := client.VolumeDestroy(name, true)
+	if err = api.GetError(response, err); err != nil {
+		return fmt.Errorf("error destroying unfinished volume %s; %v", name, err)
+	}
+	return nil
+}
+
+// cleanupFailedFlexvolCreate destroys a Flexvol left behind by a failed create, but only if it is
+// tagged as created for the same request, so that a volume created by anyone else is never touched.
+// Cleanup is best effort, as a retried create will find and finish or destroy any Flexvol left over.
+func cleanupFailedFlexvolCreate(ctx context.Context, client api.OntapAPI, name, uuid string) {
+
+	if uuid == "" {
+		return
+	}
+	if exists, err := client.VolumeExists(name); err != nil || !exists {
+		return
+	}
+	if creationUUID, err := getFlexvolCreationUUID(client, name); err != nil || creationUUID != uuid {
+		return
+	}
+
+	logc(ctx).WithField("volume", name).Debug("Destroying volume left by failed create.")
+	response, err := client.VolumeDestroy(name, true)
+	if err = api.GetError(response, err); err != nil {
+		logc(ctx).WithField("volume", name).Warningf("Could not destroy volume left by failed create; %v", err)
+	}
+}
+
+// updateFlexvolComment writes the rendered volumeCommentTemplate and creation tag to a Flexvol or
+// FlexGroup.  Comments are informational only, so failures are logged rather than returned.  Volumes
+// imported without being managed are left untouched.
+func updateFlexvolComment(
+	client *api.Client, config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig,
+) {
+
+	if volConfig.ImportNotManaged {
+		return
+	}
+
+	comment := getFlexvolComment(config, volConfig)
+	if comment == "" {
+		return
+	}
+
+	response, err := client.VolumeSetComment(volConfig.InternalName, comment)
+	if err = api.GetError(response, err); err != nil {
+		log.WithField("volume", volConfig.InternalName).Warningf("Could not set volume comment; %v", err)
+	}
+}
+
+// updateLUNComment writes the rendered volumeCommentTemplate to a LUN.  Comments are
+// informational only, so failures are logged rather than returned.  LUNs imported without
+// being managed are left untouched.
+func updateLUNComment(
+	client *api.Client, config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig, lunPath string,
+) {
+
+	if volConfig.ImportNotManaged {
+		return
+	}
+
+	comment, err := getVolumeComment(config, volConfig)
+	if err != nil {
+		log.WithField("LUN", lunPath).Warningf("Could not render LUN comment; %v", err)
+		return
+	} else if comment == "" {
+		return
+	}
+
+	response, err := client.LunSetComment(lunPath, comment)
+	if err = api.GetError(response, err); err != nil {
+		log.WithField("LUN", lunPath).Warningf("Could not set LUN comment; %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+func TestGetVolumeComment(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	volConfig := &storage.VolumeConfig{Name: "pvc-1234", Namespace: "prod", RequestName: "data", StorageClass: "gold"}
+
+	comment, err := getVolumeComment(config, volConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "", comment, "expected no comment without a template")
+
+	config.VolumeCommentTemplate = `{"pvc":"{{.pvcName}}","namespace":"{{.namespace}}","sc":"{{.storageClass}}"}`
+	comment, err = getVolumeComment(config, volConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"pvc":"data","namespace":"prod","sc":"gold"}`, comment)
+
+	config.VolumeCommentTemplate = strings.Repeat("x", maxVolumeCommentLength+10)
+	comment, err = getVolumeComment(config, volConfig)
+	assert.NoError(t, err)
+	assert.Len(t, comment, maxVolumeCommentLength)
+
+	assert.Error(t, ValidateVolumeCommentTemplate("{{.labels}}"), "expected unknown token error")
+}
+
+func TestCreationTag(t *testing.T) {
+
+	assert.Equal(t, "comment", addCreationTag("comment", "", "backend"), "expected no tag without a UUID")
+	assert.Equal(t, "trident-uuid:1234", addCreationTag("", "1234", ""))
+	assert.Equal(t, "comment trident-uuid:1234", addCreationTag("comment", "1234", ""))
+	assert.Equal(t, "comment trident-backend:abcd trident-uuid:1234", addCreationTag("comment", "1234", "abcd"))
+
+	// The tags survive truncation of a long comment
+	tagged := addCreationTag(strings.Repeat("x", maxVolumeCommentLength), "1234", "abcd")
+	assert.Len(t, tagged, maxVolumeCommentLength)
+	assert.Equal(t, "1234", getCreationUUID(tagged))
+	assert.Equal(t, "abcd", getCreationBackendUUID(tagged))
+	assert.Equal(t, "", getCreationBackendUUID("trident-uuid:1234"))
+
+	assert.Equal(t, "1234", getCreationUUID("trident-uuid:1234"))
+	assert.Equal(t, "1234", getCreationUUID(`{"pvc":"data"} trident-uuid:1234`))
+	assert.Equal(t, "", getCreationUUID(`{"pvc":"data"}`))
+	assert.Equal(t, "", getCreationUUID("trident-uuid:"))
+
+	config := newTestOntapSANConfig()
+	config.VolumeCommentTemplate = `{{.pvcName}}`
+	config.BackendUUID = "abcd"
+	volConfig := &storage.VolumeConfig{Name: "pvc-1234", RequestName: "data", UUID: "5678"}
+	assert.Equal(t, "data trident-backend:abcd trident-uuid:5678", getFlexvolComment(config, volConfig))
+}
+
+func TestUpdateComments(t *testing.T) {
+
+	requests := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin"><results status="passed"/></netapp>`))
+	}))
+	defer server.Close()
+
+	config := newTestOntapSANConfig()
+	config.VolumeCommentTemplate = `{{.pvcName}}`
+	client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+
+	tests := map[string]struct {
+		importNotManaged bool
+		requests         int
+	}{
+		"Managed":     {importNotManaged: false, requests: 2},
+		"Not managed": {importNotManaged: true, requests: 0},
+	}
+	for name, test := range tests {
+		requests = requests[:0]
+		volConfig := &storage.VolumeConfig{Name: "pvc-1234", InternalName: "trident_pvc_1234", RequestName: "data",
+			UUID: "5678", ImportNotManaged: test.importNotManaged}
+
+		updateFlexvolComment(client, config, volConfig)
+		updateLUNComment(client, config, volConfig, "/vol/trident_pvc_1234/lun0")
+		assert.Len(t, requests, test.requests, name)
+	}
+}
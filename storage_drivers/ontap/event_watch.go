@@ -0,0 +1,208 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+const (
+	// EMS messages logged when data LIFs go down, come up, or migrate between ports and nodes
+	emsDataLIFMessages = "vifmgr.*"
+	// EMS messages logged when aggregates are created, deleted, brought online or taken offline
+	emsAggregateMessages = "aggr.*|raid.aggr.*"
+	emsDataLIFPrefix     = "vifmgr."
+
+	// The data LIFs are still rediscovered this often while events are watched, in case an event is missed
+	defaultWatchedDataLIFRefreshPeriodSecs = uint64(3600) // default to 1 hour
+)
+
+// EventWatcher notices changes on the storage system soon after they happen, rather than leaving them to
+// the periodic data LIF and storage pool refreshes.  ONTAP can't push events to Trident, so the watcher
+// reads the cluster's EMS event log for data LIF and aggregate events and lists the aggregates assigned to
+// the SVM, both of which are far cheaper than rediscovering the pools.  Only events logged since the
+// previous run are acted upon.  If the event log can't be read, as with SVM-scoped credentials, the data
+// LIFs are rediscovered every time the watcher runs instead.
+type EventWatcher struct {
+	listEvents     func(messageNames string) ([]azgo.EmsMessageInfoType, error)
+	listAggregates func() ([]string, error)
+
+	onDataLIFChange func()
+	poolHandler     storage.PoolChangeHandler
+
+	primed         bool
+	seqNums        map[string]int // last event sequence number seen, by node
+	aggregates     []string
+	eventLogFailed bool
+	mutex          sync.Mutex
+}
+
+// NewEventWatcher returns a watcher for the cluster and SVM the client talks to.
+func NewEventWatcher(client *api.Client) *EventWatcher {
+	return &EventWatcher{
+		listEvents:     client.EmsMessageGetIterRequest,
+		listAggregates: client.VserverGetAggregateNames,
+		seqNums:        make(map[string]int),
+	}
+}
+
+// OnDataLIFChange registers a function to be called whenever data LIF events are logged.
+func (w *EventWatcher) OnDataLIFChange(onChange func()) {
+	w.onDataLIFChange = onChange
+}
+
+// SetPoolChangeHandler registers a function to be called whenever the aggregates assigned to the SVM change
+// or aggregate events are logged.
+func (w *EventWatcher) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.poolHandler = handler
+}
+
+// messageNames returns the pattern matching the EMS messages of interest to the watcher.
+func (w *EventWatcher) messageNames() string {
+	if w.onDataLIFChange == nil {
+		return emsAggregateMessages
+	}
+	return emsDataLIFMessages + "|" + emsAggregateMessages
+}
+
+// run is the body of the event watch job.  The first run only notes the current state, as the driver
+// has just discovered its data LIFs and pools.
+func (w *EventWatcher) run() {
+
+	w.mutex.Lock()
+	lifsChanged, poolsChanged := w.readEvents()
+	if w.readAggregates() {
+		poolsChanged = true
+	}
+	primed := w.primed
+	w.primed = true
+	handler := w.poolHandler
+	w.mutex.Unlock()
+
+	if !primed {
+		return
+	}
+	if lifsChanged && w.onDataLIFChange != nil {
+		log.Debug("Data LIFs may have changed, refreshing.")
+		w.onDataLIFChange()
+	}
+	if poolsChanged && handler != nil {
+		log.Debug("Storage pools may have changed, refreshing.")
+		handler()
+	}
+}
+
+// readEvents reads the event log, returning whether data LIF or aggregate events have been logged since
+// the log was last read.  The caller must hold the mutex.
+func (w *EventWatcher) readEvents() (lifsChanged, poolsChanged bool) {
+
+	events, err := w.listEvents(w.messageNames())
+	if err != nil {
+		if !w.eventLogFailed {
+			log.WithField("error", err).Warning(
+				"Could not read the EMS event log, data LIFs will be refreshed each time events are checked.")
+			w.eventLogFailed = true
+		}
+		return true, false
+	}
+	w.eventLogFailed = false
+
+	latest := make(map[string]int)
+	for _, event := range events {
+		if event.NodePtr == nil || event.SeqNumPtr == nil || event.MessageNamePtr == nil {
+			continue
+		}
+		node, seqNum := event.Node(), event.SeqNum()
+		if seqNum > latest[node] {
+			latest[node] = seqNum
+		}
+		if last, ok := w.seqNums[node]; ok && seqNum <= last {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"node":    node,
+			"message": event.MessageName(),
+			"seqNum":  seqNum,
+		}).Debug("Found EMS event.")
+
+		if strings.HasPrefix(event.MessageName(), emsDataLIFPrefix) {
+			lifsChanged = true
+		} else {
+			poolsChanged = true
+		}
+	}
+
+	for node, seqNum := range latest {
+		// A node whose log restarted is treated as having logged every event of interest
+		if seqNum < w.seqNums[node] {
+			lifsChanged, poolsChanged = true, true
+		}
+		w.seqNums[node] = seqNum
+	}
+
+	return lifsChanged, poolsChanged
+}
+
+// readAggregates lists the aggregates assigned to the SVM, returning whether they differ from those
+// listed last time.  The caller must hold the mutex.
+func (w *EventWatcher) readAggregates() bool {
+
+	aggregates, err := w.listAggregates()
+	if err != nil {
+		log.WithField("error", err).Warning("Could not list the aggregates assigned to the SVM.")
+		return false
+	}
+	sort.Strings(aggregates)
+
+	changed := w.aggregates != nil && !reflect.DeepEqual(aggregates, w.aggregates)
+	if changed {
+		log.WithFields(log.Fields{
+			"oldAggregates": strings.Join(w.aggregates, ","),
+			"newAggregates": strings.Join(aggregates, ","),
+		}).Info("Aggregates assigned to the SVM changed.")
+	}
+	w.aggregates = aggregates
+
+	return changed
+}
+
+// HousekeepingJob returns the job that checks for events.
+func (w *EventWatcher) HousekeepingJob(interval time.Duration) *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         eventWatchJob,
+		Interval:     interval,
+		InitialDelay: 0,
+		Jitter:       interval / housekeepingJitterDivisor,
+		Run:          w.run,
+	}
+}
+
+// getEventWatchInterval returns how often events are checked, or zero if they are not watched, as they
+// aren't by default.
+func getEventWatchInterval(config *drivers.OntapStorageDriverConfig) (time.Duration, error) {
+
+	if config.EventWatchPeriod == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(config.EventWatchPeriod, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for eventWatchPeriod: %v", err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
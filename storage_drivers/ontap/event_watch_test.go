@@ -0,0 +1,115 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func newTestEmsEvent(node string, seqNum int, messageName string) azgo.EmsMessageInfoType {
+	return *azgo.NewEmsMessageInfoType().SetNode(node).SetSeqNum(seqNum).SetMessageName(messageName)
+}
+
+func TestEventWatcher(t *testing.T) {
+
+	events := []azgo.EmsMessageInfoType{
+		newTestEmsEvent("node1", 10, "vifmgr.lifdown"),
+		newTestEmsEvent("node2", 5, "aggr.online"),
+	}
+	aggregates := []string{"aggr2", "aggr1"}
+	var eventErr error
+
+	lifChanges, poolChanges := 0, 0
+	watcher := &EventWatcher{
+		listEvents: func(messageNames string) ([]azgo.EmsMessageInfoType, error) {
+			assert.Equal(t, emsDataLIFMessages+"|"+emsAggregateMessages, messageNames)
+			return events, eventErr
+		},
+		listAggregates: func() ([]string, error) { return aggregates, nil },
+		seqNums:        make(map[string]int),
+	}
+	watcher.OnDataLIFChange(func() { lifChanges++ })
+	watcher.SetPoolChangeHandler(func() { poolChanges++ })
+
+	// The first run only notes the events already logged
+	watcher.run()
+	assert.Equal(t, 0, lifChanges)
+	assert.Equal(t, 0, poolChanges)
+
+	// Nothing new
+	aggregates = []string{"aggr1", "aggr2"}
+	watcher.run()
+	assert.Equal(t, 0, lifChanges)
+	assert.Equal(t, 0, poolChanges)
+
+	// A data LIF event
+	events = append(events, newTestEmsEvent("node1", 11, "vifmgr.lifmoved.linkdown"))
+	watcher.run()
+	assert.Equal(t, 1, lifChanges)
+	assert.Equal(t, 0, poolChanges)
+
+	// An aggregate event, then an aggregate assigned to the SVM
+	events = append(events, newTestEmsEvent("node2", 6, "raid.aggr.offline"))
+	watcher.run()
+	assert.Equal(t, 1, lifChanges)
+	assert.Equal(t, 1, poolChanges)
+	aggregates = []string{"aggr1", "aggr2", "aggr3"}
+	watcher.run()
+	assert.Equal(t, 1, lifChanges)
+	assert.Equal(t, 2, poolChanges)
+
+	// The data LIFs are polled while the event log can't be read
+	eventErr = errors.New("insufficient privileges")
+	watcher.run()
+	watcher.run()
+	assert.Equal(t, 3, lifChanges)
+	assert.Equal(t, 2, poolChanges)
+}
+
+func TestEventWatcherLogRestarted(t *testing.T) {
+
+	events := []azgo.EmsMessageInfoType{newTestEmsEvent("node1", 100, "vifmgr.lifdown")}
+
+	poolChanges := 0
+	watcher := &EventWatcher{
+		listEvents: func(messageNames string) ([]azgo.EmsMessageInfoType, error) {
+			assert.Equal(t, emsAggregateMessages, messageNames)
+			return events, nil
+		},
+		listAggregates: func() ([]string, error) { return nil, errors.New("failed") },
+		seqNums:        make(map[string]int),
+	}
+	watcher.SetPoolChangeHandler(func() { poolChanges++ })
+
+	watcher.run()
+	events = []azgo.EmsMessageInfoType{newTestEmsEvent("node1", 3, "vifmgr.lifdown")}
+	watcher.run()
+	assert.Equal(t, 1, poolChanges)
+}
+
+func TestGetEventWatchInterval(t *testing.T) {
+
+	interval, err := getEventWatchInterval(&drivers.OntapStorageDriverConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	interval, err = getEventWatchInterval(&drivers.OntapStorageDriverConfig{EventWatchPeriod: "60"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, interval)
+
+	_, err = getEventWatchInterval(&drivers.OntapStorageDriverConfig{EventWatchPeriod: "1m"})
+	assert.Error(t, err)
+
+	// The data LIFs are rediscovered less often while their events are watched
+	dataLIFs := &ISCSIDataLIFs{config: &drivers.OntapStorageDriverConfig{EventWatchPeriod: "60"}}
+	assert.Equal(t, time.Hour, dataLIFs.HousekeepingJob().Interval)
+	dataLIFs.config.DataLIFRefreshPeriod = "600"
+	assert.Equal(t, 10*time.Minute, dataLIFs.HousekeepingJob().Interval)
+}
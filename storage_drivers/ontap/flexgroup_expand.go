@@ -0,0 +1,150 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+	"github.com/netapp/trident/utils"
+)
+
+// validateFlexGroupExpand checks the backend's threshold for adding constituents to FlexGroups and caches
+// its parsed value.
+func (d *NASFlexGroupStorageDriver) validateFlexGroupExpand() error {
+
+	d.expandThresholdPct = 0
+	if d.Config.FlexgroupExpandThreshold != "" {
+		threshold, err := strconv.Atoi(d.Config.FlexgroupExpandThreshold)
+		if err != nil || threshold < 1 || threshold > 99 {
+			return fmt.Errorf("invalid value for flexgroupExpandThreshold: %s, must be between 1 and 99",
+				d.Config.FlexgroupExpandThreshold)
+		}
+		d.expandThresholdPct = threshold
+	}
+
+	return nil
+}
+
+// selectFlexGroupExpandAggregates returns the aggregates on which to add constituents to a FlexGroup, or
+// nil if the FlexGroup's aggregates are filled evenly.  The aggregates are uneven if the percentage used
+// of the fullest exceeds that of the emptiest by at least the threshold, in which case constituents are
+// added on each aggregate that much less full than the fullest.  Aggregates whose size is unknown, as
+// when their space is read through the SVM, are never judged uneven.
+func selectFlexGroupExpandAggregates(
+	capacities map[string]storage.PoolCapacity, flexgroupAggrs []string, thresholdPct int,
+) []string {
+
+	usedPct := func(name string) (int, bool) {
+		capacity, ok := capacities[name]
+		if !ok || capacity.TotalBytes == 0 {
+			return 0, false
+		}
+		return int(100 * (capacity.TotalBytes - capacity.FreeBytes) / capacity.TotalBytes), true
+	}
+
+	minUsed, maxUsed, known := 100, 0, 0
+	for _, name := range flexgroupAggrs {
+		if used, ok := usedPct(name); ok {
+			known++
+			if used < minUsed {
+				minUsed = used
+			}
+			if used > maxUsed {
+				maxUsed = used
+			}
+		}
+	}
+	if known < 2 || maxUsed-minUsed < thresholdPct {
+		return nil
+	}
+
+	aggregates := make([]string, 0)
+	for name := range capacities {
+		if used, ok := usedPct(name); ok && maxUsed-used >= thresholdPct {
+			aggregates = append(aggregates, name)
+		}
+	}
+	sort.Strings(aggregates)
+
+	return aggregates
+}
+
+// expandFlexGroup adds constituents to a FlexGroup whose aggregates have filled unevenly, so that the
+// space added by a resize lands where there is room for it.  Adding constituents is an ONTAP job that
+// may outlast the request, so the job is followed by the job tracker and a retried resize waits for it
+// rather than starting another.  A FlexGroup is expanded at most once while the tracker remembers its
+// last expansion.
+func (d *NASFlexGroupStorageDriver) expandFlexGroup(
+	ctx context.Context, name string, volAttrs *azgo.VolumeAttributesType,
+) error {
+
+	client := d.API.WithContext(ctx)
+
+	if d.asyncJobs.Operation(name) != storage.StorageJobOperationFlexGroupExpand {
+
+		if d.expandThresholdPct == 0 || volAttrs.VolumeIdAttributesPtr == nil ||
+			volAttrs.VolumeIdAttributesPtr.AggrListPtr == nil {
+			return nil
+		}
+		flexgroupAggrs := make([]string, 0)
+		for _, aggr := range volAttrs.VolumeIdAttributesPtr.AggrListPtr.AggrName() {
+			flexgroupAggrs = append(flexgroupAggrs, string(aggr))
+		}
+
+		vserverAggrs, err := d.vserverAggregates(d.API.SVM())
+		if err != nil {
+			return fmt.Errorf("error reading SVM aggregates: %v", err)
+		}
+		capacities, err := getAggregateCapacities(client, append(vserverAggrs, flexgroupAggrs...))
+		if err != nil {
+			return fmt.Errorf("error reading aggregate space: %v", err)
+		}
+
+		aggregates := selectFlexGroupExpandAggregates(capacities, flexgroupAggrs, d.expandThresholdPct)
+		if len(aggregates) == 0 {
+			return nil
+		}
+		aggrNames := make([]azgo.AggrNameType, 0, len(aggregates))
+		for _, aggregate := range aggregates {
+			aggrNames = append(aggrNames, azgo.AggrNameType(aggregate))
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"flexgroup":  name,
+			"aggregates": aggregates,
+		}).Info("Adding constituents to FlexGroup with unevenly filled aggregates.")
+
+		expandResponse, err := client.FlexGroupExpand(name, aggrNames, 1)
+		if err != nil {
+			return wrapOntapError(err, "error expanding FlexGroup")
+		}
+		asyncResult, err := api.NewZapiAsyncResult(expandResponse)
+		if err != nil {
+			return wrapOntapError(err, "error expanding FlexGroup")
+		} else if asyncResult.IsFailed() {
+			return fmt.Errorf("error expanding FlexGroup: result status is failed with errorCode %d",
+				asyncResult.ErrorCode())
+		} else if !asyncResult.IsInProgress() {
+			return nil
+		}
+		d.asyncJobs.Track(name, storage.StorageJobOperationFlexGroupExpand, asyncResult.JobID())
+	}
+
+	if err := d.asyncJobs.Wait(name, maxFlexGroupExpandWait); err != nil {
+		if utils.IsVolumeCreatingError(err) {
+			return fmt.Errorf("constituents are still being added to FlexGroup %s; %v", name, err)
+		}
+		// The FlexGroup may still be resized without new constituents
+		logc(ctx).WithField("flexgroup", name).WithError(err).Warning("Could not add constituents to FlexGroup.")
+	}
+
+	return nil
+}
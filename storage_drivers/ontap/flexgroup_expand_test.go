@@ -0,0 +1,50 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestValidateFlexGroupExpand(t *testing.T) {
+
+	d := &NASFlexGroupStorageDriver{Config: *newTestOntapSANConfig()}
+	assert.NoError(t, d.validateFlexGroupExpand())
+	assert.Equal(t, 0, d.expandThresholdPct)
+
+	d.Config.FlexgroupExpandThreshold = "20"
+	assert.NoError(t, d.validateFlexGroupExpand())
+	assert.Equal(t, 20, d.expandThresholdPct)
+
+	for _, value := range []string{"0", "100", "most"} {
+		d.Config.FlexgroupExpandThreshold = value
+		assert.Error(t, d.validateFlexGroupExpand(), "expected invalid flexgroupExpandThreshold error")
+	}
+}
+
+func TestSelectFlexGroupExpandAggregates(t *testing.T) {
+
+	capacities := map[string]storage.PoolCapacity{
+		"aggr1": {TotalBytes: 1000, FreeBytes: 100},  // 90% used
+		"aggr2": {TotalBytes: 1000, FreeBytes: 500},  // 50% used
+		"aggr3": {TotalBytes: 2000, FreeBytes: 1600}, // 20% used
+		"aggr4": {FreeBytes: 5000},                   // read through the SVM
+	}
+
+	// Constituents are added on every aggregate far enough below the fullest
+	assert.Equal(t, []string{"aggr2", "aggr3"},
+		selectFlexGroupExpandAggregates(capacities, []string{"aggr1", "aggr2"}, 30))
+	assert.Equal(t, []string{"aggr3"},
+		selectFlexGroupExpandAggregates(capacities, []string{"aggr1", "aggr3"}, 50))
+
+	// Evenly filled aggregates are left alone
+	assert.Nil(t, selectFlexGroupExpandAggregates(capacities, []string{"aggr1", "aggr2"}, 50))
+	assert.Nil(t, selectFlexGroupExpandAggregates(capacities, []string{"aggr1"}, 10))
+
+	// Aggregates of unknown size can't be judged
+	assert.Nil(t, selectFlexGroupExpandAggregates(capacities, []string{"aggr1", "aggr4"}, 10))
+}
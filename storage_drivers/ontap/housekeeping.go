@@ -0,0 +1,174 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	emsHeartbeatJob                      = "emsHeartbeat"
+	cloneSplitJob                        = "cloneSplit"
+	cloneSplitPollJob                    = "cloneSplitPoll"
+	dataLIFRefreshJob                    = "dataLIFRefresh"
+	volumeMovePollJob                    = "volumeMovePoll"
+	svmFailoverJob                       = "svmFailover"
+	spaceReclamationJob                  = "spaceReclamation"
+	poolCapacityRefreshJob               = "poolCapacityRefresh"
+	recoveryQueueReapJob                 = "recoveryQueueReap"
+	featureProbeJob                      = "featureProbe"
+	cloneSnapshotReapJob                 = "cloneSnapshotReap"
+	asyncJobPollJob                      = "asyncJobPoll"
+	snapshotPolicyReconcileJob           = "snapshotPolicyReconcile"
+	quotaUsageRefreshJob                 = "quotaUsageRefresh"
+	eventWatchJob                        = "eventWatch"
+	defaultCloneSplitRetryPeriodSecs     = uint64(300) // default to 5 minutes
+	defaultDataLIFRefreshPeriodSecs      = uint64(300) // default to 5 minutes
+	defaultPoolCapacityRefreshPeriodSecs = uint64(300) // default to 5 minutes
+	cloneSplitPollPeriod                 = 30 * time.Second
+	volumeMovePollPeriod                 = 60 * time.Second
+	asyncJobPollPeriod                   = 30 * time.Second
+	featureProbePeriod                   = time.Hour
+	snapshotPolicyReconcilePeriod        = time.Hour
+	quotaUsageRefreshPeriod              = 5 * time.Minute
+
+	// Deferred snapshot deletions are abandoned after this many failures
+	maxDeferredSnapshotDeleteFailures = 5
+
+	// Jobs are delayed by up to 1/housekeepingJitterDivisor of their interval
+	housekeepingJitterDivisor = 10
+)
+
+// HousekeepingJob is a named unit of periodic background work run by a HousekeepingScheduler.
+// A job with a non-positive Interval runs once, after its InitialDelay.
+type HousekeepingJob struct {
+	Name         string
+	Interval     time.Duration
+	InitialDelay time.Duration
+	Jitter       time.Duration
+	Run          func()
+}
+
+// HousekeepingScheduler runs a set of named housekeeping jobs, each in its own goroutine,
+// and waits for any in-flight job to finish when it is stopped.
+type HousekeepingScheduler struct {
+	owner     string
+	jobs      map[string]*HousekeepingJob
+	done      chan struct{}
+	waitGroup sync.WaitGroup
+	mutex     sync.Mutex
+	started   bool
+	stopped   bool
+}
+
+// NewHousekeepingScheduler returns an idle scheduler.  The owner is only used for logging.
+func NewHousekeepingScheduler(owner string) *HousekeepingScheduler {
+	return &HousekeepingScheduler{
+		owner: owner,
+		jobs:  make(map[string]*HousekeepingJob),
+		done:  make(chan struct{}),
+	}
+}
+
+// AddJob registers a job with the scheduler.  Jobs must be added before the scheduler is started.
+func (s *HousekeepingScheduler) AddJob(job *HousekeepingJob) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started {
+		return fmt.Errorf("cannot add housekeeping job %s, scheduler already started", job.Name)
+	}
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("housekeeping job %s already exists", job.Name)
+	}
+	s.jobs[job.Name] = job
+	return nil
+}
+
+// Jobs returns the names of the registered jobs.
+func (s *HousekeepingScheduler) Jobs() []string {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Start launches all registered jobs.  Calling Start more than once has no effect.
+func (s *HousekeepingScheduler) Start() {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+
+	for _, job := range s.jobs {
+		s.waitGroup.Add(1)
+		go s.runJob(job)
+	}
+}
+
+// Stop signals all jobs to exit and blocks until any job that is currently running has returned.
+// It is safe to call Stop more than once.
+func (s *HousekeepingScheduler) Stop() {
+
+	s.mutex.Lock()
+	if s.stopped {
+		s.mutex.Unlock()
+		return
+	}
+	s.stopped = true
+	close(s.done)
+	s.mutex.Unlock()
+
+	log.WithField("owner", s.owner).Debug("Waiting for housekeeping jobs to exit.")
+	s.waitGroup.Wait()
+}
+
+func (s *HousekeepingScheduler) runJob(job *HousekeepingJob) {
+
+	defer s.waitGroup.Done()
+
+	logFields := log.Fields{"owner": s.owner, "job": job.Name}
+
+	timer := time.NewTimer(job.InitialDelay + randomJitter(job.Jitter))
+	defer timer.Stop()
+
+	for {
+		select {
+		case tick := <-timer.C:
+			log.WithFields(logFields).WithField("tick", tick).Debug("Running housekeeping job.")
+			job.Run()
+			if job.Interval <= 0 {
+				log.WithFields(logFields).Debug("Housekeeping job has no interval, not rescheduling.")
+				return
+			}
+			timer.Reset(job.Interval + randomJitter(job.Jitter))
+		case <-s.done:
+			log.WithFields(logFields).Debug("Shut down housekeeping job.")
+			return
+		}
+	}
+}
+
+// randomJitter returns a random duration in [0, jitter), so that jobs sharing an interval
+// across many backends don't all hit the storage system at the same instant.
+func randomJitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(jitter)))
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHousekeepingScheduler(t *testing.T) {
+
+	var periodicRuns, oneShotRuns int32
+
+	scheduler := NewHousekeepingScheduler("test")
+	assert.NoError(t, scheduler.AddJob(&HousekeepingJob{
+		Name:     "periodic",
+		Interval: time.Millisecond,
+		Jitter:   time.Millisecond,
+		Run:      func() { atomic.AddInt32(&periodicRuns, 1) },
+	}))
+	assert.NoError(t, scheduler.AddJob(&HousekeepingJob{
+		Name: "oneShot",
+		Run:  func() { atomic.AddInt32(&oneShotRuns, 1) },
+	}))
+	assert.Error(t, scheduler.AddJob(&HousekeepingJob{Name: "oneShot"}), "expected duplicate job error")
+	assert.ElementsMatch(t, []string{"periodic", "oneShot"}, scheduler.Jobs())
+
+	scheduler.Start()
+	scheduler.Start()
+	time.Sleep(50 * time.Millisecond)
+	scheduler.Stop()
+	scheduler.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&oneShotRuns))
+	assert.True(t, atomic.LoadInt32(&periodicRuns) > 1, "expected periodic job to run more than once")
+
+	// Nothing runs after Stop has returned
+	runs := atomic.LoadInt32(&periodicRuns)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, runs, atomic.LoadInt32(&periodicRuns))
+
+	assert.Error(t, scheduler.AddJob(&HousekeepingJob{Name: "late"}), "expected error adding job after start")
+}
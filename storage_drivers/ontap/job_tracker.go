@@ -0,0 +1,398 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+)
+
+const (
+	// A running job is failed once its progress can't be read this many polls in a row, so that a job
+	// whose record ONTAP lost doesn't appear to run forever
+	maxJobStatusReadFailures = 10
+)
+
+// jobState is the state of a job followed by a jobTracker.  Its values match those of
+// storage.CloneSplitState and storage.VolumeMoveState.
+type jobState string
+
+const (
+	jobStateQueued   = jobState("queued")
+	jobStateRunning  = jobState("running")
+	jobStateComplete = jobState("complete")
+	jobStateFailed   = jobState("failed")
+)
+
+func (s jobState) isDone() bool {
+	return s == jobStateComplete || s == jobStateFailed
+}
+
+// jobReading is the progress of a job as read from the storage system.
+type jobReading struct {
+	state   jobState
+	percent int
+	message string
+}
+
+// trackedJob is a jobTracker's record of the job working on a single volume.
+type trackedJob struct {
+	operation string
+	state     jobState
+	percent   int
+	message   string
+	started   time.Time
+	finished  time.Time
+
+	// id is the storage system's ID for the job, if it has one
+	id int
+
+	// source and destination are the pools a job moves the volume between, if it moves the volume
+	source      string
+	destination string
+
+	// failedReadings counts the polls in a row that found the job failed, and readFailures those that
+	// couldn't read its progress at all
+	failedReadings int
+	readFailures   int
+}
+
+// jobTracker is the bookkeeping shared by the trackers that follow a driver's long-running ONTAP jobs,
+// at most one per volume.  Jobs beyond an optional limit on the number running at once are queued.
+// Each poll reads the progress of a running job, and the housekeeping run also starts queued jobs as
+// running ones finish.  Finished jobs are remembered for a while so that their outcome may be seen.
+type jobTracker struct {
+	// description names the jobs in log messages
+	description string
+
+	// maxConcurrent limits the number of jobs running at once, if not zero
+	maxConcurrent int
+
+	// failedReadingLimit is the number of polls in a row that must find a job failed before it is
+	// failed, since the storage system may not report a job that has only just started
+	failedReadingLimit int
+
+	// readFailureLimit is the number of polls in a row that may fail to read a job's progress before
+	// the job is failed, if not zero
+	readFailureLimit int
+
+	retention time.Duration
+
+	jobs  map[string]*trackedJob
+	queue []string
+	mutex sync.Mutex
+
+	// start asks the storage system to begin a job, and read reads a job's progress from it.  They
+	// are functions so that unit tests need not talk to ONTAP.
+	start func(name string, job trackedJob) error
+	read  func(name string, job trackedJob) (jobReading, error)
+
+	// finished is called, without holding the lock, with each job that completes or fails
+	finished func(name string, job trackedJob)
+}
+
+// get returns a copy of the record of the job working on a volume.
+func (t *jobTracker) get(name string) (trackedJob, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, ok := t.jobs[name]
+	if !ok {
+		return trackedJob{}, false
+	}
+	return *job, true
+}
+
+// storageJobs returns the jobs still queued or running.
+func (t *jobTracker) storageJobs() []*storage.StorageJob {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	jobs := make([]*storage.StorageJob, 0)
+	for name, job := range t.jobs {
+		if job.state.isDone() {
+			continue
+		}
+		storageJob := &storage.StorageJob{
+			InternalVolume:  name,
+			Operation:       job.operation,
+			State:           string(job.state),
+			PercentComplete: job.percent,
+			StartTime:       formatJobTime(job.started),
+			Message:         job.message,
+		}
+		if job.id != 0 {
+			storageJob.JobID = strconv.Itoa(job.id)
+		}
+		jobs = append(jobs, storageJob)
+	}
+	return jobs
+}
+
+// begin starts a job working on a volume, or queues it if the limit on running jobs has been reached.
+// It returns false if a job is already queued or running on the volume, in which case nothing is
+// started.  An error is returned if the storage system refused to start the job, which is then
+// recorded as failed.
+func (t *jobTracker) begin(name string, job trackedJob) (bool, error) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if existing, ok := t.jobs[name]; ok && !existing.state.isDone() {
+		return false, nil
+	}
+
+	job.state = jobStateQueued
+	t.jobs[name] = &job
+
+	if t.maxConcurrent > 0 && t.running() >= t.maxConcurrent {
+		t.queue = append(t.queue, name)
+		log.WithFields(log.Fields{
+			"volume":  name,
+			"running": t.maxConcurrent,
+		}).Infof("Limit reached, queued %s.", t.description)
+		return true, nil
+	}
+
+	return true, t.startQueued(name, &job)
+}
+
+// track records a job that is already running on a volume, replacing any earlier record.
+func (t *jobTracker) track(name string, job trackedJob) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job.state = jobStateRunning
+	if job.started.IsZero() {
+		job.started = time.Now()
+	}
+	t.jobs[name] = &job
+}
+
+// resume records a job that may have been running on a volume before Trident restarted, unless a
+// job is already queued or running on the volume.  The job's state is read at the next poll.
+func (t *jobTracker) resume(name string, job trackedJob) {
+
+	t.mutex.Lock()
+	existing, ok := t.jobs[name]
+	t.mutex.Unlock()
+
+	if ok && !existing.state.isDone() {
+		return
+	}
+	t.track(name, job)
+}
+
+// forget discards any record of a job working on a volume.
+func (t *jobTracker) forget(name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.jobs, name)
+}
+
+// running returns the number of jobs in progress.  The caller must hold the mutex.
+func (t *jobTracker) running() int {
+	count := 0
+	for _, job := range t.jobs {
+		if job.state == jobStateRunning {
+			count++
+		}
+	}
+	return count
+}
+
+// startQueued asks the storage system to begin a queued job and records the outcome.  The caller
+// must hold the mutex.
+func (t *jobTracker) startQueued(name string, job *trackedJob) error {
+
+	job.started = time.Now()
+
+	if err := t.start(name, *job); err != nil {
+		job.state = jobStateFailed
+		job.message = err.Error()
+		job.finished = job.started
+		return err
+	}
+
+	job.state = jobStateRunning
+	log.WithFields(jobLogFields(name, job)).Infof("Began %s.", t.description)
+	return nil
+}
+
+// poll reads the progress of the job working on a volume, if it is running, and records any change.
+// It returns a copy of the job's record, and false if no job is tracked for the volume.
+func (t *jobTracker) poll(name string) (trackedJob, bool) {
+
+	t.mutex.Lock()
+	job, ok := t.jobs[name]
+	if !ok {
+		t.mutex.Unlock()
+		return trackedJob{}, false
+	}
+	polled := *job
+	t.mutex.Unlock()
+
+	if polled.state != jobStateRunning {
+		return polled, true
+	}
+
+	// Read the job's progress without holding the lock, since ONTAP may be slow to respond
+	reading, err := t.read(name, polled)
+
+	t.mutex.Lock()
+
+	// The job may have been forgotten or replaced while its progress was read
+	if current, ok := t.jobs[name]; !ok {
+		t.mutex.Unlock()
+		return polled, true
+	} else if current != job || job.state != jobStateRunning {
+		polled = *current
+		t.mutex.Unlock()
+		return polled, true
+	}
+
+	done := t.record(name, job, reading, err)
+	polled = *job
+	finished := t.finished
+	t.mutex.Unlock()
+
+	if done && finished != nil {
+		finished(name, polled)
+	}
+	return polled, true
+}
+
+// record applies a reading of a running job's progress, returning true if the job finished.  The
+// caller must hold the mutex.
+func (t *jobTracker) record(name string, job *trackedJob, reading jobReading, err error) bool {
+
+	logFields := jobLogFields(name, job)
+
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Warningf("Could not read %s status.", t.description)
+		if job.readFailures++; t.readFailureLimit == 0 || job.readFailures < t.readFailureLimit {
+			return false
+		}
+		job.state = jobStateFailed
+		job.message = fmt.Sprintf("%s status could not be read: %v", t.description, err)
+	} else {
+		job.readFailures = 0
+
+		switch reading.state {
+		case jobStateRunning:
+			job.failedReadings = 0
+			job.percent = reading.percent
+			job.message = reading.message
+			log.WithFields(logFields).WithField("percent", reading.percent).Debugf("%s in progress.",
+				t.description)
+			return false
+		case jobStateFailed:
+			if job.failedReadings++; job.failedReadings < t.failedReadingLimit {
+				return false
+			}
+		}
+
+		job.state = reading.state
+		job.percent = reading.percent
+		job.message = reading.message
+	}
+
+	job.finished = time.Now()
+	if job.state == jobStateFailed {
+		log.WithFields(logFields).WithField("message", job.message).Warningf("%s failed.", t.description)
+	} else {
+		log.WithFields(logFields).Infof("%s complete.", t.description)
+	}
+	return true
+}
+
+// run is the body of the tracker's housekeeping job.
+func (t *jobTracker) run() {
+
+	t.mutex.Lock()
+	running := make([]string, 0)
+	for name, job := range t.jobs {
+		if job.state == jobStateRunning {
+			running = append(running, name)
+		}
+	}
+	t.mutex.Unlock()
+
+	for _, name := range running {
+		t.poll(name)
+	}
+
+	t.mutex.Lock()
+
+	// Start as many queued jobs as the limit allows
+	refused := make(map[string]trackedJob)
+	for len(t.queue) > 0 && t.running() < t.maxConcurrent {
+		name := t.queue[0]
+		t.queue = t.queue[1:]
+
+		job, ok := t.jobs[name]
+		if !ok || job.state != jobStateQueued {
+			continue
+		}
+		if err := t.startQueued(name, job); err != nil {
+			log.WithField("volume", name).WithError(err).Errorf("Could not begin queued %s.", t.description)
+			refused[name] = *job
+		}
+	}
+
+	// Forget jobs that finished long ago
+	now := time.Now()
+	for name, job := range t.jobs {
+		if job.state.isDone() && now.Sub(job.finished) > t.retention {
+			delete(t.jobs, name)
+		}
+	}
+
+	finished := t.finished
+	t.mutex.Unlock()
+
+	if finished != nil {
+		for name, job := range refused {
+			finished(name, job)
+		}
+	}
+}
+
+// housekeepingJob returns the job that polls the tracker's running jobs and starts queued ones.
+func (t *jobTracker) housekeepingJob(name string, period time.Duration) *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         name,
+		Interval:     period,
+		InitialDelay: period,
+		Jitter:       period / housekeepingJitterDivisor,
+		Run:          t.run,
+	}
+}
+
+func jobLogFields(name string, job *trackedJob) log.Fields {
+	logFields := log.Fields{"volume": name, "operation": job.operation}
+	if job.id != 0 {
+		logFields["jobId"] = job.id
+	}
+	if job.destination != "" {
+		logFields["source"] = job.source
+		logFields["destination"] = job.destination
+	}
+	return logFields
+}
+
+// formatJobTime formats a job's start or finish time for its status, or returns an empty string if
+// the time isn't known yet.
+func formatJobTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	tridentconfig "github.com/netapp/trident/config"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+// Names of the driver operations reported via the ONTAP driver metrics
+const (
+	opCloneCreate           = "clone_create"
+	opSnapshotCreate        = "snapshot_create"
+	opGroupSnapshotCreate   = "group_snapshot_create"
+	opSnapshotDelete        = "snapshot_delete"
+	opExportPolicyReconcile = "export_policy_reconcile"
+	opLunMap                = "lun_map"
+)
+
+var (
+	ontapDriverOpsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "ontap_driver",
+			Name:      "ops_total",
+			Help:      "The total number of ONTAP driver operations",
+		},
+		[]string{"backend", "svm", "op"},
+	)
+
+	ontapDriverOpsFailedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "ontap_driver",
+			Name:      "ops_failed_total",
+			Help:      "The total number of failed ONTAP driver operations",
+		},
+		[]string{"backend", "svm", "op"},
+	)
+
+	ontapDriverOpsDurationInMsSummary = promauto.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  tridentconfig.OrchestratorName,
+			Subsystem:  "ontap_driver",
+			Name:       "operation_duration_in_milliseconds",
+			Help:       "The duration of ONTAP driver operations by backend and SVM",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"backend", "svm", "op"},
+	)
+
+	ontapCloneSnapshotsReclaimedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: tridentconfig.OrchestratorName,
+			Subsystem: "ontap_driver",
+			Name:      "clone_snapshots_reclaimed_total",
+			Help:      "The total number of snapshots created as the base of a clone and deleted once unused",
+		},
+		[]string{"backend", "svm"},
+	)
+)
+
+// observeCloneSnapshotReclaimed counts the deletion of a clone's base snapshot.
+func observeCloneSnapshotReclaimed(config *drivers.OntapStorageDriverConfig) {
+
+	backendName := ""
+	if config.CommonStorageDriverConfig != nil {
+		backendName = config.BackendName
+	}
+
+	ontapCloneSnapshotsReclaimedTotal.WithLabelValues(backendName, config.SVM).Inc()
+}
+
+// observeOntapOperation records the outcome and latency of a single driver operation.  It is
+// intended to be deferred at the top of an instrumented function that uses a named error return.
+func observeOntapOperation(config *drivers.OntapStorageDriverConfig, op string, startTime time.Time, err error) {
+
+	backendName := ""
+	if config.CommonStorageDriverConfig != nil {
+		backendName = config.BackendName
+	}
+
+	ontapDriverOpsTotal.WithLabelValues(backendName, config.SVM, op).Inc()
+	if err != nil {
+		ontapDriverOpsFailedTotal.WithLabelValues(backendName, config.SVM, op).Inc()
+	}
+
+	duration := float64(time.Since(startTime).Milliseconds())
+	ontapDriverOpsDurationInMsSummary.WithLabelValues(backendName, config.SVM, op).Observe(duration)
+}
@@ -0,0 +1,26 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveOntapOperation(t *testing.T) {
+	config := newTestOntapSANConfig()
+	config.BackendName = "metricsBackend"
+
+	observeOntapOperation(config, opCloneCreate, time.Now(), nil)
+	observeOntapOperation(config, opCloneCreate, time.Now(), errors.New("failed"))
+
+	total := ontapDriverOpsTotal.WithLabelValues("metricsBackend", "SVM1", opCloneCreate)
+	failed := ontapDriverOpsFailedTotal.WithLabelValues("metricsBackend", "SVM1", opCloneCreate)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(total), "expected two operations")
+	assert.Equal(t, float64(1), testutil.ToFloat64(failed), "expected one failed operation")
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+const (
+	volumeNameTemplate = "volume name"
+
+	// Tokens available to a volumeNameTemplate or volumeCommentTemplate
+	volumeNameTokenPrefix       = "prefix"
+	volumeNameTokenVolume       = "volume"
+	volumeNameTokenNamespace    = "namespace"
+	volumeNameTokenPVCName      = "pvcName"
+	volumeNameTokenStorageClass = "storageClass"
+
+	// ONTAP object name length limits (see also maxQtreeNameLength and maxLunNameLength)
+	maxFlexvolNameLength = 203
+
+	// Number of numeric suffixes tried before giving up on finding an unused name
+	maxVolumeNameCollisions = 100
+)
+
+var (
+	invalidVolumeNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+	repeatedUnderscores    = regexp.MustCompile(`_{2,}`)
+)
+
+// parseVolumeTemplate parses a volume name or comment template.  Unknown tokens are
+// rejected when the template is executed, so the template is test-executed here as well.
+func parseVolumeTemplate(name, text string) (*template.Template, error) {
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s template; %v", name, err)
+	}
+
+	if _, err = executeVolumeTemplate(tmpl, volumeTemplateData("", &storage.VolumeConfig{})); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ValidateVolumeNameTemplate ensures a volumeNameTemplate backend option is well formed.
+func ValidateVolumeNameTemplate(nameTemplate string) error {
+	if nameTemplate == "" {
+		return nil
+	}
+	_, err := parseVolumeTemplate(volumeNameTemplate, nameTemplate)
+	return err
+}
+
+func volumeTemplateData(prefix string, volConfig *storage.VolumeConfig) map[string]string {
+	return map[string]string{
+		volumeNameTokenPrefix:       prefix,
+		volumeNameTokenVolume:       volConfig.Name,
+		volumeNameTokenNamespace:    volConfig.Namespace,
+		volumeNameTokenPVCName:      volConfig.RequestName,
+		volumeNameTokenStorageClass: volConfig.StorageClass,
+	}
+}
+
+func executeVolumeTemplate(tmpl *template.Template, data map[string]string) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not execute %s template; %v", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
+
+// sanitizeVolumeName converts an arbitrary string into a name ONTAP will accept, i.e. one
+// containing only letters, digits, and underscores, beginning with a letter or underscore,
+// and no longer than maxLength.
+func sanitizeVolumeName(name string, maxLength int) string {
+
+	name = invalidVolumeNameChars.ReplaceAllString(name, "_")
+	name = repeatedUnderscores.ReplaceAllString(name, "_")
+	name = strings.TrimRight(name, "_")
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "_")
+	}
+
+	return name
+}
+
+// getTemplatedVolumeName renders the backend's volumeNameTemplate for a new volume.  If the
+// resulting name is already in use, a numeric suffix is appended until an unused name is found.
+func getTemplatedVolumeName(
+	config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig, maxLength int,
+	volumeExists func(string) (bool, error),
+) (string, error) {
+
+	tmpl, err := parseVolumeTemplate(volumeNameTemplate, config.VolumeNameTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := executeVolumeTemplate(tmpl, volumeTemplateData(*config.StoragePrefix, volConfig))
+	if err != nil {
+		return "", err
+	}
+
+	name := sanitizeVolumeName(rendered, maxLength)
+
+	for i := 0; i <= maxVolumeNameCollisions; i++ {
+
+		candidate := name
+		if i > 0 {
+			suffix := fmt.Sprintf("_%d", i)
+			base := name
+			if len(base)+len(suffix) > maxLength {
+				base = base[:maxLength-len(suffix)]
+			}
+			candidate = base + suffix
+		}
+
+		exists, err := volumeExists(candidate)
+		if err != nil {
+			return "", fmt.Errorf("could not check for existing volume %s; %v", candidate, err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+
+		log.WithField("name", candidate).Debug("Templated volume name already in use.")
+	}
+
+	return "", fmt.Errorf("could not find an unused name for volume %s after %d attempts",
+		volConfig.Name, maxVolumeNameCollisions)
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestValidateVolumeNameTemplate(t *testing.T) {
+
+	assert.NoError(t, ValidateVolumeNameTemplate(""))
+	assert.NoError(t, ValidateVolumeNameTemplate("{{.prefix}}{{.namespace}}_{{.pvcName}}"))
+	assert.Error(t, ValidateVolumeNameTemplate("{{.namespace"), "expected parse error")
+	assert.Error(t, ValidateVolumeNameTemplate("{{.unknown}}"), "expected unknown token error")
+}
+
+func TestSanitizeVolumeName(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		maxLength int
+		expected  string
+	}{
+		{"trident_ns-1_my.pvc", 203, "trident_ns_1_my_pvc"},
+		{"a--b__c", 203, "a_b_c"},
+		{"1abc", 203, "_1abc"},
+		{"", 203, "_"},
+		{"abcdef_ghij", 7, "abcdef"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, sanitizeVolumeName(test.name, test.maxLength), test.name)
+	}
+}
+
+func TestGetTemplatedVolumeName(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.VolumeNameTemplate = "{{.prefix}}{{.namespace}}_{{.pvcName}}"
+	volConfig := &storage.VolumeConfig{Name: "pvc-1234", Namespace: "prod-db", RequestName: "data.0"}
+
+	used := map[string]bool{}
+	volumeExists := func(name string) (bool, error) { return used[name], nil }
+
+	name, err := getTemplatedVolumeName(config, volConfig, maxFlexvolNameLength, volumeExists)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_prod_db_data_0", name)
+
+	used["test_prod_db_data_0"] = true
+	used["test_prod_db_data_0_1"] = true
+	name, err = getTemplatedVolumeName(config, volConfig, maxFlexvolNameLength, volumeExists)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_prod_db_data_0_2", name)
+}
@@ -3,6 +3,7 @@
 package ontap
 
 import (
+	"context"
 	cryptorand "crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -11,10 +12,12 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"reflect"
 	"runtime/debug"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"regexp"
 
@@ -56,7 +59,28 @@ const (
 	ProvisioningType = "provisioningType"
 	SplitOnClone     = "splitOnClone"
 	TieringPolicy    = "tieringPolicy"
-	maxFlexGroupCloneWait = 120 * time.Second
+	TieringMinimumCoolingDays = "tieringMinimumCoolingDays"
+	FlexcacheOrigin  = "flexcacheOrigin"
+	MaxOverprovisionRatio = "maxOverprovisionRatio"
+	NfsMountOptions  = "nfsMountOptions"
+	LimitVolumeSize  = "limitVolumeSize"
+
+	// Snapshot parameters, such as those of a VolumeSnapshotClass
+	SnapshotParamSnapmirrorLabel = "snapmirrorLabel"
+	SnapshotParamComment         = "comment"
+
+	// ONTAP's limits on snapshot attributes
+	maxSnapmirrorLabelLength = 31
+	maxSnapshotCommentLength = 255
+
+	// How long a request waits for a FlexGroup clone before leaving the job to the job tracker
+	maxFlexGroupCloneWait = 30 * time.Second
+
+	// How long a resize waits for constituents to be added to a FlexGroup before leaving the job to the job tracker
+	maxFlexGroupExpandWait = 30 * time.Second
+
+	// How long ONTAP may fence I/O to the volumes in a consistency group snapshot; "medium" is 7 seconds
+	consistencyGroupTimeout = "medium"
 )
 
 //For legacy reasons, these strings mustn't change
@@ -68,13 +92,15 @@ const (
 
 type Telemetry struct {
 	tridentconfig.Telemetry
-	Plugin        string        `json:"plugin"`
-	SVM           string        `json:"svm"`
-	StoragePrefix string        `json:"storagePrefix"`
-	Driver        StorageDriver `json:"-"`
-	done          chan struct{}
-	ticker        *time.Ticker
-	stopped       bool
+	Plugin        string          `json:"plugin"`
+	SVM           string          `json:"svm"`
+	StoragePrefix string          `json:"storagePrefix"`
+	FeatureFlags  map[string]bool `json:"featureFlags,omitempty"`
+	VolumeCount   int             `json:"volumeCount"`
+	Driver        StorageDriver   `json:"-"`
+	interval      time.Duration
+	disabled      bool
+	volumeCount   *telemetryVolumeCount
 }
 
 type StorageDriver interface {
@@ -88,6 +114,18 @@ type NASDriver interface {
 	GetVolumeOpts(*storage.VolumeConfig, map[string]sa.Request) (map[string]string, error)
 	GetAPI() *api.Client
 	GetConfig() *drivers.OntapStorageDriverConfig
+	GetCloneSplitTracker() *CloneSplitTracker
+	GetCloneSnapshotReaper() *CloneSnapshotReaper
+	GetAsyncJobTracker() *AsyncJobTracker
+}
+
+// logModule names the ONTAP drivers in log messages, and sets their log level with --log_levels.
+const logModule = "ontap"
+
+// logc returns a log entry for the ONTAP drivers, annotated with the fields carried by the context and
+// logged at the level set for the ontap module, if any.
+func logc(ctx context.Context) *log.Entry {
+	return utils.LogcForModule(ctx, logModule)
 }
 
 // CleanBackendName removes brackets and replaces colons with periods to avoid regex parsing errors.
@@ -97,7 +135,7 @@ func CleanBackendName(backendName string) string {
 	return strings.ReplaceAll(backendName, ":", ".")
 }
 
-func CreateCloneNAS(d NASDriver, volConfig *storage.VolumeConfig, storagePool *storage.Pool,
+func CreateCloneNAS(ctx context.Context, d NASDriver, volConfig *storage.VolumeConfig, storagePool *storage.Pool,
 	useAsync bool) error {
 
 	// if cloning a FlexGroup, useAsync will be true
@@ -149,8 +187,19 @@ func CreateCloneNAS(d NASDriver, volConfig *storage.VolumeConfig, storagePool *s
 		return fmt.Errorf("invalid boolean value for splitOnClone: %v", err)
 	}
 
+	// A retried clone may still be being created by the ONTAP job recorded in its config
+	jobs := d.GetAsyncJobTracker()
+	if volConfig.CreateJobID != "" {
+		jobs.Resume(name, storage.StorageJobOperationCloneCreate, volConfig.CreateJobID)
+	} else {
+		jobs.Forget(name)
+	}
+
 	log.WithField("splitOnClone", split).Debug("Creating volume clone.")
-	return CreateOntapClone(name, source, snapshot, split, d.GetConfig(), d.GetAPI(), useAsync)
+	err = CreateOntapClone(ctx, name, source, snapshot, volConfig.UUID, split, d.GetConfig(), d.GetAPI().WithContext(ctx), useAsync,
+		d.GetCloneSplitTracker(), d.GetCloneSnapshotReaper(), jobs)
+	volConfig.CreateJobID = jobs.RunningJobID(name)
+	return err
 }
 
 // InitializeOntapConfig parses the ONTAP config, mixing in the specified common config.
@@ -175,16 +224,29 @@ func InitializeOntapConfig(
 		return nil, fmt.Errorf("could not decode JSON configuration: %v", err)
 	}
 
+	// Attributes matching no config field are probably misspelled, so don't ignore them silently
+	if err = drivers.CheckUnknownConfigFields(configJSON, config, config.StrictConfig); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
 func NewOntapTelemetry(d StorageDriver) *Telemetry {
+	config := d.GetConfig()
 	t := &Telemetry{
 		Plugin:        d.Name(),
-		SVM:           d.GetConfig().SVM,
-		StoragePrefix: *d.GetConfig().StoragePrefix,
+		SVM:           config.SVM,
+		StoragePrefix: *config.StoragePrefix,
+		FeatureFlags:  getTelemetryFeatureFlags(config),
 		Driver:        d,
-		done:          make(chan struct{}),
+		disabled:      config.DisableTelemetry,
+		volumeCount:   newTelemetryVolumeCount(telemetryVolumeCountTTL, func() int { return getTelemetryVolumeCount(d) }),
+	}
+
+	if t.disabled {
+		log.WithField("driver", d.Name()).Info("EMS telemetry is disabled by the backend config.")
+		return t
 	}
 
 	usageHeartbeat := d.GetConfig().UsageHeartbeat
@@ -199,49 +261,70 @@ func NewOntapTelemetry(d StorageDriver) *Telemetry {
 	}
 	log.WithField("intervalHours", heartbeatIntervalInHours).Debug("Configured EMS heartbeat.")
 
-	durationInHours := time.Millisecond * time.Duration(MSecPerHour*heartbeatIntervalInHours)
-	if durationInHours > 0 {
-		t.ticker = time.NewTicker(durationInHours)
-	}
+	t.interval = time.Millisecond * time.Duration(MSecPerHour*heartbeatIntervalInHours)
 	return t
 }
 
-// Start starts the flow of ASUP messages for the driver
-// These messages can be viewed via filer::> event log show -severity NOTICE.
-func (t *Telemetry) Start() {
-	go func() {
-		time.Sleep(HousekeepingStartupDelaySecs * time.Second)
-		EMSHeartbeat(t.Driver)
-		for {
-			select {
-			case tick := <-t.ticker.C:
-				log.WithFields(log.Fields{
-					"tick":   tick,
-					"driver": t.Driver.Name(),
-				}).Debug("Sending EMS heartbeat.")
-				EMSHeartbeat(t.Driver)
-			case <-t.done:
-				log.WithFields(log.Fields{
-					"driver": t.Driver.Name(),
-				}).Debugf("Shut down EMS logs for the driver.")
-				return
-			}
-		}
-	}()
+// getTelemetryFeatureFlags reports which optional backend features are in use, so that
+// the EMS payload describes how Trident is configured without including any of the values.
+func getTelemetryFeatureFlags(config *drivers.OntapStorageDriverConfig) map[string]bool {
+	return map[string]bool{
+		"autoExportPolicy":    config.AutoExportPolicy,
+		"useCHAP":             config.UseCHAP,
+		"virtualPools":        len(config.Storage) > 0,
+		"limitVolumeSize":     config.LimitVolumeSize != "",
+		"limitAggregateUsage": config.LimitAggregateUsage != "",
+	}
+}
+
+// HousekeepingJob returns the job that sends the periodic EMS heartbeat for the driver, or nil
+// if telemetry is disabled.  These messages can be viewed via filer::> event log show -severity NOTICE.
+func (t *Telemetry) HousekeepingJob() *HousekeepingJob {
+	if t.disabled {
+		return nil
+	}
+	return &HousekeepingJob{
+		Name:         emsHeartbeatJob,
+		Interval:     t.interval,
+		InitialDelay: HousekeepingStartupDelaySecs * time.Second,
+		Jitter:       t.interval / housekeepingJitterDivisor,
+		Run:          func() { EMSHeartbeat(t.Driver) },
+	}
 }
 
-func (t *Telemetry) Stop() {
-	if t.ticker != nil {
-		t.ticker.Stop()
+// NewOntapHousekeepingScheduler creates the housekeeping scheduler for an ONTAP driver, with the
+// EMS heartbeat and feature probe already registered.  Drivers may add their own jobs before starting it.
+func NewOntapHousekeepingScheduler(d StorageDriver) *HousekeepingScheduler {
+	scheduler := NewHousekeepingScheduler(d.Name())
+	if job := d.GetTelemetry().HousekeepingJob(); job != nil {
+		_ = scheduler.AddJob(job)
+	}
+	_ = scheduler.AddJob(newFeatureProbeJob(d.GetAPI()))
+	if d.GetAPI().CanFailOver() {
+		resolver, _ := d.(dataLIFResolver)
+		_ = scheduler.AddJob(NewSVMFailoverMonitor(d.GetAPI(), resolver).HousekeepingJob())
 	}
-	if !t.stopped {
-		// calling close on an already closed channel causes a panic, guard against that
-		close(t.done)
-		t.stopped = true
+	if len(d.GetConfig().SnapshotPolicies) > 0 {
+		_ = scheduler.AddJob(newSnapshotPolicyReconcileJob(d.GetAPI(), d.GetConfig()))
 	}
+	return scheduler
 }
 
-func deleteExportPolicy(policy string, clientAPI *api.Client) error {
+// newFeatureProbeJob returns the job that probes the features available on the SVM again, so that
+// licenses added or removed while the backend is running are noticed.
+func newFeatureProbeJob(client *api.Client) *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         featureProbeJob,
+		Interval:     featureProbePeriod,
+		InitialDelay: featureProbePeriod,
+		Jitter:       featureProbePeriod / housekeepingJitterDivisor,
+		Run: func() {
+			log.WithField("features", client.ProbeFeatures()).Debug("ONTAP features.")
+		},
+	}
+}
+
+func deleteExportPolicy(policy string, clientAPI api.OntapAPI) error {
 	response, err := clientAPI.ExportPolicyDestroy(policy)
 	if err = api.GetError(response, err); err != nil {
 		err = fmt.Errorf("error deleteing export policy: %v", err)
@@ -249,20 +332,61 @@ func deleteExportPolicy(policy string, clientAPI *api.Client) error {
 	return err
 }
 
-func createExportRule(desiredPolicyRule, policyName string, clientAPI *api.Client) error {
-	ruleResponse, err := clientAPI.ExportRuleCreate(policyName, desiredPolicyRule,
-		[]string{"nfs"}, []string{"any"}, []string{"any"}, []string{"any"})
+// createExportRule adds a rule to an export policy.  If the backend reserves the lower rule indexes for rules
+// managed outside Trident, the rule is appended after the existing rules, at or above the index base, so
+// that the indexes of the other rules don't change, and is added to existingRules.  Hosts listed in
+// readOnlyHosts are granted read-only access.
+func createExportRule(
+	desiredPolicyRule, policyName string, config *drivers.OntapStorageDriverConfig, clientAPI api.OntapAPI,
+	existingRules map[string]int,
+) error {
+
+	ruleIndex := 0
+	if indexBase := getExportRuleIndexBase(config); indexBase > 1 {
+		ruleIndex = indexBase
+		for _, index := range existingRules {
+			if index >= ruleIndex {
+				ruleIndex = index + 1
+			}
+		}
+	}
+
+	roSecFlavors, rwSecFlavors, suSecFlavors := exportRuleSecFlavors(config, desiredPolicyRule)
+
+	ruleResponse, err := clientAPI.ExportRuleCreateWithOptions(policyName, desiredPolicyRule, ruleIndex,
+		config.ExportRule.AnonUID, []string{"nfs"}, roSecFlavors, rwSecFlavors, suSecFlavors)
 	if err = api.GetError(ruleResponse, err); err != nil {
 		err = fmt.Errorf("error creating export rule: %v", err)
 		log.WithFields(log.Fields{
 			"ExportPolicy": policyName,
 			"ClientMatch":  desiredPolicyRule,
 		}).Error(err)
+		return err
 	}
-	return err
+	if ruleIndex > 0 {
+		existingRules[desiredPolicyRule] = ruleIndex
+	}
+	return nil
+}
+
+// exportRuleSecFlavors returns the security flavors with which an export rule grants read-only, read-write,
+// and superuser access to a client match.  A backend using Kerberos grants access only to clients
+// authenticating with its flavor, and hosts listed in readOnlyHosts are granted read-only access.
+func exportRuleSecFlavors(
+	config *drivers.OntapStorageDriverConfig, clientMatch string,
+) (roSecFlavors, rwSecFlavors, suSecFlavors []string) {
+
+	flavor := "any"
+	if config.Kerberos != "" {
+		flavor = config.Kerberos
+	}
+	if utils.SliceContainsString(config.ExportRule.ReadOnlyHosts, clientMatch) {
+		return []string{flavor}, []string{"never"}, []string{"none"}
+	}
+	return []string{flavor}, []string{flavor}, []string{flavor}
 }
 
-func deleteExportRule(ruleIndex int, policyName string, clientAPI *api.Client) error {
+func deleteExportRule(ruleIndex int, policyName string, clientAPI api.OntapAPI) error {
 	ruleDestroyResponse, err := clientAPI.ExportRuleDestroy(policyName, ruleIndex)
 	if err = api.GetError(ruleDestroyResponse, err); err != nil {
 		err = fmt.Errorf("error deleting export rule on policy %s at index %d; %v",
@@ -275,7 +399,7 @@ func deleteExportRule(ruleIndex int, policyName string, clientAPI *api.Client) e
 	return err
 }
 
-func isExportPolicyExists(policyName string, clientAPI *api.Client) (bool, error) {
+func isExportPolicyExists(policyName string, clientAPI api.OntapAPI) (bool, error) {
 	policyGetResponse, err := clientAPI.ExportPolicyGet(policyName)
 	if err != nil {
 		err = fmt.Errorf("error getting export policy; %v", err)
@@ -283,7 +407,7 @@ func isExportPolicyExists(policyName string, clientAPI *api.Client) (bool, error
 		return false, err
 	}
 	if zerr := api.NewZapiError(policyGetResponse); !zerr.IsPassed() {
-		if zerr.Code() == azgo.EOBJECTNOTFOUND {
+		if zerr.IsNotFound() {
 			log.WithField("exportPolicy", policyName).Debug("Export policy not found.")
 			return false, nil
 		} else {
@@ -295,13 +419,13 @@ func isExportPolicyExists(policyName string, clientAPI *api.Client) (bool, error
 	return true, nil
 }
 
-func ensureExportPolicyExists(policyName string, clientAPI *api.Client) error {
+func ensureExportPolicyExists(policyName string, clientAPI api.OntapAPI) error {
 	policyCreateResponse, err := clientAPI.ExportPolicyCreate(policyName)
 	if err != nil {
 		err = fmt.Errorf("error creating export policy %s: %v", policyName, err)
 	}
 	if zerr := api.NewZapiError(policyCreateResponse); !zerr.IsPassed() {
-		if zerr.Code() == azgo.EDUPLICATEENTRY {
+		if zerr.IsExists() {
 			log.WithField("exportPolicy", policyName).Debug("Export policy already exists.")
 		} else {
 			err = fmt.Errorf("error creating export policy %s: %v", policyName, zerr)
@@ -312,8 +436,8 @@ func ensureExportPolicyExists(policyName string, clientAPI *api.Client) error {
 
 // publishFlexVolShare ensures that the volume has the correct export policy applied.
 func publishFlexVolShare(
-	clientAPI *api.Client, config *drivers.OntapStorageDriverConfig, publishInfo *utils.VolumePublishInfo,
-	volumeName string,
+	clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig, publishInfo *utils.VolumePublishInfo,
+	volConfig *storage.VolumeConfig, volumeName string,
 ) error {
 
 	if config.DebugTraceFlags["method"] {
@@ -331,18 +455,25 @@ func publishFlexVolShare(
 		return nil
 	}
 
-	if err := ensureNodeAccess(publishInfo, clientAPI, config); err != nil {
+	policyName := getExportPolicyName(publishInfo.BackendUUID)
+	if isScopedExportPolicy(config) {
+		// Scoped policies only grant access to the nodes that have published the volume
+		policyName = getScopedExportPolicyName(config, publishInfo.BackendUUID, volConfig)
+		if err := ensurePublishingNodeAccess(publishInfo, clientAPI, config, policyName); err != nil {
+			return err
+		}
+	} else if err := ensureNodeAccess(publishInfo, clientAPI, config); err != nil {
 		return err
 	}
 
 	// Update volume to use the correct export policy
-	policyName := getExportPolicyName(publishInfo.BackendUUID)
 	volumeModifyResponse, err := clientAPI.VolumeModifyExportPolicy(volumeName, policyName)
 	if err = api.GetError(volumeModifyResponse, err); err != nil {
 		err = fmt.Errorf("error updating export policy on volume %s: %v", volumeName, err)
 		log.Error(err)
 		return err
 	}
+	publishInfo.NfsExportPolicy = policyName
 	return nil
 }
 
@@ -350,10 +481,194 @@ func getExportPolicyName(backendUUID string) string {
 	return fmt.Sprintf("trident-%s", backendUUID)
 }
 
+// isScopedExportPolicy returns true if automatic export policies are created per volume or per storage class
+// rather than per backend.
+func isScopedExportPolicy(config *drivers.OntapStorageDriverConfig) bool {
+	if !config.AutoExportPolicy {
+		return false
+	}
+	return config.AutoExportPolicyScope == ExportPolicyScopeVolume ||
+		config.AutoExportPolicyScope == ExportPolicyScopeStorageClass
+}
+
+// getScopedExportPolicyName returns the name of the automatic export policy for a volume, which depends
+// on the backend's export policy scope.
+func getScopedExportPolicyName(
+	config *drivers.OntapStorageDriverConfig, backendUUID string, volConfig *storage.VolumeConfig,
+) string {
+	switch config.AutoExportPolicyScope {
+	case ExportPolicyScopeVolume:
+		return fmt.Sprintf("%s-vol-%s", getExportPolicyName(backendUUID), volConfig.InternalName)
+	case ExportPolicyScopeStorageClass:
+		return fmt.Sprintf("%s-sc-%s", getExportPolicyName(backendUUID), volConfig.StorageClass)
+	default:
+		return getExportPolicyName(backendUUID)
+	}
+}
+
+// isVolumeExportPolicy returns true if the named export policy was created for the named volume.
+func isVolumeExportPolicy(policyName, volumeName string) bool {
+	return strings.HasPrefix(policyName, "trident-") && strings.HasSuffix(policyName, "-vol-"+volumeName)
+}
+
+// getAutoExportPolicy returns the export policy a new volume should be created with.  Scoped policies
+// are created empty, so the volume is inaccessible until it is published to a node.
+func getAutoExportPolicy(
+	config *drivers.OntapStorageDriverConfig, clientAPI api.OntapAPI, backendUUID string,
+	volConfig *storage.VolumeConfig,
+) (string, error) {
+	if !isScopedExportPolicy(config) {
+		return getExportPolicyName(backendUUID), nil
+	}
+	policyName := getScopedExportPolicyName(config, backendUUID, volConfig)
+	if err := ensureExportPolicyExists(policyName, clientAPI); err != nil {
+		return "", err
+	}
+	return policyName, nil
+}
+
+// getVolumeExportPolicy returns the export policy applied to a volume, or an empty string if it cannot be read.
+func getVolumeExportPolicy(clientAPI api.OntapAPI, volumeName string) string {
+	volume, err := clientAPI.VolumeGet(volumeName)
+	if err != nil || volume.VolumeExportAttributesPtr == nil {
+		return ""
+	}
+	return volume.VolumeExportAttributesPtr.Policy()
+}
+
+// ensurePublishingNodeAccess adds rules for the publishing node to a scoped export policy.  Rules for
+// other nodes are left intact, since they may still have the volume mounted; rules for nodes that
+// leave the cluster are pruned by ReconcileNodeAccess.
+func ensurePublishingNodeAccess(
+	publishInfo *utils.VolumePublishInfo, clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig,
+	policyName string,
+) error {
+	if err := ensureExportPolicyExists(policyName, clientAPI); err != nil {
+		return err
+	}
+
+	node := &utils.Node{Name: publishInfo.HostName, IPs: publishInfo.HostIP}
+	desiredRules, err := getDesiredExportPolicyRules([]*utils.Node{node}, config)
+	if err != nil {
+		return fmt.Errorf("unable to determine export policy rules for node %s; %v", publishInfo.HostName, err)
+	}
+	if len(desiredRules) == 0 {
+		log.WithFields(log.Fields{
+			"exportPolicy": policyName,
+			"node":         publishInfo.HostName,
+		}).Warning("Node has no IP addresses within autoExportCIDRs, volume will not be accessible.")
+		return nil
+	}
+
+	existingRules, err := getExportPolicyRules(policyName, config, clientAPI)
+	if err != nil {
+		return err
+	}
+	for _, rule := range desiredRules {
+		if _, ok := existingRules[rule]; !ok {
+			if err = createExportRule(rule, policyName, config, clientAPI, existingRules); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getExportPolicyRules returns the client match of each rule in an export policy that Trident manages,
+// mapped to its rule index.  Rules below the backend's export rule index base are left to the administrator
+// and aren't returned.
+func getExportPolicyRules(
+	policyName string, config *drivers.OntapStorageDriverConfig, clientAPI api.OntapAPI,
+) (map[string]int, error) {
+	ruleListResponse, err := clientAPI.ExportRuleGetIterRequest(policyName)
+	if err = api.GetError(ruleListResponse, err); err != nil {
+		return nil, fmt.Errorf("error listing export policy rules: %v", err)
+	}
+	indexBase := getExportRuleIndexBase(config)
+	rules := make(map[string]int)
+	if ruleListResponse.Result.NumRecords() > 0 {
+		rulesAttrList := ruleListResponse.Result.AttributesList()
+		for _, rule := range rulesAttrList.ExportRuleInfo() {
+			if rule.RuleIndex() >= indexBase {
+				rules[rule.ClientMatch()] = rule.RuleIndex()
+			}
+		}
+	}
+	return rules, nil
+}
+
+// getExportRuleIndexBase returns the lowest export rule index that Trident manages.  The value is checked
+// by ValidateExportRuleConfig, so a missing or invalid value means all rules are managed.
+func getExportRuleIndexBase(config *drivers.OntapStorageDriverConfig) int {
+	if indexBase, err := strconv.Atoi(config.ExportRule.IndexBase); err == nil && indexBase > 1 {
+		return indexBase
+	}
+	return 1
+}
+
+// getUndesiredExportPolicyRules returns the indexes of existing rules that grant access to no current node.
+func getUndesiredExportPolicyRules(existingRules map[string]int, allowedRules []string) []int {
+	allowed := make(map[string]bool, len(allowedRules))
+	for _, rule := range allowedRules {
+		allowed[rule] = true
+	}
+	undesired := make([]int, 0)
+	for rule, ruleIndex := range existingRules {
+		if !allowed[rule] {
+			undesired = append(undesired, ruleIndex)
+		}
+	}
+	sort.Ints(undesired)
+	return undesired
+}
+
+// reconcileScopedExportPolicies removes rules for departed nodes from the scoped export policies in use by
+// a backend's volumes.  Rules are never added here, since only publishing a volume grants a node access.
+func reconcileScopedExportPolicies(
+	nodes []*utils.Node, config *drivers.OntapStorageDriverConfig, clientAPI api.OntapAPI, backendUUID string,
+	volumes *azgo.VolumeGetIterResponse,
+) (err error) {
+
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opExportPolicyReconcile, startTime, err)
+	}(time.Now())
+
+	allowedRules, err := getDesiredExportPolicyRules(nodes, config)
+	if err != nil {
+		return fmt.Errorf("unable to determine desired export policy rules; %v", err)
+	}
+
+	policyPrefix := getExportPolicyName(backendUUID) + "-"
+	policies := make(map[string]bool)
+	if volumes.Result.AttributesListPtr != nil {
+		for _, volume := range volumes.Result.AttributesListPtr.VolumeAttributesPtr {
+			if volume.VolumeExportAttributesPtr == nil {
+				continue
+			}
+			if policy := volume.VolumeExportAttributesPtr.Policy(); strings.HasPrefix(policy, policyPrefix) {
+				policies[policy] = true
+			}
+		}
+	}
+
+	for policyName := range policies {
+		existingRules, err := getExportPolicyRules(policyName, config, clientAPI)
+		if err != nil {
+			return err
+		}
+		for _, ruleIndex := range getUndesiredExportPolicyRules(existingRules, allowedRules) {
+			if err = deleteExportRule(ruleIndex, policyName, clientAPI); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ensureNodeAccess check to see if the export policy exists and if not it will create it and force a reconcile.
 // This should be used during publish to make sure access is available if the policy has somehow been deleted.
 // Otherwise we should not need to reconcile, which could be expensive.
-func ensureNodeAccess(publishInfo *utils.VolumePublishInfo, clientAPI *api.Client, config *drivers.OntapStorageDriverConfig) error {
+func ensureNodeAccess(publishInfo *utils.VolumePublishInfo, clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig) error {
 	policyName := getExportPolicyName(publishInfo.BackendUUID)
 	if exists, err := isExportPolicyExists(policyName, clientAPI); err != nil {
 		return err
@@ -366,12 +681,17 @@ func ensureNodeAccess(publishInfo *utils.VolumePublishInfo, clientAPI *api.Clien
 }
 
 func reconcileNASNodeAccess(
-	nodes []*utils.Node, config *drivers.OntapStorageDriverConfig, clientAPI *api.Client, policyName string,
-) error {
+	nodes []*utils.Node, config *drivers.OntapStorageDriverConfig, clientAPI api.OntapAPI, policyName string,
+) (err error) {
 	if !config.AutoExportPolicy {
 		return nil
 	}
-	err := ensureExportPolicyExists(policyName, clientAPI)
+
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opExportPolicyReconcile, startTime, err)
+	}(time.Now())
+
+	err = ensureExportPolicyExists(policyName, clientAPI)
 	if err != nil {
 		return err
 	}
@@ -381,7 +701,7 @@ func reconcileNASNodeAccess(
 		log.Error(err)
 		return err
 	}
-	err = reconcileExportPolicyRules(policyName, desiredRules, clientAPI)
+	err = reconcileExportPolicyRules(policyName, desiredRules, config, clientAPI)
 	if err != nil {
 		err = fmt.Errorf("unabled to reconcile export policy rules; %v", err)
 		log.WithField("ExportPolicy", policyName).Error(err)
@@ -390,6 +710,8 @@ func reconcileNASNodeAccess(
 	return nil
 }
 
+// getDesiredExportPolicyRules returns the client matches of the rules granting the nodes access, followed by
+// those of the backend's read-only hosts.
 func getDesiredExportPolicyRules(nodes []*utils.Node, config *drivers.OntapStorageDriverConfig) ([]string, error) {
 	rules := make([]string, 0)
 	for _, node := range nodes {
@@ -399,33 +721,52 @@ func getDesiredExportPolicyRules(nodes []*utils.Node, config *drivers.OntapStora
 			return nil, err
 		}
 		if len(filteredIPs) > 0 {
-			rules = append(rules, strings.Join(filteredIPs, ","))
+			rules = append(rules, getExportRuleClientMatch(filteredIPs))
+		}
+	}
+	for _, host := range config.ExportRule.ReadOnlyHosts {
+		if !utils.SliceContainsString(rules, host) {
+			rules = append(rules, host)
 		}
 	}
 	return rules, nil
 }
 
-func reconcileExportPolicyRules(policyName string, desiredPolicyRules []string, clientAPI *api.Client) error {
+// getExportRuleClientMatch returns the client match for an export rule granting access to a node's IP
+// addresses.  Addresses are written in canonical form, so that a dual-stack node's IPv6 addresses always
+// produce the same rule no matter how they were reported.
+func getExportRuleClientMatch(ips []string) string {
+	clients := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if parsedIP := net.ParseIP(utils.ParseHostportIP(ip)); parsedIP != nil {
+			clients = append(clients, parsedIP.String())
+		} else {
+			clients = append(clients, ip)
+		}
+	}
+	return strings.Join(clients, ",")
+}
 
-	ruleListResponse, err := clientAPI.ExportRuleGetIterRequest(policyName)
-	if err = api.GetError(ruleListResponse, err); err != nil {
-		return fmt.Errorf("error listing export policy rules: %v", err)
+func reconcileExportPolicyRules(
+	policyName string, desiredPolicyRules []string, config *drivers.OntapStorageDriverConfig,
+	clientAPI api.OntapAPI,
+) error {
+
+	existingRules, err := getExportPolicyRules(policyName, config, clientAPI)
+	if err != nil {
+		return err
 	}
-	rulesToRemove := make(map[string]int, 0)
-	if ruleListResponse.Result.NumRecords() > 0 {
-		rulesAttrList := ruleListResponse.Result.AttributesList()
-		rules := rulesAttrList.ExportRuleInfo()
-		for _, rule := range rules {
-			rulesToRemove[rule.ClientMatch()] = rule.RuleIndex()
-		}
+	rulesToRemove := make(map[string]int, len(existingRules))
+	for rule, ruleIndex := range existingRules {
+		rulesToRemove[rule] = ruleIndex
 	}
 	for _, rule := range desiredPolicyRules {
 		if _, ok := rulesToRemove[rule]; ok {
 			// Rule already exists and we want it, so don't create it or delete it
 			delete(rulesToRemove, rule)
 		} else {
-			// Rule does not exist, so create it
-			err = createExportRule(rule, policyName, clientAPI)
+			// Rule does not exist, so create it after the existing rules
+			err = createExportRule(rule, policyName, config, clientAPI, existingRules)
 			if err != nil {
 				return err
 			}
@@ -441,7 +782,7 @@ func reconcileExportPolicyRules(policyName string, desiredPolicyRules []string,
 	return nil
 }
 
-func reconcileSANNodeAccess(clientAPI *api.Client, igroupName string, nodeIQNs []string) error {
+func reconcileSANNodeAccess(clientAPI api.OntapAPI, igroupName string, nodeIQNs []string) error {
 	err := ensureIGroupExists(clientAPI, igroupName)
 	if err != nil {
 		return err
@@ -474,7 +815,7 @@ func reconcileSANNodeAccess(clientAPI *api.Client, igroupName string, nodeIQNs [
 			response, err := clientAPI.IgroupAdd(igroupName, iqn)
 			err = api.GetError(response, err)
 			zerr, zerrOK := err.(api.ZapiError)
-			if err == nil || (zerrOK && zerr.Code() == azgo.EVDISK_ERROR_INITGROUP_HAS_NODE) {
+			if err == nil || (zerrOK && zerr.IsExists()) {
 				log.WithFields(log.Fields{
 					"IQN":    iqn,
 					"igroup": igroupName,
@@ -490,7 +831,7 @@ func reconcileSANNodeAccess(clientAPI *api.Client, igroupName string, nodeIQNs [
 		response, err := clientAPI.IgroupRemove(igroupName, iqn, true)
 		err = api.GetError(response, err)
 		zerr, zerrOK := err.(api.ZapiError)
-		if err == nil || (zerrOK && zerr.Code() == azgo.EVDISK_ERROR_NODE_NOT_IN_INITGROUP) {
+		if err == nil || (zerrOK && zerr.IsNotFound()) {
 			log.WithFields(log.Fields{
 				"IQN":    iqn,
 				"igroup": igroupName,
@@ -503,9 +844,57 @@ func reconcileSANNodeAccess(clientAPI *api.Client, igroupName string, nodeIQNs [
 	return nil
 }
 
+// revokeSANNodeAccess removes a deleted node's IQN from the igroup and logs out any iSCSI sessions
+// the node still has with the SVM, so that it can no longer reach the LUNs mapped to the igroup.
+func revokeSANNodeAccess(ctx context.Context, clientAPI api.OntapAPI, igroupName, iqn string) error {
+
+	if iqn == "" {
+		return nil
+	}
+
+	logFields := log.Fields{
+		"IQN":    iqn,
+		"igroup": igroupName,
+	}
+
+	response, err := clientAPI.IgroupRemove(igroupName, iqn, true)
+	err = api.GetError(response, err)
+	zerr, zerrOK := err.(api.ZapiError)
+	if err == nil {
+		logc(ctx).WithFields(logFields).Info("Removed deleted node's IQN from igroup.")
+	} else if zerrOK && (zerr.IsNotFound()) {
+		logc(ctx).WithFields(logFields).Debug("Host IQN not in igroup.")
+	} else {
+		return wrapOntapError(err, fmt.Sprintf("error removing IQN %v from igroup %v", iqn, igroupName))
+	}
+
+	// Sessions outlive the igroup membership, so end them to keep a departed node from lingering
+	sessions, err := clientAPI.IscsiInitiatorSessionsGet(iqn)
+	if err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error reading iSCSI sessions of %v", iqn))
+	}
+	for _, session := range sessions {
+		if session.TpgroupNamePtr == nil || session.TargetSessionIdPtr == nil {
+			continue
+		}
+		sessionFields := log.Fields{
+			"IQN":     iqn,
+			"tpgroup": session.TpgroupName(),
+			"tsih":    session.TargetSessionId(),
+		}
+		response, err := clientAPI.IscsiSessionShutdown(session.TpgroupName(), session.TargetSessionId())
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, fmt.Sprintf("error logging out iSCSI session of %v", iqn))
+		}
+		logc(ctx).WithFields(sessionFields).Info("Logged out deleted node's iSCSI session.")
+	}
+
+	return nil
+}
+
 // GetISCSITargetInfo returns the iSCSI node name and iSCSI interfaces using the provided client's SVM.
 func GetISCSITargetInfo(
-	clientAPI *api.Client, config *drivers.OntapStorageDriverConfig,
+	clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig,
 ) (iSCSINodeName string, iSCSIInterfaces []string, returnError error) {
 
 	// Get the SVM iSCSI IQN
@@ -539,10 +928,97 @@ func GetISCSITargetInfo(
 	return
 }
 
+// ISCSIDataLIFs tracks the iSCSI data LIFs of an SVM.  LIFs may be added, removed, or migrated
+// after a SAN driver is initialized, so the list is refreshed periodically by a housekeeping job
+// as well as whenever a LUN is published.
+type ISCSIDataLIFs struct {
+	config *drivers.OntapStorageDriverConfig
+	client *api.Client
+	ips    []string
+	mutex  sync.RWMutex
+}
+
+// NewISCSIDataLIFs discovers the SVM's iSCSI data LIFs, returning an error if there are none.
+func NewISCSIDataLIFs(config *drivers.OntapStorageDriverConfig, client *api.Client) (*ISCSIDataLIFs, error) {
+
+	ips, err := client.NetInterfaceGetDataLIFs("iscsi")
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no iSCSI data LIFs found on SVM %s", config.SVM)
+	}
+	log.WithField("dataLIFs", ips).Debug("Found iSCSI LIFs.")
+
+	return &ISCSIDataLIFs{config: config, client: client, ips: ips}, nil
+}
+
+// IPs returns the most recently discovered data LIF IP addresses.
+func (l *ISCSIDataLIFs) IPs() []string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	ips := make([]string, len(l.ips))
+	copy(ips, l.ips)
+	return ips
+}
+
+// Refresh rediscovers the data LIFs and returns the current list.  If discovery fails or finds no
+// LIFs, the previously known LIFs are kept so that a transient error cannot break publishing.
+func (l *ISCSIDataLIFs) Refresh() []string {
+
+	ips, err := l.client.NetInterfaceGetDataLIFs("iscsi")
+	if err != nil {
+		log.WithField("error", err).Warning("Could not refresh iSCSI data LIFs, using cached list.")
+		return l.IPs()
+	} else if len(ips) == 0 {
+		log.Warning("No iSCSI data LIFs found during refresh, using cached list.")
+		return l.IPs()
+	}
+
+	l.mutex.Lock()
+	if !reflect.DeepEqual(ips, l.ips) {
+		log.WithFields(log.Fields{"oldDataLIFs": l.ips, "newDataLIFs": ips}).Info("iSCSI data LIFs changed.")
+	}
+	l.ips = ips
+	l.mutex.Unlock()
+
+	return l.IPs()
+}
+
+// HousekeepingJob returns the job that periodically rediscovers the data LIFs.  The interval is
+// read from the config file, falling back to the default if missing or invalid.  The default is
+// longer if the backend watches for data LIF events.
+func (l *ISCSIDataLIFs) HousekeepingJob() *HousekeepingJob {
+
+	dataLIFRefreshPeriodSecs := defaultDataLIFRefreshPeriodSecs
+	if eventWatchInterval, _ := getEventWatchInterval(l.config); eventWatchInterval > 0 {
+		dataLIFRefreshPeriodSecs = defaultWatchedDataLIFRefreshPeriodSecs
+	}
+	if l.config.DataLIFRefreshPeriod != "" {
+		i, err := strconv.ParseUint(l.config.DataLIFRefreshPeriod, 10, 64)
+		if err != nil {
+			log.WithField("interval", l.config.DataLIFRefreshPeriod).Warnf(
+				"Invalid data LIF refresh interval. %v", err)
+		} else {
+			dataLIFRefreshPeriodSecs = i
+		}
+	}
+	log.WithField("IntervalSeconds", dataLIFRefreshPeriodSecs).Debug("Configured data LIF refresh period.")
+
+	interval := time.Duration(dataLIFRefreshPeriodSecs) * time.Second
+
+	return &HousekeepingJob{
+		Name:         dataLIFRefreshJob,
+		Interval:     interval,
+		InitialDelay: interval,
+		Jitter:       interval / housekeepingJitterDivisor,
+		Run:          func() { l.Refresh() },
+	}
+}
+
 // PopulateOntapLunMapping helper function to fill in volConfig with its LUN mapping values.
-// This function assumes that the list of data LIFs has not changed since driver initialization and volume creation
 func PopulateOntapLunMapping(
-	clientAPI *api.Client, config *drivers.OntapStorageDriverConfig,
+	clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig,
 	ips []string, volConfig *storage.VolumeConfig, lunID int, lunPath, igroupName string) error {
 
 	var (
@@ -566,16 +1042,11 @@ func PopulateOntapLunMapping(
 		}
 	}
 
-	filteredIPs, err := getISCSIDataLIFsForReportingNodes(clientAPI, ips, lunPath, igroupName)
+	filteredIPs, _, err := getISCSIPortals(clientAPI, ips, lunPath, igroupName)
 	if err != nil {
 		return err
 	}
 
-	if len(filteredIPs) == 0 {
-		log.Warn("Unable to find reporting ONTAP nodes for discovered dataLIFs.")
-		filteredIPs = ips
-	}
-
 	volConfig.AccessInfo.IscsiTargetPortal = filteredIPs[0]
 	volConfig.AccessInfo.IscsiPortals = filteredIPs[1:]
 	volConfig.AccessInfo.IscsiTargetIQN = targetIQN
@@ -596,10 +1067,9 @@ func PopulateOntapLunMapping(
 // ontap-san-economy. This method may or may not be running on the host where the volume will be
 // mounted, so it should limit itself to updating access rules, initiator groups, etc. that require
 // some host identity (but not locality) as well as storage controller API access.
-// This function assumes that the list of data LIF IP addresses does not change between driver initialization
-// and publish
+// The caller should pass a freshly discovered list of data LIF IP addresses.
 func PublishLUN(
-	clientAPI *api.Client, config *drivers.OntapStorageDriverConfig, ips []string,
+	ctx context.Context, clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig, ips []string,
 	publishInfo *utils.VolumePublishInfo, lunPath, igroupName string, iSCSINodeName string,
 ) error {
 
@@ -651,39 +1121,59 @@ func PublishLUN(
 
 	if !publishInfo.Unmanaged {
 		// Add IQN to igroup
-		igroupAddResponse, err := clientAPI.IgroupAdd(igroupName, iqn)
-		err = api.GetError(igroupAddResponse, err)
+		err := retryOntapOperation(ctx, config, retryOpLunMap, func() error {
+			igroupAddResponse, err := clientAPI.IgroupAdd(igroupName, iqn)
+			return api.GetError(igroupAddResponse, err)
+		})
 		zerr, zerrOK := err.(api.ZapiError)
-		if err == nil || (zerrOK && zerr.Code() == azgo.EVDISK_ERROR_INITGROUP_HAS_NODE) {
+		if err == nil || (zerrOK && zerr.IsExists()) {
 			log.WithFields(log.Fields{
 				"IQN":    iqn,
 				"igroup": igroupName,
 			}).Debug("Host IQN already in igroup.")
 		} else {
-			return fmt.Errorf("error adding IQN %v to igroup %v: %v", iqn, igroupName, err)
+			return wrapOntapError(err, fmt.Sprintf("error adding IQN %v to igroup %v", iqn, igroupName))
 		}
 	}
 
 	// Map LUN (it may already be mapped)
-	lunID, err := clientAPI.LunMapIfNotMapped(igroupName, lunPath, publishInfo.Unmanaged)
-	if err != nil {
+	var lunID int
+	lunMapStartTime := time.Now()
+	err = retryOntapOperation(ctx, config, retryOpLunMap, func() error {
+		lunID, err = clientAPI.LunMapIfNotMapped(igroupName, lunPath, publishInfo.Unmanaged)
 		return err
+	})
+	observeOntapOperation(config, opLunMap, lunMapStartTime, err)
+	if err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error mapping LUN %s to igroup %s", lunPath, igroupName))
 	}
 
-	filteredIPs, err := getISCSIDataLIFsForReportingNodes(clientAPI, ips, lunPath, igroupName)
+	filteredIPs, reported, err := getISCSIPortals(clientAPI, ips, lunPath, igroupName)
 	if err != nil {
 		return err
 	}
 
-	if len(filteredIPs) == 0 {
-		log.Warn("Unable to find reporting ONTAP nodes for discovered dataLIFs.")
-		filteredIPs = ips
+	// Identify the LUN so the host can make sure it attaches the intended device.  The publish fails
+	// if the LUN can't be read, so that it is retried rather than attached without being checked.
+	lunInfo, err := clientAPI.LunGet(lunPath)
+	if err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error reading serial number of LUN %s", lunPath))
+	}
+	lunSerial, lunUUID := "", ""
+	if lunInfo.SerialNumberPtr != nil {
+		lunSerial = lunInfo.SerialNumber()
+	}
+	if lunInfo.UuidPtr != nil {
+		lunUUID = lunInfo.Uuid()
 	}
 
 	// Add fields needed by Attach
 	publishInfo.IscsiLunNumber = int32(lunID)
+	publishInfo.IscsiLunSerial = lunSerial
+	publishInfo.IscsiLunUUID = lunUUID
 	publishInfo.IscsiTargetPortal = filteredIPs[0]
 	publishInfo.IscsiPortals = filteredIPs[1:]
+	publishInfo.IscsiExpectedPaths = expectedISCSIPaths(filteredIPs, reported)
 	publishInfo.IscsiTargetIQN = iSCSINodeName
 	publishInfo.IscsiIgroup = igroupName
 	publishInfo.FilesystemType = fstype
@@ -701,44 +1191,124 @@ func PublishLUN(
 	return nil
 }
 
-// getISCSIDataLIFsForReportingNodes finds the data LIFs for the reporting nodes for the LUN.
-func getISCSIDataLIFsForReportingNodes(clientAPI *api.Client, ips []string, lunPath string, igroupName string,
-) ([]string, error) {
+// UpdateLUNPublication corrects the iSCSI portals of an already-published LUN if the data LIFs
+// have moved since it was published.  It returns true if publishInfo was changed.
+func UpdateLUNPublication(
+	clientAPI api.OntapAPI, config *drivers.OntapStorageDriverConfig, ips []string,
+	publishInfo *utils.VolumePublishInfo, lunPath string,
+) (bool, error) {
 
-	lunMapGetResponse, err := clientAPI.LunMapGet(igroupName, lunPath)
-	if err != nil {
-		return nil, fmt.Errorf("could not get iSCSI reported nodes: %v", err)
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":  "UpdateLUNPublication",
+			"Type":    "ontap_common",
+			"lunPath": lunPath,
+		}
+		log.WithFields(fields).Debug(">>>> UpdateLUNPublication")
+		defer log.WithFields(fields).Debug("<<<< UpdateLUNPublication")
 	}
 
-	reportingNodeNames := make(map[string]struct{})
-	if lunMapGetResponse.Result.AttributesListPtr != nil {
-		for _, lunMapInfo := range lunMapGetResponse.Result.AttributesListPtr {
-			for _, reportingNode := range lunMapInfo.ReportingNodes() {
-				log.WithField("reportingNode", reportingNode).Debug("Reporting node found.")
-				reportingNodeNames[reportingNode] = struct{}{}
-			}
-		}
+	if publishInfo.IscsiIgroup == "" {
+		return false, errors.New("igroup not specified in publish info")
 	}
 
-	var reportedDataLIFs []string
-	for _, ip := range ips {
-		currentNodeName, err := clientAPI.NetInterfaceGetDataLIFsNode(ip)
-		if err != nil {
-			return nil, err
-		}
-		if _, ok := reportingNodeNames[currentNodeName]; ok {
-			reportedDataLIFs = append(reportedDataLIFs, ip)
-		}
+	filteredIPs, reported, err := getISCSIPortals(clientAPI, ips, lunPath, publishInfo.IscsiIgroup)
+	if err != nil {
+		return false, err
 	}
 
-	log.WithField("reportedDataLIFs", reportedDataLIFs).Debug("Data LIFs with reporting nodes")
-	return reportedDataLIFs, nil
-}
+	currentPortals := append([]string{publishInfo.IscsiTargetPortal}, publishInfo.IscsiPortals...)
+	expectedPaths := expectedISCSIPaths(filteredIPs, reported)
+	if reflect.DeepEqual(currentPortals, filteredIPs) && publishInfo.IscsiExpectedPaths == expectedPaths {
+		return false, nil
+	}
 
-// randomString returns a string of the specified length.
-func randomChapString(strSize int) (string, error) {
-	b := make([]byte, strSize)
-	_, err := cryptorand.Read(b)
+	log.WithFields(log.Fields{
+		"LUN":        lunPath,
+		"oldPortals": currentPortals,
+		"newPortals": filteredIPs,
+	}).Info("Updating stale iSCSI portals.")
+
+	publishInfo.IscsiTargetPortal = filteredIPs[0]
+	publishInfo.IscsiPortals = filteredIPs[1:]
+	publishInfo.IscsiExpectedPaths = expectedISCSIPaths(filteredIPs, reported)
+
+	return true, nil
+}
+
+// getISCSIPortals returns the data LIFs on the LUN's reporting nodes, or all data LIFs if none of
+// them are on a reporting node.  It also returns whether the portals were found on reporting nodes.
+func getISCSIPortals(clientAPI api.OntapAPI, ips []string, lunPath string, igroupName string) ([]string, bool, error) {
+
+	filteredIPs, err := getISCSIDataLIFsForReportingNodes(clientAPI, ips, lunPath, igroupName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	reported := true
+	if len(filteredIPs) == 0 {
+		log.Warn("Unable to find reporting ONTAP nodes for discovered dataLIFs.")
+		filteredIPs = ips
+		reported = false
+	}
+
+	// IPv6 portals must be bracketed so that a port may be appended
+	portals := make([]string, 0, len(filteredIPs))
+	for _, ip := range filteredIPs {
+		portals = append(portals, utils.BracketIPv6(ip))
+	}
+
+	return portals, reported, nil
+}
+
+// expectedISCSIPaths returns the number of paths a host should find to a LUN, which is one for each
+// portal on a reporting node.  It returns zero if the reporting nodes aren't known, since the LUN may
+// not be visible through every portal.
+func expectedISCSIPaths(portals []string, reported bool) int {
+	if !reported {
+		return 0
+	}
+	return len(portals)
+}
+
+// getISCSIDataLIFsForReportingNodes finds the data LIFs for the reporting nodes for the LUN.
+func getISCSIDataLIFsForReportingNodes(clientAPI api.OntapAPI, ips []string, lunPath string, igroupName string,
+) ([]string, error) {
+
+	lunMapGetResponse, err := clientAPI.LunMapGet(igroupName, lunPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get iSCSI reported nodes: %v", err)
+	}
+
+	reportingNodeNames := make(map[string]struct{})
+	if lunMapGetResponse.Result.AttributesListPtr != nil {
+		for _, lunMapInfo := range lunMapGetResponse.Result.AttributesListPtr {
+			for _, reportingNode := range lunMapInfo.ReportingNodes() {
+				log.WithField("reportingNode", reportingNode).Debug("Reporting node found.")
+				reportingNodeNames[reportingNode] = struct{}{}
+			}
+		}
+	}
+
+	var reportedDataLIFs []string
+	for _, ip := range ips {
+		currentNodeName, err := clientAPI.NetInterfaceGetDataLIFsNode(ip)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := reportingNodeNames[currentNodeName]; ok {
+			reportedDataLIFs = append(reportedDataLIFs, ip)
+		}
+	}
+
+	log.WithField("reportedDataLIFs", reportedDataLIFs).Debug("Data LIFs with reporting nodes")
+	return reportedDataLIFs, nil
+}
+
+// randomString returns a string of the specified length.
+func randomChapString(strSize int) (string, error) {
+	b := make([]byte, strSize)
+	_, err := cryptorand.Read(b)
 	if err != nil {
 		fmt.Println("error:", err)
 		return "", err
@@ -901,9 +1471,9 @@ func InitializeSANDriver(context tridentconfig.DriverContext, clientAPI *api.Cli
 	}
 	if context == tridentconfig.ContextKubernetes {
 		log.WithFields(log.Fields{
-			"driver": drivers.OntapSANStorageDriverName,
-			"SVM":    config.SVM,
-			"igroup": config.IgroupName,
+			"driver":          drivers.OntapSANStorageDriverName,
+			utils.LogFieldSVM: config.SVM,
+			"igroup":          config.IgroupName,
 		}).Warn("Please ensure all relevant hosts are added to the initiator group.")
 	}
 
@@ -976,14 +1546,14 @@ func InitializeSANDriver(context tridentconfig.DriverContext, clientAPI *api.Cli
 	return nil
 }
 
-func ensureIGroupExists(clientAPI *api.Client, igroupName string) error {
+func ensureIGroupExists(clientAPI api.OntapAPI, igroupName string) error {
 	igroupResponse, err := clientAPI.IgroupCreate(igroupName, "iscsi", "linux")
 	if err != nil {
 		return fmt.Errorf("error creating igroup: %v", err)
 	}
 	if zerr := api.NewZapiError(igroupResponse); !zerr.IsPassed() {
 		// Handle case where the igroup already exists
-		if zerr.Code() != azgo.EVDISK_ERROR_INITGROUP_EXISTS {
+		if !zerr.IsExists() {
 			return fmt.Errorf("error creating igroup %v: %v", igroupName, zerr)
 		}
 	}
@@ -1000,36 +1570,42 @@ func InitializeOntapDriver(config *drivers.OntapStorageDriverConfig) (*api.Clien
 		defer log.WithFields(fields).Debug("<<<< InitializeOntapDriver")
 	}
 
-	// Splitting config.ManagementLIF with colon allows to provide managementLIF value as address:port format
-	mgmtLIF := ""
-	if utils.IPv6Check(config.ManagementLIF) {
-		// This is an IPv6 address
-
-		mgmtLIF = strings.Split(config.ManagementLIF, "[")[1]
-		mgmtLIF = strings.Split(mgmtLIF, "]")[0]
-	} else {
-		mgmtLIF = strings.Split(config.ManagementLIF, ":")[0]
+	if err := validateSVMFailoverConfig(config); err != nil {
+		return nil, err
 	}
-
-	addressesFromHostname, err := net.LookupHost(mgmtLIF)
-	if err != nil {
-		log.WithField("ManagementLIF", mgmtLIF).Error("Host lookup failed for ManagementLIF. ", err)
+	if err := validateSnapshotPolicyConfig(config); err != nil {
 		return nil, err
 	}
 
-	log.WithFields(log.Fields{
-		"hostname":  mgmtLIF,
-		"addresses": addressesFromHostname,
-	}).Debug("Addresses found from ManagementLIF lookup.")
+	// Splitting config.ManagementLIF with colon allows to provide managementLIF value as address:port format
+	mgmtLIF := utils.ParseHostportIP(config.ManagementLIF)
+
+	// The host lookup only serves to report an unresolvable managementLIF clearly, so run it while the
+	// API client discovers the SVM
+	lookupErrChan := make(chan error, 1)
+	go func() {
+		addressesFromHostname, err := net.LookupHost(mgmtLIF)
+		if err == nil {
+			log.WithFields(log.Fields{
+				"hostname":  mgmtLIF,
+				"addresses": addressesFromHostname,
+			}).Debug("Addresses found from ManagementLIF lookup.")
+		}
+		lookupErrChan <- err
+	}()
 
 	// Get the API client
 	client, err := InitializeOntapAPI(config)
+	if lookupErr := <-lookupErrChan; lookupErr != nil {
+		log.WithField("ManagementLIF", mgmtLIF).Error("Host lookup failed for ManagementLIF. ", lookupErr)
+		return nil, lookupErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not create Data ONTAP API client: %v", err)
 	}
 
 	// Make sure we're using a valid ONTAP version
-	ontapi, err := client.SystemGetOntapiVersion()
+	ontapi, err := discoverOntapiVersion(client, config)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine Data ONTAP API version: %v", err)
 	}
@@ -1038,16 +1614,17 @@ func InitializeOntapDriver(config *drivers.OntapStorageDriverConfig) (*api.Clien
 	}
 	log.WithField("Ontapi", ontapi).Debug("ONTAP API version.")
 
-	// Log cluster node serial numbers if we can get them
-	config.SerialNumbers, err = client.NodeListSerialNumbers()
-	if err != nil {
-		log.Warnf("Could not determine controller serial numbers. %v", err)
-	} else {
-		log.WithFields(log.Fields{
-			"serialNumbers": strings.Join(config.SerialNumbers, ","),
-		}).Info("Controller serial numbers.")
+	// Make sure the user can do everything the driver needs
+	if err = ValidateSVMPrivileges(client, config); err != nil {
+		return nil, err
 	}
 
+	// Log cluster node serial numbers if we can get them
+	discoverSerialNumbers(client, config)
+
+	// Find out which features the SVM offers, beyond those its ONTAP version supports
+	log.WithField("features", client.ProbeFeatures()).Debug("ONTAP features.")
+
 	// Load default config parameters
 	err = PopulateConfigurationDefaults(config)
 	if err != nil {
@@ -1074,18 +1651,44 @@ func InitializeOntapAPI(config *drivers.OntapStorageDriverConfig) (*api.Client,
 		Password:        config.Password,
 		DriverContext:   config.DriverContext,
 		DebugTraceFlags: config.DebugTraceFlags,
+		BackendName:     config.BackendName,
+
+		SecondaryManagementLIF: config.SecondaryManagementLIF,
+		SecondarySVM:           config.SecondarySVM,
 	})
 
 	if config.SVM != "" {
 
 		vserverResponse, err := client.VserverGetRequest()
-		if err = api.GetError(vserverResponse, err); err != nil {
+		if err = api.GetError(vserverResponse, err); err != nil && client.CanFailOver() {
+
+			// Start on the SVM-DR partner if the SVM is already down
+			log.WithFields(log.Fields{
+				utils.LogFieldSVM:        config.SVM,
+				"secondarySVM":           config.SecondarySVM,
+				"secondaryManagementLIF": config.SecondaryManagementLIF,
+			}).Warningf("Could not read SVM, failing over to SVM-DR partner. %v", err)
+			if failOverErr := client.FailOver(); failOverErr != nil {
+				return nil, fmt.Errorf("error reading SVM details: %v; could not fail over to SVM-DR partner: %v",
+					err, failOverErr)
+			}
+
+			// Nothing else is using the config yet, so it may follow the client to the partner
+			config.SVM, config.SecondarySVM = config.SecondarySVM, config.SVM
+			config.ManagementLIF, config.SecondaryManagementLIF = config.SecondaryManagementLIF, config.ManagementLIF
+
+			vserverResponse, err = client.VserverGetRequest()
+			err = api.GetError(vserverResponse, err)
+		}
+		if err != nil {
 			return nil, fmt.Errorf("error reading SVM details: %v", err)
 		}
 
 		client.SVMUUID = string(vserverResponse.Result.AttributesPtr.VserverInfoPtr.Uuid())
 
-		log.WithField("SVM", config.SVM).Debug("Using specified SVM.")
+		checkSVMAccess(client, config)
+
+		log.WithField(utils.LogFieldSVM, config.SVM).Debug("Using specified SVM.")
 		return client, nil
 	}
 
@@ -1096,6 +1699,9 @@ func InitializeOntapAPI(config *drivers.OntapStorageDriverConfig) (*api.Client,
 	}
 
 	if vserverResponse.Result.NumRecords() != 1 {
+		if clusterScoped, _ := client.IsClusterScoped(); clusterScoped {
+			return nil, errors.New("cluster-scoped credentials require an SVM; please specify SVM in config file")
+		}
 		return nil, errors.New("cannot derive SVM to use; please specify SVM in config file")
 	}
 
@@ -1110,13 +1716,142 @@ func InitializeOntapAPI(config *drivers.OntapStorageDriverConfig) (*api.Client,
 		Password:        config.Password,
 		DriverContext:   config.DriverContext,
 		DebugTraceFlags: config.DebugTraceFlags,
+		BackendName:     config.BackendName,
+
+		SecondaryManagementLIF: config.SecondaryManagementLIF,
+		SecondarySVM:           config.SecondarySVM,
 	})
 	client.SVMUUID = svmUUID
 
-	log.WithField("SVM", config.SVM).Debug("Using derived SVM.")
+	checkSVMAccess(client, config)
+
+	log.WithField(utils.LogFieldSVM, config.SVM).Debug("Using derived SVM.")
 	return client, nil
 }
 
+// svmPrivilege describes an API that an ONTAP driver calls on its SVM, so that a missing
+// privilege can be reported along with what it is needed for and how to grant it.
+type svmPrivilege struct {
+	api       string
+	operation string
+	directory string // the ONTAP command directory a custom role must include
+	access    string // the access level needed to the directory, "readonly" or "all"
+}
+
+// getRequiredSVMPrivileges returns the SVM privileges needed by the configured ONTAP driver.
+func getRequiredSVMPrivileges(config *drivers.OntapStorageDriverConfig) []svmPrivilege {
+
+	privileges := []svmPrivilege{
+		{"volume-get-iter", "list volumes", "volume", "readonly"},
+		{"volume-create", "create volumes", "volume", "all"},
+		{"snapshot-get-iter", "list snapshots", "volume snapshot", "readonly"},
+		{"snapshot-create", "create snapshots", "volume snapshot", "all"},
+		{"net-interface-get-iter", "discover data LIFs", "network interface", "readonly"},
+	}
+
+	switch config.StorageDriverName {
+	case drivers.OntapNASQtreeStorageDriverName:
+		privileges = append(privileges, svmPrivilege{"qtree-list-iter", "list qtrees", "volume qtree", "readonly"})
+	case drivers.OntapSANStorageDriverName, drivers.OntapSANEconomyStorageDriverName:
+		privileges = append(privileges,
+			svmPrivilege{"lun-get-iter", "list LUNs", "lun", "readonly"},
+			svmPrivilege{"igroup-get-iter", "list igroups", "lun igroup", "readonly"},
+			svmPrivilege{"lun-map", "map LUNs to igroups", "lun mapping", "all"},
+		)
+	}
+
+	if config.AutoExportPolicy {
+		privileges = append(privileges,
+			svmPrivilege{"export-policy-create", "create export policies", "vserver export-policy", "all"})
+	}
+	if len(config.SnapshotPolicies) > 0 {
+		privileges = append(privileges,
+			svmPrivilege{"snapshot-policy-create", "create snapshot policies", "volume snapshot policy", "all"})
+	}
+
+	return privileges
+}
+
+// checkSVMAccess logs whether the credentials are cluster-scoped, in which case API calls are
+// tunneled to the SVM.
+func checkSVMAccess(client *api.Client, config *drivers.OntapStorageDriverConfig) {
+
+	clusterScoped, err := client.IsClusterScoped()
+	if err != nil {
+		log.Warnf("Could not determine the scope of the ONTAP credentials. %v", err)
+	} else if clusterScoped {
+		log.WithFields(log.Fields{
+			"username":        config.Username,
+			utils.LogFieldSVM: config.SVM,
+		}).Info("Using cluster-scoped credentials, tunneling API calls to the SVM.")
+	}
+}
+
+// ValidateSVMPrivileges checks that the configured user's role grants each operation the driver
+// needs on the SVM, so that a restrictive role is reported when the backend is created rather
+// than when a volume is provisioned.  The role is only read, never exercised, and if it can't be
+// read the check is skipped.  The error lists every missing privilege.
+func ValidateSVMPrivileges(client *api.Client, config *drivers.OntapStorageDriverConfig) error {
+
+	access, err := client.GetUserRoleAccess(config.Username)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"username":        config.Username,
+			utils.LogFieldSVM: config.SVM,
+		}).Warnf("Could not read the role of the ONTAP user, not checking its privileges. %v", err)
+		return nil
+	}
+
+	privileges := getRequiredSVMPrivileges(config)
+	return getMissingPrivilegesError(config, privileges, getMissingSVMPrivileges(privileges, access))
+}
+
+// getMissingSVMPrivileges returns the APIs of the privileges for which a role grants too little
+// access to the command directory.
+func getMissingSVMPrivileges(privileges []svmPrivilege, access api.RoleAccess) []string {
+
+	missing := make([]string, 0)
+	for _, privilege := range privileges {
+		switch access.AccessLevel(privilege.directory) {
+		case "all":
+		case "readonly":
+			if privilege.access == "all" {
+				missing = append(missing, privilege.api)
+			}
+		default:
+			missing = append(missing, privilege.api)
+		}
+	}
+	return missing
+}
+
+// getMissingPrivilegesError builds an actionable report of the missing privileges, or returns
+// nil if there are none.
+func getMissingPrivilegesError(
+	config *drivers.OntapStorageDriverConfig, privileges []svmPrivilege, missing []string,
+) error {
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	missingSet := make(map[string]bool)
+	for _, apiName := range missing {
+		missingSet[apiName] = true
+	}
+
+	reports := make([]string, 0)
+	for _, privilege := range privileges {
+		if missingSet[privilege.api] {
+			reports = append(reports, fmt.Sprintf("cannot %s (%s, command directory \"%s\")",
+				privilege.operation, privilege.api, privilege.directory))
+		}
+	}
+
+	return fmt.Errorf("user %s has insufficient privileges on SVM %s: %s; use the vsadmin role or grant "+
+		"the listed command directories", config.Username, config.SVM, strings.Join(reports, "; "))
+}
+
 // ValidateSANDriver contains the validation logic shared between ontap-san and ontap-san-economy.
 func ValidateSANDriver(api *api.Client, config *drivers.OntapStorageDriverConfig, ips []string) error {
 
@@ -1128,14 +1863,16 @@ func ValidateSANDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
 
 	// If the user sets the LIF to use in the config, disable multipathing and use just the one IP address
 	if config.DataLIF != "" {
-		// Make sure it's actually a valid address
-		if ip := net.ParseIP(config.DataLIF); nil == ip {
+		// Make sure it's actually a valid address, which may be a bracketed IPv6 address
+		dataLIF := net.ParseIP(utils.ParseHostportIP(config.DataLIF))
+		if nil == dataLIF {
 			return fmt.Errorf("data LIF is not a valid IP: %s", config.DataLIF)
 		}
 		// Make sure the IP matches one of the LIFs
 		found := false
 		for _, ip := range ips {
-			if config.DataLIF == ip {
+			if dataLIF.Equal(net.ParseIP(ip)) {
+				config.DataLIF = ip
 				found = true
 				break
 			}
@@ -1152,7 +1889,11 @@ func ValidateSANDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
 
 	if config.DriverContext == tridentconfig.ContextDocker {
 		// Make sure this host is logged into the ONTAP iSCSI target
-		err := utils.EnsureISCSISessions(ips)
+		portals := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			portals = append(portals, utils.BracketIPv6(ip))
+		}
+		err := utils.EnsureISCSISessions(portals)
 		if err != nil {
 			return fmt.Errorf("error establishing iSCSI session: %v", err)
 		}
@@ -1163,6 +1904,18 @@ func ValidateSANDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
                 return err
         }
 
+	if err := ValidateVolumeNameTemplate(config.VolumeNameTemplate); err != nil {
+		return err
+	}
+
+	if err := ValidateVolumeCommentTemplate(config.VolumeCommentTemplate); err != nil {
+		return err
+	}
+
+	if _, err := getEventWatchInterval(config); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1175,6 +1928,10 @@ func ValidateNASDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
 		defer log.WithFields(fields).Debug("<<<< ValidateNASDriver")
 	}
 
+	if err := utils.ValidateNFSMountOptions(config.NfsMountOptions); err != nil {
+		return fmt.Errorf("invalid value for nfsMountOptions: %v", err)
+	}
+
 	dataLIFs, err := api.NetInterfaceGetDataLIFs("nfs")
 	if err != nil {
 		return err
@@ -1188,18 +1945,27 @@ func ValidateNASDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
 
 	// If they didn't set a LIF to use in the config, we'll set it to the first nfs LIF we happen to find
 	if config.DataLIF == "" {
-		if utils.IPv6Check(dataLIFs[0]) {
-			config.DataLIF = "[" + dataLIFs[0] + "]"
-		} else {
-			config.DataLIF = dataLIFs[0]
-		}
+		config.DataLIF = utils.BracketIPv6(dataLIFs[0])
 	} else {
-		cleanDataLIF := strings.Replace(config.DataLIF, "[", "", 1)
-		cleanDataLIF = strings.Replace(cleanDataLIF, "]", "", 1)
+		cleanDataLIF := utils.ParseHostportIP(config.DataLIF)
 		_, err := ValidateDataLIF(cleanDataLIF, dataLIFs)
 		if err != nil {
 			return fmt.Errorf("data LIF validation failed: %v", err)
 		}
+		// IPv6 addresses must be bracketed when used in an NFS export path
+		config.DataLIF = utils.BracketIPv6(cleanDataLIF)
+	}
+
+	if config.Kerberos != "" {
+		if err := validateKerberosConfig(api, config); err != nil {
+			return err
+		}
+	}
+
+	if config.AutoExportPolicy {
+		if err = utils.ValidateCIDRs(config.AutoExportCIDRs); err != nil {
+			return fmt.Errorf("invalid autoExportCIDRs: %v", err)
+		}
 	}
 
         err = ValidateStoragePrefix(*config.StoragePrefix)
@@ -1207,9 +1973,71 @@ func ValidateNASDriver(api *api.Client, config *drivers.OntapStorageDriverConfig
                 return err
         }
 
+	if err = ValidateVolumeNameTemplate(config.VolumeNameTemplate); err != nil {
+		return err
+	}
+
+	if err = ValidateVolumeCommentTemplate(config.VolumeCommentTemplate); err != nil {
+		return err
+	}
+
+	if err = ValidateExportPolicyScope(config); err != nil {
+		return err
+	}
+
+	if err = ValidateExportRuleConfig(config); err != nil {
+		return err
+	}
+
+	if _, err = getEventWatchInterval(config); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateExportRuleConfig checks the options controlling the rules of automatic export policies.
+func ValidateExportRuleConfig(config *drivers.OntapStorageDriverConfig) error {
+	if config.ExportRule.AnonUID != "" {
+		if _, err := strconv.ParseUint(config.ExportRule.AnonUID, 10, 32); err != nil {
+			return fmt.Errorf("invalid value for exportRule.anonUID: %v", err)
+		}
+	}
+	if config.ExportRule.IndexBase != "" {
+		indexBase, err := strconv.Atoi(config.ExportRule.IndexBase)
+		if err != nil {
+			return fmt.Errorf("invalid value for exportRule.indexBase: %v", err)
+		} else if indexBase < 1 {
+			return fmt.Errorf("invalid value for exportRule.indexBase: %d; must be at least 1", indexBase)
+		}
+	}
+	for _, host := range config.ExportRule.ReadOnlyHosts {
+		if strings.TrimSpace(host) == "" {
+			return errors.New("exportRule.readOnlyHosts may not contain empty entries")
+		}
+	}
 	return nil
 }
 
+// ValidateExportPolicyScope ensures the automatic export policy scope is known and supported by the driver.
+// Qtrees share the export policy of their Flexvol, so they may only use backend-scoped policies.
+func ValidateExportPolicyScope(config *drivers.OntapStorageDriverConfig) error {
+	switch config.AutoExportPolicyScope {
+	case "", ExportPolicyScopeBackend:
+		return nil
+	case ExportPolicyScopeVolume, ExportPolicyScopeStorageClass:
+		if config.StorageDriverName == drivers.OntapNASQtreeStorageDriverName {
+			return fmt.Errorf("autoExportPolicyScope %s is not supported by the %s driver",
+				config.AutoExportPolicyScope, config.StorageDriverName)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid value for autoExportPolicyScope: %s; must be one of %s, %s, or %s",
+			config.AutoExportPolicyScope, ExportPolicyScopeBackend, ExportPolicyScopeVolume,
+			ExportPolicyScopeStorageClass)
+	}
+}
+
 func ValidateStoragePrefix(storagePrefix string) error {
 
         // Ensure storage prefix is compatible with ONTAP
@@ -1241,7 +2069,7 @@ func ValidateDataLIF(dataLIF string, dataLIFs []string) ([]string, error) {
 
 	loop:
 		for _, lifAddress := range dataLIFs {
-			if lifAddress == hostNameAddress {
+			if net.ParseIP(lifAddress).Equal(net.ParseIP(hostNameAddress)) {
 				foundValidLIFAddress = true
 				break loop
 			}
@@ -1277,6 +2105,19 @@ const DefaultLimitAggregateUsage = ""
 const DefaultLimitVolumeSize = ""
 const DefaultTieringPolicy = ""
 
+// Range of days ONTAP accepts for a volume's tiering minimum cooling period
+const (
+	minTieringMinimumCoolingDays = 2
+	maxTieringMinimumCoolingDays = 183
+)
+
+// Scopes of automatically managed export policies
+const (
+	ExportPolicyScopeBackend      = "backend"
+	ExportPolicyScopeVolume       = "volume"
+	ExportPolicyScopeStorageClass = "storageClass"
+)
+
 // PopulateConfigurationDefaults fills in default values for configuration settings if not supplied in the config file
 func PopulateConfigurationDefaults(config *drivers.OntapStorageDriverConfig) error {
 
@@ -1381,26 +2222,42 @@ func PopulateConfigurationDefaults(config *drivers.OntapStorageDriverConfig) err
 		config.AutoExportCIDRs = []string{"0.0.0.0/0", "::/0"}
 	}
 
+	if err := ValidateAggregateMedia(config); err != nil {
+		return err
+	}
+
+	if err := ValidateRetryBudgets(config); err != nil {
+		return err
+	}
+
+	if config.AutoExportPolicyScope == "" {
+		config.AutoExportPolicyScope = ExportPolicyScopeBackend
+	}
+
 	log.WithFields(log.Fields{
-		"StoragePrefix":       *config.StoragePrefix,
-		"SpaceAllocation":     config.SpaceAllocation,
-		"SpaceReserve":        config.SpaceReserve,
-		"SnapshotPolicy":      config.SnapshotPolicy,
-		"SnapshotReserve":     config.SnapshotReserve,
-		"UnixPermissions":     config.UnixPermissions,
-		"SnapshotDir":         config.SnapshotDir,
-		"ExportPolicy":        config.ExportPolicy,
-		"SecurityStyle":       config.SecurityStyle,
-		"NfsMountOptions":     config.NfsMountOptions,
-		"SplitOnClone":        config.SplitOnClone,
-		"FileSystemType":      config.FileSystemType,
-		"Encryption":          config.Encryption,
-		"LimitAggregateUsage": config.LimitAggregateUsage,
-		"LimitVolumeSize":     config.LimitVolumeSize,
-		"Size":                config.Size,
-		"TieringPolicy":       config.TieringPolicy,
-		"AutoExportPolicy":    config.AutoExportPolicy,
-		"AutoExportCIDRs":     config.AutoExportCIDRs,
+		"StoragePrefix":             *config.StoragePrefix,
+		"SpaceAllocation":           config.SpaceAllocation,
+		"SpaceReserve":              config.SpaceReserve,
+		"SnapshotPolicy":            config.SnapshotPolicy,
+		"SnapshotReserve":           config.SnapshotReserve,
+		"UnixPermissions":           config.UnixPermissions,
+		"SnapshotDir":               config.SnapshotDir,
+		"ExportPolicy":              config.ExportPolicy,
+		"SecurityStyle":             config.SecurityStyle,
+		"NfsMountOptions":           config.NfsMountOptions,
+		"SplitOnClone":              config.SplitOnClone,
+		"FileSystemType":            config.FileSystemType,
+		"Encryption":                config.Encryption,
+		"LimitAggregateUsage":       config.LimitAggregateUsage,
+		"LimitVolumeSize":           config.LimitVolumeSize,
+		"Size":                      config.Size,
+		"TieringPolicy":             config.TieringPolicy,
+		"TieringMinimumCoolingDays": config.TieringMinimumCoolingDays,
+		"FlexcacheOrigin":           config.FlexcacheOrigin,
+		"AllowVolumeShrink":         config.AllowVolumeShrink,
+		"AutoExportPolicy":          config.AutoExportPolicy,
+		"AutoExportCIDRs":           config.AutoExportCIDRs,
+		"AutoExportScope":           config.AutoExportPolicyScope,
 	}).Debugf("Configuration defaults")
 
 	return nil
@@ -1566,6 +2423,92 @@ func GetSnapshotReserve(snapshotPolicy, snapshotReserve string) (int, error) {
 	}
 }
 
+// calculateFlexvolSizeBytes returns the size of a Flexvol that still provides the requested usable
+// capacity after the snapshot reserve is set aside.  An unset reserve is treated as zero.
+func calculateFlexvolSizeBytes(usableSizeBytes uint64, snapshotReserve int) uint64 {
+
+	if snapshotReserve <= 0 || snapshotReserve >= 100 {
+		return usableSizeBytes
+	}
+
+	// Round up so that the usable capacity is never less than requested
+	usablePercent := uint64(100 - snapshotReserve)
+	return (usableSizeBytes*100 + usablePercent - 1) / usablePercent
+}
+
+// calculateUsableSizeBytes returns the capacity of a Flexvol that remains after the snapshot
+// reserve is set aside.  It is the inverse of calculateFlexvolSizeBytes.
+func calculateUsableSizeBytes(flexvolSizeBytes uint64, snapshotReserve int) uint64 {
+
+	if snapshotReserve <= 0 || snapshotReserve >= 100 {
+		return flexvolSizeBytes
+	}
+
+	return flexvolSizeBytes * uint64(100-snapshotReserve) / 100
+}
+
+// getVolumeSnapshotReserve returns the snapshot reserve percentage of a Flexvol or FlexGroup,
+// or zero if the attributes don't include it.
+func getVolumeSnapshotReserve(volAttrs *azgo.VolumeAttributesType) int {
+	if volAttrs == nil || volAttrs.VolumeSpaceAttributesPtr == nil ||
+		volAttrs.VolumeSpaceAttributesPtr.PercentageSnapshotReservePtr == nil {
+		return 0
+	}
+	return volAttrs.VolumeSpaceAttributesPtr.PercentageSnapshotReserve()
+}
+
+// getUsableVolumeSize returns the capacity of a Flexvol or FlexGroup available to the user,
+// i.e. its size less the snapshot reserve.
+func getUsableVolumeSize(volAttrs *azgo.VolumeAttributesType) uint64 {
+	if volAttrs == nil || volAttrs.VolumeSpaceAttributesPtr == nil || volAttrs.VolumeSpaceAttributesPtr.SizePtr == nil {
+		return 0
+	}
+	return calculateUsableSizeBytes(uint64(volAttrs.VolumeSpaceAttributesPtr.Size()), getVolumeSnapshotReserve(volAttrs))
+}
+
+// usableVolumeSizeFunc adapts a volume getter into a function returning the usable size of a volume,
+// suitable for use with resizeValidation.
+func usableVolumeSizeFunc(volumeGet func(string) (*azgo.VolumeAttributesType, error)) func(string) (int, error) {
+	return func(name string) (int, error) {
+		volAttrs, err := volumeGet(name)
+		if err != nil {
+			return 0, err
+		} else if volAttrs == nil {
+			return 0, fmt.Errorf("volume %s not found", name)
+		}
+		return int(getUsableVolumeSize(volAttrs)), nil
+	}
+}
+
+// adjustSizeForDefaultSnapshotReserve grows a newly created volume whose snapshot reserve was left
+// to ONTAP, so that it still provides the requested usable capacity.
+func adjustSizeForDefaultSnapshotReserve(
+	name string, usableSizeBytes uint64, volumeGet func(string) (*azgo.VolumeAttributesType, error),
+	volumeSetSize func(string, string) error,
+) error {
+
+	volAttrs, err := volumeGet(name)
+	if err != nil {
+		return fmt.Errorf("could not read snapshot reserve of volume %s; %v", name, err)
+	}
+
+	snapshotReserve := getVolumeSnapshotReserve(volAttrs)
+	if snapshotReserve == 0 {
+		return nil
+	}
+
+	flexvolSizeBytes := calculateFlexvolSizeBytes(usableSizeBytes, snapshotReserve)
+
+	log.WithFields(log.Fields{
+		"name":             name,
+		"snapshotReserve":  snapshotReserve,
+		"usableSizeBytes":  usableSizeBytes,
+		"flexvolSizeBytes": flexvolSizeBytes,
+	}).Debug("Resizing volume to account for default snapshot reserve.")
+
+	return volumeSetSize(name, strconv.FormatUint(flexvolSizeBytes, 10))
+}
+
 // EMSHeartbeat logs an ASUP message on a timer
 // view them via filer::> event log show -severity NOTICE
 func EMSHeartbeat(driver StorageDriver) {
@@ -1577,7 +2520,13 @@ func EMSHeartbeat(driver StorageDriver) {
 		hostname = "unknown"
 	}
 
-	message, _ := json.Marshal(driver.GetTelemetry())
+	// The payload is a copy, so that the telemetry shared by the driver isn't changed
+	telemetry := *driver.GetTelemetry()
+	if telemetry.volumeCount != nil {
+		telemetry.VolumeCount = telemetry.volumeCount.get()
+	}
+
+	message, _ := json.Marshal(telemetry)
 
 	emsResponse, err := driver.GetAPI().EmsAutosupportLog(
 		strconv.Itoa(drivers.ConfigVersion), false, "heartbeat", hostname,
@@ -1593,17 +2542,80 @@ func EMSHeartbeat(driver StorageDriver) {
 	}
 }
 
-const MSecPerHour = 1000 * 60 * 60 // millis * seconds * minutes
+// telemetryVolumeCountTTL is how long the volume count sent with the EMS heartbeat is reused before the
+// driver's volumes are counted again, so that a short heartbeat interval doesn't list every volume each time.
+const telemetryVolumeCountTTL = 24 * time.Hour
+
+// telemetryVolumeCount remembers how many volumes a driver manages between EMS heartbeats.
+type telemetryVolumeCount struct {
+	ttl     time.Duration
+	now     func() time.Time
+	count   func() int
+	value   int
+	expires time.Time
+	mutex   sync.Mutex
+}
 
-// probeForVolume polls for the ONTAP volume to appear, with backoff retry logic
-func probeForVolume(name string, client *api.Client) error {
-	checkVolumeExists := func() error {
-		volExists, err := client.VolumeExists(name)
-		if err != nil {
-			return err
-		}
-		if !volExists {
-			return fmt.Errorf("volume %v does not yet exist", name)
+func newTelemetryVolumeCount(ttl time.Duration, count func() int) *telemetryVolumeCount {
+	return &telemetryVolumeCount{
+		ttl:   ttl,
+		now:   time.Now,
+		count: count,
+	}
+}
+
+// get returns the remembered volume count, counting the volumes again once it has expired.
+func (c *telemetryVolumeCount) get() int {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.now().After(c.expires) {
+		c.value = c.count()
+		c.expires = c.now().Add(c.ttl)
+	}
+	return c.value
+}
+
+// getTelemetryVolumeCount returns the number of volumes the driver manages, so that the EMS
+// heartbeat carries a single batched count rather than any per-volume detail.
+func getTelemetryVolumeCount(driver StorageDriver) int {
+
+	lister, ok := driver.(interface {
+		GetVolumeExternalWrappers(chan *storage.VolumeExternalWrapper)
+	})
+	if !ok {
+		return 0
+	}
+
+	count := 0
+	channel := make(chan *storage.VolumeExternalWrapper)
+	go lister.GetVolumeExternalWrappers(channel)
+	for wrapper := range channel {
+		if wrapper.Error != nil {
+			log.WithFields(log.Fields{
+				"driver": driver.Name(),
+				"error":  wrapper.Error,
+			}).Warn("Could not count volumes for EMS heartbeat.")
+			continue
+		}
+		count++
+	}
+
+	return count
+}
+
+const MSecPerHour = 1000 * 60 * 60 // millis * seconds * minutes
+
+// probeForVolume polls for the ONTAP volume to appear, with backoff retry logic
+func probeForVolume(name string, client api.OntapAPI) error {
+	checkVolumeExists := func() error {
+		volExists, err := client.VolumeExists(name)
+		if err != nil {
+			return err
+		}
+		if !volExists {
+			return fmt.Errorf("volume %v does not yet exist", name)
 		}
 		return nil
 	}
@@ -1626,10 +2638,39 @@ func probeForVolume(name string, client *api.Client) error {
 	}
 }
 
-// Create a volume clone
+// wrapOntapError classifies an error returned by an ONTAP API call as one of the typed storage driver
+// errors, so that callers may react to missing or conflicting resources, exhausted capacity, insufficient
+// privileges, and transient failures without inspecting the message.  Errors that fit none of those are
+// returned as plain errors.  In all cases the message is prefixed to the original error.
+func wrapOntapError(err error, message string) error {
+
+	if err == nil {
+		return nil
+	}
+	message = fmt.Sprintf("%s: %v", message, err)
+
+	switch api.GetErrorCategory(err) {
+	case api.ZapiErrorCategoryNotFound:
+		return drivers.NewResourceNotFoundError(message, err)
+	case api.ZapiErrorCategoryExists:
+		return drivers.NewResourceExistsError(message, err)
+	case api.ZapiErrorCategoryAuth:
+		return drivers.NewUnauthorizedError(message, err)
+	case api.ZapiErrorCategoryRetryable, api.ZapiErrorCategoryBusy:
+		return drivers.NewRetriableError(message, err)
+	case api.ZapiErrorCategoryNoSpace:
+		return drivers.NewResourceExhaustedError(message, err)
+	}
+
+	return errors.New(message)
+}
+
+// Create a volume clone.  Unless created by an ONTAP job, the clone is tagged with the UUID of the request
+// it is created for, so that a retry may complete it and a failed create may destroy it.
 func CreateOntapClone(
-	name, source, snapshot string, split bool, config *drivers.OntapStorageDriverConfig, client *api.Client,
-	useAsync bool) error {
+	ctx context.Context, name, source, snapshot, uuid string, split bool, config *drivers.OntapStorageDriverConfig, client api.OntapAPI,
+	useAsync bool, splits *CloneSplitTracker, baseSnapshots *CloneSnapshotReaper, jobs *AsyncJobTracker,
+) (err error) {
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1644,63 +2685,186 @@ func CreateOntapClone(
 		defer log.WithFields(fields).Debug("<<<< CreateOntapClone")
 	}
 
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opCloneCreate, startTime, err)
+	}(time.Now())
+
+	// A clone already being created by an ONTAP job exists, and only its job need be waited for
+	resumed := useAsync && jobs.Has(name)
+
 	// If the specified volume already exists, return an error
-	volExists, err := client.VolumeExists(name)
-	if err != nil {
-		return fmt.Errorf("error checking for existing volume: %v", err)
-	}
-	if volExists {
-		return fmt.Errorf("volume %s already exists", name)
+	if !resumed {
+		volExists, err := client.VolumeExists(name)
+		if err != nil {
+			return wrapOntapError(err, "error checking for existing volume")
+		}
+		if volExists && (useAsync || uuid == "") {
+			return drivers.NewResourceExistsError(fmt.Sprintf("volume %s already exists", name), nil)
+		} else if volExists {
+			return checkExistingFlexvol(ctx, client, name, uuid, false, nil)
+		}
 	}
 
-	// If no specific snapshot was requested, create one
-	if snapshot == "" {
+	// If no specific snapshot was requested, create one, to be deleted once the clone no longer needs it
+	if snapshot == "" && !resumed {
 		snapshot = time.Now().UTC().Format(storage.SnapshotNameFormat)
-		snapResponse, err := client.SnapshotCreate(snapshot, source)
-		if err = api.GetError(snapResponse, err); err != nil {
-			return fmt.Errorf("error creating snapshot: %v", err)
+		err = retryOntapOperation(ctx, config, retryOpSnapshotCreate, func() error {
+			snapResponse, err := client.SnapshotCreateWithComment(snapshot, source, baseSnapshots.Comment())
+			return api.GetError(snapResponse, err)
+		})
+		if err != nil {
+			return wrapOntapError(err, "error creating snapshot")
 		}
+		baseSnapshots.Track(name, source, snapshot)
+		defer func() {
+			if err != nil && !utils.IsVolumeCreatingError(err) {
+				baseSnapshots.Release(ctx, name)
+			}
+		}()
 	}
 
 	// Create the clone based on a snapshot
 	if useAsync {
-		cloneResponse, err := client.VolumeCloneCreateAsync(name, source, snapshot)
-		err = client.WaitForAsyncResponse(cloneResponse, maxFlexGroupCloneWait)
-		if err != nil {
-			return errors.New("waiting for async response failed")
+		if err = createOntapCloneAsync(name, source, snapshot, client, jobs); err != nil {
+			return err
 		}
 	} else {
-		cloneResponse, err := client.VolumeCloneCreate(name, source, snapshot)
-		if err != nil {
-			return fmt.Errorf("error creating clone: %v", err)
+		err = retryOntapOperation(ctx, config, retryOpCloneCreate, func() error {
+			cloneResponse, err := client.VolumeCloneCreate(name, source, snapshot)
+			return api.GetError(cloneResponse, err)
+		})
+		if zerr, ok := err.(api.ZapiError); ok {
+			if err = handleCreateOntapCloneErr(zerr, client, snapshot, source, name); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return wrapOntapError(err, "error creating clone")
 		}
-		if zerr := api.NewZapiError(cloneResponse); !zerr.IsPassed() {
-			return handleCreateOntapCloneErr(zerr, client, snapshot, source, name)
+
+		// Tag the clone with its request, and destroy it if it can't be finished so a retry starts over
+		if uuid != "" {
+			commentResponse, commentErr := client.VolumeSetComment(name, addCreationTag("", uuid, config.BackendUUID))
+			if commentErr = api.GetError(commentResponse, commentErr); commentErr != nil {
+				log.WithField("volume", name).Warningf("Could not tag clone with its request; %v", commentErr)
+			}
 		}
+		defer func() {
+			if err != nil {
+				cleanupFailedFlexvolCreate(ctx, client, name, uuid)
+			}
+		}()
 	}
 
 	if config.StorageDriverName == drivers.OntapNASStorageDriverName {
 		// Mount the new volume
-		mountResponse, err := client.VolumeMount(name, "/"+name)
-		if err = api.GetError(mountResponse, err); err != nil {
-			return fmt.Errorf("error mounting volume to junction: %v", err)
+		mountResponse, mountErr := client.VolumeMount(name, "/"+name)
+		if err = api.GetError(mountResponse, mountErr); err != nil {
+			return wrapOntapError(err, "error mounting volume to junction")
 		}
 	}
 
-	// Split the clone if requested
+	// Split the clone if requested, via the tracker if there is one so the split's progress is followed
 	if split {
-		splitResponse, err := client.VolumeCloneSplitStart(name)
-		if err = api.GetError(splitResponse, err); err != nil {
-			return fmt.Errorf("error splitting clone: %v", err)
+		if splits != nil {
+			err = splits.Start(name)
+		} else {
+			splitResponse, splitErr := client.VolumeCloneSplitStart(name)
+			err = api.GetError(splitResponse, splitErr)
+		}
+		if err != nil {
+			return wrapOntapError(err, "error splitting clone")
+		}
+	}
+
+	return nil
+}
+
+// resumeFlexvolCreate checks that a Flexvol left behind by a create or clone interrupted by a restart exists
+// and was created for the same request.  A clone that was to be split may have had its split queued but not
+// started, so the split is resumed.
+func resumeFlexvolCreate(
+	ctx context.Context, client api.OntapAPI, config *drivers.OntapStorageDriverConfig,
+	volConfig *storage.VolumeConfig, splits *CloneSplitTracker,
+) error {
+
+	name := volConfig.InternalName
+
+	exists, err := client.VolumeExists(name)
+	if err != nil {
+		return wrapOntapError(err, "error checking for existing volume")
+	} else if !exists {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found", name))
+	}
+
+	if volConfig.UUID != "" {
+		creationUUID, err := getFlexvolCreationUUID(client, name)
+		if err != nil {
+			return fmt.Errorf("error checking existing volume %s; %v", name, err)
+		} else if creationUUID != "" && creationUUID != volConfig.UUID {
+			return fmt.Errorf("volume %s was not created for this request", name)
+		}
+	}
+
+	return resumeCloneSplit(ctx, config, volConfig, splits)
+}
+
+// resumeCloneSplit follows the split of a clone that was to be split from its parent again after a restart,
+// starting or queueing the split if ONTAP isn't running it.
+func resumeCloneSplit(
+	ctx context.Context, config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig,
+	splits *CloneSplitTracker,
+) error {
+
+	if volConfig.CloneSourceVolume == "" || splits == nil {
+		return nil
+	}
+	splitOnClone := volConfig.SplitOnClone
+	if splitOnClone == "" {
+		splitOnClone = config.SplitOnClone
+	}
+	if split, _ := strconv.ParseBool(splitOnClone); !split {
+		return nil
+	}
+
+	logc(ctx).WithField("volume", volConfig.InternalName).Debug("Resuming clone split.")
+	return splits.Resume(volConfig.InternalName)
+}
+
+// createOntapCloneAsync creates a clone using an ONTAP job, or waits for the job already creating it.
+// If the job is still running after maxFlexGroupCloneWait, a VolumeCreatingError is returned and the
+// job is left to the tracker.
+func createOntapCloneAsync(name, source, snapshot string, client api.OntapAPI, jobs *AsyncJobTracker) error {
+
+	if !jobs.Has(name) {
+		cloneResponse, err := client.VolumeCloneCreateAsync(name, source, snapshot)
+		if err != nil {
+			return wrapOntapError(err, "error creating clone")
+		}
+		asyncResult, err := api.NewZapiAsyncResult(cloneResponse)
+		if err != nil {
+			return wrapOntapError(err, "error creating clone")
+		} else if asyncResult.IsFailed() {
+			return fmt.Errorf("error creating clone: result status is failed with errorCode %d",
+				asyncResult.ErrorCode())
+		} else if !asyncResult.IsInProgress() {
+			return nil
 		}
+		jobs.Track(name, storage.StorageJobOperationCloneCreate, asyncResult.JobID())
 	}
 
+	if err := jobs.Wait(name, maxFlexGroupCloneWait); err != nil {
+		if utils.IsVolumeCreatingError(err) {
+			return err
+		}
+		return fmt.Errorf("error creating clone: %v", err)
+	}
 	return nil
 }
 
-func handleCreateOntapCloneErr(zerr api.ZapiError, client *api.Client, snapshot, source, name string) error {
-	if zerr.Code() == azgo.EOBJECTNOTFOUND {
-		return fmt.Errorf("snapshot %s does not exist in volume %s", snapshot, source)
+func handleCreateOntapCloneErr(zerr api.ZapiError, client api.OntapAPI, snapshot, source, name string) error {
+	if zerr.IsNotFound() {
+		return drivers.NewResourceNotFoundError(
+			fmt.Sprintf("snapshot %s does not exist in volume %s", snapshot, source), zerr)
 	} else if zerr.IsFailedToLoadJobError() {
 		fields := log.Fields{
 			"zerr": zerr,
@@ -1710,7 +2874,7 @@ func handleCreateOntapCloneErr(zerr api.ZapiError, client *api.Client, snapshot,
 			return volumeLookupError
 		}
 	} else {
-		return fmt.Errorf("error creating clone: %v", zerr)
+		return wrapOntapError(zerr, "error creating clone")
 	}
 
 	return nil
@@ -1728,10 +2892,10 @@ func GetSnapshot(
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "ontap_common",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolName,
+			"Method":             "GetSnapshot",
+			"Type":               "ontap_common",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolName,
 		}
 		log.WithFields(fields).Debug(">>>> GetSnapshot")
 		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
@@ -1744,7 +2908,7 @@ func GetSnapshot(
 
 	snapListResponse, err := client.SnapshotList(internalVolName)
 	if err = api.GetError(snapListResponse, err); err != nil {
-		return nil, fmt.Errorf("error enumerating snapshots: %v", err)
+		return nil, wrapOntapError(err, "error enumerating snapshots")
 	}
 
 	if snapListResponse.Result.AttributesListPtr != nil {
@@ -1752,23 +2916,25 @@ func GetSnapshot(
 			if snap.Name() == internalSnapName {
 
 				log.WithFields(log.Fields{
-					"snapshotName": internalSnapName,
-					"volumeName":   internalVolName,
-					"created":      snap.AccessTime(),
+					"snapshotName":       internalSnapName,
+					utils.LogFieldVolume: internalVolName,
+					"created":            snap.AccessTime(),
 				}).Debug("Found snapshot.")
 
 				return &storage.Snapshot{
-					Config:    snapConfig,
-					Created:   time.Unix(int64(snap.AccessTime()), 0).UTC().Format(storage.SnapshotTimestampFormat),
-					SizeBytes: int64(size),
+					Config:        snapConfig,
+					Created:       time.Unix(int64(snap.AccessTime()), 0).UTC().Format(storage.SnapshotTimestampFormat),
+					SizeBytes:     int64(size),
+					UsedBytes:     getSnapshotUsedBytes(snap),
+					VolumeReserve: getSnapshotReserve(client, internalVolName, snapListResponse),
 				}, nil
 			}
 		}
 	}
 
 	log.WithFields(log.Fields{
-		"snapshotName": internalSnapName,
-		"volumeName":   internalVolName,
+		"snapshotName":       internalSnapName,
+		utils.LogFieldVolume: internalVolName,
 	}).Warning("Snapshot not found.")
 
 	return nil, nil
@@ -1784,9 +2950,9 @@ func GetSnapshots(
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":     "GetSnapshotList",
-			"Type":       "ontap_common",
-			"volumeName": internalVolName,
+			"Method":             "GetSnapshotList",
+			"Type":               "ontap_common",
+			utils.LogFieldVolume: internalVolName,
 		}
 		log.WithFields(fields).Debug(">>>> GetSnapshotList")
 		defer log.WithFields(fields).Debug("<<<< GetSnapshotList")
@@ -1799,11 +2965,12 @@ func GetSnapshots(
 
 	snapListResponse, err := client.SnapshotList(internalVolName)
 	if err = api.GetError(snapListResponse, err); err != nil {
-		return nil, fmt.Errorf("error enumerating snapshots: %v", err)
+		return nil, wrapOntapError(err, "error enumerating snapshots")
 	}
 
 	log.Debugf("Returned %v snapshots.", snapListResponse.Result.NumRecords())
 	snapshots := make([]*storage.Snapshot, 0)
+	reserve := getSnapshotReserve(client, internalVolName, snapListResponse)
 
 	if snapListResponse.Result.AttributesListPtr != nil {
 		for _, snap := range snapListResponse.Result.AttributesListPtr.SnapshotInfoPtr {
@@ -1821,8 +2988,10 @@ func GetSnapshots(
 					VolumeName:         volConfig.Name,
 					VolumeInternalName: volConfig.InternalName,
 				},
-				Created:   time.Unix(int64(snap.AccessTime()), 0).UTC().Format(storage.SnapshotTimestampFormat),
-				SizeBytes: int64(size),
+				Created:       time.Unix(int64(snap.AccessTime()), 0).UTC().Format(storage.SnapshotTimestampFormat),
+				SizeBytes:     int64(size),
+				UsedBytes:     getSnapshotUsedBytes(snap),
+				VolumeReserve: reserve,
 			}
 
 			snapshots = append(snapshots, snapshot)
@@ -1832,33 +3001,78 @@ func GetSnapshots(
 	return snapshots, nil
 }
 
+// getSnapshotUsedBytes returns the space held only by a snapshot, which ONTAP reports in KiB.
+func getSnapshotUsedBytes(snap azgo.SnapshotInfoType) int64 {
+	if snap.TotalPtr == nil {
+		return 0
+	}
+	return int64(snap.Total()) * 1024
+}
+
+// getSnapshotReserve returns how a volume's snapshots use the space reserved for them, given the volume's
+// snapshots.  Usage is informational, so if the volume's space can't be read, nil is returned.
+func getSnapshotReserve(
+	client *api.Client, volumeName string, snapListResponse *azgo.SnapshotGetIterResponse,
+) *storage.SnapshotReserve {
+
+	spaceAttrs, err := client.VolumeSpaceGet(volumeName)
+	if err != nil {
+		log.WithField(utils.LogFieldVolume, volumeName).Warningf("Could not read snapshot reserve. %v", err)
+		return nil
+	}
+
+	reserve := &storage.SnapshotReserve{}
+	if spaceAttrs.SnapshotReserveSizePtr != nil {
+		reserve.ReserveBytes = int64(spaceAttrs.SnapshotReserveSize())
+	}
+	if spaceAttrs.SizeUsedBySnapshotsPtr != nil {
+		reserve.UsedBytes = int64(spaceAttrs.SizeUsedBySnapshots())
+	}
+	if reserve.UsedBytes > reserve.ReserveBytes {
+		reserve.SpillBytes = reserve.UsedBytes - reserve.ReserveBytes
+	}
+	if snapListResponse.Result.AttributesListPtr != nil {
+		reserve.Count = len(snapListResponse.Result.AttributesListPtr.SnapshotInfoPtr)
+	}
+	return reserve
+}
+
 // CreateSnapshot creates a snapshot for the given volume.
 func CreateSnapshot(
-	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	ctx context.Context, snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
 	sizeGetter func(string) (int, error),
-) (*storage.Snapshot, error) {
+) (snapshot *storage.Snapshot, err error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "CreateSnapshot",
-			"Type":         "ontap_common",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolName,
+			"Method":             "CreateSnapshot",
+			"Type":               "ontap_common",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolName,
 		}
 		log.WithFields(fields).Debug(">>>> CreateSnapshot")
 		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opSnapshotCreate, startTime, err)
+	}(time.Now())
+
 	// If the specified volume doesn't exist, return error
 	volExists, err := client.VolumeExists(internalVolName)
 	if err != nil {
-		return nil, fmt.Errorf("error checking for existing volume: %v", err)
+		return nil, wrapOntapError(err, "error checking for existing volume")
 	}
 	if !volExists {
-		return nil, fmt.Errorf("volume %s does not exist", internalVolName)
+		return nil, drivers.NewResourceNotFoundError(fmt.Sprintf("volume %s does not exist", internalVolName), nil)
+	}
+
+	comment, snapmirrorLabel, err := getSnapshotAttributes(ctx, snapConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	size, err := sizeGetter(internalVolName)
@@ -1866,15 +3080,19 @@ func CreateSnapshot(
 		return nil, fmt.Errorf("error reading volume size: %v", err)
 	}
 
-	snapResponse, err := client.SnapshotCreate(internalSnapName, internalVolName)
-	if err = api.GetError(snapResponse, err); err != nil {
-		return nil, fmt.Errorf("could not create snapshot: %v", err)
+	err = retryOntapOperation(ctx, config, retryOpSnapshotCreate, func() error {
+		snapResponse, err := client.SnapshotCreateWithAttributes(
+			internalSnapName, internalVolName, comment, snapmirrorLabel)
+		return api.GetError(snapResponse, err)
+	})
+	if err != nil {
+		return nil, wrapOntapError(err, "could not create snapshot")
 	}
 
 	// Fetching list of snapshots to get snapshot access time
 	snapListResponse, err := client.SnapshotList(internalVolName)
 	if err = api.GetError(snapListResponse, err); err != nil {
-		return nil, fmt.Errorf("error enumerating snapshots: %v", err)
+		return nil, wrapOntapError(err, "error enumerating snapshots")
 	}
 	if snapListResponse.Result.AttributesListPtr != nil {
 		for _, snap := range snapListResponse.Result.AttributesListPtr.SnapshotInfoPtr {
@@ -1890,6 +3108,130 @@ func CreateSnapshot(
 	return nil, fmt.Errorf("could not find snapshot %s for souce volume %s", internalSnapName, internalVolName)
 }
 
+// getSnapshotAttributes returns the comment and SnapMirror label requested by a snapshot's parameters,
+// which are empty if not requested.  Parameters the ONTAP drivers don't recognize are ignored, as a
+// snapshot class may be used with volumes of any backend.
+func getSnapshotAttributes(
+	ctx context.Context, snapConfig *storage.SnapshotConfig,
+) (comment, snapmirrorLabel string, err error) {
+
+	for key, value := range snapConfig.Parameters {
+		switch key {
+		case SnapshotParamComment:
+			if len(value) > maxSnapshotCommentLength {
+				return "", "", fmt.Errorf("snapshot %s comment is longer than %d characters",
+					snapConfig.Name, maxSnapshotCommentLength)
+			}
+			comment = value
+		case SnapshotParamSnapmirrorLabel:
+			if len(value) > maxSnapmirrorLabelLength {
+				return "", "", fmt.Errorf("snapshot %s SnapMirror label %s is longer than %d characters",
+					snapConfig.Name, value, maxSnapmirrorLabelLength)
+			}
+			snapmirrorLabel = value
+		default:
+			logc(ctx).WithFields(log.Fields{
+				"snapshot":  snapConfig.Name,
+				"parameter": key,
+			}).Warning("Ignoring unknown snapshot parameter.")
+		}
+	}
+	return comment, snapmirrorLabel, nil
+}
+
+// CreateGroupSnapshot creates a crash-consistent set of snapshots, one of each volume named in the
+// snapshot configs, using an ONTAP consistency group.  ONTAP fences I/O to all of the volumes while
+// the snapshots are taken, and creates either all of the snapshots or none of them.
+func CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig,
+	client *api.Client, sizeGetter func(string) (int, error),
+) (snapshots []*storage.Snapshot, err error) {
+
+	if len(snapConfigs) == 0 {
+		return nil, errors.New("a group snapshot requires at least one volume")
+	}
+
+	internalSnapName := snapConfigs[0].InternalName
+	internalVolNames := make([]string, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		if snapConfig.InternalName != internalSnapName {
+			return nil, fmt.Errorf("snapshots in a group must share a name, found %s and %s",
+				internalSnapName, snapConfig.InternalName)
+		}
+		internalVolNames = append(internalVolNames, snapConfig.VolumeInternalName)
+	}
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":       "CreateGroupSnapshot",
+			"Type":         "ontap_common",
+			"snapshotName": internalSnapName,
+			"volumeNames":  internalVolNames,
+		}
+		log.WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer log.WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opGroupSnapshotCreate, startTime, err)
+	}(time.Now())
+
+	// If any of the specified volumes doesn't exist, return error
+	for _, internalVolName := range internalVolNames {
+		volExists, err := client.VolumeExists(internalVolName)
+		if err != nil {
+			return nil, wrapOntapError(err, "error checking for existing volume")
+		}
+		if !volExists {
+			return nil, drivers.NewResourceNotFoundError(fmt.Sprintf("volume %s does not exist", internalVolName), nil)
+		}
+	}
+
+	// Fence the volumes and begin the snapshots.  A start that ONTAP rejects leaves nothing behind, so
+	// it is safe to retry.
+	var cgID int
+	err = retryOntapOperation(ctx, config, retryOpSnapshotCreate, func() error {
+		cgResponse, err := client.ConsistencyGroupStart(internalSnapName, internalVolNames, consistencyGroupTimeout)
+		if err = api.GetError(cgResponse, err); err != nil {
+			return err
+		}
+		if cgResponse.Result.CgIdPtr == nil {
+			return errors.New("consistency group start returned no ID")
+		}
+		cgID = cgResponse.Result.CgId()
+		return nil
+	})
+	if err != nil {
+		return nil, wrapOntapError(err, "could not start consistency group snapshot")
+	}
+
+	// Commit the snapshots and release the fence.  If the commit fails, ONTAP discards the snapshots
+	// once the consistency group times out.
+	cgCommitResponse, err := client.ConsistencyGroupCommit(cgID)
+	if err = api.GetError(cgCommitResponse, err); err != nil {
+		return nil, wrapOntapError(err, "could not commit consistency group snapshot")
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName": internalSnapName,
+		"volumeNames":  internalVolNames,
+		"cgID":         cgID,
+	}).Debug("Created consistency group snapshot.")
+
+	snapshots = make([]*storage.Snapshot, 0, len(snapConfigs))
+	for _, snapConfig := range snapConfigs {
+		snapshot, err := GetSnapshot(snapConfig, config, client, sizeGetter)
+		if err != nil {
+			return nil, err
+		} else if snapshot == nil {
+			return nil, fmt.Errorf("could not find snapshot %s for source volume %s",
+				internalSnapName, snapConfig.VolumeInternalName)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
 // Restore a volume (in place) from a snapshot.
 func RestoreSnapshot(
 	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client) error {
@@ -1899,10 +3241,10 @@ func RestoreSnapshot(
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "RestoreSnapshot",
-			"Type":         "ontap_common",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolName,
+			"Method":             "RestoreSnapshot",
+			"Type":               "ontap_common",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolName,
 		}
 		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
 		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
@@ -1911,128 +3253,423 @@ func RestoreSnapshot(
 	snapResponse, err := client.SnapshotRestoreVolume(internalSnapName, internalVolName)
 
 	if err = api.GetError(snapResponse, err); err != nil {
-		return fmt.Errorf("error restoring snapshot: %v", err)
+		return wrapOntapError(err, "error restoring snapshot")
 	}
 
 	log.WithFields(log.Fields{
-		"snapshotName": internalSnapName,
-		"volumeName":   internalVolName,
+		"snapshotName":       internalSnapName,
+		utils.LogFieldVolume: internalVolName,
 	}).Debug("Restored snapshot.")
 
 	return nil
 }
 
-// DeleteSnapshot deletes a single snapshot.
+// DeleteSnapshot deletes a single snapshot.  If the snapshot is busy because clones still depend on it,
+// the snapshot is handed to the clone splitter (if any), which splits off the clones and deletes the
+// snapshot in the background, and a DeletionDeferredError is returned.
 func DeleteSnapshot(
-	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client) error {
+	ctx context.Context, snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	splitter *CloneSplitter,
+) (err error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "DeleteSnapshot",
-			"Type":         "ontap_common",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolName,
+			"Method":             "DeleteSnapshot",
+			"Type":               "ontap_common",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolName,
 		}
 		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
 		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
-	snapResponse, err := client.SnapshotDelete(internalSnapName, internalVolName)
+	defer func(startTime time.Time) {
+		observeOntapOperation(config, opSnapshotDelete, startTime, err)
+	}(time.Now())
+
+	err = retryOntapOperation(ctx, config, retryOpSnapshotDelete, func() error {
+		snapResponse, err := client.SnapshotDelete(internalSnapName, internalVolName)
+		return api.GetError(snapResponse, err)
+	})
+
+	if zerr, ok := err.(api.ZapiError); ok {
+		if zerr.IsBusy() {
+			// The splitter deletes the snapshot once its clones are split, so the caller need not retry
+			if splitter != nil {
+				splitter.Enqueue(snapConfig)
+				return drivers.NewDeletionDeferredError(fmt.Sprintf(
+					"snapshot %s is backing clones, it will be deleted once they are split", internalSnapName), zerr)
+			}
+			// Start a split here before returning the error so a subsequent delete attempt may succeed.
+			_ = SplitVolumeFromBusySnapshot(snapConfig, config, client, nil)
+		}
+		return wrapOntapError(zerr, "error deleting snapshot")
+	} else if err != nil {
+		return wrapOntapError(err, "error deleting snapshot")
+	}
+
+	log.WithField("snapshotName", internalSnapName).Debug("Deleted snapshot.")
+	return nil
+}
+
+// SplitVolumeFromBusySnapshot gets the list of volumes backed by a busy snapshot and starts
+// a split operation on the first one (sorted by volume name).  If a clone split tracker is
+// supplied, the split is started by the tracker, so it may be queued behind other splits.
+func SplitVolumeFromBusySnapshot(
+	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+	tracker *CloneSplitTracker,
+) error {
+
+	internalSnapName := snapConfig.InternalName
+	internalVolName := snapConfig.VolumeInternalName
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":             "SplitVolumeFromBusySnapshot",
+			"Type":               "ontap_common",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolName,
+		}
+		log.WithFields(fields).Debug(">>>> SplitVolumeFromBusySnapshot")
+		defer log.WithFields(fields).Debug("<<<< SplitVolumeFromBusySnapshot")
+	}
+
+	childVolumes, err := client.VolumeListAllBackedBySnapshot(internalVolName, internalSnapName)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"snapshotName":     internalSnapName,
+			"parentVolumeName": internalVolName,
+			"error":            err,
+		}).Error("Could not list volumes backed by snapshot.")
+		return err
+	} else if len(childVolumes) == 0 {
+		return nil
+	}
+
+	// We're going to start a single split operation, but there could be multiple children, so we
+	// sort the volumes by name to not have more than one split operation running at a time.
+	sort.Strings(childVolumes)
+
+	if tracker != nil {
+		err = tracker.Start(childVolumes[0])
+	} else {
+		splitResponse, splitErr := client.VolumeCloneSplitStart(childVolumes[0])
+		err = api.GetError(splitResponse, splitErr)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"snapshotName":     internalSnapName,
+			"parentVolumeName": internalVolName,
+			"cloneVolumeName":  childVolumes[0],
+			"error":            err,
+		}).Error("Could not begin splitting clone from snapshot.")
+		return fmt.Errorf("error splitting clone: %v", err)
+	}
+
+	log.WithFields(log.Fields{
+		"snapshotName":     internalSnapName,
+		"parentVolumeName": internalVolName,
+		"cloneVolumeName":  childVolumes[0],
+	}).Info("Began splitting clone from snapshot.")
+
+	return nil
+}
+
+// CloneSplitter is a deferred-deletion queue for snapshots that could not be deleted because they are
+// backing clones.  Each time its housekeeping job runs, it starts splitting the next clone of every
+// queued snapshot, and once no clones depend on a snapshot any longer, it deletes the snapshot and
+// notifies its handler (if any).  A snapshot is dropped from the queue if deleting it keeps failing
+// for any reason other than the snapshot being busy.
+type CloneSplitter struct {
+	config    *drivers.OntapStorageDriverConfig
+	client    *api.Client
+	tracker   *CloneSplitTracker
+	snapshots map[string]*storage.SnapshotConfig
+	failures  map[string]int
+	handler   storage.SnapshotDeleteHandler
+	mutex     sync.Mutex
+
+	// deleteSnapshot and listClones are fields so that unit tests need not talk to ONTAP
+	deleteSnapshot func(snapConfig *storage.SnapshotConfig) error
+	listClones     func(snapConfig *storage.SnapshotConfig) ([]string, error)
+}
+
+func NewCloneSplitter(
+	config *drivers.OntapStorageDriverConfig, client *api.Client, tracker *CloneSplitTracker,
+) *CloneSplitter {
+	c := &CloneSplitter{
+		config:    config,
+		client:    client,
+		tracker:   tracker,
+		snapshots: make(map[string]*storage.SnapshotConfig),
+		failures:  make(map[string]int),
+	}
+	c.deleteSnapshot = c.deleteOntapSnapshot
+	c.listClones = c.listOntapClones
+	return c
+}
+
+// SetHandler registers a function to be called whenever a queued snapshot is deleted or dropped.
+func (c *CloneSplitter) SetHandler(handler storage.SnapshotDeleteHandler) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.handler = handler
+}
+
+// Enqueue starts a split of the first clone backed by a busy snapshot and remembers the snapshot
+// so that any remaining clones are split, and the snapshot deleted, by the housekeeping job.
+func (c *CloneSplitter) Enqueue(snapConfig *storage.SnapshotConfig) {
+
+	c.mutex.Lock()
+	c.snapshots[snapConfig.ID()] = snapConfig
+	c.mutex.Unlock()
+
+	_ = SplitVolumeFromBusySnapshot(snapConfig, c.config, c.client, c.tracker)
+}
+
+// Pending returns the number of busy snapshots still waiting to be deleted.
+func (c *CloneSplitter) Pending() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.snapshots)
+}
+
+func (c *CloneSplitter) deleteOntapSnapshot(snapConfig *storage.SnapshotConfig) error {
+	snapResponse, err := c.client.SnapshotDelete(snapConfig.InternalName, snapConfig.VolumeInternalName)
+	return wrapOntapError(api.GetError(snapResponse, err), "error deleting snapshot")
+}
+
+func (c *CloneSplitter) listOntapClones(snapConfig *storage.SnapshotConfig) ([]string, error) {
+	return c.client.VolumeListAllBackedBySnapshot(snapConfig.VolumeInternalName, snapConfig.InternalName)
+}
+
+// forget drops a snapshot from the queue and notifies the handler of the outcome.
+func (c *CloneSplitter) forget(snapConfig *storage.SnapshotConfig, err error) {
+
+	c.mutex.Lock()
+	delete(c.snapshots, snapConfig.ID())
+	delete(c.failures, snapConfig.ID())
+	handler := c.handler
+	c.mutex.Unlock()
+
+	if handler != nil {
+		handler(snapConfig, err)
+	}
+}
+
+// run is the body of the clone split housekeeping job.
+func (c *CloneSplitter) run() {
+
+	c.mutex.Lock()
+	snapshots := make([]*storage.SnapshotConfig, 0, len(c.snapshots))
+	for _, snapConfig := range c.snapshots {
+		snapshots = append(snapshots, snapConfig)
+	}
+	c.mutex.Unlock()
+
+	for _, snapConfig := range snapshots {
+
+		logFields := log.Fields{
+			"snapshotName":     snapConfig.InternalName,
+			"parentVolumeName": snapConfig.VolumeInternalName,
+		}
+
+		childVolumes, err := c.listClones(snapConfig)
+		if err != nil {
+			log.WithFields(logFields).WithField("error", err).Warning("Could not list volumes backed by snapshot.")
+			continue
+		}
+
+		if len(childVolumes) > 0 {
+			_ = SplitVolumeFromBusySnapshot(snapConfig, c.config, c.client, c.tracker)
+			continue
+		}
+
+		log.WithFields(logFields).Debug("Snapshot no longer backs any clones, deleting it.")
+
+		err = c.deleteSnapshot(snapConfig)
+		if err == nil || drivers.IsResourceNotFoundError(err) {
+			log.WithFields(logFields).Info("Deleted snapshot after splitting its clones.")
+			c.forget(snapConfig, nil)
+			continue
+		}
+
+		// A snapshot may be busy again if it was cloned meanwhile, so keep trying
+		if drivers.IsRetriableError(err) {
+			log.WithFields(logFields).WithField("error", err).Debug("Snapshot still busy.")
+			continue
+		}
+
+		c.mutex.Lock()
+		c.failures[snapConfig.ID()]++
+		failures := c.failures[snapConfig.ID()]
+		c.mutex.Unlock()
+
+		if failures < maxDeferredSnapshotDeleteFailures {
+			log.WithFields(logFields).WithField("error", err).Warning("Could not delete snapshot, will retry.")
+			continue
+		}
+
+		log.WithFields(logFields).WithField("error", err).Error("Could not delete snapshot, giving up.")
+		c.forget(snapConfig, err)
+	}
+}
+
+// HousekeepingJob returns the job that periodically splits clones from busy snapshots.  The
+// interval is read from the config file, falling back to the default if missing or invalid.
+func (c *CloneSplitter) HousekeepingJob() *HousekeepingJob {
+
+	cloneSplitRetryPeriodSecs := defaultCloneSplitRetryPeriodSecs
+	if c.config.CloneSplitRetryPeriod != "" {
+		i, err := strconv.ParseUint(c.config.CloneSplitRetryPeriod, 10, 64)
+		if err != nil {
+			log.WithField("interval", c.config.CloneSplitRetryPeriod).Warnf(
+				"Invalid clone split retry interval. %v", err)
+		} else {
+			cloneSplitRetryPeriodSecs = i
+		}
+	}
+	log.WithField("IntervalSeconds", cloneSplitRetryPeriodSecs).Debug("Configured clone split retry period.")
+
+	interval := time.Duration(cloneSplitRetryPeriodSecs) * time.Second
+
+	return &HousekeepingJob{
+		Name:         cloneSplitJob,
+		Interval:     interval,
+		InitialDelay: interval,
+		Jitter:       interval / housekeepingJitterDivisor,
+		Run:          c.run,
+	}
+}
+
+// GetVolume checks for the existence of a volume.  It returns nil if the volume
+// exists and an error if it does not (or the API call fails).
+func GetVolume(name string, client *api.Client, config *drivers.OntapStorageDriverConfig) error {
+
+	if config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "GetVolume", "Type": "ontap_common"}
+		log.WithFields(fields).Debug(">>>> GetVolume")
+		defer log.WithFields(fields).Debug("<<<< GetVolume")
+	}
 
+	volExists, err := client.VolumeExists(name)
 	if err != nil {
-		return fmt.Errorf("error deleting snapshot: %v", err)
+		return fmt.Errorf("error checking for existing volume: %v", err)
 	}
-	if zerr := api.NewZapiError(snapResponse); !zerr.IsPassed() {
-		if zerr.Code() == azgo.ESNAPSHOTBUSY {
-			// Start a split here before returning the error so a subsequent delete attempt may succeed.
-			_ = SplitVolumeFromBusySnapshot(snapConfig, config, client)
-		}
-		return fmt.Errorf("error deleting snapshot: %v", zerr)
+	if !volExists {
+		log.WithField("flexvol", name).Debug("Flexvol not found.")
+		return fmt.Errorf("volume %s does not exist", name)
 	}
 
-	log.WithField("snapshotName", internalSnapName).Debug("Deleted snapshot.")
 	return nil
 }
 
-// SplitVolumeFromBusySnapshot gets the list of volumes backed by a busy snapshot and starts
-// a split operation on the first one (sorted by volume name).
-func SplitVolumeFromBusySnapshot(
-	snapConfig *storage.SnapshotConfig, config *drivers.OntapStorageDriverConfig, client *api.Client,
+// ModifyVolume changes the UNIX permissions, security style, export policy, and snapshot policy of
+// an existing Flexvol to those set in an update request.  Attributes left empty are not changed.
+func ModifyVolume(
+	ctx context.Context, name string, updateRequest *storage.UpdateVolumeRequest,
+	config *drivers.OntapStorageDriverConfig, client *api.Client,
 ) error {
 
-	internalSnapName := snapConfig.InternalName
-	internalVolName := snapConfig.VolumeInternalName
-
 	if config.DebugTraceFlags["method"] {
-		fields := log.Fields{
-			"Method":       "SplitVolumeFromBusySnapshot",
-			"Type":         "ontap_common",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolName,
-		}
-		log.WithFields(fields).Debug(">>>> SplitVolumeFromBusySnapshot")
-		defer log.WithFields(fields).Debug("<<<< SplitVolumeFromBusySnapshot")
+		fields := log.Fields{"Method": "ModifyVolume", "Type": "ontap_common", "name": name}
+		logc(ctx).WithFields(fields).Debug(">>>> ModifyVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ModifyVolume")
 	}
 
-	childVolumes, err := client.VolumeListAllBackedBySnapshot(internalVolName, internalSnapName)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"snapshotName":     internalSnapName,
-			"parentVolumeName": internalVolName,
-			"error":            err,
-		}).Error("Could not list volumes backed by snapshot.")
-		return err
-	} else if len(childVolumes) == 0 {
+	if updateRequest.UnixPermissions == "" && updateRequest.SecurityStyle == "" &&
+		updateRequest.ExportPolicy == "" && updateRequest.SnapshotPolicy == "" {
 		return nil
 	}
 
-	// We're going to start a single split operation, but there could be multiple children, so we
-	// sort the volumes by name to not have more than one split operation running at a time.
-	sort.Strings(childVolumes)
+	if updateRequest.ExportPolicy != "" && config.AutoExportPolicy {
+		return utils.UnsupportedError("export policies are managed automatically by this backend")
+	}
 
-	splitResponse, err := client.VolumeCloneSplitStart(childVolumes[0])
-	if err = api.GetError(splitResponse, err); err != nil {
-		log.WithFields(log.Fields{
-			"snapshotName":     internalSnapName,
-			"parentVolumeName": internalVolName,
-			"cloneVolumeName":  childVolumes[0],
-			"error":            err,
-		}).Error("Could not begin splitting clone from snapshot.")
-		return fmt.Errorf("error splitting clone: %v", err)
+	if updateRequest.UnixPermissions != "" {
+		response, err := client.VolumeModifyUnixPermissions(name, updateRequest.UnixPermissions)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, "error modifying UNIX permissions")
+		}
 	}
 
-	log.WithFields(log.Fields{
-		"snapshotName":     internalSnapName,
-		"parentVolumeName": internalVolName,
-		"cloneVolumeName":  childVolumes[0],
-	}).Info("Began splitting clone from snapshot.")
+	if updateRequest.SecurityStyle != "" {
+		response, err := client.VolumeModifySecurityStyle(name, updateRequest.SecurityStyle)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, "error modifying security style")
+		}
+	}
+
+	if updateRequest.ExportPolicy != "" {
+		response, err := client.VolumeModifyExportPolicy(name, updateRequest.ExportPolicy)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, "error modifying export policy")
+		}
+	}
+
+	if updateRequest.SnapshotPolicy != "" {
+		response, err := client.VolumeModifySnapshotPolicy(name, updateRequest.SnapshotPolicy)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, "error modifying snapshot policy")
+		}
+	}
+
+	logc(ctx).WithFields(log.Fields{
+		"volume":          name,
+		"unixPermissions": updateRequest.UnixPermissions,
+		"securityStyle":   updateRequest.SecurityStyle,
+		"exportPolicy":    updateRequest.ExportPolicy,
+		"snapshotPolicy":  updateRequest.SnapshotPolicy,
+	}).Info("Modified Flexvol.")
 
 	return nil
 }
 
-// GetVolume checks for the existence of a volume.  It returns nil if the volume
-// exists and an error if it does not (or the API call fails).
-func GetVolume(name string, client *api.Client, config *drivers.OntapStorageDriverConfig) error {
+// MoveVolume begins moving a Flexvol to another of the backend's aggregates, such as to rebalance
+// aggregates or to empty one that is being decommissioned.  The move runs in the background and is
+// followed by the tracker.
+func MoveVolume(
+	ctx context.Context, name, destinationAggregate string, physicalPools map[string]*storage.Pool,
+	config *drivers.OntapStorageDriverConfig, client *api.Client, tracker *VolumeMoveTracker,
+) error {
 
 	if config.DebugTraceFlags["method"] {
-		fields := log.Fields{"Method": "GetVolume", "Type": "ontap_common"}
-		log.WithFields(fields).Debug(">>>> GetVolume")
-		defer log.WithFields(fields).Debug("<<<< GetVolume")
+		fields := log.Fields{"Method": "MoveVolume", "Type": "ontap_common", "name": name}
+		logc(ctx).WithFields(fields).Debug(">>>> MoveVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< MoveVolume")
 	}
 
-	volExists, err := client.VolumeExists(name)
+	if _, ok := physicalPools[destinationAggregate]; !ok {
+		return fmt.Errorf("aggregate %s is not a pool of this backend", destinationAggregate)
+	}
+
+	volInfo, err := client.VolumeGet(name)
 	if err != nil {
-		return fmt.Errorf("error checking for existing volume: %v", err)
+		return wrapOntapError(err, "error reading volume")
 	}
-	if !volExists {
-		log.WithField("flexvol", name).Debug("Flexvol not found.")
-		return fmt.Errorf("volume %s does not exist", name)
+	if volInfo.VolumeIdAttributesPtr == nil || volInfo.VolumeSpaceAttributesPtr == nil {
+		return fmt.Errorf("aggregate info not available from Flexvol %s", name)
 	}
 
-	return nil
+	sourceAggregate := volInfo.VolumeIdAttributesPtr.ContainingAggregateName()
+	if sourceAggregate == destinationAggregate {
+		return fmt.Errorf("volume %s is already on aggregate %s", name, destinationAggregate)
+	}
+
+	// Make sure the volume fits within any usage limit set for the destination
+	spaceReserve := volInfo.VolumeSpaceAttributesPtr.SpaceGuarantee()
+	sizeBytes := uint64(volInfo.VolumeSpaceAttributesPtr.Size())
+	if err = checkAggregateLimits(destinationAggregate, spaceReserve, sizeBytes, *config, client); err != nil {
+		return err
+	}
+
+	return tracker.Start(ctx, name, sourceAggregate, destinationAggregate)
 }
 
 type ontapPerformanceClass string
@@ -2079,12 +3716,37 @@ func discoverBackendAggrNamesCommon(d StorageDriver) ([]string, error) {
 		"pools": vserverAggrs,
 	}).Debug("Read storage pools assigned to SVM.")
 
+	return filterBackendAggrNames(config, driverName, vserverAggrs)
+}
+
+// filterBackendAggrNames returns the aggregates assigned to the SVM that the backend config allows
+// provisioning on, being the configured aggregate if set, less any excluded aggregates and those not
+// matching the aggregate selector.
+func filterBackendAggrNames(
+	config *drivers.OntapStorageDriverConfig, driverName string, vserverAggrs []string,
+) ([]string, error) {
+
+	var selector *regexp.Regexp
+	if config.AggregateSelector != "" {
+		var err error
+		if selector, err = regexp.Compile(config.AggregateSelector); err != nil {
+			return nil, fmt.Errorf("invalid aggregateSelector %s: %v", config.AggregateSelector, err)
+		}
+	}
+
+	excluded := make(map[string]bool)
+	for _, aggrName := range config.ExcludeAggregates {
+		excluded[aggrName] = true
+	}
+
 	var aggrNames []string
+	foundConfigAggr := false
 	for _, aggrName := range vserverAggrs {
 		if config.Aggregate != "" {
 			if aggrName != config.Aggregate {
 				continue
 			}
+			foundConfigAggr = true
 
 			log.WithFields(log.Fields{
 				"driverName": driverName,
@@ -2092,23 +3754,35 @@ func discoverBackendAggrNamesCommon(d StorageDriver) ([]string, error) {
 			}).Debug("Provisioning will be restricted to the aggregate set in the backend config.")
 		}
 
+		if excluded[aggrName] || (selector != nil && !selector.MatchString(aggrName)) {
+			log.WithFields(log.Fields{
+				"driverName": driverName,
+				"aggregate":  aggrName,
+			}).Debug("Aggregate excluded from provisioning by the backend config.")
+			continue
+		}
+
 		aggrNames = append(aggrNames, aggrName)
 	}
 
-	// Make sure the configured aggregate is available to the SVM
-	if config.Aggregate != "" && (len(aggrNames) == 0) {
-		err = fmt.Errorf("the assigned aggregates for SVM %s do not include the configured aggregate %s",
-			config.SVM, config.Aggregate)
-		return nil, err
+	if len(aggrNames) == 0 {
+		// Make sure the configured aggregate is available to the SVM
+		if config.Aggregate != "" && !foundConfigAggr {
+			return nil, fmt.Errorf("the assigned aggregates for SVM %s do not include the configured "+
+				"aggregate %s", config.SVM, config.Aggregate)
+		}
+		if len(excluded) > 0 || selector != nil {
+			return nil, fmt.Errorf("no aggregates assigned to SVM %s remain after applying "+
+				"excludeAggregates and aggregateSelector", config.SVM)
+		}
 	}
 
 	return aggrNames, nil
 }
 
-// getVserverAggrAttributes gets pool attributes using vserver-show-aggr-get-iter,
-// which will only succeed on Data ONTAP 9 and later.
-// If the aggregate attributes are read successfully, the pools passed to this function are updated accordingly.
-func getVserverAggrAttributes(d StorageDriver, poolsAttributeMap *map[string]map[string]sa.Offer) (err error) {
+// getVserverAggrMediaTypes returns the type of each aggregate visible to the SVM using
+// vserver-show-aggr-get-iter, which will only succeed on Data ONTAP 9 and later.
+func getVserverAggrMediaTypes(client *api.Client) (mediaTypes map[string]string, err error) {
 
 	// Handle panics from the API layer
 	defer func() {
@@ -2117,7 +3791,7 @@ func getVserverAggrAttributes(d StorageDriver, poolsAttributeMap *map[string]map
 		}
 	}()
 
-	result, err := d.GetAPI().VserverShowAggrGetIterRequest()
+	result, err := client.VserverShowAggrGetIterRequest()
 	if err != nil {
 		return
 	}
@@ -2127,43 +3801,190 @@ func getVserverAggrAttributes(d StorageDriver, poolsAttributeMap *map[string]map
 		return
 	}
 
+	mediaTypes = make(map[string]string)
 	if result.Result.AttributesListPtr != nil {
 		for _, aggr := range result.Result.AttributesListPtr.ShowAggregatesPtr {
-			aggrName := string(aggr.AggregateName())
-			aggrType := aggr.AggregateType()
+			mediaTypes[string(aggr.AggregateName())] = aggr.AggregateType()
+		}
+	}
 
-			// Find matching pool.  There are likely more aggregates in the cluster than those assigned to this backend's SVM.
-			_, ok := (*poolsAttributeMap)[aggrName]
-			if !ok {
-				continue
-			}
+	return
+}
 
-			// Get the storage attributes (i.e. MediaType) corresponding to the aggregate type
-			storageAttrs, ok := ontapPerformanceClasses[ontapPerformanceClass(aggrType)]
-			if !ok {
-				log.WithFields(log.Fields{
-					"aggregate": aggrName,
-					"mediaType": aggrType,
-				}).Debug("Aggregate has unknown performance characteristics.")
+// getAggrMediaTypes returns the type of each aggregate visible to the backend.  If the aggregates cannot
+// be read via ZAPI, typically because the user lacks privileges, the ONTAP REST API is tried instead.
+// Media types declared in the aggregateMedia config option take precedence over discovered ones, and an
+// error is only returned if discovery failed and no media types were declared.
+func getAggrMediaTypes(d StorageDriver) (map[string]string, error) {
 
-				continue
-			}
+	config := d.GetConfig()
+
+	mediaTypes, err := getVserverAggrMediaTypes(d.GetAPI())
+	if err != nil {
+		restMediaTypes, restErr := d.GetAPI().AggregateMediaTypesREST()
+		if restErr != nil {
+			log.WithField("error", restErr).Debug("Could not read aggregate media types via REST.")
+			mediaTypes = make(map[string]string)
+		} else {
+			log.WithField("zapiError", err).Debug("Read aggregate media types via REST.")
+			mediaTypes, err = restMediaTypes, nil
+		}
+	}
+
+	for aggrName, mediaType := range config.AggregateMedia {
+		mediaTypes[aggrName] = mediaType
+	}
+	if err != nil && len(config.AggregateMedia) > 0 {
+		log.WithField("error", err).Debug("Could not discover aggregate media types, using aggregateMedia.")
+		err = nil
+	}
+
+	return mediaTypes, err
+}
+
+// getVserverAggrAttributes gets pool attributes from the media type of each aggregate.
+// If the aggregate attributes are read successfully, the pools passed to this function are updated accordingly.
+func getVserverAggrAttributes(d StorageDriver, poolsAttributeMap *map[string]map[string]sa.Offer) (err error) {
+
+	mediaTypes, err := getAggrMediaTypes(d)
+
+	for aggrName, aggrType := range mediaTypes {
 
+		// Find matching pool.  There are likely more aggregates in the cluster than those assigned to this backend's SVM.
+		_, ok := (*poolsAttributeMap)[aggrName]
+		if !ok {
+			continue
+		}
+
+		// Get the storage attributes (i.e. MediaType) corresponding to the aggregate type
+		storageAttrs, ok := ontapPerformanceClasses[ontapPerformanceClass(aggrType)]
+		if !ok {
 			log.WithFields(log.Fields{
 				"aggregate": aggrName,
 				"mediaType": aggrType,
-			}).Debug("Read aggregate attributes.")
+			}).Debug("Aggregate has unknown performance characteristics.")
 
-			// Update the pool with the aggregate storage attributes
-			for attrName, attr := range storageAttrs {
-				(*poolsAttributeMap)[aggrName][attrName] = attr
-			}
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"aggregate": aggrName,
+			"mediaType": aggrType,
+		}).Debug("Read aggregate attributes.")
+
+		// Update the pool with the aggregate storage attributes
+		for attrName, attr := range storageAttrs {
+			(*poolsAttributeMap)[aggrName][attrName] = attr
 		}
 	}
 
 	return
 }
 
+// ValidateAggregateMedia ensures each media type declared in the aggregateMedia option is known.
+func ValidateAggregateMedia(config *drivers.OntapStorageDriverConfig) error {
+	for aggrName, mediaType := range config.AggregateMedia {
+		if _, ok := ontapPerformanceClasses[ontapPerformanceClass(mediaType)]; !ok {
+			return fmt.Errorf("invalid media type %s for aggregate %s in aggregateMedia; must be one of %s, %s, or %s",
+				mediaType, aggrName, ontapHDD, ontapHybrid, ontapSSD)
+		}
+	}
+	return nil
+}
+
+// validateKerberosConfig checks the backend's Kerberos security flavor, and that NFS Kerberos is enabled
+// on the data LIF its volumes are mounted from.
+func validateKerberosConfig(client *api.Client, config *drivers.OntapStorageDriverConfig) error {
+
+	switch config.Kerberos {
+	case "krb5", "krb5i", "krb5p":
+		break
+	default:
+		return fmt.Errorf("invalid value for kerberos: %s; must be krb5, krb5i, or krb5p", config.Kerberos)
+	}
+
+	enabledLIFs, err := client.KerberosEnabledDataLIFsREST()
+	if err != nil {
+		return fmt.Errorf("could not read the Kerberos configuration of SVM %s: %v", config.SVM, err)
+	}
+	return checkKerberosDataLIF(config.DataLIF, config.SVM, enabledLIFs)
+}
+
+// checkKerberosDataLIF returns an error if NFS Kerberos isn't enabled on a data LIF.
+func checkKerberosDataLIF(dataLIF, svm string, enabledLIFs []string) error {
+
+	ip := utils.ParseHostportIP(dataLIF)
+	for _, enabledLIF := range enabledLIFs {
+		if utils.ParseHostportIP(enabledLIF) == ip {
+			return nil
+		}
+	}
+	return fmt.Errorf("NFS Kerberos is not enabled on data LIF %s of SVM %s", ip, svm)
+}
+
+// nfsMountOptionsWithSecurity adds the security flavor of a backend using Kerberos to a volume's mount
+// options, unless they already specify one.
+func nfsMountOptionsWithSecurity(mountOptions, kerberos string) string {
+
+	if kerberos == "" || utils.GetNFSMountOption(mountOptions, "sec") != "" {
+		return mountOptions
+	}
+	if strings.TrimPrefix(mountOptions, "-o ") == "" {
+		return "sec=" + kerberos
+	}
+	return mountOptions + ",sec=" + kerberos
+}
+
+// resolveNFSMountOptions sets the mount options of a new volume from its storage pool, unless options
+// were requested for the volume itself, and validates them.  A volume left with no options of its own
+// is mounted with the backend's options.
+func resolveNFSMountOptions(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+
+	if volConfig.MountOptions == "" && storagePool != nil {
+		volConfig.MountOptions = storagePool.InternalAttributes[NfsMountOptions]
+	}
+	if err := utils.ValidateNFSMountOptions(volConfig.MountOptions); err != nil {
+		return fmt.Errorf("invalid mount options for volume %s: %v", volConfig.Name, err)
+	}
+	return nil
+}
+
+// getNFSTrunkingDataLIFs returns the data LIFs, other than the backend's, to which a node should trunk
+// the NFSv4.1 session of a volume mounted with a set of mount options.  Trunking only adds throughput,
+// so if the LIFs can't be discovered the volume is published without them.
+func getNFSTrunkingDataLIFs(client *api.Client, dataLIF, mountOptions string) []string {
+
+	limit := utils.GetNFSSessionTrunkingLimit(mountOptions)
+	if limit == 0 {
+		return nil
+	}
+
+	dataLIFs, err := client.NetInterfaceGetDataLIFs("nfs")
+	if err != nil {
+		log.WithField("error", err).Warning("Could not discover NAS data LIFs for session trunking.")
+		return nil
+	}
+	return selectNFSTrunkingDataLIFs(dataLIFs, dataLIF, limit)
+}
+
+// selectNFSTrunkingDataLIFs chooses the data LIFs to trunk to, so that including the backend's own
+// data LIF a session has no more than limit transports.
+func selectNFSTrunkingDataLIFs(dataLIFs []string, dataLIF string, limit int) []string {
+
+	primary := utils.ParseHostportIP(dataLIF)
+	trunkIPs := make([]string, 0)
+	for _, ip := range dataLIFs {
+		if len(trunkIPs) >= limit-1 {
+			break
+		}
+		if ip == primary {
+			continue
+		}
+		trunkIPs = append(trunkIPs, utils.BracketIPv6(ip))
+	}
+	return trunkIPs
+}
+
 // poolName constructs the name of the pool reported by this driver instance
 func poolName(name, backendName string) string {
 
@@ -2172,6 +3993,42 @@ func poolName(name, backendName string) string {
 		strings.Replace(name, "-", "", -1))
 }
 
+func init() {
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name: TieringMinimumCoolingDays,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string {
+			return defaults.TieringMinimumCoolingDays
+		},
+	}, drivers.OntapNASStorageDriverName, drivers.OntapNASQtreeStorageDriverName,
+		drivers.OntapNASFlexGroupStorageDriverName, drivers.OntapSANStorageDriverName,
+		drivers.OntapSANEconomyStorageDriverName)
+
+	// Registered for the other drivers too, so that ValidateStoragePools may reject it
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name:  FlexcacheOrigin,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string { return defaults.FlexcacheOrigin },
+	}, drivers.OntapNASStorageDriverName, drivers.OntapNASQtreeStorageDriverName, drivers.OntapSANStorageDriverName,
+		drivers.OntapSANEconomyStorageDriverName)
+
+	// Registered for ontap-nas-flexgroup too, so that ValidateStoragePools may reject it
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name: MaxOverprovisionRatio,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string {
+			return defaults.MaxOverprovisionRatio
+		},
+	}, drivers.OntapNASStorageDriverName, drivers.OntapNASQtreeStorageDriverName,
+		drivers.OntapNASFlexGroupStorageDriverName, drivers.OntapSANStorageDriverName,
+		drivers.OntapSANEconomyStorageDriverName)
+
+	// Registered for the SAN drivers too, so that ValidateStoragePools may reject it
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name:  NfsMountOptions,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string { return defaults.NfsMountOptions },
+	}, drivers.OntapNASStorageDriverName, drivers.OntapNASQtreeStorageDriverName,
+		drivers.OntapNASFlexGroupStorageDriverName, drivers.OntapSANStorageDriverName,
+		drivers.OntapSANEconomyStorageDriverName)
+}
+
 func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.Offer,
 	backendName string) (map[string]*storage.Pool, map[string]*storage.Pool, error) {
 
@@ -2201,7 +4058,8 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 		log.WithFields(log.Fields{
 			"username": config.Username,
 		}).Warn("User has insufficient privileges to obtain aggregate info. " +
-			"Storage classes with physical attributes such as 'media' will not match pools on this backend.")
+			"Storage classes with physical attributes such as 'media' will not match pools on this backend " +
+			"unless the aggregateMedia option is set.")
 	} else if aggrErr != nil {
 		log.Errorf("Could not obtain aggregate info; storage classes with physical attributes such as 'media' will"+
 			" not match pools on this backend: %v.", aggrErr)
@@ -2252,11 +4110,9 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 		pool.InternalAttributes[ExportPolicy] = config.ExportPolicy
 		pool.InternalAttributes[SecurityStyle] = config.SecurityStyle
 		pool.InternalAttributes[TieringPolicy] = config.TieringPolicy
+		pool.InternalAttributes[LimitVolumeSize] = config.LimitVolumeSize
 
-		if d.Name() == drivers.OntapSANStorageDriverName || d.Name() == drivers.OntapSANEconomyStorageDriverName {
-			pool.InternalAttributes[SpaceAllocation] = config.SpaceAllocation
-			pool.InternalAttributes[FileSystemType] = config.FileSystemType
-		}
+		applyPoolAttributeProviders(pool, d.Name(), config, nil)
 
 		physicalPools[pool.Name] = pool
 	}
@@ -2279,11 +4135,6 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 			size = vpool.Size
 		}
 
-		spaceAllocation := config.SpaceAllocation
-		if vpool.SpaceAllocation != "" {
-			spaceAllocation = vpool.SpaceAllocation
-		}
-
 		spaceReserve := config.SpaceReserve
 		if vpool.SpaceReserve != "" {
 			spaceReserve = vpool.SpaceReserve
@@ -2324,11 +4175,6 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 			securityStyle = vpool.SecurityStyle
 		}
 
-		fileSystemType := config.FileSystemType
-		if vpool.FileSystemType != "" {
-			fileSystemType = vpool.FileSystemType
-		}
-
 		encryption := config.Encryption
 		if vpool.Encryption != "" {
 			encryption = vpool.Encryption
@@ -2339,6 +4185,11 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 			tieringPolicy = vpool.TieringPolicy
 		}
 
+		limitVolumeSize := config.LimitVolumeSize
+		if vpool.LimitVolumeSize != "" {
+			limitVolumeSize = vpool.LimitVolumeSize
+		}
+
 		pool := storage.NewStoragePool(nil, poolName(fmt.Sprintf("pool_%d", index), backendName))
 
 		// Update pool with attributes set by default for this backend
@@ -2383,11 +4234,9 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 		pool.InternalAttributes[ExportPolicy] = exportPolicy
 		pool.InternalAttributes[SecurityStyle] = securityStyle
 		pool.InternalAttributes[TieringPolicy] = tieringPolicy
+		pool.InternalAttributes[LimitVolumeSize] = limitVolumeSize
 
-		if d.Name() == drivers.OntapSANStorageDriverName || d.Name() == drivers.OntapSANEconomyStorageDriverName {
-			pool.InternalAttributes[SpaceAllocation] = spaceAllocation
-			pool.InternalAttributes[FileSystemType] = fileSystemType
-		}
+		applyPoolAttributeProviders(pool, d.Name(), config, &vpool.OntapStorageDriverConfigDefaults)
 
 		virtualPools[pool.Name] = pool
 	}
@@ -2395,6 +4244,60 @@ func InitializeStoragePoolsCommon(d StorageDriver, poolAttributes map[string]sa.
 	return physicalPools, virtualPools, nil
 }
 
+// refreshStoragePoolsCommon rediscovers a driver's storage pools as InitializeStoragePoolsCommon first
+// found them, so that aggregates assigned to or removed from the SVM since are reflected.  The pools are
+// validated before being returned, so that the driver may keep its old pools if the new ones are unusable.
+func refreshStoragePoolsCommon(
+	d StorageDriver, poolAttributes map[string]sa.Offer, backendName string,
+) (map[string]*storage.Pool, map[string]*storage.Pool, error) {
+
+	physicalPools, virtualPools, err := InitializeStoragePoolsCommon(d, poolAttributes, backendName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not configure storage pools: %v", err)
+	}
+	if err = ValidateStoragePools(physicalPools, virtualPools, d.Name()); err != nil {
+		return nil, nil, fmt.Errorf("storage pool validation failed: %v", err)
+	}
+
+	log.WithFields(log.Fields{
+		"backend":       backendName,
+		"physicalPools": len(physicalPools),
+		"virtualPools":  len(virtualPools),
+	}).Debug("Rediscovered storage pools.")
+
+	return physicalPools, virtualPools, nil
+}
+
+// parseTieringMinimumCoolingDays returns the number of days in a tieringMinimumCoolingDays value, ensuring
+// it is within the range ONTAP accepts.
+func parseTieringMinimumCoolingDays(value string) (int, error) {
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for tieringMinimumCoolingDays: %v", err)
+	}
+	if days < minTieringMinimumCoolingDays || days > maxTieringMinimumCoolingDays {
+		return 0, fmt.Errorf("invalid value for tieringMinimumCoolingDays: %d is not between %d and %d",
+			days, minTieringMinimumCoolingDays, maxTieringMinimumCoolingDays)
+	}
+	return days, nil
+}
+
+// parseFlexcacheOrigin splits a flexcacheOrigin value of the form [svm:]volume into the origin SVM and
+// volume names, using defaultSVM if the value doesn't name an SVM.
+func parseFlexcacheOrigin(value, defaultSVM string) (string, string, error) {
+	svm, volume := defaultSVM, value
+	if i := strings.Index(value, ":"); i >= 0 {
+		svm, volume = value[:i], value[i+1:]
+		if svm == "" {
+			return "", "", fmt.Errorf("invalid value for flexcacheOrigin %s: SVM name is empty", value)
+		}
+	}
+	if volume == "" || strings.Contains(volume, ":") {
+		return "", "", fmt.Errorf("invalid value for flexcacheOrigin %s: expected [svm:]volume", value)
+	}
+	return svm, volume, nil
+}
+
 // ValidateStoragePools makes sure that values are set for the fields, if value(s) were not specified
 // for a field then a default should have been set in for that field in the intialize storage pools
 func ValidateStoragePools(physicalPools, virtualPools map[string]*storage.Pool, driverType string) error {
@@ -2471,6 +4374,59 @@ func ValidateStoragePools(physicalPools, virtualPools map[string]*storage.Pool,
 				poolName)
 		}
 
+		// Validate TieringMinimumCoolingDays, which only applies to policies that tier cold data
+		if pool.InternalAttributes[TieringMinimumCoolingDays] != "" {
+			if _, err := parseTieringMinimumCoolingDays(pool.InternalAttributes[TieringMinimumCoolingDays]); err != nil {
+				return fmt.Errorf("%v in pool %s", err, poolName)
+			}
+			tieringPolicy := pool.InternalAttributes[TieringPolicy]
+			if tieringPolicy == "" && driverType == drivers.OntapNASFlexGroupStorageDriverName {
+				// FlexGroups are created with tiering policy none unless told otherwise
+				tieringPolicy = "none"
+			}
+			switch tieringPolicy {
+			case "snapshot-only", "auto", "":
+				break
+			default:
+				return fmt.Errorf("tieringMinimumCoolingDays may not be set with tieringPolicy %s in pool %s",
+					tieringPolicy, poolName)
+			}
+		}
+
+		// Validate FlexcacheOrigin, which only ontap-nas supports
+		if pool.InternalAttributes[FlexcacheOrigin] != "" {
+			if driverType != drivers.OntapNASStorageDriverName {
+				return fmt.Errorf("flexcacheOrigin is not supported by %s, in pool %s", driverType, poolName)
+			}
+			if _, _, err := parseFlexcacheOrigin(pool.InternalAttributes[FlexcacheOrigin], ""); err != nil {
+				return fmt.Errorf("%v in pool %s", err, poolName)
+			}
+		}
+
+		// Validate MaxOverprovisionRatio, which FlexGroups can't enforce as they span aggregates
+		if pool.InternalAttributes[MaxOverprovisionRatio] != "" {
+			if driverType == drivers.OntapNASFlexGroupStorageDriverName {
+				return fmt.Errorf("maxOverprovisionRatio is not supported by %s, in pool %s", driverType, poolName)
+			}
+			if _, err := parseMaxOverprovisionRatio(pool.InternalAttributes[MaxOverprovisionRatio]); err != nil {
+				return fmt.Errorf("%v in pool %s", err, poolName)
+			}
+		}
+
+		// Validate NfsMountOptions, which only the NAS drivers use
+		if pool.InternalAttributes[NfsMountOptions] != "" {
+			switch driverType {
+			case drivers.OntapNASStorageDriverName, drivers.OntapNASQtreeStorageDriverName,
+				drivers.OntapNASFlexGroupStorageDriverName:
+				break
+			default:
+				return fmt.Errorf("nfsMountOptions is not supported by %s, in pool %s", driverType, poolName)
+			}
+			if err := utils.ValidateNFSMountOptions(pool.InternalAttributes[NfsMountOptions]); err != nil {
+				return fmt.Errorf("%v in pool %s", err, poolName)
+			}
+		}
+
 		// Validate media type
 		if pool.InternalAttributes[Media] != "" {
 			for _, mediaType := range strings.Split(pool.InternalAttributes[Media], ",") {
@@ -2648,6 +4604,13 @@ func getPoolsForCreate(
 	physicalPools map[string]*storage.Pool, virtualPools map[string]*storage.Pool,
 ) ([]*storage.Pool, error) {
 
+	// The requested pool must be accessible from the volume's requisite topology.  The pools of a
+	// virtual pool aren't checked, as the volume is accessible from wherever the virtual pool is.
+	if !storagePool.MatchesTopologies(volConfig.RequisiteTopologies) {
+		err := fmt.Errorf("pool %s is not accessible from the requested topology", storagePool.Name)
+		return nil, drivers.NewBackendIneligibleError(volConfig.InternalName, []error{err}, []string{})
+	}
+
 	// If a physical pool was requested, just use it
 	if _, ok := physicalPools[storagePool.Name]; ok {
 		return []*storage.Pool{storagePool}, nil
@@ -2703,8 +4666,35 @@ func getInternalVolumeNameCommon(commonConfig *drivers.CommonStorageDriverConfig
 	}
 }
 
-func createPrepareCommon(d storage.Driver, volConfig *storage.VolumeConfig) {
+// createPrepareCommon sets the internal name of a new volume.  If the backend specifies a
+// volumeNameTemplate, that is used in place of the storage prefix, with collisions resolved
+// using the supplied existence check.
+func createPrepareCommon(
+	d storage.Driver, volConfig *storage.VolumeConfig, maxNameLength int, volumeExists func(string) (bool, error),
+) {
+
 	volConfig.InternalName = d.GetInternalVolumeName(volConfig.Name)
+
+	ontapDriver, ok := d.(StorageDriver)
+	if !ok || tridentconfig.UsingPassthroughStore {
+		return
+	}
+
+	config := ontapDriver.GetConfig()
+	if config.VolumeNameTemplate == "" {
+		return
+	}
+
+	name, err := getTemplatedVolumeName(config, volConfig, maxNameLength, volumeExists)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"volume":       volConfig.Name,
+			"internalName": volConfig.InternalName,
+		}).Warningf("Could not apply volume name template, using default name; %v", err)
+		return
+	}
+
+	volConfig.InternalName = name
 }
 
 func getExternalConfig(config drivers.OntapStorageDriverConfig) interface{} {
@@ -2719,8 +4709,23 @@ func getExternalConfig(config drivers.OntapStorageDriverConfig) interface{} {
 	return cloneConfig
 }
 
+// checkVolumeSizeLimits enforces the limitVolumeSize of the storage pool, if any, or else that of the
+// backend.  The pool is nil during resize, when only the backend limit applies.  A
+// VolumeSizeLimitError is returned if the limit is exceeded.
+func checkVolumeSizeLimits(
+	sizeBytes uint64, config *drivers.OntapStorageDriverConfig, storagePool *storage.Pool,
+) (bool, uint64, error) {
+
+	limitVolumeSize := config.LimitVolumeSize
+	if storagePool != nil && storagePool.InternalAttributes[LimitVolumeSize] != "" {
+		limitVolumeSize = storagePool.InternalAttributes[LimitVolumeSize]
+	}
+
+	return drivers.CheckVolumeSizeLimit(sizeBytes, limitVolumeSize)
+}
+
 // resizeValidation performs needed validation checks prior to the resize operation.
-func resizeValidation(name string, sizeBytes uint64,
+func resizeValidation(name string, sizeBytes uint64, allowShrink bool,
 	volumeExists func(string) (bool, error),
 	volumeSize func(string) (int, error)) (uint64, error) {
 
@@ -2742,13 +4747,31 @@ func resizeValidation(name string, sizeBytes uint64,
 	}
 	volSizeBytes := uint64(volSize)
 
-	if sizeBytes < volSizeBytes {
-		return 0, fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, volSize)
+	if sizeBytes < volSizeBytes && !allowShrink {
+		return 0, drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, volSize), nil)
 	}
 
 	return volSizeBytes, nil
 }
 
+// checkVolumeShrink ensures that the data held by a Flexvol would still fit if the volume were given
+// the requested usable size.
+func checkVolumeShrink(name string, sizeBytes uint64, volAttrs *azgo.VolumeAttributesType) error {
+
+	if volAttrs == nil || volAttrs.VolumeSpaceAttributesPtr == nil ||
+		volAttrs.VolumeSpaceAttributesPtr.SizeUsedPtr == nil {
+		return fmt.Errorf("could not determine the space used by volume %s", name)
+	}
+
+	usedBytes := uint64(volAttrs.VolumeSpaceAttributesPtr.SizeUsed())
+	if sizeBytes < usedBytes {
+		return drivers.NewUnsupportedCapacityRangeError(fmt.Sprintf(
+			"requested size %d is less than the %d bytes used by volume %s", sizeBytes, usedBytes, name), nil)
+	}
+	return nil
+}
+
 // Unmount a volume and then take it offline. This may need to be done before deleting certain types of volumes.
 func UnmountAndOfflineVolume(API *api.Client, name string) (bool, error) {
 	// This call is sync and idempotent
@@ -2758,7 +4781,7 @@ func UnmountAndOfflineVolume(API *api.Client, name string) (bool, error) {
 	}
 
 	if zerr := api.NewZapiError(umountResp); !zerr.IsPassed() {
-		if zerr.Code() == azgo.EOBJECTNOTFOUND {
+		if zerr.IsNotFound() {
 			log.WithField("volume", name).Warn("Volume does not exist.")
 			return false, nil
 		} else {
@@ -2775,7 +4798,7 @@ func UnmountAndOfflineVolume(API *api.Client, name string) (bool, error) {
 	if zerr := api.NewZapiError(offlineResp); !zerr.IsPassed() {
 		if zerr.Code() == azgo.EVOLUMEOFFLINE {
 			log.WithField("volume", name).Warn("Volume already offline.")
-		} else if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
+		} else if zerr.IsNotFound() {
 			log.WithField("volume", name).Debug("Volume already deleted, skipping destroy.")
 			return false, nil
 		} else {
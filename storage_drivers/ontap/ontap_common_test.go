@@ -3,10 +3,26 @@
 package ontap
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
 	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
 	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+	"github.com/netapp/trident/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -213,3 +229,1208 @@ func TestValidateStoragePrefix(t *testing.T) {
         }
 
 }
+
+func TestSnapshotReserveSizeCalculation(t *testing.T) {
+
+	tests := []struct {
+		usableSize      uint64
+		snapshotReserve int
+		flexvolSize     uint64
+	}{
+		{107374182400, 20, 134217728000},
+		{107374182400, 0, 107374182400},
+		{107374182400, -1, 107374182400},
+		{1000, 30, 1429},
+	}
+	for _, test := range tests {
+		flexvolSize := calculateFlexvolSizeBytes(test.usableSize, test.snapshotReserve)
+		assert.Equal(t, test.flexvolSize, flexvolSize)
+		assert.Equal(t, test.usableSize, calculateUsableSizeBytes(flexvolSize, test.snapshotReserve))
+	}
+
+	volAttrs := azgo.NewVolumeAttributesType().SetVolumeSpaceAttributes(
+		*azgo.NewVolumeSpaceAttributesType().SetSize(134217728000).SetPercentageSnapshotReserve(20))
+	assert.Equal(t, 20, getVolumeSnapshotReserve(volAttrs))
+	assert.Equal(t, uint64(107374182400), getUsableVolumeSize(volAttrs))
+}
+
+func TestCheckVolumeSizeLimitsWithPoolOverride(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.LimitVolumeSize = "2Gi"
+
+	pool := storage.NewStoragePool(nil, "pool1")
+	pool.InternalAttributes[LimitVolumeSize] = "5Gi"
+
+	// The backend limit applies without a pool
+	_, _, err := checkVolumeSizeLimits(4294967296, config, nil)
+	assert.True(t, drivers.IsVolumeSizeLimitError(err))
+
+	// The pool limit overrides the backend limit
+	shouldLimit, sizeLimit, err := checkVolumeSizeLimits(4294967296, config, pool)
+	assert.NoError(t, err)
+	assert.True(t, shouldLimit)
+	assert.Equal(t, uint64(5368709120), sizeLimit)
+
+	_, _, err = checkVolumeSizeLimits(6442450944, config, pool)
+	assert.True(t, drivers.IsVolumeSizeLimitError(err))
+
+	// An empty pool limit falls back to the backend limit
+	pool.InternalAttributes[LimitVolumeSize] = ""
+	_, _, err = checkVolumeSizeLimits(4294967296, config, pool)
+	assert.True(t, drivers.IsVolumeSizeLimitError(err))
+}
+
+// newTestResizeServer returns a ZAPI server holding a 1 GiB FlexVol, qtree and LUN, each named
+// trident_pvc_1, and a function reporting whether the server was asked to resize anything.
+func newTestResizeServer() (*httptest.Server, func() bool) {
+
+	resized := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		request := string(body)
+		result := `<results status="passed"><num-records>0</num-records></results>`
+		switch {
+		case strings.Contains(request, "<volume-get-iter>"):
+			result = `<results status="passed"><num-records>1</num-records><attributes-list>` +
+				`<volume-attributes><volume-id-attributes><name>trident_pvc_1</name>` +
+				`<containing-aggregate-name>aggr1</containing-aggregate-name></volume-id-attributes>` +
+				`<volume-space-attributes><size>1073741824</size>` +
+				`<percentage-snapshot-reserve>0</percentage-snapshot-reserve><space-guarantee>none</space-guarantee>` +
+				`</volume-space-attributes></volume-attributes></attributes-list></results>`
+		case strings.Contains(request, "<volume-size>"), strings.Contains(request, "<volume-size-async>"):
+			resized = resized || strings.Contains(request, "<new-size>")
+			result = `<results status="passed"><volume-size>1g</volume-size>` +
+				`<result-status>succeeded</result-status></results>`
+		case strings.Contains(request, "<qtree-list-iter>"):
+			result = `<results status="passed"><num-records>1</num-records><attributes-list>` +
+				`<qtree-info><volume>trident_qtree_pool_test_ABCDE</volume><qtree>trident_pvc_1</qtree></qtree-info>` +
+				`</attributes-list></results>`
+		case strings.Contains(request, "<quota-list-entries-iter>"):
+			result = `<results status="passed"><num-records>1</num-records><attributes-list>` +
+				`<quota-entry><quota-target>/vol/trident_qtree_pool_test_ABCDE/trident_pvc_1</quota-target>` +
+				`<disk-limit>1048576</disk-limit></quota-entry></attributes-list></results>`
+		case strings.Contains(request, "<lun-get-iter>"):
+			result = `<results status="passed"><num-records>1</num-records><attributes-list>` +
+				`<lun-info><path>/vol/trident_lun_pool_test_ABCDE/trident_pvc_1</path>` +
+				`<volume>trident_lun_pool_test_ABCDE</volume><size>1073741824</size></lun-info>` +
+				`</attributes-list></results>`
+		case strings.Contains(request, "<system-get-ontapi-version>"):
+			result = `<results status="passed"><major-version>1</major-version><minor-version>21</minor-version></results>`
+		case strings.Contains(request, "<lun-get-geometry>"):
+			result = `<results status="passed"><max-resize-size>1099511627776</max-resize-size></results>`
+		case strings.Contains(request, "<lun-resize>"):
+			resized = true
+			result = `<results status="passed"><actual-size>2147483648</actual-size></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+
+	return server, func() bool { return resized }
+}
+
+// testResizeInPool resizes trident_pvc_1 from 1 GiB to 2 GiB with a driver's ResizeInPool, on a
+// backend limiting volumes to 1.5 GiB, and checks that the limit of the volume's pool is enforced
+// in place of the backend's.
+func testResizeInPool(
+	t *testing.T, newDriver func(config *drivers.OntapStorageDriverConfig, client *api.Client) storage.PoolResizer,
+) {
+
+	newPool := func(limit string) *storage.Pool {
+		pool := storage.NewStoragePool(nil, "pool1")
+		pool.InternalAttributes[LimitVolumeSize] = limit
+		return pool
+	}
+
+	tests := map[string]struct {
+		pool    *storage.Pool
+		limited bool
+	}{
+		"Unknown pool":       {pool: nil, limited: true},
+		"Pool without limit": {pool: newPool(""), limited: true},
+		"Larger pool limit":  {pool: newPool("5Gi"), limited: false},
+		"Smaller pool limit": {pool: newPool("1536Mi"), limited: true},
+	}
+	for name, test := range tests {
+		server, resized := newTestResizeServer()
+
+		config := newTestOntapSANConfig()
+		config.LimitVolumeSize = "1536Mi"
+		client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+		volConfig := &storage.VolumeConfig{Name: "pvc-1", InternalName: "trident_pvc_1"}
+
+		err := newDriver(config, client).ResizeInPool(context.Background(), volConfig, test.pool, 2147483648)
+		if test.limited {
+			assert.True(t, drivers.IsVolumeSizeLimitError(err), "%s: %v", name, err)
+			assert.False(t, resized(), name)
+		} else {
+			assert.NoError(t, err, name)
+			assert.True(t, resized(), name)
+			assert.Equal(t, "2147483648", volConfig.Size, name)
+		}
+		server.Close()
+	}
+}
+
+func TestISCSIDataLIFsHousekeepingJob(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	dataLIFs := &ISCSIDataLIFs{config: config, ips: []string{"1.1.1.1", "2.2.2.2"}}
+
+	job := dataLIFs.HousekeepingJob()
+	assert.Equal(t, dataLIFRefreshJob, job.Name)
+	assert.Equal(t, time.Duration(defaultDataLIFRefreshPeriodSecs)*time.Second, job.Interval)
+
+	config.DataLIFRefreshPeriod = "60"
+	assert.Equal(t, 60*time.Second, dataLIFs.HousekeepingJob().Interval)
+
+	config.DataLIFRefreshPeriod = "invalid"
+	assert.Equal(t, time.Duration(defaultDataLIFRefreshPeriodSecs)*time.Second, dataLIFs.HousekeepingJob().Interval)
+
+	// Callers get a copy of the cached list
+	ips := dataLIFs.IPs()
+	ips[0] = "3.3.3.3"
+	assert.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, dataLIFs.IPs())
+}
+
+func TestSVMPrivilegeReport(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.Username = "trident"
+	config.SVM = "svm1"
+	config.StorageDriverName = drivers.OntapSANStorageDriverName
+
+	privileges := getRequiredSVMPrivileges(config)
+	apiNames := make([]string, 0)
+	for _, privilege := range privileges {
+		apiNames = append(apiNames, privilege.api)
+	}
+	assert.Contains(t, apiNames, "lun-map")
+	assert.NotContains(t, apiNames, "export-policy-create")
+
+	config.StorageDriverName = drivers.OntapNASStorageDriverName
+	config.AutoExportPolicy = true
+	privileges = getRequiredSVMPrivileges(config)
+	assert.Equal(t, "export-policy-create", privileges[len(privileges)-1].api)
+
+	config.SnapshotPolicies = newTestSnapshotPolicyConfig().SnapshotPolicies
+	assert.Equal(t, "snapshot-policy-create", getRequiredSVMPrivileges(config)[len(privileges)].api)
+	config.SnapshotPolicies = nil
+
+	assert.Empty(t, getMissingSVMPrivileges(privileges, api.RoleAccess{"DEFAULT": "all"}))
+	assert.Equal(t, []string{"volume-create", "export-policy-create"}, getMissingSVMPrivileges(privileges,
+		api.RoleAccess{"DEFAULT": "readonly", "volume snapshot": "all", "vserver": "readonly"}))
+	assert.Len(t, getMissingSVMPrivileges(privileges, api.RoleAccess{"volume": "all"}), 2)
+
+	assert.NoError(t, getMissingPrivilegesError(config, privileges, []string{}))
+
+	err := getMissingPrivilegesError(config, privileges, []string{"volume-create", "export-policy-create"})
+	assert.EqualError(t, err, "user trident has insufficient privileges on SVM svm1: "+
+		"cannot create volumes (volume-create, command directory \"volume\"); "+
+		"cannot create export policies (export-policy-create, command directory \"vserver export-policy\"); "+
+		"use the vsadmin role or grant the listed command directories")
+}
+
+func TestScopedExportPolicies(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.StorageDriverName = drivers.OntapNASStorageDriverName
+	volConfig := &storage.VolumeConfig{InternalName: "trident_pvc_1", StorageClass: "gold"}
+
+	config.AutoExportPolicy = true
+	config.AutoExportPolicyScope = ExportPolicyScopeBackend
+	assert.False(t, isScopedExportPolicy(config))
+	assert.Equal(t, "trident-1234", getScopedExportPolicyName(config, "1234", volConfig))
+
+	config.AutoExportPolicyScope = ExportPolicyScopeVolume
+	assert.True(t, isScopedExportPolicy(config))
+	policyName := getScopedExportPolicyName(config, "1234", volConfig)
+	assert.Equal(t, "trident-1234-vol-trident_pvc_1", policyName)
+	assert.True(t, isVolumeExportPolicy(policyName, "trident_pvc_1"))
+	assert.False(t, isVolumeExportPolicy("trident-1234", "trident_pvc_1"))
+
+	config.AutoExportPolicyScope = ExportPolicyScopeStorageClass
+	policyName = getScopedExportPolicyName(config, "1234", volConfig)
+	assert.Equal(t, "trident-1234-sc-gold", policyName)
+	assert.False(t, isVolumeExportPolicy(policyName, "trident_pvc_1"))
+
+	config.AutoExportPolicy = false
+	assert.False(t, isScopedExportPolicy(config))
+}
+
+func TestValidateExportPolicyScope(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.StorageDriverName = drivers.OntapNASFlexGroupStorageDriverName
+
+	for _, scope := range []string{"", ExportPolicyScopeBackend, ExportPolicyScopeVolume,
+		ExportPolicyScopeStorageClass} {
+		config.AutoExportPolicyScope = scope
+		assert.NoError(t, ValidateExportPolicyScope(config), scope)
+	}
+
+	config.AutoExportPolicyScope = "node"
+	assert.Error(t, ValidateExportPolicyScope(config))
+
+	config.StorageDriverName = drivers.OntapNASQtreeStorageDriverName
+	config.AutoExportPolicyScope = ExportPolicyScopeVolume
+	assert.Error(t, ValidateExportPolicyScope(config))
+	config.AutoExportPolicyScope = ExportPolicyScopeBackend
+	assert.NoError(t, ValidateExportPolicyScope(config))
+}
+
+func TestGetUndesiredExportPolicyRules(t *testing.T) {
+
+	existingRules := map[string]int{
+		"10.0.0.1":         1,
+		"10.0.0.2,fd00::2": 2,
+		"10.0.0.3":         3,
+	}
+
+	undesired := getUndesiredExportPolicyRules(existingRules, []string{"10.0.0.2,fd00::2", "10.0.0.4"})
+	assert.Equal(t, []int{1, 3}, undesired)
+
+	undesired = getUndesiredExportPolicyRules(existingRules, []string{"10.0.0.1", "10.0.0.2,fd00::2", "10.0.0.3"})
+	assert.Empty(t, undesired)
+}
+
+func TestGetDesiredExportPolicyRulesDualStack(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.AutoExportCIDRs = []string{"10.0.0.0/8", "fd00::/64"}
+
+	nodes := []*utils.Node{
+		{Name: "ipv4", IPs: []string{"10.0.0.1", "192.168.0.1"}},
+		{Name: "dualstack", IPs: []string{"fd00:0:0:0::2", "10.0.0.2", "2001:db8::2"}},
+		{Name: "ipv6", IPs: []string{"fd00::3"}},
+		{Name: "outside", IPs: []string{"192.168.0.4", "2001:db8::4"}},
+	}
+
+	rules, err := getDesiredExportPolicyRules(nodes, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2,fd00::2", "fd00::3"}, rules)
+
+	config.AutoExportCIDRs = []string{"fd00::/64", "bogus"}
+	_, err = getDesiredExportPolicyRules(nodes, config)
+	assert.Error(t, err)
+}
+
+func TestValidateExportRuleConfig(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	assert.NoError(t, ValidateExportRuleConfig(config))
+
+	config.ExportRule = drivers.OntapExportRuleConfig{AnonUID: "65534", IndexBase: "100",
+		ReadOnlyHosts: []string{"10.0.1.0/24"}}
+	assert.NoError(t, ValidateExportRuleConfig(config))
+
+	for _, exportRule := range []drivers.OntapExportRuleConfig{
+		{AnonUID: "-1"},
+		{AnonUID: "nobody"},
+		{IndexBase: "0"},
+		{IndexBase: "first"},
+		{ReadOnlyHosts: []string{" "}},
+	} {
+		config.ExportRule = exportRule
+		assert.Error(t, ValidateExportRuleConfig(config), exportRule)
+	}
+}
+
+func TestReconcileExportPolicyRulesIndexBase(t *testing.T) {
+
+	// Serve an export policy with a manually managed rule below the index base and a stale Trident rule
+	requests := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result := `<results status="passed"/>`
+		if strings.Contains(string(body), "<export-rule-get-iter>") {
+			result = `<results status="passed"><num-records>2</num-records><attributes-list>` +
+				`<export-rule-info><client-match>10.1.1.1</client-match><rule-index>1</rule-index></export-rule-info>` +
+				`<export-rule-info><client-match>10.0.0.1</client-match><rule-index>10</rule-index></export-rule-info>` +
+				`</attributes-list></results>`
+		} else {
+			requests = append(requests, string(body))
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+	defer server.Close()
+
+	config := newTestOntapSANConfig()
+	config.AutoExportCIDRs = []string{"10.0.0.0/8"}
+	config.ExportRule = drivers.OntapExportRuleConfig{AnonUID: "65534", IndexBase: "10",
+		ReadOnlyHosts: []string{"backup.example.com"}}
+	client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+
+	nodes := []*utils.Node{{Name: "node1", IPs: []string{"10.0.0.2"}}}
+	desiredRules, err := getDesiredExportPolicyRules(nodes, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2", "backup.example.com"}, desiredRules)
+
+	assert.NoError(t, reconcileExportPolicyRules("trident-policy", desiredRules, config, client))
+	assert.Len(t, requests, 3)
+
+	// New rules are appended after the existing rules, without disturbing the manually managed rule
+	assert.Contains(t, requests[0], "<client-match>10.0.0.2</client-match>")
+	assert.Contains(t, requests[0], "<rule-index>11</rule-index>")
+	assert.Contains(t, requests[0], "<anonymous-user-id>65534</anonymous-user-id>")
+	assert.Contains(t, requests[1], "<client-match>backup.example.com</client-match>")
+	assert.Contains(t, requests[1], "<rule-index>12</rule-index>")
+	assert.Contains(t, requests[1], "<security-flavor>never</security-flavor>")
+	assert.Contains(t, requests[2], "<export-rule-destroy>")
+	assert.Contains(t, requests[2], "<rule-index>10</rule-index>")
+}
+
+func TestGetAggrMediaTypesFallback(t *testing.T) {
+
+	// Deny ZAPI and REST access to aggregate info, as for an SVM-scoped user
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` +
+			`<results status="failed" errno="13003" reason="Insufficient privileges"/></netapp>`))
+	}))
+	defer server.Close()
+
+	config := newTestOntapSANConfig()
+	d := &SANStorageDriver{
+		Config: *config,
+		API:    api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")}),
+	}
+
+	mediaTypes, err := getAggrMediaTypes(d)
+	assert.Error(t, err)
+	assert.Empty(t, mediaTypes)
+
+	d.Config.AggregateMedia = map[string]string{"aggr1": "ssd"}
+	mediaTypes, err = getAggrMediaTypes(d)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"aggr1": "ssd"}, mediaTypes)
+
+	poolAttrs := map[string]map[string]sa.Offer{"aggr1": {}, "aggr2": {}}
+	assert.NoError(t, getVserverAggrAttributes(d, &poolAttrs))
+	assert.Equal(t, sa.NewStringOffer(sa.SSD), poolAttrs["aggr1"][sa.Media])
+	assert.Empty(t, poolAttrs["aggr2"])
+}
+
+func TestValidateAggregateMedia(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	assert.NoError(t, ValidateAggregateMedia(config))
+
+	config.AggregateMedia = map[string]string{"aggr1": "ssd", "aggr2": "hdd", "aggr3": "hybrid"}
+	assert.NoError(t, ValidateAggregateMedia(config))
+
+	config.AggregateMedia["aggr4"] = "flash"
+	assert.Error(t, ValidateAggregateMedia(config))
+}
+
+func newTestZapiError(errno, reason string) api.ZapiError {
+	return api.NewZapiError(azgo.CloneCreateResponseResult{
+		ResultStatusAttr: "failed",
+		ResultReasonAttr: reason,
+		ResultErrnoAttr:  errno,
+	})
+}
+
+func TestWrapOntapError(t *testing.T) {
+
+	assert.Nil(t, wrapOntapError(nil, "error"))
+
+	err := wrapOntapError(newTestZapiError(azgo.EOBJECTNOTFOUND, "entry doesn't exist"), "error creating clone")
+	assert.True(t, drivers.IsResourceNotFoundError(err))
+	assert.True(t, strings.HasPrefix(err.Error(), "error creating clone: "))
+
+	var zerr api.ZapiError
+	assert.True(t, errors.As(err, &zerr), "expected the ZAPI error to be wrapped")
+	assert.Equal(t, azgo.EOBJECTNOTFOUND, zerr.Code())
+
+	err = wrapOntapError(newTestZapiError(azgo.EDUPLICATEENTRY, "duplicate entry"), "error")
+	assert.True(t, drivers.IsResourceExistsError(err))
+
+	err = wrapOntapError(newTestZapiError(azgo.EAPIPRIVILEGE, "Insufficient privileges"), "error")
+	assert.True(t, drivers.IsUnauthorizedError(err))
+
+	err = wrapOntapError(newTestZapiError(azgo.ESNAPSHOTBUSY, "snapshot is busy"), "error")
+	assert.True(t, drivers.IsRetriableError(err))
+
+	err = wrapOntapError(newTestZapiError(azgo.EAPIERROR, "Job exists"), "error")
+	assert.True(t, drivers.IsRetriableError(err))
+
+	err = wrapOntapError(newTestZapiError(azgo.EAPIERROR, "Not enough space in aggregate"), "error")
+	assert.True(t, drivers.IsResourceExhaustedError(err))
+
+	err = wrapOntapError(newTestZapiError(azgo.EINVALIDINPUTERROR, "invalid input"), "error")
+	assert.Error(t, err)
+	assert.False(t, drivers.IsRetriableError(err))
+	assert.False(t, drivers.IsResourceNotFoundError(err))
+
+	err = wrapOntapError(&net.OpError{Op: "read", Err: syscall.ECONNRESET}, "error")
+	assert.True(t, drivers.IsRetriableError(err))
+
+	err = wrapOntapError(errors.New("some other failure"), "error")
+	assert.Equal(t, "error: some other failure", err.Error())
+	assert.False(t, drivers.IsRetriableError(err))
+}
+
+func TestCloneSplitterDeferredDeletion(t *testing.T) {
+
+	splitter := NewCloneSplitter(newTestOntapSANConfig(), nil, nil)
+
+	deleteErrors := map[string]error{
+		"busy":   drivers.NewRetriableError("snapshot is busy", nil),
+		"broken": errors.New("snapshot is broken"),
+	}
+	splitter.listClones = func(snapConfig *storage.SnapshotConfig) ([]string, error) {
+		return nil, nil
+	}
+	splitter.deleteSnapshot = func(snapConfig *storage.SnapshotConfig) error {
+		return deleteErrors[snapConfig.InternalName]
+	}
+
+	results := make(map[string]error)
+	splitter.SetHandler(func(snapConfig *storage.SnapshotConfig, err error) {
+		results[snapConfig.InternalName] = err
+	})
+
+	for _, name := range []string{"deleted", "busy", "broken"} {
+		snapConfig := &storage.SnapshotConfig{Name: name, InternalName: name, VolumeName: "vol1"}
+		splitter.snapshots[snapConfig.ID()] = snapConfig
+	}
+
+	// A snapshot with no clones left is deleted, while failures are retried
+	splitter.run()
+	assert.Equal(t, map[string]error{"deleted": nil}, results)
+	assert.Equal(t, 2, splitter.Pending())
+
+	// A snapshot that can't be deleted is eventually given up on, while a busy one is kept
+	for i := 1; i < maxDeferredSnapshotDeleteFailures; i++ {
+		splitter.run()
+	}
+	assert.Error(t, results["broken"])
+	assert.NotContains(t, results, "busy")
+	assert.Equal(t, 1, splitter.Pending())
+}
+
+func TestValidateStoragePoolsTieringMinimumCoolingDays(t *testing.T) {
+
+	newPool := func(tieringPolicy, coolingDays string) map[string]*storage.Pool {
+		pool := storage.NewStoragePool(nil, "aggr1")
+		pool.InternalAttributes[SpaceReserve] = "none"
+		pool.InternalAttributes[SnapshotPolicy] = "none"
+		pool.InternalAttributes[Encryption] = "false"
+		pool.InternalAttributes[SnapshotDir] = "false"
+		pool.InternalAttributes[SecurityStyle] = "unix"
+		pool.InternalAttributes[ExportPolicy] = "default"
+		pool.InternalAttributes[UnixPermissions] = "777"
+		pool.InternalAttributes[Size] = "1G"
+		pool.InternalAttributes[SplitOnClone] = "false"
+		pool.InternalAttributes[TieringPolicy] = tieringPolicy
+		pool.InternalAttributes[TieringMinimumCoolingDays] = coolingDays
+		return map[string]*storage.Pool{pool.Name: pool}
+	}
+	nas := drivers.OntapNASStorageDriverName
+	flexgroup := drivers.OntapNASFlexGroupStorageDriverName
+
+	assert.NoError(t, ValidateStoragePools(newPool("none", ""), nil, nas))
+	assert.NoError(t, ValidateStoragePools(newPool("auto", "2"), nil, nas))
+	assert.NoError(t, ValidateStoragePools(newPool("snapshot-only", "183"), nil, nas))
+	assert.NoError(t, ValidateStoragePools(newPool("", "31"), nil, nas))
+
+	assert.Error(t, ValidateStoragePools(newPool("auto", "1"), nil, nas))
+	assert.Error(t, ValidateStoragePools(newPool("auto", "184"), nil, nas))
+	assert.Error(t, ValidateStoragePools(newPool("auto", "month"), nil, nas))
+	assert.Error(t, ValidateStoragePools(newPool("none", "31"), nil, nas))
+	assert.Error(t, ValidateStoragePools(newPool("all", "31"), nil, nas))
+
+	// FlexGroups default to tiering policy none
+	assert.Error(t, ValidateStoragePools(nil, newPool("", "31"), flexgroup))
+	assert.NoError(t, ValidateStoragePools(nil, newPool("auto", "31"), flexgroup))
+}
+
+func TestParseFlexcacheOrigin(t *testing.T) {
+
+	tests := []struct {
+		value  string
+		svm    string
+		volume string
+		valid  bool
+	}{
+		{"origin", "svm0", "origin", true},
+		{"svm1:origin", "svm1", "origin", true},
+		{"", "", "", false},
+		{"svm1:", "", "", false},
+		{":origin", "", "", false},
+		{"svm1:origin:extra", "", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			svm, volume, err := parseFlexcacheOrigin(test.value, "svm0")
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.svm, svm)
+			assert.Equal(t, test.volume, volume)
+		})
+	}
+}
+
+func TestValidateStoragePoolsFlexcacheOrigin(t *testing.T) {
+
+	newPool := func(flexcacheOrigin string) map[string]*storage.Pool {
+		pool := storage.NewStoragePool(nil, "aggr1")
+		pool.InternalAttributes[SpaceReserve] = "none"
+		pool.InternalAttributes[SnapshotPolicy] = "none"
+		pool.InternalAttributes[Encryption] = "false"
+		pool.InternalAttributes[SnapshotDir] = "false"
+		pool.InternalAttributes[SecurityStyle] = "unix"
+		pool.InternalAttributes[ExportPolicy] = "default"
+		pool.InternalAttributes[UnixPermissions] = "777"
+		pool.InternalAttributes[Size] = "1G"
+		pool.InternalAttributes[SplitOnClone] = "false"
+		pool.InternalAttributes[TieringPolicy] = "none"
+		pool.InternalAttributes[FlexcacheOrigin] = flexcacheOrigin
+		return map[string]*storage.Pool{pool.Name: pool}
+	}
+	nas := drivers.OntapNASStorageDriverName
+
+	assert.NoError(t, ValidateStoragePools(newPool(""), nil, nas))
+	assert.NoError(t, ValidateStoragePools(newPool("origin"), nil, nas))
+	assert.NoError(t, ValidateStoragePools(nil, newPool("svm1:origin"), nas))
+
+	assert.Error(t, ValidateStoragePools(newPool("svm1:"), nil, nas))
+	assert.Error(t, ValidateStoragePools(newPool("origin"), nil, drivers.OntapNASFlexGroupStorageDriverName))
+	assert.Error(t, ValidateStoragePools(newPool("origin"), nil, drivers.OntapSANStorageDriverName))
+}
+
+func TestValidateStoragePoolsNfsMountOptions(t *testing.T) {
+
+	newPool := func(mountOptions string) map[string]*storage.Pool {
+		pool := storage.NewStoragePool(nil, "aggr1")
+		pool.InternalAttributes[SpaceReserve] = "none"
+		pool.InternalAttributes[SnapshotPolicy] = "none"
+		pool.InternalAttributes[Encryption] = "false"
+		pool.InternalAttributes[SnapshotDir] = "false"
+		pool.InternalAttributes[SecurityStyle] = "unix"
+		pool.InternalAttributes[ExportPolicy] = "default"
+		pool.InternalAttributes[UnixPermissions] = "777"
+		pool.InternalAttributes[Size] = "1G"
+		pool.InternalAttributes[SplitOnClone] = "false"
+		pool.InternalAttributes[TieringPolicy] = "none"
+		pool.InternalAttributes[NfsMountOptions] = mountOptions
+		return map[string]*storage.Pool{pool.Name: pool}
+	}
+	nas := drivers.OntapNASStorageDriverName
+
+	assert.NoError(t, ValidateStoragePools(newPool(""), nil, nas))
+	assert.NoError(t, ValidateStoragePools(nil, newPool("nfsvers=4.1,nconnect=4"), nas))
+	assert.NoError(t, ValidateStoragePools(nil, newPool("-o nfsvers=3"), drivers.OntapNASQtreeStorageDriverName))
+
+	assert.Error(t, ValidateStoragePools(nil, newPool("nfsvers=4.1,nosuid"), nas))
+	assert.Error(t, ValidateStoragePools(nil, newPool("nconnect=64"), nas))
+	assert.Error(t, ValidateStoragePools(nil, newPool("nfsvers=3"), drivers.OntapSANStorageDriverName))
+}
+
+func TestGetPoolsForCreateTopology(t *testing.T) {
+
+	pool := storage.NewStoragePool(nil, "aggr1")
+	pool.Attributes[sa.Zone] = sa.NewStringOffer("z1")
+	physicalPools := map[string]*storage.Pool{pool.Name: pool}
+
+	volConfig := &storage.VolumeConfig{
+		InternalName:        "vol1",
+		RequisiteTopologies: []map[string]string{{storage.TopologyZoneKey: "z1"}},
+	}
+	pools, err := getPoolsForCreate(volConfig, pool, nil, physicalPools, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Pool{pool}, pools)
+
+	volConfig.RequisiteTopologies = []map[string]string{{storage.TopologyZoneKey: "z2"}}
+	_, err = getPoolsForCreate(volConfig, pool, nil, physicalPools, nil)
+	assert.True(t, drivers.IsBackendIneligibleError(err), "expected backend ineligible error")
+}
+
+func TestExportRuleSecFlavors(t *testing.T) {
+
+	config := &drivers.OntapStorageDriverConfig{}
+	config.ExportRule.ReadOnlyHosts = []string{"10.0.0.9"}
+
+	ro, rw, su := exportRuleSecFlavors(config, "10.0.0.1")
+	assert.Equal(t, []string{"any"}, ro)
+	assert.Equal(t, []string{"any"}, rw)
+	assert.Equal(t, []string{"any"}, su)
+
+	// Kerberos backends only grant access to clients using their flavor
+	config.Kerberos = "krb5p"
+	ro, rw, su = exportRuleSecFlavors(config, "10.0.0.1")
+	assert.Equal(t, []string{"krb5p"}, ro)
+	assert.Equal(t, []string{"krb5p"}, rw)
+	assert.Equal(t, []string{"krb5p"}, su)
+
+	ro, rw, su = exportRuleSecFlavors(config, "10.0.0.9")
+	assert.Equal(t, []string{"krb5p"}, ro)
+	assert.Equal(t, []string{"never"}, rw)
+	assert.Equal(t, []string{"none"}, su)
+}
+
+func TestValidateKerberosConfigFlavor(t *testing.T) {
+
+	for _, flavor := range []string{"krb4", "sys", "KRB5"} {
+		config := &drivers.OntapStorageDriverConfig{Kerberos: flavor}
+		assert.Error(t, validateKerberosConfig(nil, config), flavor)
+	}
+}
+
+func TestCheckKerberosDataLIF(t *testing.T) {
+
+	enabledLIFs := []string{"10.0.0.1", "fd00::2"}
+
+	assert.NoError(t, checkKerberosDataLIF("10.0.0.1", "svm0", enabledLIFs))
+	assert.NoError(t, checkKerberosDataLIF("[fd00::2]", "svm0", enabledLIFs))
+	assert.Error(t, checkKerberosDataLIF("10.0.0.3", "svm0", enabledLIFs))
+	assert.Error(t, checkKerberosDataLIF("10.0.0.1", "svm0", nil))
+}
+
+func TestNfsMountOptionsWithSecurity(t *testing.T) {
+
+	assert.Equal(t, "nfsvers=4.1", nfsMountOptionsWithSecurity("nfsvers=4.1", ""))
+	assert.Equal(t, "nfsvers=4.1,sec=krb5i", nfsMountOptionsWithSecurity("nfsvers=4.1", "krb5i"))
+	assert.Equal(t, "-o nfsvers=3,sec=krb5", nfsMountOptionsWithSecurity("-o nfsvers=3", "krb5"))
+	assert.Equal(t, "sec=krb5p", nfsMountOptionsWithSecurity("", "krb5p"))
+	assert.Equal(t, "sec=krb5p", nfsMountOptionsWithSecurity("-o ", "krb5p"))
+
+	// Volumes requesting a flavor keep it
+	assert.Equal(t, "nfsvers=4.1,sec=krb5p", nfsMountOptionsWithSecurity("nfsvers=4.1,sec=krb5p", "krb5"))
+}
+
+func TestSelectNFSTrunkingDataLIFs(t *testing.T) {
+
+	dataLIFs := []string{"10.0.0.1", "10.0.0.2", "fd00::3", "10.0.0.4"}
+
+	assert.Equal(t, []string{"10.0.0.2", "[fd00::3]"}, selectNFSTrunkingDataLIFs(dataLIFs, "10.0.0.1", 3))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"},
+		selectNFSTrunkingDataLIFs(dataLIFs, "[fd00::3]", 8))
+	assert.Empty(t, selectNFSTrunkingDataLIFs([]string{"10.0.0.1"}, "10.0.0.1", 4))
+}
+
+func TestResolveNFSMountOptions(t *testing.T) {
+
+	pool := storage.NewStoragePool(nil, "pool1")
+	pool.InternalAttributes[NfsMountOptions] = "nfsvers=4.1"
+
+	// Options requested for the volume win over the pool's
+	volConfig := &storage.VolumeConfig{Name: "vol1", MountOptions: "nfsvers=3"}
+	assert.NoError(t, resolveNFSMountOptions(volConfig, pool))
+	assert.Equal(t, "nfsvers=3", volConfig.MountOptions)
+
+	volConfig = &storage.VolumeConfig{Name: "vol1"}
+	assert.NoError(t, resolveNFSMountOptions(volConfig, pool))
+	assert.Equal(t, "nfsvers=4.1", volConfig.MountOptions)
+
+	// A volume with no options of its own, in a pool without any, uses the backend's
+	volConfig = &storage.VolumeConfig{Name: "vol1"}
+	assert.NoError(t, resolveNFSMountOptions(volConfig, storage.NewStoragePool(nil, "pool2")))
+	assert.Equal(t, "", volConfig.MountOptions)
+
+	volConfig = &storage.VolumeConfig{Name: "vol1", MountOptions: "nfsvers=3,context=foo"}
+	assert.Error(t, resolveNFSMountOptions(volConfig, pool))
+}
+
+func TestModifyVolumeWithoutChanges(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+
+	// Nothing is sent to ONTAP if the request doesn't change any Flexvol attributes
+	request := &storage.UpdateVolumeRequest{SnapshotDirectory: "true"}
+	assert.NoError(t, ModifyVolume(context.Background(), "vol1", request, config, nil))
+
+	// Export policies may not be changed when the backend manages them
+	config.AutoExportPolicy = true
+	request = &storage.UpdateVolumeRequest{ExportPolicy: "policy1"}
+	assert.True(t, utils.IsUnsupportedError(ModifyVolume(context.Background(), "vol1", request, config, nil)))
+}
+
+func TestResizeValidationShrink(t *testing.T) {
+
+	volumeExists := func(string) (bool, error) { return true, nil }
+	volumeSize := func(string) (int, error) { return 2000, nil }
+
+	_, err := resizeValidation("vol1", 1000, false, volumeExists, volumeSize)
+	assert.True(t, drivers.IsUnsupportedCapacityRangeError(err), "expected unsupported capacity range error")
+
+	currentSize, err := resizeValidation("vol1", 1000, true, volumeExists, volumeSize)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2000), currentSize)
+
+	currentSize, err = resizeValidation("vol1", 3000, false, volumeExists, volumeSize)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2000), currentSize)
+}
+
+func TestCheckVolumeShrink(t *testing.T) {
+
+	volAttrs := azgo.NewVolumeAttributesType().SetVolumeSpaceAttributes(
+		*azgo.NewVolumeSpaceAttributesType().SetSizeUsed(1500))
+
+	assert.NoError(t, checkVolumeShrink("vol1", 2000, volAttrs))
+	assert.NoError(t, checkVolumeShrink("vol1", 1500, volAttrs))
+
+	err := checkVolumeShrink("vol1", 1000, volAttrs)
+	assert.True(t, drivers.IsUnsupportedCapacityRangeError(err), "expected unsupported capacity range error")
+
+	// Without the used size the shrink cannot be known to be safe
+	err = checkVolumeShrink("vol1", 2000, azgo.NewVolumeAttributesType())
+	assert.Error(t, err)
+	assert.False(t, drivers.IsUnsupportedCapacityRangeError(err))
+}
+
+func TestReconcileNASNodeAccessWithMockAPI(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.AutoExportPolicy = true
+	config.AutoExportCIDRs = []string{"10.0.0.0/8"}
+	client := api.NewMockOntapAPI(config.SVM)
+
+	// The policy is created and granted to each node within the CIDRs
+	nodes := []*utils.Node{
+		{Name: "node1", IPs: []string{"10.0.0.1"}},
+		{Name: "node2", IPs: []string{"10.0.0.2", "192.168.0.2"}},
+		{Name: "node3", IPs: []string{"192.168.0.3"}},
+	}
+	assert.NoError(t, reconcileNASNodeAccess(nodes, config, client, "trident-policy"))
+	assert.Equal(t, map[int]string{1: "10.0.0.1", 2: "10.0.0.2"}, client.ExportPolicies["trident-policy"])
+
+	// A departed node's rule is removed, and the policy existing already is no error
+	assert.NoError(t, reconcileNASNodeAccess(nodes[1:], config, client, "trident-policy"))
+	assert.Equal(t, map[int]string{2: "10.0.0.2"}, client.ExportPolicies["trident-policy"])
+
+	// A failure to create a rule is returned
+	client.FailCall("ExportRuleCreateWithOptions", azgo.EAPIERROR, "rule limit reached")
+	assert.Error(t, reconcileNASNodeAccess(nodes, config, client, "trident-policy"))
+
+	// Publishing creates a missing policy
+	client.FailCall("ExportRuleCreateWithOptions", "", "")
+	publishInfo := &utils.VolumePublishInfo{BackendUUID: "b1", Nodes: nodes}
+	assert.NoError(t, ensureNodeAccess(publishInfo, client, config))
+	assert.Len(t, client.ExportPolicies[getExportPolicyName("b1")], 2)
+}
+
+func TestReconcileSANNodeAccessWithMockAPI(t *testing.T) {
+
+	client := api.NewMockOntapAPI("SVM1")
+	client.Igroups["trident"] = []string{"iqn.node0", "iqn.node1"}
+
+	// Missing IQNs are added and departed ones removed
+	assert.NoError(t, reconcileSANNodeAccess(client, "trident", []string{"iqn.node1", "iqn.node2"}))
+	assert.ElementsMatch(t, []string{"iqn.node1", "iqn.node2"}, client.Igroups["trident"])
+
+	// A missing igroup is created
+	assert.NoError(t, reconcileSANNodeAccess(client, "trident-new", []string{"iqn.node1"}))
+	assert.Equal(t, []string{"iqn.node1"}, client.Igroups["trident-new"])
+
+	// Other failures are returned
+	client.FailCall("IgroupAdd", azgo.EAPIERROR, "igroup is busy")
+	assert.Error(t, reconcileSANNodeAccess(client, "trident", []string{"iqn.node3"}))
+}
+
+func TestRevokeSANNodeAccessWithMockAPI(t *testing.T) {
+
+	ctx := context.Background()
+	client := api.NewMockOntapAPI("SVM1")
+	client.Igroups["trident"] = []string{"iqn.node1", "iqn.node2"}
+	session := azgo.NewIscsiInitiatorListEntryInfoType().SetTpgroupName("lif1").SetTargetSessionId(7)
+	client.Sessions["iqn.node1"] = []azgo.IscsiInitiatorListEntryInfoType{*session}
+
+	assert.NoError(t, revokeSANNodeAccess(ctx, client, "trident", "iqn.node1"))
+	assert.Equal(t, []string{"iqn.node2"}, client.Igroups["trident"])
+	assert.Empty(t, client.Sessions["iqn.node1"])
+
+	// Revoking again finds nothing to remove
+	assert.NoError(t, revokeSANNodeAccess(ctx, client, "trident", "iqn.node1"))
+	assert.Equal(t, 1, client.Called("IscsiSessionShutdown"))
+}
+
+func TestPublishLUNWithMockAPI(t *testing.T) {
+
+	ctx := context.Background()
+	config := newTestOntapSANConfig()
+	client := api.NewMockOntapAPI(config.SVM)
+	client.Igroups["trident"] = []string{}
+	client.DataLIFs = map[string]string{"10.0.0.1": "node1", "10.0.0.2": "node2", "10.0.0.3": "node1"}
+	client.LUNs["/vol/vol1/lun0"] = "serial1"
+	client.LUNs["/vol/vol2/lun0"] = "serial2"
+	client.LUNUUIDs["/vol/vol1/lun0"] = "f5c3b2a1-0d9e-4c6b-8a7f-1e2d3c4b5a69"
+	client.LUNAttributes["/vol/vol1/lun0"] = map[string]string{LUNAttributeFSType: "xfs"}
+	client.ReportingNodes["/vol/vol1/lun0"] = []string{"node1"}
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+
+	publishInfo := &utils.VolumePublishInfo{HostIQN: []string{"iqn.node1"}}
+	assert.NoError(t, PublishLUN(ctx, client, config, ips, publishInfo, "/vol/vol1/lun0", "trident", client.TargetIQN))
+	assert.Equal(t, []string{"iqn.node1"}, client.Igroups["trident"])
+	assert.Equal(t, int32(0), publishInfo.IscsiLunNumber)
+	assert.Equal(t, "serial1", publishInfo.IscsiLunSerial)
+	assert.Equal(t, "f5c3b2a1-0d9e-4c6b-8a7f-1e2d3c4b5a69", publishInfo.IscsiLunUUID)
+	assert.Equal(t, "xfs", publishInfo.FilesystemType)
+	assert.Equal(t, "10.0.0.1", publishInfo.IscsiTargetPortal)
+	assert.Equal(t, []string{"10.0.0.3"}, publishInfo.IscsiPortals)
+	assert.Equal(t, 2, publishInfo.IscsiExpectedPaths)
+
+	// Another LUN gets the next LUN ID, and all portals if its reporting nodes are unknown
+	publishInfo = &utils.VolumePublishInfo{HostIQN: []string{"iqn.node1"}}
+	assert.NoError(t, PublishLUN(ctx, client, config, ips, publishInfo, "/vol/vol2/lun0", "trident", client.TargetIQN))
+	assert.Equal(t, int32(1), publishInfo.IscsiLunNumber)
+	assert.Equal(t, "serial2", publishInfo.IscsiLunSerial)
+	assert.Empty(t, publishInfo.IscsiLunUUID)
+	assert.Equal(t, drivers.DefaultFileSystemType, publishInfo.FilesystemType)
+	assert.Equal(t, "10.0.0.1", publishInfo.IscsiTargetPortal)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, publishInfo.IscsiPortals)
+	assert.Equal(t, 0, publishInfo.IscsiExpectedPaths)
+
+	// A LUN whose identity can't be read isn't published, so that the publish is retried
+	client.FailCall("LunGet", azgo.EAPIERROR, "lun-get-iter failed")
+	publishInfo = &utils.VolumePublishInfo{HostIQN: []string{"iqn.node1"}}
+	assert.Error(t, PublishLUN(ctx, client, config, ips, publishInfo, "/vol/vol1/lun0", "trident", client.TargetIQN))
+	assert.Empty(t, publishInfo.IscsiLunSerial)
+	assert.Empty(t, publishInfo.IscsiTargetPortal)
+	client.FailCall("LunGet", "", "")
+
+	// A LUN that doesn't exist can't be mapped
+	publishInfo = &utils.VolumePublishInfo{HostIQN: []string{"iqn.node1"}}
+	assert.Error(t, PublishLUN(ctx, client, config, ips, publishInfo, "/vol/vol3/lun0", "trident", client.TargetIQN))
+}
+
+func TestUpdateLUNPublication(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	client := api.NewMockOntapAPI(config.SVM)
+	client.Igroups["trident"] = []string{"iqn.node1"}
+	client.DataLIFs = map[string]string{"10.0.0.1": "node1", "10.0.0.2": "node2", "10.0.0.3": "node1"}
+	client.LUNs["/vol/vol1/lun0"] = "serial1"
+	client.LUNMaps["/vol/vol1/lun0"] = map[string]int{"trident": 0}
+	client.ReportingNodes["/vol/vol1/lun0"] = []string{"node1"}
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+
+	publishedTo := func(targetPortal string, portals []string, expectedPaths int) *utils.VolumePublishInfo {
+		publishInfo := &utils.VolumePublishInfo{}
+		publishInfo.IscsiIgroup = "trident"
+		publishInfo.IscsiTargetPortal = targetPortal
+		publishInfo.IscsiPortals = portals
+		publishInfo.IscsiExpectedPaths = expectedPaths
+		return publishInfo
+	}
+
+	tests := map[string]struct {
+		publishInfo   *utils.VolumePublishInfo
+		updated       bool
+		targetPortal  string
+		portals       []string
+		expectedPaths int
+		wantErr       bool
+	}{
+		"No igroup": {
+			publishInfo: &utils.VolumePublishInfo{},
+			wantErr:     true,
+		},
+		"Portals unchanged": {
+			publishInfo:   publishedTo("10.0.0.1", []string{"10.0.0.3"}, 2),
+			targetPortal:  "10.0.0.1",
+			portals:       []string{"10.0.0.3"},
+			expectedPaths: 2,
+		},
+		"Portals moved": {
+			publishInfo:   publishedTo("10.0.0.2", []string{}, 0),
+			updated:       true,
+			targetPortal:  "10.0.0.1",
+			portals:       []string{"10.0.0.3"},
+			expectedPaths: 2,
+		},
+	}
+	for name, test := range tests {
+		updated, err := UpdateLUNPublication(client, config, ips, test.publishInfo, "/vol/vol1/lun0")
+		if test.wantErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, test.updated, updated, name)
+		assert.Equal(t, test.targetPortal, test.publishInfo.IscsiTargetPortal, name)
+		assert.Equal(t, test.portals, test.publishInfo.IscsiPortals, name)
+		assert.Equal(t, test.expectedPaths, test.publishInfo.IscsiExpectedPaths, name)
+	}
+}
+
+func TestCreateOntapCloneWithMockAPI(t *testing.T) {
+
+	ctx := context.Background()
+	config := newTestOntapSANConfig()
+	config.StorageDriverName = drivers.OntapNASStorageDriverName
+	client := api.NewMockOntapAPI(config.SVM)
+	client.Volumes["source"] = &api.MockVolume{Snapshots: []string{"snap1"}}
+
+	// The clone is tagged with its request, mounted, and split
+	assert.NoError(t, CreateOntapClone(ctx, "clone1", "source", "snap1", "uuid1", true, config, client,
+		false, nil, nil, nil))
+	clone := client.Volumes["clone1"]
+	assert.NotNil(t, clone)
+	assert.Equal(t, "source", clone.CloneParent)
+	assert.Equal(t, "/clone1", clone.JunctionPath)
+	assert.True(t, clone.Splitting)
+	assert.Equal(t, "uuid1", getCreationUUID(clone.Comment))
+
+	// Retrying the same request finds the clone it created
+	err := CreateOntapClone(ctx, "clone1", "source", "snap1", "uuid1", false, config, client,
+		false, nil, nil, nil)
+	assert.True(t, drivers.IsVolumeExistsError(err), "expected a VolumeExistsError, got %v", err)
+
+	// A missing snapshot is reported as such
+	err = CreateOntapClone(ctx, "clone2", "source", "snap2", "uuid2", false, config, client,
+		false, nil, nil, nil)
+	assert.True(t, drivers.IsResourceNotFoundError(err), "expected a ResourceNotFoundError, got %v", err)
+
+	// A clone that can't be mounted is destroyed so that a retry starts over
+	client.FailCall("VolumeMount", azgo.EAPIERROR, "junction path in use")
+	assert.Error(t, CreateOntapClone(ctx, "clone3", "source", "snap1", "uuid3", false, config, client,
+		false, nil, nil, nil))
+	assert.NotContains(t, client.Volumes, "clone3")
+}
+
+func TestGetSnapshotsReportsSpace(t *testing.T) {
+
+	// Serve a volume whose 3 GiB of snapshots spill 1 GiB beyond its 2 GiB snapshot reserve
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result := `<results status="passed"/>`
+		if strings.Contains(string(body), "<snapshot-get-iter>") {
+			result = `<results status="passed"><num-records>2</num-records><attributes-list>` +
+				`<snapshot-info><name>snap1</name><access-time>1590000000</access-time><total>1048576</total>` +
+				`</snapshot-info>` +
+				`<snapshot-info><name>snap2</name><access-time>1590003600</access-time><total>2097152</total>` +
+				`</snapshot-info>` +
+				`</attributes-list></results>`
+		} else if strings.Contains(string(body), "<volume-get-iter>") {
+			result = `<results status="passed"><num-records>1</num-records><attributes-list><volume-attributes>` +
+				`<volume-id-attributes><name>trident_vol1</name></volume-id-attributes>` +
+				`<volume-space-attributes><size>21474836480</size>` +
+				`<snapshot-reserve-size>2147483648</snapshot-reserve-size>` +
+				`<size-used-by-snapshots>3221225472</size-used-by-snapshots></volume-space-attributes>` +
+				`</volume-attributes></attributes-list></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+	defer server.Close()
+
+	config := newTestOntapSANConfig()
+	client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+	volConfig := &storage.VolumeConfig{Name: "vol1", InternalName: "trident_vol1"}
+
+	snapshots, err := GetSnapshots(volConfig, config, client, client.VolumeSize)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, int64(1073741824), snapshots[0].UsedBytes)
+	assert.Equal(t, int64(2147483648), snapshots[1].UsedBytes)
+
+	expected := &storage.SnapshotReserve{
+		ReserveBytes: 2147483648,
+		UsedBytes:    3221225472,
+		SpillBytes:   1073741824,
+		Count:        2,
+	}
+	assert.Equal(t, expected, snapshots[0].VolumeReserve)
+	assert.True(t, snapshots[1].VolumeReserve.Spilled())
+
+	snapshot, err := GetSnapshot(&storage.SnapshotConfig{Name: "snap2", InternalName: "snap2",
+		VolumeName: "vol1", VolumeInternalName: "trident_vol1"}, config, client, client.VolumeSize)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2147483648), snapshot.UsedBytes)
+	assert.Equal(t, expected, snapshot.VolumeReserve)
+}
+
+func TestFilterBackendAggrNames(t *testing.T) {
+
+	vserverAggrs := []string{"aggr0_root", "data_aggr1", "data_aggr2", "reserved"}
+
+	config := newTestOntapSANConfig()
+	config.Aggregate = ""
+	aggrNames, err := filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.NoError(t, err)
+	assert.Equal(t, vserverAggrs, aggrNames)
+
+	config.ExcludeAggregates = []string{"aggr0_root", "reserved"}
+	aggrNames, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data_aggr1", "data_aggr2"}, aggrNames)
+
+	config.ExcludeAggregates = []string{"data_aggr2"}
+	config.AggregateSelector = "^data_"
+	aggrNames, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data_aggr1"}, aggrNames)
+
+	// The configured aggregate must survive the filters
+	config.Aggregate = "data_aggr2"
+	_, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.Error(t, err, "expected error for excluded aggregate")
+
+	config.Aggregate = "data_aggr1"
+	aggrNames, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data_aggr1"}, aggrNames)
+
+	config.Aggregate = ""
+	config.AggregateSelector = "^ssd_"
+	_, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.Error(t, err, "expected error when no aggregates remain")
+
+	config.AggregateSelector = "["
+	_, err = filterBackendAggrNames(config, "ontap-san", vserverAggrs)
+	assert.Error(t, err, "expected invalid aggregateSelector error")
+}
+
+func TestGetSnapshotAttributes(t *testing.T) {
+
+	snapConfig := &storage.SnapshotConfig{
+		Name: "snap",
+		Parameters: map[string]string{
+			SnapshotParamSnapmirrorLabel: "daily",
+			SnapshotParamComment:         "before upgrade",
+			"retentionDays":              "7",
+		},
+	}
+	comment, label, err := getSnapshotAttributes(context.Background(), snapConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "before upgrade", comment)
+	assert.Equal(t, "daily", label)
+
+	comment, label, err = getSnapshotAttributes(context.Background(), &storage.SnapshotConfig{Name: "plain"})
+	assert.NoError(t, err)
+	assert.Empty(t, comment)
+	assert.Empty(t, label)
+
+	snapConfig.Parameters[SnapshotParamSnapmirrorLabel] = strings.Repeat("x", maxSnapmirrorLabelLength+1)
+	_, _, err = getSnapshotAttributes(context.Background(), snapConfig)
+	assert.Error(t, err, "expected error for overlong SnapMirror label")
+}
+
+func TestNewOntapTelemetryDisabled(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.UsageHeartbeat = "2"
+	d := &SANStorageDriver{Config: *config}
+
+	telemetry := NewOntapTelemetry(d)
+	job := telemetry.HousekeepingJob()
+	if assert.NotNil(t, job) {
+		assert.Equal(t, emsHeartbeatJob, job.Name)
+		assert.Equal(t, 2*time.Hour, job.Interval)
+	}
+
+	// Disabled telemetry schedules no heartbeat
+	d.Config.DisableTelemetry = true
+	telemetry = NewOntapTelemetry(d)
+	assert.Nil(t, telemetry.HousekeepingJob())
+}
+
+func TestGetTelemetryFeatureFlags(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	assert.Equal(t, map[string]bool{
+		"autoExportPolicy":    false,
+		"useCHAP":             false,
+		"virtualPools":        false,
+		"limitVolumeSize":     false,
+		"limitAggregateUsage": false,
+	}, getTelemetryFeatureFlags(config))
+
+	config.AutoExportPolicy = true
+	config.UseCHAP = true
+	config.Storage = []drivers.OntapStorageDriverPool{{}}
+	config.LimitVolumeSize = "50Gi"
+	config.LimitAggregateUsage = "80%"
+	assert.Equal(t, map[string]bool{
+		"autoExportPolicy":    true,
+		"useCHAP":             true,
+		"virtualPools":        true,
+		"limitVolumeSize":     true,
+		"limitAggregateUsage": true,
+	}, getTelemetryFeatureFlags(config))
+}
+
+func TestTelemetryVolumeCount(t *testing.T) {
+
+	now := time.Now()
+	counts := 0
+	volumeCount := newTelemetryVolumeCount(time.Hour, func() int {
+		counts++
+		return 10 * counts
+	})
+	volumeCount.now = func() time.Time { return now }
+
+	assert.Equal(t, 10, volumeCount.get())
+	now = now.Add(30 * time.Minute)
+	assert.Equal(t, 10, volumeCount.get())
+	assert.Equal(t, 1, counts)
+
+	// An expired count is counted again
+	now = now.Add(time.Hour)
+	assert.Equal(t, 20, volumeCount.get())
+	assert.Equal(t, 2, counts)
+}
+
+func TestEMSHeartbeat(t *testing.T) {
+
+	// Record the EMS messages logged and how many times the volumes are listed
+	messages := make([]string, 0)
+	volumeLists := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(body), "<ems-autosupport-log>"):
+			description := strings.SplitN(string(body), "<event-description>", 2)[1]
+			description = strings.SplitN(description, "</event-description>", 2)[0]
+			messages = append(messages, html.UnescapeString(description))
+		case strings.Contains(string(body), "<volume-get-iter>"):
+			volumeLists++
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` +
+			`<results status="passed"><num-records>0</num-records></results></netapp>`))
+	}))
+	defer server.Close()
+
+	config := newTestOntapSANConfig()
+	config.UseCHAP = true
+	d := &NASStorageDriver{
+		Config: *config,
+		API:    api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")}),
+	}
+	d.Telemetry = NewOntapTelemetry(d)
+
+	tridentconfig.OrchestratorTelemetry.PlatformUID = "cluster-uid"
+	defer func() { tridentconfig.OrchestratorTelemetry.PlatformUID = "" }()
+
+	EMSHeartbeat(d)
+	EMSHeartbeat(d)
+
+	// The volumes are counted once, and the count isn't written to the driver's telemetry
+	assert.Equal(t, 1, volumeLists)
+	assert.Zero(t, d.Telemetry.VolumeCount)
+
+	if assert.Len(t, messages, 2) {
+		payload := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(messages[0]), &payload))
+		assert.Equal(t, drivers.OntapNASStorageDriverName, payload["plugin"])
+		assert.Equal(t, "SVM1", payload["svm"])
+		assert.Equal(t, "test_", payload["storagePrefix"])
+		assert.Equal(t, "cluster-uid", payload["platformUID"])
+		assert.Equal(t, float64(0), payload["volumeCount"])
+		assert.Equal(t, true, payload["featureFlags"].(map[string]interface{})["useCHAP"])
+		assert.Equal(t, messages[0], messages[1])
+	}
+}
@@ -0,0 +1,1362 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/RoaringBitmap/roaring"
+	log "github.com/sirupsen/logrus"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+// multiSVMConfig holds the config file fields that turn an ONTAP backend into a multi-SVM backend.
+type multiSVMConfig struct {
+	SVMs        []string `json:"svms"`
+	SVMSelector string   `json:"svmSelector"`
+}
+
+// UsesMultipleSVMs returns true if an ONTAP backend config lists several SVMs or an SVM selector,
+// in which case the backend should be managed by a MultiSVMStorageDriver.
+func UsesMultipleSVMs(configJSON string) bool {
+	config := &multiSVMConfig{}
+	if err := json.Unmarshal([]byte(configJSON), config); err != nil {
+		return false
+	}
+	return len(config.SVMs) > 0 || config.SVMSelector != ""
+}
+
+// newSingleSVMStorageDriver returns an uninitialized ONTAP driver of the named type.
+func newSingleSVMStorageDriver(driverName string) (storage.Driver, error) {
+	switch driverName {
+	case drivers.OntapNASStorageDriverName:
+		return &NASStorageDriver{}, nil
+	case drivers.OntapNASFlexGroupStorageDriverName:
+		return &NASFlexGroupStorageDriver{}, nil
+	case drivers.OntapNASQtreeStorageDriverName:
+		return &NASQtreeStorageDriver{}, nil
+	case drivers.OntapSANStorageDriverName:
+		return &SANStorageDriver{}, nil
+	case drivers.OntapSANEconomyStorageDriverName:
+		return &SANEconomyStorageDriver{}, nil
+	default:
+		return nil, fmt.Errorf("unknown ONTAP storage driver: %s", driverName)
+	}
+}
+
+// MultiSVMStorageDriver presents several SVMs as a single backend.  It initializes one driver of the
+// configured type per SVM, each with its own API client, and namespaces their storage pools by SVM.
+// Volume operations are routed to the driver for the SVM that holds the volume.
+type MultiSVMStorageDriver struct {
+	initialized bool
+	driverName  string
+	Config      drivers.OntapStorageDriverConfig
+
+	svms    []string
+	drivers map[string]storage.Driver
+
+	// Storage pools by namespaced name, and the SVM and per-SVM pool behind each of them
+	poolSVMs   map[string]string
+	childPools map[string]*storage.Pool
+
+	// SVM of each volume by internal name, populated as volumes are found
+	volumeSVMs map[string]string
+
+	// SVM of each orphaned resource by orphanKey, populated as orphaned resources are listed
+	orphanSVMs map[string]string
+	mutex      sync.RWMutex
+}
+
+func NewMultiSVMStorageDriver(driverName string) *MultiSVMStorageDriver {
+	return &MultiSVMStorageDriver{
+		driverName: driverName,
+		drivers:    make(map[string]storage.Driver),
+		poolSVMs:   make(map[string]string),
+		childPools: make(map[string]*storage.Pool),
+		volumeSVMs: make(map[string]string),
+		orphanSVMs: make(map[string]string),
+	}
+}
+
+// Name is for returning the name of this driver
+func (d *MultiSVMStorageDriver) Name() string {
+	return d.driverName
+}
+
+// Initialize resolves the SVMs named by the config and initializes a driver for each of them.
+func (d *MultiSVMStorageDriver) Initialize(
+	context tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
+) error {
+
+	if commonConfig.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Initialize", "Type": "MultiSVMStorageDriver"}
+		log.WithFields(fields).Debug(">>>> Initialize")
+		defer log.WithFields(fields).Debug("<<<< Initialize")
+	}
+
+	// Parse the config
+	config, err := InitializeOntapConfig(context, configJSON, commonConfig)
+	if err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.Config = *config
+
+	if config.SVM != "" {
+		return fmt.Errorf("error initializing %s driver: svm may not be combined with svms or svmSelector",
+			d.Name())
+	}
+	if config.SecondaryManagementLIF != "" {
+		return fmt.Errorf("error initializing %s driver: secondaryManagementLIF may not be combined with "+
+			"svms or svmSelector", d.Name())
+	}
+
+	d.svms, err = resolveSVMs(config)
+	if err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	log.WithField("SVMs", d.svms).Info("Initializing multi-SVM backend.")
+
+	var configMap map[string]interface{}
+	if err = json.Unmarshal([]byte(configJSON), &configMap); err != nil {
+		return fmt.Errorf("could not decode JSON configuration: %v", err)
+	}
+	delete(configMap, "svms")
+	delete(configMap, "svmSelector")
+
+	for _, svm := range d.svms {
+
+		configMap["svm"] = svm
+		svmConfigJSON, err := json.Marshal(configMap)
+		if err != nil {
+			return fmt.Errorf("could not encode JSON configuration for SVM %s: %v", svm, err)
+		}
+
+		driver, err := newSingleSVMStorageDriver(d.driverName)
+		if err != nil {
+			return err
+		}
+		if err = driver.Initialize(context, string(svmConfigJSON), commonConfig); err != nil {
+			d.terminateDrivers("")
+			return fmt.Errorf("error initializing %s driver for SVM %s: %v", d.Name(), svm, err)
+		}
+		d.drivers[svm] = driver
+	}
+
+	// Start from the defaults the first SVM's driver filled in, but keep the multi-SVM settings
+	d.Config = *d.drivers[d.svms[0]].(StorageDriver).GetConfig()
+	d.Config.SVM = ""
+	d.Config.SVMs = config.SVMs
+	d.Config.SVMSelector = config.SVMSelector
+	d.Config.DataLIF = config.DataLIF
+
+	d.initialized = true
+	return nil
+}
+
+// resolveSVMs returns the sorted, de-duplicated list of SVMs listed in the config or matching its
+// SVM selector.  Resolving the selector requires cluster-scoped credentials.
+func resolveSVMs(config *drivers.OntapStorageDriverConfig) ([]string, error) {
+
+	svmSet := make(map[string]struct{})
+	for _, svm := range config.SVMs {
+		if svm != "" {
+			svmSet[svm] = struct{}{}
+		}
+	}
+
+	if config.SVMSelector != "" {
+
+		selector, err := regexp.Compile(config.SVMSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid svmSelector %s: %v", config.SVMSelector, err)
+		}
+
+		client := api.NewClient(api.ClientConfig{
+			ManagementLIF:   config.ManagementLIF,
+			Username:        config.Username,
+			Password:        config.Password,
+			DriverContext:   config.DriverContext,
+			DebugTraceFlags: config.DebugTraceFlags,
+		})
+
+		vserverResponse, err := client.VserverGetIterRequest()
+		if err = api.GetError(vserverResponse, err); err != nil {
+			return nil, fmt.Errorf("error enumerating SVMs: %v", err)
+		}
+
+		if vserverResponse.Result.AttributesListPtr != nil {
+			for _, vserver := range vserverResponse.Result.AttributesListPtr.VserverInfoPtr {
+				if vserver.VserverType() == "data" && selector.MatchString(vserver.VserverName()) {
+					svmSet[vserver.VserverName()] = struct{}{}
+				}
+			}
+		}
+	}
+
+	if len(svmSet) == 0 {
+		return nil, errors.New("no SVMs found matching svms or svmSelector")
+	}
+
+	svms := make([]string, 0, len(svmSet))
+	for svm := range svmSet {
+		svms = append(svms, svm)
+	}
+	sort.Strings(svms)
+
+	return svms, nil
+}
+
+func (d *MultiSVMStorageDriver) Initialized() bool {
+	return d.initialized
+}
+
+func (d *MultiSVMStorageDriver) Terminate(backendUUID string) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "Terminate", "Type": "MultiSVMStorageDriver"}
+		log.WithFields(fields).Debug(">>>> Terminate")
+		defer log.WithFields(fields).Debug("<<<< Terminate")
+	}
+
+	d.terminateDrivers(backendUUID)
+	d.initialized = false
+}
+
+func (d *MultiSVMStorageDriver) terminateDrivers(backendUUID string) {
+	for _, driver := range d.drivers {
+		driver.Terminate(backendUUID)
+	}
+}
+
+// primaryDriver returns the driver for the first SVM, which handles operations that don't
+// involve a specific volume.
+func (d *MultiSVMStorageDriver) primaryDriver() storage.Driver {
+	return d.drivers[d.svms[0]]
+}
+
+// svmPoolName returns the name of a per-SVM storage pool within this backend.
+func svmPoolName(svm, name string) string {
+	return fmt.Sprintf("%s_%s", svm, name)
+}
+
+// driverForVolume returns the SVM and driver holding a volume, checking every SVM if the volume
+// hasn't been seen before.  A volume whose name exists on several SVMs can't be found by name alone.
+func (d *MultiSVMStorageDriver) driverForVolume(name string) (string, storage.Driver, error) {
+
+	d.mutex.RLock()
+	svm, ok := d.volumeSVMs[name]
+	d.mutex.RUnlock()
+	if ok {
+		return svm, d.drivers[svm], nil
+	}
+
+	found := make([]string, 0)
+	for _, svm := range d.svms {
+		if err := d.drivers[svm].Get(name); err == nil {
+			found = append(found, svm)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", nil, utils.NotFoundError(fmt.Sprintf("volume %s not found on any SVM", name))
+	case 1:
+		d.setVolumeSVM(name, found[0])
+		return found[0], d.drivers[found[0]], nil
+	default:
+		return "", nil, fmt.Errorf("volume %s exists on SVMs %v", name, found)
+	}
+}
+
+// driverForVolumeConfig returns the SVM and driver holding a volume, using the SVM recorded in the
+// volume's config when it was created.  Volumes created before the SVM was recorded are found by name.
+func (d *MultiSVMStorageDriver) driverForVolumeConfig(volConfig *storage.VolumeConfig) (string, storage.Driver, error) {
+
+	if volConfig.SVM == "" {
+		return d.driverForVolume(volConfig.InternalName)
+	}
+
+	driver, ok := d.drivers[volConfig.SVM]
+	if !ok {
+		return "", nil, fmt.Errorf("volume %s is on SVM %s, which is not part of the backend",
+			volConfig.InternalName, volConfig.SVM)
+	}
+	d.setVolumeSVM(volConfig.InternalName, volConfig.SVM)
+	return volConfig.SVM, driver, nil
+}
+
+// recordVolumeSVM records the SVM holding a volume in the volume's config, so that it is persisted
+// with the volume.
+func (d *MultiSVMStorageDriver) recordVolumeSVM(volConfig *storage.VolumeConfig, svm string) {
+	volConfig.SVM = svm
+	d.setVolumeSVM(volConfig.InternalName, svm)
+}
+
+func (d *MultiSVMStorageDriver) setVolumeSVM(name, svm string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.volumeSVMs[name] = svm
+}
+
+func (d *MultiSVMStorageDriver) forgetVolumeSVM(name string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.volumeSVMs, name)
+}
+
+// namespaceError prefixes the physical pool names in a BackendIneligibleError with their SVM, so
+// the orchestrator can stop trying those pools.
+func namespaceError(err error, svm, volumeName string) error {
+	if !drivers.IsBackendIneligibleError(err) {
+		return err
+	}
+	_, poolNames := drivers.GetIneligiblePhysicalPoolNames(err)
+	namespacedPoolNames := make([]string, 0, len(poolNames))
+	for _, name := range poolNames {
+		namespacedPoolNames = append(namespacedPoolNames, svmPoolName(svm, name))
+	}
+	return drivers.NewBackendIneligibleError(volumeName, []error{err}, namespacedPoolNames)
+}
+
+// Create a volume on the SVM that owns the requested storage pool
+func (d *MultiSVMStorageDriver) Create(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+) error {
+
+	d.mutex.RLock()
+	svm, ok := d.poolSVMs[storagePool.Name]
+	childPool := d.childPools[storagePool.Name]
+	d.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("could not find pool %s", storagePool.Name)
+	}
+
+	if err := d.drivers[svm].Create(ctx, volConfig, childPool, volAttributes); err != nil {
+		return namespaceError(err, svm, volConfig.InternalName)
+	}
+
+	d.recordVolumeSVM(volConfig, svm)
+	return nil
+}
+
+func (d *MultiSVMStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
+	d.primaryDriver().CreatePrepare(volConfig)
+}
+
+func (d *MultiSVMStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	return driver.CreateFollowup(ctx, volConfig)
+}
+
+// CreateClone creates a clone on the SVM holding the source volume, as clones cannot span SVMs
+func (d *MultiSVMStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+
+	svm, driver, err := d.driverForVolume(volConfig.CloneSourceVolumeInternal)
+	if err != nil {
+		return err
+	}
+
+	var childPool *storage.Pool
+	if storagePool != nil {
+		d.mutex.RLock()
+		if d.poolSVMs[storagePool.Name] == svm {
+			childPool = d.childPools[storagePool.Name]
+		}
+		d.mutex.RUnlock()
+	}
+
+	if err = driver.CreateClone(ctx, volConfig, childPool); err != nil {
+		return err
+	}
+
+	d.recordVolumeSVM(volConfig, svm)
+	return nil
+}
+
+func (d *MultiSVMStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
+
+	svm, driver, err := d.driverForVolume(originalName)
+	if err != nil {
+		return err
+	}
+
+	if err = driver.Import(ctx, volConfig, originalName); err != nil {
+		return err
+	}
+
+	d.forgetVolumeSVM(originalName)
+	d.recordVolumeSVM(volConfig, svm)
+	return nil
+}
+
+func (d *MultiSVMStorageDriver) Destroy(ctx context.Context, name string) error {
+	return d.DestroyVolume(ctx, &storage.VolumeConfig{InternalName: name})
+}
+
+// DestroyVolume passes the volume's config to the driver for its SVM, if that driver can use it.
+func (d *MultiSVMStorageDriver) DestroyVolume(ctx context.Context, volConfig *storage.VolumeConfig) error {
+
+	name := volConfig.InternalName
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if utils.IsNotFoundError(err) {
+		logc(ctx).WithField("volume", name).Warn("Volume already deleted.")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if destroyer, ok := driver.(storage.VolumeDestroyer); ok {
+		err = destroyer.DestroyVolume(ctx, volConfig)
+	} else {
+		err = driver.Destroy(ctx, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.forgetVolumeSVM(name)
+	return nil
+}
+
+func (d *MultiSVMStorageDriver) Rename(ctx context.Context, name string, newName string) error {
+
+	svm, driver, err := d.driverForVolume(name)
+	if err != nil {
+		return err
+	}
+
+	if err = driver.Rename(ctx, name, newName); err != nil {
+		return err
+	}
+
+	d.forgetVolumeSVM(name)
+	d.setVolumeSVM(newName, svm)
+	return nil
+}
+
+// UpdateVolume delegates to the driver of the SVM holding the volume, if it supports updates.
+func (d *MultiSVMStorageDriver) UpdateVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, updateRequest *storage.UpdateVolumeRequest,
+) error {
+	svm, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	updater, ok := driver.(storage.VolumeUpdater)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("SVM %s does not support updating volumes", svm))
+	}
+	return updater.UpdateVolume(ctx, volConfig, updateRequest)
+}
+
+func (d *MultiSVMStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	return driver.Resize(ctx, volConfig, sizeBytes)
+}
+
+// ResizeInPool passes the SVM's own pool to its driver, so that the pool's size limit is enforced.
+func (d *MultiSVMStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	resizer, ok := driver.(storage.PoolResizer)
+	if !ok || storagePool == nil {
+		return driver.Resize(ctx, volConfig, sizeBytes)
+	}
+	d.mutex.RLock()
+	childPool := d.childPools[storagePool.Name]
+	d.mutex.RUnlock()
+	return resizer.ResizeInPool(ctx, volConfig, childPool, sizeBytes)
+}
+
+func (d *MultiSVMStorageDriver) Get(name string) error {
+	_, _, err := d.driverForVolume(name)
+	return err
+}
+
+func (d *MultiSVMStorageDriver) GetInternalVolumeName(name string) string {
+	return d.primaryDriver().GetInternalVolumeName(name)
+}
+
+// GetStorageBackendSpecs adds the storage pools of every SVM to the backend, prefixing each pool's
+// name with its SVM so that pools on aggregates shared by several SVMs remain distinct.
+func (d *MultiSVMStorageDriver) GetStorageBackendSpecs(backend *storage.Backend) error {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, svm := range d.svms {
+
+		svmBackend := &storage.Backend{
+			Driver:      d.drivers[svm],
+			BackendUUID: backend.BackendUUID,
+			Storage:     make(map[string]*storage.Pool),
+			Volumes:     make(map[string]*storage.Volume),
+		}
+		if err := d.drivers[svm].GetStorageBackendSpecs(svmBackend); err != nil {
+			return fmt.Errorf("could not get storage pools for SVM %s: %v", svm, err)
+		}
+		if backend.Name == "" {
+			backend.Name = svmBackend.Name
+		}
+
+		for name, childPool := range svmBackend.Storage {
+
+			// The per-SVM pool belongs to the real backend, so that drivers can find its UUID
+			childPool.Backend = backend
+
+			pool := storage.NewStoragePool(backend, svmPoolName(svm, name))
+			for attrName, offer := range childPool.Attributes {
+				pool.Attributes[attrName] = offer
+			}
+			for attrName, value := range childPool.InternalAttributes {
+				pool.InternalAttributes[attrName] = value
+			}
+			backend.AddStoragePool(pool)
+
+			d.poolSVMs[pool.Name] = svm
+			d.childPools[pool.Name] = childPool
+		}
+	}
+
+	return nil
+}
+
+func (d *MultiSVMStorageDriver) GetStorageBackendPhysicalPoolNames() []string {
+	physicalPoolNames := make([]string, 0)
+	for _, svm := range d.svms {
+		for _, name := range d.drivers[svm].GetStorageBackendPhysicalPoolNames() {
+			physicalPoolNames = append(physicalPoolNames, svmPoolName(svm, name))
+		}
+	}
+	return physicalPoolNames
+}
+
+// GetProtocol is answered by a new driver of the configured type, so that it works even if
+// initialization failed.
+func (d *MultiSVMStorageDriver) GetProtocol() tridentconfig.Protocol {
+	driver, err := newSingleSVMStorageDriver(d.driverName)
+	if err != nil {
+		return tridentconfig.ProtocolAny
+	}
+	return driver.GetProtocol()
+}
+
+// GetSupportedAccessModes is answered by a new driver of the configured type, like GetProtocol.
+func (d *MultiSVMStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	driver, err := newSingleSVMStorageDriver(d.driverName)
+	if err != nil {
+		return drivers.AccessModes{}
+	}
+	return driver.GetSupportedAccessModes()
+}
+
+// GetVolumeExpansion is answered by a new driver of the configured type, like GetProtocol.
+func (d *MultiSVMStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	driver, err := newSingleSVMStorageDriver(d.driverName)
+	if err != nil {
+		return drivers.VolumeExpansion{}
+	}
+	return driver.GetVolumeExpansion()
+}
+
+func (d *MultiSVMStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	return driver.Publish(ctx, volConfig, publishInfo)
+}
+
+// UpdatePublication passes the request to the SVM's driver, if that driver supports it.
+func (d *MultiSVMStorageDriver) UpdatePublication(
+	ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) (bool, error) {
+
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return false, err
+	}
+	if updater, ok := driver.(storage.PublicationUpdater); ok {
+		return updater.UpdatePublication(ctx, volConfig, publishInfo)
+	}
+	return false, nil
+}
+
+// GetCloneSplitStatus asks each SVM's driver for the volume's split status.  Splits are tracked in
+// memory, so this avoids looking up which SVM holds the volume.
+func (d *MultiSVMStorageDriver) GetCloneSplitStatus(name string) *storage.CloneSplitStatus {
+	for _, driver := range d.drivers {
+		if reporter, ok := driver.(storage.CloneSplitReporter); ok {
+			if status := reporter.GetCloneSplitStatus(name); status != nil {
+				return status
+			}
+		}
+	}
+	return nil
+}
+
+// SetCloneSplitHandler registers the handler with each SVM's driver that supports it.
+func (d *MultiSVMStorageDriver) SetCloneSplitHandler(handler storage.CloneSplitHandler) {
+	for _, driver := range d.drivers {
+		if reporter, ok := driver.(storage.CloneSplitReporter); ok {
+			reporter.SetCloneSplitHandler(handler)
+		}
+	}
+}
+
+// ResumeCloneSplit passes the split of a cloned volume to the driver of the SVM holding the volume.
+func (d *MultiSVMStorageDriver) ResumeCloneSplit(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	svm, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	reporter, ok := driver.(storage.CloneSplitReporter)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("SVM %s does not split clones", svm))
+	}
+	return reporter.ResumeCloneSplit(ctx, volConfig)
+}
+
+// SetSnapshotDeleteHandler registers the handler with each SVM's driver that supports it.
+func (d *MultiSVMStorageDriver) SetSnapshotDeleteHandler(handler storage.SnapshotDeleteHandler) {
+	for _, driver := range d.drivers {
+		if deleter, ok := driver.(storage.DeferredSnapshotDeleter); ok {
+			deleter.SetSnapshotDeleteHandler(handler)
+		}
+	}
+}
+
+// MoveVolume passes the request to the driver of the SVM holding the volume, if it supports moves.
+func (d *MultiSVMStorageDriver) MoveVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, destinationPool string,
+) error {
+	svm, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	mover, ok := driver.(storage.VolumeMover)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("SVM %s does not support moving volumes", svm))
+	}
+	return mover.MoveVolume(ctx, volConfig, destinationPool)
+}
+
+// GetVolumeMoveStatus asks each SVM's driver for the volume's move status.  Moves are tracked in
+// memory, so this avoids looking up which SVM holds the volume.
+func (d *MultiSVMStorageDriver) GetVolumeMoveStatus(name string) *storage.VolumeMoveStatus {
+	for _, driver := range d.drivers {
+		if mover, ok := driver.(storage.VolumeMover); ok {
+			if status := mover.GetVolumeMoveStatus(name); status != nil {
+				return status
+			}
+		}
+	}
+	return nil
+}
+
+// SetVolumeMoveHandler registers the handler with each SVM's driver that supports it.
+func (d *MultiSVMStorageDriver) SetVolumeMoveHandler(handler storage.VolumeMoveHandler) {
+	for _, driver := range d.drivers {
+		if mover, ok := driver.(storage.VolumeMover); ok {
+			mover.SetVolumeMoveHandler(handler)
+		}
+	}
+}
+
+// ResumeVolumeMove passes the move of a volume to the driver of the SVM holding the volume.
+func (d *MultiSVMStorageDriver) ResumeVolumeMove(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	svm, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	mover, ok := driver.(storage.VolumeMover)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("SVM %s does not support moving volumes", svm))
+	}
+	return mover.ResumeVolumeMove(ctx, volConfig)
+}
+
+// ResumeAsyncJob passes the job creating a volume to the driver of the SVM holding the volume, if it
+// creates volumes using jobs.
+func (d *MultiSVMStorageDriver) ResumeAsyncJob(volConfig *storage.VolumeConfig) {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		log.WithField("volume", volConfig.InternalName).WithError(err).Warning("Could not resume ONTAP job.")
+		return
+	}
+	if reporter, ok := driver.(storage.AsyncJobReporter); ok {
+		reporter.ResumeAsyncJob(volConfig)
+	}
+}
+
+// ListStorageJobs returns the ONTAP jobs still in progress on every SVM.
+func (d *MultiSVMStorageDriver) ListStorageJobs() []*storage.StorageJob {
+	jobs := make([]*storage.StorageJob, 0)
+	for _, svm := range d.svms {
+		if lister, ok := d.drivers[svm].(storage.StorageJobLister); ok {
+			jobs = append(jobs, lister.ListStorageJobs()...)
+		}
+	}
+	return jobs
+}
+
+// RefreshPools rediscovers the storage pools of every SVM.  The namespaced pools are rebuilt when the
+// backend next reads them with GetStorageBackendSpecs.
+func (d *MultiSVMStorageDriver) RefreshPools(ctx context.Context) error {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, svm := range d.svms {
+		refresher, ok := d.drivers[svm].(storage.PoolRefresher)
+		if !ok {
+			return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot refresh its storage pools",
+				d.driverName))
+		}
+		if err := refresher.RefreshPools(ctx); err != nil {
+			return fmt.Errorf("could not refresh storage pools for SVM %s: %v", svm, err)
+		}
+	}
+
+	d.poolSVMs = make(map[string]string)
+	d.childPools = make(map[string]*storage.Pool)
+	return nil
+}
+
+// ResumeVolumeCreate passes the check of a volume left behind by an interrupted create to the driver of the
+// SVM holding the volume.
+func (d *MultiSVMStorageDriver) ResumeVolumeCreate(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return err
+	}
+	resumer, ok := driver.(storage.VolumeCreateResumer)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot resume volume creation", d.driverName))
+	}
+	return resumer.ResumeVolumeCreate(ctx, volConfig)
+}
+
+// WatchesPoolChanges returns true only if every SVM's driver watches for changes to its pools, as the
+// pools of the others must still be rediscovered periodically.
+func (d *MultiSVMStorageDriver) WatchesPoolChanges() bool {
+	for _, driver := range d.drivers {
+		if reporter, ok := driver.(storage.PoolChangeReporter); !ok || !reporter.WatchesPoolChanges() {
+			return false
+		}
+	}
+	return len(d.drivers) > 0
+}
+
+// SetPoolChangeHandler registers the handler with each SVM's driver that supports it.
+func (d *MultiSVMStorageDriver) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	for _, driver := range d.drivers {
+		if reporter, ok := driver.(storage.PoolChangeReporter); ok {
+			reporter.SetPoolChangeHandler(handler)
+		}
+	}
+}
+
+// SetBackendUUID passes the UUID of the backend to the driver managing each SVM.
+func (d *MultiSVMStorageDriver) SetBackendUUID(backendUUID string) {
+	d.Config.BackendUUID = backendUUID
+	for _, driver := range d.drivers {
+		if setter, ok := driver.(storage.BackendUUIDSetter); ok {
+			setter.SetBackendUUID(backendUUID)
+		}
+	}
+}
+
+// SetAPITraceEnabled turns tracing of the API calls to every SVM on or off.
+func (d *MultiSVMStorageDriver) SetAPITraceEnabled(enabled bool) {
+	for _, driver := range d.drivers {
+		if tracer, ok := driver.(storage.APITracer); ok {
+			tracer.SetAPITraceEnabled(enabled)
+		}
+	}
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and those managing each SVM.
+func (d *MultiSVMStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	for _, driver := range d.drivers {
+		if configurer, ok := driver.(storage.LogConfigurer); ok {
+			configurer.SetDebugTraceFlags(flags)
+		}
+	}
+}
+
+// SetAPILogLevel sets the level at which messages about the API calls to every SVM are logged.
+func (d *MultiSVMStorageDriver) SetAPILogLevel(level string) error {
+	for _, driver := range d.drivers {
+		if configurer, ok := driver.(storage.LogConfigurer); ok {
+			if err := configurer.SetAPILogLevel(level); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetAPILogLevel returns the level at which messages about the API calls to the SVMs are logged, if set.
+func (d *MultiSVMStorageDriver) GetAPILogLevel() string {
+	for _, svm := range d.svms {
+		if configurer, ok := d.drivers[svm].(storage.LogConfigurer); ok {
+			return configurer.GetAPILogLevel()
+		}
+	}
+	return ""
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on every SVM.
+func (d *MultiSVMStorageDriver) GetFeatures() map[string]bool {
+	features := make(map[string]bool)
+	for _, svm := range d.svms {
+		reporter, ok := d.drivers[svm].(storage.FeatureReporter)
+		if !ok {
+			continue
+		}
+		for name, available := range reporter.GetFeatures() {
+			if previous, seen := features[name]; seen {
+				available = available && previous
+			}
+			features[name] = available
+		}
+	}
+	return features
+}
+
+func (d *MultiSVMStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+	_, driver, err := d.driverForVolume(snapConfig.VolumeInternalName)
+	if err != nil {
+		return nil, err
+	}
+	return driver.GetSnapshot(ctx, snapConfig)
+}
+
+func (d *MultiSVMStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return nil, err
+	}
+	return driver.GetSnapshots(ctx, volConfig)
+}
+
+func (d *MultiSVMStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+	_, driver, err := d.driverForVolume(snapConfig.VolumeInternalName)
+	if err != nil {
+		return nil, err
+	}
+	return driver.CreateSnapshot(ctx, snapConfig)
+}
+
+// CreateGroupSnapshot delegates to the driver of the SVM holding the volumes.  A consistency group
+// can't span SVMs, so all of the volumes must be on the same one.
+func (d *MultiSVMStorageDriver) CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*storage.SnapshotConfig,
+) ([]*storage.Snapshot, error) {
+
+	var groupSVM string
+	var groupDriver storage.Driver
+	for _, snapConfig := range snapConfigs {
+		svm, driver, err := d.driverForVolume(snapConfig.VolumeInternalName)
+		if err != nil {
+			return nil, err
+		}
+		if groupDriver == nil {
+			groupSVM, groupDriver = svm, driver
+		} else if svm != groupSVM {
+			return nil, fmt.Errorf("volumes in a group snapshot must be on the same SVM, found %s and %s",
+				groupSVM, svm)
+		}
+	}
+
+	snapshotter, ok := groupDriver.(storage.GroupSnapshotter)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("SVM %s does not support group snapshots", groupSVM))
+	}
+	return snapshotter.CreateGroupSnapshot(ctx, snapConfigs)
+}
+
+func (d *MultiSVMStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+	_, driver, err := d.driverForVolume(snapConfig.VolumeInternalName)
+	if err != nil {
+		return err
+	}
+	return driver.RestoreSnapshot(ctx, snapConfig)
+}
+
+func (d *MultiSVMStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+	_, driver, err := d.driverForVolume(snapConfig.VolumeInternalName)
+	if err != nil {
+		return err
+	}
+	return driver.DeleteSnapshot(ctx, snapConfig)
+}
+
+func (d *MultiSVMStorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
+	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
+	b.OntapConfig = &d.Config
+}
+
+func (d *MultiSVMStorageDriver) GetExternalConfig() interface{} {
+	return getExternalConfig(d.Config)
+}
+
+func (d *MultiSVMStorageDriver) GetVolumeExternal(name string) (*storage.VolumeExternal, error) {
+
+	svm, driver, err := d.driverForVolume(name)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := driver.GetVolumeExternal(name)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Pool != "" {
+		volume.Pool = svmPoolName(svm, volume.Pool)
+	}
+	return volume, nil
+}
+
+// GetVolumeExternalWrappers reads the volumes from every SVM and writes them to the supplied
+// channel, closing the channel when finished.
+func (d *MultiSVMStorageDriver) GetVolumeExternalWrappers(channel chan *storage.VolumeExternalWrapper) {
+
+	// Let the caller know we're done by closing the channel
+	defer close(channel)
+
+	for _, svm := range d.svms {
+
+		svmChannel := make(chan *storage.VolumeExternalWrapper)
+		go d.drivers[svm].GetVolumeExternalWrappers(svmChannel)
+
+		for wrapper := range svmChannel {
+			if wrapper.Volume != nil {
+				if wrapper.Volume.Pool != "" {
+					wrapper.Volume.Pool = svmPoolName(svm, wrapper.Volume.Pool)
+				}
+				d.setVolumeSVM(wrapper.Volume.Config.InternalName, svm)
+			}
+			channel <- wrapper
+		}
+	}
+}
+
+// GetUpdateType returns a bitmap populated with updates to the driver.  Removing an SVM from the
+// backend is not allowed, as its volumes would no longer be reachable.
+func (d *MultiSVMStorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bitmap {
+
+	bitmap := roaring.New()
+	dOrig, ok := driverOrig.(*MultiSVMStorageDriver)
+	if !ok {
+		bitmap.Add(storage.InvalidUpdate)
+		return bitmap
+	}
+
+	for svm, driverOrig := range dOrig.drivers {
+		driver, ok := d.drivers[svm]
+		if !ok {
+			log.WithField(utils.LogFieldSVM, svm).Error("SVM may not be removed from a backend.")
+			bitmap.Add(storage.InvalidUpdate)
+			continue
+		}
+		bitmap.Or(driver.GetUpdateType(driverOrig))
+	}
+
+	return bitmap
+}
+
+func (d *MultiSVMStorageDriver) ReconcileNodeAccess(nodes []*utils.Node, backendUUID string) error {
+
+	errs := make([]string, 0)
+	for _, svm := range d.svms {
+		if err := d.drivers[svm].ReconcileNodeAccess(nodes, backendUUID); err != nil {
+			errs = append(errs, fmt.Sprintf("SVM %s: %v", svm, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not reconcile node access; %v", errs)
+	}
+	return nil
+}
+
+// RevokeNodeAccess withdraws the node's access to the volumes of every SVM.  Drivers that can't revoke a
+// single node's access refuse, so that the backend reconciles the access of the remaining nodes instead.
+func (d *MultiSVMStorageDriver) RevokeNodeAccess(ctx context.Context, node *utils.Node) error {
+
+	errs := make([]string, 0)
+	for _, svm := range d.svms {
+		revoker, ok := d.drivers[svm].(storage.NodeAccessRevoker)
+		if !ok {
+			return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot revoke a single node's access",
+				d.driverName))
+		}
+		if err := revoker.RevokeNodeAccess(ctx, node); err != nil {
+			errs = append(errs, fmt.Sprintf("SVM %s: %v", svm, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not revoke access of node %s; %v", node.Name, errs)
+	}
+	return nil
+}
+
+// LogFields adds the backend's SVMs to the messages logged for its operations.
+func (d *MultiSVMStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: strings.Join(d.svms, ",")}
+}
+
+// CheckHealth returns an error if any of the SVMs can't be reached or isn't running.
+func (d *MultiSVMStorageDriver) CheckHealth(ctx context.Context) error {
+
+	errs := make([]string, 0)
+	for _, svm := range d.svms {
+		if checker, ok := d.drivers[svm].(storage.HealthChecker); ok {
+			if err := checker.CheckHealth(ctx); err != nil {
+				errs = append(errs, fmt.Sprintf("SVM %s: %v", svm, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// GetStorageLogs returns the recent log entries of every SVM, newest first.  The SVMs share a cluster, so
+// an event logged by the cluster is only returned once.
+func (d *MultiSVMStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+
+	entries := make([]*storage.StorageLogEntry, 0)
+	seen := make(map[storage.StorageLogEntry]bool)
+	for _, svm := range d.svms {
+		reader, ok := d.drivers[svm].(storage.StorageLogReader)
+		if !ok {
+			return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver cannot read storage logs", d.driverName))
+		}
+		svmEntries, err := reader.GetStorageLogs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not read storage logs for SVM %s: %v", svm, err)
+		}
+		for _, entry := range svmEntries {
+			if !seen[*entry] {
+				seen[*entry] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	// Times are in RFC3339 format, so they sort as strings
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time > entries[j].Time
+	})
+	return entries, nil
+}
+
+// GetVolumeUsage returns the usage of a volume read by the driver of the SVM holding it.
+func (d *MultiSVMStorageDriver) GetVolumeUsage(internalName string) *storage.VolumeUsage {
+	for _, svm := range d.svms {
+		if reporter, ok := d.drivers[svm].(storage.VolumeUsageReporter); ok {
+			if usage := reporter.GetVolumeUsage(internalName); usage != nil {
+				return usage
+			}
+		}
+	}
+	return nil
+}
+
+// GetBucketReport returns the buckets of every SVM, with each bucket's pool prefixed by its SVM.
+func (d *MultiSVMStorageDriver) GetBucketReport(ctx context.Context) (*storage.BucketReport, error) {
+
+	report := &storage.BucketReport{Buckets: make([]*storage.VolumeBucket, 0)}
+	for _, svm := range d.svms {
+		reporter, ok := d.drivers[svm].(storage.BucketReporter)
+		if !ok {
+			return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver does not place volumes in buckets",
+				d.driverName))
+		}
+		svmReport, err := reporter.GetBucketReport(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not get buckets for SVM %s: %v", svm, err)
+		}
+		report.VolumeLimit = svmReport.VolumeLimit
+		for _, bucket := range svmReport.Buckets {
+			bucket.Pool = svmPoolName(svm, bucket.Pool)
+			report.Buckets = append(report.Buckets, bucket)
+		}
+	}
+	return report, nil
+}
+
+// ListVolumes returns one page of the volumes of one SVM at a time.  The continuation token names the
+// SVM being listed, followed by its driver's own token.
+func (d *MultiSVMStorageDriver) ListVolumes(
+	ctx context.Context, limit int, continueToken string,
+) (*storage.VolumePage, error) {
+
+	index, svmToken := 0, ""
+	if continueToken != "" {
+		parts := strings.SplitN(continueToken, "/", 2)
+		index = sort.SearchStrings(d.svms, parts[0])
+		if len(parts) != 2 || index == len(d.svms) || d.svms[index] != parts[0] {
+			return nil, fmt.Errorf("invalid continuation token %s", continueToken)
+		}
+		svmToken = parts[1]
+	}
+
+	svm := d.svms[index]
+	lister, ok := d.drivers[svm].(storage.VolumeLister)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver cannot list volumes", d.driverName))
+	}
+	page, err := lister.ListVolumes(ctx, limit, svmToken)
+	if err != nil {
+		return nil, fmt.Errorf("could not list volumes for SVM %s: %v", svm, err)
+	}
+
+	for _, volume := range page.Volumes {
+		if volume.Pool != "" {
+			volume.Pool = svmPoolName(svm, volume.Pool)
+		}
+	}
+	if page.Continue != "" {
+		page.Continue = svm + "/" + page.Continue
+	} else if index+1 < len(d.svms) {
+		page.Continue = d.svms[index+1] + "/"
+	}
+	return page, nil
+}
+
+// ListImportCandidates returns the volumes on every SVM that could be imported, with each volume's pool
+// prefixed by its SVM.
+func (d *MultiSVMStorageDriver) ListImportCandidates(ctx context.Context) ([]*storage.ImportCandidate, error) {
+
+	candidates := make([]*storage.ImportCandidate, 0)
+	for _, svm := range d.svms {
+		lister, ok := d.drivers[svm].(storage.ImportCandidateLister)
+		if !ok {
+			return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver does not support import discovery",
+				d.driverName))
+		}
+		svmCandidates, err := lister.ListImportCandidates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not list import candidates for SVM %s: %v", svm, err)
+		}
+		for _, candidate := range svmCandidates {
+			if candidate.Pool != "" {
+				candidate.Pool = svmPoolName(svm, candidate.Pool)
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// ListRecoverableVolumes returns the deleted volumes in the recovery queue of every SVM.
+func (d *MultiSVMStorageDriver) ListRecoverableVolumes(ctx context.Context) ([]*storage.RecoverableVolume, error) {
+
+	volumes := make([]*storage.RecoverableVolume, 0)
+	for _, svm := range d.svms {
+		recoverer, ok := d.drivers[svm].(storage.VolumeRecoverer)
+		if !ok {
+			return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver cannot recover volumes", d.driverName))
+		}
+		svmVolumes, err := recoverer.ListRecoverableVolumes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not list recoverable volumes for SVM %s: %v", svm, err)
+		}
+		volumes = append(volumes, svmVolumes...)
+	}
+	return volumes, nil
+}
+
+// RecoverVolume restores a deleted volume from the recovery queue of the SVM holding it.  A volume whose
+// name is queued on several SVMs can't be recovered by name alone.
+func (d *MultiSVMStorageDriver) RecoverVolume(ctx context.Context, internalName string) error {
+
+	found := make([]string, 0)
+	for _, svm := range d.svms {
+		recoverer, ok := d.drivers[svm].(storage.VolumeRecoverer)
+		if !ok {
+			return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot recover volumes", d.driverName))
+		}
+		volumes, err := recoverer.ListRecoverableVolumes(ctx)
+		if err != nil {
+			return fmt.Errorf("could not list recoverable volumes for SVM %s: %v", svm, err)
+		}
+		for _, volume := range volumes {
+			if volume.InternalName == internalName {
+				found = append(found, svm)
+				break
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found in the recovery queue of any SVM",
+			internalName))
+	case 1:
+		if err := d.drivers[found[0]].(storage.VolumeRecoverer).RecoverVolume(ctx, internalName); err != nil {
+			return err
+		}
+		d.setVolumeSVM(internalName, found[0])
+		return nil
+	default:
+		return fmt.Errorf("volume %s is in the recovery queues of SVMs %v", internalName, found)
+	}
+}
+
+// orphanKey identifies an orphaned resource within the backend.  The backend's name is left out, as the
+// backend sets it after the resource is listed.
+func orphanKey(resource *storage.OrphanedResource) string {
+	return strings.Join([]string{resource.Type, resource.Parent, resource.Name}, "/")
+}
+
+// ListOrphanedResources returns the orphaned resources of every SVM, remembering the SVM of each so that
+// it may be deleted.
+func (d *MultiSVMStorageDriver) ListOrphanedResources(
+	ctx context.Context, backendUUID string, volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig,
+) ([]*storage.OrphanedResource, error) {
+
+	resources := make([]*storage.OrphanedResource, 0)
+	orphanSVMs := make(map[string]string)
+	for _, svm := range d.svms {
+		collector, ok := d.drivers[svm].(storage.OrphanCollector)
+		if !ok {
+			return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver cannot find orphaned resources",
+				d.driverName))
+		}
+		svmResources, err := collector.ListOrphanedResources(ctx, backendUUID, volumes, snapshots)
+		if err != nil {
+			return nil, fmt.Errorf("could not list orphaned resources for SVM %s: %v", svm, err)
+		}
+		for _, resource := range svmResources {
+			key := orphanKey(resource)
+			if _, ok := orphanSVMs[key]; ok {
+				// Found on several SVMs, so it can't be deleted by name alone
+				orphanSVMs[key] = ""
+			} else {
+				orphanSVMs[key] = svm
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	d.mutex.Lock()
+	d.orphanSVMs = orphanSVMs
+	d.mutex.Unlock()
+
+	return resources, nil
+}
+
+// DeleteOrphanedResource deletes an orphaned resource from the SVM it was found on when last listed.
+func (d *MultiSVMStorageDriver) DeleteOrphanedResource(ctx context.Context, resource *storage.OrphanedResource) error {
+
+	d.mutex.RLock()
+	svm, ok := d.orphanSVMs[orphanKey(resource)]
+	d.mutex.RUnlock()
+	if !ok {
+		return utils.NotFoundError(fmt.Sprintf("%s %s was not found on any SVM", resource.Type, resource.Name))
+	} else if svm == "" {
+		return fmt.Errorf("%s %s was found on several SVMs", resource.Type, resource.Name)
+	}
+
+	collector, ok := d.drivers[svm].(storage.OrphanCollector)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot delete orphaned resources", d.driverName))
+	}
+	return collector.DeleteOrphanedResource(ctx, resource)
+}
+
+// GetOrphanPolicy returns the orphan policy of the backend's config, which every SVM shares.
+func (d *MultiSVMStorageDriver) GetOrphanPolicy() *storage.OrphanPolicy {
+	if collector, ok := d.primaryDriver().(storage.OrphanCollector); ok {
+		return collector.GetOrphanPolicy()
+	}
+	return nil
+}
+
+// GetCopySource describes a volume, or one of its snapshots, on the SVM holding it as the source of a
+// copy by another backend.
+func (d *MultiSVMStorageDriver) GetCopySource(
+	ctx context.Context, volConfig *storage.VolumeConfig, snapshotName string,
+) (*storage.VolumeCopySource, error) {
+
+	_, driver, err := d.driverForVolumeConfig(volConfig)
+	if err != nil {
+		return nil, err
+	}
+	copier, ok := driver.(storage.VolumeCopier)
+	if !ok {
+		return nil, utils.UnsupportedError(fmt.Sprintf("the %s driver cannot copy volumes", d.driverName))
+	}
+	return copier.GetCopySource(ctx, volConfig, snapshotName)
+}
+
+// CreateCopy creates a copy of a volume on another backend on the SVM that owns the requested storage pool.
+func (d *MultiSVMStorageDriver) CreateCopy(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, source *storage.VolumeCopySource,
+) error {
+
+	d.mutex.RLock()
+	svm, ok := d.poolSVMs[storagePool.Name]
+	childPool := d.childPools[storagePool.Name]
+	d.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("could not find pool %s", storagePool.Name)
+	}
+
+	copier, ok := d.drivers[svm].(storage.VolumeCopier)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot copy volumes", d.driverName))
+	}
+
+	// The SVM is recorded first, as the volume exists while the copy runs
+	d.recordVolumeSVM(volConfig, svm)
+	return copier.CreateCopy(ctx, volConfig, childPool, source)
+}
+
+// ReleaseCopySource passes the release of a copied volume to the driver of the SVM it was copied from.
+func (d *MultiSVMStorageDriver) ReleaseCopySource(
+	ctx context.Context, source, destination *storage.VolumeCopySource,
+) error {
+
+	driver, ok := d.drivers[source.SVM]
+	if !ok {
+		return fmt.Errorf("SVM %s is not part of the backend", source.SVM)
+	}
+	copier, ok := driver.(storage.VolumeCopier)
+	if !ok {
+		return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot copy volumes", d.driverName))
+	}
+	return copier.ReleaseCopySource(ctx, source, destination)
+}
@@ -0,0 +1,286 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+func TestUsesMultipleSVMs(t *testing.T) {
+
+	tests := []struct {
+		configJSON string
+		expected   bool
+	}{
+		{`{"svm": "svm1"}`, false},
+		{`{"svms": []}`, false},
+		{`{"svms": ["svm1", "svm2"]}`, true},
+		{`{"svmSelector": "^tenant-"}`, true},
+		{`not json`, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, UsesMultipleSVMs(test.configJSON), test.configJSON)
+	}
+}
+
+func TestResolveSVMsFromList(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.SVMs = []string{"svm2", "svm1", "", "svm2"}
+
+	svms, err := resolveSVMs(config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"svm1", "svm2"}, svms)
+
+	config.SVMs = []string{}
+	_, err = resolveSVMs(config)
+	assert.Error(t, err)
+
+	config.SVMSelector = "["
+	_, err = resolveSVMs(config)
+	assert.Error(t, err)
+}
+
+func TestMultiSVMNamespaceError(t *testing.T) {
+
+	err := drivers.NewBackendIneligibleError("vol1", []error{errors.New("no space")}, []string{"aggr1"})
+	err = namespaceError(err, "svm1", "vol1")
+
+	assert.True(t, drivers.IsBackendIneligibleError(err))
+	_, poolNames := drivers.GetIneligiblePhysicalPoolNames(err)
+	assert.Equal(t, []string{"svm1_aggr1"}, poolNames)
+
+	otherErr := errors.New("failed")
+	assert.Equal(t, otherErr, namespaceError(otherErr, "svm1", "vol1"))
+}
+
+// svmDriver stands in for the driver of one SVM of a multi-SVM backend.
+type svmDriver struct {
+	storage.Driver
+	volumes     map[string]bool
+	recoverable []string
+	recovered   string
+	pages       map[string]*storage.VolumePage
+	orphans     []*storage.OrphanedResource
+	deleted     *storage.OrphanedResource
+}
+
+func (d *svmDriver) Get(name string) error {
+	if !d.volumes[name] {
+		return utils.NotFoundError("not found")
+	}
+	return nil
+}
+
+func (d *svmDriver) ListRecoverableVolumes(_ context.Context) ([]*storage.RecoverableVolume, error) {
+	volumes := make([]*storage.RecoverableVolume, 0)
+	for _, name := range d.recoverable {
+		volumes = append(volumes, &storage.RecoverableVolume{InternalName: name})
+	}
+	return volumes, nil
+}
+
+func (d *svmDriver) RecoverVolume(_ context.Context, internalName string) error {
+	d.recovered = internalName
+	return nil
+}
+
+func (d *svmDriver) ListVolumes(_ context.Context, _ int, continueToken string) (*storage.VolumePage, error) {
+	return d.pages[continueToken], nil
+}
+
+func (d *svmDriver) ListOrphanedResources(
+	_ context.Context, _ string, _ []*storage.VolumeConfig, _ []*storage.SnapshotConfig,
+) ([]*storage.OrphanedResource, error) {
+	return d.orphans, nil
+}
+
+func (d *svmDriver) DeleteOrphanedResource(_ context.Context, resource *storage.OrphanedResource) error {
+	d.deleted = resource
+	return nil
+}
+
+func (d *svmDriver) GetOrphanPolicy() *storage.OrphanPolicy {
+	return nil
+}
+
+func newTestMultiSVMDriver(svm1, svm2 *svmDriver) *MultiSVMStorageDriver {
+	d := NewMultiSVMStorageDriver(drivers.OntapNASStorageDriverName)
+	d.svms = []string{"svm1", "svm2"}
+	d.drivers["svm1"] = svm1
+	d.drivers["svm2"] = svm2
+	return d
+}
+
+func TestMultiSVMDriverForVolumeConfig(t *testing.T) {
+
+	tests := map[string]struct {
+		volConfig   *storage.VolumeConfig
+		expectedSVM string
+		expectErr   bool
+	}{
+		"Recorded SVM": {
+			volConfig:   &storage.VolumeConfig{InternalName: "vol1", SVM: "svm2"},
+			expectedSVM: "svm2",
+		},
+		"Recorded SVM not in backend": {
+			volConfig: &storage.VolumeConfig{InternalName: "vol1", SVM: "svm3"},
+			expectErr: true,
+		},
+		"Unrecorded SVM, name on one SVM": {
+			volConfig:   &storage.VolumeConfig{InternalName: "vol2"},
+			expectedSVM: "svm2",
+		},
+		"Unrecorded SVM, name on several SVMs": {
+			volConfig: &storage.VolumeConfig{InternalName: "vol1"},
+			expectErr: true,
+		},
+		"Unrecorded SVM, name on no SVM": {
+			volConfig: &storage.VolumeConfig{InternalName: "vol3"},
+			expectErr: true,
+		},
+	}
+	for name, test := range tests {
+		d := newTestMultiSVMDriver(
+			&svmDriver{volumes: map[string]bool{"vol1": true}},
+			&svmDriver{volumes: map[string]bool{"vol1": true, "vol2": true}},
+		)
+
+		svm, driver, err := d.driverForVolumeConfig(test.volConfig)
+		if test.expectErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, test.expectedSVM, svm, name)
+		assert.Equal(t, d.drivers[test.expectedSVM], driver, name)
+	}
+}
+
+func TestMultiSVMRecoverVolume(t *testing.T) {
+
+	tests := map[string]struct {
+		internalName string
+		expectedSVM  string
+		expectErr    bool
+	}{
+		"Queued on one SVM": {
+			internalName: "vol2",
+			expectedSVM:  "svm2",
+		},
+		"Queued on several SVMs": {
+			internalName: "vol1",
+			expectErr:    true,
+		},
+		"Not queued": {
+			internalName: "vol3",
+			expectErr:    true,
+		},
+	}
+	for name, test := range tests {
+		svm1 := &svmDriver{recoverable: []string{"vol1"}}
+		svm2 := &svmDriver{recoverable: []string{"vol1", "vol2"}}
+		d := newTestMultiSVMDriver(svm1, svm2)
+
+		err := d.RecoverVolume(context.Background(), test.internalName)
+		if test.expectErr {
+			assert.Error(t, err, name)
+			assert.Empty(t, svm1.recovered+svm2.recovered, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, test.internalName, d.drivers[test.expectedSVM].(*svmDriver).recovered, name)
+	}
+}
+
+func TestMultiSVMListVolumes(t *testing.T) {
+
+	svm1 := &svmDriver{pages: map[string]*storage.VolumePage{
+		"":     {Volumes: []*storage.BackendVolume{{InternalName: "vol1", Pool: "aggr1"}}, Continue: "vol1"},
+		"vol1": {Volumes: []*storage.BackendVolume{{InternalName: "vol2", Pool: "aggr1"}}},
+	}}
+	svm2 := &svmDriver{pages: map[string]*storage.VolumePage{
+		"": {Volumes: []*storage.BackendVolume{{InternalName: "vol3", Pool: "aggr2"}}},
+	}}
+	d := newTestMultiSVMDriver(svm1, svm2)
+
+	tests := map[string]struct {
+		token            string
+		expectedVolume   string
+		expectedPool     string
+		expectedContinue string
+		expectErr        bool
+	}{
+		"First page":           {"", "vol1", "svm1_aggr1", "svm1/vol1", false},
+		"Last page of an SVM":  {"svm1/vol1", "vol2", "svm1_aggr1", "svm2/", false},
+		"Last page":            {"svm2/", "vol3", "svm2_aggr2", "", false},
+		"Unknown SVM in token": {"svm3/", "", "", "", true},
+		"Malformed token":      {"svm1", "", "", "", true},
+	}
+	for name, test := range tests {
+		page, err := d.ListVolumes(context.Background(), 1, test.token)
+		if test.expectErr {
+			assert.Error(t, err, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Equal(t, test.expectedVolume, page.Volumes[0].InternalName, name)
+		assert.Equal(t, test.expectedPool, page.Volumes[0].Pool, name)
+		assert.Equal(t, test.expectedContinue, page.Continue, name)
+	}
+}
+
+func TestMultiSVMDeleteOrphanedResource(t *testing.T) {
+
+	shared := func() *storage.OrphanedResource {
+		return &storage.OrphanedResource{Type: storage.OrphanedResourceVolume, Name: "trident_shared"}
+	}
+	svm1 := &svmDriver{orphans: []*storage.OrphanedResource{shared()}}
+	svm2 := &svmDriver{orphans: []*storage.OrphanedResource{
+		shared(), {Type: storage.OrphanedResourceVolume, Name: "trident_vol2"}}}
+	d := newTestMultiSVMDriver(svm1, svm2)
+
+	resources, err := d.ListOrphanedResources(context.Background(), "uuid", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+
+	tests := map[string]struct {
+		resource  *storage.OrphanedResource
+		expectErr bool
+	}{
+		"Found on one SVM": {
+			resource: &storage.OrphanedResource{Backend: "backend", Type: storage.OrphanedResourceVolume,
+				Name: "trident_vol2"},
+		},
+		"Found on several SVMs": {
+			resource:  shared(),
+			expectErr: true,
+		},
+		"Not found": {
+			resource:  &storage.OrphanedResource{Type: storage.OrphanedResourceVolume, Name: "trident_vol3"},
+			expectErr: true,
+		},
+	}
+	for name, test := range tests {
+		svm1.deleted, svm2.deleted = nil, nil
+
+		err := d.DeleteOrphanedResource(context.Background(), test.resource)
+		if test.expectErr {
+			assert.Error(t, err, name)
+			assert.Nil(t, svm1.deleted, name)
+			assert.Nil(t, svm2.deleted, name)
+			continue
+		}
+		assert.NoError(t, err, name)
+		assert.Nil(t, svm1.deleted, name)
+		assert.Equal(t, test.resource, svm2.deleted, name)
+	}
+}
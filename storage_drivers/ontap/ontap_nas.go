@@ -3,6 +3,7 @@
 package ontap
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -26,8 +27,20 @@ type NASStorageDriver struct {
 	API         *api.Client
 	Telemetry   *Telemetry
 
+	housekeeping   *HousekeepingScheduler
+	cloneSplitter  *CloneSplitter
+	cloneSplits    *CloneSplitTracker
+	cloneSnapshots *CloneSnapshotReaper
+	volumeMoves    *VolumeMoveTracker
+	volumeCopies   *VolumeCopyTracker
+	recoveryQueue  *RecoveryQueue
+	orphans        *OrphanFinder
+
 	physicalPools map[string]*storage.Pool
 	virtualPools  map[string]*storage.Pool
+	poolCapacity  *PoolCapacityMonitor
+	eventWatcher  *EventWatcher
+	dataLIF       nasDataLIF
 }
 
 func (d *NASStorageDriver) GetConfig() *drivers.OntapStorageDriverConfig {
@@ -38,6 +51,71 @@ func (d *NASStorageDriver) GetAPI() *api.Client {
 	return d.API
 }
 
+// SetAPITraceEnabled turns tracing of the driver's API calls on or off.
+func (d *NASStorageDriver) SetAPITraceEnabled(enabled bool) {
+	d.API.SetAPITraceEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and its API client.
+func (d *NASStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	d.API.SetDebugTraceFlags(flags)
+}
+
+// SetAPILogLevel sets the level at which messages about the driver's API calls are logged.
+func (d *NASStorageDriver) SetAPILogLevel(level string) error {
+	return d.API.SetLogLevel(level)
+}
+
+// GetAPILogLevel returns the level at which messages about the driver's API calls are logged, if set.
+func (d *NASStorageDriver) GetAPILogLevel() string {
+	return d.API.LogLevel()
+}
+
+// LogFields returns the fields the driver adds to the messages logged for operations on its backend.
+func (d *NASStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+// ListStorageJobs returns the clone splits, volume moves, and volume copies still in progress.
+func (d *NASStorageDriver) ListStorageJobs() []*storage.StorageJob {
+	jobs := append(d.cloneSplits.Jobs(), d.volumeMoves.Jobs()...)
+	return append(jobs, d.volumeCopies.Jobs()...)
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on the driver's SVM.
+func (d *NASStorageDriver) GetFeatures() map[string]bool {
+	return d.API.Features()
+}
+
+// GetStorageLogs returns the recent jobs of the driver's SVM and the recent EMS events of its cluster.
+func (d *NASStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+	return getStorageLogs(d.API.WithContext(ctx))
+}
+
+// CheckHealth returns an error if the driver's SVM can't be reached or isn't running.
+func (d *NASStorageDriver) CheckHealth(ctx context.Context) error {
+	return checkSVMHealth(d.API.WithContext(ctx))
+}
+
+// resolveDataLIFs chooses the data LIF to use after the driver fails over to another SVM.
+func (d *NASStorageDriver) resolveDataLIFs() error {
+	return resolveNASDataLIF(&d.Config, d.API, &d.dataLIF)
+}
+
+func (d *NASStorageDriver) GetCloneSplitTracker() *CloneSplitTracker {
+	return d.cloneSplits
+}
+
+func (d *NASStorageDriver) GetCloneSnapshotReaper() *CloneSnapshotReaper {
+	return d.cloneSnapshots
+}
+
+// GetAsyncJobTracker returns nil, since FlexVols are cloned synchronously.
+func (d *NASStorageDriver) GetAsyncJobTracker() *AsyncJobTracker {
+	return nil
+}
+
 func (d *NASStorageDriver) GetTelemetry() *Telemetry {
 	d.Telemetry.Telemetry = tridentconfig.OrchestratorTelemetry
 	return d.Telemetry
@@ -58,6 +136,11 @@ func (d *NASStorageDriver) backendName() string {
 	}
 }
 
+// SetBackendUUID records the UUID of the driver's backend, with which the driver tags the FlexVols it creates.
+func (d *NASStorageDriver) SetBackendUUID(backendUUID string) {
+	d.Config.BackendUUID = backendUUID
+}
+
 // Initialize from the provided config
 func (d *NASStorageDriver) Initialize(
 	context tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
@@ -82,21 +165,64 @@ func (d *NASStorageDriver) Initialize(
 	}
 	d.Config = *config
 
+	// Create the snapshot policies the config asks for, or correct them if they have drifted
+	if err = reconcileSnapshotPolicies(d.API, &d.Config); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+
 	d.physicalPools, d.virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
 		d.backendName())
 	if err != nil {
 		return fmt.Errorf("could not configure storage pools: %v", err)
 	}
 
+	d.restrictFlexcachePools()
+
 	// Validate the none, true/false values
 	err = d.validate()
 	if err != nil {
 		return fmt.Errorf("error validating %s driver: %v", d.Name(), err)
 	}
 
-	// Set up the autosupport heartbeat
+	// Set up the autosupport heartbeat and other periodic housekeeping
 	d.Telemetry = NewOntapTelemetry(d)
-	d.Telemetry.Start()
+	d.housekeeping = NewOntapHousekeepingScheduler(d)
+	d.cloneSplits = NewCloneSplitTracker(&d.Config, d.API)
+	d.cloneSplitter = NewCloneSplitter(&d.Config, d.API, d.cloneSplits)
+	if err = d.housekeeping.AddJob(d.cloneSplitter.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if err = d.housekeeping.AddJob(d.cloneSplits.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.cloneSnapshots = NewCloneSnapshotReaper(&d.Config, d.API)
+	d.cloneSplits.OnComplete(d.cloneSnapshots.splitComplete)
+	if err = d.housekeeping.AddJob(d.cloneSnapshots.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.volumeCopies = NewVolumeCopyTracker()
+	d.volumeMoves = NewVolumeMoveTracker(d.API)
+	if err = d.housekeeping.AddJob(d.volumeMoves.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.poolCapacity = NewPoolCapacityMonitor(&d.Config, d.API, d.physicalPools, d.virtualPools, false)
+	if err = d.housekeeping.AddJob(d.poolCapacity.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if eventWatchInterval, _ := getEventWatchInterval(&d.Config); eventWatchInterval > 0 {
+		d.eventWatcher = NewEventWatcher(d.API)
+		if err = d.housekeeping.AddJob(d.eventWatcher.HousekeepingJob(eventWatchInterval)); err != nil {
+			return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+		}
+	}
+	softDeleteRetention, _ := getSoftDeleteRetention(&d.Config)
+	d.recoveryQueue = NewRecoveryQueue(d.API, "nas", softDeleteRetention, d.releaseQueuedVolume,
+		d.restoreQueuedVolume, d.destroyQueuedVolume)
+	if err = d.housekeeping.AddJob(d.recoveryQueue.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.orphans = NewOrphanFinder(&d.Config, d.API, tridentconfig.File)
+	d.housekeeping.Start()
 
 	d.initialized = true
 	return nil
@@ -119,8 +245,8 @@ func (d *NASStorageDriver) Terminate(backendUUID string) {
 			log.Warn(err)
 		}
 	}
-	if d.Telemetry != nil {
-		d.Telemetry.Stop()
+	if d.housekeeping != nil {
+		d.housekeeping.Stop()
 	}
 	d.initialized = false
 }
@@ -143,14 +269,52 @@ func (d *NASStorageDriver) validate() error {
 		return fmt.Errorf("storage pool validation failed: %v", err)
 	}
 
+	if _, err := getSoftDeleteRetention(&d.Config); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	if _, err := getOrphanPolicy(&d.Config); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	for _, pool := range d.allPools() {
+		if pool.InternalAttributes[FlexcacheOrigin] != "" && !d.API.SupportsFeature(api.NetAppFlexCache) {
+			return fmt.Errorf("ONTAP version does not support FlexCache volumes")
+		}
+	}
+
 	return nil
 }
 
+// restrictFlexcachePools stops the pools that create FlexCache volumes from offering snapshots or
+// clones, which caches don't support.
+func (d *NASStorageDriver) restrictFlexcachePools() {
+	for _, pool := range d.allPools() {
+		if pool.InternalAttributes[FlexcacheOrigin] != "" {
+			pool.Attributes[sa.Snapshots] = sa.NewBoolOffer(false)
+			pool.Attributes[sa.Clones] = sa.NewBoolOffer(false)
+		}
+	}
+}
+
+// allPools returns the driver's physical and virtual storage pools.
+func (d *NASStorageDriver) allPools() []*storage.Pool {
+	pools := make([]*storage.Pool, 0, len(d.physicalPools)+len(d.virtualPools))
+	for _, pool := range d.physicalPools {
+		pools = append(pools, pool)
+	}
+	for _, pool := range d.virtualPools {
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
 // Create a volume with the specified options
 func (d *NASStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -160,17 +324,17 @@ func (d *NASStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
-	// If the volume already exists, bail out
-	volExists, err := d.API.VolumeExists(name)
+	// If the volume already exists, bail out, letting a volume left by an earlier attempt be completed
+	volExists, err := client.VolumeExists(name)
 	if err != nil {
 		return fmt.Errorf("error checking for existing volume: %v", err)
 	}
 	if volExists {
-		return drivers.NewVolumeExistsError(name)
+		return checkExistingFlexvol(ctx, client, name, volConfig.UUID, true, nil)
 	}
 
 	// Get candidate physical pools
@@ -179,6 +343,11 @@ func (d *NASStorageDriver) Create(
 		return err
 	}
 
+	// Determine mount options (volume config wins, followed by pool, followed by backend config)
+	if err = resolveNFSMountOptions(volConfig, storagePool); err != nil {
+		return err
+	}
+
 	// Determine volume size in bytes
 	requestedSize, err := utils.ConvertSizeToBytes(volConfig.Size)
 	if err != nil {
@@ -211,8 +380,9 @@ func (d *NASStorageDriver) Create(
 	securityStyle := utils.GetV(opts, "securityStyle", storagePool.InternalAttributes[SecurityStyle])
 	encryption := utils.GetV(opts, "encryption", storagePool.InternalAttributes[Encryption])
 	tieringPolicy := utils.GetV(opts, "tieringPolicy", storagePool.InternalAttributes[TieringPolicy])
+	tieringMinimumCoolingDays := storagePool.InternalAttributes[TieringMinimumCoolingDays]
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
@@ -231,15 +401,27 @@ func (d *NASStorageDriver) Create(
 		return fmt.Errorf("invalid value for snapshotReserve: %v", err)
 	}
 
+	// Grow the Flexvol so the snapshot reserve doesn't eat into the requested capacity
+	flexvolSizeBytes := calculateFlexvolSizeBytes(sizeBytes, snapshotReserveInt)
+	size = strconv.FormatUint(flexvolSizeBytes, 10)
+
 	if tieringPolicy == "" {
-		tieringPolicy = d.API.TieringPolicyValue()
+		tieringPolicy = client.TieringPolicyValue()
 	}
 
 	if d.Config.AutoExportPolicy {
-		exportPolicy = getExportPolicyName(storagePool.Backend.BackendUUID)
+		exportPolicy, err = getAutoExportPolicy(&d.Config, client, storagePool.Backend.BackendUUID, volConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if flexcacheOrigin := storagePool.InternalAttributes[FlexcacheOrigin]; flexcacheOrigin != "" {
+		return d.createFlexcache(ctx, volConfig, storagePool, physicalPools, flexcacheOrigin, sizeBytes,
+			spaceReserve, exportPolicy)
 	}
 
-	log.WithFields(log.Fields{
+	logc(ctx).WithFields(log.Fields{
 		"name":            name,
 		"size":            size,
 		"spaceReserve":    spaceReserve,
@@ -251,8 +433,12 @@ func (d *NASStorageDriver) Create(
 		"securityStyle":   securityStyle,
 		"encryption":      enableEncryption,
 		"tieringPolicy":   tieringPolicy,
+		"coolingDays":     tieringMinimumCoolingDays,
 	}).Debug("Creating Flexvol.")
 
+	// Tag the Flexvol with the request it is created for, so a retry can recognize it
+	comment := getFlexvolComment(&d.Config, volConfig)
+
 	createErrors := make([]error, 0)
 	physicalPoolNames := make([]string, 0)
 
@@ -260,70 +446,192 @@ func (d *NASStorageDriver) Create(
 		aggregate := physicalPool.Name
 		physicalPoolNames = append(physicalPoolNames, aggregate)
 
-		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, flexvolSizeBytes, d.Config, client); aggrLimitsErr != nil {
 			errMessage := fmt.Sprintf("ONTAP-NAS pool %s/%s; error: %v", storagePool.Name, aggregate, aggrLimitsErr)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
-		// Create the volume
-		volCreateResponse, err := d.API.VolumeCreate(
-			name, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
-			exportPolicy, securityStyle, tieringPolicy, enableEncryption, snapshotReserveInt)
+		if err := checkAggregateOverprovisioning(aggregate, spaceReserve, flexvolSizeBytes,
+			storagePool.InternalAttributes[MaxOverprovisionRatio], &d.Config, client); err != nil {
+			logc(ctx).Errorf("ONTAP-NAS pool %s/%s; error: %v", storagePool.Name, aggregate, err)
+			createErrors = append(createErrors, fmt.Errorf("ONTAP-NAS pool %s/%s; error: %w", storagePool.Name,
+				aggregate, err))
+			continue
+		}
 
-		if err = api.GetError(volCreateResponse, err); err != nil {
+		// Create the volume
+		err := retryOntapOperation(ctx, &d.Config, retryOpVolumeCreate, func() error {
+			volCreateResponse, err := client.VolumeCreate(
+				name, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
+				exportPolicy, securityStyle, tieringPolicy, comment, enableEncryption, snapshotReserveInt)
+			return api.GetError(volCreateResponse, err)
+		})
+
+		if err != nil {
 			if zerr, ok := err.(api.ZapiError); ok {
 				// Handle case where the Create is passed to every Docker Swarm node
-				if zerr.Code() == azgo.EAPIERROR && strings.HasSuffix(strings.TrimSpace(zerr.Reason()), "Job exists") {
-					log.WithField("volume", name).Warn("Volume create job already exists, skipping volume create on this node.")
+				if zerr.IsJobExistsError() {
+					logc(ctx).WithField("volume", name).Warn("Volume create job already exists, skipping volume create on this node.")
 					return nil
 				}
 			}
 
+			// The create may have failed after ONTAP created the volume, as when the request times out
+			cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
+
 			errMessage := fmt.Sprintf("ONTAP-NAS pool %s/%s; error creating volume %s: %v", storagePool.Name, aggregate, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
-		// Disable '.snapshot' to allow official mysql container's chmod-in-init to work
-		if !enableSnapshotDir {
-			snapDirResponse, err := d.API.VolumeDisableSnapshotDirectoryAccess(name)
-			if err = api.GetError(snapDirResponse, err); err != nil {
-				return fmt.Errorf("error disabling snapshot directory access: %v", err)
+		// If ONTAP chose the snapshot reserve, grow the Flexvol to account for it
+		if snapshotReserveInt == api.NumericalValueNotSet {
+			err = adjustSizeForDefaultSnapshotReserve(name, sizeBytes, client.VolumeGet,
+				func(name, newSize string) error {
+					response, err := client.VolumeSetSize(name, newSize)
+					return api.GetError(response, err)
+				})
+			if err != nil {
+				logc(ctx).WithField("volume", name).Warningf("Could not adjust volume size for snapshot reserve; %v", err)
 			}
 		}
 
-		// Mount the volume at the specified junction
-		mountResponse, err := d.API.VolumeMount(name, "/"+name)
-		if err = api.GetError(mountResponse, err); err != nil {
-			return fmt.Errorf("error mounting volume to junction: %v", err)
+		// Finish setting up the volume, destroying it if that fails so a retry starts over
+		if err = d.finishFlexvolCreate(client, name, enableSnapshotDir, tieringMinimumCoolingDays); err != nil {
+			cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
+			return err
 		}
 
 		return nil
 	}
 
+	// All physical pools that were eligible ultimately failed, so don't try this backend again unless
+	// only the pool's overprovisioning limit stood in the way
+	return newAggregateCreateError(name, storagePool.Name, createErrors, physicalPoolNames)
+}
+
+// finishFlexvolCreate applies the settings of a new Flexvol that can't be set when it is created, and
+// mounts it at its junction.
+func (d *NASStorageDriver) finishFlexvolCreate(
+	client *api.Client, name string, enableSnapshotDir bool, tieringMinimumCoolingDays string,
+) error {
+
+	// Disable '.snapshot' to allow official mysql container's chmod-in-init to work
+	if !enableSnapshotDir {
+		snapDirResponse, err := client.VolumeDisableSnapshotDirectoryAccess(name)
+		if err = api.GetError(snapDirResponse, err); err != nil {
+			return fmt.Errorf("error disabling snapshot directory access: %v", err)
+		}
+	}
+
+	// Set the cooling period that tiering policies use to decide which data is cold
+	if tieringMinimumCoolingDays != "" {
+		coolingDays, err := parseTieringMinimumCoolingDays(tieringMinimumCoolingDays)
+		if err != nil {
+			return err
+		}
+		coolingResponse, err := client.VolumeModifyTieringMinimumCoolingDays(name, coolingDays)
+		if err = api.GetError(coolingResponse, err); err != nil {
+			return fmt.Errorf("error setting tiering minimum cooling days: %v", err)
+		}
+	}
+
+	// Mount the volume at the specified junction
+	mountResponse, err := client.VolumeMount(name, "/"+name)
+	if err = api.GetError(mountResponse, err); err != nil {
+		return fmt.Errorf("error mounting volume to junction: %v", err)
+	}
+
+	return nil
+}
+
+// createFlexcache creates a FlexCache volume of the pool's origin volume.  The cache is placed on the
+// first of the candidate aggregates with room for it.
+func (d *NASStorageDriver) createFlexcache(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool,
+	physicalPools []*storage.Pool, flexcacheOrigin string, sizeBytes uint64, spaceReserve,
+	exportPolicy string,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	originSVM, originVolume, err := parseFlexcacheOrigin(flexcacheOrigin, client.SVM())
+	if err != nil {
+		return err
+	}
+
+	logc(ctx).WithFields(log.Fields{
+		"name":         name,
+		"size":         sizeBytes,
+		"originSVM":    originSVM,
+		"originVolume": originVolume,
+		"exportPolicy": exportPolicy,
+	}).Debug("Creating FlexCache.")
+
+	createErrors := make([]error, 0)
+	physicalPoolNames := make([]string, 0)
+
+	for _, physicalPool := range physicalPools {
+		aggregate := physicalPool.Name
+		physicalPoolNames = append(physicalPoolNames, aggregate)
+
+		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, client); aggrLimitsErr != nil {
+			errMessage := fmt.Sprintf("ONTAP-NAS pool %s/%s; error: %v", storagePool.Name, aggregate, aggrLimitsErr)
+			logc(ctx).Error(errMessage)
+			createErrors = append(createErrors, fmt.Errorf(errMessage))
+			continue
+		}
+
+		_, err := client.FlexcacheCreate(name, int(sizeBytes), aggregate, originSVM, originVolume)
+		if err != nil {
+			errMessage := fmt.Sprintf("ONTAP-NAS pool %s/%s; error creating FlexCache %s: %v", storagePool.Name,
+				aggregate, name, err)
+			logc(ctx).Error(errMessage)
+			createErrors = append(createErrors, fmt.Errorf(errMessage))
+			continue
+		}
+
+		modifyResponse, err := client.VolumeModifyExportPolicy(name, exportPolicy)
+		if err = api.GetError(modifyResponse, err); err != nil {
+			return fmt.Errorf("error setting export policy of FlexCache %s: %v", name, err)
+		}
+
+		volConfig.FlexcacheOrigin = originSVM + ":" + originVolume
+		return nil
+	}
+
 	// All physical pools that were eligible ultimately failed, so don't try this backend again
 	return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
 }
 
 // Create a volume clone
-func (d *NASStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
-	return CreateCloneNAS(d, volConfig, storagePool, false)
+func (d *NASStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+	return CreateCloneNAS(ctx, d, volConfig, storagePool, false)
 }
 
 // Destroy the volume
-func (d *NASStorageDriver) Destroy(name string) error {
+func (d *NASStorageDriver) Destroy(ctx context.Context, name string) error {
+	return d.DestroyVolume(ctx, &storage.VolumeConfig{InternalName: name})
+}
+
+// DestroyVolume destroys a volume, using the FlexCache API if its config records a FlexCache origin.
+func (d *NASStorageDriver) DestroyVolume(ctx context.Context, volConfig *storage.VolumeConfig) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method": "Destroy",
+			"Method": "DestroyVolume",
 			"Type":   "NASStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		logc(ctx).WithFields(fields).Debug(">>>> DestroyVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DestroyVolume")
 	}
 
 	// TODO: If this is the parent of one or more clones, those clones have to split from this
@@ -333,24 +641,189 @@ func (d *NASStorageDriver) Destroy(name string) error {
 	// user to keep the volume around until all of the clones are gone? If we do that, need a
 	// way to list the clones. Maybe volume inspect.
 
-	volDestroyResponse, err := d.API.VolumeDestroy(name, true)
+	// Per-volume export policies are named for the volume, so note the policy before it is gone
+	exportPolicy := ""
+	if d.Config.AutoExportPolicy {
+		exportPolicy = getVolumeExportPolicy(client, name)
+	}
+
+	// FlexCache volumes must be destroyed with the FlexCache API, which also releases the origin
+	isFlexcache := false
+	if volConfig.FlexcacheOrigin != "" && client.SupportsFeature(api.NetAppFlexCache) {
+		var err error
+		if isFlexcache, err = client.FlexcacheExists(name); err != nil {
+			return fmt.Errorf("error checking whether volume %v is a FlexCache: %v", name, err)
+		}
+	}
+	if isFlexcache {
+		if _, err := client.FlexcacheDestroy(name); err != nil {
+			return fmt.Errorf("error destroying FlexCache %v: %v", name, err)
+		}
+		if isVolumeExportPolicy(exportPolicy, name) {
+			if err := deleteExportPolicy(exportPolicy, client); err != nil {
+				logc(ctx).Warn(err)
+			}
+		}
+		return nil
+	}
+
+	// Keep the volume in the recovery queue for a while, along with its export policy
+	if d.recoveryQueue.Enabled() {
+		return d.recoveryQueue.Enqueue(ctx, name)
+	}
+
+	return d.destroyFlexvol(ctx, name, name, exportPolicy)
+}
+
+// destroyFlexvol destroys a volume's FlexVol, which may have been renamed into the recovery queue, and the
+// volume's own export policy if it has one.
+func (d *NASStorageDriver) destroyFlexvol(ctx context.Context, flexvol, name, exportPolicy string) error {
+
+	client := d.API.WithContext(ctx)
+
+	volDestroyResponse, err := client.VolumeDestroy(flexvol, true)
 	if err != nil {
 		return fmt.Errorf("error destroying volume %v: %v", name, err)
 	}
 	if zerr := api.NewZapiError(volDestroyResponse); !zerr.IsPassed() {
 
 		// It's not an error if the volume no longer exists
-		if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
-			log.WithField("volume", name).Warn("Volume already deleted.")
+		if zerr.IsNotFound() {
+			logc(ctx).WithField("volume", name).Warn("Volume already deleted.")
 		} else {
 			return fmt.Errorf("error destroying volume %v: %v", name, zerr)
 		}
 	}
 
+	// A clone no longer needs the snapshot it was created from
+	d.cloneSnapshots.Release(ctx, name)
+
+	if isVolumeExportPolicy(exportPolicy, name) {
+		if err := deleteExportPolicy(exportPolicy, client); err != nil {
+			logc(ctx).Warn(err)
+		}
+	}
+
 	return nil
 }
 
-func (d *NASStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+// releaseQueuedVolume unmounts a volume entering the recovery queue, so clients can no longer reach it.
+func (d *NASStorageDriver) releaseQueuedVolume(ctx context.Context, name string) error {
+	response, err := d.API.WithContext(ctx).VolumeUnmount(name, true)
+	return api.GetError(response, err)
+}
+
+// restoreQueuedVolume mounts a recovered volume at its original junction.
+func (d *NASStorageDriver) restoreQueuedVolume(ctx context.Context, name string) error {
+	response, err := d.API.WithContext(ctx).VolumeMount(name, "/"+name)
+	return api.GetError(response, err)
+}
+
+// destroyQueuedVolume destroys an expired volume from the recovery queue.
+func (d *NASStorageDriver) destroyQueuedVolume(ctx context.Context, queuedName, name string) error {
+	exportPolicy := ""
+	if d.Config.AutoExportPolicy {
+		exportPolicy = getVolumeExportPolicy(d.API.WithContext(ctx), queuedName)
+	}
+	return d.destroyFlexvol(ctx, queuedName, name, exportPolicy)
+}
+
+// ListRecoverableVolumes returns the deleted volumes in the SVM's recovery queue.
+func (d *NASStorageDriver) ListRecoverableVolumes(ctx context.Context) ([]*storage.RecoverableVolume, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListRecoverableVolumes", "Type": "NASStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListRecoverableVolumes")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListRecoverableVolumes")
+	}
+
+	return d.recoveryQueue.List()
+}
+
+// RecoverVolume restores a deleted volume from the SVM's recovery queue.
+func (d *NASStorageDriver) RecoverVolume(ctx context.Context, internalName string) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "RecoverVolume",
+			"Type":   "NASStorageDriver",
+			"name":   internalName,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> RecoverVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RecoverVolume")
+	}
+
+	return d.recoveryQueue.Recover(ctx, internalName)
+}
+
+// ListOrphanedResources returns the FlexVols, snapshots, and export policies the backend created on the SVM
+// that no longer belong to any volume or snapshot known to Trident.
+func (d *NASStorageDriver) ListOrphanedResources(
+	ctx context.Context, backendUUID string, volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig,
+) ([]*storage.OrphanedResource, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListOrphanedResources", "Type": "NASStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListOrphanedResources")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListOrphanedResources")
+	}
+
+	return d.orphans.Find(backendUUID, volumes, snapshots)
+}
+
+// DeleteOrphanedResource deletes a resource returned by ListOrphanedResources.
+func (d *NASStorageDriver) DeleteOrphanedResource(ctx context.Context, resource *storage.OrphanedResource) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":   "DeleteOrphanedResource",
+			"Type":     "NASStorageDriver",
+			"resource": resource.Name,
+			"parent":   resource.Parent,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteOrphanedResource")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteOrphanedResource")
+	}
+
+	return d.orphans.Delete(ctx, resource)
+}
+
+// GetOrphanPolicy returns whether, and when, orphaned resources should be deleted.
+func (d *NASStorageDriver) GetOrphanPolicy() *storage.OrphanPolicy {
+	return d.orphans.Policy()
+}
+
+// ListVolumes returns one page of the FlexVols matching the storage prefix.
+func (d *NASStorageDriver) ListVolumes(ctx context.Context, limit int, continueToken string) (*storage.VolumePage, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "ListVolumes",
+			"Type":   "NASStorageDriver",
+			"limit":  limit,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> ListVolumes")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListVolumes")
+	}
+
+	return listVolumes(d.API.WithContext(ctx), &d.Config, limit, continueToken)
+}
+
+// ListImportCandidates returns the FlexVols on the SVM, whatever their names, that could be imported.
+func (d *NASStorageDriver) ListImportCandidates(ctx context.Context) ([]*storage.ImportCandidate, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListImportCandidates", "Type": "NASStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListImportCandidates")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListImportCandidates")
+	}
+
+	return listNASImportCandidates(d.API.WithContext(ctx))
+}
+
+func (d *NASStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -360,12 +833,12 @@ func (d *NASStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 			"newName":      volConfig.InternalName,
 			"notManaged":   volConfig.ImportNotManaged,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	// Ensure the volume exists
-	flexvol, err := d.API.VolumeGet(originalName)
+	flexvol, err := client.VolumeGet(originalName)
 	if err != nil {
 		return err
 	} else if flexvol == nil {
@@ -376,23 +849,23 @@ func (d *NASStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	if flexvol.VolumeIdAttributesPtr != nil {
 		volumeIdAttrs := flexvol.VolumeIdAttributes()
 		if volumeIdAttrs.TypePtr != nil && volumeIdAttrs.Type() != "rw" {
-			log.WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
+			logc(ctx).WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
 			return fmt.Errorf("volume %s type is %s, not rw", originalName, volumeIdAttrs.Type())
 		}
 	}
 
 	// Get the volume size
 	if flexvol.VolumeSpaceAttributesPtr == nil || flexvol.VolumeSpaceAttributesPtr.SizePtr == nil {
-		log.WithField("originalName", originalName).Errorf("Could not import volume, size not available")
+		logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, size not available")
 		return fmt.Errorf("volume %s size not available", originalName)
 	}
-	volConfig.Size = strconv.FormatInt(int64(flexvol.VolumeSpaceAttributesPtr.Size()), 10)
+	volConfig.Size = strconv.FormatUint(getUsableVolumeSize(flexvol), 10)
 
 	// Rename the volume if Trident will manage its lifecycle
 	if !volConfig.ImportNotManaged {
-		renameResponse, err := d.API.VolumeRename(originalName, volConfig.InternalName)
+		renameResponse, err := client.VolumeRename(originalName, volConfig.InternalName)
 		if err = api.GetError(renameResponse, err); err != nil {
-			log.WithField("originalName", originalName).Errorf("Could not import volume, rename failed: %v", err)
+			logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, rename failed: %v", err)
 			return fmt.Errorf("volume %s rename failed: %v", originalName, err)
 		}
 	}
@@ -404,9 +877,9 @@ func (d *NASStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
                 if unixPerms == "" {
                         unixPerms = d.Config.UnixPermissions
                 }
-                modifyUnixPermResponse, err := d.API.VolumeModifyUnixPermissions(volConfig.InternalName, unixPerms)
+                modifyUnixPermResponse, err := client.VolumeModifyUnixPermissions(volConfig.InternalName, unixPerms)
                 if err = api.GetError(modifyUnixPermResponse, err); err != nil {
-                        log.WithField("originalName", originalName).Errorf("Could not import volume, modifying unix permissions failed: %v", err)
+                        logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, modifying unix permissions failed: %v", err)
                         return fmt.Errorf("volume %s modify failed: %v", originalName, err)
                 }
         }
@@ -424,7 +897,9 @@ func (d *NASStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 }
 
 // Rename changes the name of a volume
-func (d *NASStorageDriver) Rename(name string, newName string) error {
+func (d *NASStorageDriver) Rename(ctx context.Context, name string, newName string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -433,13 +908,13 @@ func (d *NASStorageDriver) Rename(name string, newName string) error {
 			"name":    name,
 			"newName": newName,
 		}
-		log.WithFields(fields).Debug(">>>> Rename")
-		defer log.WithFields(fields).Debug("<<<< Rename")
+		logc(ctx).WithFields(fields).Debug(">>>> Rename")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Rename")
 	}
 
-	renameResponse, err := d.API.VolumeRename(name, newName)
+	renameResponse, err := client.VolumeRename(name, newName)
 	if err = api.GetError(renameResponse, err); err != nil {
-		log.WithField("name", name).Warnf("Could not rename volume: %v", err)
+		logc(ctx).WithField("name", name).Warnf("Could not rename volume: %v", err)
 		return fmt.Errorf("could not rename volume %s: %v", name, err)
 	}
 
@@ -449,19 +924,20 @@ func (d *NASStorageDriver) Rename(name string, newName string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *NASStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *NASStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
 			"Method":  "Publish",
-			"DataLIF": d.Config.DataLIF,
+			"DataLIF": d.dataLIF.get(&d.Config),
 			"Type":    "NASStorageDriver",
 			"name":    name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	// Determine mount options (volume config wins, followed by backend config)
@@ -469,53 +945,71 @@ func (d *NASStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 	if volConfig.MountOptions != "" {
 		mountOptions = volConfig.MountOptions
 	}
+	mountOptions = nfsMountOptionsWithSecurity(mountOptions, d.Config.Kerberos)
 
 	// Add fields needed by Attach
 	publishInfo.NfsPath = fmt.Sprintf("/%s", name)
-	publishInfo.NfsServerIP = d.Config.DataLIF
+	if volConfig.FlexcacheOrigin != "" {
+		// The cache may have been unmounted since it was created, so use its current junction
+		junctionPath, err := d.ensureFlexcacheMounted(client, name)
+		if err != nil {
+			return err
+		}
+		publishInfo.NfsPath = junctionPath
+	}
+	publishInfo.NfsServerIP = d.dataLIF.get(&d.Config)
+	publishInfo.NfsServerIPs = getNFSTrunkingDataLIFs(client, publishInfo.NfsServerIP, mountOptions)
 	publishInfo.FilesystemType = "nfs"
 	publishInfo.MountOptions = mountOptions
 
-	return publishFlexVolShare(d.API, &d.Config, publishInfo, name)
+	// Keep the volume comment in sync with the volume's current metadata
+	updateFlexvolComment(client, &d.Config, volConfig)
+
+	return publishFlexVolShare(client, &d.Config, publishInfo, volConfig, name)
 }
 
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *NASStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "NASStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "GetSnapshot",
+			"Type":               "NASStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
-	return GetSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+	return GetSnapshot(snapConfig, &d.Config, client, client.VolumeSize)
 }
 
 // Return the list of snapshots associated with the specified volume
-func (d *NASStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *NASStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":     "GetSnapshots",
-			"Type":       "NASStorageDriver",
-			"volumeName": volConfig.InternalName,
+			"Method":             "GetSnapshots",
+			"Type":               "NASStorageDriver",
+			utils.LogFieldVolume: volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
-	return GetSnapshots(volConfig, &d.Config, d.API, d.API.VolumeSize)
+	return GetSnapshots(volConfig, &d.Config, client, client.VolumeSize)
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *NASStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
+	client := d.API.WithContext(ctx)
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
 
@@ -526,45 +1020,117 @@ func (d *NASStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 			"snapshotName": internalSnapName,
 			"sourceVolume": internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
+	}
+
+	return CreateSnapshot(ctx, snapConfig, &d.Config, client, client.VolumeSize)
+}
+
+// CreateGroupSnapshot creates a crash-consistent snapshot of each of the given volumes
+func (d *NASStorageDriver) CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*storage.SnapshotConfig,
+) ([]*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "NASStorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateGroupSnapshot")
 	}
 
-	return CreateSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+	return CreateGroupSnapshot(ctx, snapConfigs, &d.Config, client, client.VolumeSize)
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *NASStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "RestoreSnapshot",
-			"Type":         "NASStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "RestoreSnapshot",
+			"Type":               "NASStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
-	return RestoreSnapshot(snapConfig, &d.Config, d.API)
+	return RestoreSnapshot(snapConfig, &d.Config, client)
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *NASStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "DeleteSnapshot",
-			"Type":         "NASStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "DeleteSnapshot",
+			"Type":               "NASStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
+	}
+
+	return DeleteSnapshot(ctx, snapConfig, &d.Config, client, d.cloneSplitter)
+}
+
+// GetCloneSplitStatus returns the progress of a volume's most recent clone split, if any.
+func (d *NASStorageDriver) GetCloneSplitStatus(name string) *storage.CloneSplitStatus {
+	return d.cloneSplits.Status(name)
+}
+
+// SetCloneSplitHandler registers a function to be called whenever a clone split completes or fails.
+func (d *NASStorageDriver) SetCloneSplitHandler(handler storage.CloneSplitHandler) {
+	d.cloneSplits.SetHandler(handler)
+}
+
+// ResumeCloneSplit follows the split of a cloned volume again after a restart.
+func (d *NASStorageDriver) ResumeCloneSplit(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	return resumeCloneSplit(ctx, &d.Config, volConfig, d.cloneSplits)
+}
+
+// SetSnapshotDeleteHandler registers a function to be called whenever a deferred snapshot deletion ends.
+func (d *NASStorageDriver) SetSnapshotDeleteHandler(handler storage.SnapshotDeleteHandler) {
+	d.cloneSplitter.SetHandler(handler)
+}
+
+// MoveVolume begins moving a volume's Flexvol to another aggregate of this backend.
+func (d *NASStorageDriver) MoveVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, destinationPool string,
+) error {
+	if volConfig.FlexcacheOrigin != "" {
+		return fmt.Errorf("volume %s is a FlexCache and cannot be moved", volConfig.Name)
 	}
+	client := d.API.WithContext(ctx)
+	return MoveVolume(ctx, volConfig.InternalName, destinationPool, d.physicalPools, &d.Config, client,
+		d.volumeMoves)
+}
+
+// GetVolumeMoveStatus returns the progress of a volume's most recent move, if any.
+func (d *NASStorageDriver) GetVolumeMoveStatus(name string) *storage.VolumeMoveStatus {
+	return d.volumeMoves.Status(name)
+}
+
+// SetVolumeMoveHandler registers a function to be called whenever a volume move completes or fails.
+func (d *NASStorageDriver) SetVolumeMoveHandler(handler storage.VolumeMoveHandler) {
+	d.volumeMoves.SetHandler(handler)
+}
 
-	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+// ResumeVolumeMove follows the move of a volume's Flexvol again after a restart.
+func (d *NASStorageDriver) ResumeVolumeMove(_ context.Context, volConfig *storage.VolumeConfig) error {
+	d.volumeMoves.Resume(volConfig.InternalName, volConfig.MoveDestinationPool)
+	return nil
 }
 
 // Test for the existence of a volume
@@ -589,6 +1155,46 @@ func (d *NASStorageDriver) GetStorageBackendPhysicalPoolNames() []string {
 	return getStorageBackendPhysicalPoolNamesCommon(d.physicalPools)
 }
 
+// RefreshPools rediscovers the driver's storage pools from the aggregates assigned to the SVM.
+func (d *NASStorageDriver) RefreshPools(ctx context.Context) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "RefreshPools", "Type": "NASStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> RefreshPools")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RefreshPools")
+	}
+
+	physicalPools, virtualPools, err := refreshStoragePoolsCommon(d, d.getStoragePoolAttributes(), d.backendName())
+	if err != nil {
+		return err
+	}
+	d.physicalPools, d.virtualPools = physicalPools, virtualPools
+	d.restrictFlexcachePools()
+
+	// Read the new pools' capacity now, rather than leaving them empty until the next refresh
+	d.poolCapacity.SetPools(physicalPools, virtualPools)
+	d.poolCapacity.Refresh()
+
+	return nil
+}
+
+// ResumeVolumeCreate checks that a volume left behind by an interrupted create or clone is ready for use.
+func (d *NASStorageDriver) ResumeVolumeCreate(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	return resumeFlexvolCreate(ctx, d.API, &d.Config, volConfig, d.cloneSplits)
+}
+
+// WatchesPoolChanges returns true if the driver watches for events that may affect its storage pools.
+func (d *NASStorageDriver) WatchesPoolChanges() bool {
+	return d.eventWatcher != nil
+}
+
+// SetPoolChangeHandler registers a function to be called whenever the storage pools may have changed.
+func (d *NASStorageDriver) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	if d.eventWatcher != nil {
+		d.eventWatcher.SetPoolChangeHandler(handler)
+	}
+}
+
 func (d *NASStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
@@ -612,17 +1218,32 @@ func (d *NASStorageDriver) GetInternalVolumeName(name string) string {
 }
 
 func (d *NASStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
-	createPrepareCommon(d, volConfig)
+	createPrepareCommon(d, volConfig, maxFlexvolNameLength, d.API.VolumeExists)
 }
 
-func (d *NASStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *NASStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
-	volConfig.AccessInfo.NfsServerIP = d.Config.DataLIF
+	client := d.API.WithContext(ctx)
+	volConfig.AccessInfo.NfsServerIP = d.dataLIF.get(&d.Config)
 	volConfig.AccessInfo.MountOptions = strings.TrimPrefix(d.Config.NfsMountOptions, "-o ")
+	if volConfig.MountOptions != "" {
+		volConfig.AccessInfo.MountOptions = strings.TrimPrefix(volConfig.MountOptions, "-o ")
+	}
 	volConfig.FileSystem = ""
 
+	updateFlexvolComment(client, &d.Config, volConfig)
+
+	if volConfig.FlexcacheOrigin != "" {
+		junctionPath, err := d.ensureFlexcacheMounted(client, volConfig.InternalName)
+		if err != nil {
+			return err
+		}
+		volConfig.AccessInfo.NfsPath = junctionPath
+		return nil
+	}
+
 	// Set correct junction path
-	flexvol, err := d.API.VolumeGet(volConfig.InternalName)
+	flexvol, err := client.VolumeGet(volConfig.InternalName)
 	if err != nil {
 		return err
 	} else if flexvol == nil {
@@ -635,7 +1256,7 @@ func (d *NASStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 	if flexvol.VolumeIdAttributesPtr.JunctionPathPtr == nil || flexvol.VolumeIdAttributesPtr.JunctionPath() == "" {
 		// Flexvol is not mounted, we need to mount it
 		volConfig.AccessInfo.NfsPath = "/" + volConfig.InternalName
-		mountResponse, err := d.API.VolumeMount(volConfig.InternalName, volConfig.AccessInfo.NfsPath)
+		mountResponse, err := client.VolumeMount(volConfig.InternalName, volConfig.AccessInfo.NfsPath)
 		if err = api.GetError(mountResponse, err); err != nil {
 			return fmt.Errorf("error mounting volume to junction %s; %v", volConfig.AccessInfo.NfsPath, err)
 		}
@@ -645,10 +1266,41 @@ func (d *NASStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 	return nil
 }
 
+// ensureFlexcacheMounted mounts a FlexCache volume at a junction named for it if it isn't mounted, and
+// returns its junction path.
+func (d *NASStorageDriver) ensureFlexcacheMounted(client *api.Client, name string) (string, error) {
+
+	flexcache, err := client.FlexcacheGet(name)
+	if err != nil {
+		return "", err
+	}
+	if flexcache.VolumeIdAttributesPtr == nil {
+		return "", fmt.Errorf("error reading volume id attributes for FlexCache %s", name)
+	}
+	if flexcache.VolumeIdAttributesPtr.JunctionPathPtr != nil && flexcache.VolumeIdAttributesPtr.JunctionPath() != "" {
+		return flexcache.VolumeIdAttributesPtr.JunctionPath(), nil
+	}
+
+	junctionPath := "/" + name
+	mountResponse, err := client.VolumeMount(name, junctionPath)
+	if err = api.GetError(mountResponse, err); err != nil {
+		return "", fmt.Errorf("error mounting FlexCache to junction %s; %v", junctionPath, err)
+	}
+	return junctionPath, nil
+}
+
 func (d *NASStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.File
 }
 
+func (d *NASStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.FileAccessModes
+}
+
+func (d *NASStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.FileVolumeExpansion
+}
+
 func (d *NASStorageDriver) StoreConfig(
 	b *storage.PersistentStorageBackendConfig,
 ) {
@@ -683,18 +1335,17 @@ func (d *NASStorageDriver) GetVolumeExternalWrappers(
 	// Let the caller know we're done by closing the channel
 	defer close(channel)
 
-	// Get all volumes matching the storage prefix
-	volumesResponse, err := d.API.VolumeGetAll(*d.Config.StoragePrefix)
-	if err = api.GetError(volumesResponse, err); err != nil {
-		channel <- &storage.VolumeExternalWrapper{Volume: nil, Error: err}
-		return
-	}
-
-	// Convert all volumes to VolumeExternal and write them to the channel
-	if volumesResponse.Result.AttributesListPtr != nil {
-		for _, volume := range volumesResponse.Result.AttributesListPtr.VolumeAttributesPtr {
-			channel <- &storage.VolumeExternalWrapper{Volume: d.getVolumeExternal(&volume), Error: nil}
+	// Convert all online volumes matching the storage prefix to VolumeExternal and write them to the
+	// channel, listing the volumes a page at a time
+	err := forEachFlexvol(d.API, *d.Config.StoragePrefix, func(volume *azgo.VolumeAttributesType) error {
+		// Deleted volumes waiting in the recovery queue match an empty storage prefix
+		if isOnlineVolume(volume) && !isQueuedVolume(volume) {
+			channel <- &storage.VolumeExternalWrapper{Volume: d.getVolumeExternal(volume), Error: nil}
 		}
+		return nil
+	})
+	if err != nil {
+		channel <- &storage.VolumeExternalWrapper{Volume: nil, Error: err}
 	}
 }
 
@@ -708,7 +1359,6 @@ func (d *NASStorageDriver) getVolumeExternal(
 	volumeIDAttrs := volumeAttrs.VolumeIdAttributesPtr
 	volumeSecurityAttrs := volumeAttrs.VolumeSecurityAttributesPtr
 	volumeSecurityUnixAttrs := volumeSecurityAttrs.VolumeSecurityUnixAttributesPtr
-	volumeSpaceAttrs := volumeAttrs.VolumeSpaceAttributesPtr
 	volumeSnapshotAttrs := volumeAttrs.VolumeSnapshotAttributesPtr
 
 	internalName := string(volumeIDAttrs.Name())
@@ -721,7 +1371,7 @@ func (d *NASStorageDriver) getVolumeExternal(
 		Version:         tridentconfig.OrchestratorAPIVersion,
 		Name:            name,
 		InternalName:    internalName,
-		Size:            strconv.FormatInt(int64(volumeSpaceAttrs.Size()), 10),
+		Size:            strconv.FormatUint(getUsableVolumeSize(volumeAttrs), 10),
 		Protocol:        tridentconfig.File,
 		SnapshotPolicy:  volumeSnapshotAttrs.SnapshotPolicy(),
 		ExportPolicy:    volumeExportAttrs.Policy(),
@@ -773,41 +1423,168 @@ func (d *NASStorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bit
 	return bitmap
 }
 
+// UpdateVolume changes attributes of an existing volume, such as whether its snapshot directory
+// is visible to clients, the days its data must be cold before it is tiered, and its permissions
+// and policies
+func (d *NASStorageDriver) UpdateVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, updateRequest *storage.UpdateVolumeRequest,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":                    "UpdateVolume",
+			"Type":                      "NASStorageDriver",
+			"name":                      name,
+			"snapshotDirectory":         updateRequest.SnapshotDirectory,
+			"tieringMinimumCoolingDays": updateRequest.TieringMinimumCoolingDays,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> UpdateVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< UpdateVolume")
+	}
+
+	if volConfig.FlexcacheOrigin != "" {
+		return fmt.Errorf("volume %s is a FlexCache and cannot be updated", volConfig.Name)
+	}
+
+	if updateRequest.SnapshotDirectory != "" {
+		enableSnapshotDir, err := strconv.ParseBool(updateRequest.SnapshotDirectory)
+		if err != nil {
+			return fmt.Errorf("invalid boolean value for snapshotDirectory: %v", err)
+		}
+
+		snapDirResponse, err := client.VolumeModifySnapshotDirectoryAccess(name, enableSnapshotDir)
+		if err = api.GetError(snapDirResponse, err); err != nil {
+			return wrapOntapError(err, "error modifying snapshot directory access")
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"volume":            name,
+			"snapshotDirectory": enableSnapshotDir,
+		}).Info("Updated snapshot directory access.")
+	}
+
+	if updateRequest.TieringMinimumCoolingDays != "" {
+		coolingDays, err := parseTieringMinimumCoolingDays(updateRequest.TieringMinimumCoolingDays)
+		if err != nil {
+			return err
+		}
+
+		coolingResponse, err := client.VolumeModifyTieringMinimumCoolingDays(name, coolingDays)
+		if err = api.GetError(coolingResponse, err); err != nil {
+			return wrapOntapError(err, "error modifying tiering minimum cooling days")
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"volume":                    name,
+			"tieringMinimumCoolingDays": coolingDays,
+		}).Info("Updated tiering minimum cooling days.")
+	}
+
+	return ModifyVolume(ctx, name, updateRequest, &d.Config, client)
+}
+
 // Resize expands the volume size.
-func (d *NASStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *NASStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	return d.ResizeInPool(ctx, volConfig, nil, sizeBytes)
+}
+
+// ResizeInPool is Resize for a volume whose pool is known, enforcing the pool's size limit rather than
+// the backend's.  The pool may be nil.
+func (d *NASStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
+
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":    "Resize",
+			"Method":    "ResizeInPool",
 			"Type":      "NASStorageDriver",
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		logc(ctx).WithFields(fields).Debug(">>>> ResizeInPool")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ResizeInPool")
 	}
 
-	flexvolSize, err := resizeValidation(name, sizeBytes, d.API.VolumeExists, d.API.VolumeSize)
+	if volConfig.FlexcacheOrigin != "" {
+		return d.resizeFlexcache(ctx, volConfig, storagePool, sizeBytes)
+	}
+
+	usableSize, err := resizeValidation(name, sizeBytes, d.Config.AllowVolumeShrink, client.VolumeExists,
+		usableVolumeSizeFunc(client.VolumeGet))
 	if err != nil {
 		return err
 	}
 
-	volConfig.Size = strconv.FormatUint(flexvolSize, 10)
-	if flexvolSize == sizeBytes {
+	volConfig.Size = strconv.FormatUint(usableSize, 10)
+	if usableSize == sizeBytes {
 		return nil
 	}
 
-	if aggrLimitsErr := checkAggregateLimitsForFlexvol(name, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+	// Grow the Flexvol so the snapshot reserve doesn't eat into the requested capacity
+	volAttrs, err := client.VolumeGet(name)
+	if err != nil {
+		return fmt.Errorf("error occurred when checking volume snapshot reserve: %v", err)
+	}
+
+	// Only shrink the Flexvol if its data would still fit
+	if sizeBytes < usableSize {
+		if err := checkVolumeShrink(name, sizeBytes, volAttrs); err != nil {
+			return err
+		}
+	}
+
+	flexvolSizeBytes := calculateFlexvolSizeBytes(sizeBytes, getVolumeSnapshotReserve(volAttrs))
+
+	if aggrLimitsErr := checkAggregateLimitsForFlexvol(name, flexvolSizeBytes, d.Config, client); aggrLimitsErr != nil {
 		return aggrLimitsErr
 	}
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
-	response, err := d.API.VolumeSetSize(name, strconv.FormatUint(sizeBytes, 10))
+	response, err := client.VolumeSetSize(name, strconv.FormatUint(flexvolSizeBytes, 10))
 	if err = api.GetError(response.Result, err); err != nil {
-		log.WithField("error", err).Error("Volume resize failed.")
+		logc(ctx).WithField("error", err).Error("Volume resize failed.")
+		return fmt.Errorf("volume resize failed")
+	}
+
+	volConfig.Size = strconv.FormatUint(sizeBytes, 10)
+	return nil
+}
+
+// resizeFlexcache expands a FlexCache volume.  Caches have no snapshot reserve of their own, so the
+// cache is sized exactly as requested.
+func (d *NASStorageDriver) resizeFlexcache(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	currentSize, err := client.FlexGroupSize(name)
+	if err != nil {
+		return fmt.Errorf("error occurred when checking FlexCache size: %v", err)
+	}
+	if sizeBytes < uint64(currentSize) {
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, currentSize), nil)
+	}
+	volConfig.Size = strconv.FormatUint(uint64(currentSize), 10)
+	if sizeBytes == uint64(currentSize) {
+		return nil
+	}
+
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
+		return checkVolumeSizeLimitsError
+	}
+
+	if _, err := client.FlexGroupSetSize(name, strconv.FormatUint(sizeBytes, 10)); err != nil {
+		logc(ctx).WithField("error", err).Error("FlexCache resize failed.")
 		return fmt.Errorf("volume resize failed")
 	}
 
@@ -831,6 +1608,14 @@ func (d *NASStorageDriver) ReconcileNodeAccess(nodes []*utils.Node, backendUUID
 		defer log.WithFields(fields).Debug("<<<< ReconcileNodeAccess")
 	}
 
+	if isScopedExportPolicy(&d.Config) {
+		volumes, err := d.API.VolumeGetAll(*d.Config.StoragePrefix)
+		if err = api.GetError(volumes, err); err != nil {
+			return fmt.Errorf("error listing volumes: %v", err)
+		}
+		return reconcileScopedExportPolicies(nodes, &d.Config, d.API, backendUUID, volumes)
+	}
+
 	policyName := getExportPolicyName(backendUUID)
 
 	return reconcileNASNodeAccess(nodes, &d.Config, d.API, policyName)
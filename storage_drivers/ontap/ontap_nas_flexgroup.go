@@ -2,10 +2,10 @@
 package ontap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
-	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
@@ -30,8 +30,17 @@ type NASFlexGroupStorageDriver struct {
 	API         *api.Client
 	Telemetry   *Telemetry
 
+	housekeeping   *HousekeepingScheduler
+	cloneSplitter  *CloneSplitter
+	cloneSplits    *CloneSplitTracker
+	cloneSnapshots *CloneSnapshotReaper
+	asyncJobs      *AsyncJobTracker
+
+	expandThresholdPct int
+
 	physicalPool *storage.Pool
 	virtualPools map[string]*storage.Pool
+	dataLIF      nasDataLIF
 }
 
 func (d *NASFlexGroupStorageDriver) GetConfig() *drivers.OntapStorageDriverConfig {
@@ -42,6 +51,74 @@ func (d *NASFlexGroupStorageDriver) GetAPI() *api.Client {
 	return d.API
 }
 
+// SetAPITraceEnabled turns tracing of the driver's API calls on or off.
+func (d *NASFlexGroupStorageDriver) SetAPITraceEnabled(enabled bool) {
+	d.API.SetAPITraceEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and its API client.
+func (d *NASFlexGroupStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	d.API.SetDebugTraceFlags(flags)
+}
+
+// SetAPILogLevel sets the level at which messages about the driver's API calls are logged.
+func (d *NASFlexGroupStorageDriver) SetAPILogLevel(level string) error {
+	return d.API.SetLogLevel(level)
+}
+
+// GetAPILogLevel returns the level at which messages about the driver's API calls are logged, if set.
+func (d *NASFlexGroupStorageDriver) GetAPILogLevel() string {
+	return d.API.LogLevel()
+}
+
+// LogFields returns the fields the driver adds to the messages logged for operations on its backend.
+func (d *NASFlexGroupStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+// ListStorageJobs returns the clone splits and FlexGroup clone jobs still in progress.
+func (d *NASFlexGroupStorageDriver) ListStorageJobs() []*storage.StorageJob {
+	return append(d.cloneSplits.Jobs(), d.asyncJobs.Jobs()...)
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on the driver's SVM.
+func (d *NASFlexGroupStorageDriver) GetFeatures() map[string]bool {
+	return d.API.Features()
+}
+
+// GetStorageLogs returns the recent jobs of the driver's SVM and the recent EMS events of its cluster.
+func (d *NASFlexGroupStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+	return getStorageLogs(d.API.WithContext(ctx))
+}
+
+// CheckHealth returns an error if the driver's SVM can't be reached or isn't running.
+func (d *NASFlexGroupStorageDriver) CheckHealth(ctx context.Context) error {
+	return checkSVMHealth(d.API.WithContext(ctx))
+}
+
+// resolveDataLIFs chooses the data LIF to use after the driver fails over to another SVM.
+func (d *NASFlexGroupStorageDriver) resolveDataLIFs() error {
+	return resolveNASDataLIF(&d.Config, d.API, &d.dataLIF)
+}
+
+func (d *NASFlexGroupStorageDriver) GetCloneSplitTracker() *CloneSplitTracker {
+	return d.cloneSplits
+}
+
+func (d *NASFlexGroupStorageDriver) GetCloneSnapshotReaper() *CloneSnapshotReaper {
+	return d.cloneSnapshots
+}
+
+func (d *NASFlexGroupStorageDriver) GetAsyncJobTracker() *AsyncJobTracker {
+	return d.asyncJobs
+}
+
+// ResumeAsyncJob resumes following the ONTAP job creating a FlexGroup after Trident restarts.
+func (d *NASFlexGroupStorageDriver) ResumeAsyncJob(volConfig *storage.VolumeConfig) {
+	d.asyncJobs.Resume(volConfig.InternalName, storage.StorageJobOperationCloneCreate, volConfig.CreateJobID)
+}
+
 func (d *NASFlexGroupStorageDriver) GetTelemetry() *Telemetry {
 	d.Telemetry.Telemetry = tridentconfig.OrchestratorTelemetry
 	return d.Telemetry
@@ -62,6 +139,11 @@ func (d *NASFlexGroupStorageDriver) backendName() string {
 	}
 }
 
+// SetBackendUUID records the UUID of the driver's backend, with which the driver tags the FlexGroups it creates.
+func (d *NASFlexGroupStorageDriver) SetBackendUUID(backendUUID string) {
+	d.Config.BackendUUID = backendUUID
+}
+
 // Initialize from the provided config
 func (d *NASFlexGroupStorageDriver) Initialize(
 	context tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
@@ -86,6 +168,11 @@ func (d *NASFlexGroupStorageDriver) Initialize(
 	}
 	d.Config = *config
 
+	// Create the snapshot policies the config asks for, or correct them if they have drifted
+	if err = reconcileSnapshotPolicies(d.API, &d.Config); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+
 	// Identify Virtual Pools
 	if err := d.initializeStoragePools(); err != nil {
 		return fmt.Errorf("could not configure storage pools: %v", err)
@@ -96,9 +183,31 @@ func (d *NASFlexGroupStorageDriver) Initialize(
 		return fmt.Errorf("error validating %s driver: %v", d.Name(), err)
 	}
 
-	// Set up the autosupport heartbeat
+	// Set up the autosupport heartbeat and other periodic housekeeping
 	d.Telemetry = NewOntapTelemetry(d)
-	d.Telemetry.Start()
+	d.housekeeping = NewOntapHousekeepingScheduler(d)
+	d.cloneSplits = NewCloneSplitTracker(&d.Config, d.API)
+	d.cloneSplitter = NewCloneSplitter(&d.Config, d.API, d.cloneSplits)
+	if err = d.housekeeping.AddJob(d.cloneSplitter.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if err = d.housekeeping.AddJob(d.cloneSplits.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.cloneSnapshots = NewCloneSnapshotReaper(&d.Config, d.API)
+	d.cloneSplits.OnComplete(d.cloneSnapshots.splitComplete)
+	if err = d.housekeeping.AddJob(d.cloneSnapshots.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.asyncJobs = NewAsyncJobTracker(d.API)
+	if err = d.housekeeping.AddJob(d.asyncJobs.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	poolCapacity := NewPoolCapacityMonitor(&d.Config, d.API, map[string]*storage.Pool{d.physicalPool.Name: d.physicalPool}, d.virtualPools, true)
+	if err = d.housekeeping.AddJob(poolCapacity.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.housekeeping.Start()
 
 	d.initialized = true
 	return nil
@@ -121,8 +230,8 @@ func (d *NASFlexGroupStorageDriver) Terminate(backendUUID string) {
 			log.Warn(err)
 		}
 	}
-	if d.Telemetry != nil {
-		d.Telemetry.Stop()
+	if d.housekeeping != nil {
+		d.housekeeping.Stop()
 	}
 	d.initialized = false
 }
@@ -183,6 +292,9 @@ func (d *NASFlexGroupStorageDriver) initializeStoragePools() error {
 	pool.InternalAttributes[ExportPolicy] = config.ExportPolicy
 	pool.InternalAttributes[SecurityStyle] = config.SecurityStyle
 	pool.InternalAttributes[TieringPolicy] = config.TieringPolicy
+	pool.InternalAttributes[LimitVolumeSize] = config.LimitVolumeSize
+
+	applyPoolAttributeProviders(pool, d.Name(), config, nil)
 
 	d.physicalPool = pool
 
@@ -252,6 +364,11 @@ func (d *NASFlexGroupStorageDriver) initializeStoragePools() error {
 				tieringPolicy = vpool.TieringPolicy
 			}
 
+			limitVolumeSize := config.LimitVolumeSize
+			if vpool.LimitVolumeSize != "" {
+				limitVolumeSize = vpool.LimitVolumeSize
+			}
+
 			pool := storage.NewStoragePool(nil, poolName(fmt.Sprintf("pool_%d", index), d.backendName()))
 
 			// Update pool with attributes set by default for this backend
@@ -295,6 +412,9 @@ func (d *NASFlexGroupStorageDriver) initializeStoragePools() error {
 			pool.InternalAttributes[ExportPolicy] = exportPolicy
 			pool.InternalAttributes[SecurityStyle] = securityStyle
 			pool.InternalAttributes[TieringPolicy] = tieringPolicy
+			pool.InternalAttributes[LimitVolumeSize] = limitVolumeSize
+
+			applyPoolAttributeProviders(pool, d.Name(), config, &vpool.OntapStorageDriverConfigDefaults)
 
 			d.virtualPools[pool.Name] = pool
 		}
@@ -303,59 +423,34 @@ func (d *NASFlexGroupStorageDriver) initializeStoragePools() error {
 	return nil
 }
 
-// getVserverAggrMediaType gets vservers' media type attribute using vserver-show-aggr-get-iter,
-// which will only succeed on Data ONTAP 9 and later.
+// getVserverAggrMediaType gets the media types of the aggregates assigned to the vserver.
 func (d *NASFlexGroupStorageDriver) getVserverAggrMediaType(aggrNames []string) (mediaOffers []sa.Offer, err error) {
 
 	aggrMediaTypes := make(map[sa.Offer]struct{})
 
-	aggrMap := make(map[string]struct{})
-	for _, s := range aggrNames {
-		aggrMap[s] = struct{}{}
-	}
+	mediaTypes, err := getAggrMediaTypes(d)
 
-	// Handle panics from the API layer
-	defer func() {
-		if r := recover(); r != nil {
-			err = fmt.Errorf("unable to inspect ONTAP backend: %v\nStack trace:\n%s", r, debug.Stack())
-		}
-	}()
+	for _, aggrName := range aggrNames {
 
-	result, err := d.GetAPI().VserverShowAggrGetIterRequest()
-	if err != nil {
-		return
-	}
-
-	if zerr := api.NewZapiError(result.Result); !zerr.IsPassed() {
-		err = zerr
-		return
-	}
-
-	if result.Result.AttributesListPtr != nil {
-		for _, aggr := range result.Result.AttributesListPtr.ShowAggregatesPtr {
-			aggrName := string(aggr.AggregateName())
-			aggrType := aggr.AggregateType()
-
-			// Find matching aggregate.
-			_, ok := aggrMap[aggrName]
-			if !ok {
-				continue
-			}
+		// Find matching aggregate.
+		aggrType, ok := mediaTypes[aggrName]
+		if !ok {
+			continue
+		}
 
-			// Get the storage attributes (i.e. MediaType) corresponding to the aggregate type
-			storageAttrs, ok := ontapPerformanceClasses[ontapPerformanceClass(aggrType)]
-			if !ok {
-				log.WithFields(log.Fields{
-					"aggregate": aggrName,
-					"mediaType": aggrType,
-				}).Debug("Aggregate has unknown performance characteristics.")
+		// Get the storage attributes (i.e. MediaType) corresponding to the aggregate type
+		storageAttrs, ok := ontapPerformanceClasses[ontapPerformanceClass(aggrType)]
+		if !ok {
+			log.WithFields(log.Fields{
+				"aggregate": aggrName,
+				"mediaType": aggrType,
+			}).Debug("Aggregate has unknown performance characteristics.")
 
-				continue
-			}
+			continue
+		}
 
-			if storageAttrs != nil {
-				aggrMediaTypes[storageAttrs[sa.Media]] = struct{}{}
-			}
+		if storageAttrs != nil {
+			aggrMediaTypes[storageAttrs[sa.Media]] = struct{}{}
 		}
 	}
 
@@ -384,6 +479,10 @@ func (d *NASFlexGroupStorageDriver) validate() error {
 		return fmt.Errorf("driver validation failed: %v", err)
 	}
 
+	if err := d.validateFlexGroupExpand(); err != nil {
+		return err
+	}
+
 	// Create a list `physicalPools` containing 1 entry
 	var physicalPools = map[string]*storage.Pool{
 		d.physicalPool.Name: d.physicalPool,
@@ -397,9 +496,10 @@ func (d *NASFlexGroupStorageDriver) validate() error {
 
 // Create a volume with the specified options
 func (d *NASFlexGroupStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -409,12 +509,12 @@ func (d *NASFlexGroupStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	// If the volume already exists, bail out
-	volExists, err := d.API.FlexGroupExists(name)
+	volExists, err := client.FlexGroupExists(name)
 	if err != nil {
 		return fmt.Errorf("error checking for existing FlexGroup: %v", err)
 	}
@@ -440,8 +540,13 @@ func (d *NASFlexGroupStorageDriver) Create(
 	}
 	size := int(sizeBytes)
 
+	// Determine mount options (volume config wins, followed by pool, followed by backend config)
+	if err = resolveNFSMountOptions(volConfig, storagePool); err != nil {
+		return err
+	}
+
 	// Get the aggregates assigned to the SVM.  There must be at least one!
-	vserverAggrs, err := d.API.VserverGetAggregateNames()
+	vserverAggrs, err := client.VserverGetAggregateNames()
 	if err != nil {
 		return err
 	}
@@ -456,7 +561,7 @@ func (d *NASFlexGroupStorageDriver) Create(
 		vserverAggrNames = append(vserverAggrNames, azgo.AggrNameType(aggrName))
 	}
 
-	log.WithFields(log.Fields{
+	logc(ctx).WithFields(log.Fields{
 		"aggregates": vserverAggrs,
 	}).Debug("Read aggregates assigned to SVM.")
 
@@ -477,8 +582,11 @@ func (d *NASFlexGroupStorageDriver) Create(
 	securityStyle := utils.GetV(opts, "securityStyle", storagePool.InternalAttributes[SecurityStyle])
 	encryption := utils.GetV(opts, "encryption", storagePool.InternalAttributes[Encryption])
 	tieringPolicy := utils.GetV(opts, "tieringPolicy", storagePool.InternalAttributes[TieringPolicy])
+	tieringMinimumCoolingDays := storagePool.InternalAttributes[TieringMinimumCoolingDays]
 
-	// limits checks are not currently applicable to the Flexgroups driver, ommited here on purpose
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
+		return checkVolumeSizeLimitsError
+	}
 
 	enableSnapshotDir, err := strconv.ParseBool(snapshotDir)
 	if err != nil {
@@ -495,15 +603,21 @@ func (d *NASFlexGroupStorageDriver) Create(
 		return fmt.Errorf("invalid value for snapshotReserve: %v", err)
 	}
 
+	// Grow the FlexGroup so the snapshot reserve doesn't eat into the requested capacity
+	size = int(calculateFlexvolSizeBytes(sizeBytes, snapshotReserveInt))
+
 	if tieringPolicy == "" {
 		tieringPolicy = "none"
 	}
 
 	if d.Config.AutoExportPolicy {
-		exportPolicy = getExportPolicyName(storagePool.Backend.BackendUUID)
+		exportPolicy, err = getAutoExportPolicy(&d.Config, client, storagePool.Backend.BackendUUID, volConfig)
+		if err != nil {
+			return err
+		}
 	}
 
-	log.WithFields(log.Fields{
+	logc(ctx).WithFields(log.Fields{
 		"name":            name,
 		"size":            size,
 		"spaceReserve":    spaceReserve,
@@ -515,6 +629,7 @@ func (d *NASFlexGroupStorageDriver) Create(
 		"aggregates":      vserverAggrNames,
 		"securityStyle":   securityStyle,
 		"encryption":      enableEncryption,
+		"coolingDays":     tieringMinimumCoolingDays,
 	}).Debug("Creating FlexGroup.")
 
 	createErrors := make([]error, 0)
@@ -523,7 +638,7 @@ func (d *NASFlexGroupStorageDriver) Create(
 
 	// Create the FlexGroup
 	checkVolumeCreated := func() error {
-		_, err = d.API.FlexGroupCreate(
+		_, err = client.FlexGroupCreate(
 			name, size, vserverAggrNames, spaceReserve, snapshotPolicy, unixPermissions,
 			exportPolicy, securityStyle, tieringPolicy, enableEncryption, snapshotReserveInt)
 
@@ -531,7 +646,7 @@ func (d *NASFlexGroupStorageDriver) Create(
 	}
 
 	volumeCreateNotify := func(err error, duration time.Duration) {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"name":      name,
 			"increment": duration}).Debug("FlexGroup not yet created, waiting.")
 	}
@@ -549,17 +664,41 @@ func (d *NASFlexGroupStorageDriver) Create(
 		return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
 	}
 
+	// If ONTAP chose the snapshot reserve, grow the FlexGroup to account for it
+	if snapshotReserveInt == api.NumericalValueNotSet {
+		err = adjustSizeForDefaultSnapshotReserve(name, sizeBytes, client.FlexGroupGet,
+			func(name, newSize string) error {
+				_, err := client.FlexGroupSetSize(name, newSize)
+				return err
+			})
+		if err != nil {
+			logc(ctx).WithField("volume", name).Warningf("Could not adjust FlexGroup size for snapshot reserve; %v", err)
+		}
+	}
+
 	// Disable '.snapshot' to allow official mysql container's chmod-in-init to work
 	if !enableSnapshotDir {
-		_, err := d.API.FlexGroupVolumeDisableSnapshotDirectoryAccess(name)
+		_, err := client.FlexGroupVolumeDisableSnapshotDirectoryAccess(name)
 		if err != nil {
 			createErrors = append(createErrors, fmt.Errorf("ONTAP-NAS-FLEXGROUP pool %s; error disabling snapshot directory access for volume %v: %v", storagePool.Name, name, err))
 			return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
 		}
 	}
 
+	// Set the cooling period that tiering policies use to decide which data is cold
+	if tieringMinimumCoolingDays != "" {
+		coolingDays, err := parseTieringMinimumCoolingDays(tieringMinimumCoolingDays)
+		if err != nil {
+			return err
+		}
+		if _, err := client.FlexGroupVolumeModifyTieringMinimumCoolingDays(name, coolingDays); err != nil {
+			createErrors = append(createErrors, fmt.Errorf("ONTAP-NAS-FLEXGROUP pool %s; error setting tiering minimum cooling days for volume %v: %v", storagePool.Name, name, err))
+			return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
+		}
+	}
+
 	// Mount the volume at the specified junction
-	mountResponse, err := d.API.VolumeMount(name, "/"+name)
+	mountResponse, err := client.VolumeMount(name, "/"+name)
 	if err = api.GetError(mountResponse, err); err != nil {
 		createErrors = append(createErrors, fmt.Errorf("ONTAP-NAS-FLEXGROUP pool %s; error mounting volume %s to junction: %v", storagePool.Name, name, err))
 		return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
@@ -569,12 +708,14 @@ func (d *NASFlexGroupStorageDriver) Create(
 }
 
 // CreateClone creates a flexgroup clone
-func (d *NASFlexGroupStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
-	return CreateCloneNAS(d, volConfig, storagePool, true)
+func (d *NASFlexGroupStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+	return CreateCloneNAS(ctx, d, volConfig, storagePool, true)
 }
 
 // Import brings an existing volume under trident's control
-func (d *NASFlexGroupStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *NASFlexGroupStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -583,12 +724,12 @@ func (d *NASFlexGroupStorageDriver) Import(volConfig *storage.VolumeConfig, orig
 			"originalName": originalName,
 			"notManaged":   volConfig.ImportNotManaged,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	// Ensure the volume exists
-	flexgroup, err := d.API.FlexGroupGet(originalName)
+	flexgroup, err := client.FlexGroupGet(originalName)
 	if err != nil {
 		return err
 	} else if flexgroup == nil {
@@ -599,17 +740,17 @@ func (d *NASFlexGroupStorageDriver) Import(volConfig *storage.VolumeConfig, orig
 	if flexgroup.VolumeIdAttributesPtr != nil {
 		volumeIdAttrs := flexgroup.VolumeIdAttributes()
 		if volumeIdAttrs.TypePtr != nil && volumeIdAttrs.Type() != "rw" {
-			log.WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
+			logc(ctx).WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
 			return fmt.Errorf("could not import volume %s, type is %s, not rw", originalName, volumeIdAttrs.Type())
 		}
 	}
 
 	// Get the volume size
 	if flexgroup.VolumeSpaceAttributesPtr == nil || flexgroup.VolumeSpaceAttributesPtr.SizePtr == nil {
-		log.WithField("originalName", originalName).Errorf("Could not import volume, size not available")
+		logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, size not available")
 		return fmt.Errorf("could not import volume %s, size not available", originalName)
 	}
-	volConfig.Size = strconv.FormatInt(int64(flexgroup.VolumeSpaceAttributesPtr.Size()), 10)
+	volConfig.Size = strconv.FormatUint(getUsableVolumeSize(flexgroup), 10)
 
 	// We cannot rename flexgroups, so internal name should match the imported originalName
 	volConfig.InternalName = originalName
@@ -621,9 +762,9 @@ func (d *NASFlexGroupStorageDriver) Import(volConfig *storage.VolumeConfig, orig
                 if unixPerms == "" {
                         unixPerms = d.Config.UnixPermissions
                 }
-                modifyUnixPermResponse, err := d.API.FlexGroupModifyUnixPermissions(volConfig.InternalName, unixPerms)
+                modifyUnixPermResponse, err := client.FlexGroupModifyUnixPermissions(volConfig.InternalName, unixPerms)
                 if err = api.GetError(modifyUnixPermResponse, err); err != nil {
-                        log.WithField("originalName", originalName).Errorf("Could not import volume, modifying unix permissions failed: %v", err)
+                        logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, modifying unix permissions failed: %v", err)
                         return fmt.Errorf("volume %s modify failed: %v", originalName, err)
                 }
         }
@@ -642,13 +783,15 @@ func (d *NASFlexGroupStorageDriver) Import(volConfig *storage.VolumeConfig, orig
 }
 
 // Rename changes the name of a volume
-func (d *NASFlexGroupStorageDriver) Rename(name string, newName string) error {
+func (d *NASFlexGroupStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 	// Flexgroups cannot be renamed
 	return nil
 }
 
 // Destroy the volume
-func (d *NASFlexGroupStorageDriver) Destroy(name string) error {
+func (d *NASFlexGroupStorageDriver) Destroy(ctx context.Context, name string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -656,8 +799,8 @@ func (d *NASFlexGroupStorageDriver) Destroy(name string) error {
 			"Type":   "NASFlexGroupStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	// Needed once FlexGroups support clones
@@ -668,7 +811,13 @@ func (d *NASFlexGroupStorageDriver) Destroy(name string) error {
 	// user to keep the volume around until all of the clones are gone? If we do that, need a
 	// way to list the clones. Maybe volume inspect.
 
-	if volExists, err := UnmountAndOfflineVolume(d.GetAPI(), name); err != nil {
+	// Per-volume export policies are named for the volume, so note the policy before it is gone
+	exportPolicy := ""
+	if d.Config.AutoExportPolicy {
+		exportPolicy = getVolumeExportPolicy(client, name)
+	}
+
+	if volExists, err := UnmountAndOfflineVolume(client, name); err != nil {
 		return err
 	} else if !volExists {
 		return nil
@@ -677,18 +826,28 @@ func (d *NASFlexGroupStorageDriver) Destroy(name string) error {
 	// This call is async, but we will receive an immediate error back for anything but very rare volume deletion
 	// failures. Failures in this category are almost certainly likely to be beyond our capability to fix or even
 	// diagnose, so we defer to the ONTAP cluster admin
-	if _, err := d.API.FlexGroupDestroy(name, true); err != nil {
+	if _, err := client.FlexGroupDestroy(name, true); err != nil {
 		return fmt.Errorf("error destroying FlexGroup %v: %v", name, err)
 	}
 
+	// A clone no longer needs the snapshot it was created from
+	d.cloneSnapshots.Release(ctx, name)
+
+	if isVolumeExportPolicy(exportPolicy, name) {
+		if err := deleteExportPolicy(exportPolicy, client); err != nil {
+			logc(ctx).Warn(err)
+		}
+	}
+
 	return nil
 }
 
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *NASFlexGroupStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *NASFlexGroupStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -697,8 +856,8 @@ func (d *NASFlexGroupStorageDriver) Publish(volConfig *storage.VolumeConfig, pub
 			"Type":   "NASFlexGroupStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	// Determine mount options (volume config wins, followed by backend config)
@@ -706,53 +865,63 @@ func (d *NASFlexGroupStorageDriver) Publish(volConfig *storage.VolumeConfig, pub
 	if volConfig.MountOptions != "" {
 		mountOptions = volConfig.MountOptions
 	}
+	mountOptions = nfsMountOptionsWithSecurity(mountOptions, d.Config.Kerberos)
 
 	// Add fields needed by Attach
 	publishInfo.NfsPath = fmt.Sprintf("/%s", name)
-	publishInfo.NfsServerIP = d.Config.DataLIF
+	publishInfo.NfsServerIP = d.dataLIF.get(&d.Config)
+	publishInfo.NfsServerIPs = getNFSTrunkingDataLIFs(client, publishInfo.NfsServerIP, mountOptions)
 	publishInfo.FilesystemType = "nfs"
 	publishInfo.MountOptions = mountOptions
 
-	return publishFlexVolShare(d.API, &d.Config, publishInfo, name)
+	// Keep the volume comment in sync with the volume's current metadata
+	updateFlexvolComment(client, &d.Config, volConfig)
+
+	return publishFlexVolShare(client, &d.Config, publishInfo, volConfig, name)
 }
 
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *NASFlexGroupStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASFlexGroupStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "NASFlexGroupStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "GetSnapshot",
+			"Type":               "NASFlexGroupStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
-	return GetSnapshot(snapConfig, &d.Config, d.API, d.API.FlexGroupSize)
+	return GetSnapshot(snapConfig, &d.Config, client, client.FlexGroupSize)
 }
 
 // Return the list of snapshots associated with the specified volume
-func (d *NASFlexGroupStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *NASFlexGroupStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":     "GetSnapshots",
-			"Type":       "NASFlexGroupStorageDriver",
-			"volumeName": volConfig.InternalName,
+			"Method":             "GetSnapshots",
+			"Type":               "NASFlexGroupStorageDriver",
+			utils.LogFieldVolume: volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
-	return GetSnapshots(volConfig, &d.Config, d.API, d.API.FlexGroupSize)
+	return GetSnapshots(volConfig, &d.Config, client, client.FlexGroupSize)
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *NASFlexGroupStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASFlexGroupStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
+	client := d.API.WithContext(ctx)
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
 
@@ -763,45 +932,69 @@ func (d *NASFlexGroupStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotC
 			"snapshotName": internalSnapName,
 			"sourceVolume": internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
-	return CreateSnapshot(snapConfig, &d.Config, d.API, d.API.FlexGroupSize)
+	return CreateSnapshot(ctx, snapConfig, &d.Config, client, client.FlexGroupSize)
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *NASFlexGroupStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASFlexGroupStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "RestoreSnapshot",
-			"Type":         "NASFlexGroupStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "RestoreSnapshot",
+			"Type":               "NASFlexGroupStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
-	return RestoreSnapshot(snapConfig, &d.Config, d.API)
+	return RestoreSnapshot(snapConfig, &d.Config, client)
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *NASFlexGroupStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASFlexGroupStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "DeleteSnapshot",
-			"Type":         "NASFlexGroupStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "DeleteSnapshot",
+			"Type":               "NASFlexGroupStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
-	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+	return DeleteSnapshot(ctx, snapConfig, &d.Config, client, d.cloneSplitter)
+}
+
+// GetCloneSplitStatus returns the progress of a volume's most recent clone split, if any.
+func (d *NASFlexGroupStorageDriver) GetCloneSplitStatus(name string) *storage.CloneSplitStatus {
+	return d.cloneSplits.Status(name)
+}
+
+// SetCloneSplitHandler registers a function to be called whenever a clone split completes or fails.
+func (d *NASFlexGroupStorageDriver) SetCloneSplitHandler(handler storage.CloneSplitHandler) {
+	d.cloneSplits.SetHandler(handler)
+}
+
+// ResumeCloneSplit follows the split of a cloned volume again after a restart.
+func (d *NASFlexGroupStorageDriver) ResumeCloneSplit(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	return resumeCloneSplit(ctx, &d.Config, volConfig, d.cloneSplits)
+}
+
+// SetSnapshotDeleteHandler registers a function to be called whenever a deferred snapshot deletion ends.
+func (d *NASFlexGroupStorageDriver) SetSnapshotDeleteHandler(handler storage.SnapshotDeleteHandler) {
+	d.cloneSplitter.SetHandler(handler)
 }
 
 // Tests the existence of a FlexGroup. Returns nil if the FlexGroup
@@ -892,17 +1085,23 @@ func (d *NASFlexGroupStorageDriver) GetInternalVolumeName(name string) string {
 }
 
 func (d *NASFlexGroupStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
-	createPrepareCommon(d, volConfig)
+	createPrepareCommon(d, volConfig, maxFlexvolNameLength, d.API.FlexGroupExists)
 }
 
-func (d *NASFlexGroupStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *NASFlexGroupStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
-	volConfig.AccessInfo.NfsServerIP = d.Config.DataLIF
+	client := d.API.WithContext(ctx)
+	volConfig.AccessInfo.NfsServerIP = d.dataLIF.get(&d.Config)
 	volConfig.AccessInfo.MountOptions = strings.TrimPrefix(d.Config.NfsMountOptions, "-o ")
+	if volConfig.MountOptions != "" {
+		volConfig.AccessInfo.MountOptions = strings.TrimPrefix(volConfig.MountOptions, "-o ")
+	}
 	volConfig.FileSystem = ""
 
+	updateFlexvolComment(client, &d.Config, volConfig)
+
 	// Set correct junction path
-	flexgroup, err := d.API.FlexGroupGet(volConfig.InternalName)
+	flexgroup, err := client.FlexGroupGet(volConfig.InternalName)
 	if err != nil {
 		return err
 	} else if flexgroup == nil {
@@ -915,7 +1114,7 @@ func (d *NASFlexGroupStorageDriver) CreateFollowup(volConfig *storage.VolumeConf
 	if flexgroup.VolumeIdAttributesPtr.JunctionPathPtr == nil || flexgroup.VolumeIdAttributesPtr.JunctionPath() == "" {
 		// Flexgroup is not mounted, we need to mount it
 		volConfig.AccessInfo.NfsPath = "/" + volConfig.InternalName
-		mountResponse, err := d.API.VolumeMount(volConfig.InternalName, volConfig.AccessInfo.NfsPath)
+		mountResponse, err := client.VolumeMount(volConfig.InternalName, volConfig.AccessInfo.NfsPath)
 		if err = api.GetError(mountResponse, err); err != nil {
 			return fmt.Errorf("error mounting volume to junction %s; %v", volConfig.AccessInfo.NfsPath, err)
 		}
@@ -930,6 +1129,14 @@ func (d *NASFlexGroupStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.File
 }
 
+func (d *NASFlexGroupStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.FileAccessModes
+}
+
+func (d *NASFlexGroupStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.FileVolumeExpansion
+}
+
 func (d *NASFlexGroupStorageDriver) StoreConfig(
 	b *storage.PersistentStorageBackendConfig,
 ) {
@@ -989,7 +1196,6 @@ func (d *NASFlexGroupStorageDriver) getVolumeExternal(
 	volumeIDAttrs := volumeAttrs.VolumeIdAttributesPtr
 	volumeSecurityAttrs := volumeAttrs.VolumeSecurityAttributesPtr
 	volumeSecurityUnixAttrs := volumeSecurityAttrs.VolumeSecurityUnixAttributesPtr
-	volumeSpaceAttrs := volumeAttrs.VolumeSpaceAttributesPtr
 	volumeSnapshotAttrs := volumeAttrs.VolumeSnapshotAttributesPtr
 
 	internalName := string(volumeIDAttrs.Name())
@@ -1002,7 +1208,7 @@ func (d *NASFlexGroupStorageDriver) getVolumeExternal(
 		Version:         tridentconfig.OrchestratorAPIVersion,
 		Name:            name,
 		InternalName:    internalName,
-		Size:            strconv.FormatInt(int64(volumeSpaceAttrs.Size()), 10),
+		Size:            strconv.FormatUint(getUsableVolumeSize(volumeAttrs), 10),
 		Protocol:        tridentconfig.File,
 		SnapshotPolicy:  volumeSnapshotAttrs.SnapshotPolicy(),
 		ExportPolicy:    volumeExportAttrs.Policy(),
@@ -1045,34 +1251,128 @@ func (d *NASFlexGroupStorageDriver) GetUpdateType(driverOrig storage.Driver) *ro
 	return bitmap
 }
 
+// UpdateVolume changes attributes of an existing volume, currently whether its snapshot
+// directory is visible to clients and the days its data must be cold before it is tiered
+func (d *NASFlexGroupStorageDriver) UpdateVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, updateRequest *storage.UpdateVolumeRequest,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":                    "UpdateVolume",
+			"Type":                      "NASFlexGroupStorageDriver",
+			"name":                      name,
+			"snapshotDirectory":         updateRequest.SnapshotDirectory,
+			"tieringMinimumCoolingDays": updateRequest.TieringMinimumCoolingDays,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> UpdateVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< UpdateVolume")
+	}
+
+	if updateRequest.UnixPermissions != "" || updateRequest.SecurityStyle != "" ||
+		updateRequest.ExportPolicy != "" || updateRequest.SnapshotPolicy != "" {
+		return utils.UnsupportedError("only the snapshot directory access and tiering minimum cooling days " +
+			"of a FlexGroup may be updated")
+	}
+
+	if updateRequest.SnapshotDirectory != "" {
+		enableSnapshotDir, err := strconv.ParseBool(updateRequest.SnapshotDirectory)
+		if err != nil {
+			return fmt.Errorf("invalid boolean value for snapshotDirectory: %v", err)
+		}
+
+		snapDirResponse, err := client.FlexGroupVolumeModifySnapshotDirectoryAccess(name, enableSnapshotDir)
+		if err = api.GetError(snapDirResponse, err); err != nil {
+			return wrapOntapError(err, "error modifying snapshot directory access")
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"volume":            name,
+			"snapshotDirectory": enableSnapshotDir,
+		}).Info("Updated snapshot directory access.")
+	}
+
+	if updateRequest.TieringMinimumCoolingDays != "" {
+		coolingDays, err := parseTieringMinimumCoolingDays(updateRequest.TieringMinimumCoolingDays)
+		if err != nil {
+			return err
+		}
+
+		coolingResponse, err := client.FlexGroupVolumeModifyTieringMinimumCoolingDays(name, coolingDays)
+		if err = api.GetError(coolingResponse, err); err != nil {
+			return wrapOntapError(err, "error modifying tiering minimum cooling days")
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"volume":                    name,
+			"tieringMinimumCoolingDays": coolingDays,
+		}).Info("Updated tiering minimum cooling days.")
+	}
+
+	return nil
+}
+
 // Resize expands the FlexGroup size.
-func (d *NASFlexGroupStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *NASFlexGroupStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	return d.ResizeInPool(ctx, volConfig, nil, sizeBytes)
+}
+
+// ResizeInPool is Resize for a volume whose pool is known, enforcing the pool's size limit rather than
+// the backend's.  The pool may be nil.
+func (d *NASFlexGroupStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":    "Resize",
+			"Method":    "ResizeInPool",
 			"Type":      "NASFlexGroupStorageDriver",
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		logc(ctx).WithFields(fields).Debug(">>>> ResizeInPool")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ResizeInPool")
+	}
+
+	usableSize, err := resizeValidation(name, sizeBytes, false, client.FlexGroupExists, usableVolumeSizeFunc(client.FlexGroupGet))
+	if err != nil {
+		return err
 	}
 
-	flexvolSize, err := resizeValidation(name, sizeBytes, d.API.FlexGroupExists, d.API.FlexGroupSize)
+	volConfig.Size = strconv.FormatUint(usableSize, 10)
+	if usableSize == sizeBytes {
+		return nil
+	}
+
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
+		return checkVolumeSizeLimitsError
+	}
+
+	// Grow the FlexGroup so the snapshot reserve doesn't eat into the requested capacity
+	volAttrs, err := client.FlexGroupGet(name)
 	if err != nil {
+		return fmt.Errorf("error occurred when checking FlexGroup snapshot reserve: %v", err)
+	}
+	flexgroupSizeBytes := calculateFlexvolSizeBytes(sizeBytes, getVolumeSnapshotReserve(volAttrs))
+
+	// Add constituents first if the aggregates have filled unevenly
+	if err = d.expandFlexGroup(ctx, name, volAttrs); err != nil {
 		return err
 	}
 
-	volConfig.Size = strconv.FormatUint(flexvolSize, 10)
-	if flexvolSize == sizeBytes {
+	// New constituents may already have grown the FlexGroup past the requested size
+	if currentSize, err := client.FlexGroupSize(name); err == nil && uint64(currentSize) >= flexgroupSizeBytes {
+		volConfig.Size = strconv.FormatUint(sizeBytes, 10)
 		return nil
 	}
 
-	_, err = d.API.FlexGroupSetSize(name, strconv.FormatUint(sizeBytes, 10))
+	_, err = client.FlexGroupSetSize(name, strconv.FormatUint(flexgroupSizeBytes, 10))
 	if err != nil {
-		log.WithField("error", err).Error("FlexGroup resize failed.")
+		logc(ctx).WithField("error", err).Error("FlexGroup resize failed.")
 		return fmt.Errorf("flexgroup resize failed")
 	}
 
@@ -1096,6 +1396,14 @@ func (d *NASFlexGroupStorageDriver) ReconcileNodeAccess(nodes []*utils.Node, bac
 		defer log.WithFields(fields).Debug("<<<< ReconcileNodeAccess")
 	}
 
+	if isScopedExportPolicy(&d.Config) {
+		volumes, err := d.API.FlexGroupGetAll(*d.Config.StoragePrefix)
+		if err = api.GetError(volumes, err); err != nil {
+			return fmt.Errorf("error listing volumes: %v", err)
+		}
+		return reconcileScopedExportPolicies(nodes, &d.Config, d.API, backendUUID, volumes)
+	}
+
 	policyName := getExportPolicyName(backendUUID)
 
 	return reconcileNASNodeAccess(nodes, &d.Config, d.API, policyName)
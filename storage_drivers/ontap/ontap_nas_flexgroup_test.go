@@ -0,0 +1,17 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+func TestNASFlexGroupResizeInPool(t *testing.T) {
+	testResizeInPool(t, func(config *drivers.OntapStorageDriverConfig, client *api.Client) storage.PoolResizer {
+		return &NASFlexGroupStorageDriver{Config: *config, API: client}
+	})
+}
@@ -3,6 +3,7 @@
 package ontap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -27,7 +28,6 @@ import (
 const (
 	deletedQtreeNamePrefix                      = "deleted_"
 	maxQtreeNameLength                          = 64
-	maxQtreesPerFlexvol                         = 200
 	defaultPruneFlexvolsPeriodSecs              = uint64(600)   // default to 10 minutes
 	defaultResizeQuotasPeriodSecs               = uint64(60)    // default to 1 minute
 	defaultEmptyFlexvolDeferredDeletePeriodSecs = uint64(28800) // default to 8 hours
@@ -49,9 +49,17 @@ type NASQtreeStorageDriver struct {
 	sharedLockID                     string
 	emptyFlexvolMap                  map[string]time.Time
 	emptyFlexvolDeferredDeletePeriod time.Duration
+	qtreesPerFlexvol                 int
+	flexvolSizeRatio                 float64
+
+	housekeeping *HousekeepingScheduler
+	quotaUsage   *QuotaUsageMonitor
 
 	physicalPools map[string]*storage.Pool
 	virtualPools  map[string]*storage.Pool
+	poolCapacity  *PoolCapacityMonitor
+	eventWatcher  *EventWatcher
+	dataLIF       nasDataLIF
 }
 
 func (d *NASQtreeStorageDriver) GetConfig() *drivers.OntapStorageDriverConfig {
@@ -62,6 +70,52 @@ func (d *NASQtreeStorageDriver) GetAPI() *api.Client {
 	return d.API
 }
 
+// SetAPITraceEnabled turns tracing of the driver's API calls on or off.
+func (d *NASQtreeStorageDriver) SetAPITraceEnabled(enabled bool) {
+	d.API.SetAPITraceEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and its API client.
+func (d *NASQtreeStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	d.API.SetDebugTraceFlags(flags)
+}
+
+// SetAPILogLevel sets the level at which messages about the driver's API calls are logged.
+func (d *NASQtreeStorageDriver) SetAPILogLevel(level string) error {
+	return d.API.SetLogLevel(level)
+}
+
+// GetAPILogLevel returns the level at which messages about the driver's API calls are logged, if set.
+func (d *NASQtreeStorageDriver) GetAPILogLevel() string {
+	return d.API.LogLevel()
+}
+
+// LogFields returns the fields the driver adds to the messages logged for operations on its backend.
+func (d *NASQtreeStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on the driver's SVM.
+func (d *NASQtreeStorageDriver) GetFeatures() map[string]bool {
+	return d.API.Features()
+}
+
+// GetStorageLogs returns the recent jobs of the driver's SVM and the recent EMS events of its cluster.
+func (d *NASQtreeStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+	return getStorageLogs(d.API.WithContext(ctx))
+}
+
+// CheckHealth returns an error if the driver's SVM can't be reached or isn't running.
+func (d *NASQtreeStorageDriver) CheckHealth(ctx context.Context) error {
+	return checkSVMHealth(d.API.WithContext(ctx))
+}
+
+// resolveDataLIFs chooses the data LIF to use after the driver fails over to another SVM.
+func (d *NASQtreeStorageDriver) resolveDataLIFs() error {
+	return resolveNASDataLIF(&d.Config, d.API, &d.dataLIF)
+}
+
 func (d *NASQtreeStorageDriver) GetTelemetry() *Telemetry {
 	d.Telemetry.Telemetry = tridentconfig.OrchestratorTelemetry
 	return d.Telemetry
@@ -110,6 +164,11 @@ func (d *NASQtreeStorageDriver) Initialize(
 	}
 	d.Config = *config
 
+	// Create the snapshot policies the config asks for, or correct them if they have drifted
+	if err = reconcileSnapshotPolicies(d.API, &d.Config); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+
 	// Remap context for artifact naming so the names remain stable over time
 	var artifactPrefix string
 	switch context {
@@ -166,7 +225,22 @@ func (d *NASQtreeStorageDriver) Initialize(
 
 	// Set up the autosupport heartbeat
 	d.Telemetry = NewOntapTelemetry(d)
-	d.Telemetry.Start()
+	d.housekeeping = NewOntapHousekeepingScheduler(d)
+	d.poolCapacity = NewPoolCapacityMonitor(&d.Config, d.API, d.physicalPools, d.virtualPools, false)
+	if err = d.housekeeping.AddJob(d.poolCapacity.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if eventWatchInterval, _ := getEventWatchInterval(&d.Config); eventWatchInterval > 0 {
+		d.eventWatcher = NewEventWatcher(d.API)
+		if err = d.housekeeping.AddJob(d.eventWatcher.HousekeepingJob(eventWatchInterval)); err != nil {
+			return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+		}
+	}
+	d.quotaUsage = NewQuotaUsageMonitor(d.API, d.FlexvolNamePrefix())
+	if err = d.housekeeping.AddJob(d.quotaUsage.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.housekeeping.Start()
 
 	d.initialized = true
 	return nil
@@ -176,6 +250,15 @@ func (d *NASQtreeStorageDriver) Initialized() bool {
 	return d.initialized
 }
 
+// GetVolumeUsage returns the space and files a qtree-backed volume used, and its quota limits, when
+// its Flexvol's quota report was last read.
+func (d *NASQtreeStorageDriver) GetVolumeUsage(internalName string) *storage.VolumeUsage {
+	if d.quotaUsage == nil {
+		return nil
+	}
+	return d.quotaUsage.Get(internalName)
+}
+
 func (d *NASQtreeStorageDriver) Terminate(backendUUID string) {
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -197,8 +280,8 @@ func (d *NASQtreeStorageDriver) Terminate(backendUUID string) {
 		}
 	}
 
-	if d.Telemetry != nil {
-		d.Telemetry.Stop()
+	if d.housekeeping != nil {
+		d.housekeeping.Stop()
 	}
 
 	if d.housekeepingWaitGroup != nil {
@@ -227,6 +310,16 @@ func (d *NASQtreeStorageDriver) validate() error {
 		return fmt.Errorf("storage pool validation failed: %v", err)
 	}
 
+	if err := d.validateQtreePacking(); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	if d.Config.QtreeFileLimit != "" {
+		if fileLimit, err := strconv.ParseUint(d.Config.QtreeFileLimit, 10, 64); err != nil || fileLimit == 0 {
+			return fmt.Errorf("invalid value for qtreeFileLimit: %s", d.Config.QtreeFileLimit)
+		}
+	}
+
 	if !d.Config.AutoExportPolicy {
 		// Make sure we have an export policy for all the Flexvols we create
 		err = d.ensureDefaultExportPolicy()
@@ -240,9 +333,10 @@ func (d *NASQtreeStorageDriver) validate() error {
 
 // Create a qtree-backed volume with the specified options
 func (d *NASQtreeStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -252,8 +346,8 @@ func (d *NASQtreeStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	// Ensure any Flexvol we create won't be pruned before we place a qtree on it
@@ -264,13 +358,13 @@ func (d *NASQtreeStorageDriver) Create(
 	createError := errors.New("volume creation failed")
 
 	// Ensure volume doesn't already exist
-	exists, existsInFlexvol, err := d.API.QtreeExists(name, d.FlexvolNamePrefix())
+	exists, existsInFlexvol, err := client.QtreeExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing volume: %v.", err)
+		logc(ctx).Errorf("Error checking for existing volume: %v.", err)
 		return createError
 	}
 	if exists {
-		log.WithFields(log.Fields{"qtree": name, "flexvol": existsInFlexvol}).Debug("Qtree already exists.")
+		logc(ctx).WithFields(log.Fields{"qtree": name, "flexvol": existsInFlexvol}).Debug("Qtree already exists.")
 		return drivers.NewVolumeExistsError(name)
 	}
 
@@ -280,6 +374,11 @@ func (d *NASQtreeStorageDriver) Create(
 		return err
 	}
 
+	// Determine mount options (volume config wins, followed by pool, followed by backend config)
+	if err = resolveNFSMountOptions(volConfig, storagePool); err != nil {
+		return err
+	}
+
 	// Determine volume size in bytes
 	requestedSize, err := utils.ConvertSizeToBytes(volConfig.Size)
 	if err != nil {
@@ -330,13 +429,17 @@ func (d *NASQtreeStorageDriver) Create(
 	}
 
 	if tieringPolicy == "" {
-		tieringPolicy = d.API.TieringPolicyValue()
+		tieringPolicy = client.TieringPolicyValue()
 	}
 
 	if d.Config.AutoExportPolicy {
 		exportPolicy = getExportPolicyName(storagePool.Backend.BackendUUID)
 	}
 
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
+		return checkVolumeSizeLimitsError
+	}
+
 	createErrors := make([]error, 0)
 	physicalPoolNames := make([]string, 0)
 
@@ -344,21 +447,29 @@ func (d *NASQtreeStorageDriver) Create(
 		aggregate := physicalPool.Name
 		physicalPoolNames = append(physicalPoolNames, aggregate)
 
-		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, client); aggrLimitsErr != nil {
 			errMessage := fmt.Sprintf("ONTAP-NAS-QTREE pool %s/%s; error: %v", storagePool.Name, aggregate, aggrLimitsErr)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
+		if err := checkAggregateOverprovisioning(aggregate, spaceReserve, sizeBytes,
+			storagePool.InternalAttributes[MaxOverprovisionRatio], &d.Config, client); err != nil {
+			logc(ctx).Errorf("ONTAP-NAS-QTREE pool %s/%s; error: %v", storagePool.Name, aggregate, err)
+			createErrors = append(createErrors, fmt.Errorf("ONTAP-NAS-QTREE pool %s/%s; error: %w", storagePool.Name,
+				aggregate, err))
+			continue
+		}
+
 		// Make sure we have a Flexvol for the new qtree
 		flexvol, err := d.ensureFlexvolForQtree(
 			aggregate, spaceReserve, snapshotPolicy, tieringPolicy, enableSnapshotDir, enableEncryption, sizeBytes,
-			d.Config, snapshotReserve, exportPolicy)
+			d.Config, snapshotReserve, exportPolicy, storagePool)
 		if err != nil {
 			errMessage := fmt.Sprintf("ONTAP-NAS-QTREE pool %s/%s; Flexvol location/creation failed %s: %v",
 				storagePool.Name, aggregate, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
@@ -368,18 +479,18 @@ func (d *NASQtreeStorageDriver) Create(
 		if err != nil {
 			errMessage := fmt.Sprintf("ONTAP-NAS-QTREE pool %s/%s; Flexvol resize failed %s/%s: %v", storagePool.Name,
 				aggregate, flexvol, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
 		// Create the qtree
-		qtreeResponse, err := d.API.QtreeCreate(name, flexvol, unixPermissions, exportPolicy, securityStyle)
+		qtreeResponse, err := client.QtreeCreate(name, flexvol, unixPermissions, exportPolicy, securityStyle)
 		if err = api.GetError(qtreeResponse, err); err != nil {
 
 			errMessage := fmt.Sprintf("ONTAP-NAS-QTREE pool %s/%s; Qtree creation failed %s/%s: %v", storagePool.Name,
 				aggregate, flexvol, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
@@ -387,7 +498,7 @@ func (d *NASQtreeStorageDriver) Create(
 		// Add the quota
 		err = d.setQuotaForQtree(name, flexvol, sizeBytes)
 		if err != nil {
-			log.Errorf("Qtree quota definition failed. %v", err)
+			logc(ctx).Errorf("Qtree quota definition failed. %v", err)
 			return fmt.Errorf("ONTAP-NAS-QTREE pool %s/%s; Qtree quota definition failed %s/%s: %v", storagePool.Name,
 				aggregate, flexvol, name, err)
 		}
@@ -395,12 +506,13 @@ func (d *NASQtreeStorageDriver) Create(
 		return nil
 	}
 
-	// All physical pools that were eligible ultimately failed, so don't try this backend again
-	return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
+	// All physical pools that were eligible ultimately failed, so don't try this backend again unless
+	// only the pool's overprovisioning limit stood in the way
+	return newAggregateCreateError(name, storagePool.Name, createErrors, physicalPoolNames)
 }
 
 // Create a volume clone
-func (d *NASQtreeStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+func (d *NASQtreeStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
 
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
@@ -414,23 +526,25 @@ func (d *NASQtreeStorageDriver) CreateClone(volConfig *storage.VolumeConfig, sto
 			"source":   source,
 			"snapshot": snapshot,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
 	return fmt.Errorf("cloning is not supported by backend type %s", d.Name())
 }
 
-func (d *NASQtreeStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *NASQtreeStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 	return errors.New("import is not implemented")
 }
 
-func (d *NASQtreeStorageDriver) Rename(name string, newName string) error {
+func (d *NASQtreeStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 	return errors.New("rename is not implemented")
 }
 
 // Destroy the volume
-func (d *NASQtreeStorageDriver) Destroy(name string) error {
+func (d *NASQtreeStorageDriver) Destroy(ctx context.Context, name string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -438,8 +552,8 @@ func (d *NASQtreeStorageDriver) Destroy(name string) error {
 			"Type":   "NASQtreeStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	// Ensure the deleted qtree reaping job doesn't interfere with this workflow
@@ -449,13 +563,13 @@ func (d *NASQtreeStorageDriver) Destroy(name string) error {
 	// Generic user-facing message
 	deleteError := errors.New("volume deletion failed")
 
-	exists, flexvol, err := d.API.QtreeExists(name, d.FlexvolNamePrefix())
+	exists, flexvol, err := client.QtreeExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing qtree. %v", err)
+		logc(ctx).Errorf("Error checking for existing qtree. %v", err)
 		return deleteError
 	}
 	if !exists {
-		log.WithField("qtree", name).Warn("Qtree not found.")
+		logc(ctx).WithField("qtree", name).Warn("Qtree not found.")
 		return nil
 	}
 
@@ -469,17 +583,17 @@ func (d *NASQtreeStorageDriver) Destroy(name string) error {
 	}
 	deletedPath := fmt.Sprintf("/vol/%s/%s", flexvol, deletedName)
 
-	renameResponse, err := d.API.QtreeRename(path, deletedPath)
+	renameResponse, err := client.QtreeRename(path, deletedPath)
 	if err = api.GetError(renameResponse, err); err != nil {
-		log.Errorf("Qtree rename failed. %v", err)
+		logc(ctx).Errorf("Qtree rename failed. %v", err)
 		return deleteError
 	}
 
 	// Destroy the qtree in the background.  If this fails, try to restore the original qtree name.
-	destroyResponse, err := d.API.QtreeDestroyAsync(deletedPath, true)
+	destroyResponse, err := client.QtreeDestroyAsync(deletedPath, true)
 	if err = api.GetError(destroyResponse, err); err != nil {
-		log.Errorf("Qtree async delete failed. %v", err)
-		defer d.API.QtreeRename(deletedPath, path)
+		logc(ctx).Errorf("Qtree async delete failed. %v", err)
+		defer client.QtreeRename(deletedPath, path)
 		return deleteError
 	}
 
@@ -489,8 +603,9 @@ func (d *NASQtreeStorageDriver) Destroy(name string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *NASQtreeStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *NASQtreeStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -499,18 +614,18 @@ func (d *NASQtreeStorageDriver) Publish(volConfig *storage.VolumeConfig, publish
 			"Type":   "NASQtreeStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	// Check if qtree exists, and find its Flexvol so we can build the export location
-	exists, flexvol, err := d.API.QtreeExists(name, d.FlexvolNamePrefix())
+	exists, flexvol, err := client.QtreeExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing qtree. %v", err)
+		logc(ctx).Errorf("Error checking for existing qtree. %v", err)
 		return errors.New("volume mount failed")
 	}
 	if !exists {
-		log.WithField("qtree", name).Debug("Qtree not found.")
+		logc(ctx).WithField("qtree", name).Debug("Qtree not found.")
 		return fmt.Errorf("volume %s not found", name)
 	}
 
@@ -519,10 +634,12 @@ func (d *NASQtreeStorageDriver) Publish(volConfig *storage.VolumeConfig, publish
 	if volConfig.MountOptions != "" {
 		mountOptions = volConfig.MountOptions
 	}
+	mountOptions = nfsMountOptionsWithSecurity(mountOptions, d.Config.Kerberos)
 
 	// Add fields needed by Attach
 	publishInfo.NfsPath = fmt.Sprintf("/%s/%s", flexvol, name)
-	publishInfo.NfsServerIP = d.Config.DataLIF
+	publishInfo.NfsServerIP = d.dataLIF.get(&d.Config)
+	publishInfo.NfsServerIPs = getNFSTrunkingDataLIFs(client, publishInfo.NfsServerIP, mountOptions)
 	publishInfo.FilesystemType = "nfs"
 	publishInfo.MountOptions = mountOptions
 
@@ -563,37 +680,37 @@ func (d *NASQtreeStorageDriver) publishQtreeShare(qtree, flexvol string, publish
 	}
 
 	// Ensure the qtree's volume has the correct export policy applied
-	return publishFlexVolShare(d.API, &d.Config, publishInfo, flexvol)
+	return publishFlexVolShare(d.API, &d.Config, publishInfo, nil, flexvol)
 }
 
 // GetSnapshot returns a snapshot of a volume, or an error if it does not exist.
-func (d *NASQtreeStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASQtreeStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "NASQtreeStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "GetSnapshot",
+			"Type":               "NASQtreeStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	return nil, drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // GetSnapshots returns the list of snapshots associated with the specified volume
-func (d *NASQtreeStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *NASQtreeStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":     "GetSnapshots",
-			"Type":       "NASQtreeStorageDriver",
-			"volumeName": volConfig.InternalName,
+			"Method":             "GetSnapshots",
+			"Type":               "NASQtreeStorageDriver",
+			utils.LogFieldVolume: volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	// Qtrees can't have snapshots, so return an empty list
@@ -601,7 +718,7 @@ func (d *NASQtreeStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *NASQtreeStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *NASQtreeStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -610,15 +727,15 @@ func (d *NASQtreeStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfi
 			"snapshotName": snapConfig.InternalName,
 			"sourceVolume": snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
 	return nil, drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *NASQtreeStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASQtreeStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -627,25 +744,25 @@ func (d *NASQtreeStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConf
 			"snapshotName": snapConfig.InternalName,
 			"sourceVolume": snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
 	return drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *NASQtreeStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *NASQtreeStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "DeleteSnapshot",
-			"Type":         "NASQtreeStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "DeleteSnapshot",
+			"Type":               "NASQtreeStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
 	return drivers.NewSnapshotsNotSupportedError(d.Name())
@@ -683,10 +800,11 @@ func (d *NASQtreeStorageDriver) Get(name string) error {
 func (d *NASQtreeStorageDriver) ensureFlexvolForQtree(
 	aggregate, spaceReserve, snapshotPolicy, tieringPolicy string, enableSnapshotDir bool, enableEncryption bool,
 	sizeBytes uint64, config drivers.OntapStorageDriverConfig, snapshotReserve, exportPolicy string,
+	storagePool *storage.Pool,
 ) (string, error) {
 
-	shouldLimitVolumeSize, flexvolQuotaSizeLimit, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(
-		sizeBytes, config.CommonStorageDriverConfig)
+	shouldLimitVolumeSize, flexvolQuotaSizeLimit, checkVolumeSizeLimitsError := checkVolumeSizeLimits(
+		sizeBytes, &config, storagePool)
 	if checkVolumeSizeLimitsError != nil {
 		return "", checkVolumeSizeLimitsError
 	}
@@ -722,7 +840,10 @@ func (d *NASQtreeStorageDriver) createFlexvolForQtree(
 	aggregate, spaceReserve, snapshotPolicy, tieringPolicy string, enableSnapshotDir bool, enableEncryption bool,
 	snapshotReserve, exportPolicy string) (string, error) {
 
-	flexvol := d.FlexvolNamePrefix() + utils.RandomString(10)
+	flexvol, err := d.getNewFlexvolName()
+	if err != nil {
+		return "", err
+	}
 	size := "1g"
 	unixPermissions := "0711"
 	if !d.Config.AutoExportPolicy {
@@ -750,10 +871,13 @@ func (d *NASQtreeStorageDriver) createFlexvolForQtree(
 	}).Debug("Creating Flexvol for qtrees.")
 
 	// Create the Flexvol
-	createResponse, err := d.API.VolumeCreate(
-		flexvol, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
-		exportPolicy, securityStyle, tieringPolicy, enableEncryption, snapshotReserveInt)
-	if err = api.GetError(createResponse, err); err != nil {
+	err = retryOntapOperation(context.Background(), &d.Config, retryOpVolumeCreate, func() error {
+		createResponse, err := d.API.VolumeCreate(
+			flexvol, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
+			exportPolicy, securityStyle, tieringPolicy, "", enableEncryption, snapshotReserveInt)
+		return api.GetError(createResponse, err)
+	})
+	if err != nil {
 		return "", fmt.Errorf("error creating Flexvol: %v", err)
 	}
 
@@ -829,7 +953,7 @@ func (d *NASQtreeStorageDriver) getFlexvolForQtree(
 				return "", fmt.Errorf("error enumerating qtrees: %v", err)
 			}
 
-			if count < maxQtreesPerFlexvol {
+			if count < d.qtreesPerFlexvol {
 				volumes = append(volumes, volName)
 			}
 		}
@@ -847,8 +971,8 @@ func (d *NASQtreeStorageDriver) getFlexvolForQtree(
 }
 
 // getOptimalSizeForFlexvol sums up all the disk limit quota rules on a Flexvol and adds the size of
-// the new qtree being added as well as the current Flexvol snapshot reserve.  This value may be used
-// to grow (or shrink) the Flexvol as new qtrees are being added.
+// the new qtree being added, scales the sum by the configured size ratio, and adds the current Flexvol
+// snapshot reserve.  This value may be used to grow (or shrink) the Flexvol as new qtrees are being added.
 func (d *NASQtreeStorageDriver) getOptimalSizeForFlexvol(
 	flexvol string, newQtreeSizeBytes uint64,
 ) (uint64, error) {
@@ -866,11 +990,12 @@ func (d *NASQtreeStorageDriver) getOptimalSizeForFlexvol(
 		return 0, err
 	}
 
-	usableSpaceBytes := float64(newQtreeSizeBytes + totalDiskLimitBytes)
+	usableSpaceBytes := float64(newQtreeSizeBytes+totalDiskLimitBytes) * d.flexvolSizeRatio
 	flexvolSizeBytes := uint64(usableSpaceBytes / snapReserveDivisor)
 
 	log.WithFields(log.Fields{
 		"flexvol":             flexvol,
+		"sizeRatio":           d.flexvolSizeRatio,
 		"snapReserveDivisor":  snapReserveDivisor,
 		"totalDiskLimitBytes": totalDiskLimitBytes,
 		"newQtreeSizeBytes":   newQtreeSizeBytes,
@@ -885,13 +1010,16 @@ func (d *NASQtreeStorageDriver) getOptimalSizeForFlexvol(
 // quota reinitialization.
 func (d *NASQtreeStorageDriver) addDefaultQuotaForFlexvol(flexvol string) error {
 
-	response, err := d.API.QuotaSetEntry("", flexvol, "", "tree", "-")
+	response, err := d.API.QuotaSetEntry("", flexvol, "", "tree", "-", "")
 	if err = api.GetError(response, err); err != nil {
 		return fmt.Errorf("error adding default quota: %v", err)
 	}
 
-	if err := d.disableQuotas(flexvol, true); err != nil {
-		log.Warningf("Could not disable quotas after adding a default quota: %v", err)
+	// A new Flexvol has quotas off, so they need only be turned on.  If they are already on, a resize
+	// brings the default rule into force without interrupting enforcement of any other qtree's quota.
+	if status, err := d.getQuotaStatus(flexvol); err == nil && status == "on" {
+		d.quotaResizeMap[flexvol] = true
+		return nil
 	}
 
 	if err := d.enableQuotas(flexvol, true); err != nil {
@@ -901,14 +1029,14 @@ func (d *NASQtreeStorageDriver) addDefaultQuotaForFlexvol(flexvol string) error
 	return nil
 }
 
-// setQuotaForQtree adds a tree quota to a Flexvol/qtree with a hard disk size limit if it doesn't exist.
-// If the quota already exists the hard disk size limit is updated.
+// setQuotaForQtree adds a tree quota to a Flexvol/qtree with a hard disk size limit, and the configured
+// file limit if any, if it doesn't exist.  If the quota already exists the limits are updated.
 func (d *NASQtreeStorageDriver) setQuotaForQtree(qtree, flexvol string, sizeBytes uint64) error {
 
 	target := fmt.Sprintf("/vol/%s/%s", flexvol, qtree)
 	sizeKB := strconv.FormatUint(sizeBytes/1024, 10)
 
-	response, err := d.API.QuotaSetEntry("", flexvol, target, "tree", sizeKB)
+	response, err := d.API.QuotaSetEntry("", flexvol, target, "tree", sizeKB, d.Config.QtreeFileLimit)
 	if err = api.GetError(response, err); err != nil {
 		return fmt.Errorf("error adding qtree quota: %v", err)
 	}
@@ -919,6 +1047,22 @@ func (d *NASQtreeStorageDriver) setQuotaForQtree(qtree, flexvol string, sizeByte
 	return nil
 }
 
+// resizeQuotasForFlexvol brings changed quota rules on a Flexvol into force right away with a quota resize,
+// which adjusts the limits of the changed rules without interrupting enforcement of the others.  If the
+// resize can't be started, the Flexvol stays flagged for the housekeeping task to try again.
+func (d *NASQtreeStorageDriver) resizeQuotasForFlexvol(flexvol string) {
+
+	response, err := d.API.QuotaResize(flexvol)
+	if err = api.GetError(response, err); err != nil {
+		log.WithFields(log.Fields{"flexvol": flexvol, "error": err}).Debug(
+			"Could not start quota resize, will retry.")
+		return
+	}
+
+	log.WithField("flexvol", flexvol).Debug("Started quota resize.")
+	delete(d.quotaResizeMap, flexvol)
+}
+
 // getQuotaDiskLimitSize returns the disk limit size for the specified quota.
 func (d *NASQtreeStorageDriver) getQuotaDiskLimitSize(name string, flexvol string) (uint64, error) {
 	quotaTarget := fmt.Sprintf("/vol/%s/%s", flexvol, name)
@@ -934,41 +1078,6 @@ func (d *NASQtreeStorageDriver) getQuotaDiskLimitSize(name string, flexvol strin
 	return quotaSize, nil
 }
 
-// enableQuotas disables quotas on a Flexvol, optionally waiting for the operation to finish.
-func (d *NASQtreeStorageDriver) disableQuotas(flexvol string, wait bool) error {
-
-	status, err := d.getQuotaStatus(flexvol)
-	if err != nil {
-		return fmt.Errorf("error disabling quotas: %v", err)
-	}
-	if status == "corrupt" {
-		return fmt.Errorf("error disabling quotas: quotas are corrupt on Flexvol %s", flexvol)
-	}
-
-	if status != "off" {
-		offResponse, err := d.API.QuotaOff(flexvol)
-		if err = api.GetError(offResponse, err); err != nil {
-			return fmt.Errorf("error disabling quotas: %v", err)
-		}
-	}
-
-	if wait {
-		for status != "off" {
-			time.Sleep(1 * time.Second)
-
-			status, err = d.getQuotaStatus(flexvol)
-			if err != nil {
-				return fmt.Errorf("error disabling quotas: %v", err)
-			}
-			if status == "corrupt" {
-				return fmt.Errorf("error disabling quotas: quotas are corrupt on flexvol %s", flexvol)
-			}
-		}
-	}
-
-	return nil
-}
-
 // enableQuotas enables quotas on a Flexvol, optionally waiting for the operation to finish.
 func (d *NASQtreeStorageDriver) enableQuotas(flexvol string, wait bool) error {
 
@@ -1046,7 +1155,7 @@ func (d *NASQtreeStorageDriver) resizeQuotas() {
 			}
 			if zerr := api.NewZapiError(resizeResponse); !zerr.IsPassed() {
 
-				if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
+				if zerr.IsNotFound() {
 					// Volume gone, so no need to try again
 					log.WithField("flexvol", flexvol).Debug("Volume does not exist.")
 					delete(d.quotaResizeMap, flexvol)
@@ -1221,7 +1330,7 @@ func (d *NASQtreeStorageDriver) ensureDefaultExportPolicy() error {
 		return fmt.Errorf("error creating export policy %s: %v", d.flexvolExportPolicy, err)
 	}
 	if zerr := api.NewZapiError(policyResponse); !zerr.IsPassed() {
-		if zerr.Code() == azgo.EDUPLICATEENTRY {
+		if zerr.IsExists() {
 			log.WithField("exportPolicy", d.flexvolExportPolicy).Debug("Export policy already exists.")
 		} else {
 			return fmt.Errorf("error creating export policy %s: %v", d.flexvolExportPolicy, zerr)
@@ -1246,9 +1355,9 @@ func (d *NASQtreeStorageDriver) ensureDefaultExportPolicyRule() error {
 		// No rules, so create one for IPv4 and IPv6
 		rules := []string{"0.0.0.0/0", "::/0"}
 		for _, rule := range rules {
+			roSecFlavors, rwSecFlavors, suSecFlavors := exportRuleSecFlavors(&d.Config, rule)
 			ruleResponse, err := d.API.ExportRuleCreate(
-				d.flexvolExportPolicy, rule,
-				[]string{"nfs"}, []string{"any"}, []string{"any"}, []string{"any"})
+				d.flexvolExportPolicy, rule, []string{"nfs"}, roSecFlavors, rwSecFlavors, suSecFlavors)
 			if err = api.GetError(ruleResponse, err); err != nil {
 				return fmt.Errorf("error creating export rule: %v", err)
 			}
@@ -1270,6 +1379,40 @@ func (d *NASQtreeStorageDriver) GetStorageBackendPhysicalPoolNames() []string {
 	return getStorageBackendPhysicalPoolNamesCommon(d.physicalPools)
 }
 
+// RefreshPools rediscovers the driver's storage pools from the aggregates assigned to the SVM.
+func (d *NASQtreeStorageDriver) RefreshPools(ctx context.Context) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "RefreshPools", "Type": "NASQtreeStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> RefreshPools")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RefreshPools")
+	}
+
+	physicalPools, virtualPools, err := refreshStoragePoolsCommon(d, d.getStoragePoolAttributes(), d.backendName())
+	if err != nil {
+		return err
+	}
+	d.physicalPools, d.virtualPools = physicalPools, virtualPools
+
+	// Read the new pools' capacity now, rather than leaving them empty until the next refresh
+	d.poolCapacity.SetPools(physicalPools, virtualPools)
+	d.poolCapacity.Refresh()
+
+	return nil
+}
+
+// WatchesPoolChanges returns true if the driver watches for events that may affect its storage pools.
+func (d *NASQtreeStorageDriver) WatchesPoolChanges() bool {
+	return d.eventWatcher != nil
+}
+
+// SetPoolChangeHandler registers a function to be called whenever the storage pools may have changed.
+func (d *NASQtreeStorageDriver) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	if d.eventWatcher != nil {
+		d.eventWatcher.SetPoolChangeHandler(handler)
+	}
+}
+
 func (d *NASQtreeStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
@@ -1320,13 +1463,17 @@ func (d *NASQtreeStorageDriver) GetInternalVolumeName(name string) string {
 }
 
 func (d *NASQtreeStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
-	createPrepareCommon(d, volConfig)
+	createPrepareCommon(d, volConfig, maxQtreeNameLength, func(name string) (bool, error) {
+		exists, _, err := d.API.QtreeExists(name, d.FlexvolNamePrefix())
+		return exists, err
+	})
 }
 
-func (d *NASQtreeStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *NASQtreeStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
+	client := d.API.WithContext(ctx)
 	// Determine which Flexvol contains the qtree
-	exists, flexvol, err := d.API.QtreeExists(volConfig.InternalName, d.FlexvolNamePrefix())
+	exists, flexvol, err := client.QtreeExists(volConfig.InternalName, d.FlexvolNamePrefix())
 	if err != nil {
 		return fmt.Errorf("could not determine if qtree %s exists: %v", volConfig.InternalName, err)
 	}
@@ -1335,9 +1482,12 @@ func (d *NASQtreeStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig)
 	}
 
 	// Set export path info on the volume config
-	volConfig.AccessInfo.NfsServerIP = d.Config.DataLIF
+	volConfig.AccessInfo.NfsServerIP = d.dataLIF.get(&d.Config)
 	volConfig.AccessInfo.NfsPath = fmt.Sprintf("/%s/%s", flexvol, volConfig.InternalName)
 	volConfig.AccessInfo.MountOptions = strings.TrimPrefix(d.Config.NfsMountOptions, "-o ")
+	if volConfig.MountOptions != "" {
+		volConfig.AccessInfo.MountOptions = strings.TrimPrefix(volConfig.MountOptions, "-o ")
+	}
 
 	return nil
 }
@@ -1346,6 +1496,14 @@ func (d *NASQtreeStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.File
 }
 
+func (d *NASQtreeStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.FileAccessModes
+}
+
+func (d *NASQtreeStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.FileVolumeExpansion
+}
+
 func (d *NASQtreeStorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
 	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
 	b.OntapConfig = &d.Config
@@ -1676,18 +1834,27 @@ func NewResizeTask(d *NASQtreeStorageDriver, tasks []func()) *HousekeepingTask {
 }
 
 // Resize expands the Flexvol containing the Qtree and updates the Qtree quota.
-func (d *NASQtreeStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *NASQtreeStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	return d.ResizeInPool(ctx, volConfig, nil, sizeBytes)
+}
 
+// ResizeInPool is Resize for a volume whose pool is known, enforcing the pool's size limit rather than
+// the backend's.  The pool may be nil.
+func (d *NASQtreeStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
+
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":    "Resize",
+			"Method":    "ResizeInPool",
 			"Type":      "NASQtreeStorageDriver",
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		logc(ctx).WithFields(fields).Debug(">>>> ResizeInPool")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ResizeInPool")
 	}
 
 	// Ensure any Flexvol won't be pruned before resize is completed.
@@ -1698,54 +1865,56 @@ func (d *NASQtreeStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeByte
 	resizeError := errors.New("storage driver failed to resize the volume")
 
 	// Check that volume exists
-	exists, flexvol, err := d.API.QtreeExists(name, d.FlexvolNamePrefix())
+	exists, flexvol, err := client.QtreeExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.WithField("error", err).Error("Error checking for existing volume.")
+		logc(ctx).WithField("error", err).Error("Error checking for existing volume.")
 		return resizeError
 	}
 	if !exists {
-		log.WithFields(log.Fields{"qtree": name, "flexvol": flexvol}).Debug("Qtree does not exist.")
+		logc(ctx).WithFields(log.Fields{"qtree": name, "flexvol": flexvol}).Debug("Qtree does not exist.")
 		return fmt.Errorf("volume %s does not exist", name)
 	}
 
 	// Calculate the delta size needed to resize the Qtree quota
 	quotaSize, err := d.getQuotaDiskLimitSize(name, flexvol)
 	if err != nil {
-		log.WithField("error", err).Error("Failed to determine quota size.")
+		logc(ctx).WithField("error", err).Error("Failed to determine quota size.")
 		return resizeError
 	}
 
 	volConfig.Size = strconv.FormatUint(quotaSize, 10)
 	if sizeBytes == quotaSize {
-		log.Infof("Requested size and existing volume size are the same for volume %s.", name)
+		logc(ctx).Infof("Requested size and existing volume size are the same for volume %s.", name)
 		return nil
 	}
 
 	if sizeBytes < quotaSize {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, quotaSize)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, quotaSize), nil)
 	}
 	deltaQuotaSize := sizeBytes - quotaSize
 
-	if aggrLimitsErr := checkAggregateLimitsForFlexvol(flexvol, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+	if aggrLimitsErr := checkAggregateLimitsForFlexvol(flexvol, sizeBytes, d.Config, client); aggrLimitsErr != nil {
 		return aggrLimitsErr
 	}
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
 	err = d.resizeFlexvol(flexvol, deltaQuotaSize)
 	if err != nil {
-		log.WithField("error", err).Error("Failed to resize flexvol.")
+		logc(ctx).WithField("error", err).Error("Failed to resize flexvol.")
 		return resizeError
 	}
 
-	// Update the quota
+	// Update the quota, and put the new limit in force without waiting for the housekeeping task
 	err = d.setQuotaForQtree(name, flexvol, sizeBytes)
 	if err != nil {
-		log.WithField("error", err).Error("Qtree quota update failed.")
+		logc(ctx).WithField("error", err).Error("Qtree quota update failed.")
 		return resizeError
 	}
+	d.resizeQuotasForFlexvol(flexvol)
 
 	volConfig.Size = strconv.FormatUint(sizeBytes, 10)
 	return nil
@@ -0,0 +1,18 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+func TestNASQtreeResizeInPool(t *testing.T) {
+	testResizeInPool(t, func(config *drivers.OntapStorageDriverConfig, client *api.Client) storage.PoolResizer {
+		return &NASQtreeStorageDriver{Config: *config, API: client, flexvolNamePrefix: "trident_qtree_pool_test_",
+			quotaResizeMap: make(map[string]bool)}
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+func TestNASResizeInPool(t *testing.T) {
+	testResizeInPool(t, func(config *drivers.OntapStorageDriverConfig, client *api.Client) storage.PoolResizer {
+		return &NASStorageDriver{Config: *config, API: client}
+	})
+}
+
+func TestNASDestroyVolume(t *testing.T) {
+
+	tests := map[string]struct {
+		flexcacheOrigin string
+		ontapiVersion   string
+		checked         bool
+	}{
+		"FlexVol":                         {ontapiVersion: "1.150"},
+		"FlexCache":                       {flexcacheOrigin: "svm1:origin", ontapiVersion: "1.150", checked: true},
+		"FlexCache without FlexCache API": {flexcacheOrigin: "svm1:origin", ontapiVersion: "1.140"},
+	}
+	for name, test := range tests {
+		checked, destroyed := false, false
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			request := string(body)
+			result := `<results status="passed"><num-records>0</num-records></results>`
+			switch {
+			case strings.Contains(request, "<flexcache-endpoint-type>"):
+				checked = true
+			case strings.Contains(request, "<volume-destroy>"):
+				destroyed = true
+				result = `<results status="passed"></results>`
+			}
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+				`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+		}))
+
+		client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+		client.SetOntapiVersion(test.ontapiVersion)
+		driver := &NASStorageDriver{Config: *newTestOntapSANConfig(), API: client}
+		volConfig := &storage.VolumeConfig{InternalName: "trident_pvc_1", FlexcacheOrigin: test.flexcacheOrigin}
+
+		assert.NoError(t, driver.DestroyVolume(context.Background(), volConfig), name)
+		assert.Equal(t, test.checked, checked, name)
+		assert.True(t, destroyed, name)
+
+		server.Close()
+	}
+}
@@ -3,6 +3,7 @@
 package ontap
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -23,16 +24,40 @@ func lunPath(name string) string {
 	return fmt.Sprintf("/vol/%v/lun0", name)
 }
 
+// The LUN options are shared by both SAN drivers
+func init() {
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name:  SpaceAllocation,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string { return defaults.SpaceAllocation },
+	}, drivers.OntapSANStorageDriverName, drivers.OntapSANEconomyStorageDriverName)
+
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name:  FileSystemType,
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string { return defaults.FileSystemType },
+	}, drivers.OntapSANStorageDriverName, drivers.OntapSANEconomyStorageDriverName)
+}
+
 // SANStorageDriver is for iSCSI storage provisioning
 type SANStorageDriver struct {
 	initialized bool
 	Config      drivers.OntapStorageDriverConfig
-	ips         []string
+	dataLIFs    *ISCSIDataLIFs
 	API         *api.Client
 	Telemetry   *Telemetry
 
+	housekeeping   *HousekeepingScheduler
+	cloneSplitter  *CloneSplitter
+	cloneSplits    *CloneSplitTracker
+	cloneSnapshots *CloneSnapshotReaper
+	volumeMoves    *VolumeMoveTracker
+	volumeCopies   *VolumeCopyTracker
+	recoveryQueue  *RecoveryQueue
+	orphans        *OrphanFinder
+
 	physicalPools map[string]*storage.Pool
 	virtualPools  map[string]*storage.Pool
+	poolCapacity  *PoolCapacityMonitor
+	eventWatcher  *EventWatcher
 }
 
 func (d *SANStorageDriver) GetConfig() *drivers.OntapStorageDriverConfig {
@@ -43,6 +68,67 @@ func (d *SANStorageDriver) GetAPI() *api.Client {
 	return d.API
 }
 
+// SetAPITraceEnabled turns tracing of the driver's API calls on or off.
+func (d *SANStorageDriver) SetAPITraceEnabled(enabled bool) {
+	d.API.SetAPITraceEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and its API client.
+func (d *SANStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	d.API.SetDebugTraceFlags(flags)
+}
+
+// SetAPILogLevel sets the level at which messages about the driver's API calls are logged.
+func (d *SANStorageDriver) SetAPILogLevel(level string) error {
+	return d.API.SetLogLevel(level)
+}
+
+// GetAPILogLevel returns the level at which messages about the driver's API calls are logged, if set.
+func (d *SANStorageDriver) GetAPILogLevel() string {
+	return d.API.LogLevel()
+}
+
+// LogFields returns the fields the driver adds to the messages logged for operations on its backend.
+func (d *SANStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+// ListStorageJobs returns the clone splits, volume moves, and volume copies still in progress.
+func (d *SANStorageDriver) ListStorageJobs() []*storage.StorageJob {
+	jobs := append(d.cloneSplits.Jobs(), d.volumeMoves.Jobs()...)
+	return append(jobs, d.volumeCopies.Jobs()...)
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on the driver's SVM.
+func (d *SANStorageDriver) GetFeatures() map[string]bool {
+	return d.API.Features()
+}
+
+// GetStorageLogs returns the recent jobs of the driver's SVM and the recent EMS events of its cluster.
+func (d *SANStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+	return getStorageLogs(d.API.WithContext(ctx))
+}
+
+// CheckHealth returns an error if the driver's SVM can't be reached or isn't running.
+func (d *SANStorageDriver) CheckHealth(ctx context.Context) error {
+	return checkSVMHealth(d.API.WithContext(ctx))
+}
+
+// resolveDataLIFs rediscovers the iSCSI data LIFs after the driver fails over to another SVM.
+func (d *SANStorageDriver) resolveDataLIFs() error {
+	d.dataLIFs.Refresh()
+	return nil
+}
+
+func (d *SANStorageDriver) GetCloneSplitTracker() *CloneSplitTracker {
+	return d.cloneSplits
+}
+
+func (d *SANStorageDriver) GetCloneSnapshotReaper() *CloneSnapshotReaper {
+	return d.cloneSnapshots
+}
+
 func (d *SANStorageDriver) GetTelemetry() *Telemetry {
 	d.Telemetry.Telemetry = tridentconfig.OrchestratorTelemetry
 	return d.Telemetry
@@ -57,12 +143,17 @@ func (d SANStorageDriver) Name() string {
 func (d *SANStorageDriver) backendName() string {
 	if d.Config.BackendName == "" {
 		// Use the old naming scheme if no name is specified
-		return CleanBackendName("ontapsan_" + d.ips[0])
+		return CleanBackendName("ontapsan_" + d.dataLIFs.IPs()[0])
 	} else {
 		return d.Config.BackendName
 	}
 }
 
+// SetBackendUUID records the UUID of the driver's backend, with which the driver tags the FlexVols it creates.
+func (d *SANStorageDriver) SetBackendUUID(backendUUID string) {
+	d.Config.BackendUUID = backendUUID
+}
+
 // Initialize from the provided config
 func (d *SANStorageDriver) Initialize(
 	context tridentconfig.DriverContext, configJSON string, commonConfig *drivers.CommonStorageDriverConfig,
@@ -87,15 +178,14 @@ func (d *SANStorageDriver) Initialize(
 	}
 	d.Config = *config
 
-	d.ips, err = d.API.NetInterfaceGetDataLIFs("iscsi")
-	if err != nil {
-		return err
+	// Create the snapshot policies the config asks for, or correct them if they have drifted
+	if err = reconcileSnapshotPolicies(d.API, &d.Config); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
 	}
 
-	if len(d.ips) == 0 {
-		return fmt.Errorf("no iSCSI data LIFs found on SVM %s", config.SVM)
-	} else {
-		log.WithField("dataLIFs", d.ips).Debug("Found iSCSI LIFs.")
+	d.dataLIFs, err = NewISCSIDataLIFs(&d.Config, d.API)
+	if err != nil {
+		return err
 	}
 
 	d.physicalPools, d.virtualPools, err = InitializeStoragePoolsCommon(d, d.getStoragePoolAttributes(),
@@ -109,9 +199,56 @@ func (d *SANStorageDriver) Initialize(
 		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
 	}
 
-	// Set up the autosupport heartbeat
+	// Set up the autosupport heartbeat and other periodic housekeeping
 	d.Telemetry = NewOntapTelemetry(d)
-	d.Telemetry.Start()
+	d.housekeeping = NewOntapHousekeepingScheduler(d)
+	d.cloneSplits = NewCloneSplitTracker(&d.Config, d.API)
+	d.cloneSplitter = NewCloneSplitter(&d.Config, d.API, d.cloneSplits)
+	if err = d.housekeeping.AddJob(d.cloneSplitter.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if err = d.housekeeping.AddJob(d.cloneSplits.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.cloneSnapshots = NewCloneSnapshotReaper(&d.Config, d.API)
+	d.cloneSplits.OnComplete(d.cloneSnapshots.splitComplete)
+	if err = d.housekeeping.AddJob(d.cloneSnapshots.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.volumeCopies = NewVolumeCopyTracker()
+	d.volumeMoves = NewVolumeMoveTracker(d.API)
+	if err = d.housekeeping.AddJob(d.volumeMoves.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if err = d.housekeeping.AddJob(d.dataLIFs.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if spaceReclamationInterval, _ := getSpaceReclamationInterval(&d.Config); spaceReclamationInterval > 0 {
+		lunPathPattern := fmt.Sprintf("/vol/%v/lun0", *d.Config.StoragePrefix+"*")
+		reclaimer := NewSpaceReclaimer(d.API, lunPathPattern)
+		if err = d.housekeeping.AddJob(reclaimer.HousekeepingJob(spaceReclamationInterval)); err != nil {
+			return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+		}
+	}
+	d.poolCapacity = NewPoolCapacityMonitor(&d.Config, d.API, d.physicalPools, d.virtualPools, false)
+	if err = d.housekeeping.AddJob(d.poolCapacity.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if eventWatchInterval, _ := getEventWatchInterval(&d.Config); eventWatchInterval > 0 {
+		d.eventWatcher = NewEventWatcher(d.API)
+		d.eventWatcher.OnDataLIFChange(func() { d.dataLIFs.Refresh() })
+		if err = d.housekeeping.AddJob(d.eventWatcher.HousekeepingJob(eventWatchInterval)); err != nil {
+			return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+		}
+	}
+	softDeleteRetention, _ := getSoftDeleteRetention(&d.Config)
+	d.recoveryQueue = NewRecoveryQueue(d.API, "san", softDeleteRetention, d.releaseQueuedVolume,
+		d.restoreQueuedVolume, d.destroyQueuedVolume)
+	if err = d.housekeeping.AddJob(d.recoveryQueue.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.orphans = NewOrphanFinder(&d.Config, d.API, tridentconfig.Block)
+	d.housekeeping.Start()
 
 	d.initialized = true
 	return nil
@@ -128,8 +265,8 @@ func (d *SANStorageDriver) Terminate(string) {
 		log.WithFields(fields).Debug(">>>> Terminate")
 		defer log.WithFields(fields).Debug("<<<< Terminate")
 	}
-	if d.Telemetry != nil {
-		d.Telemetry.Stop()
+	if d.housekeeping != nil {
+		d.housekeeping.Stop()
 	}
 	d.initialized = false
 }
@@ -143,7 +280,19 @@ func (d *SANStorageDriver) validate() error {
 		defer log.WithFields(fields).Debug("<<<< validate")
 	}
 
-	if err := ValidateSANDriver(d.API, &d.Config, d.ips); err != nil {
+	if err := ValidateSANDriver(d.API, &d.Config, d.dataLIFs.IPs()); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	if _, err := getSpaceReclamationInterval(&d.Config); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	if _, err := getSoftDeleteRetention(&d.Config); err != nil {
+		return fmt.Errorf("driver validation failed: %v", err)
+	}
+
+	if _, err := getOrphanPolicy(&d.Config); err != nil {
 		return fmt.Errorf("driver validation failed: %v", err)
 	}
 
@@ -156,9 +305,10 @@ func (d *SANStorageDriver) validate() error {
 
 // Create a volume+LUN with the specified options
 func (d *SANStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	var fstype string
@@ -170,17 +320,23 @@ func (d *SANStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
-	// If the volume already exists, bail out
-	volExists, err := d.API.VolumeExists(name)
+	// If the volume already exists, bail out, letting a volume left by an earlier attempt be completed if
+	// its LUN was created, or destroyed if not
+	volExists, err := client.VolumeExists(name)
 	if err != nil {
 		return fmt.Errorf("error checking for existing volume: %v", err)
 	}
 	if volExists {
-		return drivers.NewVolumeExistsError(name)
+		if err = checkExistingFlexvol(ctx, client, name, volConfig.UUID, true, func() (bool, error) {
+			count, err := client.LunCount(name)
+			return count > 0, err
+		}); err != nil {
+			return err
+		}
 	}
 
 	// Get candidate physical pools
@@ -222,8 +378,9 @@ func (d *SANStorageDriver) Create(
 	securityStyle := utils.GetV(opts, "securityStyle", storagePool.InternalAttributes[SecurityStyle])
 	encryption := utils.GetV(opts, "encryption", storagePool.InternalAttributes[Encryption])
 	tieringPolicy := utils.GetV(opts, "tieringPolicy", storagePool.InternalAttributes[TieringPolicy])
+	tieringMinimumCoolingDays := storagePool.InternalAttributes[TieringMinimumCoolingDays]
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
@@ -244,10 +401,18 @@ func (d *SANStorageDriver) Create(
 	}
 
 	if tieringPolicy == "" {
-		tieringPolicy = d.API.TieringPolicyValue()
+		tieringPolicy = client.TieringPolicyValue()
+	}
+
+	// Space allocation can't be enabled once the LUN is online, and the blocks hosts trim from a LUN
+	// without it aren't reclaimed
+	if interval, _ := getSpaceReclamationInterval(&d.Config); interval > 0 && !spaceAllocation {
+		logc(ctx).WithField("name", name).Warning(
+			"Enabling space allocation on the LUN, since space reclamation is enabled.")
+		spaceAllocation = true
 	}
 
-	log.WithFields(log.Fields{
+	logc(ctx).WithFields(log.Fields{
 		"name":            name,
 		"size":            size,
 		"spaceAllocation": spaceAllocation,
@@ -259,8 +424,12 @@ func (d *SANStorageDriver) Create(
 		"exportPolicy":    exportPolicy,
 		"securityStyle":   securityStyle,
 		"encryption":      enableEncryption,
+		"coolingDays":     tieringMinimumCoolingDays,
 	}).Debug("Creating Flexvol.")
 
+	// Tag the Flexvol with the request it is created for, so a retry can recognize it
+	comment := getFlexvolComment(&d.Config, volConfig)
+
 	createErrors := make([]error, 0)
 	physicalPoolNames := make([]string, 0)
 
@@ -268,80 +437,109 @@ func (d *SANStorageDriver) Create(
 		aggregate := physicalPool.Name
 		physicalPoolNames = append(physicalPoolNames, aggregate)
 
-		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, client); aggrLimitsErr != nil {
 			errMessage := fmt.Sprintf("ONTAP-SAN pool %s/%s; error: %v", storagePool.Name, aggregate, aggrLimitsErr)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
+		if err := checkAggregateOverprovisioning(aggregate, spaceReserve, sizeBytes,
+			storagePool.InternalAttributes[MaxOverprovisionRatio], &d.Config, client); err != nil {
+			logc(ctx).Errorf("ONTAP-SAN pool %s/%s; error: %v", storagePool.Name, aggregate, err)
+			createErrors = append(createErrors, fmt.Errorf("ONTAP-SAN pool %s/%s; error: %w", storagePool.Name,
+				aggregate, err))
+			continue
+		}
+
 		// Create the volume
-		volCreateResponse, err := d.API.VolumeCreate(
-			name, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
-			exportPolicy, securityStyle, tieringPolicy, enableEncryption, snapshotReserveInt)
+		err := retryOntapOperation(ctx, &d.Config, retryOpVolumeCreate, func() error {
+			volCreateResponse, err := client.VolumeCreate(
+				name, aggregate, size, spaceReserve, snapshotPolicy, unixPermissions,
+				exportPolicy, securityStyle, tieringPolicy, comment, enableEncryption, snapshotReserveInt)
+			return api.GetError(volCreateResponse, err)
+		})
 
-		if err = api.GetError(volCreateResponse, err); err != nil {
+		if err != nil {
 			if zerr, ok := err.(api.ZapiError); ok {
 				// Handle case where the Create is passed to every Docker Swarm node
-				if zerr.Code() == azgo.EAPIERROR && strings.HasSuffix(strings.TrimSpace(zerr.Reason()), "Job exists") {
-					log.WithField("volume", name).Warn("Volume create job already exists, " +
+				if zerr.IsJobExistsError() {
+					logc(ctx).WithField("volume", name).Warn("Volume create job already exists, " +
 						"skipping volume create on this node.")
 					return nil
 				}
 			}
 
+			// The create may have failed after ONTAP created the volume, as when the request times out
+			cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
+
 			errMessage := fmt.Sprintf("ONTAP-SAN pool %s/%s; error creating volume %s: %v", storagePool.Name,
 				aggregate, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
+		// Set the cooling period that tiering policies use to decide which data is cold
+		if tieringMinimumCoolingDays != "" {
+			coolingDays, err := parseTieringMinimumCoolingDays(tieringMinimumCoolingDays)
+			if err != nil {
+				return err
+			}
+			coolingResponse, err := client.VolumeModifyTieringMinimumCoolingDays(name, coolingDays)
+			if err = api.GetError(coolingResponse, err); err != nil {
+				cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
+				return fmt.Errorf("error setting tiering minimum cooling days: %v", err)
+			}
+		}
+
 		lunPath := lunPath(name)
 		osType := "linux"
 
 		// Create the LUN
-		lunCreateResponse, err := d.API.LunCreate(lunPath, int(sizeBytes), osType, false, spaceAllocation)
+		lunCreateResponse, err := client.LunCreate(lunPath, int(sizeBytes), osType, false, spaceAllocation)
 		if err = api.GetError(lunCreateResponse, err); err != nil {
+			cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
 			errMessage := fmt.Sprintf("ONTAP-SAN pool %s/%s; error creating LUN %s: %v", storagePool.Name,
 				aggregate, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
 		// Save the fstype in a LUN attribute so we know what to do in Attach
-		attrResponse, err := d.API.LunSetAttribute(lunPath, LUNAttributeFSType, fstype)
+		attrResponse, err := client.LunSetAttribute(lunPath, LUNAttributeFSType, fstype)
 		if err = api.GetError(attrResponse, err); err != nil {
-			defer d.API.LunDestroy(lunPath)
+			client.LunDestroy(lunPath)
+			cleanupFailedFlexvolCreate(ctx, client, name, volConfig.UUID)
 			return fmt.Errorf("ONTAP-SAN pool %s/%s; error saving file system type for LUN %s: %v", storagePool.Name,
 				aggregate, name, err)
 		}
 		// Save the context
-		attrResponse, err = d.API.LunSetAttribute(lunPath, "context", string(d.Config.DriverContext))
+		attrResponse, err = client.LunSetAttribute(lunPath, "context", string(d.Config.DriverContext))
 		if err = api.GetError(attrResponse, err); err != nil {
-			log.WithField("name", name).Warning("Failed to save the driver context attribute for new volume.")
+			logc(ctx).WithField("name", name).Warning("Failed to save the driver context attribute for new volume.")
 		}
 
 		// Resize FlexVol to be the same size or bigger than LUN because ONTAP creates
 		// larger LUNs sometimes based on internal geometry
 		lunSize := uint64(lunCreateResponse.Result.ActualSize())
-		if initialVolumeSize, err := d.API.VolumeSize(name); err != nil {
-			log.WithField("name", name).Warning("Failed to get volume size.")
+		if initialVolumeSize, err := client.VolumeSize(name); err != nil {
+			logc(ctx).WithField("name", name).Warning("Failed to get volume size.")
 		} else if lunSize != uint64(initialVolumeSize) {
-			volumeSizeResponse, err := d.API.VolumeSetSize(name, strconv.FormatUint(lunSize, 10))
+			volumeSizeResponse, err := client.VolumeSetSize(name, strconv.FormatUint(lunSize, 10))
 			if err = api.GetError(volumeSizeResponse, err); err != nil {
 				volConfig.Size = strconv.FormatUint(uint64(initialVolumeSize), 10)
-				log.WithFields(log.Fields{
+				logc(ctx).WithFields(log.Fields{
 					"name":              name,
 					"initialVolumeSize": initialVolumeSize,
 					"lunSize":           lunSize}).Warning("Failed to resize new volume to LUN size.")
 			} else {
-				if adjustedVolumeSize, err := d.API.VolumeSize(name); err != nil {
-					log.WithField("name", name).Warning("Failed to get volume size after the second resize operation.")
+				if adjustedVolumeSize, err := client.VolumeSize(name); err != nil {
+					logc(ctx).WithField("name", name).Warning("Failed to get volume size after the second resize operation.")
 				} else {
 					volConfig.Size = strconv.FormatUint(uint64(adjustedVolumeSize), 10)
-					log.WithFields(log.Fields{
+					logc(ctx).WithFields(log.Fields{
 						"name":              name,
 						"initialVolumeSize": initialVolumeSize,
 						"adjustedVolSize":   adjustedVolumeSize}).Debug("FlexVol resized.")
@@ -351,13 +549,15 @@ func (d *SANStorageDriver) Create(
 		return nil
 	}
 
-	// All physical pools that were eligible ultimately failed, so don't try this backend again
-	return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
+	// All physical pools that were eligible ultimately failed, so don't try this backend again unless
+	// only the pool's overprovisioning limit stood in the way
+	return newAggregateCreateError(name, storagePool.Name, createErrors, physicalPoolNames)
 }
 
 // Create a volume clone
-func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+func (d *SANStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	source := volConfig.CloneSourceVolumeInternal
 	snapshot := volConfig.CloneSourceSnapshot
@@ -371,8 +571,8 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 			"snapshot":    snapshot,
 			"storagePool": storagePool,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
 	opts, err := d.GetVolumeOpts(volConfig, make(map[string]sa.Request))
@@ -402,11 +602,15 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 		return fmt.Errorf("invalid boolean value for splitOnClone: %v", err)
 	}
 
-	log.WithField("splitOnClone", split).Debug("Creating volume clone.")
-	return CreateOntapClone(name, source, snapshot, split, &d.Config, d.API, false)
+	logc(ctx).WithField("splitOnClone", split).Debug("Creating volume clone.")
+	return CreateOntapClone(ctx, name, source, snapshot, volConfig.UUID, split, &d.Config, client, false, d.cloneSplits,
+		d.cloneSnapshots, nil)
 }
 
-func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *SANStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
+
+	client := d.API.WithContext(ctx)
+
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
 			"Method":       "Import",
@@ -415,12 +619,12 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 			"newName":      volConfig.InternalName,
 			"notManaged":   volConfig.ImportNotManaged,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	// Ensure the volume exists
-	flexvol, err := d.API.VolumeGet(originalName)
+	flexvol, err := client.VolumeGet(originalName)
 	if err != nil {
 		return err
 	} else if flexvol == nil {
@@ -428,7 +632,7 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	}
 
 	// Ensure the volume has only one LUN
-	lunInfo, err := d.API.LunGet("/vol/" + originalName + "/*")
+	lunInfo, err := client.LunGet("/vol/" + originalName + "/*")
 	if err != nil {
 		return err
 	}
@@ -438,7 +642,7 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	if flexvol.VolumeIdAttributesPtr != nil {
 		volumeIdAttrs := flexvol.VolumeIdAttributes()
 		if volumeIdAttrs.TypePtr != nil && volumeIdAttrs.Type() != "rw" {
-			log.WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
+			logc(ctx).WithField("originalName", originalName).Error("Could not import volume, type is not rw.")
 			return fmt.Errorf("volume %s type is %s, not rw", originalName, volumeIdAttrs.Type())
 		}
 	}
@@ -452,7 +656,7 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 
 	// Use the LUN size
 	if lunInfo.SizePtr == nil {
-		log.WithField("originalName", originalName).Errorf("Could not import volume, size not available")
+		logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, size not available")
 		return fmt.Errorf("volume %s size not available", originalName)
 	}
 	volConfig.Size = strconv.FormatInt(int64(lunInfo.Size()), 10)
@@ -460,16 +664,16 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	// Rename the volume or LUN if Trident will manage its lifecycle
 	if !volConfig.ImportNotManaged {
 		if lunInfo.Path() != targetPath {
-			renameResponse, err := d.API.LunRename(lunInfo.Path(), targetPath)
+			renameResponse, err := client.LunRename(lunInfo.Path(), targetPath)
 			if err = api.GetError(renameResponse, err); err != nil {
-				log.WithField("path", lunInfo.Path()).Errorf("Could not import volume, rename LUN failed: %v", err)
+				logc(ctx).WithField("path", lunInfo.Path()).Errorf("Could not import volume, rename LUN failed: %v", err)
 				return fmt.Errorf("LUN path %s rename failed: %v", lunInfo.Path(), err)
 			}
 		}
 
-		renameResponse, err := d.API.VolumeRename(originalName, volConfig.InternalName)
+		renameResponse, err := client.VolumeRename(originalName, volConfig.InternalName)
 		if err = api.GetError(renameResponse, err); err != nil {
-			log.WithField("originalName", originalName).Errorf("Could not import volume, rename volume failed: %v", err)
+			logc(ctx).WithField("originalName", originalName).Errorf("Could not import volume, rename volume failed: %v", err)
 			return fmt.Errorf("volume %s rename failed: %v", originalName, err)
 		}
 	} else {
@@ -490,7 +694,10 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	return nil
 }
 
-func (d *SANStorageDriver) Rename(name string, newName string) error {
+func (d *SANStorageDriver) Rename(ctx context.Context, name string, newName string) error {
+
+	client := d.API.WithContext(ctx)
+
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
 			"Method":  "Rename",
@@ -498,13 +705,13 @@ func (d *SANStorageDriver) Rename(name string, newName string) error {
 			"name":    name,
 			"newName": newName,
 		}
-		log.WithFields(fields).Debug(">>>> Rename")
-		defer log.WithFields(fields).Debug("<<<< Rename")
+		logc(ctx).WithFields(fields).Debug(">>>> Rename")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Rename")
 	}
 
-	renameResponse, err := d.API.VolumeRename(name, newName)
+	renameResponse, err := client.VolumeRename(name, newName)
 	if err = api.GetError(renameResponse, err); err != nil {
-		log.WithField("name", name).Warnf("Could not rename volume: %v", err)
+		logc(ctx).WithField("name", name).Warnf("Could not rename volume: %v", err)
 		return fmt.Errorf("could not rename volume %s: %v", name, err)
 	}
 
@@ -512,7 +719,9 @@ func (d *SANStorageDriver) Rename(name string, newName string) error {
 }
 
 // Destroy the requested (volume,lun) storage tuple
-func (d *SANStorageDriver) Destroy(name string) error {
+func (d *SANStorageDriver) Destroy(ctx context.Context, name string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -520,8 +729,8 @@ func (d *SANStorageDriver) Destroy(name string) error {
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	var (
@@ -531,27 +740,27 @@ func (d *SANStorageDriver) Destroy(name string) error {
 	)
 
 	// Validate Flexvol exists before trying to destroy
-	volExists, err := d.API.VolumeExists(name)
+	volExists, err := client.VolumeExists(name)
 	if err != nil {
 		return fmt.Errorf("error checking for existing volume: %v", err)
 	}
 	if !volExists {
-		log.WithField("volume", name).Debug("Volume already deleted, skipping destroy.")
+		logc(ctx).WithField("volume", name).Debug("Volume already deleted, skipping destroy.")
 		return nil
 	}
 
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
 
 		// Get target info
-		iSCSINodeName, _, err = GetISCSITargetInfo(d.API, &d.Config)
+		iSCSINodeName, _, err = GetISCSITargetInfo(client, &d.Config)
 		if err != nil {
-			log.WithField("error", err).Error("Could not get target info.")
+			logc(ctx).WithField("error", err).Error("Could not get target info.")
 			return err
 		}
 
 		// Get the LUN ID
 		lunPath := fmt.Sprintf("/vol/%s/lun0", name)
-		lunMapResponse, err := d.API.LunMapListInfo(lunPath)
+		lunMapResponse, err := client.LunMapListInfo(lunPath)
 		if err != nil {
 			return fmt.Errorf("error reading LUN maps for volume %s: %v", name, err)
 		}
@@ -569,28 +778,155 @@ func (d *SANStorageDriver) Destroy(name string) error {
 		}
 	}
 
+	// Keep the volume in the recovery queue for a while, with its LUN offline
+	if d.recoveryQueue.Enabled() {
+		return d.recoveryQueue.Enqueue(ctx, name)
+	}
+
+	return d.destroyFlexvol(ctx, name, name)
+}
+
+// destroyFlexvol destroys a volume's FlexVol and LUN.  The FlexVol may have been renamed into the recovery
+// queue.
+func (d *SANStorageDriver) destroyFlexvol(ctx context.Context, flexvol, name string) error {
+
 	// Delete the Flexvol & LUN
-	volDestroyResponse, err := d.API.VolumeDestroy(name, true)
+	volDestroyResponse, err := d.API.WithContext(ctx).VolumeDestroy(flexvol, true)
 	if err != nil {
 		return fmt.Errorf("error destroying volume %v: %v", name, err)
 	}
 	if zerr := api.NewZapiError(volDestroyResponse); !zerr.IsPassed() {
 		// Handle case where the Destroy is passed to every Docker Swarm node
-		if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
-			log.WithField("volume", name).Warn("Volume already deleted.")
+		if zerr.IsNotFound() {
+			logc(ctx).WithField("volume", name).Warn("Volume already deleted.")
 		} else {
 			return fmt.Errorf("error destroying volume %v: %v", name, zerr)
 		}
 	}
 
+	// A clone no longer needs the snapshot it was created from
+	d.cloneSnapshots.Release(ctx, name)
+
 	return nil
 }
 
+// releaseQueuedVolume takes the LUN of a volume entering the recovery queue offline, so hosts can no
+// longer reach it.
+func (d *SANStorageDriver) releaseQueuedVolume(ctx context.Context, name string) error {
+	response, err := d.API.WithContext(ctx).LunOffline(lunPath(name))
+	return api.GetError(response, err)
+}
+
+// restoreQueuedVolume brings the LUN of a recovered volume back online.
+func (d *SANStorageDriver) restoreQueuedVolume(ctx context.Context, name string) error {
+	response, err := d.API.WithContext(ctx).LunOnline(lunPath(name))
+	return api.GetError(response, err)
+}
+
+// destroyQueuedVolume destroys an expired volume from the recovery queue.
+func (d *SANStorageDriver) destroyQueuedVolume(ctx context.Context, queuedName, name string) error {
+	return d.destroyFlexvol(ctx, queuedName, name)
+}
+
+// ListRecoverableVolumes returns the deleted volumes in the SVM's recovery queue.
+func (d *SANStorageDriver) ListRecoverableVolumes(ctx context.Context) ([]*storage.RecoverableVolume, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListRecoverableVolumes", "Type": "SANStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListRecoverableVolumes")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListRecoverableVolumes")
+	}
+
+	return d.recoveryQueue.List()
+}
+
+// RecoverVolume restores a deleted volume from the SVM's recovery queue.
+func (d *SANStorageDriver) RecoverVolume(ctx context.Context, internalName string) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "RecoverVolume",
+			"Type":   "SANStorageDriver",
+			"name":   internalName,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> RecoverVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RecoverVolume")
+	}
+
+	return d.recoveryQueue.Recover(ctx, internalName)
+}
+
+// ListOrphanedResources returns the FlexVols, LUNs, and snapshots the backend created on the SVM that no
+// longer belong to any volume or snapshot known to Trident.
+func (d *SANStorageDriver) ListOrphanedResources(
+	ctx context.Context, backendUUID string, volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig,
+) ([]*storage.OrphanedResource, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListOrphanedResources", "Type": "SANStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListOrphanedResources")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListOrphanedResources")
+	}
+
+	return d.orphans.Find(backendUUID, volumes, snapshots)
+}
+
+// DeleteOrphanedResource deletes a resource returned by ListOrphanedResources.
+func (d *SANStorageDriver) DeleteOrphanedResource(ctx context.Context, resource *storage.OrphanedResource) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":   "DeleteOrphanedResource",
+			"Type":     "SANStorageDriver",
+			"resource": resource.Name,
+			"parent":   resource.Parent,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteOrphanedResource")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteOrphanedResource")
+	}
+
+	return d.orphans.Delete(ctx, resource)
+}
+
+// GetOrphanPolicy returns whether, and when, orphaned resources should be deleted.
+func (d *SANStorageDriver) GetOrphanPolicy() *storage.OrphanPolicy {
+	return d.orphans.Policy()
+}
+
+// ListVolumes returns one page of the FlexVols matching the storage prefix.
+func (d *SANStorageDriver) ListVolumes(ctx context.Context, limit int, continueToken string) (*storage.VolumePage, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "ListVolumes",
+			"Type":   "SANStorageDriver",
+			"limit":  limit,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> ListVolumes")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListVolumes")
+	}
+
+	return listVolumes(d.API.WithContext(ctx), &d.Config, limit, continueToken)
+}
+
+// ListImportCandidates returns the FlexVols on the SVM holding a single LUN, whatever their names, that could be imported.
+func (d *SANStorageDriver) ListImportCandidates(ctx context.Context) ([]*storage.ImportCandidate, error) {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "ListImportCandidates", "Type": "SANStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> ListImportCandidates")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ListImportCandidates")
+	}
+
+	return listSANImportCandidates(d.API.WithContext(ctx))
+}
+
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *SANStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -599,64 +935,102 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	lunPath := lunPath(name)
 	igroupName := d.Config.IgroupName
 
+	// Keep the LUN comment in sync with the volume's current metadata
+	updateLUNComment(client, &d.Config, volConfig, lunPath)
+
 	// Get target info
-	iSCSINodeName, _, err := GetISCSITargetInfo(d.API, &d.Config)
+	iSCSINodeName, _, err := GetISCSITargetInfo(client, &d.Config)
 	if err != nil {
 		return err
 	}
 
-	err = PublishLUN(d.API, &d.Config, d.ips, publishInfo, lunPath, igroupName, iSCSINodeName)
+	// Rediscover the data LIFs in case any have moved since the driver was initialized
+	ips := d.dataLIFs.Refresh()
+
+	err = PublishLUN(ctx, client, &d.Config, ips, publishInfo, lunPath, igroupName, iSCSINodeName)
 	if err != nil {
 		return fmt.Errorf("error publishing %s driver: %v", d.Name(), err)
 	}
 
+	// Have the node trim the LUN's filesystem so that the blocks freed on it are reclaimed
+	if interval, _ := getSpaceReclamationInterval(&d.Config); interval > 0 {
+		publishInfo.SpaceReclamationPeriod = int(interval.Seconds())
+	}
+
 	return nil
 }
 
+// UpdatePublication corrects the iSCSI portals in publishInfo for a volume that was published
+// before its data LIFs moved.  It returns true if publishInfo was changed.
+func (d *SANStorageDriver) UpdatePublication(
+	ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) (bool, error) {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "UpdatePublication",
+			"Type":   "SANStorageDriver",
+			"name":   name,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> UpdatePublication")
+		defer logc(ctx).WithFields(fields).Debug("<<<< UpdatePublication")
+	}
+
+	return UpdateLUNPublication(client, &d.Config, d.dataLIFs.Refresh(), publishInfo, lunPath(name))
+}
+
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "SANStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "GetSnapshot",
+			"Type":               "SANStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
-	return GetSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+	return GetSnapshot(snapConfig, &d.Config, client, client.VolumeSize)
 }
 
 // Return the list of snapshots associated with the specified volume
-func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":     "GetSnapshots",
-			"Type":       "SANStorageDriver",
-			"volumeName": volConfig.InternalName,
+			"Method":             "GetSnapshots",
+			"Type":               "SANStorageDriver",
+			utils.LogFieldVolume: volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
-	return GetSnapshots(volConfig, &d.Config, d.API, d.API.VolumeSize)
+	return GetSnapshots(volConfig, &d.Config, client, client.VolumeSize)
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
+	client := d.API.WithContext(ctx)
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
 
@@ -667,45 +1041,114 @@ func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 			"snapshotName": internalSnapName,
 			"sourceVolume": internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
-	return CreateSnapshot(snapConfig, &d.Config, d.API, d.API.VolumeSize)
+	return CreateSnapshot(ctx, snapConfig, &d.Config, client, client.VolumeSize)
+}
+
+// CreateGroupSnapshot creates a crash-consistent snapshot of each of the given volumes
+func (d *SANStorageDriver) CreateGroupSnapshot(
+	ctx context.Context, snapConfigs []*storage.SnapshotConfig,
+) ([]*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":    "CreateGroupSnapshot",
+			"Type":      "SANStorageDriver",
+			"snapshots": len(snapConfigs),
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> CreateGroupSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateGroupSnapshot")
+	}
+
+	return CreateGroupSnapshot(ctx, snapConfigs, &d.Config, client, client.VolumeSize)
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "RestoreSnapshot",
-			"Type":         "SANStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "RestoreSnapshot",
+			"Type":               "SANStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
-	return RestoreSnapshot(snapConfig, &d.Config, d.API)
+	return RestoreSnapshot(snapConfig, &d.Config, client)
 }
 
 // DeleteSnapshot creates a snapshot of a volume.
-func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "DeleteSnapshot",
-			"Type":         "SANStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "DeleteSnapshot",
+			"Type":               "SANStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
-	return DeleteSnapshot(snapConfig, &d.Config, d.API)
+	return DeleteSnapshot(ctx, snapConfig, &d.Config, client, d.cloneSplitter)
+}
+
+// GetCloneSplitStatus returns the progress of a volume's most recent clone split, if any.
+func (d *SANStorageDriver) GetCloneSplitStatus(name string) *storage.CloneSplitStatus {
+	return d.cloneSplits.Status(name)
+}
+
+// SetCloneSplitHandler registers a function to be called whenever a clone split completes or fails.
+func (d *SANStorageDriver) SetCloneSplitHandler(handler storage.CloneSplitHandler) {
+	d.cloneSplits.SetHandler(handler)
+}
+
+// ResumeCloneSplit follows the split of a cloned volume again after a restart.
+func (d *SANStorageDriver) ResumeCloneSplit(ctx context.Context, volConfig *storage.VolumeConfig) error {
+	return resumeCloneSplit(ctx, &d.Config, volConfig, d.cloneSplits)
+}
+
+// SetSnapshotDeleteHandler registers a function to be called whenever a deferred snapshot deletion ends.
+func (d *SANStorageDriver) SetSnapshotDeleteHandler(handler storage.SnapshotDeleteHandler) {
+	d.cloneSplitter.SetHandler(handler)
+}
+
+// MoveVolume begins moving a volume's Flexvol to another aggregate of this backend.
+func (d *SANStorageDriver) MoveVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, destinationPool string,
+) error {
+	client := d.API.WithContext(ctx)
+	return MoveVolume(ctx, volConfig.InternalName, destinationPool, d.physicalPools, &d.Config, client,
+		d.volumeMoves)
+}
+
+// GetVolumeMoveStatus returns the progress of a volume's most recent move, if any.
+func (d *SANStorageDriver) GetVolumeMoveStatus(name string) *storage.VolumeMoveStatus {
+	return d.volumeMoves.Status(name)
+}
+
+// SetVolumeMoveHandler registers a function to be called whenever a volume move completes or fails.
+func (d *SANStorageDriver) SetVolumeMoveHandler(handler storage.VolumeMoveHandler) {
+	d.volumeMoves.SetHandler(handler)
+}
+
+// ResumeVolumeMove follows the move of a volume's Flexvol again after a restart.
+func (d *SANStorageDriver) ResumeVolumeMove(_ context.Context, volConfig *storage.VolumeConfig) error {
+	d.volumeMoves.Resume(volConfig.InternalName, volConfig.MoveDestinationPool)
+	return nil
 }
 
 // Test for the existence of a volume
@@ -730,6 +1173,57 @@ func (d *SANStorageDriver) GetStorageBackendPhysicalPoolNames() []string {
 	return getStorageBackendPhysicalPoolNamesCommon(d.physicalPools)
 }
 
+// RefreshPools rediscovers the driver's storage pools from the aggregates assigned to the SVM.
+func (d *SANStorageDriver) RefreshPools(ctx context.Context) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "RefreshPools", "Type": "SANStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> RefreshPools")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RefreshPools")
+	}
+
+	physicalPools, virtualPools, err := refreshStoragePoolsCommon(d, d.getStoragePoolAttributes(), d.backendName())
+	if err != nil {
+		return err
+	}
+	d.physicalPools, d.virtualPools = physicalPools, virtualPools
+
+	// Read the new pools' capacity now, rather than leaving them empty until the next refresh
+	d.poolCapacity.SetPools(physicalPools, virtualPools)
+	d.poolCapacity.Refresh()
+
+	return nil
+}
+
+// ResumeVolumeCreate checks that a volume left behind by an interrupted create or clone is ready for use,
+// which requires its LUN to be online.
+func (d *SANStorageDriver) ResumeVolumeCreate(ctx context.Context, volConfig *storage.VolumeConfig) error {
+
+	if err := resumeFlexvolCreate(ctx, d.API, &d.Config, volConfig, d.cloneSplits); err != nil {
+		return err
+	}
+
+	lunInfo, err := d.API.LunGet(lunPath(volConfig.InternalName))
+	if err != nil {
+		return fmt.Errorf("error checking LUN of volume %s; %v", volConfig.InternalName, err)
+	} else if lunInfo.OnlinePtr == nil || !lunInfo.Online() {
+		return fmt.Errorf("LUN of volume %s is not online", volConfig.InternalName)
+	}
+	return nil
+}
+
+// WatchesPoolChanges returns true if the driver watches for events that may affect its storage pools.
+func (d *SANStorageDriver) WatchesPoolChanges() bool {
+	return d.eventWatcher != nil
+}
+
+// SetPoolChangeHandler registers a function to be called whenever the storage pools may have changed.
+func (d *SANStorageDriver) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	if d.eventWatcher != nil {
+		d.eventWatcher.SetPoolChangeHandler(handler)
+	}
+}
+
 func (d *SANStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
@@ -753,10 +1247,10 @@ func (d *SANStorageDriver) GetInternalVolumeName(name string) string {
 }
 
 func (d *SANStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
-	createPrepareCommon(d, volConfig)
+	createPrepareCommon(d, volConfig, maxFlexvolNameLength, d.API.VolumeExists)
 }
 
-func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *SANStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -765,12 +1259,12 @@ func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 			"name":         volConfig.Name,
 			"internalName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateFollowup")
-		defer log.WithFields(fields).Debug("<<<< CreateFollowup")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateFollowup")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateFollowup")
 	}
 
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
-		log.Debug("No follow-up create actions for Docker.")
+		logc(ctx).Debug("No follow-up create actions for Docker.")
 		return nil
 	}
 
@@ -781,12 +1275,17 @@ func (d *SANStorageDriver) mapOntapSANLun(volConfig *storage.VolumeConfig) error
 
 	// get the lunPath and lunID
 	lunPath := fmt.Sprintf("/vol/%v/lun0", volConfig.InternalName)
+
+	updateFlexvolComment(d.API, &d.Config, volConfig)
+	updateLUNComment(d.API, &d.Config, volConfig, lunPath)
+
 	lunID, err := d.API.LunMapIfNotMapped(d.Config.IgroupName, lunPath, volConfig.ImportNotManaged)
 	if err != nil {
 		return err
 	}
 
-	err = PopulateOntapLunMapping(d.API, &d.Config, d.ips, volConfig, lunID, lunPath, d.Config.IgroupName)
+	err = PopulateOntapLunMapping(d.API, &d.Config, d.dataLIFs.IPs(), volConfig, lunID, lunPath,
+		d.Config.IgroupName)
 	if err != nil {
 		return fmt.Errorf("error mapping LUN for %s driver: %v", d.Name(), err)
 	}
@@ -798,6 +1297,14 @@ func (d *SANStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.Block
 }
 
+func (d *SANStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.BlockAccessModes
+}
+
+func (d *SANStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.BlockVolumeExpansion
+}
+
 func (d *SANStorageDriver) StoreConfig(
 	b *storage.PersistentStorageBackendConfig,
 ) {
@@ -838,9 +1345,16 @@ func (d *SANStorageDriver) GetVolumeExternalWrappers(
 	// Let the caller know we're done by closing the channel
 	defer close(channel)
 
-	// Get all volumes matching the storage prefix
-	volumesResponse, err := d.API.VolumeGetAll(*d.Config.StoragePrefix)
-	if err = api.GetError(volumesResponse, err); err != nil {
+	// Make a map of the online volumes matching the storage prefix for faster correlation with LUNs,
+	// listing the volumes a page at a time
+	volumeMap := make(map[string]azgo.VolumeAttributesType)
+	err := forEachFlexvol(d.API, *d.Config.StoragePrefix, func(volume *azgo.VolumeAttributesType) error {
+		if isOnlineVolume(volume) && volume.VolumeIdAttributesPtr != nil {
+			volumeMap[volume.VolumeIdAttributesPtr.Name()] = *volume
+		}
+		return nil
+	})
+	if err != nil {
 		channel <- &storage.VolumeExternalWrapper{Volume: nil, Error: err}
 		return
 	}
@@ -853,19 +1367,15 @@ func (d *SANStorageDriver) GetVolumeExternalWrappers(
 		return
 	}
 
-	// Make a map of volumes for faster correlation with LUNs
-	volumeMap := make(map[string]azgo.VolumeAttributesType)
-	if volumesResponse.Result.AttributesListPtr != nil {
-		for _, volumeAttrs := range volumesResponse.Result.AttributesListPtr.VolumeAttributesPtr {
-			internalName := volumeAttrs.VolumeIdAttributesPtr.Name()
-			volumeMap[internalName] = volumeAttrs
-		}
-	}
-
 	// Convert all LUNs to VolumeExternal and write them to the channel
 	if lunsResponse.Result.AttributesListPtr != nil {
 		for _, lun := range lunsResponse.Result.AttributesListPtr.LunInfoPtr {
 
+			// Deleted volumes waiting in the recovery queue match an empty storage prefix
+			if strings.HasPrefix(lun.Volume(), recoveryQueuePrefix) {
+				continue
+			}
+
 			volume, ok := volumeMap[lun.Volume()]
 			if !ok {
 				log.WithField("path", lun.Path()).Warning("Flexvol not found for LUN.")
@@ -940,25 +1450,80 @@ func (d *SANStorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bit
 	return bitmap
 }
 
+// UpdateVolume changes attributes of an existing volume, currently the days its data must be
+// cold before it is tiered and its snapshot policy
+func (d *SANStorageDriver) UpdateVolume(
+	ctx context.Context, volConfig *storage.VolumeConfig, updateRequest *storage.UpdateVolumeRequest,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method":                    "UpdateVolume",
+			"Type":                      "SANStorageDriver",
+			"name":                      name,
+			"snapshotDirectory":         updateRequest.SnapshotDirectory,
+			"tieringMinimumCoolingDays": updateRequest.TieringMinimumCoolingDays,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> UpdateVolume")
+		defer logc(ctx).WithFields(fields).Debug("<<<< UpdateVolume")
+	}
+
+	if updateRequest.SnapshotDirectory != "" || updateRequest.UnixPermissions != "" ||
+		updateRequest.SecurityStyle != "" || updateRequest.ExportPolicy != "" {
+		return utils.UnsupportedError("snapshot directory access, UNIX permissions, security style, and " +
+			"export policy do not apply to SAN volumes")
+	}
+
+	if updateRequest.TieringMinimumCoolingDays != "" {
+		coolingDays, err := parseTieringMinimumCoolingDays(updateRequest.TieringMinimumCoolingDays)
+		if err != nil {
+			return err
+		}
+
+		coolingResponse, err := client.VolumeModifyTieringMinimumCoolingDays(name, coolingDays)
+		if err = api.GetError(coolingResponse, err); err != nil {
+			return wrapOntapError(err, "error modifying tiering minimum cooling days")
+		}
+
+		logc(ctx).WithFields(log.Fields{
+			"volume":                    name,
+			"tieringMinimumCoolingDays": coolingDays,
+		}).Info("Updated tiering minimum cooling days.")
+	}
+
+	return ModifyVolume(ctx, name, updateRequest, &d.Config, client)
+}
+
 // Resize expands the volume size.
-func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *SANStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	return d.ResizeInPool(ctx, volConfig, nil, sizeBytes)
+}
+
+// ResizeInPool is Resize for a volume whose pool is known, enforcing the pool's size limit rather than
+// the backend's.  The pool may be nil.
+func (d *SANStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":    "Resize",
+			"Method":    "ResizeInPool",
 			"Type":      "SANStorageDriver",
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		logc(ctx).WithFields(fields).Debug(">>>> ResizeInPool")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ResizeInPool")
 	}
 
 	// Validation checks
-	volExists, err := d.API.VolumeExists(name)
+	volExists, err := client.VolumeExists(name)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"error": err,
 			"name":  name,
 		}).Error("Error checking for existing volume.")
@@ -968,9 +1533,9 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 		return fmt.Errorf("volume %s does not exist", name)
 	}
 
-	volSize, err := d.API.VolumeSize(name)
+	volSize, err := client.VolumeSize(name)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"error": err,
 			"name":  name,
 		}).Error("Error checking volume size.")
@@ -983,7 +1548,7 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 	}
 
 	if sameSize {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"requestedSize":     sizeBytes,
 			"currentVolumeSize": volSize,
 			"name":              name,
@@ -995,29 +1560,30 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 
 	volSizeBytes := uint64(volSize)
 	if sizeBytes < volSizeBytes {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes), nil)
 	}
 
-	if aggrLimitsErr := checkAggregateLimitsForFlexvol(name, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+	if aggrLimitsErr := checkAggregateLimitsForFlexvol(name, sizeBytes, d.Config, client); aggrLimitsErr != nil {
 		return aggrLimitsErr
 	}
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
 	// Resize operations
-	if !d.API.SupportsFeature(api.LunGeometrySkip) {
+	if !client.SupportsFeature(api.LunGeometrySkip) {
 		// Check LUN geometry and verify LUN max size.
-		lunGeometry, err := d.API.LunGetGeometry(lunPath(name))
+		lunGeometry, err := client.LunGetGeometry(lunPath(name))
 		if err != nil {
-			log.WithField("error", err).Error("LUN resize failed.")
+			logc(ctx).WithField("error", err).Error("LUN resize failed.")
 			return fmt.Errorf("volume resize failed")
 		}
 
 		lunMaxSize := lunGeometry.Result.MaxResizeSize()
 		if lunMaxSize < int(sizeBytes) {
-			log.WithFields(log.Fields{
+			logc(ctx).WithFields(log.Fields{
 				"error":      err,
 				"sizeBytes":  sizeBytes,
 				"lunMaxSize": lunMaxSize,
@@ -1028,38 +1594,38 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 	}
 
 	// Resize FlexVol
-	response, err := d.API.VolumeSetSize(name, strconv.FormatUint(sizeBytes, 10))
+	response, err := client.VolumeSetSize(name, strconv.FormatUint(sizeBytes, 10))
 	if err = api.GetError(response.Result, err); err != nil {
-		log.WithField("error", err).Error("Volume resize failed.")
+		logc(ctx).WithField("error", err).Error("Volume resize failed.")
 		return fmt.Errorf("volume resize failed")
 	}
 
 	// Resize LUN0
-	returnSize, err := d.API.LunResize(lunPath(name), int(sizeBytes))
+	returnSize, err := client.LunResize(lunPath(name), int(sizeBytes))
 	if err != nil {
-		log.WithField("error", err).Error("LUN resize failed.")
+		logc(ctx).WithField("error", err).Error("LUN resize failed.")
 		return fmt.Errorf("volume resize failed")
 	}
 
 	// Resize FlexVol to be the same size or bigger than LUN because ONTAP creates
 	// larger LUNs sometimes based on internal geometry
-	if initialVolumeSize, err := d.API.VolumeSize(name); err != nil {
-		log.WithField("name", name).Warning("Failed to get volume size.")
+	if initialVolumeSize, err := client.VolumeSize(name); err != nil {
+		logc(ctx).WithField("name", name).Warning("Failed to get volume size.")
 	} else if returnSize != uint64(initialVolumeSize) {
-		volumeSizeResponse, err := d.API.VolumeSetSize(name, strconv.FormatUint(returnSize, 10))
+		volumeSizeResponse, err := client.VolumeSetSize(name, strconv.FormatUint(returnSize, 10))
 		if err = api.GetError(volumeSizeResponse, err); err != nil {
 			volConfig.Size = strconv.FormatUint(uint64(initialVolumeSize), 10)
-			log.WithFields(log.Fields{
+			logc(ctx).WithFields(log.Fields{
 				"name":               name,
 				"initialVolumeSize":  initialVolumeSize,
 				"adjustedVolumeSize": returnSize}).Warning("Failed to resize volume to match LUN size.")
 		} else {
-			if adjustedVolumeSize, err := d.API.VolumeSize(name); err != nil {
-				log.WithField("name", name).
+			if adjustedVolumeSize, err := client.VolumeSize(name); err != nil {
+				logc(ctx).WithField("name", name).
 					Warning("Failed to get volume size after the second resize operation.")
 			} else {
 				volConfig.Size = strconv.FormatUint(uint64(adjustedVolumeSize), 10)
-				log.WithFields(log.Fields{
+				logc(ctx).WithFields(log.Fields{
 					"name":               name,
 					"initialVolumeSize":  initialVolumeSize,
 					"adjustedVolumeSize": adjustedVolumeSize}).Debug("FlexVol resized.")
@@ -1093,3 +1659,19 @@ func (d *SANStorageDriver) ReconcileNodeAccess(nodes []*utils.Node, _ string) er
 
 	return reconcileSANNodeAccess(d.API, d.Config.IgroupName, nodeIQNs)
 }
+
+// RevokeNodeAccess removes a deleted node's IQN from the backend's igroup and logs out its sessions.
+func (d *SANStorageDriver) RevokeNodeAccess(ctx context.Context, node *utils.Node) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "RevokeNodeAccess",
+			"Type":   "SANStorageDriver",
+			"Node":   node.Name,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> RevokeNodeAccess")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RevokeNodeAccess")
+	}
+
+	return revokeSANNodeAccess(ctx, d.API, d.Config.IgroupName, node.IQN)
+}
@@ -3,6 +3,7 @@
 package ontap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -25,8 +26,17 @@ import (
 
 const (
 	maxLunNameLength      = 254
-	maxLunsPerFlexvol     = 100
 	snapshotNameSeparator = "_snapshot_"
+
+	// Limits on the number of LUNs placed in one Flexvol
+	defaultLunsPerFlexvol = 100
+	minLunsPerFlexvol     = 50
+	maxLunsPerFlexvol     = 200
+
+	// Flexvol autosize modes
+	autosizeModeGrow       = "grow"
+	autosizeModeGrowShrink = "grow_shrink"
+	autosizeModeOff        = "off"
 )
 
 func GetLUNPathEconomy(bucketName string, volNameInternal string) string {
@@ -206,14 +216,22 @@ func (o *LUNHelper) GetBucketName(lunPath string) string {
 type SANEconomyStorageDriver struct {
 	initialized       bool
 	Config            drivers.OntapStorageDriverConfig
-	ips               []string
+	dataLIFs          *ISCSIDataLIFs
 	API               *api.Client
 	Telemetry         *Telemetry
 	flexvolNamePrefix string
 	helper            *LUNHelper
 
+	autosizeMaximumSizeBytes int
+	autosizeGrowThresholdPct int
+	lunsPerFlexvol           int
+
+	housekeeping *HousekeepingScheduler
+
 	physicalPools map[string]*storage.Pool
 	virtualPools  map[string]*storage.Pool
+	poolCapacity  *PoolCapacityMonitor
+	eventWatcher  *EventWatcher
 }
 
 func (d *SANEconomyStorageDriver) GetConfig() *drivers.OntapStorageDriverConfig {
@@ -224,6 +242,53 @@ func (d *SANEconomyStorageDriver) GetAPI() *api.Client {
 	return d.API
 }
 
+// SetAPITraceEnabled turns tracing of the driver's API calls on or off.
+func (d *SANEconomyStorageDriver) SetAPITraceEnabled(enabled bool) {
+	d.API.SetAPITraceEnabled(enabled)
+}
+
+// SetDebugTraceFlags replaces the debug trace flags of the driver and its API client.
+func (d *SANEconomyStorageDriver) SetDebugTraceFlags(flags map[string]bool) {
+	d.Config.DebugTraceFlags = flags
+	d.API.SetDebugTraceFlags(flags)
+}
+
+// SetAPILogLevel sets the level at which messages about the driver's API calls are logged.
+func (d *SANEconomyStorageDriver) SetAPILogLevel(level string) error {
+	return d.API.SetLogLevel(level)
+}
+
+// GetAPILogLevel returns the level at which messages about the driver's API calls are logged, if set.
+func (d *SANEconomyStorageDriver) GetAPILogLevel() string {
+	return d.API.LogLevel()
+}
+
+// LogFields returns the fields the driver adds to the messages logged for operations on its backend.
+func (d *SANEconomyStorageDriver) LogFields() map[string]interface{} {
+	return map[string]interface{}{utils.LogFieldSVM: d.Config.SVM}
+}
+
+// GetFeatures returns whether each optional ONTAP feature is available on the driver's SVM.
+func (d *SANEconomyStorageDriver) GetFeatures() map[string]bool {
+	return d.API.Features()
+}
+
+// GetStorageLogs returns the recent jobs of the driver's SVM and the recent EMS events of its cluster.
+func (d *SANEconomyStorageDriver) GetStorageLogs(ctx context.Context) ([]*storage.StorageLogEntry, error) {
+	return getStorageLogs(d.API.WithContext(ctx))
+}
+
+// CheckHealth returns an error if the driver's SVM can't be reached or isn't running.
+func (d *SANEconomyStorageDriver) CheckHealth(ctx context.Context) error {
+	return checkSVMHealth(d.API.WithContext(ctx))
+}
+
+// resolveDataLIFs rediscovers the iSCSI data LIFs after the driver fails over to another SVM.
+func (d *SANEconomyStorageDriver) resolveDataLIFs() error {
+	d.dataLIFs.Refresh()
+	return nil
+}
+
 func (d *SANEconomyStorageDriver) GetTelemetry() *Telemetry {
 	d.Telemetry.Telemetry = tridentconfig.OrchestratorTelemetry
 	return d.Telemetry
@@ -238,7 +303,7 @@ func (d *SANEconomyStorageDriver) Name() string {
 func (d *SANEconomyStorageDriver) backendName() string {
 	if d.Config.BackendName == "" {
 		// Use the old naming scheme if no name is specified
-		return CleanBackendName("ontapsaneco_" + d.ips[0])
+		return CleanBackendName("ontapsaneco_" + d.dataLIFs.IPs()[0])
 	} else {
 		return d.Config.BackendName
 	}
@@ -271,19 +336,18 @@ func (d *SANEconomyStorageDriver) Initialize(
 		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
 	}
 	d.Config = *config
+
+	// Create the snapshot policies the config asks for, or correct them if they have drifted
+	if err = reconcileSnapshotPolicies(d.API, &d.Config); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
 	d.helper = NewLUNHelper(d.Config, context)
 
-	d.ips, err = d.API.NetInterfaceGetDataLIFs("iscsi")
+	d.dataLIFs, err = NewISCSIDataLIFs(&d.Config, d.API)
 	if err != nil {
 		return err
 	}
 
-	if len(d.ips) == 0 {
-		return fmt.Errorf("no iSCSI data LIFs found on SVM %s", config.SVM)
-	} else {
-		log.WithField("dataLIFs", d.ips).Debug("Found iSCSI LIFs.")
-	}
-
 	// Remap context for artifact naming so the names remain stable over time
 	var artifactPrefix string
 	switch context {
@@ -313,9 +377,24 @@ func (d *SANEconomyStorageDriver) Initialize(
 		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
 	}
 
-	// Set up the autosupport heartbeat
+	// Set up the autosupport heartbeat and other periodic housekeeping
 	d.Telemetry = NewOntapTelemetry(d)
-	d.Telemetry.Start()
+	d.housekeeping = NewOntapHousekeepingScheduler(d)
+	if err = d.housekeeping.AddJob(d.dataLIFs.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	d.poolCapacity = NewPoolCapacityMonitor(&d.Config, d.API, d.physicalPools, d.virtualPools, false)
+	if err = d.housekeeping.AddJob(d.poolCapacity.HousekeepingJob()); err != nil {
+		return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+	}
+	if eventWatchInterval, _ := getEventWatchInterval(&d.Config); eventWatchInterval > 0 {
+		d.eventWatcher = NewEventWatcher(d.API)
+		d.eventWatcher.OnDataLIFChange(func() { d.dataLIFs.Refresh() })
+		if err = d.housekeeping.AddJob(d.eventWatcher.HousekeepingJob(eventWatchInterval)); err != nil {
+			return fmt.Errorf("error initializing %s driver: %v", d.Name(), err)
+		}
+	}
+	d.housekeeping.Start()
 
 	d.initialized = true
 	return nil
@@ -333,8 +412,8 @@ func (d *SANEconomyStorageDriver) Terminate(string) {
 		defer log.WithFields(fields).Debug("<<<< Terminate")
 	}
 
-	if d.Telemetry != nil {
-		d.Telemetry.Stop()
+	if d.housekeeping != nil {
+		d.housekeeping.Stop()
 	}
 
 	d.initialized = false
@@ -349,7 +428,15 @@ func (d *SANEconomyStorageDriver) validate() error {
 		defer log.WithFields(fields).Debug("<<<< validate")
 	}
 
-	if err := ValidateSANDriver(d.API, &d.Config, d.ips); err != nil {
+	if err := ValidateSANDriver(d.API, &d.Config, d.dataLIFs.IPs()); err != nil {
+		return fmt.Errorf("error driver validation failed: %v", err)
+	}
+
+	if err := d.validateAutosize(); err != nil {
+		return fmt.Errorf("error driver validation failed: %v", err)
+	}
+
+	if err := d.validateLUNPacking(); err != nil {
 		return fmt.Errorf("error driver validation failed: %v", err)
 	}
 
@@ -362,9 +449,10 @@ func (d *SANEconomyStorageDriver) validate() error {
 
 // Create a volume+LUN with the specified options
 func (d *SANEconomyStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -373,8 +461,8 @@ func (d *SANEconomyStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	// Generic user-facing message
@@ -383,11 +471,11 @@ func (d *SANEconomyStorageDriver) Create(
 	// Determine a way to see if the volume already exists
 	exists, existsInFlexvol, err := d.LUNExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing volume: %v", err)
+		logc(ctx).Errorf("Error checking for existing volume: %v", err)
 		return createError
 	}
 	if exists {
-		log.WithFields(log.Fields{"LUN": name, "bucketVol": existsInFlexvol}).Debug("LUN already exists.")
+		logc(ctx).WithFields(log.Fields{"LUN": name, "bucketVol": existsInFlexvol}).Debug("LUN already exists.")
 		return drivers.NewVolumeExistsError(name)
 	}
 
@@ -410,6 +498,9 @@ func (d *SANEconomyStorageDriver) Create(
 	if err != nil {
 		return err
 	}
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes, &d.Config, storagePool); checkVolumeSizeLimitsError != nil {
+		return checkVolumeSizeLimitsError
+	}
 
 	// Ensure LUN name isn't too long
 	if len(name) > maxLunNameLength {
@@ -443,7 +534,7 @@ func (d *SANEconomyStorageDriver) Create(
 	}
 
 	if tieringPolicy == "" {
-		tieringPolicy = d.API.TieringPolicyValue()
+		tieringPolicy = client.TieringPolicyValue()
 	}
 
 	createErrors := make([]error, 0)
@@ -453,32 +544,52 @@ func (d *SANEconomyStorageDriver) Create(
 		aggregate := physicalPool.Name
 		physicalPoolNames = append(physicalPoolNames, aggregate)
 
-		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+		if aggrLimitsErr := checkAggregateLimits(aggregate, spaceReserve, sizeBytes, d.Config, client); aggrLimitsErr != nil {
 			errMessage := fmt.Sprintf("ONTAP-SAN-ECONOMY pool %s/%s; error: %v", storagePool.Name, aggregate,
 				aggrLimitsErr)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
+		if err := checkAggregateOverprovisioning(aggregate, spaceReserve, sizeBytes,
+			storagePool.InternalAttributes[MaxOverprovisionRatio], &d.Config, client); err != nil {
+			logc(ctx).Errorf("ONTAP-SAN-ECONOMY pool %s/%s; error: %v", storagePool.Name, aggregate, err)
+			createErrors = append(createErrors, fmt.Errorf("ONTAP-SAN-ECONOMY pool %s/%s; error: %w", storagePool.Name,
+				aggregate, err))
+			continue
+		}
+
 		// Make sure we have a Flexvol for the new LUN
 		bucketVol, err := d.ensureFlexvolForLUN(aggregate, spaceReserve, snapshotPolicy, tieringPolicy, false,
-			enableEncryption, sizeBytes, opts, d.Config, storagePool)
+			enableEncryption, sizeBytes, opts, d.Config, storagePool, d.needsDedicatedFlexvol(snapshotPolicy))
 		if err != nil {
 			errMessage := fmt.Sprintf("ONTAP-SAN-ECONOMY pool %s/%s; BucketVol location/creation failed %s: %v",
 				storagePool.Name,
 				aggregate, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
 
-		// Grow or shrink the Flexvol as needed
+		// Reapply autosize in case the backend config has changed since the Flexvol was created
+		if err = d.setFlexvolAutosize(bucketVol); err != nil {
+			logc(ctx).WithField("flexvol", bucketVol).Warning(err)
+		}
+
+		// Grow or shrink the Flexvol as needed.  If autosize is enabled, ONTAP will grow the Flexvol
+		// as the LUN fills, so a failed resize need not fail the create.
 		err = d.resizeFlexvol(bucketVol, sizeBytes)
-		if err != nil {
+		if err != nil && d.autosizeGrowEnabled() {
+			logc(ctx).WithFields(log.Fields{
+				"flexvol": bucketVol,
+				"LUN":     name,
+				"error":   err,
+			}).Warning("Flexvol resize failed, relying on autosize to grow the Flexvol.")
+		} else if err != nil {
 			errMessage := fmt.Sprintf("ONTAP-SAN-ECONOMY pool %s/%s; Flexvol resize failed %s/%s: %v",
 				storagePool.Name, aggregate, bucketVol, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
@@ -487,11 +598,11 @@ func (d *SANEconomyStorageDriver) Create(
 		osType := "linux"
 
 		// Create the LUN
-		lunCreateResponse, err := d.API.LunCreate(lunPath, int(sizeBytes), osType, false, spaceAllocation)
+		lunCreateResponse, err := client.LunCreate(lunPath, int(sizeBytes), osType, false, spaceAllocation)
 		if err = api.GetError(lunCreateResponse, err); err != nil {
 			errMessage := fmt.Sprintf("ONTAP-SAN-ECONOMY pool %s/%s; error creating LUN %s/%s: %v", storagePool.Name,
 				aggregate, bucketVol, name, err)
-			log.Error(errMessage)
+			logc(ctx).Error(errMessage)
 			createErrors = append(createErrors, fmt.Errorf(errMessage))
 			continue
 		}
@@ -499,38 +610,38 @@ func (d *SANEconomyStorageDriver) Create(
 		volConfig.Size = strconv.FormatUint(uint64(lunCreateResponse.Result.ActualSize()), 10)
 
 		// Save the fstype in a LUN attribute so we know what to do in Attach
-		attrResponse, err := d.API.LunSetAttribute(lunPath, LUNAttributeFSType, fstype)
+		attrResponse, err := client.LunSetAttribute(lunPath, LUNAttributeFSType, fstype)
 		if err = api.GetError(attrResponse, err); err != nil {
-			d.API.LunDestroy(lunPath)
+			client.LunDestroy(lunPath)
 			return fmt.Errorf("ONTAP-SAN-ECONOMY pool %s/%s; error saving file system type for LUN %s/%s: %v",
 				storagePool.Name, aggregate, bucketVol, name, err)
 		}
 		// Save the context
-		attrResponse, err = d.API.LunSetAttribute(lunPath, "context", string(d.Config.DriverContext))
+		attrResponse, err = client.LunSetAttribute(lunPath, "context", string(d.Config.DriverContext))
 		if err = api.GetError(attrResponse, err); err != nil {
-			log.WithField("name", name).Warning("Failed to save the driver context attribute for new volume.")
+			logc(ctx).WithField("name", name).Warning("Failed to save the driver context attribute for new volume.")
 		}
 
 		// Resize Flexvol to be the same size or bigger than sum of constituent LUNs because ONTAP creates
 		// larger LUNs sometimes based on internal geometry
 		lunSize := uint64(lunCreateResponse.Result.ActualSize())
 		if lunSize > sizeBytes {
-			if initialVolumeSize, err := d.API.VolumeSize(bucketVol); err != nil {
-				log.WithField("name", bucketVol).Warning("Failed to get volume size.")
+			if initialVolumeSize, err := client.VolumeSize(bucketVol); err != nil {
+				logc(ctx).WithField("name", bucketVol).Warning("Failed to get volume size.")
 			} else {
 				err = d.resizeFlexvol(bucketVol, 0)
 				if err != nil {
-					log.WithFields(log.Fields{
+					logc(ctx).WithFields(log.Fields{
 						"name":               bucketVol,
 						"initialVolumeSize":  initialVolumeSize,
 						"adjustedVolumeSize": uint64(initialVolumeSize) + lunSize - sizeBytes,
 					}).Warning("Failed to resize new volume to exact sum of LUNs' size.")
 				} else {
-					if adjustedVolumeSize, err := d.API.VolumeSize(bucketVol); err != nil {
-						log.WithField("name", bucketVol).
+					if adjustedVolumeSize, err := client.VolumeSize(bucketVol); err != nil {
+						logc(ctx).WithField("name", bucketVol).
 							Warning("Failed to get volume size after the second resize operation.")
 					} else {
-						log.WithFields(log.Fields{
+						logc(ctx).WithFields(log.Fields{
 							"name":               bucketVol,
 							"initialVolumeSize":  initialVolumeSize,
 							"adjustedVolumeSize": adjustedVolumeSize}).Debug("FlexVol resized.")
@@ -541,13 +652,15 @@ func (d *SANEconomyStorageDriver) Create(
 		return nil
 	}
 
-	// All physical pools that were eligible ultimately failed, so don't try this backend again
-	return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
+	// All physical pools that were eligible ultimately failed, so don't try this backend again unless
+	// only the pool's overprovisioning limit stood in the way
+	return newAggregateCreateError(name, storagePool.Name, createErrors, physicalPoolNames)
 }
 
 // Create a volume clone
-func (d *SANEconomyStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _ *storage.Pool) error {
+func (d *SANEconomyStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, _ *storage.Pool) error {
 
+	client := d.API.WithContext(ctx)
 	source := volConfig.CloneSourceVolumeInternal
 	name := volConfig.InternalName
 	snapshot := volConfig.CloneSourceSnapshot
@@ -561,11 +674,11 @@ func (d *SANEconomyStorageDriver) CreateClone(volConfig *storage.VolumeConfig, _
 			"source":   source,
 			"snapshot": snapshot,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
-	return d.createLUNClone(name, source, snapshot, &d.Config, d.API, d.FlexvolNamePrefix(), isFromSnapshot)
+	return d.createLUNClone(name, source, snapshot, &d.Config, client, d.FlexvolNamePrefix(), isFromSnapshot)
 }
 
 // Create a volume clone
@@ -615,7 +728,7 @@ func (d *SANEconomyStorageDriver) createLUNClone(
 		return fmt.Errorf("error creating clone: %v", err)
 	}
 	if zerr := api.NewZapiError(cloneResponse); !zerr.IsPassed() {
-		if zerr.Code() == azgo.EOBJECTNOTFOUND {
+		if zerr.IsNotFound() {
 			return fmt.Errorf("snapshot %s does not exist in volume %s", snapshot, source)
 		} else if zerr.IsFailedToLoadJobError() {
 			fields := log.Fields{
@@ -635,7 +748,7 @@ func (d *SANEconomyStorageDriver) createLUNClone(
 	return d.resizeFlexvol(flexvol, 0)
 }
 
-func (d *SANEconomyStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *SANEconomyStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -645,14 +758,14 @@ func (d *SANEconomyStorageDriver) Import(volConfig *storage.VolumeConfig, origin
 			"newName":      volConfig.InternalName,
 			"notManaged":   volConfig.ImportNotManaged,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	return errors.New("import is not implemented")
 }
 
-func (d *SANEconomyStorageDriver) Rename(name string, newName string) error {
+func (d *SANEconomyStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -661,15 +774,17 @@ func (d *SANEconomyStorageDriver) Rename(name string, newName string) error {
 			"name":    name,
 			"newName": newName,
 		}
-		log.WithFields(fields).Debug(">>>> Rename")
-		defer log.WithFields(fields).Debug("<<<< Rename")
+		logc(ctx).WithFields(fields).Debug(">>>> Rename")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Rename")
 	}
 
 	return errors.New("rename is not implemented")
 }
 
 // Destroy the LUN
-func (d *SANEconomyStorageDriver) Destroy(name string) error {
+func (d *SANEconomyStorageDriver) Destroy(ctx context.Context, name string) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -677,8 +792,8 @@ func (d *SANEconomyStorageDriver) Destroy(name string) error {
 			"Type":   "SANEconomyStorageDriver",
 			"Name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	var (
@@ -692,11 +807,11 @@ func (d *SANEconomyStorageDriver) Destroy(name string) error {
 
 	exists, bucketVol, err := d.LUNExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing LUN: %v", err)
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
 		return err
 	}
 	if !exists {
-		log.Warnf("LUN %v does not exist", name)
+		logc(ctx).Warnf("LUN %v does not exist", name)
 		return nil
 	}
 
@@ -704,14 +819,14 @@ func (d *SANEconomyStorageDriver) Destroy(name string) error {
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
 
 		// Get target info
-		iSCSINodeName, _, err = GetISCSITargetInfo(d.API, &d.Config)
+		iSCSINodeName, _, err = GetISCSITargetInfo(client, &d.Config)
 		if err != nil {
-			log.WithField("error", err).Error("Could not get target info")
+			logc(ctx).WithField("error", err).Error("Could not get target info")
 			return err
 		}
 
 		// Get the LUN ID
-		lunMapResponse, err := d.API.LunMapListInfo(lunPath)
+		lunMapResponse, err := client.LunMapListInfo(lunPath)
 		if err != nil {
 			return fmt.Errorf("error reading LUN maps for volume %s, path %s: %v", name, lunPath, err)
 		}
@@ -733,18 +848,18 @@ func (d *SANEconomyStorageDriver) Destroy(name string) error {
 	externalVolumeName := d.helper.GetExternalVolumeNameFromPath(lunPath)
 	snapList, err := d.getSnapshotsEconomy(name, externalVolumeName)
 	if err != nil {
-		log.Errorf("Error enumerating snapshots: %v", err)
+		logc(ctx).Errorf("Error enumerating snapshots: %v", err)
 		return deleteError
 	}
 	for _, snap := range snapList {
-		err = d.DeleteSnapshot(snap.Config)
+		err = d.DeleteSnapshot(ctx, snap.Config)
 		if err != nil {
-			log.Errorf("Error snap-LUN delete failed: %v", err)
+			logc(ctx).Errorf("Error snap-LUN delete failed: %v", err)
 			return err
 		}
 	}
 
-	offlineResponse, err := d.API.LunOffline(lunPath)
+	offlineResponse, err := client.LunOffline(lunPath)
 	if err != nil {
 		fields := log.Fields{
 			"Method":   "Destroy",
@@ -753,12 +868,12 @@ func (d *SANEconomyStorageDriver) Destroy(name string) error {
 			"Response": offlineResponse,
 			"Error":    err,
 		}
-		log.WithFields(fields)
+		logc(ctx).WithFields(fields)
 	}
 
-	destroyResponse, err := d.API.LunDestroy(lunPath)
+	destroyResponse, err := client.LunDestroy(lunPath)
 	if err = api.GetError(destroyResponse, err); err != nil {
-		log.Errorf("Error LUN delete failed: %v", err)
+		logc(ctx).Errorf("Error LUN delete failed: %v", err)
 		return deleteError
 	}
 	// Check if a bucket volume has no more LUNs. If none left, delete the bucketVol. Else, call for resize
@@ -790,7 +905,7 @@ func (d *SANEconomyStorageDriver) DeleteBucketIfEmpty(bucketVol string) error {
 			return fmt.Errorf("error destroying volume %v: %v", bucketVol, err)
 		}
 		if zerr := api.NewZapiError(volDestroyResponse); !zerr.IsPassed() {
-			if zerr.Code() == azgo.EVOLUMEDOESNOTEXIST {
+			if zerr.IsNotFound() {
 				log.WithField("volume", bucketVol).Warn("Volume already deleted.")
 			} else {
 				return fmt.Errorf("error destroying volume %v: %v", bucketVol, zerr)
@@ -809,8 +924,9 @@ func (d *SANEconomyStorageDriver) DeleteBucketIfEmpty(bucketVol string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *SANEconomyStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *SANEconomyStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 
 	if d.Config.DebugTraceFlags["method"] {
@@ -820,13 +936,13 @@ func (d *SANEconomyStorageDriver) Publish(volConfig *storage.VolumeConfig, publi
 			"name":        name,
 			"publishInfo": publishInfo,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	exists, bucketVol, err := d.LUNExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing LUN: %v", err)
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
 		return err
 	}
 	if !exists {
@@ -836,13 +952,19 @@ func (d *SANEconomyStorageDriver) Publish(volConfig *storage.VolumeConfig, publi
 	lunPath := d.helper.GetLUNPath(bucketVol, name)
 	igroupName := d.Config.IgroupName
 
+	// Keep the LUN comment in sync with the volume's current metadata
+	updateLUNComment(client, &d.Config, volConfig, lunPath)
+
 	// Get target info
-	iSCSINodeName, _, err := GetISCSITargetInfo(d.API, &d.Config)
+	iSCSINodeName, _, err := GetISCSITargetInfo(client, &d.Config)
 	if err != nil {
 		return err
 	}
 
-	err = PublishLUN(d.API, &d.Config, d.ips, publishInfo, lunPath, igroupName, iSCSINodeName)
+	// Rediscover the data LIFs in case any have moved since the driver was initialized
+	ips := d.dataLIFs.Refresh()
+
+	err = PublishLUN(ctx, client, &d.Config, ips, publishInfo, lunPath, igroupName, iSCSINodeName)
 	if err != nil {
 		return fmt.Errorf("error publishing %s driver: %v", d.Name(), err)
 	}
@@ -850,19 +972,52 @@ func (d *SANEconomyStorageDriver) Publish(volConfig *storage.VolumeConfig, publi
 	return nil
 }
 
+// UpdatePublication corrects the iSCSI portals in publishInfo for a volume that was published
+// before its data LIFs moved.  It returns true if publishInfo was changed.
+func (d *SANEconomyStorageDriver) UpdatePublication(
+	ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo,
+) (bool, error) {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "UpdatePublication",
+			"Type":   "SANEconomyStorageDriver",
+			"name":   name,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> UpdatePublication")
+		defer logc(ctx).WithFields(fields).Debug("<<<< UpdatePublication")
+	}
+
+	exists, bucketVol, err := d.LUNExists(name, d.FlexvolNamePrefix())
+	if err != nil {
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
+		return false, err
+	}
+	if !exists {
+		return false, fmt.Errorf("error LUN %v does not exist", name)
+	}
+
+	lunPath := d.helper.GetLUNPath(bucketVol, name)
+
+	return UpdateLUNPublication(client, &d.Config, d.dataLIFs.Refresh(), publishInfo, lunPath)
+}
+
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *SANEconomyStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANEconomyStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "GetSnapshot",
-			"Type":         "SANEconomyStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "GetSnapshot",
+			"Type":               "SANEconomyStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	return d.getSnapshotEconomy(snapConfig, &d.Config)
@@ -877,10 +1032,10 @@ func (d *SANEconomyStorageDriver) getSnapshotEconomy(
 
 	if config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "getSnapshotEconomy",
-			"Type":         "SANEconomyStorageDriver",
-			"snapshotName": internalSnapName,
-			"volumeName":   internalVolumeName,
+			"Method":             "getSnapshotEconomy",
+			"Type":               "SANEconomyStorageDriver",
+			"snapshotName":       internalSnapName,
+			utils.LogFieldVolume: internalVolumeName,
 		}
 		log.WithFields(fields).Debug(">>>> getSnapshotEconomy")
 		defer log.WithFields(fields).Debug("<<<< getSnapshotEconomy")
@@ -910,22 +1065,22 @@ func (d *SANEconomyStorageDriver) getSnapshotEconomy(
 
 }
 
-func (d *SANEconomyStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *SANEconomyStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
 			"Method":             "GetSnapshots",
 			"Type":               "SANEconomyStorageDriver",
 			"internalVolumeName": volConfig.InternalName,
-			"volumeName":         volConfig.Name,
+			utils.LogFieldVolume: volConfig.Name,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	exists, _, err := d.LUNExists(volConfig.InternalName, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing LUN: %v", err)
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
 		return nil, err
 	}
 	if !exists {
@@ -988,18 +1143,20 @@ func (d *SANEconomyStorageDriver) getSnapshotsEconomy(
 }
 
 // CreateSnapshot creates a snapshot for the given volume.
-func (d *SANEconomyStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANEconomyStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "CreateSnapshot",
-			"Type":         "SANEconomyStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
-			"snapConfig":   snapConfig,
+			"Method":             "CreateSnapshot",
+			"Type":               "SANEconomyStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
+			"snapConfig":         snapConfig,
 		}
-		log.WithFields(fields).Info(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Info("<<<< CreateSnapshot")
+		logc(ctx).WithFields(fields).Info(">>>> CreateSnapshot")
+		defer logc(ctx).WithFields(fields).Info("<<<< CreateSnapshot")
 	}
 
 	internalSnapName := snapConfig.InternalName
@@ -1008,7 +1165,7 @@ func (d *SANEconomyStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotCon
 	// Check to see if source LUN exists
 	exists, bucketVol, err := d.LUNExists(internalVolumeName, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing LUN: %v", err)
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
 		return nil, err
 	}
 	if !exists {
@@ -1018,7 +1175,7 @@ func (d *SANEconomyStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotCon
 	lunPath := GetLUNPathEconomy(bucketVol, internalVolumeName)
 
 	// If the specified volume doesn't exist, return error
-	lunInfo, err := d.API.LunGet(lunPath)
+	lunInfo, err := client.LunGet(lunPath)
 	if err != nil {
 		return nil, fmt.Errorf("error checking for existing volume: %v", err)
 	}
@@ -1033,7 +1190,7 @@ func (d *SANEconomyStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotCon
 
 	// Create the "snap-LUN" where the snapshot is a LUN clone of the source LUN
 	err = d.createLUNClone(lunName, snapConfig.VolumeInternalName, snapConfig.Name,
-		&d.Config, d.API, d.FlexvolNamePrefix(), false)
+		&d.Config, client, d.FlexvolNamePrefix(), false)
 	if err != nil {
 		return nil, fmt.Errorf("could not create snapshot: %v", err)
 	}
@@ -1057,35 +1214,37 @@ func (d *SANEconomyStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotCon
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *SANEconomyStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANEconomyStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":       "RestoreSnapshot",
-			"Type":         "SANEconomyStorageDriver",
-			"snapshotName": snapConfig.InternalName,
-			"volumeName":   snapConfig.VolumeInternalName,
+			"Method":             "RestoreSnapshot",
+			"Type":               "SANEconomyStorageDriver",
+			"snapshotName":       snapConfig.InternalName,
+			utils.LogFieldVolume: snapConfig.VolumeInternalName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
 	return drivers.NewSnapshotsNotSupportedError(d.Name())
 }
 
 // DeleteSnapshot deletes a LUN snapshot.
-func (d *SANEconomyStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANEconomyStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
+
+	client := d.API.WithContext(ctx)
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
 			"Method":                "DeleteSnapshot",
 			"Type":                  "SANEconomyStorageDriver",
 			"snapshotName":          snapConfig.InternalName,
-			"volumeName":            snapConfig.VolumeInternalName,
+			utils.LogFieldVolume:    snapConfig.VolumeInternalName,
 			"snapConfig.VolumeName": snapConfig.VolumeName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
 	internalSnapName := snapConfig.InternalName
@@ -1095,7 +1254,7 @@ func (d *SANEconomyStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotCon
 	// Check to see if the source LUN exists
 	exists, bucketVol, err := d.LUNExists(snapLunName, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing LUN: %v", err)
+		logc(ctx).Errorf("Error checking for existing LUN: %v", err)
 		return err
 	}
 	if !exists {
@@ -1104,9 +1263,9 @@ func (d *SANEconomyStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotCon
 
 	snapPath := GetLUNPathEconomy(bucketVol, snapLunName)
 
-	offlineResponse, err := d.API.LunOffline(snapPath)
+	offlineResponse, err := client.LunOffline(snapPath)
 	if err != nil {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"Method":   "DeleteSnapshot",
 			"Type":     "SANEconomyStorageDriver",
 			"snap-LUN": snapPath,
@@ -1115,9 +1274,9 @@ func (d *SANEconomyStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotCon
 		}).Warn("Error attempting to offline snap-LUN.")
 	}
 
-	destroyResponse, err := d.API.LunDestroy(snapPath)
+	destroyResponse, err := client.LunDestroy(snapPath)
 	if err = api.GetError(destroyResponse, err); err != nil {
-		log.Errorf("Snap-LUN delete failed: %v", err)
+		logc(ctx).Errorf("Snap-LUN delete failed: %v", err)
 		return fmt.Errorf("error deleting snapshot: %v", err)
 	}
 
@@ -1153,18 +1312,29 @@ func (d *SANEconomyStorageDriver) Get(name string) error {
 }
 
 // ensureFlexvolForLUN accepts a set of Flexvol characteristics and either finds one to contain a new
-// LUN or it creates a new Flexvol with the needed attributes.
+// LUN or it creates a new Flexvol with the needed attributes.  A LUN needing a dedicated Flexvol always
+// gets a new one.
 func (d *SANEconomyStorageDriver) ensureFlexvolForLUN(
 	aggregate, spaceReserve, snapshotPolicy, tieringPolicy string, enableSnapshotDir bool, encrypt bool,
 	sizeBytes uint64, opts map[string]string, config drivers.OntapStorageDriverConfig, storagePool *storage.Pool,
+	dedicated bool,
 ) (string, error) {
 
-	shouldLimitVolumeSize, flexvolSizeLimit, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes,
-		config.CommonStorageDriverConfig)
+	shouldLimitVolumeSize, flexvolSizeLimit, checkVolumeSizeLimitsError := checkVolumeSizeLimits(sizeBytes,
+		&config, storagePool)
 	if checkVolumeSizeLimitsError != nil {
 		return "", checkVolumeSizeLimitsError
 	}
 
+	if dedicated {
+		flexvol, err := d.createFlexvolForLUN(aggregate, spaceReserve, snapshotPolicy, tieringPolicy,
+			enableSnapshotDir, encrypt, opts, storagePool)
+		if err != nil {
+			return "", fmt.Errorf("error creating dedicated Flexvol for LUN: %v", err)
+		}
+		return flexvol, nil
+	}
+
 	// Check if a suitable Flexvol already exists
 	flexvol, err := d.getFlexvolForLUN(aggregate, spaceReserve, snapshotPolicy, tieringPolicy, enableSnapshotDir,
 		encrypt, sizeBytes,
@@ -1226,11 +1396,13 @@ func (d *SANEconomyStorageDriver) createFlexvolForLUN(
 	}).Debug("Creating Flexvol for LUNs.")
 
 	// Create the flexvol
-	volCreateResponse, err := d.API.VolumeCreate(
-		flexvol, aggregate, size, spaceReserve, snapshotPolicy,
-		unixPermissions, exportPolicy, securityStyle, tieringPolicy, encrypt, snapshotReserveInt)
-
-	if err = api.GetError(volCreateResponse, err); err != nil {
+	err = retryOntapOperation(context.Background(), &d.Config, retryOpVolumeCreate, func() error {
+		volCreateResponse, err := d.API.VolumeCreate(
+			flexvol, aggregate, size, spaceReserve, snapshotPolicy,
+			unixPermissions, exportPolicy, securityStyle, tieringPolicy, "", encrypt, snapshotReserveInt)
+		return api.GetError(volCreateResponse, err)
+	})
+	if err != nil {
 		return "", fmt.Errorf("error creating volume: %v", err)
 	}
 
@@ -1242,6 +1414,10 @@ func (d *SANEconomyStorageDriver) createFlexvolForLUN(
 		}
 	}
 
+	if err = d.setFlexvolAutosize(flexvol); err != nil {
+		return "", err
+	}
+
 	return flexvol, nil
 }
 
@@ -1298,7 +1474,7 @@ func (d *SANEconomyStorageDriver) getFlexvolForLUN(
 				}
 			}
 
-			if count < maxLunsPerFlexvol {
+			if count < d.lunsPerFlexvol {
 				volumes = append(volumes, volName)
 			}
 		}
@@ -1392,6 +1568,40 @@ func (d *SANEconomyStorageDriver) GetStorageBackendPhysicalPoolNames() []string
 	return getStorageBackendPhysicalPoolNamesCommon(d.physicalPools)
 }
 
+// RefreshPools rediscovers the driver's storage pools from the aggregates assigned to the SVM.
+func (d *SANEconomyStorageDriver) RefreshPools(ctx context.Context) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{"Method": "RefreshPools", "Type": "SANEconomyStorageDriver"}
+		logc(ctx).WithFields(fields).Debug(">>>> RefreshPools")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RefreshPools")
+	}
+
+	physicalPools, virtualPools, err := refreshStoragePoolsCommon(d, d.getStoragePoolAttributes(), d.backendName())
+	if err != nil {
+		return err
+	}
+	d.physicalPools, d.virtualPools = physicalPools, virtualPools
+
+	// Read the new pools' capacity now, rather than leaving them empty until the next refresh
+	d.poolCapacity.SetPools(physicalPools, virtualPools)
+	d.poolCapacity.Refresh()
+
+	return nil
+}
+
+// WatchesPoolChanges returns true if the driver watches for events that may affect its storage pools.
+func (d *SANEconomyStorageDriver) WatchesPoolChanges() bool {
+	return d.eventWatcher != nil
+}
+
+// SetPoolChangeHandler registers a function to be called whenever the storage pools may have changed.
+func (d *SANEconomyStorageDriver) SetPoolChangeHandler(handler storage.PoolChangeHandler) {
+	if d.eventWatcher != nil {
+		d.eventWatcher.SetPoolChangeHandler(handler)
+	}
+}
+
 func (d *SANEconomyStorageDriver) getStoragePoolAttributes() map[string]sa.Offer {
 
 	return map[string]sa.Offer{
@@ -1414,10 +1624,13 @@ func (d *SANEconomyStorageDriver) GetInternalVolumeName(name string) string {
 }
 
 func (d *SANEconomyStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
-	createPrepareCommon(d, volConfig)
+	createPrepareCommon(d, volConfig, maxLunNameLength, func(name string) (bool, error) {
+		exists, _, err := d.LUNExists(name, d.FlexvolNamePrefix())
+		return exists, err
+	})
 }
 
-func (d *SANEconomyStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *SANEconomyStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1426,12 +1639,12 @@ func (d *SANEconomyStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig
 			"name":         volConfig.Name,
 			"internalName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateFollowup")
-		defer log.WithFields(fields).Debug("<<<< CreateFollowup")
+		logc(ctx).WithFields(fields).Debug(">>>> CreateFollowup")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateFollowup")
 	}
 
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
-		log.Debug("No follow-up create actions for Docker.")
+		logc(ctx).Debug("No follow-up create actions for Docker.")
 		return nil
 	}
 
@@ -1450,12 +1663,15 @@ func (d *SANEconomyStorageDriver) mapOntapSANLUN(volConfig *storage.VolumeConfig
 	}
 	// Map LUN
 	lunPath := GetLUNPathEconomy(flexvol, volConfig.InternalName)
+
+	updateLUNComment(d.API, &d.Config, volConfig, lunPath)
+
 	lunID, err := d.API.LunMapIfNotMapped(d.Config.IgroupName, lunPath, volConfig.ImportNotManaged)
 	if err != nil {
 		return err
 	}
 
-	err = PopulateOntapLunMapping(d.API, &d.Config, d.ips, volConfig, lunID, lunPath, d.Config.IgroupName)
+	err = PopulateOntapLunMapping(d.API, &d.Config, d.dataLIFs.IPs(), volConfig, lunID, lunPath, d.Config.IgroupName)
 	if err != nil {
 		return fmt.Errorf("error mapping LUN for %s driver: %v", d.Name(), err)
 	}
@@ -1467,6 +1683,14 @@ func (d *SANEconomyStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.Block
 }
 
+func (d *SANEconomyStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.BlockAccessModes
+}
+
+func (d *SANEconomyStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.BlockVolumeExpansion
+}
+
 func (d *SANEconomyStorageDriver) StoreConfig(b *storage.PersistentStorageBackendConfig) {
 	drivers.SanitizeCommonStorageDriverConfig(d.Config.CommonStorageDriverConfig)
 	b.OntapConfig = &d.Config
@@ -1650,19 +1874,28 @@ func (d *SANEconomyStorageDriver) LUNExists(name, bucketPrefix string) (bool, st
 	return false, "", nil
 }
 
-// Create a LUN with the specified options and find (or create) a bucket volume for the LUN
-func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+// Resize expands a LUN, growing the bucket volume containing it as needed.
+func (d *SANEconomyStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+	return d.ResizeInPool(ctx, volConfig, nil, sizeBytes)
+}
 
+// ResizeInPool is Resize for a volume whose pool is known, enforcing the pool's size limit rather than
+// the backend's.  The pool may be nil.
+func (d *SANEconomyStorageDriver) ResizeInPool(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, sizeBytes uint64,
+) error {
+
+	client := d.API.WithContext(ctx)
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
-			"Method":    "Resize",
+			"Method":    "ResizeInPool",
 			"Type":      "SANEconomyStorageDriver",
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		logc(ctx).WithFields(fields).Debug(">>>> ResizeInPool")
+		defer logc(ctx).WithFields(fields).Debug("<<<< ResizeInPool")
 	}
 
 	// Generic user-facing message
@@ -1672,7 +1905,7 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	// get the volume where the lun exists
 	exists, bucketVol, err := d.LUNExists(name, d.FlexvolNamePrefix())
 	if err != nil {
-		log.Errorf("Error checking for existing volume: %v", err)
+		logc(ctx).Errorf("Error checking for existing volume: %v", err)
 		return resizeError
 	}
 	if !exists {
@@ -1682,19 +1915,19 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	// Calculate the delta size needed to resize the bucketVol
 	totalLunSize, err := d.getTotalLUNSize(bucketVol)
 	if err != nil {
-		log.WithField("error", err).Error("Failed to determine total LUN size")
+		logc(ctx).WithField("error", err).Error("Failed to determine total LUN size")
 		return resizeError
 	}
 
 	currentLunSize, resizeError := d.getLUNSize(name, bucketVol)
 	if err != nil {
-		log.WithField("error", err).Error("Failed to determine LUN size")
+		logc(ctx).WithField("error", err).Error("Failed to determine LUN size")
 		return resizeError
 	}
 
 	flexvolSize, err := d.getOptimalSizeForFlexvol(bucketVol, (sizeBytes - currentLunSize))
 	if err != nil {
-		log.Warnf("Could not calculate optimal Flexvol size. %v", err)
+		logc(ctx).Warnf("Could not calculate optimal Flexvol size. %v", err)
 		flexvolSize = totalLunSize + sizeBytes - currentLunSize
 	}
 
@@ -1705,7 +1938,7 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	}
 
 	if sameSize {
-		log.WithFields(log.Fields{
+		logc(ctx).WithFields(log.Fields{
 			"requestedSize":     flexvolSize,
 			"currentVolumeSize": totalLunSize,
 			"name":              name,
@@ -1717,31 +1950,32 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	}
 
 	if flexvolSize < totalLunSize {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", flexvolSize, totalLunSize)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", flexvolSize, totalLunSize), nil)
 	}
 
-	if aggrLimitsErr := checkAggregateLimitsForFlexvol(bucketVol, flexvolSize, d.Config, d.GetAPI()); aggrLimitsErr != nil {
+	if aggrLimitsErr := checkAggregateLimitsForFlexvol(bucketVol, flexvolSize, d.Config, client); aggrLimitsErr != nil {
 		return aggrLimitsErr
 	}
 
-	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(flexvolSize,
-		d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
+	if _, _, checkVolumeSizeLimitsError := checkVolumeSizeLimits(flexvolSize, &d.Config,
+		storagePool); checkVolumeSizeLimitsError != nil {
 		return checkVolumeSizeLimitsError
 	}
 
 	// Resize operations
 	lunPath := d.helper.GetLUNPath(bucketVol, name)
-	if !d.API.SupportsFeature(api.LunGeometrySkip) {
+	if !client.SupportsFeature(api.LunGeometrySkip) {
 		// Check LUN geometry and verify LUN max size.
-		lunGeometry, err := d.API.LunGetGeometry(lunPath)
+		lunGeometry, err := client.LunGetGeometry(lunPath)
 		if err != nil {
-			log.WithField("error", err).Error("LUN resize failed.")
+			logc(ctx).WithField("error", err).Error("LUN resize failed.")
 			return fmt.Errorf("volume resize failed")
 		}
 
 		lunMaxSize := lunGeometry.Result.MaxResizeSize()
 		if lunMaxSize < int(sizeBytes) {
-			log.WithFields(log.Fields{
+			logc(ctx).WithFields(log.Fields{
 				"error":      err,
 				"sizeBytes":  sizeBytes,
 				"lunMaxSize": lunMaxSize,
@@ -1752,16 +1986,16 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	}
 
 	// Resize FlexVol
-	response, err := d.API.VolumeSetSize(bucketVol, strconv.FormatUint(flexvolSize, 10))
+	response, err := client.VolumeSetSize(bucketVol, strconv.FormatUint(flexvolSize, 10))
 	if err = api.GetError(response, err); err != nil {
-		log.WithField("error", err).Error("Volume resize failed.")
+		logc(ctx).WithField("error", err).Error("Volume resize failed.")
 		return fmt.Errorf("volume resize failed")
 	}
 
 	// Resize LUN
-	returnSize, err := d.API.LunResize(lunPath, int(sizeBytes))
+	returnSize, err := client.LunResize(lunPath, int(sizeBytes))
 	if err = api.GetError(response, err); err != nil {
-		log.WithField("error", err).Error("LUN resize failed.")
+		logc(ctx).WithField("error", err).Error("LUN resize failed.")
 		return fmt.Errorf("volume resize failed")
 	}
 
@@ -1772,24 +2006,107 @@ func (d *SANEconomyStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBy
 	if returnSize > sizeBytes {
 		err = d.resizeFlexvol(bucketVol, 0)
 		if err != nil {
-			log.WithFields(log.Fields{
+			logc(ctx).WithFields(log.Fields{
 				"name":               bucketVol,
 				"initialVolumeSize":  flexvolSize,
 				"adjustedVolumeSize": flexvolSize + returnSize - sizeBytes,
 			}).Warning("Failed to resize new volume to exact sum of LUNs' size.")
 		} else {
-			if adjustedVolumeSize, err := d.API.VolumeSize(bucketVol); err != nil {
-				log.WithField("name", bucketVol).
+			if adjustedVolumeSize, err := client.VolumeSize(bucketVol); err != nil {
+				logc(ctx).WithField("name", bucketVol).
 					Warning("Failed to get volume size after the second resize operation.")
 			} else {
-				log.WithFields(log.Fields{
+				logc(ctx).WithFields(log.Fields{
 					"name":               bucketVol,
 					"initialVolumeSize":  flexvolSize,
 					"adjustedVolumeSize": adjustedVolumeSize}).Debug("FlexVol resized.")
 			}
 		}
 	}
-	log.WithField("size", returnSize).Debug("Returning.")
+	logc(ctx).WithField("size", returnSize).Debug("Returning.")
+
+	return nil
+}
+
+// validateAutosize checks the backend's Flexvol autosize options and caches their parsed values.
+func (d *SANEconomyStorageDriver) validateAutosize() error {
+
+	switch d.Config.AutosizeMode {
+	case "", autosizeModeGrow, autosizeModeGrowShrink, autosizeModeOff:
+	default:
+		return fmt.Errorf("invalid value for autosizeMode: %s", d.Config.AutosizeMode)
+	}
+
+	if d.Config.AutosizeMaximumSize != "" {
+		maxSize, err := utils.ConvertSizeToBytes(d.Config.AutosizeMaximumSize)
+		if err != nil {
+			return fmt.Errorf("invalid value for autosizeMaximumSize: %v", err)
+		}
+		if d.autosizeMaximumSizeBytes, err = strconv.Atoi(maxSize); err != nil {
+			return fmt.Errorf("invalid value for autosizeMaximumSize: %v", err)
+		}
+	}
+
+	if d.Config.AutosizeGrowThreshold != "" {
+		threshold, err := strconv.Atoi(d.Config.AutosizeGrowThreshold)
+		if err != nil || threshold < 1 || threshold > 99 {
+			return fmt.Errorf("invalid value for autosizeGrowThreshold: %s", d.Config.AutosizeGrowThreshold)
+		}
+		d.autosizeGrowThresholdPct = threshold
+	}
+
+	return nil
+}
+
+// validateLUNPacking checks the backend's limit on LUNs per Flexvol and caches its parsed value.
+func (d *SANEconomyStorageDriver) validateLUNPacking() error {
+
+	d.lunsPerFlexvol = defaultLunsPerFlexvol
+	if d.Config.LUNsPerFlexvol != "" {
+		limit, err := strconv.Atoi(d.Config.LUNsPerFlexvol)
+		if err != nil || limit < minLunsPerFlexvol || limit > maxLunsPerFlexvol {
+			return fmt.Errorf("invalid value for lunsPerFlexvol: %s, must be between %d and %d",
+				d.Config.LUNsPerFlexvol, minLunsPerFlexvol, maxLunsPerFlexvol)
+		}
+		d.lunsPerFlexvol = limit
+	}
+
+	return nil
+}
+
+// needsDedicatedFlexvol returns true if a LUN with the given snapshot policy must be placed in a Flexvol of
+// its own.  ONTAP snapshots of a shared Flexvol capture every LUN in it, so restoring one would roll back
+// unrelated volumes.  Shared Flexvols are only chosen among those with the same snapshot policy, so LUNs
+// without a snapshot policy are never placed alongside a dedicated one.
+func (d *SANEconomyStorageDriver) needsDedicatedFlexvol(snapshotPolicy string) bool {
+	return d.Config.DedicatedSnapshotFlexvols && snapshotPolicy != "" && snapshotPolicy != "none"
+}
+
+// autosizeGrowEnabled returns true if the backend allows ONTAP to grow bucket Flexvols.
+func (d *SANEconomyStorageDriver) autosizeGrowEnabled() bool {
+	return d.Config.AutosizeMode == autosizeModeGrow || d.Config.AutosizeMode == autosizeModeGrowShrink
+}
+
+// setFlexvolAutosize applies the backend's autosize options to a bucket Flexvol.  If the
+// backend doesn't specify an autosize mode, the Flexvol is left with the ONTAP default.
+func (d *SANEconomyStorageDriver) setFlexvolAutosize(flexvol string) error {
+
+	if d.Config.AutosizeMode == "" {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"flexvol":              flexvol,
+		"mode":                 d.Config.AutosizeMode,
+		"maximumSizeBytes":     d.autosizeMaximumSizeBytes,
+		"growThresholdPercent": d.autosizeGrowThresholdPct,
+	}).Debug("Setting Flexvol autosize.")
+
+	response, err := d.API.VolumeSetAutosize(flexvol, d.Config.AutosizeMode, d.autosizeMaximumSizeBytes,
+		d.autosizeGrowThresholdPct)
+	if err = api.GetError(response, err); err != nil {
+		return fmt.Errorf("error setting autosize for Flexvol %s: %v", flexvol, err)
+	}
 
 	return nil
 }
@@ -1840,3 +2157,19 @@ func (d *SANEconomyStorageDriver) ReconcileNodeAccess(nodes []*utils.Node, _ str
 
 	return reconcileSANNodeAccess(d.API, d.Config.IgroupName, nodeIQNs)
 }
+
+// RevokeNodeAccess removes a deleted node's IQN from the backend's igroup and logs out its sessions.
+func (d *SANEconomyStorageDriver) RevokeNodeAccess(ctx context.Context, node *utils.Node) error {
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "RevokeNodeAccess",
+			"Type":   "SANEconomyStorageDriver",
+			"Node":   node.Name,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> RevokeNodeAccess")
+		defer logc(ctx).WithFields(fields).Debug("<<<< RevokeNodeAccess")
+	}
+
+	return revokeSANNodeAccess(ctx, d.API, d.Config.IgroupName, node.IQN)
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
 	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
 )
 
 // ToStringPointer takes a string and returns a string pointer
@@ -160,3 +162,59 @@ func TestGetComponentsNoSnapshot(t *testing.T) {
 	assert.NotEqual(t, "myLun", volName2, "Strings are equal")
 	assert.Equal(t, "", volName2, "Strings are NOT equal")
 }
+
+func TestValidateAutosize(t *testing.T) {
+
+	d := &SANEconomyStorageDriver{Config: *newTestOntapSANConfig()}
+	assert.NoError(t, d.validateAutosize())
+	assert.False(t, d.autosizeGrowEnabled())
+
+	d.Config.AutosizeMode = autosizeModeGrow
+	d.Config.AutosizeMaximumSize = "2Gi"
+	d.Config.AutosizeGrowThreshold = "90"
+	assert.NoError(t, d.validateAutosize())
+	assert.True(t, d.autosizeGrowEnabled())
+	assert.Equal(t, 2147483648, d.autosizeMaximumSizeBytes)
+	assert.Equal(t, 90, d.autosizeGrowThresholdPct)
+
+	d.Config.AutosizeGrowThreshold = "100"
+	assert.Error(t, d.validateAutosize(), "expected invalid threshold error")
+
+	d.Config.AutosizeGrowThreshold = ""
+	d.Config.AutosizeMode = "shrink"
+	assert.Error(t, d.validateAutosize(), "expected invalid mode error")
+}
+
+func TestValidateLUNPacking(t *testing.T) {
+
+	d := &SANEconomyStorageDriver{Config: *newTestOntapSANConfig()}
+	assert.NoError(t, d.validateLUNPacking())
+	assert.Equal(t, defaultLunsPerFlexvol, d.lunsPerFlexvol)
+
+	d.Config.LUNsPerFlexvol = "150"
+	assert.NoError(t, d.validateLUNPacking())
+	assert.Equal(t, 150, d.lunsPerFlexvol)
+
+	for _, value := range []string{"49", "201", "many"} {
+		d.Config.LUNsPerFlexvol = value
+		assert.Error(t, d.validateLUNPacking(), "expected invalid lunsPerFlexvol error")
+	}
+}
+
+func TestNeedsDedicatedFlexvol(t *testing.T) {
+
+	d := &SANEconomyStorageDriver{Config: *newTestOntapSANConfig()}
+	assert.False(t, d.needsDedicatedFlexvol("default"))
+
+	d.Config.DedicatedSnapshotFlexvols = true
+	assert.True(t, d.needsDedicatedFlexvol("default"))
+	assert.False(t, d.needsDedicatedFlexvol("none"))
+	assert.False(t, d.needsDedicatedFlexvol(""))
+}
+
+func TestSANEconomyResizeInPool(t *testing.T) {
+	testResizeInPool(t, func(config *drivers.OntapStorageDriverConfig, client *api.Client) storage.PoolResizer {
+		return &SANEconomyStorageDriver{Config: *config, API: client, flexvolNamePrefix: "trident_lun_pool_test_",
+			helper: NewLUNHelper(*config, tridentconfig.ContextCSI)}
+	})
+}
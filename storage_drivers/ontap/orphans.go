@@ -0,0 +1,383 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+const (
+	// OrphanPolicyReport and OrphanPolicyDelete are the values of the orphanPolicy option, which says
+	// whether orphaned resources are only reported or are also deleted.
+	OrphanPolicyReport = "report"
+	OrphanPolicyDelete = "delete"
+
+	// defaultOrphanGracePeriod is how long a resource must have been seen to be orphaned before it is deleted.
+	defaultOrphanGracePeriod = 24 * time.Hour
+
+	// csiSnapshotPrefix starts the names of the snapshots Trident creates for CSI volume snapshots.
+	csiSnapshotPrefix = "snapshot-"
+)
+
+// flexvolIdentity is a FlexVol found on the SVM.
+type flexvolIdentity struct {
+	name         string
+	comment      string
+	exportPolicy string
+}
+
+// knownResources indexes the volumes and snapshots known to Trident.
+type knownResources struct {
+	volumes   map[string]bool // internal volume names
+	unmanaged map[string]bool // internal names of volumes imported without being managed
+	uuids     map[string]bool // volume request UUIDs
+	snapshots map[string]bool // internal volume name and internal snapshot name, joined by a slash
+}
+
+func newKnownResources(volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig) *knownResources {
+
+	known := &knownResources{
+		volumes:   make(map[string]bool),
+		unmanaged: make(map[string]bool),
+		uuids:     make(map[string]bool),
+		snapshots: make(map[string]bool),
+	}
+	for _, volume := range volumes {
+		known.volumes[volume.InternalName] = true
+		if volume.ImportNotManaged {
+			known.unmanaged[volume.InternalName] = true
+		}
+		if volume.UUID != "" {
+			known.uuids[volume.UUID] = true
+		}
+	}
+	for _, snapshot := range snapshots {
+		known.snapshots[snapshot.VolumeInternalName+"/"+snapshot.InternalName] = true
+	}
+	return known
+}
+
+// OrphanFinder finds the resources a driver created on its SVM that no longer belong to any volume or
+// snapshot known to Trident, and deletes them when asked.  Only FlexVols tagged as created by the
+// finder's backend are considered, so volumes created outside Trident, by other backends or Trident
+// instances sharing the SVM, or by releases that didn't tag them, are never reported; nor are volumes
+// imported without being managed, which are never tagged.  Shared resources, such as igroups, are
+// never touched.
+type OrphanFinder struct {
+	prefix string
+	policy *storage.OrphanPolicy
+
+	// listFlexvols lists every FlexVol on the SVM, so that export policies in use by volumes outside the
+	// storage prefix are known
+	listFlexvols  func() ([]flexvolIdentity, error)
+	listSnapshots func() ([]baseSnapshot, error)
+	// listLUNs is nil unless the driver creates LUNs, and listExportPolicies is nil unless it creates
+	// export policies
+	listLUNs           func(flexvol string) ([]string, error)
+	listExportPolicies func(prefix string) ([]string, error)
+
+	deleteResource func(ctx context.Context, resource *storage.OrphanedResource) error
+}
+
+// NewOrphanFinder returns the orphan finder for a driver of the ontap-nas or ontap-san kind, according
+// to the protocol.  The config's orphan policy must have been validated.
+func NewOrphanFinder(
+	config *drivers.OntapStorageDriverConfig, client *api.Client, protocol tridentconfig.Protocol,
+) *OrphanFinder {
+
+	policy, _ := getOrphanPolicy(config)
+
+	f := &OrphanFinder{
+		prefix: *config.StoragePrefix,
+		policy: policy,
+		listFlexvols: func() ([]flexvolIdentity, error) {
+			return listFlexvolIdentities(client, "")
+		},
+		listSnapshots: func() ([]baseSnapshot, error) {
+			return listVolumeSnapshots(client, *config.StoragePrefix)
+		},
+		deleteResource: func(ctx context.Context, resource *storage.OrphanedResource) error {
+			return deleteOrphanedResource(client.WithContext(ctx), resource)
+		},
+	}
+
+	if protocol == tridentconfig.Block {
+		f.listLUNs = func(flexvol string) ([]string, error) {
+			return listLUNNames(client, flexvol)
+		}
+	} else {
+		f.listExportPolicies = func(prefix string) ([]string, error) {
+			return listExportPolicies(client, prefix)
+		}
+	}
+
+	return f
+}
+
+// Policy returns whether, and when, orphaned resources should be deleted.
+func (f *OrphanFinder) Policy() *storage.OrphanPolicy {
+	return f.policy
+}
+
+// Find returns the resources the backend created on the SVM that belong to none of the supplied volumes or
+// snapshots, in an order in which they may be deleted.  The contents of an orphaned FlexVol, such as its
+// LUNs, are listed before the FlexVol, and the export policy of an orphaned FlexVol isn't listed until the
+// FlexVol is gone.
+func (f *OrphanFinder) Find(
+	backendUUID string, volumes []*storage.VolumeConfig, snapshots []*storage.SnapshotConfig,
+) ([]*storage.OrphanedResource, error) {
+
+	known := newKnownResources(volumes, snapshots)
+
+	flexvols, err := f.listFlexvols()
+	if err != nil {
+		return nil, fmt.Errorf("error listing volumes: %v", err)
+	}
+
+	orphanedVolumes := make([]*storage.OrphanedResource, 0)
+	orphanedLUNs := make([]*storage.OrphanedResource, 0)
+	exportPolicies := make(map[string]bool)
+	for _, flexvol := range flexvols {
+
+		exportPolicies[flexvol.exportPolicy] = true
+
+		// Deleted volumes waiting in the recovery queue match an empty storage prefix
+		if !strings.HasPrefix(flexvol.name, f.prefix) || strings.HasPrefix(flexvol.name, recoveryQueuePrefix) {
+			continue
+		}
+
+		uuid := getCreationUUID(flexvol.comment)
+		if known.volumes[flexvol.name] || uuid == "" || known.uuids[uuid] ||
+			getCreationBackendUUID(flexvol.comment) != backendUUID {
+			continue
+		}
+
+		if f.listLUNs != nil {
+			luns, err := f.listLUNs(flexvol.name)
+			if err != nil {
+				return nil, fmt.Errorf("error listing LUNs in volume %s: %v", flexvol.name, err)
+			}
+			for _, lun := range luns {
+				orphanedLUNs = append(orphanedLUNs, &storage.OrphanedResource{
+					Type:   storage.OrphanedResourceLUN,
+					Name:   lun,
+					Parent: flexvol.name,
+				})
+			}
+		}
+		orphanedVolumes = append(orphanedVolumes, &storage.OrphanedResource{
+			Type: storage.OrphanedResourceVolume,
+			Name: flexvol.name,
+		})
+	}
+
+	resources := orphanedLUNs
+
+	volumeSnapshots, err := f.listSnapshots()
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots: %v", err)
+	}
+	for _, snapshot := range volumeSnapshots {
+		if !known.volumes[snapshot.volume] || known.unmanaged[snapshot.volume] ||
+			!strings.HasPrefix(snapshot.snapshot, csiSnapshotPrefix) ||
+			known.snapshots[snapshot.volume+"/"+snapshot.snapshot] {
+			continue
+		}
+		resources = append(resources, &storage.OrphanedResource{
+			Type:   storage.OrphanedResourceSnapshot,
+			Name:   snapshot.snapshot,
+			Parent: snapshot.volume,
+		})
+	}
+
+	resources = append(resources, orphanedVolumes...)
+
+	// Only the policies scoped to a volume or storage class are considered, as the backend's own policy
+	// is always in use
+	if f.listExportPolicies != nil {
+		policies, err := f.listExportPolicies(getExportPolicyName(backendUUID) + "-")
+		if err != nil {
+			return nil, fmt.Errorf("error listing export policies: %v", err)
+		}
+		for _, policy := range policies {
+			if !exportPolicies[policy] {
+				resources = append(resources, &storage.OrphanedResource{
+					Type: storage.OrphanedResourceExportPolicy,
+					Name: policy,
+				})
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// Delete deletes an orphaned resource returned by Find.
+func (f *OrphanFinder) Delete(ctx context.Context, resource *storage.OrphanedResource) error {
+	return f.deleteResource(ctx, resource)
+}
+
+// deleteOrphanedResource deletes an orphaned resource from the SVM.  A resource that no longer exists
+// has already been deleted.
+func deleteOrphanedResource(client *api.Client, resource *storage.OrphanedResource) error {
+
+	switch resource.Type {
+
+	case storage.OrphanedResourceVolume:
+		response, err := client.VolumeDestroy(resource.Name, true)
+		if err = api.GetError(response, err); err != nil {
+			if zerr, ok := err.(api.ZapiError); ok && zerr.IsNotFound() {
+				return nil
+			}
+			return fmt.Errorf("error destroying volume %s: %v", resource.Name, err)
+		}
+
+	case storage.OrphanedResourceLUN:
+		lunPath := path.Join("/vol", resource.Parent, resource.Name)
+		if response, err := client.LunOffline(lunPath); api.GetError(response, err) != nil {
+			log.WithField("LUN", lunPath).Debugf("Could not take LUN offline; %v", api.GetError(response, err))
+		}
+		response, err := client.LunDestroy(lunPath)
+		if err = api.GetError(response, err); err != nil {
+			return fmt.Errorf("error destroying LUN %s: %v", lunPath, err)
+		}
+
+	case storage.OrphanedResourceSnapshot:
+		response, err := client.SnapshotDelete(resource.Name, resource.Parent)
+		if err = api.GetError(response, err); err != nil {
+			return fmt.Errorf("error deleting snapshot %s of volume %s: %v", resource.Name, resource.Parent, err)
+		}
+
+	case storage.OrphanedResourceExportPolicy:
+		return deleteExportPolicy(resource.Name, client)
+
+	default:
+		return fmt.Errorf("unknown orphaned resource type %s", resource.Type)
+	}
+
+	return nil
+}
+
+// listFlexvolIdentities returns the FlexVols whose names start with the prefix, listing them a page at a time.
+func listFlexvolIdentities(client *api.Client, prefix string) ([]flexvolIdentity, error) {
+
+	flexvols := make([]flexvolIdentity, 0)
+	err := forEachFlexvol(client, prefix, func(volAttrs *azgo.VolumeAttributesType) error {
+		idAttrs := volAttrs.VolumeIdAttributesPtr
+		if idAttrs == nil || idAttrs.NamePtr == nil {
+			return nil
+		}
+		flexvol := flexvolIdentity{name: string(idAttrs.Name())}
+		if idAttrs.CommentPtr != nil {
+			flexvol.comment = idAttrs.Comment()
+		}
+		if volAttrs.VolumeExportAttributesPtr != nil && volAttrs.VolumeExportAttributesPtr.PolicyPtr != nil {
+			flexvol.exportPolicy = volAttrs.VolumeExportAttributesPtr.Policy()
+		}
+		flexvols = append(flexvols, flexvol)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flexvols, nil
+}
+
+// listVolumeSnapshots returns the snapshots of the volumes whose names start with the prefix.
+func listVolumeSnapshots(client *api.Client, prefix string) ([]baseSnapshot, error) {
+
+	response, err := client.SnapshotListForVolumes(prefix)
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]baseSnapshot, 0)
+	if response.Result.AttributesListPtr == nil {
+		return snapshots, nil
+	}
+	for _, snap := range response.Result.AttributesListPtr.SnapshotInfoPtr {
+		if snap.NamePtr != nil && snap.VolumePtr != nil {
+			snapshots = append(snapshots, baseSnapshot{volume: snap.Volume(), snapshot: snap.Name()})
+		}
+	}
+	return snapshots, nil
+}
+
+// listLUNNames returns the names of the LUNs in a FlexVol.
+func listLUNNames(client *api.Client, flexvol string) ([]string, error) {
+
+	response, err := client.LunGetAllForVolume(flexvol)
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	if response.Result.AttributesListPtr == nil {
+		return names, nil
+	}
+	for _, lun := range response.Result.AttributesListPtr.LunInfoPtr {
+		if lun.PathPtr != nil {
+			names = append(names, path.Base(lun.Path()))
+		}
+	}
+	return names, nil
+}
+
+// listExportPolicies returns the names of the export policies that start with the prefix.
+func listExportPolicies(client *api.Client, prefix string) ([]string, error) {
+
+	response, err := client.ExportPolicyList(prefix)
+	if err = api.GetError(response, err); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	if response.Result.AttributesListPtr == nil {
+		return names, nil
+	}
+	for _, policy := range response.Result.AttributesListPtr.ExportPolicyInfoPtr {
+		if policy.PolicyNamePtr != nil {
+			names = append(names, string(policy.PolicyName()))
+		}
+	}
+	return names, nil
+}
+
+// getOrphanPolicy returns whether, and when, a backend's orphaned resources should be deleted.  By default
+// they are only reported.
+func getOrphanPolicy(config *drivers.OntapStorageDriverConfig) (*storage.OrphanPolicy, error) {
+
+	policy := &storage.OrphanPolicy{GracePeriod: defaultOrphanGracePeriod}
+
+	switch config.OrphanPolicy {
+	case "", OrphanPolicyReport:
+	case OrphanPolicyDelete:
+		policy.Delete = true
+	default:
+		return nil, fmt.Errorf("invalid value for orphanPolicy: %s, must be %s or %s", config.OrphanPolicy,
+			OrphanPolicyReport, OrphanPolicyDelete)
+	}
+
+	if config.OrphanGracePeriod != "" {
+		seconds, err := strconv.ParseUint(config.OrphanGracePeriod, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for orphanGracePeriod: %v", err)
+		}
+		policy.GracePeriod = time.Duration(seconds) * time.Second
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+func TestOrphanFinderFind(t *testing.T) {
+
+	prefix := "trident_"
+	config := &drivers.OntapStorageDriverConfig{
+		CommonStorageDriverConfig: &drivers.CommonStorageDriverConfig{StoragePrefix: &prefix},
+	}
+
+	flexvols := []flexvolIdentity{
+		{name: "trident_pvc_1", comment: "trident-backend:backend trident-uuid:1",
+			exportPolicy: "trident-backend-vol-trident_pvc_1"},
+		{name: "trident_pvc_2", comment: "trident-backend:backend trident-uuid:2",
+			exportPolicy: "trident-backend-vol-trident_pvc_2"},
+		{name: "trident_pvc_3", comment: "", exportPolicy: "default"},
+		{name: "trident_pvc_4", comment: "renamed trident-backend:backend trident-uuid:4", exportPolicy: "default"},
+		{name: "deleted_nas_1600003600_trident_pvc_5", comment: "trident-backend:backend trident-uuid:5",
+			exportPolicy: "default"},
+		{name: "other", comment: "trident-backend:backend trident-uuid:6", exportPolicy: "trident-backend-vol-other"},
+		{name: "trident_pvc_7", comment: "trident-backend:other trident-uuid:7", exportPolicy: "default"},
+		{name: "trident_pvc_8", comment: "trident-uuid:8", exportPolicy: "default"},
+	}
+	snapshots := []baseSnapshot{
+		{volume: "trident_pvc_1", snapshot: "snapshot-a"},
+		{volume: "trident_pvc_1", snapshot: "snapshot-b"},
+		{volume: "trident_pvc_1", snapshot: "hourly.0"},
+		{volume: "trident_pvc_2", snapshot: "snapshot-c"},
+		{volume: "unmanaged", snapshot: "snapshot-d"},
+	}
+
+	knownVolumes := []*storage.VolumeConfig{
+		{InternalName: "trident_pvc_1", UUID: "1"},
+		{InternalName: "trident_pvc_imported", UUID: "4"},
+		{InternalName: "unmanaged", ImportNotManaged: true},
+	}
+	knownSnapshots := []*storage.SnapshotConfig{
+		{InternalName: "snapshot-a", VolumeInternalName: "trident_pvc_1"},
+	}
+
+	// Untagged FlexVols, FlexVols renamed by an import, FlexVols tagged by other backends or by releases that
+	// didn't tag the backend, and FlexVols outside the storage prefix or in the recovery queue are never
+	// orphaned, nor are snapshots not created for CSI snapshots or of volumes imported without being managed.
+	// LUNs are listed before their FlexVols.
+	tests := map[string]struct {
+		protocol  tridentconfig.Protocol
+		resources []*storage.OrphanedResource
+	}{
+		"NAS": {
+			protocol: tridentconfig.File,
+			resources: []*storage.OrphanedResource{
+				{Type: storage.OrphanedResourceSnapshot, Name: "snapshot-b", Parent: "trident_pvc_1"},
+				{Type: storage.OrphanedResourceVolume, Name: "trident_pvc_2"},
+				{Type: storage.OrphanedResourceExportPolicy, Name: "trident-backend-sc-gold"},
+			},
+		},
+		"SAN": {
+			protocol: tridentconfig.Block,
+			resources: []*storage.OrphanedResource{
+				{Type: storage.OrphanedResourceLUN, Name: "lun0", Parent: "trident_pvc_2"},
+				{Type: storage.OrphanedResourceSnapshot, Name: "snapshot-b", Parent: "trident_pvc_1"},
+				{Type: storage.OrphanedResourceVolume, Name: "trident_pvc_2"},
+			},
+		},
+	}
+	for name, test := range tests {
+		finder := NewOrphanFinder(config, nil, test.protocol)
+		finder.listFlexvols = func() ([]flexvolIdentity, error) { return flexvols, nil }
+		finder.listSnapshots = func() ([]baseSnapshot, error) { return snapshots, nil }
+		if finder.listLUNs != nil {
+			finder.listLUNs = func(flexvol string) ([]string, error) { return []string{"lun0"}, nil }
+		}
+		if finder.listExportPolicies != nil {
+			finder.listExportPolicies = func(prefix string) ([]string, error) {
+				assert.Equal(t, "trident-backend-", prefix, name)
+				return []string{"trident-backend-vol-trident_pvc_1", "trident-backend-vol-trident_pvc_2",
+					"trident-backend-vol-other", "trident-backend-sc-gold"}, nil
+			}
+		}
+
+		resources, err := finder.Find("backend", knownVolumes, knownSnapshots)
+		assert.NoError(t, err, name)
+		assert.Equal(t, test.resources, resources, name)
+	}
+}
+
+func TestGetOrphanPolicy(t *testing.T) {
+
+	policy, err := getOrphanPolicy(&drivers.OntapStorageDriverConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.OrphanPolicy{Delete: false, GracePeriod: defaultOrphanGracePeriod}, policy)
+
+	policy, err = getOrphanPolicy(&drivers.OntapStorageDriverConfig{OrphanPolicy: "delete", OrphanGracePeriod: "600"})
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.OrphanPolicy{Delete: true, GracePeriod: 10 * time.Minute}, policy)
+
+	_, err = getOrphanPolicy(&drivers.OntapStorageDriverConfig{OrphanPolicy: "purge"})
+	assert.Error(t, err)
+
+	_, err = getOrphanPolicy(&drivers.OntapStorageDriverConfig{OrphanGracePeriod: "-1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+// aggregateProvisioningTTL is how long the thin provisioned capacity of an aggregate is reused before
+// it is read again.  Volumes created in the meantime are added to the cached value.
+const aggregateProvisioningTTL = time.Minute
+
+// aggregateProvisioning is the size of an aggregate and the total size of the thin provisioned FlexVols
+// an SVM has placed on it.
+type aggregateProvisioning struct {
+	sizeBytes        uint64
+	provisionedBytes uint64
+	expires          time.Time
+}
+
+// aggregateProvisioningCache remembers the thin provisioned capacity of aggregates, so that each create
+// need not list every volume on the SVM.
+type aggregateProvisioningCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]*aggregateProvisioning
+	mutex   sync.Mutex
+}
+
+var ontapAggregateProvisioning = newAggregateProvisioningCache(aggregateProvisioningTTL)
+
+func newAggregateProvisioningCache(ttl time.Duration) *aggregateProvisioningCache {
+	return &aggregateProvisioningCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*aggregateProvisioning),
+	}
+}
+
+// reserve adds a new volume to the thin provisioned capacity of an aggregate, unless doing so would take
+// the ratio of provisioned capacity to aggregate size past the limit.  The read function is called to
+// read the aggregate's provisioning if it isn't cached.
+func (c *aggregateProvisioningCache) reserve(
+	key string, sizeBytes uint64, maxRatio float64, read func() (*aggregateProvisioning, error),
+) error {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || c.now().After(entry.expires) {
+		var err error
+		if entry, err = read(); err != nil {
+			return err
+		}
+		entry.expires = c.now().Add(c.ttl)
+		c.entries[key] = entry
+	}
+
+	if entry.sizeBytes == 0 {
+		return fmt.Errorf("aggregate size unknown")
+	}
+	ratio := float64(entry.provisionedBytes+sizeBytes) / float64(entry.sizeBytes)
+	if ratio > maxRatio {
+		return drivers.NewPoolOverprovisionedError(fmt.Sprintf(
+			"aggregate overprovisioning ratio of %.2f would exceed the limit of %.2f", ratio, maxRatio), nil)
+	}
+	entry.provisionedBytes += sizeBytes
+	return nil
+}
+
+// parseMaxOverprovisionRatio returns the value of a maxOverprovisionRatio attribute, ensuring it is positive.
+func parseMaxOverprovisionRatio(value string) (float64, error) {
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio <= 0 {
+		return 0, fmt.Errorf("invalid value for maxOverprovisionRatio: %s", value)
+	}
+	return ratio, nil
+}
+
+// checkAggregateOverprovisioning returns a PoolOverprovisionedError if a thin provisioned volume of the
+// given size would take the ratio of thin provisioned capacity to size of an aggregate past the pool's
+// limit.  Otherwise the volume is counted against the aggregate, so concurrent creates can't together
+// exceed the limit.  Thick provisioned volumes are limited by limitAggregateUsage instead.  The limit
+// is not enforced if the aggregate's size cannot be read.
+func checkAggregateOverprovisioning(
+	aggregate, spaceReserve string, sizeBytes uint64, maxOverprovisionRatio string,
+	config *drivers.OntapStorageDriverConfig, client *api.Client,
+) error {
+
+	if maxOverprovisionRatio == "" || spaceReserve != "none" {
+		return nil
+	}
+	maxRatio, err := parseMaxOverprovisionRatio(maxOverprovisionRatio)
+	if err != nil {
+		return err
+	}
+
+	key := config.ManagementLIF + "/" + config.SVM + "/" + aggregate
+	err = ontapAggregateProvisioning.reserve(key, sizeBytes, maxRatio, func() (*aggregateProvisioning, error) {
+		return readAggregateProvisioning(aggregate, client)
+	})
+	if err != nil && !drivers.IsPoolOverprovisionedError(err) {
+		log.WithFields(log.Fields{
+			"aggregate": aggregate,
+			"error":     err,
+		}).Warning("Could not read aggregate provisioning, not enforcing maxOverprovisionRatio.")
+		return nil
+	}
+	return err
+}
+
+// readAggregateProvisioning reads the size of an aggregate and the total size of the SVM's thin
+// provisioned FlexVols on it.
+func readAggregateProvisioning(aggregate string, client *api.Client) (*aggregateProvisioning, error) {
+
+	provisioning := &aggregateProvisioning{}
+
+	spaceResponse, err := client.AggrSpaceGetIterRequest(aggregate)
+	if err = api.GetError(spaceResponse, err); err != nil {
+		return nil, err
+	}
+	if spaceResponse.Result.AttributesListPtr != nil {
+		for _, aggrSpace := range spaceResponse.Result.AttributesListPtr.SpaceInformationPtr {
+			if aggrSpace.AggregatePtr != nil && aggrSpace.Aggregate() == aggregate &&
+				aggrSpace.AggregateSizePtr != nil {
+				provisioning.sizeBytes = uint64(aggrSpace.AggregateSize())
+				break
+			}
+		}
+	}
+
+	volumeResponse, err := client.VolumeGetAll("")
+	if err = api.GetError(volumeResponse, err); err != nil {
+		return nil, err
+	}
+	if volumeResponse.Result.AttributesListPtr != nil {
+		for _, volAttrs := range volumeResponse.Result.AttributesListPtr.VolumeAttributesPtr {
+			idAttrs, spaceAttrs := volAttrs.VolumeIdAttributesPtr, volAttrs.VolumeSpaceAttributesPtr
+			if idAttrs == nil || spaceAttrs == nil || idAttrs.ContainingAggregateNamePtr == nil ||
+				spaceAttrs.SizePtr == nil || spaceAttrs.SpaceGuaranteePtr == nil {
+				continue
+			}
+			if idAttrs.ContainingAggregateName() == aggregate && spaceAttrs.SpaceGuarantee() == "none" {
+				provisioning.provisionedBytes += uint64(spaceAttrs.Size())
+			}
+		}
+	}
+
+	return provisioning, nil
+}
+
+// newAggregateCreateError returns the error for a create that failed on each of a pool's aggregates.
+// If the pool's overprovisioning limit ruled out every aggregate, pools with a higher limit may still
+// have room, so the backend is not reported as ineligible.
+func newAggregateCreateError(name, poolName string, createErrors []error, physicalPoolNames []string) error {
+
+	overprovisioned := len(createErrors) > 0
+	for _, err := range createErrors {
+		if !drivers.IsPoolOverprovisionedError(err) {
+			overprovisioned = false
+			break
+		}
+	}
+	if !overprovisioned {
+		return drivers.NewBackendIneligibleError(name, createErrors, physicalPoolNames)
+	}
+
+	return drivers.NewPoolOverprovisionedError(fmt.Sprintf(
+		"every aggregate of pool %s would exceed its overprovisioning limit with volume %s", poolName, name),
+		createErrors[0])
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+func TestAggregateProvisioningCacheReserve(t *testing.T) {
+
+	now := time.Now()
+	cache := newAggregateProvisioningCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	reads := 0
+	read := func() (*aggregateProvisioning, error) {
+		reads++
+		return &aggregateProvisioning{sizeBytes: 1000, provisionedBytes: 1500}, nil
+	}
+
+	// Reservations count against the cached capacity until the limit is reached
+	assert.NoError(t, cache.reserve("aggr1", 400, 2.0, read))
+	assert.NoError(t, cache.reserve("aggr1", 100, 2.0, read))
+	err := cache.reserve("aggr1", 1, 2.0, read)
+	assert.True(t, drivers.IsPoolOverprovisionedError(err), "expected overprovisioned error, got %v", err)
+	assert.NoError(t, cache.reserve("aggr1", 1000, 3.0, read))
+	assert.Equal(t, 1, reads)
+
+	// Expired entries are read again
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, cache.reserve("aggr1", 400, 2.0, read))
+	assert.Equal(t, 2, reads)
+
+	// Failed reads aren't cached
+	failed := func() (*aggregateProvisioning, error) { return nil, errors.New("failed") }
+	assert.Error(t, cache.reserve("aggr2", 1, 2.0, failed))
+	assert.NoError(t, cache.reserve("aggr2", 1, 2.0, read))
+}
+
+func TestNewAggregateCreateError(t *testing.T) {
+
+	overprovisioned := fmt.Errorf("pool_0/aggr1; error: %w", drivers.NewPoolOverprovisionedError("too full", nil))
+	failed := errors.New("pool_0/aggr2; error: failed")
+
+	err := newAggregateCreateError("vol1", "pool_0", []error{overprovisioned, overprovisioned},
+		[]string{"aggr1", "aggr2"})
+	assert.True(t, drivers.IsPoolOverprovisionedError(err))
+	assert.False(t, drivers.IsBackendIneligibleError(err))
+
+	err = newAggregateCreateError("vol1", "pool_0", []error{overprovisioned, failed}, []string{"aggr1", "aggr2"})
+	assert.True(t, drivers.IsBackendIneligibleError(err))
+
+	err = newAggregateCreateError("vol1", "pool_0", []error{}, []string{})
+	assert.True(t, drivers.IsBackendIneligibleError(err))
+}
+
+func TestParseMaxOverprovisionRatio(t *testing.T) {
+
+	ratio, err := parseMaxOverprovisionRatio("2.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, ratio)
+
+	for _, value := range []string{"", "0", "-1", "many"} {
+		_, err = parseMaxOverprovisionRatio(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"sync"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+// PoolAttributeProvider contributes an internal attribute to the storage pools of the ONTAP drivers for
+// which it is registered.  A virtual pool takes the attribute's value from its own defaults if it is set
+// there, and from the backend's defaults otherwise.
+type PoolAttributeProvider struct {
+	// Name is the name of the internal attribute
+	Name string
+	// Value returns the attribute's value in a set of defaults, or "" if it isn't set there
+	Value func(defaults *drivers.OntapStorageDriverConfigDefaults) string
+}
+
+var (
+	poolAttributeProviders      = make(map[string][]PoolAttributeProvider)
+	poolAttributeProvidersMutex sync.RWMutex
+)
+
+// RegisterPoolAttributeProvider adds an attribute to the pools of each of the named drivers.  Drivers
+// register their attributes from init functions, so that InitializeStoragePoolsCommon need not know
+// about every driver's options.
+func RegisterPoolAttributeProvider(provider PoolAttributeProvider, driverNames ...string) {
+
+	poolAttributeProvidersMutex.Lock()
+	defer poolAttributeProvidersMutex.Unlock()
+
+	for _, driverName := range driverNames {
+		poolAttributeProviders[driverName] = append(poolAttributeProviders[driverName], provider)
+	}
+}
+
+// getPoolAttributeProviders returns the providers registered for a driver.
+func getPoolAttributeProviders(driverName string) []PoolAttributeProvider {
+
+	poolAttributeProvidersMutex.RLock()
+	defer poolAttributeProvidersMutex.RUnlock()
+
+	return poolAttributeProviders[driverName]
+}
+
+// applyPoolAttributeProviders sets the internal attributes registered for a driver on one of its pools.
+// The virtual pool defaults are nil for a physical pool.
+func applyPoolAttributeProviders(
+	pool *storage.Pool, driverName string, config *drivers.OntapStorageDriverConfig,
+	vpoolDefaults *drivers.OntapStorageDriverConfigDefaults,
+) {
+	for _, provider := range getPoolAttributeProviders(driverName) {
+		value := ""
+		if vpoolDefaults != nil {
+			value = provider.Value(vpoolDefaults)
+		}
+		if value == "" {
+			value = provider.Value(&config.OntapStorageDriverConfigDefaults)
+		}
+		pool.InternalAttributes[provider.Name] = value
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+)
+
+func TestApplyPoolAttributeProviders(t *testing.T) {
+
+	RegisterPoolAttributeProvider(PoolAttributeProvider{
+		Name:  "testPolicy",
+		Value: func(defaults *drivers.OntapStorageDriverConfigDefaults) string { return defaults.SnapshotPolicy },
+	}, "test-driver")
+
+	config := &drivers.OntapStorageDriverConfig{}
+	config.SnapshotPolicy = "backend-policy"
+
+	// Physical pools use the backend's defaults
+	pool := storage.NewStoragePool(nil, "aggr1")
+	applyPoolAttributeProviders(pool, "test-driver", config, nil)
+	assert.Equal(t, "backend-policy", pool.InternalAttributes["testPolicy"])
+
+	// Virtual pools inherit the backend's defaults unless they set their own
+	vpool := drivers.OntapStorageDriverPool{}
+	pool = storage.NewStoragePool(nil, "pool_0")
+	applyPoolAttributeProviders(pool, "test-driver", config, &vpool.OntapStorageDriverConfigDefaults)
+	assert.Equal(t, "backend-policy", pool.InternalAttributes["testPolicy"])
+
+	vpool.SnapshotPolicy = "pool-policy"
+	applyPoolAttributeProviders(pool, "test-driver", config, &vpool.OntapStorageDriverConfigDefaults)
+	assert.Equal(t, "pool-policy", pool.InternalAttributes["testPolicy"])
+
+	// Other drivers' pools are unaffected
+	pool = storage.NewStoragePool(nil, "aggr1")
+	applyPoolAttributeProviders(pool, drivers.OntapNASStorageDriverName, config, nil)
+	_, ok := pool.InternalAttributes["testPolicy"]
+	assert.False(t, ok)
+}
+
+func TestBuiltInPoolAttributeProviders(t *testing.T) {
+
+	config := &drivers.OntapStorageDriverConfig{}
+	config.SpaceAllocation = "false"
+	config.TieringMinimumCoolingDays = "10"
+
+	pool := storage.NewStoragePool(nil, "aggr1")
+	applyPoolAttributeProviders(pool, drivers.OntapSANEconomyStorageDriverName, config, nil)
+	assert.Equal(t, "false", pool.InternalAttributes[SpaceAllocation])
+	assert.Equal(t, "10", pool.InternalAttributes[TieringMinimumCoolingDays])
+
+	pool = storage.NewStoragePool(nil, "aggr1")
+	applyPoolAttributeProviders(pool, drivers.OntapNASStorageDriverName, config, nil)
+	_, ok := pool.InternalAttributes[SpaceAllocation]
+	assert.False(t, ok)
+	assert.Equal(t, "10", pool.InternalAttributes[TieringMinimumCoolingDays])
+}
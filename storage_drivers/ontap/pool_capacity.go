@@ -0,0 +1,212 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+// PoolCapacityMonitor periodically records the free and provisioned capacity of a driver's storage pools,
+// so that the core can avoid placing volumes in pools that are nearly full.  A physical pool is an
+// aggregate, unless the driver's volumes span all of the SVM's aggregates, as FlexGroups do.
+type PoolCapacityMonitor struct {
+	config          *drivers.OntapStorageDriverConfig
+	client          *api.Client
+	physicalPools   map[string]*storage.Pool
+	virtualPools    map[string]*storage.Pool
+	spansAggregates bool
+	mutex           sync.Mutex
+}
+
+// NewPoolCapacityMonitor returns a monitor for a driver's pools.
+func NewPoolCapacityMonitor(
+	config *drivers.OntapStorageDriverConfig, client *api.Client, physicalPools, virtualPools map[string]*storage.Pool,
+	spansAggregates bool,
+) *PoolCapacityMonitor {
+	return &PoolCapacityMonitor{
+		config:          config,
+		client:          client,
+		physicalPools:   physicalPools,
+		virtualPools:    virtualPools,
+		spansAggregates: spansAggregates,
+	}
+}
+
+// Refresh reads the capacity of the SVM's aggregates and records it in each pool.  Pools keep their
+// previous capacity if it cannot be read.
+func (m *PoolCapacityMonitor) Refresh() {
+
+	aggregates, err := m.client.VserverGetAggregateNames()
+	if err != nil {
+		log.WithField("error", err).Warning("Could not refresh storage pool capacity.")
+		return
+	}
+
+	capacities, err := getAggregateCapacities(m.client, aggregates)
+	if err != nil {
+		log.WithField("error", err).Warning("Could not refresh storage pool capacity.")
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	assignPoolCapacities(capacities, m.physicalPools, m.virtualPools, m.spansAggregates)
+}
+
+// SetPools replaces the pools whose capacity is recorded, as when a driver rediscovers its pools.
+func (m *PoolCapacityMonitor) SetPools(physicalPools, virtualPools map[string]*storage.Pool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.physicalPools = physicalPools
+	m.virtualPools = virtualPools
+}
+
+// HousekeepingJob returns the job that periodically refreshes the pools' capacity, starting right away.
+// The interval is read from the config file, falling back to the default if missing or invalid.
+func (m *PoolCapacityMonitor) HousekeepingJob() *HousekeepingJob {
+
+	poolCapacityRefreshPeriodSecs := defaultPoolCapacityRefreshPeriodSecs
+	if m.config.PoolCapacityRefreshPeriod != "" {
+		i, err := strconv.ParseUint(m.config.PoolCapacityRefreshPeriod, 10, 64)
+		if err != nil {
+			log.WithField("interval", m.config.PoolCapacityRefreshPeriod).Warnf(
+				"Invalid pool capacity refresh interval. %v", err)
+		} else {
+			poolCapacityRefreshPeriodSecs = i
+		}
+	}
+	log.WithField("IntervalSeconds", poolCapacityRefreshPeriodSecs).Debug(
+		"Configured pool capacity refresh period.")
+
+	interval := time.Duration(poolCapacityRefreshPeriodSecs) * time.Second
+
+	return &HousekeepingJob{
+		Name:     poolCapacityRefreshJob,
+		Interval: interval,
+		Jitter:   interval / housekeepingJitterDivisor,
+		Run:      m.Refresh,
+	}
+}
+
+// getAggregateCapacities returns the capacity of each of the named aggregates.  The aggregates' space is
+// read cluster-wide if the user may do so, and otherwise only their free space is read through the SVM.
+// The provisioned capacity of an aggregate is the total size of the SVM's FlexVols on it.
+func getAggregateCapacities(client *api.Client, aggregates []string) (map[string]storage.PoolCapacity, error) {
+
+	refreshed := time.Now()
+	capacities := make(map[string]storage.PoolCapacity, len(aggregates))
+	wanted := make(map[string]bool, len(aggregates))
+	for _, aggregate := range aggregates {
+		wanted[aggregate] = true
+	}
+
+	spaceResponse, err := client.AggrSpaceGetIterRequest("")
+	if err = api.GetError(spaceResponse, err); err == nil {
+		if spaceResponse.Result.AttributesListPtr != nil {
+			for _, aggrSpace := range spaceResponse.Result.AttributesListPtr.SpaceInformationPtr {
+				if aggrSpace.AggregatePtr == nil || aggrSpace.AggregateSizePtr == nil ||
+					aggrSpace.UsedIncludingSnapshotReservePtr == nil {
+					continue
+				}
+				// Aggregates with a capacity tier are listed once per tier, performance tier first
+				name := aggrSpace.Aggregate()
+				if _, ok := capacities[name]; ok || !wanted[name] {
+					continue
+				}
+				capacity := storage.PoolCapacity{TotalBytes: uint64(aggrSpace.AggregateSize()), Refreshed: refreshed}
+				if used := uint64(aggrSpace.UsedIncludingSnapshotReserve()); used < capacity.TotalBytes {
+					capacity.FreeBytes = capacity.TotalBytes - used
+				}
+				capacities[name] = capacity
+			}
+		}
+	} else {
+		log.WithField("error", err).Debug("Could not read aggregate space, reading free space through the SVM.")
+
+		showResponse, err := client.VserverShowAggrGetIterRequest()
+		if err = api.GetError(showResponse, err); err != nil {
+			return nil, err
+		}
+		if showResponse.Result.AttributesListPtr != nil {
+			for _, aggr := range showResponse.Result.AttributesListPtr.ShowAggregatesPtr {
+				if aggr.AggregateNamePtr == nil || aggr.AvailableSizePtr == nil {
+					continue
+				}
+				name := string(aggr.AggregateName())
+				if wanted[name] {
+					capacities[name] = storage.PoolCapacity{
+						FreeBytes: uint64(aggr.AvailableSize()),
+						Refreshed: refreshed,
+					}
+				}
+			}
+		}
+	}
+
+	volumeResponse, err := client.VolumeGetAll("")
+	if err = api.GetError(volumeResponse, err); err != nil {
+		log.WithField("error", err).Warning("Could not read the provisioned capacity of aggregates.")
+	} else if volumeResponse.Result.AttributesListPtr != nil {
+		for _, volAttrs := range volumeResponse.Result.AttributesListPtr.VolumeAttributesPtr {
+			if volAttrs.VolumeIdAttributesPtr == nil || volAttrs.VolumeSpaceAttributesPtr == nil ||
+				volAttrs.VolumeIdAttributesPtr.ContainingAggregateNamePtr == nil ||
+				volAttrs.VolumeSpaceAttributesPtr.SizePtr == nil {
+				continue
+			}
+			name := volAttrs.VolumeIdAttributesPtr.ContainingAggregateName()
+			if capacity, ok := capacities[name]; ok {
+				capacity.ProvisionedBytes += uint64(volAttrs.VolumeSpaceAttributesPtr.Size())
+				capacities[name] = capacity
+			}
+		}
+	}
+
+	return capacities, nil
+}
+
+// assignPoolCapacities records the capacity of the aggregates in the pools that use them.  When each
+// volume is placed on a single aggregate, a physical pool has the capacity of its aggregate and a
+// virtual pool, which may place volumes on any aggregate, that of the aggregate with the most free
+// space.  When volumes span the aggregates, every pool has their combined capacity.
+func assignPoolCapacities(
+	capacities map[string]storage.PoolCapacity, physicalPools, virtualPools map[string]*storage.Pool,
+	spansAggregates bool,
+) {
+	if len(capacities) == 0 {
+		return
+	}
+
+	var combined, largest storage.PoolCapacity
+	for _, capacity := range capacities {
+		combined.TotalBytes += capacity.TotalBytes
+		combined.FreeBytes += capacity.FreeBytes
+		combined.ProvisionedBytes += capacity.ProvisionedBytes
+		combined.Refreshed = capacity.Refreshed
+		if capacity.FreeBytes >= largest.FreeBytes {
+			largest = capacity
+		}
+	}
+
+	for name, pool := range physicalPools {
+		if spansAggregates {
+			pool.SetCapacity(combined)
+		} else if capacity, ok := capacities[name]; ok {
+			pool.SetCapacity(capacity)
+		}
+	}
+	for _, pool := range virtualPools {
+		if spansAggregates {
+			pool.SetCapacity(combined)
+		} else {
+			pool.SetCapacity(largest)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+func TestAssignPoolCapacities(t *testing.T) {
+
+	capacities := map[string]storage.PoolCapacity{
+		"aggr1": {TotalBytes: 1000, FreeBytes: 100, ProvisionedBytes: 1500},
+		"aggr2": {TotalBytes: 2000, FreeBytes: 1200, ProvisionedBytes: 500},
+	}
+	newPools := func(names ...string) map[string]*storage.Pool {
+		pools := make(map[string]*storage.Pool)
+		for _, name := range names {
+			pools[name] = storage.NewStoragePool(nil, name)
+		}
+		return pools
+	}
+
+	// Physical pools get the capacity of their aggregate, virtual pools that of the emptiest aggregate
+	physicalPools, virtualPools := newPools("aggr1", "aggr2", "aggr3"), newPools("pool_0")
+	assignPoolCapacities(capacities, physicalPools, virtualPools, false)
+	assert.Equal(t, uint64(100), physicalPools["aggr1"].Capacity().FreeBytes)
+	assert.Equal(t, uint64(1200), physicalPools["aggr2"].Capacity().FreeBytes)
+	assert.Nil(t, physicalPools["aggr3"].Capacity())
+	assert.Equal(t, uint64(1200), virtualPools["pool_0"].Capacity().FreeBytes)
+
+	// Pools spanning the aggregates get their combined capacity
+	physicalPools, virtualPools = newPools("flexgroup"), newPools("pool_0")
+	assignPoolCapacities(capacities, physicalPools, virtualPools, true)
+	expected := storage.PoolCapacity{TotalBytes: 3000, FreeBytes: 1300, ProvisionedBytes: 2000}
+	assert.Equal(t, expected, *physicalPools["flexgroup"].Capacity())
+	assert.Equal(t, expected, *virtualPools["pool_0"].Capacity())
+
+	// Pools are left alone if no capacity was read
+	physicalPools = newPools("aggr1")
+	assignPoolCapacities(map[string]storage.PoolCapacity{}, physicalPools, nil, false)
+	assert.Nil(t, physicalPools["aggr1"].Capacity())
+}
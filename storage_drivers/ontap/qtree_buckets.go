@@ -0,0 +1,166 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// Limits on the number of qtrees placed in one Flexvol
+	defaultQtreesPerFlexvol = 200
+	minQtreesPerFlexvol     = 50
+	maxQtreesPerFlexvol     = 300
+
+	// Limits on the ratio of a Flexvol's usable space to the sum of its qtrees' quotas
+	defaultQtreeFlexvolSizeRatio = 1.0
+	maxQtreeFlexvolSizeRatio     = 10.0
+
+	// Flexvol naming schemes
+	qtreeFlexvolNameSchemeRandom     = "random"
+	qtreeFlexvolNameSchemeSequential = "sequential"
+)
+
+// validateQtreePacking checks the backend's options for packing qtrees into Flexvols and caches their
+// parsed values.
+func (d *NASQtreeStorageDriver) validateQtreePacking() error {
+
+	d.qtreesPerFlexvol = defaultQtreesPerFlexvol
+	if d.Config.QtreesPerFlexvol != "" {
+		limit, err := strconv.Atoi(d.Config.QtreesPerFlexvol)
+		if err != nil || limit < minQtreesPerFlexvol || limit > maxQtreesPerFlexvol {
+			return fmt.Errorf("invalid value for qtreesPerFlexvol: %s, must be between %d and %d",
+				d.Config.QtreesPerFlexvol, minQtreesPerFlexvol, maxQtreesPerFlexvol)
+		}
+		d.qtreesPerFlexvol = limit
+	}
+
+	d.flexvolSizeRatio = defaultQtreeFlexvolSizeRatio
+	if d.Config.QtreeFlexvolSizeRatio != "" {
+		ratio, err := strconv.ParseFloat(d.Config.QtreeFlexvolSizeRatio, 64)
+		if err != nil || ratio <= 0 || ratio > maxQtreeFlexvolSizeRatio {
+			return fmt.Errorf("invalid value for qtreeFlexvolSizeRatio: %s, must be above 0 and at most %v",
+				d.Config.QtreeFlexvolSizeRatio, maxQtreeFlexvolSizeRatio)
+		}
+		d.flexvolSizeRatio = ratio
+	}
+
+	switch d.Config.QtreeFlexvolNameScheme {
+	case "", qtreeFlexvolNameSchemeRandom, qtreeFlexvolNameSchemeSequential:
+	default:
+		return fmt.Errorf("invalid value for qtreeFlexvolNameScheme: %s", d.Config.QtreeFlexvolNameScheme)
+	}
+
+	return nil
+}
+
+// getNewFlexvolName returns the name for a new Flexvol to hold qtrees.  The sequential scheme numbers
+// the Flexvols in the order they are created, never reusing the number of one since pruned, while the
+// default scheme appends random letters.
+func (d *NASQtreeStorageDriver) getNewFlexvolName() (string, error) {
+
+	if d.Config.QtreeFlexvolNameScheme != qtreeFlexvolNameSchemeSequential {
+		return d.FlexvolNamePrefix() + utils.RandomString(10), nil
+	}
+
+	volumeListResponse, err := d.API.VolumeList(d.FlexvolNamePrefix())
+	if err = api.GetError(volumeListResponse, err); err != nil {
+		return "", fmt.Errorf("error listing Flexvols: %v", err)
+	}
+
+	// Random suffixes are letters only, so Flexvols named under either scheme may share a prefix
+	highest := 0
+	if volumeListResponse.Result.AttributesListPtr != nil {
+		for _, volAttrs := range volumeListResponse.Result.AttributesListPtr.VolumeAttributesPtr {
+			volIDAttrs := volAttrs.VolumeIdAttributes()
+			suffix := strings.TrimPrefix(string(volIDAttrs.Name()), d.FlexvolNamePrefix())
+			if number, err := strconv.Atoi(suffix); err == nil && number > highest {
+				highest = number
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s%04d", d.FlexvolNamePrefix(), highest+1), nil
+}
+
+// GetBucketReport returns the Flexvols holding this backend's qtrees, with the number of qtrees in each
+// and the sum of their quotas, so that an uneven distribution of qtrees may be found.
+func (d *NASQtreeStorageDriver) GetBucketReport(ctx context.Context) (*storage.BucketReport, error) {
+
+	client := d.API.WithContext(ctx)
+
+	volumesResponse, err := client.VolumeGetAll(d.FlexvolNamePrefix())
+	if err = api.GetError(volumesResponse, err); err != nil {
+		return nil, fmt.Errorf("error listing Flexvols: %v", err)
+	}
+
+	buckets := make(map[string]*storage.VolumeBucket)
+	allocated := make(map[string]int64)
+	if volumesResponse.Result.AttributesListPtr != nil {
+		for _, volAttrs := range volumesResponse.Result.AttributesListPtr.VolumeAttributesPtr {
+			volIDAttrs := volAttrs.VolumeIdAttributes()
+			name := string(volIDAttrs.Name())
+			bucket := &storage.VolumeBucket{Name: name, Pool: volIDAttrs.ContainingAggregateName()}
+			if volAttrs.VolumeSpaceAttributesPtr != nil {
+				bucket.Size = strconv.Itoa(volAttrs.VolumeSpaceAttributesPtr.Size())
+			}
+			buckets[name] = bucket
+		}
+	}
+
+	report := &storage.BucketReport{VolumeLimit: d.qtreesPerFlexvol, Buckets: make([]*storage.VolumeBucket, 0)}
+	if len(buckets) == 0 {
+		return report, nil
+	}
+
+	qtreesResponse, err := client.QtreeGetAll(d.FlexvolNamePrefix())
+	if err = api.GetError(qtreesResponse, err); err != nil {
+		return nil, fmt.Errorf("error listing qtrees: %v", err)
+	}
+
+	quotasResponse, err := client.QuotaEntryList(d.FlexvolNamePrefix() + "*")
+	if err = api.GetError(quotasResponse, err); err != nil {
+		return nil, fmt.Errorf("error listing quotas: %v", err)
+	}
+
+	diskLimits := make(map[string]int64)
+	if quotasResponse.Result.AttributesListPtr != nil {
+		for _, quota := range quotasResponse.Result.AttributesListPtr.QuotaEntryPtr {
+			diskLimits[quota.QuotaTarget()] = convertDiskLimitToBytes(quota.DiskLimit())
+		}
+	}
+
+	if qtreesResponse.Result.AttributesListPtr != nil {
+		for _, qtree := range qtreesResponse.Result.AttributesListPtr.QtreeInfoPtr {
+
+			// Ignore Flexvol-level qtrees
+			bucket, ok := buckets[qtree.Volume()]
+			if !ok || qtree.Qtree() == "" {
+				continue
+			}
+
+			if strings.HasPrefix(qtree.Qtree(), deletedQtreeNamePrefix) {
+				bucket.DeletedVolumes++
+				continue
+			}
+			bucket.Volumes++
+			allocated[bucket.Name] += diskLimits[fmt.Sprintf("/vol/%s/%s", qtree.Volume(), qtree.Qtree())]
+		}
+	}
+
+	for name, bucket := range buckets {
+		bucket.Allocated = strconv.FormatInt(allocated[name], 10)
+		report.Buckets = append(report.Buckets, bucket)
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool { return report.Buckets[i].Name < report.Buckets[j].Name })
+
+	return report, nil
+}
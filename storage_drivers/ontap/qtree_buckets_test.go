@@ -0,0 +1,152 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+// newTestQtreeBucketServer returns a ZAPI server listing three Flexvols holding qtrees, one of them
+// named under the random scheme, and the qtrees and quota rules in two of them.
+func newTestQtreeBucketServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result := `<results status="passed"><num-records>0</num-records></results>`
+		switch {
+		case strings.Contains(string(body), "<volume-get-iter>"):
+			result = `<results status="passed"><num-records>3</num-records><attributes-list>`
+			for _, volume := range []string{"trident_qtree_pool_0002", "trident_qtree_pool_0009",
+				"trident_qtree_pool_ABCDEFGHIJ"} {
+				result += `<volume-attributes><volume-id-attributes><name>` + volume + `</name>` +
+					`<containing-aggregate-name>aggr1</containing-aggregate-name></volume-id-attributes>` +
+					`<volume-space-attributes><size>10737418240</size></volume-space-attributes>` +
+					`</volume-attributes>`
+			}
+			result += `</attributes-list></results>`
+		case strings.Contains(string(body), "<qtree-list-iter>"):
+			result = `<results status="passed"><num-records>5</num-records><attributes-list>` +
+				`<qtree-info><volume>trident_qtree_pool_0002</volume><qtree></qtree></qtree-info>` +
+				`<qtree-info><volume>trident_qtree_pool_0002</volume><qtree>trident_pvc_1</qtree></qtree-info>` +
+				`<qtree-info><volume>trident_qtree_pool_0002</volume><qtree>trident_pvc_2</qtree></qtree-info>` +
+				`<qtree-info><volume>trident_qtree_pool_0002</volume><qtree>deleted_trident_pvc_3_ABCDE</qtree></qtree-info>` +
+				`<qtree-info><volume>trident_qtree_pool_0009</volume><qtree>trident_pvc_4</qtree></qtree-info>` +
+				`</attributes-list></results>`
+		case strings.Contains(string(body), "<quota-list-entries-iter>"):
+			result = `<results status="passed"><num-records>3</num-records><attributes-list>` +
+				`<quota-entry><quota-target>/vol/trident_qtree_pool_0002/trident_pvc_1</quota-target>` +
+				`<disk-limit>1048576</disk-limit></quota-entry>` +
+				`<quota-entry><quota-target>/vol/trident_qtree_pool_0002/trident_pvc_2</quota-target>` +
+				`<disk-limit>2097152</disk-limit></quota-entry>` +
+				`<quota-entry><quota-target>/vol/trident_qtree_pool_0009/trident_pvc_4</quota-target>` +
+				`<disk-limit>1048576</disk-limit></quota-entry>` +
+				`</attributes-list></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+}
+
+func newTestQtreeBucketDriver(server *httptest.Server) *NASQtreeStorageDriver {
+	return &NASQtreeStorageDriver{
+		Config:            *newTestOntapSANConfig(),
+		API:               api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")}),
+		flexvolNamePrefix: "trident_qtree_pool_",
+	}
+}
+
+func TestValidateQtreePacking(t *testing.T) {
+
+	d := &NASQtreeStorageDriver{Config: *newTestOntapSANConfig()}
+	assert.NoError(t, d.validateQtreePacking())
+	assert.Equal(t, defaultQtreesPerFlexvol, d.qtreesPerFlexvol)
+	assert.Equal(t, defaultQtreeFlexvolSizeRatio, d.flexvolSizeRatio)
+
+	d.Config.QtreesPerFlexvol = "100"
+	d.Config.QtreeFlexvolSizeRatio = "0.5"
+	d.Config.QtreeFlexvolNameScheme = qtreeFlexvolNameSchemeSequential
+	assert.NoError(t, d.validateQtreePacking())
+	assert.Equal(t, 100, d.qtreesPerFlexvol)
+	assert.Equal(t, 0.5, d.flexvolSizeRatio)
+
+	for _, value := range []string{"49", "301", "many"} {
+		d.Config.QtreesPerFlexvol = value
+		assert.Error(t, d.validateQtreePacking(), "expected invalid qtreesPerFlexvol error")
+	}
+	d.Config.QtreesPerFlexvol = ""
+
+	for _, value := range []string{"0", "-1", "11"} {
+		d.Config.QtreeFlexvolSizeRatio = value
+		assert.Error(t, d.validateQtreePacking(), "expected invalid qtreeFlexvolSizeRatio error")
+	}
+	d.Config.QtreeFlexvolSizeRatio = ""
+
+	d.Config.QtreeFlexvolNameScheme = "alphabetical"
+	assert.Error(t, d.validateQtreePacking(), "expected invalid qtreeFlexvolNameScheme error")
+}
+
+func TestGetNewFlexvolName(t *testing.T) {
+
+	server := newTestQtreeBucketServer()
+	defer server.Close()
+	d := newTestQtreeBucketDriver(server)
+
+	name, err := d.getNewFlexvolName()
+	assert.NoError(t, err)
+	assert.Regexp(t, "^trident_qtree_pool_[A-Z]{10}$", name)
+
+	// The next number follows the highest in use, skipping those since pruned
+	d.Config.QtreeFlexvolNameScheme = qtreeFlexvolNameSchemeSequential
+	name, err = d.getNewFlexvolName()
+	assert.NoError(t, err)
+	assert.Equal(t, "trident_qtree_pool_0010", name)
+}
+
+func TestGetBucketReport(t *testing.T) {
+
+	server := newTestQtreeBucketServer()
+	defer server.Close()
+	d := newTestQtreeBucketDriver(server)
+	d.qtreesPerFlexvol = 2
+
+	report, err := d.GetBucketReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.BucketReport{
+		VolumeLimit: 2,
+		Buckets: []*storage.VolumeBucket{
+			{
+				Name:           "trident_qtree_pool_0002",
+				Pool:           "aggr1",
+				Size:           "10737418240",
+				Allocated:      "3221225472",
+				Volumes:        2,
+				DeletedVolumes: 1,
+			},
+			{
+				Name:      "trident_qtree_pool_0009",
+				Pool:      "aggr1",
+				Size:      "10737418240",
+				Allocated: "1073741824",
+				Volumes:   1,
+			},
+			{
+				Name:      "trident_qtree_pool_ABCDEFGHIJ",
+				Pool:      "aggr1",
+				Size:      "10737418240",
+				Allocated: "0",
+			},
+		},
+	}, report)
+
+	assert.Equal(t, 2, report.MinimumBuckets())
+	assert.Equal(t, []*storage.VolumeBucket{report.Buckets[0]}, report.OverLimit())
+}
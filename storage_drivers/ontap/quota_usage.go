@@ -0,0 +1,107 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+// QuotaUsageMonitor periodically reads the quota report of the Flexvols holding a driver's qtrees, so that
+// the space and files each qtree-backed volume uses may be reported without a storage call per volume.
+type QuotaUsageMonitor struct {
+	client         *api.Client
+	flexvolPattern string
+	usage          map[string]*storage.VolumeUsage
+	mutex          sync.RWMutex
+}
+
+// NewQuotaUsageMonitor returns a monitor for the qtrees in the Flexvols whose names start with the prefix.
+func NewQuotaUsageMonitor(client *api.Client, flexvolPrefix string) *QuotaUsageMonitor {
+	return &QuotaUsageMonitor{
+		client:         client,
+		flexvolPattern: flexvolPrefix + "*",
+		usage:          make(map[string]*storage.VolumeUsage),
+	}
+}
+
+// Refresh reads the usage of every qtree.  The previous usage is kept if the quota report cannot be read.
+func (m *QuotaUsageMonitor) Refresh() {
+
+	response, err := m.client.QuotaReport(m.flexvolPattern)
+	if err = api.GetError(response, err); err != nil {
+		log.WithField("error", err).Warning("Could not refresh qtree usage.")
+		return
+	}
+
+	updateTime := time.Now().UTC().Format(time.RFC3339)
+	usage := make(map[string]*storage.VolumeUsage)
+	if response.Result.AttributesListPtr != nil {
+		for _, quota := range response.Result.AttributesListPtr.QuotaPtr {
+			// The report also lists the default rule's usage, which belongs to no qtree
+			if quota.TreePtr == nil || quota.Tree() == "" {
+				continue
+			}
+			usage[quota.Tree()] = newVolumeUsage(quota, updateTime)
+		}
+	}
+
+	m.mutex.Lock()
+	m.usage = usage
+	m.mutex.Unlock()
+}
+
+// Get returns the most recently read usage of a qtree, or nil if it hasn't been read.
+func (m *QuotaUsageMonitor) Get(qtree string) *storage.VolumeUsage {
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	usage, ok := m.usage[qtree]
+	if !ok {
+		return nil
+	}
+	usageCopy := *usage
+	return &usageCopy
+}
+
+// HousekeepingJob returns the job that periodically refreshes the qtrees' usage, starting right away.
+func (m *QuotaUsageMonitor) HousekeepingJob() *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:     quotaUsageRefreshJob,
+		Interval: quotaUsageRefreshPeriod,
+		Jitter:   quotaUsageRefreshPeriod / housekeepingJitterDivisor,
+		Run:      m.Refresh,
+	}
+}
+
+// newVolumeUsage converts a quota report entry, which gives space in KiB and "-" for no limit, to a
+// volume's usage.
+func newVolumeUsage(quota azgo.QuotaType, updateTime string) *storage.VolumeUsage {
+
+	parse := func(value *string) int64 {
+		if value == nil {
+			return 0
+		}
+		i, err := strconv.ParseInt(*value, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return i
+	}
+
+	return &storage.VolumeUsage{
+		UsedBytes:  parse(quota.DiskUsedPtr) * 1024,
+		LimitBytes: parse(quota.DiskLimitPtr) * 1024,
+		FilesUsed:  parse(quota.FilesUsedPtr),
+		FilesLimit: parse(quota.FileLimitPtr),
+		UpdateTime: updateTime,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+func TestQuotaUsageMonitor(t *testing.T) {
+
+	available := true
+	requests := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		result := `<results status="failed" errno="13001" reason="unavailable"/>`
+		if available {
+			result = `<results status="passed"><num-records>3</num-records><attributes-list>` +
+				`<quota><volume>trident_qtree_pool_1</volume><tree></tree><disk-used>0</disk-used>` +
+				`<disk-limit>-</disk-limit><files-used>0</files-used><file-limit>-</file-limit></quota>` +
+				`<quota><volume>trident_qtree_pool_1</volume><tree>trident_pvc_1</tree><disk-used>524288</disk-used>` +
+				`<disk-limit>1048576</disk-limit><files-used>120</files-used><file-limit>10000</file-limit></quota>` +
+				`<quota><volume>trident_qtree_pool_1</volume><tree>trident_pvc_2</tree><disk-used>4</disk-used>` +
+				`<disk-limit>2097152</disk-limit><files-used>1</files-used><file-limit>-</file-limit></quota>` +
+				`</attributes-list></results>`
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.21" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+	monitor := NewQuotaUsageMonitor(client, "trident_qtree_pool_")
+	assert.Nil(t, monitor.Get("trident_pvc_1"))
+
+	monitor.Refresh()
+	assert.Len(t, requests, 1)
+	assert.Contains(t, requests[0], "<volume>trident_qtree_pool_*</volume>")
+	assert.Contains(t, requests[0], "<quota-type>tree</quota-type>")
+
+	usage := monitor.Get("trident_pvc_1")
+	assert.NotNil(t, usage)
+	assert.NotEmpty(t, usage.UpdateTime)
+	usage.UpdateTime = ""
+	assert.Equal(t, &storage.VolumeUsage{
+		UsedBytes:  536870912,
+		LimitBytes: 1073741824,
+		FilesUsed:  120,
+		FilesLimit: 10000,
+	}, usage)
+	assert.Equal(t, int64(0), monitor.Get("trident_pvc_2").FilesLimit)
+	assert.Nil(t, monitor.Get(""))
+
+	// Usage is kept if the report can't be read
+	available = false
+	monitor.Refresh()
+	assert.Equal(t, int64(536870912), monitor.Get("trident_pvc_1").UsedBytes)
+}
@@ -0,0 +1,276 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// recoveryQueuePrefix starts the names of deleted FlexVols waiting in a recovery queue.  It doesn't
+	// start with the default storage prefix, so queued FlexVols aren't mistaken for live volumes.
+	recoveryQueuePrefix = "deleted_"
+
+	// recoveryQueueReapInterval is how often expired FlexVols are looked for.
+	recoveryQueueReapInterval = 10 * time.Minute
+)
+
+// recoveryQueueHook does driver-specific work on a volume as it enters or leaves the recovery queue.
+type recoveryQueueHook func(ctx context.Context, name string) error
+
+// RecoveryQueue keeps the FlexVols a driver deletes for a retention period, so that an accidental deletion
+// may be undone, and destroys them once the period expires.  A queued FlexVol is renamed to record which
+// kind of driver deleted it and when it expires, so the queue is shared by every backend of that kind on
+// the SVM, and each FlexVol keeps the retention of the backend that deleted it.
+type RecoveryQueue struct {
+	prefix    string
+	retention time.Duration
+	now       func() time.Time
+
+	listVolumes  func(prefix string) ([]string, error)
+	volumeExists func(name string) (bool, error)
+	renameVolume func(name, newName string) error
+
+	// release runs before a volume is queued, restore after it is recovered
+	release recoveryQueueHook
+	restore recoveryQueueHook
+	// destroy permanently destroys a queued FlexVol, given its queued and original names
+	destroy func(ctx context.Context, queuedName, name string) error
+}
+
+// NewRecoveryQueue returns the recovery queue for the FlexVols deleted by one kind of driver, such as
+// "nas" or "san".  Volumes are only queued if the retention is positive.
+func NewRecoveryQueue(
+	client *api.Client, kind string, retention time.Duration, release, restore recoveryQueueHook,
+	destroy func(ctx context.Context, queuedName, name string) error,
+) *RecoveryQueue {
+	return &RecoveryQueue{
+		prefix:    recoveryQueuePrefix + kind + "_",
+		retention: retention,
+		now:       time.Now,
+		listVolumes: func(prefix string) ([]string, error) {
+			response, err := client.VolumeList(prefix)
+			if err = api.GetError(response, err); err != nil {
+				return nil, err
+			}
+			names := make([]string, 0)
+			if response.Result.AttributesListPtr != nil {
+				for _, volAttrs := range response.Result.AttributesListPtr.VolumeAttributesPtr {
+					if volAttrs.VolumeIdAttributesPtr != nil && volAttrs.VolumeIdAttributesPtr.NamePtr != nil {
+						names = append(names, string(volAttrs.VolumeIdAttributesPtr.Name()))
+					}
+				}
+			}
+			return names, nil
+		},
+		volumeExists: func(name string) (bool, error) {
+			return client.VolumeExists(name)
+		},
+		renameVolume: func(name, newName string) error {
+			response, err := client.VolumeRename(name, newName)
+			return api.GetError(response, err)
+		},
+		release: release,
+		restore: restore,
+		destroy: destroy,
+	}
+}
+
+// Enabled returns true if deleted volumes should be queued rather than destroyed.
+func (q *RecoveryQueue) Enabled() bool {
+	return q != nil && q.retention > 0
+}
+
+// queuedName returns the name of a FlexVol in the queue, which records when it expires.
+func (q *RecoveryQueue) queuedName(name string, expires time.Time) string {
+	return q.prefix + strconv.FormatInt(expires.Unix(), 10) + "_" + name
+}
+
+// parseQueuedName returns the original name of a queued FlexVol and when it expires.
+func (q *RecoveryQueue) parseQueuedName(queuedName string) (string, time.Time, bool) {
+	if !strings.HasPrefix(queuedName, q.prefix) {
+		return "", time.Time{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(queuedName, q.prefix), "_", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", time.Time{}, false
+	}
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return parts[1], time.Unix(expires, 0), true
+}
+
+// Enqueue takes a volume's FlexVol out of use and renames it into the queue.  If the queued name would be
+// too long for ONTAP, the FlexVol is destroyed right away.
+func (q *RecoveryQueue) Enqueue(ctx context.Context, name string) error {
+
+	exists, err := q.volumeExists(name)
+	if err != nil {
+		return fmt.Errorf("error checking for existing volume: %v", err)
+	}
+	if !exists {
+		logc(ctx).WithField("volume", name).Debug("Volume already deleted, skipping destroy.")
+		return nil
+	}
+
+	queuedName := q.queuedName(name, q.now().Add(q.retention))
+	if len(queuedName) > maxFlexvolNameLength {
+		logc(ctx).WithField("volume", name).Warning(
+			"Volume name too long for the recovery queue, destroying the volume.")
+		return q.destroy(ctx, name, name)
+	}
+
+	if err = q.release(ctx, name); err != nil {
+		return fmt.Errorf("error preparing volume %s for the recovery queue: %v", name, err)
+	}
+	if err = q.renameVolume(name, queuedName); err != nil {
+		return fmt.Errorf("error moving volume %s to the recovery queue: %v", name, err)
+	}
+
+	logc(ctx).WithFields(log.Fields{
+		"volume":     name,
+		"queuedName": queuedName,
+		"retention":  q.retention,
+	}).Info("Volume moved to the recovery queue.")
+
+	return nil
+}
+
+// List returns the volumes in the queue, soonest to expire first.
+func (q *RecoveryQueue) List() ([]*storage.RecoverableVolume, error) {
+
+	queuedNames, err := q.listVolumes(q.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the recovery queue: %v", err)
+	}
+
+	volumes := make([]*storage.RecoverableVolume, 0, len(queuedNames))
+	for _, queuedName := range queuedNames {
+		name, expires, ok := q.parseQueuedName(queuedName)
+		if !ok {
+			continue
+		}
+		volumes = append(volumes, &storage.RecoverableVolume{
+			InternalName:   name,
+			QueuedName:     queuedName,
+			ExpirationTime: expires.UTC().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].ExpirationTime < volumes[j].ExpirationTime
+	})
+
+	return volumes, nil
+}
+
+// Recover renames a queued FlexVol back to its original name and puts it back in use.  If the volume was
+// deleted more than once, its most recent copy is recovered.
+func (q *RecoveryQueue) Recover(ctx context.Context, name string) error {
+
+	volumes, err := q.List()
+	if err != nil {
+		return err
+	}
+	queuedName := ""
+	for _, volume := range volumes {
+		if volume.InternalName == name {
+			queuedName = volume.QueuedName
+		}
+	}
+	if queuedName == "" {
+		return utils.NotFoundError(fmt.Sprintf("volume %s not found in the recovery queue", name))
+	}
+
+	exists, err := q.volumeExists(name)
+	if err != nil {
+		return fmt.Errorf("error checking for existing volume: %v", err)
+	}
+	if exists {
+		return fmt.Errorf("volume %s already exists", name)
+	}
+
+	if err = q.renameVolume(queuedName, name); err != nil {
+		return fmt.Errorf("error recovering volume %s: %v", name, err)
+	}
+	if err = q.restore(ctx, name); err != nil {
+		return fmt.Errorf("volume %s was recovered but could not be put back in use: %v", name, err)
+	}
+
+	logc(ctx).WithFields(log.Fields{
+		"volume":     name,
+		"queuedName": queuedName,
+	}).Info("Volume recovered from the recovery queue.")
+
+	return nil
+}
+
+// reap destroys the queued FlexVols that have expired.
+func (q *RecoveryQueue) reap() {
+
+	ctx := utils.GenerateRequestContext(context.Background(), "", utils.ContextSourceInternal)
+
+	volumes, err := q.List()
+	if err != nil {
+		logc(ctx).Warning(err)
+		return
+	}
+
+	now := q.now()
+	for _, volume := range volumes {
+		if _, expires, _ := q.parseQueuedName(volume.QueuedName); expires.After(now) {
+			continue
+		}
+		logFields := log.Fields{"volume": volume.InternalName, "queuedName": volume.QueuedName}
+		if err := q.destroy(ctx, volume.QueuedName, volume.InternalName); err != nil {
+			logc(ctx).WithFields(logFields).Warningf("Could not destroy expired volume. %v", err)
+			continue
+		}
+		logc(ctx).WithFields(logFields).Info("Destroyed expired volume from the recovery queue.")
+	}
+}
+
+// HousekeepingJob returns the job that destroys expired FlexVols.  It runs even if the queue is disabled,
+// so that FlexVols queued before soft delete was turned off still expire.
+func (q *RecoveryQueue) HousekeepingJob() *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:     recoveryQueueReapJob,
+		Interval: recoveryQueueReapInterval,
+		Jitter:   recoveryQueueReapInterval / housekeepingJitterDivisor,
+		Run:      q.reap,
+	}
+}
+
+// isQueuedVolume returns true if a FlexVol is waiting in a recovery queue.
+func isQueuedVolume(volAttrs *azgo.VolumeAttributesType) bool {
+	idAttrs := volAttrs.VolumeIdAttributesPtr
+	return idAttrs != nil && idAttrs.NamePtr != nil && strings.HasPrefix(string(idAttrs.Name()), recoveryQueuePrefix)
+}
+
+// getSoftDeleteRetention returns how long deleted volumes are kept in the recovery queue, or zero if soft
+// delete is disabled, as it is by default.
+func getSoftDeleteRetention(config *drivers.OntapStorageDriverConfig) (time.Duration, error) {
+
+	if config.SoftDeleteRetention == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(config.SoftDeleteRetention, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for softDeleteRetention: %v", err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/utils"
+)
+
+// fakeRecoveryQueueSVM stands in for the FlexVols of an SVM and records what a recovery queue does to them.
+type fakeRecoveryQueueSVM struct {
+	volumes   map[string]bool
+	released  []string
+	restored  []string
+	destroyed []string
+}
+
+func newFakeRecoveryQueueSVM(volumes ...string) *fakeRecoveryQueueSVM {
+	svm := &fakeRecoveryQueueSVM{volumes: make(map[string]bool)}
+	for _, volume := range volumes {
+		svm.volumes[volume] = true
+	}
+	return svm
+}
+
+func (svm *fakeRecoveryQueueSVM) release(_ context.Context, name string) error {
+	svm.released = append(svm.released, name)
+	return nil
+}
+
+func (svm *fakeRecoveryQueueSVM) restore(_ context.Context, name string) error {
+	svm.restored = append(svm.restored, name)
+	return nil
+}
+
+func (svm *fakeRecoveryQueueSVM) destroy(_ context.Context, queuedName, name string) error {
+	delete(svm.volumes, queuedName)
+	svm.destroyed = append(svm.destroyed, name)
+	return nil
+}
+
+func (svm *fakeRecoveryQueueSVM) list(prefix string) ([]string, error) {
+	names := make([]string, 0)
+	for name := range svm.volumes {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (svm *fakeRecoveryQueueSVM) exists(name string) (bool, error) {
+	return svm.volumes[name], nil
+}
+
+func (svm *fakeRecoveryQueueSVM) rename(name, newName string) error {
+	if !svm.volumes[name] {
+		return errors.New("volume not found")
+	}
+	delete(svm.volumes, name)
+	svm.volumes[newName] = true
+	return nil
+}
+
+func TestRecoveryQueueEnqueue(t *testing.T) {
+
+	now := time.Unix(1600000000, 0)
+	longName := "trident_pvc_" + strings.Repeat("a", maxFlexvolNameLength)
+
+	tests := map[string]struct {
+		volumes   []string
+		name      string
+		released  []string
+		queued    string
+		destroyed []string
+	}{
+		"Volume queued": {
+			volumes:  []string{"trident_pvc_1"},
+			name:     "trident_pvc_1",
+			released: []string{"trident_pvc_1"},
+			queued:   "deleted_nas_1600003600_trident_pvc_1",
+		},
+		"Volume already gone": {
+			name: "trident_pvc_1",
+		},
+		"Queued name too long": {
+			volumes:   []string{longName},
+			name:      longName,
+			destroyed: []string{longName},
+		},
+	}
+	for name, test := range tests {
+		svm := newFakeRecoveryQueueSVM(test.volumes...)
+		queue := NewRecoveryQueue(nil, "nas", time.Hour, svm.release, svm.restore, svm.destroy)
+		queue.now = func() time.Time { return now }
+		queue.listVolumes, queue.volumeExists, queue.renameVolume = svm.list, svm.exists, svm.rename
+		assert.True(t, queue.Enabled(), name)
+
+		assert.NoError(t, queue.Enqueue(context.Background(), test.name), name)
+		assert.Equal(t, test.released, svm.released, name)
+		assert.Equal(t, test.destroyed, svm.destroyed, name)
+		if test.queued != "" {
+			assert.True(t, svm.volumes[test.queued], name)
+			assert.False(t, svm.volumes[test.name], name)
+		}
+	}
+}
+
+func TestRecoveryQueueList(t *testing.T) {
+
+	tests := map[string]struct {
+		volumes      []string
+		names        []string
+		queuedNames  []string
+		expiredTimes []string
+	}{
+		"Soonest to expire first": {
+			volumes:      []string{"deleted_nas_1600003660_trident_pvc_2", "deleted_nas_1600003600_trident_pvc_1"},
+			names:        []string{"trident_pvc_1", "trident_pvc_2"},
+			queuedNames:  []string{"deleted_nas_1600003600_trident_pvc_1", "deleted_nas_1600003660_trident_pvc_2"},
+			expiredTimes: []string{"2020-09-13T13:26:40Z", "2020-09-13T13:27:40Z"},
+		},
+		"Other volumes ignored": {
+			volumes: []string{"trident_pvc_1", "deleted_san_1600003600_trident_pvc_2", "deleted_nas_soon_pvc_3"},
+		},
+	}
+	for name, test := range tests {
+		svm := newFakeRecoveryQueueSVM(test.volumes...)
+		queue := NewRecoveryQueue(nil, "nas", time.Hour, svm.release, svm.restore, svm.destroy)
+		queue.listVolumes = svm.list
+
+		volumes, err := queue.List()
+		assert.NoError(t, err, name)
+		if assert.Len(t, volumes, len(test.names), name) {
+			for i, volume := range volumes {
+				assert.Equal(t, test.names[i], volume.InternalName, name)
+				assert.Equal(t, test.queuedNames[i], volume.QueuedName, name)
+				assert.Equal(t, test.expiredTimes[i], volume.ExpirationTime, name)
+			}
+		}
+	}
+}
+
+func TestRecoveryQueueRecover(t *testing.T) {
+
+	tests := map[string]struct {
+		volumes   []string
+		recovered string
+		remaining []string
+		notFound  bool
+		valid     bool
+	}{
+		"Volume recovered": {
+			volumes:   []string{"deleted_nas_1600003600_trident_pvc_1"},
+			recovered: "deleted_nas_1600003600_trident_pvc_1",
+			valid:     true,
+		},
+		"Most recent copy recovered": {
+			volumes:   []string{"deleted_nas_1600003600_trident_pvc_1", "deleted_nas_1600007200_trident_pvc_1"},
+			recovered: "deleted_nas_1600007200_trident_pvc_1",
+			remaining: []string{"deleted_nas_1600003600_trident_pvc_1"},
+			valid:     true,
+		},
+		"Volume not queued": {
+			volumes:  []string{"deleted_nas_1600003600_trident_pvc_2"},
+			notFound: true,
+		},
+		"Name in use": {
+			volumes:   []string{"deleted_nas_1600003600_trident_pvc_1", "trident_pvc_1"},
+			remaining: []string{"deleted_nas_1600003600_trident_pvc_1"},
+		},
+	}
+	for name, test := range tests {
+		svm := newFakeRecoveryQueueSVM(test.volumes...)
+		queue := NewRecoveryQueue(nil, "nas", time.Hour, svm.release, svm.restore, svm.destroy)
+		queue.listVolumes, queue.volumeExists, queue.renameVolume = svm.list, svm.exists, svm.rename
+
+		err := queue.Recover(context.Background(), "trident_pvc_1")
+
+		if test.valid {
+			assert.NoError(t, err, name)
+			assert.True(t, svm.volumes["trident_pvc_1"], name)
+			assert.False(t, svm.volumes[test.recovered], name)
+			assert.Equal(t, []string{"trident_pvc_1"}, svm.restored, name)
+		} else {
+			assert.Error(t, err, name)
+			assert.Equal(t, test.notFound, utils.IsNotFoundError(err), name)
+			assert.Empty(t, svm.restored, name)
+		}
+		for _, volume := range test.remaining {
+			assert.True(t, svm.volumes[volume], name)
+		}
+	}
+}
+
+func TestRecoveryQueueReap(t *testing.T) {
+
+	now := time.Unix(1600000000, 0)
+
+	tests := map[string]struct {
+		elapsed   time.Duration
+		destroyed []string
+	}{
+		"Not yet expired": {elapsed: time.Minute},
+		"Expired":         {elapsed: 2 * time.Hour, destroyed: []string{"trident_pvc_1"}},
+	}
+	for name, test := range tests {
+		svm := newFakeRecoveryQueueSVM("deleted_nas_1600003600_trident_pvc_1", "trident_pvc_2")
+		queue := NewRecoveryQueue(nil, "nas", time.Hour, svm.release, svm.restore, svm.destroy)
+		queue.now = func() time.Time { return now.Add(test.elapsed) }
+		queue.listVolumes = svm.list
+
+		queue.reap()
+		assert.Equal(t, test.destroyed, svm.destroyed, name)
+		assert.True(t, svm.volumes["trident_pvc_2"], name)
+	}
+}
+
+func TestRecoveryQueueParseQueuedName(t *testing.T) {
+
+	queue := NewRecoveryQueue(nil, "san", time.Hour, nil, nil, nil)
+
+	name, expires, ok := queue.parseQueuedName("deleted_san_1600000000_trident_pvc_a_b")
+	assert.True(t, ok)
+	assert.Equal(t, "trident_pvc_a_b", name)
+	assert.Equal(t, int64(1600000000), expires.Unix())
+
+	for _, queuedName := range []string{
+		"trident_pvc_a", "deleted_nas_1600000000_trident_pvc_a", "deleted_san_soon_trident_pvc_a",
+		"deleted_san_1600000000_",
+	} {
+		_, _, ok = queue.parseQueuedName(queuedName)
+		assert.False(t, ok, queuedName)
+	}
+
+	assert.False(t, (*RecoveryQueue)(nil).Enabled())
+	assert.False(t, NewRecoveryQueue(nil, "san", 0, nil, nil, nil).Enabled())
+}
+
+func TestGetSoftDeleteRetention(t *testing.T) {
+
+	retention, err := getSoftDeleteRetention(&drivers.OntapStorageDriverConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), retention)
+
+	retention, err = getSoftDeleteRetention(&drivers.OntapStorageDriverConfig{SoftDeleteRetention: "86400"})
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, retention)
+
+	_, err = getSoftDeleteRetention(&drivers.OntapStorageDriverConfig{SoftDeleteRetention: "1d"})
+	assert.Error(t, err)
+}
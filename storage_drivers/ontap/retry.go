@@ -0,0 +1,112 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// Operations whose retry budgets may be set individually via the retryBudgets backend option
+	retryOpDefault        = "default"
+	retryOpVolumeCreate   = "volumeCreate"
+	retryOpCloneCreate    = "cloneCreate"
+	retryOpSnapshotCreate = "snapshotCreate"
+	retryOpSnapshotDelete = "snapshotDelete"
+	retryOpLunMap         = "lunMap"
+
+	defaultRetryBudgetSecs = uint64(30)
+
+	retryInitialInterval     = 1 * time.Second
+	retryMaxInterval         = 10 * time.Second
+	retryMultiplier          = 2
+	retryRandomizationFactor = 0.5
+)
+
+var retryOps = []string{
+	retryOpDefault, retryOpVolumeCreate, retryOpCloneCreate, retryOpSnapshotCreate, retryOpSnapshotDelete,
+	retryOpLunMap,
+}
+
+// ValidateRetryBudgets ensures a retryBudgets backend option names only known operations,
+// each with a whole number of seconds.
+func ValidateRetryBudgets(config *drivers.OntapStorageDriverConfig) error {
+	for op, budget := range config.RetryBudgets {
+		if !utils.StringInSlice(op, retryOps) {
+			return fmt.Errorf("invalid operation '%s' in retryBudgets, must be one of %s",
+				op, strings.Join(retryOps, ", "))
+		}
+		if _, err := strconv.ParseUint(budget, 10, 64); err != nil {
+			return fmt.Errorf("invalid retry budget '%s' for operation '%s' in retryBudgets; %v", budget, op, err)
+		}
+	}
+	return nil
+}
+
+// getRetryBudget returns how long an operation may be retried, preferring a budget set for the operation
+// itself, then the default budget from the backend config, and finally the built-in default.
+func getRetryBudget(config *drivers.OntapStorageDriverConfig, op string) time.Duration {
+
+	budgetSecs := defaultRetryBudgetSecs
+	for _, key := range []string{op, retryOpDefault} {
+		if value, ok := config.RetryBudgets[key]; ok {
+			if i, err := strconv.ParseUint(value, 10, 64); err == nil {
+				budgetSecs = i
+				break
+			}
+		}
+	}
+	return time.Duration(budgetSecs) * time.Second
+}
+
+// retryOntapOperation invokes an ONTAP operation, retrying it with jittered exponential backoff for as long
+// as it fails with a transient error and its retry budget allows.  Any other error is returned immediately.
+// The operation should return api.GetError of its ZAPI response, so that ZAPI failures may be examined.
+func retryOntapOperation(
+	ctx context.Context, config *drivers.OntapStorageDriverConfig, op string, operation func() error,
+) error {
+
+	budget := getRetryBudget(config, op)
+	if budget == 0 {
+		return operation()
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attempt := func() error {
+		err := operation()
+		if err != nil && !api.IsRetryableError(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	attemptNotify := func(err error, duration time.Duration) {
+		logc(ctx).WithFields(log.Fields{
+			"operation": op,
+			"increment": duration,
+			"error":     err,
+		}).Debug("Transient ONTAP error, retrying.")
+	}
+
+	retryBackoff := backoff.NewExponentialBackOff()
+	retryBackoff.InitialInterval = retryInitialInterval
+	retryBackoff.MaxInterval = retryMaxInterval
+	retryBackoff.Multiplier = retryMultiplier
+	retryBackoff.RandomizationFactor = retryRandomizationFactor
+	retryBackoff.MaxElapsedTime = budget
+
+	return backoff.RetryNotify(attempt, backoff.WithContext(retryBackoff, ctx), attemptNotify)
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func TestValidateRetryBudgets(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	assert.NoError(t, ValidateRetryBudgets(config))
+
+	config.RetryBudgets = map[string]string{"default": "60", "volumeCreate": "120", "lunMap": "0"}
+	assert.NoError(t, ValidateRetryBudgets(config))
+
+	config.RetryBudgets = map[string]string{"volumeDelete": "60"}
+	assert.Error(t, ValidateRetryBudgets(config))
+
+	config.RetryBudgets = map[string]string{"cloneCreate": "1m"}
+	assert.Error(t, ValidateRetryBudgets(config))
+}
+
+func TestGetRetryBudget(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	assert.Equal(t, time.Duration(defaultRetryBudgetSecs)*time.Second, getRetryBudget(config, retryOpCloneCreate))
+
+	config.RetryBudgets = map[string]string{"default": "60"}
+	assert.Equal(t, 60*time.Second, getRetryBudget(config, retryOpCloneCreate))
+
+	config.RetryBudgets["cloneCreate"] = "0"
+	assert.Equal(t, time.Duration(0), getRetryBudget(config, retryOpCloneCreate))
+	assert.Equal(t, 60*time.Second, getRetryBudget(config, retryOpLunMap))
+}
+
+func TestRetryOntapOperation(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	config.RetryBudgets = map[string]string{"default": "5", "lunMap": "0"}
+	ctx := context.Background()
+
+	// Permanent errors are not retried
+	calls := 0
+	err := retryOntapOperation(ctx, config, retryOpCloneCreate, func() error {
+		calls++
+		return newTestZapiError(azgo.EOBJECTNOTFOUND, "entry doesn't exist")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Transient errors are retried until the operation succeeds
+	calls = 0
+	err = retryOntapOperation(ctx, config, retryOpCloneCreate, func() error {
+		calls++
+		if calls == 1 {
+			return newTestZapiError(azgo.EAPIERROR, "Volume is busy")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// A zero budget disables retries
+	calls = 0
+	err = retryOntapOperation(ctx, config, retryOpLunMap, func() error {
+		calls++
+		return &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Retries stop when the request context is done
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	calls = 0
+	err = retryOntapOperation(cancelledCtx, config, retryOpCloneCreate, func() error {
+		calls++
+		return &net.OpError{Op: "read", Err: syscall.ECONNRESET}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+const (
+	// ONTAP allows a snapshot policy at most this many schedules, keeping at most this many snapshots in all
+	maxSnapshotPolicySchedules = 5
+	maxSnapshotPolicyCount     = 1023
+)
+
+// validateSnapshotPolicyConfig checks the snapshot policies the backend config asks Trident to manage,
+// so that a policy ONTAP would reject is reported before anything is changed on the SVM.
+func validateSnapshotPolicyConfig(config *drivers.OntapStorageDriverConfig) error {
+
+	names := make(map[string]bool)
+	for i, policy := range config.SnapshotPolicies {
+		if policy.Name == "" {
+			return fmt.Errorf("snapshot policy %d has no name", i)
+		}
+		if policy.Name == "none" {
+			return fmt.Errorf("snapshot policy name %s is reserved by ONTAP", policy.Name)
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("snapshot policy %s is listed more than once", policy.Name)
+		}
+		names[policy.Name] = true
+
+		if len(policy.Schedules) == 0 || len(policy.Schedules) > maxSnapshotPolicySchedules {
+			return fmt.Errorf("snapshot policy %s must have between 1 and %d schedules", policy.Name,
+				maxSnapshotPolicySchedules)
+		}
+		schedules := make(map[string]bool)
+		total := 0
+		for _, schedule := range policy.Schedules {
+			if schedule.Schedule == "" {
+				return fmt.Errorf("snapshot policy %s has a schedule with no name", policy.Name)
+			}
+			if schedules[schedule.Schedule] {
+				return fmt.Errorf("snapshot policy %s lists schedule %s more than once", policy.Name,
+					schedule.Schedule)
+			}
+			schedules[schedule.Schedule] = true
+			if schedule.Count < 1 {
+				return fmt.Errorf("schedule %s of snapshot policy %s must keep at least one snapshot",
+					schedule.Schedule, policy.Name)
+			}
+			total += schedule.Count
+		}
+		if total > maxSnapshotPolicyCount {
+			return fmt.Errorf("snapshot policy %s keeps %d snapshots, more than the maximum of %d", policy.Name,
+				total, maxSnapshotPolicyCount)
+		}
+	}
+	return nil
+}
+
+// reconcileSnapshotPolicies creates the snapshot policies listed in the backend config that the SVM lacks,
+// and corrects the schedules, snapshot counts, state, and comment of those that have drifted from the config.
+// Policies not listed are left alone.
+func reconcileSnapshotPolicies(client api.OntapAPI, config *drivers.OntapStorageDriverConfig) error {
+
+	for _, policy := range config.SnapshotPolicies {
+		if err := reconcileSnapshotPolicy(client, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileSnapshotPolicy brings one snapshot policy on the SVM in line with its config.
+func reconcileSnapshotPolicy(client api.OntapAPI, desired drivers.OntapSnapshotPolicyConfig) error {
+
+	enabled := desired.Enabled == nil || *desired.Enabled
+	logFields := log.Fields{"snapshotPolicy": desired.Name}
+
+	existing, err := client.SnapshotPolicyGet(desired.Name)
+	if err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error reading snapshot policy %s", desired.Name))
+	}
+
+	if existing == nil {
+		first := desired.Schedules[0]
+		response, err := client.SnapshotPolicyCreate(desired.Name, enabled, desired.Comment, first.Schedule,
+			first.Count, first.Prefix)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, fmt.Sprintf("error creating snapshot policy %s", desired.Name))
+		}
+		for _, schedule := range desired.Schedules[1:] {
+			if err = addSnapshotPolicySchedule(client, desired.Name, schedule); err != nil {
+				return err
+			}
+		}
+		log.WithFields(logFields).Info("Created snapshot policy.")
+		return nil
+	}
+
+	current := make(map[string]int)
+	if existing.SnapshotPolicySchedulesPtr != nil {
+		for _, info := range existing.SnapshotPolicySchedulesPtr.SnapshotScheduleInfo() {
+			if info.SchedulePtr != nil && info.CountPtr != nil {
+				current[info.Schedule()] = info.Count()
+			}
+		}
+	}
+	wanted := make(map[string]bool)
+	for _, schedule := range desired.Schedules {
+		wanted[schedule.Schedule] = true
+	}
+	extra := make([]string, 0)
+	for schedule := range current {
+		if !wanted[schedule] {
+			extra = append(extra, schedule)
+		}
+	}
+	sort.Strings(extra)
+
+	// A policy must keep at least one schedule and may have no more than five, so the unwanted schedules
+	// are removed first, holding one back until a wanted schedule has been added if the policy has none
+	heldBack := ""
+	if len(extra) > 0 && len(extra) == len(current) {
+		heldBack, extra = extra[len(extra)-1], extra[:len(extra)-1]
+	}
+	for _, schedule := range extra {
+		if err = removeSnapshotPolicySchedule(client, desired.Name, schedule); err != nil {
+			return err
+		}
+	}
+
+	for _, schedule := range desired.Schedules {
+		count, ok := current[schedule.Schedule]
+		if !ok {
+			err = addSnapshotPolicySchedule(client, desired.Name, schedule)
+		} else if count != schedule.Count {
+			response, modifyErr := client.SnapshotPolicyModifySchedule(desired.Name, schedule.Schedule,
+				schedule.Count)
+			if err = api.GetError(response, modifyErr); err != nil {
+				err = wrapOntapError(err, fmt.Sprintf("error changing schedule %s of snapshot policy %s",
+					schedule.Schedule, desired.Name))
+			} else {
+				log.WithFields(logFields).WithFields(log.Fields{
+					"schedule": schedule.Schedule,
+					"from":     count,
+					"to":       schedule.Count,
+				}).Info("Corrected snapshot count of snapshot policy schedule.")
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if heldBack != "" {
+		if err = removeSnapshotPolicySchedule(client, desired.Name, heldBack); err != nil {
+			return err
+		}
+	}
+
+	currentComment := ""
+	if existing.CommentPtr != nil {
+		currentComment = existing.Comment()
+	}
+	if (existing.EnabledPtr != nil && existing.Enabled() != enabled) || currentComment != desired.Comment {
+		response, err := client.SnapshotPolicyModify(desired.Name, enabled, desired.Comment)
+		if err = api.GetError(response, err); err != nil {
+			return wrapOntapError(err, fmt.Sprintf("error modifying snapshot policy %s", desired.Name))
+		}
+		log.WithFields(logFields).WithField("enabled", enabled).Info("Corrected snapshot policy.")
+	}
+
+	return nil
+}
+
+// addSnapshotPolicySchedule adds a schedule to a snapshot policy.
+func addSnapshotPolicySchedule(
+	client api.OntapAPI, policy string, schedule drivers.OntapSnapshotScheduleConfig,
+) error {
+
+	response, err := client.SnapshotPolicyAddSchedule(policy, schedule.Schedule, schedule.Count, schedule.Prefix)
+	if err = api.GetError(response, err); err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error adding schedule %s to snapshot policy %s",
+			schedule.Schedule, policy))
+	}
+	log.WithFields(log.Fields{
+		"snapshotPolicy": policy,
+		"schedule":       schedule.Schedule,
+		"count":          schedule.Count,
+	}).Info("Added schedule to snapshot policy.")
+	return nil
+}
+
+// removeSnapshotPolicySchedule removes a schedule from a snapshot policy.
+func removeSnapshotPolicySchedule(client api.OntapAPI, policy, schedule string) error {
+
+	response, err := client.SnapshotPolicyRemoveSchedule(policy, schedule)
+	if err = api.GetError(response, err); err != nil {
+		return wrapOntapError(err, fmt.Sprintf("error removing schedule %s from snapshot policy %s",
+			schedule, policy))
+	}
+	log.WithFields(log.Fields{
+		"snapshotPolicy": policy,
+		"schedule":       schedule,
+	}).Info("Removed schedule from snapshot policy.")
+	return nil
+}
+
+// newSnapshotPolicyReconcileJob returns the job that corrects the managed snapshot policies if they have
+// been changed on the SVM since the backend was initialized.
+func newSnapshotPolicyReconcileJob(client api.OntapAPI, config *drivers.OntapStorageDriverConfig) *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         snapshotPolicyReconcileJob,
+		Interval:     snapshotPolicyReconcilePeriod,
+		InitialDelay: snapshotPolicyReconcilePeriod,
+		Jitter:       snapshotPolicyReconcilePeriod / housekeepingJitterDivisor,
+		Run: func() {
+			if err := reconcileSnapshotPolicies(client, config); err != nil {
+				log.WithError(err).Warning("Could not reconcile snapshot policies.")
+			}
+		},
+	}
+}
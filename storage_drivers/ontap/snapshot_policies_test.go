@@ -0,0 +1,122 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func newTestSnapshotPolicyConfig() *drivers.OntapStorageDriverConfig {
+	return &drivers.OntapStorageDriverConfig{
+		SnapshotPolicies: []drivers.OntapSnapshotPolicyConfig{
+			{
+				Name:    "trident-gold",
+				Comment: "Managed by Trident",
+				Schedules: []drivers.OntapSnapshotScheduleConfig{
+					{Schedule: "hourly", Count: 6},
+					{Schedule: "daily", Count: 7, Prefix: "day"},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateSnapshotPolicyConfig(t *testing.T) {
+
+	assert.NoError(t, validateSnapshotPolicyConfig(&drivers.OntapStorageDriverConfig{}))
+	assert.NoError(t, validateSnapshotPolicyConfig(newTestSnapshotPolicyConfig()))
+
+	hourly := drivers.OntapSnapshotScheduleConfig{Schedule: "hourly", Count: 1}
+	for name, policies := range map[string][]drivers.OntapSnapshotPolicyConfig{
+		"no name":  {{Schedules: []drivers.OntapSnapshotScheduleConfig{hourly}}},
+		"reserved": {{Name: "none", Schedules: []drivers.OntapSnapshotScheduleConfig{hourly}}},
+		"duplicate policy": {
+			{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{hourly}},
+			{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{hourly}},
+		},
+		"no schedules": {{Name: "p1"}},
+		"too many schedules": {{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{
+			{Schedule: "s1", Count: 1}, {Schedule: "s2", Count: 1}, {Schedule: "s3", Count: 1},
+			{Schedule: "s4", Count: 1}, {Schedule: "s5", Count: 1}, {Schedule: "s6", Count: 1},
+		}}},
+		"unnamed schedule":   {{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{{Count: 1}}}},
+		"duplicate schedule": {{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{hourly, hourly}}},
+		"zero count": {{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{
+			{Schedule: "hourly"},
+		}}},
+		"too many snapshots": {{Name: "p1", Schedules: []drivers.OntapSnapshotScheduleConfig{
+			{Schedule: "hourly", Count: 1000}, {Schedule: "daily", Count: 24},
+		}}},
+	} {
+		config := &drivers.OntapStorageDriverConfig{SnapshotPolicies: policies}
+		assert.Error(t, validateSnapshotPolicyConfig(config), name)
+	}
+}
+
+func TestReconcileSnapshotPoliciesCreates(t *testing.T) {
+
+	client := api.NewMockOntapAPI("svm1")
+	config := newTestSnapshotPolicyConfig()
+
+	assert.NoError(t, reconcileSnapshotPolicies(client, config))
+	assert.Equal(t, &api.MockSnapshotPolicy{
+		Enabled:   true,
+		Comment:   "Managed by Trident",
+		Schedules: map[string]int{"hourly": 6, "daily": 7},
+	}, client.SnapshotPolicies["trident-gold"])
+
+	// Reconciling again changes nothing
+	client.Calls = nil
+	assert.NoError(t, reconcileSnapshotPolicies(client, config))
+	assert.Equal(t, []string{"SnapshotPolicyGet"}, client.Calls)
+}
+
+func TestReconcileSnapshotPoliciesCorrectsDrift(t *testing.T) {
+
+	client := api.NewMockOntapAPI("svm1")
+	client.SnapshotPolicies["trident-gold"] = &api.MockSnapshotPolicy{
+		Enabled:   false,
+		Comment:   "changed by hand",
+		Schedules: map[string]int{"hourly": 2, "weekly": 4},
+	}
+
+	assert.NoError(t, reconcileSnapshotPolicies(client, newTestSnapshotPolicyConfig()))
+	assert.Equal(t, &api.MockSnapshotPolicy{
+		Enabled:   true,
+		Comment:   "Managed by Trident",
+		Schedules: map[string]int{"hourly": 6, "daily": 7},
+	}, client.SnapshotPolicies["trident-gold"])
+}
+
+func TestReconcileSnapshotPoliciesReplacesAllSchedules(t *testing.T) {
+
+	// None of the existing schedules is wanted, and the policy is full, so one must be removed before the
+	// wanted schedules can be added, and one kept until they have been
+	client := api.NewMockOntapAPI("svm1")
+	client.SnapshotPolicies["trident-gold"] = &api.MockSnapshotPolicy{
+		Enabled:   true,
+		Comment:   "Managed by Trident",
+		Schedules: map[string]int{"s1": 1, "s2": 1, "s3": 1, "s4": 1, "s5": 1},
+	}
+
+	assert.NoError(t, reconcileSnapshotPolicies(client, newTestSnapshotPolicyConfig()))
+	assert.Equal(t, map[string]int{"hourly": 6, "daily": 7}, client.SnapshotPolicies["trident-gold"].Schedules)
+	assert.Equal(t, 0, client.Called("SnapshotPolicyModify"))
+}
+
+func TestReconcileSnapshotPoliciesFailure(t *testing.T) {
+
+	client := api.NewMockOntapAPI("svm1")
+	client.FailCall("SnapshotPolicyCreate", azgo.EAPIERROR, "insufficient privileges")
+
+	err := reconcileSnapshotPolicies(client, newTestSnapshotPolicyConfig())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error creating snapshot policy trident-gold")
+	assert.Empty(t, client.SnapshotPolicies)
+}
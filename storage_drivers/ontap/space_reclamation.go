@@ -0,0 +1,107 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+// SpaceReclaimer coordinates the reclamation of blocks freed by hosts on thin-provisioned LUNs.  Nodes
+// trim the filesystems of the LUNs on the schedule in their publish info, but ONTAP only returns the
+// blocks a host unmaps to the aggregate if the LUN has space allocation (SCSI thin provisioning)
+// enabled.  LUNs are created with it while reclamation is enabled, but it can't be enabled on an
+// online LUN, so the reclaimer periodically reports the backend's thin LUNs that lack it.
+type SpaceReclaimer struct {
+	pathPattern string
+
+	listLUNs func(pathPattern string) ([]azgo.LunInfoType, error)
+
+	// LUNs already reported, which aren't reported again until they have space allocation
+	reported map[string]bool
+}
+
+// NewSpaceReclaimer returns a reclaimer for the LUNs whose paths match the supplied pattern.
+func NewSpaceReclaimer(client *api.Client, pathPattern string) *SpaceReclaimer {
+	return &SpaceReclaimer{
+		pathPattern: pathPattern,
+		listLUNs: func(pathPattern string) ([]azgo.LunInfoType, error) {
+			response, err := client.LunGetAll(pathPattern)
+			if err = api.GetError(response, err); err != nil {
+				return nil, err
+			}
+			if response.Result.AttributesListPtr == nil {
+				return nil, nil
+			}
+			return response.Result.AttributesListPtr.LunInfoPtr, nil
+		},
+		reported: make(map[string]bool),
+	}
+}
+
+// run is the body of the space reclamation job.
+func (r *SpaceReclaimer) run() {
+
+	luns, err := r.listLUNs(r.pathPattern)
+	if err != nil {
+		log.WithField("pathPattern", r.pathPattern).Errorf("Could not list LUNs for space reclamation. %v", err)
+		return
+	}
+
+	unreclaimable := make(map[string]bool)
+	for _, lun := range luns {
+		if lun.PathPtr == nil || lun.IsSpaceReservationEnabledPtr == nil || lun.IsSpaceAllocEnabledPtr == nil {
+			continue
+		}
+		// Thick LUNs keep their blocks reserved, and the rest already return unmapped blocks
+		if lun.IsSpaceReservationEnabled() || lun.IsSpaceAllocEnabled() {
+			continue
+		}
+
+		unreclaimable[lun.Path()] = true
+		if !r.reported[lun.Path()] {
+			log.WithField("LUN", lun.Path()).Warning("LUN does not have space allocation enabled, so the " +
+				"blocks hosts free on it can't be reclaimed. Space allocation may be enabled while the LUN " +
+				"is offline.")
+		}
+	}
+	r.reported = unreclaimable
+
+	log.WithFields(log.Fields{
+		"pathPattern":   r.pathPattern,
+		"LUNs":          len(luns),
+		"unreclaimable": len(unreclaimable),
+	}).Debug("Space reclamation check complete.")
+}
+
+// HousekeepingJob returns the job that checks that the LUNs' freed blocks may be reclaimed.
+func (r *SpaceReclaimer) HousekeepingJob(interval time.Duration) *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         spaceReclamationJob,
+		Interval:     interval,
+		InitialDelay: interval,
+		Jitter:       interval / housekeepingJitterDivisor,
+		Run:          r.run,
+	}
+}
+
+// getSpaceReclamationInterval returns how often space reclamation runs, or zero if it is disabled,
+// as it is by default.
+func getSpaceReclamationInterval(config *drivers.OntapStorageDriverConfig) (time.Duration, error) {
+
+	if config.SpaceReclamationPeriod == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(config.SpaceReclamationPeriod, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for spaceReclamationPeriod: %v", err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
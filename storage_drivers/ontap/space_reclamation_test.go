@@ -0,0 +1,64 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func newTestLUN(path string, spaceReserved, spaceAllocated bool) azgo.LunInfoType {
+	return *azgo.NewLunInfoType().
+		SetPath(path).
+		SetIsSpaceReservationEnabled(spaceReserved).
+		SetIsSpaceAllocEnabled(spaceAllocated)
+}
+
+func TestSpaceReclaimerRun(t *testing.T) {
+
+	reclaimer := NewSpaceReclaimer(nil, "/vol/trident_*/lun0")
+
+	luns := []azgo.LunInfoType{
+		newTestLUN("/vol/trident_thin/lun0", false, false),
+		newTestLUN("/vol/trident_thick/lun0", true, false),
+		newTestLUN("/vol/trident_enabled/lun0", false, true),
+	}
+	reclaimer.listLUNs = func(pathPattern string) ([]azgo.LunInfoType, error) {
+		assert.Equal(t, "/vol/trident_*/lun0", pathPattern)
+		return luns, nil
+	}
+
+	reclaimer.run()
+	assert.Equal(t, map[string]bool{"/vol/trident_thin/lun0": true}, reclaimer.reported)
+
+	// A failure to list the LUNs leaves those reported before
+	reclaimer.listLUNs = func(string) ([]azgo.LunInfoType, error) { return nil, errors.New("timeout") }
+	reclaimer.run()
+	assert.Equal(t, map[string]bool{"/vol/trident_thin/lun0": true}, reclaimer.reported)
+
+	// A LUN given space allocation is forgotten, so it is reported again if it loses it
+	luns[0] = newTestLUN("/vol/trident_thin/lun0", false, true)
+	reclaimer.listLUNs = func(string) ([]azgo.LunInfoType, error) { return luns, nil }
+	reclaimer.run()
+	assert.Empty(t, reclaimer.reported)
+}
+
+func TestGetSpaceReclamationInterval(t *testing.T) {
+
+	interval, err := getSpaceReclamationInterval(&drivers.OntapStorageDriverConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	interval, err = getSpaceReclamationInterval(&drivers.OntapStorageDriverConfig{SpaceReclamationPeriod: "86400"})
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, interval)
+
+	_, err = getSpaceReclamationInterval(&drivers.OntapStorageDriverConfig{SpaceReclamationPeriod: "1d"})
+	assert.Error(t, err)
+}
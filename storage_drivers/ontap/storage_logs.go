@@ -0,0 +1,118 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+const (
+	storageLogJobs   = "job"
+	storageLogEvents = "event"
+
+	// The event log is gathered for support cases, so every message is of interest
+	emsAllMessages = "*"
+)
+
+// getStorageLogs reads the recent jobs of the driver's SVM and the recent EMS events of its cluster, newest
+// first.  If only one of the logs can be read, as with the event log and SVM-scoped credentials, the
+// failure to read the other is returned as an entry of that log.
+func getStorageLogs(client *api.Client) ([]*storage.StorageLogEntry, error) {
+
+	jobs, jobErr := client.JobGetIterForSVM()
+	events, eventErr := client.EmsMessageGetIterRequest(emsAllMessages)
+	if jobErr != nil && eventErr != nil {
+		return nil, fmt.Errorf("could not read the job log; %v; could not read the event log; %v", jobErr, eventErr)
+	}
+
+	return newStorageLogEntries(jobs, jobErr, events, eventErr), nil
+}
+
+// newStorageLogEntries converts ONTAP jobs and EMS events to storage log entries, sorted newest first,
+// preceded by entries recording any failure to read either log.
+func newStorageLogEntries(
+	jobs []azgo.JobInfoType, jobErr error, events []azgo.EmsMessageInfoType, eventErr error,
+) []*storage.StorageLogEntry {
+
+	entries := make([]*storage.StorageLogEntry, 0, len(jobs)+len(events))
+
+	for _, job := range jobs {
+		entry := &storage.StorageLogEntry{Log: storageLogJobs}
+		if job.JobNamePtr != nil {
+			entry.Name = *job.JobNamePtr
+		}
+		if job.JobNodePtr != nil {
+			entry.Node = *job.JobNodePtr
+		}
+		if job.JobStatePtr != nil {
+			entry.Severity = *job.JobStatePtr
+		}
+
+		// A job is logged when it last changed, which is when it ended if it has finished
+		for _, jobTime := range []*int{job.JobEndTimePtr, job.JobStartTimePtr, job.JobQueueTimePtr} {
+			if jobTime != nil && *jobTime > 0 {
+				entry.Time = formatStorageLogTime(*jobTime)
+				break
+			}
+		}
+
+		message := make([]string, 0, 3)
+		for _, text := range []*string{job.JobDescriptionPtr, job.JobProgressPtr, job.JobCompletionPtr} {
+			if text != nil && *text != "" {
+				message = append(message, *text)
+			}
+		}
+		entry.Message = strings.Join(message, "; ")
+
+		entries = append(entries, entry)
+	}
+
+	for _, event := range events {
+		entry := &storage.StorageLogEntry{Log: storageLogEvents}
+		if event.MessageNamePtr != nil {
+			entry.Name = *event.MessageNamePtr
+		}
+		if event.NodePtr != nil {
+			entry.Node = *event.NodePtr
+		}
+		if event.SeverityPtr != nil {
+			entry.Severity = *event.SeverityPtr
+		}
+		if event.TimePtr != nil {
+			entry.Time = formatStorageLogTime(*event.TimePtr)
+		}
+		if event.EventPtr != nil {
+			entry.Message = *event.EventPtr
+		}
+		entries = append(entries, entry)
+	}
+
+	// Times are in RFC3339 format, so they sort as strings
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time > entries[j].Time
+	})
+
+	failures := make([]*storage.StorageLogEntry, 0, 2)
+	if jobErr != nil {
+		failures = append(failures, &storage.StorageLogEntry{
+			Log: storageLogJobs, Name: "error", Message: fmt.Sprintf("could not read the job log; %v", jobErr)})
+	}
+	if eventErr != nil {
+		failures = append(failures, &storage.StorageLogEntry{
+			Log: storageLogEvents, Name: "error", Message: fmt.Sprintf("could not read the event log; %v", eventErr)})
+	}
+
+	return append(failures, entries...)
+}
+
+// formatStorageLogTime formats an ONTAP time, in seconds since the epoch.
+func formatStorageLogTime(seconds int) string {
+	return time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+}
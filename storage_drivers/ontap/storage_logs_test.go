@@ -0,0 +1,39 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func TestNewStorageLogEntries(t *testing.T) {
+
+	finishedJob := azgo.NewJobInfoType().SetJobName("Clone Split").SetJobStartTime(1588327200).
+		SetJobEndTime(1588330800).SetJobState("success").SetJobDescription("Split trident_pvc_1")
+	queuedJob := azgo.NewJobInfoType().SetJobName("Vol Move").SetJobQueueTime(1588334400).SetJobState("queued")
+	event := azgo.NewEmsMessageInfoType().SetMessageName("vifmgr.lifdown").SetNode("node1").
+		SetSeverity("error").SetTime(1588332600).SetEvent("LIF lif1 is down")
+
+	entries := newStorageLogEntries([]azgo.JobInfoType{*finishedJob, *queuedJob}, nil,
+		[]azgo.EmsMessageInfoType{*event}, nil)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "Vol Move", entries[0].Name, "entries not sorted newest first")
+		assert.Equal(t, "2020-05-01T12:00:00Z", entries[0].Time)
+		assert.Equal(t, storageLogEvents, entries[1].Log)
+		assert.Equal(t, "LIF lif1 is down", entries[1].Message)
+		assert.Equal(t, "2020-05-01T11:00:00Z", entries[2].Time, "job not logged when it ended")
+		assert.Equal(t, "success", entries[2].Severity)
+	}
+
+	// A log that can't be read is reported ahead of the other's entries
+	entries = newStorageLogEntries([]azgo.JobInfoType{*finishedJob}, nil, nil, errors.New("insufficient privileges"))
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, storageLogEvents, entries[0].Log)
+		assert.Contains(t, entries[0].Message, "insufficient privileges")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	svmHealthCheckPeriod = 60 * time.Second
+
+	// An SVM must fail this many consecutive health checks before the driver fails over
+	svmFailoverThreshold = 3
+)
+
+// dataLIFResolver is implemented by drivers that must rediscover their data LIFs once their API
+// client has failed over to another SVM.
+type dataLIFResolver interface {
+	resolveDataLIFs() error
+}
+
+// SVMFailoverMonitor checks the health of a driver's SVM and, if the SVM stays unreachable, fails the
+// driver's API client over to the SVM-DR partner named in the config.  Volumes replicated by SVM-DR
+// may then continue to be managed after a site failover.
+type SVMFailoverMonitor struct {
+	client   *api.Client
+	resolver dataLIFResolver
+	failures int
+	mutex    sync.Mutex
+
+	checkHealth func() error
+	failOver    func() error
+}
+
+// NewSVMFailoverMonitor returns a monitor for a driver's SVM.  The resolver, if not nil, is called to
+// rediscover the driver's data LIFs after a failover.
+func NewSVMFailoverMonitor(client *api.Client, resolver dataLIFResolver) *SVMFailoverMonitor {
+	m := &SVMFailoverMonitor{
+		client:   client,
+		resolver: resolver,
+	}
+	m.checkHealth = m.checkOntapHealth
+	m.failOver = client.FailOver
+	return m
+}
+
+// checkOntapHealth returns an error if the SVM can't be reached or isn't running.
+func (m *SVMFailoverMonitor) checkOntapHealth() error {
+	return checkSVMHealth(m.client)
+}
+
+// checkSVMHealth returns an error if the client's SVM can't be reached or isn't running.
+func checkSVMHealth(client *api.Client) error {
+
+	vserverResponse, err := client.VserverGetRequest()
+	if err = api.GetError(vserverResponse, err); err != nil {
+		return err
+	}
+	if vserverResponse.Result.AttributesPtr == nil || vserverResponse.Result.AttributesPtr.VserverInfoPtr == nil {
+		return errors.New("SVM details missing from response")
+	}
+	vserverInfo := vserverResponse.Result.AttributesPtr.VserverInfoPtr
+	if vserverInfo.StatePtr != nil && vserverInfo.State() != "running" {
+		return fmt.Errorf("SVM is %s", vserverInfo.State())
+	}
+	return nil
+}
+
+// run is the body of the SVM health check job.  The SVM in use is read from the client rather than the
+// config, which keeps the configured SVMs, so that a failover changes nothing the driver reads unlocked.
+func (m *SVMFailoverMonitor) run() {
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	logFields := log.Fields{
+		utils.LogFieldSVM: m.client.SVM(),
+		"managementLIF":   m.client.ManagementLIF(),
+	}
+
+	err := m.checkHealth()
+	if err == nil {
+		if m.failures > 0 {
+			log.WithFields(logFields).Info("SVM is reachable again.")
+		}
+		m.failures = 0
+		return
+	}
+
+	m.failures++
+	log.WithFields(logFields).WithField("failures", m.failures).Warningf("SVM health check failed. %v", err)
+
+	if m.failures < svmFailoverThreshold {
+		return
+	}
+
+	partnerFields := log.Fields{
+		"secondarySVM":           m.client.SecondarySVM(),
+		"secondaryManagementLIF": m.client.SecondaryManagementLIF(),
+	}
+
+	if err = m.failOver(); err != nil {
+		log.WithFields(logFields).WithFields(partnerFields).Errorf("Could not fail over to SVM-DR partner. %v", err)
+		return
+	}
+	m.failures = 0
+
+	log.WithFields(logFields).WithFields(partnerFields).Warning("Failed over to SVM-DR partner.")
+
+	if m.resolver != nil {
+		if err = m.resolver.resolveDataLIFs(); err != nil {
+			log.WithField(utils.LogFieldSVM, m.client.SVM()).Errorf("Could not discover data LIFs after failover. %v", err)
+		}
+	}
+}
+
+// HousekeepingJob returns the job that checks the health of the SVM.
+func (m *SVMFailoverMonitor) HousekeepingJob() *HousekeepingJob {
+	return &HousekeepingJob{
+		Name:         svmFailoverJob,
+		Interval:     svmHealthCheckPeriod,
+		InitialDelay: svmHealthCheckPeriod,
+		Jitter:       svmHealthCheckPeriod / housekeepingJitterDivisor,
+		Run:          m.run,
+	}
+}
+
+// validateSVMFailoverConfig checks the SVM-DR partner settings, defaulting the secondary SVM name to
+// that of the primary.
+func validateSVMFailoverConfig(config *drivers.OntapStorageDriverConfig) error {
+
+	if config.SecondaryManagementLIF == "" {
+		if config.SecondarySVM != "" {
+			return errors.New("secondarySVM requires secondaryManagementLIF")
+		}
+		return nil
+	}
+	if utils.ParseHostportIP(config.SecondaryManagementLIF) == utils.ParseHostportIP(config.ManagementLIF) {
+		return errors.New("secondaryManagementLIF must differ from managementLIF")
+	}
+	if config.SecondarySVM == "" {
+		if config.SVM == "" {
+			return errors.New("secondarySVM is required if svm is not set")
+		}
+		config.SecondarySVM = config.SVM
+	}
+	return nil
+}
+
+// nasDataLIF is the data LIF a NAS driver gives to nodes to mount its volumes from.  It is the configured
+// data LIF until a failover chooses another, and may be read while the SVM failover monitor changes it.
+type nasDataLIF struct {
+	failover atomic.Value
+}
+
+// get returns the data LIF to mount volumes from.
+func (l *nasDataLIF) get(config *drivers.OntapStorageDriverConfig) string {
+	if dataLIF, ok := l.failover.Load().(string); ok {
+		return dataLIF
+	}
+	return config.DataLIF
+}
+
+// resolveNASDataLIF chooses the NAS data LIF after a failover, keeping the data LIF in use if the new
+// SVM has it (as it does when SVM-DR preserves network identity) and otherwise using the first NFS
+// LIF found.
+func resolveNASDataLIF(config *drivers.OntapStorageDriverConfig, client *api.Client, dataLIF *nasDataLIF) error {
+
+	dataLIFs, err := client.NetInterfaceGetDataLIFs("nfs")
+	if err != nil {
+		return err
+	}
+	if len(dataLIFs) == 0 {
+		return fmt.Errorf("no NAS data LIFs found on SVM %s", client.SVM())
+	}
+
+	oldDataLIF := dataLIF.get(config)
+	if _, err := ValidateDataLIF(utils.ParseHostportIP(oldDataLIF), dataLIFs); err == nil {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"oldDataLIF": oldDataLIF,
+		"newDataLIF": dataLIFs[0],
+	}).Warning("Data LIF not found on SVM, using another.")
+	dataLIF.failover.Store(utils.BracketIPv6(dataLIFs[0]))
+	return nil
+}
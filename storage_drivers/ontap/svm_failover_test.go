@@ -0,0 +1,188 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+type fakeDataLIFResolver struct {
+	calls int
+}
+
+func (r *fakeDataLIFResolver) resolveDataLIFs() error {
+	r.calls++
+	return nil
+}
+
+// newTestSVMServer returns a server answering for an SVM in the given state, with NFS data LIFs.
+func newTestSVMServer(svm, state string, dataLIFs ...string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result := `<results status="passed"/>`
+		switch {
+		case strings.Contains(string(body), "<vserver-get>"):
+			result = `<results status="passed"><attributes><vserver-info><vserver-name>` + svm +
+				`</vserver-name><state>` + state + `</state></vserver-info></attributes></results>`
+		case strings.Contains(string(body), "<system-get-ontapi-version>"):
+			result = `<results status="passed"><major-version>1</major-version>` +
+				`<minor-version>170</minor-version></results>`
+		case strings.Contains(string(body), "<net-interface-get-iter>"):
+			result = `<results status="passed"><attributes-list>`
+			for _, dataLIF := range dataLIFs {
+				result += `<net-interface-info><address>` + dataLIF + `</address><data-protocols>` +
+					`<data-protocol>nfs</data-protocol></data-protocols></net-interface-info>`
+			}
+			result += fmt.Sprintf(`</attributes-list><num-records>%d</num-records></results>`, len(dataLIFs))
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<netapp version="1.170" xmlns="http://www.netapp.com/filer/admin">` + result + `</netapp>`))
+	}))
+}
+
+func TestSVMFailoverMonitor(t *testing.T) {
+
+	primary := newTestSVMServer("svm_primary", "running")
+	defer primary.Close()
+	primaryLIF := strings.TrimPrefix(primary.URL, "https://")
+
+	tests := map[string]struct {
+		checks       []bool // whether each health check passes
+		partnerState string
+		failedOver   bool
+	}{
+		"Healthy": {
+			checks:       []bool{true, true, true, true},
+			partnerState: "running",
+		},
+		"Reachable again before the threshold": {
+			checks:       []bool{false, false, true, false, false},
+			partnerState: "running",
+		},
+		"Fails over": {
+			checks:       []bool{false, false, true, false, false, false},
+			partnerState: "running",
+			failedOver:   true,
+		},
+		"Partner unavailable": {
+			checks:       []bool{false, false, false, false},
+			partnerState: "stopped",
+		},
+	}
+	for name, test := range tests {
+		partner := newTestSVMServer("svm_secondary", test.partnerState)
+		partnerLIF := strings.TrimPrefix(partner.URL, "https://")
+		config := &drivers.OntapStorageDriverConfig{
+			ManagementLIF:          primaryLIF,
+			SVM:                    "svm_primary",
+			SecondaryManagementLIF: partnerLIF,
+			SecondarySVM:           "svm_secondary",
+		}
+		client := api.NewClient(api.ClientConfig{
+			ManagementLIF:          config.ManagementLIF,
+			SVM:                    config.SVM,
+			SecondaryManagementLIF: config.SecondaryManagementLIF,
+			SecondarySVM:           config.SecondarySVM,
+		})
+		resolver := &fakeDataLIFResolver{}
+		monitor := NewSVMFailoverMonitor(client, resolver)
+
+		for _, healthy := range test.checks {
+			monitor.checkHealth = func() error {
+				if healthy {
+					return nil
+				}
+				return errors.New("connection refused")
+			}
+			monitor.run()
+		}
+		partner.Close()
+
+		// The config keeps the configured SVMs, and only the client follows the failover
+		assert.Equal(t, "svm_primary", config.SVM, name)
+		assert.Equal(t, primaryLIF, config.ManagementLIF, name)
+		if test.failedOver {
+			assert.Equal(t, "svm_secondary", client.SVM(), name)
+			assert.Equal(t, partnerLIF, client.ManagementLIF(), name)
+			assert.Equal(t, 1, resolver.calls, name)
+		} else {
+			assert.Equal(t, "svm_primary", client.SVM(), name)
+			assert.Equal(t, primaryLIF, client.ManagementLIF(), name)
+			assert.Equal(t, 0, resolver.calls, name)
+		}
+	}
+}
+
+func TestResolveNASDataLIF(t *testing.T) {
+
+	tests := map[string]struct {
+		dataLIFs []string
+		dataLIF  string
+		valid    bool
+	}{
+		"Data LIF kept":     {dataLIFs: []string{"10.0.0.2", "10.0.0.3"}, dataLIF: "10.0.0.3", valid: true},
+		"Data LIF replaced": {dataLIFs: []string{"10.0.1.2"}, dataLIF: "10.0.1.2", valid: true},
+		"No data LIFs":      {dataLIF: "10.0.0.3"},
+	}
+	for name, test := range tests {
+		server := newTestSVMServer("svm_secondary", "running", test.dataLIFs...)
+		client := api.NewClient(api.ClientConfig{ManagementLIF: strings.TrimPrefix(server.URL, "https://")})
+		config := &drivers.OntapStorageDriverConfig{DataLIF: "10.0.0.3"}
+		dataLIF := &nasDataLIF{}
+
+		err := resolveNASDataLIF(config, client, dataLIF)
+		server.Close()
+
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+		assert.Equal(t, test.dataLIF, dataLIF.get(config), name)
+		assert.Equal(t, "10.0.0.3", config.DataLIF, name)
+	}
+}
+
+func TestValidateSVMFailoverConfig(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		config       drivers.OntapStorageDriverConfig
+		secondarySVM string
+		valid        bool
+	}{
+		{"No partner", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1", SVM: "svm1"}, "", true},
+		{"Partner", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1", SVM: "svm1",
+			SecondaryManagementLIF: "10.0.1.1", SecondarySVM: "svm1_dr"}, "svm1_dr", true},
+		{"Partner SVM defaulted", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1", SVM: "svm1",
+			SecondaryManagementLIF: "10.0.1.1"}, "svm1", true},
+		{"Partner SVM not derivable", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1",
+			SecondaryManagementLIF: "10.0.1.1"}, "", false},
+		{"Partner SVM without LIF", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1", SVM: "svm1",
+			SecondarySVM: "svm1_dr"}, "", false},
+		{"Same LIF", drivers.OntapStorageDriverConfig{ManagementLIF: "10.0.0.1", SVM: "svm1",
+			SecondaryManagementLIF: "10.0.0.1:443"}, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSVMFailoverConfig(&test.config)
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.secondarySVM, test.config.SecondarySVM)
+		})
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netapp/trident/storage"
+	sa "github.com/netapp/trident/storage_attribute"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/utils"
+)
+
+const (
+	// The layouts of a Flexvol's data that SnapMirror copies whole, so that a copy is only usable by a
+	// driver laying out its volumes the same way
+	volumeCopyFormatNAS = "ontap-flexvol-files"
+	volumeCopyFormatSAN = "ontap-flexvol-lun"
+
+	volumeCopyRelationshipType = "extended_data_protection"
+	volumeCopyPolicy           = "MirrorAllSnapshots"
+
+	// SnapMirror relationship states
+	snapmirrorStateMirrored = "snapmirrored"
+	snapmirrorStatusIdle    = "idle"
+)
+
+// VolumeCopyTracker records the progress of the SnapMirror transfers copying volumes to a driver's SVM
+// from other backends, so they may be listed as storage jobs.  Its records are refreshed whenever the
+// copying volume's creation is retried, and rebuilt from the relationships on the SVM after a restart.
+type VolumeCopyTracker struct {
+	jobs  map[string]*storage.StorageJob
+	mutex sync.Mutex
+}
+
+// NewVolumeCopyTracker returns a tracker for the copies made to a driver's SVM.
+func NewVolumeCopyTracker() *VolumeCopyTracker {
+	return &VolumeCopyTracker{jobs: make(map[string]*storage.StorageJob)}
+}
+
+// update records the progress of the copy to the named volume.
+func (t *VolumeCopyTracker) update(name string, percentComplete int, message string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, ok := t.jobs[name]
+	if !ok {
+		job = &storage.StorageJob{
+			InternalVolume: name,
+			Operation:      storage.StorageJobOperationVolumeCopy,
+			State:          "running",
+			StartTime:      time.Now().UTC().Format(time.RFC3339),
+		}
+		t.jobs[name] = job
+	}
+	job.PercentComplete = percentComplete
+	job.Message = message
+}
+
+// Forget discards any record of a copy to the named volume.
+func (t *VolumeCopyTracker) Forget(name string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.jobs, name)
+}
+
+// Jobs returns the copies still running.
+func (t *VolumeCopyTracker) Jobs() []*storage.StorageJob {
+	jobs := make([]*storage.StorageJob, 0)
+	if t == nil {
+		return jobs
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, job := range t.jobs {
+		jobCopy := *job
+		jobs = append(jobs, &jobCopy)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].InternalVolume < jobs[j].InternalVolume })
+	return jobs
+}
+
+// flexvolCopyOptions holds the attributes of a Flexvol created to receive a copy.
+type flexvolCopyOptions struct {
+	aggregate      string
+	size           string
+	spaceReserve   string
+	snapshotPolicy string
+	exportPolicy   string
+	comment        string
+}
+
+// snapmirrorLocation returns the SnapMirror path of a Flexvol.
+func snapmirrorLocation(svm, volume string) string {
+	return svm + ":" + volume
+}
+
+// getFlexvolCopySource describes a Flexvol, or one of its snapshots, as the source of a copy.
+func getFlexvolCopySource(
+	client *api.Client, config *drivers.OntapStorageDriverConfig, format, name, snapshot string,
+) (*storage.VolumeCopySource, error) {
+
+	if config.SVM == "" {
+		return nil, utils.UnsupportedError("volumes can only be copied from a backend with a single SVM")
+	}
+
+	volAttrs, err := client.VolumeGet(name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading volume %s: %v", name, err)
+	}
+
+	source := &storage.VolumeCopySource{
+		Format:   format,
+		SVM:      client.SVM(),
+		Volume:   name,
+		Snapshot: snapshot,
+	}
+	if volAttrs.VolumeSpaceAttributesPtr != nil && volAttrs.VolumeSpaceAttributesPtr.SizeUsedPtr != nil {
+		source.UsedBytes = uint64(volAttrs.VolumeSpaceAttributesPtr.SizeUsed())
+	}
+	return source, nil
+}
+
+// releaseFlexvolCopySource releases a Flexvol from the relationship that copied it, deleting the
+// snapshots SnapMirror kept for the relationship.
+func releaseFlexvolCopySource(client *api.Client, destination *storage.VolumeCopySource) error {
+	releaseResponse, err := client.SnapmirrorRelease(snapmirrorLocation(destination.SVM, destination.Volume))
+	if err = api.GetError(releaseResponse, err); err != nil {
+		if zerr, ok := err.(api.ZapiError); ok && zerr.IsNotFound() {
+			return nil
+		}
+		return fmt.Errorf("error releasing SnapMirror source: %v", err)
+	}
+	return nil
+}
+
+// createFlexvolCopy creates a Flexvol holding a copy of the source's Flexvol, made by a SnapMirror
+// relationship from the source's SVM.  The first call creates a data protection Flexvol and starts the
+// transfer, and later calls report its progress until it completes, when the relationship is broken
+// and deleted and finish is called to make the Flexvol usable like one the driver created.  The state
+// of the copy is read from the SVM on each call, so the copy may outlast a restart.
+func createFlexvolCopy(
+	ctx context.Context, client *api.Client, config *drivers.OntapStorageDriverConfig, copies *VolumeCopyTracker,
+	name, format string, source *storage.VolumeCopySource, opts *flexvolCopyOptions, finish func() error,
+) error {
+
+	if source.Format != format {
+		return utils.UnsupportedError(fmt.Sprintf("the %s driver cannot copy data laid out as %s; volumes "+
+			"are only copied between backends of the same driver", config.StorageDriverName, source.Format))
+	}
+	if config.SVM == "" {
+		return utils.UnsupportedError("volumes can only be copied to a backend with a single SVM")
+	}
+
+	sourceLocation := snapmirrorLocation(source.SVM, source.Volume)
+	destinationLocation := snapmirrorLocation(client.SVM(), name)
+	logFields := log.Fields{"source": sourceLocation, "destination": destinationLocation}
+
+	relationship, err := client.SnapmirrorGet(destinationLocation)
+	if err != nil {
+		return fmt.Errorf("error reading SnapMirror relationship: %v", err)
+	}
+
+	if relationship == nil {
+
+		volExists, err := client.VolumeExists(name)
+		if err != nil {
+			return fmt.Errorf("error checking for existing volume: %v", err)
+		}
+		if volExists {
+			return drivers.NewResourceExistsError(fmt.Sprintf("volume %s already exists", name), nil)
+		}
+
+		logc(ctx).WithFields(logFields).Info("Copying volume with SnapMirror.")
+
+		volCreateResponse, err := client.VolumeCreateDataProtection(name, opts.aggregate, opts.size,
+			opts.spaceReserve, opts.exportPolicy, opts.comment)
+		if err = api.GetError(volCreateResponse, err); err != nil {
+			return fmt.Errorf("error creating volume %s to copy into: %v", name, err)
+		}
+
+		createResponse, err := client.SnapmirrorCreate(sourceLocation, destinationLocation,
+			volumeCopyRelationshipType, volumeCopyPolicy)
+		if err = api.GetError(createResponse, err); err != nil {
+			cleanupFailedFlexvolCopy(ctx, client, name, destinationLocation)
+			return fmt.Errorf("error creating SnapMirror relationship from %s: %v", sourceLocation, err)
+		}
+
+		initResponse, err := client.SnapmirrorInitialize(sourceLocation, destinationLocation, source.Snapshot)
+		if err = api.GetError(initResponse, err); err != nil {
+			cleanupFailedFlexvolCopy(ctx, client, name, destinationLocation)
+			return fmt.Errorf("error initializing SnapMirror relationship from %s: %v", sourceLocation, err)
+		}
+
+		copies.update(name, 0, "copying from "+sourceLocation)
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being copied from %s", name, sourceLocation))
+	}
+
+	mirrorState, status, transferError := "", "", ""
+	if relationship.MirrorStatePtr != nil {
+		mirrorState = relationship.MirrorState()
+	}
+	if relationship.RelationshipStatusPtr != nil {
+		status = relationship.RelationshipStatus()
+	}
+	if relationship.LastTransferErrorPtr != nil {
+		transferError = relationship.LastTransferError()
+	}
+
+	switch {
+	case mirrorState == snapmirrorStateMirrored && status == snapmirrorStatusIdle:
+
+		breakResponse, err := client.SnapmirrorBreak(destinationLocation)
+		if err = api.GetError(breakResponse, err); err != nil {
+			return fmt.Errorf("error breaking SnapMirror relationship: %v", err)
+		}
+		destroyResponse, err := client.SnapmirrorDestroy(destinationLocation)
+		if err = api.GetError(destroyResponse, err); err != nil {
+			return fmt.Errorf("error deleting SnapMirror relationship: %v", err)
+		}
+		copies.Forget(name)
+
+		logc(ctx).WithFields(logFields).Info("Volume copy complete.")
+		if opts.snapshotPolicy != "" {
+			policyResponse, err := client.VolumeModifySnapshotPolicy(name, opts.snapshotPolicy)
+			if err = api.GetError(policyResponse, err); err != nil {
+				return fmt.Errorf("error setting snapshot policy: %v", err)
+			}
+		}
+		return finish()
+
+	case status == snapmirrorStatusIdle && transferError != "":
+
+		copies.Forget(name)
+		cleanupFailedFlexvolCopy(ctx, client, name, destinationLocation)
+		return fmt.Errorf("copy of volume %s from %s failed: %s", name, sourceLocation, transferError)
+
+	default:
+
+		percentComplete := 0
+		message := "copying from " + sourceLocation
+		if relationship.SnapshotProgressPtr != nil {
+			transferred := relationship.SnapshotProgress()
+			message = fmt.Sprintf("copied %d bytes from %s", transferred, sourceLocation)
+			if source.UsedBytes > 0 {
+				if percentComplete = int(100 * transferred / source.UsedBytes); percentComplete > 99 {
+					percentComplete = 99
+				}
+			}
+		}
+		copies.update(name, percentComplete, message)
+		return utils.VolumeCreatingError(fmt.Sprintf("volume %s is being copied from %s; %s", name,
+			sourceLocation, message))
+	}
+}
+
+// cleanupFailedFlexvolCopy deletes the relationship and Flexvol left by a copy that failed, so that a
+// retry starts over.
+func cleanupFailedFlexvolCopy(ctx context.Context, client *api.Client, name, destinationLocation string) {
+
+	destroyResponse, err := client.SnapmirrorDestroy(destinationLocation)
+	if err = api.GetError(destroyResponse, err); err != nil {
+		logc(ctx).WithField("destination", destinationLocation).WithError(err).Debug(
+			"Could not delete SnapMirror relationship of failed copy.")
+	}
+
+	volDestroyResponse, err := client.VolumeDestroy(name, true)
+	if err = api.GetError(volDestroyResponse, err); err != nil {
+		logc(ctx).WithField("volume", name).WithError(err).Warning("Could not delete volume of failed copy.")
+	}
+}
+
+// getFlexvolCopyOptions returns the attributes of a Flexvol to receive a copy in a storage pool, chosen
+// as for a new volume of the pool.
+func getFlexvolCopyOptions(
+	config *drivers.OntapStorageDriverConfig, volConfig *storage.VolumeConfig, storagePool *storage.Pool,
+	physicalPools, virtualPools map[string]*storage.Pool, opts map[string]string,
+) (*flexvolCopyOptions, error) {
+
+	candidates, err := getPoolsForCreate(volConfig, storagePool, make(map[string]sa.Request), physicalPools,
+		virtualPools)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no aggregates are available in pool %s", storagePool.Name)
+	}
+
+	requestedSize, err := utils.ConvertSizeToBytes(volConfig.Size)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert volume size %s: %v", volConfig.Size, err)
+	}
+	sizeBytes, err := strconv.ParseUint(requestedSize, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%v is an invalid volume size: %v", volConfig.Size, err)
+	}
+	snapshotPolicy := utils.GetV(opts, "snapshotPolicy", storagePool.InternalAttributes[SnapshotPolicy])
+	snapshotReserve, err := GetSnapshotReserve(snapshotPolicy,
+		utils.GetV(opts, "snapshotReserve", storagePool.InternalAttributes[SnapshotReserve]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for snapshotReserve: %v", err)
+	}
+
+	return &flexvolCopyOptions{
+		aggregate:      candidates[0].Name,
+		size:           strconv.FormatUint(calculateFlexvolSizeBytes(sizeBytes, snapshotReserve), 10),
+		spaceReserve:   utils.GetV(opts, "spaceReserve", storagePool.InternalAttributes[SpaceReserve]),
+		snapshotPolicy: snapshotPolicy,
+		comment:        getFlexvolComment(config, volConfig),
+	}, nil
+}
+
+// GetCopySource describes a volume, or one of its snapshots, as the source of a copy by another backend.
+func (d *NASStorageDriver) GetCopySource(
+	ctx context.Context, volConfig *storage.VolumeConfig, snapshotName string,
+) (*storage.VolumeCopySource, error) {
+	return getFlexvolCopySource(d.API.WithContext(ctx), &d.Config, volumeCopyFormatNAS, volConfig.InternalName,
+		snapshotName)
+}
+
+// CreateCopy creates a volume holding a copy of a volume on another backend's SVM.
+func (d *NASStorageDriver) CreateCopy(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, source *storage.VolumeCopySource,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "CreateCopy",
+			"Type":   "NASStorageDriver",
+			"name":   name,
+			"source": source.Volume,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> CreateCopy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateCopy")
+	}
+
+	volOpts, err := d.GetVolumeOpts(volConfig, make(map[string]sa.Request))
+	if err != nil {
+		return err
+	}
+	opts, err := getFlexvolCopyOptions(&d.Config, volConfig, storagePool, d.physicalPools, d.virtualPools, volOpts)
+	if err != nil {
+		return err
+	}
+	opts.exportPolicy = utils.GetV(volOpts, "exportPolicy", storagePool.InternalAttributes[ExportPolicy])
+	if d.Config.AutoExportPolicy {
+		if opts.exportPolicy, err = getAutoExportPolicy(&d.Config, client, storagePool.Backend.BackendUUID,
+			volConfig); err != nil {
+			return err
+		}
+	}
+
+	enableSnapshotDir, err := strconv.ParseBool(
+		utils.GetV(volOpts, "snapshotDir", storagePool.InternalAttributes[SnapshotDir]))
+	if err != nil {
+		return fmt.Errorf("invalid boolean value for snapshotDir: %v", err)
+	}
+
+	return createFlexvolCopy(ctx, client, &d.Config, d.volumeCopies, name, volumeCopyFormatNAS, source, opts,
+		func() error {
+			return d.finishFlexvolCreate(client, name, enableSnapshotDir,
+				storagePool.InternalAttributes[TieringMinimumCoolingDays])
+		})
+}
+
+// ReleaseCopySource releases a volume from the relationship that copied it to another backend.
+func (d *NASStorageDriver) ReleaseCopySource(
+	ctx context.Context, _, destination *storage.VolumeCopySource,
+) error {
+	return releaseFlexvolCopySource(d.API.WithContext(ctx), destination)
+}
+
+// GetCopySource describes a volume, or one of its snapshots, as the source of a copy by another backend.
+func (d *SANStorageDriver) GetCopySource(
+	ctx context.Context, volConfig *storage.VolumeConfig, snapshotName string,
+) (*storage.VolumeCopySource, error) {
+	return getFlexvolCopySource(d.API.WithContext(ctx), &d.Config, volumeCopyFormatSAN, volConfig.InternalName,
+		snapshotName)
+}
+
+// CreateCopy creates a volume holding a copy of a volume on another backend's SVM.  The copied Flexvol
+// holds the source's LUN, which is brought online once the copy completes.
+func (d *SANStorageDriver) CreateCopy(
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, source *storage.VolumeCopySource,
+) error {
+
+	client := d.API.WithContext(ctx)
+	name := volConfig.InternalName
+
+	if d.Config.DebugTraceFlags["method"] {
+		fields := log.Fields{
+			"Method": "CreateCopy",
+			"Type":   "SANStorageDriver",
+			"name":   name,
+			"source": source.Volume,
+		}
+		logc(ctx).WithFields(fields).Debug(">>>> CreateCopy")
+		defer logc(ctx).WithFields(fields).Debug("<<<< CreateCopy")
+	}
+
+	volOpts, err := d.GetVolumeOpts(volConfig, make(map[string]sa.Request))
+	if err != nil {
+		return err
+	}
+	opts, err := getFlexvolCopyOptions(&d.Config, volConfig, storagePool, d.physicalPools, d.virtualPools, volOpts)
+	if err != nil {
+		return err
+	}
+
+	return createFlexvolCopy(ctx, client, &d.Config, d.volumeCopies, name, volumeCopyFormatSAN, source, opts,
+		func() error {
+			onlineResponse, err := client.LunOnline(lunPath(name))
+			if err = api.GetError(onlineResponse, err); err != nil {
+				return fmt.Errorf("error bringing copied LUN online: %v", err)
+			}
+			return nil
+		})
+}
+
+// ReleaseCopySource releases a volume from the relationship that copied it to another backend.
+func (d *SANStorageDriver) ReleaseCopySource(
+	ctx context.Context, _, destination *storage.VolumeCopySource,
+) error {
+	return releaseFlexvolCopySource(d.API.WithContext(ctx), destination)
+}
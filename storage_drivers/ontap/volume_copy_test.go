@@ -0,0 +1,59 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/utils"
+)
+
+func TestVolumeCopyTracker(t *testing.T) {
+
+	tracker := NewVolumeCopyTracker()
+	assert.Empty(t, tracker.Jobs())
+
+	tracker.update("vol2", 0, "copying from svm1:vol1")
+	tracker.update("vol1", 10, "copying from svm1:vol0")
+	tracker.update("vol1", 40, "copied 400 bytes from svm1:vol0")
+
+	jobs := tracker.Jobs()
+	if assert.Len(t, jobs, 2) {
+		assert.Equal(t, "vol1", jobs[0].InternalVolume)
+		assert.Equal(t, storage.StorageJobOperationVolumeCopy, jobs[0].Operation)
+		assert.Equal(t, 40, jobs[0].PercentComplete)
+		assert.Equal(t, "copied 400 bytes from svm1:vol0", jobs[0].Message)
+		assert.Equal(t, "vol2", jobs[1].InternalVolume)
+	}
+
+	tracker.Forget("vol1")
+	tracker.Forget("vol2")
+	assert.Empty(t, tracker.Jobs())
+
+	// A driver that never initialized its tracker has no copies
+	var nilTracker *VolumeCopyTracker
+	nilTracker.update("vol1", 0, "")
+	assert.Empty(t, nilTracker.Jobs())
+}
+
+func TestCreateFlexvolCopyUnsupported(t *testing.T) {
+
+	config := newTestOntapSANConfig()
+	source := &storage.VolumeCopySource{Format: volumeCopyFormatNAS, SVM: "svm1", Volume: "vol1"}
+
+	// LUNs can't be made from a copy of files
+	err := createFlexvolCopy(context.Background(), nil, config, NewVolumeCopyTracker(), "vol2",
+		volumeCopyFormatSAN, source, &flexvolCopyOptions{}, func() error { return nil })
+	assert.True(t, utils.IsUnsupportedError(err), "expected unsupported error")
+
+	// SnapMirror relationships must name a single SVM
+	config.SVM = ""
+	source.Format = volumeCopyFormatSAN
+	err = createFlexvolCopy(context.Background(), nil, config, NewVolumeCopyTracker(), "vol2",
+		volumeCopyFormatSAN, source, &flexvolCopyOptions{}, func() error { return nil })
+	assert.True(t, utils.IsUnsupportedError(err), "expected unsupported error")
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"strconv"
+
+	tridentconfig "github.com/netapp/trident/config"
+	"github.com/netapp/trident/storage"
+	drivers "github.com/netapp/trident/storage_drivers"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+const (
+	// defaultVolumePageSize is how many FlexVols are listed at a time unless the caller says otherwise.
+	defaultVolumePageSize = 100
+
+	// maxVolumePageSize limits how many FlexVols ONTAP is asked for at a time, however many the caller wants.
+	maxVolumePageSize = 1000
+)
+
+// listFlexvolPage returns up to limit FlexVols whose names start with the prefix, whatever their state,
+// starting from the continuation token, or from the first FlexVol if the token is empty.  The returned
+// token continues the listing, and is empty after the last page.
+func listFlexvolPage(
+	client *api.Client, prefix string, limit int, token string,
+) ([]azgo.VolumeAttributesType, string, error) {
+
+	if limit <= 0 {
+		limit = defaultVolumePageSize
+	} else if limit > maxVolumePageSize {
+		limit = maxVolumePageSize
+	}
+
+	response, err := client.VolumeListPage(prefix, limit, token)
+	if err = api.GetError(response, err); err != nil {
+		return nil, "", err
+	}
+
+	var flexvols []azgo.VolumeAttributesType
+	if response.Result.AttributesListPtr != nil {
+		flexvols = response.Result.AttributesListPtr.VolumeAttributesPtr
+	}
+	next := ""
+	if response.Result.NextTagPtr != nil {
+		next = response.Result.NextTag()
+	}
+	return flexvols, next, nil
+}
+
+// forEachFlexvol calls the function with each FlexVol whose name starts with the prefix, whatever its
+// state, listing the FlexVols a page at a time so that no single request returns all of them.  Listing
+// stops at the first error returned by the function.
+func forEachFlexvol(client *api.Client, prefix string, fn func(*azgo.VolumeAttributesType) error) error {
+
+	token := ""
+	for {
+		flexvols, next, err := listFlexvolPage(client, prefix, defaultVolumePageSize, token)
+		if err != nil {
+			return err
+		}
+		for i := range flexvols {
+			if err = fn(&flexvols[i]); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// isOnlineVolume returns true if a FlexVol listed with its state is online.
+func isOnlineVolume(volAttrs *azgo.VolumeAttributesType) bool {
+	stateAttrs := volAttrs.VolumeStateAttributesPtr
+	return stateAttrs != nil && stateAttrs.StatePtr != nil && stateAttrs.State() == "online"
+}
+
+// listVolumes returns one page of the FlexVols matching the storage prefix, other than those waiting
+// in the recovery queue, for the ontap-nas and ontap-san drivers.  A page may hold fewer than limit
+// volumes even if more follow.
+func listVolumes(
+	client *api.Client, config *drivers.OntapStorageDriverConfig, limit int, token string,
+) (*storage.VolumePage, error) {
+
+	flexvols, next, err := listFlexvolPage(client, *config.StoragePrefix, limit, token)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &storage.VolumePage{
+		Volumes:  make([]*storage.BackendVolume, 0, len(flexvols)),
+		Continue: next,
+	}
+	for i := range flexvols {
+		if isQueuedVolume(&flexvols[i]) {
+			continue
+		}
+		if volume := getBackendVolume(&flexvols[i]); volume != nil {
+			page.Volumes = append(page.Volumes, volume)
+		}
+	}
+
+	return page, nil
+}
+
+// getBackendVolume describes a FlexVol listed with its state, or returns nil if the FlexVol has no name.
+func getBackendVolume(volAttrs *azgo.VolumeAttributesType) *storage.BackendVolume {
+
+	idAttrs := volAttrs.VolumeIdAttributesPtr
+	if idAttrs == nil || idAttrs.NamePtr == nil {
+		return nil
+	}
+
+	volume := &storage.BackendVolume{
+		InternalName: string(idAttrs.Name()),
+		Size:         strconv.FormatUint(getUsableVolumeSize(volAttrs), 10),
+	}
+	if idAttrs.ContainingAggregateNamePtr != nil {
+		volume.Pool = idAttrs.ContainingAggregateName()
+	}
+	if stateAttrs := volAttrs.VolumeStateAttributesPtr; stateAttrs != nil && stateAttrs.StatePtr != nil {
+		volume.State = stateAttrs.State()
+	}
+	return volume
+}
+
+// isImportableFlexvol returns true if a FlexVol listed with its state and type could be imported: it must be
+// online, writable, and neither the SVM's root volume nor waiting in the recovery queue.
+func isImportableFlexvol(volAttrs *azgo.VolumeAttributesType) bool {
+
+	if !isOnlineVolume(volAttrs) || isQueuedVolume(volAttrs) {
+		return false
+	}
+	stateAttrs := volAttrs.VolumeStateAttributesPtr
+	if stateAttrs.IsVserverRootPtr != nil && stateAttrs.IsVserverRoot() {
+		return false
+	}
+	idAttrs := volAttrs.VolumeIdAttributesPtr
+	if idAttrs == nil || idAttrs.NamePtr == nil {
+		return false
+	}
+	return idAttrs.TypePtr == nil || idAttrs.Type() == "rw"
+}
+
+// listNASImportCandidates returns the FlexVols on the SVM that the ontap-nas driver could import, whatever
+// their names.
+func listNASImportCandidates(client *api.Client) ([]*storage.ImportCandidate, error) {
+
+	candidates := make([]*storage.ImportCandidate, 0)
+	err := forEachFlexvol(client, "", func(volAttrs *azgo.VolumeAttributesType) error {
+		if !isImportableFlexvol(volAttrs) {
+			return nil
+		}
+		volume := getBackendVolume(volAttrs)
+		candidate := &storage.ImportCandidate{
+			InternalName: volume.InternalName,
+			Size:         volume.Size,
+			Pool:         volume.Pool,
+			Protocol:     tridentconfig.File,
+		}
+		if idAttrs := volAttrs.VolumeIdAttributesPtr; idAttrs.JunctionPathPtr != nil {
+			candidate.JunctionPath = string(idAttrs.JunctionPath())
+		}
+		candidates = append(candidates, candidate)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// listSANImportCandidates returns the FlexVols on the SVM that the ontap-san driver could import, which are
+// those holding exactly one LUN, whatever their names.  Each is the size of its LUN.
+func listSANImportCandidates(client *api.Client) ([]*storage.ImportCandidate, error) {
+
+	lunsResponse, err := client.LunGetAll("/vol/*/*")
+	if err = api.GetError(lunsResponse, err); err != nil {
+		return nil, err
+	}
+	lunsByFlexvol := make(map[string][]azgo.LunInfoType)
+	if lunsResponse.Result.AttributesListPtr != nil {
+		for _, lun := range lunsResponse.Result.AttributesListPtr.LunInfoPtr {
+			if lun.VolumePtr != nil && lun.PathPtr != nil && lun.SizePtr != nil {
+				lunsByFlexvol[lun.Volume()] = append(lunsByFlexvol[lun.Volume()], lun)
+			}
+		}
+	}
+
+	candidates := make([]*storage.ImportCandidate, 0)
+	err = forEachFlexvol(client, "", func(volAttrs *azgo.VolumeAttributesType) error {
+		if !isImportableFlexvol(volAttrs) {
+			return nil
+		}
+		volume := getBackendVolume(volAttrs)
+		luns := lunsByFlexvol[volume.InternalName]
+		if len(luns) != 1 {
+			return nil
+		}
+		candidates = append(candidates, &storage.ImportCandidate{
+			InternalName: volume.InternalName,
+			Size:         strconv.Itoa(luns[0].Size()),
+			Pool:         volume.Pool,
+			Protocol:     tridentconfig.Block,
+			LUN:          luns[0].Path(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
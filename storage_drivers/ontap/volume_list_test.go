@@ -0,0 +1,62 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api/azgo"
+)
+
+func TestGetBackendVolume(t *testing.T) {
+
+	volAttrs := azgo.NewVolumeAttributesType().
+		SetVolumeIdAttributes(*azgo.NewVolumeIdAttributesType().
+			SetName("trident_pvc_1").
+			SetContainingAggregateName("aggr1")).
+		SetVolumeSpaceAttributes(*azgo.NewVolumeSpaceAttributesType().
+			SetSize(1073741824).
+			SetPercentageSnapshotReserve(0)).
+		SetVolumeStateAttributes(*azgo.NewVolumeStateAttributesType().SetState("online"))
+
+	assert.True(t, isOnlineVolume(volAttrs))
+	assert.Equal(t, &storage.BackendVolume{
+		InternalName: "trident_pvc_1",
+		Size:         "1073741824",
+		Pool:         "aggr1",
+		State:        "online",
+	}, getBackendVolume(volAttrs))
+
+	// Volumes listed without their state aren't known to be online
+	volAttrs.VolumeStateAttributesPtr = nil
+	assert.False(t, isOnlineVolume(volAttrs))
+	assert.Equal(t, "", getBackendVolume(volAttrs).State)
+
+	// Volumes without names are skipped
+	assert.Nil(t, getBackendVolume(azgo.NewVolumeAttributesType()))
+}
+
+func TestIsImportableFlexvol(t *testing.T) {
+
+	newFlexvol := func(volType string, root bool) *azgo.VolumeAttributesType {
+		return azgo.NewVolumeAttributesType().
+			SetVolumeIdAttributes(*azgo.NewVolumeIdAttributesType().
+				SetName("app_data").
+				SetType(volType).
+				SetJunctionPath("/app_data")).
+			SetVolumeStateAttributes(*azgo.NewVolumeStateAttributesType().
+				SetState("online").
+				SetIsVserverRoot(root))
+	}
+
+	assert.True(t, isImportableFlexvol(newFlexvol("rw", false)))
+	assert.False(t, isImportableFlexvol(newFlexvol("dp", false)), "mirror destination importable")
+	assert.False(t, isImportableFlexvol(newFlexvol("rw", true)), "SVM root volume importable")
+
+	offline := newFlexvol("rw", false)
+	offline.VolumeStateAttributesPtr.SetState("offline")
+	assert.False(t, isImportableFlexvol(offline), "offline volume importable")
+}
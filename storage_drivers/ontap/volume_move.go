@@ -0,0 +1,171 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/netapp/trident/storage"
+	"github.com/netapp/trident/storage_drivers/ontap/api"
+)
+
+const (
+	// Finished moves are remembered long enough for their outcome to be seen in the volume's status
+	volumeMoveStatusRetention = 1 * time.Hour
+)
+
+// VolumeMoveTracker starts moving Flexvols between aggregates on behalf of a driver.  The tracker's
+// housekeeping job polls each running move and notifies a handler (if any) when a move completes
+// or fails, so that the volume's pool may be updated.
+type VolumeMoveTracker struct {
+	client  *api.Client
+	jobs    *jobTracker
+	handler storage.VolumeMoveHandler
+	mutex   sync.Mutex
+}
+
+// NewVolumeMoveTracker returns a tracker for a driver's volume moves.
+func NewVolumeMoveTracker(client *api.Client) *VolumeMoveTracker {
+	t := &VolumeMoveTracker{client: client}
+	t.jobs = &jobTracker{
+		description:      "volume move",
+		readFailureLimit: maxJobStatusReadFailures,
+		retention:        volumeMoveStatusRetention,
+		jobs:             make(map[string]*trackedJob),
+		start:            t.startOntapMove,
+		read:             t.getOntapMoveStatus,
+		finished:         t.notify,
+	}
+	return t
+}
+
+// SetHandler registers a function to be called whenever a move completes or fails.
+func (t *VolumeMoveTracker) SetHandler(handler storage.VolumeMoveHandler) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handler = handler
+}
+
+// Status returns a copy of the latest status of a volume's move, or nil if the volume hasn't been
+// moved recently.
+func (t *VolumeMoveTracker) Status(name string) *storage.VolumeMoveStatus {
+	if t == nil {
+		return nil
+	}
+	move, ok := t.jobs.get(name)
+	if !ok {
+		return nil
+	}
+	return volumeMoveStatus(move)
+}
+
+// Jobs returns the moves still running.
+func (t *VolumeMoveTracker) Jobs() []*storage.StorageJob {
+	if t == nil {
+		return make([]*storage.StorageJob, 0)
+	}
+	return t.jobs.storageJobs()
+}
+
+// Start asks ONTAP to begin moving a volume to another aggregate.  A volume may only have one move
+// running at a time.
+func (t *VolumeMoveTracker) Start(ctx context.Context, name, sourceAggregate, destinationAggregate string) error {
+
+	begun, err := t.jobs.begin(name, trackedJob{
+		operation:   storage.StorageJobOperationVolumeMove,
+		source:      sourceAggregate,
+		destination: destinationAggregate,
+	})
+	if err != nil {
+		return wrapOntapError(err, "error moving volume")
+	} else if !begun {
+		move, _ := t.jobs.get(name)
+		return fmt.Errorf("volume %s is already moving to %s", name, move.destination)
+	}
+	return nil
+}
+
+// Resume follows a move that may have been running when Trident restarted.  Its state is read from
+// ONTAP at the next poll, so the handler hears about a move that finished meanwhile.
+func (t *VolumeMoveTracker) Resume(name, destinationAggregate string) {
+	if t == nil {
+		return
+	}
+	t.jobs.resume(name, trackedJob{
+		operation:   storage.StorageJobOperationVolumeMove,
+		destination: destinationAggregate,
+	})
+}
+
+func (t *VolumeMoveTracker) startOntapMove(name string, move trackedJob) error {
+	moveResponse, err := t.client.VolumeMoveStart(name, move.destination)
+	return api.GetError(moveResponse, err)
+}
+
+// getOntapMoveStatus reads a move's progress from ONTAP, which reports the move's state as healthy,
+// warning, alert, failed, or done.  ONTAP purges the records of finished moves after a while, so a
+// move it no longer reports is complete if the volume is on the destination aggregate.
+func (t *VolumeMoveTracker) getOntapMoveStatus(name string, move trackedJob) (jobReading, error) {
+
+	info, err := t.client.VolumeMoveGet(name)
+	if err != nil {
+		if volInfo, volErr := t.client.VolumeGet(name); volErr == nil && volInfo.VolumeIdAttributesPtr != nil &&
+			volInfo.VolumeIdAttributesPtr.ContainingAggregateName() == move.destination {
+			return jobReading{state: jobStateComplete, percent: 100}, nil
+		}
+		return jobReading{}, err
+	}
+
+	reading := jobReading{state: jobStateRunning}
+	if info.PercentCompletePtr != nil {
+		reading.percent = info.PercentComplete()
+	}
+	if info.DetailsPtr != nil {
+		reading.message = info.Details()
+	}
+
+	if info.StatePtr != nil {
+		switch info.State() {
+		case "done":
+			reading.state, reading.percent = jobStateComplete, 100
+		case "failed":
+			reading.state = jobStateFailed
+		}
+	}
+	return reading, nil
+}
+
+// notify tells the handler about a move that finished.
+func (t *VolumeMoveTracker) notify(name string, move trackedJob) {
+
+	t.mutex.Lock()
+	handler := t.handler
+	t.mutex.Unlock()
+
+	if handler != nil {
+		handler(name, volumeMoveStatus(move))
+	}
+}
+
+// HousekeepingJob returns the job that polls running volume moves.
+func (t *VolumeMoveTracker) HousekeepingJob() *HousekeepingJob {
+	return t.jobs.housekeepingJob(volumeMovePollJob, volumeMovePollPeriod)
+}
+
+func volumeMoveStatus(move trackedJob) *storage.VolumeMoveStatus {
+	return &storage.VolumeMoveStatus{
+		State:           storage.VolumeMoveState(move.state),
+		SourcePool:      move.source,
+		DestinationPool: move.destination,
+		PercentComplete: move.percent,
+		StartTime:       formatJobTime(move.started),
+		EndTime:         formatJobTime(move.finished),
+		Message:         move.message,
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package ontap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/storage"
+)
+
+// startVolumeMoves records each move started in states, refusing to move a volume to "full".
+func startVolumeMoves(states map[string]jobState) func(string, trackedJob) error {
+	return func(name string, move trackedJob) error {
+		if move.destination == "full" {
+			return errors.New("aggregate is full")
+		}
+		states[name] = jobStateRunning
+		return nil
+	}
+}
+
+// readVolumeMoveStates reads the state of each move from states, failing to read moves not listed.
+func readVolumeMoveStates(states map[string]jobState) func(string, trackedJob) (jobReading, error) {
+	return func(name string, _ trackedJob) (jobReading, error) {
+		switch states[name] {
+		case "":
+			return jobReading{}, errors.New("no move found")
+		case jobStateFailed:
+			return jobReading{state: jobStateFailed, percent: 40, message: "cutover failed"}, nil
+		}
+		return jobReading{state: states[name], percent: 40}, nil
+	}
+}
+
+func TestVolumeMoveTrackerStart(t *testing.T) {
+
+	tests := map[string]struct {
+		destinations []string
+		state        storage.VolumeMoveState
+		destination  string
+		valid        bool
+	}{
+		"Move started": {
+			destinations: []string{"aggr2"},
+			state:        storage.VolumeMoveStateRunning,
+			destination:  "aggr2",
+			valid:        true,
+		},
+		"Refused by ONTAP": {
+			destinations: []string{"full"},
+			state:        storage.VolumeMoveStateFailed,
+			destination:  "full",
+		},
+		"Already moving": {
+			destinations: []string{"aggr2", "aggr3"},
+			state:        storage.VolumeMoveStateRunning,
+			destination:  "aggr2",
+		},
+		"Moved again after a refusal": {
+			destinations: []string{"full", "aggr2"},
+			state:        storage.VolumeMoveStateRunning,
+			destination:  "aggr2",
+			valid:        true,
+		},
+	}
+	for name, test := range tests {
+		states := make(map[string]jobState)
+		tracker := NewVolumeMoveTracker(nil)
+		tracker.jobs.start = startVolumeMoves(states)
+		tracker.jobs.read = readVolumeMoveStates(states)
+
+		var err error
+		for _, destination := range test.destinations {
+			err = tracker.Start(context.Background(), "vol1", "aggr1", destination)
+		}
+
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+		status := tracker.Status("vol1")
+		if assert.NotNil(t, status, name) {
+			assert.Equal(t, test.state, status.State, name)
+			assert.Equal(t, "aggr1", status.SourcePool, name)
+			assert.Equal(t, test.destination, status.DestinationPool, name)
+		}
+	}
+
+	var nilTracker *VolumeMoveTracker
+	assert.Nil(t, nilTracker.Status("vol1"))
+	assert.Empty(t, nilTracker.Jobs())
+	nilTracker.SetHandler(nil)
+	nilTracker.Resume("vol1", "aggr2")
+}
+
+func TestVolumeMoveTrackerRun(t *testing.T) {
+
+	tests := map[string]struct {
+		resumed    bool
+		ontapState jobState
+		polls      int
+		state      storage.VolumeMoveState
+		message    string
+	}{
+		"Move in progress": {
+			ontapState: jobStateRunning,
+			polls:      1,
+			state:      storage.VolumeMoveStateRunning,
+		},
+		"Move complete": {
+			ontapState: jobStateComplete,
+			polls:      1,
+			state:      storage.VolumeMoveStateComplete,
+		},
+		"Move failed": {
+			ontapState: jobStateFailed,
+			polls:      1,
+			state:      storage.VolumeMoveStateFailed,
+			message:    "cutover failed",
+		},
+		"Move status briefly unreadable": {
+			polls: maxJobStatusReadFailures - 1,
+			state: storage.VolumeMoveStateRunning,
+		},
+		"Move status never readable": {
+			polls: maxJobStatusReadFailures,
+			state: storage.VolumeMoveStateFailed,
+		},
+		"Resumed move still running": {
+			resumed:    true,
+			ontapState: jobStateRunning,
+			polls:      1,
+			state:      storage.VolumeMoveStateRunning,
+		},
+		"Resumed move finished while stopped": {
+			resumed:    true,
+			ontapState: jobStateComplete,
+			polls:      1,
+			state:      storage.VolumeMoveStateComplete,
+		},
+	}
+	for name, test := range tests {
+		states := make(map[string]jobState)
+		tracker := NewVolumeMoveTracker(nil)
+		tracker.jobs.start = startVolumeMoves(states)
+		tracker.jobs.read = readVolumeMoveStates(states)
+
+		var notified *storage.VolumeMoveStatus
+		tracker.SetHandler(func(_ string, status *storage.VolumeMoveStatus) {
+			notified = status
+		})
+
+		if test.resumed {
+			tracker.Resume("vol1", "aggr2")
+		} else {
+			assert.NoError(t, tracker.Start(context.Background(), "vol1", "aggr1", "aggr2"), name)
+		}
+		states["vol1"] = test.ontapState
+
+		for i := 0; i < test.polls; i++ {
+			tracker.jobs.run()
+		}
+
+		status := tracker.Status("vol1")
+		assert.Equal(t, test.state, status.State, name)
+		assert.Equal(t, "aggr2", status.DestinationPool, name)
+		if test.message != "" {
+			assert.Equal(t, test.message, status.Message, name)
+		}
+
+		// Running moves report their progress without notifying the handler
+		if test.state == storage.VolumeMoveStateRunning {
+			assert.Nil(t, notified, name)
+			assert.Empty(t, status.EndTime, name)
+			if assert.Len(t, tracker.Jobs(), 1, name) {
+				assert.Equal(t, storage.StorageJobOperationVolumeMove, tracker.Jobs()[0].Operation, name)
+			}
+			continue
+		}
+		if assert.NotNil(t, notified, name) {
+			assert.Equal(t, test.state, notified.State, name)
+			assert.Equal(t, "aggr2", notified.DestinationPool, name)
+			assert.NotEmpty(t, notified.EndTime, name)
+		}
+		if test.state == storage.VolumeMoveStateFailed {
+			assert.NotEmpty(t, status.Message, name)
+		}
+		assert.Empty(t, tracker.Jobs(), name)
+
+		// A finished move may be followed by another
+		assert.NoError(t, tracker.Start(context.Background(), "vol1", "aggr2", "aggr1"), name)
+		assert.Equal(t, storage.VolumeMoveStateRunning, tracker.Status("vol1").State, name)
+	}
+}
@@ -3,6 +3,7 @@
 package solidfire
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -703,7 +704,7 @@ func MakeSolidFireName(name string) string {
 
 // Create a SolidFire volume
 func (d *SANStorageDriver) Create(
-	volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
+	ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool, volAttributes map[string]sa.Request,
 ) error {
 
 	name := volConfig.InternalName
@@ -715,8 +716,8 @@ func (d *SANStorageDriver) Create(
 			"name":   name,
 			"attrs":  volAttributes,
 		}
-		log.WithFields(fields).Debug(">>>> Create")
-		defer log.WithFields(fields).Debug("<<<< Create")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Create")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Create")
 	}
 
 	var req api.CreateVolumeRequest
@@ -742,7 +743,7 @@ func (d *SANStorageDriver) Create(
 		return err
 	}
 	if exists {
-		log.WithField("volume", name).Warning("Found existing volume.")
+		utils.Logc(ctx).WithField("volume", name).Warning("Found existing volume.")
 		return drivers.NewVolumeExistsError(name)
 	}
 
@@ -784,7 +785,7 @@ func (d *SANStorageDriver) Create(
 	typeOpt := utils.GetV(opts, "type", "")
 	if typeOpt != "" {
 		if qos.MinIOPS != 0 {
-			log.Warning("QoS values appear to have been set using -o qos, but " +
+			utils.Logc(ctx).Warning("QoS values appear to have been set using -o qos, but " +
 				"type is set as well, overriding with type option.")
 		}
 
@@ -821,7 +822,7 @@ func (d *SANStorageDriver) Create(
 			req.Enable512e = true
 		}
 	}
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"blocksize":  blockSizeOpt,
 		"enable512e": req.Enable512e,
 	}).Debug("Parsed blocksize option.")
@@ -846,7 +847,7 @@ func (d *SANStorageDriver) Create(
 }
 
 // Create a volume clone
-func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
+func (d *SANStorageDriver) CreateClone(ctx context.Context, volConfig *storage.VolumeConfig, storagePool *storage.Pool) error {
 
 	name := volConfig.InternalName
 	sourceName := volConfig.CloneSourceVolumeInternal
@@ -861,8 +862,8 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 			"snapshot":    snapshotName,
 			"storagePool": storagePool,
 		}
-		log.WithFields(fields).Debug(">>>> CreateClone")
-		defer log.WithFields(fields).Debug("<<<< CreateClone")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateClone")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateClone")
 	}
 
 	var err error
@@ -887,7 +888,7 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 	if typeOpt != "" {
 		doModify = true
 		if qos.MinIOPS != 0 {
-			log.Warning("qos values appear to have been set using -o qos, but type is set as well, " +
+			utils.Logc(ctx).Warning("qos values appear to have been set using -o qos, but type is set as well, " +
 				"overriding with type option")
 		}
 
@@ -924,14 +925,14 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 		return err
 	}
 	if exists {
-		log.Warningf("Found existing volume %s, aborting clone operation.", name)
+		utils.Logc(ctx).Warningf("Found existing volume %s, aborting clone operation.", name)
 		return errors.New("volume with requested name already exists")
 	}
 
 	// Get the volume ID for the source volume
 	sourceVolume, err := d.GetVolume(sourceName)
 	if err != nil || sourceVolume.VolumeID == 0 {
-		log.Errorf("Unable to locate requested source volume: %v", err)
+		utils.Logc(ctx).Errorf("Unable to locate requested source volume: %v", err)
 		return errors.New("error performing clone operation, source volume not found")
 	}
 
@@ -939,7 +940,7 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 	if snapshotName != "" {
 		s, err := d.Client.GetSnapshot(0, sourceVolume.VolumeID, snapshotName)
 		if err != nil || s.SnapshotID == 0 {
-			log.Errorf("Unable to locate requested source snapshot: %v", err)
+			utils.Logc(ctx).Errorf("Unable to locate requested source snapshot: %v", err)
 			return errors.New("error performing clone operation, source snapshot not found")
 		}
 		req.SnapshotID = s.SnapshotID
@@ -952,7 +953,7 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 
 	cloneVolume, err := d.Client.CloneVolume(&req)
 	if err != nil {
-		log.Errorf("Failed to create clone: %v", err)
+		utils.Logc(ctx).Errorf("Failed to create clone: %v", err)
 		return errors.New("error performing clone operation")
 	}
 
@@ -964,14 +965,14 @@ func (d *SANStorageDriver) CreateClone(volConfig *storage.VolumeConfig, storageP
 		modifyReq.Qos = qos
 		err = d.Client.ModifyVolume(&modifyReq)
 		if err != nil {
-			log.Errorf("Failed to update QoS on clone: %v", err)
+			utils.Logc(ctx).Errorf("Failed to update QoS on clone: %v", err)
 			return errors.New("error performing clone operation")
 		}
 	}
 	return nil
 }
 
-func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName string) error {
+func (d *SANStorageDriver) Import(ctx context.Context, volConfig *storage.VolumeConfig, originalName string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -980,8 +981,8 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 			"originalName": originalName,
 			"notManaged":   volConfig.ImportNotManaged,
 		}
-		log.WithFields(fields).Debug(">>>> Import")
-		defer log.WithFields(fields).Debug("<<<< Import")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Import")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Import")
 	}
 
 	volume, err := d.GetVolume(originalName)
@@ -1020,13 +1021,13 @@ func (d *SANStorageDriver) Import(volConfig *storage.VolumeConfig, originalName
 	return nil
 }
 
-func (d *SANStorageDriver) Rename(name string, newName string) error {
+func (d *SANStorageDriver) Rename(ctx context.Context, name string, newName string) error {
 	// Solidfire cannot rename volumes
 	return nil
 }
 
 // Destroy the requested docker volume
-func (d *SANStorageDriver) Destroy(name string) error {
+func (d *SANStorageDriver) Destroy(ctx context.Context, name string) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1034,17 +1035,17 @@ func (d *SANStorageDriver) Destroy(name string) error {
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Destroy")
-		defer log.WithFields(fields).Debug("<<<< Destroy")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Destroy")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Destroy")
 	}
 
 	v, err := d.GetVolume(name)
 	if err != nil && err.Error() != "volume not found" {
-		log.Errorf("Unable to locate volume for delete operation: %v", err)
+		utils.Logc(ctx).Errorf("Unable to locate volume for delete operation: %v", err)
 		return err
 	} else if err != nil {
 		// Volume wasn't found. No action needs to be taken.
-		log.Warnf("Volume %s doesn't exist.", name)
+		utils.Logc(ctx).Warnf("Volume %s doesn't exist.", name)
 		return nil
 	}
 
@@ -1056,13 +1057,13 @@ func (d *SANStorageDriver) Destroy(name string) error {
 		// Logout from the session
 		err = d.Client.DetachVolume(v)
 		if err != nil {
-			log.Warningf("Unable to detach volume, deleting anyway: %v", err)
+			utils.Logc(ctx).Warningf("Unable to detach volume, deleting anyway: %v", err)
 		}
 	}
 
 	err = d.Client.DeleteVolume(v.VolumeID)
 	if err != nil {
-		log.Errorf("Error during delete operation: %v", err)
+		utils.Logc(ctx).Errorf("Error during delete operation: %v", err)
 		return err
 	}
 
@@ -1072,7 +1073,7 @@ func (d *SANStorageDriver) Destroy(name string) error {
 // Publish the volume to the host specified in publishInfo.  This method may or may not be running on the host
 // where the volume will be mounted, so it should limit itself to updating access rules, initiator groups, etc.
 // that require some host identity (but not locality) as well as storage controller API access.
-func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
+func (d *SANStorageDriver) Publish(ctx context.Context, volConfig *storage.VolumeConfig, publishInfo *utils.VolumePublishInfo) error {
 
 	name := volConfig.InternalName
 
@@ -1082,13 +1083,13 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 			"Type":   "SANStorageDriver",
 			"name":   name,
 		}
-		log.WithFields(fields).Debug(">>>> Publish")
-		defer log.WithFields(fields).Debug("<<<< Publish")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Publish")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Publish")
 	}
 
 	v, err := d.GetVolume(name)
 	if err != nil {
-		log.Errorf("Unable to locate volume for mount operation: %+v", err)
+		utils.Logc(ctx).Errorf("Unable to locate volume for mount operation: %+v", err)
 		return errors.New("volume not found")
 	}
 
@@ -1104,7 +1105,7 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 	req.AccountID = v.AccountID
 	account, err := d.Client.GetAccountByID(&req)
 	if err != nil {
-		log.Errorf("Failed to get account %v: %+v ", v.AccountID, err)
+		utils.Logc(ctx).Errorf("Failed to get account %v: %+v ", v.AccountID, err)
 		return errors.New("volume attach failure")
 	}
 
@@ -1124,7 +1125,7 @@ func (d *SANStorageDriver) Publish(volConfig *storage.VolumeConfig, publishInfo
 
 // GetSnapshot gets a snapshot.  To distinguish between an API error reading the snapshot
 // and a non-existent snapshot, this method may return (nil, nil).
-func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1136,13 +1137,13 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshot")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshot")
 	}
 
 	volume, err := d.GetVolume(internalVolName)
 	if err != nil {
-		log.Errorf("Unable to locate snapshot parent volume: %+v", err)
+		utils.Logc(ctx).Errorf("Unable to locate snapshot parent volume: %+v", err)
 		return nil, errors.New("volume not found")
 	}
 
@@ -1151,16 +1152,16 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 
 	snapList, err := d.Client.ListSnapshots(&req)
 	if err != nil {
-		log.Errorf("Unable to locate snapshot: %+v", err)
+		utils.Logc(ctx).Errorf("Unable to locate snapshot: %+v", err)
 		return nil, errors.New("snapshot not found")
 	}
 
-	log.Debugf("Returned %d snapshots", len(snapList))
+	utils.Logc(ctx).Debugf("Returned %d snapshots", len(snapList))
 
 	for _, snap := range snapList {
 		if snap.Name == internalSnapName {
 
-			log.WithFields(log.Fields{
+			utils.Logc(ctx).WithFields(log.Fields{
 				"snapshotName": internalSnapName,
 				"volumeName":   internalVolName,
 				"created":      snap.CreateTime,
@@ -1174,7 +1175,7 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 		}
 	}
 
-	log.WithFields(log.Fields{
+	utils.Logc(ctx).WithFields(log.Fields{
 		"snapshotName": internalSnapName,
 		"volumeName":   internalVolName,
 	}).Warning("Snapshot not found.")
@@ -1183,7 +1184,7 @@ func (d *SANStorageDriver) GetSnapshot(snapConfig *storage.SnapshotConfig) (*sto
 }
 
 // SnapshotList returns the list of snapshots associated with the specified volume
-func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
+func (d *SANStorageDriver) GetSnapshots(ctx context.Context, volConfig *storage.VolumeConfig) ([]*storage.Snapshot, error) {
 
 	internalVolName := volConfig.InternalName
 
@@ -1193,13 +1194,13 @@ func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 			"Type":       "SANStorageDriver",
 			"volumeName": internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> GetSnapshots")
-		defer log.WithFields(fields).Debug("<<<< GetSnapshots")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> GetSnapshots")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< GetSnapshots")
 	}
 
 	volume, err := d.GetVolume(internalVolName)
 	if err != nil {
-		log.Errorf("Unable to locate parent volume in snapshot list: %+v", err)
+		utils.Logc(ctx).Errorf("Unable to locate parent volume in snapshot list: %+v", err)
 		return nil, errors.New("volume not found")
 	}
 
@@ -1208,15 +1209,15 @@ func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 
 	snapList, err := d.Client.ListSnapshots(&req)
 	if err != nil {
-		log.Errorf("Unable to list snapshots: %+v", err)
+		utils.Logc(ctx).Errorf("Unable to list snapshots: %+v", err)
 		return nil, errors.New("no snapshots found")
 	}
 
-	log.Debugf("Returned %d snapshots", len(snapList))
+	utils.Logc(ctx).Debugf("Returned %d snapshots", len(snapList))
 	var snapshots []*storage.Snapshot
 
 	for _, snap := range snapList {
-		log.Debugf("Snapshot name: %s, date: %s", snap.Name, snap.CreateTime)
+		utils.Logc(ctx).Debugf("Snapshot name: %s, date: %s", snap.Name, snap.CreateTime)
 
 		snapshot := &storage.Snapshot{
 			Config: &storage.SnapshotConfig{
@@ -1237,7 +1238,7 @@ func (d *SANStorageDriver) GetSnapshots(volConfig *storage.VolumeConfig) ([]*sto
 }
 
 // CreateSnapshot creates a snapshot for the given volume
-func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
+func (d *SANStorageDriver) CreateSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) (*storage.Snapshot, error) {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1249,14 +1250,14 @@ func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateSnapshot")
-		defer log.WithFields(fields).Debug("<<<< CreateSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateSnapshot")
 	}
 
 	// Check to see if the volume exists
 	sourceVolume, err := d.GetVolume(internalVolName)
 	if err != nil {
-		log.Errorf("unable to locate parent volume: %+v", err)
+		utils.Logc(ctx).Errorf("unable to locate parent volume: %+v", err)
 		return nil, fmt.Errorf("volume %s does not exist", internalVolName)
 	}
 
@@ -1277,7 +1278,7 @@ func (d *SANStorageDriver) CreateSnapshot(snapConfig *storage.SnapshotConfig) (*
 }
 
 // RestoreSnapshot restores a volume (in place) from a snapshot.
-func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) RestoreSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1289,8 +1290,8 @@ func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) e
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> RestoreSnapshot")
-		defer log.WithFields(fields).Debug("<<<< RestoreSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> RestoreSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< RestoreSnapshot")
 	}
 
 	volume, err := d.GetVolume(internalVolName)
@@ -1321,7 +1322,7 @@ func (d *SANStorageDriver) RestoreSnapshot(snapConfig *storage.SnapshotConfig) e
 }
 
 // DeleteSnapshot deletes a snapshot of a volume.
-func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) error {
+func (d *SANStorageDriver) DeleteSnapshot(ctx context.Context, snapConfig *storage.SnapshotConfig) error {
 
 	internalSnapName := snapConfig.InternalName
 	internalVolName := snapConfig.VolumeInternalName
@@ -1333,8 +1334,8 @@ func (d *SANStorageDriver) DeleteSnapshot(snapConfig *storage.SnapshotConfig) er
 			"snapshotName": internalSnapName,
 			"volumeName":   internalVolName,
 		}
-		log.WithFields(fields).Debug(">>>> DeleteSnapshot")
-		defer log.WithFields(fields).Debug("<<<< DeleteSnapshot")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> DeleteSnapshot")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< DeleteSnapshot")
 	}
 
 	volume, err := d.GetVolume(internalVolName)
@@ -1516,7 +1517,7 @@ func (d *SANStorageDriver) CreatePrepare(volConfig *storage.VolumeConfig) {
 	volConfig.InternalName = d.GetInternalVolumeName(volConfig.Name)
 }
 
-func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error {
+func (d *SANStorageDriver) CreateFollowup(ctx context.Context, volConfig *storage.VolumeConfig) error {
 
 	if d.Config.DebugTraceFlags["method"] {
 		fields := log.Fields{
@@ -1525,12 +1526,12 @@ func (d *SANStorageDriver) CreateFollowup(volConfig *storage.VolumeConfig) error
 			"name":         volConfig.Name,
 			"internalName": volConfig.InternalName,
 		}
-		log.WithFields(fields).Debug(">>>> CreateFollowup")
-		defer log.WithFields(fields).Debug("<<<< CreateFollowup")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> CreateFollowup")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< CreateFollowup")
 	}
 
 	if d.Config.DriverContext == tridentconfig.ContextDocker {
-		log.Debug("No follow-up create actions for Docker.")
+		utils.Logc(ctx).Debug("No follow-up create actions for Docker.")
 		return nil
 	}
 
@@ -1634,6 +1635,14 @@ func (d *SANStorageDriver) GetProtocol() tridentconfig.Protocol {
 	return tridentconfig.Block
 }
 
+func (d *SANStorageDriver) GetSupportedAccessModes() drivers.AccessModes {
+	return drivers.BlockAccessModes
+}
+
+func (d *SANStorageDriver) GetVolumeExpansion() drivers.VolumeExpansion {
+	return drivers.BlockVolumeExpansion
+}
+
 func (d *SANStorageDriver) StoreConfig(
 	b *storage.PersistentStorageBackendConfig,
 ) {
@@ -1795,7 +1804,7 @@ func (d *SANStorageDriver) GetUpdateType(driverOrig storage.Driver) *roaring.Bit
 }
 
 // Resize expands the volume size.
-func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uint64) error {
+func (d *SANStorageDriver) Resize(ctx context.Context, volConfig *storage.VolumeConfig, sizeBytes uint64) error {
 
 	name := volConfig.InternalName
 	if d.Config.DebugTraceFlags["method"] {
@@ -1805,13 +1814,13 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 			"name":      name,
 			"sizeBytes": sizeBytes,
 		}
-		log.WithFields(fields).Debug(">>>> Resize")
-		defer log.WithFields(fields).Debug("<<<< Resize")
+		utils.Logc(ctx).WithFields(fields).Debug(">>>> Resize")
+		defer utils.Logc(ctx).WithFields(fields).Debug("<<<< Resize")
 	}
 
 	volume, err := d.GetVolume(name)
 	if err != nil {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume": name,
 			"error":  err,
 		}).Error("Error checking for existing volume.")
@@ -1829,7 +1838,7 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 	}
 
 	if sameSize {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"requestedSize":     sizeBytes,
 			"currentVolumeSize": volume.TotalSize,
 			"name":              name,
@@ -1840,7 +1849,8 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 
 	volSizeBytes := uint64(volume.TotalSize)
 	if sizeBytes < volSizeBytes {
-		return fmt.Errorf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes)
+		return drivers.NewUnsupportedCapacityRangeError(
+			fmt.Sprintf("requested size %d is less than existing volume size %d", sizeBytes, volSizeBytes), nil)
 	}
 
 	if _, _, checkVolumeSizeLimitsError := drivers.CheckVolumeSizeLimits(sizeBytes, d.Config.CommonStorageDriverConfig); checkVolumeSizeLimitsError != nil {
@@ -1859,7 +1869,7 @@ func (d *SANStorageDriver) Resize(volConfig *storage.VolumeConfig, sizeBytes uin
 	// Get volume's size after resize
 	volume, err = d.GetVolume(name)
 	if err != nil {
-		log.WithFields(log.Fields{
+		utils.Logc(ctx).WithFields(log.Fields{
 			"volume": name,
 			"error":  err,
 		}).Error("Error checking for volume after resize.")
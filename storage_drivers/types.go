@@ -3,6 +3,7 @@ package storagedrivers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 // CommonStorageDriverConfig holds settings in common across all StorageDrivers
 type CommonStorageDriverConfig struct {
 	Version           int                   `json:"version"`
+	SchemaVersion     int                   `json:"schemaVersion,omitempty"` // see ConfigSchemaVersion
 	StorageDriverName string                `json:"storageDriverName"`
 	BackendName       string                `json:"backendName"`
 	Debug             bool                  `json:"debug"`           // Unsupported!
@@ -24,6 +26,11 @@ type CommonStorageDriverConfig struct {
 	SerialNumbers     []string              `json:"serialNumbers,omitEmpty"`
 	DriverContext     trident.DriverContext `json:"-"`
 	LimitVolumeSize   string                `json:"limitVolumeSize"`
+	// Limits on concurrent operations against the backend, zero meaning unlimited
+	MaxConcurrentCreates int `json:"maxConcurrentCreates,omitempty"`
+	MaxConcurrentClones  int `json:"maxConcurrentClones,omitempty"`
+	// Fail on unknown attributes in the backend config instead of ignoring them
+	StrictConfig bool `json:"strictConfig,omitempty"`
 }
 
 type CommonStorageDriverConfigDefaults struct {
@@ -74,28 +81,87 @@ type EseriesStorageDriverConfigDefaults struct {
 // OntapStorageDriverConfig holds settings for OntapStorageDrivers
 type OntapStorageDriverConfig struct {
 	*CommonStorageDriverConfig                // embedded types replicate all fields
+	BackendUUID                      string   `json:"-"` // set once the backend has a UUID
 	ManagementLIF                    string   `json:"managementLIF"`
 	DataLIF                          string   `json:"dataLIF"`
 	IgroupName                       string   `json:"igroupName"`
 	SVM                              string   `json:"svm"`
+	SVMs                             []string `json:"svms"`                   // several SVMs in one backend
+	SVMSelector                      string   `json:"svmSelector"`            // regular expression matching SVM names
+	SecondaryManagementLIF           string   `json:"secondaryManagementLIF"` // SVM-DR partner to fail over to
+	SecondarySVM                     string   `json:"secondarySVM"`           // defaults to the svm name
 	Username                         string   `json:"username"`
 	Password                         string   `json:"password"`
 	Aggregate                        string   `json:"aggregate"`
+	ExcludeAggregates                []string `json:"excludeAggregates"`
+	AggregateSelector                string   `json:"aggregateSelector"`                // regular expression matching aggregate names
 	UsageHeartbeat                   string   `json:"usageHeartbeat"`                   // in hours, default to 24.0
 	QtreePruneFlexvolsPeriod         string   `json:"qtreePruneFlexvolsPeriod"`         // in seconds, default to 600
 	QtreeQuotaResizePeriod           string   `json:"qtreeQuotaResizePeriod"`           // in seconds, default to 60
 	EmptyFlexvolDeferredDeletePeriod string   `json:"emptyFlexvolDeferredDeletePeriod"` // in seconds, default to 28800
+	CloneSplitRetryPeriod            string   `json:"cloneSplitRetryPeriod"`            // in seconds, default to 300
+	CloneSplitConcurrency            string   `json:"cloneSplitConcurrency"`            // default to 4
+	DataLIFRefreshPeriod             string   `json:"dataLIFRefreshPeriod"`             // in seconds, default to 300
+	PoolCapacityRefreshPeriod        string   `json:"poolCapacityRefreshPeriod"`        // in seconds, default to 300
+	SpaceReclamationPeriod           string   `json:"spaceReclamationPeriod"`           // in seconds, disabled by default
+	EventWatchPeriod                 string   `json:"eventWatchPeriod"`                 // in seconds, disabled by default
+	SoftDeleteRetention              string   `json:"softDeleteRetention"`              // in seconds, disabled by default
+	OrphanPolicy                     string   `json:"orphanPolicy"`                     // report or delete, default to report
+	OrphanGracePeriod                string   `json:"orphanGracePeriod"`                // in seconds, default to 86400
 	NfsMountOptions                  string   `json:"nfsMountOptions"`
 	LimitAggregateUsage              string   `json:"limitAggregateUsage"`
 	AutoExportPolicy                 bool     `json:"autoExportPolicy"`
 	AutoExportCIDRs                  []string `json:"autoExportCIDRs"`
+	AutoExportPolicyScope            string   `json:"autoExportPolicyScope"` // backend, volume, or storageClass
+	DisableTelemetry                 bool     `json:"disableTelemetry"`
+	VolumeNameTemplate               string   `json:"volumeNameTemplate"`
+	VolumeCommentTemplate            string   `json:"volumeCommentTemplate"`
+	AutosizeMode                     string   `json:"autosizeMode"`              // ontap-san-economy only
+	AutosizeMaximumSize              string   `json:"autosizeMaximumSize"`       // ontap-san-economy only
+	AutosizeGrowThreshold            string   `json:"autosizeGrowThreshold"`     // in percent, ontap-san-economy only
+	LUNsPerFlexvol                   string   `json:"lunsPerFlexvol"`            // default to 100, ontap-san-economy only
+	DedicatedSnapshotFlexvols        bool     `json:"dedicatedSnapshotFlexvols"` // ontap-san-economy only
+	AllowVolumeShrink                bool     `json:"allowVolumeShrink"`         // ontap-nas only
+	FlexgroupExpandThreshold         string   `json:"flexgroupExpandThreshold"`  // in percent, ontap-nas-flexgroup only
+	RetainCloneSnapshots             bool     `json:"retainCloneSnapshots"`
+	QtreeFileLimit                   string   `json:"qtreeFileLimit"`         // files per volume, ontap-nas-economy only
+	QtreesPerFlexvol                 string   `json:"qtreesPerFlexvol"`       // default to 200, ontap-nas-economy only
+	QtreeFlexvolSizeRatio            string   `json:"qtreeFlexvolSizeRatio"`  // default to 1.0, ontap-nas-economy only
+	QtreeFlexvolNameScheme           string   `json:"qtreeFlexvolNameScheme"` // random or sequential, ontap-nas-economy only
 	OntapStorageDriverPool
-	Storage                   []OntapStorageDriverPool `json:"storage"`
-	UseCHAP                   bool                     `json:"useCHAP"`
-	ChapUsername              string                   `json:"chapUsername"`
-	ChapInitiatorSecret       string                   `json:"chapInitiatorSecret"`
-	ChapTargetUsername        string                   `json:"chapTargetUsername"`
-	ChapTargetInitiatorSecret string                   `json:"chapTargetInitiatorSecret"`
+	Storage                   []OntapStorageDriverPool    `json:"storage"`
+	AggregateMedia            map[string]string           `json:"aggregateMedia"` // aggregate name to hdd, hybrid, or ssd
+	RetryBudgets              map[string]string           `json:"retryBudgets"`   // operation name to seconds
+	ExportRule                OntapExportRuleConfig       `json:"exportRule"`
+	Kerberos                  string                      `json:"kerberos"` // krb5, krb5i, or krb5p; ontap-nas* only
+	UseCHAP                   bool                        `json:"useCHAP"`
+	ChapUsername              string                      `json:"chapUsername"`
+	ChapInitiatorSecret       string                      `json:"chapInitiatorSecret"`
+	ChapTargetUsername        string                      `json:"chapTargetUsername"`
+	ChapTargetInitiatorSecret string                      `json:"chapTargetInitiatorSecret"`
+	SnapshotPolicies          []OntapSnapshotPolicyConfig `json:"snapshotPolicies"` // policies Trident creates and keeps in line
+}
+
+// OntapExportRuleConfig controls the rules written to automatic export policies
+type OntapExportRuleConfig struct {
+	AnonUID       string   `json:"anonUID"`       // UID for anonymous users, default to the ONTAP default
+	IndexBase     string   `json:"indexBase"`     // lowest rule index managed by Trident, default to 1
+	ReadOnlyHosts []string `json:"readOnlyHosts"` // client matches granted read-only access
+}
+
+// OntapSnapshotPolicyConfig describes a snapshot policy that Trident creates on the SVM and corrects if it drifts
+type OntapSnapshotPolicyConfig struct {
+	Name      string                        `json:"name"`
+	Enabled   *bool                         `json:"enabled"` // default to true
+	Comment   string                        `json:"comment"`
+	Schedules []OntapSnapshotScheduleConfig `json:"schedules"`
+}
+
+// OntapSnapshotScheduleConfig is one of a snapshot policy's schedules
+type OntapSnapshotScheduleConfig struct {
+	Schedule string `json:"schedule"` // an existing ONTAP job schedule, such as hourly
+	Count    int    `json:"count"`    // number of snapshots kept
+	Prefix   string `json:"prefix"`   // snapshot name prefix, default to the schedule name; set only on creation
 }
 
 type OntapStorageDriverPool struct {
@@ -106,6 +172,7 @@ type OntapStorageDriverPool struct {
 }
 
 type OntapStorageDriverConfigDefaults struct {
+	LimitVolumeSize string `json:"limitVolumeSize"` // virtual pools only, overrides the backend limit
 	SpaceAllocation string `json:"spaceAllocation"`
 	SpaceReserve    string `json:"spaceReserve"`
 	SnapshotPolicy  string `json:"snapshotPolicy"`
@@ -118,6 +185,14 @@ type OntapStorageDriverConfigDefaults struct {
 	FileSystemType  string `json:"fileSystemType"`
 	Encryption      string `json:"encryption"`
 	TieringPolicy   string `json:"tieringPolicy"`
+	// Days data must be cold before the tiering policy moves it to the cloud tier
+	TieringMinimumCoolingDays string `json:"tieringMinimumCoolingDays"`
+	// Origin volume, as [svm:]volume, of which to create FlexCache volumes (ontap-nas only)
+	FlexcacheOrigin string `json:"flexcacheOrigin"`
+	// Largest ratio of thin provisioned capacity to aggregate size (not ontap-nas-flexgroup)
+	MaxOverprovisionRatio string `json:"maxOverprovisionRatio"`
+	// NFS mount options of the volumes in a virtual pool, overriding the backend's (NAS drivers only)
+	NfsMountOptions string `json:"nfsMountOptions"`
 	CommonStorageDriverConfigDefaults
 }
 
@@ -257,6 +332,8 @@ type FakeStorageDriverConfig struct {
 	// Volumes are the modeled backend volumes that exist when the driver starts.  Optional.
 	Volumes      []fake.Volume `json:"volumes"`
 	InstanceName string        `json:"instanceName"`
+	// OfflineExpansion models a storage system that grows volumes only while they aren't published.  Optional.
+	OfflineExpansion bool `json:"offlineExpansion,omitempty"`
 	FakeStorageDriverPool
 	Storage []FakeStorageDriverPool `json:"storage"`
 }
@@ -272,6 +349,88 @@ type FakeStorageDriverConfigDefaults struct {
 	CommonStorageDriverConfigDefaults
 }
 
+// FakeOntapStorageDriverConfig holds settings for the fake-ontap driver, which models an ONTAP SVM in memory
+type FakeOntapStorageDriverConfig struct {
+	*CommonStorageDriverConfig
+	SVM      string            `json:"svm"`
+	Protocol trident.Protocol  `json:"protocol"`
+	DataLIF  string            `json:"dataLIF"`
+	Labels   map[string]string `json:"labels"`
+	// Aggregates are the modeled aggregates, each reported as a physical pool.  At least one is required.
+	Aggregates                           []FakeOntapAggregate `json:"aggregates"`
+	FakeOntapStorageDriverConfigDefaults `json:"defaults"`
+}
+
+type FakeOntapAggregate struct {
+	Name      string `json:"name"`
+	SizeBytes uint64 `json:"sizeBytes"`
+	Media     string `json:"media"`
+}
+
+type FakeOntapStorageDriverConfigDefaults struct {
+	SnapshotPolicy string `json:"snapshotPolicy"`
+	ExportPolicy   string `json:"exportPolicy"`
+	CommonStorageDriverConfigDefaults
+}
+
+// AccessModes lists the access modes a driver's volumes support in each volume mode.  A volume mode
+// missing from the map is not supported at all.
+type AccessModes map[trident.VolumeMode][]trident.AccessMode
+
+var (
+	// FileAccessModes are supported by drivers of shared filesystems, such as NFS, which may be written
+	// from many nodes at once.
+	FileAccessModes = AccessModes{
+		trident.Filesystem: {trident.ReadWriteOnce, trident.ReadOnlyMany, trident.ReadWriteMany},
+	}
+	// BlockAccessModes are supported by drivers of block devices, such as iSCSI LUNs.  A filesystem on a
+	// block device may be written from only one node, but a raw device may be shared by applications
+	// that coordinate their own writes.
+	BlockAccessModes = AccessModes{
+		trident.Filesystem: {trident.ReadWriteOnce, trident.ReadOnlyMany},
+		trident.RawBlock:   {trident.ReadWriteOnce, trident.ReadOnlyMany, trident.ReadWriteMany},
+	}
+)
+
+// Supports reports whether a volume with the given volume mode and access mode may be provisioned.  An
+// empty volume mode is Filesystem, and an empty access mode is supported by any volume mode listed.
+func (m AccessModes) Supports(volumeMode trident.VolumeMode, accessMode trident.AccessMode) bool {
+	if volumeMode == "" {
+		volumeMode = trident.Filesystem
+	}
+	accessModes, ok := m[volumeMode]
+	if !ok {
+		return false
+	}
+	if accessMode == trident.ModeAny {
+		return true
+	}
+	for _, supported := range accessModes {
+		if supported == accessMode {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumeExpansion describes how a driver's volumes are grown.
+type VolumeExpansion struct {
+	// Offline is true if a volume may be grown only while it isn't published to any node.
+	Offline bool `json:"offline"`
+	// NodeExpansion is true if each node using a volume must rescan its device and grow its filesystem
+	// once the storage system has grown the volume.
+	NodeExpansion bool `json:"nodeExpansion"`
+}
+
+var (
+	// FileVolumeExpansion is supported by drivers of shared filesystems, such as NFS, whose volumes may
+	// be grown while mounted and need nothing done on the nodes.
+	FileVolumeExpansion = VolumeExpansion{}
+	// BlockVolumeExpansion is supported by drivers of block devices, such as iSCSI LUNs, whose volumes
+	// may be grown while attached but must then be rescanned, and their filesystems grown, on the nodes.
+	BlockVolumeExpansion = VolumeExpansion{NodeExpansion: true}
+)
+
 type BackendIneligibleError struct {
 	message                 string
 	ineligiblePhysicalPools []string
@@ -330,6 +489,26 @@ func IsVolumeExistsError(err error) bool {
 	return ok
 }
 
+type VolumeSizeLimitError struct {
+	message string
+}
+
+func (e *VolumeSizeLimitError) Error() string { return e.message }
+
+func NewVolumeSizeLimitError(requestedSize, sizeLimit uint64) error {
+	return &VolumeSizeLimitError{
+		message: fmt.Sprintf("requested size: %d > the size limit: %d", requestedSize, sizeLimit),
+	}
+}
+
+func IsVolumeSizeLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*VolumeSizeLimitError)
+	return ok
+}
+
 type SnapshotsNotSupportedError struct {
 	message string
 }
@@ -341,3 +520,151 @@ func NewSnapshotsNotSupportedError(backendType string) error {
 		message: fmt.Sprintf("snapshots are not supported by backend type %s", backendType),
 	}
 }
+
+// The following errors classify storage failures independently of any one storage API, so that the
+// orchestrator and frontends may react to them (e.g. by choosing a gRPC status code) without inspecting
+// error messages.  Each wraps the underlying API error, if any, which remains available via errors.As.
+
+type ResourceNotFoundError struct {
+	message string
+	err     error
+}
+
+func (e *ResourceNotFoundError) Error() string { return e.message }
+func (e *ResourceNotFoundError) Unwrap() error { return e.err }
+
+func NewResourceNotFoundError(message string, err error) error {
+	return &ResourceNotFoundError{message: message, err: err}
+}
+
+func IsResourceNotFoundError(err error) bool {
+	var target *ResourceNotFoundError
+	return errors.As(err, &target)
+}
+
+type ResourceExistsError struct {
+	message string
+	err     error
+}
+
+func (e *ResourceExistsError) Error() string { return e.message }
+func (e *ResourceExistsError) Unwrap() error { return e.err }
+
+func NewResourceExistsError(message string, err error) error {
+	return &ResourceExistsError{message: message, err: err}
+}
+
+func IsResourceExistsError(err error) bool {
+	var target *ResourceExistsError
+	return errors.As(err, &target)
+}
+
+type ResourceExhaustedError struct {
+	message string
+	err     error
+}
+
+func (e *ResourceExhaustedError) Error() string { return e.message }
+func (e *ResourceExhaustedError) Unwrap() error { return e.err }
+
+func NewResourceExhaustedError(message string, err error) error {
+	return &ResourceExhaustedError{message: message, err: err}
+}
+
+func IsResourceExhaustedError(err error) bool {
+	var target *ResourceExhaustedError
+	return errors.As(err, &target)
+}
+
+type UnauthorizedError struct {
+	message string
+	err     error
+}
+
+func (e *UnauthorizedError) Error() string { return e.message }
+func (e *UnauthorizedError) Unwrap() error { return e.err }
+
+func NewUnauthorizedError(message string, err error) error {
+	return &UnauthorizedError{message: message, err: err}
+}
+
+func IsUnauthorizedError(err error) bool {
+	var target *UnauthorizedError
+	return errors.As(err, &target)
+}
+
+// DeletionDeferredError indicates that a resource could not be deleted yet, but that the driver will
+// go on trying to delete it in the background, so the caller need not retry.
+type DeletionDeferredError struct {
+	message string
+	err     error
+}
+
+func (e *DeletionDeferredError) Error() string { return e.message }
+func (e *DeletionDeferredError) Unwrap() error { return e.err }
+
+func NewDeletionDeferredError(message string, err error) error {
+	return &DeletionDeferredError{message: message, err: err}
+}
+
+func IsDeletionDeferredError(err error) bool {
+	var target *DeletionDeferredError
+	return errors.As(err, &target)
+}
+
+// RetriableError indicates a transient failure, such as a busy resource or a timeout, after which the
+// same operation may succeed if attempted again.
+type RetriableError struct {
+	message string
+	err     error
+}
+
+func (e *RetriableError) Error() string { return e.message }
+func (e *RetriableError) Unwrap() error { return e.err }
+
+func NewRetriableError(message string, err error) error {
+	return &RetriableError{message: message, err: err}
+}
+
+func IsRetriableError(err error) bool {
+	var target *RetriableError
+	return errors.As(err, &target)
+}
+
+// UnsupportedCapacityRangeError indicates that a volume cannot be given the requested capacity, such as
+// when a resize would shrink a volume that the driver may not shrink.
+type UnsupportedCapacityRangeError struct {
+	message string
+	err     error
+}
+
+func (e *UnsupportedCapacityRangeError) Error() string { return e.message }
+func (e *UnsupportedCapacityRangeError) Unwrap() error { return e.err }
+
+func NewUnsupportedCapacityRangeError(message string, err error) error {
+	return &UnsupportedCapacityRangeError{message: message, err: err}
+}
+
+func IsUnsupportedCapacityRangeError(err error) bool {
+	var target *UnsupportedCapacityRangeError
+	return errors.As(err, &target)
+}
+
+// PoolOverprovisionedError indicates that a volume would take a storage pool past the limit on how far
+// its storage may be overprovisioned.  Other pools of the same backend may still have room for it.
+type PoolOverprovisionedError struct {
+	message string
+	err     error
+}
+
+func (e *PoolOverprovisionedError) Error() string { return e.message }
+func (e *PoolOverprovisionedError) Unwrap() error { return e.err }
+
+func NewPoolOverprovisionedError(message string, err error) error {
+	return &PoolOverprovisionedError{message: message, err: err}
+}
+
+func IsPoolOverprovisionedError(err error) bool {
+	var target *PoolOverprovisionedError
+	return errors.As(err, &target)
+}
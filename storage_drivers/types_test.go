@@ -0,0 +1,37 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package storagedrivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netapp/trident/config"
+)
+
+func TestAccessModesSupports(t *testing.T) {
+
+	tests := []struct {
+		accessModes AccessModes
+		volumeMode  config.VolumeMode
+		accessMode  config.AccessMode
+		supported   bool
+	}{
+		{FileAccessModes, config.Filesystem, config.ReadWriteMany, true},
+		{FileAccessModes, "", config.ReadWriteOnce, true},
+		{FileAccessModes, config.RawBlock, config.ReadWriteOnce, false},
+		{FileAccessModes, config.RawBlock, config.ModeAny, false},
+		{BlockAccessModes, config.Filesystem, config.ReadWriteOnce, true},
+		{BlockAccessModes, config.Filesystem, config.ReadOnlyMany, true},
+		{BlockAccessModes, config.Filesystem, config.ReadWriteMany, false},
+		{BlockAccessModes, "", config.ReadWriteMany, false},
+		{BlockAccessModes, config.RawBlock, config.ReadWriteMany, true},
+		{BlockAccessModes, config.Filesystem, config.ModeAny, true},
+		{AccessModes{}, config.Filesystem, config.ModeAny, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.supported, test.accessModes.Supports(test.volumeMode, test.accessMode),
+			"volume mode %s, access mode %s", test.volumeMode, test.accessMode)
+	}
+}
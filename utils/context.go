@@ -0,0 +1,121 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type contextKey string
+
+const (
+	ContextKeyRequestID     contextKey = "requestID"
+	ContextKeyRequestSource contextKey = "requestSource"
+
+	ContextSourceCSI      = "CSI"
+	ContextSourceREST     = "REST"
+	ContextSourceDocker   = "Docker"
+	ContextSourceInternal = "Internal"
+)
+
+// GenerateRequestContext returns a context carrying a request ID and the source of the request, so that
+// every log line written on behalf of the request may be correlated.  If requestID is empty, a new ID is
+// generated.  If ctx already carries a request ID, it is returned unchanged.
+func GenerateRequestContext(ctx context.Context, requestID, requestSource string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if GetRequestID(ctx) != "" {
+		return ctx
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	ctx = context.WithValue(ctx, ContextKeyRequestID, requestID)
+	return context.WithValue(ctx, ContextKeyRequestSource, requestSource)
+}
+
+// GetRequestID returns the request ID carried by a context, or an empty string if there is none.
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestID, ok := ctx.Value(ContextKeyRequestID).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// GetRequestSource returns the source of the request carried by a context, or an empty string if there is none.
+func GetRequestSource(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if requestSource, ok := ctx.Value(ContextKeyRequestSource).(string); ok {
+		return requestSource
+	}
+	return ""
+}
+
+// DetachedContext returns a context that is never canceled and has no deadline, but that carries the
+// request ID and source, and any log fields, of ctx.  Work that must finish even if the request that
+// started it is abandoned, such as cleaning up after a failed operation, may use it and still be logged
+// as part of the request.
+func DetachedContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	if ctx == nil {
+		return detached
+	}
+	if requestID, ok := ctx.Value(ContextKeyRequestID).(string); ok {
+		detached = context.WithValue(detached, ContextKeyRequestID, requestID)
+	}
+	if requestSource, ok := ctx.Value(ContextKeyRequestSource).(string); ok {
+		detached = context.WithValue(detached, ContextKeyRequestSource, requestSource)
+	}
+	if fields := GetLogFields(ctx); len(fields) > 0 {
+		detached = WithLogFields(detached, fields)
+	}
+	return detached
+}
+
+// Logc returns a log entry annotated with the request ID and source, and any other log fields, carried by a
+// context.
+func Logc(ctx context.Context) *log.Entry {
+	return annotateEntry(log.NewEntry(log.StandardLogger()), ctx)
+}
+
+// LogcAtLevel returns a log entry like Logc, but from a logger that writes wherever and however the standard
+// logger does while logging at its own level, so that one component's messages may be more or less verbose
+// than the rest.
+func LogcAtLevel(ctx context.Context, level log.Level) *log.Entry {
+	std := log.StandardLogger()
+	logger := &log.Logger{
+		Out:          std.Out,
+		Hooks:        std.Hooks,
+		Formatter:    std.Formatter,
+		ReportCaller: std.ReportCaller,
+		Level:        level,
+		ExitFunc:     std.ExitFunc,
+	}
+	return annotateEntry(log.NewEntry(logger), ctx)
+}
+
+// annotateEntry adds the request ID and source, and any other log fields, carried by a context to a log entry.
+func annotateEntry(entry *log.Entry, ctx context.Context) *log.Entry {
+	if ctx == nil {
+		return entry
+	}
+	if fields := GetLogFields(ctx); len(fields) > 0 {
+		entry = entry.WithFields(fields)
+	}
+	if requestID, ok := ctx.Value(ContextKeyRequestID).(string); ok {
+		entry = entry.WithField(string(ContextKeyRequestID), requestID)
+	}
+	if requestSource, ok := ctx.Value(ContextKeyRequestSource).(string); ok {
+		entry = entry.WithField(string(ContextKeyRequestSource), requestSource)
+	}
+	return entry
+}
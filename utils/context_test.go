@@ -0,0 +1,71 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRequestContext(t *testing.T) {
+
+	ctx := GenerateRequestContext(nil, "", ContextSourceCSI)
+	requestID := GetRequestID(ctx)
+	assert.NotEmpty(t, requestID, "expected a generated request ID")
+	assert.Equal(t, ContextSourceCSI, ctx.Value(ContextKeyRequestSource))
+
+	// An existing request ID must be preserved
+	ctx = GenerateRequestContext(ctx, "other", ContextSourceREST)
+	assert.Equal(t, requestID, GetRequestID(ctx))
+	assert.Equal(t, ContextSourceCSI, ctx.Value(ContextKeyRequestSource))
+
+	ctx = GenerateRequestContext(context.Background(), "12345", ContextSourceDocker)
+	assert.Equal(t, "12345", GetRequestID(ctx))
+	assert.Equal(t, ContextSourceDocker, GetRequestSource(ctx))
+	assert.Empty(t, GetRequestSource(nil))
+}
+
+func TestDetachedContext(t *testing.T) {
+
+	ctx := GenerateRequestContext(context.Background(), "12345", ContextSourceCSI)
+	ctx = WithLogFields(ctx, log.Fields{"volume": "pvc-1"})
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	detached := DetachedContext(ctx)
+	assert.NoError(t, detached.Err())
+	assert.Nil(t, detached.Done())
+	assert.Equal(t, "12345", GetRequestID(detached))
+	assert.Equal(t, ContextSourceCSI, GetRequestSource(detached))
+	assert.Equal(t, "pvc-1", Logc(detached).Data["volume"])
+
+	assert.NoError(t, DetachedContext(nil).Err())
+}
+
+func TestLogc(t *testing.T) {
+
+	entry := Logc(context.Background())
+	assert.Empty(t, entry.Data)
+
+	ctx := GenerateRequestContext(context.Background(), "12345", ContextSourceInternal)
+	entry = Logc(ctx)
+	assert.Equal(t, "12345", entry.Data["requestID"])
+	assert.Equal(t, ContextSourceInternal, entry.Data["requestSource"])
+
+	assert.Empty(t, GetRequestID(nil))
+}
+
+func TestLogcAtLevel(t *testing.T) {
+
+	ctx := GenerateRequestContext(context.Background(), "12345", ContextSourceInternal)
+	entry := LogcAtLevel(ctx, log.TraceLevel)
+	assert.Equal(t, "12345", entry.Data["requestID"])
+	assert.True(t, entry.Logger.IsLevelEnabled(log.TraceLevel))
+	assert.Equal(t, log.StandardLogger().Out, entry.Logger.Out)
+
+	entry = LogcAtLevel(nil, log.ErrorLevel)
+	assert.False(t, entry.Logger.IsLevelEnabled(log.WarnLevel))
+}
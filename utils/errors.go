@@ -279,3 +279,135 @@ func IsUnsupportedConfigError(err error) bool {
 	_, ok := err.(*unsupportedConfigError)
 	return ok
 }
+
+/////////////////////////////////////////////////////////////////////////////
+// iscsiDeviceMismatchError
+/////////////////////////////////////////////////////////////////////////////
+
+type iscsiDeviceMismatchError struct {
+	message string
+}
+
+func (e *iscsiDeviceMismatchError) Error() string { return e.message }
+
+func ISCSIDeviceMismatchError(message string) error {
+	return &iscsiDeviceMismatchError{message}
+}
+
+func IsISCSIDeviceMismatchError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*iscsiDeviceMismatchError)
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// iscsiPathsDegradedError
+/////////////////////////////////////////////////////////////////////////////
+
+type iscsiPathsDegradedError struct {
+	message string
+}
+
+func (e *iscsiPathsDegradedError) Error() string { return e.message }
+
+func ISCSIPathsDegradedError(message string) error {
+	return &iscsiPathsDegradedError{message}
+}
+
+func IsISCSIPathsDegradedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*iscsiPathsDegradedError)
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// quotaExceededError
+/////////////////////////////////////////////////////////////////////////////
+
+type quotaExceededError struct {
+	message string
+}
+
+func (e *quotaExceededError) Error() string { return e.message }
+
+func QuotaExceededError(message string) error {
+	return &quotaExceededError{message}
+}
+
+func IsQuotaExceededError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*quotaExceededError)
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// unsupportedAccessModeError
+/////////////////////////////////////////////////////////////////////////////
+
+type unsupportedAccessModeError struct {
+	message string
+}
+
+func (e *unsupportedAccessModeError) Error() string { return e.message }
+
+func UnsupportedAccessModeError(message string) error {
+	return &unsupportedAccessModeError{message}
+}
+
+func IsUnsupportedAccessModeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*unsupportedAccessModeError)
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// volumePublishedError
+/////////////////////////////////////////////////////////////////////////////
+
+type volumePublishedError struct {
+	message string
+}
+
+func (e *volumePublishedError) Error() string { return e.message }
+
+func VolumePublishedError(message string) error {
+	return &volumePublishedError{message}
+}
+
+func IsVolumePublishedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*volumePublishedError)
+	return ok
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// cloneNotPermittedError
+/////////////////////////////////////////////////////////////////////////////
+
+type cloneNotPermittedError struct {
+	message string
+}
+
+func (e *cloneNotPermittedError) Error() string { return e.message }
+
+func CloneNotPermittedError(message string) error {
+	return &cloneNotPermittedError{message}
+}
+
+func IsCloneNotPermittedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*cloneNotPermittedError)
+	return ok
+}
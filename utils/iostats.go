@@ -0,0 +1,151 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	procDiskStats   = "/proc/diskstats"
+	procMountStats  = "/proc/self/mountstats"
+	diskSectorBytes = 512
+)
+
+// VolumeIOStats are the cumulative I/O counters the kernel keeps for a volume's block device or NFS
+// mount, from which rates such as IOPS, throughput, and latency may be derived.
+type VolumeIOStats struct {
+	ReadOps     uint64 `json:"readOps"`
+	WriteOps    uint64 `json:"writeOps"`
+	ReadBytes   uint64 `json:"readBytes"`
+	WriteBytes  uint64 `json:"writeBytes"`
+	ReadTimeMs  uint64 `json:"readTimeMs"`  // Time spent completing reads
+	WriteTimeMs uint64 `json:"writeTimeMs"` // Time spent completing writes
+}
+
+// GetBlockDeviceIOStats returns the I/O counters of a block device, such as an iSCSI LUN or the multipath
+// device atop it, as listed in /proc/diskstats.
+func GetBlockDeviceIOStats(devicePath string) (*VolumeIOStats, error) {
+
+	resolvedPath, err := filepath.EvalSymlinks(devicePath)
+	if err != nil {
+		return nil, err
+	}
+
+	diskStats, err := os.Open(procDiskStats)
+	if err != nil {
+		return nil, err
+	}
+	defer diskStats.Close()
+
+	return parseDiskStats(diskStats, filepath.Base(resolvedPath))
+}
+
+// GetNFSMountIOStats returns the I/O counters of the NFS mount at a mount point, as listed in
+// /proc/self/mountstats and reported by nfsiostat.
+func GetNFSMountIOStats(mountpoint string) (*VolumeIOStats, error) {
+
+	mountStats, err := os.Open(procMountStats)
+	if err != nil {
+		return nil, err
+	}
+	defer mountStats.Close()
+
+	return parseNFSMountStats(mountStats, filepath.Clean(mountpoint))
+}
+
+// parseDiskStats finds a device in the contents of /proc/diskstats.  Each line holds a device's major
+// and minor numbers and name, followed by its reads, merged reads, sectors read, and milliseconds spent
+// reading, then the same four counters for writes.
+func parseDiskStats(diskStats io.Reader, deviceName string) (*VolumeIOStats, error) {
+
+	scanner := bufio.NewScanner(diskStats)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 11 || fields[2] != deviceName {
+			continue
+		}
+		counters, err := parseCounters(fields[3:11])
+		if err != nil {
+			return nil, fmt.Errorf("invalid diskstats for device %s; %v", deviceName, err)
+		}
+		return &VolumeIOStats{
+			ReadOps:     counters[0],
+			ReadBytes:   counters[2] * diskSectorBytes,
+			ReadTimeMs:  counters[3],
+			WriteOps:    counters[4],
+			WriteBytes:  counters[6] * diskSectorBytes,
+			WriteTimeMs: counters[7],
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, NotFoundError(fmt.Sprintf("device %s not found in diskstats", deviceName))
+}
+
+// parseNFSMountStats finds an NFS mount in the contents of /proc/self/mountstats.  Each mount begins with
+// a "device <export> mounted on <mountpoint> with fstype nfs..." line, and its per-op statistics include
+// READ and WRITE lines holding the operations, transmissions, timeouts, bytes sent and received, and
+// the milliseconds spent queued, on the wire, and executing in all.
+func parseNFSMountStats(mountStats io.Reader, mountpoint string) (*VolumeIOStats, error) {
+
+	var stats *VolumeIOStats
+
+	scanner := bufio.NewScanner(mountStats)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "device" {
+			if stats != nil {
+				break
+			}
+			if len(fields) >= 8 && fields[3] == "on" && fields[4] == mountpoint &&
+				strings.HasPrefix(fields[7], "nfs") {
+				stats = &VolumeIOStats{}
+			}
+			continue
+		}
+		if stats == nil || (fields[0] != "READ:" && fields[0] != "WRITE:") || len(fields) < 9 {
+			continue
+		}
+
+		counters, err := parseCounters(fields[1:9])
+		if err != nil {
+			return nil, fmt.Errorf("invalid mountstats for mount %s; %v", mountpoint, err)
+		}
+		if fields[0] == "READ:" {
+			stats.ReadOps, stats.ReadBytes, stats.ReadTimeMs = counters[0], counters[4], counters[7]
+		} else {
+			stats.WriteOps, stats.WriteBytes, stats.WriteTimeMs = counters[0], counters[3], counters[7]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, NotFoundError(fmt.Sprintf("NFS mount %s not found in mountstats", mountpoint))
+	}
+	return stats, nil
+}
+
+func parseCounters(fields []string) ([]uint64, error) {
+	counters := make([]uint64, len(fields))
+	for i, field := range fields {
+		counter, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		counters[i] = counter
+	}
+	return counters, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDiskStats = `   8       0 sda 5013 1325 366042 3250 3627 2870 128528 4410 0 5060 7270 0 0 0 0
+   8      16 sdb 220 0 12800 140 75 10 4096 300 0 410 440 0 0 0 0
+ 253       0 dm-0 210 0 12600 150 70 0 4000 310 0 420 460 0 0 0 0
+`
+
+const testMountStats = `device rootfs mounted on / with fstype rootfs
+device 10.0.0.1:/trident_pvc_a mounted on /var/lib/kubelet/pods/a/volumes/kubernetes.io~csi/pvc-a/mount with fstype nfs4 statvers=1.1
+	opts:	rw,vers=4.1,rsize=65536,wsize=65536
+	per-op statistics
+	        NULL: 1 1 0 44 24 0 0 0
+	        READ: 120 120 0 17280 491520 10 250 270
+	       WRITE: 80 80 0 337920 10880 5 300 320
+device 10.0.0.1:/trident_pvc_b mounted on /var/lib/kubelet/pods/b/volumes/kubernetes.io~csi/pvc-b/mount with fstype nfs statvers=1.1
+	per-op statistics
+	        READ: 7 7 0 1008 28672 0 14 15
+	       WRITE: 0 0 0 0 0 0 0 0
+`
+
+func TestParseDiskStats(t *testing.T) {
+
+	stats, err := parseDiskStats(strings.NewReader(testDiskStats), "dm-0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &VolumeIOStats{
+			ReadOps:     210,
+			WriteOps:    70,
+			ReadBytes:   12600 * 512,
+			WriteBytes:  4000 * 512,
+			ReadTimeMs:  150,
+			WriteTimeMs: 310,
+		}, stats)
+	}
+
+	_, err = parseDiskStats(strings.NewReader(testDiskStats), "sdc")
+	assert.True(t, IsNotFoundError(err), "unknown device found")
+}
+
+func TestParseNFSMountStats(t *testing.T) {
+
+	stats, err := parseNFSMountStats(strings.NewReader(testMountStats),
+		"/var/lib/kubelet/pods/a/volumes/kubernetes.io~csi/pvc-a/mount")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &VolumeIOStats{
+			ReadOps:     120,
+			WriteOps:    80,
+			ReadBytes:   491520,
+			WriteBytes:  337920,
+			ReadTimeMs:  270,
+			WriteTimeMs: 320,
+		}, stats)
+	}
+
+	stats, err = parseNFSMountStats(strings.NewReader(testMountStats),
+		"/var/lib/kubelet/pods/b/volumes/kubernetes.io~csi/pvc-b/mount")
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(7), stats.ReadOps)
+		assert.Equal(t, uint64(0), stats.WriteOps)
+	}
+
+	_, err = parseNFSMountStats(strings.NewReader(testMountStats), "/")
+	assert.True(t, IsNotFoundError(err), "non-NFS mount found")
+}
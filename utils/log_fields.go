@@ -0,0 +1,109 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Names of the fields that correlate log messages written on behalf of the same operation, so that every
+// component logs them alike and tools ingesting the logs may filter and group by them.
+const (
+	LogFieldBackend   = "backend"
+	LogFieldSVM       = "svm"
+	LogFieldVolume    = "volume"
+	LogFieldOperation = "op"
+	LogFieldModule    = "module"
+
+	ContextKeyLogFields contextKey = "logFields"
+)
+
+var (
+	moduleLogLevels     = make(map[string]log.Level)
+	moduleLogLevelsLock sync.RWMutex
+)
+
+// WithLogFields returns a context carrying the given fields in addition to any already carried by ctx, so that
+// Logc adds them to every message logged with the returned context.  Fields given here replace those of the
+// same name already carried.
+func WithLogFields(ctx context.Context, fields log.Fields) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	merged := make(log.Fields)
+	for k, v := range GetLogFields(ctx) {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, ContextKeyLogFields, merged)
+}
+
+// GetLogFields returns the log fields carried by a context, or nil if there are none.  The returned map must
+// not be modified.
+func GetLogFields(ctx context.Context) log.Fields {
+	if ctx == nil {
+		return nil
+	}
+	if fields, ok := ctx.Value(ContextKeyLogFields).(log.Fields); ok {
+		return fields
+	}
+	return nil
+}
+
+// ParseModuleLogLevels parses a comma-separated list of module=level pairs, such as "ontap=debug,core=warn".
+func ParseModuleLogLevels(spec string) (map[string]log.Level, error) {
+	levels := make(map[string]log.Level)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid module log level '%s'; expected module=level", pair)
+		}
+		level, err := log.ParseLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level for module %s; %v", strings.TrimSpace(parts[0]), err)
+		}
+		levels[strings.TrimSpace(parts[0])] = level
+	}
+	return levels, nil
+}
+
+// SetModuleLogLevels replaces the log levels of individual modules.  Modules without a level of their own log
+// at the standard logger's level.
+func SetModuleLogLevels(levels map[string]log.Level) {
+	moduleLogLevelsLock.Lock()
+	defer moduleLogLevelsLock.Unlock()
+
+	moduleLogLevels = make(map[string]log.Level, len(levels))
+	for module, level := range levels {
+		moduleLogLevels[module] = level
+	}
+}
+
+// GetModuleLogLevel returns the log level of a module, and false if the module has no level of its own.
+func GetModuleLogLevel(module string) (log.Level, bool) {
+	moduleLogLevelsLock.RLock()
+	defer moduleLogLevelsLock.RUnlock()
+
+	level, ok := moduleLogLevels[module]
+	return level, ok
+}
+
+// LogcForModule returns a log entry like Logc, naming the module that logs it and logging at the module's own
+// level, if it has one.
+func LogcForModule(ctx context.Context, module string) *log.Entry {
+	if level, ok := GetModuleLogLevel(module); ok {
+		return LogcAtLevel(ctx, level).WithField(LogFieldModule, module)
+	}
+	return Logc(ctx).WithField(LogFieldModule, module)
+}
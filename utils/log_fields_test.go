@@ -0,0 +1,53 @@
+// Copyright 2020 NetApp, Inc. All Rights Reserved.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogFields(t *testing.T) {
+
+	ctx := GenerateRequestContext(context.Background(), "req1", ContextSourceREST)
+	ctx = WithLogFields(ctx, log.Fields{LogFieldBackend: "b1", LogFieldOperation: "create"})
+	child := WithLogFields(ctx, log.Fields{LogFieldVolume: "v1", LogFieldOperation: "clone"})
+
+	// The parent context's fields are unchanged
+	assert.Equal(t, log.Fields{LogFieldBackend: "b1", LogFieldOperation: "create"}, GetLogFields(ctx))
+
+	entry := Logc(child)
+	assert.Equal(t, "req1", entry.Data[string(ContextKeyRequestID)])
+	assert.Equal(t, "b1", entry.Data[LogFieldBackend])
+	assert.Equal(t, "v1", entry.Data[LogFieldVolume])
+	assert.Equal(t, "clone", entry.Data[LogFieldOperation])
+
+	assert.Nil(t, GetLogFields(nil))
+	assert.Nil(t, GetLogFields(context.Background()))
+}
+
+func TestModuleLogLevels(t *testing.T) {
+
+	levels, err := ParseModuleLogLevels("ontap=debug, core = warn,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]log.Level{"ontap": log.DebugLevel, "core": log.WarnLevel}, levels)
+
+	_, err = ParseModuleLogLevels("ontap")
+	assert.Error(t, err)
+	_, err = ParseModuleLogLevels("ontap=loud")
+	assert.Error(t, err)
+
+	SetModuleLogLevels(levels)
+	defer SetModuleLogLevels(nil)
+
+	entry := LogcForModule(context.Background(), "ontap")
+	assert.Equal(t, log.DebugLevel, entry.Logger.Level)
+	assert.Equal(t, "ontap", entry.Data[LogFieldModule])
+
+	entry = LogcForModule(context.Background(), "solidfire")
+	assert.Equal(t, log.StandardLogger(), entry.Logger)
+	assert.Equal(t, "solidfire", entry.Data[LogFieldModule])
+}
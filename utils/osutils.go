@@ -27,8 +27,16 @@ const (
 	iSCSIDeviceDiscoveryTimeoutSecs     = 90
 	multipathDeviceDiscoveryTimeoutSecs = 90
 	resourceDeletionTimeoutSecs         = 40
+	iSCSIPathVerificationTimeoutSecs    = 30
+	iSCSIResizeTimeoutSecs              = 30
+	fstrimTimeoutSecs                   = 600
 	fsRaw                               = "raw"
 	temporaryMountDir                   = "/tmp_mnt"
+	nfsTrunkMountDir                    = "trunk_mnt"
+
+	// Oldest kernels supporting the nconnect and max_connect NFS mount options
+	minKernelVersionNFSNconnect        = "5.3"
+	minKernelVersionNFSSessionTrunking = "5.15"
 )
 
 var xtermControlRegex = regexp.MustCompile(`\x1B\[[0-9;]*[a-zA-Z]`)
@@ -36,6 +44,15 @@ var pidRunningRegex = regexp.MustCompile(`pid \d+ running`)
 var pidRegex = regexp.MustCompile(`^\d+$`)
 var chrootPathPrefix string
 
+// The commands run, and the device sizes and filesystem types read, while a device is resized are found
+// through these, so that tests may stand in for the host.
+var (
+	resizeExecCommand = execCommandWithTimeout
+	resizeDiskSize    = getISCSIDiskSize
+	resizeFSType      = getFSType
+	resizeTimeout     = iSCSIResizeTimeoutSecs * time.Second
+)
+
 func IPv6Check(ip string) bool {
 	return strings.Count(ip, ":") >= 2
 }
@@ -56,16 +73,117 @@ func AttachNFSVolume(name, mountpoint string, publishInfo *VolumePublishInfo) er
 	defer log.Debug("<<<< osutils.AttachNFSVolume")
 
 	var exportPath = fmt.Sprintf("%s:%s", publishInfo.NfsServerIP, publishInfo.NfsPath)
-	var options = publishInfo.MountOptions
+
+	kernel, err := getKernelVersion()
+	if err != nil {
+		log.WithField("error", err).Warning("Could not determine kernel version.")
+	}
+	options, trunkIPs, err := nfsMountOptionsForKernel(publishInfo.MountOptions, publishInfo.NfsServerIPs, kernel)
+	if err != nil {
+		return err
+	}
 
 	log.WithFields(log.Fields{
 		"volume":     name,
 		"exportPath": exportPath,
 		"mountpoint": mountpoint,
 		"options":    options,
+		"trunkIPs":   trunkIPs,
 	}).Debug("Publishing NFS volume.")
 
-	return mountNFSPath(exportPath, mountpoint, options)
+	if err = mountNFSPath(exportPath, mountpoint, options); err != nil {
+		return err
+	}
+
+	addNFSSessionTrunks(publishInfo.NfsPath, mountpoint, options, trunkIPs)
+	return nil
+}
+
+// getKernelVersion returns the version of the running kernel.
+func getKernelVersion() (*Version, error) {
+
+	release, err := ioutil.ReadFile(chrootPathPrefix + "/proc/sys/kernel/osrelease")
+	if err != nil {
+		return nil, err
+	}
+	return ParseGeneric(strings.TrimSpace(string(release)))
+}
+
+// nfsMountOptionsForKernel checks a volume's NFS mount options against the features of the running
+// kernel, and returns the options to mount it with and the data LIFs to trunk its session to.  A
+// kernel too old for nconnect can't mount the volume as requested, while one too old for session
+// trunking mounts it over a single transport.  If the kernel version is unknown, nothing is changed.
+func nfsMountOptionsForKernel(options string, trunkIPs []string, kernel *Version) (string, []string, error) {
+
+	if kernel == nil {
+		return options, trunkIPs, nil
+	}
+
+	if GetNFSMountOption(options, "nconnect") != "" &&
+		!kernel.AtLeast(MustParseGeneric(minKernelVersionNFSNconnect)) {
+		return "", nil, UnsupportedError(fmt.Sprintf(
+			"kernel %s does not support the nconnect NFS mount option, which requires kernel %s or later",
+			kernel.String(), minKernelVersionNFSNconnect))
+	}
+
+	if GetNFSMountOption(options, "max_connect") != "" &&
+		!kernel.AtLeast(MustParseGeneric(minKernelVersionNFSSessionTrunking)) {
+		log.WithFields(log.Fields{
+			"kernel":   kernel.String(),
+			"required": minKernelVersionNFSSessionTrunking,
+		}).Warning("Kernel does not support NFS session trunking, mounting without it.")
+		return removeNFSMountOption(options, "max_connect"), nil, nil
+	}
+
+	return options, trunkIPs, nil
+}
+
+// removeNFSMountOption returns a set of NFS mount options without any instances of the named option.
+func removeNFSMountOption(options, name string) string {
+
+	kept := make([]string, 0)
+	for _, option := range strings.Split(strings.TrimPrefix(options, "-o "), ",") {
+		option = strings.TrimSpace(option)
+		if option == "" || option == name || strings.HasPrefix(option, name+"=") {
+			continue
+		}
+		kept = append(kept, option)
+	}
+	return strings.Join(kept, ",")
+}
+
+// addNFSSessionTrunks adds transports to an NFSv4.1 mount's session by mounting its export from each
+// of the server's other data LIFs.  The kernel trunks each such mount into the existing session, and
+// keeps the transport once the temporary mount is removed.  Trunking only adds throughput, so a data
+// LIF that can't be reached is skipped.
+func addNFSSessionTrunks(nfsPath, mountpoint, options string, trunkIPs []string) {
+
+	if len(trunkIPs) == 0 {
+		return
+	}
+
+	trunkMountpoint := path.Join(path.Dir(mountpoint), nfsTrunkMountDir)
+	defer func() {
+		if err := os.Remove(trunkMountpoint); err != nil && !os.IsNotExist(err) {
+			log.WithFields(log.Fields{"mountpoint": trunkMountpoint, "error": err}).Warning(
+				"Could not remove NFS trunk mountpoint.")
+		}
+	}()
+
+	for _, ip := range trunkIPs {
+		exportPath := fmt.Sprintf("%s:%s", ip, nfsPath)
+		if err := mountNFSPath(exportPath, trunkMountpoint, options); err != nil {
+			log.WithFields(log.Fields{"exportPath": exportPath, "error": err}).Warning(
+				"Could not trunk NFS session to data LIF.")
+			continue
+		}
+		if err := Umount(trunkMountpoint); err != nil {
+			log.WithFields(log.Fields{"mountpoint": trunkMountpoint, "error": err}).Warning(
+				"Could not unmount NFS trunk mountpoint.")
+			return
+		}
+		log.WithField("exportPath", exportPath).Debug("Trunked NFS session to data LIF.")
+	}
 }
 
 // AttachISCSIVolume attaches the volume to the local host.  This method must be able to accomplish its task using only the data passed in.
@@ -81,18 +199,10 @@ func AttachISCSIVolume(name, mountpoint string, publishInfo *VolumePublishInfo)
 	var lunID = int(publishInfo.IscsiLunNumber)
 
 	var bkportal []string
-	var portalIps []string
-	bkportal = append(bkportal, publishInfo.IscsiTargetPortal)
 
-	if IPv6Check(publishInfo.IscsiTargetPortal) {
-		// this is an IPv6 address
-		portalIps = append(portalIps, strings.Split(publishInfo.IscsiTargetPortal, "]")[0])
-	} else {
-		portalIps = append(portalIps, strings.Split(publishInfo.IscsiTargetPortal, ":")[0])
-	}
-	for _, p := range publishInfo.IscsiPortals {
-		bkportal = append(bkportal, p)
-		portalIps = append(portalIps, strings.Split(p, ":")[0])
+	// Portals published by older versions may contain IPv6 addresses without brackets
+	for _, p := range append([]string{publishInfo.IscsiTargetPortal}, publishInfo.IscsiPortals...) {
+		bkportal = append(bkportal, BracketIPv6(p))
 	}
 
 	var targetIQN = publishInfo.IscsiTargetIQN
@@ -139,7 +249,7 @@ func AttachISCSIVolume(name, mountpoint string, publishInfo *VolumePublishInfo)
 				}
 			}
 		} else {
-			err = EnsureISCSISessions(portalIps)
+			err = EnsureISCSISessions(bkportal)
 			if err != nil {
 				return fmt.Errorf("iSCSI session error: %v", err)
 			}
@@ -170,6 +280,13 @@ func AttachISCSIVolume(name, mountpoint string, publishInfo *VolumePublishInfo)
 		return fmt.Errorf("could not get iSCSI device information for LUN %d", lunID)
 	}
 
+	// Make sure the device is the LUN that was published, and that it has all of its paths, before
+	// trusting it with a filesystem
+	deviceInfo, err = verifyISCSIDevice(name, lunID, bkportal, publishInfo, deviceInfo, needFSType)
+	if err != nil {
+		return err
+	}
+
 	log.WithFields(log.Fields{
 		"scsiLun":         deviceInfo.LUN,
 		"multipathDevice": deviceInfo.MultipathDevice,
@@ -231,6 +348,361 @@ func AttachISCSIVolume(name, mountpoint string, publishInfo *VolumePublishInfo)
 	return nil
 }
 
+// verifyISCSIDevice checks that the devices found for a LUN report the serial number the controller
+// published, and that the LUN may be reached through a path for each portal on its reporting nodes.
+// Portals lacking a path are logged in to again and the LUN is rescanned until the paths appear or
+// the verification times out.  It returns the LUN's device info, which is refreshed if paths were
+// added, an ISCSIDeviceMismatchError if a device is some other LUN, or an ISCSIPathsDegradedError if
+// paths are still missing.
+func verifyISCSIDevice(
+	name string, lunID int, portals []string, publishInfo *VolumePublishInfo, deviceInfo *ScsiDeviceInfo,
+	needFSType bool,
+) (*ScsiDeviceInfo, error) {
+
+	fields := log.Fields{
+		"volume":        name,
+		"lunID":         lunID,
+		"expectedPaths": publishInfo.IscsiExpectedPaths,
+	}
+	log.WithFields(fields).Debug(">>>> osutils.verifyISCSIDevice")
+	defer log.WithFields(fields).Debug("<<<< osutils.verifyISCSIDevice")
+
+	if err := verifyISCSILunSerial(name, publishInfo.IscsiLunSerial, deviceInfo.Devices); err != nil {
+		return nil, err
+	}
+
+	// Publications from older versions don't say how many paths to expect
+	expectedPaths := publishInfo.IscsiExpectedPaths
+	if expectedPaths == 0 {
+		return deviceInfo, nil
+	}
+
+	targetIQN := publishInfo.IscsiTargetIQN
+
+	if len(deviceInfo.Devices) < expectedPaths {
+
+		log.WithFields(fields).WithField("paths", len(deviceInfo.Devices)).Warning(
+			"LUN is missing iSCSI paths, retrying portal logins.")
+
+		checkPaths := func() error {
+
+			if err := loginMissingISCSIPortals(portals, publishInfo); err != nil {
+				log.WithField("error", err).Warning("Could not log in to iSCSI portals.")
+			}
+			if err := waitForDeviceScanIfNeeded(lunID, targetIQN, true); err != nil {
+				return err
+			}
+
+			refreshedInfo, err := getDeviceInfoForLUN(lunID, targetIQN, needFSType)
+			if err != nil {
+				return err
+			} else if refreshedInfo == nil {
+				return fmt.Errorf("could not get iSCSI device information for LUN %d", lunID)
+			}
+			deviceInfo = refreshedInfo
+
+			if len(deviceInfo.Devices) < expectedPaths {
+				return fmt.Errorf("found %d of %d paths", len(deviceInfo.Devices), expectedPaths)
+			}
+			return nil
+		}
+
+		pathsNotify := func(err error, duration time.Duration) {
+			log.WithField("increment", duration).WithError(err).Debug("iSCSI paths not yet present, waiting.")
+		}
+
+		pathsBackoff := backoff.NewExponentialBackOff()
+		pathsBackoff.InitialInterval = 1 * time.Second
+		pathsBackoff.Multiplier = 1.414 // approx sqrt(2)
+		pathsBackoff.RandomizationFactor = 0.1
+		pathsBackoff.MaxElapsedTime = iSCSIPathVerificationTimeoutSecs * time.Second
+
+		if err := backoff.RetryNotify(checkPaths, pathsBackoff, pathsNotify); err != nil {
+			return nil, ISCSIPathsDegradedError(fmt.Sprintf(
+				"LUN %d for volume %s has %d of %d expected iSCSI paths", lunID, name,
+				len(deviceInfo.Devices), expectedPaths))
+		}
+
+		// The new paths must be the same LUN, and must join its multipath device
+		if err := verifyISCSILunSerial(name, publishInfo.IscsiLunSerial, deviceInfo.Devices); err != nil {
+			return nil, err
+		}
+		if err := waitForMultipathDeviceForLUN(lunID, targetIQN); err != nil {
+			return nil, err
+		}
+		refreshedInfo, err := getDeviceInfoForLUN(lunID, targetIQN, needFSType)
+		if err != nil {
+			return nil, fmt.Errorf("error getting iSCSI device information: %v", err)
+		} else if refreshedInfo == nil {
+			return nil, fmt.Errorf("could not get iSCSI device information for LUN %d", lunID)
+		}
+		deviceInfo = refreshedInfo
+	}
+
+	// With more than one path, multipathd must have assembled all of them into one device
+	if expectedPaths > 1 && multipathdIsRunning() {
+		if deviceInfo.MultipathDevice == "" {
+			return nil, ISCSIPathsDegradedError(fmt.Sprintf(
+				"no multipath device found for the %d paths to LUN %d for volume %s",
+				len(deviceInfo.Devices), lunID, name))
+		}
+		if multipathPaths := findDevicesForMultipathDevice(deviceInfo.MultipathDevice); len(multipathPaths) < expectedPaths {
+			return nil, ISCSIPathsDegradedError(fmt.Sprintf(
+				"multipath device %s for volume %s has %d of %d expected paths",
+				deviceInfo.MultipathDevice, name, len(multipathPaths), expectedPaths))
+		}
+	}
+
+	log.WithFields(fields).WithField("paths", len(deviceInfo.Devices)).Debug("Verified iSCSI device.")
+	return deviceInfo, nil
+}
+
+// verifyISCSILunSerial checks that each of a LUN's devices reports the expected serial number.  A
+// device whose serial number can't be read can't be shown to be the published LUN, so it fails the
+// check too.  Publish info written by older versions has no serial number, so it isn't checked.
+func verifyISCSILunSerial(name, expectedSerial string, devices []string) error {
+
+	if expectedSerial == "" {
+		log.WithField("volume", name).Warning(
+			"Publish info has no LUN serial number, not verifying the LUN's devices.")
+		return nil
+	}
+
+	for _, device := range devices {
+		serial, err := getLunSerial(device)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"volume": name,
+				"device": device,
+				"error":  err,
+			}).Error("Could not read LUN serial number of device.")
+			return ISCSIDeviceMismatchError(fmt.Sprintf(
+				"could not read LUN serial number of device %s, expected %s for volume %s: %v",
+				device, expectedSerial, name, err))
+		}
+		if serial != expectedSerial {
+			log.WithFields(log.Fields{
+				"volume":         name,
+				"device":         device,
+				"serial":         serial,
+				"expectedSerial": expectedSerial,
+			}).Error("Device is not the LUN that was published.")
+			return ISCSIDeviceMismatchError(fmt.Sprintf(
+				"device %s has LUN serial number %s, expected %s for volume %s",
+				device, serial, expectedSerial, name))
+		}
+	}
+	return nil
+}
+
+// getLunSerial reads the serial number a SCSI device reports in its unit serial number VPD page.
+func getLunSerial(device string) (string, error) {
+
+	vpdPath := chrootPathPrefix + "/sys/block/" + device + "/device/vpd_pg80"
+	page, err := ioutil.ReadFile(vpdPath)
+	if err != nil {
+		return "", err
+	}
+
+	// The page is a 4-byte header, ending with the page length, followed by the serial number
+	if len(page) < 4 || page[1] != 0x80 {
+		return "", fmt.Errorf("invalid unit serial number page in %s", vpdPath)
+	}
+	length := int(page[2])<<8 | int(page[3])
+	if len(page) < 4+length {
+		return "", fmt.Errorf("truncated unit serial number page in %s", vpdPath)
+	}
+	return strings.TrimSpace(string(page[4 : 4+length])), nil
+}
+
+// loginMissingISCSIPortals logs in to each of a LUN's portals that has no session to its target.
+// Every such portal is tried, and an error naming those that could not be logged in to is returned.
+func loginMissingISCSIPortals(portals []string, publishInfo *VolumePublishInfo) error {
+
+	missing, err := missingISCSIPortals(portals, publishInfo.IscsiTargetIQN)
+	if err != nil {
+		return err
+	}
+
+	iscsiInterface := publishInfo.IscsiInterface
+	if iscsiInterface == "" {
+		iscsiInterface = "default"
+	}
+
+	failed := make([]string, 0)
+	for _, portal := range missing {
+		log.WithField("portal", portal).Debug("Logging in to iSCSI portal without a session.")
+		if publishInfo.UseCHAP {
+			err = loginWithChap(publishInfo.IscsiTargetIQN, portal, publishInfo.IscsiUsername,
+				publishInfo.IscsiInitiatorSecret, publishInfo.IscsiTargetUsername, publishInfo.IscsiTargetSecret,
+				iscsiInterface, false)
+		} else {
+			err = EnsureISCSISession(portal)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{"portal": portal, "error": err}).Warning("iSCSI login failed.")
+			failed = append(failed, portal)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("iSCSI login to portals %v failed", failed)
+	}
+	return nil
+}
+
+// missingISCSIPortals returns those of a target's portals that have no session to it.
+func missingISCSIPortals(portals []string, targetIQN string) ([]string, error) {
+
+	sessions, err := getISCSISessionInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	loggedIn := make(map[string]bool)
+	for _, session := range sessions {
+		if session.TargetName == targetIQN {
+			loggedIn[session.PortalIP] = true
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, portal := range portals {
+		if !loggedIn[iSCSIPortalIP(portal)] {
+			missing = append(missing, portal)
+		}
+	}
+	return missing, nil
+}
+
+// HealISCSISessions restores the iSCSI sessions a published volume depends on.  CHAP credentials in
+// the node records of the volume's portals that have drifted from the volume's are corrected, so that
+// logins succeed, and any portal that has lost its session to the volume's target is logged in to
+// again.  It returns an error naming the portals whose sessions could not be restored.
+func HealISCSISessions(publishInfo *VolumePublishInfo) error {
+
+	fields := log.Fields{"targetIQN": publishInfo.IscsiTargetIQN}
+	log.WithFields(fields).Debug(">>>> osutils.HealISCSISessions")
+	defer log.WithFields(fields).Debug("<<<< osutils.HealISCSISessions")
+
+	if !ISCSISupported() {
+		return errors.New("iSCSI support not detected")
+	}
+
+	var portals []string
+	for _, p := range append([]string{publishInfo.IscsiTargetPortal}, publishInfo.IscsiPortals...) {
+		portals = append(portals, BracketIPv6(p))
+	}
+
+	if publishInfo.UseCHAP {
+		for _, portal := range portals {
+			if err := ensureISCSIChapCredentials(publishInfo, portal); err != nil {
+				log.WithFields(log.Fields{"portal": portal, "error": err}).Warning(
+					"Could not check CHAP credentials of iSCSI node record.")
+			}
+		}
+	}
+
+	if err := loginMissingISCSIPortals(portals, publishInfo); err != nil {
+		log.WithFields(fields).WithError(err).Warning("Could not restore iSCSI sessions.")
+	}
+
+	missing, err := missingISCSIPortals(portals, publishInfo.IscsiTargetIQN)
+	if err != nil {
+		return err
+	} else if len(missing) > 0 {
+		return fmt.Errorf("no iSCSI session to target %s through portals %v", publishInfo.IscsiTargetIQN, missing)
+	}
+	return nil
+}
+
+// ensureISCSIChapCredentials corrects the CHAP credentials in a portal's node record if they differ
+// from a volume's.  A portal without a node record is left for a login to create one.
+func ensureISCSIChapCredentials(publishInfo *VolumePublishInfo, portal string) error {
+
+	record, err := getISCSINodeRecord(publishInfo.IscsiTargetIQN, portal)
+	if err != nil {
+		return err
+	} else if record == nil {
+		return nil
+	}
+
+	expected := map[string]string{
+		"node.session.auth.authmethod": "CHAP",
+		"node.session.auth.username":   publishInfo.IscsiUsername,
+		"node.session.auth.password":   publishInfo.IscsiInitiatorSecret,
+	}
+	if publishInfo.IscsiTargetUsername != "" && publishInfo.IscsiTargetSecret != "" {
+		expected["node.session.auth.username_in"] = publishInfo.IscsiTargetUsername
+		expected["node.session.auth.password_in"] = publishInfo.IscsiTargetSecret
+	}
+
+	drifted := false
+	for name, value := range expected {
+		if record[name] != value {
+			drifted = true
+			break
+		}
+	}
+	if !drifted {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"targetIQN": publishInfo.IscsiTargetIQN,
+		"portal":    portal,
+	}).Info("Correcting CHAP credentials in iSCSI node record.")
+
+	return setISCSIChapCredentials(publishInfo.IscsiTargetIQN, portal, publishInfo.IscsiUsername,
+		publishInfo.IscsiInitiatorSecret, publishInfo.IscsiTargetUsername, publishInfo.IscsiTargetSecret)
+}
+
+// getISCSINodeRecord returns the settings in a portal's node record for a target, including its
+// secrets, or nil if there is no such record.
+func getISCSINodeRecord(targetIQN, portal string) (map[string]string, error) {
+
+	out, err := execIscsiadmCommand("-m", "node", "-T", targetIQN, "-p", portal+":3260", "-S")
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if ok && exitErr.ProcessState.Sys().(syscall.WaitStatus).ExitStatus() == iSCSIErrNoObjsFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseISCSINodeRecord(string(out)), nil
+}
+
+// parseISCSINodeRecord parses the 'name = value' lines that 'iscsiadm -m node' prints for a node
+// record.  Unset values are returned as empty strings.
+func parseISCSINodeRecord(out string) map[string]string {
+
+	record := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		if value == "<empty>" {
+			value = ""
+		}
+		record[strings.TrimSpace(parts[0])] = value
+	}
+	return record
+}
+
+// iSCSIPortalIP returns the address of a portal without its port, keeping the brackets around an
+// IPv6 address as 'iscsiadm -m session' reports them.
+func iSCSIPortalIP(portal string) string {
+	if strings.HasPrefix(portal, "[") {
+		if end := strings.Index(portal, "]"); end > 0 {
+			return portal[:end+1]
+		}
+		return portal
+	}
+	return strings.Split(portal, ":")[0]
+}
+
 // DFInfo data structure for wrapping the parsed output from the 'df' command
 type DFInfo struct {
 	Target string
@@ -1178,10 +1650,10 @@ func ExpandISCSIFilesystem(publishInfo *VolumePublishInfo, stagedTargetPath stri
 	}
 	defer removeMountPoint(tmpMountPoint)
 
-	// Don't need to verify the filesystem type as the resize utilities will throw an error if the filesystem
-	// is not the correct type.
+	fsType := getExpandFilesystemType(devicePath, publishInfo.FilesystemType)
+
 	var size int64
-	switch publishInfo.FilesystemType {
+	switch fsType {
 	case "xfs":
 		size, err = expandFilesystem("xfs_growfs", tmpMountPoint, tmpMountPoint)
 		if err != nil {
@@ -1193,12 +1665,46 @@ func ExpandISCSIFilesystem(publishInfo *VolumePublishInfo, stagedTargetPath stri
 			return 0, err
 		}
 	default:
-		err = fmt.Errorf("unsupported file system type: %s", publishInfo.FilesystemType)
+		err = fmt.Errorf("unsupported file system type: %s", fsType)
 	}
 
 	return size, err
 }
 
+// getExpandFilesystemType returns the type of the filesystem to grow on a device.  That is the type the
+// device holds, which the resize utilities would refuse to work on if it were some other type, or the
+// type the volume was published with if the device's type can't be read.
+func getExpandFilesystemType(devicePath, publishedFsType string) string {
+
+	logFields := log.Fields{"devicePath": devicePath, "filesystemType": publishedFsType}
+
+	existingFsType, err := resizeFSType(devicePath)
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Warning("Could not determine filesystem type.")
+		return publishedFsType
+	}
+	if existingFsType != "" && existingFsType != publishedFsType {
+		log.WithFields(logFields).WithField("existingFilesystemType", existingFsType).Warning(
+			"Device holds a different filesystem type than the volume was published with.")
+		return existingFsType
+	}
+	return publishedFsType
+}
+
+// TrimFilesystem discards the unused blocks of the filesystem mounted at a path, so that a
+// thin-provisioned LUN beneath it may return them to its storage system.
+func TrimFilesystem(mountpoint string) error {
+
+	log.WithField("mountpoint", mountpoint).Debug(">>>> osutils.TrimFilesystem")
+	defer log.WithField("mountpoint", mountpoint).Debug("<<<< osutils.TrimFilesystem")
+
+	out, err := execCommandWithTimeout("fstrim", fstrimTimeoutSecs, mountpoint)
+	if err != nil {
+		return fmt.Errorf("could not trim filesystem at %s: %v; %s", mountpoint, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 func expandFilesystem(cmd string, cmdArguments string, tmpMountPoint string) (int64, error) {
 	logFields := log.Fields{
 		"cmd":           cmd,
@@ -1284,15 +1790,13 @@ func ISCSIRescanDevices(targetIQN string, lunID int32, minSize int64) error {
 		return fmt.Errorf("could not get iSCSI device information for LUN: %d", lunID)
 	}
 
-	allLargeEnough := true
+	rescanned := make([]string, 0)
 	for _, diskDevice := range deviceInfo.Devices {
 		size, err := getISCSIDiskSize("/dev/" + diskDevice)
 		if err != nil {
 			return err
 		}
-		if size < minSize {
-			allLargeEnough = false
-		} else {
+		if size >= minSize {
 			continue
 		}
 
@@ -1301,19 +1805,14 @@ func ISCSIRescanDevices(targetIQN string, lunID int32, minSize int64) error {
 			log.WithField("diskDevice", diskDevice).Error("Failed to rescan disk.")
 			return fmt.Errorf("failed to rescan disk %s: %s", diskDevice, err)
 		}
+		rescanned = append(rescanned, diskDevice)
 	}
 
-	if !allLargeEnough {
-		time.Sleep(time.Second)
-		for _, diskDevice := range deviceInfo.Devices {
-			size, err := getISCSIDiskSize("/dev/" + diskDevice)
-			if err != nil {
-				return err
-			}
-			if size < minSize {
-				log.Error("Disk size not large enough after resize.")
-				return fmt.Errorf("disk size not large enough after resize: %d, %d", size, minSize)
-			}
+	// The new size may take a moment to be reported after a rescan
+	for _, diskDevice := range rescanned {
+		if err = waitForDeviceSize(diskDevice, minSize); err != nil {
+			log.Error("Disk size not large enough after resize.")
+			return fmt.Errorf("disk size not large enough after resize: %v", err)
 		}
 	}
 
@@ -1326,19 +1825,13 @@ func ISCSIRescanDevices(targetIQN string, lunID int32, minSize int64) error {
 
 		fields = log.Fields{"size": size, "minSize": minSize}
 		if size < minSize {
-			log.WithFields(fields).Debug("Reloading the multipath device.")
-			err := reloadMultipathDevice(multipathDevice)
-			if err != nil {
+			log.WithFields(fields).Debug("Resizing the multipath device.")
+			if err := resizeMultipathDevice(multipathDevice); err != nil {
 				return err
 			}
-			time.Sleep(time.Second)
-			size, err = getISCSIDiskSize("/dev/" + multipathDevice)
-			if err != nil {
-				return err
-			}
-			if size < minSize {
+			if err = waitForDeviceSize(multipathDevice, minSize); err != nil {
 				log.Error("Multipath device not large enough after resize.")
-				return fmt.Errorf("multipath device not large enough after resize: %d < %d", size, minSize)
+				return fmt.Errorf("multipath device not large enough after resize: %v", err)
 			}
 		} else {
 			log.WithFields(fields).Debug("Not reloading the multipath device because the size is greater than or equal to the minimum size.")
@@ -1348,6 +1841,64 @@ func ISCSIRescanDevices(targetIQN string, lunID int32, minSize int64) error {
 	return nil
 }
 
+// waitForDeviceSize waits until a device reports a size of at least minSize.
+func waitForDeviceSize(device string, minSize int64) error {
+
+	fields := log.Fields{"device": device, "minSize": minSize}
+	log.WithFields(fields).Debug(">>>> osutils.waitForDeviceSize")
+	defer log.WithFields(fields).Debug("<<<< osutils.waitForDeviceSize")
+
+	var size int64
+	checkDeviceSize := func() error {
+		var err error
+		if size, err = resizeDiskSize("/dev/" + device); err != nil {
+			return backoff.Permanent(err)
+		}
+		if size < minSize {
+			return fmt.Errorf("device %s is %d bytes", device, size)
+		}
+		return nil
+	}
+
+	sizeNotify := func(err error, duration time.Duration) {
+		log.WithField("increment", duration).WithError(err).Debug("Device not yet resized, waiting.")
+	}
+
+	sizeBackoff := backoff.NewExponentialBackOff()
+	sizeBackoff.InitialInterval = 1 * time.Second
+	sizeBackoff.Multiplier = 1.414 // approx sqrt(2)
+	sizeBackoff.RandomizationFactor = 0.1
+	sizeBackoff.MaxElapsedTime = resizeTimeout
+
+	if err := backoff.RetryNotify(checkDeviceSize, sizeBackoff, sizeNotify); err != nil {
+		return fmt.Errorf("device %s is %d bytes, expected at least %d", device, size, minSize)
+	}
+	return nil
+}
+
+// resizeMultipathDevice asks multipathd to grow a multipath map to the size of its paths, falling
+// back to reloading the map if multipathd can't.
+func resizeMultipathDevice(multipathDevice string) error {
+
+	fields := log.Fields{"multipathDevice": multipathDevice}
+	log.WithFields(fields).Debug(">>>> osutils.resizeMultipathDevice")
+	defer log.WithFields(fields).Debug("<<<< osutils.resizeMultipathDevice")
+
+	out, err := resizeExecCommand("multipathd", 30, "resize", "map", multipathDevice)
+	if err == nil && !strings.Contains(string(out), "fail") {
+		log.WithFields(fields).Debug("Multipath device resized.")
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"device": multipathDevice,
+		"output": string(out),
+		"error":  err,
+	}).Warning("Could not resize multipath device, reloading it.")
+
+	return reloadMultipathDevice(multipathDevice)
+}
+
 func reloadMultipathDevice(multipathDevice string) error {
 	fields := log.Fields{"multipathDevice": multipathDevice}
 	log.WithFields(fields).Debug(">>>> osutils.reloadMultipathDevice")
@@ -1357,7 +1908,7 @@ func reloadMultipathDevice(multipathDevice string) error {
 		return fmt.Errorf("cannot reload an empty multipathDevice")
 	}
 
-	_, err := execCommandWithTimeout("multipath", 30, "-r", "/dev/"+multipathDevice)
+	_, err := resizeExecCommand("multipath", 30, "-r", "/dev/"+multipathDevice)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"device": multipathDevice,
@@ -2227,6 +2778,24 @@ func loginWithChap(tiqn, portal, username, password, targetUsername, targetIniti
 		return err
 	}
 
+	if err := setISCSIChapCredentials(tiqn, portal, username, password, targetUsername, targetInitiatorSecret); err != nil {
+		return err
+	}
+
+	loginArgs := append(args, []string{"--login"}...)
+	if _, err := execIscsiadmCommand(loginArgs...); err != nil {
+		log.Error("Error running iscsiadm login.")
+		return err
+	}
+	listAllISCSIDevices()
+	return nil
+}
+
+// setISCSIChapCredentials writes CHAP credentials to a portal's node record for a target.
+func setISCSIChapCredentials(tiqn, portal, username, password, targetUsername, targetInitiatorSecret string) error {
+
+	args := []string{"-m", "node", "-T", tiqn, "-p", portal + ":3260"}
+
 	authMethodArgs := append(args, []string{"--op=update", "--name", "node.session.auth.authmethod", "--value=CHAP"}...)
 	if _, err := execIscsiadmCommand(authMethodArgs...); err != nil {
 		log.Error("Error running iscsiadm set authmethod.")
@@ -2259,12 +2828,6 @@ func loginWithChap(tiqn, portal, username, password, targetUsername, targetIniti
 		}
 	}
 
-	loginArgs := append(args, []string{"--login"}...)
-	if _, err := execIscsiadmCommand(loginArgs...); err != nil {
-		log.Error("Error running iscsiadm login.")
-		return err
-	}
-	listAllISCSIDevices()
 	return nil
 }
 
@@ -3,7 +3,13 @@
 package utils
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -117,3 +123,244 @@ func TestParseIPv4Valid(t *testing.T) {
 		assert.False(t, test.predicate(test.input), "Predicate failed")
 	}
 }
+
+func TestGetLunSerial(t *testing.T) {
+
+	sysDir, err := ioutil.TempDir("", "sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sysDir)
+
+	savedPrefix := chrootPathPrefix
+	chrootPathPrefix = sysDir
+	defer func() { chrootPathPrefix = savedPrefix }()
+
+	writePage := func(device string, page []byte) {
+		deviceDir := filepath.Join(sysDir, "sys", "block", device, "device")
+		if err := os.MkdirAll(deviceDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(deviceDir, "vpd_pg80"), page, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	serial := "80BB3+Kd1pqV"
+	writePage("sda", append([]byte{0x00, 0x80, 0x00, byte(len(serial))}, []byte(serial)...))
+	writePage("sdb", append([]byte{0x00, 0x80, 0x00, 0x0c}, []byte("wrongSerial!")...))
+	writePage("sdc", []byte{0x00, 0x80, 0x00, 0x0c, 'x'})
+
+	result, err := getLunSerial("sda")
+	assert.NoError(t, err)
+	assert.Equal(t, serial, result)
+
+	_, err = getLunSerial("sdc")
+	assert.Error(t, err, "expected an error for a truncated page")
+	_, err = getLunSerial("sdd")
+	assert.Error(t, err, "expected an error for a missing device")
+
+	tests := map[string]struct {
+		expectedSerial string
+		devices        []string
+		mismatch       bool
+	}{
+		"Matching devices":         {expectedSerial: serial, devices: []string{"sda"}},
+		"Publish info from before": {expectedSerial: "", devices: []string{"sdb", "sdd"}},
+		"Mismatched device":        {expectedSerial: serial, devices: []string{"sda", "sdb"}, mismatch: true},
+		"Unreadable device":        {expectedSerial: serial, devices: []string{"sda", "sdd"}, mismatch: true},
+		"Truncated page":           {expectedSerial: serial, devices: []string{"sdc"}, mismatch: true},
+	}
+	for name, test := range tests {
+		err := verifyISCSILunSerial("vol1", test.expectedSerial, test.devices)
+		if test.mismatch {
+			assert.True(t, IsISCSIDeviceMismatchError(err), name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
+
+func TestISCSIPortalIP(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", iSCSIPortalIP("10.0.0.1"))
+	assert.Equal(t, "10.0.0.1", iSCSIPortalIP("10.0.0.1:3260"))
+	assert.Equal(t, "[fd20:8b1e:b258:2000::1]", iSCSIPortalIP("[fd20:8b1e:b258:2000::1]"))
+	assert.Equal(t, "[fd20:8b1e:b258:2000::1]", iSCSIPortalIP("[fd20:8b1e:b258:2000::1]:3260"))
+}
+
+func TestParseISCSINodeRecord(t *testing.T) {
+
+	out := `# BEGIN RECORD 2.0-874
+node.name = iqn.1992-08.com.netapp:sn.afbb1784f77411e582f8080027e22798:vs.3
+node.session.auth.authmethod = CHAP
+node.session.auth.username = chapUser
+node.session.auth.password = chapSecret
+node.session.auth.username_in = <empty>
+node.conn[0].address = 10.0.207.7
+# END RECORD`
+
+	record := parseISCSINodeRecord(out)
+	assert.Equal(t, "CHAP", record["node.session.auth.authmethod"])
+	assert.Equal(t, "chapUser", record["node.session.auth.username"])
+	assert.Equal(t, "chapSecret", record["node.session.auth.password"])
+	assert.Equal(t, "", record["node.session.auth.username_in"])
+	assert.Equal(t, "10.0.207.7", record["node.conn[0].address"])
+	assert.NotContains(t, record, "# BEGIN RECORD 2.0-874")
+}
+
+func TestNfsMountOptionsForKernel(t *testing.T) {
+	log.Debug("Running TestNfsMountOptionsForKernel...")
+
+	trunkIPs := []string{"10.0.0.2", "10.0.0.3"}
+
+	// An unknown kernel changes nothing
+	options, ips, err := nfsMountOptionsForKernel("nfsvers=4.1,nconnect=4,max_connect=3", trunkIPs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "nfsvers=4.1,nconnect=4,max_connect=3", options)
+	assert.Equal(t, trunkIPs, ips)
+
+	options, ips, err = nfsMountOptionsForKernel("nfsvers=4.1,max_connect=3", trunkIPs,
+		MustParseGeneric("5.15.0-1019-aws"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nfsvers=4.1,max_connect=3", options)
+	assert.Equal(t, trunkIPs, ips)
+
+	// Older kernels mount without session trunking
+	options, ips, err = nfsMountOptionsForKernel("nfsvers=4.1,max_connect=3,hard", trunkIPs,
+		MustParseGeneric("5.4.0-42-generic"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nfsvers=4.1,hard", options)
+	assert.Empty(t, ips)
+
+	options, _, err = nfsMountOptionsForKernel("nfsvers=3,nconnect=8", nil, MustParseGeneric("5.3.18"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nfsvers=3,nconnect=8", options)
+
+	_, _, err = nfsMountOptionsForKernel("nfsvers=3,nconnect=8", nil, MustParseGeneric("4.18.0-193.el8.x86_64"))
+	assert.True(t, IsUnsupportedError(err), "expected unsupported error")
+}
+
+func TestRemoveNFSMountOption(t *testing.T) {
+	log.Debug("Running TestRemoveNFSMountOption...")
+
+	assert.Equal(t, "nfsvers=4.1,hard", removeNFSMountOption("-o nfsvers=4.1, max_connect=2,hard", "max_connect"))
+	assert.Equal(t, "nfsvers=4.1", removeNFSMountOption("nfsvers=4.1", "max_connect"))
+	assert.Equal(t, "", removeNFSMountOption("max_connect=2", "max_connect"))
+}
+
+func TestGetExpandFilesystemType(t *testing.T) {
+
+	savedFSType := resizeFSType
+	defer func() { resizeFSType = savedFSType }()
+
+	tests := map[string]struct {
+		existingFsType  string
+		err             error
+		publishedFsType string
+		expected        string
+	}{
+		"Same type":         {existingFsType: "ext4", publishedFsType: "ext4", expected: "ext4"},
+		"Different type":    {existingFsType: "xfs", publishedFsType: "ext4", expected: "xfs"},
+		"Unknown type":      {existingFsType: "", publishedFsType: "ext4", expected: "ext4"},
+		"Unreadable type":   {err: errors.New("blkid failed"), publishedFsType: "xfs", expected: "xfs"},
+		"No published type": {existingFsType: "ext3", publishedFsType: "", expected: "ext3"},
+	}
+	for name, test := range tests {
+		resizeFSType = func(string) (string, error) { return test.existingFsType, test.err }
+		assert.Equal(t, test.expected, getExpandFilesystemType("/dev/sda", test.publishedFsType), name)
+	}
+}
+
+func TestWaitForDeviceSize(t *testing.T) {
+
+	savedDiskSize, savedTimeout := resizeDiskSize, resizeTimeout
+	defer func() { resizeDiskSize, resizeTimeout = savedDiskSize, savedTimeout }()
+
+	tests := map[string]struct {
+		sizes    []int64
+		err      error
+		timeout  time.Duration
+		expected bool
+		probes   int
+	}{
+		"Already resized":       {sizes: []int64{2048}, expected: true, probes: 1},
+		"Resized after a retry": {sizes: []int64{1024, 2048}, expected: true, probes: 2},
+		"Never resized":         {sizes: []int64{1024}, timeout: 100 * time.Millisecond, probes: 1},
+		"Unreadable size":       {err: errors.New("no such device"), probes: 1},
+	}
+	for name, test := range tests {
+		probes := 0
+		resizeDiskSize = func(devicePath string) (int64, error) {
+			assert.Equal(t, "/dev/sdb", devicePath, name)
+			probes++
+			if test.err != nil {
+				return 0, test.err
+			}
+			if probes > len(test.sizes) {
+				return test.sizes[len(test.sizes)-1], nil
+			}
+			return test.sizes[probes-1], nil
+		}
+		resizeTimeout = 5 * time.Second
+		if test.timeout != 0 {
+			resizeTimeout = test.timeout
+		}
+
+		err := waitForDeviceSize("sdb", 2048)
+		if test.expected {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+		assert.Equal(t, test.probes, probes, name)
+	}
+}
+
+func TestResizeMultipathDevice(t *testing.T) {
+
+	savedExecCommand := resizeExecCommand
+	defer func() { resizeExecCommand = savedExecCommand }()
+
+	tests := map[string]struct {
+		resizeOutput string
+		resizeErr    error
+		reloadErr    error
+		expected     bool
+		commands     []string
+	}{
+		"Resized": {
+			resizeOutput: "ok", expected: true,
+			commands: []string{"multipathd resize map dm-0"},
+		},
+		"Resize failed": {
+			resizeErr: errors.New("exit status 1"), expected: true,
+			commands: []string{"multipathd resize map dm-0", "multipath -r /dev/dm-0"},
+		},
+		"Resize reported failure": {
+			resizeOutput: "fail", expected: true,
+			commands: []string{"multipathd resize map dm-0", "multipath -r /dev/dm-0"},
+		},
+		"Reload failed": {
+			resizeErr: errors.New("exit status 1"), reloadErr: errors.New("exit status 1"),
+			commands: []string{"multipathd resize map dm-0", "multipath -r /dev/dm-0"},
+		},
+	}
+	for name, test := range tests {
+		commands := make([]string, 0)
+		resizeExecCommand = func(name string, _ time.Duration, args ...string) ([]byte, error) {
+			commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+			if name == "multipathd" {
+				return []byte(test.resizeOutput), test.resizeErr
+			}
+			return nil, test.reloadErr
+		}
+
+		err := resizeMultipathDevice("dm-0")
+		if test.expected {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+		assert.Equal(t, test.commands, commands, name)
+	}
+}
@@ -13,6 +13,9 @@ type IscsiAccessInfo struct {
 	IscsiPortals         []string `json:"iscsiPortals,omitempty"`
 	IscsiTargetIQN       string   `json:"iscsiTargetIqn,omitempty"`
 	IscsiLunNumber       int32    `json:"iscsiLunNumber,omitempty"`
+	IscsiLunSerial       string   `json:"iscsiLunSerial,omitempty"`
+	IscsiExpectedPaths   int      `json:"iscsiExpectedPaths,omitempty"`
+	IscsiLunUUID         string   `json:"iscsiLunUuid,omitempty"`
 	IscsiInterface       string   `json:"iscsiInterface,omitempty"`
 	IscsiIgroup          string   `json:"iscsiIgroup,omitempty"`
 	IscsiVAGs            []int64  `json:"iscsiVags,omitempty"`
@@ -25,6 +28,10 @@ type IscsiAccessInfo struct {
 type NfsAccessInfo struct {
 	NfsServerIP string `json:"nfsServerIp,omitempty"`
 	NfsPath     string `json:"nfsPath,omitempty"`
+	// Other data LIFs of the server, to which NFSv4.1 sessions are trunked
+	NfsServerIPs []string `json:"nfsServerIps,omitempty"`
+	// Export policy granting the publishing node access, if the driver manages export policies
+	NfsExportPolicy string `json:"nfsExportPolicy,omitempty"`
 }
 
 type VolumePublishInfo struct {
@@ -39,6 +46,8 @@ type VolumePublishInfo struct {
 	SharedTarget   bool     `json:"sharedTarget,omitempty"`
 	DevicePath     string   `json:"devicePath,omitempty"`
 	Unmanaged      bool     `json:"unmanaged,omitempty"`
+	// Seconds between trims of the volume's filesystem by the node, or zero if it isn't trimmed
+	SpaceReclamationPeriod int `json:"spaceReclamationPeriod,omitempty"`
 	VolumeAccessInfo
 }
 
@@ -46,6 +55,13 @@ type VolumeTrackingPublishInfo struct {
 	StagingTargetPath string `json:"stagingTargetPath"`
 }
 
+// VolumeEvent is an event about a volume observed by a node, such as the loss of its iSCSI sessions.
+type VolumeEvent struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
 type Node struct {
 	Name string   `json:"name"`
 	IQN  string   `json:"iqn,omitempty"`
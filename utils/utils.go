@@ -436,6 +436,35 @@ func FilterIPs(ips, cidrs []string) ([]string, error) {
 	return filteredIPs, nil
 }
 
+// ParseHostportIP returns the host portion of an address that may include a port, with any brackets
+// around an IPv6 address removed.  For example, "[fd00::1]:443" and "fd00::1" both yield "fd00::1".
+func ParseHostportIP(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]")
+}
+
+// BracketIPv6 returns the host portion of an address, enclosing it in brackets if it is an IPv6
+// address.  The result may be followed by a port or path, as in iSCSI portals and NFS export paths.
+func BracketIPv6(address string) string {
+	host := ParseHostportIP(address)
+	if IPv6Check(host) {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// ValidateCIDRs checks that each of the provided strings is a valid IPv4 or IPv6 CIDR block.
+func ValidateCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %s; %v", cidr, err)
+		}
+	}
+	return nil
+}
+
 // GetYAMLTagWithSpaceCount returns the line that matches the pattern, tag name, and the spaces before the tag
 func GetYAMLTagWithSpaceCount(text string) (string, string, int) {
 
@@ -519,6 +548,126 @@ func GetNFSVersionFromMountOptions(mountOptions, defaultVersion string, supporte
 	}
 }
 
+// nfsMountOptionFlags are the NFS mount options, taking no value, that Trident accepts.
+var nfsMountOptionFlags = []string{
+	"hard", "soft", "intr", "nointr", "ro", "rw", "sync", "async", "ac", "noac", "cto", "nocto",
+	"lock", "nolock", "acl", "noacl", "rdirplus", "nordirplus", "sharecache", "nosharecache",
+	"resvport", "noresvport", "fsc", "nofsc", "atime", "noatime", "diratime", "nodiratime",
+	"relatime", "norelatime",
+}
+
+// nfsMountOptionValues are the NFS mount options, taking a value, that Trident accepts.  Each maps
+// to the values it may take, or to nil if it takes a non-negative number.
+var nfsMountOptionValues = map[string][]string{
+	"nfsvers":      {"3", "4", "4.0", "4.1", "4.2"},
+	"vers":         {"3", "4", "4.0", "4.1", "4.2"},
+	"minorversion": {"0", "1", "2"},
+	"proto":        {"tcp", "tcp6", "rdma", "rdma6"},
+	"mountproto":   {"tcp", "tcp6", "udp", "udp6"},
+	"sec":          {"sys", "krb5", "krb5i", "krb5p"},
+	"lookupcache":  {"all", "none", "pos", "positive"},
+	"local_lock":   {"none", "all", "flock", "posix"},
+	"nconnect":     nil,
+	"max_connect":  nil,
+	"rsize":        nil,
+	"wsize":        nil,
+	"timeo":        nil,
+	"retrans":      nil,
+	"retry":        nil,
+	"acregmin":     nil,
+	"acregmax":     nil,
+	"acdirmin":     nil,
+	"acdirmax":     nil,
+	"actimeo":      nil,
+	"port":         nil,
+	"mountport":    nil,
+}
+
+// maxNFSConnections is the largest number of connections the Linux NFS client opens to a server.
+const maxNFSConnections = 16
+
+// ValidateNFSMountOptions checks a set of NFS mount options, as they appear in a backend config or a
+// storage class, against the options Trident accepts.  An empty set of options is valid.
+func ValidateNFSMountOptions(mountOptions string) error {
+
+	mountOptions = strings.TrimPrefix(strings.TrimSpace(mountOptions), "-o ")
+	if mountOptions == "" {
+		return nil
+	}
+
+	for _, mountOption := range strings.Split(mountOptions, ",") {
+
+		mountOption = strings.TrimSpace(mountOption)
+		if mountOption == "" {
+			continue
+		}
+
+		name, value, hasValue := mountOption, "", false
+		if i := strings.Index(mountOption, "="); i >= 0 {
+			name, value, hasValue = mountOption[:i], mountOption[i+1:], true
+		}
+
+		if !hasValue {
+			if !StringInSlice(name, nfsMountOptionFlags) {
+				return fmt.Errorf("unsupported NFS mount option %s", mountOption)
+			}
+			continue
+		}
+
+		allowedValues, ok := nfsMountOptionValues[name]
+		if !ok {
+			return fmt.Errorf("unsupported NFS mount option %s", mountOption)
+		}
+		if allowedValues != nil {
+			if !StringInSlice(value, allowedValues) {
+				return fmt.Errorf("invalid value for NFS mount option %s; must be one of %s", name,
+					strings.Join(allowedValues, ", "))
+			}
+			continue
+		}
+
+		number, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value for NFS mount option %s; must be a non-negative integer", name)
+		}
+		if (name == "nconnect" || name == "max_connect") && (number < 1 || number > maxNFSConnections) {
+			return fmt.Errorf("invalid value for NFS mount option %s; must be between 1 and %d", name,
+				maxNFSConnections)
+		}
+	}
+
+	return nil
+}
+
+// GetNFSMountOption returns the value of an NFS mount option, or "" if it isn't set.  Last option wins.
+func GetNFSMountOption(mountOptions, name string) string {
+
+	value := ""
+	for _, mountOption := range strings.Split(strings.TrimPrefix(mountOptions, "-o "), ",") {
+		if parts := strings.SplitN(strings.TrimSpace(mountOption), "=", 2); len(parts) == 2 && parts[0] == name {
+			value = parts[1]
+		}
+	}
+	return value
+}
+
+// GetNFSSessionTrunkingLimit returns the number of transports an NFS mount may trunk its session over,
+// as set by the max_connect mount option, or 0 if the mount options don't request session trunking.
+// Only NFSv4.1 and later support session trunking.
+func GetNFSSessionTrunkingLimit(mountOptions string) int {
+
+	version, err := GetNFSVersionFromMountOptions(mountOptions, "", nil)
+	if err != nil || version == "" || version == "3" || version == "4" || version == "4.0" {
+		return 0
+	}
+
+	limit, err := strconv.Atoi(GetNFSMountOption(mountOptions, "max_connect"))
+	if err != nil || limit < 2 {
+		return 0
+	}
+	return limit
+}
+
 // GetRegexSubmatches accepts a regular expression with one or more groups and returns a map
 // of the group matches found in the supplied string.
 func GetRegexSubmatches(r *regexp.Regexp, s string) map[string]string {
@@ -247,6 +247,48 @@ func TestFilterIPs(t *testing.T) {
 	}
 }
 
+func TestParseHostportIP(t *testing.T) {
+	log.Debug("Running TestParseHostportIP...")
+
+	inputs := map[string]string{
+		"10.0.0.1":              "10.0.0.1",
+		"10.0.0.1:443":          "10.0.0.1",
+		"fd00::1":               "fd00::1",
+		"[fd00::1]":             "fd00::1",
+		"[fd00::1]:443":         "fd00::1",
+		"cluster.example.com":   "cluster.example.com",
+		"cluster.example.com:8": "cluster.example.com",
+	}
+	for input, expected := range inputs {
+		assert.Equal(t, expected, ParseHostportIP(input), input)
+	}
+}
+
+func TestBracketIPv6(t *testing.T) {
+	log.Debug("Running TestBracketIPv6...")
+
+	inputs := map[string]string{
+		"10.0.0.1":            "10.0.0.1",
+		"fd00::1":             "[fd00::1]",
+		"[fd00::1]":           "[fd00::1]",
+		"[fd00::1]:3260":      "[fd00::1]",
+		"nfs.example.com":     "nfs.example.com",
+		"fe80::2:3:4:5:6:7:8": "[fe80::2:3:4:5:6:7:8]",
+	}
+	for input, expected := range inputs {
+		assert.Equal(t, expected, BracketIPv6(input), input)
+	}
+}
+
+func TestValidateCIDRs(t *testing.T) {
+	log.Debug("Running TestValidateCIDRs...")
+
+	assert.NoError(t, ValidateCIDRs([]string{"0.0.0.0/0", "::/0", "10.0.0.0/8", "fd00::/64"}))
+	assert.NoError(t, ValidateCIDRs(nil))
+	assert.Error(t, ValidateCIDRs([]string{"10.0.0.0/8", "fd00::1"}))
+	assert.Error(t, ValidateCIDRs([]string{"10.0.0.0/33"}))
+}
+
 func TestGetYAMLTagWithSpaceCount(t *testing.T) {
 	log.Debug("Running TestGetYAMLTagWithSpaceCount...")
 
@@ -355,3 +397,61 @@ func TestGetNFSVersionFromMountOptions(t *testing.T) {
 		assert.Equal(t, test.errNotNil, err != nil)
 	}
 }
+
+func TestValidateNFSMountOptions(t *testing.T) {
+	log.Debug("Running TestValidateNFSMountOptions...")
+
+	var tests = []struct {
+		mountOptions string
+		errNotNil    bool
+	}{
+		// Positive tests
+		{"", false},
+		{"-o nfsvers=3", false},
+		{"nfsvers=4.1,proto=tcp,nconnect=8", false},
+		{"hard, timeo=600 ,retrans=2", false},
+		{"vers=4,minorversion=1,sec=krb5p", false},
+		{"rsize=65536,wsize=65536,noatime", false},
+		{"nconnect=16", false},
+		{"nfsvers=4.1,max_connect=4", false},
+
+		// Negative tests
+		{"nfsvers=2", true},
+		{"proto=udp", true},
+		{"nconnect=0", true},
+		{"nconnect=17", true},
+		{"max_connect=0", true},
+		{"timeo=-1", true},
+		{"rsize=big", true},
+		{"sec=none", true},
+		{"nosuid", true},
+		{"context=system_u:object_r:nfs_t:s0", true},
+	}
+
+	for _, test := range tests {
+		err := ValidateNFSMountOptions(test.mountOptions)
+		assert.Equal(t, test.errNotNil, err != nil, test.mountOptions)
+	}
+}
+
+func TestGetNFSSessionTrunkingLimit(t *testing.T) {
+	log.Debug("Running TestGetNFSSessionTrunkingLimit...")
+
+	var tests = []struct {
+		mountOptions string
+		limit        int
+	}{
+		{"", 0},
+		{"nfsvers=4.1", 0},
+		{"nfsvers=4.1,max_connect=4", 4},
+		{"-o vers=4,minorversion=1,max_connect=2", 2},
+		{"nfsvers=4.2,max_connect=1", 0},
+		{"nfsvers=4,max_connect=4", 0},
+		{"nfsvers=3,max_connect=4", 0},
+		{"max_connect=4", 0},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.limit, GetNFSSessionTrunkingLimit(test.mountOptions), test.mountOptions)
+	}
+}